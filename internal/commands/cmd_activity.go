@@ -0,0 +1,243 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/activity"
+	"github.com/colonyops/hive/internal/core/styles"
+	"github.com/colonyops/hive/internal/data/stores"
+	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/pkg/iojson"
+	"github.com/urfave/cli/v3"
+)
+
+// activityLevels are the cell glyphs from least to most active. Index 0 is used
+// for days without activity.
+var activityLevels = []string{"·", "░", "▒", "▓", "█"}
+
+type ActivityCmd struct {
+	flags *Flags
+	app   *hive.App
+
+	weeks int
+	month bool
+	json  bool
+
+	now func() time.Time // overridden in tests
+}
+
+// NewActivityCmd creates a new activity command
+func NewActivityCmd(flags *Flags, app *hive.App) *ActivityCmd {
+	return &ActivityCmd{flags: flags, app: app, now: time.Now}
+}
+
+// Register adds the activity command to the application
+func (cmd *ActivityCmd) Register(app *cli.Command) *cli.Command {
+	app.Commands = append(app.Commands, &cli.Command{
+		Name:      "activity",
+		Usage:     "Show a calendar of sessions, reviews and messages per day",
+		UsageText: "hive activity [--weeks N | --month] [--json]",
+		Description: `Renders a contribution-graph style calendar of daily activity: sessions
+created, reviews finalized and messages published. Darker cells mean more
+activity relative to the busiest day in the range.
+
+By default the last 26 weeks are shown. Use --month for the current
+calendar month.
+
+Sessions that have been deleted are no longer counted. The totals include
+the focused time spent on the reviews finalized.
+
+Examples:
+  hive activity
+  hive activity --weeks 8
+  hive activity --month --json`,
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:        "weeks",
+				Aliases:     []string{"w"},
+				Usage:       "number of weeks to show, ending today",
+				Value:       26,
+				Destination: &cmd.weeks,
+			},
+			&cli.BoolFlag{
+				Name:        "month",
+				Aliases:     []string{"m"},
+				Usage:       "show the current calendar month",
+				Destination: &cmd.month,
+			},
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "output one JSON line per day",
+				Destination: &cmd.json,
+			},
+		},
+		Action: cmd.run,
+	})
+
+	return app
+}
+
+// activityDayJSON is the JSON shape of a single day of activity.
+type activityDayJSON struct {
+	Date       string `json:"date"`
+	Sessions   int    `json:"sessions"`
+	Reviews    int    `json:"reviews"`
+	ReviewTime int64  `json:"review_seconds"` // focused time spent on the reviews finalized
+	Messages   int    `json:"messages"`
+}
+
+func (cmd *ActivityCmd) run(ctx context.Context, c *cli.Command) error {
+	since, until, err := cmd.dateRange()
+	if err != nil {
+		return err
+	}
+
+	days, err := stores.NewActivityStore(cmd.app.DB).Daily(ctx, since, until)
+	if err != nil {
+		return fmt.Errorf("load activity: %w", err)
+	}
+
+	w := c.Root().Writer
+	if cmd.json {
+		for _, d := range days {
+			if err := iojson.WriteLine(w, activityDayJSON{
+				Date:       d.Date.Format(time.DateOnly),
+				Sessions:   d.Sessions,
+				Reviews:    d.Reviews,
+				ReviewTime: int64(d.ReviewTime.Seconds()),
+				Messages:   d.Messages,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	renderActivityCalendar(w, days)
+	return nil
+}
+
+// dateRange returns the [since, until) range to show. until is the start of
+// tomorrow so today's activity is included.
+func (cmd *ActivityCmd) dateRange() (time.Time, time.Time, error) {
+	now := cmd.now()
+	y, m, d := now.Date()
+	until := time.Date(y, m, d+1, 0, 0, 0, 0, now.Location())
+
+	if cmd.month {
+		return time.Date(y, m, 1, 0, 0, 0, 0, now.Location()), until, nil
+	}
+
+	if cmd.weeks < 1 {
+		return time.Time{}, time.Time{}, fmt.Errorf("--weeks must be at least 1, got %d", cmd.weeks)
+	}
+	return until.AddDate(0, 0, -7*cmd.weeks), until, nil
+}
+
+// renderActivityCalendar writes days as a grid with one column per week
+// (Monday first) and one row per weekday, followed by a legend and totals.
+func renderActivityCalendar(w io.Writer, days []activity.Day) {
+	if len(days) == 0 {
+		_, _ = fmt.Fprintln(w, "No activity")
+		return
+	}
+
+	// Pad the first column so rows line up with weekdays.
+	lead := weekdayIndex(days[0].Date)
+	weeks := (lead + len(days) + 6) / 7
+
+	maxTotal := 0
+	var sessions, reviews, messages, active int
+	var reviewTime time.Duration
+	for _, d := range days {
+		maxTotal = max(maxTotal, d.Total())
+		sessions += d.Sessions
+		reviews += d.Reviews
+		reviewTime += d.ReviewTime
+		messages += d.Messages
+		if d.Total() > 0 {
+			active++
+		}
+	}
+
+	const labelWidth = 4
+
+	// Label each column whose first day starts a new month. The last label may
+	// extend past the final column.
+	header := []rune(strings.Repeat(" ", labelWidth+weeks*2+len("Jan")))
+	lastLabelEnd := 0
+	var lastMonth time.Month
+	for col := range weeks {
+		first := days[max(col*7-lead, 0)].Date
+		if first.Month() == lastMonth {
+			continue
+		}
+		lastMonth = first.Month()
+		pos := labelWidth + col*2
+		label := first.Format("Jan")
+		if pos >= lastLabelEnd && pos+len(label) <= len(header) {
+			copy(header[pos:], []rune(label))
+			lastLabelEnd = pos + len(label) + 1
+		}
+	}
+	_, _ = fmt.Fprintln(w, styles.TextMutedStyle.Render(strings.TrimRight(string(header), " ")))
+
+	rowLabels := []string{"Mon", "", "Wed", "", "Fri", "", ""}
+	for row := range 7 {
+		cells := make([]string, 0, weeks)
+		for col := range weeks {
+			i := col*7 + row - lead
+			if i < 0 || i >= len(days) {
+				cells = append(cells, " ")
+				continue
+			}
+			cells = append(cells, activityCell(days[i].Total(), maxTotal))
+		}
+		for len(cells) > 0 && cells[len(cells)-1] == " " {
+			cells = cells[:len(cells)-1]
+		}
+
+		var line string
+		if rowLabels[row] != "" {
+			line = styles.TextMutedStyle.Render(rowLabels[row])
+		}
+		if len(cells) > 0 {
+			line += strings.Repeat(" ", labelWidth-len(rowLabels[row])) + strings.Join(cells, " ")
+		}
+		_, _ = fmt.Fprintln(w, line)
+	}
+
+	_, _ = fmt.Fprintln(w)
+	legend := make([]string, len(activityLevels))
+	for i := range activityLevels {
+		legend[i] = activityCell(i, len(activityLevels)-1)
+	}
+	_, _ = fmt.Fprintf(w, "%s %s %s\n",
+		styles.TextMutedStyle.Render("Less"), strings.Join(legend, " "), styles.TextMutedStyle.Render("More"))
+	reviewed := fmt.Sprintf("%d reviews finalized", reviews)
+	if reviewTime > 0 {
+		reviewed += fmt.Sprintf(" (%s reviewing)", reviewTime.Round(time.Second))
+	}
+	_, _ = fmt.Fprintf(w, "%d sessions • %s • %d messages • %d/%d active days\n",
+		sessions, reviewed, messages, active, len(days))
+}
+
+// activityCell returns the styled glyph for a day's total relative to the
+// busiest day in the range.
+func activityCell(total, maxTotal int) string {
+	if total <= 0 || maxTotal <= 0 {
+		return styles.TextMutedStyle.Render(activityLevels[0])
+	}
+	steps := len(activityLevels) - 1
+	level := min((total*steps+maxTotal-1)/maxTotal, steps)
+	return styles.TextSuccessStyle.Render(activityLevels[level])
+}
+
+// weekdayIndex returns 0 for Monday through 6 for Sunday.
+func weekdayIndex(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/colonyops/hive/internal/core/activity"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/internal/data/stores"
+	"github.com/colonyops/hive/internal/hive"
+)
+
+func runActivity(t *testing.T, database *db.DB, now time.Time, args ...string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	app := &cli.Command{Name: "hive", Writer: &buf}
+	cmd := NewActivityCmd(&Flags{}, &hive.App{DB: database})
+	cmd.now = func() time.Time { return now }
+	cmd.Register(app)
+
+	require.NoError(t, app.Run(context.Background(), append([]string{"hive", "activity"}, args...)))
+	return buf.String()
+}
+
+func TestActivity_MonthJSON(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+
+	ctx := context.Background()
+	now := time.Date(2026, 3, 4, 15, 0, 0, 0, time.Local)
+
+	reviews := stores.NewReviewStore(database)
+	sess, err := reviews.CreateSession(ctx, "/ctx/plans/plan.md", "hash")
+	require.NoError(t, err)
+	_, err = database.Conn().ExecContext(ctx, `UPDATE review_sessions SET finalized_at = ? WHERE id = ?`,
+		time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local).UnixNano(), sess.ID)
+	require.NoError(t, err)
+	require.NoError(t, reviews.AddReviewTime(ctx, sess.ID, 90*time.Second))
+
+	out := runActivity(t, database, now, "--month", "--json")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 4, "March 1 through today")
+
+	var day activityDayJSON
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &day))
+	assert.Equal(t, "2026-03-02", day.Date)
+	assert.Equal(t, 1, day.Reviews)
+	assert.Equal(t, int64(90), day.ReviewTime)
+
+	out = runActivity(t, database, now, "--month")
+	assert.Contains(t, out, "1 reviews finalized (1m30s reviewing)")
+}
+
+func TestActivity_InvalidWeeks(t *testing.T) {
+	var buf bytes.Buffer
+	app := &cli.Command{Name: "hive", Writer: &buf}
+	NewActivityCmd(&Flags{}, &hive.App{}).Register(app)
+
+	err := app.Run(context.Background(), []string{"hive", "activity", "--weeks", "0"})
+	require.ErrorContains(t, err, "--weeks must be at least 1")
+}
+
+func TestRenderActivityCalendar(t *testing.T) {
+	// Wednesday 2026-04-22 through Friday 2026-05-08: three week columns.
+	start := time.Date(2026, 4, 22, 0, 0, 0, 0, time.UTC)
+	days := make([]activity.Day, 17)
+	for i := range days {
+		days[i].Date = start.AddDate(0, 0, i)
+	}
+	days[0].Sessions = 4  // Wed Apr 22
+	days[13].Messages = 1 // Tue May 5
+
+	var buf bytes.Buffer
+	renderActivityCalendar(&buf, days)
+	lines := strings.Split(terminal.StripANSI(buf.String()), "\n")
+
+	assert.Equal(t, "    Apr May", lines[0], "months are labelled where a column starts in a new month")
+	assert.Equal(t, "Mon   · ·", lines[1], "days before the range are blank")
+	assert.Equal(t, "      · ░", lines[2])
+	assert.Equal(t, "Wed █ · ·", lines[3])
+	assert.Equal(t, "    · ·", lines[6], "days after the range are trimmed")
+	assert.Contains(t, buf.String(), "4 sessions • 0 reviews finalized • 1 messages • 2/17 active days")
+}
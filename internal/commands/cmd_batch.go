@@ -3,13 +3,18 @@ package commands
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"time"
 
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
 	"github.com/colonyops/hive/internal/core/validate"
 	"github.com/colonyops/hive/internal/hive"
 	"github.com/colonyops/hive/pkg/iojson"
 	"github.com/colonyops/hive/pkg/randid"
 	"github.com/hay-kot/criterio"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v3"
 )
@@ -19,14 +24,31 @@ type BatchCmd struct {
 	app   *hive.App
 	fr    *iojson.FileReader[BatchInput]
 	agent string
+
+	watch       bool
+	json        bool
+	concurrency int
+	interval    time.Duration
+	timeout     time.Duration
+
+	// output receives each session's creation output once it is created;
+	// nil discards it.
+	output io.Writer
+
+	// create and statusOf create a session and report its terminal status;
+	// overridden in tests. create writes the session's output to progress.
+	create   func(ctx context.Context, sess BatchSession, progress io.Writer) BatchResult
+	statusOf func(ctx context.Context, sess *session.Session) terminal.Status
 }
 
 func NewBatchCmd(flags *Flags, app *hive.App) *BatchCmd {
-	return &BatchCmd{
+	cmd := &BatchCmd{
 		flags: flags,
 		app:   app,
 		fr:    &iojson.FileReader[BatchInput]{},
 	}
+	cmd.create = cmd.createSession
+	return cmd
 }
 
 func (cmd *BatchCmd) Register(app *cli.Command) *cli.Command {
@@ -42,15 +64,32 @@ Read from file:
   hive batch -f sessions.json
 
 Use an agent profile for sessions without a per-session agent:
-  hive batch --agent claude -f sessions.json`,
+  hive batch --agent claude -f sessions.json
+
+Run a pipeline, watching its progress:
+  hive batch --watch -f pipeline.json
+
+Run a batch defined under batches: in config:
+  hive batch run review --var pr=123`,
 		Description: `Creates multiple agent sessions from a JSON specification.
 
-Each session in the input array is created sequentially. A terminal is
-spawned for each session using the batch_spawn commands if configured,
-otherwise falls back to spawn commands.
+Up to --concurrency sessions (default 4) are created at a time. Sessions for
+the same repository are created one after another, in input order, so they do
+not contend for its clone. A terminal is spawned for each session using the
+batch_spawn commands if configured, otherwise falls back to spawn commands.
+
+A progress line is written to stderr as each session is created, fails, or is
+skipped; with --json each line is the session's result as JSON. --watch draws
+the whole batch instead.
 
 Processing stops after 3 failures. Sessions not attempted are marked as skipped.
 
+A session with depends_on is created only once every session it depends on
+has completed: it published a message to agent.<session-id>.done, or its
+agent finished working and went back to the ready prompt. hive batch keeps
+running until every session is created or skipped; a session whose
+dependency failed, was skipped, or was deleted first is skipped too.
+
 Input JSON schema:
   {
     "sessions": [
@@ -61,7 +100,8 @@ Input JSON schema:
         "remote": "optional-url",
         "source": "optional-path",
         "agent": "optional-agent-key",
-        "tags": ["optional", "labels"]
+        "tags": ["optional", "labels"],
+        "depends_on": ["optional", "session-names"]
       }
     ]
   }
@@ -74,6 +114,7 @@ Fields:
   source     - Optional. Directory to copy files from (per copy rules in config).
   agent      - Optional. Agent profile key from agents config.
   tags       - Optional. Labels for external provider tracking (filterable via hive ls --tags).
+  depends_on - Optional. Names of sessions in the batch to wait for before creating this one.
 
 Config example (in ~/.config/hive/config.yaml):
   rules:
@@ -94,8 +135,39 @@ Log entries are written to the shared hive log file, tagged with a
 				Usage:       "default agent profile key for sessions without an agent field",
 				Destination: &cmd.agent,
 			},
+			&cli.BoolFlag{
+				Name:        "watch",
+				Aliases:     []string{"w"},
+				Usage:       "draw the progress of the batch on stderr until it is done",
+				Destination: &cmd.watch,
+			},
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "write progress lines to stderr as JSON",
+				Destination: &cmd.json,
+			},
+			&cli.IntFlag{
+				Name:        "concurrency",
+				Usage:       "maximum number of sessions to create at once",
+				Value:       4,
+				Destination: &cmd.concurrency,
+			},
+			&cli.DurationFlag{
+				Name:        "interval",
+				Usage:       "how often to check dependencies (default: tmux.poll_interval)",
+				Destination: &cmd.interval,
+			},
+			&cli.DurationFlag{
+				Name:        "timeout",
+				Usage:       "skip sessions still waiting on dependencies after this long (0 waits forever)",
+				Destination: &cmd.timeout,
+			},
 		},
 		Action: cmd.run,
+		Commands: []*cli.Command{
+			cmd.runPresetCmd(),
+			cmd.listPresetsCmd(),
+		},
 	})
 
 	return app
@@ -114,6 +186,11 @@ func (cmd *BatchCmd) run(ctx context.Context, c *cli.Command) error {
 		return iojson.WriteError(fmt.Sprintf("read input: %s", err), nil)
 	}
 
+	return cmd.execute(ctx, c, batchID, input, logger)
+}
+
+// execute validates input, creates its sessions, and writes the results.
+func (cmd *BatchCmd) execute(ctx context.Context, c *cli.Command, batchID string, input BatchInput, logger zerolog.Logger) error {
 	if err := input.Validate(); err != nil {
 		logger.Error().Err(err).Msg("input validation failed")
 		return iojson.WriteError(fmt.Sprintf("invalid input: %s", err), nil)
@@ -124,36 +201,24 @@ func (cmd *BatchCmd) run(ctx context.Context, c *cli.Command) error {
 		return iojson.WriteError(fmt.Sprintf("invalid input: %s", err), nil)
 	}
 
+	if cmd.statusOf == nil {
+		cmd.statusOf = tmuxStatusFunc(cmd.app)
+	}
+	if cmd.concurrency < 1 {
+		return iojson.WriteError("invalid input: --concurrency must be at least 1", nil)
+	}
+	var progress, stream io.Writer
+	if cmd.watch {
+		progress = c.Root().ErrWriter
+	} else {
+		stream = c.Root().ErrWriter
+		cmd.output = c.Root().ErrWriter
+	}
+
 	output := BatchOutput{
 		BatchID: batchID,
 		LogFile: cmd.flags.ResolvedLogFile(),
-		Results: make([]BatchResult, 0, len(input.Sessions)),
-	}
-
-	failures := 0
-	for i, sess := range input.Sessions {
-		if failures >= maxFailures {
-			logger.Warn().Str("name", sess.Name).Msg("skipping session due to failure threshold")
-			for j := i; j < len(input.Sessions); j++ {
-				output.Results = append(output.Results, BatchResult{
-					Name:   input.Sessions[j].Name,
-					Status: StatusSkipped,
-				})
-			}
-			break
-		}
-
-		logger.Info().Str("name", sess.Name).Int("index", i).Msg("creating session")
-
-		result := cmd.createSession(ctx, sess)
-		output.Results = append(output.Results, result)
-
-		if result.Status == StatusFailed {
-			failures++
-			logger.Error().Str("name", sess.Name).Str("error", result.Error).Msg("session creation failed")
-		} else {
-			logger.Info().Str("name", sess.Name).Str("session_id", result.SessionID).Msg("session created")
-		}
+		Results: cmd.process(ctx, batchID, input, logger, progress, stream),
 	}
 
 	logger.Info().
@@ -186,7 +251,7 @@ func (cmd *BatchCmd) validateAgents(input BatchInput) error {
 	return errs.ToError()
 }
 
-func (cmd *BatchCmd) createSession(ctx context.Context, sess BatchSession) BatchResult {
+func (cmd *BatchCmd) createSession(ctx context.Context, sess BatchSession, progress io.Writer) BatchResult {
 	source := sess.Source
 	if source == "" {
 		var err error
@@ -210,6 +275,7 @@ func (cmd *BatchCmd) createSession(ctx context.Context, sess BatchSession) Batch
 		CloneStrategy: sess.CloneStrategy,
 		AgentKey:      cmd.agentForSession(sess),
 		Tags:          sess.Tags,
+		Progress:      progress,
 	}
 
 	created, err := cmd.app.Sessions.CreateSession(ctx, opts)
@@ -232,7 +298,7 @@ func (cmd *BatchCmd) createSession(ctx context.Context, sess BatchSession) Batch
 const (
 	StatusCreated = "created" // StatusCreated indicates the session was created successfully.
 	StatusFailed  = "failed"  // StatusFailed indicates the session creation failed.
-	StatusSkipped = "skipped" // StatusSkipped indicates the session was not attempted due to failure threshold.
+	StatusSkipped = "skipped" // StatusSkipped indicates the session was not attempted due to failure threshold or a failed dependency.
 	maxFailures   = 3         // maxFailures is the number of failures before stopping batch processing.
 )
 
@@ -287,8 +353,11 @@ func (b BatchInput) Validate() error {
 			seenIDs[sess.SessionID] = true
 		}
 	}
+	if len(errs) > 0 {
+		return errs.ToError()
+	}
 
-	return errs.ToError()
+	return validateDependsOn(b.Sessions)
 }
 
 // BatchSession defines a single session to create.
@@ -301,6 +370,7 @@ type BatchSession struct {
 	CloneStrategy string   `json:"clone_strategy,omitempty"`
 	Agent         string   `json:"agent,omitempty"`
 	Tags          []string `json:"tags,omitempty"`
+	DependsOn     []string `json:"depends_on,omitempty"`
 }
 
 // BatchResult is the output for a single session creation attempt.
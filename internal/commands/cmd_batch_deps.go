@@ -0,0 +1,468 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hay-kot/criterio"
+	"github.com/rs/zerolog"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/styles"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/pkg/iojson"
+)
+
+// batchNode is a batch entry and the progress of its session.
+type batchNode struct {
+	BatchSession
+	result BatchResult // Status is empty until the session is created, fails or is skipped
+
+	busy  bool // the agent has been seen working
+	done  bool // the session has completed
+	ended bool // the session was deleted, recycled or archived before completing
+}
+
+// pending reports whether the session has not been created, failed or skipped.
+func (n *batchNode) pending() bool {
+	return n.result.Status == ""
+}
+
+// batchDoneTopic is the topic a batch session publishes to when it has
+// completed, releasing the sessions that depend on it. The topic is unique to
+// the session, so any message on it marks completion.
+func batchDoneTopic(sessionID string) string {
+	return "agent." + sessionID + ".done"
+}
+
+// process creates the sessions of input and returns their results in input
+// order. A session with depends_on is created once all its dependencies have
+// completed: they published to their completion topic, or their agent went
+// from working to ready. When progress is non-nil, the graph is redrawn on it
+// after every change; when stream is non-nil, a line is written to it as each
+// session is created, fails, or is skipped.
+func (cmd *BatchCmd) process(ctx context.Context, batchID string, input BatchInput, logger zerolog.Logger, progress, stream io.Writer) []BatchResult {
+	nodes := make([]*batchNode, len(input.Sessions))
+	byName := make(map[string]*batchNode, len(input.Sessions))
+	for i, sess := range input.Sessions {
+		nodes[i] = &batchNode{BatchSession: sess, result: BatchResult{Name: sess.Name}}
+		byName[sess.Name] = nodes[i]
+	}
+
+	interval := cmd.interval
+	if interval <= 0 && cmd.app.Config != nil {
+		interval = cmd.app.Config.Tmux.PollInterval
+	}
+	if interval <= 0 {
+		interval = 1500 * time.Millisecond
+	}
+	var deadline <-chan time.Time
+	if cmd.timeout > 0 {
+		timer := time.NewTimer(cmd.timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	failures := 0
+	for {
+		cmd.spawnReady(ctx, nodes, byName, &failures, logger, stream)
+		if progress != nil {
+			drawBatchProgress(progress, batchID, nodes, time.Now())
+		}
+		if !slices.ContainsFunc(nodes, (*batchNode).pending) {
+			break
+		}
+
+		stop := ""
+		select {
+		case <-ctx.Done():
+			stop = "cancelled while waiting for dependencies"
+		case <-deadline:
+			stop = "timed out waiting for dependencies"
+		case <-ticker.C:
+		}
+		if stop != "" {
+			for _, n := range nodes {
+				if n.pending() {
+					n.result.Status, n.result.Error = StatusSkipped, stop
+					logger.Warn().Str("name", n.Name).Msg(stop)
+					cmd.writeResult(stream, n.result)
+				}
+			}
+			if progress != nil {
+				drawBatchProgress(progress, batchID, nodes, time.Now())
+			}
+			break
+		}
+
+		cmd.checkDependencies(ctx, nodes, byName, logger)
+	}
+
+	results := make([]BatchResult, len(nodes))
+	for i, n := range nodes {
+		results[i] = n.result
+	}
+	return results
+}
+
+// spawnReady creates every pending session whose dependencies have all
+// completed and skips those whose dependencies failed.
+func (cmd *BatchCmd) spawnReady(ctx context.Context, nodes []*batchNode, byName map[string]*batchNode, failures *int, logger zerolog.Logger, stream io.Writer) {
+	// Skipping or creating one session can release the sessions depending on it
+	for changed := true; changed; {
+		changed = false
+		var ready []*batchNode
+		for _, n := range nodes {
+			if !n.pending() {
+				continue
+			}
+			if *failures >= maxFailures {
+				logger.Warn().Str("name", n.Name).Msg("skipping session due to failure threshold")
+				n.result.Status = StatusSkipped
+				cmd.writeResult(stream, n.result)
+				changed = true
+				continue
+			}
+
+			ok, reason := dependenciesReady(n, byName)
+			if reason != "" {
+				logger.Warn().Str("name", n.Name).Str("reason", reason).Msg("skipping session")
+				n.result.Status, n.result.Error = StatusSkipped, reason
+				cmd.writeResult(stream, n.result)
+				changed = true
+				continue
+			}
+			if ok {
+				ready = append(ready, n)
+			}
+		}
+		if len(ready) > 0 {
+			cmd.createAll(ctx, ready, failures, logger, stream)
+			changed = true
+		}
+	}
+}
+
+// createAll creates the sessions of ready, up to cmd.concurrency at a time.
+// Sessions for the same repository are created one after another in input
+// order so they do not contend for its clone.
+func (cmd *BatchCmd) createAll(ctx context.Context, ready []*batchNode, failures *int, logger zerolog.Logger, stream io.Writer) {
+	limit := max(cmd.concurrency, 1)
+
+	var repos []string
+	byRepo := make(map[string][]*batchNode)
+	for _, n := range ready {
+		repo := ""
+		if limit > 1 {
+			repo = cmd.repoKey(ctx, n.BatchSession)
+		}
+		if _, ok := byRepo[repo]; !ok {
+			repos = append(repos, repo)
+		}
+		byRepo[repo] = append(byRepo[repo], n)
+	}
+
+	var (
+		mu  sync.Mutex // guards failures, results, stream, and cmd.output
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, limit)
+	)
+	for _, repo := range repos {
+		wg.Go(func() {
+			for _, n := range byRepo[repo] {
+				mu.Lock()
+				stop := *failures >= maxFailures
+				if stop {
+					logger.Warn().Str("name", n.Name).Msg("skipping session due to failure threshold")
+					n.result.Status = StatusSkipped
+					cmd.writeResult(stream, n.result)
+				}
+				mu.Unlock()
+				if stop {
+					continue
+				}
+
+				// Each session writes to its own buffer so the output of
+				// parallel creations is not interleaved
+				var out bytes.Buffer
+				sem <- struct{}{}
+				logger.Info().Str("name", n.Name).Str("repo", repo).Msg("creating session")
+				result := cmd.create(ctx, n.BatchSession, &out)
+				<-sem
+
+				mu.Lock()
+				if cmd.output != nil && out.Len() > 0 {
+					_, _ = out.WriteTo(cmd.output)
+				}
+				n.result = result
+				if result.Status == StatusFailed {
+					*failures++
+					logger.Error().Str("name", n.Name).Str("error", result.Error).Msg("session creation failed")
+				} else {
+					logger.Info().Str("name", n.Name).Str("session_id", result.SessionID).Msg("session created")
+				}
+				cmd.writeResult(stream, result)
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+}
+
+// repoKey returns the repository a session is created from: its remote, the
+// remote detected in its source directory, or the source directory itself.
+func (cmd *BatchCmd) repoKey(ctx context.Context, sess BatchSession) string {
+	if sess.Remote != "" {
+		return sess.Remote
+	}
+	source := sess.Source
+	if source == "" {
+		source = "."
+	}
+	if remote, err := cmd.app.Sessions.DetectRemote(ctx, source); err == nil && remote != "" {
+		return remote
+	}
+	return source
+}
+
+// writeResult writes a progress line for a finished session to w, as JSON
+// with --json. It is a no-op when w is nil.
+func (cmd *BatchCmd) writeResult(w io.Writer, result BatchResult) {
+	if w == nil {
+		return
+	}
+	if cmd.json {
+		_ = iojson.WriteLine(w, result)
+		return
+	}
+
+	line := result.Name + ": " + result.Status
+	switch {
+	case result.Status == StatusCreated:
+		line += " " + result.SessionID
+		if result.Path != "" {
+			line += " (" + result.Path + ")"
+		}
+	case result.Error != "":
+		line += ": " + result.Error
+	}
+	_, _ = fmt.Fprintln(w, line)
+}
+
+// dependenciesReady reports whether all of n's dependencies have completed.
+// If one never will, it returns why n is skipped.
+func dependenciesReady(n *batchNode, byName map[string]*batchNode) (bool, string) {
+	ready := true
+	for _, name := range n.DependsOn {
+		dep := byName[name]
+		switch {
+		case dep.result.Status == StatusFailed:
+			return false, fmt.Sprintf("dependency %q failed", name)
+		case dep.result.Status == StatusSkipped:
+			return false, fmt.Sprintf("dependency %q was skipped", name)
+		case dep.ended:
+			return false, fmt.Sprintf("dependency %q ended before completing", name)
+		case !dep.done:
+			ready = false
+		}
+	}
+	return ready, ""
+}
+
+// checkDependencies updates the completion of created sessions that pending
+// sessions depend on.
+func (cmd *BatchCmd) checkDependencies(ctx context.Context, nodes []*batchNode, byName map[string]*batchNode, logger zerolog.Logger) {
+	for _, n := range nodes {
+		if !n.pending() {
+			continue
+		}
+		for _, name := range n.DependsOn {
+			dep := byName[name]
+			if dep.result.Status != StatusCreated || dep.done || dep.ended {
+				continue
+			}
+			cmd.checkDone(ctx, dep)
+			if dep.done {
+				logger.Info().Str("name", dep.Name).Str("session_id", dep.result.SessionID).Msg("dependency completed")
+			}
+		}
+	}
+}
+
+// checkDone records whether a created session has completed or ended.
+func (cmd *BatchCmd) checkDone(ctx context.Context, n *batchNode) {
+	id := n.result.SessionID
+	if cmd.app.Messages != nil {
+		messages, err := cmd.app.Messages.Subscribe(ctx, batchDoneTopic(id), time.Time{})
+		if err == nil && len(messages) > 0 {
+			n.done = true
+			return
+		}
+	}
+
+	sess, err := cmd.app.Sessions.GetSession(ctx, id)
+	if errors.Is(err, session.ErrNotFound) || (err == nil && sess.State != session.StateActive) {
+		n.ended = true
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	// A new agent is ready before it starts on its prompt, so ready only
+	// counts once it has been seen working
+	switch cmd.statusOf(ctx, &sess) {
+	case terminal.StatusActive, terminal.StatusApproval:
+		n.busy = true
+	case terminal.StatusReady:
+		n.done = n.busy
+	}
+}
+
+// validateDependsOn checks that every dependency names another session of
+// the batch and that dependencies do not form a cycle.
+func validateDependsOn(sessions []BatchSession) error {
+	var errs criterio.FieldErrorsBuilder
+	index := make(map[string]int, len(sessions))
+	for i, sess := range sessions {
+		index[sess.Name] = i
+	}
+
+	valid := true
+	for i, sess := range sessions {
+		for _, dep := range sess.DependsOn {
+			field := fmt.Sprintf("sessions[%d].depends_on", i)
+			switch _, ok := index[dep]; {
+			case dep == sess.Name:
+				errs = errs.Append(field, fmt.Errorf("session %q depends on itself", dep))
+				valid = false
+			case !ok:
+				errs = errs.Append(field, fmt.Errorf("unknown session %q", dep))
+				valid = false
+			}
+		}
+	}
+	if !valid {
+		return errs.ToError()
+	}
+
+	// Depth-first search; a dependency on a session still being visited is a cycle
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(sessions))
+	var path []string
+	var visit func(i int) []string
+	visit = func(i int) []string {
+		state[i] = visiting
+		path = append(path, sessions[i].Name)
+		for _, dep := range sessions[i].DependsOn {
+			j := index[dep]
+			switch state[j] {
+			case visiting:
+				start := slices.Index(path, dep)
+				return append(slices.Clone(path[start:]), dep)
+			case unvisited:
+				if cycle := visit(j); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		return nil
+	}
+	for i := range sessions {
+		if state[i] != unvisited {
+			continue
+		}
+		if cycle := visit(i); cycle != nil {
+			return criterio.NewFieldErrors(fmt.Sprintf("sessions[%d].depends_on", index[cycle[0]]),
+				fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " → ")))
+		}
+	}
+	return nil
+}
+
+// drawBatchProgress redraws the progress of the batch on w.
+func drawBatchProgress(w io.Writer, batchID string, nodes []*batchNode, now time.Time) {
+	_, _ = io.WriteString(w, clearScreen)
+	_, _ = io.WriteString(w, renderBatchProgress(batchID, nodes, now))
+}
+
+// renderBatchProgress renders one line per session with its state and, for
+// sessions not yet created, the dependencies they wait on.
+func renderBatchProgress(batchID string, nodes []*batchNode, now time.Time) string {
+	var b strings.Builder
+
+	nameWidth := 0
+	for _, n := range nodes {
+		nameWidth = max(nameWidth, len(n.Name))
+	}
+
+	b.WriteString(styles.TextPrimaryBoldStyle.Render("Hive Batch"))
+	b.WriteString(styles.TextMutedStyle.Render(fmt.Sprintf("  %s · %s", batchID, now.Format("15:04:05"))))
+	b.WriteString("\n")
+	b.WriteString(styles.TextMutedStyle.Render(strings.Repeat("─", 40)))
+	b.WriteString("\n")
+
+	byName := make(map[string]*batchNode, len(nodes))
+	for _, n := range nodes {
+		byName[n.Name] = n
+	}
+
+	var created, done int
+	for _, n := range nodes {
+		var icon, detail string
+		switch {
+		case n.result.Status == StatusFailed:
+			icon = styles.TextErrorStyle.Render("✘")
+			detail = styles.TextErrorStyle.Render("failed: " + n.result.Error)
+		case n.result.Status == StatusSkipped:
+			icon = styles.TextMutedStyle.Render("-")
+			detail = "skipped"
+			if n.result.Error != "" {
+				detail += ": " + n.result.Error
+			}
+			detail = styles.TextMutedStyle.Render(detail)
+		case n.pending():
+			var waiting []string
+			for _, dep := range n.DependsOn {
+				if !byName[dep].done {
+					waiting = append(waiting, dep)
+				}
+			}
+			icon = styles.TextMutedStyle.Render("○")
+			detail = styles.TextMutedStyle.Render("waiting on " + strings.Join(waiting, ", "))
+		case n.done:
+			created++
+			done++
+			icon = styles.TextSuccessStyle.Render("✔")
+			detail = styles.TextSuccessStyle.Render("done") + styles.TextMutedStyle.Render(" "+n.result.SessionID)
+		case n.ended:
+			created++
+			icon = styles.TextWarningStyle.Render("●")
+			detail = styles.TextWarningStyle.Render("ended") + styles.TextMutedStyle.Render(" "+n.result.SessionID)
+		default:
+			created++
+			icon = styles.TextPrimaryStyle.Render("●")
+			detail = "running" + styles.TextMutedStyle.Render(" "+n.result.SessionID)
+		}
+		fmt.Fprintf(&b, "%s %-*s  %s\n", icon, nameWidth, n.Name, detail)
+	}
+
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "%d/%d created  %d done\n", created, len(nodes), done)
+	return b.String()
+}
@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/pkg/iojson"
+	"github.com/colonyops/hive/pkg/randid"
+	"github.com/colonyops/hive/pkg/tmpl"
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v3"
+)
+
+func (cmd *BatchCmd) runPresetCmd() *cli.Command {
+	var vars []string
+
+	return &cli.Command{
+		Name:      "run",
+		Usage:     "Create the sessions of a batch defined in config",
+		UsageText: "hive batch run <name> [--var key=value]...",
+		Description: `Renders the named batch from the batches: section of config and creates
+its sessions as hive batch does with JSON input.
+
+Session names, prompts, remotes, sources, tags, and depends_on entries are
+templates. Variables are available under .Vars: the batch's vars provide
+defaults and --var sets or overrides them.
+
+Config example:
+  batches:
+    review:
+      description: Review a pull request
+      agent: claude
+      vars:
+        repo: https://github.com/org/app
+      sessions:
+        - name: "review-{{ .Vars.pr }}"
+          remote: "{{ .Vars.repo }}"
+          prompt: "Review pull request #{{ .Vars.pr }}"
+        - name: "fix-{{ .Vars.pr }}"
+          remote: "{{ .Vars.repo }}"
+          prompt: "Address the review of #{{ .Vars.pr }}"
+          depends_on: ["review-{{ .Vars.pr }}"]
+
+Usage:
+  hive batch run review --var pr=123`,
+		DisableSliceFlagSeparator: true,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "var",
+				Usage:       "template variable as key=value (repeatable)",
+				Destination: &vars,
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			name := c.Args().First()
+			if name == "" {
+				return fmt.Errorf("batch name required")
+			}
+
+			batchID := randid.Generate(6)
+			logger := log.With().Str("batch", batchID).Str("preset", name).Logger()
+			logger.Info().Msg("starting batch processing")
+
+			batch, err := cmd.app.Config.Batch(name)
+			if err != nil {
+				return iojson.WriteError(err.Error(), nil)
+			}
+			overrides, err := parseBatchVars(vars)
+			if err != nil {
+				return iojson.WriteError(fmt.Sprintf("invalid input: %s", err), nil)
+			}
+			input, err := renderBatch(cmd.app.Renderer, batch, overrides)
+			if err != nil {
+				logger.Error().Err(err).Msg("failed to render batch")
+				return iojson.WriteError(fmt.Sprintf("render batch %q: %s", name, err), nil)
+			}
+			if cmd.agent == "" {
+				cmd.agent = batch.Agent
+			}
+
+			return cmd.execute(ctx, c, batchID, input, logger)
+		},
+	}
+}
+
+func (cmd *BatchCmd) listPresetsCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "list",
+		Aliases:   []string{"ls"},
+		Usage:     "List the batches defined in config",
+		UsageText: "hive batch list",
+		Action: func(_ context.Context, c *cli.Command) error {
+			out := c.Root().Writer
+			names := cmd.app.Config.BatchNames()
+			if len(names) == 0 {
+				_, _ = fmt.Fprintln(out, "No batches configured. Define them under batches: in your config.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "NAME\tSESSIONS\tVARS\tDESCRIPTION")
+			for _, name := range names {
+				batch := cmd.app.Config.Batches[name]
+				vars := make([]string, 0, len(batch.Vars))
+				for _, k := range slices.Sorted(maps.Keys(batch.Vars)) {
+					vars = append(vars, k+"="+batch.Vars[k])
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", name, len(batch.Sessions), strings.Join(vars, " "), batch.Description)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+// parseBatchVars parses key=value pairs given with --var.
+func parseBatchVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("--var %q: expected key=value", pair)
+		}
+		vars[strings.TrimSpace(key)] = value
+	}
+	return vars, nil
+}
+
+// renderBatch renders the templates of a configured batch into batch input.
+// overrides take precedence over the batch's default vars.
+func renderBatch(r *tmpl.Renderer, batch config.BatchConfig, overrides map[string]string) (BatchInput, error) {
+	vars := make(map[string]string, len(batch.Vars)+len(overrides))
+	maps.Copy(vars, batch.Vars)
+	maps.Copy(vars, overrides)
+	data := map[string]any{"Vars": vars}
+
+	var renderErr error
+	render := func(field, s string) string {
+		if renderErr != nil || s == "" {
+			return s
+		}
+		out, err := r.Render(s, data)
+		if err != nil {
+			renderErr = fmt.Errorf("%s: %w", field, err)
+		}
+		return strings.TrimSpace(out)
+	}
+	renderAll := func(field string, ss []string) []string {
+		if len(ss) == 0 {
+			return nil
+		}
+		out := make([]string, len(ss))
+		for i, s := range ss {
+			out[i] = render(fmt.Sprintf("%s[%d]", field, i), s)
+		}
+		return out
+	}
+
+	input := BatchInput{Sessions: make([]BatchSession, len(batch.Sessions))}
+	for i, sess := range batch.Sessions {
+		field := fmt.Sprintf("sessions[%d]", i)
+		input.Sessions[i] = BatchSession{
+			Name:          render(field+".name", sess.Name),
+			Prompt:        render(field+".prompt", sess.Prompt),
+			Remote:        render(field+".remote", sess.Remote),
+			Source:        render(field+".source", sess.Source),
+			CloneStrategy: sess.CloneStrategy,
+			Agent:         sess.Agent,
+			Tags:          renderAll(field+".tags", sess.Tags),
+			DependsOn:     renderAll(field+".depends_on", sess.DependsOn),
+		}
+		if renderErr != nil {
+			return BatchInput{}, renderErr
+		}
+	}
+	return input, nil
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/pkg/tmpl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBatchVars(t *testing.T) {
+	vars, err := parseBatchVars([]string{"pr=123", "msg=a=b,c"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"pr": "123", "msg": "a=b,c"}, vars)
+
+	_, err = parseBatchVars([]string{"pr"})
+	require.Error(t, err)
+	_, err = parseBatchVars([]string{"=123"})
+	require.Error(t, err)
+}
+
+func TestRenderBatch(t *testing.T) {
+	batch := config.BatchConfig{
+		Vars: map[string]string{"pr": "1", "repo": "https://github.com/org/app"},
+		Sessions: []config.BatchSessionConfig{
+			{Name: "review-{{ .Vars.pr }}", Remote: "{{ .Vars.repo }}", Prompt: "Review #{{ .Vars.pr }}", Tags: []string{"pr-{{ .Vars.pr }}"}},
+			{Name: "fix-{{ .Vars.pr }}", Agent: "codex", CloneStrategy: "copy", DependsOn: []string{"review-{{ .Vars.pr }}"}},
+		},
+	}
+
+	input, err := renderBatch(tmpl.NewValidation(), batch, map[string]string{"pr": "123"})
+	require.NoError(t, err)
+	assert.Equal(t, BatchInput{Sessions: []BatchSession{
+		{Name: "review-123", Remote: "https://github.com/org/app", Prompt: "Review #123", Tags: []string{"pr-123"}},
+		{Name: "fix-123", Agent: "codex", CloneStrategy: "copy", DependsOn: []string{"review-123"}},
+	}}, input)
+	require.NoError(t, input.Validate())
+	assert.Equal(t, "1", batch.Vars["pr"], "overrides do not change the config")
+}
+
+func TestRenderBatch_MissingVar(t *testing.T) {
+	batch := config.BatchConfig{
+		Sessions: []config.BatchSessionConfig{{Name: "s", Prompt: "Review #{{ .Vars.pr }}"}},
+	}
+
+	_, err := renderBatch(tmpl.NewValidation(), batch, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sessions[0].prompt")
+}
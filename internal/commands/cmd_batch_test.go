@@ -1,11 +1,23 @@
 package commands
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/messaging"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/data/stores"
 	"github.com/colonyops/hive/internal/hive"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -72,6 +84,38 @@ func TestBatchInput_Validate(t *testing.T) {
 			}},
 			wantErr: "duplicate session_id",
 		},
+		{
+			name: "unknown dependency",
+			input: BatchInput{Sessions: []BatchSession{
+				{Name: "plan", DependsOn: []string{"research"}},
+			}},
+			wantErr: `sessions[0].depends_on: unknown session "research"`,
+		},
+		{
+			name: "self dependency",
+			input: BatchInput{Sessions: []BatchSession{
+				{Name: "plan", DependsOn: []string{"plan"}},
+			}},
+			wantErr: "depends on itself",
+		},
+		{
+			name: "dependency cycle",
+			input: BatchInput{Sessions: []BatchSession{
+				{Name: "research"},
+				{Name: "plan", DependsOn: []string{"research", "implement"}},
+				{Name: "implement", DependsOn: []string{"plan"}},
+			}},
+			wantErr: "sessions[1].depends_on: dependency cycle: plan → implement → plan",
+		},
+		{
+			name: "valid dependencies",
+			input: BatchInput{Sessions: []BatchSession{
+				{Name: "implement", DependsOn: []string{"plan"}},
+				{Name: "plan", DependsOn: []string{"research"}},
+				{Name: "research"},
+			}},
+			wantErr: "",
+		},
 		{
 			name: "valid input",
 			input: BatchInput{Sessions: []BatchSession{
@@ -180,3 +224,209 @@ func TestCountByStatus(t *testing.T) {
 	assert.Equal(t, 1, countByStatus(results, StatusFailed), "countByStatus(failed) = %d, want 1", countByStatus(results, StatusFailed))
 	assert.Equal(t, 3, countByStatus(results, StatusSkipped), "countByStatus(skipped) = %d, want 3", countByStatus(results, StatusSkipped))
 }
+
+// newBatchTestCmd returns a batch command whose sessions are only saved to
+// the store, with each agent's terminal status scripted by statuses (the last
+// status repeats). It returns the names of the sessions in creation order.
+func newBatchTestCmd(t *testing.T, statuses map[string][]terminal.Status) (*BatchCmd, *[]string) {
+	t.Helper()
+	app := newWaitApp(t)
+	store := stores.NewSessionStore(app.DB)
+
+	cmd := NewBatchCmd(&Flags{}, app)
+	cmd.interval = 5 * time.Millisecond
+
+	var created []string
+	cmd.create = func(ctx context.Context, sess BatchSession, _ io.Writer) BatchResult {
+		if sess.Name == "broken" {
+			return BatchResult{Name: sess.Name, Status: StatusFailed, Error: "clone failed"}
+		}
+		id := sess.Name + "1"
+		require.NoError(t, store.Save(ctx, session.Session{ID: id, Name: sess.Name, Slug: sess.Name, State: session.StateActive}))
+		created = append(created, sess.Name)
+		return BatchResult{Name: sess.Name, SessionID: id, Status: StatusCreated}
+	}
+
+	var mu sync.Mutex
+	calls := map[string]int{}
+	cmd.statusOf = func(_ context.Context, sess *session.Session) terminal.Status {
+		mu.Lock()
+		defer mu.Unlock()
+		script := statuses[sess.Name]
+		if len(script) == 0 {
+			return terminal.StatusActive
+		}
+		status := script[min(calls[sess.Name], len(script)-1)]
+		calls[sess.Name]++
+		return status
+	}
+	return cmd, &created
+}
+
+func TestBatchCmd_DependsOn(t *testing.T) {
+	cmd, created := newBatchTestCmd(t, map[string][]terminal.Status{
+		// research is ready before and after working on its prompt
+		"research": {terminal.StatusReady, terminal.StatusActive, terminal.StatusReady},
+		// plan never looks busy; it completes by publishing instead
+		"plan": {terminal.StatusReady},
+	})
+
+	published := false
+	input := BatchInput{Sessions: []BatchSession{
+		{Name: "implement", DependsOn: []string{"plan"}},
+		{Name: "plan", DependsOn: []string{"research"}},
+		{Name: "research"},
+		{Name: "docs"},
+	}}
+	inner := cmd.create
+	cmd.create = func(ctx context.Context, sess BatchSession, progress io.Writer) BatchResult {
+		result := inner(ctx, sess, progress)
+		if sess.Name == "plan" {
+			_, err := cmd.app.Messages.Publish(ctx, messaging.Message{Payload: "plan ready"}, []string{batchDoneTopic(result.SessionID)})
+			require.NoError(t, err)
+			published = true
+		}
+		return result
+	}
+
+	var progress bytes.Buffer
+	results := cmd.process(context.Background(), "b1", input, zerolog.Nop(), &progress, nil)
+
+	assert.True(t, published)
+	assert.Equal(t, []string{"research", "docs", "plan", "implement"}, *created)
+	for _, r := range results {
+		assert.Equal(t, StatusCreated, r.Status, r.Name)
+	}
+	assert.Equal(t, "implement", results[0].Name, "results keep input order")
+
+	out := terminal.StripANSI(progress.String())
+	assert.Contains(t, out, "waiting on plan")
+	assert.Contains(t, out, "4/4 created")
+}
+
+func TestBatchCmd_DependsOnFailure(t *testing.T) {
+	cmd, created := newBatchTestCmd(t, nil)
+
+	results := cmd.process(context.Background(), "b1", BatchInput{Sessions: []BatchSession{
+		{Name: "broken"},
+		{Name: "plan", DependsOn: []string{"broken"}},
+		{Name: "implement", DependsOn: []string{"plan"}},
+		{Name: "docs"},
+	}}, zerolog.Nop(), nil, nil)
+
+	assert.Equal(t, []string{"docs"}, *created)
+	assert.Equal(t, StatusFailed, results[0].Status)
+	assert.Equal(t, BatchResult{Name: "plan", Status: StatusSkipped, Error: `dependency "broken" failed`}, results[1])
+	assert.Equal(t, BatchResult{Name: "implement", Status: StatusSkipped, Error: `dependency "plan" was skipped`}, results[2])
+	assert.Equal(t, StatusCreated, results[3].Status)
+}
+
+func TestBatchCmd_DependsOnEnded(t *testing.T) {
+	cmd, _ := newBatchTestCmd(t, nil)
+	inner := cmd.create
+	cmd.create = func(ctx context.Context, sess BatchSession, progress io.Writer) BatchResult {
+		result := inner(ctx, sess, progress)
+		if sess.Name == "research" {
+			require.NoError(t, stores.NewSessionStore(cmd.app.DB).Delete(ctx, result.SessionID))
+		}
+		return result
+	}
+
+	results := cmd.process(context.Background(), "b1", BatchInput{Sessions: []BatchSession{
+		{Name: "research"},
+		{Name: "plan", DependsOn: []string{"research"}},
+	}}, zerolog.Nop(), nil, nil)
+
+	assert.Equal(t, `dependency "research" ended before completing`, results[1].Error)
+}
+
+func TestBatchCmd_DependsOnTimeout(t *testing.T) {
+	cmd, created := newBatchTestCmd(t, nil) // agents stay busy
+	cmd.timeout = 30 * time.Millisecond
+
+	results := cmd.process(context.Background(), "b1", BatchInput{Sessions: []BatchSession{
+		{Name: "research"},
+		{Name: "plan", DependsOn: []string{"research"}},
+	}}, zerolog.Nop(), nil, nil)
+
+	assert.Equal(t, []string{"research"}, *created)
+	assert.Equal(t, BatchResult{Name: "plan", Status: StatusSkipped, Error: "timed out waiting for dependencies"}, results[1])
+}
+
+func TestBatchCmd_Concurrency(t *testing.T) {
+	cmd, _ := newBatchTestCmd(t, nil)
+	cmd.concurrency = 2
+
+	var (
+		mu       sync.Mutex
+		order    []string
+		inFlight = map[string]int{}
+		maxTotal int
+		maxRepo  int
+	)
+	var output bytes.Buffer
+	cmd.output = &output
+	cmd.create = func(_ context.Context, sess BatchSession, progress io.Writer) BatchResult {
+		_, _ = io.WriteString(progress, sess.Name+": cloning\n")
+		mu.Lock()
+		order = append(order, sess.Name)
+		inFlight[sess.Remote]++
+		inFlight[""]++
+		maxRepo = max(maxRepo, inFlight[sess.Remote])
+		maxTotal = max(maxTotal, inFlight[""])
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+		_, _ = io.WriteString(progress, sess.Name+": spawned\n")
+
+		mu.Lock()
+		inFlight[sess.Remote]--
+		inFlight[""]--
+		mu.Unlock()
+		return BatchResult{Name: sess.Name, SessionID: sess.Name + "1", Status: StatusCreated}
+	}
+
+	var stream bytes.Buffer
+	results := cmd.process(context.Background(), "b1", BatchInput{Sessions: []BatchSession{
+		{Name: "api-1", Remote: "https://github.com/org/api"},
+		{Name: "api-2", Remote: "https://github.com/org/api"},
+		{Name: "web-1", Remote: "https://github.com/org/web"},
+	}}, zerolog.Nop(), nil, &stream)
+
+	for _, r := range results {
+		assert.Equal(t, StatusCreated, r.Status, r.Name)
+	}
+	assert.Equal(t, 2, maxTotal, "sessions for different repositories are created at once")
+	assert.Equal(t, 1, maxRepo, "sessions for one repository are created one at a time")
+	assert.Less(t, slices.Index(order, "api-1"), slices.Index(order, "api-2"))
+
+	lines := strings.Split(strings.TrimSpace(stream.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Contains(t, lines, "web-1: created web-11")
+
+	for _, name := range []string{"api-1", "api-2", "web-1"} {
+		assert.Contains(t, output.String(), name+": cloning\n"+name+": spawned\n", "output of parallel creations is not interleaved")
+	}
+}
+
+func TestBatchCmd_StreamJSON(t *testing.T) {
+	cmd, _ := newBatchTestCmd(t, nil)
+	cmd.json = true
+
+	var stream bytes.Buffer
+	cmd.process(context.Background(), "b1", BatchInput{Sessions: []BatchSession{
+		{Name: "broken"},
+		{Name: "plan", DependsOn: []string{"broken"}},
+	}}, zerolog.Nop(), nil, &stream)
+
+	var got []BatchResult
+	for line := range strings.Lines(stream.String()) {
+		var r BatchResult
+		require.NoError(t, json.Unmarshal([]byte(line), &r))
+		got = append(got, r)
+	}
+	assert.Equal(t, []BatchResult{
+		{Name: "broken", Status: StatusFailed, Error: "clone failed"},
+		{Name: "plan", Status: StatusSkipped, Error: `dependency "broken" failed`},
+	}, got)
+}
@@ -1,21 +1,55 @@
 package commands
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 
+	"charm.land/huh/v2"
+	"github.com/hay-kot/criterio"
+	"github.com/urfave/cli/v3"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/doctor"
+	"github.com/colonyops/hive/internal/core/styles"
 	"github.com/colonyops/hive/internal/hive"
 	"github.com/colonyops/hive/pkg/iojson"
-	"github.com/urfave/cli/v3"
 )
 
 type ConfigCmd struct {
 	flags *Flags
 	app   *hive.App
+
+	// init flags
+	initAgent     string
+	initWorkspace string
+	initForce     bool
+
+	// validate flags
+	validateJSON   bool
+	validateStrict bool
+
+	// openEditor edits the file at path; overridden in tests.
+	openEditor func(ctx context.Context, path string) error
+	// confirmReedit asks whether to edit an invalid file again; overridden
+	// in tests.
+	confirmReedit func() bool
 }
 
 // NewConfigCmd creates a new config command
 func NewConfigCmd(flags *Flags, app *hive.App) *ConfigCmd {
-	return &ConfigCmd{flags: flags, app: app}
+	return &ConfigCmd{
+		flags:         flags,
+		app:           app,
+		openEditor:    runEditor,
+		confirmReedit: promptReedit,
+	}
 }
 
 // Register adds the config command to the application
@@ -23,17 +57,344 @@ func (cmd *ConfigCmd) Register(app *cli.Command) *cli.Command {
 	app.Commands = append(app.Commands, &cli.Command{
 		Name:      "config",
 		Usage:     "Display resolved configuration",
-		UsageText: "hive config",
+		UsageText: "hive config [command]",
 		Description: `Dumps the fully resolved configuration as pretty-printed JSON.
 
 This shows the effective configuration after loading the config file,
-applying defaults, and merging keybindings.`,
+applying defaults, and merging keybindings. Values taken from environment
+variable or secret references are shown as written.
+
+The init, validate, and edit subcommands work on the config file itself and
+run even when it is missing or invalid.`,
 		Action: cmd.run,
+		Commands: []*cli.Command{
+			cmd.initCmd(),
+			cmd.validateCmd(),
+			cmd.editCmd(),
+		},
 	})
 
 	return app
 }
 
 func (cmd *ConfigCmd) run(_ context.Context, c *cli.Command) error {
-	return iojson.WriteWith(c.Root().Writer, c.Root().ErrWriter, cmd.app.Config)
+	cfg, err := cmd.app.Config.Unresolved()
+	if err != nil {
+		return err
+	}
+	return iojson.WriteWith(c.Root().Writer, c.Root().ErrWriter, cfg)
+}
+
+// configPath returns the config file the subcommands work on: --config, or
+// the default location when no config file exists yet.
+func (cmd *ConfigCmd) configPath() string {
+	if cmd.flags.ConfigPath != "" {
+		return cmd.flags.ConfigPath
+	}
+	return defaultConfigPath()
+}
+
+func (cmd *ConfigCmd) initCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "init",
+		Usage:     "Write a commented starter config file",
+		UsageText: "hive config init [--agent <name>] [--workspace <dir>] [--force]",
+		Description: `Writes a starter config file with comments explaining each section, without
+the interactive prompts of 'hive init'. It is written to --config, or to
+$XDG_CONFIG_HOME/hive/config.yaml.
+
+The default agent is the first agent found on PATH (claude when none is),
+and every other installed agent is listed too. An existing file is only
+replaced with --force.
+
+Example:
+  hive config init --agent codex --workspace ~/code`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "agent",
+				Usage:       "default agent (defaults to the first one installed)",
+				Destination: &cmd.initAgent,
+			},
+			&cli.StringFlag{
+				Name:        "workspace",
+				Usage:       "parent directory of your repositories (defaults to your home directory)",
+				Destination: &cmd.initWorkspace,
+			},
+			&cli.BoolFlag{
+				Name:        "force",
+				Aliases:     []string{"f"},
+				Usage:       "replace an existing config file",
+				Destination: &cmd.initForce,
+			},
+		},
+		Action: cmd.runInit,
+	}
+}
+
+func (cmd *ConfigCmd) runInit(_ context.Context, _ *cli.Command) error {
+	path := cmd.configPath()
+	if _, err := os.Stat(path); err == nil && !cmd.initForce {
+		return fmt.Errorf("%s already exists (use --force to replace it)", path)
+	}
+
+	installed := detectInstalledAgents(knownAgents)
+	agent := cmd.initAgent
+	if agent == "" {
+		agent = "claude"
+		if len(installed) > 0 {
+			agent = installed[0]
+		}
+	}
+
+	workspace := cmd.initWorkspace
+	if workspace == "" {
+		workspace = "~"
+	}
+
+	data := starterConfigData("hive config init", cmd.app.Build.Version, agent, installed, expandTilde(workspace), false)
+	rendered, err := renderConfigTemplate(data)
+	if err != nil {
+		return fmt.Errorf("render config: %w", err)
+	}
+	if err := config.WriteFile(path, []byte(rendered), nil); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote %s\n", path)
+	return nil
+}
+
+func (cmd *ConfigCmd) validateCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "validate",
+		Usage:     "Check the config file for errors",
+		UsageText: "hive config validate [--strict] [--json]",
+		Description: `Loads the config file and runs every validation check on it: structure,
+template syntax, rule patterns, keybindings, and file access. Errors and
+warnings are listed with the field they concern.
+
+Exits with status 0 when the config is valid and status 3 when it is not,
+so it can gate config changes in CI. With --strict, warnings fail too.
+
+Example:
+  hive --config=./hive.yaml config validate --strict`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "strict",
+				Usage:       "treat warnings as errors",
+				Destination: &cmd.validateStrict,
+			},
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "output the result as JSON",
+				Destination: &cmd.validateJSON,
+			},
+		},
+		Action: cmd.runValidate,
+	}
+}
+
+// configValidation is the JSON output of hive config validate.
+type configValidation struct {
+	Path     string             `json:"path"`
+	Valid    bool               `json:"valid"`
+	Errors   []doctor.CheckItem `json:"errors"`
+	Warnings []doctor.CheckItem `json:"warnings"`
+}
+
+func (cmd *ConfigCmd) runValidate(ctx context.Context, c *cli.Command) error {
+	path := cmd.flags.ConfigPath
+	if path == "" {
+		return WithKind(ErrorKindConfig, fmt.Errorf("no config file found at %s", defaultConfigPath()))
+	}
+
+	v := validateConfigFile(ctx, path, cmd.flags.DataDir)
+	if cmd.validateStrict && len(v.Warnings) > 0 {
+		v.Valid = false
+	}
+
+	if cmd.validateJSON {
+		if err := iojson.WriteLine(c.Root().Writer, v); err != nil {
+			return err
+		}
+	} else {
+		printConfigValidation(os.Stderr, v)
+	}
+
+	if !v.Valid {
+		return WithKind(ErrorKindConfig, fmt.Errorf("%s is invalid", path))
+	}
+	return nil
+}
+
+// validateConfigFile loads the config file at path and validates it deeply.
+func validateConfigFile(ctx context.Context, path, dataDir string) configValidation {
+	v := configValidation{Path: path, Errors: []doctor.CheckItem{}, Warnings: []doctor.CheckItem{}}
+
+	cfg, err := config.Load(path, dataDir)
+	if err != nil {
+		var fieldErrs criterio.FieldErrors
+		if errors.As(err, &fieldErrs) && len(fieldErrs) > 0 {
+			for _, fe := range fieldErrs {
+				v.Errors = append(v.Errors, doctor.CheckItem{Label: fe.Field, Status: doctor.StatusFail, Detail: fe.Err.Error()})
+			}
+		} else {
+			v.Errors = append(v.Errors, doctor.CheckItem{Label: "load", Status: doctor.StatusFail, Detail: err.Error()})
+		}
+	} else {
+		for _, item := range doctor.NewConfigCheck(cfg, path).Run(ctx).Items {
+			switch item.Status {
+			case doctor.StatusFail:
+				v.Errors = append(v.Errors, item)
+			case doctor.StatusWarn:
+				v.Warnings = append(v.Warnings, item)
+			}
+		}
+	}
+
+	for _, items := range [][]doctor.CheckItem{v.Errors, v.Warnings} {
+		for i := range items {
+			items[i].StatusStr = items[i].Status.String()
+		}
+	}
+	v.Valid = len(v.Errors) == 0
+	return v
+}
+
+// printConfigValidation prints a validation result in the style of hive
+// doctor.
+func printConfigValidation(w io.Writer, v configValidation) {
+	for _, item := range v.Errors {
+		_, _ = fmt.Fprintf(w, "  %s %s %s\n", styles.TextErrorStyle.Render("✘"), item.Label, styles.TextMutedStyle.Render(item.Detail))
+	}
+	for _, item := range v.Warnings {
+		_, _ = fmt.Fprintf(w, "  %s %s %s\n", styles.TextWarningStyle.Render("●"), item.Label, styles.TextMutedStyle.Render(item.Detail))
+	}
+
+	if len(v.Errors) == 0 && len(v.Warnings) == 0 {
+		_, _ = fmt.Fprintf(w, "%s %s is valid\n", styles.TextSuccessStyle.Render("✔"), v.Path)
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\n%s: %s  %s\n", v.Path,
+		styles.TextErrorStyle.Render(fmt.Sprintf("%d errors", len(v.Errors))),
+		styles.TextWarningStyle.Render(fmt.Sprintf("%d warnings", len(v.Warnings))),
+	)
+}
+
+func (cmd *ConfigCmd) editCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "edit",
+		Usage:     "Edit the config file and validate it on save",
+		UsageText: "hive config edit",
+		Description: `Opens a copy of the config file in $VISUAL or $EDITOR (vi when neither is
+set). When the editor exits, the copy is validated like 'hive config
+validate' and only replaces the config file when it is valid, so running
+hive processes never see a broken file.
+
+When the copy is invalid, its problems are listed and you can edit it again
+or discard the changes.`,
+		Action: cmd.runEdit,
+	}
+}
+
+func (cmd *ConfigCmd) runEdit(ctx context.Context, _ *cli.Command) error {
+	if cmd.flags.ConfigPath == "" {
+		return fmt.Errorf("no config file found at %s (run 'hive config init' first)", defaultConfigPath())
+	}
+
+	// Replace the file a symlinked config points to, not the link
+	path, err := filepath.EvalSymlinks(cmd.flags.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("resolve config path: %w", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+
+	// Edit a copy next to the original so relative includes still resolve
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".hive-edit-*"+filepath.Ext(path))
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	_, err = tmp.Write(original)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("write temp file: %w", err)
+	}
+
+	for {
+		if err := cmd.openEditor(ctx, tmp.Name()); err != nil {
+			return fmt.Errorf("run editor: %w", err)
+		}
+
+		edited, err := os.ReadFile(tmp.Name())
+		if err != nil {
+			return fmt.Errorf("read edited config: %w", err)
+		}
+		if bytes.Equal(edited, original) {
+			fmt.Fprintln(os.Stderr, "No changes.")
+			return nil
+		}
+
+		v := validateConfigFile(ctx, tmp.Name(), cmd.flags.DataDir)
+		if v.Valid {
+			if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+				return err
+			}
+			if err := os.Rename(tmp.Name(), path); err != nil {
+				return fmt.Errorf("save config: %w", err)
+			}
+			v.Path = path
+			printConfigValidation(os.Stderr, v)
+			fmt.Fprintf(os.Stderr, "Saved %s\n", path)
+			return nil
+		}
+
+		v.Path = path
+		printConfigValidation(os.Stderr, v)
+		if !cmd.confirmReedit() {
+			return WithKind(ErrorKindConfig, fmt.Errorf("changes discarded: %s is unchanged", path))
+		}
+	}
+}
+
+// runEditor opens path in $VISUAL or $EDITOR, falling back to vi. The
+// variable may include arguments, such as "code --wait".
+func runEditor(ctx context.Context, path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+	}
+
+	c := exec.CommandContext(ctx, args[0], append(args[1:], path)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return c.Run()
+}
+
+// promptReedit asks whether to edit an invalid config again. Aborting the
+// prompt discards the changes.
+func promptReedit() bool {
+	again := true
+	err := huh.NewConfirm().
+		Title("The edited config is invalid. Edit it again?").
+		Affirmative("Edit again").
+		Negative("Discard changes").
+		Value(&again).
+		WithTheme(huh.ThemeFunc(huhThemeTokyoNight)).
+		Run()
+	return err == nil && again
 }
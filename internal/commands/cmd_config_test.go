@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/colonyops/hive/internal/hive"
+)
+
+const invalidTestConfig = "rules:\n  - pattern: \"[\"\n"
+
+func runConfigCmd(t *testing.T, cmd *ConfigCmd, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	app := &cli.Command{Name: "hive", Writer: &out, ErrWriter: &out}
+	cmd.Register(app)
+	err := app.Run(context.Background(), append([]string{"hive", "config"}, args...))
+	return out.String(), err
+}
+
+func TestConfigInitAndValidate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hive", "config.yaml")
+	flags := &Flags{ConfigPath: path, DataDir: t.TempDir()}
+	cmd := NewConfigCmd(flags, &hive.App{})
+
+	_, err := runConfigCmd(t, cmd, "init", "--agent", "codex", "--workspace", t.TempDir())
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "generated by `hive config init`")
+	assert.Contains(t, string(data), "default: codex")
+
+	_, err = runConfigCmd(t, NewConfigCmd(flags, &hive.App{}), "init")
+	require.Error(t, err, "an existing config is not replaced")
+	assert.Contains(t, err.Error(), "--force")
+
+	out, err := runConfigCmd(t, NewConfigCmd(flags, &hive.App{}), "validate", "--json")
+	require.NoError(t, err)
+	var v configValidation
+	require.NoError(t, json.Unmarshal([]byte(out), &v))
+	assert.True(t, v.Valid)
+	assert.Empty(t, v.Errors)
+
+	require.NoError(t, os.WriteFile(path, []byte(invalidTestConfig), 0o644))
+	out, err = runConfigCmd(t, NewConfigCmd(flags, &hive.App{}), "validate", "--json")
+	require.Error(t, err)
+	assert.Equal(t, ErrorKindConfig, KindOf(err))
+	require.NoError(t, json.Unmarshal([]byte(out), &v))
+	assert.False(t, v.Valid)
+	require.NotEmpty(t, v.Errors)
+	assert.Equal(t, "fail", v.Errors[0].StatusStr)
+}
+
+func TestConfigEdit(t *testing.T) {
+	const valid = "tui:\n  theme: tokyo-night\n"
+
+	setup := func(t *testing.T, edits ...string) (*ConfigCmd, string, *int) {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("{}\n"), 0o600))
+
+		cmd := NewConfigCmd(&Flags{ConfigPath: path, DataDir: t.TempDir()}, &hive.App{})
+		opened := 0
+		cmd.openEditor = func(_ context.Context, p string) error {
+			assert.NotEqual(t, path, p, "the editor gets a copy")
+			assert.Equal(t, filepath.Dir(path), filepath.Dir(p))
+			content := edits[min(opened, len(edits)-1)]
+			opened++
+			return os.WriteFile(p, []byte(content), 0o600)
+		}
+		return cmd, path, &opened
+	}
+
+	t.Run("valid edit is saved", func(t *testing.T) {
+		cmd, path, _ := setup(t, valid)
+
+		_, err := runConfigCmd(t, cmd, "edit")
+		require.NoError(t, err)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, valid, string(data))
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("invalid edit is edited again", func(t *testing.T) {
+		cmd, path, opened := setup(t, invalidTestConfig, valid)
+		cmd.confirmReedit = func() bool { return true }
+
+		_, err := runConfigCmd(t, cmd, "edit")
+		require.NoError(t, err)
+		assert.Equal(t, 2, *opened)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, valid, string(data))
+	})
+
+	t.Run("invalid edit is discarded", func(t *testing.T) {
+		cmd, path, _ := setup(t, invalidTestConfig)
+		cmd.confirmReedit = func() bool { return false }
+
+		_, err := runConfigCmd(t, cmd, "edit")
+		require.Error(t, err)
+		assert.Equal(t, ErrorKindConfig, KindOf(err))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "{}\n", string(data))
+
+		entries, err := os.ReadDir(filepath.Dir(path))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "the edited copy is removed")
+	})
+}
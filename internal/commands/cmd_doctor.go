@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/colonyops/hive/internal/core/doctor"
 	"github.com/colonyops/hive/internal/core/styles"
@@ -14,22 +15,50 @@ import (
 )
 
 type DoctorCmd struct {
-	flags   *Flags
-	app     *hive.App
-	format  string
-	autofix bool
+	flags    *Flags
+	app      *hive.App
+	format   string
+	fix      bool
+	watch    bool
+	interval time.Duration
+
+	// pollInterval is how often --watch polls for corrupted sessions.
+	pollInterval time.Duration
+	// runChecks runs the doctor checks; overridden in tests.
+	runChecks func(ctx context.Context) []doctor.Result
+	// runReconcile runs the reconcile checks; overridden in tests.
+	runReconcile func(ctx context.Context) []doctor.Result
 }
 
 func NewDoctorCmd(flags *Flags, app *hive.App) *DoctorCmd {
-	return &DoctorCmd{flags: flags, app: app}
+	cmd := &DoctorCmd{flags: flags, app: app, pollInterval: corruptionPollInterval}
+	cmd.runChecks = func(ctx context.Context) []doctor.Result {
+		return cmd.app.Doctor.RunChecks(ctx, cmd.flags.ConfigPath, cmd.fix)
+	}
+	cmd.runReconcile = func(ctx context.Context) []doctor.Result {
+		return cmd.app.Doctor.Reconcile(ctx, cmd.fix)
+	}
+	return cmd
 }
 
 func (cmd *DoctorCmd) Register(app *cli.Command) *cli.Command {
 	app.Commands = append(app.Commands, &cli.Command{
-		Name:        "doctor",
-		Usage:       "Run health checks on your hive setup",
-		UsageText:   "hive doctor [options]",
-		Description: "Runs diagnostic checks on configuration, environment, and dependencies.",
+		Name:      "doctor",
+		Usage:     "Run health checks on your hive setup",
+		UsageText: "hive doctor [options]",
+		Description: `Runs diagnostic checks on configuration, environment, and dependencies.
+
+--fix repairs the issues it can: it creates missing data directories,
+re-extracts the bundled scripts, deletes orphaned worktrees and the records of
+sessions whose directory was deleted, kills tmux sessions left running in
+the repos directory without an active hive session, and removes what deleted
+sessions left behind.
+
+--watch keeps running as a health monitor, for example in a tmux pane during
+long agent runs. Checks re-run every --interval, whenever the config file
+changes, and when a session's data is found corrupted. Text output is a live
+table of checks; --format json prints one JSON object per run. Watch mode
+exits with status 1 as soon as a run fails after a run that did not.`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:        "format",
@@ -38,40 +67,104 @@ func (cmd *DoctorCmd) Register(app *cli.Command) *cli.Command {
 				Destination: &cmd.format,
 			},
 			&cli.BoolFlag{
-				Name:        "autofix",
-				Usage:       "automatically fix issues (e.g., delete orphaned worktrees)",
-				Destination: &cmd.autofix,
+				Name:        "fix",
+				Aliases:     []string{"autofix"},
+				Usage:       "repair the issues marked fixable (e.g., delete orphaned worktrees)",
+				Destination: &cmd.fix,
+			},
+			&cli.BoolFlag{
+				Name:        "watch",
+				Aliases:     []string{"w"},
+				Usage:       "re-run checks continuously and exit 1 when they start failing",
+				Destination: &cmd.watch,
+			},
+			&cli.DurationFlag{
+				Name:        "interval",
+				Usage:       "how often --watch re-runs the checks",
+				Value:       30 * time.Second,
+				Destination: &cmd.interval,
 			},
 		},
-		Action: cmd.run,
+		Action:   cmd.run,
+		Commands: []*cli.Command{cmd.reconcileCmd()},
 	})
 	return app
 }
 
+func (cmd *DoctorCmd) reconcileCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "reconcile",
+		Usage:     "Find sessions whose directories or tmux sessions vanished",
+		UsageText: "hive doctor reconcile [--fix] [--format json]",
+		Description: `Cross-references session records, the directories in the repos directory,
+and live tmux sessions. It reports:
+
+  - directories without a session record
+  - records of active and recycled sessions whose directory was deleted
+  - tmux sessions in the repos directory without an active hive session
+  - deleted sessions whose directory, tmux sessions, inbox, or stored
+    values were not fully removed
+
+--fix deletes the directories and records, kills the tmux sessions, and
+removes the leftovers of deleted sessions.
+Set tui.reconcile_on_start to run the report when the TUI starts.`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "output format (text, json)",
+				Value:       "text",
+				Destination: &cmd.format,
+			},
+			&cli.BoolFlag{
+				Name:        "fix",
+				Usage:       "clean up what is found",
+				Destination: &cmd.fix,
+			},
+		},
+		Action: cmd.runReconcileCmd,
+	}
+}
+
+func (cmd *DoctorCmd) runReconcileCmd(ctx context.Context, c *cli.Command) error {
+	results := cmd.runReconcile(ctx)
+
+	if cmd.format == "json" {
+		return cmd.outputJSON(c, results)
+	}
+
+	return cmd.outputText(ctx, results, "hive doctor reconcile --fix")
+}
+
 func (cmd *DoctorCmd) run(ctx context.Context, c *cli.Command) error {
-	results := cmd.app.Doctor.RunChecks(ctx, cmd.flags.ConfigPath, cmd.autofix)
+	if cmd.watch {
+		return cmd.runWatch(ctx, c)
+	}
+
+	results := cmd.runChecks(ctx)
 
 	if cmd.format == "json" {
 		return cmd.outputJSON(c, results)
 	}
 
-	return cmd.outputText(ctx, results)
+	return cmd.outputText(ctx, results, "hive doctor --fix")
 }
 
 func (cmd *DoctorCmd) outputJSON(c *cli.Command, results []doctor.Result) error {
 	passed, warned, failed := doctor.Summary(results)
 
-	out := struct {
-		Healthy bool            `json:"healthy"`
-		Summary summaryJSON     `json:"summary"`
-		Checks  []doctor.Result `json:"checks"`
-	}{
+	return iojson.WriteWith(c.Root().Writer, os.Stderr, reportJSON{
 		Healthy: failed == 0,
 		Summary: summaryJSON{Passed: passed, Warned: warned, Failed: failed},
 		Checks:  results,
-	}
+	})
+}
 
-	return iojson.WriteWith(c.Root().Writer, os.Stderr, out)
+type reportJSON struct {
+	Healthy bool            `json:"healthy"`
+	Summary summaryJSON     `json:"summary"`
+	Checks  []doctor.Result `json:"checks"`
+	Time    time.Time       `json:"time,omitzero"`     // set in --watch mode
+	Trigger string          `json:"trigger,omitempty"` // set in --watch mode
 }
 
 type summaryJSON struct {
@@ -80,7 +173,9 @@ type summaryJSON struct {
 	Failed int `json:"failed"`
 }
 
-func (cmd *DoctorCmd) outputText(_ context.Context, results []doctor.Result) error {
+// outputText prints results as a table, suggesting fixCmd when issues are
+// fixable.
+func (cmd *DoctorCmd) outputText(_ context.Context, results []doctor.Result, fixCmd string) error {
 	w := os.Stderr
 	divider := styles.TextMutedStyle.Render(strings.Repeat("─", 40))
 
@@ -121,11 +216,11 @@ func (cmd *DoctorCmd) outputText(_ context.Context, results []doctor.Result) err
 	)
 	_, _ = fmt.Fprintln(w, summary)
 
-	if !cmd.autofix {
+	if !cmd.fix {
 		fixable := doctor.CountFixable(results)
 		if fixable > 0 {
 			_, _ = fmt.Fprintln(w)
-			hint := styles.TextMutedStyle.Render(fmt.Sprintf("Run 'hive doctor --autofix' to fix %d issue(s)", fixable))
+			hint := styles.TextMutedStyle.Render(fmt.Sprintf("Run '%s' to fix %d issue(s)", fixCmd, fixable))
 			_, _ = fmt.Fprintln(w, hint)
 		}
 	}
@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/colonyops/hive/internal/core/doctor"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/data/stores"
+	"github.com/colonyops/hive/internal/hive"
+)
+
+func checkResults(statuses ...doctor.Status) []doctor.Result {
+	results := make([]doctor.Result, 0, len(statuses))
+	for i, status := range statuses {
+		results = append(results, doctor.Result{
+			Name:  []string{"Tools", "Configuration"}[i],
+			Items: []doctor.CheckItem{{Label: "item", Status: status}},
+		})
+	}
+	return results
+}
+
+// runDoctorWatch runs hive doctor --watch --format json, returning each
+// run's trigger and the exit code. runs are returned in turn by the checks;
+// the last one repeats.
+func runDoctorWatch(t *testing.T, ctx context.Context, configPath string, runs [][]doctor.Result, args ...string) ([]string, int) {
+	t.Helper()
+	cmd := NewDoctorCmd(&Flags{ConfigPath: configPath}, &hive.App{})
+	var mu sync.Mutex
+	calls := 0
+	cmd.runChecks = func(context.Context) []doctor.Result {
+		mu.Lock()
+		defer mu.Unlock()
+		results := runs[min(calls, len(runs)-1)]
+		calls++
+		return results
+	}
+
+	var out bytes.Buffer
+	exitCode := 0
+	app := &cli.Command{
+		Name:   "hive",
+		Writer: &out,
+		ExitErrHandler: func(_ context.Context, _ *cli.Command, err error) {
+			var coder cli.ExitCoder
+			if errors.As(err, &coder) {
+				exitCode = coder.ExitCode()
+			}
+		},
+	}
+	cmd.Register(app)
+	err := app.Run(ctx, append([]string{"hive", "doctor", "--watch", "--format", "json"}, args...))
+	if exitCode == 0 {
+		require.NoError(t, err)
+	}
+
+	var triggers []string
+	for line := range strings.Lines(out.String()) {
+		var report reportJSON
+		require.NoError(t, json.Unmarshal([]byte(line), &report))
+		triggers = append(triggers, report.Trigger)
+	}
+	return triggers, exitCode
+}
+
+func TestDoctorWatch_ExitsOnTransitionToFailure(t *testing.T) {
+	pass := checkResults(doctor.StatusPass, doctor.StatusWarn)
+	fail := checkResults(doctor.StatusPass, doctor.StatusFail)
+
+	triggers, code := runDoctorWatch(t, context.Background(), "", [][]doctor.Result{pass, pass, fail}, "--interval", "5ms")
+	assert.Equal(t, 1, code)
+	assert.Equal(t, []string{triggerStart, triggerInterval, triggerInterval}, triggers)
+}
+
+func TestDoctorWatch_InitialFailureKeepsWatching(t *testing.T) {
+	fail := checkResults(doctor.StatusFail, doctor.StatusPass)
+	pass := checkResults(doctor.StatusPass, doctor.StatusPass)
+
+	triggers, code := runDoctorWatch(t, context.Background(), "", [][]doctor.Result{fail, fail, pass, fail}, "--interval", "5ms")
+	assert.Equal(t, 1, code, "exits once checks that recovered fail again")
+	assert.Len(t, triggers, 4)
+}
+
+func TestDoctorWatch_RerunsOnConfigChange(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("{}\n"), 0o644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		assert.NoError(t, os.WriteFile(configPath, []byte("# edited\n{}\n"), 0o644))
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+
+	pass := checkResults(doctor.StatusPass)
+	triggers, code := runDoctorWatch(t, ctx, configPath, [][]doctor.Result{pass}, "--interval", "1h")
+	assert.Equal(t, 0, code)
+	require.NotEmpty(t, triggers)
+	assert.Equal(t, triggerStart, triggers[0])
+	assert.Contains(t, triggers, triggerConfig)
+}
+
+func TestDoctorWatch_PollsForCorruptedSessions(t *testing.T) {
+	app := newStatusApp(t, session.StateActive, session.StateCorrupted)
+	cmd := NewDoctorCmd(&Flags{}, app)
+	cmd.pollInterval = 5 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	corrupted := make(chan struct{}, 1)
+	go cmd.watchCorruptedSessions(ctx, func() {
+		select {
+		case corrupted <- struct{}{}:
+		default:
+		}
+	})
+
+	time.Sleep(30 * time.Millisecond)
+	require.Empty(t, corrupted, "sessions corrupted before the watch started do not trigger a run")
+
+	// Another process marks a session corrupted
+	sess, err := app.Sessions.GetSession(ctx, "a")
+	require.NoError(t, err)
+	sess.State = session.StateCorrupted
+	require.NoError(t, stores.NewSessionStore(app.DB).Save(ctx, sess))
+
+	select {
+	case <-corrupted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("newly corrupted session did not trigger a run")
+	}
+}
+
+func TestDoctorWatch_Table(t *testing.T) {
+	results := []doctor.Result{
+		{Name: "Tools", Items: []doctor.CheckItem{{Label: "git", Status: doctor.StatusPass}}},
+		{Name: "Configuration", Items: []doctor.CheckItem{
+			{Label: "Config valid", Status: doctor.StatusPass},
+			{Label: "rules[0]", Status: doctor.StatusFail},
+			{Label: "keybindings", Status: doctor.StatusWarn},
+		}},
+	}
+
+	out := terminal.StripANSI(renderWatchTable(results, triggerInterval, 30*time.Second, time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)))
+	assert.Contains(t, out, "every 30s · last run 15:04:05 (interval)")
+	assert.Contains(t, out, "✔ Tools          ok")
+	assert.Contains(t, out, "✘ Configuration  rules[0], keybindings")
+	assert.Contains(t, out, "2 passed  1 warnings  1 failed")
+}
+
+func TestDoctorReconcile_FixAndJSON(t *testing.T) {
+	cmd := NewDoctorCmd(&Flags{}, &hive.App{})
+	var fixed []bool
+	cmd.runReconcile = func(context.Context) []doctor.Result {
+		fixed = append(fixed, cmd.fix)
+		return []doctor.Result{{Name: "Session Records", Items: []doctor.CheckItem{
+			{Label: "auth (abc123)", Status: doctor.StatusWarn, StatusStr: "warn", Fixable: true},
+		}}}
+	}
+
+	var out bytes.Buffer
+	app := &cli.Command{Name: "hive", Writer: &out}
+	cmd.Register(app)
+	require.NoError(t, app.Run(context.Background(), []string{"hive", "doctor", "reconcile", "--fix", "--format", "json"}))
+
+	assert.Equal(t, []bool{true}, fixed)
+	var report reportJSON
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.True(t, report.Healthy)
+	assert.Equal(t, 1, report.Summary.Warned)
+	require.Len(t, report.Checks, 1)
+	assert.Equal(t, "Session Records", report.Checks[0].Name)
+}
@@ -0,0 +1,256 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v3"
+
+	"github.com/colonyops/hive/internal/core/doctor"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/styles"
+	"github.com/colonyops/hive/pkg/iojson"
+)
+
+// Reasons a --watch run was triggered.
+const (
+	triggerStart     = "start"
+	triggerInterval  = "interval"
+	triggerConfig    = "config changed"
+	triggerCorrupted = "session corrupted"
+)
+
+// corruptionPollInterval is how often --watch reads the sessions table for
+// sessions another hive process marked corrupted.
+const corruptionPollInterval = 2 * time.Second
+
+// clearScreen moves the cursor home and clears the terminal, so each run
+// redraws the table in place.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// runWatch re-runs the checks on every interval and trigger until ctx is
+// cancelled. It exits 1 once a run fails after a run that did not.
+func (cmd *DoctorCmd) runWatch(ctx context.Context, c *cli.Command) error {
+	if cmd.interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	triggers := make(chan string, 8)
+	notify := func(reason string) {
+		select {
+		case triggers <- reason:
+		default: // a run is already pending
+		}
+	}
+
+	if cmd.app.Sessions != nil {
+		go cmd.watchCorruptedSessions(ctx, func() { notify(triggerCorrupted) })
+	}
+	if stop := watchConfigFile(ctx, cmd.flags.ConfigPath, func() { notify(triggerConfig) }); stop != nil {
+		defer stop()
+	}
+
+	ticker := time.NewTicker(cmd.interval)
+	defer ticker.Stop()
+
+	trigger := triggerStart
+	failing := false
+	for {
+		results := cmd.runChecks(ctx)
+		if err := cmd.printWatchRun(c, results, trigger); err != nil {
+			return err
+		}
+
+		_, _, failed := doctor.Summary(results)
+		if failed > 0 && !failing && trigger != triggerStart {
+			return cli.Exit("", 1)
+		}
+		failing = failed > 0
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			trigger = triggerInterval
+		case trigger = <-triggers:
+		}
+
+		// Coalesce triggers that arrived together into one run
+		for drained := false; !drained; {
+			select {
+			case reason := <-triggers:
+				if reason == triggerConfig {
+					trigger = reason
+				}
+			default:
+				drained = true
+			}
+		}
+
+		if trigger == triggerConfig && cmd.app.Doctor != nil {
+			if err := cmd.app.Doctor.ReloadConfig(cmd.flags.ConfigPath); err != nil {
+				log.Debug().Err(err).Msg("doctor: failed to reload config")
+			}
+		}
+	}
+}
+
+// watchCorruptedSessions polls the sessions table and calls onCorrupted when a
+// session is newly stored as corrupted. Corruption is usually detected by the
+// TUI or another command, so the in-process event bus would never see it.
+func (cmd *DoctorCmd) watchCorruptedSessions(ctx context.Context, onCorrupted func()) {
+	ticker := time.NewTicker(cmd.pollInterval)
+	defer ticker.Stop()
+
+	var seen map[string]bool
+	for {
+		sessions, err := cmd.app.Sessions.ListSessions(ctx)
+		if err != nil {
+			log.Debug().Err(err).Msg("doctor: failed to list sessions")
+		} else {
+			corrupted := make(map[string]bool)
+			for _, sess := range sessions {
+				if sess.State == session.StateCorrupted {
+					corrupted[sess.ID] = true
+				}
+			}
+			// The first poll only records sessions that were already corrupted
+			if seen != nil {
+				for id := range corrupted {
+					if !seen[id] {
+						onCorrupted()
+						break
+					}
+				}
+			}
+			seen = corrupted
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchConfigFile calls onChange whenever the file at path is written or
+// replaced, and returns a function that stops watching. It returns nil if
+// the file cannot be watched.
+func watchConfigFile(ctx context.Context, path string, onChange func()) (stop func()) {
+	if path == "" {
+		return nil
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Debug().Err(err).Msg("doctor: cannot watch config file")
+		return nil
+	}
+	// Watch the directory: editors often replace the file rather than write it
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		log.Debug().Err(err).Str("path", path).Msg("doctor: cannot watch config file")
+		return nil
+	}
+
+	name := filepath.Clean(path)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) == name && !event.Has(fsnotify.Chmod) {
+					onChange()
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+	return func() { _ = watcher.Close() }
+}
+
+// printWatchRun prints one --watch run: a JSON line with --format json,
+// otherwise a redrawn table.
+func (cmd *DoctorCmd) printWatchRun(c *cli.Command, results []doctor.Result, trigger string) error {
+	passed, warned, failed := doctor.Summary(results)
+	if cmd.format == "json" {
+		return iojson.WriteLine(c.Root().Writer, reportJSON{
+			Healthy: failed == 0,
+			Summary: summaryJSON{Passed: passed, Warned: warned, Failed: failed},
+			Checks:  results,
+			Time:    time.Now(),
+			Trigger: trigger,
+		})
+	}
+
+	w := c.Root().ErrWriter
+	_, _ = io.WriteString(w, clearScreen)
+	_, _ = io.WriteString(w, renderWatchTable(results, trigger, cmd.interval, time.Now()))
+	return nil
+}
+
+// renderWatchTable renders one line per check with its worst status and the
+// items that did not pass.
+func renderWatchTable(results []doctor.Result, trigger string, interval time.Duration, now time.Time) string {
+	var b strings.Builder
+
+	nameWidth := 0
+	for _, r := range results {
+		nameWidth = max(nameWidth, len(r.Name))
+	}
+
+	b.WriteString(styles.TextPrimaryBoldStyle.Render("Hive Doctor"))
+	b.WriteString(styles.TextMutedStyle.Render(fmt.Sprintf("  every %s · last run %s (%s)", interval, now.Format("15:04:05"), trigger)))
+	b.WriteString("\n")
+	b.WriteString(styles.TextMutedStyle.Render(strings.Repeat("─", 40)))
+	b.WriteString("\n")
+
+	for _, r := range results {
+		var icon, detail string
+		switch r.Status() {
+		case doctor.StatusPass:
+			icon = styles.TextSuccessStyle.Render("✔")
+			detail = styles.TextMutedStyle.Render("ok")
+		case doctor.StatusWarn:
+			icon = styles.TextWarningStyle.Render("●")
+			detail = styles.TextWarningStyle.Render(issueLabels(r))
+		case doctor.StatusFail:
+			icon = styles.TextErrorStyle.Render("✘")
+			detail = styles.TextErrorStyle.Render(issueLabels(r))
+		}
+		fmt.Fprintf(&b, "%s %-*s  %s\n", icon, nameWidth, r.Name, detail)
+	}
+
+	passed, warned, failed := doctor.Summary(results)
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "%s  %s  %s\n",
+		styles.TextSuccessStyle.Render(fmt.Sprintf("%d passed", passed)),
+		styles.TextWarningStyle.Render(fmt.Sprintf("%d warnings", warned)),
+		styles.TextErrorStyle.Render(fmt.Sprintf("%d failed", failed)),
+	)
+	return b.String()
+}
+
+// issueLabels lists the labels of the result's items that did not pass.
+func issueLabels(r doctor.Result) string {
+	var labels []string
+	for _, item := range r.Items {
+		if item.Status != doctor.StatusPass {
+			labels = append(labels, item.Label)
+		}
+	}
+	return strings.Join(labels, ", ")
+}
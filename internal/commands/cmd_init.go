@@ -27,6 +27,7 @@ type agentTemplateData struct {
 }
 
 type configTemplateData struct {
+	Command   string // command that generated the file
 	Version   string
 	Workspace string
 	Default   string
@@ -272,7 +273,7 @@ func renderConfigTemplate(data configTemplateData) (string, error) {
 	return sb.String(), nil
 }
 
-const configTemplate = `# hive configuration - generated by ` + "`hive init`" + `
+const configTemplate = `# hive configuration - generated by ` + "`{{ .Command }}`" + `
 # Full reference: https://hive.colonyops.io/configuration
 version: {{ .Version }}
 
@@ -661,6 +662,20 @@ func (cmd *InitCmd) run(_ context.Context, _ *cli.Command) error {
 }
 
 func (cmd *InitCmd) applyConfigFile(cfgPath, agentName string, installed []string, workspace string, skipPerms bool) stepResult {
+	data := starterConfigData("hive init", cmd.app.Build.Version, agentName, installed, workspace, skipPerms)
+	rendered, err := renderConfigTemplate(data)
+	if err != nil {
+		return stepResult{name: "Config file", status: statusFailed, detail: err.Error()}
+	}
+	if err := config.WriteFile(cfgPath, []byte(rendered), nil); err != nil {
+		return stepResult{name: "Config file", status: statusFailed, detail: err.Error()}
+	}
+	return stepResult{name: "Config file", status: statusDone, detail: "created " + cfgPath}
+}
+
+// starterConfigData returns the template data for a starter config with
+// agentName as the default agent, followed by the other installed agents.
+func starterConfigData(command, version, agentName string, installed []string, workspace string, skipPerms bool) configTemplateData {
 	agents := make([]agentTemplateData, 0, len(installed))
 	agents = append(agents, agentTemplateData{
 		Name:  agentName,
@@ -676,30 +691,13 @@ func (cmd *InitCmd) applyConfigFile(cfgPath, agentName string, installed []strin
 		})
 	}
 
-	data := configTemplateData{
-		Version:   cmd.app.Build.Version,
+	return configTemplateData{
+		Command:   command,
+		Version:   version,
 		Workspace: workspace,
 		Default:   agentName,
 		Agents:    agents,
 	}
-	rendered, err := renderConfigTemplate(data)
-	if err != nil {
-		return stepResult{name: "Config file", status: statusFailed, detail: err.Error()}
-	}
-
-	if err := os.MkdirAll(filepath.Dir(cfgPath), 0o755); err != nil {
-		return stepResult{name: "Config file", status: statusFailed, detail: err.Error()}
-	}
-
-	tmp := cfgPath + ".tmp"
-	if err := os.WriteFile(tmp, []byte(rendered), 0o644); err != nil {
-		return stepResult{name: "Config file", status: statusFailed, detail: err.Error()}
-	}
-	if err := os.Rename(tmp, cfgPath); err != nil {
-		_ = os.Remove(tmp)
-		return stepResult{name: "Config file", status: statusFailed, detail: err.Error()}
-	}
-	return stepResult{name: "Config file", status: statusDone, detail: "created " + cfgPath}
 }
 
 // flagsFor returns the skip-permissions flags for an agent when skipPerms is true,
@@ -0,0 +1,208 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/hive"
+	"github.com/urfave/cli/v3"
+)
+
+// KVCmd reads and writes values scoped to a session.
+type KVCmd struct {
+	flags *Flags
+	app   *hive.App
+
+	session string
+	lsJSON  bool
+}
+
+// NewKVCmd creates a new kv command.
+func NewKVCmd(flags *Flags, app *hive.App) *KVCmd {
+	return &KVCmd{flags: flags, app: app}
+}
+
+// Register adds the kv command to the application.
+func (cmd *KVCmd) Register(app *cli.Command) *cli.Command {
+	app.Commands = append(app.Commands, &cli.Command{
+		Name:  "kv",
+		Usage: "Store per-session values for user commands and scripts",
+		Description: `Stores small string values scoped to a session, such as the last deployed
+sha or a review round counter. Values are removed when the session is deleted.
+
+The session is given with --session, or detected from the current directory.
+User command templates read the selected session's values with kvGet:
+
+  usercommands:
+    Deploy:
+      sh: 'deploy --since {{ kvGet "last_sha" | shq }} && hive kv set last_sha "$(git rev-parse HEAD)"'
+
+Examples:
+  hive kv set last_sha abc123
+  hive kv --session fix-auth get review_round
+  hive kv ls --json`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "session",
+				Aliases:     []string{"s"},
+				Usage:       "session ID, name, or slug (default: session of the current directory)",
+				Destination: &cmd.session,
+			},
+		},
+		Commands: []*cli.Command{
+			cmd.getCmd(),
+			cmd.setCmd(),
+			cmd.rmCmd(),
+			cmd.lsCmd(),
+		},
+	})
+
+	return app
+}
+
+func (cmd *KVCmd) getCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "get",
+		Usage:     "Print the value of a key",
+		UsageText: "hive kv get <key>",
+		Action: func(ctx context.Context, c *cli.Command) error {
+			key := c.Args().First()
+			if key == "" {
+				return fmt.Errorf("key required")
+			}
+			id, err := cmd.resolveSession(ctx)
+			if err != nil {
+				return err
+			}
+			value, err := cmd.app.Sessions.SessionValue(ctx, id, key)
+			if errors.Is(err, hive.ErrNoSessionValue) {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			if err != nil {
+				return fmt.Errorf("get %s: %w", key, err)
+			}
+			_, _ = fmt.Fprintln(c.Root().Writer, value)
+			return nil
+		},
+	}
+}
+
+func (cmd *KVCmd) setCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "set",
+		Usage:     "Set the value of a key",
+		UsageText: "hive kv set <key> <value>",
+		Action: func(ctx context.Context, c *cli.Command) error {
+			if c.Args().Len() != 2 {
+				return fmt.Errorf("expected <key> <value>")
+			}
+			id, err := cmd.resolveSession(ctx)
+			if err != nil {
+				return err
+			}
+			key := c.Args().Get(0)
+			if err := cmd.app.Sessions.SetSessionValue(ctx, id, key, c.Args().Get(1)); err != nil {
+				return fmt.Errorf("set %s: %w", key, err)
+			}
+			return nil
+		},
+	}
+}
+
+func (cmd *KVCmd) rmCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "rm",
+		Usage:     "Remove a key",
+		UsageText: "hive kv rm <key>...",
+		Action: func(ctx context.Context, c *cli.Command) error {
+			if c.Args().Len() == 0 {
+				return fmt.Errorf("key required")
+			}
+			id, err := cmd.resolveSession(ctx)
+			if err != nil {
+				return err
+			}
+			for _, key := range c.Args().Slice() {
+				if err := cmd.app.Sessions.DeleteSessionValue(ctx, id, key); err != nil {
+					return fmt.Errorf("remove %s: %w", key, err)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func (cmd *KVCmd) lsCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "ls",
+		Aliases:   []string{"list"},
+		Usage:     "List the values of a session",
+		UsageText: "hive kv ls [--json]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "output as a JSON object",
+				Destination: &cmd.lsJSON,
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			id, err := cmd.resolveSession(ctx)
+			if err != nil {
+				return err
+			}
+			values, err := cmd.app.Sessions.SessionValues(ctx, id)
+			if err != nil {
+				return fmt.Errorf("list values: %w", err)
+			}
+
+			out := c.Root().Writer
+			if cmd.lsJSON {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(values)
+			}
+
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "KEY\tVALUE")
+			for _, key := range slices.Sorted(maps.Keys(values)) {
+				_, _ = fmt.Fprintf(w, "%s\t%s\n", key, values[key])
+			}
+			return w.Flush()
+		},
+	}
+}
+
+// resolveSession returns the ID of the session given with --session, or of
+// the session the current directory belongs to.
+func (cmd *KVCmd) resolveSession(ctx context.Context) (string, error) {
+	if cmd.session == "" {
+		id, err := cmd.app.Sessions.DetectSession(ctx)
+		if err != nil {
+			return "", fmt.Errorf("detect session: %w", err)
+		}
+		if id == "" {
+			return "", fmt.Errorf("not in a hive session: pass --session")
+		}
+		return id, nil
+	}
+
+	if sess, err := cmd.app.Sessions.GetSession(ctx, cmd.session); err == nil {
+		return sess.ID, nil
+	}
+	sessions, err := cmd.app.Sessions.ListSessions(ctx)
+	if err != nil {
+		return "", fmt.Errorf("list sessions: %w", err)
+	}
+	for _, s := range sessions {
+		if s.State == session.StateActive && (s.Name == cmd.session || s.Slug == cmd.session) {
+			return s.ID, nil
+		}
+	}
+	return "", fmt.Errorf("session not found: %s", cmd.session)
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"log"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/colonyops/hive/internal/core/messaging"
@@ -30,14 +31,35 @@ type MsgCmd struct {
 	pubFile    string
 	pubSender  string
 	pubMessage string
+	pubType    string
+	pubJSON    bool
+	pubCorrID  string
 
 	// sub flags
-	subTopic   string
-	subTimeout string
-	subTail    int
-	subListen  bool
-	subWait    bool
-	subAck     bool
+	subTopic    string
+	subTimeout  string
+	subTail     int
+	subListen   bool
+	subWait     bool
+	subAck      bool
+	subAfter    int64
+	subFollow   bool
+	subCount    int
+	subConsumer string
+	subUnacked  bool
+	subType     string
+
+	// request flags (share pubMessage, pubFile and pubSender)
+	requestTopics       []string
+	requestReplyTimeout string
+
+	// reply flags (share pubMessage, pubFile and pubSender)
+	replyTo string
+
+	// ack flags
+	ackConsumer string
+	ackTopic    string
+	ackSeq      int64
 
 	// inbox flags
 	inboxAll     bool
@@ -51,11 +73,17 @@ type MsgCmd struct {
 	// topic flags
 	topicNew    bool
 	topicPrefix string
+
+	// prune flags
+	pruneTopic     string
+	pruneOlderThan string
+
+	pollInterval time.Duration // how often --listen, --wait and --follow check for messages
 }
 
 // NewMsgCmd creates a new msg command.
 func NewMsgCmd(flags *Flags, app *hive.App) *MsgCmd {
-	return &MsgCmd{flags: flags, app: app}
+	return &MsgCmd{flags: flags, app: app, pollInterval: 500 * time.Millisecond}
 }
 
 // Register adds the msg command to the application.
@@ -75,9 +103,13 @@ Warnings and errors are written to stderr.`,
 		Commands: []*cli.Command{
 			cmd.pubCmd(),
 			cmd.subCmd(),
+			cmd.requestCmd(),
+			cmd.replyCmd(),
+			cmd.ackCmd(),
 			cmd.inboxCmd(),
 			cmd.listCmd(),
 			cmd.topicCmd(),
+			cmd.pruneCmd(),
 		},
 	})
 
@@ -100,16 +132,27 @@ The message can be provided as:
 Only one message source may be used. An error is returned if multiple are provided.
 
 The sender is auto-detected from the current hive session, or can be overridden with --sender.
-Topic supports wildcards for publishing to multiple topics (e.g., agent.*.inbox).
+Topic supports wildcards for publishing to every existing matching topic:
+"*" matches one dot-separated token (agent.*.inbox) and ">" matches all
+remaining tokens (build.>).
+
+Envelope:
+--type, --correlation-id, and --json add structured fields to the message,
+stored alongside the payload and shown by "hive msg sub". --type names the
+kind of message (lowercase words, like "handoff" or "review.done") so readers
+can filter on it with "hive msg sub --type". --json declares the payload as
+JSON and rejects it if it does not parse.
 
-Output: JSON confirmation line with status, resolved topics, and sender.
+Output: JSON confirmation line with status, resolved topics, per-topic seqs, and sender.
 
 Examples:
   hive msg pub --topic build.started -m "Build starting"
   hive msg pub -t agent.abc.inbox -t agent.xyz.inbox -m "Hello all"
   hive msg pub -t "agent.*.inbox" -m "Broadcast message"
+  hive msg pub -t "build.>" -m "Cancel all builds"
   echo "Hello" | hive msg pub --topic greetings
-  hive msg pub --topic logs -f build.log`,
+  hive msg pub --topic logs -f build.log
+  hive msg pub -t agent.abc.inbox --type handoff --json -m '{"branch":"feat/x"}'`,
 		Flags: []cli.Flag{
 			&cli.StringSliceFlag{
 				Name:        "topic",
@@ -136,6 +179,21 @@ Examples:
 				Usage:       "override sender ID (default: auto-detect from session)",
 				Destination: &cmd.pubSender,
 			},
+			&cli.StringFlag{
+				Name:        "type",
+				Usage:       "message type, e.g. handoff (readers filter with hive msg sub --type)",
+				Destination: &cmd.pubType,
+			},
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "declare the payload as JSON, rejecting it if it is not valid JSON",
+				Destination: &cmd.pubJSON,
+			},
+			&cli.StringFlag{
+				Name:        "correlation-id",
+				Usage:       "ID tying this message to related ones",
+				Destination: &cmd.pubCorrID,
+			},
 		},
 		Action: cmd.runPub,
 	}
@@ -145,36 +203,67 @@ func (cmd *MsgCmd) subCmd() *cli.Command {
 	return &cli.Command{
 		Name:      "sub",
 		Usage:     "Read messages from a topic",
-		UsageText: "hive msg sub [--topic <pattern>] [--tail N] [--listen] [--ack]",
+		UsageText: "hive msg sub [--topic <pattern>] [--type TYPE] [--tail N] [--after-seq N] [--listen | --follow] [--count N] [--consumer NAME [--unacked]] [--ack]",
 		Description: `Reads messages from topics, optionally filtering by topic pattern.
 
 By default, returns all messages as JSON Lines and exits without acknowledging.
 Use --ack to mark messages as read. Use --listen to poll for new messages,
 or --wait to block until a single message arrives (useful for inter-agent handoff).
 
+--follow prints the current messages like the default mode, then keeps running
+and streams new messages as they arrive, like "tail -f". It runs until
+interrupted unless --timeout is set. --count N exits once N messages were
+printed (with --follow, existing messages count too), so a script can block
+on its inbox without a polling loop.
+
+--type TYPE returns only messages published with that envelope type (see
+"hive msg pub --type"); the other modes and flags apply to those messages.
+
 For unread inbox messages, use "hive msg inbox" instead.
 
+Consumers:
+--ack marks messages read by the current session. With --consumer NAME it
+instead moves NAME's cursor past the printed messages, so several agents
+reading the same broadcast topic each track their own progress. --unacked
+returns only the messages past NAME's cursor; with --listen, --wait or
+--follow it also starts streaming from there, so messages published while the
+consumer was away are not missed. See "hive msg ack".
+
 Topic patterns:
 - No topic or "*": all messages
 - "exact.topic": exact topic match
-- "prefix.*": wildcard match for topics starting with "prefix."
+- "agent.*.inbox": "*" matches one or more dot-separated tokens
+- "build.>": ">" matches one or more trailing tokens (build.started, build.api.done)
+
+Ordering and cursors:
+Every message carries a "seq" field that increases monotonically per topic and
+is assigned atomically at publish time. Messages within a topic are always
+returned in seq order. Pass the last seen seq to --after-seq to resume reading
+an exact topic without gaps or duplicates. --listen and --wait track seq
+internally, so messages published concurrently are never skipped.
 
 Output: One JSON object per line (JSON Lines format).
-On timeout (--listen/--wait), prints a JSON status line to stdout and exits with code 1.
+On timeout (--listen/--wait/--follow), prints a JSON status line to stdout and exits with code 1.
 
 Examples:
   hive msg sub                       # all messages as JSON
   hive msg sub --topic agent.build   # specific topic
-  hive msg sub --topic agent.*       # wildcard pattern
+  hive msg sub -t "agent.*.inbox"    # every session inbox
+  hive msg sub -t "build.>" --listen # all build topics, at any depth
   hive msg sub --tail 10             # last 10 messages
+  hive msg sub -t handoff --after-seq 42  # messages after seq 42
   hive msg sub --listen              # poll for new messages
   hive msg sub --wait --topic handoff # wait for single message
-  hive msg sub --ack                 # read and acknowledge`,
+  hive msg sub -t agent.x7k2 --follow # print the topic, then stream new messages
+  hive msg sub -t handoff --after-seq 42 --follow --count 3 --timeout 10m
+  hive msg sub -t "agent.*.inbox" --type handoff  # only handoff messages
+  hive msg sub --ack                 # read and acknowledge
+  hive msg sub -t announce --consumer reviewer --unacked --ack  # new since reviewer's last ack`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:        "topic",
 				Aliases:     []string{"t"},
-				Usage:       "topic pattern to subscribe to (supports wildcards like agent.*)",
+				Usage:       "topic pattern to subscribe to (supports wildcards like agent.*.inbox and build.>)",
 				Destination: &cmd.subTopic,
 			},
 			&cli.IntFlag{
@@ -200,9 +289,40 @@ Examples:
 				Usage:       "acknowledge (mark as read) messages after reading",
 				Destination: &cmd.subAck,
 			},
+			&cli.Int64Flag{
+				Name:        "after-seq",
+				Usage:       "return only messages with seq greater than N (requires an exact --topic)",
+				Destination: &cmd.subAfter,
+			},
+			&cli.BoolFlag{
+				Name:        "follow",
+				Aliases:     []string{"f"},
+				Usage:       "print current messages, then stream new ones as they arrive",
+				Destination: &cmd.subFollow,
+			},
+			&cli.IntFlag{
+				Name:        "count",
+				Usage:       "exit after N messages (with --listen or --follow)",
+				Destination: &cmd.subCount,
+			},
+			&cli.StringFlag{
+				Name:        "consumer",
+				Usage:       "named consumer whose cursor --ack advances and --unacked reads from",
+				Destination: &cmd.subConsumer,
+			},
+			&cli.BoolFlag{
+				Name:        "unacked",
+				Usage:       "return only messages past the --consumer cursor",
+				Destination: &cmd.subUnacked,
+			},
+			&cli.StringFlag{
+				Name:        "type",
+				Usage:       "return only messages with this envelope type",
+				Destination: &cmd.subType,
+			},
 			&cli.StringFlag{
 				Name:        "timeout",
-				Usage:       "timeout for --listen/--wait mode (e.g., 30s, 5m, 24h)",
+				Usage:       "timeout for --listen/--wait/--follow mode (e.g., 30s, 5m, 24h; --follow has none by default)",
 				Value:       "30s",
 				Destination: &cmd.subTimeout,
 			},
@@ -213,6 +333,195 @@ Examples:
 	}
 }
 
+func (cmd *MsgCmd) requestCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "request",
+		Usage:     "Publish a message and wait for a reply",
+		UsageText: "hive msg request --topic <topic> [--reply-timeout 30s] [-m message | message | -f file | stdin]",
+		Description: `Publishes a request and blocks until a reply arrives.
+
+The request is published like "hive msg pub" with a reply topic generated for
+it, exposed to subscribers as the message's reply_to. The responder answers
+with "hive msg reply --to <message-id>", and the first reply is printed.
+
+If no reply arrives within --reply-timeout, a timeout status line is printed
+and the command exits with status 1.
+
+Output: the reply as a JSON message line.
+
+Examples:
+  hive msg request -t agent.abc.inbox -m "Which port is the API on?"
+  hive msg request -t review.requests --reply-timeout 5m -f plan.md`,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "topic",
+				Aliases:     []string{"t"},
+				Usage:       "topic(s) to publish the request to (supports wildcards, repeatable)",
+				Required:    true,
+				Destination: &cmd.requestTopics,
+			},
+			&cli.StringFlag{
+				Name:        "reply-timeout",
+				Usage:       "how long to wait for a reply (e.g. 30s, 5m)",
+				Value:       "30s",
+				Destination: &cmd.requestReplyTimeout,
+			},
+			&cli.StringFlag{
+				Name:        "message",
+				Aliases:     []string{"m"},
+				Usage:       "inline message content",
+				Destination: &cmd.pubMessage,
+			},
+			&cli.StringFlag{
+				Name:        "file",
+				Aliases:     []string{"f"},
+				Usage:       "read message from file",
+				Destination: &cmd.pubFile,
+			},
+			&cli.StringFlag{
+				Name:        "sender",
+				Aliases:     []string{"s"},
+				Usage:       "override sender ID (default: auto-detect from session)",
+				Destination: &cmd.pubSender,
+			},
+		},
+		Action: cmd.runRequest,
+	}
+}
+
+func (cmd *MsgCmd) replyCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "reply",
+		Usage:     "Reply to a request",
+		UsageText: "hive msg reply --to <message-id> [-m message | message | -f file | stdin]",
+		Description: `Publishes a reply to a message sent with "hive msg request".
+
+The reply goes to the request's reply topic, where the requester is waiting
+for it. Replying to a message that was not sent as a request is an error.
+
+Output: JSON confirmation line with status, reply topic, seq, and sender.
+
+Examples:
+  hive msg reply --to Ab3xK9pQ -m "8080"
+  hive msg reply --to Ab3xK9pQ -f review.md`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "to",
+				Usage:       "ID of the request message",
+				Required:    true,
+				Destination: &cmd.replyTo,
+			},
+			&cli.StringFlag{
+				Name:        "message",
+				Aliases:     []string{"m"},
+				Usage:       "inline message content",
+				Destination: &cmd.pubMessage,
+			},
+			&cli.StringFlag{
+				Name:        "file",
+				Aliases:     []string{"f"},
+				Usage:       "read message from file",
+				Destination: &cmd.pubFile,
+			},
+			&cli.StringFlag{
+				Name:        "sender",
+				Aliases:     []string{"s"},
+				Usage:       "override sender ID (default: auto-detect from session)",
+				Destination: &cmd.pubSender,
+			},
+		},
+		Action: cmd.runReply,
+	}
+}
+
+func (cmd *MsgCmd) ackCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "ack",
+		Usage:     "Advance a named consumer's read position",
+		UsageText: "hive msg ack --consumer NAME (<message-id>... | --topic <pattern> [--seq N])",
+		Description: `Acknowledges messages for a named consumer.
+
+Each consumer keeps its own cursor per topic: the seq of the last message it
+acknowledged. Acknowledging a message also acknowledges every earlier message
+of its topic, and a cursor never moves backwards. "hive msg sub --consumer
+NAME --unacked" reads the messages past the cursor.
+
+Pass message IDs to acknowledge through those messages, or --topic to
+acknowledge every message currently in the matching topics. --seq N
+acknowledges an exact --topic through seq N.
+
+Output: JSON confirmation line with the consumer's cursor in the affected topics.
+
+Examples:
+  hive msg ack --consumer reviewer Ab3xK9pQ
+  hive msg ack --consumer reviewer -t announce
+  hive msg ack --consumer reviewer -t announce --seq 42
+  hive msg ack --consumer reviewer -t "agent.*.inbox"`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "consumer",
+				Usage:       "consumer name",
+				Required:    true,
+				Destination: &cmd.ackConsumer,
+			},
+			&cli.StringFlag{
+				Name:        "topic",
+				Aliases:     []string{"t"},
+				Usage:       "acknowledge every message in topics matching this pattern",
+				Destination: &cmd.ackTopic,
+			},
+			&cli.Int64Flag{
+				Name:        "seq",
+				Usage:       "acknowledge only through seq N (requires an exact --topic)",
+				Destination: &cmd.ackSeq,
+			},
+		},
+		Action: cmd.runAck,
+	}
+}
+
+func (cmd *MsgCmd) runAck(ctx context.Context, c *cli.Command) error {
+	msgs := cmd.messages()
+
+	ids := c.Args().Slice()
+	topic := cmd.ackTopic
+	switch {
+	case len(ids) > 0 && topic != "":
+		return fmt.Errorf("pass message IDs or --topic, not both")
+	case len(ids) == 0 && topic == "":
+		return fmt.Errorf("message IDs or --topic required")
+	case c.IsSet("seq") && (topic == "" || messaging.IsTopicPattern(topic)):
+		return fmt.Errorf("--seq requires an exact --topic; sequence numbers are per topic")
+	}
+
+	if topic != "" {
+		messages, err := msgs.GetUnacked(ctx, cmd.ackConsumer, topic)
+		if err != nil && !errors.Is(err, messaging.ErrTopicNotFound) {
+			return fmt.Errorf("read unacked messages: %w", err)
+		}
+		if c.IsSet("seq") {
+			messages = slices.DeleteFunc(messages, func(m messaging.Message) bool { return m.Seq > cmd.ackSeq })
+		}
+		ids = messageIDs(messages)
+	} else {
+		topic = "*"
+	}
+
+	if err := msgs.AckThrough(ctx, cmd.ackConsumer, ids); err != nil {
+		return fmt.Errorf("acknowledge: %w", err)
+	}
+
+	cursor, err := msgs.ConsumerCursor(ctx, cmd.ackConsumer, topic)
+	if err != nil {
+		return fmt.Errorf("read consumer cursor: %w", err)
+	}
+	return iojson.WriteLine(c.Root().Writer, struct {
+		Status   string           `json:"status"`
+		Consumer string           `json:"consumer"`
+		Cursor   messaging.Cursor `json:"cursor"`
+	}{Status: "ok", Consumer: cmd.ackConsumer, Cursor: cursor})
+}
+
 func (cmd *MsgCmd) listCmd() *cli.Command {
 	return &cli.Command{
 		Name:      "list",
@@ -265,6 +574,71 @@ Examples:
 	}
 }
 
+func (cmd *MsgCmd) pruneCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "prune",
+		Usage:     "Delete messages past the retention policy",
+		UsageText: "hive msg prune [--topic <pattern>] [--older-than <duration>]",
+		Description: `Deletes the messages the configured retention policy no longer keeps,
+read or not. Topics matched by a messaging.topics entry with acked_only: true
+only lose messages a session or consumer has acknowledged.
+
+Retention is configured with messaging.retention (maximum message age),
+messaging.max_per_topic (messages kept per topic), and per-topic overrides
+under messaging.topics. Long-running hive processes such as the TUI apply
+the same policy every few minutes; prune applies it immediately.
+
+--older-than additionally deletes messages older than the given duration,
+even when the policy would keep them. --topic limits pruning to topics
+matching a pattern (supports wildcards like agent.*.inbox and build.>).
+
+Output: JSON confirmation line with the number of messages removed.
+
+Examples:
+  hive msg prune                       # apply the retention policy
+  hive msg prune --older-than 7d       # also drop anything older than a week
+  hive msg prune -t "build.>" --older-than 1h`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "topic",
+				Aliases:     []string{"t"},
+				Usage:       "only prune topics matching this pattern",
+				Destination: &cmd.pruneTopic,
+			},
+			&cli.StringFlag{
+				Name:        "older-than",
+				Usage:       "also delete messages older than this (e.g., 12h, 7d)",
+				Destination: &cmd.pruneOlderThan,
+			},
+		},
+		Action: cmd.runPrune,
+	}
+}
+
+func (cmd *MsgCmd) runPrune(ctx context.Context, c *cli.Command) error {
+	var maxAge time.Duration
+	if cmd.pruneOlderThan != "" {
+		d, err := parseDuration(cmd.pruneOlderThan)
+		if err != nil {
+			return fmt.Errorf("invalid --older-than: %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("--older-than must be positive")
+		}
+		maxAge = d
+	}
+
+	removed, err := cmd.messages().ApplyRetention(ctx, cmd.pruneTopic, maxAge)
+	if err != nil {
+		return fmt.Errorf("prune messages: %w", err)
+	}
+
+	return iojson.WriteLine(c.Root().Writer, struct {
+		Status  string `json:"status"`
+		Removed int    `json:"removed"`
+	}{Status: "ok", Removed: removed})
+}
+
 func (cmd *MsgCmd) runTopic(_ context.Context, c *cli.Command) error {
 	// Determine prefix: flag override > config > default "agent"
 	prefix := cmd.app.Config.Messaging.TopicPrefix
@@ -299,6 +673,55 @@ func (cmd *MsgCmd) runPub(ctx context.Context, c *cli.Command) error {
 		return err
 	}
 
+	msg := cmd.newMessage(ctx, payload)
+	result, err := msgs.Publish(ctx, msg, topics)
+	if err != nil {
+		return fmt.Errorf("publish message: %w", err)
+	}
+	return cmd.printPubConfirmation(c, result, msg.Sender)
+}
+
+func (cmd *MsgCmd) runRequest(ctx context.Context, c *cli.Command) error {
+	msgs := cmd.messages()
+
+	timeout, err := parseDuration(cmd.requestReplyTimeout)
+	if err != nil {
+		return fmt.Errorf("invalid reply timeout: %w", err)
+	}
+
+	payload, err := cmd.resolvePayload(c)
+	if err != nil {
+		return err
+	}
+
+	_, replyTopic, err := msgs.Request(ctx, cmd.newMessage(ctx, payload), cmd.requestTopics)
+	if err != nil {
+		return fmt.Errorf("publish request: %w", err)
+	}
+
+	// The reply topic is new, so any message in it is the reply
+	return cmd.pollForMessage(ctx, c, msgs, replyTopic, messaging.Cursor{}, false, timeout)
+}
+
+func (cmd *MsgCmd) runReply(ctx context.Context, c *cli.Command) error {
+	msgs := cmd.messages()
+
+	payload, err := cmd.resolvePayload(c)
+	if err != nil {
+		return err
+	}
+
+	msg := cmd.newMessage(ctx, payload)
+	result, err := msgs.Reply(ctx, cmd.replyTo, msg)
+	if err != nil {
+		return fmt.Errorf("reply: %w", err)
+	}
+	return cmd.printPubConfirmation(c, result, msg.Sender)
+}
+
+// newMessage returns a message with payload and the envelope flags, sent by
+// the current session unless --sender overrides it.
+func (cmd *MsgCmd) newMessage(ctx context.Context, payload string) messaging.Message {
 	// Auto-detect session and set sender
 	sessionID, _ := cmd.detectSessionID(ctx) // Best-effort detection for sender
 	sender := cmd.pubSender
@@ -307,25 +730,30 @@ func (cmd *MsgCmd) runPub(ctx context.Context, c *cli.Command) error {
 	}
 
 	msg := messaging.Message{
-		Payload:   payload,
-		Sender:    sender,
-		SessionID: sessionID,
+		Payload:       payload,
+		Sender:        sender,
+		SessionID:     sessionID,
+		Type:          cmd.pubType,
+		CorrelationID: cmd.pubCorrID,
 	}
-
-	result, err := msgs.Publish(ctx, msg, topics)
-	if err != nil {
-		return fmt.Errorf("publish message: %w", err)
+	if cmd.pubJSON {
+		msg.ContentType = messaging.ContentTypeJSON
 	}
+	return msg
+}
 
-	// Print confirmation
+// printPubConfirmation prints the confirmation line for a published message.
+func (cmd *MsgCmd) printPubConfirmation(c *cli.Command, result messaging.PublishResult, sender string) error {
 	type pubConfirmation struct {
-		Status string   `json:"status"`
-		Topics []string `json:"topics"`
-		Sender string   `json:"sender,omitempty"`
+		Status string           `json:"status"`
+		Topics []string         `json:"topics"`
+		Seqs   map[string]int64 `json:"seqs"`
+		Sender string           `json:"sender,omitempty"`
 	}
 	confirmation := pubConfirmation{
 		Status: "ok",
 		Topics: result.Topics,
+		Seqs:   result.Seqs,
 		Sender: sender,
 	}
 	return iojson.WriteLine(c.Root().Writer, confirmation)
@@ -377,24 +805,84 @@ func (cmd *MsgCmd) runSub(ctx context.Context, c *cli.Command) error {
 		topic = "*"
 	}
 
-	// Wait mode: wait for a single message and exit
-	if cmd.subWait {
-		return cmd.waitForMessage(ctx, c, msgs, topic, cmd.subAck)
+	afterSeq := c.IsSet("after-seq")
+	if afterSeq && messaging.IsTopicPattern(topic) {
+		return fmt.Errorf("--after-seq requires an exact --topic; sequence numbers are per topic")
+	}
+	if cmd.subFollow && (cmd.subWait || cmd.subListen) {
+		return fmt.Errorf("--follow cannot be combined with --listen or --wait")
+	}
+	if cmd.subUnacked {
+		if cmd.subConsumer == "" {
+			return fmt.Errorf("--unacked requires --consumer")
+		}
+		if afterSeq {
+			return fmt.Errorf("--unacked cannot be combined with --after-seq")
+		}
+	}
+	if c.IsSet("count") {
+		if !cmd.subListen && !cmd.subFollow {
+			return fmt.Errorf("--count requires --listen or --follow")
+		}
+		if cmd.subCount <= 0 {
+			return fmt.Errorf("--count must be positive")
+		}
 	}
 
-	// Listen mode: poll for new messages
-	if cmd.subListen {
-		return cmd.listenForMessages(ctx, c, msgs, topic, cmd.subAck)
+	if cmd.subFollow {
+		return cmd.followMessages(ctx, c, msgs, topic, afterSeq)
+	}
+
+	if cmd.subWait || cmd.subListen {
+		var cursor messaging.Cursor
+		switch {
+		case afterSeq:
+			cursor = messaging.Cursor{topic: cmd.subAfter}
+		case cmd.subUnacked:
+			var err error
+			cursor, err = msgs.ConsumerCursor(ctx, cmd.subConsumer, topic)
+			if err != nil {
+				return fmt.Errorf("read consumer cursor: %w", err)
+			}
+		default:
+			var err error
+			cursor, err = msgs.Head(ctx, topic)
+			if err != nil {
+				return fmt.Errorf("read topic head: %w", err)
+			}
+		}
+
+		// Wait mode: wait for a single message and exit
+		if cmd.subWait {
+			return cmd.waitForMessage(ctx, c, msgs, topic, cursor, cmd.subAck)
+		}
+
+		// Listen mode: poll for new messages
+		timeout, err := time.ParseDuration(cmd.subTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid timeout: %w", err)
+		}
+		return cmd.listenForMessages(ctx, c, msgs, topic, cursor, cmd.subAck, timeout, cmd.subCount)
 	}
 
 	// Default: return messages immediately
-	messages, err := msgs.Subscribe(ctx, topic, time.Time{})
+	var messages []messaging.Message
+	var err error
+	switch {
+	case afterSeq:
+		messages, err = msgs.SubscribeAfter(ctx, topic, messaging.Cursor{topic: cmd.subAfter})
+	case cmd.subUnacked:
+		messages, err = msgs.GetUnacked(ctx, cmd.subConsumer, topic)
+	default:
+		messages, err = msgs.Subscribe(ctx, topic, time.Time{})
+	}
 	if err != nil {
 		if errors.Is(err, messaging.ErrTopicNotFound) {
 			return nil // No messages, no output
 		}
 		return fmt.Errorf("subscribe: %w", err)
 	}
+	messages = cmd.matchingType(messages)
 
 	// Apply --tail N limit if specified
 	if cmd.subTail > 0 && len(messages) > cmd.subTail {
@@ -413,46 +901,114 @@ func (cmd *MsgCmd) runSub(ctx context.Context, c *cli.Command) error {
 	return nil
 }
 
-func (cmd *MsgCmd) listenForMessages(ctx context.Context, c *cli.Command, msgs *hive.MessageService, topic string, ack bool) error {
-	timeout, err := time.ParseDuration(cmd.subTimeout)
-	if err != nil {
-		return fmt.Errorf("invalid timeout: %w", err)
+// followMessages prints the messages already stored, as the default mode
+// does, then streams new ones. The cursor is advanced past every stored
+// message, including those --tail leaves out, so no message is printed twice
+// or skipped between the two phases.
+func (cmd *MsgCmd) followMessages(ctx context.Context, c *cli.Command, msgs *hive.MessageService, topic string, afterSeq bool) error {
+	// Unlike --listen, --follow runs until interrupted unless a timeout is given
+	var timeout time.Duration
+	if c.IsSet("timeout") {
+		var err error
+		timeout, err = time.ParseDuration(cmd.subTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid timeout: %w", err)
+		}
 	}
 
-	deadline := time.Now().Add(timeout)
-	since := time.Now()
-	ticker := time.NewTicker(500 * time.Millisecond)
+	cursor := messaging.Cursor{}
+	switch {
+	case afterSeq:
+		cursor[topic] = cmd.subAfter
+	case cmd.subUnacked:
+		var err error
+		cursor, err = msgs.ConsumerCursor(ctx, cmd.subConsumer, topic)
+		if err != nil {
+			return fmt.Errorf("read consumer cursor: %w", err)
+		}
+	}
+	messages, err := msgs.SubscribeAfter(ctx, topic, cursor)
+	if err != nil && !errors.Is(err, messaging.ErrTopicNotFound) {
+		return fmt.Errorf("subscribe: %w", err)
+	}
+	cursor.Advance(messages)
+	messages = cmd.matchingType(messages)
+
+	if cmd.subTail > 0 && len(messages) > cmd.subTail {
+		messages = messages[len(messages)-cmd.subTail:]
+	}
+	if cmd.subCount > 0 && len(messages) > cmd.subCount {
+		messages = messages[:cmd.subCount]
+	}
+
+	if err := cmd.printMessages(c.Root().Writer, messages); err != nil {
+		return err
+	}
+	if cmd.subAck && len(messages) > 0 {
+		cmd.acknowledgeMessages(ctx, msgs, messages)
+	}
+
+	remaining := 0
+	if cmd.subCount > 0 {
+		remaining = cmd.subCount - len(messages)
+		if remaining == 0 {
+			return nil
+		}
+	}
+	return cmd.listenForMessages(ctx, c, msgs, topic, cursor, cmd.subAck, timeout, remaining)
+}
+
+// listenForMessages polls for messages past cursor, advancing the cursor
+// after each batch so no message is printed twice. It returns once limit
+// messages were printed, or when the timeout elapses. A zero limit or timeout
+// means no limit.
+func (cmd *MsgCmd) listenForMessages(ctx context.Context, c *cli.Command, msgs *hive.MessageService, topic string, cursor messaging.Cursor, ack bool, timeout time.Duration, limit int) error {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	ticker := time.NewTicker(cmd.pollInterval)
 	defer ticker.Stop()
 
+	printed := 0
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-ticker.C:
-			if time.Now().After(deadline) {
+			if !deadline.IsZero() && time.Now().After(deadline) {
 				return cmd.handleTimeout(c, topic, timeout)
 			}
 
-			messages, err := msgs.Subscribe(ctx, topic, since)
+			messages, err := msgs.SubscribeAfter(ctx, topic, cursor)
 			if err != nil && !errors.Is(err, messaging.ErrTopicNotFound) {
 				return fmt.Errorf("subscribe: %w", err)
 			}
+			messages = cmd.matchingType(messages)
 
 			if len(messages) > 0 {
+				if limit > 0 && len(messages) > limit-printed {
+					messages = messages[:limit-printed]
+				}
 				if err := cmd.printMessages(c.Root().Writer, messages); err != nil {
 					return err
 				}
-				since = messages[len(messages)-1].CreatedAt
+				cursor.Advance(messages)
+				printed += len(messages)
 
 				if ack {
 					cmd.acknowledgeMessages(ctx, msgs, messages)
 				}
+				if limit > 0 && printed >= limit {
+					return nil
+				}
 			}
 		}
 	}
 }
 
-func (cmd *MsgCmd) waitForMessage(ctx context.Context, c *cli.Command, msgs *hive.MessageService, topic string, ack bool) error {
+// waitForMessage blocks until a message past cursor arrives and prints it.
+func (cmd *MsgCmd) waitForMessage(ctx context.Context, c *cli.Command, msgs *hive.MessageService, topic string, cursor messaging.Cursor, ack bool) error {
 	// Use 24h default for --wait mode (essentially forever for handoff scenarios)
 	timeout := 24 * time.Hour
 	if cmd.subTimeout != "30s" { // User explicitly set a timeout
@@ -462,10 +1018,14 @@ func (cmd *MsgCmd) waitForMessage(ctx context.Context, c *cli.Command, msgs *hiv
 			return fmt.Errorf("invalid timeout: %w", err)
 		}
 	}
+	return cmd.pollForMessage(ctx, c, msgs, topic, cursor, ack, timeout)
+}
 
+// pollForMessage blocks until a message past cursor arrives and prints it,
+// giving up after timeout.
+func (cmd *MsgCmd) pollForMessage(ctx context.Context, c *cli.Command, msgs *hive.MessageService, topic string, cursor messaging.Cursor, ack bool, timeout time.Duration) error {
 	deadline := time.Now().Add(timeout)
-	since := time.Now()
-	ticker := time.NewTicker(500 * time.Millisecond)
+	ticker := time.NewTicker(cmd.pollInterval)
 	defer ticker.Stop()
 
 	for {
@@ -477,10 +1037,11 @@ func (cmd *MsgCmd) waitForMessage(ctx context.Context, c *cli.Command, msgs *hiv
 				return cmd.handleTimeout(c, topic, timeout)
 			}
 
-			messages, err := msgs.Subscribe(ctx, topic, since)
+			messages, err := msgs.SubscribeAfter(ctx, topic, cursor)
 			if err != nil && !errors.Is(err, messaging.ErrTopicNotFound) {
 				return fmt.Errorf("subscribe: %w", err)
 			}
+			messages = cmd.matchingType(messages)
 
 			if len(messages) > 0 {
 				// Return only the first message
@@ -498,6 +1059,18 @@ func (cmd *MsgCmd) waitForMessage(ctx context.Context, c *cli.Command, msgs *hiv
 	}
 }
 
+// matchingType returns the messages with the --type envelope type, or all of
+// them without --type. Skipped messages are never printed, so cursors only
+// move past them along with a later matching message.
+func (cmd *MsgCmd) matchingType(messages []messaging.Message) []messaging.Message {
+	if cmd.subType == "" {
+		return messages
+	}
+	return slices.DeleteFunc(messages, func(m messaging.Message) bool {
+		return m.Type != cmd.subType
+	})
+}
+
 // handleTimeout prints a JSON status line and returns a non-zero exit.
 func (cmd *MsgCmd) handleTimeout(c *cli.Command, topic string, duration time.Duration) error {
 	type timeoutStatus struct {
@@ -609,9 +1182,17 @@ func (cmd *MsgCmd) printMessages(w io.Writer, messages []messaging.Message) erro
 	return nil
 }
 
-// acknowledgeMessages marks messages as read by the current session.
-// Logs errors but does not fail the operation.
+// acknowledgeMessages marks messages as read by the sub --consumer, or by
+// the current session when no consumer is named. Logs errors but does not
+// fail the operation.
 func (cmd *MsgCmd) acknowledgeMessages(ctx context.Context, msgs *hive.MessageService, messages []messaging.Message) {
+	if cmd.subConsumer != "" {
+		if err := msgs.AckThrough(ctx, cmd.subConsumer, messageIDs(messages)); err != nil {
+			log.Printf("warning: failed to acknowledge %d messages for consumer %s: %v", len(messages), cmd.subConsumer, err)
+		}
+		return
+	}
+
 	sessionID, err := cmd.detectSessionID(ctx)
 	if err != nil {
 		log.Printf("warning: failed to detect session for acknowledgment: %v", err)
@@ -621,13 +1202,17 @@ func (cmd *MsgCmd) acknowledgeMessages(ctx context.Context, msgs *hive.MessageSe
 		return // Not in a session, skip acknowledgment
 	}
 
-	messageIDs := make([]string, len(messages))
-	for i, msg := range messages {
-		messageIDs[i] = msg.ID
+	if err := msgs.Acknowledge(ctx, sessionID, messageIDs(messages)); err != nil {
+		log.Printf("warning: failed to acknowledge %d messages: %v", len(messages), err)
 	}
-	if err := msgs.Acknowledge(ctx, sessionID, messageIDs); err != nil {
-		log.Printf("warning: failed to acknowledge %d messages: %v", len(messageIDs), err)
+}
+
+func messageIDs(messages []messaging.Message) []string {
+	ids := make([]string, len(messages))
+	for i, msg := range messages {
+		ids[i] = msg.ID
 	}
+	return ids
 }
 
 func (cmd *MsgCmd) inboxCmd() *cli.Command {
@@ -708,14 +1293,22 @@ func (cmd *MsgCmd) runInbox(ctx context.Context, c *cli.Command) error {
 	inboxTopic := "agent." + sessionID + ".inbox"
 
 	// Delegate to listen/wait modes if requested
-	if cmd.inboxWait {
+	if cmd.inboxWait || cmd.inboxListen {
+		cursor, err := msgs.Head(ctx, inboxTopic)
+		if err != nil {
+			return fmt.Errorf("read inbox head: %w", err)
+		}
+
 		// Override sub fields for shared logic
 		cmd.subTimeout = cmd.inboxTimeout
-		return cmd.waitForMessage(ctx, c, msgs, inboxTopic, cmd.inboxAck)
-	}
-	if cmd.inboxListen {
-		cmd.subTimeout = cmd.inboxTimeout
-		return cmd.listenForMessages(ctx, c, msgs, inboxTopic, cmd.inboxAck)
+		if cmd.inboxWait {
+			return cmd.waitForMessage(ctx, c, msgs, inboxTopic, cursor, cmd.inboxAck)
+		}
+		timeout, err := time.ParseDuration(cmd.inboxTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid timeout: %w", err)
+		}
+		return cmd.listenForMessages(ctx, c, msgs, inboxTopic, cursor, cmd.inboxAck, timeout, 0)
 	}
 
 	var messages []messaging.Message
@@ -3,11 +3,23 @@ package commands
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/eventbus/testbus"
+	"github.com/colonyops/hive/internal/core/messaging"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/internal/data/stores"
 	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/pkg/executil/executiltest"
+	"github.com/colonyops/hive/pkg/tmpl"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v3"
@@ -172,3 +184,318 @@ func TestRunTopic_Uniqueness(t *testing.T) {
 	// Should have generated unique IDs (with 36^4 = 1.6M combinations, duplicates in 10 tries would be very rare)
 	assert.GreaterOrEqual(t, len(seen), 9, "generated only %d unique topic IDs in 10 attempts, expected near 10", len(seen))
 }
+
+func newMsgTestCmd(t *testing.T) (*MsgCmd, *hive.MessageService) {
+	t.Helper()
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+
+	cfg := &config.Config{}
+	msgs := hive.NewMessageService(stores.NewMessageStore(database, 0), cfg, testbus.New(t).EventBus)
+	return newMsgTestCmdFor(t, msgs)
+}
+
+// newMsgTestCmdFor returns a fresh command, with no flags set, reading msgs.
+// It runs outside any hive session, so no sender is detected.
+func newMsgTestCmdFor(t *testing.T, msgs *hive.MessageService) (*MsgCmd, *hive.MessageService) {
+	t.Helper()
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+
+	cfg := &config.Config{}
+	sessions := hive.NewSessionService(stores.NewSessionStore(database), nil, cfg, testbus.New(t).EventBus,
+		&executiltest.Exec{}, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard)
+	cmd := NewMsgCmd(&Flags{}, &hive.App{Config: cfg, Messages: msgs, Sessions: sessions})
+	cmd.pollInterval = 5 * time.Millisecond
+	return cmd, msgs
+}
+
+// runMsgSub runs hive msg sub and returns the payloads printed, the exit code,
+// and the raw output.
+func runMsgSub(t *testing.T, cmd *MsgCmd, args ...string) ([]string, int, string) {
+	t.Helper()
+	return runMsg(t, cmd, append([]string{"sub"}, args...)...)
+}
+
+// runMsg runs a hive msg subcommand that prints messages and returns the
+// payloads printed, the exit code, and the raw output.
+func runMsg(t *testing.T, cmd *MsgCmd, args ...string) ([]string, int, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	exitCode := 0
+	app := &cli.Command{
+		Name:   "hive",
+		Writer: &buf,
+		ExitErrHandler: func(_ context.Context, _ *cli.Command, err error) {
+			var coder cli.ExitCoder
+			if errors.As(err, &coder) {
+				exitCode = coder.ExitCode()
+			}
+		},
+	}
+	cmd.Register(app)
+
+	err := app.Run(context.Background(), append([]string{"hive", "msg"}, args...))
+	if exitCode == 0 {
+		require.NoError(t, err)
+	}
+
+	var payloads []string
+	for line := range strings.Lines(buf.String()) {
+		var msg messaging.Message
+		require.NoError(t, json.Unmarshal([]byte(line), &msg))
+		if msg.Payload != "" {
+			payloads = append(payloads, msg.Payload)
+		}
+	}
+	return payloads, exitCode, buf.String()
+}
+
+func publishMsg(t *testing.T, msgs *hive.MessageService, topic, payload string) {
+	t.Helper()
+	_, err := msgs.Publish(context.Background(), messaging.Message{Payload: payload}, []string{topic})
+	require.NoError(t, err)
+}
+
+func TestRunSub_FollowStreamsNewMessages(t *testing.T) {
+	cmd, msgs := newMsgTestCmd(t)
+	publishMsg(t, msgs, "handoff", "one")
+	publishMsg(t, msgs, "handoff", "two")
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		for _, topic := range []string{"other", "handoff"} {
+			_, err := msgs.Publish(context.Background(), messaging.Message{Payload: "three"}, []string{topic})
+			assert.NoError(t, err)
+		}
+	}()
+
+	payloads, code, _ := runMsgSub(t, cmd, "-t", "handoff", "--tail", "1", "--follow", "--count", "2", "--timeout", "5s")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, []string{"two", "three"}, payloads, "the tail of the topic, then the new message")
+}
+
+func TestRunSub_FollowCountFromBacklog(t *testing.T) {
+	cmd, msgs := newMsgTestCmd(t)
+	publishMsg(t, msgs, "handoff", "one")
+	publishMsg(t, msgs, "handoff", "two")
+
+	payloads, code, _ := runMsgSub(t, cmd, "-t", "handoff", "--follow", "--count", "1")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, []string{"one"}, payloads)
+}
+
+func TestRunSub_FollowTimeout(t *testing.T) {
+	cmd, msgs := newMsgTestCmd(t)
+	publishMsg(t, msgs, "handoff", "one")
+
+	payloads, code, out := runMsgSub(t, cmd, "-t", "handoff", "--follow", "--timeout", "30ms")
+	assert.Equal(t, 1, code)
+	assert.Equal(t, []string{"one"}, payloads)
+	assert.Contains(t, out, `"status":"timeout"`)
+}
+
+func TestRunSub_FollowFlagValidation(t *testing.T) {
+	cmd, _ := newMsgTestCmd(t)
+	app := &cli.Command{Name: "hive", Writer: &bytes.Buffer{}}
+	cmd.Register(app)
+
+	for _, args := range [][]string{
+		{"--follow", "--listen"},
+		{"--follow", "--wait"},
+		{"--count", "2"},
+		{"--follow", "--count", "0"},
+	} {
+		err := app.Run(context.Background(), append([]string{"hive", "msg", "sub"}, args...))
+		assert.Error(t, err, "args %v", args)
+	}
+}
+
+// runMsgAck runs hive msg ack and returns the cursor it printed.
+func runMsgAck(t *testing.T, cmd *MsgCmd, args ...string) messaging.Cursor {
+	t.Helper()
+	var buf bytes.Buffer
+	app := &cli.Command{Name: "hive", Writer: &buf}
+	cmd.Register(app)
+
+	require.NoError(t, app.Run(context.Background(), append([]string{"hive", "msg", "ack"}, args...)))
+
+	var out struct {
+		Status string           `json:"status"`
+		Cursor messaging.Cursor `json:"cursor"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, "ok", out.Status)
+	return out.Cursor
+}
+
+func TestRunSub_ConsumerUnacked(t *testing.T) {
+	cmd, msgs := newMsgTestCmd(t)
+	publishMsg(t, msgs, "announce", "one")
+	publishMsg(t, msgs, "announce", "two")
+
+	payloads, _, _ := runMsgSub(t, cmd, "-t", "announce", "--consumer", "alice", "--unacked", "--ack")
+	assert.Equal(t, []string{"one", "two"}, payloads)
+
+	publishMsg(t, msgs, "announce", "three")
+
+	cmd, _ = newMsgTestCmdFor(t, msgs)
+	payloads, _, _ = runMsgSub(t, cmd, "-t", "announce", "--consumer", "alice", "--unacked")
+	assert.Equal(t, []string{"three"}, payloads, "alice already acknowledged the first two")
+
+	cmd, _ = newMsgTestCmdFor(t, msgs)
+	payloads, _, _ = runMsgSub(t, cmd, "-t", "announce", "--consumer", "bob", "--unacked")
+	assert.Equal(t, []string{"one", "two", "three"}, payloads, "bob tracks his own progress")
+
+	cmd, _ = newMsgTestCmdFor(t, msgs)
+	payloads, code, _ := runMsgSub(t, cmd, "-t", "announce", "--consumer", "alice", "--unacked", "--wait", "--timeout", "1s")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, []string{"three"}, payloads, "--wait starts from the consumer cursor")
+}
+
+func TestRunAck(t *testing.T) {
+	cmd, msgs := newMsgTestCmd(t)
+	publishMsg(t, msgs, "announce", "one")
+	publishMsg(t, msgs, "announce", "two")
+	publishMsg(t, msgs, "announce", "three")
+	publishMsg(t, msgs, "other", "four")
+
+	cursor := runMsgAck(t, cmd, "--consumer", "alice", "-t", "announce", "--seq", "2")
+	assert.Equal(t, messaging.Cursor{"announce": 2}, cursor)
+
+	messages, err := msgs.Subscribe(context.Background(), "other", time.Time{})
+	require.NoError(t, err)
+	cmd, _ = newMsgTestCmdFor(t, msgs)
+	cursor = runMsgAck(t, cmd, "--consumer", "alice", messages[0].ID)
+	assert.Equal(t, messaging.Cursor{"announce": 2, "other": 1}, cursor)
+
+	cmd, _ = newMsgTestCmdFor(t, msgs)
+	cursor = runMsgAck(t, cmd, "--consumer", "alice", "-t", ">")
+	assert.Equal(t, messaging.Cursor{"announce": 3, "other": 1}, cursor)
+}
+
+func TestRunAck_FlagValidation(t *testing.T) {
+	cmd, _ := newMsgTestCmd(t)
+	app := &cli.Command{Name: "hive", Writer: &bytes.Buffer{}}
+	cmd.Register(app)
+
+	for _, args := range [][]string{
+		{"-t", "announce"},
+		{"--consumer", "alice"},
+		{"--consumer", "alice", "-t", "announce", "abc123"},
+		{"--consumer", "alice", "-t", "agent.*.inbox", "--seq", "2"},
+	} {
+		err := app.Run(context.Background(), append([]string{"hive", "msg", "ack"}, args...))
+		assert.Error(t, err, "args %v", args)
+	}
+
+	err := app.Run(context.Background(), []string{"hive", "msg", "sub", "--unacked"})
+	assert.Error(t, err, "--unacked requires --consumer")
+}
+
+func TestRunRequest_ReceivesReply(t *testing.T) {
+	cmd, msgs := newMsgTestCmd(t)
+
+	go func() {
+		ctx := context.Background()
+		for range 200 {
+			time.Sleep(5 * time.Millisecond)
+			requests, err := msgs.Subscribe(ctx, "questions", time.Time{})
+			if err != nil || len(requests) == 0 {
+				continue
+			}
+			assert.NotEmpty(t, requests[0].ReplyTo)
+
+			replyCmd, _ := newMsgTestCmdFor(t, msgs)
+			app := &cli.Command{Name: "hive", Writer: &bytes.Buffer{}}
+			replyCmd.Register(app)
+			assert.NoError(t, app.Run(ctx, []string{"hive", "msg", "reply", "--to", requests[0].ID, "-m", "8080"}))
+			return
+		}
+	}()
+
+	payloads, code, out := runMsg(t, cmd, "request", "-t", "questions", "--reply-timeout", "5s", "-m", "which port?")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, []string{"8080"}, payloads)
+	assert.Contains(t, out, `"topic":"reply.`)
+	assert.Contains(t, out, `"correlation_id":`, "replies are correlated with their request")
+}
+
+func TestRunRequest_Timeout(t *testing.T) {
+	cmd, _ := newMsgTestCmd(t)
+
+	payloads, code, out := runMsg(t, cmd, "request", "-t", "questions", "--reply-timeout", "30ms", "-m", "anyone?")
+	assert.Equal(t, 1, code)
+	assert.Empty(t, payloads)
+	assert.Contains(t, out, `"status":"timeout"`)
+}
+
+func TestRunReply_RequiresRequest(t *testing.T) {
+	cmd, msgs := newMsgTestCmd(t)
+	publishMsg(t, msgs, "announce", "hello")
+	published, err := msgs.Subscribe(context.Background(), "announce", time.Time{})
+	require.NoError(t, err)
+	require.Len(t, published, 1)
+
+	app := &cli.Command{Name: "hive", Writer: &bytes.Buffer{}}
+	cmd.Register(app)
+
+	err = app.Run(context.Background(), []string{"hive", "msg", "reply", "--to", published[0].ID, "-m", "hi"})
+	require.ErrorIs(t, err, messaging.ErrNoReplyTopic)
+
+	err = app.Run(context.Background(), []string{"hive", "msg", "reply", "--to", "missing", "-m", "hi"})
+	require.ErrorIs(t, err, messaging.ErrMessageNotFound)
+}
+
+func TestRunPub_Envelope(t *testing.T) {
+	cmd, msgs := newMsgTestCmd(t)
+	app := &cli.Command{Name: "hive", Writer: &bytes.Buffer{}}
+	cmd.Register(app)
+
+	err := app.Run(context.Background(), []string{"hive", "msg", "pub", "-t", "handoffs", "--type", "handoff", "--json", "--correlation-id", "task-42", "-m", `{"branch":"main"}`})
+	require.NoError(t, err)
+
+	err = app.Run(context.Background(), []string{"hive", "msg", "pub", "-t", "handoffs", "--json", "-m", "not json"})
+	require.ErrorIs(t, err, messaging.ErrInvalidEnvelope)
+
+	published, err := msgs.Subscribe(context.Background(), "handoffs", time.Time{})
+	require.NoError(t, err)
+	require.Len(t, published, 1, "invalid payloads are rejected")
+	assert.Equal(t, "handoff", published[0].Type)
+	assert.Equal(t, "task-42", published[0].CorrelationID)
+	assert.Equal(t, messaging.ContentTypeJSON, published[0].ContentType)
+}
+
+func TestRunSub_FilterByType(t *testing.T) {
+	cmd, msgs := newMsgTestCmd(t)
+	for _, msg := range []messaging.Message{
+		{Payload: "one", Type: "handoff"},
+		{Payload: "chatter"},
+		{Payload: "two", Type: "handoff"},
+		{Payload: "status", Type: "status"},
+	} {
+		_, err := msgs.Publish(context.Background(), msg, []string{"agent.a.inbox"})
+		require.NoError(t, err)
+	}
+
+	payloads, _, _ := runMsgSub(t, cmd, "-t", "agent.*.inbox", "--type", "handoff")
+	assert.Equal(t, []string{"one", "two"}, payloads)
+
+	cmd, _ = newMsgTestCmdFor(t, msgs)
+	payloads, _, _ = runMsgSub(t, cmd, "-t", "agent.a.inbox", "--type", "handoff", "--tail", "1")
+	assert.Equal(t, []string{"two"}, payloads, "--tail applies to matching messages")
+
+	cmd, _ = newMsgTestCmdFor(t, msgs)
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		for _, msg := range []messaging.Message{{Payload: "noise"}, {Payload: "three", Type: "handoff"}} {
+			_, err := msgs.Publish(context.Background(), msg, []string{"agent.a.inbox"})
+			assert.NoError(t, err)
+		}
+	}()
+	payloads, code, _ := runMsgSub(t, cmd, "-t", "agent.a.inbox", "--type", "handoff", "--wait", "--timeout", "5s")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, []string{"three"}, payloads)
+}
@@ -5,10 +5,16 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 
+	"github.com/colonyops/hive/internal/core/git"
 	"github.com/colonyops/hive/internal/core/session"
 	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/internal/hive/plugins/github"
+	"github.com/colonyops/hive/internal/sources"
+	"github.com/colonyops/hive/pkg/iojson"
+	"github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v3"
 )
 
@@ -21,6 +27,10 @@ type createSessionFlags struct {
 	cloneStrategy string
 	agent         string
 	tags          []string
+
+	// Set by callers rather than flags.
+	prompt   string            // passed to batch_spawn; selects batch_spawn when set
+	metadata map[string]string // stored on the session
 }
 
 // sessionCreateFlags returns the flag set shared by 'hive new' and
@@ -75,23 +85,22 @@ func createSessionFromFlags(ctx context.Context, app *hive.App, name string, f *
 		}
 	}
 
-	source := f.source
-	if source == "" {
-		var err error
-		source, err = os.Getwd()
-		if err != nil {
-			return nil, fmt.Errorf("determine source directory: %w", err)
-		}
+	source, err := f.sourceDir()
+	if err != nil {
+		return nil, err
 	}
 
 	sess, err := app.Sessions.CreateSession(ctx, hive.CreateOptions{
 		Name:          name,
 		Remote:        f.remote,
 		Source:        source,
+		Prompt:        f.prompt,
+		UseBatchSpawn: f.prompt != "",
 		Background:    f.background,
 		CloneStrategy: f.cloneStrategy,
 		AgentKey:      f.agent,
 		Tags:          f.tags,
+		Metadata:      f.metadata,
 		Progress:      progress,
 	})
 	if err != nil {
@@ -100,10 +109,24 @@ func createSessionFromFlags(ctx context.Context, app *hive.App, name string, f *
 	return sess, nil
 }
 
+// sourceDir returns --source, defaulting to the current directory.
+func (f *createSessionFlags) sourceDir() (string, error) {
+	if f.source != "" {
+		return f.source, nil
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("determine source directory: %w", err)
+	}
+	return dir, nil
+}
+
 type NewCmd struct {
 	flags       *Flags
 	app         *hive.App
 	createFlags createSessionFlags
+	output      string
+	fromIssue   string
 }
 
 // NewNewCmd creates a new new command
@@ -116,7 +139,7 @@ func (cmd *NewCmd) Register(app *cli.Command) *cli.Command {
 	app.Commands = append(app.Commands, &cli.Command{
 		Name:      "new",
 		Usage:     "Create a new agent session",
-		UsageText: "hive new <name...>",
+		UsageText: "hive new <name...>\n   hive new --issue <number|url> [name...]",
 		Description: `Creates a new isolated git environment for an AI agent session.
 
 If a recyclable session exists for the same remote, it will be reused
@@ -125,11 +148,39 @@ If a recyclable session exists for the same remote, it will be reused
 After setup, any matching hooks are executed and the configured spawn
 command launches a terminal with the AI tool.
 
+With --output json, the created session is printed as JSON, including the
+tmux session, agent window index, agent pane ID, and every window's pane IDs
+when the session was spawned with windows. Combine with --background to get
+the result without attaching.
+
+With --issue (or --from-issue), the GitHub issue is read with gh and
+rendered with the sources.issues.templates used by the TUI issue picker:
+the session is named after the issue unless a name is given, gets the
+template's tags, and is spawned with batch_spawn (like hive batch) with
+the rendered prompt. The issue is written to references/issue-<number>.md
+in the repository's context directory, and its number and URL are stored
+in the session's metadata. A bare issue number is resolved against
+--remote, or the repository in the source directory.
+
 Example:
   hive new Fix Auth Bug
   hive new --agent claude Refactor Utils
-  hive new bugfix --source /some/path`,
-		Flags:  sessionCreateFlags(&cmd.createFlags),
+  hive new bugfix --source /some/path
+  hive new --background --output json Fix Auth Bug
+  hive new --issue 123
+  hive new --issue https://github.com/org/repo/issues/123`,
+		Flags: append(sessionCreateFlags(&cmd.createFlags), &cli.StringFlag{
+			Name:        "output",
+			Aliases:     []string{"o"},
+			Usage:       "output format: text or json",
+			Value:       "text",
+			Destination: &cmd.output,
+		}, &cli.StringFlag{
+			Name:        "from-issue",
+			Aliases:     []string{"issue"},
+			Usage:       "create the session from a GitHub issue number or URL",
+			Destination: &cmd.fromIssue,
+		}),
 		Action: cmd.run,
 	})
 
@@ -137,11 +188,32 @@ Example:
 }
 
 func (cmd *NewCmd) run(ctx context.Context, c *cli.Command) error {
-	args := c.Args().Slice()
-	if len(args) == 0 {
+	if cmd.output != "text" && cmd.output != "json" {
+		return fmt.Errorf("invalid --output %q: must be text or json", cmd.output)
+	}
+
+	name := strings.Join(c.Args().Slice(), " ")
+	if cmd.fromIssue != "" {
+		issueName, err := cmd.applyIssue(ctx)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			name = issueName
+		}
+	}
+	if name == "" {
 		return fmt.Errorf("session name required\n\nUsage: hive new <name...>\n\nExample: hive new Fix Auth Bug")
 	}
-	name := strings.Join(args, " ")
+
+	if cmd.output == "json" {
+		// Keep stdout clean for JSON; progress goes to stderr.
+		sess, err := createSessionFromFlags(ctx, cmd.app, name, &cmd.createFlags, os.Stderr)
+		if err != nil {
+			return err
+		}
+		return iojson.WriteLine(c.Root().Writer, buildSessionJSON(*sess))
+	}
 
 	sess, err := createSessionFromFlags(ctx, cmd.app, name, &cmd.createFlags, nil)
 	if err != nil {
@@ -151,3 +223,59 @@ func (cmd *NewCmd) run(ctx context.Context, c *cli.Command) error {
 	fmt.Fprintf(os.Stderr, "Session created\n  %s\n", sess.Path)
 	return nil
 }
+
+// applyIssue fetches the --from-issue issue, writes it to the repository's
+// context directory, and sets the session's prompt, tags, and metadata from
+// it with the issues source templates. It returns the session name rendered
+// from the issue.
+func (cmd *NewCmd) applyIssue(ctx context.Context) (string, error) {
+	dir, err := cmd.createFlags.sourceDir()
+	if err != nil {
+		return "", err
+	}
+
+	var repo string
+	if cmd.createFlags.remote != "" {
+		if owner, name := git.ExtractOwnerRepo(cmd.createFlags.remote); owner != "" {
+			repo = owner + "/" + name
+		}
+	}
+
+	issue, err := github.FetchIssue(ctx, cmd.app.Exec, dir, repo, cmd.fromIssue)
+	if err != nil {
+		return "", err
+	}
+
+	templates := cmd.app.Config.Sources.Issues.Templates
+	item, detail := issue.SourceItem()
+	rendered, err := sources.RenderSessionTemplates(sources.TemplateConfig{
+		Name:   templates.Name,
+		Prompt: templates.Prompt,
+		Tags:   templates.Tags,
+	}, item, detail)
+	if err != nil {
+		return "", fmt.Errorf("render sources.issues.templates: %w", err)
+	}
+
+	if owner, name := issue.Repo(); owner != "" {
+		path, err := hive.WriteIssueContext(cmd.app.Config.RepoContextDir(owner, name), hive.IssueContext{
+			Number: item.ID,
+			Title:  issue.Title,
+			URL:    issue.URL,
+			Body:   issue.Body,
+		})
+		if err != nil {
+			log.Warn().Err(err).Int("issue", issue.Number).Msg("failed to write issue to context directory")
+		} else {
+			fmt.Fprintf(os.Stderr, "Issue written to %s\n", path)
+		}
+	}
+
+	cmd.createFlags.prompt = rendered.Prompt
+	cmd.createFlags.tags = append(cmd.createFlags.tags, rendered.Tags...)
+	cmd.createFlags.metadata = map[string]string{
+		session.MetaIssueNumber: strconv.Itoa(issue.Number),
+		session.MetaIssueURL:    issue.URL,
+	}
+	return rendered.Name, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/colonyops/hive/internal/hive"
 	"github.com/urfave/cli/v3"
@@ -32,7 +33,9 @@ max_recycled config) and deletes the rest.
 
 Use --all to delete ALL recycled sessions regardless of the limit.
 
-Active sessions are not affected.`,
+Active sessions are not affected, except that when due.archive_after is set,
+sessions overdue by more than that are archived unless they have uncommitted
+or unpushed work.`,
 		Action: cmd.run,
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
@@ -47,6 +50,14 @@ Active sessions are not affected.`,
 }
 
 func (cmd *PruneCmd) run(ctx context.Context, c *cli.Command) error {
+	archived, err := cmd.app.Sessions.ArchiveOverdue(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("archive overdue sessions: %w", err)
+	}
+	if len(archived) > 0 {
+		fmt.Fprintf(os.Stderr, "Archived %d overdue session(s)\n", len(archived))
+	}
+
 	all := c.Bool("all")
 	count, err := cmd.app.Sessions.Prune(ctx, all)
 	if err != nil {
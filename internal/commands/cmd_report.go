@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/data/stores"
+	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/internal/hive/plugins/github"
+	"github.com/colonyops/hive/internal/hive/report"
+	"github.com/urfave/cli/v3"
+)
+
+type ReportCmd struct {
+	flags *Flags
+	app   *hive.App
+
+	format string
+	noPR   bool
+
+	now func() time.Time // overridden in tests
+}
+
+// NewReportCmd creates a new report command
+func NewReportCmd(flags *Flags, app *hive.App) *ReportCmd {
+	return &ReportCmd{flags: flags, app: app, now: time.Now}
+}
+
+// Register adds the report command to the application
+func (cmd *ReportCmd) Register(app *cli.Command) *cli.Command {
+	app.Commands = append(app.Commands, &cli.Command{
+		Name:      "report",
+		Usage:     "Write a status report of active sessions",
+		UsageText: "hive report [--format markdown|html] [--no-pr]",
+		Description: `Writes a snapshot of the active sessions, grouped by repository, for pasting
+into a standup note or sending on. Each session lists its agent status,
+branch, pull request with its checks, and the number of open reviews of its
+documents.
+
+Agent statuses come from the last poll of a running hive TUI, as with hive
+status. Pull requests are looked up with gh, one call per session; --no-pr
+skips them.
+
+Examples:
+  hive report > standup.md
+  hive report --format html > status.html
+  hive report --no-pr | pbcopy`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "output format (markdown, html)",
+				Value:       "markdown",
+				Destination: &cmd.format,
+			},
+			&cli.BoolFlag{
+				Name:        "no-pr",
+				Usage:       "do not look up pull requests",
+				Destination: &cmd.noPR,
+			},
+		},
+		Action: cmd.run,
+	})
+
+	return app
+}
+
+func (cmd *ReportCmd) run(ctx context.Context, c *cli.Command) error {
+	if cmd.format != "markdown" && cmd.format != "html" {
+		return fmt.Errorf("unknown format %q (expected markdown or html)", cmd.format)
+	}
+
+	r, err := report.Build(ctx, cmd.sources(), cmd.now())
+	if err != nil {
+		return fmt.Errorf("build report: %w", err)
+	}
+
+	if cmd.format == "html" {
+		return r.HTML(c.Root().Writer)
+	}
+	return r.Markdown(c.Root().Writer)
+}
+
+// sources reads the report from the app's services.
+func (cmd *ReportCmd) sources() report.Sources {
+	src := report.Sources{
+		Sessions: cmd.app.Sessions.ListSessions,
+		Statuses: func(ctx context.Context) (*terminal.StatusSnapshot, error) {
+			return terminal.LoadStatusSnapshot(ctx, cmd.app.KV)
+		},
+		Git: cmd.app.Sessions.VCS,
+	}
+
+	if cmd.app.DB != nil {
+		reviews := stores.NewReviewStore(cmd.app.DB)
+		src.Reviews = func(ctx context.Context, sessions []session.Session) (map[string]int, error) {
+			pending, err := reviews.GetAllActiveSessionsWithCounts(ctx)
+			if err != nil {
+				return nil, err
+			}
+			commentCounts := make(map[string]int, len(pending))
+			for path, info := range pending {
+				commentCounts[path] = info.CommentCount
+			}
+			return hive.SessionReviewCounts(cmd.app.Config, sessions, commentCounts), nil
+		}
+	}
+
+	if !cmd.noPR && github.New(cmd.app.Config.Plugins.GitHub, nil).Available() {
+		exec := cmd.app.SessionExec()
+		src.PullRequest = func(ctx context.Context, sess *session.Session) (github.PullRequestStatus, error) {
+			// ParseRemote only recognizes Azure DevOps and Bitbucket remotes,
+			// which gh cannot query.
+			if _, ok := git.ParseRemote(sess.Remote); ok {
+				return github.PullRequestStatus{}, fmt.Errorf("not a GitHub remote")
+			}
+			return github.ViewPullRequest(ctx, exec, sess.Path)
+		}
+	}
+	return src
+}
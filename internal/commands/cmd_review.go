@@ -5,11 +5,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
+	corereview "github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/internal/data/stores"
 	"github.com/colonyops/hive/internal/hive"
 	"github.com/colonyops/hive/internal/tui"
 	review "github.com/colonyops/hive/internal/tui/views/review"
+	"github.com/colonyops/hive/pkg/iojson"
+	"github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v3"
 )
 
@@ -18,11 +25,27 @@ type ReviewCmd struct {
 	app    *hive.App
 	file   string
 	latest bool
+
+	// export flags
+	exportDoc  string
+	exportJSON bool
+
+	// search flags
+	searchLimit int
+	searchJSON  bool
+
+	// dispatch flags
+	dispatchFile    string
+	dispatchSession string
+	dispatchWait    bool
+	dispatchTimeout time.Duration
+
+	pollInterval time.Duration // how often dispatch --wait checks for the acknowledgment
 }
 
 // NewReviewCmd creates a new review command.
 func NewReviewCmd(flags *Flags, app *hive.App) *ReviewCmd {
-	return &ReviewCmd{flags: flags, app: app}
+	return &ReviewCmd{flags: flags, app: app, pollInterval: 500 * time.Millisecond}
 }
 
 // Register adds the review command to the application.
@@ -43,7 +66,10 @@ Examples:
   hive review --latest               # Open latest document (requires context dir)
   hive review -f plans/my.md         # Open file relative to context dir
   hive review -f ./notes.md          # Open file relative to current directory
-  hive review -f /tmp/notes.md       # Open file with absolute path`,
+  hive review -f /tmp/notes.md       # Open file with absolute path
+  hive review export --json          # Dump pending reviews as JSON lines
+  hive review dispatch -f plans/my.md -s abc123 --wait  # Send feedback to an agent
+  hive review search "error handling"  # Find past feedback`,
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:        "file",
@@ -58,11 +84,232 @@ Examples:
 			},
 		},
 		Action: cmd.run,
+		Commands: []*cli.Command{
+			cmd.exportCmd(),
+			cmd.dispatchCmd(),
+			cmd.searchCmd(),
+		},
 	})
 
 	return app
 }
 
+func (cmd *ReviewCmd) exportCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "export",
+		Usage:     "Export pending review sessions and their comments",
+		UsageText: "hive review export [--doc PATH] [--json]",
+		Description: `Dumps all active (non-finalized) review sessions and their comments to stdout.
+
+Use this to poll for pending human feedback without opening the TUI, or to
+archive review artifacts in CI.
+
+By default, prints the same plain-text feedback format that finalizing a
+review produces. Sessions without comments are omitted.
+
+With --json, outputs one JSON object per session (JSON Lines format),
+including sessions without comments.
+Fields: id, document_path, content_hash, created_at, comment_count, comments.
+
+Examples:
+  hive review export
+  hive review export --json
+  hive review export --doc plans/my.md --json`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "doc",
+				Aliases:     []string{"d"},
+				Usage:       "only export the session for this document (absolute or relative to cwd)",
+				Destination: &cmd.exportDoc,
+			},
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "output as JSON lines",
+				Destination: &cmd.exportJSON,
+			},
+		},
+		Action: cmd.runExport,
+	}
+}
+
+// reviewExport is the JSON shape of an exported review session.
+type reviewExport struct {
+	ID           string                `json:"id"`
+	DocumentPath string                `json:"document_path"`
+	ContentHash  string                `json:"content_hash"`
+	CreatedAt    time.Time             `json:"created_at"`
+	Documents    []string              `json:"documents"`
+	CommentCount int                   `json:"comment_count"`
+	ReviewTime   int64                 `json:"review_seconds"` // focused time spent reviewing
+	Comments     []reviewCommentExport `json:"comments"`
+}
+
+// reviewCommentExport is the JSON shape of an exported review comment.
+type reviewCommentExport struct {
+	ID           string    `json:"id"`
+	DocumentPath string    `json:"document_path"`
+	StartLine    int       `json:"start_line"`
+	EndLine      int       `json:"end_line"`
+	StartCol     int       `json:"start_col,omitempty"`
+	EndCol       int       `json:"end_col,omitempty"`
+	Outdated     bool      `json:"outdated,omitempty"`
+	Severity     string    `json:"severity"`
+	ContextText  string    `json:"context_text"`
+	CommentText  string    `json:"comment_text"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (cmd *ReviewCmd) runExport(ctx context.Context, c *cli.Command) error {
+	var docPath string
+	if cmd.exportDoc != "" {
+		var err error
+		docPath, err = resolveFilePath(cmd.exportDoc)
+		if err != nil {
+			return err
+		}
+	}
+
+	store := stores.NewReviewStore(cmd.app.DB)
+	active, err := store.GetAllActiveSessionsWithCounts(ctx)
+	if err != nil {
+		return fmt.Errorf("list review sessions: %w", err)
+	}
+
+	paths := make([]string, 0, len(active))
+	for path := range active {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var template, reviewer string
+	if !cmd.exportJSON {
+		// Feedback templates follow the rules for the repository in the
+		// current directory; outside one only review.feedback_template applies.
+		remote, err := cmd.app.Sessions.DetectRemote(ctx, ".")
+		if err != nil {
+			log.Debug().Err(err).Msg("no remote detected; using the global feedback template")
+		}
+		template = cmd.app.Config.GetFeedbackTemplate(remote)
+		reviewer = cmd.app.Config.Review.ReviewerName()
+	}
+
+	w := c.Root().Writer
+	printed := 0
+	for _, path := range paths {
+		info := active[path]
+
+		docs, err := store.ListDocuments(ctx, info.Session.ID)
+		if err != nil {
+			return fmt.Errorf("list documents for %s: %w", path, err)
+		}
+		if docPath != "" && !slices.ContainsFunc(docs, func(d corereview.Document) bool { return d.DocumentPath == docPath }) {
+			continue
+		}
+
+		comments, err := store.ListComments(ctx, info.Session.ID)
+		if err != nil {
+			return fmt.Errorf("list comments for %s: %w", path, err)
+		}
+
+		if cmd.exportJSON {
+			if err := iojson.WriteLine(w, toReviewExport(info.Session, docs, comments)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		feedback, err := review.RenderReviewFeedback(toReviewViewSession(info.Session, docs, comments), path, template, reviewer, cmd.app.Config.Review.IncludeTiming)
+		if err != nil {
+			return err
+		}
+		if feedback == "" {
+			continue
+		}
+		if printed > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return fmt.Errorf("failed to write output: %w", err)
+			}
+		}
+		if _, err := fmt.Fprint(w, feedback); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+		printed++
+	}
+
+	return nil
+}
+
+// toReviewExport converts a stored session, its documents and comments to the JSON export shape.
+func toReviewExport(sess corereview.Session, docs []corereview.Document, comments []corereview.Comment) reviewExport {
+	out := reviewExport{
+		ID:           sess.ID,
+		DocumentPath: sess.DocumentPath,
+		ContentHash:  sess.ContentHash,
+		CreatedAt:    sess.CreatedAt.UTC(),
+		Documents:    make([]string, 0, len(docs)),
+		CommentCount: len(comments),
+		ReviewTime:   int64(sess.ReviewTime.Seconds()),
+		Comments:     make([]reviewCommentExport, 0, len(comments)),
+	}
+	for _, d := range docs {
+		out.Documents = append(out.Documents, d.DocumentPath)
+	}
+	for _, c := range comments {
+		out.Comments = append(out.Comments, toReviewCommentExport(c))
+	}
+	return out
+}
+
+// toReviewCommentExport converts a stored comment to the JSON export shape.
+func toReviewCommentExport(c corereview.Comment) reviewCommentExport {
+	return reviewCommentExport{
+		ID:           c.ID,
+		DocumentPath: c.DocumentPath,
+		StartLine:    c.StartLine,
+		EndLine:      c.EndLine,
+		StartCol:     c.StartCol,
+		EndCol:       c.EndCol,
+		Outdated:     c.Outdated,
+		Severity:     c.Severity.Label(),
+		ContextText:  c.ContextText,
+		CommentText:  c.CommentText,
+		CreatedAt:    c.CreatedAt.UTC(),
+	}
+}
+
+// toReviewViewSession converts a stored session to the review view's session
+// type so the export can reuse the finalization feedback format.
+func toReviewViewSession(sess corereview.Session, docs []corereview.Document, comments []corereview.Comment) *review.Session {
+	out := &review.Session{
+		ID:         sess.ID,
+		DocPath:    sess.DocumentPath,
+		CreatedAt:  sess.CreatedAt,
+		ReviewTime: sess.ReviewTime,
+		Documents:  make([]review.SessionDocument, 0, len(docs)),
+		Comments:   make([]review.Comment, 0, len(comments)),
+	}
+	for _, d := range docs {
+		out.Documents = append(out.Documents, review.SessionDocument{Path: d.DocumentPath, RelPath: d.DocumentPath})
+	}
+	for _, c := range comments {
+		out.Comments = append(out.Comments, review.Comment{
+			ID:          c.ID,
+			SessionID:   c.SessionID,
+			DocPath:     c.DocumentPath,
+			StartLine:   c.StartLine,
+			EndLine:     c.EndLine,
+			StartCol:    c.StartCol,
+			EndCol:      c.EndCol,
+			Outdated:    c.Outdated,
+			Severity:    c.Severity,
+			ContextText: c.ContextText,
+			CommentText: c.CommentText,
+			CreatedAt:   c.CreatedAt,
+		})
+	}
+	return out
+}
+
 func (cmd *ReviewCmd) run(ctx context.Context, c *cli.Command) error {
 	// If --file is specified, load directly without context directory requirement
 	if cmd.file != "" {
@@ -75,21 +322,11 @@ func (cmd *ReviewCmd) run(ctx context.Context, c *cli.Command) error {
 
 // runWithDirectFile loads a specific file directly without context directory requirements.
 func (cmd *ReviewCmd) runWithDirectFile(ctx context.Context) error {
-	// Resolve file path (absolute or relative to cwd)
-	var targetPath string
-	if filepath.IsAbs(cmd.file) {
-		targetPath = cmd.file
-	} else {
-		cwd, err := os.Getwd()
-		if err != nil {
-			return fmt.Errorf("failed to get current directory: %w", err)
-		}
-		targetPath = filepath.Join(cwd, cmd.file)
+	targetPath, err := resolveFilePath(cmd.file)
+	if err != nil {
+		return err
 	}
 
-	// Clean the path
-	targetPath = filepath.Clean(targetPath)
-
 	// Verify file exists
 	info, err := os.Stat(targetPath)
 	if err != nil {
@@ -113,7 +350,20 @@ func (cmd *ReviewCmd) runWithDirectFile(ctx context.Context) error {
 	// Launch review TUI with single document
 	// contextDir is the parent directory of the file
 	contextDir := filepath.Dir(targetPath)
-	return cmd.launchReviewTUI(ctx, []review.Document{doc}, &doc, contextDir)
+	return cmd.launchReviewTUI(ctx, []review.Document{doc}, nil, &doc, contextDir)
+}
+
+// resolveFilePath resolves a path given on the command line to a clean
+// absolute path (absolute or relative to cwd).
+func resolveFilePath(path string) (string, error) {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path), nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current directory: %w", err)
+	}
+	return filepath.Join(cwd, path), nil
 }
 
 // runWithContextDir uses context directory discovery for picker/latest modes.
@@ -137,7 +387,9 @@ func (cmd *ReviewCmd) runWithContextDir(ctx context.Context, c *cli.Command) err
 		return fmt.Errorf("failed to discover documents: %w", err)
 	}
 
-	if len(documents) == 0 {
+	vaultDocs := review.DiscoverVaultDocuments(cmd.app.Config.Context.Vaults)
+
+	if len(documents) == 0 && len(vaultDocs) == 0 {
 		if _, err := fmt.Fprintf(c.Root().Writer, "No documents found in %s\n", contextDir); err != nil {
 			return fmt.Errorf("failed to write output: %w", err)
 		}
@@ -151,7 +403,7 @@ func (cmd *ReviewCmd) runWithContextDir(ctx context.Context, c *cli.Command) err
 	var initialDoc *review.Document
 	if cmd.latest {
 		// Use latest document
-		controller := review.NewPickerController(documents)
+		controller := review.NewPickerController(slices.Concat(documents, vaultDocs))
 		initialDoc = controller.GetLatest()
 		if initialDoc == nil {
 			return fmt.Errorf("no documents found")
@@ -159,18 +411,29 @@ func (cmd *ReviewCmd) runWithContextDir(ctx context.Context, c *cli.Command) err
 	}
 
 	// Launch review TUI
-	return cmd.launchReviewTUI(ctx, documents, initialDoc, contextDir)
+	return cmd.launchReviewTUI(ctx, documents, vaultDocs, initialDoc, contextDir)
 }
 
 // launchReviewTUI starts the review-only TUI with the given documents.
-func (cmd *ReviewCmd) launchReviewTUI(ctx context.Context, documents []review.Document, initialDoc *review.Document, contextDir string) error {
+func (cmd *ReviewCmd) launchReviewTUI(ctx context.Context, documents, vaultDocs []review.Document, initialDoc *review.Document, contextDir string) error {
 	// Create review-only options
 	opts := tui.ReviewOnlyOptions{
-		Documents:   documents,
-		InitialDoc:  initialDoc,
-		ContextDir:  contextDir,
-		DB:          cmd.app.DB,
-		CopyCommand: cmd.app.Config.CopyCommand,
+		Documents:    documents,
+		VaultDocs:    vaultDocs,
+		InitialDoc:   initialDoc,
+		ContextDir:   contextDir,
+		DB:           cmd.app.DB,
+		CopyCommand:  cmd.app.Config.CopyCommand,
+		SaveFeedback: cmd.app.Config.Review.SaveFeedback,
+		Hooks:        cmd.app.Config.Review.FinalizeHooks,
+		Timing:       cmd.app.Config.Review.IncludeTiming,
+		Renderer:     cmd.app.Config.Review.Renderer,
+		GitPath:      cmd.app.Config.GitPath,
+		Exec:         cmd.app.Exec,
+	}
+	if cmd.app.Messages != nil {
+		opts.Events = cmd.app.Messages
+		opts.Instant = cmd.detectInstantTarget(ctx)
 	}
 
 	// Create review-only model
@@ -180,9 +443,15 @@ func (cmd *ReviewCmd) launchReviewTUI(ctx context.Context, documents []review.Do
 	p := tea.NewProgram(m)
 
 	// Run program
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if err != nil {
 		return fmt.Errorf("run review TUI: %w", err)
 	}
+	if fm, ok := final.(tui.ReviewOnlyModel); ok {
+		for _, err := range fm.Errors() {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
 
 	return nil
 }
@@ -205,3 +474,18 @@ func (cmd *ReviewCmd) resolveContextDir(ctx context.Context) (string, error) {
 
 	return contextDir, nil
 }
+
+// detectInstantTarget returns the session the command runs in as the
+// recipient for instant-mode comments. Detection failures leave instant mode
+// without a recipient rather than failing the review.
+func (cmd *ReviewCmd) detectInstantTarget(ctx context.Context) review.InstantTarget {
+	sessionID, err := cmd.app.Sessions.DetectSession(ctx)
+	if err != nil || sessionID == "" {
+		return review.InstantTarget{}
+	}
+	sess, err := cmd.app.Sessions.GetSession(ctx, sessionID)
+	if err != nil {
+		return review.InstantTarget{}
+	}
+	return review.InstantTarget{ID: sess.ID, Name: sess.Name, Topic: sess.InboxTopic()}
+}
@@ -0,0 +1,294 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/eventbus"
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/messaging"
+	corereview "github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/data/stores"
+	review "github.com/colonyops/hive/internal/tui/views/review"
+	"github.com/colonyops/hive/pkg/iojson"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	// reviewDispatchSender identifies dispatched feedback in the recipient's
+	// inbox, matching the sender of instant-mode comments.
+	reviewDispatchSender = "hive-review"
+
+	// reviewDispatchType is the envelope type of dispatched feedback.
+	reviewDispatchType = "review-feedback"
+)
+
+func (cmd *ReviewCmd) dispatchCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "dispatch",
+		Usage:     "Send a document's review feedback to a session's agent",
+		UsageText: "hive review dispatch --file <path> --session <id> [--wait [--timeout 30m]]",
+		Description: `Sends the feedback of a document's latest review to the inbox of a session's
+agent and marks the review as dispatched.
+
+An active review is finalized first, as in the review view: the feedback is
+saved when review.save_feedback is on, review.finalize_hooks run, and a
+review.finalized event is published. When the latest review is already
+finalized, its feedback is sent again. Reviews without comments have no
+feedback and cannot be dispatched. The feedback uses the feedback template
+of the rules matching the target session's repository.
+
+The feedback is sent as a request, so the agent acknowledges it with
+"hive msg reply --to <message-id>". With --wait, dispatch blocks until the
+acknowledgment arrives and prints it; if none arrives within --timeout, a
+timeout status line is printed and the command exits with status 1.
+
+Output: a JSON line with the review ID, the target session, and the reply
+topic the acknowledgment is published to, then the acknowledgment with --wait.
+
+Examples:
+  hive review dispatch -f plans/auth.md -s 26kj0c
+  hive review dispatch -f plans/auth.md -s auth-refactor --wait --timeout 1h`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "file",
+				Aliases:     []string{"f"},
+				Usage:       "reviewed document (absolute or relative to cwd)",
+				Required:    true,
+				Destination: &cmd.dispatchFile,
+			},
+			&cli.StringFlag{
+				Name:        "session",
+				Aliases:     []string{"s"},
+				Usage:       "session ID, name, or slug to send the feedback to",
+				Required:    true,
+				Destination: &cmd.dispatchSession,
+			},
+			&cli.BoolFlag{
+				Name:        "wait",
+				Usage:       "wait for the agent to acknowledge the feedback",
+				Destination: &cmd.dispatchWait,
+			},
+			&cli.DurationFlag{
+				Name:        "timeout",
+				Usage:       "with --wait, give up after this long (0 waits forever)",
+				Value:       30 * time.Minute,
+				Destination: &cmd.dispatchTimeout,
+			},
+		},
+		Action: cmd.runDispatch,
+	}
+}
+
+// reviewDispatch is the JSON line printed when feedback is dispatched.
+type reviewDispatch struct {
+	Status       string `json:"status"`
+	ReviewID     string `json:"review_id"`
+	DocumentPath string `json:"document_path"`
+	Finalized    bool   `json:"finalized"` // the review was finalized by this dispatch
+	SessionID    string `json:"session_id"`
+	Topic        string `json:"topic"`
+	ReplyTo      string `json:"reply_to"`
+}
+
+func (cmd *ReviewCmd) runDispatch(ctx context.Context, c *cli.Command) error {
+	if cmd.app.Messages == nil {
+		return fmt.Errorf("messaging is not available")
+	}
+
+	docPath, err := resolveFilePath(cmd.dispatchFile)
+	if err != nil {
+		return err
+	}
+	target, err := resolveSessionRef(ctx, cmd.app, cmd.dispatchSession)
+	if err != nil {
+		return err
+	}
+	// resolveSessionRef returns sessions of any state by ID, for hive wait
+	// to report them gone; feedback only goes to a running agent.
+	if target.State != session.StateActive {
+		return fmt.Errorf("session %s is %s, not active", cmd.dispatchSession, target.State)
+	}
+
+	store := stores.NewReviewStore(cmd.app.DB)
+	sess, err := store.GetActiveSessionForDocument(ctx, docPath)
+	if errors.Is(err, corereview.ErrSessionNotFound) {
+		sess, err = store.GetSession(ctx, docPath)
+	}
+	if errors.Is(err, corereview.ErrSessionNotFound) {
+		return fmt.Errorf("no review found for %s", docPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	docs, err := store.ListDocuments(ctx, sess.ID)
+	if err != nil {
+		return fmt.Errorf("list documents: %w", err)
+	}
+	comments, err := store.ListComments(ctx, sess.ID)
+	if err != nil {
+		return fmt.Errorf("list comments: %w", err)
+	}
+
+	template := cmd.app.Config.GetFeedbackTemplate(target.Remote)
+	reviewer := cmd.app.Config.Review.ReviewerName()
+	feedback, err := review.RenderReviewFeedback(toReviewViewSession(sess, docs, comments), sess.DocumentPath, template, reviewer, cmd.app.Config.Review.IncludeTiming)
+	if err != nil {
+		return err
+	}
+	if feedback == "" {
+		return fmt.Errorf("review of %s has no comments to dispatch", docPath)
+	}
+
+	finalize := sess.FinalizedAt == nil
+	if finalize {
+		if err := cmd.finalizeForDispatch(ctx, c, store, sess, target, feedback); err != nil {
+			return err
+		}
+	}
+
+	msg := messaging.Message{
+		Payload:       feedback,
+		Sender:        reviewDispatchSender,
+		Type:          reviewDispatchType,
+		ContentType:   messaging.ContentTypeMarkdown,
+		CorrelationID: sess.ID,
+	}
+	topic := target.InboxTopic()
+	_, replyTo, err := cmd.app.Messages.Request(ctx, msg, []string{topic})
+	if err != nil {
+		return fmt.Errorf("send feedback: %w", err)
+	}
+	if err := store.MarkDispatched(ctx, sess.ID, target.ID, replyTo); err != nil {
+		return err
+	}
+
+	if err := iojson.WriteLine(c.Root().Writer, reviewDispatch{
+		Status:       "dispatched",
+		ReviewID:     sess.ID,
+		DocumentPath: sess.DocumentPath,
+		Finalized:    finalize,
+		SessionID:    target.ID,
+		Topic:        topic,
+		ReplyTo:      replyTo,
+	}); err != nil {
+		return err
+	}
+	if !cmd.dispatchWait {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Waiting for %s to acknowledge the feedback...\n", target.Name)
+	return cmd.waitForAck(ctx, c, replyTo)
+}
+
+// finalizeForDispatch finalizes a review as the review view does: it saves
+// the feedback when review.save_feedback is on, runs review.finalize_hooks,
+// and publishes review.finalized. Save and hook failures are reported as
+// warnings, since the review is already finalized.
+func (cmd *ReviewCmd) finalizeForDispatch(ctx context.Context, c *cli.Command, store *stores.ReviewStore, sess corereview.Session, target *session.Session, feedback string) error {
+	if err := store.FinalizeSession(ctx, sess.ID); err != nil {
+		return err
+	}
+
+	contextDir := cmd.documentContextDir(sess.DocumentPath, target.Remote)
+	docRel := sess.DocumentPath
+	if contextDir != "" {
+		if rel, err := filepath.Rel(contextDir, sess.DocumentPath); err == nil {
+			docRel = rel
+		}
+	}
+	data := config.FinalizeHookTemplateData{
+		DocPath:    sess.DocumentPath,
+		DocRel:     docRel,
+		Verdict:    string(corereview.VerdictComment),
+		ReviewID:   sess.ID,
+		SessionID:  target.ID,
+		Reviewer:   cmd.app.Config.Review.ReviewerName(),
+		ContextDir: contextDir,
+	}
+
+	if cmd.app.Config.Review.SaveFeedback && contextDir != "" {
+		saved, err := review.SaveFeedback(contextDir, docRel, feedback, time.Now())
+		if err != nil {
+			fmt.Fprintf(c.Root().ErrWriter, "Warning: failed to save feedback: %v\n", err)
+		}
+		data.SavedPath = saved
+	}
+	if hooks := cmd.app.Config.Review.FinalizeHooks; len(hooks) > 0 {
+		if err := review.RunFinalizeHooks(ctx, cmd.app.Exec, hooks, data, feedback); err != nil {
+			fmt.Fprintf(c.Root().ErrWriter, "Warning: review finalize hooks failed: %v\n", err)
+		}
+	}
+
+	if cmd.app.Bus != nil {
+		cmd.app.Bus.PublishReviewFinalized(eventbus.ReviewFinalizedPayload{
+			DocumentPath: sess.DocumentPath,
+			DocumentRel:  docRel,
+			Feedback:     feedback,
+		})
+	}
+	return nil
+}
+
+// documentContextDir returns the context directory holding docPath: the one
+// of the repository of remote, or the shared one. It returns "" when the
+// document is in neither.
+func (cmd *ReviewCmd) documentContextDir(docPath, remote string) string {
+	var dirs []string
+	if owner, repo := git.ExtractOwnerRepo(remote); owner != "" && repo != "" {
+		dirs = append(dirs, cmd.app.Config.RepoContextDir(owner, repo))
+	}
+	dirs = append(dirs, cmd.app.Config.SharedContextDir())
+	for _, dir := range dirs {
+		if rel, err := filepath.Rel(dir, docPath); err == nil && !strings.HasPrefix(rel, "..") {
+			return dir
+		}
+	}
+	return ""
+}
+
+// waitForAck blocks until a message arrives on the reply topic and prints it,
+// giving up after --timeout.
+func (cmd *ReviewCmd) waitForAck(ctx context.Context, c *cli.Command, replyTo string) error {
+	var deadline <-chan time.Time
+	if cmd.dispatchTimeout > 0 {
+		timer := time.NewTimer(cmd.dispatchTimeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	ticker := time.NewTicker(cmd.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// The reply topic is new, so any message in it is the acknowledgment
+		messages, err := cmd.app.Messages.SubscribeAfter(ctx, replyTo, messaging.Cursor{})
+		if err != nil && !errors.Is(err, messaging.ErrTopicNotFound) {
+			return fmt.Errorf("subscribe: %w", err)
+		}
+		if len(messages) > 0 {
+			return iojson.WriteLine(c.Root().Writer, messages[0])
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			_ = iojson.WriteLine(c.Root().Writer, struct {
+				Status   string `json:"status"`
+				Topic    string `json:"topic"`
+				Duration string `json:"duration"`
+			}{Status: "timeout", Topic: replyTo, Duration: cmd.dispatchTimeout.String()})
+			return cli.Exit("", 1)
+		case <-ticker.C:
+		}
+	}
+}
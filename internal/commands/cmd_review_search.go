@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corereview "github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/internal/data/stores"
+	"github.com/colonyops/hive/pkg/iojson"
+	"github.com/urfave/cli/v3"
+)
+
+func (cmd *ReviewCmd) searchCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "search",
+		Usage:     "Search the comments of finalized reviews",
+		UsageText: `hive review search <query> [--limit 20] [--json]`,
+		Description: `Finds comments from finalized reviews whose text or quoted document context
+contains every word of the query, so earlier guidance can be found and reused.
+Matching ignores case and punctuation. Words match whole words, except the
+last, which also matches as a prefix: "error hand" finds "error handling".
+
+Results are listed newest review first, with the document, lines, date, and
+severity of each comment. Active reviews are not searched.
+
+With --json, outputs one JSON object per comment (JSON Lines format).
+Fields: review_id, finalized_at, and the comment fields of hive review export.
+
+Examples:
+  hive review search "error handling"
+  hive review search retry --limit 5 --json`,
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:        "limit",
+				Aliases:     []string{"n"},
+				Usage:       "maximum number of comments to show",
+				Value:       20,
+				Destination: &cmd.searchLimit,
+			},
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "output as JSON lines",
+				Destination: &cmd.searchJSON,
+			},
+		},
+		Action: cmd.runSearch,
+	}
+}
+
+// reviewSearchResult is the JSON shape of a comment found by review search.
+type reviewSearchResult struct {
+	ReviewID    string    `json:"review_id"`
+	FinalizedAt time.Time `json:"finalized_at"`
+	reviewCommentExport
+}
+
+func (cmd *ReviewCmd) runSearch(ctx context.Context, c *cli.Command) error {
+	query := strings.Join(c.Args().Slice(), " ")
+	if strings.TrimSpace(query) == "" {
+		return fmt.Errorf("search query required")
+	}
+	if cmd.searchLimit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+
+	results, err := stores.NewReviewStore(cmd.app.DB).SearchComments(ctx, query, cmd.searchLimit)
+	if err != nil {
+		return err
+	}
+
+	w := c.Root().Writer
+	if cmd.searchJSON {
+		for _, r := range results {
+			if err := iojson.WriteLine(w, reviewSearchResult{
+				ReviewID:            r.SessionID,
+				FinalizedAt:         r.FinalizedAt.UTC(),
+				reviewCommentExport: toReviewCommentExport(r.Comment),
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(results) == 0 {
+		_, _ = fmt.Fprintln(w, "No matching feedback.")
+		return nil
+	}
+	for i, r := range results {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		_, _ = fmt.Fprint(w, formatSearchResult(r))
+	}
+	return nil
+}
+
+// formatSearchResult renders a found comment as a location and date header,
+// the first line of the quoted context, and the comment text.
+func formatSearchResult(r corereview.SearchResult) string {
+	var b strings.Builder
+	lines := fmt.Sprintf("%d", r.StartLine)
+	if r.EndLine > r.StartLine {
+		lines = fmt.Sprintf("%d-%d", r.StartLine, r.EndLine)
+	}
+	fmt.Fprintf(&b, "%s:%s  %s  %s\n", r.DocumentPath, lines, r.FinalizedAt.Local().Format(time.DateOnly), r.Severity.Label())
+
+	if quoted := strings.TrimSpace(r.ContextText); quoted != "" {
+		first, rest, more := strings.Cut(quoted, "\n")
+		if more && strings.TrimSpace(rest) != "" {
+			first += " …"
+		}
+		fmt.Fprintf(&b, "  > %s\n", first)
+	}
+	for line := range strings.Lines(strings.TrimSpace(r.CommentText)) {
+		fmt.Fprintf(&b, "  %s", line)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,373 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/eventbus"
+	"github.com/colonyops/hive/internal/core/eventbus/testbus"
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/messaging"
+	corereview "github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/internal/data/stores"
+	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/pkg/executil"
+	"github.com/colonyops/hive/pkg/executil/executiltest"
+	"github.com/colonyops/hive/pkg/tmpl"
+)
+
+// seedReviews creates two active review sessions (one with comments) and one
+// finalized session, returning the database.
+func seedReviews(t *testing.T) *db.DB {
+	t.Helper()
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+
+	ctx := context.Background()
+	store := stores.NewReviewStore(database)
+
+	plan, err := store.CreateSession(ctx, "/ctx/plans/plan.md", "hash-plan")
+	require.NoError(t, err)
+	require.NoError(t, store.SaveComment(ctx, corereview.Comment{
+		ID: "c1", SessionID: plan.ID, StartLine: 3, EndLine: 4,
+		ContextText: "step one\nstep two", CommentText: "split these", CreatedAt: time.Now(),
+	}))
+
+	_, err = store.CreateSession(ctx, "/ctx/research/notes.md", "hash-notes")
+	require.NoError(t, err)
+
+	done, err := store.CreateSession(ctx, "/ctx/plans/done.md", "hash-done")
+	require.NoError(t, err)
+	require.NoError(t, store.SaveComment(ctx, corereview.Comment{
+		ID: "c2", SessionID: done.ID, StartLine: 1, EndLine: 1, CommentText: "old", CreatedAt: time.Now(),
+	}))
+	require.NoError(t, store.FinalizeSession(ctx, done.ID))
+
+	return database
+}
+
+func runReviewExport(t *testing.T, database *db.DB, args ...string) string {
+	t.Helper()
+	return runReviewExportWithConfig(t, database, &config.Config{}, args...)
+}
+
+// runReviewExportWithConfig runs review export from a repository whose origin
+// is https://github.com/acme/api.
+func runReviewExportWithConfig(t *testing.T, database *db.DB, cfg *config.Config, args ...string) string {
+	t.Helper()
+	exec := &executiltest.Exec{Responses: []executiltest.Response{{Out: []byte("https://github.com/acme/api\n")}}}
+	sessions := hive.NewSessionService(nil, git.NewExecutor("git", exec), cfg, nil, exec, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard)
+
+	var buf bytes.Buffer
+	app := &cli.Command{Name: "hive", Writer: &buf}
+	NewReviewCmd(&Flags{}, &hive.App{DB: database, Config: cfg, Sessions: sessions}).Register(app)
+
+	require.NoError(t, app.Run(context.Background(), append([]string{"hive", "review", "export"}, args...)))
+	return buf.String()
+}
+
+func TestReviewExport_JSON(t *testing.T) {
+	database := seedReviews(t)
+
+	out := runReviewExport(t, database, "--json")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2, "finalized sessions are excluded")
+
+	var first, second reviewExport
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+
+	assert.Equal(t, "/ctx/plans/plan.md", first.DocumentPath)
+	assert.Equal(t, 1, first.CommentCount)
+	require.Len(t, first.Comments, 1)
+	assert.Equal(t, "split these", first.Comments[0].CommentText)
+	assert.Equal(t, 3, first.Comments[0].StartLine)
+
+	assert.Equal(t, "/ctx/research/notes.md", second.DocumentPath)
+	assert.Equal(t, 0, second.CommentCount)
+	assert.Empty(t, second.Comments)
+}
+
+func TestReviewExport_DocFilter(t *testing.T) {
+	database := seedReviews(t)
+
+	out := runReviewExport(t, database, "--json", "--doc", "/ctx/research/notes.md")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 1)
+
+	var got reviewExport
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &got))
+	assert.Equal(t, "/ctx/research/notes.md", got.DocumentPath)
+}
+
+func TestReviewExport_Text(t *testing.T) {
+	database := seedReviews(t)
+
+	out := runReviewExport(t, database)
+	assert.Contains(t, out, "Document: /ctx/plans/plan.md")
+	assert.Contains(t, out, "Lines 3-4:")
+	assert.Contains(t, out, "> step one")
+	assert.Contains(t, out, "split these")
+	assert.NotContains(t, out, "notes.md", "sessions without comments are omitted")
+	assert.NotContains(t, out, "done.md", "finalized sessions are omitted")
+}
+
+func TestReviewExport_FeedbackTemplate(t *testing.T) {
+	database := seedReviews(t)
+	cfg := &config.Config{
+		Review: config.ReviewConfig{
+			FeedbackTemplate: "global",
+			Reviewer:         "sam",
+		},
+		Rules: []config.Rule{
+			{Pattern: ".*/other/.*", FeedbackTemplate: "other"},
+			{Pattern: ".*/acme/.*", FeedbackTemplate: "{{ .Reviewer }} left {{ .Counts.Comments }} on {{ .DocPath }}\n{{ range .Comments }}- {{ .Anchor }}: {{ .Text }}\n{{ end }}"},
+		},
+	}
+
+	out := runReviewExportWithConfig(t, database, cfg)
+	assert.Equal(t, "sam left 1 on /ctx/plans/plan.md\n- Lines 3-4: split these\n", out)
+
+	cfg.Rules = cfg.Rules[:1]
+	assert.Equal(t, "global", runReviewExportWithConfig(t, database, cfg), "review.feedback_template applies when no rule sets one")
+}
+
+func TestReviewExport_AttachedDocuments(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+
+	ctx := context.Background()
+	store := stores.NewReviewStore(database)
+
+	plan, err := store.CreateSession(ctx, "/ctx/plans/plan.md", "hash-plan")
+	require.NoError(t, err)
+	require.NoError(t, store.AddDocument(ctx, plan.ID, "/ctx/research/notes.md", "hash-notes"))
+	require.NoError(t, store.SaveComment(ctx, corereview.Comment{
+		ID: "c1", SessionID: plan.ID, DocumentPath: "/ctx/plans/plan.md", StartLine: 1, EndLine: 1,
+		CommentText: "split this", CreatedAt: time.Now(),
+	}))
+	require.NoError(t, store.SaveComment(ctx, corereview.Comment{
+		ID: "c2", SessionID: plan.ID, DocumentPath: "/ctx/research/notes.md", StartLine: 2, EndLine: 2,
+		CommentText: "cite this", CreatedAt: time.Now(),
+	}))
+
+	out := runReviewExport(t, database, "--json", "--doc", "/ctx/research/notes.md")
+	var got reviewExport
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(out)), &got), "filtering by an attached document finds the session")
+	assert.Equal(t, "/ctx/plans/plan.md", got.DocumentPath)
+	assert.Equal(t, []string{"/ctx/plans/plan.md", "/ctx/research/notes.md"}, got.Documents)
+	require.Len(t, got.Comments, 2)
+	assert.Equal(t, "suggestion", got.Comments[0].Severity, "comments saved without a severity export as suggestions")
+
+	text := runReviewExport(t, database)
+	assert.Contains(t, text, "Documents: 2\nComments: 2 (2 suggestions)\n")
+	assert.Contains(t, text, "Document: /ctx/plans/plan.md\nComments: 1 (1 suggestion)\n")
+	assert.Contains(t, text, "Document: /ctx/research/notes.md\nComments: 1 (1 suggestion)\n")
+}
+
+// newReviewDispatchCmd returns a review command for database with messaging
+// and an active hive session s1 named auth to dispatch to.
+func newReviewDispatchCmd(t *testing.T, database *db.DB) (*ReviewCmd, *hive.MessageService) {
+	t.Helper()
+	cfg := &config.Config{}
+	sessionStore := stores.NewSessionStore(database)
+	require.NoError(t, sessionStore.Save(context.Background(), session.Session{
+		ID: "s1", Name: "auth", Slug: "auth", State: session.StateActive, Path: "/src/auth",
+		CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}))
+	require.NoError(t, sessionStore.Save(context.Background(), session.Session{
+		ID: "s2", Name: "old", Slug: "old", State: session.StateRecycled, Path: "/src/old",
+		CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}))
+	bus := testbus.New(t).EventBus
+	sessions := hive.NewSessionService(sessionStore, nil, cfg, bus, &executiltest.Exec{}, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard)
+	msgs := hive.NewMessageService(stores.NewMessageStore(database, 0), cfg, bus)
+
+	cmd := NewReviewCmd(&Flags{}, &hive.App{DB: database, Config: cfg, Bus: bus, Sessions: sessions, Messages: msgs, Exec: &executil.RealExecutor{}})
+	cmd.pollInterval = 5 * time.Millisecond
+	return cmd, msgs
+}
+
+// runReviewDispatch runs hive review dispatch and returns its output and exit code.
+func runReviewDispatch(t *testing.T, cmd *ReviewCmd, args ...string) (string, int, error) {
+	t.Helper()
+	var buf bytes.Buffer
+	exitCode := 0
+	app := &cli.Command{
+		Name:   "hive",
+		Writer: &buf,
+		ExitErrHandler: func(_ context.Context, _ *cli.Command, err error) {
+			var coder cli.ExitCoder
+			if errors.As(err, &coder) {
+				exitCode = coder.ExitCode()
+			}
+		},
+	}
+	cmd.Register(app)
+
+	err := app.Run(context.Background(), append([]string{"hive", "review", "dispatch"}, args...))
+	return buf.String(), exitCode, err
+}
+
+func TestReviewDispatch(t *testing.T) {
+	database := seedReviews(t)
+	cmd, msgs := newReviewDispatchCmd(t, database)
+	ctx := context.Background()
+
+	out, _, err := runReviewDispatch(t, cmd, "-f", "/ctx/plans/plan.md", "-s", "auth")
+	require.NoError(t, err)
+
+	var got reviewDispatch
+	require.NoError(t, json.Unmarshal([]byte(out), &got))
+	assert.Equal(t, "dispatched", got.Status)
+	assert.True(t, got.Finalized)
+	assert.Equal(t, "s1", got.SessionID)
+	assert.Equal(t, "agent.s1.inbox", got.Topic)
+
+	inbox, err := msgs.Subscribe(ctx, "agent.s1.inbox", time.Time{})
+	require.NoError(t, err)
+	require.Len(t, inbox, 1)
+	assert.Contains(t, inbox[0].Payload, "split these")
+	assert.Equal(t, reviewDispatchType, inbox[0].Type)
+	assert.Equal(t, got.ReplyTo, inbox[0].ReplyTo)
+	assert.Equal(t, got.ReviewID, inbox[0].CorrelationID)
+
+	sess, err := stores.NewReviewStore(database).GetSession(ctx, "/ctx/plans/plan.md")
+	require.NoError(t, err)
+	assert.NotNil(t, sess.FinalizedAt)
+	require.NotNil(t, sess.DispatchedAt)
+	assert.Equal(t, "s1", sess.DispatchedTo)
+
+	// Dispatching again reuses the finalized review
+	out, _, err = runReviewDispatch(t, cmd, "-f", "/ctx/plans/plan.md", "-s", "s1")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(out), &got))
+	assert.False(t, got.Finalized)
+	assert.Equal(t, sess.ID, got.ReviewID)
+}
+
+func TestReviewDispatch_Errors(t *testing.T) {
+	database := seedReviews(t)
+	cmd, _ := newReviewDispatchCmd(t, database)
+
+	_, _, err := runReviewDispatch(t, cmd, "-f", "/ctx/plans/missing.md", "-s", "s1")
+	require.ErrorContains(t, err, "no review found")
+
+	_, _, err = runReviewDispatch(t, cmd, "-f", "/ctx/research/notes.md", "-s", "s1")
+	require.ErrorContains(t, err, "no comments")
+
+	_, _, err = runReviewDispatch(t, cmd, "-f", "/ctx/plans/plan.md", "-s", "nope")
+	require.ErrorContains(t, err, "session not found")
+
+	_, _, err = runReviewDispatch(t, cmd, "-f", "/ctx/plans/plan.md", "-s", "s2")
+	require.ErrorContains(t, err, "session s2 is recycled, not active")
+}
+
+func TestReviewDispatch_FinalizesLikeTheReviewView(t *testing.T) {
+	database := seedReviews(t)
+	cmd, _ := newReviewDispatchCmd(t, database)
+
+	var published []eventbus.ReviewFinalizedPayload
+	cmd.app.Bus.OnPublish(func(event eventbus.Event, payload any) {
+		if p, ok := payload.(eventbus.ReviewFinalizedPayload); ok {
+			published = append(published, p)
+		}
+	})
+	hookOut := filepath.Join(t.TempDir(), "hook.out")
+	cmd.app.Config.Review.FinalizeHooks = []string{"cat > " + hookOut}
+
+	_, _, err := runReviewDispatch(t, cmd, "-f", "/ctx/plans/plan.md", "-s", "s1")
+	require.NoError(t, err)
+
+	out, err := os.ReadFile(hookOut)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "split these", "finalize hooks get the feedback on stdin")
+	require.Len(t, published, 1)
+	assert.Equal(t, "/ctx/plans/plan.md", published[0].DocumentPath)
+	assert.Contains(t, published[0].Feedback, "split these")
+}
+
+func TestReviewDispatch_WaitForAck(t *testing.T) {
+	database := seedReviews(t)
+	cmd, msgs := newReviewDispatchCmd(t, database)
+
+	go func() {
+		ctx := context.Background()
+		for {
+			inbox, err := msgs.Subscribe(ctx, "agent.s1.inbox", time.Time{})
+			if err == nil && len(inbox) > 0 {
+				_, err = msgs.Reply(ctx, inbox[0].ID, messaging.Message{Payload: "on it"})
+				assert.NoError(t, err)
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	out, code, err := runReviewDispatch(t, cmd, "-f", "/ctx/plans/plan.md", "-s", "s1", "--wait", "--timeout", "5s")
+	require.NoError(t, err)
+	assert.Equal(t, 0, code)
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+	var ack messaging.Message
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &ack))
+	assert.Equal(t, "on it", ack.Payload)
+}
+
+func TestReviewDispatch_WaitTimeout(t *testing.T) {
+	database := seedReviews(t)
+	cmd, _ := newReviewDispatchCmd(t, database)
+
+	out, code, _ := runReviewDispatch(t, cmd, "-f", "/ctx/plans/plan.md", "-s", "s1", "--wait", "--timeout", "30ms")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, out, `"status":"timeout"`)
+}
+
+func runReviewSearch(t *testing.T, database *db.DB, args ...string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	app := &cli.Command{Name: "hive", Writer: &buf}
+	NewReviewCmd(&Flags{}, &hive.App{DB: database, Config: &config.Config{}}).Register(app)
+
+	require.NoError(t, app.Run(context.Background(), append([]string{"hive", "review", "search"}, args...)))
+	return buf.String()
+}
+
+func TestReviewSearch(t *testing.T) {
+	database := seedReviews(t)
+
+	out := runReviewSearch(t, database, "old")
+	assert.Contains(t, out, "/ctx/plans/done.md:1  ")
+	assert.Contains(t, out, "suggestion\n  old\n")
+	assert.NotContains(t, out, "split these", "active reviews are not searched")
+
+	out = runReviewSearch(t, database, "split")
+	assert.Equal(t, "No matching feedback.\n", out)
+
+	out = runReviewSearch(t, database, "--json", "ol")
+	var got reviewSearchResult
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(out)), &got))
+	assert.Equal(t, "c2", got.ID)
+	assert.NotEmpty(t, got.ReviewID)
+	assert.Equal(t, "/ctx/plans/done.md", got.DocumentPath)
+	assert.False(t, got.FinalizedAt.IsZero())
+}
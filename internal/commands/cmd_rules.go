@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/styles"
+	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/pkg/iojson"
+	"github.com/urfave/cli/v3"
+)
+
+type RulesCmd struct {
+	flags *Flags
+	app   *hive.App
+
+	testJSON bool
+}
+
+// NewRulesCmd creates a new rules command
+func NewRulesCmd(flags *Flags, app *hive.App) *RulesCmd {
+	return &RulesCmd{flags: flags, app: app}
+}
+
+// Register adds the rules command to the application
+func (cmd *RulesCmd) Register(app *cli.Command) *cli.Command {
+	app.Commands = append(app.Commands, &cli.Command{
+		Name:  "rules",
+		Usage: "Inspect repository rules",
+		Commands: []*cli.Command{
+			cmd.testCmd(),
+		},
+	})
+	return app
+}
+
+func (cmd *RulesCmd) testCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "test",
+		Usage:     "Show which rules match a remote and what they resolve to",
+		UsageText: "hive rules test [remote-url] [--json]",
+		Description: `Evaluates the configured rules against a remote URL without creating a
+session. Lists the matching rules in order and the effective agent, spawn,
+batch spawn, recycle, clone strategy, vcs, branch template and max_recycled
+values after last-match-wins merging, with the rule each value came from.
+Setup commands and copy patterns from every matching rule are listed in the
+order they run.
+
+Defaults to the origin remote of the current directory.
+
+Examples:
+  hive rules test https://github.com/acme/api
+  hive rules test git@github.com:acme/api.git --json`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "output as JSON",
+				Destination: &cmd.testJSON,
+			},
+		},
+		Action: cmd.runTest,
+	}
+}
+
+// rulesTestJSON is the JSON output format for rules test.
+type rulesTestJSON struct {
+	Remote         string               `json:"remote"`
+	Matches        []rulesTestMatchJSON `json:"matches"`
+	Agent          string               `json:"agent"`
+	Spawn          rulesTestSpawnJSON   `json:"spawn"`
+	BatchSpawn     rulesTestSpawnJSON   `json:"batch_spawn"`
+	Recycle        []string             `json:"recycle"`
+	MaxRecycled    int                  `json:"max_recycled"`
+	CloneStrategy  string               `json:"clone_strategy"`
+	VCS            string               `json:"vcs"`
+	BranchTemplate string               `json:"branch_template"`
+	BaseBranch     string               `json:"base_branch"`
+	Commands       []string             `json:"commands"`
+	Copy           []string             `json:"copy"`
+	Sources        map[string]string    `json:"sources"`
+}
+
+type rulesTestMatchJSON struct {
+	Index int         `json:"index"`
+	Rule  config.Rule `json:"rule"`
+}
+
+type rulesTestSpawnJSON struct {
+	Windows  []config.WindowConfig `json:"windows,omitempty"`
+	Commands []string              `json:"commands,omitempty"`
+}
+
+func (cmd *RulesCmd) runTest(ctx context.Context, c *cli.Command) error {
+	remote := c.Args().First()
+	if remote == "" {
+		detected, err := cmd.app.Sessions.DetectRemote(ctx, ".")
+		if err != nil {
+			return fmt.Errorf("no remote given and none detected in the current directory: %w", err)
+		}
+		remote = detected
+	}
+
+	resolved := cmd.app.Config.ResolveRules(remote)
+	w := c.Root().Writer
+
+	if cmd.testJSON {
+		out := rulesTestJSON{
+			Remote:         resolved.Remote,
+			Matches:        make([]rulesTestMatchJSON, 0, len(resolved.Matches)),
+			Agent:          resolved.Agent,
+			Spawn:          rulesTestSpawnJSON{Windows: resolved.Spawn.Windows, Commands: resolved.Spawn.Commands},
+			BatchSpawn:     rulesTestSpawnJSON{Windows: resolved.BatchSpawn.Windows, Commands: resolved.BatchSpawn.Commands},
+			Recycle:        resolved.Recycle,
+			MaxRecycled:    resolved.MaxRecycled,
+			CloneStrategy:  resolved.CloneStrategy,
+			VCS:            resolved.VCS,
+			BranchTemplate: resolved.BranchTemplate,
+			BaseBranch:     resolved.BaseBranch,
+			Commands:       resolved.Commands,
+			Copy:           resolved.Copy,
+			Sources:        make(map[string]string, len(config.ResolvedRuleFields)),
+		}
+		for _, m := range resolved.Matches {
+			out.Matches = append(out.Matches, rulesTestMatchJSON{Index: m.Index, Rule: m.Rule})
+		}
+		for _, f := range config.ResolvedRuleFields {
+			out.Sources[f] = resolved.Source(f)
+		}
+		return iojson.WriteLine(w, out)
+	}
+
+	renderResolvedRules(w, resolved, len(cmd.app.Config.Rules))
+	return nil
+}
+
+// renderResolvedRules writes a human-readable report of resolved rules.
+func renderResolvedRules(w io.Writer, r config.ResolvedRules, total int) {
+	muted := styles.TextMutedStyle
+
+	_, _ = fmt.Fprintf(w, "Remote: %s\n\n", r.Remote)
+
+	_, _ = fmt.Fprintf(w, "Matching rules (%d of %d, last match wins):\n", len(r.Matches), total)
+	if len(r.Matches) == 0 {
+		_, _ = fmt.Fprintln(w, muted.Render("  none, defaults apply"))
+	}
+	for _, m := range r.Matches {
+		_, _ = fmt.Fprintf(w, "  rules[%d]  %s\n", m.Index, config.RulePatternLabel(m.Rule.Pattern))
+	}
+
+	_, _ = fmt.Fprintln(w, "\nResolved:")
+	for _, f := range config.ResolvedRuleFields {
+		values := r.Display(f)
+		source := muted.Render("(" + r.Source(f) + ")")
+		if len(values) == 1 {
+			_, _ = fmt.Fprintf(w, "  %-16s %s %s\n", f, values[0], source)
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "  %-16s %s\n", f, source)
+		for _, v := range values {
+			_, _ = fmt.Fprintf(w, "    - %s\n", v)
+		}
+	}
+
+	for _, f := range []string{"commands", "copy"} {
+		values := r.Display(f)
+		_, _ = fmt.Fprintf(w, "  %-16s %s\n", f, muted.Render("(all matching rules)"))
+		if len(values) == 0 {
+			_, _ = fmt.Fprintln(w, muted.Render("    none"))
+		}
+		for _, v := range values {
+			_, _ = fmt.Fprintf(w, "    - %s\n", v)
+		}
+	}
+}
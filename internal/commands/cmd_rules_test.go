@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/hive"
+)
+
+func TestRulesTest(t *testing.T) {
+	cfg := &config.Config{
+		Agents: config.AgentsConfig{Default: "claude"},
+		Rules: []config.Rule{
+			{Pattern: "", Commands: []string{"hive ctx init"}},
+			{Pattern: ".*/my-org/.*", Agent: "aider"},
+		},
+	}
+	run := func(args ...string) string {
+		t.Helper()
+		var out bytes.Buffer
+		app := &cli.Command{Name: "hive", Writer: &out, ErrWriter: &out}
+		NewRulesCmd(&Flags{}, &hive.App{Config: cfg}).Register(app)
+		require.NoError(t, app.Run(context.Background(), append([]string{"hive", "rules", "test"}, args...)))
+		return out.String()
+	}
+
+	out := run("https://github.com/my-org/api")
+	assert.Contains(t, out, "Matching rules (2 of 2")
+	assert.Contains(t, out, "rules[0]  (matches all)")
+	assert.Contains(t, out, "rules[1]  .*/my-org/.*")
+	assert.Contains(t, out, "aider")
+	assert.Contains(t, out, "- hive ctx init")
+
+	var got rulesTestJSON
+	require.NoError(t, json.Unmarshal([]byte(run("--json", "https://github.com/acme/api")), &got))
+	assert.Len(t, got.Matches, 1)
+	assert.Equal(t, "claude", got.Agent)
+	assert.Equal(t, "default", got.Sources["agent"])
+	assert.Equal(t, []string{"hive ctx init"}, got.Commands)
+}
@@ -0,0 +1,345 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v3"
+
+	"github.com/colonyops/hive/internal/core/eventbus"
+	"github.com/colonyops/hive/internal/core/messaging"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/internal/hive/control"
+	"github.com/colonyops/hive/internal/hive/dashboard"
+	"github.com/colonyops/hive/internal/hive/metrics"
+)
+
+// serveSessionRefresh is how often hive serve hands the session list to the
+// plugin poller.
+const serveSessionRefresh = 30 * time.Second
+
+type ServeCmd struct {
+	flags *Flags
+	app   *hive.App
+
+	metrics string
+	control bool
+	http    string
+}
+
+// NewServeCmd creates a new serve command.
+func NewServeCmd(flags *Flags, app *hive.App) *ServeCmd {
+	return &ServeCmd{flags: flags, app: app}
+}
+
+// Register adds the serve command to the application.
+func (cmd *ServeCmd) Register(app *cli.Command) *cli.Command {
+	app.Commands = append(app.Commands, &cli.Command{
+		Name:      "serve",
+		Usage:     "Run hive as a long-running daemon",
+		UsageText: "hive serve [--metrics <addr>] [--control] [--http <addr>]",
+		Description: `Runs until interrupted, polling plugin statuses for active sessions and
+serving the enabled endpoints.
+
+--metrics (or serve.metrics in config) serves Prometheus metrics at /metrics:
+sessions by state, the recycled pool size per remote, agent statuses from
+the last TUI poll, message store size, and plugin poll and database query
+timings.
+
+--control (or serve.control in config) serves a JSON-RPC 2.0 control API on
+a Unix socket in the data directory (hive.sock), one JSON object per line.
+Methods: sessions.list, sessions.create, sessions.recycle, sessions.delete,
+messages.publish, messages.subscribe, statuses.get. The API is for editors
+and scripts; other hive commands do not use it.
+
+--http (or serve.http in config) serves a read-only dashboard: an HTML page
+at / listing sessions, agent statuses, git summaries, and recent messages,
+backed by a JSON API (/api/sessions, /api/messages) and refreshed over
+server-sent events (/api/events). It has no authentication.
+
+Examples:
+  hive serve --metrics :9100
+  hive serve --metrics 127.0.0.1:9100
+  hive serve --control
+  hive serve --http :8080`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "metrics",
+				Usage:       "address to serve Prometheus metrics on (overrides serve.metrics)",
+				Destination: &cmd.metrics,
+			},
+			&cli.BoolFlag{
+				Name:        "control",
+				Usage:       "serve the JSON-RPC control API on the data directory's hive.sock (overrides serve.control)",
+				Destination: &cmd.control,
+			},
+			&cli.StringFlag{
+				Name:        "http",
+				Usage:       "address to serve the read-only dashboard on (overrides serve.http)",
+				Destination: &cmd.http,
+			},
+		},
+		Action: cmd.run,
+	})
+
+	return app
+}
+
+func (cmd *ServeCmd) run(ctx context.Context, c *cli.Command) error {
+	addr := cmd.metrics
+	if addr == "" {
+		addr = cmd.app.Config.Serve.Metrics
+	}
+	serveControl := cmd.control || cmd.app.Config.Serve.Control
+	httpAddr := cmd.http
+	if httpAddr == "" {
+		httpAddr = cmd.app.Config.Serve.HTTP
+	}
+	if addr == "" && !serveControl && httpAddr == "" {
+		return fmt.Errorf("nothing to serve: pass --metrics, --control, or --http, or set serve.metrics, serve.control, or serve.http")
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cmd.pollPlugins(ctx)
+	defer cmd.app.Plugins.Stop()
+
+	errc := make(chan error, 3)
+	var shutdowns []func(context.Context) error
+
+	if addr != "" {
+		shutdown, err := cmd.serveMetrics(c, addr, errc)
+		if err != nil {
+			return err
+		}
+		shutdowns = append(shutdowns, shutdown)
+	}
+
+	if httpAddr != "" {
+		dash := dashboard.New(cmd.dashboardSources())
+		cmd.app.Bus.OnPublish(func(event eventbus.Event, _ any) {
+			dash.Notify(string(event))
+		})
+		shutdown, err := listenHTTP(c, "dashboard", httpAddr, "/", dash.Handler(), errc)
+		if err != nil {
+			return err
+		}
+		shutdowns = append(shutdowns, shutdown)
+	}
+
+	if serveControl {
+		path := cmd.app.Config.ControlSocket()
+		ln, err := control.Listen(path)
+		if err != nil {
+			return fmt.Errorf("listen: %w", err)
+		}
+		defer func() { _ = os.Remove(path) }()
+		fmt.Fprintf(c.Root().ErrWriter, "Serving control API on %s\n", path)
+
+		srvCtx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			if err := control.NewServer(cmd.controlBackend()).Serve(srvCtx, ln); err != nil {
+				errc <- fmt.Errorf("serve control API: %w", err)
+			}
+		}()
+		shutdowns = append(shutdowns, func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}
+
+	var serveErr error
+	select {
+	case serveErr = <-errc:
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for _, shutdown := range shutdowns {
+		if err := shutdown(shutdownCtx); err != nil && serveErr == nil {
+			serveErr = fmt.Errorf("shutdown: %w", err)
+		}
+	}
+	return serveErr
+}
+
+// serveMetrics starts the Prometheus endpoint on addr, reporting serve
+// failures on errc, and returns a function that shuts it down.
+func (cmd *ServeCmd) serveMetrics(c *cli.Command, addr string, errc chan<- error) (func(context.Context) error, error) {
+	collector := metrics.NewCollector(cmd.metricSources())
+	cmd.app.DB.ObserveQueries(collector.DB.Observe)
+	cmd.app.Plugins.ObservePolls(collector.Plugins.Observe)
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", collector)
+	shutdown, err := listenHTTP(c, "metrics", addr, "/metrics", mux, errc)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) error {
+		cmd.app.DB.ObserveQueries(nil)
+		cmd.app.Plugins.ObservePolls(nil)
+		return shutdown(ctx)
+	}, nil
+}
+
+// listenHTTP serves handler on addr, reporting serve failures on errc, and
+// returns a function that shuts the server down. Request contexts are
+// cancelled on shutdown so long-lived streams end. name and path only appear
+// in the startup message.
+func listenHTTP(c *cli.Command, name, addr, path string, handler http.Handler, errc chan<- error) (func(context.Context) error, error) {
+	baseCtx, cancel := context.WithCancel(context.Background())
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return baseCtx },
+	}
+	srv.RegisterOnShutdown(cancel)
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("listen: %w", err)
+	}
+	fmt.Fprintf(c.Root().ErrWriter, "Serving %s on http://%s%s\n", name, ln.Addr(), path)
+
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errc <- fmt.Errorf("serve %s: %w", name, err)
+		}
+	}()
+
+	return func(ctx context.Context) error {
+		if err := srv.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}, nil
+}
+
+// dashboardSources reads dashboard data from the app's services.
+func (cmd *ServeCmd) dashboardSources() dashboard.Sources {
+	return dashboard.Sources{
+		Sessions: cmd.app.Sessions.ListSessions,
+		Statuses: cmd.metricSources().Statuses,
+		Git:      cmd.app.Sessions.VCS,
+		Messages: func(ctx context.Context, since time.Time) ([]messaging.Message, error) {
+			return cmd.app.Messages.Subscribe(ctx, "*", since)
+		},
+	}
+}
+
+// controlBackend exposes the app's services to the control API.
+func (cmd *ServeCmd) controlBackend() control.Backend {
+	statuses := cmd.metricSources().Statuses
+	return control.Backend{
+		ListSessions: cmd.app.Sessions.ListSessions,
+		CreateSession: func(ctx context.Context, p control.SessionsCreateParams) (*session.Session, error) {
+			return createSessionFromFlags(ctx, cmd.app, p.Name, &createSessionFlags{
+				remote:     p.Remote,
+				source:     p.Source,
+				background: true,
+				agent:      p.Agent,
+				tags:       p.Tags,
+				prompt:     p.Prompt,
+			}, io.Discard)
+		},
+		RecycleSession: func(ctx context.Context, id string, force bool) error {
+			if !force {
+				if err := checkSessionRisk(ctx, cmd.app, id, "recycle", "force", os.Stderr); err != nil {
+					return err
+				}
+			}
+			return cmd.app.Sessions.RecycleSession(ctx, id, io.Discard)
+		},
+		DeleteSession: func(ctx context.Context, id string, force bool) error {
+			if !force {
+				if err := checkSessionRisk(ctx, cmd.app, id, "delete", "force", os.Stderr); err != nil {
+					return err
+				}
+			}
+			return cmd.app.Sessions.DeleteSession(ctx, id)
+		},
+		Publish:        cmd.app.Messages.Publish,
+		SubscribeAfter: cmd.app.Messages.SubscribeAfter,
+		Statuses:       statuses,
+	}
+}
+
+// metricSources reads metrics from the app's stores.
+func (cmd *ServeCmd) metricSources() metrics.Sources {
+	return metrics.Sources{
+		Sessions: cmd.app.Sessions.ListSessions,
+		Statuses: func(ctx context.Context) (*terminal.StatusSnapshot, error) {
+			return terminal.LoadStatusSnapshot(ctx, cmd.app.KV)
+		},
+		Messages: cmd.app.DB.Queries().CountMessages,
+		Topics:   cmd.app.Messages.ListTopics,
+	}
+}
+
+// pollPlugins starts the plugin status poller and keeps its session list
+// current until ctx is done.
+func (cmd *ServeCmd) pollPlugins(ctx context.Context) {
+	interval := 5 * time.Second
+	if cmd.app.Config.Plugins.GitHub.ResultsCache > 0 {
+		interval = cmd.app.Config.Plugins.GitHub.ResultsCache
+	}
+
+	update := func() {
+		sessions, err := cmd.app.Sessions.ListSessions(ctx)
+		if err != nil {
+			log.Warn().Err(err).Msg("list sessions for plugin poll")
+			return
+		}
+		active := make([]*session.Session, 0, len(sessions))
+		for i := range sessions {
+			if sessions[i].State == session.StateActive {
+				active = append(active, &sessions[i])
+			}
+		}
+		cmd.app.Plugins.UpdateSessions(active)
+	}
+
+	update()
+	results := cmd.app.Plugins.StartBackgroundWorker(ctx, interval)
+	go func() {
+		ticker := time.NewTicker(serveSessionRefresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				update()
+			case _, ok := <-results:
+				// Statuses are only needed for their timings; drain them
+				// so the poller does not block.
+				if !ok {
+					results = nil
+				}
+			}
+		}
+	}()
+}
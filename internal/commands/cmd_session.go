@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -13,8 +14,14 @@ import (
 
 	"github.com/colonyops/hive/internal/core/git"
 	"github.com/colonyops/hive/internal/core/session"
+	coretmux "github.com/colonyops/hive/internal/core/tmux"
+	"github.com/colonyops/hive/internal/core/usage"
+	"github.com/colonyops/hive/internal/data/stores"
 	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/internal/hive/plugins/claude"
+	"github.com/colonyops/hive/internal/hive/plugins/github"
 	"github.com/colonyops/hive/pkg/iojson"
+	"github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v3"
 )
 
@@ -23,25 +30,64 @@ type SessionCmd struct {
 	app   *hive.App
 
 	// per-subcommand flags
-	infoJSON bool
-	lsJSON   bool
-	lsTags   []string
+	infoJSON   bool
+	lsJSON     bool
+	lsTags     []string
+	lsArchived bool
 
 	showJSON bool
 
+	creationLogJSON bool
+
 	createJSON  bool
 	createFlags createSessionFlags
 
+	compareJSON bool
+
+	cloneJSON       bool
+	cloneName       string
+	cloneBackground bool
+	cloneAgent      string
+
+	renameJSON bool
+
 	updateJSON       bool
 	updateName       string
 	updateGroup      string
 	updateClearGroup bool
 
+	tagJSON   bool
+	tagRemove bool
+	tagClear  bool
+
+	dueJSON  bool
+	dueClear bool
+
 	deleteJSON  bool
 	deleteForce bool
 
 	recycleJSON  bool
 	recycleForce bool
+
+	archiveJSON  bool
+	archiveForce bool
+
+	syncJSON bool
+
+	snapshotJSON  bool
+	snapshotsJSON bool
+
+	restoreJSON       bool
+	restoreName       string
+	restoreBackground bool
+	restoreAgent      string
+
+	prJSON   bool
+	prBase   string
+	prTitle  string
+	prBody   string
+	prDraft  bool
+	prNoPush bool
 }
 
 // NewSessionCmd creates a new session command
@@ -65,10 +111,22 @@ Use 'hive session info' to get details about the current session.`,
 				lsCommand,
 				cmd.infoCmd(),
 				cmd.showCmd(),
+				cmd.creationLogCmd(),
 				cmd.createCmd(),
+				cmd.cloneCmd(),
+				cmd.compareCmd(),
+				cmd.prCmd(),
+				cmd.renameCmd(),
 				cmd.updateCmd(),
+				cmd.tagCmd(),
+				cmd.dueCmd(),
 				cmd.deleteCmd(),
+				cmd.syncCmd(),
 				cmd.recycleCmd(),
+				cmd.archiveCmd(),
+				cmd.snapshotCmd(),
+				cmd.snapshotsCmd(),
+				cmd.restoreCmd(),
 			},
 		},
 		// Top-level alias: "hive ls" -> "hive session list"
@@ -90,10 +148,11 @@ func (cmd *SessionCmd) lsCmd() *cli.Command {
 		Name:      "list",
 		Aliases:   []string{"ls"},
 		Usage:     "List all sessions",
-		UsageText: "hive session list [--json]",
+		UsageText: "hive session list [--json] [--archived]",
 		Description: `Displays a table of all sessions with their repo, name, state, and path.
 
-Use --json for LLM-friendly output with additional fields like inbox topic and unread count.`,
+Use --json for LLM-friendly output with additional fields like inbox topic and unread count.
+Use --archived to list archived sessions with the git state recorded when they were archived.`,
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
 				Name:        "json",
@@ -106,6 +165,11 @@ Use --json for LLM-friendly output with additional fields like inbox topic and u
 				Usage:       "filter sessions by tag (repeatable, all tags must match)",
 				Destination: &cmd.lsTags,
 			},
+			&cli.BoolFlag{
+				Name:        "archived",
+				Usage:       "list archived sessions instead of live ones",
+				Destination: &cmd.lsArchived,
+			},
 		},
 		Action: cmd.runLs,
 	}
@@ -135,19 +199,54 @@ Example output (--json):
 // sessionJSON is the machine-readable representation of a session used by
 // session info/show/create/update/recycle --json output.
 type sessionJSON struct {
-	ID            string    `json:"id"`
-	Name          string    `json:"name"`
-	Slug          string    `json:"slug"`
-	Repo          string    `json:"repo"`
-	Remote        string    `json:"remote"`
-	Path          string    `json:"path"`
-	Inbox         string    `json:"inbox"`
-	State         string    `json:"state"`
-	Group         string    `json:"group,omitempty"`
-	CloneStrategy string    `json:"clone_strategy,omitempty"`
-	Tags          []string  `json:"tags"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID            string           `json:"id"`
+	Name          string           `json:"name"`
+	Slug          string           `json:"slug"`
+	Repo          string           `json:"repo"`
+	Remote        string           `json:"remote"`
+	Path          string           `json:"path"`
+	Inbox         string           `json:"inbox"`
+	State         string           `json:"state"`
+	Group         string           `json:"group,omitempty"`
+	Agent         string           `json:"agent,omitempty"`
+	CloneStrategy string           `json:"clone_strategy,omitempty"`
+	Branch        string           `json:"branch,omitempty"`
+	BaseBranch    string           `json:"base_branch,omitempty"`
+	Tags          []string         `json:"tags"`
+	Tmux          *sessionTmuxJSON `json:"tmux,omitempty"`
+	DueAt         *time.Time       `json:"due_at,omitempty"`
+	Overdue       bool             `json:"overdue,omitempty"`
+	SyncedAt      *time.Time       `json:"synced_at,omitempty"`
+	SyncConflict  string           `json:"sync_conflict,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+// sessionTmuxJSON reports the tmux targets recorded for a session. Window,
+// pane, and windows are only known for sessions hive spawned with windows.
+type sessionTmuxJSON struct {
+	Session string                  `json:"session"`
+	Window  string                  `json:"window,omitempty"`
+	Pane    string                  `json:"pane,omitempty"`
+	Windows []coretmux.WindowTarget `json:"windows,omitempty"`
+}
+
+func buildSessionTmuxJSON(s session.Session) *sessionTmuxJSON {
+	name := s.GetMeta(session.MetaTmuxSession)
+	if name == "" {
+		return nil
+	}
+	out := &sessionTmuxJSON{
+		Session: name,
+		Window:  s.GetMeta(session.MetaTmuxWindow),
+		Pane:    s.GetMeta(session.MetaTmuxPane),
+	}
+	if raw := s.GetMeta(session.MetaTmuxTargets); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &out.Windows); err != nil {
+			log.Debug().Err(err).Str("session_id", s.ID).Msg("invalid tmux targets metadata")
+		}
+	}
+	return out
 }
 
 func buildSessionJSON(s session.Session) sessionJSON {
@@ -155,7 +254,7 @@ func buildSessionJSON(s session.Session) sessionJSON {
 	if tags == nil {
 		tags = []string{}
 	}
-	return sessionJSON{
+	out := sessionJSON{
 		ID:            s.ID,
 		Name:          s.Name,
 		Slug:          s.Slug,
@@ -165,11 +264,24 @@ func buildSessionJSON(s session.Session) sessionJSON {
 		Inbox:         s.InboxTopic(),
 		State:         string(s.State),
 		Group:         s.GetMeta(session.MetaGroup),
+		Agent:         s.GetMeta(session.MetaAgentProfile),
 		CloneStrategy: s.CloneStrategy,
+		Branch:        s.Branch(),
+		BaseBranch:    s.GetMeta(session.MetaBaseBranch),
 		Tags:          tags,
+		Tmux:          buildSessionTmuxJSON(s),
+		Overdue:       s.IsOverdue(time.Now()),
+		SyncConflict:  s.SyncConflict(),
 		CreatedAt:     s.CreatedAt,
 		UpdatedAt:     s.UpdatedAt,
 	}
+	if due, ok := s.DueAt(); ok {
+		out.DueAt = &due
+	}
+	if synced, ok := s.SyncedAt(); ok {
+		out.SyncedAt = &synced
+	}
+	return out
 }
 
 func printSessionHuman(out io.Writer, sess session.Session) {
@@ -182,9 +294,34 @@ func printSessionHuman(out io.Writer, sess session.Session) {
 	if group := sess.GetMeta(session.MetaGroup); group != "" {
 		_, _ = fmt.Fprintf(out, "Group:       %s\n", group)
 	}
+	if agent := sess.GetMeta(session.MetaAgentProfile); agent != "" {
+		_, _ = fmt.Fprintf(out, "Agent:       %s\n", agent)
+	}
+	if branch := sess.Branch(); branch != "" {
+		if base := sess.GetMeta(session.MetaBaseBranch); base != "" {
+			branch += " (from " + base + ")"
+		}
+		_, _ = fmt.Fprintf(out, "Branch:      %s\n", branch)
+	}
+	if syncErr := sess.SyncConflict(); syncErr != "" {
+		syncErr, _, _ = strings.Cut(syncErr, "\n")
+		_, _ = fmt.Fprintf(out, "Sync:        failed: %s\n", syncErr)
+	} else if synced, ok := sess.SyncedAt(); ok {
+		_, _ = fmt.Fprintf(out, "Synced:      %s\n", synced.Local().Format(time.DateTime))
+	}
 	if len(sess.Tags) > 0 {
 		_, _ = fmt.Fprintf(out, "Tags:        %s\n", strings.Join(sess.Tags, ", "))
 	}
+	if sess.Notes != "" {
+		_, _ = fmt.Fprintf(out, "Notes:       %s\n", strings.ReplaceAll(sess.Notes, "\n", "\n             "))
+	}
+	if due, ok := sess.DueAt(); ok {
+		_, _ = fmt.Fprintf(out, "Due:         %s\n", formatDue(sess, due, time.Now()))
+	}
+	if sess.State == session.StateArchived {
+		summary := sess.ArchiveSummary()
+		_, _ = fmt.Fprintf(out, "Archived:    %s %s %s\n", summary.ArchivedAt.Local().Format(time.DateTime), summary.Branch, formatArchiveChanges(summary))
+	}
 }
 
 func (cmd *SessionCmd) runInfo(ctx context.Context, c *cli.Command) error {
@@ -259,6 +396,56 @@ func (cmd *SessionCmd) runShow(ctx context.Context, c *cli.Command) error {
 	return nil
 }
 
+func (cmd *SessionCmd) creationLogCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "creation-log",
+		Usage:     "Show the output of the last attempt to create a session",
+		UsageText: "hive session creation-log <id> [--json]",
+		Description: `Prints the captured output of the last attempt to create a session:
+progress lines plus rule command, hook, and spawn output.
+
+Failed creations are recorded too, under the ID shown in the TUI's error
+modal. Logs are kept for 30 days.`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "output as JSON (recommended for LLMs)",
+				Destination: &cmd.creationLogJSON,
+			},
+		},
+		Action: cmd.runCreationLog,
+	}
+}
+
+func (cmd *SessionCmd) runCreationLog(ctx context.Context, c *cli.Command) error {
+	id := c.Args().First()
+	if id == "" {
+		return fmt.Errorf("session ID required")
+	}
+
+	entry, err := cmd.app.Sessions.CreationLog(ctx, id)
+	if errors.Is(err, hive.ErrNoCreationLog) {
+		return fmt.Errorf("no creation log for session %q", id)
+	}
+	if err != nil {
+		return fmt.Errorf("get creation log: %w", err)
+	}
+
+	out := c.Root().Writer
+	if cmd.creationLogJSON {
+		return iojson.WriteLine(out, entry)
+	}
+
+	_, _ = fmt.Fprint(out, entry.Output)
+	if entry.Output != "" && !strings.HasSuffix(entry.Output, "\n") {
+		_, _ = fmt.Fprintln(out)
+	}
+	if entry.Error != "" {
+		_, _ = fmt.Fprintf(out, "Error: %s\n", entry.Error)
+	}
+	return nil
+}
+
 func (cmd *SessionCmd) createCmd() *cli.Command {
 	return &cli.Command{
 		Name:      "create",
@@ -304,6 +491,215 @@ func (cmd *SessionCmd) runCreate(ctx context.Context, c *cli.Command) error {
 	return nil
 }
 
+func (cmd *SessionCmd) cloneCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "clone",
+		Usage:     "Create a new session from an existing session's branch state",
+		UsageText: "hive session clone <id> [--name <name>] [--background] [--json]",
+		Description: `Creates a new session from the same remote as an active session and checks
+out a new branch at the source session's current HEAD. Files matched by the
+rules' copy patterns are copied from the source session's directory, and the
+source's tags are carried over.
+
+Commit the source's work first: uncommitted changes are not cloned.
+
+The name defaults to "<source name>-clone".
+
+Example:
+  hive session clone 26kj0c --name auth-variant-b --background`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "name",
+				Aliases:     []string{"n"},
+				Usage:       "name for the new session",
+				Destination: &cmd.cloneName,
+			},
+			&cli.BoolFlag{
+				Name:        "background",
+				Aliases:     []string{"bg"},
+				Usage:       "create session without attaching to tmux",
+				Destination: &cmd.cloneBackground,
+			},
+			&cli.StringFlag{
+				Name:        "agent",
+				Usage:       "agent profile to spawn (defaults to the configured default)",
+				Destination: &cmd.cloneAgent,
+			},
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "write the created session as JSON to stdout",
+				Destination: &cmd.cloneJSON,
+			},
+		},
+		Action: cmd.runClone,
+	}
+}
+
+func (cmd *SessionCmd) runClone(ctx context.Context, c *cli.Command) error {
+	id := c.Args().First()
+	if id == "" {
+		return fmt.Errorf("session ID required")
+	}
+	if cmd.cloneAgent != "" {
+		if _, ok := cmd.app.Config.Agents.Profiles[cmd.cloneAgent]; !ok {
+			return fmt.Errorf("unknown agent %q", cmd.cloneAgent)
+		}
+	}
+
+	// Keep stdout clean for --json output; progress goes to stderr.
+	sess, err := cmd.app.Sessions.CloneSession(ctx, id, hive.CreateOptions{
+		Name:       cmd.cloneName,
+		Background: cmd.cloneBackground,
+		AgentKey:   cmd.cloneAgent,
+		Progress:   os.Stderr,
+	})
+	if err != nil {
+		return fmt.Errorf("clone session: %w", err)
+	}
+
+	if cmd.cloneJSON {
+		return iojson.WriteLine(c.Root().Writer, buildSessionJSON(*sess))
+	}
+
+	fmt.Fprintf(os.Stderr, "Session %s cloned to %s\n  %s\n", id, sess.Name, sess.Path)
+	return nil
+}
+
+func (cmd *SessionCmd) compareCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "compare",
+		Usage:     "Compare two sessions of the same repository",
+		UsageText: "hive session compare <id-a> <id-b> [--json]",
+		Description: `Shows two active sessions side by side: branch, changes against the default
+branch, uncommitted work, the commits only in each session, and the diffstat
+between their HEADs. Useful for choosing between parallel attempts at a task.`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "write the comparison as JSON to stdout",
+				Destination: &cmd.compareJSON,
+			},
+		},
+		Action: cmd.runCompare,
+	}
+}
+
+func (cmd *SessionCmd) runCompare(ctx context.Context, c *cli.Command) error {
+	if c.Args().Len() != 2 {
+		return fmt.Errorf("two session IDs required\n\nUsage: hive session compare <id-a> <id-b>")
+	}
+
+	cmp, err := cmd.app.Sessions.CompareSessions(ctx, c.Args().Get(0), c.Args().Get(1))
+	if err != nil {
+		return err
+	}
+
+	out := c.Root().Writer
+	if cmd.compareJSON {
+		return iojson.WriteLine(out, cmp)
+	}
+	for _, side := range []hive.CompareSide{cmp.A, cmp.B} {
+		for _, msg := range side.Errors {
+			_, _ = fmt.Fprintf(c.Root().ErrWriter, "warning: session %s: %s\n", side.Session.ID, msg)
+		}
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "\tA\tB")
+	_, _ = fmt.Fprintf(w, "SESSION\t%s (%s)\t%s (%s)\n", cmp.A.Session.Name, cmp.A.Session.ID, cmp.B.Session.Name, cmp.B.Session.ID)
+	_, _ = fmt.Fprintf(w, "BRANCH\t%s\t%s\n", cmp.A.Branch, cmp.B.Branch)
+	_, _ = fmt.Fprintf(w, "CHANGES\t%s\t%s\n", formatCompareChanges(cmp.A), formatCompareChanges(cmp.B))
+	_, _ = fmt.Fprintf(w, "COMMITS\t%d only in A\t%d only in B\n", len(cmp.Divergence.Ahead), len(cmp.Divergence.Behind))
+	totals := cmd.usageTotals(ctx)
+	if totals[cmp.A.Session.ID] != nil || totals[cmp.B.Session.ID] != nil {
+		_, _ = fmt.Fprintf(w, "COST\t%s\t%s\n", formatCompareCost(totals[cmp.A.Session.ID]), formatCompareCost(totals[cmp.B.Session.ID]))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, side := range []struct {
+		label   string
+		commits []git.Commit
+	}{{"A", cmp.Divergence.Ahead}, {"B", cmp.Divergence.Behind}} {
+		if len(side.commits) == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintf(out, "\nOnly in %s:\n", side.label)
+		for _, commit := range side.commits {
+			_, _ = fmt.Fprintf(out, "  %s %s\n", commit.Hash, commit.Subject)
+		}
+	}
+
+	_, _ = fmt.Fprintf(out, "\nBetween HEADs: +%d -%d\n", cmp.Divergence.Additions, cmp.Divergence.Deletions)
+	return nil
+}
+
+// formatCompareChanges renders a session's diff stats against its default
+// branch, e.g. "+12 -3 (uncommitted)".
+func formatCompareChanges(side hive.CompareSide) string {
+	changes := fmt.Sprintf("+%d -%d", side.Additions, side.Deletions)
+	if side.Uncommitted {
+		changes += " (uncommitted)"
+	}
+	return changes
+}
+
+// formatCompareCost renders a session's agent cost, or "-" without usage.
+func formatCompareCost(u *usage.Usage) string {
+	if u == nil {
+		return "-"
+	}
+	return claude.FormatCost(u.CostUSD)
+}
+
+func (cmd *SessionCmd) renameCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "rename",
+		Usage:     "Rename a session and its tmux session",
+		UsageText: "hive session rename <id> <name> [--json]",
+		Description: `Renames a session and regenerates its slug. A tmux session named after
+the old slug is renamed with it, so running agents keep going and stay
+tracked under the new name. If the session can't be saved, the tmux session
+keeps its old name.
+
+Examples:
+  hive session rename abc123 "Auth Refactor"
+  hive session rename abc123 auth-refactor --json`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "write the renamed session as JSON to stdout",
+				Destination: &cmd.renameJSON,
+			},
+		},
+		Action: cmd.runRename,
+	}
+}
+
+func (cmd *SessionCmd) runRename(ctx context.Context, c *cli.Command) error {
+	if c.Args().Len() != 2 {
+		return fmt.Errorf("usage: hive session rename <id> <name>")
+	}
+	id, name := c.Args().Get(0), c.Args().Get(1)
+
+	if err := cmd.app.Sessions.RenameSession(ctx, id, name); err != nil {
+		return fmt.Errorf("rename session: %w", err)
+	}
+
+	sess, err := cmd.app.Sessions.GetSession(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get session: %w", err)
+	}
+
+	if cmd.renameJSON {
+		return iojson.WriteLine(c.Root().Writer, buildSessionJSON(sess))
+	}
+
+	fmt.Fprintf(os.Stderr, "Session %s renamed to %s (%s)\n", id, sess.Name, sess.Slug)
+	return nil
+}
+
 func (cmd *SessionCmd) updateCmd() *cli.Command {
 	return &cli.Command{
 		Name:      "update",
@@ -385,18 +781,216 @@ func (cmd *SessionCmd) runUpdate(ctx context.Context, c *cli.Command) error {
 	return nil
 }
 
+func (cmd *SessionCmd) tagCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "tag",
+		Usage:     "Add or remove session tags",
+		UsageText: "hive session tag <id> <tags...> [--remove] [--json]\nhive session tag <id> --clear",
+		Description: `Adds tags to a session. Tags are single words matched without regard to
+case; the sessions view narrows to a tag with the tag:<name> filter.
+
+Examples:
+  hive session tag abc123 backend urgent
+  hive session tag abc123 urgent --remove
+  hive session tag abc123 --clear`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "remove",
+				Aliases:     []string{"r"},
+				Usage:       "remove the given tags instead of adding them",
+				Destination: &cmd.tagRemove,
+			},
+			&cli.BoolFlag{
+				Name:        "clear",
+				Usage:       "remove all tags",
+				Destination: &cmd.tagClear,
+			},
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "write the updated session as JSON to stdout",
+				Destination: &cmd.tagJSON,
+			},
+		},
+		Action: cmd.runTag,
+	}
+}
+
+func (cmd *SessionCmd) runTag(ctx context.Context, c *cli.Command) error {
+	id := c.Args().First()
+	if id == "" {
+		return fmt.Errorf("session ID required")
+	}
+	tags := c.Args().Tail()
+
+	switch {
+	case cmd.tagClear && (cmd.tagRemove || len(tags) > 0):
+		return fmt.Errorf("--clear takes no tags and cannot be combined with --remove")
+	case !cmd.tagClear && len(tags) == 0:
+		return fmt.Errorf("at least one tag required")
+	}
+
+	sess, err := cmd.app.Sessions.GetSession(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get session: %w", err)
+	}
+
+	switch {
+	case cmd.tagClear:
+		sess.Tags = nil
+	case cmd.tagRemove:
+		sess.RemoveTags(tags...)
+	default:
+		sess.AddTags(tags...)
+	}
+
+	if err := cmd.app.Sessions.SetSessionTags(ctx, id, sess.Tags); err != nil {
+		return fmt.Errorf("set session tags: %w", err)
+	}
+
+	if cmd.tagJSON {
+		sess, err := cmd.app.Sessions.GetSession(ctx, id)
+		if err != nil {
+			return fmt.Errorf("get session: %w", err)
+		}
+		return iojson.WriteLine(c.Root().Writer, buildSessionJSON(sess))
+	}
+
+	fmt.Fprintf(os.Stderr, "Session %s tags: %s\n", id, formatTags(sess.Tags))
+	return nil
+}
+
+func (cmd *SessionCmd) dueCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "due",
+		Usage:     "Set when a session is expected to be done",
+		UsageText: "hive session due <id> <duration|date> [--json]\nhive session due <id> --clear",
+		Description: `Sets a session's due date, either a duration from now (90m, 12h, 3d) or a
+date (2006-01-02, due at the end of that day). Overdue sessions are marked
+in the sessions view, raise a warning, and are listed by 'hive doctor'.
+
+When due.archive_after is set, overdue sessions are archived that long after
+their due date, unless they have uncommitted or unpushed work.
+
+Examples:
+  hive session due abc123 3d
+  hive session due abc123 2026-11-01
+  hive session due abc123 --clear`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "clear",
+				Usage:       "remove the due date",
+				Destination: &cmd.dueClear,
+			},
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "write the updated session as JSON to stdout",
+				Destination: &cmd.dueJSON,
+			},
+		},
+		Action: cmd.runDue,
+	}
+}
+
+func (cmd *SessionCmd) runDue(ctx context.Context, c *cli.Command) error {
+	id := c.Args().First()
+	if id == "" {
+		return fmt.Errorf("session ID required")
+	}
+	when := c.Args().Get(1)
+
+	var due time.Time
+	switch {
+	case cmd.dueClear && when != "":
+		return fmt.Errorf("--clear takes no due date")
+	case cmd.dueClear:
+	case when == "":
+		return fmt.Errorf("due date required, e.g. 3d or 2026-11-01")
+	default:
+		var err error
+		due, err = parseDue(when, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := cmd.app.Sessions.SetSessionDue(ctx, id, due); err != nil {
+		return fmt.Errorf("set session due date: %w", err)
+	}
+
+	sess, err := cmd.app.Sessions.GetSession(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get session: %w", err)
+	}
+
+	if cmd.dueJSON {
+		return iojson.WriteLine(c.Root().Writer, buildSessionJSON(sess))
+	}
+
+	if due.IsZero() {
+		fmt.Fprintf(os.Stderr, "Session %s due date cleared\n", id)
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "Session %s due %s\n", id, formatDue(sess, due, time.Now()))
+	return nil
+}
+
+// parseDue parses a due date given as a duration from now, with a "d" suffix
+// for days, or as a date, which is due at the end of that day.
+func parseDue(s string, now time.Time) (time.Time, error) {
+	if date, err := time.ParseInLocation(time.DateOnly, s, now.Location()); err == nil {
+		return date.AddDate(0, 0, 1).Add(-time.Minute), nil
+	}
+	d, err := parseDuration(s)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("invalid due date %q: use a duration such as 3d or 12h, or a date such as 2026-11-01", s)
+	}
+	return now.Add(d), nil
+}
+
+// formatDue renders a session's due date, e.g. "2026-11-01 23:59 (overdue)".
+func formatDue(sess session.Session, due, now time.Time) string {
+	out := due.Local().Format("2006-01-02 15:04")
+	if sess.IsOverdue(now) {
+		out += " (overdue)"
+	}
+	return out
+}
+
+// formatTags renders tags for human-readable output.
+func formatTags(tags []string) string {
+	if len(tags) == 0 {
+		return "(none)"
+	}
+	return strings.Join(tags, ", ")
+}
+
 // lsSessionInfo is the JSON output format for hive session ls --json.
 type lsSessionInfo struct {
-	ID     string   `json:"id"`
-	Name   string   `json:"name"`
-	Repo   string   `json:"repo"`
-	Inbox  string   `json:"inbox"`
-	State  string   `json:"state"`
-	Unread int      `json:"unread"`
-	Tags   []string `json:"tags"`
+	ID      string                  `json:"id"`
+	Name    string                  `json:"name"`
+	Repo    string                  `json:"repo"`
+	Inbox   string                  `json:"inbox"`
+	State   string                  `json:"state"`
+	Unread  int                     `json:"unread"`
+	Tags    []string                `json:"tags"`
+	Notes   string                  `json:"notes,omitempty"`
+	DueAt   *time.Time              `json:"due_at,omitempty"`
+	Overdue bool                    `json:"overdue,omitempty"`
+	Archive *session.ArchiveSummary `json:"archive,omitempty"`
+	Usage   *usage.Usage            `json:"usage,omitempty"`
+	// GitHub is the session's pull request as last cached by the github
+	// plugin's status provider; ls does not call gh itself.
+	GitHub *github.PullRequestStatus `json:"github,omitempty"`
+	// Capabilities is what the session's agent last announced on its meta
+	// topic.
+	Capabilities *session.Capabilities `json:"capabilities,omitempty"`
 }
 
 func (cmd *SessionCmd) runLs(ctx context.Context, c *cli.Command) error {
+	if cmd.lsArchived {
+		return cmd.runLsArchived(ctx, c)
+	}
+
 	sessions, err := cmd.app.Sessions.ListSessions(ctx)
 	if err != nil {
 		return fmt.Errorf("list sessions: %w", err)
@@ -431,8 +1025,14 @@ func (cmd *SessionCmd) runLs(ctx context.Context, c *cli.Command) error {
 
 	// JSON output mode
 	if cmd.lsJSON {
+		totals := cmd.usageTotals(ctx)
+		gh := github.New(cmd.app.Config.Plugins.GitHub, cmd.app.KV)
 		for _, s := range normal {
 			info := cmd.buildLsSessionInfo(ctx, s)
+			info.Usage = totals[s.ID]
+			if pr, ok := gh.CachedPullRequest(ctx, s.ID); ok {
+				info.GitHub = &pr
+			}
 			if err := iojson.WriteLine(out, info); err != nil {
 				return fmt.Errorf("encode session: %w", err)
 			}
@@ -445,9 +1045,14 @@ func (cmd *SessionCmd) runLs(ctx context.Context, c *cli.Command) error {
 		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
 		_, _ = fmt.Fprintln(w, "REPO\tNAME\tSTATE\tPATH")
 
+		now := time.Now()
 		for _, s := range normal {
 			repo := git.ExtractRepoName(s.Remote)
-			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", repo, s.Name, s.State, s.Path)
+			state := string(s.State)
+			if s.IsOverdue(now) {
+				state += " (overdue)"
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", repo, s.Name, state, s.Path)
 		}
 
 		_ = w.Flush()
@@ -467,6 +1072,87 @@ func (cmd *SessionCmd) runLs(ctx context.Context, c *cli.Command) error {
 	return nil
 }
 
+// usageTotals returns the cumulative agent usage recorded by the TUI's
+// background refresh, by session ID. Sessions without usage are omitted; a
+// failure to load totals is logged and yields none.
+func (cmd *SessionCmd) usageTotals(ctx context.Context) map[string]*usage.Usage {
+	totals, err := stores.NewUsageStore(cmd.app.DB).SessionTotals(ctx)
+	if err != nil {
+		log.Debug().Err(err).Msg("load session usage")
+		return nil
+	}
+
+	byID := make(map[string]*usage.Usage, len(totals))
+	for id, total := range totals {
+		byID[id] = &total
+	}
+	return byID
+}
+
+// runLsArchived lists archived sessions, most recently archived first.
+func (cmd *SessionCmd) runLsArchived(ctx context.Context, c *cli.Command) error {
+	sessions, err := cmd.app.Sessions.ListArchivedSessions(ctx)
+	if err != nil {
+		return fmt.Errorf("list archived sessions: %w", err)
+	}
+
+	if len(cmd.lsTags) > 0 {
+		sessions = slices.DeleteFunc(sessions, func(s session.Session) bool {
+			return !sessionHasAllTags(s, cmd.lsTags)
+		})
+	}
+
+	if len(sessions) == 0 {
+		if !cmd.lsJSON {
+			fmt.Fprintf(os.Stderr, "No archived sessions found\n")
+		}
+		return nil
+	}
+
+	out := c.Root().Writer
+
+	if cmd.lsJSON {
+		totals := cmd.usageTotals(ctx)
+		for _, s := range sessions {
+			info := cmd.buildLsSessionInfo(ctx, s)
+			summary := s.ArchiveSummary()
+			info.Archive = &summary
+			info.Usage = totals[s.ID]
+			if err := iojson.WriteLine(out, info); err != nil {
+				return fmt.Errorf("encode session: %w", err)
+			}
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "REPO\tNAME\tBRANCH\tCHANGES\tARCHIVED")
+	for _, s := range sessions {
+		summary := s.ArchiveSummary()
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			git.ExtractRepoName(s.Remote), s.Name, summary.Branch,
+			formatArchiveChanges(summary), summary.ArchivedAt.Local().Format(time.DateTime))
+	}
+	return w.Flush()
+}
+
+// formatArchiveChanges renders an archive summary's diff stats and git state,
+// e.g. "+12 -3 (uncommitted)".
+func formatArchiveChanges(summary session.ArchiveSummary) string {
+	changes := fmt.Sprintf("+%d -%d", summary.Additions, summary.Deletions)
+	var flags []string
+	if summary.Uncommitted {
+		flags = append(flags, "uncommitted")
+	}
+	if summary.Unpushed {
+		flags = append(flags, "unpushed")
+	}
+	if len(flags) > 0 {
+		changes += " (" + strings.Join(flags, ", ") + ")"
+	}
+	return changes
+}
+
 func (cmd *SessionCmd) deleteCmd() *cli.Command {
 	return &cli.Command{
 		Name:      "delete",
@@ -585,19 +1271,142 @@ func (cmd *SessionCmd) runRecycle(ctx context.Context, c *cli.Command) error {
 	return nil
 }
 
+func (cmd *SessionCmd) syncCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "sync",
+		Usage:     "Rebase a session's branch onto its base branch",
+		UsageText: "hive session sync <id> [--json]",
+		Description: `Fetches origin and brings the session's branch up to date with the branch it
+started from (base_branch, or the default branch), so agents working over
+several days do not drift from main.
+
+The commands come from the sync list of the last matching rule and default
+to:
+  git fetch origin
+  git rebase origin/{{ .BaseBranch }}
+
+Set sync to merge instead, for example "git merge origin/{{ .BaseBranch }}".
+If a command fails, usually on a conflict, the session is marked with a
+sync conflict and its working tree is left as the command left it for the
+agent or you to resolve. Run sync again afterwards to clear the mark.`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "write the synced session as JSON to stdout",
+				Destination: &cmd.syncJSON,
+			},
+		},
+		Action: cmd.runSync,
+	}
+}
+
+func (cmd *SessionCmd) runSync(ctx context.Context, c *cli.Command) error {
+	id := c.Args().First()
+	if id == "" {
+		return fmt.Errorf("session ID required")
+	}
+
+	if err := cmd.app.Sessions.SyncSession(ctx, id, os.Stderr); err != nil {
+		return fmt.Errorf("sync session: %w", err)
+	}
+
+	if cmd.syncJSON {
+		sess, err := cmd.app.Sessions.GetSession(ctx, id)
+		if err != nil {
+			return fmt.Errorf("get session after sync: %w", err)
+		}
+		return iojson.WriteLine(c.Root().Writer, buildSessionJSON(sess))
+	}
+
+	fmt.Fprintf(os.Stderr, "Session %s synced\n", id)
+	return nil
+}
+
+func (cmd *SessionCmd) archiveCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "archive",
+		Usage:     "Archive a session, keeping its record",
+		UsageText: "hive session archive <id> [--force] [--json]",
+		Description: `Removes a session's directory and tmux session but keeps its record, with
+the branch, diff stats, and git status the checkout had when it was archived.
+Worktree sessions keep their branch in the shared bare clone.
+
+Archived sessions are hidden from 'hive ls'; use 'hive ls --archived' to list
+them and 'hive session delete' to remove one for good.
+
+If the session has uncommitted changes or unpushed commits, the archive is
+refused unless --force is passed.`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "force",
+				Aliases:     []string{"f"},
+				Usage:       "archive even if the session has uncommitted or unpushed work",
+				Destination: &cmd.archiveForce,
+			},
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "write the archived session as JSON to stdout",
+				Destination: &cmd.archiveJSON,
+			},
+		},
+		Action: cmd.runArchive,
+	}
+}
+
+func (cmd *SessionCmd) runArchive(ctx context.Context, c *cli.Command) error {
+	id := c.Args().First()
+	if id == "" {
+		return fmt.Errorf("session ID required")
+	}
+
+	if !cmd.archiveForce {
+		if err := cmd.checkRisk(ctx, id, "archive"); err != nil {
+			return err
+		}
+	}
+
+	if err := cmd.app.Sessions.ArchiveSession(ctx, id); err != nil {
+		return fmt.Errorf("archive session: %w", err)
+	}
+
+	sess, err := cmd.app.Sessions.GetSession(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get session after archive: %w", err)
+	}
+	summary := sess.ArchiveSummary()
+
+	if cmd.archiveJSON {
+		return iojson.WriteLine(c.Root().Writer, struct {
+			sessionJSON
+			Archive session.ArchiveSummary `json:"archive"`
+		}{buildSessionJSON(sess), summary})
+	}
+
+	fmt.Fprintf(os.Stderr, "Session %s archived (%s)\n", id, formatArchiveChanges(summary))
+	return nil
+}
+
 func (cmd *SessionCmd) buildLsSessionInfo(ctx context.Context, s session.Session) lsSessionInfo {
 	tags := s.Tags
 	if tags == nil {
 		tags = []string{}
 	}
 	info := lsSessionInfo{
-		ID:     s.ID,
-		Name:   s.Name,
-		Repo:   git.ExtractRepoName(s.Remote),
-		Inbox:  s.InboxTopic(),
-		State:  string(s.State),
-		Unread: 0,
-		Tags:   tags,
+		ID:      s.ID,
+		Name:    s.Name,
+		Repo:    git.ExtractRepoName(s.Remote),
+		Inbox:   s.InboxTopic(),
+		State:   string(s.State),
+		Unread:  0,
+		Tags:    tags,
+		Notes:   s.Notes,
+		Overdue: s.IsOverdue(time.Now()),
+	}
+	if due, ok := s.DueAt(); ok {
+		info.DueAt = &due
+	}
+	if caps, ok := s.Capabilities(); ok {
+		info.Capabilities = &caps
 	}
 
 	// Count unread inbox messages
@@ -611,7 +1420,15 @@ func (cmd *SessionCmd) buildLsSessionInfo(ctx context.Context, s session.Session
 // checkRisk returns an error describing uncommitted or unpushed work that
 // would be lost if the session were destroyed by the given action.
 func (cmd *SessionCmd) checkRisk(ctx context.Context, id, action string) error {
-	risk, err := cmd.app.Sessions.CheckSessionRisk(ctx, id)
+	return checkSessionRisk(ctx, cmd.app, id, action, "--force", os.Stderr)
+}
+
+// checkSessionRisk refuses an action on a session with uncommitted or
+// unpushed work, naming the override that skips the check. When
+// unsaved_work is "warn" it writes the same description to warn and lets the
+// action proceed.
+func checkSessionRisk(ctx context.Context, app *hive.App, id, action, override string, warn io.Writer) error {
+	risk, err := app.Sessions.CheckSessionRisk(ctx, id)
 	if err != nil {
 		return fmt.Errorf("check session risk: %w", err)
 	}
@@ -620,22 +1437,30 @@ func (cmd *SessionCmd) checkRisk(ctx context.Context, id, action string) error {
 	}
 	var reasons []string
 	if risk.UncommittedChanges {
-		reasons = append(reasons, "uncommitted changes")
+		if n := len(risk.ChangedFiles); n > 0 {
+			reasons = append(reasons, fmt.Sprintf("uncommitted changes in %d file(s)", n))
+		} else {
+			reasons = append(reasons, "uncommitted changes")
+		}
 	}
 	if risk.UnpushedCommits {
-		reasons = append(reasons, "unpushed commits")
+		if risk.UnpushedCount > 0 {
+			reasons = append(reasons, fmt.Sprintf("%d unpushed commit(s)", risk.UnpushedCount))
+		} else {
+			reasons = append(reasons, "unpushed commits")
+		}
 	}
-	return fmt.Errorf("session %s has %s; use --force to %s anyway", id, strings.Join(reasons, " and "), action)
+	if app.Config.WarnOnUnsavedWork() {
+		fmt.Fprintf(warn, "warning: session %s has %s\n", id, strings.Join(reasons, " and "))
+		return nil
+	}
+	return fmt.Errorf("session %s has %s; use %s to %s anyway", id, strings.Join(reasons, " and "), override, action)
 }
 
 // sessionHasAllTags returns true if the session has every tag in required.
 func sessionHasAllTags(s session.Session, required []string) bool {
-	tagSet := make(map[string]struct{}, len(s.Tags))
-	for _, t := range s.Tags {
-		tagSet[t] = struct{}{}
-	}
 	for _, r := range required {
-		if _, ok := tagSet[r]; !ok {
+		if !s.HasTag(r) {
 			return false
 		}
 	}
@@ -0,0 +1,185 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/hive/plugins/github"
+	"github.com/colonyops/hive/pkg/iojson"
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v3"
+)
+
+// prResultJSON is the JSON output of hive session pr.
+type prResultJSON struct {
+	SessionID string `json:"session_id"`
+	Branch    string `json:"branch"`
+	Base      string `json:"base"`
+	Title     string `json:"title"`
+	URL       string `json:"url"`
+}
+
+func (cmd *SessionCmd) prCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "pr",
+		Usage:     "Push a session's branch and open a pull request",
+		UsageText: "hive session pr [id] [--base <branch>] [--draft] [--title <tmpl>] [--body <tmpl>] [--no-push] [--json]",
+		Description: `Pushes the session's branch to origin and opens a GitHub pull request for it
+with gh. Without an ID, the session is detected from the current directory.
+
+The title and body are templates from plugins.github.pr in config, or
+--title and --body. They can use:
+  .Name      session name
+  .Branch    branch the pull request is opened from
+  .Base      branch the pull request targets
+  .Prompt    prompt the session was created with
+  .Commits   subjects of the branch's commits, oldest first
+  .Issue     number of the issue the session was created from (hive new --issue)
+  .IssueURL  URL of that issue
+
+The pull request URL is printed to stdout.
+
+Examples:
+  hive session pr abc123
+  hive session pr --draft --base develop
+  hive session pr abc123 --title "{{ .Name }}" --json`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "base",
+				Usage:       "branch to open the pull request against (default: the repository's default branch)",
+				Destination: &cmd.prBase,
+			},
+			&cli.BoolFlag{
+				Name:        "draft",
+				Usage:       "open the pull request as a draft",
+				Destination: &cmd.prDraft,
+			},
+			&cli.StringFlag{
+				Name:        "title",
+				Usage:       "title template (overrides plugins.github.pr.title)",
+				Destination: &cmd.prTitle,
+			},
+			&cli.StringFlag{
+				Name:        "body",
+				Usage:       "body template (overrides plugins.github.pr.body)",
+				Destination: &cmd.prBody,
+			},
+			&cli.BoolFlag{
+				Name:        "no-push",
+				Usage:       "do not push the branch first",
+				Destination: &cmd.prNoPush,
+			},
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "write the pull request as JSON to stdout",
+				Destination: &cmd.prJSON,
+			},
+		},
+		Action: cmd.runPR,
+	}
+}
+
+func (cmd *SessionCmd) runPR(ctx context.Context, c *cli.Command) error {
+	id := c.Args().First()
+	if id == "" {
+		detected, err := cmd.app.Sessions.DetectSession(ctx)
+		if err != nil {
+			return fmt.Errorf("detect session: %w", err)
+		}
+		if detected == "" {
+			return fmt.Errorf("session ID required (not inside a hive session)")
+		}
+		id = detected
+	}
+
+	sess, err := cmd.app.Sessions.GetSession(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get session: %w", err)
+	}
+	if sess.State != session.StateActive {
+		return fmt.Errorf("session %s has no checkout (state: %s)", sess.ID, sess.State)
+	}
+	if sess.GetMeta(session.MetaVCS) == config.VCSJJ {
+		return fmt.Errorf("session %s uses jj; pull requests need a git session", sess.ID)
+	}
+	if _, ok := git.ParseRemote(sess.Remote); ok {
+		return fmt.Errorf("session %s is not a GitHub repository: %s", sess.ID, sess.Remote)
+	}
+
+	vcs := cmd.app.Sessions.VCS(&sess)
+	branch, err := vcs.Branch(ctx, sess.Path)
+	if err != nil {
+		return fmt.Errorf("get branch: %w", err)
+	}
+	base := cmd.prBase
+	if base == "" {
+		if base, err = vcs.DefaultBranch(ctx, sess.Path); err != nil {
+			return fmt.Errorf("get default branch: %w", err)
+		}
+	}
+	if branch == base {
+		return fmt.Errorf("session %s is on the base branch %s; commit to another branch first", sess.ID, base)
+	}
+
+	exec := cmd.app.SessionExec()
+	commits, err := github.BranchCommits(ctx, exec, cmd.app.Config.GitPath, sess.Path, base)
+	if err != nil {
+		log.Warn().Err(err).Str("session", sess.ID).Msg("failed to list branch commits for pull request")
+	} else if len(commits) == 0 {
+		return fmt.Errorf("branch %s has no commits ahead of %s", branch, base)
+	}
+
+	prCfg := cmd.app.Config.Plugins.GitHub.PR
+	if cmd.prTitle != "" {
+		prCfg.Title = cmd.prTitle
+	}
+	if cmd.prBody != "" {
+		prCfg.Body = cmd.prBody
+	}
+	title, body, err := github.RenderPullRequest(cmd.app.Renderer, prCfg, github.PRTemplateData{
+		Name:     sess.Name,
+		Branch:   branch,
+		Base:     base,
+		Prompt:   sess.GetMeta(session.MetaPrompt),
+		Commits:  commits,
+		Issue:    sess.GetMeta(session.MetaIssueNumber),
+		IssueURL: sess.GetMeta(session.MetaIssueURL),
+	})
+	if err != nil {
+		return fmt.Errorf("render pull request: %w", err)
+	}
+
+	if !cmd.prNoPush {
+		fmt.Fprintf(os.Stderr, "Pushing %s\n", branch)
+		if err := github.PushBranch(ctx, exec, cmd.app.Config.GitPath, sess.Path, branch); err != nil {
+			return err
+		}
+	}
+
+	url, err := github.CreatePullRequest(ctx, exec, sess.Path, github.PullRequest{
+		Branch: branch,
+		Base:   base,
+		Title:  title,
+		Body:   body,
+		Draft:  cmd.prDraft || prCfg.Draft,
+	})
+	if err != nil {
+		return err
+	}
+
+	if cmd.prJSON {
+		return iojson.WriteLine(c.Root().Writer, prResultJSON{
+			SessionID: sess.ID,
+			Branch:    branch,
+			Base:      base,
+			Title:     title,
+			URL:       url,
+		})
+	}
+	_, _ = fmt.Fprintln(c.Root().Writer, url)
+	return nil
+}
@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/pkg/iojson"
+	"github.com/colonyops/hive/pkg/timeutil"
+	"github.com/urfave/cli/v3"
+)
+
+func (cmd *SessionCmd) snapshotCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "snapshot",
+		Usage:     "Save a copy of a session's directory",
+		UsageText: "hive session snapshot <id> [--json]",
+		Description: `Archives an active session's directory, including uncommitted changes and
+untracked files, under the snapshots directory in the data directory. Restore
+it later with 'hive session restore'.
+
+Worktree sessions share their history with a bare clone, so their branch is
+saved as a git bundle next to the archive.
+
+Only the newest snapshots.max_per_session snapshots (default 3) of a session
+are kept. Set snapshot_on_recycle on a rule to snapshot matching sessions
+automatically before they are recycled.
+
+Example:
+  hive session snapshot 26kj0c`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "write the snapshot as JSON to stdout",
+				Destination: &cmd.snapshotJSON,
+			},
+		},
+		Action: cmd.runSnapshot,
+	}
+}
+
+func (cmd *SessionCmd) runSnapshot(ctx context.Context, c *cli.Command) error {
+	id := c.Args().First()
+	if id == "" {
+		return fmt.Errorf("session ID required")
+	}
+
+	snap, err := cmd.app.Sessions.SnapshotSession(ctx, id)
+	if err != nil {
+		return fmt.Errorf("snapshot session: %w", err)
+	}
+
+	if cmd.snapshotJSON {
+		return iojson.WriteLine(c.Root().Writer, snap)
+	}
+
+	fmt.Fprintf(os.Stderr, "Snapshot %s saved (%s)\n", snap.ID, formatSnapshotSize(snap.Size))
+	return nil
+}
+
+func (cmd *SessionCmd) snapshotsCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "snapshots",
+		Usage:     "List session snapshots",
+		UsageText: "hive session snapshots [id] [--json]",
+		Description: `Lists the snapshots of a session, or of every session when no ID is given,
+newest first. Snapshots outlive their session, so deleted and recycled
+sessions can still be restored.`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "output as JSON lines",
+				Destination: &cmd.snapshotsJSON,
+			},
+		},
+		Action: cmd.runSnapshots,
+	}
+}
+
+func (cmd *SessionCmd) runSnapshots(_ context.Context, c *cli.Command) error {
+	snapshots, err := cmd.app.Sessions.ListSnapshots(c.Args().First())
+	if err != nil {
+		return fmt.Errorf("list snapshots: %w", err)
+	}
+
+	out := c.Root().Writer
+	if cmd.snapshotsJSON {
+		for _, snap := range snapshots {
+			if err := iojson.WriteLine(out, snap); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(snapshots) == 0 {
+		_, _ = fmt.Fprintln(out, "No snapshots.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tSESSION\tNAME\tBRANCH\tSIZE\tCREATED")
+	for _, snap := range snapshots {
+		created := timeutil.Ago(snap.CreatedAt)
+		if snap.Reason == hive.SnapshotReasonRecycle {
+			created += " (recycle)"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", snap.ID, snap.SessionID, snap.Name, snap.Branch, formatSnapshotSize(snap.Size), created)
+	}
+	return w.Flush()
+}
+
+func (cmd *SessionCmd) restoreCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "restore",
+		Usage:     "Create a new session from a snapshot",
+		UsageText: "hive session restore <snapshot-id> [--name <name>] [--background] [--agent <name>] [--json]",
+		Description: `Unpacks a snapshot into a new full-clone session and spawns it like 'hive new'.
+The snapshotted directory's files, uncommitted changes included, are restored
+as they were. Setup commands and copy rules are not run again.
+
+The name defaults to the snapshotted session's name, with a numeric suffix
+when an active session already uses it.
+
+Example:
+  hive session restore x7b2qp --name auth-retry --background`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "name",
+				Aliases:     []string{"n"},
+				Usage:       "name for the new session",
+				Destination: &cmd.restoreName,
+			},
+			&cli.BoolFlag{
+				Name:        "background",
+				Aliases:     []string{"bg"},
+				Usage:       "create session without attaching to tmux",
+				Destination: &cmd.restoreBackground,
+			},
+			&cli.StringFlag{
+				Name:        "agent",
+				Usage:       "agent profile to spawn (defaults to the configured default)",
+				Destination: &cmd.restoreAgent,
+			},
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "write the restored session as JSON to stdout",
+				Destination: &cmd.restoreJSON,
+			},
+		},
+		Action: cmd.runRestore,
+	}
+}
+
+func (cmd *SessionCmd) runRestore(ctx context.Context, c *cli.Command) error {
+	id := c.Args().First()
+	if id == "" {
+		return fmt.Errorf("snapshot ID required")
+	}
+	if cmd.restoreAgent != "" {
+		if _, ok := cmd.app.Config.Agents.Profiles[cmd.restoreAgent]; !ok {
+			return fmt.Errorf("unknown agent %q", cmd.restoreAgent)
+		}
+	}
+
+	// Keep stdout clean for --json output; progress goes to stderr.
+	sess, err := cmd.app.Sessions.RestoreSnapshot(ctx, id, hive.CreateOptions{
+		Name:       cmd.restoreName,
+		Background: cmd.restoreBackground,
+		AgentKey:   cmd.restoreAgent,
+		Progress:   os.Stderr,
+	})
+	if err != nil {
+		return fmt.Errorf("restore snapshot: %w", err)
+	}
+
+	if cmd.restoreJSON {
+		return iojson.WriteLine(c.Root().Writer, buildSessionJSON(*sess))
+	}
+
+	fmt.Fprintf(os.Stderr, "Snapshot %s restored to %s\n  %s\n", id, sess.Name, sess.Path)
+	return nil
+}
+
+// formatSnapshotSize formats a byte count with a binary unit.
+func formatSnapshotSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/hive"
+)
+
+func runSession(t *testing.T, app *hive.App, args ...string) error {
+	t.Helper()
+	root := &cli.Command{Name: "hive", Writer: &bytes.Buffer{}}
+	NewSessionCmd(&Flags{}, app).Register(root)
+	return root.Run(context.Background(), append([]string{"hive", "session"}, args...))
+}
+
+func TestSessionTag(t *testing.T) {
+	app := newStatusApp(t, session.StateActive)
+	ctx := context.Background()
+
+	tags := func() []string {
+		sess, err := app.Sessions.GetSession(ctx, "a")
+		require.NoError(t, err)
+		return sess.Tags
+	}
+
+	require.NoError(t, runSession(t, app, "tag", "a", "backend", "urgent"))
+	assert.Equal(t, []string{"backend", "urgent"}, tags())
+
+	require.NoError(t, runSession(t, app, "tag", "a", "Backend", "infra"))
+	assert.Equal(t, []string{"backend", "urgent", "infra"}, tags(), "tags are deduplicated ignoring case")
+
+	require.NoError(t, runSession(t, app, "tag", "--remove", "a", "URGENT"))
+	assert.Equal(t, []string{"backend", "infra"}, tags())
+
+	require.Error(t, runSession(t, app, "tag", "a", "two words"))
+	require.Error(t, runSession(t, app, "tag", "a"))
+
+	require.NoError(t, runSession(t, app, "tag", "--clear", "a"))
+	assert.Empty(t, tags())
+}
+
+func TestSessionDue(t *testing.T) {
+	app := newStatusApp(t, session.StateActive)
+	ctx := context.Background()
+
+	due := func() (time.Time, bool) {
+		sess, err := app.Sessions.GetSession(ctx, "a")
+		require.NoError(t, err)
+		return sess.DueAt()
+	}
+
+	require.NoError(t, runSession(t, app, "due", "a", "3d"))
+	got, ok := due()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(72*time.Hour), got, time.Minute)
+
+	require.Error(t, runSession(t, app, "due", "a"))
+	require.Error(t, runSession(t, app, "due", "a", "soon"))
+	require.Error(t, runSession(t, app, "due", "--clear", "a", "3d"))
+
+	require.NoError(t, runSession(t, app, "due", "--clear", "a"))
+	_, ok = due()
+	assert.False(t, ok)
+}
+
+func TestParseDue(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	for in, want := range map[string]time.Time{
+		"90m":        now.Add(90 * time.Minute),
+		"3d":         now.AddDate(0, 0, 3),
+		"2026-03-12": time.Date(2026, 3, 12, 23, 59, 0, 0, time.UTC),
+	} {
+		got, err := parseDue(in, now)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"", "-1h", "0d", "tomorrow", "2026-13-01"} {
+		_, err := parseDue(in, now)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestSessionArchive(t *testing.T) {
+	app := newStatusApp(t, session.StateActive, session.StateActive)
+	ctx := context.Background()
+
+	require.Error(t, runSession(t, app, "archive"))
+	require.NoError(t, runSession(t, app, "archive", "--force", "a"))
+
+	archived, err := app.Sessions.GetSession(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, session.StateArchived, archived.State)
+
+	live, err := app.Sessions.ListSessions(ctx)
+	require.NoError(t, err)
+	require.Len(t, live, 1)
+	assert.Equal(t, "b", live[0].ID)
+
+	history, err := app.Sessions.ListArchivedSessions(ctx)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "a", history[0].ID)
+
+	require.Error(t, runSession(t, app, "archive", "--force", "a"), "archived sessions cannot be archived again")
+}
+
+func TestSessionCompare(t *testing.T) {
+	app := newStatusApp(t, session.StateActive, session.StateActive)
+
+	require.Error(t, runSession(t, app, "compare", "a"))
+	require.Error(t, runSession(t, app, "compare", "a", "a"), "a session cannot be compared with itself")
+	require.NoError(t, runSession(t, app, "compare", "--json", "a", "b"))
+}
+
+func TestBuildSessionJSON_Tmux(t *testing.T) {
+	sess := session.Session{ID: "a", Name: "a", State: session.StateActive}
+	assert.Nil(t, buildSessionJSON(sess).Tmux, "no tmux metadata")
+
+	sess.Metadata = map[string]string{
+		session.MetaTmuxSession: "a",
+		session.MetaTmuxWindow:  "1",
+		session.MetaTmuxPane:    "%8",
+		session.MetaTmuxTargets: `[{"index":"0","name":"shell","panes":["%7"]},{"index":"1","name":"agent","panes":["%8"]}]`,
+	}
+	tmux := buildSessionJSON(sess).Tmux
+	require.NotNil(t, tmux)
+	assert.Equal(t, "a", tmux.Session)
+	assert.Equal(t, "1", tmux.Window)
+	assert.Equal(t, "%8", tmux.Pane)
+	require.Len(t, tmux.Windows, 2)
+	assert.Equal(t, []string{"%8"}, tmux.Windows[1].Panes)
+}
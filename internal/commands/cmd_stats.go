@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/usage"
+	"github.com/colonyops/hive/internal/data/stores"
+	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/internal/hive/plugins/claude"
+	"github.com/colonyops/hive/pkg/iojson"
+)
+
+type StatsCmd struct {
+	flags *Flags
+	app   *hive.App
+
+	days int
+	json bool
+
+	now func() time.Time // overridden in tests
+}
+
+// NewStatsCmd creates a new stats command
+func NewStatsCmd(flags *Flags, app *hive.App) *StatsCmd {
+	return &StatsCmd{flags: flags, app: app, now: time.Now}
+}
+
+// Register adds the stats command to the application
+func (cmd *StatsCmd) Register(app *cli.Command) *cli.Command {
+	app.Commands = append(app.Commands, &cli.Command{
+		Name:      "stats",
+		Usage:     "Show agent token usage and cost per repository and day",
+		UsageText: "hive stats [--days N] [--json]",
+		Description: `Reads token usage from Claude Code's session transcripts, records it per
+session, and prints totals grouped by repository and day.
+
+Costs come from the transcripts when Claude Code records them and are
+otherwise estimated from list prices. Usage stays recorded after sessions
+are deleted and after Claude Code prunes old transcripts.
+
+Examples:
+  hive stats
+  hive stats --days 7
+  hive stats --json`,
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:        "days",
+				Aliases:     []string{"d"},
+				Usage:       "number of days to show, ending today",
+				Value:       30,
+				Destination: &cmd.days,
+			},
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "output one JSON line per repository and day",
+				Destination: &cmd.json,
+			},
+		},
+		Action: cmd.run,
+	})
+
+	return app
+}
+
+// statsDayJSON is the JSON shape of one repository's usage on one day.
+type statsDayJSON struct {
+	Date   string `json:"date"`
+	Repo   string `json:"repo"`
+	Remote string `json:"remote"`
+	usage.Usage
+}
+
+func (cmd *StatsCmd) run(ctx context.Context, c *cli.Command) error {
+	if cmd.days < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+
+	since := cmd.now().AddDate(0, 0, 1-cmd.days).Format(time.DateOnly)
+	days, err := stores.NewUsageStore(cmd.app.DB).ByRepoDay(ctx, since)
+	if err != nil {
+		return fmt.Errorf("load usage: %w", err)
+	}
+
+	w := c.Root().Writer
+	if cmd.json {
+		for _, d := range days {
+			if err := iojson.WriteLine(w, statsDayJSON{
+				Date:   d.Date,
+				Repo:   git.ExtractRepoName(d.Remote),
+				Remote: d.Remote,
+				Usage:  d.Usage,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(days) == 0 {
+		_, _ = fmt.Fprintln(w, "No usage recorded")
+		return nil
+	}
+
+	var total usage.Usage
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "DATE\tREPO\tTOKENS\tCOST")
+	for _, d := range days {
+		total.Add(d.Usage)
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", d.Date, git.ExtractRepoName(d.Remote), formatTokens(d.Tokens()), claude.FormatCost(d.CostUSD))
+	}
+	_, _ = fmt.Fprintf(tw, "TOTAL\t\t%s\t%s\n", formatTokens(total.Tokens()), claude.FormatCost(total.CostUSD))
+	return tw.Flush()
+}
+
+// formatTokens renders a token count compactly, e.g. "950", "12.3k", "4.1M".
+func formatTokens(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/usage"
+	"github.com/colonyops/hive/internal/data/stores"
+)
+
+func runStats(t *testing.T, cmd *StatsCmd, args ...string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	root := &cli.Command{Name: "hive", Writer: &buf}
+	cmd.Register(root)
+	require.NoError(t, root.Run(context.Background(), append([]string{"hive", "stats"}, args...)))
+	return buf.String()
+}
+
+func TestStats(t *testing.T) {
+	app := newStatusApp(t, session.StateActive)
+	require.NoError(t, stores.NewUsageStore(app.DB).Record(context.Background(), []usage.Day{
+		{SessionID: "a", Remote: "git@github.com:org/app.git", Date: "2026-03-01", Usage: usage.Usage{InputTokens: 1500, CostUSD: 0.5}},
+		{SessionID: "b", Remote: "git@github.com:org/app.git", Date: "2026-03-09", Usage: usage.Usage{OutputTokens: 2_000_000, CostUSD: 2}},
+		{SessionID: "c", Remote: "git@github.com:org/api.git", Date: "2026-03-10", Usage: usage.Usage{OutputTokens: 10, CostUSD: 0.25}},
+	}))
+
+	cmd := NewStatsCmd(&Flags{}, app)
+	cmd.now = func() time.Time { return time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local) }
+
+	out := runStats(t, cmd, "--days", "7")
+	assert.NotContains(t, out, "2026-03-01", "days before the range are excluded")
+	assert.Contains(t, out, "2026-03-09")
+	assert.Contains(t, out, "2.0M")
+	assert.Contains(t, out, "$2.25", "total row sums the range")
+
+	lines := strings.Split(strings.TrimSpace(runStats(t, cmd, "--json")), "\n")
+	require.Len(t, lines, 3)
+	var first statsDayJSON
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "2026-03-01", first.Date)
+	assert.Equal(t, "app", first.Repo)
+	assert.Equal(t, int64(1500), first.InputTokens)
+}
@@ -0,0 +1,183 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/pkg/iojson"
+	"github.com/urfave/cli/v3"
+)
+
+type StatusCmd struct {
+	flags *Flags
+	app   *hive.App
+
+	short bool
+	json  bool
+
+	now func() time.Time // overridden in tests
+}
+
+// NewStatusCmd creates a new status command
+func NewStatusCmd(flags *Flags, app *hive.App) *StatusCmd {
+	return &StatusCmd{flags: flags, app: app, now: time.Now}
+}
+
+// Register adds the status command to the application
+func (cmd *StatusCmd) Register(app *cli.Command) *cli.Command {
+	app.Commands = append(app.Commands, &cli.Command{
+		Name:      "status",
+		Usage:     "Summarize active sessions by agent status",
+		UsageText: "hive status [--short | --json]",
+		Description: `Counts active sessions and their agents' terminal statuses.
+
+Statuses come from the last poll of a running hive TUI, so the command is
+cheap enough for a tmux status line or shell prompt. Without a running TUI
+only the session count is known.
+
+Examples:
+  hive status
+  hive status --short    # 7 sessions • 3 active • 2 approval • 1 ready
+  hive status --json
+
+tmux:
+  set -g status-right '#(hive status --short)'`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "short",
+				Aliases:     []string{"s"},
+				Usage:       "print a single-line summary",
+				Destination: &cmd.short,
+			},
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "output the summary as JSON",
+				Destination: &cmd.json,
+			},
+		},
+		Action: cmd.run,
+	})
+
+	return app
+}
+
+// fleetSummary counts active sessions by terminal status.
+type fleetSummary struct {
+	Sessions  int        `json:"sessions"`
+	Active    int        `json:"active"`
+	Approval  int        `json:"approval"`
+	Ready     int        `json:"ready"`
+	Missing   int        `json:"missing"`
+	Unknown   int        `json:"unknown"` // sessions without a cached status
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+func (cmd *StatusCmd) run(ctx context.Context, c *cli.Command) error {
+	if cmd.short && cmd.json {
+		return fmt.Errorf("--short and --json cannot be used together")
+	}
+
+	sessions, err := cmd.app.Sessions.ListSessions(ctx)
+	if err != nil {
+		return fmt.Errorf("list sessions: %w", err)
+	}
+
+	// A missing or expired snapshot means no TUI is polling; counts fall
+	// back to "unknown".
+	snapshot, err := terminal.LoadStatusSnapshot(ctx, cmd.app.KV)
+	if err != nil {
+		return err
+	}
+
+	summary := summarizeFleet(sessions, snapshot)
+	w := c.Root().Writer
+	switch {
+	case cmd.json:
+		return iojson.WriteLine(w, summary)
+	case cmd.short:
+		_, err := fmt.Fprintln(w, summary.shortLine())
+		return err
+	default:
+		renderFleetSummary(w, summary, cmd.now())
+		return nil
+	}
+}
+
+// summarizeFleet counts active sessions by their status in snapshot.
+func summarizeFleet(sessions []session.Session, snapshot *terminal.StatusSnapshot) fleetSummary {
+	var summary fleetSummary
+	if snapshot != nil {
+		summary.UpdatedAt = &snapshot.UpdatedAt
+	}
+
+	for _, s := range sessions {
+		if s.State != session.StateActive {
+			continue
+		}
+		summary.Sessions++
+
+		var status terminal.Status
+		if snapshot != nil {
+			status = snapshot.Statuses[s.ID]
+		}
+		switch status {
+		case terminal.StatusActive:
+			summary.Active++
+		case terminal.StatusApproval:
+			summary.Approval++
+		case terminal.StatusReady:
+			summary.Ready++
+		case terminal.StatusMissing:
+			summary.Missing++
+		default:
+			summary.Unknown++
+		}
+	}
+	return summary
+}
+
+// shortLine renders the summary for status lines, omitting zero counts:
+// "7 sessions • 3 active • 2 approval • 1 ready".
+func (s fleetSummary) shortLine() string {
+	noun := "sessions"
+	if s.Sessions == 1 {
+		noun = "session"
+	}
+	parts := []string{fmt.Sprintf("%d %s", s.Sessions, noun)}
+	for _, c := range []struct {
+		n     int
+		label string
+	}{
+		{s.Active, "active"},
+		{s.Approval, "approval"},
+		{s.Ready, "ready"},
+	} {
+		if c.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", c.n, c.label))
+		}
+	}
+	return strings.Join(parts, " • ")
+}
+
+// renderFleetSummary writes the summary as one labeled count per line.
+func renderFleetSummary(w io.Writer, s fleetSummary, now time.Time) {
+	_, _ = fmt.Fprintf(w, "Sessions:  %d\n", s.Sessions)
+	if s.UpdatedAt == nil {
+		_, _ = fmt.Fprintln(w, "Statuses:  unavailable (no hive TUI is polling)")
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Active:    %d\n", s.Active)
+	_, _ = fmt.Fprintf(w, "Approval:  %d\n", s.Approval)
+	_, _ = fmt.Fprintf(w, "Ready:     %d\n", s.Ready)
+	_, _ = fmt.Fprintf(w, "Missing:   %d\n", s.Missing)
+	if s.Unknown > 0 {
+		_, _ = fmt.Fprintf(w, "Unknown:   %d\n", s.Unknown)
+	}
+	_, _ = fmt.Fprintf(w, "Updated:   %s ago\n", now.Sub(*s.UpdatedAt).Round(time.Second))
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/internal/data/stores"
+	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/pkg/executil/executiltest"
+	"github.com/colonyops/hive/pkg/tmpl"
+)
+
+func newStatusApp(t *testing.T, states ...session.State) *hive.App {
+	t.Helper()
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+
+	store := stores.NewSessionStore(database)
+	for i, state := range states {
+		id := string(rune('a' + i))
+		require.NoError(t, store.Save(context.Background(), session.Session{ID: id, Name: id, Slug: id, State: state}))
+	}
+
+	exec := &executiltest.Exec{}
+	cfg := &config.Config{DataDir: t.TempDir()}
+	sessions := hive.NewSessionService(store, git.NewExecutor("git", exec), cfg, nil, exec, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard)
+	return &hive.App{DB: database, Config: cfg, Sessions: sessions, KV: stores.NewKVStore(database)}
+}
+
+func runStatus(t *testing.T, app *hive.App, args ...string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	root := &cli.Command{Name: "hive", Writer: &buf}
+	NewStatusCmd(&Flags{}, app).Register(root)
+	require.NoError(t, root.Run(context.Background(), append([]string{"hive", "status"}, args...)))
+	return buf.String()
+}
+
+func TestStatus_Short(t *testing.T) {
+	app := newStatusApp(t, session.StateActive, session.StateActive, session.StateActive, session.StateActive, session.StateRecycled)
+	require.NoError(t, app.KV.Set(context.Background(), terminal.StatusSnapshotKey, terminal.StatusSnapshot{
+		UpdatedAt: time.Now(),
+		Statuses: map[string]terminal.Status{
+			"a": terminal.StatusActive,
+			"b": terminal.StatusActive,
+			"c": terminal.StatusApproval,
+			"d": terminal.StatusMissing,
+			"e": terminal.StatusReady, // recycled sessions are not counted
+		},
+	}))
+
+	assert.Equal(t, "4 sessions • 2 active • 1 approval\n", runStatus(t, app, "--short"))
+
+	var summary fleetSummary
+	require.NoError(t, json.Unmarshal([]byte(runStatus(t, app, "--json")), &summary))
+	assert.Equal(t, 4, summary.Sessions)
+	assert.Equal(t, 1, summary.Missing)
+	assert.NotNil(t, summary.UpdatedAt)
+}
+
+func TestStatus_NoSnapshot(t *testing.T) {
+	app := newStatusApp(t, session.StateActive)
+
+	assert.Equal(t, "1 session\n", runStatus(t, app, "--short"))
+	assert.Contains(t, runStatus(t, app), "Statuses:  unavailable")
+
+	var summary fleetSummary
+	require.NoError(t, json.Unmarshal([]byte(runStatus(t, app, "--json")), &summary))
+	assert.Equal(t, 1, summary.Unknown)
+	assert.Nil(t, summary.UpdatedAt)
+}
@@ -81,7 +81,7 @@ func (cmd *TuiCmd) run(ctx context.Context, _ *cli.Command) error {
 
 	// Create terminal integration manager (tmux always enabled)
 	termMgr := terminal.NewManager([]string{"tmux"})
-	tmuxIntegration := newTmuxIntegration(cmd.app.Config)
+	tmuxIntegration := newTmuxIntegration(cmd.app.Config, cmd.app.Remote)
 	if tmuxIntegration.Available() {
 		termMgr.Register(tmuxIntegration)
 	}
@@ -106,6 +106,8 @@ func (cmd *TuiCmd) run(ctx context.Context, _ *cli.Command) error {
 		DoctorService: cmd.app.Doctor,
 		Honeycomb:     cmd.app.Honeycomb,
 		Sources:       cmd.app.Sources,
+		Workers:       cmd.app.Workers,
+		Exec:          cmd.app.Exec,
 	}
 	opts := tui.Opts{
 		LocalRemote: localRemote,
@@ -0,0 +1,307 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/colonyops/hive/internal/core/messaging"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+	terminaltmux "github.com/colonyops/hive/internal/core/terminal/tmux"
+	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/pkg/iojson"
+)
+
+// Exit codes for hive wait outcomes other than success. They start at 10 so
+// they never collide with the error exit codes in errors.go.
+const (
+	waitExitTimeout = 10
+	waitExitMessage = 11
+	waitExitGone    = 12
+)
+
+// waitStatuses lists the terminal statuses --status accepts.
+var waitStatuses = []terminal.Status{terminal.StatusReady, terminal.StatusActive, terminal.StatusApproval, terminal.StatusMissing}
+
+type WaitCmd struct {
+	flags *Flags
+	app   *hive.App
+
+	session   string
+	statuses  []string
+	timeout   time.Duration
+	messageOn string
+	interval  time.Duration
+
+	// statusOf reports a session's current terminal status; overridden in tests.
+	statusOf func(ctx context.Context, sess *session.Session) terminal.Status
+}
+
+// NewWaitCmd creates a new wait command.
+func NewWaitCmd(flags *Flags, app *hive.App) *WaitCmd {
+	return &WaitCmd{flags: flags, app: app}
+}
+
+// Register adds the wait command to the application.
+func (cmd *WaitCmd) Register(app *cli.Command) *cli.Command {
+	app.Commands = append(app.Commands, &cli.Command{
+		Name:      "wait",
+		Usage:     "Block until a session reaches a status or a message arrives",
+		UsageText: "hive wait [--session <id> [--status <status>]] [--message-on <topic>] [--timeout <duration>]",
+		Description: `Waits for an agent in a session to reach a terminal status, for a new
+message on a topic, or both — whichever happens first. Statuses are read from
+tmux directly, so no hive TUI needs to be running. If the session already has
+the status, wait returns immediately.
+
+Prints one JSON line describing what happened and exits with:
+  0   the session reached the status
+  10  the timeout elapsed
+  11  a message arrived on --message-on (the message is included)
+  12  the session was deleted, recycled, or archived
+
+Failures exit with the usual error codes, e.g. 5 if --session matches no
+session.
+
+Statuses: ready, active, approval, missing. Repeat --status (or separate
+with commas) to accept any of several.
+
+Examples:
+  hive wait --session abc123 --timeout 30m
+  hive wait --session abc123 --status approval,ready
+  hive wait --session abc123 --message-on agent.abc123.done
+  hive wait --message-on handoff --timeout 1h`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "session",
+				Aliases:     []string{"s"},
+				Usage:       "session ID, name, or slug to watch",
+				Destination: &cmd.session,
+			},
+			&cli.StringSliceFlag{
+				Name:        "status",
+				Usage:       "status to wait for (default: ready)",
+				Destination: &cmd.statuses,
+			},
+			&cli.DurationFlag{
+				Name:        "timeout",
+				Usage:       "give up after this long (0 waits forever)",
+				Value:       30 * time.Minute,
+				Destination: &cmd.timeout,
+			},
+			&cli.StringFlag{
+				Name:        "message-on",
+				Usage:       "also return when a new message is published to this topic",
+				Destination: &cmd.messageOn,
+			},
+			&cli.DurationFlag{
+				Name:        "interval",
+				Usage:       "how often to check (default: tmux.poll_interval)",
+				Destination: &cmd.interval,
+			},
+		},
+		Action: cmd.run,
+	})
+
+	return app
+}
+
+// waitResult is the JSON line printed when wait returns.
+type waitResult struct {
+	Result    string             `json:"result"` // "status", "message", "timeout", or "gone"
+	SessionID string             `json:"session_id,omitempty"`
+	Status    terminal.Status    `json:"status,omitempty"`
+	State     session.State      `json:"state,omitempty"`
+	Topic     string             `json:"topic,omitempty"`
+	Message   *messaging.Message `json:"message,omitempty"`
+	Elapsed   string             `json:"elapsed"`
+}
+
+func (cmd *WaitCmd) run(ctx context.Context, c *cli.Command) error {
+	if cmd.session == "" && cmd.messageOn == "" {
+		return fmt.Errorf("nothing to wait for: provide --session, --message-on, or both")
+	}
+	if cmd.session == "" && len(cmd.statuses) > 0 {
+		return fmt.Errorf("--status requires --session")
+	}
+	if messaging.IsTopicPattern(cmd.messageOn) {
+		return fmt.Errorf("--message-on requires an exact topic")
+	}
+
+	want, err := parseWaitStatuses(cmd.statuses)
+	if err != nil {
+		return err
+	}
+
+	var sess *session.Session
+	if cmd.session != "" {
+		sess, err = resolveSessionRef(ctx, cmd.app, cmd.session)
+		if err != nil {
+			return err
+		}
+	}
+
+	var cursor messaging.Cursor
+	if cmd.messageOn != "" {
+		cursor, err = cmd.app.Messages.Head(ctx, cmd.messageOn)
+		if err != nil {
+			return fmt.Errorf("read topic head: %w", err)
+		}
+	}
+
+	statusOf := cmd.statusOf
+	if statusOf == nil && sess != nil {
+		statusOf = tmuxStatusFunc(cmd.app)
+	}
+
+	interval := cmd.interval
+	if interval <= 0 {
+		interval = cmd.app.Config.Tmux.PollInterval
+	}
+	if interval <= 0 {
+		interval = 1500 * time.Millisecond
+	}
+
+	start := time.Now()
+	var deadline <-chan time.Time
+	if cmd.timeout > 0 {
+		timer := time.NewTimer(cmd.timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	finish := func(res waitResult, code int) error {
+		res.Elapsed = time.Since(start).Round(time.Second).String()
+		if err := iojson.WriteLine(c.Root().Writer, res); err != nil {
+			return err
+		}
+		if code == 0 {
+			return nil
+		}
+		return cli.Exit("", code)
+	}
+
+	for {
+		if cmd.messageOn != "" {
+			messages, err := cmd.app.Messages.SubscribeAfter(ctx, cmd.messageOn, cursor)
+			if err != nil && !errors.Is(err, messaging.ErrTopicNotFound) {
+				return fmt.Errorf("subscribe: %w", err)
+			}
+			if len(messages) > 0 {
+				return finish(waitResult{Result: "message", Topic: cmd.messageOn, Message: &messages[0]}, waitExitMessage)
+			}
+		}
+
+		if sess != nil {
+			current, err := cmd.app.Sessions.GetSession(ctx, sess.ID)
+			if errors.Is(err, session.ErrNotFound) {
+				return finish(waitResult{Result: "gone", SessionID: sess.ID}, waitExitGone)
+			}
+			if err != nil {
+				return fmt.Errorf("get session: %w", err)
+			}
+			if current.State != session.StateActive {
+				return finish(waitResult{Result: "gone", SessionID: sess.ID, State: current.State}, waitExitGone)
+			}
+			if status := statusOf(ctx, &current); slices.Contains(want, status) {
+				return finish(waitResult{Result: "status", SessionID: sess.ID, Status: status}, 0)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			res := waitResult{Result: "timeout", Topic: cmd.messageOn}
+			if sess != nil {
+				res.SessionID = sess.ID
+			}
+			return finish(res, waitExitTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// parseWaitStatuses validates --status values, defaulting to ready.
+func parseWaitStatuses(values []string) ([]terminal.Status, error) {
+	var want []terminal.Status
+	for _, v := range values {
+		for part := range strings.SplitSeq(v, ",") {
+			status := terminal.Status(strings.ToLower(strings.TrimSpace(part)))
+			if status == "" {
+				continue
+			}
+			if !slices.Contains(waitStatuses, status) {
+				return nil, fmt.Errorf("invalid --status %q: must be one of ready, active, approval, missing", part)
+			}
+			want = append(want, status)
+		}
+	}
+	if len(want) == 0 {
+		want = []terminal.Status{terminal.StatusReady}
+	}
+	return want, nil
+}
+
+// resolveSessionRef finds a session by ID, then by name or slug among active sessions.
+func resolveSessionRef(ctx context.Context, app *hive.App, ref string) (*session.Session, error) {
+	sess, err := app.Sessions.GetSession(ctx, ref)
+	if err == nil {
+		return &sess, nil
+	}
+	if !errors.Is(err, session.ErrNotFound) {
+		return nil, fmt.Errorf("get session: %w", err)
+	}
+
+	sessions, err := app.Sessions.ListSessions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+	for i := range sessions {
+		s := &sessions[i]
+		if s.State == session.StateActive && (s.Name == ref || s.Slug == ref) {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", session.ErrNotFound, ref)
+}
+
+// tmuxStatusFunc returns a status function that queries tmux on each call.
+func tmuxStatusFunc(app *hive.App) func(ctx context.Context, sess *session.Session) terminal.Status {
+	mgr := terminal.NewManager([]string{"tmux"})
+	if integration := newTmuxIntegration(app.Config, app.Remote); integration.Available() {
+		mgr.Register(integration)
+	}
+
+	return func(ctx context.Context, sess *session.Session) terminal.Status {
+		if !mgr.HasEnabledIntegrations() {
+			return terminal.StatusMissing
+		}
+		mgr.RefreshAll()
+
+		metadata := sess.Metadata
+		if sess.Path != "" {
+			metadata = make(map[string]string, len(sess.Metadata)+1)
+			maps.Copy(metadata, sess.Metadata)
+			metadata[terminaltmux.SessionPathKey] = sess.Path
+		}
+
+		info, integration, err := mgr.DiscoverSession(ctx, sess.Slug, metadata)
+		if err != nil || info == nil || integration == nil {
+			return terminal.StatusMissing
+		}
+		status, err := integration.GetStatus(ctx, info)
+		if err != nil {
+			return terminal.StatusMissing
+		}
+		return status
+	}
+}
@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/colonyops/hive/internal/core/eventbus/testbus"
+	"github.com/colonyops/hive/internal/core/messaging"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/data/stores"
+	"github.com/colonyops/hive/internal/hive"
+)
+
+func newWaitApp(t *testing.T, states ...session.State) *hive.App {
+	t.Helper()
+	app := newStatusApp(t, states...)
+	app.Messages = hive.NewMessageService(stores.NewMessageStore(app.DB, 0), app.Config, testbus.New(t).EventBus)
+	return app
+}
+
+// runWait runs hive wait with a scripted status sequence; the last status repeats.
+func runWait(t *testing.T, app *hive.App, statuses []terminal.Status, args ...string) (waitResult, int) {
+	t.Helper()
+	var buf bytes.Buffer
+	exitCode := 0
+	root := &cli.Command{
+		Name:   "hive",
+		Writer: &buf,
+		ExitErrHandler: func(_ context.Context, _ *cli.Command, err error) {
+			var coder cli.ExitCoder
+			if errors.As(err, &coder) {
+				exitCode = coder.ExitCode()
+			}
+		},
+	}
+
+	cmd := NewWaitCmd(&Flags{}, app)
+	calls := 0
+	cmd.statusOf = func(context.Context, *session.Session) terminal.Status {
+		status := statuses[min(calls, len(statuses)-1)]
+		calls++
+		return status
+	}
+	cmd.Register(root)
+
+	err := root.Run(context.Background(), append([]string{"hive", "wait", "--interval", "5ms"}, args...))
+	if exitCode == 0 {
+		require.NoError(t, err)
+	}
+
+	var res waitResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &res), "output: %s", buf.String())
+	return res, exitCode
+}
+
+func TestWait_StatusReached(t *testing.T) {
+	app := newWaitApp(t, session.StateActive)
+
+	res, code := runWait(t, app, []terminal.Status{terminal.StatusActive, terminal.StatusActive, terminal.StatusReady}, "--session", "a")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "status", res.Result)
+	assert.Equal(t, "a", res.SessionID)
+	assert.Equal(t, terminal.StatusReady, res.Status)
+}
+
+func TestWait_AnyOfSeveralStatuses(t *testing.T) {
+	app := newWaitApp(t, session.StateActive)
+
+	res, code := runWait(t, app, []terminal.Status{terminal.StatusActive, terminal.StatusApproval}, "--session", "a", "--status", "approval,ready")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, terminal.StatusApproval, res.Status)
+}
+
+func TestWait_Timeout(t *testing.T) {
+	app := newWaitApp(t, session.StateActive)
+
+	res, code := runWait(t, app, []terminal.Status{terminal.StatusActive}, "--session", "a", "--timeout", "30ms")
+	assert.Equal(t, waitExitTimeout, code)
+	assert.Equal(t, "timeout", res.Result)
+}
+
+func TestWait_SessionGone(t *testing.T) {
+	app := newWaitApp(t, session.StateActive, session.StateRecycled)
+
+	res, code := runWait(t, app, []terminal.Status{terminal.StatusActive}, "--session", "b")
+	assert.Equal(t, waitExitGone, code)
+	assert.Equal(t, "gone", res.Result)
+	assert.Equal(t, session.StateRecycled, res.State)
+}
+
+func TestWait_UnknownSession(t *testing.T) {
+	app := newWaitApp(t, session.StateActive)
+
+	cmd := NewWaitCmd(&Flags{}, app)
+	root := &cli.Command{Name: "hive", Writer: &bytes.Buffer{}}
+	cmd.Register(root)
+
+	err := root.Run(context.Background(), []string{"hive", "wait", "--session", "nope"})
+	require.Error(t, err)
+	assert.Equal(t, ExitCodeSessionNotFound, ExitCode(err))
+}
+
+func TestWait_MessageArrives(t *testing.T) {
+	app := newWaitApp(t, session.StateActive)
+	ctx := context.Background()
+
+	// Messages published before wait starts are ignored.
+	_, err := app.Messages.Publish(ctx, messaging.Message{Payload: "old"}, []string{"handoff"})
+	require.NoError(t, err)
+
+	published := false
+	statuses := []terminal.Status{terminal.StatusActive}
+	var buf bytes.Buffer
+	exitCode := 0
+	root := &cli.Command{
+		Name:   "hive",
+		Writer: &buf,
+		ExitErrHandler: func(_ context.Context, _ *cli.Command, err error) {
+			var coder cli.ExitCoder
+			if errors.As(err, &coder) {
+				exitCode = coder.ExitCode()
+			}
+		},
+	}
+	cmd := NewWaitCmd(&Flags{}, app)
+	cmd.statusOf = func(context.Context, *session.Session) terminal.Status {
+		if !published {
+			_, err := app.Messages.Publish(ctx, messaging.Message{Payload: "done"}, []string{"handoff"})
+			require.NoError(t, err)
+			published = true
+		}
+		return statuses[0]
+	}
+	cmd.Register(root)
+	_ = root.Run(ctx, []string{"hive", "wait", "--interval", "5ms", "--timeout", "5s", "--session", "a", "--message-on", "handoff"})
+
+	var res waitResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+	assert.Equal(t, waitExitMessage, exitCode)
+	assert.Equal(t, "message", res.Result)
+	require.NotNil(t, res.Message)
+	assert.Equal(t, "done", res.Message.Payload)
+}
+
+func TestWait_Validation(t *testing.T) {
+	app := newWaitApp(t, session.StateActive)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "nothing to wait for", args: nil, want: "nothing to wait for"},
+		{name: "status without session", args: []string{"--status", "ready", "--message-on", "x"}, want: "--status requires --session"},
+		{name: "invalid status", args: []string{"--session", "a", "--status", "done"}, want: "invalid --status"},
+		{name: "wildcard topic", args: []string{"--message-on", "agent.*"}, want: "exact topic"},
+		{name: "unknown session", args: []string{"--session", "nope"}, want: "session not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := &cli.Command{Name: "hive", Writer: &bytes.Buffer{}}
+			NewWaitCmd(&Flags{}, app).Register(root)
+			err := root.Run(context.Background(), append([]string{"hive", "wait", "--timeout", time.Second.String()}, tt.args...))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
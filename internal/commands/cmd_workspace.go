@@ -31,6 +31,8 @@ func (cmd *WorkspaceCmd) Register(app *cli.Command) *cli.Command {
 		Usage: "Workspace management commands",
 		Commands: []*cli.Command{
 			cmd.listCmd(),
+			cmd.exportCmd(),
+			cmd.importCmd(),
 		},
 	})
 	return app
@@ -0,0 +1,216 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"charm.land/huh/v2"
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
+)
+
+func (cmd *WorkspaceCmd) exportCmd() *cli.Command {
+	var output string
+	return &cli.Command{
+		Name:      "export",
+		Usage:     "Export shareable config as a workspace bundle",
+		UsageText: "hive workspace export [--output FILE]",
+		Description: `Writes a sanitized bundle of the config file for onboarding teammates:
+agent profiles, rules, user commands, keybindings, view and source
+templates, todo actions and TUI, messaging and plugin settings.
+
+Machine-specific settings (workspaces, context paths, database, git and
+copy commands, history) and all sessions are left out. Values that look
+like secrets are replaced with <redacted>, and paths under your home
+directory are rewritten to ~/.
+
+Examples:
+  hive workspace export > team.yaml
+  hive workspace export -o team.yaml`,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "output",
+				Aliases:     []string{"o"},
+				Usage:       "write the bundle to a file instead of stdout",
+				Destination: &output,
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			return cmd.runExport(c, output)
+		},
+	}
+}
+
+func (cmd *WorkspaceCmd) runExport(c *cli.Command, output string) error {
+	// Export the fragments the config includes along with it
+	data, err := config.ReadWithIncludes(cmd.flags.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("resolve home directory: %w", err)
+	}
+	bundle, redacted, err := config.ExportBundle(data, home)
+	if err != nil {
+		return fmt.Errorf("export bundle: %w", err)
+	}
+
+	for _, path := range redacted {
+		_, _ = fmt.Fprintf(c.Root().ErrWriter, "redacted %s\n", path)
+	}
+
+	if output == "" {
+		_, err = c.Root().Writer.Write(bundle)
+		return err
+	}
+	if err := os.WriteFile(output, bundle, 0o644); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	_, _ = fmt.Fprintf(c.Root().ErrWriter, "Wrote %s\n", output)
+	return nil
+}
+
+func (cmd *WorkspaceCmd) importCmd() *cli.Command {
+	var overwrite, dryRun bool
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "Merge a workspace bundle into your config",
+		UsageText: "hive workspace import [--overwrite] [--dry-run] <file|->",
+		Description: `Merges a bundle created by 'hive workspace export' into the config file.
+
+Settings missing from your config are added and rules are appended unless an
+identical rule already exists. When a setting differs, you are asked which
+version to keep; without a terminal (or when reading the bundle from stdin)
+your current value is kept unless --overwrite is set.
+
+The merged config is validated before it is written. Comments in your config
+file are preserved.
+
+Examples:
+  hive workspace import team.yaml
+  hive workspace import --dry-run team.yaml
+  curl -s https://example.com/team.yaml | hive workspace import --overwrite -`,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:        "overwrite",
+				Usage:       "replace conflicting settings with the bundle's without asking",
+				Destination: &overwrite,
+			},
+			&cli.BoolFlag{
+				Name:        "dry-run",
+				Usage:       "show what would change without writing the config",
+				Destination: &dryRun,
+			},
+		},
+		Action: func(ctx context.Context, c *cli.Command) error {
+			if c.Args().Len() != 1 {
+				return fmt.Errorf("expected a bundle file (or - for stdin)")
+			}
+			path := c.Args().First()
+
+			var resolve func(config.BundleConflict) bool
+			switch {
+			case overwrite:
+				resolve = func(config.BundleConflict) bool { return true }
+			case !dryRun && path != "-" && term.IsTerminal(int(os.Stdin.Fd())):
+				resolve = promptBundleConflict
+			}
+			return cmd.runImport(c, path, resolve, dryRun)
+		},
+	}
+}
+
+func (cmd *WorkspaceCmd) runImport(c *cli.Command, path string, resolve func(config.BundleConflict) bool, dryRun bool) error {
+	var (
+		bundle []byte
+		err    error
+	)
+	if path == "-" {
+		bundle, err = io.ReadAll(os.Stdin)
+	} else {
+		bundle, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("read bundle: %w", err)
+	}
+
+	cfgPath := cmd.flags.ConfigPath
+	if cfgPath == "" {
+		cfgPath = defaultConfigPath()
+	}
+	current, err := os.ReadFile(cfgPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	merged, result, err := config.MergeBundle(current, bundle, resolve)
+	if err != nil {
+		return fmt.Errorf("merge bundle: %w", err)
+	}
+
+	w := c.Root().Writer
+	printBundleResult(w, result)
+
+	if !result.Changed() {
+		_, _ = fmt.Fprintln(w, "Config already up to date")
+		return nil
+	}
+	if dryRun {
+		_, _ = fmt.Fprintf(w, "Dry run: %s not modified\n", cfgPath)
+		return nil
+	}
+
+	err = config.WriteFile(cfgPath, merged, func(tmp string) error {
+		if _, err := config.Load(tmp, cmd.flags.DataDir); err != nil {
+			return fmt.Errorf("merged config is invalid, %s not modified: %w", cfgPath, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(w, "Updated %s\n", cfgPath)
+	if bytes.Contains(merged, []byte(config.BundleRedacted)) {
+		_, _ = fmt.Fprintf(c.Root().ErrWriter, "warning: %s contains %s placeholders; replace them with your own values\n", cfgPath, config.BundleRedacted)
+	}
+	return nil
+}
+
+// printBundleResult writes one line per setting touched by an import.
+func printBundleResult(w io.Writer, r config.BundleMergeResult) {
+	for _, p := range r.Added {
+		_, _ = fmt.Fprintf(w, "+ %s\n", p)
+	}
+	for _, p := range r.Replaced {
+		_, _ = fmt.Fprintf(w, "~ %s\n", p)
+	}
+	for _, p := range r.Kept {
+		_, _ = fmt.Fprintf(w, "= %s (kept current)\n", p)
+	}
+	for _, p := range r.Skipped {
+		_, _ = fmt.Fprintf(w, "- %s (not shareable, ignored)\n", p)
+	}
+}
+
+// promptBundleConflict asks whether to replace a conflicting setting with the
+// bundle's version. Aborting the prompt keeps the current value.
+func promptBundleConflict(c config.BundleConflict) bool {
+	replace := false
+	err := huh.NewConfirm().
+		Title(fmt.Sprintf("%s differs. Use the bundle's version?", c.Path)).
+		Description(fmt.Sprintf("Current:\n%s\n\nBundle:\n%s", c.Current, c.Incoming)).
+		Affirmative("Use bundle").
+		Negative("Keep current").
+		Value(&replace).
+		WithTheme(huh.ThemeFunc(huhThemeTokyoNight)).
+		Run()
+	return err == nil && replace
+}
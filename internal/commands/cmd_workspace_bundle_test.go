@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/colonyops/hive/internal/hive"
+)
+
+func runWorkspace(t *testing.T, flags *Flags, args ...string) (string, error) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	app := &cli.Command{Name: "hive", Writer: &out, ErrWriter: &errOut}
+	NewWorkspaceCmd(flags, &hive.App{}).Register(app)
+
+	err := app.Run(context.Background(), append([]string{"hive", "workspace"}, args...))
+	return out.String(), err
+}
+
+func TestWorkspaceExportImport(t *testing.T) {
+	dir := t.TempDir()
+	teamCfg := filepath.Join(dir, "team.yaml")
+	require.NoError(t, os.WriteFile(teamCfg, []byte(`workspaces: [/src]
+usercommands:
+  review:
+    sh: hive review
+`), 0o644))
+
+	bundlePath := filepath.Join(dir, "bundle.yaml")
+	_, err := runWorkspace(t, &Flags{ConfigPath: teamCfg}, "export", "-o", bundlePath)
+	require.NoError(t, err)
+
+	myCfg := filepath.Join(dir, "mine.yaml")
+	require.NoError(t, os.WriteFile(myCfg, []byte("# mine\nworkspaces: [/home/me/code]\n"), 0o644))
+	flags := &Flags{ConfigPath: myCfg, DataDir: filepath.Join(dir, "data")}
+
+	out, err := runWorkspace(t, flags, "import", "--dry-run", bundlePath)
+	require.NoError(t, err)
+	assert.Contains(t, out, "+ usercommands\n", "whole sections missing from the config are added")
+	assert.Contains(t, out, "Dry run")
+	unchanged, err := os.ReadFile(myCfg)
+	require.NoError(t, err)
+	assert.Equal(t, "# mine\nworkspaces: [/home/me/code]\n", string(unchanged))
+
+	out, err = runWorkspace(t, flags, "import", bundlePath)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Updated "+myCfg)
+	merged, err := os.ReadFile(myCfg)
+	require.NoError(t, err)
+	assert.Contains(t, string(merged), "# mine")
+	assert.Contains(t, string(merged), "sh: hive review")
+	assert.Contains(t, string(merged), "/home/me/code", "machine settings are untouched")
+
+	out, err = runWorkspace(t, flags, "import", bundlePath)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Config already up to date")
+}
+
+func TestWorkspaceImport_InvalidResult(t *testing.T) {
+	dir := t.TempDir()
+	bundlePath := filepath.Join(dir, "bundle.yaml")
+	require.NoError(t, os.WriteFile(bundlePath, []byte("agents:\n  default: missing\n"), 0o644))
+
+	myCfg := filepath.Join(dir, "mine.yaml")
+	require.NoError(t, os.WriteFile(myCfg, []byte("tui:\n  theme: tokyo-night\n"), 0o644))
+
+	_, err := runWorkspace(t, &Flags{ConfigPath: myCfg, DataDir: dir}, "import", bundlePath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not modified")
+
+	got, err := os.ReadFile(myCfg)
+	require.NoError(t, err)
+	assert.Equal(t, "tui:\n  theme: tokyo-night\n", string(got))
+	assert.NoFileExists(t, myCfg+".tmp")
+}
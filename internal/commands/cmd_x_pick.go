@@ -21,6 +21,7 @@ import (
 	terminaltmux "github.com/colonyops/hive/internal/core/terminal/tmux"
 	"github.com/colonyops/hive/internal/core/tmux"
 	"github.com/colonyops/hive/internal/tui/components"
+	"github.com/colonyops/hive/internal/tui/components/input"
 	"github.com/colonyops/hive/pkg/iojson"
 	"github.com/urfave/cli/v3"
 )
@@ -112,7 +113,7 @@ type pickModel struct {
 }
 
 func newPickModel(baseItems, items []pickItem, currentSlug string, termMgr *terminal.Manager, pollInterval time.Duration, recentsMap map[string]time.Time, statusFilter string) pickModel {
-	ti := textinput.New()
+	ti := input.NewTextInput()
 	ti.Prompt = "/ "
 	ti.CharLimit = 64
 
@@ -567,7 +568,7 @@ func (cmd *ExperimentalCmd) pickCmd() *cli.Command {
 
 			// Create terminal manager (same as TUI) since cmd.app.Terminal is nil at app level
 			termMgr := terminal.NewManager([]string{"tmux"})
-			tmuxIntegration := newTmuxIntegration(cmd.app.Config)
+			tmuxIntegration := newTmuxIntegration(cmd.app.Config, cmd.app.Remote)
 			if tmuxIntegration.Available() {
 				termMgr.Register(tmuxIntegration)
 			}
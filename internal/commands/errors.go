@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"errors"
+	"io"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/pkg/iojson"
+)
+
+// ErrorKind classifies a command failure so scripts can branch on the cause
+// instead of parsing error messages.
+type ErrorKind string
+
+const (
+	ErrorKindUnknown         ErrorKind = "error"
+	ErrorKindConfig          ErrorKind = "config"
+	ErrorKindGit             ErrorKind = "git"
+	ErrorKindSessionNotFound ErrorKind = "session_not_found"
+	ErrorKindDBLocked        ErrorKind = "db_locked"
+)
+
+// Exit codes returned by hive for each error kind. 0 is success and 2 is
+// left for usage errors.
+const (
+	ExitCodeError           = 1
+	ExitCodeConfig          = 3
+	ExitCodeGit             = 4
+	ExitCodeSessionNotFound = 5
+	ExitCodeDBLocked        = 6
+)
+
+var exitCodes = map[ErrorKind]int{
+	ErrorKindUnknown:         ExitCodeError,
+	ErrorKindConfig:          ExitCodeConfig,
+	ErrorKindGit:             ExitCodeGit,
+	ErrorKindSessionNotFound: ExitCodeSessionNotFound,
+	ErrorKindDBLocked:        ExitCodeDBLocked,
+}
+
+// kindError tags an error with a kind that cannot be inferred from its chain.
+type kindError struct {
+	kind ErrorKind
+	err  error
+}
+
+func (e *kindError) Error() string { return e.err.Error() }
+
+func (e *kindError) Unwrap() error { return e.err }
+
+// WithKind tags err with kind. It returns nil if err is nil.
+func WithKind(kind ErrorKind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &kindError{kind: kind, err: err}
+}
+
+// KindOf returns the kind of err. Explicit WithKind tags win over kinds
+// inferred from the error chain.
+func KindOf(err error) ErrorKind {
+	var ke *kindError
+	var gitErr *git.Error
+	switch {
+	case errors.As(err, &ke):
+		return ke.kind
+	case db.IsLocked(err):
+		return ErrorKindDBLocked
+	case errors.Is(err, session.ErrNotFound):
+		return ErrorKindSessionNotFound
+	case errors.As(err, &gitErr):
+		return ErrorKindGit
+	default:
+		return ErrorKindUnknown
+	}
+}
+
+// ExitCode returns the process exit code for err: 0 for nil, the code carried
+// by a cli.ExitCoder, or the code for the error's kind.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr cli.ExitCoder
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return exitCodes[KindOf(err)]
+}
+
+// errorJSON is the JSON output format for a failed command run with --json.
+type errorJSON struct {
+	Error    string    `json:"error"`
+	Kind     ErrorKind `json:"kind"`
+	ExitCode int       `json:"exit_code"`
+}
+
+// WriteErrorJSON writes err as a single JSON line.
+func WriteErrorJSON(w io.Writer, err error) error {
+	return iojson.WriteLine(w, errorJSON{
+		Error:    err.Error(),
+		Kind:     KindOf(err),
+		ExitCode: ExitCode(err),
+	})
+}
+
+// WantsJSON reports whether args request JSON output with --json, ignoring
+// anything after a "--" terminator.
+func WantsJSON(args []string) bool {
+	for _, arg := range args {
+		switch arg {
+		case "--":
+			return false
+		case "--json", "--json=true":
+			return true
+		}
+	}
+	return false
+}
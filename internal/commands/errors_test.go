@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/session"
+)
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind ErrorKind
+		code int
+	}{
+		{"plain", errors.New("boom"), ErrorKindUnknown, ExitCodeError},
+		{"tagged", WithKind(ErrorKindConfig, errors.New("bad yaml")), ErrorKindConfig, ExitCodeConfig},
+		{"git", fmt.Errorf("recycle: %w", &git.Error{Op: "pull", Err: errors.New("exit status 1")}), ErrorKindGit, ExitCodeGit},
+		{"jj", fmt.Errorf("recycle: %w", &git.Error{Tool: "jj", Op: "git fetch", Err: errors.New("exit status 1")}), ErrorKindGit, ExitCodeGit},
+		{"not found", fmt.Errorf("get session: %w", session.ErrNotFound), ErrorKindSessionNotFound, ExitCodeSessionNotFound},
+		{"exit coder", cli.Exit("", 7), ErrorKindUnknown, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.kind, KindOf(tt.err))
+			assert.Equal(t, tt.code, ExitCode(tt.err))
+		})
+	}
+
+	assert.Equal(t, 0, ExitCode(nil))
+	assert.NoError(t, WithKind(ErrorKindConfig, nil))
+}
+
+func TestSessionShow_NotFoundKind(t *testing.T) {
+	app := newStatusApp(t)
+
+	err := runSession(t, app, "show", "missing")
+	require.Error(t, err)
+	assert.Equal(t, ErrorKindSessionNotFound, KindOf(err))
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteErrorJSON(&buf, err))
+	assert.Contains(t, buf.String(), `"kind":"session_not_found"`)
+	assert.Contains(t, buf.String(), `"exit_code":5`)
+}
+
+func TestWantsJSON(t *testing.T) {
+	assert.True(t, WantsJSON([]string{"session", "show", "--json", "abc"}))
+	assert.False(t, WantsJSON([]string{"session", "show", "abc"}))
+	assert.False(t, WantsJSON([]string{"batch", "--", "--json"}))
+}
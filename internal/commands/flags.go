@@ -8,6 +8,7 @@ type Flags struct {
 	ConfigPath   string
 	DataDir      string
 	ProfilerPort int
+	Host         string // --host: name of the remote host from the hosts config
 }
 
 // ResolvedLogFile returns the log file path in use: the explicit --log-file
@@ -5,14 +5,20 @@ import (
 
 	"github.com/colonyops/hive/internal/core/config"
 	terminaltmux "github.com/colonyops/hive/internal/core/terminal/tmux"
+	"github.com/colonyops/hive/pkg/executil"
 )
 
-func newTmuxIntegration(cfg *config.Config) *terminaltmux.Integration {
+// newTmuxIntegration creates the tmux integration. A non-nil remote runs tmux
+// through it, for sessions on another host.
+func newTmuxIntegration(cfg *config.Config, remote executil.Executor) *terminaltmux.Integration {
+	var options []terminaltmux.Option
+	if remote != nil {
+		options = append(options, terminaltmux.WithExecutor(remote))
+	}
 	if cfg == nil {
-		return terminaltmux.NewFromPreviewMatchers(nil)
+		return terminaltmux.NewFromPreviewMatchers(nil, options...)
 	}
 
-	var options []terminaltmux.Option
 	if cfg.Tmux.CaptureRecording.Enabled {
 		recorder, err := terminaltmux.NewJSONCaptureRecorder(cfg.TmuxCaptureRecordingsDir())
 		if err != nil {
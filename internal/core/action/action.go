@@ -64,6 +64,7 @@ type Action struct {
 	SessionName   string // Session display name (for tmux actions)
 	SessionPath   string
 	SessionRemote string // Session remote URL (for tmux actions)
+	SessionAgent  string // Agent profile the session was spawned with (for tmux actions)
 	// TmuxWindow carries the resolved tmux target (window name/index or pane ID)
 	// for TmuxOpen and TmuxStart actions. The name predates pane-level targeting;
 	// SpawnWindowsPayload.TmuxTarget is preferred for new spawn actions.
@@ -82,13 +83,18 @@ func (a Action) NeedsConfirm() bool {
 // Shell, None, and DeleteRecycledBatch are internal-only.
 var configActions = map[Type]bool{
 	TypeRecycle:          true,
+	TypeRecycleReset:     true,
 	TypeDelete:           true,
+	TypeArchive:          true,
+	TypeSync:             true,
 	TypeTmuxOpen:         true,
 	TypeTmuxStart:        true,
 	TypeFilterAll:        true,
 	TypeFilterActive:     true,
 	TypeFilterApproval:   true,
 	TypeFilterReady:      true,
+	TypeFilterAttention:  true,
+	TypeFilterTag:        true,
 	TypeDocReview:        true,
 	TypeNewSession:       true,
 	TypeSetTheme:         true,
@@ -98,10 +104,16 @@ var configActions = map[Type]bool{
 	TypePrevActive:       true,
 	TypeHiveInfo:         true,
 	TypeHiveDoctor:       true,
+	TypeHiveRules:        true,
 	TypeGroupSet:         true,
 	TypeGroupToggle:      true,
+	TypeSortCycle:        true,
+	TypeArchivedToggle:   true,
+	TypeCompare:          true,
+	TypeEditNotes:        true,
 	TypeTodoPanel:        true,
 	TypeOpenSourcePicker: true,
+	TypeBindKey:          true,
 
 	TypeTasksRefresh:       true,
 	TypeTasksFilter:        true,
@@ -6,7 +6,10 @@ package action
 //
 //	None
 //	Recycle
+//	RecycleReset
 //	Delete
+//	Archive
+//	Sync
 //	Shell
 //	TmuxOpen
 //	TmuxStart
@@ -14,6 +17,8 @@ package action
 //	FilterActive
 //	FilterApproval
 //	FilterReady
+//	FilterAttention
+//	FilterTag
 //	DocReview
 //	NewSession
 //	SetTheme
@@ -25,8 +30,13 @@ package action
 //	SpawnWindows
 //	HiveInfo
 //	HiveDoctor
+//	HiveRules
 //	GroupSet
 //	GroupToggle
+//	SortCycle
+//	ArchivedToggle
+//	Compare
+//	EditNotes
 //	TodoPanel
 //	TasksRefresh
 //	TasksFilter
@@ -40,6 +50,7 @@ package action
 //	TasksDelete
 //	TasksPrune
 //	ViewTasks
+//	OpenPlan
 //	DocsCopyPath
 //	DocsCopyRelPath
 //	DocsCopyContents
@@ -47,17 +58,28 @@ package action
 //	DocsTogglePreview
 //	DocsToggleTree
 //	DocsSelectRepo
+//	DocsAddToReview
+//	DocsToggleInstant
+//	DocsToggleComments
+//	DocsOpenRevision
+//	DocsToggleAnnotations
+//	DocsCommentEdits
+//	DocsNextComment
+//	DocsPrevComment
 //	SessionsRefreshGitStatuses
 //	SessionsTogglePreview
 //	SessionsNavigateUp
 //	SessionsNavigateDown
 //	SessionsFilterStart
 //	SessionsCommandPaletteOpen
+//	BoardColumnPrev
+//	BoardColumnNext
 //	GoToTop
 //	GoToBottom
 //	Quit
 //	ShowHelp
 //	OpenSourcePicker
+//	BindKey
 //
 // )
 type Type string
@@ -15,8 +15,14 @@ const (
 	TypeNone Type = "None"
 	// TypeRecycle is a Type of type Recycle.
 	TypeRecycle Type = "Recycle"
+	// TypeRecycleReset is a Type of type RecycleReset.
+	TypeRecycleReset Type = "RecycleReset"
 	// TypeDelete is a Type of type Delete.
 	TypeDelete Type = "Delete"
+	// TypeArchive is a Type of type Archive.
+	TypeArchive Type = "Archive"
+	// TypeSync is a Type of type Sync.
+	TypeSync Type = "Sync"
 	// TypeShell is a Type of type Shell.
 	TypeShell Type = "Shell"
 	// TypeTmuxOpen is a Type of type TmuxOpen.
@@ -31,6 +37,10 @@ const (
 	TypeFilterApproval Type = "FilterApproval"
 	// TypeFilterReady is a Type of type FilterReady.
 	TypeFilterReady Type = "FilterReady"
+	// TypeFilterAttention is a Type of type FilterAttention.
+	TypeFilterAttention Type = "FilterAttention"
+	// TypeFilterTag is a Type of type FilterTag.
+	TypeFilterTag Type = "FilterTag"
 	// TypeDocReview is a Type of type DocReview.
 	TypeDocReview Type = "DocReview"
 	// TypeNewSession is a Type of type NewSession.
@@ -53,10 +63,20 @@ const (
 	TypeHiveInfo Type = "HiveInfo"
 	// TypeHiveDoctor is a Type of type HiveDoctor.
 	TypeHiveDoctor Type = "HiveDoctor"
+	// TypeHiveRules is a Type of type HiveRules.
+	TypeHiveRules Type = "HiveRules"
 	// TypeGroupSet is a Type of type GroupSet.
 	TypeGroupSet Type = "GroupSet"
 	// TypeGroupToggle is a Type of type GroupToggle.
 	TypeGroupToggle Type = "GroupToggle"
+	// TypeSortCycle is a Type of type SortCycle.
+	TypeSortCycle Type = "SortCycle"
+	// TypeArchivedToggle is a Type of type ArchivedToggle.
+	TypeArchivedToggle Type = "ArchivedToggle"
+	// TypeCompare is a Type of type Compare.
+	TypeCompare Type = "Compare"
+	// TypeEditNotes is a Type of type EditNotes.
+	TypeEditNotes Type = "EditNotes"
 	// TypeTodoPanel is a Type of type TodoPanel.
 	TypeTodoPanel Type = "TodoPanel"
 	// TypeTasksRefresh is a Type of type TasksRefresh.
@@ -83,6 +103,8 @@ const (
 	TypeTasksPrune Type = "TasksPrune"
 	// TypeViewTasks is a Type of type ViewTasks.
 	TypeViewTasks Type = "ViewTasks"
+	// TypeOpenPlan is a Type of type OpenPlan.
+	TypeOpenPlan Type = "OpenPlan"
 	// TypeDocsCopyPath is a Type of type DocsCopyPath.
 	TypeDocsCopyPath Type = "DocsCopyPath"
 	// TypeDocsCopyRelPath is a Type of type DocsCopyRelPath.
@@ -97,6 +119,22 @@ const (
 	TypeDocsToggleTree Type = "DocsToggleTree"
 	// TypeDocsSelectRepo is a Type of type DocsSelectRepo.
 	TypeDocsSelectRepo Type = "DocsSelectRepo"
+	// TypeDocsAddToReview is a Type of type DocsAddToReview.
+	TypeDocsAddToReview Type = "DocsAddToReview"
+	// TypeDocsToggleInstant is a Type of type DocsToggleInstant.
+	TypeDocsToggleInstant Type = "DocsToggleInstant"
+	// TypeDocsToggleComments is a Type of type DocsToggleComments.
+	TypeDocsToggleComments Type = "DocsToggleComments"
+	// TypeDocsOpenRevision is a Type of type DocsOpenRevision.
+	TypeDocsOpenRevision Type = "DocsOpenRevision"
+	// TypeDocsToggleAnnotations is a Type of type DocsToggleAnnotations.
+	TypeDocsToggleAnnotations Type = "DocsToggleAnnotations"
+	// TypeDocsCommentEdits is a Type of type DocsCommentEdits.
+	TypeDocsCommentEdits Type = "DocsCommentEdits"
+	// TypeDocsNextComment is a Type of type DocsNextComment.
+	TypeDocsNextComment Type = "DocsNextComment"
+	// TypeDocsPrevComment is a Type of type DocsPrevComment.
+	TypeDocsPrevComment Type = "DocsPrevComment"
 	// TypeSessionsRefreshGitStatuses is a Type of type SessionsRefreshGitStatuses.
 	TypeSessionsRefreshGitStatuses Type = "SessionsRefreshGitStatuses"
 	// TypeSessionsTogglePreview is a Type of type SessionsTogglePreview.
@@ -109,6 +147,10 @@ const (
 	TypeSessionsFilterStart Type = "SessionsFilterStart"
 	// TypeSessionsCommandPaletteOpen is a Type of type SessionsCommandPaletteOpen.
 	TypeSessionsCommandPaletteOpen Type = "SessionsCommandPaletteOpen"
+	// TypeBoardColumnPrev is a Type of type BoardColumnPrev.
+	TypeBoardColumnPrev Type = "BoardColumnPrev"
+	// TypeBoardColumnNext is a Type of type BoardColumnNext.
+	TypeBoardColumnNext Type = "BoardColumnNext"
 	// TypeGoToTop is a Type of type GoToTop.
 	TypeGoToTop Type = "GoToTop"
 	// TypeGoToBottom is a Type of type GoToBottom.
@@ -119,6 +161,8 @@ const (
 	TypeShowHelp Type = "ShowHelp"
 	// TypeOpenSourcePicker is a Type of type OpenSourcePicker.
 	TypeOpenSourcePicker Type = "OpenSourcePicker"
+	// TypeBindKey is a Type of type BindKey.
+	TypeBindKey Type = "BindKey"
 )
 
 var ErrInvalidType = fmt.Errorf("not a valid Type, try [%s]", strings.Join(_TypeNames, ", "))
@@ -126,7 +170,10 @@ var ErrInvalidType = fmt.Errorf("not a valid Type, try [%s]", strings.Join(_Type
 var _TypeNames = []string{
 	string(TypeNone),
 	string(TypeRecycle),
+	string(TypeRecycleReset),
 	string(TypeDelete),
+	string(TypeArchive),
+	string(TypeSync),
 	string(TypeShell),
 	string(TypeTmuxOpen),
 	string(TypeTmuxStart),
@@ -134,6 +181,8 @@ var _TypeNames = []string{
 	string(TypeFilterActive),
 	string(TypeFilterApproval),
 	string(TypeFilterReady),
+	string(TypeFilterAttention),
+	string(TypeFilterTag),
 	string(TypeDocReview),
 	string(TypeNewSession),
 	string(TypeSetTheme),
@@ -145,8 +194,13 @@ var _TypeNames = []string{
 	string(TypeSpawnWindows),
 	string(TypeHiveInfo),
 	string(TypeHiveDoctor),
+	string(TypeHiveRules),
 	string(TypeGroupSet),
 	string(TypeGroupToggle),
+	string(TypeSortCycle),
+	string(TypeArchivedToggle),
+	string(TypeCompare),
+	string(TypeEditNotes),
 	string(TypeTodoPanel),
 	string(TypeTasksRefresh),
 	string(TypeTasksFilter),
@@ -160,6 +214,7 @@ var _TypeNames = []string{
 	string(TypeTasksDelete),
 	string(TypeTasksPrune),
 	string(TypeViewTasks),
+	string(TypeOpenPlan),
 	string(TypeDocsCopyPath),
 	string(TypeDocsCopyRelPath),
 	string(TypeDocsCopyContents),
@@ -167,17 +222,28 @@ var _TypeNames = []string{
 	string(TypeDocsTogglePreview),
 	string(TypeDocsToggleTree),
 	string(TypeDocsSelectRepo),
+	string(TypeDocsAddToReview),
+	string(TypeDocsToggleInstant),
+	string(TypeDocsToggleComments),
+	string(TypeDocsOpenRevision),
+	string(TypeDocsToggleAnnotations),
+	string(TypeDocsCommentEdits),
+	string(TypeDocsNextComment),
+	string(TypeDocsPrevComment),
 	string(TypeSessionsRefreshGitStatuses),
 	string(TypeSessionsTogglePreview),
 	string(TypeSessionsNavigateUp),
 	string(TypeSessionsNavigateDown),
 	string(TypeSessionsFilterStart),
 	string(TypeSessionsCommandPaletteOpen),
+	string(TypeBoardColumnPrev),
+	string(TypeBoardColumnNext),
 	string(TypeGoToTop),
 	string(TypeGoToBottom),
 	string(TypeQuit),
 	string(TypeShowHelp),
 	string(TypeOpenSourcePicker),
+	string(TypeBindKey),
 }
 
 // TypeNames returns a list of possible string values of Type.
@@ -204,8 +270,14 @@ var _TypeValue = map[string]Type{
 	"none":                       TypeNone,
 	"Recycle":                    TypeRecycle,
 	"recycle":                    TypeRecycle,
+	"RecycleReset":               TypeRecycleReset,
+	"recyclereset":               TypeRecycleReset,
 	"Delete":                     TypeDelete,
 	"delete":                     TypeDelete,
+	"Archive":                    TypeArchive,
+	"archive":                    TypeArchive,
+	"Sync":                       TypeSync,
+	"sync":                       TypeSync,
 	"Shell":                      TypeShell,
 	"shell":                      TypeShell,
 	"TmuxOpen":                   TypeTmuxOpen,
@@ -220,6 +292,10 @@ var _TypeValue = map[string]Type{
 	"filterapproval":             TypeFilterApproval,
 	"FilterReady":                TypeFilterReady,
 	"filterready":                TypeFilterReady,
+	"FilterAttention":            TypeFilterAttention,
+	"filterattention":            TypeFilterAttention,
+	"FilterTag":                  TypeFilterTag,
+	"filtertag":                  TypeFilterTag,
 	"DocReview":                  TypeDocReview,
 	"docreview":                  TypeDocReview,
 	"NewSession":                 TypeNewSession,
@@ -242,10 +318,20 @@ var _TypeValue = map[string]Type{
 	"hiveinfo":                   TypeHiveInfo,
 	"HiveDoctor":                 TypeHiveDoctor,
 	"hivedoctor":                 TypeHiveDoctor,
+	"HiveRules":                  TypeHiveRules,
+	"hiverules":                  TypeHiveRules,
 	"GroupSet":                   TypeGroupSet,
 	"groupset":                   TypeGroupSet,
 	"GroupToggle":                TypeGroupToggle,
 	"grouptoggle":                TypeGroupToggle,
+	"SortCycle":                  TypeSortCycle,
+	"sortcycle":                  TypeSortCycle,
+	"ArchivedToggle":             TypeArchivedToggle,
+	"archivedtoggle":             TypeArchivedToggle,
+	"Compare":                    TypeCompare,
+	"compare":                    TypeCompare,
+	"EditNotes":                  TypeEditNotes,
+	"editnotes":                  TypeEditNotes,
 	"TodoPanel":                  TypeTodoPanel,
 	"todopanel":                  TypeTodoPanel,
 	"TasksRefresh":               TypeTasksRefresh,
@@ -272,6 +358,8 @@ var _TypeValue = map[string]Type{
 	"tasksprune":                 TypeTasksPrune,
 	"ViewTasks":                  TypeViewTasks,
 	"viewtasks":                  TypeViewTasks,
+	"OpenPlan":                   TypeOpenPlan,
+	"openplan":                   TypeOpenPlan,
 	"DocsCopyPath":               TypeDocsCopyPath,
 	"docscopypath":               TypeDocsCopyPath,
 	"DocsCopyRelPath":            TypeDocsCopyRelPath,
@@ -286,6 +374,22 @@ var _TypeValue = map[string]Type{
 	"docstoggletree":             TypeDocsToggleTree,
 	"DocsSelectRepo":             TypeDocsSelectRepo,
 	"docsselectrepo":             TypeDocsSelectRepo,
+	"DocsAddToReview":            TypeDocsAddToReview,
+	"docsaddtoreview":            TypeDocsAddToReview,
+	"DocsToggleInstant":          TypeDocsToggleInstant,
+	"docstoggleinstant":          TypeDocsToggleInstant,
+	"DocsToggleComments":         TypeDocsToggleComments,
+	"docstogglecomments":         TypeDocsToggleComments,
+	"DocsOpenRevision":           TypeDocsOpenRevision,
+	"docsopenrevision":           TypeDocsOpenRevision,
+	"DocsToggleAnnotations":      TypeDocsToggleAnnotations,
+	"docstoggleannotations":      TypeDocsToggleAnnotations,
+	"DocsCommentEdits":           TypeDocsCommentEdits,
+	"docscommentedits":           TypeDocsCommentEdits,
+	"DocsNextComment":            TypeDocsNextComment,
+	"docsnextcomment":            TypeDocsNextComment,
+	"DocsPrevComment":            TypeDocsPrevComment,
+	"docsprevcomment":            TypeDocsPrevComment,
 	"SessionsRefreshGitStatuses": TypeSessionsRefreshGitStatuses,
 	"sessionsrefreshgitstatuses": TypeSessionsRefreshGitStatuses,
 	"SessionsTogglePreview":      TypeSessionsTogglePreview,
@@ -298,6 +402,10 @@ var _TypeValue = map[string]Type{
 	"sessionsfilterstart":        TypeSessionsFilterStart,
 	"SessionsCommandPaletteOpen": TypeSessionsCommandPaletteOpen,
 	"sessionscommandpaletteopen": TypeSessionsCommandPaletteOpen,
+	"BoardColumnPrev":            TypeBoardColumnPrev,
+	"boardcolumnprev":            TypeBoardColumnPrev,
+	"BoardColumnNext":            TypeBoardColumnNext,
+	"boardcolumnnext":            TypeBoardColumnNext,
 	"GoToTop":                    TypeGoToTop,
 	"gototop":                    TypeGoToTop,
 	"GoToBottom":                 TypeGoToBottom,
@@ -308,6 +416,8 @@ var _TypeValue = map[string]Type{
 	"showhelp":                   TypeShowHelp,
 	"OpenSourcePicker":           TypeOpenSourcePicker,
 	"opensourcepicker":           TypeOpenSourcePicker,
+	"BindKey":                    TypeBindKey,
+	"bindkey":                    TypeBindKey,
 }
 
 // ParseType attempts to convert a string to a Type.
@@ -0,0 +1,28 @@
+// Package activity aggregates per-day workflow counts for the activity calendar.
+package activity
+
+import (
+	"context"
+	"time"
+)
+
+// Day holds the activity counts for a single calendar day.
+type Day struct {
+	Date       time.Time     // Midnight in the caller's location
+	Sessions   int           // Sessions created
+	Reviews    int           // Review sessions finalized
+	ReviewTime time.Duration // Time spent on the reviews finalized
+	Messages   int           // Messages published
+}
+
+// Total returns the combined activity count for the day.
+func (d Day) Total() int {
+	return d.Sessions + d.Reviews + d.Messages
+}
+
+// Store provides aggregate activity counts.
+type Store interface {
+	// Daily returns one Day per calendar day in [since, until), including days
+	// without activity. Days are bucketed in since's location.
+	Daily(ctx context.Context, since, until time.Time) ([]Day, error)
+}
@@ -8,11 +8,13 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/colonyops/hive/internal/core/action"
+	"github.com/colonyops/hive/internal/core/git"
 	"github.com/colonyops/hive/internal/core/styles"
 	"github.com/colonyops/hive/pkg/pathutil"
 	"github.com/hay-kot/criterio"
@@ -63,6 +65,12 @@ const (
 	CloneStrategyWorktree = "worktree"
 )
 
+// Version control backends selectable per rule.
+const (
+	VCSGit = "git"
+	VCSJJ  = "jj"
+)
+
 // ValidFormTypes lists all valid form field types.
 var ValidFormTypes = []string{FormTypeText, FormTypeTextArea, FormTypeSelect, FormTypeMultiSelect}
 
@@ -91,12 +99,34 @@ var defaultUserCommands = map[string]UserCommand{
 		Confirm: "Are you sure you want to recycle this session?",
 		Scope:   []string{"sessions"},
 	},
+	"RecycleReset": {
+		Action:  action.TypeRecycleReset,
+		Help:    "discard local changes and recycle",
+		Confirm: "Discard all local changes and recycle this session?",
+		Scope:   []string{"sessions"},
+	},
+	"RecycleShell": {
+		Windows: []WindowConfig{{Name: "recycle", Dir: "{{ .Path }}", Focus: true}},
+		Help:    "open a shell window to fix a failed recycle",
+		Scope:   []string{"sessions"},
+	},
 	"Delete": {
 		Action:  action.TypeDelete,
 		Help:    "delete",
 		Confirm: "Are you sure you want to delete this session?",
 		Scope:   []string{"sessions"},
 	},
+	"Sync": {
+		Action: action.TypeSync,
+		Help:   "rebase onto the base branch",
+		Scope:  []string{"sessions"},
+	},
+	"Archive": {
+		Action:  action.TypeArchive,
+		Help:    "archive",
+		Confirm: "Archive this session? Its directory is removed but its record is kept.",
+		Scope:   []string{"sessions"},
+	},
 	"NewSession": {
 		Action: action.TypeNewSession,
 		Help:   "new session",
@@ -133,6 +163,18 @@ var defaultUserCommands = map[string]UserCommand{
 		Silent: true,
 		Scope:  []string{"sessions"},
 	},
+	"FilterAttention": {
+		Action: action.TypeFilterAttention,
+		Help:   "show sessions needing attention (approval, unread messages, reviews)",
+		Silent: true,
+		Scope:  []string{"sessions"},
+	},
+	"FilterTag": {
+		Action: action.TypeFilterTag,
+		Help:   "show sessions with a tag (usage: FilterTag [tag])",
+		Silent: true,
+		Scope:  []string{"sessions"},
+	},
 	"ThemePreview": {
 		Action: action.TypeSetTheme,
 		Help:   "preview theme (" + strings.Join(styles.ThemeNames(), ", ") + ")",
@@ -171,6 +213,16 @@ var defaultUserCommands = map[string]UserCommand{
 		Help:   "run health checks",
 		Silent: true,
 	},
+	"HiveRules": {
+		Action: action.TypeHiveRules,
+		Help:   "show rules matching a remote",
+		Silent: true,
+	},
+	"Bind": {
+		Action: action.TypeBindKey,
+		Help:   "record a key and bind it to a command",
+		Silent: true,
+	},
 	"GroupSet": {
 		Action: action.TypeGroupSet,
 		Help:   "set session group",
@@ -183,6 +235,30 @@ var defaultUserCommands = map[string]UserCommand{
 		Silent: true,
 		Scope:  []string{"sessions"},
 	},
+	"SortCycle": {
+		Action: action.TypeSortCycle,
+		Help:   "cycle session sort order",
+		Silent: true,
+		Scope:  []string{"sessions"},
+	},
+	"ArchivedToggle": {
+		Action: action.TypeArchivedToggle,
+		Help:   "toggle archived sessions",
+		Silent: true,
+		Scope:  []string{"sessions"},
+	},
+	"Compare": {
+		Action: action.TypeCompare,
+		Help:   "compare with another session (usage: Compare [id])",
+		Silent: true,
+		Scope:  []string{"sessions"},
+	},
+	"EditNotes": {
+		Action: action.TypeEditNotes,
+		Help:   "edit session notes",
+		Silent: true,
+		Scope:  []string{"sessions"},
+	},
 	"TodoPanel": {
 		Action: action.TypeTodoPanel,
 		Help:   "open todo panel",
@@ -215,6 +291,12 @@ var defaultUserCommands = map[string]UserCommand{
 		Silent: true,
 		Scope:  []string{"sessions"},
 	},
+	"OpenPlan": {
+		Action: action.TypeOpenPlan,
+		Help:   "review the session's plan",
+		Silent: true,
+		Scope:  []string{"sessions"},
+	},
 	"TasksRefresh": {
 		Action: action.TypeTasksRefresh,
 		Help:   "reload tasks from store",
@@ -317,12 +399,60 @@ var defaultUserCommands = map[string]UserCommand{
 		Silent: true,
 		Scope:  []string{"review"},
 	},
+	"DocsToggleComments": {
+		Action: action.TypeDocsToggleComments,
+		Help:   "show or hide comments panel",
+		Silent: true,
+		Scope:  []string{"review"},
+	},
+	"DocsToggleAnnotations": {
+		Action: action.TypeDocsToggleAnnotations,
+		Help:   "show or hide annotations",
+		Silent: true,
+		Scope:  []string{"review"},
+	},
 	"DocsSelectRepo": {
 		Action: action.TypeDocsSelectRepo,
 		Help:   "switch repository",
 		Silent: true,
 		Scope:  []string{"review"},
 	},
+	"DocsAddToReview": {
+		Action: action.TypeDocsAddToReview,
+		Help:   "add to current review",
+		Silent: true,
+		Scope:  []string{"review"},
+	},
+	"DocsOpenRevision": {
+		Action: action.TypeDocsOpenRevision,
+		Help:   "open at an earlier commit",
+		Silent: true,
+		Scope:  []string{"review"},
+	},
+	"DocsCommentEdits": {
+		Action: action.TypeDocsCommentEdits,
+		Help:   "show edits of the comment at the cursor",
+		Silent: true,
+		Scope:  []string{"review"},
+	},
+	"DocsNextComment": {
+		Action: action.TypeDocsNextComment,
+		Help:   "next comment (]c)",
+		Silent: true,
+		Scope:  []string{"review"},
+	},
+	"DocsPrevComment": {
+		Action: action.TypeDocsPrevComment,
+		Help:   "previous comment ([c)",
+		Silent: true,
+		Scope:  []string{"review"},
+	},
+	"DocsToggleInstant": {
+		Action: action.TypeDocsToggleInstant,
+		Help:   "send comments to the agent as they are saved",
+		Silent: true,
+		Scope:  []string{"review"},
+	},
 	"SessionsRefreshGitStatuses": {
 		Action: action.TypeSessionsRefreshGitStatuses,
 		Help:   "refresh git status",
@@ -359,6 +489,18 @@ var defaultUserCommands = map[string]UserCommand{
 		Silent: true,
 		Scope:  []string{"sessions"},
 	},
+	"BoardColumnPrev": {
+		Action: action.TypeBoardColumnPrev,
+		Help:   "previous board column",
+		Silent: true,
+		Scope:  []string{"sessions"},
+	},
+	"BoardColumnNext": {
+		Action: action.TypeBoardColumnNext,
+		Help:   "next board column",
+		Silent: true,
+		Scope:  []string{"sessions"},
+	},
 	"GoToTop": {
 		Action: action.TypeGoToTop,
 		Help:   "jump to top",
@@ -381,6 +523,12 @@ var defaultUserCommands = map[string]UserCommand{
 		Help:   "show help",
 		Silent: true,
 	},
+	"CreatePR": {
+		Sh:      "hive session pr {{ .ID | shq }}",
+		Help:    "push the session's branch and open a pull request",
+		Confirm: "Push this session's branch and open a pull request?",
+		Scope:   []string{"sessions"},
+	},
 	"SendBatch": {
 		Sh: `{{ range .Form.targets }}
 {{ agentSend }} {{ .Name | shq }}:claude {{ $.Form.message | shq }}
@@ -416,19 +564,25 @@ const (
 	EnvContextBaseDir = "HIVE_CONTEXT_BASE_DIR"
 	// EnvGitPath overrides git_path when set.
 	EnvGitPath = "HIVE_GIT_PATH"
+	// EnvJJPath overrides jj_path when set.
+	EnvJJPath = "HIVE_JJ_PATH"
 )
 
 // Config holds the application configuration.
 type Config struct {
 	Version             string                 `json:"version"               yaml:"version"`
+	Include             []string               `json:"include"               yaml:"include"`      // config fragments merged into this file (globs, relative to it)
 	CopyCommand         string                 `json:"copy_command"          yaml:"copy_command"` // command to copy to clipboard (e.g., pbcopy, xclip)
 	Git                 GitConfig              `json:"git"                   yaml:"git"`
 	GitPath             string                 `json:"git_path"              yaml:"git_path"`
+	JJPath              string                 `json:"jj_path"               yaml:"jj_path"`
 	Keybindings         map[string]Keybinding  `json:"keybindings"           yaml:"keybindings"`
 	UserCommands        map[string]UserCommand `json:"usercommands"          yaml:"usercommands"`
 	Rules               []Rule                 `json:"rules"                 yaml:"rules"`
 	Agents              AgentsConfig           `json:"agents"                yaml:"agents"`
 	AutoDeleteCorrupted bool                   `json:"auto_delete_corrupted" yaml:"auto_delete_corrupted"`
+	SessionFile         bool                   `json:"session_file"          yaml:"session_file"` // write .hive-session.md into each session directory
+	UnsavedWork         string                 `json:"unsaved_work"          yaml:"unsaved_work"` // block (default) or warn when deleting, recycling, or archiving sessions with unsaved work
 	History             HistoryConfig          `json:"history"               yaml:"history"`
 	Context             ContextConfig          `json:"context"               yaml:"context"`
 	TUI                 TUIConfig              `json:"tui"                   yaml:"tui"`
@@ -441,10 +595,20 @@ type Config struct {
 	Todos               TodosConfig            `json:"todos"                 yaml:"todos"`
 	Workspaces          []string               `json:"workspaces"            yaml:"workspaces"` // parent directories containing git repository folders for new session dialog
 	Views               ViewsConfig            `json:"views"                 yaml:"views"`
+	Watchdog            WatchdogConfig         `json:"watchdog"              yaml:"watchdog"`
+	Due                 DueConfig              `json:"due"                   yaml:"due"`
+	Snapshots           SnapshotsConfig        `json:"snapshots"             yaml:"snapshots"`
+	DesktopNotify       DesktopNotifyConfig    `json:"desktop_notify"        yaml:"desktop_notify"`
+	Integrations        IntegrationsConfig     `json:"integrations"          yaml:"integrations"`
+	Serve               ServeConfig            `json:"serve"                 yaml:"serve"`
+	Hosts               map[string]HostConfig  `json:"hosts"                 yaml:"hosts"`     // remote hosts selectable with --host
+	Batches             map[string]BatchConfig `json:"batches"               yaml:"batches"`   // named batches run with hive batch run
 	RepoDirsCompat      []string               `json:"-"                     yaml:"repo_dirs"` // deprecated: use workspaces instead (kept for backwards compatibility)
 	DataDir             string                 `json:"-"                     yaml:"-"`         // set by caller, not from config file
 
-	hasLegacyKeybindings bool `json:"-" yaml:"-"`
+	hasLegacyKeybindings bool              `json:"-" yaml:"-"`
+	origins              configOrigins     `json:"-" yaml:"-"` // fragment each included value came from
+	references           map[string]string `json:"-" yaml:"-"` // values with references resolved → as written
 }
 
 // AgentsConfig holds agent profile configuration.
@@ -503,8 +667,9 @@ func (a AgentsConfig) DefaultProfile() AgentProfile {
 
 // AgentProfile defines an agent's command and flags.
 type AgentProfile struct {
-	Command string   `json:"command" yaml:"command"` // CLI binary (defaults to profile key if omitted)
-	Flags   []string `json:"flags"   yaml:"flags"`   // extra CLI args appended to command on spawn
+	Command   string           `json:"command"             yaml:"command"`             // CLI binary (defaults to profile key if omitted)
+	Flags     []string         `json:"flags"               yaml:"flags"`               // extra CLI args appended to command on spawn
+	Preflight []PreflightCheck `json:"preflight,omitempty" yaml:"preflight,omitempty"` // checks run before sessions using this profile spawn
 }
 
 // CommandOrDefault returns the command, falling back to the given key name.
@@ -530,8 +695,24 @@ type HistoryConfig struct {
 
 // ContextConfig configures context directory behavior.
 type ContextConfig struct {
-	BaseDir     string `json:"base_dir"     yaml:"base_dir"`     // override context base path (default: $HIVE_DATA_DIR/context/)
-	SymlinkName string `json:"symlink_name" yaml:"symlink_name"` // default: ".hive"
+	BaseDir     string        `json:"base_dir"     yaml:"base_dir"`     // override context base path (default: $HIVE_DATA_DIR/context/)
+	SymlinkName string        `json:"symlink_name" yaml:"symlink_name"` // default: ".hive"
+	Vaults      []VaultConfig `json:"vaults"       yaml:"vaults"`       // external notes vaults included in review discovery
+}
+
+// Unsaved work modes for unsaved_work.
+const (
+	UnsavedWorkBlock = "block" // Require typed confirmation in the TUI and --force on the CLI (default)
+	UnsavedWorkWarn  = "warn"  // List the unsaved work but proceed after a normal confirmation
+)
+
+// ValidUnsavedWorkModes lists all valid unsaved_work values.
+var ValidUnsavedWorkModes = []string{UnsavedWorkBlock, UnsavedWorkWarn}
+
+// WarnOnUnsavedWork reports whether destroying a session with uncommitted or
+// unpushed work only warns instead of requiring an explicit override.
+func (c *Config) WarnOnUnsavedWork() bool {
+	return c.UnsavedWork == UnsavedWorkWarn
 }
 
 // Group-by mode constants for tree view grouping.
@@ -545,26 +726,150 @@ var ValidGroupByModes = []string{GroupByRepo, GroupByGroup}
 
 // TUIConfig holds TUI-related configuration.
 type TUIConfig struct {
-	Theme         string `json:"theme"          yaml:"theme"`          // built-in theme name (default: "tokyo-night")
-	Icons         *bool  `json:"icons"          yaml:"icons"`          // enable nerd font icons (nil = true by default)
-	UpdateChecker bool   `json:"update_checker" yaml:"update_checker"` // enable startup update checker (default: true)
-	Store         bool   `json:"store"          yaml:"store"`          // KV store browser (default: false)
+	Theme            string            `json:"theme"              yaml:"theme"`              // built-in theme name (default: "tokyo-night")
+	Icons            *bool             `json:"icons"              yaml:"icons"`              // enable nerd font icons (nil = true by default)
+	UpdateChecker    bool              `json:"update_checker"     yaml:"update_checker"`     // enable startup update checker (default: true)
+	Store            bool              `json:"store"              yaml:"store"`              // KV store browser (default: false)
+	RestoreState     bool              `json:"restore_state"      yaml:"restore_state"`      // restore tab, selection, and filters from the last run (default: true)
+	ReconcileOnStart bool              `json:"reconcile_on_start" yaml:"reconcile_on_start"` // warn about orphaned sessions, directories, and tmux sessions at startup (default: false)
+	Sessions         TUISessionsConfig `json:"sessions"           yaml:"sessions"`
+}
+
+// TUISessionsConfig holds sessions tree display configuration.
+type TUISessionsConfig struct {
+	Columns []SessionColumn `json:"columns" yaml:"columns"` // ordered tree columns (empty = default layout)
+	Sort    string          `json:"sort"    yaml:"sort"`    // initial sort mode within each group (default: "name")
+}
+
+// Sort mode constants for ordering sessions within a tree group.
+const (
+	SessionSortActivity = "activity" // Most recent terminal activity first
+	SessionSortAge      = "age"      // Newest sessions first
+	SessionSortStatus   = "status"   // Agents needing approval, then active, then ready
+	SessionSortName     = "name"     // Alphabetical by name (default)
+)
+
+// ValidSessionSorts lists all valid sort modes, in the order SortCycle visits them.
+var ValidSessionSorts = []string{SessionSortName, SessionSortActivity, SessionSortAge, SessionSortStatus}
+
+// Built-in sessions tree columns. Plugin statuses are selected with
+// SessionColumnPluginPrefix followed by the plugin name, e.g. "plugin:github".
+const (
+	SessionColumnAgentStatus  = "agent_status"
+	SessionColumnName         = "name"
+	SessionColumnID           = "id"
+	SessionColumnBranch       = "branch"
+	SessionColumnGit          = "git"
+	SessionColumnAge          = "age"
+	SessionColumnTag          = "tag"
+	SessionColumnAgent        = "agent"
+	SessionColumnCost         = "cost"
+	SessionColumnPluginPrefix = "plugin:"
+)
+
+// ValidSessionColumns lists the built-in column names other than plugin columns.
+var ValidSessionColumns = []string{
+	SessionColumnAgentStatus,
+	SessionColumnName,
+	SessionColumnID,
+	SessionColumnBranch,
+	SessionColumnGit,
+	SessionColumnAge,
+	SessionColumnTag,
+	SessionColumnAgent,
+	SessionColumnCost,
+}
+
+// SessionColumn is one column of the sessions tree: a built-in column, or a
+// custom column rendered from Template.
+type SessionColumn struct {
+	Name     string `json:"name"               yaml:"name"`
+	Template string `json:"template,omitempty" yaml:"template,omitempty"` // Go template over the session (custom columns only)
+}
+
+// UnmarshalYAML supports string shorthand: "branch" → {name: branch}
+func (c *SessionColumn) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&c.Name)
+	}
+
+	type sessionColumnAlias SessionColumn
+	var alias sessionColumnAlias
+	if err := node.Decode(&alias); err != nil {
+		return err
+	}
+	*c = SessionColumn(alias)
+	return nil
+}
+
+// IsCustom reports whether the column is rendered from a template.
+func (c SessionColumn) IsCustom() bool {
+	return c.Template != ""
+}
+
+// Plugin returns the plugin name of a "plugin:<name>" column.
+func (c SessionColumn) Plugin() (string, bool) {
+	if c.IsCustom() {
+		return "", false
+	}
+	name, ok := strings.CutPrefix(c.Name, SessionColumnPluginPrefix)
+	return name, ok && name != ""
+}
+
+// DefaultSessionColumns returns the default sessions tree layout.
+func DefaultSessionColumns() []SessionColumn {
+	return []SessionColumn{
+		{Name: SessionColumnAgentStatus},
+		{Name: SessionColumnName},
+		{Name: SessionColumnID},
+		{Name: SessionColumnBranch},
+		{Name: SessionColumnGit},
+		{Name: SessionColumnPluginPrefix + "github"},
+		{Name: SessionColumnCost},
+	}
+}
+
+// ColumnsOrDefault returns the configured columns, or the default layout if unset.
+func (s TUISessionsConfig) ColumnsOrDefault() []SessionColumn {
+	if len(s.Columns) == 0 {
+		return DefaultSessionColumns()
+	}
+	return s.Columns
 }
 
 // ReviewConfig holds review-related configuration.
-type ReviewConfig struct{}
+type ReviewConfig struct {
+	FeedbackTemplate string   `json:"feedback_template" yaml:"feedback_template"` // Go template for finalized feedback (empty = built-in format)
+	Reviewer         string   `json:"reviewer"          yaml:"reviewer"`          // name shown as .Reviewer in feedback templates (default: $USER)
+	SaveFeedback     bool     `json:"save_feedback"     yaml:"save_feedback"`     // write finalized feedback to <context-dir>/reviews/
+	FinalizeHooks    []string `json:"finalize_hooks"    yaml:"finalize_hooks"`    // shell commands run after finalization, feedback on stdin
+	IncludeTiming    bool     `json:"include_timing"    yaml:"include_timing"`    // add review time and comment timestamps to built-in feedback
+	Renderer         string   `json:"renderer"          yaml:"renderer"`          // document renderer: glamour (default), minimal, or raw
+}
+
+// Review renderer constants for review.renderer.
+const (
+	ReviewRendererGlamour = "glamour" // Styled markdown (default)
+	ReviewRendererMinimal = "minimal" // Line-based highlighting, fast on large documents
+	ReviewRendererRaw     = "raw"     // Source text as-is
+)
+
+// ValidReviewRenderers lists all valid review.renderer values.
+var ValidReviewRenderers = []string{ReviewRendererGlamour, ReviewRendererMinimal, ReviewRendererRaw}
+
+// ReviewerName returns the configured reviewer, falling back to $USER.
+func (r ReviewConfig) ReviewerName() string {
+	if r.Reviewer != "" {
+		return r.Reviewer
+	}
+	return os.Getenv("USER")
+}
 
 // IconsEnabled returns true if nerd font icons should be shown.
 func (t TUIConfig) IconsEnabled() bool {
 	return t.Icons == nil || *t.Icons
 }
 
-// MessagingConfig holds messaging-related configuration.
-type MessagingConfig struct {
-	TopicPrefix string `json:"topic_prefix" yaml:"topic_prefix"` // default: "agent"
-	MaxMessages int    `json:"max_messages" yaml:"max_messages"` // max messages per topic (default: 100, 0 = unlimited)
-}
-
 // TmuxConfig holds tmux integration configuration.
 type TmuxConfig struct {
 	PollInterval         time.Duration              `json:"poll_interval"          yaml:"poll_interval"`          // status check frequency, default 1.5s
@@ -595,8 +900,18 @@ type TmuxPluginConfig struct {
 
 // GitHubPluginConfig holds GitHub plugin configuration.
 type GitHubPluginConfig struct {
-	Enabled      *bool         `json:"enabled"       yaml:"enabled"`       // nil = auto-detect, true/false = override
-	ResultsCache time.Duration `json:"results_cache" yaml:"results_cache"` // status cache duration (default: 8m)
+	Enabled      *bool          `json:"enabled"       yaml:"enabled"`       // nil = auto-detect, true/false = override
+	ResultsCache time.Duration  `json:"results_cache" yaml:"results_cache"` // status cache duration (default: 8m)
+	PR           GitHubPRConfig `json:"pr"            yaml:"pr"`
+}
+
+// GitHubPRConfig configures pull requests opened with hive session pr and
+// the CreatePR command. Title and Body are templates rendered with the
+// session's name, branch, base, prompt, commits, and issue.
+type GitHubPRConfig struct {
+	Title string `json:"title" yaml:"title"`
+	Body  string `json:"body"  yaml:"body"`
+	Draft bool   `json:"draft" yaml:"draft"` // open pull requests as drafts
 }
 
 // LazyGitPluginConfig holds lazygit plugin configuration.
@@ -616,7 +931,8 @@ type ContextDirPluginConfig struct {
 
 // ClaudePluginConfig holds Claude Code plugin configuration.
 type ClaudePluginConfig struct {
-	Enabled *bool `json:"enabled" yaml:"enabled"` // nil = auto-detect, true/false = override
+	Enabled  *bool `json:"enabled"   yaml:"enabled"`   // nil = auto-detect, true/false = override
+	ShowCost bool  `json:"show_cost" yaml:"show_cost"` // show cumulative session cost in the sessions tree
 }
 
 // SourcesConfig configures the sources system (GitHub issues and pull
@@ -694,12 +1010,41 @@ type Rule struct {
 	BatchSpawn []string `json:"batch_spawn,omitempty" yaml:"batch_spawn,omitempty"`
 	// Recycle commands to run when recycling a session.
 	Recycle []string `json:"recycle,omitempty" yaml:"recycle,omitempty"`
+	// Sync commands bring a session's branch up to date with its base branch
+	// (hive session sync).
+	Sync []string `json:"sync,omitempty" yaml:"sync,omitempty"`
 	// CloneStrategy overrides the clone strategy for matching repos ("full" or "worktree").
 	CloneStrategy string `json:"clone_strategy,omitempty" yaml:"clone_strategy,omitempty"`
-	// BranchTemplate is a Go template for the git branch name when using the worktree
-	// clone strategy. Available variables: .Name, .Slug, .Owner, .Repo, .ID.
-	// Defaults to "hive/{{ .Slug }}-{{ .ID }}" when empty.
+	// BranchTemplate is a Go template for the branch each new or recycled
+	// session checks out. Available variables: .Name, .Slug, .Owner, .Repo, .ID.
+	// Worktree sessions default to "hive/{{ .Slug }}-{{ .ID }}" when empty;
+	// full clones stay on the default branch unless this or BaseBranch is set.
 	BranchTemplate string `json:"branch_template,omitempty" yaml:"branch_template,omitempty"`
+	// BaseBranch is the remote branch session branches start from. Defaults
+	// to the repository's default branch.
+	BaseBranch string `json:"base_branch,omitempty" yaml:"base_branch,omitempty"`
+	// FeedbackTemplate overrides review.feedback_template for matching repos.
+	FeedbackTemplate string `json:"feedback_template,omitempty" yaml:"feedback_template,omitempty"`
+	// VCS selects the version control tool for matching repos ("git" or "jj").
+	VCS string `json:"vcs,omitempty" yaml:"vcs,omitempty"`
+	// Notify lists the agent statuses ("approval", "ready") that send a desktop
+	// notification when a matching session enters them.
+	Notify []string `json:"notify,omitempty" yaml:"notify,omitempty"`
+	// SnapshotOnRecycle snapshots matching sessions before they are
+	// recycled (hive session restore brings them back).
+	// nil = inherit from previous rule or default (false)
+	SnapshotOnRecycle *bool `json:"snapshot_on_recycle,omitempty" yaml:"snapshot_on_recycle,omitempty"`
+	// PlanDocument is a Go template for the path of a session's plan,
+	// relative to the repository's context directory. Available variables:
+	// .Name, .Slug, .Owner, .Repo, .ID. Defaults to DefaultPlanDocument.
+	PlanDocument string `json:"plan_document,omitempty" yaml:"plan_document,omitempty"`
+	// RepoConfig applies the .hive.yaml in matching repositories' clones
+	// (see RepoConfig). Only enable it for repositories you trust.
+	// nil = inherit from previous rule or default (false)
+	RepoConfig *bool `json:"repo_config,omitempty" yaml:"repo_config,omitempty"`
+	// Preflight checks run before the spawn commands. Every matching rule
+	// contributes its checks.
+	Preflight []PreflightCheck `json:"preflight,omitempty" yaml:"preflight,omitempty"`
 }
 
 // Keybinding defines a TUI keybinding that references a UserCommand.
@@ -784,6 +1129,27 @@ var DefaultRecycleCommands = []string{
 	"git clean -fd",
 }
 
+// DefaultJJRecycleCommands are the default recycle commands for repos using
+// jj. The session's previous changes stay in the repo and can be restored
+// with jj.
+var DefaultJJRecycleCommands = []string{
+	"jj git fetch",
+	"jj new {{ .DefaultBranch }}@origin",
+}
+
+// DefaultSyncCommands are the default commands run when syncing a session:
+// rebase its branch onto the latest base branch.
+var DefaultSyncCommands = []string{
+	"git fetch origin",
+	"git rebase origin/{{ .BaseBranch }}",
+}
+
+// DefaultJJSyncCommands are the default sync commands for repos using jj.
+var DefaultJJSyncCommands = []string{
+	"jj git fetch",
+	"jj rebase -d {{ .BaseBranch }}@origin",
+}
+
 // DefaultConfig returns a Config with sensible defaults.
 func DefaultConfig() Config {
 	return Config{
@@ -791,6 +1157,7 @@ func DefaultConfig() Config {
 			StatusWorkers: 3,
 		},
 		GitPath:             "git",
+		JJPath:              "jj",
 		Keybindings:         map[string]Keybinding{},
 		AutoDeleteCorrupted: true,
 		History: HistoryConfig{
@@ -801,6 +1168,7 @@ func DefaultConfig() Config {
 		},
 		TUI: TUIConfig{
 			UpdateChecker: true,
+			RestoreState:  true,
 		},
 		Views: ViewsConfig{
 			Sessions: SessionsViewConfig{
@@ -810,7 +1178,18 @@ func DefaultConfig() Config {
 		},
 		Messaging: MessagingConfig{
 			TopicPrefix: "agent",
-			MaxMessages: 100,
+			Retention:   DefaultMessageRetention,
+			MaxPerTopic: 100,
+		},
+		Watchdog: WatchdogConfig{
+			Approval: 5 * time.Minute,
+			Idle:     10 * time.Minute,
+		},
+		Snapshots: SnapshotsConfig{
+			MaxPerSession: DefaultSnapshotsPerSession,
+		},
+		DesktopNotify: DesktopNotifyConfig{
+			RateLimit: time.Minute,
 		},
 		Todos: TodosConfig{
 			Limiter: TodosLimiterConfig{
@@ -821,6 +1200,25 @@ func DefaultConfig() Config {
 				Toast: true,
 			},
 		},
+		Plugins: PluginsConfig{
+			GitHub: GitHubPluginConfig{
+				PR: GitHubPRConfig{
+					// A single commit titles the pull request; otherwise the
+					// session name does.
+					Title: `{{ if eq (len .Commits) 1 }}{{ index .Commits 0 }}{{ else }}{{ .Name }}{{ end }}`,
+					Body: `{{ if .Prompt }}## Task
+
+{{ .Prompt }}
+{{ end }}{{ if .Commits }}
+## Commits
+
+{{ range .Commits }}- {{ . }}
+{{ end }}{{ end }}{{ if .Issue }}
+Closes #{{ .Issue }}
+{{ end }}`,
+				},
+			},
+		},
 		Sources: SourcesConfig{
 			Issues: BuiltinSourceConfig{
 				Templates: SourceTemplateConfig{
@@ -853,14 +1251,17 @@ func Load(configPath, dataDir string) (*Config, error) {
 
 	if configPath != "" {
 		if _, err := os.Stat(configPath); err == nil {
-			data, err := os.ReadFile(configPath)
+			written := map[string]string{}
+			root, origins, err := loadConfigDocument(configPath, written)
 			if err != nil {
-				return nil, fmt.Errorf("read config file: %w", err)
+				return nil, err
 			}
 
-			if err := yaml.Unmarshal(data, &cfg); err != nil {
+			if err := root.Decode(&cfg); err != nil {
 				return nil, fmt.Errorf("parse config file: %w", err)
 			}
+			cfg.origins = origins
+			cfg.references = written
 
 			// Re-set dataDir since Unmarshal may have cleared it
 			cfg.DataDir = dataDir
@@ -870,13 +1271,19 @@ func Load(configPath, dataDir string) (*Config, error) {
 				cfg.Workspaces = cfg.RepoDirsCompat
 			}
 			cfg.RepoDirsCompat = nil
+
+			// Backwards compat: migrate deprecated messaging.max_messages → max_per_topic
+			if cfg.Messaging.MaxMessagesCompat != nil {
+				cfg.Messaging.MaxPerTopic = *cfg.Messaging.MaxMessagesCompat
+				cfg.Messaging.MaxMessagesCompat = nil
+			}
 		}
 	}
 
 	// Validate user keybindings before merging defaults (defaults may reference
 	// plugin commands that don't exist at config-load time).
 	if err := cfg.validateUserKeybindings(); err != nil {
-		return nil, fmt.Errorf("invalid config: %w", err)
+		return nil, fmt.Errorf("invalid config: %w", cfg.attributeErrors(err))
 	}
 
 	// The top-level keybindings field is deprecated in favor of views.sessions.keybindings.
@@ -926,6 +1333,9 @@ func (c *Config) applyDefaults() {
 	if c.Views.Sessions.GroupBy == "" {
 		c.Views.Sessions.GroupBy = GroupByRepo
 	}
+	if c.TUI.Sessions.Sort == "" {
+		c.TUI.Sessions.Sort = SessionSortName
+	}
 	if c.CopyCommand == "" {
 		c.CopyCommand = defaultCopyCommand()
 	}
@@ -968,6 +1378,7 @@ func (c *Config) applyEnvironmentOverrides() {
 		{env: EnvDefaultAgent, target: &c.Agents.Default},
 		{env: EnvContextBaseDir, target: &c.Context.BaseDir},
 		{env: EnvGitPath, target: &c.GitPath},
+		{env: EnvJJPath, target: &c.JJPath},
 	}
 
 	for _, override := range overrides {
@@ -1014,35 +1425,55 @@ func DefaultUserCommands() map[string]UserCommand {
 	return defaultUserCommands
 }
 
-// Validate checks that the configuration is valid.
+// Validate checks that the configuration is valid. Errors about values from
+// included fragments name the fragment.
 func (c *Config) Validate() error {
-	return criterio.ValidateStruct(
+	return c.attributeErrors(criterio.ValidateStruct(
 		criterio.Run("git_path", c.GitPath, criterio.Required[string]),
 		criterio.Run("data_dir", c.DataDir, criterio.Required[string]),
 		criterio.Run("git.status_workers", c.Git.StatusWorkers, criterio.Min(1)),
 		criterio.Run("database.max_open_conns", c.Database.MaxOpenConns, criterio.Min(1)),
 		criterio.Run("database.max_idle_conns", c.Database.MaxIdleConns, criterio.Min(1)),
+		criterio.Run("snapshots.max_per_session", c.Snapshots.MaxPerSession, criterio.Min(0)),
 		criterio.Run("database.busy_timeout", c.Database.BusyTimeout, criterio.Min(0)),
 		c.validateTheme(),
 		c.validateGroupBy(),
+		c.validateSessionSort(),
+		c.validateReviewRenderer(),
+		c.validateUnsavedWork(),
+		c.validateSessionColumns(),
 		c.validateKeybindingsBasic(),
 		c.validateUserCommandsBasic(),
 		c.validateMaxRecycled(),
 		c.validateAgents(),
 		c.validateWindowsBasic(),
 		c.validateTodos(),
+		c.validateWatchdog(),
+		c.validatePreflight(),
+		c.validateDue(),
+		c.validateMessaging(),
+		c.validateDesktopNotify(),
+		c.validateIntegrations(),
+		c.validateServe(),
+		c.validateHosts(),
 		c.validateCloneStrategies(),
 		c.validateSources(),
-	)
+		c.validateVaults(),
+		c.validateBatches(),
+		c.validateGitHubPR(),
+	))
 }
 
-// validateCloneStrategies checks clone_strategy on each rule.
+// validateCloneStrategies checks clone_strategy and vcs on each rule.
 func (c *Config) validateCloneStrategies() error {
 	var errs criterio.FieldErrorsBuilder
 	for i, rule := range c.Rules {
 		if err := ValidateCloneStrategy(rule.CloneStrategy); err != nil {
 			errs = errs.Append(fmt.Sprintf("rules[%d].clone_strategy", i), err)
 		}
+		if err := ValidateVCS(rule.VCS); err != nil {
+			errs = errs.Append(fmt.Sprintf("rules[%d].vcs", i), err)
+		}
 	}
 	return errs.ToError()
 }
@@ -1163,6 +1594,12 @@ func (c *Config) validateAgents() error {
 	if _, ok := c.Agents.Profiles[c.Agents.Default]; !ok {
 		errs = errs.Append("agents.default", fmt.Errorf("profile %q not found in agents config", c.Agents.Default))
 	}
+	return criterio.ValidateStruct(errs.ToError(), c.validateRuleAgents())
+}
+
+// validateRuleAgents checks that the agents rules select are configured profiles.
+func (c *Config) validateRuleAgents() error {
+	var errs criterio.FieldErrorsBuilder
 	for i, rule := range c.Rules {
 		if rule.Agent == "" {
 			continue
@@ -1187,6 +1624,55 @@ func (c *Config) validateGroupBy() error {
 	return criterio.Run("views.sessions.group_by", c.Views.Sessions.GroupBy, criterio.StrOneOf(ValidGroupByModes...))
 }
 
+// validateSessionSort checks that the configured sessions sort mode is valid.
+func (c *Config) validateSessionSort() error {
+	if c.TUI.Sessions.Sort == "" {
+		return nil
+	}
+	return criterio.Run("tui.sessions.sort", c.TUI.Sessions.Sort, criterio.StrOneOf(ValidSessionSorts...))
+}
+
+// validateReviewRenderer checks that the configured review renderer is valid.
+func (c *Config) validateReviewRenderer() error {
+	if c.Review.Renderer == "" {
+		return nil
+	}
+	return criterio.Run("review.renderer", c.Review.Renderer, criterio.StrOneOf(ValidReviewRenderers...))
+}
+
+// validateUnsavedWork checks that the configured unsaved_work mode is valid.
+func (c *Config) validateUnsavedWork() error {
+	if c.UnsavedWork == "" {
+		return nil
+	}
+	return criterio.Run("unsaved_work", c.UnsavedWork, criterio.StrOneOf(ValidUnsavedWorkModes...))
+}
+
+// validateSessionColumns checks that each tree column is a known built-in,
+// a plugin column, or a custom column with a valid template.
+func (c *Config) validateSessionColumns() error {
+	var errs criterio.FieldErrorsBuilder
+	for i, col := range c.TUI.Sessions.Columns {
+		field := fmt.Sprintf("tui.sessions.columns[%d]", i)
+		switch {
+		case col.Name == "":
+			errs = errs.Append(field+".name", fmt.Errorf("column name is required"))
+		case col.IsCustom():
+			if err := validationRenderer.ValidateSyntax(col.Template); err != nil {
+				errs = errs.Append(field+".template", err)
+			}
+		case strings.HasPrefix(col.Name, SessionColumnPluginPrefix):
+			if _, ok := col.Plugin(); !ok {
+				errs = errs.Append(field+".name", fmt.Errorf("plugin column requires a plugin name, e.g. %q", SessionColumnPluginPrefix+"github"))
+			}
+		case !slices.Contains(ValidSessionColumns, col.Name):
+			errs = errs.Append(field+".name", fmt.Errorf("unknown column %q (valid: %s, %s<name>, or a custom column with a template)",
+				col.Name, strings.Join(ValidSessionColumns, ", "), SessionColumnPluginPrefix))
+		}
+	}
+	return errs.ToError()
+}
+
 // validateKeybindingsBasic performs basic keybinding validation for the Validate() method.
 // Only checks structural validity (non-empty cmd). Command reference validation
 // is done earlier via validateUserKeybindings() before defaults are merged,
@@ -1303,7 +1789,7 @@ func (c *Config) GetMaxRecycled(remote string) int {
 	// Check rules in order - last matching rule with MaxRecycled set wins
 	var result *int
 	for _, rule := range c.Rules {
-		if rule.Pattern == "" || matchesPattern(rule.Pattern, remote) {
+		if rule.Matches(remote) {
 			if rule.MaxRecycled != nil {
 				result = rule.MaxRecycled
 			}
@@ -1331,7 +1817,7 @@ func (c *Config) GetCloneStrategy(remote string) string {
 
 // GetBranchTemplate returns the branch_template for the given remote URL.
 // The last matching rule with a branch_template set wins.
-// Returns "" if no rule defines a template (caller uses the default "hive-<id>" branch).
+// Returns "" if no rule defines a template (caller uses the default "hive/<slug>-<id>" branch).
 func (c *Config) GetBranchTemplate(remote string) string {
 	var tmpl string
 	for _, rule := range c.Rules {
@@ -1342,6 +1828,81 @@ func (c *Config) GetBranchTemplate(remote string) string {
 	return tmpl
 }
 
+// DefaultPlanDocument is the plan path used when no rule sets plan_document.
+const DefaultPlanDocument = "plans/{{ .Slug }}.md"
+
+// GetPlanDocument returns the plan_document template for the given remote
+// URL. The last matching rule with a plan_document set wins. Returns
+// DefaultPlanDocument if no rule defines one.
+func (c *Config) GetPlanDocument(remote string) string {
+	doc := DefaultPlanDocument
+	for _, rule := range c.Rules {
+		if rule.Matches(remote) && rule.PlanDocument != "" {
+			doc = rule.PlanDocument
+		}
+	}
+	return doc
+}
+
+// GetBaseBranch returns the base_branch for the given remote URL. The last
+// matching rule with a base_branch set wins. Returns "" for the repository's
+// default branch.
+func (c *Config) GetBaseBranch(remote string) string {
+	var base string
+	for _, rule := range c.Rules {
+		if rule.Matches(remote) && rule.BaseBranch != "" {
+			base = rule.BaseBranch
+		}
+	}
+	return base
+}
+
+// GetFeedbackTemplate returns the review feedback template for the given
+// remote URL. The last matching rule with a feedback_template set wins;
+// otherwise review.feedback_template applies. Returns "" for the built-in
+// feedback format.
+func (c *Config) GetFeedbackTemplate(remote string) string {
+	tmpl := c.Review.FeedbackTemplate
+	for _, rule := range c.Rules {
+		if rule.Matches(remote) && rule.FeedbackTemplate != "" {
+			tmpl = rule.FeedbackTemplate
+		}
+	}
+	return tmpl
+}
+
+// GetVCS returns the version control tool for the given remote. The last
+// matching rule with vcs set wins; defaults to "git".
+func (c *Config) GetVCS(remote string) string {
+	vcs := VCSGit
+	for _, rule := range c.Rules {
+		if rule.Matches(remote) && rule.VCS != "" {
+			vcs = rule.VCS
+		}
+	}
+	return vcs
+}
+
+// UsesJJ reports whether any rule selects jj.
+func (c *Config) UsesJJ() bool {
+	for _, rule := range c.Rules {
+		if rule.VCS == VCSJJ {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateVCS returns an error if s is not a valid vcs value.
+func ValidateVCS(s string) error {
+	switch s {
+	case "", VCSGit, VCSJJ:
+		return nil
+	default:
+		return fmt.Errorf("invalid vcs %q: must be %q or %q", s, VCSGit, VCSJJ)
+	}
+}
+
 // ValidateCloneStrategy returns an error if s is not a valid clone strategy value.
 func ValidateCloneStrategy(s string) error {
 	switch s {
@@ -1396,29 +1957,67 @@ func ResolveSpawn(rules []Rule, remote string, batch bool) SpawnStrategy {
 
 // GetRecycleCommands returns the recycle commands for the given remote URL.
 // Rules are evaluated in order; the last matching rule with recycle commands wins.
-// If no rules define recycle commands, returns DefaultRecycleCommands.
+// If no rules define recycle commands, returns the defaults for the remote's vcs.
 func (c *Config) GetRecycleCommands(remote string) []string {
+	return c.GetRecycleCommandsForVCS(remote, c.GetVCS(remote))
+}
+
+// GetRecycleCommandsForVCS is GetRecycleCommands for a worktree created with
+// vcs, which may differ from the vcs rules now select for remote. The
+// defaults follow vcs: DefaultJJRecycleCommands for jj, otherwise
+// DefaultRecycleCommands.
+func (c *Config) GetRecycleCommandsForVCS(remote, vcs string) []string {
 	var result []string
 	for _, rule := range c.Rules {
-		if rule.Pattern == "" || matchesPattern(rule.Pattern, remote) {
+		if rule.Matches(remote) {
 			if len(rule.Recycle) > 0 {
 				result = rule.Recycle
 			}
 		}
 	}
 	if len(result) == 0 {
+		if vcs == VCSJJ {
+			return DefaultJJRecycleCommands
+		}
 		return DefaultRecycleCommands
 	}
 	return result
 }
 
+// GetSyncCommands returns the sync commands for the given remote URL.
+// Rules are evaluated in order; the last matching rule with sync commands wins.
+// If no rules define sync commands, returns DefaultSyncCommands.
+func (c *Config) GetSyncCommands(remote string) []string {
+	var result []string
+	for _, rule := range c.Rules {
+		if rule.Matches(remote) && len(rule.Sync) > 0 {
+			result = rule.Sync
+		}
+	}
+	if len(result) == 0 {
+		if c.GetVCS(remote) == VCSJJ {
+			return DefaultJJSyncCommands
+		}
+		return DefaultSyncCommands
+	}
+	return result
+}
+
 // Matches reports whether this rule matches the given remote URL.
-// An empty pattern matches everything.
+// An empty pattern matches everything. Azure DevOps and Bitbucket remotes
+// also match against their HTTPS URL, so one pattern covers SSH and HTTPS
+// clones of those repos.
 func (r Rule) Matches(remote string) bool {
 	if r.Pattern == "" {
 		return true
 	}
-	return matchesPattern(r.Pattern, remote)
+	if matchesPattern(r.Pattern, remote) {
+		return true
+	}
+	if parsed, ok := git.ParseRemote(remote); ok {
+		return matchesPattern(r.Pattern, parsed.WebURL())
+	}
+	return false
 }
 
 // matchesPattern checks if remote matches the regex pattern.
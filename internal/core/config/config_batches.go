@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hay-kot/criterio"
+)
+
+// BatchConfig is a named batch of sessions run with hive batch run <name>.
+// Session names, prompts, remotes, sources, tags, and dependencies are
+// templates rendered with the batch variables under .Vars.
+type BatchConfig struct {
+	Description string               `json:"description" yaml:"description"` // shown by hive batch list
+	Agent       string               `json:"agent"       yaml:"agent"`       // agent profile for sessions without their own
+	Vars        map[string]string    `json:"vars"        yaml:"vars"`        // default variable values, overridden with --var
+	Sessions    []BatchSessionConfig `json:"sessions"    yaml:"sessions"`
+}
+
+// BatchSessionConfig is one session of a configured batch. Its fields match
+// the hive batch JSON input.
+type BatchSessionConfig struct {
+	Name          string   `json:"name"           yaml:"name"`
+	Prompt        string   `json:"prompt"         yaml:"prompt"`
+	Remote        string   `json:"remote"         yaml:"remote"`
+	Source        string   `json:"source"         yaml:"source"`
+	CloneStrategy string   `json:"clone_strategy" yaml:"clone_strategy"`
+	Agent         string   `json:"agent"          yaml:"agent"`
+	Tags          []string `json:"tags"           yaml:"tags"`
+	DependsOn     []string `json:"depends_on"     yaml:"depends_on"`
+}
+
+// BatchNames returns the configured batch names, sorted.
+func (c *Config) BatchNames() []string {
+	names := make([]string, 0, len(c.Batches))
+	for name := range c.Batches {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Batch returns the configuration of the named batch.
+func (c *Config) Batch(name string) (BatchConfig, error) {
+	batch, ok := c.Batches[name]
+	if !ok {
+		if len(c.Batches) == 0 {
+			return BatchConfig{}, fmt.Errorf("unknown batch %q: no batches configured", name)
+		}
+		return BatchConfig{}, fmt.Errorf("unknown batch %q (configured: %s)", name, strings.Join(c.BatchNames(), ", "))
+	}
+	return batch, nil
+}
+
+// validateBatches checks that each batch has sessions with names, known agent
+// profiles and clone strategies, and templates that parse.
+func (c *Config) validateBatches() error {
+	var errs criterio.FieldErrorsBuilder
+
+	for _, name := range c.BatchNames() {
+		batch := c.Batches[name]
+		field := "batches." + name
+
+		if batch.Agent != "" {
+			if _, ok := c.Agents.Profiles[batch.Agent]; !ok {
+				errs = errs.Append(field+".agent", fmt.Errorf("profile %q not found in agents config", batch.Agent))
+			}
+		}
+		if len(batch.Sessions) == 0 {
+			errs = errs.Append(field+".sessions", fmt.Errorf("at least one session is required"))
+		}
+
+		for i, sess := range batch.Sessions {
+			sessField := fmt.Sprintf("%s.sessions[%d]", field, i)
+			if strings.TrimSpace(sess.Name) == "" {
+				errs = errs.Append(sessField+".name", fmt.Errorf("is required"))
+			}
+			if sess.Agent != "" {
+				if _, ok := c.Agents.Profiles[sess.Agent]; !ok {
+					errs = errs.Append(sessField+".agent", fmt.Errorf("profile %q not found in agents config", sess.Agent))
+				}
+			}
+			if err := ValidateCloneStrategy(sess.CloneStrategy); err != nil {
+				errs = errs.Append(sessField+".clone_strategy", err)
+			}
+
+			templates := map[string]string{
+				"name":   sess.Name,
+				"prompt": sess.Prompt,
+				"remote": sess.Remote,
+				"source": sess.Source,
+			}
+			for j, tag := range sess.Tags {
+				templates[fmt.Sprintf("tags[%d]", j)] = tag
+			}
+			for j, dep := range sess.DependsOn {
+				templates[fmt.Sprintf("depends_on[%d]", j)] = dep
+			}
+			for _, key := range slices.Sorted(maps.Keys(templates)) {
+				if err := validationRenderer.ValidateSyntax(templates[key]); err != nil {
+					errs = errs.Append(sessField+"."+key, err)
+				}
+			}
+		}
+	}
+
+	return errs.ToError()
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBatches(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Agents.Profiles = map[string]AgentProfile{"claude": {}}
+	cfg.Batches = map[string]BatchConfig{
+		"review": {
+			Agent: "claude",
+			Sessions: []BatchSessionConfig{
+				{Name: "review-{{ .Vars.pr }}", Prompt: "Review #{{ .Vars.pr }}"},
+				{Name: "fix-{{ .Vars.pr }}", DependsOn: []string{"review-{{ .Vars.pr }}"}},
+			},
+		},
+	}
+	require.NoError(t, cfg.validateBatches())
+
+	cfg.Batches = map[string]BatchConfig{
+		"empty": {},
+		"bad": {
+			Agent: "nope",
+			Sessions: []BatchSessionConfig{
+				{Prompt: "x"},
+				{Name: "s", CloneStrategy: "teleport", Tags: []string{"{{ .Vars.pr"}},
+			},
+		},
+	}
+	err := cfg.validateBatches()
+	require.Error(t, err)
+	for _, field := range []string{
+		"batches.empty.sessions",
+		"batches.bad.agent",
+		"batches.bad.sessions[0].name",
+		"batches.bad.sessions[1].clone_strategy",
+		"batches.bad.sessions[1].tags[0]",
+	} {
+		assert.Contains(t, err.Error(), field)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	cfg := DefaultConfig()
+	_, err := cfg.Batch("review")
+	require.ErrorContains(t, err, "no batches configured")
+
+	cfg.Batches = map[string]BatchConfig{
+		"review": {Description: "review a PR"},
+		"deploy": {},
+	}
+	batch, err := cfg.Batch("review")
+	require.NoError(t, err)
+	assert.Equal(t, "review a PR", batch.Description)
+
+	_, err = cfg.Batch("other")
+	require.ErrorContains(t, err, "configured: deploy, review")
+}
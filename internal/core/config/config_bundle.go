@@ -0,0 +1,306 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BundleSections are the top-level config keys shared in a workspace bundle.
+// Everything else (workspaces, context paths, database, git binary, copy
+// command, history) describes a single machine and is left out.
+var BundleSections = []string{
+	"agents",
+	"keybindings",
+	"messaging",
+	"plugins",
+	"rules",
+	"sources",
+	"todos",
+	"tui",
+	"usercommands",
+	"views",
+}
+
+// bundleAtomicParents are mappings whose entries are merged as a whole: a user
+// command, agent profile or keybinding is replaced outright rather than mixed
+// field by field.
+var bundleAtomicParents = []string{"agents", "keybindings", "usercommands"}
+
+// BundleRedacted replaces secret values in an exported bundle.
+const BundleRedacted = "<redacted>"
+
+var (
+	// bundleSecretKeyPattern matches mapping keys whose values are secrets.
+	bundleSecretKeyPattern = regexp.MustCompile(`(?i)(token|secret|password|passwd|api[_-]?key|credential)`)
+
+	// bundleSecretAssignPattern matches VAR=value assignments of secrets inside
+	// command strings.
+	bundleSecretAssignPattern = regexp.MustCompile(`(?i)\b([A-Z0-9_]*(?:TOKEN|SECRET|PASSWORD|API_KEY)[A-Z0-9_]*)=("[^"]*"|'[^']*'|\S+)`)
+
+	// bundleSecretValuePattern matches well-known credential formats.
+	bundleSecretValuePattern = regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,}|sk-[A-Za-z0-9_-]{20,}|xox[abprs]-[A-Za-z0-9-]{10,})\b`)
+)
+
+// ExportBundle builds a workspace bundle from the raw contents of a config
+// file: only BundleSections are kept, secret-looking values are redacted
+// unless they are environment variable or secret references, and home
+// directory paths are rewritten to ~. Returns the bundle YAML and the
+// dotted paths of every redacted value.
+func ExportBundle(data []byte, home string) ([]byte, []string, error) {
+	src, err := parseBundleDocument(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, val := src.Content[i], src.Content[i+1]
+		if slices.Contains(BundleSections, key.Value) {
+			out.Content = append(out.Content, key, val)
+		}
+	}
+
+	var redacted []string
+	sanitizeBundleNode(out, "", home, &redacted)
+
+	doc := &yaml.Node{
+		Kind:        yaml.DocumentNode,
+		HeadComment: "hive workspace bundle: import with `hive workspace import <file>`",
+		Content:     []*yaml.Node{out},
+	}
+	bundle, err := encodeBundleNode(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bundle, redacted, nil
+}
+
+// sanitizeBundleNode redacts secrets and shortens home paths in place.
+func sanitizeBundleNode(n *yaml.Node, path, home string, redacted *[]string) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, val := n.Content[i], n.Content[i+1]
+			child := joinBundlePath(path, key.Value)
+			if val.Kind == yaml.ScalarNode && val.Value != "" && !IsConfigReference(val.Value) && bundleSecretKeyPattern.MatchString(key.Value) {
+				val.Value, val.Tag, val.Style = BundleRedacted, "!!str", 0
+				*redacted = append(*redacted, child)
+				continue
+			}
+			sanitizeBundleNode(val, child, home, redacted)
+		}
+	case yaml.SequenceNode:
+		for i, item := range n.Content {
+			sanitizeBundleNode(item, fmt.Sprintf("%s[%d]", path, i), home, redacted)
+		}
+	case yaml.ScalarNode:
+		value := bundleSecretAssignPattern.ReplaceAllStringFunc(n.Value, func(match string) string {
+			name, assigned, _ := strings.Cut(match, "=")
+			if IsConfigReference(strings.Trim(assigned, `"'`)) {
+				return match
+			}
+			return name + "=" + BundleRedacted
+		})
+		value = bundleSecretValuePattern.ReplaceAllString(value, BundleRedacted)
+		if value != n.Value {
+			*redacted = append(*redacted, path)
+		}
+		if home != "" {
+			value = strings.ReplaceAll(value, home+"/", "~/")
+		}
+		n.Value = value
+	}
+}
+
+// BundleConflict is a bundle value that differs from the current config.
+type BundleConflict struct {
+	Path     string // dotted config path, e.g. "usercommands.review"
+	Current  string // current value as YAML
+	Incoming string // bundle value as YAML
+}
+
+// BundleMergeResult lists the config paths touched by MergeBundle.
+type BundleMergeResult struct {
+	Added    []string // values missing from the current config
+	Replaced []string // conflicts resolved in favor of the bundle
+	Kept     []string // conflicts resolved in favor of the current config
+	Skipped  []string // bundle sections that are not shareable
+}
+
+// Changed reports whether merging modified the config.
+func (r BundleMergeResult) Changed() bool {
+	return len(r.Added) > 0 || len(r.Replaced) > 0
+}
+
+// MergeBundle merges a workspace bundle into the raw contents of a config
+// file, preserving the config's comments and key order. Values missing from
+// the config are added, rules are appended unless an identical rule exists,
+// and resolve decides each conflicting value (true takes the bundle's).
+// current may be empty when no config file exists yet.
+func MergeBundle(current, bundle []byte, resolve func(BundleConflict) bool) ([]byte, BundleMergeResult, error) {
+	var result BundleMergeResult
+
+	dst, err := parseBundleDocument(current)
+	if err != nil {
+		return nil, result, fmt.Errorf("parse config: %w", err)
+	}
+	src, err := parseBundleDocument(bundle)
+	if err != nil {
+		return nil, result, fmt.Errorf("parse bundle: %w", err)
+	}
+
+	m := bundleMerger{resolve: resolve, result: &result}
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, val := src.Content[i], src.Content[i+1]
+		if !slices.Contains(BundleSections, key.Value) {
+			result.Skipped = append(result.Skipped, key.Value)
+			continue
+		}
+		if err := m.mergeEntry(dst, key, val, key.Value, false); err != nil {
+			return nil, result, err
+		}
+	}
+
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{dst}}
+	if len(current) > 0 {
+		// Keep the config's own document comments
+		var orig yaml.Node
+		if err := yaml.Unmarshal(current, &orig); err == nil && orig.Kind == yaml.DocumentNode {
+			doc.HeadComment, doc.FootComment = orig.HeadComment, orig.FootComment
+		}
+	}
+	merged, err := encodeBundleNode(doc)
+	if err != nil {
+		return nil, result, err
+	}
+	return merged, result, nil
+}
+
+// bundleMerger merges bundle nodes into config nodes.
+type bundleMerger struct {
+	resolve func(BundleConflict) bool
+	result  *BundleMergeResult
+}
+
+// mergeEntry merges one key/value pair of a bundle mapping into dst.
+func (m bundleMerger) mergeEntry(dst, key, val *yaml.Node, path string, atomic bool) error {
+	idx := bundleMappingIndex(dst, key.Value)
+	if idx < 0 {
+		dst.Content = append(dst.Content, key, val)
+		m.result.Added = append(m.result.Added, path)
+		return nil
+	}
+
+	cur := dst.Content[idx+1]
+	switch {
+	case bundleNodesEqual(cur, val):
+		return nil
+	case !atomic && cur.Kind == yaml.MappingNode && val.Kind == yaml.MappingNode:
+		childAtomic := slices.Contains(bundleAtomicParents, key.Value)
+		for i := 0; i+1 < len(val.Content); i += 2 {
+			if err := m.mergeEntry(cur, val.Content[i], val.Content[i+1], joinBundlePath(path, val.Content[i].Value), childAtomic); err != nil {
+				return err
+			}
+		}
+		return nil
+	case path == "rules" && cur.Kind == yaml.SequenceNode && val.Kind == yaml.SequenceNode:
+		for i, rule := range val.Content {
+			if !slices.ContainsFunc(cur.Content, func(n *yaml.Node) bool { return bundleNodesEqual(n, rule) }) {
+				cur.Content = append(cur.Content, rule)
+				m.result.Added = append(m.result.Added, fmt.Sprintf("rules[%d]", i))
+			}
+		}
+		return nil
+	}
+
+	conflict := BundleConflict{Path: path}
+	var err error
+	if conflict.Current, err = bundleNodeString(cur); err != nil {
+		return err
+	}
+	if conflict.Incoming, err = bundleNodeString(val); err != nil {
+		return err
+	}
+	if m.resolve != nil && m.resolve(conflict) {
+		dst.Content[idx+1] = val
+		m.result.Replaced = append(m.result.Replaced, path)
+	} else {
+		m.result.Kept = append(m.result.Kept, path)
+	}
+	return nil
+}
+
+// parseBundleDocument parses YAML into its top-level mapping. Empty input
+// yields an empty mapping.
+func parseBundleDocument(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.MappingNode}, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, errors.New("expected a YAML mapping at the top level")
+	}
+	return root, nil
+}
+
+// bundleMappingIndex returns the index of key in a mapping's content, or -1.
+func bundleMappingIndex(n *yaml.Node, key string) int {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// bundleNodesEqual compares two nodes by value, ignoring comments and style.
+func bundleNodesEqual(a, b *yaml.Node) bool {
+	var va, vb any
+	if a.Decode(&va) != nil || b.Decode(&vb) != nil {
+		return false
+	}
+	sa, errA := yaml.Marshal(va)
+	sb, errB := yaml.Marshal(vb)
+	return errA == nil && errB == nil && bytes.Equal(sa, sb)
+}
+
+// bundleNodeString renders a node as YAML without a trailing newline.
+func bundleNodeString(n *yaml.Node) (string, error) {
+	out, err := encodeBundleNode(n)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+// encodeBundleNode encodes a node with the two-space indent used by hive configs.
+func encodeBundleNode(n *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(n); err != nil {
+		return nil, fmt.Errorf("encode yaml: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("encode yaml: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// joinBundlePath appends key to a dotted config path.
+func joinBundlePath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
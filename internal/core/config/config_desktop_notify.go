@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hay-kot/criterio"
+)
+
+// Agent statuses a rule can opt into desktop notifications for.
+const (
+	NotifyApproval = "approval" // agent is waiting for permission
+	NotifyReady    = "ready"    // agent finished and is waiting for input
+)
+
+// DesktopNotifyConfig controls native desktop notifications for agent status
+// changes. Which statuses notify is opted into per rule with notify.
+type DesktopNotifyConfig struct {
+	RateLimit time.Duration `json:"rate_limit" yaml:"rate_limit"` // minimum time between notifications for one session (default: 1m, 0 disables)
+}
+
+// GetNotify returns the agent statuses that send a desktop notification for
+// the given remote URL. The last matching rule with notify set wins, so a
+// later rule can opt a repository out with an empty list.
+func (c *Config) GetNotify(remote string) []string {
+	var notify []string
+	for _, rule := range c.Rules {
+		if rule.Matches(remote) && rule.Notify != nil {
+			notify = rule.Notify
+		}
+	}
+	return notify
+}
+
+// validateDesktopNotify checks the rate limit and each rule's notify statuses.
+func (c *Config) validateDesktopNotify() error {
+	var errs criterio.FieldErrorsBuilder
+
+	if c.DesktopNotify.RateLimit < 0 {
+		errs = errs.Append("desktop_notify.rate_limit", fmt.Errorf("must be >= 0"))
+	}
+	for i, rule := range c.Rules {
+		for j, status := range rule.Notify {
+			if !slices.Contains([]string{NotifyApproval, NotifyReady}, status) {
+				errs = errs.Append(fmt.Sprintf("rules[%d].notify[%d]", i, j),
+					fmt.Errorf("invalid status %q: must be %q or %q", status, NotifyApproval, NotifyReady))
+			}
+		}
+	}
+
+	return errs.ToError()
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNotify(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Rules = []Rule{
+		{Notify: []string{NotifyApproval}},
+		{Pattern: "colonyops/.*", Notify: []string{NotifyApproval, NotifyReady}},
+		{Pattern: "colonyops/quiet", Notify: []string{}},
+		{Pattern: "colonyops/.*", Spawn: []string{"claude"}},
+	}
+
+	assert.Equal(t, []string{NotifyApproval}, cfg.GetNotify("github.com/other/repo"))
+	assert.Equal(t, []string{NotifyApproval, NotifyReady}, cfg.GetNotify("github.com/colonyops/hive"), "rules without notify do not override")
+	assert.Empty(t, cfg.GetNotify("github.com/colonyops/quiet"), "an empty list opts out")
+
+	defaults := DefaultConfig()
+	assert.Empty(t, defaults.GetNotify("github.com/colonyops/hive"), "notifications are opt-in")
+}
+
+func TestValidateDesktopNotify(t *testing.T) {
+	t.Run("defaults pass", func(t *testing.T) {
+		cfg := DefaultConfig()
+		assert.NoError(t, cfg.validateDesktopNotify())
+		assert.Equal(t, time.Minute, cfg.DesktopNotify.RateLimit)
+	})
+
+	t.Run("invalid values rejected", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.DesktopNotify.RateLimit = -time.Second
+		cfg.Rules = []Rule{{Notify: []string{NotifyReady, "active"}}}
+		err := cfg.validateDesktopNotify()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "desktop_notify.rate_limit")
+		assert.Contains(t, err.Error(), "rules[0].notify[1]")
+	})
+}
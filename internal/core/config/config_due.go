@@ -0,0 +1,24 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hay-kot/criterio"
+)
+
+// DueConfig controls what happens to sessions that pass their due date.
+type DueConfig struct {
+	ArchiveAfter time.Duration `json:"archive_after" yaml:"archive_after"` // archive overdue sessions this long after their due date (default: 0, never)
+}
+
+// validateDue checks the overdue grace period.
+func (c *Config) validateDue() error {
+	var errs criterio.FieldErrorsBuilder
+
+	if c.Due.ArchiveAfter < 0 {
+		errs = errs.Append("due.archive_after", fmt.Errorf("must be >= 0"))
+	}
+
+	return errs.ToError()
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDue(t *testing.T) {
+	cfg := DefaultConfig()
+	require.NoError(t, cfg.validateDue())
+	assert.Zero(t, cfg.Due.ArchiveAfter)
+
+	cfg.Due.ArchiveAfter = 72 * time.Hour
+	require.NoError(t, cfg.validateDue())
+
+	cfg.Due.ArchiveAfter = -time.Hour
+	err := cfg.validateDue()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "due.archive_after")
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hay-kot/criterio"
+)
+
+// HostConfig describes a remote host whose sessions hive manages over ssh.
+type HostConfig struct {
+	SSH     string   `json:"ssh"      yaml:"ssh"`      // ssh destination, e.g. "me@devbox" or a Host alias from ~/.ssh/config
+	SSHArgs []string `json:"ssh_args" yaml:"ssh_args"` // extra ssh options, e.g. ["-p", "2222"]
+	DataDir string   `json:"data_dir" yaml:"data_dir"` // absolute path of the hive data directory on the host
+}
+
+// HostNames returns the configured host names, sorted.
+func (c *Config) HostNames() []string {
+	names := make([]string, 0, len(c.Hosts))
+	for name := range c.Hosts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Host returns the configuration of the named host.
+func (c *Config) Host(name string) (HostConfig, error) {
+	host, ok := c.Hosts[name]
+	if !ok {
+		if len(c.Hosts) == 0 {
+			return HostConfig{}, fmt.Errorf("unknown host %q: no hosts configured", name)
+		}
+		return HostConfig{}, fmt.Errorf("unknown host %q (configured: %s)", name, strings.Join(c.HostNames(), ", "))
+	}
+	return host, nil
+}
+
+// HostDataDir returns the local data directory hive uses while managing the
+// named host. It holds that host's session records; the session directories
+// themselves live under the host's data_dir.
+func (c *Config) HostDataDir(name string) string {
+	return filepath.Join(c.DataDir, "hosts", name)
+}
+
+// validateHosts checks that each host has an ssh destination and an absolute
+// data directory, and that host names are usable as directory names.
+func (c *Config) validateHosts() error {
+	var errs criterio.FieldErrorsBuilder
+
+	for _, name := range c.HostNames() {
+		host := c.Hosts[name]
+		field := "hosts." + name
+		if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+			errs = errs.Append(field, fmt.Errorf("invalid host name %q", name))
+		}
+		if strings.TrimSpace(host.SSH) == "" {
+			errs = errs.Append(field+".ssh", fmt.Errorf("ssh destination is required"))
+		}
+		if host.DataDir == "" {
+			errs = errs.Append(field+".data_dir", fmt.Errorf("data_dir is required"))
+		} else if !path.IsAbs(host.DataDir) {
+			errs = errs.Append(field+".data_dir", fmt.Errorf("data_dir must be an absolute path, got %q", host.DataDir))
+		}
+	}
+
+	return errs.ToError()
+}
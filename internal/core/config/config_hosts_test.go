@@ -0,0 +1,45 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateHosts(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Hosts = map[string]HostConfig{
+		"devbox": {SSH: "me@devbox", DataDir: "/home/me/.local/share/hive"},
+	}
+	require.NoError(t, cfg.validateHosts())
+
+	cfg.Hosts = map[string]HostConfig{
+		"a/b":  {SSH: "x", DataDir: "/d"},
+		"nos":  {DataDir: "/d"},
+		"rel":  {SSH: "x", DataDir: "~/.local/share/hive"},
+		"none": {SSH: "x"},
+	}
+	err := cfg.validateHosts()
+	require.Error(t, err)
+	for _, field := range []string{"hosts.a/b", "hosts.nos.ssh", "hosts.rel.data_dir", "hosts.none.data_dir"} {
+		assert.Contains(t, err.Error(), field)
+	}
+}
+
+func TestHost(t *testing.T) {
+	cfg := DefaultConfig()
+	_, err := cfg.Host("devbox")
+	require.ErrorContains(t, err, "no hosts configured")
+
+	cfg.Hosts = map[string]HostConfig{
+		"b": {SSH: "b"},
+		"a": {SSH: "a"},
+	}
+	host, err := cfg.Host("a")
+	require.NoError(t, err)
+	assert.Equal(t, "a", host.SSH)
+
+	_, err = cfg.Host("c")
+	require.ErrorContains(t, err, "configured: a, b")
+}
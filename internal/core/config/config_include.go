@@ -0,0 +1,241 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hay-kot/criterio"
+	"gopkg.in/yaml.v3"
+
+	"github.com/colonyops/hive/pkg/pathutil"
+)
+
+// Config files can be split into fragments listed under include:
+//
+//	include:
+//	  - rules.yaml
+//	  - conf.d/*.yaml
+//
+// Fragments are merged in the order listed, glob matches in name order, and
+// the config file itself is merged last, so later files take precedence.
+// Mappings are merged key by key (user commands, agent profiles and
+// keybindings as a whole), top-level lists such as rules are appended, and
+// any other value is replaced.
+
+// configOrigins maps config paths, in the dotted form used by validation
+// errors, to the fragment that set them. Paths set by the config file itself
+// map to "".
+type configOrigins map[string]string
+
+// lookup returns the fragment that set field or its nearest parent.
+func (o configOrigins) lookup(field string) string {
+	for field != "" {
+		if file, ok := o[field]; ok {
+			return file
+		}
+		field = field[:max(strings.LastIndexAny(field, ".["), 0)]
+	}
+	return ""
+}
+
+// set records file as the origin of path, replacing the origins of its
+// children.
+func (o configOrigins) set(path, file string) {
+	for p := range o {
+		if strings.HasPrefix(p, path+".") || strings.HasPrefix(p, path+"[") {
+			delete(o, p)
+		}
+	}
+	o[path] = file
+}
+
+// includeMerger merges the top-level mapping of one file into another.
+type includeMerger struct {
+	origins configOrigins
+	file    string // origin recorded for merged values
+}
+
+// merge merges the entries of mapping src into mapping dst.
+func (m includeMerger) merge(dst, src *yaml.Node, path string, atomic bool) {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, val := src.Content[i], src.Content[i+1]
+		child := joinBundlePath(path, key.Value)
+
+		idx := bundleMappingIndex(dst, key.Value)
+		if idx < 0 {
+			dst.Content = append(dst.Content, key, val)
+			m.origins.set(child, m.file)
+			continue
+		}
+
+		cur := dst.Content[idx+1]
+		switch {
+		case !atomic && cur.Kind == yaml.MappingNode && val.Kind == yaml.MappingNode:
+			m.merge(cur, val, child, slices.Contains(bundleAtomicParents, key.Value))
+		case path == "" && cur.Kind == yaml.SequenceNode && val.Kind == yaml.SequenceNode:
+			for j := range val.Content {
+				m.origins[fmt.Sprintf("%s[%d]", child, len(cur.Content)+j)] = m.file
+			}
+			cur.Content = append(cur.Content, val.Content...)
+		default:
+			dst.Content[idx+1] = val
+			m.origins.set(child, m.file)
+		}
+	}
+}
+
+// loadConfigDocument reads the config file at path and merges in the
+// fragments it includes. It returns the merged top-level mapping and the
+// origin of every value that came from a fragment. With written set, the
+// environment variable and secret references in every file are replaced and
+// written maps each value with references replaced to the value as written.
+func loadConfigDocument(path string, written map[string]string) (*yaml.Node, configOrigins, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read config file: %w", err)
+	}
+	root, err := parseBundleDocument(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse config file: %w", err)
+	}
+	if written != nil {
+		if err := expandConfigReferences(root, written); err != nil {
+			return nil, nil, fmt.Errorf("invalid config: %w", err)
+		}
+	}
+
+	var patterns []string
+	if idx := bundleMappingIndex(root, "include"); idx >= 0 {
+		if err := root.Content[idx+1].Decode(&patterns); err != nil {
+			return nil, nil, fmt.Errorf("parse config file: include: %w", err)
+		}
+	}
+	if len(patterns) == 0 {
+		return root, nil, nil
+	}
+
+	files, err := resolveIncludes(path, patterns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	merged := &yaml.Node{Kind: yaml.MappingNode}
+	origins := configOrigins{}
+	dir := filepath.Dir(path)
+	for _, file := range files {
+		name := file
+		if rel, err := filepath.Rel(dir, file); err == nil && !strings.HasPrefix(rel, "..") {
+			name = rel
+		}
+
+		fragment, err := loadConfigFragment(file, written)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse config file %s: %w", name, err)
+		}
+		includeMerger{origins: origins, file: name}.merge(merged, fragment, "", false)
+	}
+	includeMerger{origins: origins}.merge(merged, root, "", false)
+
+	return merged, origins, nil
+}
+
+// loadConfigFragment reads an included file. With written set, its
+// references are replaced as by loadConfigDocument and it is checked to
+// decode as a config on its own, so type errors are reported against the file.
+func loadConfigFragment(path string, written map[string]string) (*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fragment, err := parseBundleDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	if bundleMappingIndex(fragment, "include") >= 0 {
+		return nil, errors.New("include is only supported in the main config file")
+	}
+	if written == nil {
+		return fragment, nil
+	}
+	if err := expandConfigReferences(fragment, written); err != nil {
+		return nil, err
+	}
+	var probe Config
+	if err := fragment.Decode(&probe); err != nil {
+		return nil, err
+	}
+	return fragment, nil
+}
+
+// resolveIncludes expands include patterns, relative to the directory of the
+// config file, into the files to merge in order. Each file is merged once,
+// and the config file itself is skipped. A pattern without glob characters
+// must name an existing file.
+func resolveIncludes(configPath string, patterns []string) ([]string, error) {
+	dir := filepath.Dir(configPath)
+	self, _ := filepath.Abs(configPath)
+
+	var files []string
+	seen := map[string]bool{self: true}
+	for _, pattern := range patterns {
+		pattern = pathutil.ExpandHome(pattern)
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(dir, pattern)
+		}
+
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("include %q: %w", pattern, err)
+		}
+		if len(matches) == 0 && !strings.ContainsAny(pattern, "*?[") {
+			return nil, fmt.Errorf("include %q: file not found", pattern)
+		}
+
+		// Glob returns matches in name order
+		for _, match := range matches {
+			abs, err := filepath.Abs(match)
+			if err != nil {
+				return nil, fmt.Errorf("include %q: %w", match, err)
+			}
+			if info, err := os.Stat(abs); err != nil || info.IsDir() || seen[abs] {
+				continue
+			}
+			seen[abs] = true
+			files = append(files, abs)
+		}
+	}
+	return files, nil
+}
+
+// ReadWithIncludes returns the config file at path with its included
+// fragments merged in, as YAML. References to environment variables and
+// secrets are kept as written.
+func ReadWithIncludes(path string) ([]byte, error) {
+	root, _, err := loadConfigDocument(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return encodeBundleNode(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}})
+}
+
+// attributeErrors notes, on each validation error about a value that came
+// from an included fragment, the fragment it came from.
+func (c *Config) attributeErrors(err error) error {
+	var fieldErrs criterio.FieldErrors
+	if len(c.origins) == 0 || !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	attributed := make(criterio.FieldErrors, len(fieldErrs))
+	for i, fe := range fieldErrs {
+		if file := c.origins.lookup(fe.Field); file != "" {
+			fe.Err = fmt.Errorf("%w (from %s)", fe.Err, file)
+		}
+		attributed[i] = fe
+	}
+	return attributed
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfigFiles writes files, keyed by path relative to a temp dir, and
+// returns the path of config.yaml.
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return filepath.Join(dir, "config.yaml")
+}
+
+func TestLoad_Include(t *testing.T) {
+	configPath := writeConfigFiles(t, map[string]string{
+		"config.yaml": `
+include:
+  - rules.yaml
+  - conf.d/*.yaml
+git:
+  status_workers: 5
+rules:
+  - pattern: main
+`,
+		"rules.yaml": `
+rules:
+  - pattern: first
+  - pattern: second
+`,
+		"conf.d/10-team.yaml": `
+git:
+  status_workers: 2
+usercommands:
+  deploy:
+    sh: make deploy
+    help: deploy it
+  lint:
+    sh: make lint
+`,
+		"conf.d/20-overlay.yaml": `
+usercommands:
+  deploy:
+    sh: ./deploy.sh
+`,
+	})
+
+	cfg, err := Load(configPath, t.TempDir())
+	require.NoError(t, err)
+
+	patterns := make([]string, 0, len(cfg.Rules))
+	for _, r := range cfg.Rules {
+		patterns = append(patterns, r.Pattern)
+	}
+	assert.Equal(t, []string{"first", "second", "main"}, patterns, "rules are appended in load order")
+	assert.Equal(t, 5, cfg.Git.StatusWorkers, "the config file takes precedence")
+	assert.Equal(t, "./deploy.sh", cfg.UserCommands["deploy"].Sh, "later fragments take precedence")
+	assert.Empty(t, cfg.UserCommands["deploy"].Help, "user commands are replaced as a whole")
+	assert.Equal(t, "make lint", cfg.UserCommands["lint"].Sh)
+}
+
+func TestLoad_IncludeErrorAttribution(t *testing.T) {
+	configPath := writeConfigFiles(t, map[string]string{
+		"config.yaml": `
+include: [conf.d/*.yaml]
+rules:
+  - pattern: main
+    clone_strategy: bogus
+`,
+		"conf.d/rules.yaml": `
+rules:
+  - pattern: ok
+  - pattern: bad
+    clone_strategy: sideways
+`,
+	})
+
+	_, err := Load(configPath, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rules[1].clone_strategy")
+	assert.Contains(t, err.Error(), "(from conf.d/rules.yaml)")
+	assert.Contains(t, err.Error(), "rules[2].clone_strategy")
+	assert.True(t, strings.HasSuffix(err.Error(), `"bogus": must be "full" or "worktree"`), "errors in the config file are not attributed")
+}
+
+func TestLoad_IncludeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name:  "missing file",
+			files: map[string]string{"config.yaml": "include: [missing.yaml]\n"},
+			want:  "file not found",
+		},
+		{
+			name:  "empty glob",
+			files: map[string]string{"config.yaml": "include: [conf.d/*.yaml]\n"},
+		},
+		{
+			name: "nested include",
+			files: map[string]string{
+				"config.yaml": "include: [a.yaml]\n",
+				"a.yaml":      "include: [b.yaml]\n",
+			},
+			want: "parse config file a.yaml: include is only supported in the main config file",
+		},
+		{
+			name: "type error",
+			files: map[string]string{
+				"config.yaml": "include: [a.yaml]\n",
+				"a.yaml":      "git:\n  status_workers: many\n",
+			},
+			want: "parse config file a.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeConfigFiles(t, tt.files), t.TempDir())
+			if tt.want == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestReadWithIncludes(t *testing.T) {
+	configPath := writeConfigFiles(t, map[string]string{
+		"config.yaml":   "include: [commands.yaml]\ntui:\n  theme: tokyo-night\n",
+		"commands.yaml": "usercommands:\n  lint:\n    sh: make lint\n",
+	})
+
+	data, err := ReadWithIncludes(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "sh: make lint")
+	assert.Contains(t, string(data), "theme: tokyo-night")
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/hay-kot/criterio"
+)
+
+// WebhookEvents lists the events a webhook can subscribe to.
+var WebhookEvents = []string{
+	"agent.status-changed",
+	"message.received",
+	"review.finalized",
+	"session.created",
+	"session.deleted",
+	"session.recycled",
+}
+
+// IntegrationsConfig holds settings for sending hive events to external services.
+type IntegrationsConfig struct {
+	Webhooks []WebhookConfig `json:"webhooks" yaml:"webhooks"`
+}
+
+// WebhookConfig is an HTTP endpoint that receives events as JSON POST requests.
+type WebhookConfig struct {
+	URL     string            `json:"url"     yaml:"url"`
+	Events  []string          `json:"events"  yaml:"events"`  // events to send (default: all of WebhookEvents)
+	Headers map[string]string `json:"headers" yaml:"headers"` // extra request headers, e.g. Authorization
+}
+
+// Wants reports whether the webhook subscribes to event.
+func (w WebhookConfig) Wants(event string) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, event)
+}
+
+// validateIntegrations checks each webhook's URL and event filters.
+func (c *Config) validateIntegrations() error {
+	var errs criterio.FieldErrorsBuilder
+
+	for i, hook := range c.Integrations.Webhooks {
+		field := fmt.Sprintf("integrations.webhooks[%d]", i)
+
+		u, err := url.Parse(hook.URL)
+		switch {
+		case hook.URL == "":
+			errs = errs.Append(field+".url", fmt.Errorf("is required"))
+		case err != nil:
+			errs = errs.Append(field+".url", err)
+		case u.Scheme != "http" && u.Scheme != "https", u.Host == "":
+			errs = errs.Append(field+".url", fmt.Errorf("must be an absolute http or https URL"))
+		}
+
+		for j, event := range hook.Events {
+			if !slices.Contains(WebhookEvents, event) {
+				errs = errs.Append(fmt.Sprintf("%s.events[%d]", field, j),
+					fmt.Errorf("unknown event %q: must be one of %s", event, strings.Join(WebhookEvents, ", ")))
+			}
+		}
+	}
+
+	return errs.ToError()
+}
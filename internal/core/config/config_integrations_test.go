@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookConfigWants(t *testing.T) {
+	all := WebhookConfig{URL: "https://example.com/hook"}
+	assert.True(t, all.Wants("session.created"), "no filter sends every event")
+
+	filtered := WebhookConfig{URL: "https://example.com/hook", Events: []string{"review.finalized"}}
+	assert.True(t, filtered.Wants("review.finalized"))
+	assert.False(t, filtered.Wants("session.created"))
+}
+
+func TestValidateIntegrations(t *testing.T) {
+	t.Run("defaults pass", func(t *testing.T) {
+		cfg := DefaultConfig()
+		assert.NoError(t, cfg.validateIntegrations())
+	})
+
+	t.Run("valid webhooks pass", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Integrations.Webhooks = []WebhookConfig{
+			{URL: "https://example.com/hook"},
+			{URL: "http://localhost:8080/events", Events: []string{"session.created", "agent.status-changed"}},
+		}
+		assert.NoError(t, cfg.validateIntegrations())
+	})
+
+	t.Run("invalid values rejected", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Integrations.Webhooks = []WebhookConfig{
+			{URL: ""},
+			{URL: "ftp://example.com"},
+			{URL: "https://example.com", Events: []string{"session.created", "session.exploded"}},
+		}
+		err := cfg.validateIntegrations()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "integrations.webhooks[0].url")
+		assert.Contains(t, err.Error(), "integrations.webhooks[1].url")
+		assert.Contains(t, err.Error(), "integrations.webhooks[2].events[1]")
+		assert.NotContains(t, err.Error(), "integrations.webhooks[2].url")
+	})
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KeybindingViews lists the views a keybinding can be written to, in the
+// order their keybindings apply: global first, overridden per view.
+var KeybindingViews = []string{"global", "sessions", "tasks", "review"}
+
+// SetKeybinding binds key to the command cmd in view within the raw contents
+// of a config file, preserving the config's comments and key order. The
+// binding is written to views.<view>.keybindings, or to the deprecated
+// top-level keybindings when that already binds key in the sessions view,
+// since those take precedence. An existing binding's help and confirm
+// overrides are dropped, as they described the previous command.
+func SetKeybinding(current []byte, view, key, cmd string) ([]byte, error) {
+	root, err := parseBundleDocument(current)
+	if err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+
+	var bindings *yaml.Node
+	if idx := bundleMappingIndex(root, "keybindings"); view == "sessions" && idx >= 0 {
+		if legacy := root.Content[idx+1]; legacy.Kind == yaml.MappingNode && bundleMappingIndex(legacy, key) >= 0 {
+			bindings = legacy
+		}
+	}
+	if bindings == nil {
+		bindings = root
+		for _, name := range []string{"views", view, "keybindings"} {
+			if bindings, err = childMapping(bindings, name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	binding := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Value: "cmd"},
+		{Kind: yaml.ScalarNode, Value: cmd},
+	}}
+	if idx := bundleMappingIndex(bindings, key); idx >= 0 {
+		bindings.Content[idx+1] = binding
+	} else {
+		bindings.Content = append(bindings.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, binding)
+	}
+
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}
+	var orig yaml.Node
+	if err := yaml.Unmarshal(current, &orig); err == nil && orig.Kind == yaml.DocumentNode {
+		doc.HeadComment, doc.FootComment = orig.HeadComment, orig.FootComment
+	}
+	return encodeBundleNode(doc)
+}
+
+// childMapping returns the mapping under key in n, adding an empty one if
+// key is missing or null.
+func childMapping(n *yaml.Node, key string) (*yaml.Node, error) {
+	idx := bundleMappingIndex(n, key)
+	if idx < 0 {
+		child := &yaml.Node{Kind: yaml.MappingNode}
+		n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, child)
+		return child, nil
+	}
+
+	child := n.Content[idx+1]
+	switch {
+	case child.Kind == yaml.MappingNode:
+		return child, nil
+	case child.Kind == yaml.ScalarNode && child.Tag == "!!null":
+		child = &yaml.Node{Kind: yaml.MappingNode}
+		n.Content[idx+1] = child
+		return child, nil
+	default:
+		return nil, fmt.Errorf("%s: expected a mapping", key)
+	}
+}
+
+// WriteKeybinding binds key to cmd in view in the config file at path,
+// creating the file if needed. The previous contents are saved next to it
+// with a .bak suffix, whose path is returned, and the file is left untouched
+// if the result does not load. A symlinked config is updated through the
+// link and keeps its mode, as with WriteFile.
+func WriteKeybinding(path, dataDir, view, key, cmd string) (string, error) {
+	target, mode, err := resolveWritePath(path)
+	if err != nil {
+		return "", err
+	}
+	current, err := os.ReadFile(target)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("read config file: %w", err)
+	}
+	exists := err == nil
+
+	updated, err := SetKeybinding(current, view, key, cmd)
+	if err != nil {
+		return "", err
+	}
+
+	backup := ""
+	err = WriteFile(target, updated, func(tmp string) error {
+		if _, err := Load(tmp, dataDir); err != nil {
+			return fmt.Errorf("updated config is invalid, %s not modified: %w", path, err)
+		}
+		if exists {
+			backup = target + ".bak"
+			if err := os.WriteFile(backup, current, mode); err != nil {
+				return fmt.Errorf("write backup: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return backup, nil
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetKeybinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		view    string
+		key     string
+		want    string
+	}{
+		{
+			name:    "empty config",
+			current: "",
+			view:    "sessions",
+			key:     "ctrl+o",
+			want:    "views:\n  sessions:\n    keybindings:\n      ctrl+o:\n        cmd: Deploy\n",
+		},
+		{
+			name: "keeps comments and order",
+			current: `# my config
+tui:
+  theme: tokyo-night # favourite
+views:
+  global:
+    keybindings:
+      x: {cmd: Old, help: old help}
+`,
+			view: "global",
+			key:  "x",
+			want: `# my config
+tui:
+  theme: tokyo-night # favourite
+views:
+  global:
+    keybindings:
+      x:
+        cmd: Deploy
+`,
+		},
+		{
+			name:    "null view",
+			current: "views:\n  tasks:\n",
+			view:    "tasks",
+			key:     "D",
+			want:    "views:\n  tasks:\n    keybindings:\n      D:\n        cmd: Deploy\n",
+		},
+		{
+			name:    "legacy keybindings",
+			current: "keybindings:\n  d:\n    cmd: Delete\n",
+			view:    "sessions",
+			key:     "d",
+			want:    "keybindings:\n  d:\n    cmd: Deploy\n",
+		},
+		{
+			name:    "legacy keybindings without the key",
+			current: "keybindings:\n  d:\n    cmd: Delete\n",
+			view:    "sessions",
+			key:     "e",
+			want:    "keybindings:\n  d:\n    cmd: Delete\nviews:\n  sessions:\n    keybindings:\n      e:\n        cmd: Deploy\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetKeybinding([]byte(tt.current), tt.view, tt.key, "Deploy")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestSetKeybinding_NotAMapping(t *testing.T) {
+	_, err := SetKeybinding([]byte("views: [sessions]\n"), "sessions", "x", "Deploy")
+	require.ErrorContains(t, err, "views: expected a mapping")
+}
+
+func TestWriteKeybinding(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	original := "usercommands:\n  Deploy:\n    sh: make deploy\n"
+	require.NoError(t, os.WriteFile(path, []byte(original), 0o644))
+
+	backup, err := WriteKeybinding(path, t.TempDir(), "sessions", "ctrl+o", "Deploy")
+	require.NoError(t, err)
+	assert.Equal(t, path+".bak", backup)
+
+	saved, err := os.ReadFile(backup)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(saved))
+
+	cfg, err := Load(path, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, "Deploy", cfg.Views.Sessions.Keybindings["ctrl+o"].Cmd)
+	assert.NoFileExists(t, path+".tmp")
+}
+
+func TestWriteKeybinding_NewFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hive", "config.yaml")
+
+	backup, err := WriteKeybinding(path, t.TempDir(), "global", "ctrl+o", "HiveInfo")
+	require.NoError(t, err)
+	assert.Empty(t, backup, "nothing to back up")
+	assert.FileExists(t, path)
+}
+
+func TestWriteKeybinding_SymlinkKeepsMode(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "dotfiles", "config.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(target), 0o755))
+	require.NoError(t, os.WriteFile(target, []byte("usercommands:\n  Deploy:\n    sh: make deploy\n"), 0o600))
+	link := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.Symlink(target, link))
+
+	backup, err := WriteKeybinding(link, t.TempDir(), "sessions", "ctrl+o", "Deploy")
+	require.NoError(t, err)
+	assert.Equal(t, target+".bak", backup, "backup sits next to the linked file")
+
+	fi, err := os.Lstat(link)
+	require.NoError(t, err)
+	assert.NotZero(t, fi.Mode()&os.ModeSymlink, "link is not replaced by a regular file")
+	for _, p := range []string{target, backup} {
+		info, err := os.Stat(p)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), p)
+	}
+
+	cfg, err := Load(link, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, "Deploy", cfg.Views.Sessions.Keybindings["ctrl+o"].Cmd)
+}
+
+func TestWriteKeybinding_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	original := "tui:\n  theme: bogus\n"
+	require.NoError(t, os.WriteFile(path, []byte(original), 0o644))
+
+	_, err := WriteKeybinding(path, t.TempDir(), "global", "ctrl+o", "HiveInfo")
+	require.ErrorContains(t, err, "not modified")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(data))
+	assert.NoFileExists(t, path+".bak")
+	assert.NoFileExists(t, path+".tmp")
+}
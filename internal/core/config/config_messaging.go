@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hay-kot/criterio"
+
+	"github.com/colonyops/hive/internal/core/messaging"
+)
+
+// DefaultMessageRetention is how long messages are kept when
+// messaging.retention is not set.
+const DefaultMessageRetention = 30 * 24 * time.Hour
+
+// MessagingConfig holds messaging-related configuration.
+type MessagingConfig struct {
+	TopicPrefix       string                 `json:"topic_prefix"  yaml:"topic_prefix"`  // default: "agent"
+	Retention         time.Duration          `json:"retention"     yaml:"retention"`     // delete messages older than this (default: 720h, 0 = keep forever)
+	MaxPerTopic       int                    `json:"max_per_topic" yaml:"max_per_topic"` // max messages kept per topic (default: 100, 0 = unlimited)
+	Topics            []TopicRetentionConfig `json:"topics"        yaml:"topics"`        // per-topic overrides, last match wins
+	MaxMessagesCompat *int                   `json:"-"             yaml:"max_messages"`  // deprecated: use max_per_topic instead (kept for backwards compatibility)
+}
+
+// TopicRetentionConfig overrides retention for topics matching Pattern.
+// Unset fields inherit the messaging defaults.
+type TopicRetentionConfig struct {
+	Pattern     string         `json:"pattern"                 yaml:"pattern"`                 // topic pattern, e.g. "build.>" or "agent.*.inbox"
+	Retention   *time.Duration `json:"retention,omitempty"     yaml:"retention,omitempty"`     // 0 = keep forever
+	MaxPerTopic *int           `json:"max_per_topic,omitempty" yaml:"max_per_topic,omitempty"` // 0 = unlimited
+	AckedOnly   bool           `json:"acked_only,omitempty"    yaml:"acked_only,omitempty"`    // only delete messages a consumer acknowledged
+}
+
+// RetentionPolicy returns the message retention policy described by the
+// config, with each override's unset fields filled from the defaults.
+func (m MessagingConfig) RetentionPolicy() messaging.RetentionPolicy {
+	policy := messaging.RetentionPolicy{
+		Default: messaging.Retention{MaxAge: m.Retention, MaxPerTopic: m.MaxPerTopic},
+	}
+	for _, t := range m.Topics {
+		r := policy.Default
+		if t.Retention != nil {
+			r.MaxAge = *t.Retention
+		}
+		if t.MaxPerTopic != nil {
+			r.MaxPerTopic = *t.MaxPerTopic
+		}
+		r.AckedOnly = t.AckedOnly
+		policy.Topics = append(policy.Topics, messaging.TopicRetention{Pattern: t.Pattern, Retention: r})
+	}
+	return policy
+}
+
+// validateMessaging checks retention limits and override patterns.
+func (c *Config) validateMessaging() error {
+	var errs criterio.FieldErrorsBuilder
+
+	if c.Messaging.Retention < 0 {
+		errs = errs.Append("messaging.retention", fmt.Errorf("must be >= 0"))
+	}
+	if c.Messaging.MaxPerTopic < 0 {
+		errs = errs.Append("messaging.max_per_topic", fmt.Errorf("must be >= 0"))
+	}
+
+	for i, t := range c.Messaging.Topics {
+		field := fmt.Sprintf("messaging.topics[%d]", i)
+		if t.Pattern == "" {
+			errs = errs.Append(field+".pattern", fmt.Errorf("pattern is required"))
+		} else if err := messaging.ValidateTopicPattern(t.Pattern); err != nil {
+			errs = errs.Append(field+".pattern", err)
+		}
+		if t.Retention != nil && *t.Retention < 0 {
+			errs = errs.Append(field+".retention", fmt.Errorf("must be >= 0"))
+		}
+		if t.MaxPerTopic != nil && *t.MaxPerTopic < 0 {
+			errs = errs.Append(field+".max_per_topic", fmt.Errorf("must be >= 0"))
+		}
+	}
+
+	return errs.ToError()
+}
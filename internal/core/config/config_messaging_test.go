@@ -0,0 +1,77 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/messaging"
+)
+
+func TestValidateMessaging(t *testing.T) {
+	cfg := DefaultConfig()
+	require.NoError(t, cfg.validateMessaging())
+
+	neg := -time.Hour
+	negInt := -1
+	cfg.Messaging.Retention = -time.Hour
+	cfg.Messaging.MaxPerTopic = -1
+	cfg.Messaging.Topics = []TopicRetentionConfig{
+		{},
+		{Pattern: "build.>.x"},
+		{Pattern: "build.>", Retention: &neg, MaxPerTopic: &negInt},
+	}
+	err := cfg.validateMessaging()
+	require.Error(t, err)
+	for _, field := range []string{
+		"messaging.retention",
+		"messaging.max_per_topic",
+		"messaging.topics[0].pattern",
+		"messaging.topics[1].pattern",
+		"messaging.topics[2].retention",
+		"messaging.topics[2].max_per_topic",
+	} {
+		assert.Contains(t, err.Error(), field)
+	}
+}
+
+func TestMessagingConfig_RetentionPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `messaging:
+  retention: 168h
+  topics:
+    - pattern: "build.>"
+      retention: 24h
+    - pattern: "agent.*.inbox"
+      max_per_topic: 0
+      acked_only: true
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	cfg, err := Load(path, t.TempDir())
+	require.NoError(t, err)
+
+	policy := cfg.Messaging.RetentionPolicy()
+	assert.Equal(t, messaging.Retention{MaxAge: 168 * time.Hour, MaxPerTopic: 100}, policy.For("other"))
+	assert.Equal(t, messaging.Retention{MaxAge: 24 * time.Hour, MaxPerTopic: 100}, policy.For("build.api"))
+	assert.Equal(t, messaging.Retention{MaxAge: 168 * time.Hour, AckedOnly: true}, policy.For("agent.abc.inbox"))
+}
+
+func TestLoad_MaxMessagesCompat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("messaging:\n  max_messages: 25\n"), 0o644))
+
+	cfg, err := Load(path, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, 25, cfg.Messaging.MaxPerTopic)
+
+	require.NoError(t, os.WriteFile(path, []byte("messaging:\n  max_messages: 0\n"), 0o644))
+	cfg, err = Load(path, t.TempDir())
+	require.NoError(t, err)
+	assert.Zero(t, cfg.Messaging.MaxPerTopic, "max_messages: 0 is unlimited")
+	assert.Equal(t, DefaultMessageRetention, cfg.Messaging.Retention)
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hay-kot/criterio"
+)
+
+// PreflightCheck is a prerequisite verified before a session's spawn commands
+// run, so a missing tool fails session creation with a clear message instead
+// of spawning windows that exit immediately. Each check sets exactly one of
+// Command, Env, Port, or Docker.
+type PreflightCheck struct {
+	Command string `json:"command,omitempty" yaml:"command,omitempty"` // executable that must be on PATH
+	Env     string `json:"env,omitempty"     yaml:"env,omitempty"`     // environment variable that must be set and non-empty
+	Port    int    `json:"port,omitempty"    yaml:"port,omitempty"`    // TCP port that must be free on localhost
+	Docker  bool   `json:"docker,omitempty"  yaml:"docker,omitempty"`  // the docker daemon must be reachable
+	Hint    string `json:"hint,omitempty"    yaml:"hint,omitempty"`    // how to fix a failure, shown with the error
+}
+
+// kinds counts how many of the check's targets are set.
+func (p PreflightCheck) kinds() int {
+	n := 0
+	for _, set := range []bool{p.Command != "", p.Env != "", p.Port != 0, p.Docker} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// GetPreflight returns the preflight checks for a session of remote spawned
+// with the agent profile agentKey: those of every matching rule, in order,
+// followed by the profile's. An empty agentKey selects the agent the rules
+// resolve to, or agents.default.
+func (c *Config) GetPreflight(remote, agentKey string, batch bool) []PreflightCheck {
+	var checks []PreflightCheck
+	for _, rule := range c.Rules {
+		if rule.Matches(remote) {
+			checks = append(checks, rule.Preflight...)
+		}
+	}
+	if agentKey == "" {
+		agentKey = ResolveSpawn(c.Rules, remote, batch).Agent
+	}
+	if agentKey == "" {
+		agentKey = c.Agents.Default
+	}
+	return append(checks, c.Agents.Profiles[agentKey].Preflight...)
+}
+
+// validatePreflight checks that every rule and agent profile preflight check
+// names exactly one target and that ports are in range.
+func (c *Config) validatePreflight() error {
+	var errs criterio.FieldErrorsBuilder
+	check := func(prefix string, checks []PreflightCheck) {
+		for i, p := range checks {
+			field := fmt.Sprintf("%s.preflight[%d]", prefix, i)
+			if p.kinds() != 1 {
+				errs = errs.Append(field, fmt.Errorf("must set exactly one of command, env, port, or docker"))
+			}
+			if p.Port < 0 || p.Port > 65535 {
+				errs = errs.Append(field+".port", fmt.Errorf("must be between 1 and 65535, got %d", p.Port))
+			}
+		}
+	}
+	for i, rule := range c.Rules {
+		check(fmt.Sprintf("rules[%d]", i), rule.Preflight)
+	}
+	for _, key := range slices.Sorted(maps.Keys(c.Agents.Profiles)) {
+		check("agents."+key, c.Agents.Profiles[key].Preflight)
+	}
+	return errs.ToError()
+}
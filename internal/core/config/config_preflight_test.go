@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPreflight(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Agents.Default = "claude"
+	cfg.Agents.Profiles = map[string]AgentProfile{
+		"claude": {Preflight: []PreflightCheck{{Command: "claude"}}},
+		"codex":  {Preflight: []PreflightCheck{{Env: "OPENAI_API_KEY"}}},
+	}
+	cfg.Rules = []Rule{
+		{Pattern: "", Preflight: []PreflightCheck{{Docker: true}}},
+		{Pattern: "api", Preflight: []PreflightCheck{{Port: 5432}}, Agent: "codex"},
+	}
+
+	assert.Equal(t, []PreflightCheck{{Docker: true}, {Command: "claude"}},
+		cfg.GetPreflight("git@github.com:org/web.git", "", false), "default agent profile")
+	assert.Equal(t, []PreflightCheck{{Docker: true}, {Port: 5432}, {Env: "OPENAI_API_KEY"}},
+		cfg.GetPreflight("git@github.com:org/api.git", "", false), "agent resolved from rules")
+	assert.Equal(t, []PreflightCheck{{Docker: true}, {Port: 5432}, {Command: "claude"}},
+		cfg.GetPreflight("git@github.com:org/api.git", "claude", false), "explicit agent overrides rules")
+}
+
+func TestValidatePreflight(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Rules = []Rule{{Preflight: []PreflightCheck{
+		{Command: "docker"},
+		{},
+		{Command: "make", Env: "HOME"},
+		{Port: 70000},
+	}}}
+	cfg.Agents.Profiles = map[string]AgentProfile{"claude": {Preflight: []PreflightCheck{{Hint: "install claude"}}}}
+
+	err := cfg.validatePreflight()
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "rules[0].preflight[0]")
+	assert.Contains(t, err.Error(), "rules[0].preflight[1]")
+	assert.Contains(t, err.Error(), "rules[0].preflight[2]")
+	assert.Contains(t, err.Error(), "rules[0].preflight[3].port")
+	assert.Contains(t, err.Error(), "agents.claude.preflight[0]")
+
+	valid := DefaultConfig()
+	assert.NoError(t, valid.validatePreflight())
+}
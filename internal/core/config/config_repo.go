@@ -0,0 +1,164 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hay-kot/criterio"
+	"gopkg.in/yaml.v3"
+)
+
+// RepoConfigFile is the repository config read from the root of a session's
+// clone.
+const RepoConfigFile = ".hive.yaml"
+
+// RepoConfig is the config a repository ships in RepoConfigFile. It adds
+// rules, user commands and windows for that repository's sessions only, on
+// top of the global config. It is only read for repositories a global rule
+// trusts with repo_config, since its commands run on the user's machine.
+//
+//	windows:
+//	  - name: "{{ agentWindow }}"
+//	    command: "{{ agentCommand }} {{ agentFlags }}"
+//	    focus: true
+//	  - name: server
+//	    command: make dev
+//	rules:
+//	  - commands: [make setup]
+//	usercommands:
+//	  test:
+//	    sh: "cd {{ .Path }} && make test"
+type RepoConfig struct {
+	Rules        []Rule                 `json:"rules"        yaml:"rules"`
+	UserCommands map[string]UserCommand `json:"usercommands" yaml:"usercommands"`
+	// Windows is shorthand for a rule with only windows, applied before
+	// Rules.
+	Windows []WindowConfig `json:"windows" yaml:"windows"`
+}
+
+// LoadRepoConfig reads RepoConfigFile from dir. It returns nil when dir has
+// none.
+func LoadRepoConfig(dir string) (*RepoConfig, error) {
+	data, err := os.ReadFile(filepath.Join(dir, RepoConfigFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rc RepoConfig
+	if err := yaml.Unmarshal(data, &rc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", RepoConfigFile, err)
+	}
+	return &rc, nil
+}
+
+// rules returns the rules rc adds: its windows, then its rules.
+func (rc *RepoConfig) rules() []Rule {
+	if len(rc.Windows) == 0 {
+		return rc.Rules
+	}
+	return slices.Concat([]Rule{{Windows: rc.Windows}}, rc.Rules)
+}
+
+// GetRepoConfig reports whether sessions of remote apply the repository's
+// RepoConfigFile. The last matching rule with repo_config set wins; defaults
+// to false.
+func (c *Config) GetRepoConfig(remote string) bool {
+	enabled := false
+	for _, rule := range c.Rules {
+		if rule.Matches(remote) && rule.RepoConfig != nil {
+			enabled = *rule.RepoConfig
+		}
+	}
+	return enabled
+}
+
+// WithRepoConfig returns a copy of c with rc applied: its rules follow the
+// global rules, so they take precedence, and its user commands replace global
+// commands of the same name. rc must have been validated with
+// ValidateRepoConfig.
+func (c *Config) WithRepoConfig(rc *RepoConfig) *Config {
+	out := *c
+	out.Rules = slices.Concat(c.Rules, rc.rules())
+	out.UserCommands = mergeUserCommands(c.UserCommands, rc.UserCommands)
+	return &out
+}
+
+// globalOnlyRuleFields reports, by YAML name, the fields set on rule that take
+// effect before a session's clone exists or outside its sessions, and so
+// cannot be set by a repository config.
+func globalOnlyRuleFields(rule Rule) []string {
+	set := map[string]bool{
+		"clone_strategy":      rule.CloneStrategy != "",
+		"vcs":                 rule.VCS != "",
+		"branch_template":     rule.BranchTemplate != "",
+		"base_branch":         rule.BaseBranch != "",
+		"max_recycled":        rule.MaxRecycled != nil,
+		"feedback_template":   rule.FeedbackTemplate != "",
+		"notify":              rule.Notify != nil,
+		"snapshot_on_recycle": rule.SnapshotOnRecycle != nil,
+		"plan_document":       rule.PlanDocument != "",
+		"preflight":           len(rule.Preflight) > 0,
+		"repo_config":         rule.RepoConfig != nil,
+	}
+	var fields []string
+	for field, ok := range set {
+		if ok {
+			fields = append(fields, field)
+		}
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+// ValidateRepoConfig checks rc against c, whose agent profiles its rules may
+// select. Errors name fields as they appear in RepoConfigFile.
+func (c *Config) ValidateRepoConfig(rc *RepoConfig) error {
+	probe := *c
+	probe.origins = nil
+	probe.Rules = rc.Rules
+	probe.UserCommands = rc.UserCommands
+
+	var errs criterio.FieldErrorsBuilder
+	for i, rule := range rc.Rules {
+		for _, field := range globalOnlyRuleFields(rule) {
+			errs = errs.Append(fmt.Sprintf("rules[%d].%s", i, field), fmt.Errorf("can only be set in the global config"))
+		}
+	}
+
+	windows := *c
+	windows.Rules = []Rule{{Windows: rc.Windows}}
+
+	return criterio.ValidateStruct(
+		errs.ToError(),
+		probe.validateRules(),
+		probe.validateWindowsBasic(),
+		probe.validateRuleAgents(),
+		probe.validateUserCommandsBasic(),
+		probe.validateUserCommandTemplates(),
+		renameRuleFields(windows.validateRules(), "rules[0]."),
+		renameRuleFields(windows.validateWindowsBasic(), "rules[0]."),
+	)
+}
+
+// renameRuleFields strips prefix from the fields of validation errors, so
+// errors about the top-level windows of a repository config name them as
+// written.
+func renameRuleFields(err error, prefix string) error {
+	var fieldErrs criterio.FieldErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+	renamed := make(criterio.FieldErrors, len(fieldErrs))
+	for i, fe := range fieldErrs {
+		fe.Field = strings.TrimPrefix(fe.Field, prefix)
+		renamed[i] = fe
+	}
+	return renamed
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRepoConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	rc, err := LoadRepoConfig(dir)
+	require.NoError(t, err)
+	assert.Nil(t, rc, "a repository without a config has none")
+
+	content := `
+windows:
+  - name: server
+    command: make dev
+rules:
+  - commands: [make setup]
+usercommands:
+  test:
+    sh: make test
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, RepoConfigFile), []byte(content), 0o644))
+	rc, err = LoadRepoConfig(dir)
+	require.NoError(t, err)
+	require.NotNil(t, rc)
+	assert.Equal(t, []WindowConfig{{Name: "server", Command: "make dev"}}, rc.Windows)
+	assert.Equal(t, []string{"make setup"}, rc.Rules[0].Commands)
+	assert.Equal(t, "make test", rc.UserCommands["test"].Sh)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, RepoConfigFile), []byte("rules: {"), 0o644))
+	_, err = LoadRepoConfig(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), RepoConfigFile)
+}
+
+func TestWithRepoConfig(t *testing.T) {
+	const remote = "https://github.com/my-org/api"
+	cfg := &Config{
+		Rules: []Rule{
+			{Pattern: "", Commands: []string{"hive ctx init"}, Spawn: []string{"echo global"}},
+			{Pattern: ".*/my-org/.*", RepoConfig: new(true)},
+		},
+		UserCommands: map[string]UserCommand{
+			"test": {Sh: "go test ./..."},
+			"lint": {Sh: "golangci-lint run"},
+		},
+	}
+	rc := &RepoConfig{
+		Windows:      []WindowConfig{{Name: "server", Command: "make dev"}},
+		Rules:        []Rule{{Commands: []string{"make setup"}}},
+		UserCommands: map[string]UserCommand{"test": {Sh: "make test"}},
+	}
+
+	assert.True(t, cfg.GetRepoConfig(remote))
+	assert.False(t, cfg.GetRepoConfig("https://github.com/other/api"), "untrusted by default")
+
+	merged := cfg.WithRepoConfig(rc)
+	r := merged.ResolveRules(remote)
+	assert.Equal(t, []string{"hive ctx init", "make setup"}, r.Commands, "repository rules follow global rules")
+	assert.Equal(t, []WindowConfig{{Name: "server", Command: "make dev"}}, r.Spawn.Windows, "repository windows take precedence")
+	assert.Equal(t, "make test", merged.UserCommands["test"].Sh)
+	assert.Equal(t, "golangci-lint run", merged.UserCommands["lint"].Sh)
+
+	assert.Len(t, cfg.Rules, 2, "the global config is unchanged")
+	assert.Equal(t, "go test ./...", cfg.UserCommands["test"].Sh)
+}
+
+func TestValidateRepoConfig(t *testing.T) {
+	cfg := &Config{Agents: AgentsConfig{Profiles: map[string]AgentProfile{"claude": {}}}}
+
+	require.NoError(t, cfg.ValidateRepoConfig(&RepoConfig{
+		Windows: []WindowConfig{{Name: "server"}},
+		Rules:   []Rule{{Pattern: ".*", Agent: "claude", Commands: []string{"make setup"}}},
+	}))
+
+	err := cfg.ValidateRepoConfig(&RepoConfig{
+		Windows: []WindowConfig{{Command: "make dev"}},
+		Rules: []Rule{
+			{BaseBranch: "develop", RepoConfig: new(true)},
+			{Agent: "aider"},
+		},
+		UserCommands: map[string]UserCommand{"broken": {}},
+	})
+	require.Error(t, err)
+	for _, field := range []string{
+		"windows[0].name",
+		"rules[0].base_branch: can only be set in the global config",
+		"rules[0].repo_config: can only be set in the global config",
+		"rules[1].agent",
+		`usercommands["broken"]`,
+	} {
+		assert.Contains(t, err.Error(), field)
+	}
+}
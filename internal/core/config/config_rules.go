@@ -0,0 +1,208 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ResolvedRuleFields are the last-match-wins fields of a rule, by YAML name,
+// in display order.
+var ResolvedRuleFields = []string{"agent", "spawn", "batch_spawn", "recycle", "sync", "clone_strategy", "vcs", "branch_template", "base_branch", "feedback_template", "notify", "max_recycled", "snapshot_on_recycle", "plan_document", "repo_config"}
+
+// RuleMatch is a rule that matched a remote, with its position in the config.
+type RuleMatch struct {
+	Index int // 0-based position in the rules list
+	Rule  Rule
+}
+
+// ResolvedRules is the effective rule configuration for a remote, as applied
+// when a session is created or recycled.
+type ResolvedRules struct {
+	Remote  string
+	Matches []RuleMatch // matching rules in evaluation order
+
+	// Last-match-wins values. Sources holds the index of the rule that set
+	// each of them, keyed by its YAML name; values taken from defaults have
+	// no entry.
+	Agent          string        // agent profile; falls back to agents.default
+	Spawn          SpawnStrategy // used by hive new
+	BatchSpawn     SpawnStrategy // used by hive batch
+	Recycle        []string
+	Sync           []string
+	MaxRecycled    int // 0 = unlimited
+	CloneStrategy  string
+	VCS            string
+	BranchTemplate string
+	BaseBranch     string // "" for the default branch
+	// FeedbackTemplate falls back to review.feedback_template; empty means
+	// the built-in format.
+	FeedbackTemplate  string
+	Notify            []string // agent statuses that send a desktop notification
+	SnapshotOnRecycle bool
+	PlanDocument      string // plan path template, relative to the context dir
+	RepoConfig        bool   // the repository's .hive.yaml is applied
+	Sources           map[string]int
+
+	// Every matching rule contributes, in order.
+	Commands []string
+	Copy     []string
+}
+
+// ResolveRules evaluates c.Rules against remote and returns the matching
+// rules and the values they resolve to.
+func (c *Config) ResolveRules(remote string) ResolvedRules {
+	r := ResolvedRules{
+		Remote:            remote,
+		Spawn:             ResolveSpawn(c.Rules, remote, false),
+		BatchSpawn:        ResolveSpawn(c.Rules, remote, true),
+		Recycle:           c.GetRecycleCommands(remote),
+		Sync:              c.GetSyncCommands(remote),
+		MaxRecycled:       c.GetMaxRecycled(remote),
+		CloneStrategy:     c.GetCloneStrategy(remote),
+		VCS:               c.GetVCS(remote),
+		BranchTemplate:    c.GetBranchTemplate(remote),
+		BaseBranch:        c.GetBaseBranch(remote),
+		FeedbackTemplate:  c.GetFeedbackTemplate(remote),
+		Notify:            c.GetNotify(remote),
+		SnapshotOnRecycle: c.GetSnapshotOnRecycle(remote),
+		PlanDocument:      c.GetPlanDocument(remote),
+		RepoConfig:        c.GetRepoConfig(remote),
+		Sources:           make(map[string]int),
+	}
+
+	for i, rule := range c.Rules {
+		if !rule.Matches(remote) {
+			continue
+		}
+		r.Matches = append(r.Matches, RuleMatch{Index: i, Rule: rule})
+		r.Commands = append(r.Commands, rule.Commands...)
+		r.Copy = append(r.Copy, rule.Copy...)
+
+		set := map[string]bool{
+			"agent":               rule.Agent != "",
+			"spawn":               len(rule.Windows) > 0 || len(rule.Spawn) > 0,
+			"batch_spawn":         len(rule.Windows) > 0 || len(rule.BatchSpawn) > 0,
+			"recycle":             len(rule.Recycle) > 0,
+			"sync":                len(rule.Sync) > 0,
+			"max_recycled":        rule.MaxRecycled != nil,
+			"clone_strategy":      rule.CloneStrategy != "",
+			"vcs":                 rule.VCS != "",
+			"branch_template":     rule.BranchTemplate != "",
+			"base_branch":         rule.BaseBranch != "",
+			"feedback_template":   rule.FeedbackTemplate != "",
+			"notify":              rule.Notify != nil,
+			"snapshot_on_recycle": rule.SnapshotOnRecycle != nil,
+			"plan_document":       rule.PlanDocument != "",
+			"repo_config":         rule.RepoConfig != nil,
+		}
+		for field, ok := range set {
+			if ok {
+				r.Sources[field] = i
+			}
+		}
+	}
+
+	r.Agent = r.Spawn.Agent
+	if r.Agent == "" {
+		r.Agent = c.Agents.Default
+	}
+	return r
+}
+
+// Source describes where a resolved value came from: "rules[i]" for the rule
+// that set it, "review.feedback_template" for the global feedback template,
+// or "default".
+func (r ResolvedRules) Source(field string) string {
+	if i, ok := r.Sources[field]; ok {
+		return fmt.Sprintf("rules[%d]", i)
+	}
+	if field == "feedback_template" && r.FeedbackTemplate != "" {
+		return "review.feedback_template"
+	}
+	return "default"
+}
+
+// Display formats a resolved field for display, one entry per line. Fields
+// that resolve to a single value always yield exactly one entry.
+func (r ResolvedRules) Display(field string) []string {
+	switch field {
+	case "agent":
+		return []string{orNone(r.Agent)}
+	case "spawn":
+		return spawnDisplay(r.Spawn)
+	case "batch_spawn":
+		return spawnDisplay(r.BatchSpawn)
+	case "recycle":
+		return r.Recycle
+	case "sync":
+		return r.Sync
+	case "clone_strategy":
+		return []string{r.CloneStrategy}
+	case "vcs":
+		return []string{r.VCS}
+	case "branch_template":
+		return []string{orNone(r.BranchTemplate)}
+	case "base_branch":
+		if r.BaseBranch == "" {
+			return []string{"(default branch)"}
+		}
+		return []string{r.BaseBranch}
+	case "feedback_template":
+		if r.FeedbackTemplate == "" {
+			return []string{"(built-in)"}
+		}
+		return strings.Split(strings.TrimRight(r.FeedbackTemplate, "\n"), "\n")
+	case "notify":
+		return []string{orNone(strings.Join(r.Notify, ", "))}
+	case "max_recycled":
+		if r.MaxRecycled == 0 {
+			return []string{"unlimited"}
+		}
+		return []string{strconv.Itoa(r.MaxRecycled)}
+	case "snapshot_on_recycle":
+		return []string{strconv.FormatBool(r.SnapshotOnRecycle)}
+	case "plan_document":
+		return []string{r.PlanDocument}
+	case "repo_config":
+		return []string{strconv.FormatBool(r.RepoConfig)}
+	case "commands":
+		return r.Commands
+	case "copy":
+		return r.Copy
+	}
+	return nil
+}
+
+// RulePatternLabel returns a display label for a rule pattern.
+func RulePatternLabel(pattern string) string {
+	if pattern == "" {
+		return "(matches all)"
+	}
+	return pattern
+}
+
+func spawnDisplay(s SpawnStrategy) []string {
+	if !s.IsWindows() {
+		return s.Commands
+	}
+	values := make([]string, 0, len(s.Windows))
+	for _, win := range s.Windows {
+		switch {
+		case len(win.Panes) > 0:
+			values = append(values, fmt.Sprintf("window %s: %d panes", win.Name, len(win.Panes)))
+		case win.Command != "":
+			values = append(values, fmt.Sprintf("window %s: %s", win.Name, win.Command))
+		default:
+			values = append(values, fmt.Sprintf("window %s: shell", win.Name))
+		}
+	}
+	return values
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveRules(t *testing.T) {
+	three := 3
+	cfg := &Config{
+		Agents: AgentsConfig{Default: "claude"},
+		Rules: []Rule{
+			{Pattern: "", Commands: []string{"hive ctx init"}, Copy: []string{".envrc"}},
+			{Pattern: ".*/my-org/.*", Agent: "aider", Spawn: []string{"echo spawn"}, MaxRecycled: &three, Commands: []string{"npm install"}},
+			{Pattern: ".*/other/.*", Agent: "codex"},
+			{Pattern: ".*/my-org/.*", Notify: []string{NotifyApproval, NotifyReady}},
+			{Pattern: ".*/my-org/api", CloneStrategy: CloneStrategyWorktree, Recycle: []string{"git pull"}},
+		},
+	}
+
+	t.Run("last match wins", func(t *testing.T) {
+		r := cfg.ResolveRules("https://github.com/my-org/api")
+
+		require.Len(t, r.Matches, 4)
+		assert.Equal(t, []int{0, 1, 3, 4}, []int{r.Matches[0].Index, r.Matches[1].Index, r.Matches[2].Index, r.Matches[3].Index})
+
+		assert.Equal(t, "aider", r.Agent)
+		assert.Equal(t, "rules[1]", r.Source("agent"))
+		assert.Equal(t, []string{"echo spawn"}, r.Spawn.Commands)
+		assert.Equal(t, "rules[1]", r.Source("spawn"))
+		assert.Equal(t, "default", r.Source("batch_spawn"))
+		assert.True(t, r.BatchSpawn.IsWindows())
+		assert.Equal(t, 3, r.MaxRecycled)
+		assert.Equal(t, CloneStrategyWorktree, r.CloneStrategy)
+		assert.Equal(t, "rules[4]", r.Source("clone_strategy"))
+		assert.Equal(t, []string{"git pull"}, r.Recycle)
+		assert.Equal(t, "rules[4]", r.Source("recycle"))
+		assert.Equal(t, "rules[3]", r.Source("notify"))
+		assert.Equal(t, []string{"approval, ready"}, r.Display("notify"))
+		assert.Equal(t, []string{"(none)"}, r.Display("branch_template"))
+		assert.Equal(t, []string{"(default branch)"}, r.Display("base_branch"))
+
+		assert.Equal(t, []string{"hive ctx init", "npm install"}, r.Commands, "commands accumulate")
+		assert.Equal(t, []string{".envrc"}, r.Copy)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		r := (&Config{Agents: AgentsConfig{Default: "claude"}}).ResolveRules("https://github.com/x/y")
+
+		assert.Empty(t, r.Matches)
+		assert.Equal(t, "claude", r.Agent)
+		assert.Equal(t, DefaultMaxRecycled, r.MaxRecycled)
+		for _, f := range ResolvedRuleFields {
+			assert.Equal(t, "default", r.Source(f), f)
+			assert.NotEmpty(t, r.Display(f), f)
+		}
+	})
+}
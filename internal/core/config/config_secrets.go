@@ -0,0 +1,255 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hay-kot/criterio"
+	"gopkg.in/yaml.v3"
+)
+
+// Config values can reference environment variables and secrets instead of
+// holding them, so tokens stay out of config files:
+//
+//	plugins:
+//	  github:
+//	    token: secret://op/Personal/GitHub/token
+//	usercommands:
+//	  deploy:
+//	    sh: "DEPLOY_KEY=${secret://env/DEPLOY_KEY} make deploy ENV=${DEPLOY_ENV}"
+//
+// ${NAME} is replaced by the environment variable NAME anywhere in a value,
+// and $${NAME} by a literal ${NAME}. Other shell forms such as $NAME and
+// ${NAME:-default} are left alone. A value that is a secret reference as a
+// whole, or a ${secret://...} inside a value, is replaced by what the
+// provider's SecretResolver returns. References are resolved when the config
+// is loaded; an unset variable or unresolvable secret is a validation error.
+
+// SecretScheme prefixes secret references: secret://<provider>/<path>.
+const SecretScheme = "secret://"
+
+// SecretResolver returns the secret at path for a provider, e.g.
+// "vault/item/field" for secret://op/vault/item/field.
+type SecretResolver func(ctx context.Context, path string) (string, error)
+
+// secretLookupTimeout bounds a single secret lookup, which may wait for the
+// user to unlock a password manager.
+const secretLookupTimeout = time.Minute
+
+var (
+	secretResolversMu sync.RWMutex
+	secretResolvers   = map[string]SecretResolver{
+		"env": resolveEnvSecret,
+		"op":  resolveOnePasswordSecret,
+	}
+
+	// configRefPattern matches $${...} escapes and ${NAME} and
+	// ${secret://...} references.
+	configRefPattern = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*|secret://[^}\s]+)\}`)
+)
+
+// RegisterSecretResolver makes secret://<provider>/... references resolve
+// with fn, replacing any resolver already registered for provider. The env
+// (environment variables) and op (1Password CLI) providers are built in.
+func RegisterSecretResolver(provider string, fn SecretResolver) {
+	secretResolversMu.Lock()
+	defer secretResolversMu.Unlock()
+	secretResolvers[provider] = fn
+}
+
+// resolveEnvSecret reads the secret from the environment variable path.
+func resolveEnvSecret(_ context.Context, path string) (string, error) {
+	value, ok := os.LookupEnv(path)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", path)
+	}
+	return value, nil
+}
+
+// resolveOnePasswordSecret reads the secret with the 1Password CLI, as
+// `op read op://<path>`.
+func resolveOnePasswordSecret(ctx context.Context, path string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "op", "read", "--no-newline", "op://"+path)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("op read: %s", msg)
+		}
+		return "", fmt.Errorf("op read: %w", err)
+	}
+	return stdout.String(), nil
+}
+
+// IsConfigReference reports whether a whole config value is a reference to
+// an environment variable or secret rather than the value itself.
+func IsConfigReference(value string) bool {
+	if strings.HasPrefix(value, SecretScheme) {
+		return true
+	}
+	loc := configRefPattern.FindStringIndex(value)
+	return loc != nil && loc[0] == 0 && loc[1] == len(value) && !strings.HasPrefix(value, "$$")
+}
+
+// refExpander replaces references in config values, resolving each distinct
+// reference once.
+type refExpander struct {
+	ctx      context.Context
+	resolved map[string]string
+	written  map[string]string // expanded value → value as written
+	errs     criterio.FieldErrorsBuilder
+}
+
+// expandConfigReferences replaces the references in every value of the
+// config document root in place, recording each replaced value in written.
+// Errors name the dotted path of the value.
+func expandConfigReferences(root *yaml.Node, written map[string]string) error {
+	e := &refExpander{ctx: context.Background(), resolved: map[string]string{}, written: written}
+	e.walk(root, "")
+	return e.errs.ToError()
+}
+
+func (e *refExpander) walk(n *yaml.Node, path string) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			e.walk(n.Content[i+1], joinBundlePath(path, n.Content[i].Value))
+		}
+	case yaml.SequenceNode:
+		for i, item := range n.Content {
+			e.walk(item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case yaml.ScalarNode:
+		value, err := e.expand(n.Value)
+		if err != nil {
+			e.errs = e.errs.Append(path, err)
+			return
+		}
+		if value != n.Value {
+			if _, ok := e.written[value]; !ok {
+				e.written[value] = n.Value
+			}
+			n.Value = value
+			if n.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+				n.Tag = "" // let the expanded value decode as a number or bool
+			}
+		}
+	}
+}
+
+// expand returns value with its references replaced.
+func (e *refExpander) expand(value string) (string, error) {
+	if strings.HasPrefix(value, SecretScheme) {
+		return e.resolve(strings.TrimSpace(value))
+	}
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+
+	var errs []error
+	out := configRefPattern.ReplaceAllStringFunc(value, func(match string) string {
+		if strings.HasPrefix(match, "$$") {
+			return match[1:]
+		}
+		resolved, err := e.resolve(match[2 : len(match)-1])
+		if err != nil {
+			errs = append(errs, err)
+		}
+		return resolved
+	})
+	return out, errors.Join(errs...)
+}
+
+// resolve returns the value of an environment variable name or a secret
+// reference.
+func (e *refExpander) resolve(ref string) (string, error) {
+	if value, ok := e.resolved[ref]; ok {
+		return value, nil
+	}
+
+	var (
+		value string
+		err   error
+	)
+	if rest, ok := strings.CutPrefix(ref, SecretScheme); ok {
+		value, err = e.resolveSecret(rest)
+	} else if v, ok := os.LookupEnv(ref); ok {
+		value = v
+	} else {
+		err = fmt.Errorf("environment variable %s is not set (write $${%s} for a literal)", ref, ref)
+	}
+	if err != nil {
+		return "", err
+	}
+	e.resolved[ref] = value
+	return value, nil
+}
+
+// resolveSecret resolves "<provider>/<path>" with the provider's resolver.
+func (e *refExpander) resolveSecret(ref string) (string, error) {
+	provider, path, ok := strings.Cut(ref, "/")
+	if !ok || provider == "" || path == "" {
+		return "", fmt.Errorf("invalid secret reference %q: want %s<provider>/<path>", SecretScheme+ref, SecretScheme)
+	}
+
+	secretResolversMu.RLock()
+	fn, ok := secretResolvers[provider]
+	secretResolversMu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("unknown secret provider %q in %s", provider, SecretScheme+ref)
+	}
+
+	ctx, cancel := context.WithTimeout(e.ctx, secretLookupTimeout)
+	defer cancel()
+	value, err := fn(ctx, path)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", SecretScheme+ref, err)
+	}
+	return value, nil
+}
+
+// Unresolved returns the config for dumping as JSON, with every value that
+// was resolved from a reference shown as written so the dump does not reveal
+// secrets.
+func (c *Config) Unresolved() (any, error) {
+	if len(c.references) == 0 {
+		return c, nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return unresolveValue(v, c.references), nil
+}
+
+// unresolveValue replaces the strings in v that are keys of written.
+func unresolveValue(v any, written map[string]string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			v[k] = unresolveValue(item, written)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = unresolveValue(item, written)
+		}
+	case string:
+		if w, ok := written[v]; ok && v != "" {
+			return w
+		}
+	}
+	return v
+}
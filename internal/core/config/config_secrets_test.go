@@ -0,0 +1,154 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_EnvReferences(t *testing.T) {
+	t.Setenv("HIVE_TEST_ENV", "staging")
+	t.Setenv("HIVE_TEST_WORKERS", "7")
+	t.Setenv("HIVE_TEST_TOKEN", "s3cret")
+
+	configPath := writeConfigFile(t, `
+git:
+  status_workers: ${HIVE_TEST_WORKERS}
+usercommands:
+  deploy:
+    sh: "TOKEN=${secret://env/HIVE_TEST_TOKEN} make deploy ENV=${HIVE_TEST_ENV}"
+  literal:
+    sh: echo $${HOME} $HOME ${HOME:-none}
+  token:
+    sh: secret://env/HIVE_TEST_TOKEN
+`)
+
+	cfg, err := Load(configPath, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, 7, cfg.Git.StatusWorkers, "plain values decode as their expanded type")
+	assert.Equal(t, "TOKEN=s3cret make deploy ENV=staging", cfg.UserCommands["deploy"].Sh)
+	assert.Equal(t, "echo ${HOME} $HOME ${HOME:-none}", cfg.UserCommands["literal"].Sh, "escapes and other shell forms are kept")
+	assert.Equal(t, "s3cret", cfg.UserCommands["token"].Sh)
+}
+
+func TestLoad_ReferenceErrors(t *testing.T) {
+	unsetEnv(t, "HIVE_TEST_UNSET")
+
+	configPath := writeConfigFile(t, `
+usercommands:
+  deploy:
+    sh: make deploy ENV=${HIVE_TEST_UNSET}
+`)
+	_, err := Load(configPath, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "usercommands.deploy.sh")
+	assert.Contains(t, err.Error(), "environment variable HIVE_TEST_UNSET is not set (write $${HIVE_TEST_UNSET} for a literal)")
+
+	configPath = writeConfigFiles(t, map[string]string{
+		"config.yaml": "include: [team.yaml]\n",
+		"team.yaml": `
+usercommands:
+  lint:
+    sh: secret://vault/lint/token
+`,
+	})
+	_, err = Load(configPath, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "team.yaml")
+	assert.Contains(t, err.Error(), "usercommands.lint.sh")
+	assert.Contains(t, err.Error(), `unknown secret provider "vault"`)
+}
+
+func TestRegisterSecretResolver(t *testing.T) {
+	RegisterSecretResolver("hivetest", func(_ context.Context, path string) (string, error) {
+		if path == "missing" {
+			return "", errors.New("no such secret")
+		}
+		return "value-of-" + path, nil
+	})
+
+	configPath := writeConfigFile(t, `
+usercommands:
+  deploy:
+    sh: secret://hivetest/deploy/key
+`)
+	cfg, err := Load(configPath, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, "value-of-deploy/key", cfg.UserCommands["deploy"].Sh)
+
+	configPath = writeConfigFile(t, `
+usercommands:
+  deploy:
+    sh: secret://hivetest/missing
+`)
+	_, err = Load(configPath, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "resolve secret://hivetest/missing: no such secret")
+}
+
+func TestIsConfigReference(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"${GITHUB_TOKEN}", true},
+		{"secret://op/Personal/GitHub/token", true},
+		{"${secret://env/TOKEN}", true},
+		{"$${GITHUB_TOKEN}", false},
+		{"Bearer ${GITHUB_TOKEN}", false},
+		{"$GITHUB_TOKEN", false},
+		{"ghp_abc", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, IsConfigReference(tt.value), tt.value)
+	}
+}
+
+func TestReferencesKeptUnresolved(t *testing.T) {
+	unsetEnv(t, "HIVE_TEST_UNSET")
+
+	configPath := writeConfigFile(t, `
+agents:
+  default: claude
+usercommands:
+  deploy:
+    sh: "GITHUB_TOKEN=${HIVE_TEST_UNSET} ./deploy.sh"
+    api_key: secret://op/Team/deploy/key
+`)
+
+	data, err := ReadWithIncludes(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "${HIVE_TEST_UNSET}")
+
+	bundle, redacted, err := ExportBundle(data, "")
+	require.NoError(t, err)
+	assert.Contains(t, string(bundle), "GITHUB_TOKEN=${HIVE_TEST_UNSET} ./deploy.sh")
+	assert.Contains(t, string(bundle), "secret://op/Team/deploy/key")
+	assert.Empty(t, redacted, "references are not secrets")
+}
+
+func TestConfigUnresolved(t *testing.T) {
+	t.Setenv("HIVE_TEST_TOKEN", "s3cret")
+
+	configPath := writeConfigFile(t, `
+usercommands:
+  deploy:
+    sh: TOKEN=${HIVE_TEST_TOKEN} make deploy
+  token:
+    sh: secret://env/HIVE_TEST_TOKEN
+`)
+	cfg, err := Load(configPath, t.TempDir())
+	require.NoError(t, err)
+
+	v, err := cfg.Unresolved()
+	require.NoError(t, err)
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "s3cret")
+	assert.Contains(t, string(data), `"sh":"TOKEN=${HIVE_TEST_TOKEN} make deploy"`)
+	assert.Contains(t, string(data), `"sh":"secret://env/HIVE_TEST_TOKEN"`)
+}
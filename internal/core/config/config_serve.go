@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+
+	"github.com/hay-kot/criterio"
+)
+
+// ServeConfig configures the listeners started by hive serve.
+type ServeConfig struct {
+	Metrics string `json:"metrics" yaml:"metrics"` // address for the Prometheus /metrics endpoint, e.g. ":9100" (empty disables)
+	Control bool   `json:"control" yaml:"control"` // serve the JSON-RPC control API on ControlSocket()
+	HTTP    string `json:"http"    yaml:"http"`    // address for the read-only HTML dashboard and JSON API, e.g. ":8080" (empty disables)
+}
+
+// ControlSocket returns the path of the Unix socket hive serve exposes its
+// control API on.
+func (c *Config) ControlSocket() string {
+	return filepath.Join(c.DataDir, "hive.sock")
+}
+
+// validateServe checks that listen addresses are host:port pairs.
+func (c *Config) validateServe() error {
+	var errs criterio.FieldErrorsBuilder
+
+	if c.Serve.Metrics != "" {
+		if _, _, err := net.SplitHostPort(c.Serve.Metrics); err != nil {
+			errs = errs.Append("serve.metrics", fmt.Errorf("invalid address %q: %w", c.Serve.Metrics, err))
+		}
+	}
+	if c.Serve.HTTP != "" {
+		if _, _, err := net.SplitHostPort(c.Serve.HTTP); err != nil {
+			errs = errs.Append("serve.http", fmt.Errorf("invalid address %q: %w", c.Serve.HTTP, err))
+		}
+	}
+
+	return errs.ToError()
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateServe(t *testing.T) {
+	for _, addr := range []string{"", ":9100", "127.0.0.1:9100", "[::1]:9100"} {
+		cfg := DefaultConfig()
+		cfg.Serve.Metrics = addr
+		assert.NoError(t, cfg.validateServe(), addr)
+	}
+
+	cfg := DefaultConfig()
+	cfg.Serve.Metrics = "9100"
+	err := cfg.validateServe()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "serve.metrics")
+
+	cfg = DefaultConfig()
+	cfg.Serve.HTTP = "localhost"
+	err = cfg.validateServe()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "serve.http")
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"path/filepath"
+)
+
+// DefaultSnapshotsPerSession is the number of snapshots kept per session
+// when snapshots.max_per_session is not set.
+const DefaultSnapshotsPerSession = 3
+
+// SnapshotsConfig controls session snapshots (hive session snapshot).
+type SnapshotsConfig struct {
+	// MaxPerSession is how many snapshots are kept for each session; older
+	// ones are deleted when a new one is taken. 0 keeps every snapshot.
+	MaxPerSession int `json:"max_per_session" yaml:"max_per_session"`
+}
+
+// SnapshotsDir returns the path where session snapshots are stored.
+func (c *Config) SnapshotsDir() string {
+	return filepath.Join(c.DataDir, "snapshots")
+}
+
+// GetSnapshotOnRecycle reports whether sessions of remote are snapshotted
+// before they are recycled. The last matching rule with snapshot_on_recycle
+// set wins; defaults to false.
+func (c *Config) GetSnapshotOnRecycle(remote string) bool {
+	enabled := false
+	for _, rule := range c.Rules {
+		if rule.Matches(remote) && rule.SnapshotOnRecycle != nil {
+			enabled = *rule.SnapshotOnRecycle
+		}
+	}
+	return enabled
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/colonyops/hive/pkg/pathutil"
+	"github.com/hay-kot/criterio"
+)
+
+// Vault access modes.
+const (
+	VaultModeReadOnly  = "read-only"  // hive never modifies vault documents (default)
+	VaultModeReadWrite = "read-write" // vault documents may be opened in $EDITOR from the review view
+)
+
+// ValidVaultModes lists all valid vault mode values.
+var ValidVaultModes = []string{VaultModeReadOnly, VaultModeReadWrite}
+
+// VaultConfig maps folders from an external notes vault (e.g. Obsidian) into
+// review document discovery, so planning docs can live where humans already
+// write them.
+type VaultConfig struct {
+	Name    string   `json:"name"    yaml:"name"`    // label shown in the review tree (default: vault directory name)
+	Path    string   `json:"path"    yaml:"path"`    // vault root directory (absolute or ~/)
+	Folders []string `json:"folders" yaml:"folders"` // folders within the vault to include (default: entire vault)
+	Mode    string   `json:"mode"    yaml:"mode"`    // "read-only" (default) or "read-write"
+}
+
+// Root returns the vault directory with ~ expanded.
+func (v VaultConfig) Root() string {
+	return pathutil.ExpandHome(v.Path)
+}
+
+// DisplayName returns the configured name, falling back to the vault directory name.
+func (v VaultConfig) DisplayName() string {
+	if v.Name != "" {
+		return v.Name
+	}
+	return filepath.Base(v.Root())
+}
+
+// ReadOnly reports whether hive must treat vault documents as read-only.
+func (v VaultConfig) ReadOnly() bool {
+	return v.Mode != VaultModeReadWrite
+}
+
+// validateVaults checks that each vault has a path, a valid mode, folders that
+// stay inside the vault, and a unique display name.
+func (c *Config) validateVaults() error {
+	var errs criterio.FieldErrorsBuilder
+	seen := make(map[string]bool, len(c.Context.Vaults))
+	for i, vault := range c.Context.Vaults {
+		field := fmt.Sprintf("context.vaults[%d]", i)
+
+		if vault.Path == "" {
+			errs = errs.Append(field+".path", fmt.Errorf("is required"))
+		} else if !filepath.IsAbs(vault.Root()) {
+			errs = errs.Append(field+".path", fmt.Errorf("must be an absolute path or start with ~/, got %q", vault.Path))
+		}
+
+		if vault.Mode != "" && !slices.Contains(ValidVaultModes, vault.Mode) {
+			errs = errs.Append(field+".mode", fmt.Errorf("invalid mode %q, must be one of: %s", vault.Mode, strings.Join(ValidVaultModes, ", ")))
+		}
+
+		for j, folder := range vault.Folders {
+			clean := filepath.Clean(folder)
+			if filepath.IsAbs(folder) || clean == ".." || strings.HasPrefix(clean, "../") {
+				errs = errs.Append(fmt.Sprintf("%s.folders[%d]", field, j), fmt.Errorf("must be a path inside the vault, got %q", folder))
+			}
+		}
+
+		if vault.Path != "" {
+			name := vault.DisplayName()
+			if seen[name] {
+				errs = errs.Append(field+".name", fmt.Errorf("duplicate vault name %q", name))
+			}
+			seen[name] = true
+		}
+	}
+	return errs.ToError()
+}
+
+// validateVaultPaths checks that each configured vault root is an existing directory.
+func (c *Config) validateVaultPaths() error {
+	var errs criterio.FieldErrorsBuilder
+	for i, vault := range c.Context.Vaults {
+		if vault.Path == "" {
+			continue
+		}
+		info, err := os.Stat(vault.Root())
+		switch {
+		case err != nil:
+			errs = errs.Append(fmt.Sprintf("context.vaults[%d].path", i), fmt.Errorf("cannot access: %w", err))
+		case !info.IsDir():
+			errs = errs.Append(fmt.Sprintf("context.vaults[%d].path", i), fmt.Errorf("exists but is not a directory"))
+		}
+	}
+	return errs.ToError()
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultConfig_Defaults(t *testing.T) {
+	v := VaultConfig{Path: "/home/me/Notes"}
+	assert.Equal(t, "Notes", v.DisplayName())
+	assert.True(t, v.ReadOnly(), "vaults are read-only unless configured otherwise")
+
+	v.Name = "brain"
+	v.Mode = VaultModeReadWrite
+	assert.Equal(t, "brain", v.DisplayName())
+	assert.False(t, v.ReadOnly())
+}
+
+func TestValidateVaults(t *testing.T) {
+	t.Run("valid vaults pass", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Context.Vaults = []VaultConfig{
+			{Path: "~/Notes", Folders: []string{"Projects/hive"}},
+			{Name: "work", Path: "/srv/vault", Mode: VaultModeReadWrite},
+		}
+		assert.NoError(t, cfg.validateVaults())
+	})
+
+	tests := []struct {
+		name    string
+		vaults  []VaultConfig
+		wantErr string
+	}{
+		{name: "missing path", vaults: []VaultConfig{{Name: "notes"}}, wantErr: "is required"},
+		{name: "relative path", vaults: []VaultConfig{{Path: "notes"}}, wantErr: "must be an absolute path"},
+		{name: "invalid mode", vaults: []VaultConfig{{Path: "/notes", Mode: "rw"}}, wantErr: "invalid mode"},
+		{name: "folder escapes vault", vaults: []VaultConfig{{Path: "/notes", Folders: []string{"../other"}}}, wantErr: "must be a path inside the vault"},
+		{name: "duplicate names", vaults: []VaultConfig{{Path: "/a/notes"}, {Path: "/b/notes"}}, wantErr: "duplicate vault name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Context.Vaults = tt.vaults
+			err := cfg.validateVaults()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateVaultPaths(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.md")
+	require.NoError(t, os.WriteFile(file, []byte("x"), 0o644))
+
+	cfg := DefaultConfig()
+	cfg.Context.Vaults = []VaultConfig{{Path: dir}}
+	require.NoError(t, cfg.validateVaultPaths())
+
+	cfg.Context.Vaults = []VaultConfig{{Path: filepath.Join(dir, "missing")}}
+	require.ErrorContains(t, cfg.validateVaultPaths(), "cannot access")
+
+	cfg.Context.Vaults = []VaultConfig{{Path: file}}
+	require.ErrorContains(t, cfg.validateVaultPaths(), "not a directory")
+}
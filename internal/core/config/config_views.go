@@ -94,6 +94,7 @@ var defaultViewsConfig = ViewsConfig{
 		Keybindings: map[string]Keybinding{
 			"r":      {Cmd: "Recycle"},
 			"d":      {Cmd: "Delete"},
+			"a":      {Cmd: "Archive"},
 			"n":      {Cmd: "NewSession"},
 			"g":      {Cmd: "SessionsRefreshGitStatuses"},
 			"v":      {Cmd: "SessionsTogglePreview"},
@@ -101,7 +102,13 @@ var defaultViewsConfig = ViewsConfig{
 			"k":      {Cmd: "SessionsNavigateUp"},
 			"down":   {Cmd: "SessionsNavigateDown"},
 			"j":      {Cmd: "SessionsNavigateDown"},
+			"left":   {Cmd: "BoardColumnPrev"},
+			"h":      {Cmd: "BoardColumnPrev"},
+			"right":  {Cmd: "BoardColumnNext"},
+			"l":      {Cmd: "BoardColumnNext"},
 			"/":      {Cmd: "SessionsFilterStart"},
+			"#":      {Cmd: "FilterTag"},
+			"!":      {Cmd: "FilterAttention"},
 			":":      {Cmd: "SessionsCommandPaletteOpen"},
 			"enter":  {Cmd: "TmuxOpen"},
 			"ctrl+d": {Cmd: "TmuxKill"},
@@ -114,7 +121,12 @@ var defaultViewsConfig = ViewsConfig{
 			"K":      {Cmd: "PrevActive"},
 			"t":      {Cmd: "TodoPanel"},
 			"T":      {Cmd: "ViewTasks"},
+			"P":      {Cmd: "OpenPlan"},
 			"i":      {Cmd: "SourceIssues"},
+			"H":      {Cmd: "ArchivedToggle"},
+			"c":      {Cmd: "Compare"},
+			"N":      {Cmd: "EditNotes"},
+			"s":      {Cmd: "SortCycle"},
 		},
 	},
 	Tasks: TasksViewConfig{
@@ -142,6 +154,14 @@ var defaultViewsConfig = ViewsConfig{
 			"o": {Cmd: "DocsOpen"},
 			"v": {Cmd: "DocsTogglePreview"},
 			"r": {Cmd: "DocsSelectRepo"},
+			"a": {Cmd: "DocsAddToReview"},
+			"I": {Cmd: "DocsToggleInstant"},
+			"C": {Cmd: "DocsToggleComments"},
+			"H": {Cmd: "DocsToggleAnnotations"},
+			"P": {Cmd: "DocsOpenRevision"},
+			"R": {Cmd: "DocsCommentEdits"},
+			"]": {Cmd: "DocsNextComment"},
+			"[": {Cmd: "DocsPrevComment"},
 			"g": {Cmd: "GoToTop"},
 			"G": {Cmd: "GoToBottom"},
 		},
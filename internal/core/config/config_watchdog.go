@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hay-kot/criterio"
+)
+
+// WatchdogConfig controls detection of stalled agent sessions.
+type WatchdogConfig struct {
+	Enabled  bool          `json:"enabled"  yaml:"enabled"`  // track statuses and raise alerts (default: false)
+	Approval time.Duration `json:"approval" yaml:"approval"` // alert after waiting for approval this long (default: 5m, 0 disables)
+	Idle     time.Duration `json:"idle"     yaml:"idle"`     // alert after an active agent's pane is unchanged this long (default: 10m, 0 disables)
+	Command  string        `json:"command"  yaml:"command"`  // shell command template run for each alert
+}
+
+// validateWatchdog checks the watchdog thresholds and command template.
+func (c *Config) validateWatchdog() error {
+	var errs criterio.FieldErrorsBuilder
+
+	if c.Watchdog.Approval < 0 {
+		errs = errs.Append("watchdog.approval", fmt.Errorf("must be >= 0"))
+	}
+	if c.Watchdog.Idle < 0 {
+		errs = errs.Append("watchdog.idle", fmt.Errorf("must be >= 0"))
+	}
+	if c.Watchdog.Command != "" {
+		if err := validationRenderer.ValidateSyntax(c.Watchdog.Command); err != nil {
+			errs = errs.Append("watchdog.command", err)
+		}
+	}
+
+	return errs.ToError()
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWatchdog(t *testing.T) {
+	t.Run("defaults pass", func(t *testing.T) {
+		cfg := DefaultConfig()
+		assert.NoError(t, cfg.validateWatchdog())
+		assert.Equal(t, 5*time.Minute, cfg.Watchdog.Approval)
+		assert.Equal(t, 10*time.Minute, cfg.Watchdog.Idle)
+	})
+
+	t.Run("negative thresholds rejected", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Watchdog.Approval = -time.Minute
+		cfg.Watchdog.Idle = -time.Minute
+		err := cfg.validateWatchdog()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "watchdog.approval")
+		assert.Contains(t, err.Error(), "watchdog.idle")
+	})
+
+	t.Run("malformed command rejected", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Watchdog.Command = "notify-send {{ .Name"
+		err := cfg.validateWatchdog()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "watchdog.command")
+	})
+
+	t.Run("load keeps unset thresholds", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		content := "watchdog:\n  enabled: true\n  idle: 0s\n  command: notify-send \"hive\" {{ shq (printf \"%s is %s for %s\" .Name .Reason .Duration) }}\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+		cfg, err := Load(path, t.TempDir())
+		require.NoError(t, err)
+		assert.True(t, cfg.Watchdog.Enabled)
+		assert.Equal(t, 5*time.Minute, cfg.Watchdog.Approval)
+		assert.Zero(t, cfg.Watchdog.Idle, "an explicit 0 disables the idle check")
+	})
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteFile replaces the config file at path with data. The data is written
+// to a temporary file next to it and renamed into place, so a failed write
+// never leaves a partial config. A symlinked path is followed and the file it
+// points to replaced, keeping the link. The file keeps its mode; new files
+// are created with 0o644 along with their directory.
+//
+// When check is non-nil it is called with the temporary file before the
+// rename; an error from it leaves path untouched and is returned as is.
+func WriteFile(path string, data []byte, check func(tmp string) error) error {
+	target, mode, err := resolveWritePath(path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+
+	// Keep the extension so check can load the file as config
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".hive-write-*"+filepath.Ext(target))
+	if err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), mode)
+	}
+	if err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+
+	if check != nil {
+		if err := check(tmp.Name()); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	return nil
+}
+
+// resolveWritePath returns the file a write to path replaces, following
+// symlinks, and the mode to write it with.
+func resolveWritePath(path string) (string, os.FileMode, error) {
+	target, err := filepath.EvalSymlinks(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return path, 0o644, nil
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("resolve config path: %w", err)
+	}
+	info, err := os.Stat(target)
+	if err != nil {
+		return "", 0, fmt.Errorf("stat config: %w", err)
+	}
+	return target, info.Mode().Perm(), nil
+}
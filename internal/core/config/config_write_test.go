@@ -0,0 +1,68 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFile(t *testing.T) {
+	t.Run("creates the file and its directory", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "hive", "config.yaml")
+		require.NoError(t, WriteFile(path, []byte("a: 1\n"), nil))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "a: 1\n", string(data))
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+	})
+
+	t.Run("keeps the mode and the symlink", func(t *testing.T) {
+		dir := t.TempDir()
+		target := filepath.Join(dir, "dotfiles", "config.yaml")
+		require.NoError(t, os.MkdirAll(filepath.Dir(target), 0o755))
+		require.NoError(t, os.WriteFile(target, []byte("a: 1\n"), 0o600))
+		link := filepath.Join(dir, "config.yaml")
+		require.NoError(t, os.Symlink(target, link))
+
+		require.NoError(t, WriteFile(link, []byte("a: 2\n"), nil))
+
+		fi, err := os.Lstat(link)
+		require.NoError(t, err)
+		assert.NotZero(t, fi.Mode()&os.ModeSymlink, "link is not replaced by a regular file")
+		data, err := os.ReadFile(target)
+		require.NoError(t, err)
+		assert.Equal(t, "a: 2\n", string(data))
+		info, err := os.Stat(target)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("check failure leaves the file untouched", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("a: 1\n"), 0o644))
+
+		errInvalid := errors.New("invalid")
+		err := WriteFile(path, []byte("a: 2\n"), func(tmp string) error {
+			data, err := os.ReadFile(tmp)
+			require.NoError(t, err)
+			assert.Equal(t, "a: 2\n", string(data))
+			return errInvalid
+		})
+		require.ErrorIs(t, err, errInvalid)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "a: 1\n", string(data))
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "temporary file is removed")
+	})
+}
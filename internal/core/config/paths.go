@@ -3,14 +3,18 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"runtime"
 )
 
 var configNames = []string{"config.yaml", "config.yml", "hive.yaml", "hive.yml"}
 
 // DefaultConfigDir returns $XDG_CONFIG_HOME/hive (falling back to
-// ~/.config/hive).
+// ~/.config/hive, or %APPDATA%\hive on Windows).
 func DefaultConfigDir() string {
 	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" && runtime.GOOS == "windows" {
+		configHome = os.Getenv("APPDATA")
+	}
 	if configHome == "" {
 		home, _ := os.UserHomeDir()
 		configHome = filepath.Join(home, ".config")
@@ -32,9 +36,13 @@ func DefaultConfigPath() string {
 	return ""
 }
 
-// DefaultDataDir returns the default data directory using XDG_DATA_HOME.
+// DefaultDataDir returns the default data directory using XDG_DATA_HOME
+// (falling back to ~/.local/share/hive, or %LOCALAPPDATA%\hive on Windows).
 func DefaultDataDir() string {
 	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" && runtime.GOOS == "windows" {
+		dataHome = os.Getenv("LOCALAPPDATA")
+	}
 	if dataHome == "" {
 		home, _ := os.UserHomeDir()
 		dataHome = filepath.Join(home, ".local", "share")
@@ -6,7 +6,10 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strings"
+	"time"
 
+	"github.com/colonyops/hive/internal/core/git"
 	"github.com/colonyops/hive/internal/sources"
 	"github.com/colonyops/hive/pkg/pathutil"
 	"github.com/colonyops/hive/pkg/tmpl"
@@ -41,6 +44,13 @@ type RecycleTemplateData struct {
 	DefaultBranch string // Default branch name (e.g., "main" or "master")
 }
 
+// SyncTemplateData defines available fields for sync command templates.
+type SyncTemplateData struct {
+	DefaultBranch string // Default branch name (e.g., "main" or "master")
+	BaseBranch    string // base_branch, or the default branch when unset
+	Branch        string // Session branch being synced
+}
+
 // BranchTemplateData defines available fields for branch_template Go templates.
 type BranchTemplateData struct {
 	Name  string // Session name (display name)
@@ -50,6 +60,73 @@ type BranchTemplateData struct {
 	ID    string // Short random ID shared with the session directory
 }
 
+// PlanDocumentTemplateData defines available fields for plan_document Go
+// templates.
+type PlanDocumentTemplateData struct {
+	Name  string // Session name (display name)
+	Slug  string // Session slug (URL-safe version of name)
+	Owner string // Repository owner
+	Repo  string // Repository name
+	ID    string // Short random ID shared with the session directory
+}
+
+// FeedbackTemplateData defines available fields for review feedback templates
+// (review.feedback_template and rules[].feedback_template).
+type FeedbackTemplateData struct {
+	Reviewer   string                 // review.reviewer, or $USER
+	DocPath    string                 // Path of the reviewed document
+	Documents  []FeedbackDocumentData // Commented documents, session document first
+	Comments   []FeedbackCommentData  // All comments, by document then line
+	Counts     FeedbackCounts
+	ReviewTime string // Focused time spent on the review, e.g. "12m30s"
+	Default    string // Feedback in the built-in format
+}
+
+// FinalizeHookTemplateData defines available fields for review.finalize_hooks
+// commands. The finalized feedback is passed on stdin.
+type FinalizeHookTemplateData struct {
+	DocPath    string // Absolute path of the reviewed document
+	DocRel     string // Document path relative to the context directory
+	Verdict    string // "comment", "approve", or "request_changes"
+	ReviewID   string // Review session ID
+	SessionID  string // Hive session the review was opened from ("" if none)
+	Reviewer   string // review.reviewer, or $USER
+	ContextDir string // Context directory of the reviewed document
+	SavedPath  string // Saved feedback file when review.save_feedback is on ("" otherwise)
+}
+
+// FeedbackDocumentData is a commented document in FeedbackTemplateData.
+type FeedbackDocumentData struct {
+	Path     string
+	Comments []FeedbackCommentData
+}
+
+// FeedbackCommentData is a single review comment in FeedbackTemplateData.
+type FeedbackCommentData struct {
+	Document  string // Path of the document the comment is on
+	Anchor    string // e.g. "Line 4", "Lines 4-6", "Line 4 (cols 3-9)"
+	StartLine int
+	EndLine   int
+	StartCol  int // 0 for whole-line comments
+	EndCol    int
+	Context   string    // Quoted document text
+	Text      string    // Reviewer's comment, with document references as relative markdown links
+	Outdated  bool      // Anchored text was removed from the document
+	Severity  string    // "nit", "suggestion", "issue", or "blocker"
+	CreatedAt time.Time // When the comment was made
+}
+
+// FeedbackCounts summarizes the comments in FeedbackTemplateData.
+type FeedbackCounts struct {
+	Comments    int
+	Documents   int
+	Outdated    int
+	Blockers    int
+	Issues      int
+	Suggestions int
+	Nits        int
+}
+
 // SourceTemplateData defines available fields for source session
 // templates (name/prompt/tags). Fields is a map because item field names are
 // dynamic per-source; a missing .Fields.<key> is a render-time error, not
@@ -125,12 +202,14 @@ func (c *Config) ValidateDeep(configPath string) error {
 		return err
 	}
 
-	return criterio.ValidateStruct(
+	return c.attributeErrors(criterio.ValidateStruct(
 		c.validateFileAccess(configPath),
 		c.validateContextBaseDir(),
+		c.validateVaultPaths(),
 		c.validateRules(),
 		c.validateUserCommandTemplates(),
-	)
+		c.validateReviewTemplates(),
+	))
 }
 
 // Warnings returns non-fatal configuration issues.
@@ -157,11 +236,17 @@ func (c *Config) Warnings() []ValidationWarning {
 	return warnings
 }
 
-// validateFileAccess checks config file, data directory, and git executable.
+// validateFileAccess checks config file, data directory, and the git and jj
+// executables. jj is only required when a rule selects it.
 func (c *Config) validateFileAccess(configPath string) error {
+	jjPath := ""
+	if c.UsesJJ() {
+		jjPath = c.JJPath
+	}
 	return criterio.ValidateStruct(
 		validateConfigFile(configPath),
 		criterio.Run("git_path", c.GitPath, gitExecutableExists),
+		criterio.Run("jj_path", jjPath, gitExecutableExists),
 		criterio.Run("data_dir", c.DataDir, isDirectoryOrNotExist),
 	)
 }
@@ -255,6 +340,11 @@ func (c *Config) validateRules() error {
 				errs = errs.Append(fmt.Sprintf("rules[%d].recycle[%d]", i, j), fmt.Errorf("template error: %w", err))
 			}
 		}
+		for j, cmd := range rule.Sync {
+			if err := validateTemplate(cmd, SyncTemplateData{}); err != nil {
+				errs = errs.Append(fmt.Sprintf("rules[%d].sync[%d]", i, j), fmt.Errorf("template error: %w", err))
+			}
+		}
 		// Validate window templates
 		for j, w := range rule.Windows {
 			prefix := fmt.Sprintf("rules[%d].windows[%d]", i, j)
@@ -296,6 +386,50 @@ func (c *Config) validateRules() error {
 				errs = errs.Append(fmt.Sprintf("rules[%d].branch_template", i), fmt.Errorf("template error: %w", err))
 			}
 		}
+		if rule.BaseBranch != "" {
+			if err := git.ValidateBranchName(rule.BaseBranch); err != nil {
+				errs = errs.Append(fmt.Sprintf("rules[%d].base_branch", i), err)
+			}
+		}
+		if rule.FeedbackTemplate != "" {
+			if err := validateTemplate(rule.FeedbackTemplate, feedbackValidationData); err != nil {
+				errs = errs.Append(fmt.Sprintf("rules[%d].feedback_template", i), fmt.Errorf("template error: %w", err))
+			}
+		}
+		if rule.PlanDocument != "" {
+			if err := validateTemplate(rule.PlanDocument, PlanDocumentTemplateData{}); err != nil {
+				errs = errs.Append(fmt.Sprintf("rules[%d].plan_document", i), fmt.Errorf("template error: %w", err))
+			}
+		}
+	}
+	return errs.ToError()
+}
+
+// feedbackValidationData has one comment so templates ranging over comments
+// are executed against every field.
+var feedbackValidationData = FeedbackTemplateData{
+	Documents: []FeedbackDocumentData{{Comments: []FeedbackCommentData{{}}}},
+	Comments:  []FeedbackCommentData{{}},
+}
+
+// validateReviewTemplates checks template syntax for review.feedback_template
+// and review.finalize_hooks.
+func (c *Config) validateReviewTemplates() error {
+	var errs criterio.FieldErrorsBuilder
+	if c.Review.FeedbackTemplate != "" {
+		if err := validateTemplate(c.Review.FeedbackTemplate, feedbackValidationData); err != nil {
+			errs = errs.Append("review.feedback_template", fmt.Errorf("template error: %w", err))
+		}
+	}
+	for i, hook := range c.Review.FinalizeHooks {
+		field := fmt.Sprintf("review.finalize_hooks[%d]", i)
+		if strings.TrimSpace(hook) == "" {
+			errs = errs.Append(field, fmt.Errorf("command cannot be empty"))
+			continue
+		}
+		if err := validateTemplate(hook, FinalizeHookTemplateData{}); err != nil {
+			errs = errs.Append(field, fmt.Errorf("template error: %w", err))
+		}
 	}
 	return errs.ToError()
 }
@@ -319,6 +453,8 @@ func buildValidationData(formFields []FormField) map[string]any {
 		"Remote":     "https://github.com/test/repo",
 		"ID":         "test123",
 		"Name":       "test-session",
+		"Tags":       []string{"backend"},
+		"Notes":      "waiting on CI",
 		"Tool":       "claude",
 		"TmuxWindow": "main",
 		"Args":       []string{"arg1", "arg2"},
@@ -363,3 +499,15 @@ func validateTemplate(tmplStr string, data any) error {
 	_, err := validationRenderer.Render(tmplStr, data)
 	return err
 }
+
+// validateGitHubPR checks the syntax of the pull request templates.
+func (c *Config) validateGitHubPR() error {
+	var errs criterio.FieldErrorsBuilder
+	if err := validationRenderer.ValidateSyntax(c.Plugins.GitHub.PR.Title); err != nil {
+		errs = errs.Append("plugins.github.pr.title", err)
+	}
+	if err := validationRenderer.ValidateSyntax(c.Plugins.GitHub.PR.Body); err != nil {
+		errs = errs.Append("plugins.github.pr.body", err)
+	}
+	return errs.ToError()
+}
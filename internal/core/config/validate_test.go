@@ -498,6 +498,27 @@ func TestGetRecycleCommands(t *testing.T) {
 	}
 }
 
+func TestGetSyncCommands(t *testing.T) {
+	cfg := validConfig(t)
+	assert.Equal(t, DefaultSyncCommands, cfg.GetSyncCommands("https://github.com/foo/bar"))
+
+	cfg.Rules = []Rule{
+		{Pattern: "", Sync: []string{"git pull --rebase"}},
+		{Pattern: "github.com/foo/.*", Sync: []string{"git fetch origin", "git merge origin/{{ .BaseBranch }}"}},
+		{Pattern: "github.com/jj/.*", VCS: VCSJJ},
+	}
+	assert.Equal(t, []string{"git fetch origin", "git merge origin/{{ .BaseBranch }}"}, cfg.GetSyncCommands("https://github.com/foo/bar"))
+	assert.Equal(t, []string{"git pull --rebase"}, cfg.GetSyncCommands("https://github.com/other/bar"))
+
+	cfg.Rules = []Rule{{Pattern: "github.com/jj/.*", VCS: VCSJJ}}
+	assert.Equal(t, DefaultJJSyncCommands, cfg.GetSyncCommands("https://github.com/jj/bar"))
+
+	cfg.Rules = []Rule{{Pattern: "", Sync: []string{"git rebase origin/{{ .Missing }}"}}}
+	err := cfg.ValidateDeep("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rules[0].sync[0]")
+}
+
 func TestValidate_MaxRecycledNegative(t *testing.T) {
 	intPtr := func(n int) *int { return &n }
 
@@ -1657,6 +1678,90 @@ func TestValidate_GroupByValidModes(t *testing.T) {
 	}
 }
 
+func TestValidate_ReviewRenderer(t *testing.T) {
+	for _, name := range append([]string{""}, ValidReviewRenderers...) {
+		t.Run("valid "+name, func(t *testing.T) {
+			cfg := validConfig(t)
+			cfg.Review.Renderer = name
+			assert.NoError(t, cfg.Validate())
+		})
+	}
+
+	cfg := validConfig(t)
+	cfg.Review.Renderer = "fancy"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "review.renderer")
+}
+
+func TestValidate_UnsavedWork(t *testing.T) {
+	for _, mode := range append([]string{""}, ValidUnsavedWorkModes...) {
+		t.Run("valid "+mode, func(t *testing.T) {
+			cfg := validConfig(t)
+			cfg.UnsavedWork = mode
+			assert.NoError(t, cfg.Validate())
+		})
+	}
+
+	cfg := validConfig(t)
+	cfg.UnsavedWork = "ignore"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsaved_work")
+}
+
+func TestValidate_SessionColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []SessionColumn
+		wantErr string
+	}{
+		{name: "built-in and plugin columns", columns: []SessionColumn{{Name: "name"}, {Name: "plugin:github"}, {Name: "cost"}}},
+		{name: "custom column", columns: []SessionColumn{{Name: "group", Template: "{{ .Group }}"}}},
+		{name: "unknown column", columns: []SessionColumn{{Name: "bogus"}}, wantErr: "tui.sessions.columns[0].name"},
+		{name: "plugin without name", columns: []SessionColumn{{Name: "plugin:"}}, wantErr: "tui.sessions.columns[0].name"},
+		{name: "missing name", columns: []SessionColumn{{Template: "x"}}, wantErr: "tui.sessions.columns[0].name"},
+		{name: "bad template", columns: []SessionColumn{{Name: "x", Template: "{{ .Name"}}, wantErr: "tui.sessions.columns[0].template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig(t)
+			cfg.TUI.Sessions.Columns = tt.columns
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSessionColumn_UnmarshalYAML(t *testing.T) {
+	var cfg TUISessionsConfig
+	require.NoError(t, yaml.Unmarshal([]byte(`
+columns:
+  - name
+  - plugin:github
+  - name: group
+    template: "{{ .Group }}"
+`), &cfg))
+
+	assert.Equal(t, []SessionColumn{
+		{Name: "name"},
+		{Name: "plugin:github"},
+		{Name: "group", Template: "{{ .Group }}"},
+	}, cfg.Columns)
+
+	plugin, ok := cfg.Columns[1].Plugin()
+	assert.True(t, ok)
+	assert.Equal(t, "github", plugin)
+	assert.Equal(t, DefaultSessionColumns(), TUISessionsConfig{}.ColumnsOrDefault())
+}
+
 func TestGetCloneStrategy(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1733,6 +1838,38 @@ func TestValidateCloneStrategy(t *testing.T) {
 	})
 }
 
+func TestRuleMatches_HostedForgeTransports(t *testing.T) {
+	azure := Rule{Pattern: `dev\.azure\.com/acme/payments/`}
+	assert.True(t, azure.Matches("https://acme@dev.azure.com/acme/payments/_git/api"))
+	assert.True(t, azure.Matches("git@ssh.dev.azure.com:v3/acme/payments/api"), "SSH remotes match via their HTTPS URL")
+	assert.False(t, azure.Matches("git@ssh.dev.azure.com:v3/acme/billing/api"))
+
+	bitbucket := Rule{Pattern: "bitbucket.org/team/"}
+	assert.True(t, bitbucket.Matches("git@bitbucket.org:team/repo.git"))
+}
+
+func TestGetVCS(t *testing.T) {
+	cfg := validConfig(t)
+	assert.Equal(t, VCSGit, cfg.GetVCS("https://github.com/foo/bar"))
+	assert.Equal(t, DefaultRecycleCommands, cfg.GetRecycleCommands("https://github.com/foo/bar"))
+
+	cfg.Rules = []Rule{
+		{Pattern: "github.com/foo/.*", VCS: VCSJJ},
+		{Pattern: "github.com/foo/legacy", VCS: VCSGit},
+	}
+	assert.Equal(t, VCSJJ, cfg.GetVCS("https://github.com/foo/bar"))
+	assert.Equal(t, VCSGit, cfg.GetVCS("https://github.com/foo/legacy"), "last matching rule wins")
+	assert.Equal(t, VCSGit, cfg.GetVCS("https://github.com/other/bar"))
+	assert.Equal(t, DefaultJJRecycleCommands, cfg.GetRecycleCommands("https://github.com/foo/bar"))
+	assert.Equal(t, DefaultRecycleCommands, cfg.GetRecycleCommandsForVCS("https://github.com/foo/bar", VCSGit), "worktree vcs picks the defaults")
+	assert.True(t, cfg.UsesJJ())
+
+	cfg.Rules = []Rule{{VCS: "hg"}}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rules[0].vcs")
+}
+
 func TestGetBranchTemplate(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1821,3 +1958,103 @@ func TestValidateDeep_BranchTemplate(t *testing.T) {
 		assert.NoError(t, cfg.ValidateDeep(""))
 	})
 }
+
+func TestGetPlanDocument(t *testing.T) {
+	cfg := validConfig(t)
+	assert.Equal(t, DefaultPlanDocument, cfg.GetPlanDocument("https://github.com/foo/bar"))
+
+	cfg.Rules = []Rule{
+		{Pattern: "", PlanDocument: "plans/{{ .ID }}.md"},
+		{Pattern: "github.com/foo/.*", PlanDocument: "docs/{{ .Slug }}/plan.md"},
+	}
+	assert.Equal(t, "docs/{{ .Slug }}/plan.md", cfg.GetPlanDocument("https://github.com/foo/bar"), "last matching rule wins")
+	assert.Equal(t, "plans/{{ .ID }}.md", cfg.GetPlanDocument("https://github.com/other/bar"))
+
+	cfg.Rules = []Rule{{PlanDocument: "plans/{{ .Branch }}.md"}}
+	err := cfg.ValidateDeep("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rules[0].plan_document")
+}
+
+func TestValidateDeep_BaseBranch(t *testing.T) {
+	cfg := validConfig(t)
+	cfg.Rules = []Rule{{Pattern: "", BaseBranch: "release/2.x"}}
+	require.NoError(t, cfg.ValidateDeep(""))
+	assert.Equal(t, "release/2.x", cfg.GetBaseBranch("https://github.com/x/y"))
+
+	cfg.Rules = []Rule{{Pattern: "", BaseBranch: "my base"}}
+	err := cfg.ValidateDeep("")
+	var fieldErrs criterio.FieldErrors
+	require.ErrorAs(t, err, &fieldErrs)
+	assert.Equal(t, "rules[0].base_branch", fieldErrs[0].Field)
+}
+
+func TestValidateDeep_FeedbackTemplate(t *testing.T) {
+	t.Run("valid templates pass", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Review.FeedbackTemplate = "{{ .Reviewer }}: {{ .Counts.Comments }}\n{{ range .Comments }}{{ .Anchor }} {{ .Text }}\n{{ end }}"
+		cfg.Rules = []Rule{
+			{Pattern: "", Commands: []string{"echo"}, FeedbackTemplate: "{{ range .Documents }}{{ .Path }}{{ range .Comments }}{{ .Context }}{{ end }}{{ end }}{{ .Default }}"},
+		}
+		assert.NoError(t, cfg.ValidateDeep(""))
+	})
+
+	t.Run("unknown comment field fails", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Review.FeedbackTemplate = "{{ range .Comments }}{{ .Priority }}{{ end }}"
+		err := cfg.ValidateDeep("")
+		var fieldErrs criterio.FieldErrors
+		require.ErrorAs(t, err, &fieldErrs)
+		assert.Equal(t, "review.feedback_template", fieldErrs[0].Field)
+	})
+
+	t.Run("invalid rule template fails", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Rules = []Rule{
+			{Pattern: "", Commands: []string{"echo"}, FeedbackTemplate: "{{ .DocPath"},
+		}
+		err := cfg.ValidateDeep("")
+		var fieldErrs criterio.FieldErrors
+		require.ErrorAs(t, err, &fieldErrs)
+		assert.Contains(t, fieldErrs[0].Field, "rules[0].feedback_template")
+	})
+}
+
+func TestValidateDeep_FinalizeHooks(t *testing.T) {
+	t.Run("valid hooks pass", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Review.FinalizeHooks = []string{"notify-slack --doc {{ .DocRel }} --verdict {{ .Verdict }} --session {{ .SessionID }}"}
+		assert.NoError(t, cfg.ValidateDeep(""))
+	})
+
+	t.Run("unknown field fails", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Review.FinalizeHooks = []string{"echo ok", "echo {{ .Feedback }}"}
+		err := cfg.ValidateDeep("")
+		var fieldErrs criterio.FieldErrors
+		require.ErrorAs(t, err, &fieldErrs)
+		assert.Equal(t, "review.finalize_hooks[1]", fieldErrs[0].Field)
+	})
+
+	t.Run("empty command fails", func(t *testing.T) {
+		cfg := validConfig(t)
+		cfg.Review.FinalizeHooks = []string{"  "}
+		err := cfg.ValidateDeep("")
+		var fieldErrs criterio.FieldErrors
+		require.ErrorAs(t, err, &fieldErrs)
+		assert.Equal(t, "review.finalize_hooks[0]", fieldErrs[0].Field)
+	})
+}
+
+func TestGetFeedbackTemplate(t *testing.T) {
+	cfg := validConfig(t)
+	cfg.Review.FeedbackTemplate = "global"
+	cfg.Rules = []Rule{
+		{Pattern: ".*/acme/.*", FeedbackTemplate: "acme"},
+		{Pattern: ".*/acme/api", FeedbackTemplate: "api"},
+	}
+
+	assert.Equal(t, "global", cfg.GetFeedbackTemplate("https://github.com/other/repo"))
+	assert.Equal(t, "acme", cfg.GetFeedbackTemplate("https://github.com/acme/web"))
+	assert.Equal(t, "api", cfg.GetFeedbackTemplate("https://github.com/acme/api"), "last match wins")
+}
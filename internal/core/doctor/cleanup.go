@@ -0,0 +1,95 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SessionLeftovers describes the resources a deleted session left behind.
+type SessionLeftovers struct {
+	SessionID string
+	Name      string
+	Resources []string // e.g. "directory /path", "tmux session foo"
+}
+
+// LeftoverCleaner finds and removes what deleted sessions left behind.
+type LeftoverCleaner interface {
+	// Leftovers verifies the recorded deletions again and returns those
+	// that still left resources behind.
+	Leftovers(ctx context.Context) ([]SessionLeftovers, error)
+	// CleanLeftovers removes what a deleted session left behind, returning
+	// an error if anything remains afterwards.
+	CleanLeftovers(ctx context.Context, sessionID string) error
+}
+
+// CleanupCheck reports deleted sessions whose directory, tmux sessions,
+// messages, or stored values were not fully removed.
+type CleanupCheck struct {
+	cleaner LeftoverCleaner
+	fix     bool
+}
+
+// NewCleanupCheck creates a new session cleanup check.
+// If fix is true, leftovers are removed.
+func NewCleanupCheck(cleaner LeftoverCleaner, fix bool) *CleanupCheck {
+	return &CleanupCheck{cleaner: cleaner, fix: fix}
+}
+
+func (c *CleanupCheck) Name() string {
+	return "Session Cleanup"
+}
+
+func (c *CleanupCheck) Run(ctx context.Context) Result {
+	result := Result{Name: c.Name()}
+
+	leftovers, err := c.cleaner.Leftovers(ctx)
+	if err != nil {
+		result.Items = append(result.Items, CheckItem{
+			Label:  "Verify deletions",
+			Status: StatusFail,
+			Detail: err.Error(),
+		})
+		return result
+	}
+
+	if len(leftovers) == 0 {
+		result.Items = append(result.Items, CheckItem{
+			Label:  "No leftovers",
+			Status: StatusPass,
+			Detail: "deleted sessions were fully cleaned up",
+		})
+		return result
+	}
+
+	for _, l := range leftovers {
+		label := fmt.Sprintf("%s (%s)", l.Name, l.SessionID)
+		resources := strings.Join(l.Resources, ", ")
+
+		if !c.fix {
+			result.Items = append(result.Items, CheckItem{
+				Label:   label,
+				Status:  StatusWarn,
+				Detail:  "deleted session left " + resources,
+				Fixable: true,
+			})
+			continue
+		}
+
+		if err := c.cleaner.CleanLeftovers(ctx, l.SessionID); err != nil {
+			result.Items = append(result.Items, CheckItem{
+				Label:  label,
+				Status: StatusFail,
+				Detail: fmt.Sprintf("failed to clean up: %v", err),
+			})
+		} else {
+			result.Items = append(result.Items, CheckItem{
+				Label:  label,
+				Status: StatusPass,
+				Detail: "removed " + resources,
+			})
+		}
+	}
+
+	return result
+}
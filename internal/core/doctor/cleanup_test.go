@@ -0,0 +1,78 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockCleaner struct {
+	leftovers []SessionLeftovers
+	err       error
+	cleanErr  error
+	cleaned   []string
+}
+
+func (m *mockCleaner) Leftovers(context.Context) ([]SessionLeftovers, error) {
+	return m.leftovers, m.err
+}
+
+func (m *mockCleaner) CleanLeftovers(_ context.Context, id string) error {
+	m.cleaned = append(m.cleaned, id)
+	return m.cleanErr
+}
+
+func TestCleanupCheck(t *testing.T) {
+	leftovers := []SessionLeftovers{
+		{SessionID: "abc123", Name: "auth", Resources: []string{"tmux session auth", "3 inbox messages"}},
+	}
+
+	t.Run("reports leftovers", func(t *testing.T) {
+		cleaner := &mockCleaner{leftovers: leftovers}
+		result := NewCleanupCheck(cleaner, false).Run(context.Background())
+
+		assert.Equal(t, "Session Cleanup", result.Name)
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, "auth (abc123)", result.Items[0].Label)
+		assert.Equal(t, StatusWarn, result.Items[0].Status)
+		assert.Equal(t, "deleted session left tmux session auth, 3 inbox messages", result.Items[0].Detail)
+		assert.True(t, result.Items[0].Fixable)
+		assert.Empty(t, cleaner.cleaned)
+	})
+
+	t.Run("fix cleans up leftovers", func(t *testing.T) {
+		cleaner := &mockCleaner{leftovers: leftovers}
+		result := NewCleanupCheck(cleaner, true).Run(context.Background())
+
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, StatusPass, result.Items[0].Status)
+		assert.Equal(t, []string{"abc123"}, cleaner.cleaned)
+	})
+
+	t.Run("fix failure", func(t *testing.T) {
+		cleaner := &mockCleaner{leftovers: leftovers, cleanErr: errors.New("tmux session auth still running")}
+		result := NewCleanupCheck(cleaner, true).Run(context.Background())
+
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, StatusFail, result.Items[0].Status)
+		assert.Contains(t, result.Items[0].Detail, "still running")
+	})
+
+	t.Run("no leftovers", func(t *testing.T) {
+		result := NewCleanupCheck(&mockCleaner{}, false).Run(context.Background())
+
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, StatusPass, result.Items[0].Status)
+		assert.Equal(t, "No leftovers", result.Items[0].Label)
+	})
+
+	t.Run("verification error", func(t *testing.T) {
+		result := NewCleanupCheck(&mockCleaner{err: errors.New("database locked")}, false).Run(context.Background())
+
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, StatusFail, result.Items[0].Status)
+	})
+}
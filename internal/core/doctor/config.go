@@ -12,6 +12,7 @@ import (
 type ConfigCheck struct {
 	config     *config.Config
 	configPath string
+	loadErr    error
 }
 
 // NewConfigCheck creates a new configuration check.
@@ -22,6 +23,13 @@ func NewConfigCheck(cfg *config.Config, configPath string) *ConfigCheck {
 	}
 }
 
+// WithLoadError reports err, from reloading the configuration file, as a
+// failure. The check still validates the configuration last loaded.
+func (c *ConfigCheck) WithLoadError(err error) *ConfigCheck {
+	c.loadErr = err
+	return c
+}
+
 func (c *ConfigCheck) Name() string {
 	return "Configuration"
 }
@@ -29,6 +37,14 @@ func (c *ConfigCheck) Name() string {
 func (c *ConfigCheck) Run(ctx context.Context) Result {
 	result := Result{Name: c.Name()}
 
+	if c.loadErr != nil {
+		result.Items = append(result.Items, CheckItem{
+			Label:  "Config reloaded",
+			Status: StatusFail,
+			Detail: c.loadErr.Error(),
+		})
+	}
+
 	if c.config == nil {
 		result.Items = append(result.Items, CheckItem{
 			Label:  "Config loaded",
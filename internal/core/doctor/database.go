@@ -0,0 +1,69 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+)
+
+// maxIntegrityProblems caps how many quick_check problems are listed.
+const maxIntegrityProblems = 5
+
+// IntegrityChecker runs SQLite's PRAGMA quick_check.
+type IntegrityChecker interface {
+	// QuickCheck returns the problems found, or none when the database is intact.
+	QuickCheck(ctx context.Context) ([]string, error)
+}
+
+// DatabaseCheck verifies the integrity of the hive database.
+type DatabaseCheck struct {
+	db IntegrityChecker
+}
+
+// NewDatabaseCheck creates a new database integrity check.
+func NewDatabaseCheck(db IntegrityChecker) *DatabaseCheck {
+	return &DatabaseCheck{db: db}
+}
+
+func (c *DatabaseCheck) Name() string {
+	return "Database"
+}
+
+func (c *DatabaseCheck) Run(ctx context.Context) Result {
+	result := Result{Name: c.Name()}
+
+	problems, err := c.db.QuickCheck(ctx)
+	if err != nil {
+		result.Items = append(result.Items, CheckItem{
+			Label:  "integrity",
+			Status: StatusFail,
+			Detail: fmt.Sprintf("quick_check failed: %v", err),
+		})
+		return result
+	}
+
+	if len(problems) == 0 {
+		result.Items = append(result.Items, CheckItem{
+			Label:  "integrity",
+			Status: StatusPass,
+			Detail: "quick_check ok",
+		})
+		return result
+	}
+
+	for _, problem := range problems[:min(len(problems), maxIntegrityProblems)] {
+		result.Items = append(result.Items, CheckItem{
+			Label:  "integrity",
+			Status: StatusFail,
+			Detail: problem,
+		})
+	}
+	if extra := len(problems) - maxIntegrityProblems; extra > 0 {
+		result.Items = append(result.Items, CheckItem{
+			Label:  "integrity",
+			Status: StatusFail,
+			Detail: fmt.Sprintf("%d more problem(s)", extra),
+		})
+	}
+
+	return result
+}
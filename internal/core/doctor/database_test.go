@@ -0,0 +1,50 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type integrityFunc func(ctx context.Context) ([]string, error)
+
+func (f integrityFunc) QuickCheck(ctx context.Context) ([]string, error) {
+	return f(ctx)
+}
+
+func TestDatabaseCheck(t *testing.T) {
+	t.Run("intact", func(t *testing.T) {
+		check := NewDatabaseCheck(integrityFunc(func(context.Context) ([]string, error) { return nil, nil }))
+		result := check.Run(context.Background())
+
+		assert.Equal(t, "Database", result.Name)
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, StatusPass, result.Items[0].Status)
+	})
+
+	t.Run("problems are capped", func(t *testing.T) {
+		var problems []string
+		for i := range 7 {
+			problems = append(problems, fmt.Sprintf("row %d missing from index", i))
+		}
+		check := NewDatabaseCheck(integrityFunc(func(context.Context) ([]string, error) { return problems, nil }))
+		result := check.Run(context.Background())
+
+		require.Len(t, result.Items, maxIntegrityProblems+1)
+		assert.Equal(t, StatusFail, result.Status())
+		assert.Equal(t, "2 more problem(s)", result.Items[maxIntegrityProblems].Detail)
+	})
+
+	t.Run("check error", func(t *testing.T) {
+		check := NewDatabaseCheck(integrityFunc(func(context.Context) ([]string, error) { return nil, errors.New("disk I/O error") }))
+		result := check.Run(context.Background())
+
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, StatusFail, result.Items[0].Status)
+		assert.Contains(t, result.Items[0].Detail, "disk I/O error")
+	})
+}
@@ -0,0 +1,73 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// DataDirsCheck verifies that the directories hive writes to exist.
+type DataDirsCheck struct {
+	dirs []string
+	fix  bool
+}
+
+// NewDataDirsCheck creates a new data directories check.
+// If fix is true, missing directories will be created.
+func NewDataDirsCheck(dirs []string, fix bool) *DataDirsCheck {
+	return &DataDirsCheck{dirs: dirs, fix: fix}
+}
+
+func (c *DataDirsCheck) Name() string {
+	return "Data Directories"
+}
+
+func (c *DataDirsCheck) Run(_ context.Context) Result {
+	result := Result{Name: c.Name()}
+
+	for _, dir := range c.dirs {
+		info, err := os.Stat(dir)
+		switch {
+		case os.IsNotExist(err) && c.fix:
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				result.Items = append(result.Items, CheckItem{
+					Label:  dir,
+					Status: StatusFail,
+					Detail: fmt.Sprintf("failed to create: %v", err),
+				})
+			} else {
+				result.Items = append(result.Items, CheckItem{
+					Label:  dir,
+					Status: StatusPass,
+					Detail: "created missing directory",
+				})
+			}
+		case os.IsNotExist(err):
+			result.Items = append(result.Items, CheckItem{
+				Label:   dir,
+				Status:  StatusWarn,
+				Detail:  "directory does not exist",
+				Fixable: true,
+			})
+		case err != nil:
+			result.Items = append(result.Items, CheckItem{
+				Label:  dir,
+				Status: StatusFail,
+				Detail: fmt.Sprintf("inaccessible: %v", err),
+			})
+		case !info.IsDir():
+			result.Items = append(result.Items, CheckItem{
+				Label:  dir,
+				Status: StatusFail,
+				Detail: "path is not a directory",
+			})
+		default:
+			result.Items = append(result.Items, CheckItem{
+				Label:  dir,
+				Status: StatusPass,
+			})
+		}
+	}
+
+	return result
+}
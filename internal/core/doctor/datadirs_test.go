@@ -0,0 +1,42 @@
+package doctor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataDirsCheck(t *testing.T) {
+	tmpDir := t.TempDir()
+	missing := filepath.Join(tmpDir, "repos")
+
+	result := NewDataDirsCheck([]string{tmpDir, missing}, false).Run(context.Background())
+
+	assert.Equal(t, "Data Directories", result.Name)
+	require.Len(t, result.Items, 2)
+	assert.Equal(t, StatusPass, result.Items[0].Status)
+	assert.Equal(t, StatusWarn, result.Items[1].Status)
+	assert.True(t, result.Items[1].Fixable)
+
+	result = NewDataDirsCheck([]string{tmpDir, missing}, true).Run(context.Background())
+
+	require.Len(t, result.Items, 2)
+	assert.Equal(t, StatusPass, result.Items[1].Status)
+	assert.Equal(t, "created missing directory", result.Items[1].Detail)
+	assert.DirExists(t, missing)
+}
+
+func TestDataDirsCheck_NotADirectory(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "data")
+	require.NoError(t, os.WriteFile(file, []byte("x"), 0o644))
+
+	result := NewDataDirsCheck([]string{file}, true).Run(context.Background())
+
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, StatusFail, result.Items[0].Status)
+	assert.Equal(t, "path is not a directory", result.Items[0].Detail)
+}
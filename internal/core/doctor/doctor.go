@@ -41,6 +41,15 @@ type Result struct {
 	Items []CheckItem `json:"items"`
 }
 
+// Status returns the worst status among the result's items.
+func (r Result) Status() Status {
+	status := StatusPass
+	for _, item := range r.Items {
+		status = max(status, item.Status)
+	}
+	return status
+}
+
 // Check defines the interface for a doctor check.
 type Check interface {
 	Name() string
@@ -13,6 +13,7 @@ import (
 
 type mockStore struct {
 	sessions []session.Session
+	deleted  []string
 }
 
 func (m *mockStore) List(_ context.Context) ([]session.Session, error) {
@@ -27,7 +28,8 @@ func (m *mockStore) Save(_ context.Context, _ session.Session) error {
 	return nil
 }
 
-func (m *mockStore) Delete(_ context.Context, _ string) error {
+func (m *mockStore) Delete(_ context.Context, id string) error {
+	m.deleted = append(m.deleted, id)
 	return nil
 }
 
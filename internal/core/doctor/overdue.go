@@ -0,0 +1,78 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/pkg/timeutil"
+)
+
+// OverdueCheck reports active sessions that are past their due date.
+type OverdueCheck struct {
+	sessions     session.Store
+	archiveAfter time.Duration
+}
+
+// NewOverdueCheck creates a new overdue session check. archiveAfter is the
+// configured due.archive_after grace period, 0 if overdue sessions are
+// never archived.
+func NewOverdueCheck(sessions session.Store, archiveAfter time.Duration) *OverdueCheck {
+	return &OverdueCheck{
+		sessions:     sessions,
+		archiveAfter: archiveAfter,
+	}
+}
+
+func (c *OverdueCheck) Name() string {
+	return "Overdue Sessions"
+}
+
+func (c *OverdueCheck) Run(ctx context.Context) Result {
+	result := Result{Name: c.Name()}
+
+	sessions, err := c.sessions.List(ctx)
+	if err != nil {
+		result.Items = append(result.Items, CheckItem{
+			Label:  "List sessions",
+			Status: StatusFail,
+			Detail: err.Error(),
+		})
+		return result
+	}
+
+	now := time.Now()
+	sessions = slices.DeleteFunc(sessions, func(s session.Session) bool {
+		return !s.IsOverdue(now)
+	})
+	if len(sessions) == 0 {
+		result.Items = append(result.Items, CheckItem{
+			Label:  "No overdue sessions",
+			Status: StatusPass,
+		})
+		return result
+	}
+
+	slices.SortFunc(sessions, func(a, b session.Session) int {
+		dueA, _ := a.DueAt()
+		dueB, _ := b.DueAt()
+		return dueA.Compare(dueB)
+	})
+
+	for _, sess := range sessions {
+		due, _ := sess.DueAt()
+		detail := "due " + timeutil.Ago(due)
+		if c.archiveAfter > 0 {
+			detail += fmt.Sprintf(", archived after %s unless it has unsaved work", due.Add(c.archiveAfter).Local().Format("2006-01-02 15:04"))
+		}
+		result.Items = append(result.Items, CheckItem{
+			Label:  fmt.Sprintf("%s (%s)", sess.Name, sess.ID),
+			Status: StatusWarn,
+			Detail: detail,
+		})
+	}
+
+	return result
+}
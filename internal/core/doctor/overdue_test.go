@@ -0,0 +1,47 @@
+package doctor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverdueCheck(t *testing.T) {
+	dueSession := func(id string, state session.State, due time.Duration) session.Session {
+		s := session.Session{ID: id, Name: id, State: state}
+		s.SetDueAt(time.Now().Add(due))
+		return s
+	}
+
+	t.Run("none overdue", func(t *testing.T) {
+		store := &mockStore{sessions: []session.Session{
+			{ID: "nodue", State: session.StateActive},
+			dueSession("later", session.StateActive, time.Hour),
+			dueSession("archived", session.StateArchived, -time.Hour),
+		}}
+
+		result := NewOverdueCheck(store, 0).Run(context.Background())
+		assert.Equal(t, "Overdue Sessions", result.Name)
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, StatusPass, result.Items[0].Status)
+	})
+
+	t.Run("overdue sessions warn, oldest first", func(t *testing.T) {
+		store := &mockStore{sessions: []session.Session{
+			dueSession("recent", session.StateActive, -time.Hour),
+			dueSession("old", session.StateActive, -72*time.Hour),
+		}}
+
+		result := NewOverdueCheck(store, 24*time.Hour).Run(context.Background())
+		require.Len(t, result.Items, 2)
+		assert.Equal(t, "old (old)", result.Items[0].Label)
+		assert.Equal(t, StatusWarn, result.Items[0].Status)
+		assert.Contains(t, result.Items[0].Detail, "due 3d ago")
+		assert.Contains(t, result.Items[0].Detail, "archived after")
+		assert.Equal(t, "recent (recent)", result.Items[1].Label)
+	})
+}
@@ -0,0 +1,90 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// ScriptsCheck verifies that the bundled helper scripts are extracted and
+// executable.
+type ScriptsCheck struct {
+	paths   map[string]string
+	extract func() error
+	fix     bool
+}
+
+// NewScriptsCheck creates a new bundled scripts check for the script name ->
+// path map. If fix is true and a script is missing or not executable, all
+// scripts are re-extracted with extract.
+func NewScriptsCheck(paths map[string]string, extract func() error, fix bool) *ScriptsCheck {
+	return &ScriptsCheck{paths: paths, extract: extract, fix: fix}
+}
+
+func (c *ScriptsCheck) Name() string {
+	return "Bundled Scripts"
+}
+
+func (c *ScriptsCheck) Run(_ context.Context) Result {
+	result := Result{Name: c.Name()}
+
+	names := make([]string, 0, len(c.paths))
+	for name := range c.paths {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	problems := make(map[string]string)
+	for _, name := range names {
+		if problem := scriptProblem(c.paths[name]); problem != "" {
+			problems[name] = problem
+		}
+	}
+
+	if len(problems) > 0 && c.fix {
+		if err := c.extract(); err != nil {
+			result.Items = append(result.Items, CheckItem{
+				Label:  "extract",
+				Status: StatusFail,
+				Detail: fmt.Sprintf("failed to re-extract scripts: %v", err),
+			})
+			return result
+		}
+	}
+
+	for _, name := range names {
+		problem, broken := problems[name]
+		switch {
+		case !broken:
+			result.Items = append(result.Items, CheckItem{Label: name, Status: StatusPass, Detail: c.paths[name]})
+		case c.fix:
+			result.Items = append(result.Items, CheckItem{Label: name, Status: StatusPass, Detail: "re-extracted"})
+		default:
+			result.Items = append(result.Items, CheckItem{
+				Label:   name,
+				Status:  StatusWarn,
+				Detail:  fmt.Sprintf("%s: %s", c.paths[name], problem),
+				Fixable: true,
+			})
+		}
+	}
+
+	return result
+}
+
+// scriptProblem describes what is wrong with the script at path, or returns
+// "" when it is usable.
+func scriptProblem(path string) string {
+	info, err := os.Stat(path)
+	switch {
+	case os.IsNotExist(err):
+		return "missing"
+	case err != nil:
+		return err.Error()
+	case goos != "windows" && info.Mode().Perm()&0o111 == 0:
+		return "not executable"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,60 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptsCheck(t *testing.T) {
+	dir := t.TempDir()
+	paths := map[string]string{
+		"agent-send": filepath.Join(dir, "agent-send"),
+		"hive-tmux":  filepath.Join(dir, "hive-tmux"),
+	}
+	require.NoError(t, os.WriteFile(paths["hive-tmux"], []byte("#!/bin/sh\n"), 0o755))
+
+	extracted := 0
+	extract := func() error {
+		extracted++
+		return os.WriteFile(paths["agent-send"], []byte("#!/bin/sh\n"), 0o755)
+	}
+
+	result := NewScriptsCheck(paths, extract, false).Run(context.Background())
+
+	assert.Equal(t, "Bundled Scripts", result.Name)
+	require.Len(t, result.Items, 2)
+	assert.Equal(t, "agent-send", result.Items[0].Label)
+	assert.Equal(t, StatusWarn, result.Items[0].Status)
+	assert.Contains(t, result.Items[0].Detail, "missing")
+	assert.True(t, result.Items[0].Fixable)
+	assert.Equal(t, StatusPass, result.Items[1].Status)
+	assert.Zero(t, extracted)
+
+	result = NewScriptsCheck(paths, extract, true).Run(context.Background())
+
+	require.Len(t, result.Items, 2)
+	assert.Equal(t, StatusPass, result.Items[0].Status)
+	assert.Equal(t, "re-extracted", result.Items[0].Detail)
+	assert.Equal(t, 1, extracted)
+	assert.FileExists(t, paths["agent-send"])
+
+	// Nothing to repair: extract is not called again
+	NewScriptsCheck(paths, extract, true).Run(context.Background())
+	assert.Equal(t, 1, extracted)
+}
+
+func TestScriptsCheck_ExtractFails(t *testing.T) {
+	paths := map[string]string{"hive-tmux": filepath.Join(t.TempDir(), "hive-tmux")}
+
+	result := NewScriptsCheck(paths, func() error { return errors.New("read-only") }, true).Run(context.Background())
+
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, StatusFail, result.Items[0].Status)
+	assert.Contains(t, result.Items[0].Detail, "read-only")
+}
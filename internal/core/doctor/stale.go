@@ -0,0 +1,92 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/colonyops/hive/internal/core/session"
+)
+
+// StaleSessionCheck detects session records whose directory was deleted
+// outside hive.
+type StaleSessionCheck struct {
+	sessions session.Store
+	fix      bool
+}
+
+// NewStaleSessionCheck creates a new stale session record check.
+// If fix is true, stale records will be deleted.
+func NewStaleSessionCheck(sessions session.Store, fix bool) *StaleSessionCheck {
+	return &StaleSessionCheck{sessions: sessions, fix: fix}
+}
+
+func (c *StaleSessionCheck) Name() string {
+	return "Session Records"
+}
+
+func (c *StaleSessionCheck) Run(ctx context.Context) Result {
+	result := Result{Name: c.Name()}
+
+	sessions, err := c.sessions.List(ctx)
+	if err != nil {
+		result.Items = append(result.Items, CheckItem{
+			Label:  "List sessions",
+			Status: StatusFail,
+			Detail: err.Error(),
+		})
+		return result
+	}
+
+	var stale []session.Session
+	for _, sess := range sessions {
+		// Archived and corrupted sessions are expected to lack a usable directory
+		if sess.State != session.StateActive && sess.State != session.StateRecycled {
+			continue
+		}
+		if sess.Path == "" {
+			continue
+		}
+		if _, err := os.Stat(sess.Path); os.IsNotExist(err) {
+			stale = append(stale, sess)
+		}
+	}
+
+	if len(stale) == 0 {
+		result.Items = append(result.Items, CheckItem{
+			Label:  "No stale records",
+			Status: StatusPass,
+			Detail: "all sessions have a directory",
+		})
+		return result
+	}
+
+	for _, sess := range stale {
+		label := fmt.Sprintf("%s (%s)", sess.Name, sess.ID)
+
+		if c.fix {
+			if err := c.sessions.Delete(ctx, sess.ID); err != nil {
+				result.Items = append(result.Items, CheckItem{
+					Label:  label,
+					Status: StatusFail,
+					Detail: fmt.Sprintf("failed to delete record: %v", err),
+				})
+			} else {
+				result.Items = append(result.Items, CheckItem{
+					Label:  label,
+					Status: StatusPass,
+					Detail: "deleted record of missing directory",
+				})
+			}
+		} else {
+			result.Items = append(result.Items, CheckItem{
+				Label:   label,
+				Status:  StatusWarn,
+				Detail:  fmt.Sprintf("directory %s no longer exists", sess.Path),
+				Fixable: true,
+			})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,56 @@
+package doctor
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleSessionCheck(t *testing.T) {
+	tmpDir := t.TempDir()
+	gone := filepath.Join(tmpDir, "gone")
+
+	newStore := func() *mockStore {
+		return &mockStore{
+			sessions: []session.Session{
+				{ID: "abc123", Name: "present", State: session.StateActive, Path: tmpDir},
+				{ID: "def456", Name: "deleted", State: session.StateActive, Path: gone},
+				{ID: "ghi789", Name: "archived", State: session.StateArchived, Path: gone},
+			},
+		}
+	}
+
+	t.Run("reports records of missing directories", func(t *testing.T) {
+		store := newStore()
+		result := NewStaleSessionCheck(store, false).Run(context.Background())
+
+		assert.Equal(t, "Session Records", result.Name)
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, "deleted (def456)", result.Items[0].Label)
+		assert.Equal(t, StatusWarn, result.Items[0].Status)
+		assert.True(t, result.Items[0].Fixable)
+		assert.Empty(t, store.deleted)
+	})
+
+	t.Run("fix deletes stale records", func(t *testing.T) {
+		store := newStore()
+		result := NewStaleSessionCheck(store, true).Run(context.Background())
+
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, StatusPass, result.Items[0].Status)
+		assert.Equal(t, []string{"def456"}, store.deleted)
+	})
+
+	t.Run("no stale records", func(t *testing.T) {
+		store := &mockStore{sessions: []session.Session{{ID: "abc123", State: session.StateActive, Path: tmpDir}}}
+		result := NewStaleSessionCheck(store, false).Run(context.Background())
+
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, StatusPass, result.Items[0].Status)
+		assert.Equal(t, "No stale records", result.Items[0].Label)
+	})
+}
@@ -0,0 +1,153 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/pkg/executil"
+)
+
+// TmuxSessionsCheck detects tmux sessions running in the repos folder that
+// no active hive session owns, such as those left behind when a session was
+// deleted while tmux was unreachable. A tmux session is owned when its name,
+// its working directory, or the @hive-session tag of one of its panes
+// matches an active session.
+type TmuxSessionsCheck struct {
+	exec     executil.Executor
+	sessions session.Store
+	reposDir string
+	fix      bool
+}
+
+// NewTmuxSessionsCheck creates a new orphaned tmux session check.
+// If fix is true, orphaned tmux sessions will be killed.
+func NewTmuxSessionsCheck(exec executil.Executor, sessions session.Store, reposDir string, fix bool) *TmuxSessionsCheck {
+	return &TmuxSessionsCheck{
+		exec:     exec,
+		sessions: sessions,
+		reposDir: reposDir,
+		fix:      fix,
+	}
+}
+
+func (c *TmuxSessionsCheck) Name() string {
+	return "Tmux Sessions"
+}
+
+func (c *TmuxSessionsCheck) Run(ctx context.Context) Result {
+	result := Result{Name: c.Name()}
+
+	// Fails when tmux is not installed or no server is running; either way
+	// there is nothing to clean up.
+	out, err := c.exec.Run(ctx, "tmux", "list-sessions", "-F", "#{session_name}\t#{session_path}")
+	if err != nil {
+		result.Items = append(result.Items, CheckItem{
+			Label:  "No orphans",
+			Status: StatusPass,
+			Detail: "no tmux server running",
+		})
+		return result
+	}
+
+	sessions, err := c.sessions.List(ctx)
+	if err != nil {
+		result.Items = append(result.Items, CheckItem{
+			Label:  "List sessions",
+			Status: StatusFail,
+			Detail: err.Error(),
+		})
+		return result
+	}
+
+	owned := make(map[string]bool)
+	ownedPaths := make(map[string]bool)
+	for _, sess := range sessions {
+		if sess.State != session.StateActive {
+			continue
+		}
+		owned[sess.Name] = true
+		owned[sess.Slug] = true
+		if name := sess.GetMeta(session.MetaTmuxSession); name != "" {
+			owned[name] = true
+		}
+		if sess.Path != "" {
+			ownedPaths[filepath.Clean(sess.Path)] = true
+		}
+	}
+
+	var orphans []string
+	for line := range strings.Lines(string(out)) {
+		name, path, ok := strings.Cut(strings.TrimRight(line, "\r\n"), "\t")
+		if !ok || owned[name] || ownedPaths[filepath.Clean(path)] || !c.inReposDir(path) {
+			continue
+		}
+		orphans = append(orphans, name)
+	}
+	if len(orphans) > 0 {
+		orphans = c.untagged(ctx, orphans, owned)
+	}
+
+	if len(orphans) == 0 {
+		result.Items = append(result.Items, CheckItem{
+			Label:  "No orphans",
+			Status: StatusPass,
+			Detail: "all tmux sessions in the repos directory belong to active sessions",
+		})
+		return result
+	}
+
+	for _, name := range orphans {
+		if c.fix {
+			if _, err := c.exec.Run(ctx, "tmux", "kill-session", "-t", "="+name); err != nil {
+				result.Items = append(result.Items, CheckItem{
+					Label:  name,
+					Status: StatusFail,
+					Detail: fmt.Sprintf("failed to kill: %v", err),
+				})
+			} else {
+				result.Items = append(result.Items, CheckItem{
+					Label:  name,
+					Status: StatusPass,
+					Detail: "killed orphaned tmux session",
+				})
+			}
+		} else {
+			result.Items = append(result.Items, CheckItem{
+				Label:   name,
+				Status:  StatusWarn,
+				Detail:  "orphaned tmux session (no active hive session)",
+				Fixable: true,
+			})
+		}
+	}
+
+	return result
+}
+
+// untagged drops the tmux sessions with a pane tagged (@hive-session) with
+// an owned name, such as a session renamed outside hive.
+func (c *TmuxSessionsCheck) untagged(ctx context.Context, names []string, owned map[string]bool) []string {
+	out, err := c.exec.Run(ctx, "tmux", "list-panes", "-a", "-F", "#{session_name}\t#{@hive-session}")
+	if err != nil {
+		return names
+	}
+	tagged := make(map[string]bool)
+	for line := range strings.Lines(string(out)) {
+		name, tag, _ := strings.Cut(strings.TrimRight(line, "\r\n"), "\t")
+		if owned[tag] {
+			tagged[name] = true
+		}
+	}
+	return slices.DeleteFunc(names, func(name string) bool { return tagged[name] })
+}
+
+// inReposDir reports whether path is inside the repos directory. tmux
+// sessions elsewhere are the user's own and never considered orphans.
+func (c *TmuxSessionsCheck) inReposDir(path string) bool {
+	rel, err := filepath.Rel(c.reposDir, path)
+	return err == nil && rel != "." && !strings.HasPrefix(rel, "..")
+}
@@ -0,0 +1,59 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/pkg/executil/executiltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTmuxSessionsCheck(t *testing.T) {
+	store := &mockStore{
+		sessions: []session.Session{
+			{ID: "abc123", Name: "auth", Slug: "auth", State: session.StateActive, Path: "/data/repos/auth-abc123"},
+			{ID: "def456", Name: "old", Slug: "old", State: session.StateRecycled, Path: "/data/repos/old-def456"},
+		},
+	}
+	listing := "auth\t/data/repos/auth-abc123\n" +
+		"old\t/data/repos/old-def456\n" +
+		"renamed\t/data/repos/auth-abc123\n" +
+		"tagged\t/data/repos/other\n" +
+		"notes\t/home/me/notes\n"
+	panes := "auth\tauth\nold\told\ntagged\tauth\n"
+
+	t.Run("reports orphans in the repos directory", func(t *testing.T) {
+		exec := &executiltest.Exec{Responses: []executiltest.Response{{Out: []byte(listing)}, {Out: []byte(panes)}}}
+		result := NewTmuxSessionsCheck(exec, store, "/data/repos", false).Run(context.Background())
+
+		assert.Equal(t, "Tmux Sessions", result.Name)
+		require.Len(t, result.Items, 1, "sessions in an active session's directory or tagged with it are owned")
+		assert.Equal(t, "old", result.Items[0].Label)
+		assert.Equal(t, StatusWarn, result.Items[0].Status)
+		assert.True(t, result.Items[0].Fixable)
+		assert.Len(t, exec.Calls(), 2)
+	})
+
+	t.Run("fix kills orphans", func(t *testing.T) {
+		exec := &executiltest.Exec{Responses: []executiltest.Response{{Out: []byte(listing)}, {Out: []byte(panes)}}}
+		result := NewTmuxSessionsCheck(exec, store, "/data/repos", true).Run(context.Background())
+
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, StatusPass, result.Items[0].Status)
+		calls := exec.Calls()
+		require.Len(t, calls, 3)
+		assert.Equal(t, []string{"kill-session", "-t", "=old"}, calls[2].Args)
+	})
+
+	t.Run("no tmux server", func(t *testing.T) {
+		exec := &executiltest.Exec{Responses: []executiltest.Response{{Err: errors.New("no server running")}}}
+		result := NewTmuxSessionsCheck(exec, store, "/data/repos", true).Run(context.Background())
+
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, StatusPass, result.Items[0].Status)
+		assert.Len(t, exec.Calls(), 1)
+	})
+}
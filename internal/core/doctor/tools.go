@@ -2,26 +2,55 @@ package doctor
 
 import (
 	"context"
+	"fmt"
 	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/colonyops/hive/pkg/executil"
 )
 
 // lookPathFunc is the function used to find executables on PATH.
 // Package-level variable to allow test overrides.
 var lookPathFunc = exec.LookPath
 
-// ToolsCheck verifies that required external tools are available on $PATH.
-type ToolsCheck struct{}
+// goos is the platform whose tools are checked, overridable in tests.
+var goos = runtime.GOOS
+
+// Oldest tool versions hive is tested against: git 2.22 added
+// "branch --show-current" and tmux 3.0 added pane options ("set-option -p").
+var (
+	minGitVersion  = toolVersion{2, 22}
+	minTmuxVersion = toolVersion{3, 0}
+)
+
+// ToolsCheck verifies that required external tools are available on $PATH
+// and recent enough.
+type ToolsCheck struct {
+	exec        executil.Executor
+	copyCommand string
+}
+
+// NewToolsCheck creates a new tools check. exec runs the tools' version
+// commands; when nil, versions are not checked.
+func NewToolsCheck(exec executil.Executor) *ToolsCheck {
+	return &ToolsCheck{exec: exec}
+}
 
-// NewToolsCheck creates a new tools check.
-func NewToolsCheck() *ToolsCheck {
-	return &ToolsCheck{}
+// WithClipboard also checks that the program of the configured copy
+// command is available.
+func (c *ToolsCheck) WithClipboard(copyCommand string) *ToolsCheck {
+	c.copyCommand = copyCommand
+	return c
 }
 
 func (c *ToolsCheck) Name() string {
 	return "Dependencies"
 }
 
-func (c *ToolsCheck) Run(_ context.Context) Result {
+func (c *ToolsCheck) Run(ctx context.Context) Result {
 	result := Result{Name: c.Name()}
 
 	// git is required
@@ -32,27 +61,130 @@ func (c *ToolsCheck) Run(_ context.Context) Result {
 			Detail: "not found on PATH",
 		})
 	} else {
-		result.Items = append(result.Items, CheckItem{
-			Label:  "git",
-			Status: StatusPass,
-			Detail: path,
-		})
+		result.Items = append(result.Items, c.versionItem(ctx, "git", path, "--version", minGitVersion))
 	}
 
-	// tmux is optional but recommended
-	if path, err := lookPathFunc("tmux"); err != nil {
-		result.Items = append(result.Items, CheckItem{
-			Label:  "tmux",
+	if goos == "windows" {
+		result.Items = append(result.Items, c.windowsItems()...)
+	} else {
+		// tmux is optional but recommended
+		if path, err := lookPathFunc("tmux"); err != nil {
+			result.Items = append(result.Items, CheckItem{
+				Label:  "tmux",
+				Status: StatusWarn,
+				Detail: "not found on PATH (required for session spawn and preview)",
+			})
+		} else {
+			result.Items = append(result.Items, c.versionItem(ctx, "tmux", path, "-V", minTmuxVersion))
+		}
+	}
+
+	if item, ok := c.clipboardItem(); ok {
+		result.Items = append(result.Items, item)
+	}
+
+	return result
+}
+
+// versionItem reports a tool found at path with its version, warning when
+// the version is older than minimum. A version that cannot be determined
+// (development builds such as "tmux master") passes.
+func (c *ToolsCheck) versionItem(ctx context.Context, label, path, arg string, minimum toolVersion) CheckItem {
+	item := CheckItem{Label: label, Status: StatusPass, Detail: path}
+	if c.exec == nil {
+		return item
+	}
+
+	out, err := c.exec.Run(ctx, path, arg)
+	if err != nil {
+		return item
+	}
+	version, ok := parseToolVersion(string(out))
+	if !ok {
+		return item
+	}
+
+	item.Detail = fmt.Sprintf("%s (%s)", path, version)
+	if version.less(minimum) {
+		item.Status = StatusWarn
+		item.Detail = fmt.Sprintf("%s: version %s is older than %s, some features may fail", path, version, minimum)
+	}
+	return item
+}
+
+// clipboardItem checks the program of the copy command; ok is false when no
+// copy command is configured.
+func (c *ToolsCheck) clipboardItem() (CheckItem, bool) {
+	fields := strings.Fields(c.copyCommand)
+	if len(fields) == 0 {
+		return CheckItem{}, false
+	}
+
+	program := fields[0]
+	path, err := lookPathFunc(program)
+	if err != nil {
+		return CheckItem{
+			Label:  "clipboard",
 			Status: StatusWarn,
-			Detail: "not found on PATH (required for session spawn and preview)",
+			Detail: fmt.Sprintf("%s not found on PATH (copying fails; install it or set copy_command)", program),
+		}, true
+	}
+	return CheckItem{Label: "clipboard", Status: StatusPass, Detail: path}, true
+}
+
+// toolVersion is a major.minor version of an external tool.
+type toolVersion struct {
+	major, minor int
+}
+
+var versionPattern = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+// parseToolVersion extracts the first major.minor version from a tool's
+// version output, such as "git version 2.43.0" or "tmux 3.3a".
+func parseToolVersion(out string) (toolVersion, bool) {
+	m := versionPattern.FindStringSubmatch(out)
+	if m == nil {
+		return toolVersion{}, false
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	return toolVersion{major, minor}, true
+}
+
+func (v toolVersion) less(o toolVersion) bool {
+	if v.major != o.major {
+		return v.major < o.major
+	}
+	return v.minor < o.minor
+}
+
+func (v toolVersion) String() string {
+	return fmt.Sprintf("%d.%d", v.major, v.minor)
+}
+
+// windowsItems checks the tools that replace tmux and sh on Windows.
+func (c *ToolsCheck) windowsItems() []CheckItem {
+	var items []CheckItem
+
+	if path, err := lookPathFunc("wt.exe"); err != nil {
+		items = append(items, CheckItem{
+			Label:  "wt.exe",
+			Status: StatusWarn,
+			Detail: "not found on PATH (Windows Terminal is required for session spawn)",
 		})
 	} else {
-		result.Items = append(result.Items, CheckItem{
-			Label:  "tmux",
-			Status: StatusPass,
-			Detail: path,
+		items = append(items, CheckItem{Label: "wt.exe", Status: StatusPass, Detail: path})
+	}
+
+	if path, err := lookPathFunc("pwsh"); err == nil {
+		items = append(items, CheckItem{Label: "pwsh", Status: StatusPass, Detail: path})
+	} else {
+		items = append(items, CheckItem{
+			Label:  "pwsh",
+			Status: StatusWarn,
+			Detail: "not found on PATH (commands fall back to Windows PowerShell, which lacks && and ||)",
 		})
 	}
 
-	return result
+	return items
 }
@@ -8,8 +8,25 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/pkg/executil/executiltest"
 )
 
+// versionExec answers version commands with the output for each tool path;
+// other paths fail.
+type versionExec struct {
+	executiltest.Exec
+	versions map[string]string
+}
+
+func (e *versionExec) Run(_ context.Context, cmd string, _ ...string) ([]byte, error) {
+	out, ok := e.versions[cmd]
+	if !ok {
+		return nil, fmt.Errorf("%s: no version", cmd)
+	}
+	return []byte(out), nil
+}
+
 func TestToolsCheck_BothPresent(t *testing.T) {
 	orig := lookPathFunc
 	t.Cleanup(func() { lookPathFunc = orig })
@@ -18,7 +35,7 @@ func TestToolsCheck_BothPresent(t *testing.T) {
 		return "/usr/bin/" + file, nil
 	}
 
-	check := NewToolsCheck()
+	check := NewToolsCheck(&versionExec{})
 	result := check.Run(context.Background())
 
 	assert.Equal(t, "Dependencies", result.Name)
@@ -44,7 +61,7 @@ func TestToolsCheck_GitMissing(t *testing.T) {
 		return "/usr/bin/" + file, nil
 	}
 
-	check := NewToolsCheck()
+	check := NewToolsCheck(&versionExec{})
 	result := check.Run(context.Background())
 
 	require.Len(t, result.Items, 2)
@@ -64,7 +81,7 @@ func TestToolsCheck_TmuxMissing(t *testing.T) {
 		return "/usr/bin/" + file, nil
 	}
 
-	check := NewToolsCheck()
+	check := NewToolsCheck(&versionExec{})
 	result := check.Run(context.Background())
 
 	require.Len(t, result.Items, 2)
@@ -72,3 +89,87 @@ func TestToolsCheck_TmuxMissing(t *testing.T) {
 	assert.Equal(t, StatusWarn, result.Items[1].Status)
 	assert.Contains(t, result.Items[1].Detail, "not found on PATH")
 }
+
+func TestToolsCheck_Windows(t *testing.T) {
+	origLook, origGOOS := lookPathFunc, goos
+	t.Cleanup(func() { lookPathFunc, goos = origLook, origGOOS })
+
+	goos = "windows"
+	lookPathFunc = func(file string) (string, error) {
+		if file == "pwsh" {
+			return "", &exec.Error{Name: file, Err: fmt.Errorf("not found")}
+		}
+		return `C:\bin\` + file, nil
+	}
+
+	result := NewToolsCheck(&versionExec{}).Run(context.Background())
+
+	require.Len(t, result.Items, 3)
+	assert.Equal(t, "git", result.Items[0].Label)
+	assert.Equal(t, "wt.exe", result.Items[1].Label)
+	assert.Equal(t, StatusPass, result.Items[1].Status)
+	assert.Equal(t, "pwsh", result.Items[2].Label)
+	assert.Equal(t, StatusWarn, result.Items[2].Status)
+}
+
+func TestToolsCheck_Versions(t *testing.T) {
+	orig := lookPathFunc
+	t.Cleanup(func() { lookPathFunc = orig })
+
+	lookPathFunc = func(file string) (string, error) {
+		return "/usr/bin/" + file, nil
+	}
+	versions := &versionExec{versions: map[string]string{
+		"/usr/bin/git":  "git version 2.43.0 (Apple Git-145)\n",
+		"/usr/bin/tmux": "tmux 2.9a\n",
+	}}
+
+	result := NewToolsCheck(versions).Run(context.Background())
+
+	require.Len(t, result.Items, 2)
+	assert.Equal(t, StatusPass, result.Items[0].Status)
+	assert.Equal(t, "/usr/bin/git (2.43)", result.Items[0].Detail)
+	assert.Equal(t, StatusWarn, result.Items[1].Status)
+	assert.Contains(t, result.Items[1].Detail, "version 2.9 is older than 3.0")
+}
+
+func TestToolsCheck_Clipboard(t *testing.T) {
+	orig := lookPathFunc
+	t.Cleanup(func() { lookPathFunc = orig })
+
+	lookPathFunc = func(file string) (string, error) {
+		if file == "xclip" {
+			return "", &exec.Error{Name: file, Err: fmt.Errorf("not found")}
+		}
+		return "/usr/bin/" + file, nil
+	}
+
+	result := NewToolsCheck(&versionExec{}).WithClipboard("xclip -selection clipboard").Run(context.Background())
+	require.Len(t, result.Items, 3)
+	assert.Equal(t, "clipboard", result.Items[2].Label)
+	assert.Equal(t, StatusWarn, result.Items[2].Status)
+	assert.Contains(t, result.Items[2].Detail, "xclip not found on PATH")
+
+	result = NewToolsCheck(&versionExec{}).WithClipboard("pbcopy").Run(context.Background())
+	require.Len(t, result.Items, 3)
+	assert.Equal(t, StatusPass, result.Items[2].Status)
+	assert.Equal(t, "/usr/bin/pbcopy", result.Items[2].Detail)
+}
+
+func TestParseToolVersion(t *testing.T) {
+	tests := []struct {
+		out  string
+		want toolVersion
+		ok   bool
+	}{
+		{"git version 2.39.3 (Apple Git-145)", toolVersion{2, 39}, true},
+		{"tmux 3.3a", toolVersion{3, 3}, true},
+		{"tmux next-3.5", toolVersion{3, 5}, true},
+		{"tmux master", toolVersion{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseToolVersion(tt.out)
+		assert.Equal(t, tt.ok, ok, tt.out)
+		assert.Equal(t, tt.want, got, tt.out)
+	}
+}
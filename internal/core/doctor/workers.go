@@ -0,0 +1,72 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/colonyops/hive/pkg/timeutil"
+)
+
+// WorkerInfo describes the health of a supervised background worker for
+// doctor checks. Decoupled from the supervisor to avoid import cycles.
+type WorkerInfo struct {
+	Process   string // process running the worker, e.g. "pid 4242"
+	Name      string
+	State     string // running, restarting, or stopped
+	Stale     bool   // busy past its stall deadline
+	Restarts  int
+	LastBeat  time.Time
+	LastError string
+}
+
+// WorkerCheck reports wedged and restarting background workers.
+type WorkerCheck struct {
+	workers []WorkerInfo
+}
+
+// NewWorkerCheck creates a new background worker health check.
+func NewWorkerCheck(workers []WorkerInfo) *WorkerCheck {
+	return &WorkerCheck{workers: workers}
+}
+
+func (c *WorkerCheck) Name() string {
+	return "Background Workers"
+}
+
+func (c *WorkerCheck) Run(_ context.Context) Result {
+	result := Result{Name: c.Name()}
+
+	if len(c.workers) == 0 {
+		result.Items = append(result.Items, CheckItem{
+			Label:  "No background workers",
+			Status: StatusPass,
+			Detail: "no long-running hive process is publishing worker health",
+		})
+		return result
+	}
+
+	for _, w := range c.workers {
+		item := CheckItem{Label: fmt.Sprintf("%s (%s)", w.Name, w.Process)}
+		switch {
+		case w.Stale:
+			item.Status = StatusFail
+			item.Detail = "wedged, last heartbeat " + timeutil.Ago(w.LastBeat)
+		case w.State == "restarting":
+			item.Status = StatusWarn
+			item.Detail = fmt.Sprintf("restarting, %d restarts, last error: %s", w.Restarts, w.LastError)
+		case w.Restarts > 0:
+			item.Status = StatusWarn
+			item.Detail = fmt.Sprintf("%s, %d restarts, last error: %s", w.State, w.Restarts, w.LastError)
+		default:
+			item.Status = StatusPass
+			item.Detail = w.State
+			if w.State == "running" {
+				item.Detail += ", last heartbeat " + timeutil.Ago(w.LastBeat)
+			}
+		}
+		result.Items = append(result.Items, item)
+	}
+
+	return result
+}
@@ -0,0 +1,39 @@
+package doctor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerCheck(t *testing.T) {
+	now := time.Now()
+	check := NewWorkerCheck([]WorkerInfo{
+		{Process: "pid 1", Name: "sweep.kv", State: "running", LastBeat: now},
+		{Process: "pid 1", Name: "plugins.worker-0", State: "running", Stale: true, LastBeat: now.Add(-5 * time.Minute)},
+		{Process: "pid 1", Name: "plugins.worker-1", State: "restarting", Restarts: 2, LastError: "panic: boom"},
+		{Process: "pid 2", Name: "terminal.poller", State: "running", Restarts: 1, LastError: "panic: nil map"},
+	})
+	result := check.Run(context.Background())
+
+	assert.Equal(t, "Background Workers", result.Name)
+	require.Len(t, result.Items, 4)
+	assert.Equal(t, "sweep.kv (pid 1)", result.Items[0].Label)
+	assert.Equal(t, StatusPass, result.Items[0].Status)
+	assert.Equal(t, StatusFail, result.Items[1].Status)
+	assert.Contains(t, result.Items[1].Detail, "wedged")
+	assert.Equal(t, StatusWarn, result.Items[2].Status)
+	assert.Equal(t, "restarting, 2 restarts, last error: panic: boom", result.Items[2].Detail)
+	assert.Equal(t, StatusWarn, result.Items[3].Status)
+	assert.Contains(t, result.Items[3].Detail, "running, 1 restarts")
+}
+
+func TestWorkerCheck_NoWorkers(t *testing.T) {
+	result := NewWorkerCheck(nil).Run(context.Background())
+
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, StatusPass, result.Items[0].Status)
+}
@@ -0,0 +1,78 @@
+package eventbus
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/terminal"
+)
+
+// DesktopNotifier sends native desktop notifications when an agent enters a
+// status its session's rules opted into. Handlers run on the bus goroutine,
+// so its state needs no locking.
+type DesktopNotifier struct {
+	bus       *EventBus
+	statuses  func(remote string) []string // statuses that notify for a remote
+	send      func(title, message string)
+	rateLimit time.Duration
+	now       func() time.Time
+
+	lastSent map[string]time.Time // session ID → last notification
+}
+
+// NewDesktopNotifier constructs a notifier. statuses returns the agent
+// statuses that notify for a session's remote; send delivers a notification
+// and must not block the bus. At most one notification is sent per session
+// within rateLimit; 0 disables rate limiting.
+func NewDesktopNotifier(bus *EventBus, statuses func(remote string) []string, send func(title, message string), rateLimit time.Duration) *DesktopNotifier {
+	return &DesktopNotifier{
+		bus:       bus,
+		statuses:  statuses,
+		send:      send,
+		rateLimit: rateLimit,
+		now:       time.Now,
+		lastSent:  make(map[string]time.Time),
+	}
+}
+
+// Register subscribes the notifier to agent status changes.
+func (n *DesktopNotifier) Register() {
+	if n == nil || n.bus == nil {
+		return
+	}
+
+	n.bus.SubscribeAgentStatusChanged(n.handle)
+	n.bus.SubscribeSessionDeleted(func(p SessionDeletedPayload) {
+		delete(n.lastSent, p.SessionID)
+	})
+}
+
+func (n *DesktopNotifier) handle(p AgentStatusChangedPayload) {
+	// A missing old status is the first poll after startup or a session
+	// appearing, not a transition the user is waiting on.
+	if p.Session == nil || p.OldStatus == terminal.StatusMissing {
+		return
+	}
+
+	var message string
+	switch p.NewStatus {
+	case terminal.StatusApproval:
+		message = fmt.Sprintf("%s needs approval", p.Session.Name)
+	case terminal.StatusReady:
+		message = fmt.Sprintf("%s finished and is waiting for input", p.Session.Name)
+	default:
+		return
+	}
+	if !slices.Contains(n.statuses(p.Session.Remote), string(p.NewStatus)) {
+		return
+	}
+
+	now := n.now()
+	if last, ok := n.lastSent[p.Session.ID]; ok && n.rateLimit > 0 && now.Sub(last) < n.rateLimit {
+		return
+	}
+	n.lastSent[p.Session.ID] = now
+
+	n.send("hive", message)
+}
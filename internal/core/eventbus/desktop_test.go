@@ -0,0 +1,55 @@
+package eventbus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+)
+
+func TestDesktopNotifier(t *testing.T) {
+	statuses := func(remote string) []string {
+		if remote == "github.com/colonyops/hive" {
+			return []string{"approval", "ready"}
+		}
+		return []string{"approval"}
+	}
+
+	var sent []string
+	n := NewDesktopNotifier(New(1), statuses, func(_, message string) {
+		sent = append(sent, message)
+	}, time.Minute)
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	n.now = func() time.Time { return now }
+
+	hive := &session.Session{ID: "a", Name: "alpha", Remote: "github.com/colonyops/hive"}
+	other := &session.Session{ID: "b", Name: "bravo", Remote: "github.com/other/repo"}
+	change := func(sess *session.Session, from, to terminal.Status) {
+		n.handle(AgentStatusChangedPayload{Session: sess, OldStatus: from, NewStatus: to})
+	}
+
+	change(hive, terminal.StatusMissing, terminal.StatusReady)
+	assert.Empty(t, sent, "the first status seen for a session does not notify")
+
+	change(hive, terminal.StatusActive, terminal.StatusApproval)
+	assert.Equal(t, []string{"alpha needs approval"}, sent)
+
+	change(hive, terminal.StatusApproval, terminal.StatusReady)
+	assert.Len(t, sent, 1, "rate limited within the window")
+
+	change(other, terminal.StatusActive, terminal.StatusReady)
+	assert.Len(t, sent, 1, "ready is not opted into for this remote")
+	change(other, terminal.StatusActive, terminal.StatusApproval)
+	assert.Equal(t, "bravo needs approval", sent[len(sent)-1], "sessions are rate limited independently")
+
+	now = now.Add(time.Minute)
+	change(hive, terminal.StatusActive, terminal.StatusReady)
+	assert.Equal(t, "alpha finished and is waiting for input", sent[len(sent)-1])
+
+	change(hive, terminal.StatusReady, terminal.StatusActive)
+	assert.Len(t, sent, 3, "active never notifies")
+}
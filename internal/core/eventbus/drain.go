@@ -0,0 +1,31 @@
+package eventbus
+
+// Drain runs the handlers of the events still queued, once Start has
+// returned. Call it on shutdown so events published just before exiting,
+// as short-lived commands do, still reach their subscribers.
+func (bus *EventBus) Drain() {
+	for {
+		select {
+		case env := <-bus.ch:
+			bus.mu.RLock()
+			subs := make([]any, len(bus.subscribers[env.event]))
+			copy(subs, bus.subscribers[env.event])
+			bus.mu.RUnlock()
+
+			for _, sub := range subs {
+				func() {
+					defer func() {
+						if r := recover(); r != nil {
+							bus.runOnPanic(env.event, env.payload, r)
+						}
+					}()
+					if fn, ok := sub.(func(any)); ok {
+						fn(env.payload)
+					}
+				}()
+			}
+		default:
+			return
+		}
+	}
+}
@@ -15,11 +15,14 @@ const (
 	EventMessageReceived       Event = "message.received"
 	EventNotificationPublished Event = "notification.published"
 	EventRepoFocused           Event = "repo.focused"
+	EventReviewFinalized       Event = "review.finalized"
 	EventSessionCorrupted      Event = "session.corrupted"
 	EventSessionCreated        Event = "session.created"
 	EventSessionDeleted        Event = "session.deleted"
+	EventSessionOverdue        Event = "session.overdue"
 	EventSessionRecycled       Event = "session.recycled"
 	EventSessionRenamed        Event = "session.renamed"
+	EventSessionStalled        Event = "session.stalled"
 	EventTodoCreated           Event = "todo.created"
 	EventTuiStarted            Event = "tui.started"
 	EventTuiStopped            Event = "tui.stopped"
@@ -62,11 +65,14 @@ func newSubscribersMap() map[Event][]any {
 		EventMessageReceived:       {},
 		EventNotificationPublished: {},
 		EventRepoFocused:           {},
+		EventReviewFinalized:       {},
 		EventSessionCorrupted:      {},
 		EventSessionCreated:        {},
 		EventSessionDeleted:        {},
+		EventSessionOverdue:        {},
 		EventSessionRecycled:       {},
 		EventSessionRenamed:        {},
+		EventSessionStalled:        {},
 		EventTodoCreated:           {},
 		EventTuiStarted:            {},
 		EventTuiStopped:            {},
@@ -221,6 +227,30 @@ func (bus *EventBus) SubscribeRepoFocused(fn func(RepoFocusedPayload)) {
 	bus.runOnSubscribe(EventRepoFocused)
 }
 
+// PublishReviewFinalized publishes a review.finalized event.
+func (bus *EventBus) PublishReviewFinalized(payload ReviewFinalizedPayload) {
+	select {
+	case bus.ch <- envelope{event: EventReviewFinalized, payload: payload}:
+		bus.runOnPublish(EventReviewFinalized, payload)
+	default:
+		bus.runOnDrop(EventReviewFinalized, payload)
+	}
+}
+
+// SubscribeReviewFinalized registers a handler for review.finalized events.
+func (bus *EventBus) SubscribeReviewFinalized(fn func(ReviewFinalizedPayload)) {
+	bus.mu.Lock()
+	bus.subscribers[EventReviewFinalized] = append(bus.subscribers[EventReviewFinalized], func(v any) {
+		payload, ok := v.(ReviewFinalizedPayload)
+		if !ok {
+			return
+		}
+		fn(payload)
+	})
+	bus.mu.Unlock()
+	bus.runOnSubscribe(EventReviewFinalized)
+}
+
 // PublishSessionCorrupted publishes a session.corrupted event.
 func (bus *EventBus) PublishSessionCorrupted(payload SessionCorruptedPayload) {
 	select {
@@ -293,6 +323,30 @@ func (bus *EventBus) SubscribeSessionDeleted(fn func(SessionDeletedPayload)) {
 	bus.runOnSubscribe(EventSessionDeleted)
 }
 
+// PublishSessionOverdue publishes a session.overdue event.
+func (bus *EventBus) PublishSessionOverdue(payload SessionOverduePayload) {
+	select {
+	case bus.ch <- envelope{event: EventSessionOverdue, payload: payload}:
+		bus.runOnPublish(EventSessionOverdue, payload)
+	default:
+		bus.runOnDrop(EventSessionOverdue, payload)
+	}
+}
+
+// SubscribeSessionOverdue registers a handler for session.overdue events.
+func (bus *EventBus) SubscribeSessionOverdue(fn func(SessionOverduePayload)) {
+	bus.mu.Lock()
+	bus.subscribers[EventSessionOverdue] = append(bus.subscribers[EventSessionOverdue], func(v any) {
+		payload, ok := v.(SessionOverduePayload)
+		if !ok {
+			return
+		}
+		fn(payload)
+	})
+	bus.mu.Unlock()
+	bus.runOnSubscribe(EventSessionOverdue)
+}
+
 // PublishSessionRecycled publishes a session.recycled event.
 func (bus *EventBus) PublishSessionRecycled(payload SessionRecycledPayload) {
 	select {
@@ -341,6 +395,30 @@ func (bus *EventBus) SubscribeSessionRenamed(fn func(SessionRenamedPayload)) {
 	bus.runOnSubscribe(EventSessionRenamed)
 }
 
+// PublishSessionStalled publishes a session.stalled event.
+func (bus *EventBus) PublishSessionStalled(payload SessionStalledPayload) {
+	select {
+	case bus.ch <- envelope{event: EventSessionStalled, payload: payload}:
+		bus.runOnPublish(EventSessionStalled, payload)
+	default:
+		bus.runOnDrop(EventSessionStalled, payload)
+	}
+}
+
+// SubscribeSessionStalled registers a handler for session.stalled events.
+func (bus *EventBus) SubscribeSessionStalled(fn func(SessionStalledPayload)) {
+	bus.mu.Lock()
+	bus.subscribers[EventSessionStalled] = append(bus.subscribers[EventSessionStalled], func(v any) {
+		payload, ok := v.(SessionStalledPayload)
+		if !ok {
+			return
+		}
+		fn(payload)
+	})
+	bus.mu.Unlock()
+	bus.runOnSubscribe(EventSessionStalled)
+}
+
 // PublishTodoCreated publishes a todo.created event.
 func (bus *EventBus) PublishTodoCreated(payload TodoCreatedPayload) {
 	select {
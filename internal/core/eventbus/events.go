@@ -3,12 +3,15 @@
 package eventbus
 
 import (
+	"time"
+
 	"github.com/colonyops/hive/internal/core/config"
 	"github.com/colonyops/hive/internal/core/messaging"
 	"github.com/colonyops/hive/internal/core/notify"
 	"github.com/colonyops/hive/internal/core/session"
 	"github.com/colonyops/hive/internal/core/terminal"
 	"github.com/colonyops/hive/internal/core/todo"
+	"github.com/colonyops/hive/internal/core/watchdog"
 )
 
 //go:generate gobusgen generate -p .Events
@@ -21,11 +24,14 @@ var Events = map[string]any{
 	"message.received":       MessageReceivedPayload{},
 	"notification.published": NotificationPublishedPayload{},
 	"repo.focused":           RepoFocusedPayload{},
+	"review.finalized":       ReviewFinalizedPayload{},
 	"session.corrupted":      SessionCorruptedPayload{},
 	"session.created":        SessionCreatedPayload{},
 	"session.deleted":        SessionDeletedPayload{},
+	"session.overdue":        SessionOverduePayload{},
 	"session.recycled":       SessionRecycledPayload{},
 	"session.renamed":        SessionRenamedPayload{},
+	"session.stalled":        SessionStalledPayload{},
 	"todo.created":           TodoCreatedPayload{},
 	"tui.started":            TUIStartedPayload{},
 	"tui.stopped":            TUIStoppedPayload{},
@@ -52,6 +58,20 @@ type SessionRenamedPayload struct {
 	OldName string
 }
 
+// SessionStalledPayload is emitted when the watchdog finds a session waiting
+// for approval, or active without pane output, for longer than its threshold.
+type SessionStalledPayload struct {
+	Session *session.Session
+	Alert   watchdog.Alert
+}
+
+// SessionOverduePayload is emitted when an active session passes its due
+// date.
+type SessionOverduePayload struct {
+	Session *session.Session
+	DueAt   time.Time
+}
+
 // SessionCorruptedPayload is emitted when a session is marked corrupted.
 type SessionCorruptedPayload struct {
 	Session *session.Session
@@ -92,6 +112,13 @@ type RepoFocusedPayload struct {
 	RepoKey string
 }
 
+// ReviewFinalizedPayload is emitted when a review is finalized in the TUI.
+type ReviewFinalizedPayload struct {
+	DocumentPath string
+	DocumentRel  string
+	Feedback     string
+}
+
 // ConfigReloadedPayload is emitted when configuration is reloaded.
 type ConfigReloadedPayload struct {
 	Config *config.Config
@@ -5,6 +5,8 @@ import (
 
 	"github.com/colonyops/hive/internal/core/notify"
 	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/core/watchdog"
+	"github.com/colonyops/hive/pkg/timeutil"
 )
 
 // NotificationRouter maps domain events to user-facing notifications.
@@ -50,6 +52,25 @@ func (r *NotificationRouter) Register() {
 		}
 	})
 
+	r.bus.SubscribeSessionStalled(func(p SessionStalledPayload) {
+		if p.Session == nil {
+			return
+		}
+		switch p.Alert.Reason {
+		case watchdog.ReasonApproval:
+			r.notifyf(notify.LevelWarning, "agent %q stalled waiting for approval, last change %s", p.Session.Name, timeutil.Ago(p.Alert.Since))
+		case watchdog.ReasonIdle:
+			r.notifyf(notify.LevelWarning, "agent %q stalled with no pane output, last change %s", p.Session.Name, timeutil.Ago(p.Alert.Since))
+		}
+	})
+
+	r.bus.SubscribeSessionOverdue(func(p SessionOverduePayload) {
+		if p.Session == nil {
+			return
+		}
+		r.notifyf(notify.LevelWarning, "session %q is overdue, due %s", p.Session.Name, timeutil.Ago(p.DueAt))
+	})
+
 	r.bus.SubscribeMessageReceived(func(p MessageReceivedPayload) {
 		r.notifyf(notify.LevelInfo, "message received on %s", p.Topic)
 	})
@@ -9,6 +9,7 @@ import (
 	"github.com/colonyops/hive/internal/core/notify"
 	"github.com/colonyops/hive/internal/core/session"
 	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/core/watchdog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -63,6 +64,36 @@ func TestNotificationRouter_SessionRecycled(t *testing.T) {
 	assert.Contains(t, p.Message, "beta")
 }
 
+func TestNotificationRouter_SessionStalled(t *testing.T) {
+	tb := testbus.New(t)
+	eventbus.NewNotificationRouter(tb.EventBus).Register()
+
+	tb.PublishSessionStalled(eventbus.SessionStalledPayload{
+		Session: &session.Session{Name: "gamma"},
+		Alert:   watchdog.Alert{Reason: watchdog.ReasonApproval, Since: time.Now().Add(-10 * time.Minute)},
+	})
+	p := latestNotificationPayload(tb, t)
+
+	assert.Equal(t, notify.LevelWarning, p.Level)
+	assert.Contains(t, p.Message, "gamma")
+	assert.Contains(t, p.Message, "approval")
+}
+
+func TestNotificationRouter_SessionOverdue(t *testing.T) {
+	tb := testbus.New(t)
+	eventbus.NewNotificationRouter(tb.EventBus).Register()
+
+	tb.PublishSessionOverdue(eventbus.SessionOverduePayload{
+		Session: &session.Session{Name: "delta"},
+		DueAt:   time.Now().Add(-2 * time.Hour),
+	})
+	p := latestNotificationPayload(tb, t)
+
+	assert.Equal(t, notify.LevelWarning, p.Level)
+	assert.Contains(t, p.Message, "delta")
+	assert.Contains(t, p.Message, "overdue")
+}
+
 func TestNotificationRouter_MessageReceived(t *testing.T) {
 	tb := testbus.New(t)
 	eventbus.NewNotificationRouter(tb.EventBus).Register()
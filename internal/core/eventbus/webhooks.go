@@ -0,0 +1,258 @@
+package eventbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/messaging"
+	"github.com/colonyops/hive/internal/core/session"
+)
+
+const (
+	// webhookQueueSize is the number of deliveries buffered per webhook.
+	// Events are dropped when a slow endpoint lets its queue fill.
+	webhookQueueSize = 64
+	// webhookAttempts is the number of times a delivery is tried.
+	webhookAttempts = 4
+	// webhookTimeout bounds a single delivery attempt.
+	webhookTimeout = 10 * time.Second
+	// webhookDrainTimeout bounds how long Run keeps delivering queued events
+	// after its context is cancelled, so short-lived commands still send the
+	// events they publish without hanging on a dead endpoint.
+	webhookDrainTimeout = 5 * time.Second
+)
+
+// webhookBody is the JSON document POSTed to webhooks.
+type webhookBody struct {
+	Event     string    `json:"event"`
+	Timestamp time.Time `json:"timestamp"`
+	Data      any       `json:"data"`
+}
+
+// WebhookDispatcher POSTs bus events to the webhooks configured under
+// integrations.webhooks. Bus handlers only encode and queue events; each
+// webhook is delivered to by its own worker, so a slow endpoint neither
+// blocks the bus nor delays other webhooks.
+type WebhookDispatcher struct {
+	bus     *EventBus
+	hooks   []config.WebhookConfig
+	client  *http.Client
+	logger  zerolog.Logger
+	backoff time.Duration // delay before the first retry, doubled after each
+	drain   time.Duration // how long queued events are delivered after shutdown
+	now     func() time.Time
+
+	queues []chan []byte // one per hook, in hooks order
+}
+
+// NewWebhookDispatcher constructs a dispatcher for hooks. A nil client uses
+// one with a 10 second timeout.
+func NewWebhookDispatcher(bus *EventBus, hooks []config.WebhookConfig, client *http.Client, logger zerolog.Logger) *WebhookDispatcher {
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+
+	queues := make([]chan []byte, len(hooks))
+	for i := range queues {
+		queues[i] = make(chan []byte, webhookQueueSize)
+	}
+
+	return &WebhookDispatcher{
+		bus:     bus,
+		hooks:   hooks,
+		client:  client,
+		logger:  logger,
+		backoff: time.Second,
+		drain:   webhookDrainTimeout,
+		now:     time.Now,
+		queues:  queues,
+	}
+}
+
+// Register subscribes the dispatcher to the events webhooks can receive.
+func (d *WebhookDispatcher) Register() {
+	if d == nil || d.bus == nil || len(d.hooks) == 0 {
+		return
+	}
+
+	d.bus.SubscribeSessionCreated(func(p SessionCreatedPayload) {
+		d.enqueue(EventSessionCreated, webhookSession{Session: p.Session})
+	})
+	d.bus.SubscribeSessionRecycled(func(p SessionRecycledPayload) {
+		d.enqueue(EventSessionRecycled, webhookSession{Session: p.Session})
+	})
+	d.bus.SubscribeSessionDeleted(func(p SessionDeletedPayload) {
+		d.enqueue(EventSessionDeleted, webhookSessionDeleted{SessionID: p.SessionID})
+	})
+	d.bus.SubscribeAgentStatusChanged(func(p AgentStatusChangedPayload) {
+		d.enqueue(EventAgentStatusChanged, webhookStatusChanged{
+			Session:   p.Session,
+			OldStatus: string(p.OldStatus),
+			NewStatus: string(p.NewStatus),
+		})
+	})
+	d.bus.SubscribeMessageReceived(func(p MessageReceivedPayload) {
+		d.enqueue(EventMessageReceived, webhookMessage{Topic: p.Topic, Message: p.Message})
+	})
+	d.bus.SubscribeReviewFinalized(func(p ReviewFinalizedPayload) {
+		d.enqueue(EventReviewFinalized, webhookReview{
+			DocumentPath: p.DocumentPath,
+			DocumentRel:  p.DocumentRel,
+			Feedback:     p.Feedback,
+		})
+	})
+}
+
+// Run delivers queued events until ctx is cancelled, then keeps delivering
+// the events still queued for up to the drain timeout. Events left after
+// that are discarded.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	deliverCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	stop := context.AfterFunc(ctx, func() { time.AfterFunc(d.drain, cancel) })
+	defer stop()
+
+	var wg sync.WaitGroup
+	for i := range d.hooks {
+		wg.Go(func() {
+			for {
+				select {
+				case <-ctx.Done():
+					d.drainQueue(deliverCtx, i)
+					return
+				case body := <-d.queues[i]:
+					d.deliver(deliverCtx, d.hooks[i], body)
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
+
+// drainQueue delivers the events queued for the i-th hook until the queue
+// is empty or ctx is done.
+func (d *WebhookDispatcher) drainQueue(ctx context.Context, i int) {
+	for {
+		select {
+		case body := <-d.queues[i]:
+			if ctx.Err() != nil {
+				d.logger.Warn().Str("url", d.hooks[i].URL).Int("dropped", len(d.queues[i])+1).Msg("webhook shutdown timed out, events dropped")
+				return
+			}
+			d.deliver(ctx, d.hooks[i], body)
+		default:
+			return
+		}
+	}
+}
+
+// enqueue encodes an event and queues it for every webhook that wants it.
+func (d *WebhookDispatcher) enqueue(event Event, data any) {
+	var body []byte
+	for i, hook := range d.hooks {
+		if !hook.Wants(string(event)) {
+			continue
+		}
+
+		if body == nil {
+			var err error
+			body, err = json.Marshal(webhookBody{Event: string(event), Timestamp: d.now().UTC(), Data: data})
+			if err != nil {
+				d.logger.Error().Err(err).Str("event", string(event)).Msg("encode webhook payload")
+				return
+			}
+		}
+
+		select {
+		case d.queues[i] <- body:
+		default:
+			d.logger.Warn().Str("event", string(event)).Str("url", hook.URL).Msg("webhook queue full, event dropped")
+		}
+	}
+}
+
+// deliver POSTs body to hook, retrying with exponential backoff on network
+// errors, 5xx and 429 responses.
+func (d *WebhookDispatcher) deliver(ctx context.Context, hook config.WebhookConfig, body []byte) {
+	delay := d.backoff
+	for attempt := 1; ; attempt++ {
+		retry, err := d.post(ctx, hook, body)
+		if err == nil {
+			return
+		}
+		if !retry || attempt == webhookAttempts {
+			d.logger.Warn().Err(err).Str("url", hook.URL).Int("attempts", attempt).Msg("webhook delivery failed")
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// post makes a single delivery attempt and reports whether a failure is
+// worth retrying.
+func (d *WebhookDispatcher) post(ctx context.Context, hook config.WebhookConfig, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "hive")
+	for k, v := range hook.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode >= 500, resp.StatusCode == http.StatusTooManyRequests:
+		return true, fmt.Errorf("unexpected status: %s", resp.Status)
+	default:
+		return false, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+}
+
+// Webhook payload data, one type per event.
+type (
+	webhookSession struct {
+		Session *session.Session `json:"session"`
+	}
+	webhookSessionDeleted struct {
+		SessionID string `json:"session_id"`
+	}
+	webhookStatusChanged struct {
+		Session   *session.Session `json:"session"`
+		OldStatus string           `json:"old_status"`
+		NewStatus string           `json:"new_status"`
+	}
+	webhookMessage struct {
+		Topic   string             `json:"topic"`
+		Message *messaging.Message `json:"message"`
+	}
+	webhookReview struct {
+		DocumentPath string `json:"document_path"`
+		DocumentRel  string `json:"document_rel"`
+		Feedback     string `json:"feedback"`
+	}
+)
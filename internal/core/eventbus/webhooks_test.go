@@ -0,0 +1,223 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+)
+
+// webhookRecorder is a test endpoint that records request bodies.
+type webhookRecorder struct {
+	mu       sync.Mutex
+	bodies   []webhookBody
+	headers  []http.Header
+	received chan struct{}
+}
+
+func newWebhookRecorder(t *testing.T, status func(n int) int) (*httptest.Server, *webhookRecorder) {
+	t.Helper()
+
+	rec := &webhookRecorder{received: make(chan struct{}, 16)}
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		data, _ := io.ReadAll(r.Body)
+
+		code := status(n)
+		if code < 300 {
+			var body webhookBody
+			assert.NoError(t, json.Unmarshal(data, &body))
+			rec.mu.Lock()
+			rec.bodies = append(rec.bodies, body)
+			rec.headers = append(rec.headers, r.Header.Clone())
+			rec.mu.Unlock()
+			rec.received <- struct{}{}
+		}
+		w.WriteHeader(code)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, rec
+}
+
+func (r *webhookRecorder) wait(t *testing.T) {
+	t.Helper()
+	select {
+	case <-r.received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for webhook")
+	}
+}
+
+func startDispatcher(t *testing.T, hooks []config.WebhookConfig) *WebhookDispatcher {
+	t.Helper()
+
+	d := NewWebhookDispatcher(New(1), hooks, nil, zerolog.Nop())
+	d.backoff = time.Millisecond
+	d.now = func() time.Time { return time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC) }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return d
+}
+
+func TestWebhookDispatcher_Delivers(t *testing.T) {
+	srv, rec := newWebhookRecorder(t, func(int) int { return http.StatusNoContent })
+	d := startDispatcher(t, []config.WebhookConfig{
+		{URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer token"}},
+	})
+
+	sess := &session.Session{ID: "a", Name: "alpha"}
+	d.enqueue(EventAgentStatusChanged, webhookStatusChanged{
+		Session:   sess,
+		OldStatus: string(terminal.StatusActive),
+		NewStatus: string(terminal.StatusReady),
+	})
+	rec.wait(t)
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	require.Len(t, rec.bodies, 1)
+	assert.Equal(t, "agent.status-changed", rec.bodies[0].Event)
+	assert.Equal(t, time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC), rec.bodies[0].Timestamp)
+
+	data, ok := rec.bodies[0].Data.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "ready", data["new_status"])
+	assert.Equal(t, "alpha", data["session"].(map[string]any)["name"])
+
+	assert.Equal(t, "application/json", rec.headers[0].Get("Content-Type"))
+	assert.Equal(t, "Bearer token", rec.headers[0].Get("Authorization"))
+}
+
+func TestWebhookDispatcher_FiltersEvents(t *testing.T) {
+	srv, rec := newWebhookRecorder(t, func(int) int { return http.StatusOK })
+	d := startDispatcher(t, []config.WebhookConfig{
+		{URL: srv.URL, Events: []string{"review.finalized"}},
+	})
+
+	d.enqueue(EventSessionCreated, webhookSession{Session: &session.Session{ID: "a"}})
+	d.enqueue(EventReviewFinalized, webhookReview{DocumentRel: "plans/a.md", Feedback: "L1: fix"})
+	rec.wait(t)
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	require.Len(t, rec.bodies, 1)
+	assert.Equal(t, "review.finalized", rec.bodies[0].Event)
+}
+
+func TestWebhookDispatcher_Retries(t *testing.T) {
+	srv, rec := newWebhookRecorder(t, func(n int) int {
+		if n < 3 {
+			return http.StatusServiceUnavailable
+		}
+		return http.StatusOK
+	})
+	d := startDispatcher(t, []config.WebhookConfig{{URL: srv.URL}})
+
+	d.enqueue(EventSessionDeleted, webhookSessionDeleted{SessionID: "a"})
+	rec.wait(t)
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	require.Len(t, rec.bodies, 1)
+	assert.Equal(t, "session.deleted", rec.bodies[0].Event)
+}
+
+func TestWebhookDispatcher_DeliversQueuedEventsOnShutdown(t *testing.T) {
+	srv, rec := newWebhookRecorder(t, func(int) int { return http.StatusOK })
+	bus := New(4)
+	d := NewWebhookDispatcher(bus, []config.WebhookConfig{{URL: srv.URL}}, nil, zerolog.Nop())
+	d.Register()
+
+	// A short-lived command publishes and exits before the bus runs.
+	bus.PublishSessionDeleted(SessionDeletedPayload{SessionID: "a"})
+	bus.PublishSessionDeleted(SessionDeletedPayload{SessionID: "b"})
+	bus.Drain()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d.Run(ctx)
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	assert.Len(t, rec.bodies, 2, "queued events are delivered after the context is cancelled")
+}
+
+func TestWebhookDispatcher_DrainTimeout(t *testing.T) {
+	srv, rec := newWebhookRecorder(t, func(int) int { return http.StatusServiceUnavailable })
+	d := NewWebhookDispatcher(New(1), []config.WebhookConfig{{URL: srv.URL}}, nil, zerolog.Nop())
+	d.backoff = time.Hour
+	d.drain = 50 * time.Millisecond
+	d.enqueue(EventSessionDeleted, webhookSessionDeleted{SessionID: "a"})
+	d.enqueue(EventSessionDeleted, webhookSessionDeleted{SessionID: "b"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	d.Run(ctx)
+	assert.Less(t, time.Since(start), 5*time.Second, "a failing endpoint does not hold up shutdown")
+	assert.Empty(t, rec.bodies)
+}
+
+func TestWebhookDispatcher_Post(t *testing.T) {
+	tests := []struct {
+		status    int
+		wantErr   bool
+		wantRetry bool
+	}{
+		{http.StatusOK, false, false},
+		{http.StatusAccepted, false, false},
+		{http.StatusBadRequest, true, false},
+		{http.StatusNotFound, true, false},
+		{http.StatusTooManyRequests, true, true},
+		{http.StatusInternalServerError, true, true},
+		{http.StatusBadGateway, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			hook := config.WebhookConfig{URL: srv.URL}
+			d := NewWebhookDispatcher(New(1), []config.WebhookConfig{hook}, nil, zerolog.Nop())
+			retry, err := d.post(context.Background(), hook, []byte(`{}`))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRetry, retry)
+		})
+	}
+}
+
+func TestWebhookDispatcher_RegisterWithoutHooks(t *testing.T) {
+	bus := New(1)
+	NewWebhookDispatcher(bus, nil, nil, zerolog.Nop()).Register()
+	assert.Empty(t, bus.subscribers[EventSessionCreated], "no subscriptions without webhooks")
+}
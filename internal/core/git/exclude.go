@@ -0,0 +1,91 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AddLocalExclude appends pattern to the repository's info/exclude file so
+// files hive writes into a checkout do not show up as untracked changes.
+// It is a no-op when the pattern is already listed. Worktrees share the
+// exclude file of their main repository.
+func AddLocalExclude(dir, pattern string) error {
+	gitDir, err := commonGitDir(dir)
+	if err != nil {
+		return err
+	}
+
+	excludePath := filepath.Join(gitDir, "info", "exclude")
+	existing, err := os.ReadFile(excludePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("read %s: %w", excludePath, err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(existing))
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == pattern {
+			return nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(excludePath), 0o755); err != nil {
+		return fmt.Errorf("create info dir: %w", err)
+	}
+	f, err := os.OpenFile(excludePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", excludePath, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	line := pattern + "\n"
+	if len(existing) > 0 && !bytes.HasSuffix(existing, []byte("\n")) {
+		line = "\n" + line
+	}
+	if _, err := f.WriteString(line); err != nil {
+		return fmt.Errorf("write %s: %w", excludePath, err)
+	}
+	return nil
+}
+
+// commonGitDir returns the git directory shared by all worktrees of the
+// checkout at dir. A linked worktree has a .git file pointing at its
+// per-worktree git dir, whose commondir file points back at the main one.
+func commonGitDir(dir string) (string, error) {
+	dotGit := filepath.Join(dir, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return "", fmt.Errorf("stat .git: %w", err)
+	}
+	if info.IsDir() {
+		return dotGit, nil
+	}
+
+	content, err := os.ReadFile(dotGit)
+	if err != nil {
+		return "", fmt.Errorf("read .git: %w", err)
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir:")
+	if !ok {
+		return "", fmt.Errorf("unrecognized .git file in %s", dir)
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(dir, gitDir)
+	}
+
+	common, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if os.IsNotExist(err) {
+		return gitDir, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("read commondir: %w", err)
+	}
+	commonDir := strings.TrimSpace(string(common))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(gitDir, commonDir)
+	}
+	return filepath.Clean(commonDir), nil
+}
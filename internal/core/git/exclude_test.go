@@ -0,0 +1,44 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddLocalExclude(t *testing.T) {
+	dir := t.TempDir()
+	infoDir := filepath.Join(dir, ".git", "info")
+	require.NoError(t, os.MkdirAll(infoDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(infoDir, "exclude"), []byte("# comment\n*.log"), 0o644))
+
+	require.NoError(t, AddLocalExclude(dir, ".hive-session.md"))
+	require.NoError(t, AddLocalExclude(dir, ".hive-session.md"), "adding twice is a no-op")
+
+	got, err := os.ReadFile(filepath.Join(infoDir, "exclude"))
+	require.NoError(t, err)
+	assert.Equal(t, "# comment\n*.log\n.hive-session.md\n", string(got))
+}
+
+func TestAddLocalExclude_Worktree(t *testing.T) {
+	bare := t.TempDir()
+	wtGitDir := filepath.Join(bare, "worktrees", "wt")
+	require.NoError(t, os.MkdirAll(wtGitDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(wtGitDir, "commondir"), []byte("../..\n"), 0o644))
+
+	wt := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(wt, ".git"), []byte("gitdir: "+wtGitDir+"\n"), 0o644))
+
+	require.NoError(t, AddLocalExclude(wt, ".hive-session.md"))
+
+	got, err := os.ReadFile(filepath.Join(bare, "info", "exclude"))
+	require.NoError(t, err, "worktrees share the main repository's exclude file")
+	assert.Equal(t, ".hive-session.md\n", string(got))
+}
+
+func TestAddLocalExclude_NotARepo(t *testing.T) {
+	require.Error(t, AddLocalExclude(t.TempDir(), ".hive-session.md"))
+}
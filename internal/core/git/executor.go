@@ -2,15 +2,40 @@ package git
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/colonyops/hive/pkg/executil"
 )
 
-// Executor implements Git using the git command-line tool.
+// ErrBranchInUse is returned when a worktree branch is not deleted because
+// another worktree has it checked out.
+var ErrBranchInUse = errors.New("branch is checked out in another worktree")
+
+// Error is returned when a git command run by Executor, or a jj command run
+// by JJExecutor, fails.
+type Error struct {
+	Tool string // command that failed; empty means git
+	Op   string // subcommand and arguments, e.g. "clone" or "checkout main"
+	Err  error
+}
+
+func (e *Error) Error() string {
+	tool := e.Tool
+	if tool == "" {
+		tool = "git"
+	}
+	return tool + " " + e.Op + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Executor implements VCS using the git command-line tool.
 type Executor struct {
 	gitPath string
 	exec    executil.Executor
@@ -23,28 +48,28 @@ func NewExecutor(gitPath string, exec executil.Executor) *Executor {
 
 func (e *Executor) Clone(ctx context.Context, url, dest string) error {
 	if _, err := e.exec.Run(ctx, e.gitPath, "clone", url, dest); err != nil {
-		return fmt.Errorf("git clone: %w", err)
+		return &Error{Op: "clone", Err: err}
 	}
 	return nil
 }
 
 func (e *Executor) Checkout(ctx context.Context, dir, branch string) error {
 	if _, err := e.exec.RunDir(ctx, dir, e.gitPath, "checkout", branch); err != nil {
-		return fmt.Errorf("git checkout %s: %w", branch, err)
+		return &Error{Op: "checkout " + branch, Err: err}
 	}
 	return nil
 }
 
 func (e *Executor) Pull(ctx context.Context, dir string) error {
 	if _, err := e.exec.RunDir(ctx, dir, e.gitPath, "pull"); err != nil {
-		return fmt.Errorf("git pull: %w", err)
+		return &Error{Op: "pull", Err: err}
 	}
 	return nil
 }
 
 func (e *Executor) ResetHard(ctx context.Context, dir string) error {
 	if _, err := e.exec.RunDir(ctx, dir, e.gitPath, "reset", "--hard"); err != nil {
-		return fmt.Errorf("git reset --hard: %w", err)
+		return &Error{Op: "reset --hard", Err: err}
 	}
 	return nil
 }
@@ -52,7 +77,7 @@ func (e *Executor) ResetHard(ctx context.Context, dir string) error {
 func (e *Executor) RemoteURL(ctx context.Context, dir string) (string, error) {
 	out, err := e.exec.RunDir(ctx, dir, e.gitPath, "--no-optional-locks", "remote", "get-url", "origin")
 	if err != nil {
-		return "", fmt.Errorf("git remote get-url: %w", err)
+		return "", &Error{Op: "remote get-url", Err: err}
 	}
 	return strings.TrimSpace(string(out)), nil
 }
@@ -60,16 +85,32 @@ func (e *Executor) RemoteURL(ctx context.Context, dir string) (string, error) {
 func (e *Executor) IsClean(ctx context.Context, dir string) (bool, error) {
 	out, err := e.exec.RunDir(ctx, dir, e.gitPath, "--no-optional-locks", "status", "--porcelain")
 	if err != nil {
-		return false, fmt.Errorf("git status: %w", err)
+		return false, &Error{Op: "status", Err: err}
 	}
 	return len(strings.TrimSpace(string(out))) == 0, nil
 }
 
+func (e *Executor) ChangedFiles(ctx context.Context, dir string) ([]string, error) {
+	out, err := e.exec.RunDir(ctx, dir, e.gitPath, "--no-optional-locks", "status", "--porcelain")
+	if err != nil {
+		return nil, &Error{Op: "status", Err: err}
+	}
+	var files []string
+	for line := range strings.SplitSeq(strings.TrimRight(string(out), "\n"), "\n") {
+		// Each line is a two-letter status, a space, and the path; renames
+		// are listed as "old -> new".
+		if len(line) > 3 {
+			files = append(files, line[3:])
+		}
+	}
+	return files, nil
+}
+
 func (e *Executor) Branch(ctx context.Context, dir string) (string, error) {
 	// Try to get branch name first
 	out, err := e.exec.RunDir(ctx, dir, e.gitPath, "--no-optional-locks", "branch", "--show-current")
 	if err != nil {
-		return "", fmt.Errorf("git branch: %w", err)
+		return "", &Error{Op: "branch", Err: err}
 	}
 
 	branch := strings.TrimSpace(string(out))
@@ -80,7 +121,7 @@ func (e *Executor) Branch(ctx context.Context, dir string) (string, error) {
 	// Empty branch name means detached HEAD - get short commit SHA
 	out, err = e.exec.RunDir(ctx, dir, e.gitPath, "--no-optional-locks", "rev-parse", "--short", "HEAD")
 	if err != nil {
-		return "", fmt.Errorf("git rev-parse: %w", err)
+		return "", &Error{Op: "rev-parse", Err: err}
 	}
 
 	return strings.TrimSpace(string(out)), nil
@@ -96,16 +137,16 @@ func (e *Executor) DefaultBranch(ctx context.Context, dir string) (string, error
 		// dir's HEAD is the checked-out branch, not the default branch.
 		commonDir, cerr := e.exec.RunDir(ctx, dir, e.gitPath, "--no-optional-locks", "rev-parse", "--path-format=absolute", "--git-common-dir")
 		if cerr != nil {
-			return "", fmt.Errorf("git symbolic-ref: %w", err)
+			return "", &Error{Op: "symbolic-ref", Err: err}
 		}
 		bareDir := strings.TrimSpace(string(commonDir))
 		bare, berr := e.exec.RunDir(ctx, bareDir, e.gitPath, "--no-optional-locks", "rev-parse", "--is-bare-repository")
 		if berr != nil || strings.TrimSpace(string(bare)) != "true" {
-			return "", fmt.Errorf("git symbolic-ref: %w", err)
+			return "", &Error{Op: "symbolic-ref", Err: err}
 		}
 		head, herr := e.exec.RunDir(ctx, bareDir, e.gitPath, "--no-optional-locks", "symbolic-ref", "HEAD", "--short")
 		if herr != nil {
-			return "", fmt.Errorf("git symbolic-ref: %w", err)
+			return "", &Error{Op: "symbolic-ref", Err: err}
 		}
 		return strings.TrimSpace(string(head)), nil
 	}
@@ -135,7 +176,7 @@ func (e *Executor) DiffStats(ctx context.Context, dir string) (additions, deleti
 	}
 
 	if err != nil {
-		return 0, 0, fmt.Errorf("git diff: %w", err)
+		return 0, 0, &Error{Op: "diff", Err: err}
 	}
 
 	return parseDiffStats(string(out))
@@ -194,7 +235,7 @@ func (e *Executor) IsValidRepo(ctx context.Context, dir string) error {
 	}
 
 	if _, err := e.exec.RunDir(ctx, dir, e.gitPath, "--no-optional-locks", "rev-parse", "--git-dir"); err != nil {
-		return fmt.Errorf("git rev-parse failed: %w", err)
+		return &Error{Op: "rev-parse failed", Err: err}
 	}
 
 	return nil
@@ -202,46 +243,177 @@ func (e *Executor) IsValidRepo(ctx context.Context, dir string) error {
 
 func (e *Executor) CloneBare(ctx context.Context, url, dest string) error {
 	if _, err := e.exec.Run(ctx, e.gitPath, "clone", "--bare", url, dest); err != nil {
-		return fmt.Errorf("git clone --bare: %w", err)
+		return &Error{Op: "clone --bare", Err: err}
 	}
 	return nil
 }
 
 func (e *Executor) WorktreeAdd(ctx context.Context, repoDir, path, branch string) error {
 	if _, err := e.exec.RunDir(ctx, repoDir, e.gitPath, "worktree", "add", "-b", branch, path); err != nil {
-		return fmt.Errorf("git worktree add: %w", err)
+		return &Error{Op: "worktree add", Err: err}
 	}
 	return nil
 }
 
+// WorktreeRemove removes the worktree at path, even if it is locked, and
+// deletes branch. A worktree whose directory is already gone is pruned
+// instead. The branch is kept, with an ErrBranchInUse error, when another
+// worktree has it checked out.
 func (e *Executor) WorktreeRemove(ctx context.Context, repoDir, path, branch string) error {
-	var errs []string
-	if _, err := e.exec.RunDir(ctx, repoDir, e.gitPath, "worktree", "remove", "--force", path); err != nil {
-		errs = append(errs, fmt.Sprintf("git worktree remove: %v", err))
+	var errs []error
+	// A second --force removes locked worktrees too.
+	if _, err := e.exec.RunDir(ctx, repoDir, e.gitPath, "worktree", "remove", "--force", "--force", path); err != nil {
+		if _, serr := os.Stat(path); !os.IsNotExist(serr) {
+			errs = append(errs, &Error{Op: "worktree remove", Err: err})
+		} else if err := e.WorktreePrune(ctx, repoDir); err != nil {
+			errs = append(errs, err)
+		}
 	}
-	if branch != "" {
-		if _, err := e.exec.RunDir(ctx, repoDir, e.gitPath, "branch", "-D", branch); err != nil {
-			errs = append(errs, fmt.Sprintf("git branch -D: %v", err))
+	if branch != "" && len(errs) == 0 {
+		if err := e.deleteBranch(ctx, repoDir, branch); err != nil {
+			errs = append(errs, err)
 		}
 	}
-	if len(errs) > 0 {
-		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	return errors.Join(errs...)
+}
+
+// WorktreePrune forgets worktrees of repoDir whose directories were removed.
+func (e *Executor) WorktreePrune(ctx context.Context, repoDir string) error {
+	if _, err := e.exec.RunDir(ctx, repoDir, e.gitPath, "worktree", "prune"); err != nil {
+		return &Error{Op: "worktree prune", Err: err}
+	}
+	return nil
+}
+
+// deleteBranch deletes branch from repoDir unless a worktree has it checked
+// out, which git refuses with a less helpful error.
+func (e *Executor) deleteBranch(ctx context.Context, repoDir, branch string) error {
+	out, err := e.exec.RunDir(ctx, repoDir, e.gitPath, "worktree", "list", "--porcelain")
+	if err != nil {
+		return &Error{Op: "worktree list", Err: err}
+	}
+	for line := range strings.Lines(string(out)) {
+		if strings.TrimSpace(line) == "branch refs/heads/"+branch {
+			return fmt.Errorf("%w: %s", ErrBranchInUse, branch)
+		}
+	}
+	if _, err := e.exec.RunDir(ctx, repoDir, e.gitPath, "branch", "-D", branch); err != nil {
+		return &Error{Op: "branch -D " + branch, Err: err}
+	}
+	return nil
+}
+
+func (e *Executor) StartBranch(ctx context.Context, dir, branch, base string) error {
+	if base == "" {
+		var err error
+		if base, err = e.DefaultBranch(ctx, dir); err != nil {
+			return err
+		}
+	}
+	if _, err := e.exec.RunDir(ctx, dir, e.gitPath, "fetch", "--no-tags", "origin", base); err != nil {
+		return &Error{Op: "fetch origin " + base, Err: err}
+	}
+	if _, err := e.exec.RunDir(ctx, dir, e.gitPath, "checkout", "-B", branch, "FETCH_HEAD"); err != nil {
+		return &Error{Op: "checkout -B " + branch, Err: err}
 	}
 	return nil
 }
 
-func (e *Executor) HasUnpushedCommits(ctx context.Context, dir string) (bool, error) {
+func (e *Executor) ForkBranch(ctx context.Context, dir, srcDir, branch string) error {
+	if _, err := e.exec.RunDir(ctx, dir, e.gitPath, "fetch", "--no-tags", srcDir, "HEAD"); err != nil {
+		return &Error{Op: "fetch " + srcDir, Err: err}
+	}
+	if _, err := e.exec.RunDir(ctx, dir, e.gitPath, "checkout", "-B", branch, "FETCH_HEAD"); err != nil {
+		return &Error{Op: "checkout -B " + branch, Err: err}
+	}
+	return nil
+}
+
+// maxCompareCommits caps the commits listed on each side by Compare.
+const maxCompareCommits = 100
+
+func (e *Executor) Compare(ctx context.Context, dir, otherDir string) (Divergence, error) {
+	if _, err := e.exec.RunDir(ctx, dir, e.gitPath, "fetch", "--no-tags", otherDir, "HEAD"); err != nil {
+		return Divergence{}, &Error{Op: "fetch " + otherDir, Err: err}
+	}
+
+	var d Divergence
+	var err error
+	if d.Ahead, err = e.logRange(ctx, dir, "FETCH_HEAD..HEAD"); err != nil {
+		return Divergence{}, err
+	}
+	if d.Behind, err = e.logRange(ctx, dir, "HEAD..FETCH_HEAD"); err != nil {
+		return Divergence{}, err
+	}
+
+	out, err := e.exec.RunDir(ctx, dir, e.gitPath, "--no-optional-locks", "diff", "--shortstat", "HEAD", "FETCH_HEAD")
+	if err != nil {
+		return Divergence{}, &Error{Op: "diff", Err: err}
+	}
+	if d.Additions, d.Deletions, err = parseDiffStats(string(out)); err != nil {
+		return Divergence{}, err
+	}
+	return d, nil
+}
+
+// logRange lists the commits in a revision range as hash/subject pairs.
+func (e *Executor) logRange(ctx context.Context, dir, revRange string) ([]Commit, error) {
+	out, err := e.exec.RunDir(ctx, dir, e.gitPath, "--no-optional-locks", "log",
+		"--max-count="+strconv.Itoa(maxCompareCommits), "--format=%h%x09%s", revRange)
+	if err != nil {
+		return nil, &Error{Op: "log " + revRange, Err: err}
+	}
+
+	var commits []Commit
+	for line := range strings.SplitSeq(strings.TrimSpace(string(out)), "\n") {
+		if line == "" {
+			continue
+		}
+		hash, subject, _ := strings.Cut(line, "\t")
+		commits = append(commits, Commit{Hash: hash, Subject: subject})
+	}
+	return commits, nil
+}
+
+func (e *Executor) HeadStatus(ctx context.Context, dir string) (HeadStatus, error) {
+	out, err := e.exec.RunDir(ctx, dir, e.gitPath, "--no-optional-locks", "log", "-1", "--format=%ct%x09%s")
+	if err != nil {
+		return HeadStatus{}, &Error{Op: "log -1", Err: err}
+	}
+	var hs HeadStatus
+	ts, subject, _ := strings.Cut(strings.TrimSpace(string(out)), "\t")
+	if secs, err := strconv.ParseInt(ts, 10, 64); err == nil {
+		hs.CommittedAt = time.Unix(secs, 0)
+	}
+	hs.Subject = subject
+
+	// Fails when the branch has no upstream, which is common for a session
+	// branch that was never pushed.
+	out, err = e.exec.RunDir(ctx, dir, e.gitPath, "--no-optional-locks", "rev-list", "--left-right", "--count", "HEAD...@{upstream}")
+	if err != nil {
+		return hs, nil
+	}
+	ahead, behind, ok := strings.Cut(strings.TrimSpace(string(out)), "\t")
+	if !ok {
+		return hs, nil
+	}
+	hs.HasUpstream = true
+	hs.Ahead, _ = parseInt(ahead)
+	hs.Behind, _ = parseInt(behind)
+	return hs, nil
+}
+
+func (e *Executor) UnpushedCommits(ctx context.Context, dir string) (int, error) {
 	// Try the upstream tracking branch first (set via "git push -u" or "git branch --set-upstream-to").
 	out, err := e.exec.RunDir(ctx, dir, e.gitPath, "--no-optional-locks", "rev-list", "--count", "@{upstream}..HEAD")
 	if err == nil {
-		n, _ := parseInt(strings.TrimSpace(string(out)))
-		return n > 0, nil
+		return parseInt(strings.TrimSpace(string(out)))
 	}
 
 	// No upstream — fall back to comparing against origin/<default branch>.
 	defaultBranch, err := e.DefaultBranch(ctx, dir)
 	if err != nil {
-		return false, fmt.Errorf("get default branch: %w", err)
+		return 0, fmt.Errorf("get default branch: %w", err)
 	}
 
 	out, err = e.exec.RunDir(ctx, dir, e.gitPath, "--no-optional-locks", "rev-list", "--count", "origin/"+defaultBranch+"..HEAD")
@@ -250,18 +422,17 @@ func (e *Executor) HasUnpushedCommits(ctx context.Context, dir string) (bool, er
 		// local default branch mirrors the remote, so compare against it instead.
 		out, err = e.exec.RunDir(ctx, dir, e.gitPath, "--no-optional-locks", "rev-list", "--count", defaultBranch+"..HEAD")
 		if err != nil {
-			return false, fmt.Errorf("rev-list unpushed: %w", err)
+			return 0, &Error{Op: "rev-list unpushed", Err: err}
 		}
 	}
 
-	n, _ := parseInt(strings.TrimSpace(string(out)))
-	return n > 0, nil
+	return parseInt(strings.TrimSpace(string(out)))
 }
 
 func (e *Executor) Fetch(ctx context.Context, dir string) error {
 	out, err := e.exec.RunDir(ctx, dir, e.gitPath, "--no-optional-locks", "rev-parse", "--is-bare-repository")
 	if err != nil {
-		return fmt.Errorf("git rev-parse --is-bare-repository: %w", err)
+		return &Error{Op: "rev-parse --is-bare-repository", Err: err}
 	}
 
 	args := []string{"fetch", "origin"}
@@ -274,7 +445,7 @@ func (e *Executor) Fetch(ctx context.Context, dir string) error {
 		args = append(args, "+refs/heads/"+branch+":refs/heads/"+branch, "--prune")
 	}
 	if _, err := e.exec.RunDir(ctx, dir, e.gitPath, args...); err != nil {
-		return fmt.Errorf("git fetch: %w", err)
+		return &Error{Op: "fetch", Err: err}
 	}
 	return nil
 }
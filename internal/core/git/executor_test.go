@@ -2,10 +2,13 @@ package git
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -203,13 +206,35 @@ func TestExecutor_DefaultBranch(t *testing.T) {
 
 func TestExecutor_WorktreeRemove(t *testing.T) {
 	tests := []struct {
-		name       string
-		branch     string
-		wantCalls  int
-		wantBranch bool
+		name      string
+		branch    string
+		worktrees string // git worktree list --porcelain output
+		wantCalls [][]string
+		wantErr   error
 	}{
-		{name: "removes worktree and branch", branch: "hive/session", wantCalls: 2, wantBranch: true},
-		{name: "removes worktree without branch metadata", wantCalls: 1},
+		{
+			name:   "removes worktree and branch",
+			branch: "hive/session",
+			wantCalls: [][]string{
+				{"worktree", "remove", "--force", "--force", "/worktree"},
+				{"worktree", "list", "--porcelain"},
+				{"branch", "-D", "hive/session"},
+			},
+		},
+		{
+			name:      "removes worktree without branch metadata",
+			wantCalls: [][]string{{"worktree", "remove", "--force", "--force", "/worktree"}},
+		},
+		{
+			name:      "keeps branch checked out in another worktree",
+			branch:    "hive/session",
+			worktrees: "worktree /bare\nbare\n\nworktree /other\nHEAD abc123\nbranch refs/heads/hive/session\n",
+			wantCalls: [][]string{
+				{"worktree", "remove", "--force", "--force", "/worktree"},
+				{"worktree", "list", "--porcelain"},
+			},
+			wantErr: ErrBranchInUse,
+		},
 	}
 
 	for _, tt := range tests {
@@ -220,6 +245,9 @@ func TestExecutor_WorktreeRemove(t *testing.T) {
 					require.Equal(t, "/bare", dir)
 					require.Equal(t, "git", cmd)
 					calls = append(calls, args)
+					if args[0] == "worktree" && args[1] == "list" {
+						return []byte(tt.worktrees), nil
+					}
 					return nil, nil
 				},
 			}
@@ -227,16 +255,200 @@ func TestExecutor_WorktreeRemove(t *testing.T) {
 			e := NewExecutor("git", mock)
 			err := e.WorktreeRemove(context.Background(), "/bare", "/worktree", tt.branch)
 
-			require.NoError(t, err)
-			require.Len(t, calls, tt.wantCalls)
-			assert.Equal(t, []string{"worktree", "remove", "--force", "/worktree"}, calls[0])
-			if tt.wantBranch {
-				assert.Equal(t, []string{"branch", "-D", tt.branch}, calls[1])
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestExecutor_WorktreeRemove_MissingDirectory(t *testing.T) {
+	var calls [][]string
+	mock := &mockExecutor{
+		runDirFunc: func(_ context.Context, _, _ string, args ...string) ([]byte, error) {
+			calls = append(calls, args)
+			if args[1] == "remove" {
+				return nil, errors.New("validation failed, cannot remove working tree")
+			}
+			return nil, nil
+		},
+	}
+
+	e := NewExecutor("git", mock)
+	missing := filepath.Join(t.TempDir(), "gone")
+	require.NoError(t, e.WorktreeRemove(context.Background(), "/bare", missing, "hive/session"))
+	assert.Equal(t, [][]string{
+		{"worktree", "remove", "--force", "--force", missing},
+		{"worktree", "prune"},
+		{"worktree", "list", "--porcelain"},
+		{"branch", "-D", "hive/session"},
+	}, calls, "a worktree whose directory is gone is pruned")
+}
+
+func TestExecutor_StartBranch(t *testing.T) {
+	var calls [][]string
+	mock := &mockExecutor{
+		runDirFunc: func(_ context.Context, dir, cmd string, args ...string) ([]byte, error) {
+			require.Equal(t, "/clone", dir)
+			calls = append(calls, args)
+			if args[len(args)-2] == "refs/remotes/origin/HEAD" {
+				return []byte("origin/main\n"), nil
+			}
+			return nil, nil
+		},
+	}
+
+	e := NewExecutor("git", mock)
+	require.NoError(t, e.StartBranch(context.Background(), "/clone", "agent/auth", "develop"))
+	assert.Equal(t, [][]string{
+		{"fetch", "--no-tags", "origin", "develop"},
+		{"checkout", "-B", "agent/auth", "FETCH_HEAD"},
+	}, calls)
+
+	calls = nil
+	require.NoError(t, e.StartBranch(context.Background(), "/clone", "agent/auth", ""))
+	assert.Equal(t, []string{"fetch", "--no-tags", "origin", "main"}, calls[len(calls)-2], "an empty base is the default branch")
+}
+
+func TestExecutor_ForkBranch(t *testing.T) {
+	var calls [][]string
+	mock := &mockExecutor{
+		runDirFunc: func(_ context.Context, dir, cmd string, args ...string) ([]byte, error) {
+			require.Equal(t, "/clone", dir)
+			require.Equal(t, "git", cmd)
+			calls = append(calls, args)
+			return nil, nil
+		},
+	}
+
+	e := NewExecutor("git", mock)
+	require.NoError(t, e.ForkBranch(context.Background(), "/clone", "/source", "hive/variant"))
+	assert.Equal(t, [][]string{
+		{"fetch", "--no-tags", "/source", "HEAD"},
+		{"checkout", "-B", "hive/variant", "FETCH_HEAD"},
+	}, calls)
+}
+
+func TestExecutor_Compare(t *testing.T) {
+	mock := &mockExecutor{
+		runDirFunc: func(_ context.Context, dir, _ string, args ...string) ([]byte, error) {
+			require.Equal(t, "/a", dir)
+			switch args[len(args)-1] {
+			case "HEAD":
+				assert.Equal(t, []string{"fetch", "--no-tags", "/b", "HEAD"}, args)
+				return nil, nil
+			case "FETCH_HEAD..HEAD":
+				return []byte("abc1234\tUse JWT\ndef5678\tAdd login\n"), nil
+			case "HEAD..FETCH_HEAD":
+				return nil, nil
+			case "FETCH_HEAD":
+				assert.Equal(t, []string{"--no-optional-locks", "diff", "--shortstat", "HEAD", "FETCH_HEAD"}, args)
+				return []byte(" 2 files changed, 12 insertions(+), 3 deletions(-)\n"), nil
 			}
+			return nil, fmt.Errorf("unexpected args %v", args)
+		},
+	}
+
+	d, err := NewExecutor("git", mock).Compare(context.Background(), "/a", "/b")
+	require.NoError(t, err)
+	assert.Equal(t, []Commit{{Hash: "abc1234", Subject: "Use JWT"}, {Hash: "def5678", Subject: "Add login"}}, d.Ahead)
+	assert.Empty(t, d.Behind)
+	assert.Equal(t, 12, d.Additions)
+	assert.Equal(t, 3, d.Deletions)
+}
+
+func TestExecutor_HeadStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		upstreamOut string
+		upstreamErr error
+		want        HeadStatus
+	}{
+		{
+			name:        "ahead and behind upstream",
+			upstreamOut: "2\t1\n",
+			want:        HeadStatus{Subject: "Add login", CommittedAt: time.Unix(1700000000, 0), HasUpstream: true, Ahead: 2, Behind: 1},
+		},
+		{
+			name:        "no upstream",
+			upstreamErr: errors.New("fatal: no upstream configured"),
+			want:        HeadStatus{Subject: "Add login", CommittedAt: time.Unix(1700000000, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockExecutor{
+				runDirFunc: func(_ context.Context, _, _ string, args ...string) ([]byte, error) {
+					switch args[1] {
+					case "log":
+						return []byte("1700000000\tAdd login\n"), nil
+					case "rev-list":
+						assert.Equal(t, []string{"--no-optional-locks", "rev-list", "--left-right", "--count", "HEAD...@{upstream}"}, args)
+						return []byte(tt.upstreamOut), tt.upstreamErr
+					}
+					return nil, fmt.Errorf("unexpected args %v", args)
+				},
+			}
+
+			got, err := NewExecutor("git", mock).HeadStatus(context.Background(), "/test/dir")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
+func TestExecutor_ChangedFiles(t *testing.T) {
+	mock := &mockExecutor{
+		runDirFunc: func(_ context.Context, _, _ string, args ...string) ([]byte, error) {
+			assert.Equal(t, []string{"--no-optional-locks", "status", "--porcelain"}, args)
+			return []byte(" M main.go\n?? docs/new file.md\nR  old.go -> new.go\n"), nil
+		},
+	}
+
+	files, err := NewExecutor("git", mock).ChangedFiles(context.Background(), "/test/dir")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main.go", "docs/new file.md", "old.go -> new.go"}, files)
+}
+
+func TestExecutor_UnpushedCommits(t *testing.T) {
+	t.Run("counts commits ahead of upstream", func(t *testing.T) {
+		mock := &mockExecutor{
+			runDirFunc: func(_ context.Context, _, _ string, args ...string) ([]byte, error) {
+				assert.Equal(t, []string{"--no-optional-locks", "rev-list", "--count", "@{upstream}..HEAD"}, args)
+				return []byte("3\n"), nil
+			},
+		}
+
+		n, err := NewExecutor("git", mock).UnpushedCommits(context.Background(), "/test/dir")
+		require.NoError(t, err)
+		assert.Equal(t, 3, n)
+	})
+
+	t.Run("falls back to the default branch", func(t *testing.T) {
+		mock := &mockExecutor{
+			runDirFunc: func(_ context.Context, _, _ string, args ...string) ([]byte, error) {
+				switch args[len(args)-1] {
+				case "@{upstream}..HEAD":
+					return nil, errors.New("fatal: no upstream configured")
+				case "--short":
+					return []byte("origin/main\n"), nil
+				case "origin/main..HEAD":
+					return []byte("0\n"), nil
+				}
+				return nil, fmt.Errorf("unexpected args %v", args)
+			},
+		}
+
+		n, err := NewExecutor("git", mock).UnpushedCommits(context.Background(), "/test/dir")
+		require.NoError(t, err)
+		assert.Zero(t, n)
+	})
+}
+
 func TestExecutor_Fetch(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -1,14 +1,18 @@
-// Package git provides an abstraction for git operations.
+// Package git provides an abstraction for version control operations, with
+// backends for git and Jujutsu (jj).
 package git
 
 import (
 	"context"
 	"net/url"
 	"strings"
+	"time"
 )
 
-// Git defines git operations needed by hive.
-type Git interface {
+// VCS defines the version control operations needed by hive. Executor
+// implements it with git and JJExecutor with Jujutsu; methods are phrased in
+// git terms and each backend maps them to its own model.
+type VCS interface {
 	// Clone clones a repository from url to dest.
 	Clone(ctx context.Context, url, dest string) error
 	// Checkout switches to the specified branch in dir.
@@ -35,27 +39,76 @@ type Git interface {
 	WorktreeAdd(ctx context.Context, repoDir, path, branch string) error
 	// WorktreeRemove removes the worktree at path and deletes branch from repoDir when provided.
 	WorktreeRemove(ctx context.Context, repoDir, path, branch string) error
+	// WorktreePrune forgets worktrees of repoDir whose directories were removed
+	// without WorktreeRemove.
+	WorktreePrune(ctx context.Context, repoDir string) error
 	// Fetch fetches all remotes in dir.
 	Fetch(ctx context.Context, dir string) error
-	// HasUnpushedCommits returns true if there are local commits not yet pushed to a remote.
+	// StartBranch fetches base from the remote and checks out branch in dir at
+	// it, creating or resetting the branch. An empty base means the default
+	// branch.
+	StartBranch(ctx context.Context, dir, branch, base string) error
+	// ForkBranch checks out branch in dir at the HEAD commit of srcDir, another
+	// checkout of the same repository, creating or resetting the branch.
+	ForkBranch(ctx context.Context, dir, srcDir, branch string) error
+	// Compare reports how the HEAD of dir and the HEAD of otherDir, another
+	// checkout of the same repository, have diverged.
+	Compare(ctx context.Context, dir, otherDir string) (Divergence, error)
+	// HeadStatus returns the subject and date of the HEAD commit of dir, and
+	// how far its branch is ahead of and behind the upstream branch it tracks.
+	// A branch without an upstream is not an error.
+	HeadStatus(ctx context.Context, dir string) (HeadStatus, error)
+	// UnpushedCommits returns the number of local commits not yet pushed to a remote.
 	// It first checks the upstream tracking branch; if none is set, it falls back to comparing
-	// against origin/<default branch>. Returns false (no risk) on any git error.
-	HasUnpushedCommits(ctx context.Context, dir string) (bool, error)
+	// against origin/<default branch>.
+	UnpushedCommits(ctx context.Context, dir string) (int, error)
+	// ChangedFiles returns the paths in dir with uncommitted changes.
+	ChangedFiles(ctx context.Context, dir string) ([]string, error)
+}
+
+// Commit is a one-line commit summary.
+type Commit struct {
+	Hash    string `json:"hash"`
+	Subject string `json:"subject"`
+}
+
+// Divergence describes two checkouts' HEADs relative to each other.
+type Divergence struct {
+	Ahead     []Commit `json:"ahead"`  // commits only in the first checkout, newest first
+	Behind    []Commit `json:"behind"` // commits only in the other checkout, newest first
+	Additions int      `json:"additions"`
+	Deletions int      `json:"deletions"`
+}
+
+// HeadStatus describes the checked out commit and how its branch compares to
+// its upstream.
+type HeadStatus struct {
+	Subject     string    `json:"subject"`      // subject line of the HEAD commit
+	CommittedAt time.Time `json:"committed_at"` // committer date of the HEAD commit
+	HasUpstream bool      `json:"has_upstream"` // the branch tracks an upstream branch
+	Ahead       int       `json:"ahead"`        // commits on HEAD not in the upstream
+	Behind      int       `json:"behind"`       // commits in the upstream not on HEAD
 }
 
 // ExtractRepoName extracts the repository name from a git remote URL.
 // Handles both SSH (git@github.com:user/repo.git) and HTTPS (https://github.com/user/repo.git) formats.
 // RemoteIdentity returns a stable identity suitable for matching remotes.
 // GitHub's HTTPS and SSH spellings identify the same repository, so they map
-// to github.com/<owner>/<repo>. Other remotes deliberately retain their exact
-// (trimmed) spelling: a local path or a non-GitHub forge URL is an explicit
-// user choice and must not be conflated with another transport.
+// to github.com/<owner>/<repo>; Azure DevOps and Bitbucket Cloud remotes map
+// to <host>/<owner>/<repo> the same way. Other remotes deliberately retain
+// their exact (trimmed) spelling: a local path or a self-hosted forge URL is
+// an explicit user choice and must not be conflated with another transport.
 func RemoteIdentity(remote string) string {
 	remote = strings.TrimSpace(remote)
 	if remote == "" {
 		return ""
 	}
 
+	// Both forges treat owner and repo names case-insensitively.
+	if r, ok := ParseRemote(remote); ok && (r.Forge == ForgeAzureDevOps || r.Host == "bitbucket.org") {
+		return strings.ToLower(r.Host + "/" + r.Owner + "/" + r.Repo)
+	}
+
 	owner, repo, ok := githubOwnerRepo(remote)
 	if !ok {
 		return remote
@@ -109,9 +162,13 @@ func githubPath(path string) (owner, repo string, ok bool) {
 }
 
 func ExtractRepoName(remote string) string {
+	if r, ok := ParseRemote(remote); ok {
+		return r.Repo
+	}
 	remote = strings.TrimSuffix(remote, ".git")
 
-	if idx := strings.LastIndex(remote, "/"); idx != -1 {
+	// Local paths may use Windows separators (C:\src\repo).
+	if idx := strings.LastIndexAny(remote, `/\`); idx != -1 {
 		return remote[idx+1:]
 	}
 
@@ -128,13 +185,17 @@ func ExtractRepoName(remote string) string {
 
 // ExtractHost extracts the host (without port) from a git remote URL.
 // Handles SCP-style SSH (git@github.com:owner/repo.git), ssh:// and https://
-// URLs, and hosts with non-standard ports (git.example.com:2222). Returns an
-// empty string when no host can be determined.
+// URLs, and hosts with non-standard ports (git.example.com:2222). Azure DevOps
+// SSH and visualstudio.com remotes report dev.azure.com. Returns an empty
+// string when no host can be determined.
 func ExtractHost(remote string) string {
 	remote = strings.TrimSpace(remote)
 	if remote == "" {
 		return ""
 	}
+	if r, ok := ParseRemote(remote); ok {
+		return r.Host
+	}
 
 	// Scheme-based URLs: ssh://, https://, http://, git://.
 	if idx := strings.Index(remote, "://"); idx != -1 {
@@ -176,9 +237,14 @@ func stripPort(host string) string {
 }
 
 // ExtractOwnerRepo extracts owner and repo from a git remote URL.
-// Handles SSH (git@github.com:owner/repo.git) and HTTPS (https://github.com/owner/repo.git).
+// Handles SSH (git@github.com:owner/repo.git) and HTTPS (https://github.com/owner/repo.git),
+// and the Azure DevOps and Bitbucket layouts understood by ParseRemote, where
+// the Azure DevOps owner is "<org>/<project>".
 // Returns empty strings if parsing fails.
 func ExtractOwnerRepo(remote string) (owner, repo string) {
+	if r, ok := ParseRemote(remote); ok {
+		return r.Owner, r.Repo
+	}
 	remote = strings.TrimSuffix(remote, ".git")
 
 	// SSH format: git@github.com:owner/repo
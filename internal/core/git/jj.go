@@ -0,0 +1,285 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/colonyops/hive/pkg/executil"
+)
+
+// JJExecutor implements VCS using the Jujutsu (jj) command-line tool.
+//
+// Clones are colocated, so the checkout also contains a .git directory and
+// git-based tooling keeps working. Worktree sessions map to jj workspaces on a
+// shared (non-bare) clone, and branches map to bookmarks.
+type JJExecutor struct {
+	jjPath string
+	exec   executil.Executor
+}
+
+// NewJJExecutor creates a new jj executor with the specified jj binary path.
+func NewJJExecutor(jjPath string, exec executil.Executor) *JJExecutor {
+	return &JJExecutor{jjPath: jjPath, exec: exec}
+}
+
+// jjError wraps a failed jj command like Executor wraps git commands, so
+// callers can recognize VCS failures with errors.As.
+func jjError(op string, err error) error {
+	return &Error{Tool: "jj", Op: op, Err: err}
+}
+
+// trunkRevset resolves to the remote default branch head.
+const trunkRevset = "trunk()"
+
+func (e *JJExecutor) Clone(ctx context.Context, url, dest string) error {
+	if _, err := e.exec.Run(ctx, e.jjPath, "git", "clone", "--colocate", url, dest); err != nil {
+		return jjError("git clone", err)
+	}
+	return nil
+}
+
+func (e *JJExecutor) Checkout(ctx context.Context, dir, branch string) error {
+	if _, err := e.exec.RunDir(ctx, dir, e.jjPath, "new", branch); err != nil {
+		return jjError("new "+branch, err)
+	}
+	return nil
+}
+
+// Pull fetches and starts a new change on top of the updated trunk. Earlier
+// changes stay in the repository history rather than being merged.
+func (e *JJExecutor) Pull(ctx context.Context, dir string) error {
+	if err := e.Fetch(ctx, dir); err != nil {
+		return err
+	}
+	if _, err := e.exec.RunDir(ctx, dir, e.jjPath, "new", trunkRevset); err != nil {
+		return jjError("new "+trunkRevset, err)
+	}
+	return nil
+}
+
+func (e *JJExecutor) ResetHard(ctx context.Context, dir string) error {
+	if _, err := e.exec.RunDir(ctx, dir, e.jjPath, "restore"); err != nil {
+		return jjError("restore", err)
+	}
+	return nil
+}
+
+func (e *JJExecutor) RemoteURL(ctx context.Context, dir string) (string, error) {
+	out, err := e.exec.RunDir(ctx, dir, e.jjPath, "--ignore-working-copy", "git", "remote", "list")
+	if err != nil {
+		return "", jjError("git remote list", err)
+	}
+	for line := range strings.Lines(string(out)) {
+		name, url, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if ok && name == "origin" {
+			return strings.TrimSpace(url), nil
+		}
+	}
+	return "", fmt.Errorf("jj git remote list: no origin remote")
+}
+
+// IsClean reports whether the working-copy change is empty. jj snapshots
+// edits into the working-copy change, so "uncommitted changes" are the
+// changes it contains.
+func (e *JJExecutor) IsClean(ctx context.Context, dir string) (bool, error) {
+	out, err := e.exec.RunDir(ctx, dir, e.jjPath, "log", "-r", "@", "--no-graph", "-T", "empty")
+	if err != nil {
+		return false, jjError("log", err)
+	}
+	return strings.TrimSpace(string(out)) == "true", nil
+}
+
+// Branch returns the bookmarks on the working-copy change or its parent, or
+// the short change ID when neither has one.
+func (e *JJExecutor) Branch(ctx context.Context, dir string) (string, error) {
+	out, err := e.exec.RunDir(ctx, dir, e.jjPath, "--ignore-working-copy", "log", "-r", "@ | @-", "--no-graph",
+		"-T", `if(local_bookmarks, local_bookmarks.map(|b| b.name()).join(" ") ++ "\n")`)
+	if err != nil {
+		return "", jjError("log", err)
+	}
+	if line, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n"); line != "" {
+		return line, nil
+	}
+
+	out, err = e.exec.RunDir(ctx, dir, e.jjPath, "--ignore-working-copy", "log", "-r", "@", "--no-graph", "-T", "change_id.short()")
+	if err != nil {
+		return "", jjError("log", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func (e *JJExecutor) DefaultBranch(ctx context.Context, dir string) (string, error) {
+	out, err := e.exec.RunDir(ctx, dir, e.jjPath, "--ignore-working-copy", "log", "-r", trunkRevset, "--no-graph",
+		"-T", `remote_bookmarks.map(|b| b.name()).join("\n")`)
+	if err != nil {
+		return "", jjError("log "+trunkRevset, err)
+	}
+	branch, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	if branch == "" {
+		return "", fmt.Errorf("jj log %s: no remote bookmark", trunkRevset)
+	}
+	return branch, nil
+}
+
+func (e *JJExecutor) DiffStats(ctx context.Context, dir string) (additions, deletions int, err error) {
+	out, err := e.exec.RunDir(ctx, dir, e.jjPath, "diff", "--stat", "--from", trunkRevset, "--to", "@")
+	if err != nil {
+		return 0, 0, jjError("diff", err)
+	}
+
+	// The summary is the last line and uses git's --shortstat format.
+	summary := strings.TrimSpace(string(out))
+	if idx := strings.LastIndex(summary, "\n"); idx != -1 {
+		summary = summary[idx+1:]
+	}
+	return parseDiffStats(summary)
+}
+
+func (e *JJExecutor) IsValidRepo(ctx context.Context, dir string) error {
+	if _, err := os.Stat(filepath.Join(dir, ".jj")); os.IsNotExist(err) {
+		return fmt.Errorf(".jj directory missing")
+	}
+
+	if _, err := e.exec.RunDir(ctx, dir, e.jjPath, "--ignore-working-copy", "root"); err != nil {
+		return jjError("root", err)
+	}
+
+	return nil
+}
+
+// CloneBare clones the shared repository that worktree sessions add jj
+// workspaces to. jj has no bare clones, so this is a regular clone whose own
+// working copy is left unused.
+func (e *JJExecutor) CloneBare(ctx context.Context, url, dest string) error {
+	if _, err := e.exec.Run(ctx, e.jjPath, "git", "clone", url, dest); err != nil {
+		return jjError("git clone", err)
+	}
+	return nil
+}
+
+// WorktreeAdd adds a workspace named branch at path, starting a new change on
+// trunk with a bookmark named branch.
+func (e *JJExecutor) WorktreeAdd(ctx context.Context, repoDir, path, branch string) error {
+	if _, err := e.exec.RunDir(ctx, repoDir, e.jjPath, "workspace", "add", "--name", branch, "-r", trunkRevset, path); err != nil {
+		return jjError("workspace add", err)
+	}
+	if _, err := e.exec.RunDir(ctx, path, e.jjPath, "bookmark", "create", branch, "-r", "@"); err != nil {
+		return jjError("bookmark create", err)
+	}
+	return nil
+}
+
+// WorktreeRemove forgets the workspace named branch and deletes its bookmark.
+// Without a branch, the workspace is looked up by its default name, the base
+// name of path. jj leaves the workspace directory in place; callers remove it.
+func (e *JJExecutor) WorktreeRemove(ctx context.Context, repoDir, path, branch string) error {
+	var errs []error
+	name := branch
+	if name == "" {
+		name = filepath.Base(path)
+	}
+	if _, err := e.exec.RunDir(ctx, repoDir, e.jjPath, "workspace", "forget", name); err != nil {
+		errs = append(errs, jjError("workspace forget", err))
+	}
+	if branch != "" {
+		if _, err := e.exec.RunDir(ctx, repoDir, e.jjPath, "bookmark", "delete", branch); err != nil {
+			errs = append(errs, jjError("bookmark delete", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WorktreePrune does nothing: jj does not track workspace directories, and
+// WorktreeRemove forgets the workspace whether or not its directory remains.
+func (e *JJExecutor) WorktreePrune(_ context.Context, _ string) error {
+	return nil
+}
+
+// StartBranch fetches and starts a new change on base, or on trunk when base
+// is empty, pointing a bookmark named branch at it.
+func (e *JJExecutor) StartBranch(ctx context.Context, dir, branch, base string) error {
+	if err := e.Fetch(ctx, dir); err != nil {
+		return err
+	}
+	rev := trunkRevset
+	if base != "" {
+		rev = base + "@origin"
+	}
+	if _, err := e.exec.RunDir(ctx, dir, e.jjPath, "new", rev); err != nil {
+		return jjError("new "+rev, err)
+	}
+	if _, err := e.exec.RunDir(ctx, dir, e.jjPath, "bookmark", "set", branch, "-r", "@", "--allow-backwards"); err != nil {
+		return jjError("bookmark set", err)
+	}
+	return nil
+}
+
+// ForkBranch is not supported: jj has no equivalent of fetching another
+// checkout's HEAD by path.
+func (e *JJExecutor) ForkBranch(_ context.Context, _, _, _ string) error {
+	return fmt.Errorf("jj: forking another checkout is not supported")
+}
+
+// Compare is not supported: jj has no equivalent of fetching another
+// checkout's HEAD by path.
+func (e *JJExecutor) Compare(_ context.Context, _, _ string) (Divergence, error) {
+	return Divergence{}, fmt.Errorf("jj: comparing checkouts is not supported")
+}
+
+func (e *JJExecutor) Fetch(ctx context.Context, dir string) error {
+	if _, err := e.exec.RunDir(ctx, dir, e.jjPath, "git", "fetch"); err != nil {
+		return jjError("git fetch", err)
+	}
+	return nil
+}
+
+// HeadStatus describes the most recent non-empty change on the working
+// copy's ancestry, since the working copy change itself is usually empty.
+// Jujutsu bookmarks have no upstream in git's sense, so ahead and behind
+// counts are not reported.
+func (e *JJExecutor) HeadStatus(ctx context.Context, dir string) (HeadStatus, error) {
+	out, err := e.exec.RunDir(ctx, dir, e.jjPath, "--ignore-working-copy", "log", "-r", "latest(::@ ~ empty())",
+		"--no-graph", "-T", `committer.timestamp().format("%s") ++ "\t" ++ description.first_line()`)
+	if err != nil {
+		return HeadStatus{}, jjError("log head", err)
+	}
+	var hs HeadStatus
+	ts, subject, _ := strings.Cut(strings.TrimSpace(string(out)), "\t")
+	if secs, err := strconv.ParseInt(ts, 10, 64); err == nil {
+		hs.CommittedAt = time.Unix(secs, 0)
+	}
+	hs.Subject = subject
+	return hs, nil
+}
+
+// UnpushedCommits counts the non-empty changes between the remote bookmarks
+// and the working copy that have not been pushed.
+func (e *JJExecutor) UnpushedCommits(ctx context.Context, dir string) (int, error) {
+	out, err := e.exec.RunDir(ctx, dir, e.jjPath, "--ignore-working-copy", "log", "-r", "remote_bookmarks()..@ ~ empty()",
+		"--no-graph", "-T", `change_id.short() ++ "\n"`)
+	if err != nil {
+		return 0, jjError("log unpushed", err)
+	}
+	return len(strings.Fields(string(out))), nil
+}
+
+// ChangedFiles lists the files changed in the working-copy change.
+func (e *JJExecutor) ChangedFiles(ctx context.Context, dir string) ([]string, error) {
+	out, err := e.exec.RunDir(ctx, dir, e.jjPath, "diff", "--name-only")
+	if err != nil {
+		return nil, jjError("diff", err)
+	}
+	var files []string
+	for line := range strings.SplitSeq(strings.TrimRight(string(out), "\n"), "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
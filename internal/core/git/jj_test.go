@@ -0,0 +1,138 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJJExecutor_Branch(t *testing.T) {
+	tests := []struct {
+		name         string
+		bookmarksOut string
+		changeOut    string
+		want         string
+	}{
+		{name: "bookmark on working copy", bookmarksOut: "hive/feature\n", want: "hive/feature"},
+		{name: "bookmark on parent", bookmarksOut: "main\n", want: "main"},
+		{name: "no bookmark falls back to change id", changeOut: "kpqxywon\n", want: "kpqxywon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			mock := &mockExecutor{
+				runDirFunc: func(_ context.Context, _, cmd string, args ...string) ([]byte, error) {
+					require.Equal(t, "jj", cmd)
+					calls++
+					if calls == 1 {
+						return []byte(tt.bookmarksOut), nil
+					}
+					return []byte(tt.changeOut), nil
+				},
+			}
+
+			got, err := NewJJExecutor("jj", mock).Branch(context.Background(), "/test/dir")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJJExecutor_RemoteURL(t *testing.T) {
+	mock := &mockExecutor{
+		runDirFunc: func(_ context.Context, _, _ string, _ ...string) ([]byte, error) {
+			return []byte("upstream https://github.com/up/repo\norigin git@github.com:me/repo.git\n"), nil
+		},
+	}
+
+	got, err := NewJJExecutor("jj", mock).RemoteURL(context.Background(), "/test/dir")
+	require.NoError(t, err)
+	assert.Equal(t, "git@github.com:me/repo.git", got)
+}
+
+func TestJJExecutor_DiffStats(t *testing.T) {
+	mock := &mockExecutor{
+		runDirFunc: func(_ context.Context, _, _ string, args ...string) ([]byte, error) {
+			assert.Equal(t, []string{"diff", "--stat", "--from", "trunk()", "--to", "@"}, args)
+			return []byte("main.go | 12 ++++++++----\n1 file changed, 8 insertions(+), 4 deletions(-)\n"), nil
+		},
+	}
+
+	add, del, err := NewJJExecutor("jj", mock).DiffStats(context.Background(), "/test/dir")
+	require.NoError(t, err)
+	assert.Equal(t, 8, add)
+	assert.Equal(t, 4, del)
+}
+
+func TestJJExecutor_WorktreeAddRemove(t *testing.T) {
+	var calls []string
+	mock := &mockExecutor{
+		runDirFunc: func(_ context.Context, dir, _ string, args ...string) ([]byte, error) {
+			calls = append(calls, dir+": "+strings.Join(args, " "))
+			return nil, nil
+		},
+	}
+	e := NewJJExecutor("jj", mock)
+
+	require.NoError(t, e.WorktreeAdd(context.Background(), "/shared", "/ws", "hive/feat"))
+	require.NoError(t, e.WorktreeRemove(context.Background(), "/shared", "/ws", "hive/feat"))
+	assert.Equal(t, []string{
+		"/shared: workspace add --name hive/feat -r trunk() /ws",
+		"/ws: bookmark create hive/feat -r @",
+		"/shared: workspace forget hive/feat",
+		"/shared: bookmark delete hive/feat",
+	}, calls)
+}
+
+func TestJJExecutor_IsCleanAndUnpushed(t *testing.T) {
+	out := map[string]string{"log -r @ --no-graph -T empty": "false"}
+	mock := &mockExecutor{
+		runDirFunc: func(_ context.Context, _, _ string, args ...string) ([]byte, error) {
+			return []byte(out[strings.Join(args, " ")]), nil
+		},
+	}
+	e := NewJJExecutor("jj", mock)
+
+	clean, err := e.IsClean(context.Background(), "/test/dir")
+	require.NoError(t, err)
+	assert.False(t, clean)
+
+	unpushed, err := e.UnpushedCommits(context.Background(), "/test/dir")
+	require.NoError(t, err)
+	assert.Zero(t, unpushed, "no changes between remote bookmarks and @")
+}
+
+func TestJJExecutor_HeadStatus(t *testing.T) {
+	mock := &mockExecutor{
+		runDirFunc: func(_ context.Context, _, _ string, args ...string) ([]byte, error) {
+			assert.Equal(t, "latest(::@ ~ empty())", args[3])
+			return []byte("1700000000\tAdd login\n"), nil
+		},
+	}
+
+	got, err := NewJJExecutor("jj", mock).HeadStatus(context.Background(), "/test/dir")
+	require.NoError(t, err)
+	assert.Equal(t, HeadStatus{Subject: "Add login", CommittedAt: time.Unix(1700000000, 0)}, got)
+}
+
+func TestJJExecutor_ErrorsAreVCSErrors(t *testing.T) {
+	mock := &mockExecutor{
+		runDirFunc: func(_ context.Context, _, _ string, _ ...string) ([]byte, error) {
+			return nil, errors.New("exit status 1")
+		},
+	}
+
+	_, err := NewJJExecutor("jj", mock).IsClean(context.Background(), "/test/dir")
+	var gitErr *Error
+	require.ErrorAs(t, err, &gitErr)
+	assert.Equal(t, "jj log: exit status 1", err.Error())
+
+	err = NewJJExecutor("jj", mock).WorktreeRemove(context.Background(), "/repo", "/ws", "hive/x")
+	require.ErrorAs(t, err, &gitErr)
+}
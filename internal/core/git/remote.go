@@ -0,0 +1,151 @@
+package git
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Hosted forges whose remote URLs need layout-aware parsing.
+const (
+	ForgeAzureDevOps = "azure-devops"
+	ForgeBitbucket   = "bitbucket"
+)
+
+// azureHost is the canonical host for Azure DevOps remotes, including the
+// ssh.dev.azure.com and legacy *.visualstudio.com spellings.
+const azureHost = "dev.azure.com"
+
+// Remote is a remote URL parsed according to its forge's layout.
+type Remote struct {
+	Forge string
+	Host  string // web host, e.g. dev.azure.com for Azure DevOps SSH remotes
+	// Owner is the namespace the repo lives in: "<org>/<project>" for Azure
+	// DevOps, the workspace for Bitbucket Cloud and the project key (or
+	// ~user) for Bitbucket Server.
+	Owner string
+	Repo  string
+}
+
+// WebURL returns the repository's HTTPS URL, which is the same for every
+// transport spelling of the remote.
+func (r Remote) WebURL() string {
+	if r.Forge == ForgeAzureDevOps {
+		org, project, _ := strings.Cut(r.Owner, "/")
+		return "https://" + r.Host + "/" + org + "/" + project + "/_git/" + r.Repo
+	}
+	return "https://" + r.Host + "/" + r.Owner + "/" + r.Repo
+}
+
+// ParseRemote parses Azure DevOps and Bitbucket remotes, whose paths carry
+// more than owner/repo. It recognizes:
+//
+//	https://[user@]dev.azure.com/<org>/<project>/_git/<repo>
+//	https://<org>.visualstudio.com/[DefaultCollection/]<project>/_git/<repo>
+//	git@ssh.dev.azure.com:v3/<org>/<project>/<repo>
+//	<org>@vs-ssh.visualstudio.com:v3/<org>/<project>/<repo>
+//	https://[user@]bitbucket.org/<workspace>/<repo>.git
+//	git@bitbucket.org:<workspace>/<repo>.git
+//	https://<host>[/<context>]/scm/<project>/<repo>.git (Bitbucket Server)
+//
+// ok is false for other remotes, which callers handle generically.
+func ParseRemote(remote string) (r Remote, ok bool) {
+	host, path := splitRemote(remote)
+	if host == "" {
+		return Remote{}, false
+	}
+	host = strings.ToLower(host)
+	segments := pathSegments(path)
+
+	switch {
+	case host == azureHost || host == "ssh."+azureHost || host == "vs-ssh.visualstudio.com":
+		// SSH: v3/<org>/<project>/<repo>; HTTPS: <org>/<project>/_git/<repo>
+		if len(segments) == 4 && segments[0] == "v3" {
+			return azureRemote(segments[1], segments[2], segments[3])
+		}
+		return azureHTTPSRemote(segments)
+	case strings.HasSuffix(host, ".visualstudio.com"):
+		org := strings.TrimSuffix(host, ".visualstudio.com")
+		if len(segments) > 0 && strings.EqualFold(segments[0], "DefaultCollection") {
+			segments = segments[1:]
+		}
+		return azureHTTPSRemote(append([]string{org}, segments...))
+	case host == "bitbucket.org":
+		if len(segments) != 2 {
+			return Remote{}, false
+		}
+		return Remote{Forge: ForgeBitbucket, Host: host, Owner: segments[0], Repo: trimGitSuffix(segments[1])}, true
+	}
+
+	// Bitbucket Server / Data Center serves HTTPS clones under /scm/.
+	for i, seg := range segments {
+		if seg == "scm" && len(segments) == i+3 {
+			return Remote{Forge: ForgeBitbucket, Host: host, Owner: segments[i+1], Repo: trimGitSuffix(segments[i+2])}, true
+		}
+	}
+	return Remote{}, false
+}
+
+// azureHTTPSRemote parses <org>/<project>/_git/<repo> or <org>/_git/<repo>,
+// where the project shares the repo's name.
+func azureHTTPSRemote(segments []string) (Remote, bool) {
+	switch {
+	case len(segments) == 4 && segments[2] == "_git":
+		return azureRemote(segments[0], segments[1], segments[3])
+	case len(segments) == 3 && segments[1] == "_git":
+		return azureRemote(segments[0], segments[2], segments[2])
+	}
+	return Remote{}, false
+}
+
+func azureRemote(org, project, repo string) (Remote, bool) {
+	repo = trimGitSuffix(repo)
+	if org == "" || project == "" || repo == "" {
+		return Remote{}, false
+	}
+	return Remote{Forge: ForgeAzureDevOps, Host: azureHost, Owner: org + "/" + project, Repo: repo}, true
+}
+
+// splitRemote returns the host (without user or port) and path of a
+// scheme-based or SCP-style remote.
+func splitRemote(remote string) (host, path string) {
+	remote = strings.TrimSpace(remote)
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil {
+			return "", ""
+		}
+		return u.Hostname(), u.Path
+	}
+
+	// SCP-style: [user@]host:path
+	if at := strings.LastIndex(remote, "@"); at != -1 {
+		remote = remote[at+1:]
+	}
+	host, path, ok := strings.Cut(remote, ":")
+	if !ok || strings.Contains(host, "/") {
+		return "", ""
+	}
+	return host, path
+}
+
+// pathSegments splits a remote path into unescaped, non-empty segments.
+func pathSegments(path string) []string {
+	var segments []string
+	for seg := range strings.SplitSeq(path, "/") {
+		if seg == "" {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(seg); err == nil {
+			seg = unescaped
+		}
+		segments = append(segments, seg)
+	}
+	return segments
+}
+
+func trimGitSuffix(repo string) string {
+	if len(repo) >= len(".git") && strings.EqualFold(repo[len(repo)-len(".git"):], ".git") {
+		return repo[:len(repo)-len(".git")]
+	}
+	return repo
+}
@@ -23,6 +23,15 @@ func TestRemoteIdentity_GitHubTransportsAreEquivalent(t *testing.T) {
 	assert.True(t, EquivalentRemote(https, userlessSCP))
 }
 
+func TestRemoteIdentity_AzureAndBitbucketTransportsAreEquivalent(t *testing.T) {
+	assert.Equal(t, "dev.azure.com/org/project/repo", RemoteIdentity("git@ssh.dev.azure.com:v3/Org/Project/Repo"))
+	assert.True(t, EquivalentRemote("https://org@dev.azure.com/org/project/_git/repo", "git@ssh.dev.azure.com:v3/org/project/repo"))
+	assert.True(t, EquivalentRemote("https://org.visualstudio.com/DefaultCollection/project/_git/repo", "https://dev.azure.com/org/project/_git/repo"))
+	assert.True(t, EquivalentRemote("https://user@bitbucket.org/team/repo.git", "git@bitbucket.org:team/repo.git"))
+	assert.False(t, EquivalentRemote("https://bitbucket.example.com/scm/proj/repo.git", "ssh://git@bitbucket.example.com:7999/proj/repo.git"),
+		"self-hosted Bitbucket keeps exact matching")
+}
+
 func TestRemoteIdentity_DoesNotConflateOtherRemotes(t *testing.T) {
 	assert.False(t, EquivalentRemote("https://git.example.test/owner/repo.git", "ssh://git@git.example.test/owner/repo.git"))
 	assert.False(t, EquivalentRemote("git.example.test:owner/repo.git", "https://git.example.test/owner/repo.git"))
@@ -0,0 +1,55 @@
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"regexp"
+)
+
+// Content types with special handling. Payloads of other types are stored
+// as-is.
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeMarkdown = "text/markdown"
+)
+
+// ErrInvalidEnvelope is returned when a message's envelope fields are
+// malformed.
+var ErrInvalidEnvelope = errors.New("invalid message envelope")
+
+// messageType matches message types: lowercase dot-, dash- or
+// underscore-separated words, like topic tokens.
+var messageType = regexp.MustCompile(`^[a-z0-9]+([._-][a-z0-9]+)*$`)
+
+const maxEnvelopeField = 128
+
+// Validate checks the message's envelope fields. Messages without an
+// envelope are always valid. A ContentTypeJSON payload must be valid JSON.
+func (m Message) Validate() error {
+	if m.Type != "" && (len(m.Type) > maxEnvelopeField || !messageType.MatchString(m.Type)) {
+		return fmt.Errorf("%w: type %q must be lowercase words separated by '.', '-' or '_'", ErrInvalidEnvelope, m.Type)
+	}
+	if len(m.CorrelationID) > maxEnvelopeField {
+		return fmt.Errorf("%w: correlation id longer than %d characters", ErrInvalidEnvelope, maxEnvelopeField)
+	}
+	if m.ContentType == "" {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(m.ContentType)
+	if err != nil {
+		return fmt.Errorf("%w: content type %q: %w", ErrInvalidEnvelope, m.ContentType, err)
+	}
+	if mediaType == ContentTypeJSON && !json.Valid([]byte(m.Payload)) {
+		return fmt.Errorf("%w: payload is not valid JSON", ErrInvalidEnvelope)
+	}
+	return nil
+}
+
+// IsJSON reports whether the payload is declared as JSON.
+func (m Message) IsJSON() bool {
+	mediaType, _, err := mime.ParseMediaType(m.ContentType)
+	return err == nil && mediaType == ContentTypeJSON
+}
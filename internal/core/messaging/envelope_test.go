@@ -0,0 +1,38 @@
+package messaging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		msg   Message
+		valid bool
+	}{
+		{name: "no envelope", msg: Message{Payload: "hello"}, valid: true},
+		{name: "type", msg: Message{Type: "handoff"}, valid: true},
+		{name: "dotted type", msg: Message{Type: "review.done_v2"}, valid: true},
+		{name: "uppercase type", msg: Message{Type: "Handoff"}},
+		{name: "type with spaces", msg: Message{Type: "hand off"}},
+		{name: "json payload", msg: Message{ContentType: ContentTypeJSON, Payload: `{"files":["a.go"]}`}, valid: true},
+		{name: "json with charset", msg: Message{ContentType: "application/json; charset=utf-8", Payload: `[]`}, valid: true},
+		{name: "invalid json payload", msg: Message{ContentType: ContentTypeJSON, Payload: `{"files":`}},
+		{name: "markdown", msg: Message{ContentType: ContentTypeMarkdown, Payload: "# Plan"}, valid: true},
+		{name: "malformed content type", msg: Message{ContentType: "not a type"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.Validate()
+			if tt.valid {
+				require.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidEnvelope)
+		})
+	}
+}
@@ -3,13 +3,24 @@ package messaging
 import "time"
 
 // Message represents a single message published to a topic.
+//
+// Seq is assigned by the store at publish time and increases monotonically
+// per topic. It is never reused, even after the message is pruned.
 type Message struct {
 	ID        string    `json:"id"`
 	Topic     string    `json:"topic"`
+	Seq       int64     `json:"seq"`
 	Payload   string    `json:"payload"`
 	Sender    string    `json:"sender,omitempty"`
 	SessionID string    `json:"session_id,omitempty"`
+	ReplyTo   string    `json:"reply_to,omitempty"` // Topic the sender awaits a reply on (see hive msg request)
 	CreatedAt time.Time `json:"created_at"`
+
+	// Optional envelope fields, checked by Validate. SessionID is the
+	// envelope's sender session and Payload its body.
+	Type          string `json:"type,omitempty"`           // Kind of message, e.g. "handoff"
+	CorrelationID string `json:"correlation_id,omitempty"` // Ties related messages together, e.g. a reply to its request
+	ContentType   string `json:"content_type,omitempty"`   // Media type of Payload, e.g. ContentTypeJSON
 }
 
 // Topic represents a named channel for messages.
@@ -18,3 +29,16 @@ type Topic struct {
 	Messages  []Message `json:"messages"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// Cursor records the last sequence number consumed for each topic. Readers
+// pass it to Store.SubscribeAfter to resume without gaps or duplicates.
+type Cursor map[string]int64
+
+// Advance moves the cursor past the given messages.
+func (c Cursor) Advance(msgs []Message) {
+	for _, msg := range msgs {
+		if msg.Seq > c[msg.Topic] {
+			c[msg.Topic] = msg.Seq
+		}
+	}
+}
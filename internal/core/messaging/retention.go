@@ -0,0 +1,34 @@
+package messaging
+
+import "time"
+
+// Retention limits how long a topic keeps messages and how many it keeps.
+type Retention struct {
+	MaxAge      time.Duration // delete messages older than this; 0 keeps them forever
+	MaxPerTopic int           // keep at most this many messages; 0 is unlimited
+	AckedOnly   bool          // only delete messages a consumer acknowledged
+}
+
+// TopicRetention sets the retention of topics matching Pattern (see
+// MatchTopic).
+type TopicRetention struct {
+	Pattern string
+	Retention
+}
+
+// RetentionPolicy resolves the retention of each topic.
+type RetentionPolicy struct {
+	Default Retention
+	Topics  []TopicRetention // evaluated in order; the last match wins
+}
+
+// For returns the retention of topic.
+func (p RetentionPolicy) For(topic string) Retention {
+	r := p.Default
+	for _, t := range p.Topics {
+		if MatchTopic(t.Pattern, topic) {
+			r = t.Retention
+		}
+	}
+	return r
+}
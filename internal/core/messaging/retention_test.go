@@ -0,0 +1,22 @@
+package messaging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetentionPolicy_For(t *testing.T) {
+	p := RetentionPolicy{
+		Default: Retention{MaxAge: 720 * time.Hour, MaxPerTopic: 100},
+		Topics: []TopicRetention{
+			{Pattern: "build.>", Retention: Retention{MaxAge: 24 * time.Hour, MaxPerTopic: 100}},
+			{Pattern: "build.release", Retention: Retention{}},
+		},
+	}
+
+	assert.Equal(t, p.Default, p.For("agent.abc.inbox"))
+	assert.Equal(t, Retention{MaxAge: 24 * time.Hour, MaxPerTopic: 100}, p.For("build.api.done"))
+	assert.Equal(t, Retention{}, p.For("build.release"), "last match wins")
+}
@@ -32,6 +32,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/colonyops/hive/internal/core/session"
@@ -70,7 +71,7 @@ func (d *SessionDetector) DetectSessionFromPath(ctx context.Context, path string
 	if err != nil {
 		return "", fmt.Errorf("get absolute path: %w", err)
 	}
-	path = filepath.Clean(path)
+	path = normalizePath(path)
 
 	// Find the longest matching session path (most specific match)
 	var bestMatch session.Session
@@ -81,7 +82,7 @@ func (d *SessionDetector) DetectSessionFromPath(ctx context.Context, path string
 			continue
 		}
 
-		sessPath := filepath.Clean(sess.Path)
+		sessPath := normalizePath(sess.Path)
 
 		// Check if path equals or is within the session path
 		if path == sessPath || isSubpath(sessPath, path) {
@@ -95,6 +96,16 @@ func (d *SessionDetector) DetectSessionFromPath(ctx context.Context, path string
 	return bestMatch.ID, nil
 }
 
+// normalizePath cleans path for comparison. Windows paths are compared
+// case-insensitively, as the filesystem treats them.
+func normalizePath(path string) string {
+	path = filepath.Clean(path)
+	if runtime.GOOS == "windows" {
+		path = strings.ToLower(path)
+	}
+	return path
+}
+
 // isSubpath returns true if child is a subdirectory of parent.
 func isSubpath(parent, child string) bool {
 	// Ensure parent ends with separator for correct prefix matching
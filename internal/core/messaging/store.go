@@ -6,25 +6,64 @@ import (
 	"time"
 )
 
-var ErrTopicNotFound = errors.New("topic not found")
+var (
+	ErrTopicNotFound   = errors.New("topic not found")
+	ErrMessageNotFound = errors.New("message not found")
+	ErrNoReplyTopic    = errors.New("message does not expect a reply")
+)
 
 // PublishResult contains information about a successful publish operation.
 type PublishResult struct {
 	// Topics is the list of topics the message was actually published to
 	// (after wildcard expansion and deduplication).
 	Topics []string
+
+	// Seqs maps each resolved topic to the sequence number assigned to the
+	// message in that topic.
+	Seqs map[string]int64
 }
 
 // Store defines the interface for message persistence.
+//
+// # Consistency Guarantees
+//
+// Implementations must provide the following guarantees:
+//
+//   - Per-topic ordering: every message receives a sequence number that is
+//     strictly greater than all previous sequence numbers in its topic.
+//     Sequence numbers are assigned atomically with the insert and are never
+//     reused, even after retention or pruning removes messages.
+//   - Read-your-writes: once Publish returns, the message is visible to any
+//     subsequent read from any process.
+//   - Deterministic reads: messages within a topic are returned in sequence
+//     order. Messages from multiple topics are ordered by creation time, then
+//     topic name, then sequence number.
 type Store interface {
 	// Publish adds a message to multiple topics.
-	// Wildcards are expanded before publishing.
+	// Wildcards are expanded to the existing matching topics before
+	// publishing (see MatchTopic).
 	Publish(ctx context.Context, msg Message, topics []string) (PublishResult, error)
 
-	// Subscribe returns all messages for a topic, optionally filtered by since timestamp.
-	// Returns ErrTopicNotFound if the topic doesn't exist.
+	// Subscribe returns all messages for a topic pattern, optionally filtered
+	// by since timestamp.
+	// Returns ErrTopicNotFound if no matching topics exist.
 	Subscribe(ctx context.Context, topic string, since time.Time) ([]Message, error)
 
+	// SubscribeAfter returns messages for a topic pattern whose sequence number
+	// is greater than the cursor position for their topic. Topics missing from
+	// the cursor are read from the beginning.
+	// Returns ErrTopicNotFound if no matching topics exist.
+	SubscribeAfter(ctx context.Context, topic string, cursor Cursor) ([]Message, error)
+
+	// Get returns the message with the given ID.
+	// Returns ErrMessageNotFound if it does not exist.
+	Get(ctx context.Context, id string) (Message, error)
+
+	// Head returns a cursor positioned at the latest sequence number of every
+	// topic matching the pattern. Reading with SubscribeAfter from the returned
+	// cursor yields only messages published after Head was called.
+	Head(ctx context.Context, topic string) (Cursor, error)
+
 	// Acknowledge marks messages as read by a consumer.
 	Acknowledge(ctx context.Context, consumerID string, messageIDs []string) error
 
@@ -32,10 +71,32 @@ type Store interface {
 	// Supports wildcard topic patterns.
 	GetUnread(ctx context.Context, consumerID string, topic string) ([]Message, error)
 
+	// ConsumerCursor returns the read position of a named consumer in every
+	// topic matching the pattern that it has acknowledged messages in.
+	// Reading with SubscribeAfter from the returned cursor yields the
+	// messages the consumer has not acknowledged yet.
+	ConsumerCursor(ctx context.Context, consumer string, topic string) (Cursor, error)
+
+	// AckThrough moves a named consumer's cursor in each message's topic to
+	// that message, acknowledging it and every earlier message of the topic.
+	// Cursors never move backwards. Returns ErrMessageNotFound if an ID does
+	// not exist.
+	AckThrough(ctx context.Context, consumer string, messageIDs []string) error
+
 	// List returns all topic names.
 	List(ctx context.Context) ([]string, error)
 
 	// Prune removes messages older than the given duration across all topics.
 	// Returns the number of messages removed.
 	Prune(ctx context.Context, olderThan time.Duration) (int, error)
+
+	// ApplyRetention deletes the messages the store's retention policy no
+	// longer keeps from topics matching the pattern. A positive maxAge also
+	// removes messages older than it. Returns the number of messages removed.
+	ApplyRetention(ctx context.Context, topic string, maxAge time.Duration) (int, error)
+
+	// DeleteTopic removes every message of a topic, such as the inbox of a
+	// deleted session. Sequence numbers are not reused if the topic is
+	// published to again. Returns the number of messages removed.
+	DeleteTopic(ctx context.Context, topic string) (int, error)
 }
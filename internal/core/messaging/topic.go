@@ -0,0 +1,69 @@
+package messaging
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Topics are split into dot-separated tokens, and a pattern may use two
+// wildcard tokens:
+//
+//	agent.*.inbox   // "*" matches one or more tokens
+//	build.>         // ">" matches one or more trailing tokens
+//
+// The empty pattern and a lone "*" match every topic. Any other character,
+// including a "*" or ">" inside a token, is matched literally.
+const (
+	wildcardToken = "*"
+	fullWildcard  = ">"
+)
+
+// IsTopicPattern reports whether s contains wildcard tokens and so may match
+// more than one topic.
+func IsTopicPattern(s string) bool {
+	for _, tok := range strings.Split(s, ".") {
+		if tok == wildcardToken || tok == fullWildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTopicPattern checks that ">" only appears as the last token of
+// pattern. Every topic name without wildcard tokens is valid.
+func ValidateTopicPattern(pattern string) error {
+	tokens := strings.Split(pattern, ".")
+	for i, tok := range tokens {
+		if tok == fullWildcard && i != len(tokens)-1 {
+			return fmt.Errorf("invalid topic pattern %q: %q must be the last token", pattern, fullWildcard)
+		}
+	}
+	return nil
+}
+
+// MatchTopic reports whether topic matches pattern. Patterns without
+// wildcards match only the identical topic.
+func MatchTopic(pattern, topic string) bool {
+	if pattern == "" || pattern == wildcardToken {
+		return true
+	}
+	return matchTokens(strings.Split(pattern, "."), strings.Split(topic, "."))
+}
+
+func matchTokens(pt, tt []string) bool {
+	if len(pt) == 0 {
+		return len(tt) == 0
+	}
+	switch pt[0] {
+	case fullWildcard:
+		return len(pt) == 1 && len(tt) > 0
+	case wildcardToken:
+		for n := 1; n <= len(tt); n++ {
+			if matchTokens(pt[1:], tt[n:]) {
+				return true
+			}
+		}
+		return false
+	}
+	return len(tt) > 0 && pt[0] == tt[0] && matchTokens(pt[1:], tt[1:])
+}
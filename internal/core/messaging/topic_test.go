@@ -0,0 +1,60 @@
+package messaging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchTopic(t *testing.T) {
+	tests := []struct {
+		pattern string
+		topic   string
+		want    bool
+	}{
+		{"", "anything.at.all", true},
+		{"*", "anything.at.all", true},
+		{"build", "build", true},
+		{"build", "build.started", false},
+		{"agent.*.inbox", "agent.abc.inbox", true},
+		{"agent.*.inbox", "agent.abc.outbox", false},
+		{"agent.*.inbox", "agent.abc.main.inbox", true},
+		{"agent.*.inbox", "agent.inbox", false},
+		{"agent.*", "agent.abc", true},
+		{"agent.*", "agent.abc.inbox", true},
+		{"agent.*", "agent", false},
+		{"build.>", "build.started", true},
+		{"build.>", "build.api.finished", true},
+		{"build.>", "build", false},
+		{"build.>", "builder.started", false},
+		{"*.*.inbox", "agent.abc.inbox", true},
+		{"agent.*.>", "agent.abc.inbox", true},
+		{"agent.*.>", "agent.abc", false},
+		{"agent.in*box", "agent.in*box", true},
+		{"agent.in*box", "agent.inbox", false},
+		{"a..b", "a..b", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"~"+tt.topic, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchTopic(tt.pattern, tt.topic))
+		})
+	}
+}
+
+func TestIsTopicPattern(t *testing.T) {
+	assert.True(t, IsTopicPattern("*"))
+	assert.True(t, IsTopicPattern("agent.*.inbox"))
+	assert.True(t, IsTopicPattern("build.>"))
+	assert.False(t, IsTopicPattern("agent.abc.inbox"))
+	assert.False(t, IsTopicPattern("agent*"))
+}
+
+func TestValidateTopicPattern(t *testing.T) {
+	for _, ok := range []string{"", "*", "build", "agent.*.inbox", "build.>", ">", "agent*", "a..b", "build.>>", "a>b"} {
+		assert.NoError(t, ValidateTopicPattern(ok), ok)
+	}
+	for _, bad := range []string{"build.>.x", ">.x"} {
+		assert.Error(t, ValidateTopicPattern(bad), bad)
+	}
+}
@@ -0,0 +1,42 @@
+package review
+
+import (
+	"context"
+	"time"
+)
+
+// Annotation is a personal note on a document. Annotations belong to the
+// document rather than a review session, so they survive finalization and
+// are never included in review feedback.
+type Annotation struct {
+	ID           string
+	DocumentPath string
+	ContentHash  string // SHA256 hash of the content the anchor refers to
+	StartLine    int
+	EndLine      int
+	StartCol     int  // 1-indexed column on StartLine; 0 anchors to whole lines
+	EndCol       int  // Inclusive column on EndLine; 0 anchors to whole lines
+	Outdated     bool // The anchored text was removed from the document
+	ContextText  string
+	Note         string
+	CreatedAt    time.Time
+}
+
+// AnnotationStore defines persistence operations for document annotations.
+type AnnotationStore interface {
+	// SaveAnnotation adds an annotation to a document.
+	SaveAnnotation(ctx context.Context, annotation Annotation) error
+
+	// ListAnnotations returns all annotations on a document, sorted by start line.
+	ListAnnotations(ctx context.Context, documentPath string) ([]Annotation, error)
+
+	// UpdateAnnotation updates the note of an existing annotation.
+	UpdateAnnotation(ctx context.Context, annotation Annotation) error
+
+	// ReanchorAnnotations records a changed document's new content hash and
+	// moves the given annotations to their re-anchored positions.
+	ReanchorAnnotations(ctx context.Context, contentHash string, annotations []Annotation) error
+
+	// DeleteAnnotation removes a specific annotation.
+	DeleteAnnotation(ctx context.Context, annotationID string) error
+}
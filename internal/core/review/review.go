@@ -8,18 +8,54 @@ type Session struct {
 	DocumentPath string
 	ContentHash  string // SHA256 hash of document content
 	CreatedAt    time.Time
-	FinalizedAt  *time.Time // nil if not finalized
+	FinalizedAt  *time.Time    // nil if not finalized
+	ReviewTime   time.Duration // Time spent in the review view, idle stretches excluded
+
+	// Set once the finalized feedback is sent to an agent with hive review dispatch.
+	DispatchedAt    *time.Time // nil if never dispatched
+	DispatchedTo    string     // hive session ID the feedback was sent to
+	DispatchReplyTo string     // topic the agent acknowledges the feedback on
+}
+
+// Document is a file attached to a review session. Every session includes its
+// own DocumentPath; related files can be attached so their comments are
+// finalized together.
+type Document struct {
+	SessionID    string
+	DocumentPath string
+	ContentHash  string // SHA256 hash when the document was attached
+	AddedAt      time.Time
 }
 
 // Comment represents inline feedback on a document section.
 type Comment struct {
-	ID          string
-	SessionID   string
-	StartLine   int
-	EndLine     int
-	ContextText string
+	ID           string
+	SessionID    string
+	DocumentPath string // Document the comment was made on (empty means the session's document)
+	StartLine    int
+	EndLine      int
+	StartCol     int  // 1-indexed column on StartLine; 0 anchors to whole lines
+	EndCol       int  // Inclusive column on EndLine; 0 anchors to whole lines
+	Outdated     bool // The anchored text was removed from the document
+	Severity     Severity
+	ContextText  string
+	CommentText  string
+	CreatedAt    time.Time
+}
+
+// SearchResult is a comment from a finalized review that matched a search of
+// past feedback. Its DocumentPath is always set.
+type SearchResult struct {
+	Comment
+	FinalizedAt time.Time
+}
+
+// CommentRevision is an earlier version of an edited comment.
+type CommentRevision struct {
+	CommentID   string
 	CommentText string
-	CreatedAt   time.Time
+	Severity    Severity
+	ReplacedAt  time.Time // When an edit replaced this version
 }
 
 // IsFinalized returns true if the review session has been finalized.
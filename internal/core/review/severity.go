@@ -0,0 +1,48 @@
+package review
+
+import (
+	"slices"
+	"strings"
+)
+
+// Severity is how strongly a reviewer wants a comment addressed.
+type Severity string
+
+const (
+	SeverityNit        Severity = "nit"
+	SeveritySuggestion Severity = "suggestion"
+	SeverityIssue      Severity = "issue"
+	SeverityBlocker    Severity = "blocker"
+)
+
+// DefaultSeverity is the severity of comments made without choosing one.
+const DefaultSeverity = SeveritySuggestion
+
+// Severities lists severities from least to most severe, the order the
+// comment modal cycles through them.
+var Severities = []Severity{SeverityNit, SeveritySuggestion, SeverityIssue, SeverityBlocker}
+
+// ParseSeverity returns the severity named s, ignoring case.
+func ParseSeverity(s string) (Severity, bool) {
+	sev := Severity(strings.ToLower(strings.TrimSpace(s)))
+	return sev, slices.Contains(Severities, sev)
+}
+
+// OrDefault returns s, or DefaultSeverity when s is empty or unknown.
+func (s Severity) OrDefault() Severity {
+	if slices.Contains(Severities, s) {
+		return s
+	}
+	return DefaultSeverity
+}
+
+// Rank returns the position of the severity in Severities; higher is more
+// severe.
+func (s Severity) Rank() int {
+	return slices.Index(Severities, s.OrDefault())
+}
+
+// Label returns a human-readable name for the severity.
+func (s Severity) Label() string {
+	return string(s.OrDefault())
+}
@@ -3,11 +3,13 @@ package review
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 // Sentinel errors for review operations.
 var (
-	ErrSessionNotFound = errors.New("review session not found")
+	ErrSessionNotFound  = errors.New("review session not found")
+	ErrDocumentInReview = errors.New("document already belongs to an active review session")
 )
 
 // Store defines persistence operations for review sessions and comments.
@@ -24,14 +26,42 @@ type Store interface {
 	// Returns ErrSessionNotFound if not found.
 	GetSessionByHash(ctx context.Context, documentPath string, contentHash string) (Session, error)
 
-	// CleanupStaleSessions removes review sessions for a document with different content hash.
-	// Used to clean up sessions when document content changes.
+	// GetActiveSessionForDocument returns the active session the document is
+	// attached to, whether as the session's own document or as a related file.
+	// Returns ErrSessionNotFound if not found.
+	GetActiveSessionForDocument(ctx context.Context, documentPath string) (Session, error)
+
+	// AddDocument attaches a related document to an existing session.
+	// Returns ErrDocumentInReview if the document already belongs to an active session.
+	AddDocument(ctx context.Context, sessionID string, documentPath string, contentHash string) error
+
+	// ListDocuments returns the documents attached to a session in the order they were added.
+	ListDocuments(ctx context.Context, sessionID string) ([]Document, error)
+
+	// LastReviewedHashes returns, for each document that has been part of a
+	// finalized review, the content hash it had in the most recent one.
+	LastReviewedHashes(ctx context.Context) (map[string]string, error)
+
+	// CleanupStaleSessions removes unfinalized review sessions for a document with
+	// a different content hash. Used to clean up sessions when document content
+	// changes; finalized sessions are kept as review history.
 	CleanupStaleSessions(ctx context.Context, documentPath string, currentHash string) error
 
+	// ReanchorDocument records a changed document's new content hash on a session
+	// and moves the given comments to their re-anchored positions.
+	ReanchorDocument(ctx context.Context, sessionID string, documentPath string, contentHash string, comments []Comment) error
+
 	// FinalizeSession marks a review session as finalized.
 	// Returns ErrSessionNotFound if not found.
 	FinalizeSession(ctx context.Context, sessionID string) error
 
+	// MarkDispatched records that a finalized session's feedback was sent to
+	// the hive session target, which acknowledges it on replyTo.
+	MarkDispatched(ctx context.Context, sessionID string, target string, replyTo string) error
+
+	// AddReviewTime adds time spent reviewing to a review session.
+	AddReviewTime(ctx context.Context, sessionID string, d time.Duration) error
+
 	// DeleteSession removes a review session and all associated comments.
 	// Returns ErrSessionNotFound if not found.
 	DeleteSession(ctx context.Context, sessionID string) error
@@ -42,9 +72,18 @@ type Store interface {
 	// ListComments returns all comments for a review session, sorted by start line.
 	ListComments(ctx context.Context, sessionID string) ([]Comment, error)
 
-	// UpdateComment updates the comment text for an existing comment.
+	// UpdateComment updates the text and severity of an existing comment. When
+	// the text changes, the previous version is kept as a revision.
 	UpdateComment(ctx context.Context, comment Comment) error
 
+	// ListCommentRevisions returns the earlier versions of a comment, oldest first.
+	ListCommentRevisions(ctx context.Context, commentID string) ([]CommentRevision, error)
+
 	// DeleteComment removes a specific comment.
 	DeleteComment(ctx context.Context, commentID string) error
+
+	// SearchComments returns up to limit comments from finalized reviews whose
+	// text or quoted context contains every word of query (the last one as a
+	// prefix), newest review first.
+	SearchComments(ctx context.Context, query string, limit int) ([]SearchResult, error)
 }
@@ -0,0 +1,25 @@
+package review
+
+// Verdict is the reviewer's overall conclusion on a finalized review.
+type Verdict string
+
+const (
+	VerdictComment        Verdict = "comment"
+	VerdictApprove        Verdict = "approve"
+	VerdictRequestChanges Verdict = "request_changes"
+)
+
+// Verdicts lists verdicts in the order the finalize modal cycles through them.
+var Verdicts = []Verdict{VerdictComment, VerdictApprove, VerdictRequestChanges}
+
+// Label returns a human-readable name for the verdict.
+func (v Verdict) Label() string {
+	switch v {
+	case VerdictApprove:
+		return "approve"
+	case VerdictRequestChanges:
+		return "request changes"
+	default:
+		return "comment"
+	}
+}
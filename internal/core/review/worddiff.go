@@ -0,0 +1,97 @@
+package review
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DiffOp is the kind of change a DiffSpan makes.
+type DiffOp int
+
+const (
+	DiffEqual  DiffOp = iota // text in both versions
+	DiffDelete               // text only in the old version
+	DiffInsert               // text only in the new version
+)
+
+// DiffSpan is a run of text kept, removed, or added by an edit.
+type DiffSpan struct {
+	Op   DiffOp
+	Text string
+}
+
+// diffTokenPattern splits text into words and the whitespace between them.
+var diffTokenPattern = regexp.MustCompile(`\s+|\S+`)
+
+// DiffWords compares two versions of a text word by word. Joining the equal
+// and deleted spans gives old; joining the equal and inserted spans gives
+// new. Within a changed region, deletions come before insertions.
+func DiffWords(old, new string) []DiffSpan {
+	a := diffTokenPattern.FindAllString(old, -1)
+	b := diffTokenPattern.FindAllString(new, -1)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var spans []DiffSpan
+	add := func(op DiffOp, text string) {
+		if n := len(spans); n > 0 && spans[n-1].Op == op {
+			spans[n-1].Text += text
+			return
+		}
+		spans = append(spans, DiffSpan{Op: op, Text: text})
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			add(DiffEqual, a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			add(DiffDelete, a[i])
+			i++
+		default:
+			add(DiffInsert, b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		add(DiffDelete, a[i])
+	}
+	for ; j < len(b); j++ {
+		add(DiffInsert, b[j])
+	}
+	return spans
+}
+
+// FormatWordDiff renders spans as plain text, marking removed text as
+// [-text-] and added text as {+text+}, like git diff --word-diff.
+func FormatWordDiff(spans []DiffSpan) string {
+	var b strings.Builder
+	for _, span := range spans {
+		switch span.Op {
+		case DiffDelete:
+			b.WriteString("[-" + span.Text + "-]")
+		case DiffInsert:
+			b.WriteString("{+" + span.Text + "+}")
+		default:
+			b.WriteString(span.Text)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,43 @@
+package review
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffWords(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want string
+	}{
+		{name: "unchanged", old: "rename the function", new: "rename the function", want: "rename the function"},
+		{name: "replaced word", old: "rename the function", new: "rename the handler", want: "rename the [-function-]{+handler+}"},
+		{name: "appended", old: "add tests", new: "add tests for errors", want: "add tests{+ for errors+}"},
+		{name: "removed", old: "please do not panic here", new: "please panic here", want: "please [-do not -]panic here"},
+		{name: "from empty", old: "", new: "new text", want: "{+new text+}"},
+		{name: "multiline", old: "one\ntwo", new: "one\nthree", want: "one\n[-two-]{+three+}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spans := DiffWords(tt.old, tt.new)
+			assert.Equal(t, tt.want, FormatWordDiff(spans))
+
+			var old, new strings.Builder
+			for _, s := range spans {
+				if s.Op != DiffInsert {
+					old.WriteString(s.Text)
+				}
+				if s.Op != DiffDelete {
+					new.WriteString(s.Text)
+				}
+			}
+			assert.Equal(t, tt.old, old.String())
+			assert.Equal(t, tt.new, new.String())
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package session
+
+import (
+	"strconv"
+	"time"
+)
+
+// Metadata keys for archived sessions.
+const (
+	MetaArchivedAt         = "archived_at"
+	MetaArchiveBranch      = "archive_branch"
+	MetaArchiveAdditions   = "archive_additions"
+	MetaArchiveDeletions   = "archive_deletions"
+	MetaArchiveUncommitted = "archive_uncommitted" // "true" if the working tree was dirty
+	MetaArchiveUnpushed    = "archive_unpushed"    // "true" if commits were not pushed
+)
+
+// ArchiveSummary is the final state of a session's checkout, recorded when
+// the session is archived and its directory removed.
+type ArchiveSummary struct {
+	ArchivedAt  time.Time `json:"archived_at"`
+	Branch      string    `json:"branch,omitempty"`
+	Additions   int       `json:"additions"`
+	Deletions   int       `json:"deletions"`
+	Uncommitted bool      `json:"uncommitted"`
+	Unpushed    bool      `json:"unpushed"`
+}
+
+// MarkArchived transitions the session to the archived state and records
+// summary in its metadata.
+func (s *Session) MarkArchived(summary ArchiveSummary) {
+	s.State = StateArchived
+	s.UpdatedAt = summary.ArchivedAt
+	delete(s.Metadata, MetaRecycleError)
+
+	s.SetMeta(MetaArchivedAt, summary.ArchivedAt.UTC().Format(time.RFC3339))
+	s.SetMeta(MetaArchiveAdditions, strconv.Itoa(summary.Additions))
+	s.SetMeta(MetaArchiveDeletions, strconv.Itoa(summary.Deletions))
+	s.SetMeta(MetaArchiveUncommitted, strconv.FormatBool(summary.Uncommitted))
+	s.SetMeta(MetaArchiveUnpushed, strconv.FormatBool(summary.Unpushed))
+	if summary.Branch != "" {
+		s.SetMeta(MetaArchiveBranch, summary.Branch)
+	}
+}
+
+// ArchiveSummary returns the summary recorded by MarkArchived. Fields that
+// were not recorded are left at their zero values.
+func (s *Session) ArchiveSummary() ArchiveSummary {
+	archivedAt, _ := time.Parse(time.RFC3339, s.GetMeta(MetaArchivedAt))
+	additions, _ := strconv.Atoi(s.GetMeta(MetaArchiveAdditions))
+	deletions, _ := strconv.Atoi(s.GetMeta(MetaArchiveDeletions))
+	uncommitted, _ := strconv.ParseBool(s.GetMeta(MetaArchiveUncommitted))
+	unpushed, _ := strconv.ParseBool(s.GetMeta(MetaArchiveUnpushed))
+
+	return ArchiveSummary{
+		ArchivedAt:  archivedAt,
+		Branch:      s.GetMeta(MetaArchiveBranch),
+		Additions:   additions,
+		Deletions:   deletions,
+		Uncommitted: uncommitted,
+		Unpushed:    unpushed,
+	}
+}
@@ -0,0 +1,31 @@
+package session
+
+// Metadata keys for the branch hive checked out for a session.
+const (
+	MetaBranch     = "branch"      // branch started for the session from the rule's branch_template
+	MetaBaseBranch = "base_branch" // remote branch it started from; unset means the default branch
+)
+
+// Branch returns the branch hive checked out for the session, or "" if the
+// session was left on the clone's default branch. The agent may have
+// switched branches since; this is what the session started on.
+func (s *Session) Branch() string {
+	if branch := s.GetMeta(MetaBranch); branch != "" {
+		return branch
+	}
+	return s.GetMeta(MetaWorktreeBranch)
+}
+
+// SetBranch records the branch hive checked out for the session and the
+// base it started from. An empty branch clears both.
+func (s *Session) SetBranch(branch, base string) {
+	delete(s.Metadata, MetaBaseBranch)
+	if branch == "" {
+		delete(s.Metadata, MetaBranch)
+		return
+	}
+	s.SetMeta(MetaBranch, branch)
+	if base != "" {
+		s.SetMeta(MetaBaseBranch, base)
+	}
+}
@@ -0,0 +1,83 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MetaCapabilities is the JSON Capabilities the session's agent last
+// announced on its meta topic.
+const MetaCapabilities = "agent_capabilities"
+
+// Capabilities is what an agent announces about itself on startup by
+// publishing a JSON object to its session's meta topic, so orchestrators can
+// route work to agents able to do it. Fields other than these are ignored.
+type Capabilities struct {
+	Agent       string    `json:"agent,omitempty"` // agent name, e.g. "claude"
+	Model       string    `json:"model,omitempty"`
+	Version     string    `json:"version,omitempty"`
+	Tools       []string  `json:"tools,omitempty"`
+	AnnouncedAt time.Time `json:"announced_at"`
+}
+
+// ParseCapabilities parses an announcement published to a meta topic.
+func ParseCapabilities(payload string) (Capabilities, error) {
+	var c Capabilities
+	if err := json.Unmarshal([]byte(payload), &c); err != nil {
+		return Capabilities{}, errors.New("capabilities must be a JSON object with agent, model, version, or tools")
+	}
+	if c.Agent == "" && c.Model == "" && c.Version == "" && len(c.Tools) == 0 {
+		return Capabilities{}, errors.New("capabilities must set at least one of agent, model, version, or tools")
+	}
+	return c, nil
+}
+
+// MetaTopic returns the topic the session's agent announces its
+// capabilities on.
+//
+// Format: agent.<session-id>.meta
+func (s *Session) MetaTopic() string {
+	return "agent." + s.ID + ".meta"
+}
+
+// SessionIDFromMetaTopic returns the session ID of a meta topic, and false
+// for other topics.
+func SessionIDFromMetaTopic(topic string) (string, bool) {
+	rest, ok := strings.CutPrefix(topic, "agent.")
+	if !ok {
+		return "", false
+	}
+	id, ok := strings.CutSuffix(rest, ".meta")
+	if !ok || id == "" || strings.ContainsAny(id, ".*") {
+		return "", false
+	}
+	return id, true
+}
+
+// Capabilities returns the capabilities the session's agent last announced,
+// and false if it has not announced any.
+func (s *Session) Capabilities() (Capabilities, bool) {
+	raw := s.GetMeta(MetaCapabilities)
+	if raw == "" {
+		return Capabilities{}, false
+	}
+	var c Capabilities
+	if err := json.Unmarshal([]byte(raw), &c); err != nil {
+		return Capabilities{}, false
+	}
+	return c, true
+}
+
+// SetCapabilities records the capabilities the session's agent announced.
+// The session is unchanged when they cannot be encoded.
+func (s *Session) SetCapabilities(c Capabilities) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("encode capabilities: %w", err)
+	}
+	s.SetMeta(MetaCapabilities, string(data))
+	return nil
+}
@@ -0,0 +1,48 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCapabilities(t *testing.T) {
+	caps, err := ParseCapabilities(`{"agent":"claude","model":"opus","version":"1.2","tools":["bash","edit"],"extra":1}`)
+	require.NoError(t, err)
+	assert.Equal(t, Capabilities{Agent: "claude", Model: "opus", Version: "1.2", Tools: []string{"bash", "edit"}}, caps)
+
+	_, err = ParseCapabilities("ready")
+	require.Error(t, err)
+	_, err = ParseCapabilities(`{"status":"ready"}`)
+	require.Error(t, err, "an announcement must describe the agent")
+}
+
+func TestSessionIDFromMetaTopic(t *testing.T) {
+	s := Session{ID: "abc123"}
+	id, ok := SessionIDFromMetaTopic(s.MetaTopic())
+	assert.True(t, ok)
+	assert.Equal(t, "abc123", id)
+
+	for _, topic := range []string{"agent.abc123.inbox", "agent.*.meta", "agent..meta", "agent.a.b.meta", "meta"} {
+		_, ok := SessionIDFromMetaTopic(topic)
+		assert.False(t, ok, topic)
+	}
+}
+
+func TestSession_Capabilities(t *testing.T) {
+	s := Session{ID: "test-id", State: StateActive}
+	_, ok := s.Capabilities()
+	assert.False(t, ok)
+
+	want := Capabilities{Agent: "claude", Tools: []string{"bash"}, AnnouncedAt: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)}
+	require.NoError(t, s.SetCapabilities(want))
+	got, ok := s.Capabilities()
+	assert.True(t, ok)
+	assert.Equal(t, want, got)
+
+	s.MarkRecycled(time.Now())
+	_, ok = s.Capabilities()
+	assert.False(t, ok, "recycling forgets the previous agent")
+}
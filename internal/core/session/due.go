@@ -0,0 +1,32 @@
+package session
+
+import "time"
+
+// MetaDueAt is the RFC 3339 time a session is expected to be done by.
+const MetaDueAt = "due_at"
+
+// DueAt returns when the session is expected to be done, and false if no
+// due date is set.
+func (s *Session) DueAt() (time.Time, bool) {
+	due, err := time.Parse(time.RFC3339, s.GetMeta(MetaDueAt))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return due, true
+}
+
+// SetDueAt sets the session's due date. A zero time clears it.
+func (s *Session) SetDueAt(due time.Time) {
+	if due.IsZero() {
+		delete(s.Metadata, MetaDueAt)
+		return
+	}
+	s.SetMeta(MetaDueAt, due.UTC().Format(time.RFC3339))
+}
+
+// IsOverdue returns true if the session is active and its due date has
+// passed.
+func (s *Session) IsOverdue(now time.Time) bool {
+	due, ok := s.DueAt()
+	return ok && s.State == StateActive && now.After(due)
+}
@@ -0,0 +1,32 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSession_DueAt(t *testing.T) {
+	due := time.Date(2026, 3, 1, 17, 0, 0, 0, time.UTC)
+	s := Session{ID: "test-id", State: StateActive}
+
+	_, ok := s.DueAt()
+	assert.False(t, ok)
+	assert.False(t, s.IsOverdue(due.Add(time.Hour)))
+
+	s.SetDueAt(due)
+	got, ok := s.DueAt()
+	assert.True(t, ok)
+	assert.Equal(t, due, got)
+	assert.False(t, s.IsOverdue(due))
+	assert.True(t, s.IsOverdue(due.Add(time.Second)))
+
+	s.State = StateArchived
+	assert.False(t, s.IsOverdue(due.Add(time.Hour)), "only active sessions are overdue")
+
+	s.SetDueAt(time.Time{})
+	_, ok = s.DueAt()
+	assert.False(t, ok)
+	assert.NotContains(t, s.Metadata, MetaDueAt)
+}
@@ -4,8 +4,10 @@ package session
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
+	"unicode"
 )
 
 var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
@@ -48,6 +50,7 @@ const (
 	StateActive    State = "active"
 	StateRecycled  State = "recycled"
 	StateCorrupted State = "corrupted"
+	StateArchived  State = "archived"
 )
 
 // Metadata keys for terminal integration.
@@ -57,6 +60,11 @@ const (
 	// persisted before the tmux_window rename remain discoverable; the fallback can
 	// be dropped after one release cycle.
 	MetaTmuxWindow = "tmux_window"
+	// MetaTmuxPane and MetaTmuxTargets are recorded when hive creates the tmux
+	// session: the agent pane ID (%N) and a JSON list of every window with its
+	// pane IDs.
+	MetaTmuxPane    = "tmux_pane_id"
+	MetaTmuxTargets = "tmux_targets"
 )
 
 // Metadata keys for session organization.
@@ -75,6 +83,34 @@ const (
 	MetaWorktreeBranch = "worktree_branch" // branch name used by the git worktree
 )
 
+// Metadata keys for the agent's task.
+const (
+	MetaPrompt       = "prompt"        // prompt the session was created with
+	MetaAgentProfile = "agent_profile" // agent profile the session's windows were spawned with
+)
+
+// Metadata keys for the GitHub issue a session was created from, kept for
+// linking the session's pull request back to it.
+const (
+	MetaIssueNumber = "github_issue"
+	MetaIssueURL    = "github_issue_url"
+)
+
+// Metadata keys for recycle failures.
+const (
+	MetaRecycleError = "recycle_error" // error from the last failed recycle attempt
+)
+
+// Metadata keys for snapshots.
+const (
+	MetaRestoredFrom = "restored_from" // ID of the snapshot the session was restored from
+)
+
+// Metadata keys for version control.
+const (
+	MetaVCS = "vcs" // version control backend; unset means git
+)
+
 // Session represents an isolated git environment for an AI agent.
 //
 // Terminology:
@@ -95,6 +131,7 @@ type Session struct {
 	State         State             `json:"state"`
 	CloneStrategy string            `json:"clone_strategy,omitempty"` // "full" (default) or "worktree"
 	Tags          []string          `json:"tags,omitempty"`           // user-defined labels for external provider tracking
+	Notes         string            `json:"notes,omitempty"`          // free-form reminders kept by the user
 	Metadata      map[string]string `json:"metadata,omitempty"`       // integration data (e.g., tmux session name)
 	CreatedAt     time.Time         `json:"created_at"`
 	UpdatedAt     time.Time         `json:"updated_at"`
@@ -118,10 +155,31 @@ func (s *Session) CanRecycle() bool {
 	return s.State == StateActive
 }
 
+// NeedsAttention returns true if the last recycle attempt failed and left the
+// session's working tree for the user to fix.
+func (s *Session) NeedsAttention() bool {
+	return s.State == StateActive && s.GetMeta(MetaRecycleError) != ""
+}
+
 // MarkRecycled transitions the session to the recycled state.
 func (s *Session) MarkRecycled(now time.Time) {
 	s.State = StateRecycled
 	s.UpdatedAt = now
+	delete(s.Metadata, MetaRecycleError)
+	delete(s.Metadata, MetaCapabilities) // the next agent announces its own
+	delete(s.Metadata, MetaSyncedAt)
+	delete(s.Metadata, MetaSyncFailed)
+	s.SetBranch("", "") // recycling resets the checkout to the default branch
+}
+
+// MarkRecycleFailed records a failed recycle attempt. The session stays active
+// so the recycle can be retried once the working tree is fixed.
+func (s *Session) MarkRecycleFailed(err error, now time.Time) {
+	if s.Metadata == nil {
+		s.Metadata = make(map[string]string)
+	}
+	s.Metadata[MetaRecycleError] = err.Error()
+	s.UpdatedAt = now
 }
 
 // MarkCorrupted transitions the session to the corrupted state.
@@ -162,3 +220,36 @@ func (s *Session) SetGroup(group string) {
 	}
 	s.SetMeta(MetaGroup, group)
 }
+
+// HasTag reports whether the session carries tag, ignoring case.
+func (s *Session) HasTag(tag string) bool {
+	return slices.ContainsFunc(s.Tags, func(t string) bool { return strings.EqualFold(t, tag) })
+}
+
+// AddTags appends tags the session does not already carry.
+func (s *Session) AddTags(tags ...string) {
+	for _, tag := range tags {
+		if !s.HasTag(tag) {
+			s.Tags = append(s.Tags, tag)
+		}
+	}
+}
+
+// RemoveTags drops tags from the session, ignoring case.
+func (s *Session) RemoveTags(tags ...string) {
+	s.Tags = slices.DeleteFunc(s.Tags, func(t string) bool {
+		return slices.ContainsFunc(tags, func(r string) bool { return strings.EqualFold(t, r) })
+	})
+}
+
+// ValidateTag returns an error if tag cannot be used as a session tag.
+// Tags are single words so they can be matched by the tag:<name> filter.
+func ValidateTag(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("tag cannot be empty")
+	}
+	if strings.ContainsFunc(tag, unicode.IsSpace) || strings.Contains(tag, ",") {
+		return fmt.Errorf("invalid tag %q: tags cannot contain spaces or commas", tag)
+	}
+	return nil
+}
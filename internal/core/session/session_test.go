@@ -1,6 +1,7 @@
 package session
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -48,6 +49,41 @@ func TestSession_MarkRecycled(t *testing.T) {
 	assert.Equal(t, now, s.UpdatedAt)
 }
 
+func TestSession_MarkRecycleFailed(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	s := Session{ID: "test-id", State: StateActive}
+	require.False(t, s.NeedsAttention())
+
+	s.MarkRecycleFailed(errors.New("execute recycle command \"git checkout -f main\": exit status 1"), now)
+
+	assert.True(t, s.NeedsAttention())
+	assert.True(t, s.CanRecycle(), "a failed recycle can be retried")
+	assert.Equal(t, now, s.UpdatedAt)
+
+	s.MarkRecycled(now)
+	assert.False(t, s.NeedsAttention())
+	assert.Empty(t, s.GetMeta(MetaRecycleError))
+}
+
+func TestSession_MarkArchived(t *testing.T) {
+	archivedAt := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	s := Session{ID: "test-id", State: StateActive}
+
+	summary := ArchiveSummary{
+		ArchivedAt:  archivedAt,
+		Branch:      "feat/login",
+		Additions:   42,
+		Deletions:   7,
+		Uncommitted: true,
+	}
+	s.MarkArchived(summary)
+
+	assert.Equal(t, StateArchived, s.State)
+	assert.False(t, s.CanRecycle())
+	assert.Equal(t, archivedAt, s.UpdatedAt)
+	assert.Equal(t, summary, s.ArchiveSummary())
+}
+
 func TestSession_InboxTopic(t *testing.T) {
 	s := Session{ID: "abc123"}
 	assert.Equal(t, "agent.abc123.inbox", s.InboxTopic())
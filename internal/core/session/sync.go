@@ -0,0 +1,42 @@
+package session
+
+import "time"
+
+// Metadata keys for syncing a session's branch with its base branch.
+const (
+	MetaSyncedAt   = "synced_at"   // RFC 3339 time of the last successful sync
+	MetaSyncFailed = "sync_failed" // error from the last failed sync, usually a conflict
+)
+
+// SyncedAt returns when the session's branch was last synced with its base
+// branch, and false if it has not been.
+func (s *Session) SyncedAt() (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, s.GetMeta(MetaSyncedAt))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// SyncConflict returns the error from the last failed sync, or "" if the
+// last sync succeeded or none was run.
+func (s *Session) SyncConflict() string {
+	if s.State != StateActive {
+		return ""
+	}
+	return s.GetMeta(MetaSyncFailed)
+}
+
+// MarkSynced records a successful sync, clearing any earlier failure.
+func (s *Session) MarkSynced(now time.Time) {
+	s.SetMeta(MetaSyncedAt, now.UTC().Format(time.RFC3339))
+	delete(s.Metadata, MetaSyncFailed)
+	s.UpdatedAt = now
+}
+
+// MarkSyncFailed records a failed sync. The working tree is left as the sync
+// commands left it, mid-rebase or mid-merge, for the agent or user to resolve.
+func (s *Session) MarkSyncFailed(err error, now time.Time) {
+	s.SetMeta(MetaSyncFailed, err.Error())
+	s.UpdatedAt = now
+}
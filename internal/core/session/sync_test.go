@@ -0,0 +1,33 @@
+package session
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSession_Sync(t *testing.T) {
+	now := time.Date(2026, 3, 1, 17, 0, 0, 0, time.UTC)
+	s := Session{ID: "test-id", State: StateActive}
+
+	_, ok := s.SyncedAt()
+	assert.False(t, ok)
+
+	s.MarkSyncFailed(errors.New("rebase conflict"), now)
+	assert.Equal(t, "rebase conflict", s.SyncConflict())
+
+	s.MarkSynced(now)
+	assert.Empty(t, s.SyncConflict())
+	synced, ok := s.SyncedAt()
+	assert.True(t, ok)
+	assert.Equal(t, now, synced)
+
+	s.MarkSyncFailed(errors.New("rebase conflict"), now)
+	s.MarkRecycled(now)
+	s.State = StateActive
+	assert.Empty(t, s.SyncConflict(), "recycling clears the sync state")
+	_, ok = s.SyncedAt()
+	assert.False(t, ok)
+}
@@ -12,6 +12,7 @@ var (
 	IconBee       = "\U000F0FA1" // 󰾡
 	IconHive      = "\U000F10CE" // 󱃎
 	IconComment   = "\uf41f "
+	IconNote      = "\uf249 " // fa-sticky_note
 
 	IconTodo = "\uf4a0 " // checklist
 	IconLink = " "      // oct-link
@@ -83,6 +83,7 @@ var (
 	ReviewSearchMatchStyle        lipgloss.Style
 	ReviewCurrentSearchMatchStyle lipgloss.Style
 	ReviewCommentedLineNumStyle   lipgloss.Style
+	ReviewCommentedSpanStyle      lipgloss.Style
 	ReviewSearchInputStyle        lipgloss.Style
 	ReviewModeNormalStyle         lipgloss.Style
 	ReviewModeVisualStyle         lipgloss.Style
@@ -91,6 +92,7 @@ var (
 	ReviewHelpStyle               lipgloss.Style
 	ReviewStatusBarBgStyle        lipgloss.Style
 	ReviewInlineCommentStyle      lipgloss.Style
+	ReviewInlineAnnotationStyle   lipgloss.Style
 
 	ReviewTreeHeaderStyle         lipgloss.Style
 	ReviewTreeHeaderSelectedStyle lipgloss.Style
@@ -275,6 +277,9 @@ func SetTheme(p Palette) {
 	ReviewCommentedLineNumStyle = lipgloss.NewStyle().
 		Foreground(ColorWarning).
 		Bold(true)
+	ReviewCommentedSpanStyle = lipgloss.NewStyle().
+		Foreground(ColorWarning).
+		Underline(true)
 	ReviewSearchInputStyle = lipgloss.NewStyle().
 		Foreground(ColorPrimary).
 		Background(ColorBackground).
@@ -309,6 +314,11 @@ func SetTheme(p Palette) {
 		Background(ColorBackground).
 		Padding(0, 1).
 		Bold(true)
+	ReviewInlineAnnotationStyle = lipgloss.NewStyle().
+		Foreground(ColorMuted).
+		Background(ColorBackground).
+		Padding(0, 1).
+		Italic(true)
 
 	ReviewTreeHeaderStyle = lipgloss.NewStyle().
 		Bold(true).
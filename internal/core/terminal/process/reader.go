@@ -1,5 +1,7 @@
 package process
 
+import "errors"
+
 // ProcessReader abstracts OS-level process introspection.
 // Unit tests inject fakes; production uses OSReader.
 type ProcessReader interface {
@@ -33,3 +35,22 @@ func (OSReader) Environ(pid int) map[string]string { return environForPID(pid) }
 
 // Children returns direct child PIDs of the given pid.
 func (OSReader) Children(pid int) ([]int, error) { return childrenForPID(pid) }
+
+// NoReader reports no process information. It stands in for OSReader when
+// panes run on another host, whose PIDs mean nothing locally.
+type NoReader struct{}
+
+// TPGID always fails.
+func (NoReader) TPGID(int) (int, error) { return 0, errors.New("process info unavailable") }
+
+// Comm returns "".
+func (NoReader) Comm(int) string { return "" }
+
+// Cmdline always fails.
+func (NoReader) Cmdline(int) ([]string, error) { return nil, errors.New("process info unavailable") }
+
+// Environ returns nil.
+func (NoReader) Environ(int) map[string]string { return nil }
+
+// Children returns no children.
+func (NoReader) Children(int) ([]int, error) { return nil, nil }
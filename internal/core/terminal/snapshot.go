@@ -0,0 +1,45 @@
+package terminal
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/kv"
+)
+
+// StatusSnapshotKey is the KV key under which the TUI stores the statuses
+// from its latest poll, so other commands can read them without polling.
+const StatusSnapshotKey = "terminal.statuses"
+
+// StatusSnapshot is the set of terminal statuses from one poll cycle.
+type StatusSnapshot struct {
+	UpdatedAt time.Time         `json:"updated_at"`
+	Statuses  map[string]Status `json:"statuses"` // session ID -> status
+}
+
+// StatusSnapshotTTL returns how long a snapshot stays valid for a poll
+// interval. Snapshots outlive a few missed polls, then expire so a closed TUI
+// does not leave stale statuses behind.
+func StatusSnapshotTTL(pollInterval time.Duration) time.Duration {
+	return max(3*pollInterval, 30*time.Second)
+}
+
+// LoadStatusSnapshot reads the snapshot from store. A missing or expired
+// snapshot means no TUI is polling and returns nil without an error, as does
+// a nil store.
+func LoadStatusSnapshot(ctx context.Context, store kv.KV) (*StatusSnapshot, error) {
+	if store == nil {
+		return nil, nil
+	}
+	var s StatusSnapshot
+	if err := store.Get(ctx, StatusSnapshotKey, &s); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read terminal statuses: %w", err)
+	}
+	return &s, nil
+}
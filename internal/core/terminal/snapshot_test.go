@@ -0,0 +1,47 @@
+package terminal
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/kv"
+)
+
+// snapshotKV is a kv.KV whose Get returns err.
+type snapshotKV struct {
+	kv.KV
+	err error
+}
+
+func (s snapshotKV) Get(_ context.Context, key string, dest any) error {
+	if s.err != nil {
+		return s.err
+	}
+	*dest.(*StatusSnapshot) = StatusSnapshot{Statuses: map[string]Status{"a": StatusActive}}
+	return nil
+}
+
+func TestLoadStatusSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	snap, err := LoadStatusSnapshot(ctx, nil)
+	require.NoError(t, err)
+	assert.Nil(t, snap)
+
+	snap, err = LoadStatusSnapshot(ctx, snapshotKV{err: fmt.Errorf("kv get: %w", sql.ErrNoRows)})
+	require.NoError(t, err, "a missing snapshot means no TUI is polling")
+	assert.Nil(t, snap)
+
+	_, err = LoadStatusSnapshot(ctx, snapshotKV{err: errors.New("database is locked")})
+	require.ErrorContains(t, err, "database is locked")
+
+	snap, err = LoadStatusSnapshot(ctx, snapshotKV{})
+	require.NoError(t, err)
+	assert.Equal(t, StatusActive, snap.Statuses["a"])
+}
@@ -1,7 +1,10 @@
 // Package terminal provides interfaces for terminal multiplexer integrations.
 package terminal
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Status represents the detected state of a terminal session.
 type Status string
@@ -15,13 +18,14 @@ const (
 
 // SessionInfo holds information about a discovered terminal session.
 type SessionInfo struct {
-	Name         string // terminal session name (e.g., tmux session name)
-	WindowIndex  string // tmux window index (e.g., "0", "1")
-	PaneID       string // tmux pane ID in %N format
-	WindowName   string // window name (for display and template data)
-	Status       Status // current detected status
-	DetectedTool string // detected AI tool (claude, gemini, etc.)
-	PaneContent  string // captured pane content for preview
+	Name         string    // terminal session name (e.g., tmux session name)
+	WindowIndex  string    // tmux window index (e.g., "0", "1")
+	PaneID       string    // tmux pane ID in %N format
+	WindowName   string    // window name (for display and template data)
+	Status       Status    // current detected status
+	DetectedTool string    // detected AI tool (claude, gemini, etc.)
+	PaneContent  string    // captured pane content for preview
+	Activity     time.Time // last terminal activity (zero if unknown)
 }
 
 // Integration defines the interface for terminal multiplexer integrations.
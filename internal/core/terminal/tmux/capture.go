@@ -4,14 +4,27 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+
+	"github.com/colonyops/hive/pkg/executil"
 )
 
 // TmuxCapture implements classifier.ContentCapture via tmux capture-pane.
-type TmuxCapture struct{}
+type TmuxCapture struct {
+	Exec executil.Executor // runs tmux; nil runs the local tmux directly
+}
 
 // CapturePane captures content from a tmux pane or target address.
-func (TmuxCapture) CapturePane(ctx context.Context, target string) (string, error) {
-	output, err := exec.CommandContext(ctx, "tmux", "capture-pane", "-t", target, "-p", "-J").Output()
+func (c TmuxCapture) CapturePane(ctx context.Context, target string) (string, error) {
+	args := []string{"capture-pane", "-t", target, "-p", "-J"}
+	if c.Exec != nil {
+		output, err := c.Exec.Run(ctx, "tmux", args...)
+		if err != nil {
+			return "", fmt.Errorf("capture-pane failed: %w", err)
+		}
+		return string(output), nil
+	}
+
+	output, err := exec.CommandContext(ctx, "tmux", args...).Output()
 	if err != nil {
 		return "", fmt.Errorf("capture-pane failed: %w", err)
 	}
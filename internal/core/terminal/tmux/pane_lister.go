@@ -1,11 +1,14 @@
 package tmux
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/colonyops/hive/internal/core/terminal/classifier"
+	"github.com/colonyops/hive/pkg/executil"
 )
 
 // PaneLister abstracts tmux pane enumeration.
@@ -15,7 +18,13 @@ type PaneLister interface {
 }
 
 // TmuxPaneLister calls tmux list-panes -a and parses the output.
-type TmuxPaneLister struct{}
+type TmuxPaneLister struct {
+	Exec executil.Executor // runs tmux; nil runs the local tmux directly
+}
+
+// listPanesTimeout bounds a list-panes call through an executor, which may
+// be an ssh connection to an unreachable host.
+const listPanesTimeout = 10 * time.Second
 
 // listPanesFormat is the delimited format used for `tmux list-panes -a`.
 // tmux replaces literal tab format separators with underscores, so use a
@@ -42,8 +51,16 @@ type paneLine struct {
 }
 
 // ListAllPanes returns all tmux panes visible to the current tmux client.
-func (TmuxPaneLister) ListAllPanes() ([]classifier.PaneInput, error) {
-	output, err := exec.Command("tmux", "list-panes", "-a", "-F", listPanesFormat).Output()
+func (l TmuxPaneLister) ListAllPanes() ([]classifier.PaneInput, error) {
+	var output []byte
+	var err error
+	if l.Exec != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), listPanesTimeout)
+		defer cancel()
+		output, err = l.Exec.Run(ctx, "tmux", "list-panes", "-a", "-F", listPanesFormat)
+	} else {
+		output, err = exec.Command("tmux", "list-panes", "-a", "-F", listPanesFormat).Output()
+	}
 	if err != nil {
 		return nil, fmt.Errorf("tmux list-panes failed: %w", err)
 	}
@@ -0,0 +1,26 @@
+package tmux
+
+import (
+	"context"
+	"testing"
+
+	"github.com/colonyops/hive/pkg/executil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutorRunsTmux(t *testing.T) {
+	rec := &executil.RecordingExecutor{Outputs: map[string][]byte{"tmux": []byte("pane text\n")}}
+
+	_, err := TmuxPaneLister{Exec: rec}.ListAllPanes()
+	require.NoError(t, err)
+	out, err := TmuxCapture{Exec: rec}.CapturePane(context.Background(), "%1")
+	require.NoError(t, err)
+	assert.Equal(t, "pane text\n", out)
+
+	require.Len(t, rec.Commands, 2)
+	assert.Equal(t, []string{"list-panes", "-a", "-F", listPanesFormat}, rec.Commands[0].Args)
+	assert.Equal(t, []string{"capture-pane", "-t", "%1", "-p", "-J"}, rec.Commands[1].Args)
+
+	assert.True(t, NewFromPreviewMatchers(nil, WithExecutor(rec)).Available())
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/colonyops/hive/internal/core/terminal/classifier"
 	"github.com/colonyops/hive/internal/core/terminal/content"
 	"github.com/colonyops/hive/internal/core/terminal/process"
+	"github.com/colonyops/hive/pkg/executil"
 )
 
 // contentCheckInterval is the minimum time between Tier 3 content-capture
@@ -38,6 +39,7 @@ type Integration struct {
 	lister          PaneLister
 	capture         classifier.ContentCapture
 	recorder        CaptureRecorder
+	exec            executil.Executor // runs tmux remotely; nil runs it locally
 }
 
 // sessionCache holds all panes for a single tmux session.
@@ -128,16 +130,36 @@ func WithCaptureRecorder(recorder CaptureRecorder) Option {
 	}
 }
 
+// WithExecutor runs tmux through exec, such as an executil.SSHExecutor for
+// sessions on another host. Process inspection is disabled, since pane PIDs
+// then belong to that host; agents are detected from titles and content.
+func WithExecutor(exec executil.Executor) Option {
+	return func(integration *Integration) {
+		integration.exec = exec
+	}
+}
+
 // NewFromPreviewMatchers creates the production tmux integration from config
 // matchers. Tool names for process detection are derived automatically from
 // the pattern strings (e.g. "^pi$" → "pi"), so callers only need to pass
 // the single PreviewWindowMatcher slice from config.
 func NewFromPreviewMatchers(previewMatchers []string, options ...Option) *Integration {
-	capture := TmuxCapture{}
-	reader := process.OSReader{}
+	// The executor decides how panes are listed and read, so resolve it
+	// before building the classifier.
+	var opts Integration
+	for _, option := range options {
+		option(&opts)
+	}
+
+	capture := TmuxCapture{Exec: opts.exec}
+	var reader process.ProcessReader = process.OSReader{}
+	if opts.exec != nil {
+		reader = process.NoReader{}
+	}
 	agentNames := classifier.ToolNamesFromPatterns(previewMatchers)
 	cls := classifier.New(classifier.TitlePatternsFromConfig(previewMatchers, agentNames), reader, capture, content.NewScorer())
-	integration := NewWithReader(cls, TmuxPaneLister{}, reader)
+	integration := NewWithReader(cls, TmuxPaneLister{Exec: opts.exec}, reader)
+	integration.capture = capture
 	for _, option := range options {
 		option(integration)
 	}
@@ -183,6 +205,11 @@ func (t *Integration) Name() string { return "tmux" }
 // Available returns true if tmux is installed and accessible.
 func (t *Integration) Available() bool {
 	t.availableOnce.Do(func() {
+		if t.exec != nil {
+			// The host's tmux is checked when it is first used.
+			t.available = true
+			return
+		}
 		_, err := exec.LookPath("tmux")
 		t.available = err == nil
 	})
@@ -362,6 +389,10 @@ func (t *Integration) DiscoverSession(_ context.Context, slug string, metadata m
 		return nil, nil
 	}
 
+	if pane := sc.findAgentPane(metadata[session.MetaTmuxPane]); pane != nil {
+		return sessionInfoFromPane(sessionName, pane), nil
+	}
+
 	windowIdx := metadata[session.MetaTmuxWindow]
 	if windowIdx == "" {
 		windowIdx = metadata["tmux_pane"]
@@ -402,13 +433,17 @@ func sessionInfoFromPane(sessionName string, pane *cachedPane) *terminal.Session
 	if pane == nil {
 		return nil
 	}
-	return &terminal.SessionInfo{
+	info := &terminal.SessionInfo{
 		Name:         sessionName,
 		WindowIndex:  pane.input.WindowIndex,
 		PaneID:       pane.input.PaneID,
 		WindowName:   pane.input.WindowName,
 		DetectedTool: pane.result.Tool,
 	}
+	if pane.input.Activity > 0 {
+		info.Activity = time.Unix(pane.input.Activity, 0)
+	}
+	return info
 }
 
 // GetStatus returns the current status of a specific agent pane.
@@ -287,6 +287,16 @@ func TestDiscoverSession(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, info)
 	assert.Equal(t, "%1", info.PaneID)
+
+	info, err = integ.DiscoverSession(context.Background(), "my-session", map[string]string{"tmux_pane_id": "%2", "tmux_window": "0"})
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, "%2", info.PaneID, "recorded pane ID wins over window index")
+
+	info, err = integ.DiscoverSession(context.Background(), "my-session", map[string]string{"tmux_pane_id": "%9", "tmux_window": "0"})
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, "%1", info.PaneID, "unknown pane ID falls back to window index")
 }
 
 func TestDiscoverAllPanes(t *testing.T) {
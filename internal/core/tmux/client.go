@@ -31,6 +31,13 @@ type RenderedWindow struct {
 	Panes   []RenderedPane // Panes to create in this window; mutually exclusive with Command
 }
 
+// WindowTarget identifies a tmux window created for a session and its panes.
+type WindowTarget struct {
+	Index string   `json:"index"` // window index (e.g. "0")
+	Name  string   `json:"name"`
+	Panes []string `json:"panes"` // pane IDs in %N format, in pane order
+}
+
 // Client creates and manages tmux sessions from window definitions.
 type Client struct {
 	exec executil.Executor
@@ -145,6 +152,70 @@ func (c *Client) AttachOrSwitch(ctx context.Context, name string) error {
 	return nil
 }
 
+// RenameSession renames the tmux session oldName to newName. Panes tagged with
+// @hive-session oldName, and windows named oldName, follow the new name so
+// terminal discovery keeps working. It reports false without error when
+// oldName does not exist; tmux rejects a newName that is already taken.
+func (c *Client) RenameSession(ctx context.Context, oldName, newName string) (bool, error) {
+	if !c.HasSession(ctx, oldName) {
+		return false, nil
+	}
+	if out, err := c.exec.Run(ctx, "tmux", "rename-session", "-t", oldName, newName); err != nil {
+		return false, fmt.Errorf("tmux rename-session %q: %w; output: %s", oldName, err, strings.TrimSpace(string(out)))
+	}
+
+	out, err := c.exec.Run(ctx, "tmux", "list-panes", "-s", "-t", newName, "-F", "#{pane_id}\t#{@hive-session}")
+	if err != nil {
+		c.log.Debug().Err(err).Str("session", newName).Msg("failed to list panes for retagging")
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		paneID, tag, ok := strings.Cut(line, "\t")
+		if ok && tag == oldName {
+			c.tagPanesWithSession(ctx, paneID, newName)
+		}
+	}
+
+	out, err = c.exec.Run(ctx, "tmux", "list-windows", "-t", newName, "-F", "#{window_id}\t#{window_name}")
+	if err != nil {
+		c.log.Debug().Err(err).Str("session", newName).Msg("failed to list windows for renaming")
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		windowID, name, ok := strings.Cut(line, "\t")
+		if !ok || name != oldName {
+			continue
+		}
+		if _, err := c.exec.Run(ctx, "tmux", "rename-window", "-t", windowID, newName); err != nil {
+			c.log.Debug().Err(err).Str("window", windowID).Msg("failed to rename window")
+		}
+	}
+
+	return true, nil
+}
+
+// ListTargets returns the windows of a session with their pane IDs, in window
+// order.
+func (c *Client) ListTargets(ctx context.Context, name string) ([]WindowTarget, error) {
+	out, err := c.exec.Run(ctx, "tmux", "list-panes", "-s", "-t", name, "-F", "#{window_index}\t#{window_name}\t#{pane_id}")
+	if err != nil {
+		return nil, fmt.Errorf("tmux list-panes %q: %w; output: %s", name, err, strings.TrimSpace(string(out)))
+	}
+
+	var targets []WindowTarget
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		parts := strings.Split(line, "\t")
+		if len(parts) != 3 {
+			continue
+		}
+		index, windowName, paneID := parts[0], parts[1], parts[2]
+		if n := len(targets); n > 0 && targets[n-1].Index == index {
+			targets[n-1].Panes = append(targets[n-1].Panes, paneID)
+			continue
+		}
+		targets = append(targets, WindowTarget{Index: index, Name: windowName, Panes: []string{paneID}})
+	}
+	return targets, nil
+}
+
 // OpenSession creates a session if it doesn't exist, or attaches to it.
 // If targetWindow is non-empty and the session already exists, select that legacy tmux target (window or pane).
 func (c *Client) OpenSession(ctx context.Context, name, workDir string, windows []RenderedWindow, background bool, targetWindow string) error {
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/colonyops/hive/pkg/executil"
+	"github.com/colonyops/hive/pkg/executil/executiltest"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -379,3 +380,83 @@ func TestClient_AddWindows(t *testing.T) {
 		assert.Contains(t, rec.Commands[2].Args, "/custom")
 	})
 }
+
+func TestClient_RenameSession(t *testing.T) {
+	t.Run("renames session, retags panes and renames matching windows", func(t *testing.T) {
+		exec := &executiltest.Exec{Responses: []executiltest.Response{
+			{}, // has-session
+			{}, // rename-session
+			{Out: []byte("%1\told\n%2\t\n%3\told\n")},
+			{}, // set-option %1
+			{}, // set-option %3
+			{Out: []byte("@1\tagent\n@2\told\n")},
+		}}
+		c := New(exec, nopLog)
+
+		renamed, err := c.RenameSession(context.Background(), "old", "new")
+		require.NoError(t, err)
+		assert.True(t, renamed)
+
+		calls := exec.Calls()
+		require.Len(t, calls, 7)
+		assert.Equal(t, []string{"rename-session", "-t", "old", "new"}, calls[1].Args)
+		assert.Equal(t, []string{"set-option", "-p", "-t", "%1", "@hive-session", "new"}, calls[3].Args)
+		assert.Equal(t, []string{"set-option", "-p", "-t", "%3", "@hive-session", "new"}, calls[4].Args)
+		assert.Equal(t, []string{"rename-window", "-t", "@2", "new"}, calls[6].Args)
+	})
+
+	t.Run("missing session is not an error", func(t *testing.T) {
+		rec := &executil.RecordingExecutor{
+			Errors: map[string]error{"tmux": fmt.Errorf("can't find session")},
+		}
+		c := New(rec, nopLog)
+
+		renamed, err := c.RenameSession(context.Background(), "old", "new")
+		require.NoError(t, err)
+		assert.False(t, renamed)
+		require.Len(t, rec.Commands, 1)
+	})
+
+	t.Run("rename failure is returned", func(t *testing.T) {
+		exec := &executiltest.Exec{Responses: []executiltest.Response{
+			{},
+			{Stderr: []byte("duplicate session: new"), Err: fmt.Errorf("exit status 1")},
+		}}
+		c := New(exec, nopLog)
+
+		renamed, err := c.RenameSession(context.Background(), "old", "new")
+		require.Error(t, err)
+		assert.False(t, renamed)
+		assert.Contains(t, err.Error(), "duplicate session")
+	})
+}
+
+func TestClient_ListTargets(t *testing.T) {
+	t.Run("groups panes by window", func(t *testing.T) {
+		exec := &executiltest.Exec{Responses: []executiltest.Response{
+			{Out: []byte("0\tagent\t%4\n0\tagent\t%5\n1\tshell\t%6\n")},
+		}}
+		c := New(exec, nopLog)
+
+		targets, err := c.ListTargets(context.Background(), "sess")
+		require.NoError(t, err)
+		assert.Equal(t, []WindowTarget{
+			{Index: "0", Name: "agent", Panes: []string{"%4", "%5"}},
+			{Index: "1", Name: "shell", Panes: []string{"%6"}},
+		}, targets)
+
+		calls := exec.Calls()
+		require.Len(t, calls, 1)
+		assert.Equal(t, []string{"list-panes", "-s", "-t", "sess", "-F", "#{window_index}\t#{window_name}\t#{pane_id}"}, calls[0].Args)
+	})
+
+	t.Run("error is returned", func(t *testing.T) {
+		exec := &executiltest.Exec{Responses: []executiltest.Response{
+			{Stderr: []byte("can't find session: sess"), Err: fmt.Errorf("exit status 1")},
+		}}
+		c := New(exec, nopLog)
+
+		_, err := c.ListTargets(context.Background(), "sess")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,58 @@
+// Package usage tracks agent token usage and cost per session.
+package usage
+
+import "context"
+
+// Usage is a token and cost tally.
+type Usage struct {
+	InputTokens         int64   `json:"input_tokens"`
+	OutputTokens        int64   `json:"output_tokens"`
+	CacheCreationTokens int64   `json:"cache_creation_tokens"`
+	CacheReadTokens     int64   `json:"cache_read_tokens"`
+	CostUSD             float64 `json:"cost_usd"`
+}
+
+// Add accumulates o into u.
+func (u *Usage) Add(o Usage) {
+	u.InputTokens += o.InputTokens
+	u.OutputTokens += o.OutputTokens
+	u.CacheCreationTokens += o.CacheCreationTokens
+	u.CacheReadTokens += o.CacheReadTokens
+	u.CostUSD += o.CostUSD
+}
+
+// Tokens returns the total token count, including cache reads and writes.
+func (u Usage) Tokens() int64 {
+	return u.InputTokens + u.OutputTokens + u.CacheCreationTokens + u.CacheReadTokens
+}
+
+// IsZero reports whether nothing has been recorded.
+func (u Usage) IsZero() bool {
+	return u == Usage{}
+}
+
+// Day is one session's usage on a single local calendar day.
+type Day struct {
+	SessionID string
+	Remote    string
+	Date      string // YYYY-MM-DD
+	Usage
+}
+
+// RepoDay is the usage of all sessions of one repository on a single day.
+type RepoDay struct {
+	Remote string `json:"remote"`
+	Date   string `json:"date"`
+	Usage
+}
+
+// Store persists usage.
+type Store interface {
+	// Record upserts per-day usage. Recorded counters never decrease.
+	Record(ctx context.Context, days []Day) error
+	// SessionTotals returns cumulative usage keyed by session ID.
+	SessionTotals(ctx context.Context) (map[string]Usage, error)
+	// ByRepoDay returns usage per repository and day for days on or after
+	// since (YYYY-MM-DD), ordered by day then remote.
+	ByRepoDay(ctx context.Context, since string) ([]RepoDay, error)
+}
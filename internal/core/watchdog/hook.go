@@ -0,0 +1,69 @@
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/pkg/executil"
+	"github.com/colonyops/hive/pkg/tmpl"
+)
+
+// hookTimeout bounds how long a single hook command may run.
+const hookTimeout = 30 * time.Second
+
+// HookData is the template data for the watchdog hook command.
+type HookData struct {
+	ID       string
+	Name     string
+	Path     string
+	Remote   string
+	Reason   Reason
+	Status   terminal.Status
+	Duration string // How long the session had been stalled, e.g. "5m0s"
+}
+
+// NewHookData builds the hook template data for an alert on sess.
+func NewHookData(sess session.Session, alert Alert, now time.Time) HookData {
+	return HookData{
+		ID:       sess.ID,
+		Name:     sess.Name,
+		Path:     sess.Path,
+		Remote:   sess.Remote,
+		Reason:   alert.Reason,
+		Status:   alert.Status,
+		Duration: alert.Duration(now).Truncate(time.Second).String(),
+	}
+}
+
+// Hook runs the configured shell command for each alert.
+type Hook struct {
+	command  string
+	executor executil.Executor
+	renderer *tmpl.Renderer
+}
+
+// NewHook creates a hook that renders command as a Go template and runs it
+// in the platform shell.
+func NewHook(command string, executor executil.Executor, renderer *tmpl.Renderer) *Hook {
+	return &Hook{command: command, executor: executor, renderer: renderer}
+}
+
+// Run renders and runs the hook command for data.
+func (h *Hook) Run(ctx context.Context, data HookData) error {
+	cmd, err := h.renderer.Render(h.command, data)
+	if err != nil {
+		return fmt.Errorf("render watchdog command: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+	defer cancel()
+
+	shell, args := executil.Shell(cmd)
+	if out, err := h.executor.Run(ctx, shell, args...); err != nil {
+		return fmt.Errorf("run watchdog command: %w: %s", err, out)
+	}
+	return nil
+}
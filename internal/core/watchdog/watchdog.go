@@ -0,0 +1,128 @@
+// Package watchdog detects agent sessions that have stalled, either waiting
+// for approval or producing no pane output, for longer than a threshold.
+package watchdog
+
+import (
+	"hash/fnv"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/terminal"
+)
+
+// Reason describes why a session is considered stalled.
+type Reason string
+
+const (
+	ReasonApproval Reason = "approval" // Waiting for approval longer than the approval threshold
+	ReasonIdle     Reason = "idle"     // Active, but the pane has not changed for longer than the idle threshold
+)
+
+// Alert reports a stalled session.
+type Alert struct {
+	SessionID string
+	Reason    Reason
+	Status    terminal.Status
+	Since     time.Time // When the session entered the status, or its pane last changed
+}
+
+// Duration returns how long the session had been stalled at now.
+func (a Alert) Duration(now time.Time) time.Duration {
+	return now.Sub(a.Since)
+}
+
+// sessionState is what the tracker remembers about one session between polls.
+type sessionState struct {
+	status      terminal.Status
+	statusSince time.Time
+	paneHash    uint64
+	paneSince   time.Time
+	alert       *Alert // Raised alert, cleared when the session makes progress
+}
+
+// Tracker follows session statuses across polls. It is not safe for
+// concurrent use.
+type Tracker struct {
+	approval time.Duration
+	idle     time.Duration
+	sessions map[string]*sessionState
+}
+
+// NewTracker creates a tracker. A zero threshold disables that check.
+func NewTracker(approval, idle time.Duration) *Tracker {
+	return &Tracker{
+		approval: approval,
+		idle:     idle,
+		sessions: make(map[string]*sessionState),
+	}
+}
+
+// Observe records the status and pane content of a session at now. It returns
+// an alert the first time the session crosses a threshold; the session must
+// change status or produce output before it can alert again.
+func (t *Tracker) Observe(now time.Time, sessionID string, status terminal.Status, paneContent string) (Alert, bool) {
+	hash := hashPane(paneContent)
+
+	st, ok := t.sessions[sessionID]
+	if !ok {
+		t.sessions[sessionID] = &sessionState{
+			status:      status,
+			statusSince: now,
+			paneHash:    hash,
+			paneSince:   now,
+		}
+		return Alert{}, false
+	}
+
+	if st.status != status {
+		st.status = status
+		st.statusSince = now
+		st.alert = nil
+	}
+	if st.paneHash != hash {
+		st.paneHash = hash
+		st.paneSince = now
+		if st.alert != nil && st.alert.Reason == ReasonIdle {
+			st.alert = nil
+		}
+	}
+	if st.alert != nil {
+		return Alert{}, false
+	}
+
+	var alert Alert
+	switch {
+	case status == terminal.StatusApproval && t.approval > 0 && now.Sub(st.statusSince) >= t.approval:
+		alert = Alert{SessionID: sessionID, Reason: ReasonApproval, Status: status, Since: st.statusSince}
+	case status == terminal.StatusActive && t.idle > 0 && now.Sub(st.paneSince) >= t.idle:
+		alert = Alert{SessionID: sessionID, Reason: ReasonIdle, Status: status, Since: st.paneSince}
+	default:
+		return Alert{}, false
+	}
+	st.alert = &alert
+	return alert, true
+}
+
+// Stalled returns the alert raised for a session that is still stalled.
+func (t *Tracker) Stalled(sessionID string) (Alert, bool) {
+	st, ok := t.sessions[sessionID]
+	if !ok || st.alert == nil {
+		return Alert{}, false
+	}
+	return *st.alert, true
+}
+
+// Retain forgets every session for which keep returns false, e.g. sessions
+// that were deleted or stopped reporting a status.
+func (t *Tracker) Retain(keep func(sessionID string) bool) {
+	for id := range t.sessions {
+		if !keep(id) {
+			delete(t.sessions, id)
+		}
+	}
+}
+
+func hashPane(content string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(content))
+	return h.Sum64()
+}
@@ -0,0 +1,118 @@
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/pkg/executil"
+	"github.com/colonyops/hive/pkg/tmpl"
+)
+
+func TestTracker_Approval(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tr := NewTracker(5*time.Minute, 0)
+
+	_, ok := tr.Observe(base, "a", terminal.StatusApproval, "Allow edit?")
+	assert.False(t, ok, "first observation only records state")
+	_, ok = tr.Observe(base.Add(4*time.Minute), "a", terminal.StatusApproval, "Allow edit?")
+	assert.False(t, ok)
+
+	alert, ok := tr.Observe(base.Add(5*time.Minute), "a", terminal.StatusApproval, "Allow edit?")
+	require.True(t, ok)
+	assert.Equal(t, Alert{SessionID: "a", Reason: ReasonApproval, Status: terminal.StatusApproval, Since: base}, alert)
+	assert.Equal(t, 5*time.Minute, alert.Duration(base.Add(5*time.Minute)))
+
+	_, ok = tr.Observe(base.Add(10*time.Minute), "a", terminal.StatusApproval, "Allow edit?")
+	assert.False(t, ok, "alerts once per stall")
+	stalled, ok := tr.Stalled("a")
+	require.True(t, ok)
+	assert.Equal(t, ReasonApproval, stalled.Reason)
+
+	_, ok = tr.Observe(base.Add(11*time.Minute), "a", terminal.StatusActive, "working")
+	assert.False(t, ok)
+	_, ok = tr.Stalled("a")
+	assert.False(t, ok, "changing status clears the stall")
+}
+
+func TestTracker_Idle(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tr := NewTracker(0, 10*time.Minute)
+
+	tr.Observe(base, "a", terminal.StatusActive, "step 1")
+	_, ok := tr.Observe(base.Add(8*time.Minute), "a", terminal.StatusActive, "step 2")
+	assert.False(t, ok)
+
+	_, ok = tr.Observe(base.Add(17*time.Minute), "a", terminal.StatusActive, "step 2")
+	assert.False(t, ok, "pane changed at 8m")
+
+	alert, ok := tr.Observe(base.Add(18*time.Minute), "a", terminal.StatusActive, "step 2")
+	require.True(t, ok)
+	assert.Equal(t, ReasonIdle, alert.Reason)
+	assert.Equal(t, base.Add(8*time.Minute), alert.Since)
+
+	_, ok = tr.Observe(base.Add(19*time.Minute), "a", terminal.StatusActive, "step 3")
+	assert.False(t, ok)
+	_, ok = tr.Stalled("a")
+	assert.False(t, ok, "new output clears the stall")
+
+	// Ready agents are expected to sit without output.
+	tr.Observe(base, "b", terminal.StatusReady, "$")
+	_, ok = tr.Observe(base.Add(time.Hour), "b", terminal.StatusReady, "$")
+	assert.False(t, ok)
+}
+
+func TestTracker_DisabledThresholds(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tr := NewTracker(0, 0)
+
+	tr.Observe(base, "a", terminal.StatusApproval, "")
+	tr.Observe(base, "b", terminal.StatusActive, "")
+	_, ok := tr.Observe(base.Add(24*time.Hour), "a", terminal.StatusApproval, "")
+	assert.False(t, ok)
+	_, ok = tr.Observe(base.Add(24*time.Hour), "b", terminal.StatusActive, "")
+	assert.False(t, ok)
+}
+
+func TestTracker_Retain(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tr := NewTracker(time.Minute, 0)
+
+	tr.Observe(base, "a", terminal.StatusApproval, "")
+	tr.Observe(base.Add(time.Minute), "a", terminal.StatusApproval, "")
+	_, ok := tr.Stalled("a")
+	require.True(t, ok)
+
+	tr.Retain(func(id string) bool { return id != "a" })
+	_, ok = tr.Stalled("a")
+	assert.False(t, ok)
+
+	_, ok = tr.Observe(base.Add(2*time.Minute), "a", terminal.StatusApproval, "")
+	assert.False(t, ok, "a forgotten session starts over")
+}
+
+func TestHook_Run(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	sess := session.Session{ID: "abc", Name: "fix auth", Path: "/work/fix-auth"}
+	alert := Alert{SessionID: "abc", Reason: ReasonIdle, Status: terminal.StatusActive, Since: now.Add(-12 * time.Minute)}
+
+	data := NewHookData(sess, alert, now)
+	assert.Equal(t, "12m0s", data.Duration)
+
+	exec := &executil.RecordingExecutor{}
+	hook := NewHook(`notify-send {{ shq .Name }} "{{ .Reason }} for {{ .Duration }}"`, exec, tmpl.New(tmpl.Config{}))
+	require.NoError(t, hook.Run(context.Background(), data))
+
+	require.Len(t, exec.Commands, 1)
+	assert.Equal(t, "sh", exec.Commands[0].Cmd)
+	assert.Equal(t, []string{"-c", `notify-send 'fix auth' "idle for 12m0s"`}, exec.Commands[0].Args)
+
+	exec.Errors = map[string]error{"sh": errors.New("exit status 1")}
+	assert.Error(t, hook.Run(context.Background(), data))
+}
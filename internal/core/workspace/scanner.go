@@ -22,7 +22,7 @@ type DiscoveredRepo struct {
 // ScanRepoDirs scans parent directories for git repositories.
 // Each directory in dirs is expected to contain subdirectories that are git repos.
 // Repositories that fail to scan are silently skipped.
-func ScanRepoDirs(ctx context.Context, dirs []string, gitExec git.Git) ([]DiscoveredRepo, error) {
+func ScanRepoDirs(ctx context.Context, dirs []string, gitExec git.VCS) ([]DiscoveredRepo, error) {
 	var repos []DiscoveredRepo
 
 	for _, dir := range dirs {
@@ -8,6 +8,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/git"
 )
 
 type mockGit struct {
@@ -26,8 +28,18 @@ func (m *mockGit) IsValidRepo(context.Context, string) error
 func (m *mockGit) CloneBare(context.Context, string, string) error              { return nil }
 func (m *mockGit) WorktreeAdd(context.Context, string, string, string) error    { return nil }
 func (m *mockGit) WorktreeRemove(context.Context, string, string, string) error { return nil }
+func (m *mockGit) WorktreePrune(context.Context, string) error                  { return nil }
 func (m *mockGit) Fetch(context.Context, string) error                          { return nil }
-func (m *mockGit) HasUnpushedCommits(context.Context, string) (bool, error)     { return false, nil }
+func (m *mockGit) ForkBranch(context.Context, string, string, string) error     { return nil }
+func (m *mockGit) StartBranch(context.Context, string, string, string) error    { return nil }
+func (m *mockGit) Compare(context.Context, string, string) (git.Divergence, error) {
+	return git.Divergence{}, nil
+}
+func (m *mockGit) UnpushedCommits(context.Context, string) (int, error)   { return 0, nil }
+func (m *mockGit) ChangedFiles(context.Context, string) ([]string, error) { return nil, nil }
+func (m *mockGit) HeadStatus(context.Context, string) (git.HeadStatus, error) {
+	return git.HeadStatus{}, nil
+}
 func (m *mockGit) RemoteURL(_ context.Context, dir string) (string, error) {
 	if remote, ok := m.remotes[dir]; ok {
 		return remote, nil
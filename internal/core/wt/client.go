@@ -0,0 +1,200 @@
+// Package wt opens hive sessions in Windows Terminal, the session backend on
+// Windows where tmux is unavailable. Each session is a named Windows Terminal
+// window and each configured window becomes a tab in it.
+package wt
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	coretmux "github.com/colonyops/hive/internal/core/tmux"
+	"github.com/colonyops/hive/pkg/executil"
+)
+
+// Client creates Windows Terminal windows from rendered window definitions.
+// It implements the same session operations as the tmux client.
+type Client struct {
+	exec executil.Executor
+	log  zerolog.Logger
+
+	mu     sync.Mutex
+	opened map[string]bool // sessions opened by this process
+}
+
+// New creates a Client with the given executor and logger.
+func New(exec executil.Executor, log zerolog.Logger) *Client {
+	return &Client{exec: exec, log: log, opened: make(map[string]bool)}
+}
+
+// CreateSession opens a Windows Terminal window named name with one tab per
+// window. Windows Terminal has no detached sessions, so the window opens even
+// when background is set.
+func (c *Client) CreateSession(ctx context.Context, name, workDir string, windows []coretmux.RenderedWindow, background bool) error {
+	if len(windows) == 0 {
+		return fmt.Errorf("wt: at least one window is required")
+	}
+
+	args := []string{"-w", name}
+	args = appendTabs(args, workDir, windows)
+	args = append(args, ";", "focus-tab", "-t", strconv.Itoa(focusIndex(windows)))
+	if err := c.run(ctx, args); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.opened[name] = true
+	c.mu.Unlock()
+	return nil
+}
+
+// AddWindows opens windows as new tabs in the session's window.
+func (c *Client) AddWindows(ctx context.Context, name, workDir string, windows []coretmux.RenderedWindow) error {
+	if len(windows) == 0 {
+		return nil
+	}
+	return c.run(ctx, appendTabs([]string{"-w", name}, workDir, windows))
+}
+
+// AttachOrSwitch brings the session's window to the front on its first tab.
+func (c *Client) AttachOrSwitch(ctx context.Context, name string) error {
+	return c.run(ctx, []string{"-w", name, "focus-tab", "-t", "0"})
+}
+
+// OpenSession focuses the session's window when this process already opened
+// it, and creates it otherwise. Windows Terminal cannot list its windows, so
+// a window opened by another hive process is created again as new tabs.
+func (c *Client) OpenSession(ctx context.Context, name, workDir string, windows []coretmux.RenderedWindow, background bool, _ string) error {
+	c.mu.Lock()
+	opened := c.opened[name]
+	c.mu.Unlock()
+
+	if opened {
+		if background {
+			return nil
+		}
+		return c.AttachOrSwitch(ctx, name)
+	}
+	return c.CreateSession(ctx, name, workDir, windows, background)
+}
+
+// RenameSession reports false: Windows Terminal windows cannot be renamed
+// from the command line, so the window keeps its original name.
+func (c *Client) RenameSession(_ context.Context, _, _ string) (bool, error) {
+	return false, nil
+}
+
+// ListTargets returns no targets since Windows Terminal does not expose its
+// tabs and panes to other processes.
+func (c *Client) ListTargets(_ context.Context, _ string) ([]coretmux.WindowTarget, error) {
+	return nil, nil
+}
+
+func (c *Client) run(ctx context.Context, args []string) error {
+	c.log.Debug().Strs("args", args).Msg("wt")
+	if out, err := c.exec.Run(ctx, "wt.exe", args...); err != nil {
+		return fmt.Errorf("wt: %w; output: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// appendTabs appends a new-tab subcommand for each window, followed by
+// split-pane subcommands for its additional panes. Subcommands are separated
+// by ";" arguments.
+func appendTabs(args []string, workDir string, windows []coretmux.RenderedWindow) []string {
+	for i, w := range windows {
+		if i > 0 {
+			args = append(args, ";")
+		}
+
+		dir := w.Dir
+		if dir == "" {
+			dir = workDir
+		}
+		command := w.Command
+		if len(w.Panes) > 0 {
+			command = w.Panes[0].Command
+			if w.Panes[0].Dir != "" {
+				dir = w.Panes[0].Dir
+			}
+		}
+		args = append(args, "new-tab", "--title", w.Name)
+		args = appendPaneArgs(args, dir, command)
+
+		for j := 1; j < len(w.Panes); j++ {
+			p := w.Panes[j]
+			args = append(args, ";", "split-pane", splitFlag(p.Split))
+			if size, ok := paneSize(p.Size); ok {
+				args = append(args, "--size", size)
+			}
+			args = append(args, "--title", w.Name)
+			paneDir := p.Dir
+			if paneDir == "" {
+				paneDir = dir
+			}
+			args = appendPaneArgs(args, paneDir, p.Command)
+		}
+	}
+	return args
+}
+
+// appendPaneArgs appends the starting directory and, when command is set,
+// the shell command line the tab or pane runs.
+func appendPaneArgs(args []string, dir, command string) []string {
+	if dir != "" {
+		args = append(args, "-d", dir)
+	}
+	if command == "" {
+		return args
+	}
+	shell, shellArgs := executil.Shell(command)
+	args = append(args, shell)
+	for _, a := range shellArgs {
+		args = append(args, escapeDelimiters(a))
+	}
+	return args
+}
+
+// escapeDelimiters escapes ";" so Windows Terminal does not treat it as the
+// start of the next subcommand.
+func escapeDelimiters(s string) string {
+	return strings.ReplaceAll(s, ";", `\;`)
+}
+
+// splitFlag maps a tmux split direction to Windows Terminal's. tmux splits
+// "horizontal" into side-by-side panes, which Windows Terminal calls a
+// vertical split.
+func splitFlag(split string) string {
+	if split == "horizontal" {
+		return "-V"
+	}
+	return "-H"
+}
+
+// paneSize converts a tmux percentage size ("30%") to the fraction Windows
+// Terminal expects. Absolute line and column counts have no equivalent.
+func paneSize(size string) (string, bool) {
+	pct, ok := strings.CutSuffix(size, "%")
+	if !ok {
+		return "", false
+	}
+	n, err := strconv.Atoi(pct)
+	if err != nil || n <= 0 || n >= 100 {
+		return "", false
+	}
+	return strconv.FormatFloat(float64(n)/100, 'f', -1, 64), true
+}
+
+// focusIndex returns the index of the first window with Focus set, else 0.
+func focusIndex(windows []coretmux.RenderedWindow) int {
+	for i, w := range windows {
+		if w.Focus {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,98 @@
+package wt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coretmux "github.com/colonyops/hive/internal/core/tmux"
+	"github.com/colonyops/hive/pkg/executil"
+)
+
+var nopLog = zerolog.Nop()
+
+// shellArgs returns the command line a tab runs for command on this platform.
+func shellArgs(command string) []string {
+	shell, args := executil.Shell(command)
+	out := []string{shell}
+	for _, a := range args {
+		out = append(out, escapeDelimiters(a))
+	}
+	return out
+}
+
+func TestClient_CreateSession(t *testing.T) {
+	rec := &executil.RecordingExecutor{}
+	c := New(rec, nopLog)
+
+	err := c.CreateSession(context.Background(), "sess", `C:\work`, []coretmux.RenderedWindow{
+		{Name: "claude", Command: "claude; echo done"},
+		{Name: "shell", Focus: true},
+	}, true)
+	require.NoError(t, err)
+
+	require.Len(t, rec.Commands, 1)
+	assert.Equal(t, "wt.exe", rec.Commands[0].Cmd)
+
+	want := []string{"-w", "sess", "new-tab", "--title", "claude", "-d", `C:\work`}
+	want = append(want, shellArgs("claude; echo done")...)
+	want = append(want, ";", "new-tab", "--title", "shell", "-d", `C:\work`, ";", "focus-tab", "-t", "1")
+	assert.Equal(t, want, rec.Commands[0].Args)
+	assert.Contains(t, rec.Commands[0].Args, `claude\; echo done`)
+}
+
+func TestClient_CreateSession_Panes(t *testing.T) {
+	rec := &executil.RecordingExecutor{}
+	c := New(rec, nopLog)
+
+	err := c.CreateSession(context.Background(), "sess", "/work", []coretmux.RenderedWindow{
+		{Name: "dev", Panes: []coretmux.RenderedPane{
+			{},
+			{Split: "horizontal", Size: "30%", Dir: "/work/api"},
+			{Size: "20"},
+		}},
+	}, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"-w", "sess",
+		"new-tab", "--title", "dev", "-d", "/work",
+		";", "split-pane", "-V", "--size", "0.3", "--title", "dev", "-d", "/work/api",
+		";", "split-pane", "-H", "--title", "dev", "-d", "/work",
+		";", "focus-tab", "-t", "0",
+	}, rec.Commands[0].Args)
+}
+
+func TestClient_OpenSession(t *testing.T) {
+	rec := &executil.RecordingExecutor{}
+	c := New(rec, nopLog)
+	ctx := context.Background()
+	windows := []coretmux.RenderedWindow{{Name: "shell"}}
+
+	require.NoError(t, c.OpenSession(ctx, "sess", "/work", windows, false, ""))
+	require.Len(t, rec.Commands, 1)
+	assert.Contains(t, rec.Commands[0].Args, "new-tab")
+
+	// Reopening focuses the existing window instead of adding tabs.
+	require.NoError(t, c.OpenSession(ctx, "sess", "/work", windows, false, ""))
+	require.Len(t, rec.Commands, 2)
+	assert.Equal(t, []string{"-w", "sess", "focus-tab", "-t", "0"}, rec.Commands[1].Args)
+
+	require.NoError(t, c.OpenSession(ctx, "sess", "/work", windows, true, ""))
+	assert.Len(t, rec.Commands, 2)
+}
+
+func TestClient_Unsupported(t *testing.T) {
+	c := New(&executil.RecordingExecutor{}, nopLog)
+
+	renamed, err := c.RenameSession(context.Background(), "a", "b")
+	require.NoError(t, err)
+	assert.False(t, renamed)
+
+	targets, err := c.ListTargets(context.Background(), "a")
+	require.NoError(t, err)
+	assert.Empty(t, targets)
+}
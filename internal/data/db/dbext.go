@@ -3,10 +3,13 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"path/filepath"
+	"sync/atomic"
 
-	_ "modernc.org/sqlite"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
 // OpenOptions configures database connection settings.
@@ -25,10 +28,26 @@ func DefaultOpenOptions() OpenOptions {
 	}
 }
 
+// IsLocked reports whether err was caused by another connection holding a
+// lock on the database for longer than the busy timeout.
+func IsLocked(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	// Extended result codes keep the primary code in the low byte.
+	switch sqliteErr.Code() & 0xff {
+	case sqlite3.SQLITE_BUSY, sqlite3.SQLITE_LOCKED:
+		return true
+	}
+	return false
+}
+
 // DB wraps a SQL database connection with sqlc queries.
 type DB struct {
-	conn    *sql.DB
-	queries *Queries
+	conn     *sql.DB
+	queries  *Queries
+	observer *atomic.Pointer[QueryObserver]
 }
 
 // Open creates a new database connection with the given options.
@@ -62,9 +81,11 @@ func Open(dataDir string, opts OpenOptions) (*DB, error) {
 	conn.SetMaxIdleConns(opts.MaxIdleConns)
 	conn.SetConnMaxLifetime(0) // Connections live forever
 
+	observer := &atomic.Pointer[QueryObserver]{}
 	db := &DB{
-		conn:    conn,
-		queries: New(conn),
+		conn:     conn,
+		queries:  New(observedDBTX{DBTX: conn, observer: observer}),
+		observer: observer,
 	}
 
 	// Verify connectivity - fail fast for SQLite
@@ -109,7 +130,7 @@ func (db *DB) WithTx(ctx context.Context, fn func(*Queries) error) error {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
-	queries := db.queries.WithTx(tx)
+	queries := New(observedDBTX{DBTX: tx, observer: db.observer})
 	if err := fn(queries); err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
 			return fmt.Errorf("transaction failed: %w (rollback also failed: %w)", err, rbErr)
@@ -124,6 +145,28 @@ func (db *DB) WithTx(ctx context.Context, fn func(*Queries) error) error {
 	return nil
 }
 
+// QuickCheck runs PRAGMA quick_check and returns the problems it reports,
+// or none when the database is intact.
+func (db *DB) QuickCheck(ctx context.Context) ([]string, error) {
+	rows, err := db.conn.QueryContext(ctx, "PRAGMA quick_check")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return nil, err
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	return problems, rows.Err()
+}
+
 // initSchema runs all pending up migrations.
 func (db *DB) initSchema(ctx context.Context) error {
 	return runMigrations(ctx, db.conn)
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsLocked(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "locked.db")
+
+	open := func() *sql.DB {
+		conn, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=busy_timeout(0)", dbPath))
+		require.NoError(t, err)
+		conn.SetMaxOpenConns(1)
+		t.Cleanup(func() { _ = conn.Close() })
+		return conn
+	}
+
+	holder, other := open(), open()
+	_, err := holder.ExecContext(ctx, "CREATE TABLE t (v INTEGER)")
+	require.NoError(t, err)
+
+	tx, err := holder.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback() }()
+	_, err = tx.ExecContext(ctx, "INSERT INTO t VALUES (1)")
+	require.NoError(t, err)
+
+	_, err = other.ExecContext(ctx, "INSERT INTO t VALUES (2)")
+	require.Error(t, err)
+	assert.True(t, IsLocked(fmt.Errorf("save: %w", err)))
+
+	assert.False(t, IsLocked(errors.New("database is locked")))
+	assert.False(t, IsLocked(nil))
+}
+
+func TestObserveQueries(t *testing.T) {
+	ctx := context.Background()
+	database, err := Open(t.TempDir(), DefaultOpenOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+
+	var names []string
+	database.ObserveQueries(func(name string, d time.Duration) {
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		names = append(names, name)
+	})
+
+	_, err = database.Queries().CountMessages(ctx)
+	require.NoError(t, err)
+	require.NoError(t, database.WithTx(ctx, func(q *Queries) error {
+		_, err := q.CountNotifications(ctx)
+		return err
+	}))
+	assert.Equal(t, []string{"CountMessages", "CountNotifications"}, names, "queries in transactions are observed too")
+
+	database.ObserveQueries(nil)
+	_, err = database.Queries().CountMessages(ctx)
+	require.NoError(t, err)
+	assert.Len(t, names, 2)
+}
+
+func TestQueryName(t *testing.T) {
+	assert.Equal(t, "CountMessages", queryName(countMessages))
+	assert.Equal(t, "unknown", queryName("SELECT 1"))
+}
+
+func TestQuickCheck(t *testing.T) {
+	database, err := Open(t.TempDir(), DefaultOpenOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+
+	problems, err := database.QuickCheck(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// QueryObserver is called after each sqlc query with the query's name and
+// how long it took to execute. Reading the rows of a multi-row query is not
+// included.
+type QueryObserver func(name string, d time.Duration)
+
+// ObserveQueries reports the timing of every sqlc query to fn. Passing nil
+// stops reporting. Queries run directly on Conn are not observed.
+func (db *DB) ObserveQueries(fn QueryObserver) {
+	if fn == nil {
+		db.observer.Store(nil)
+		return
+	}
+	db.observer.Store(&fn)
+}
+
+// observedDBTX wraps a DBTX, timing queries for the observer, if any.
+type observedDBTX struct {
+	DBTX
+	observer *atomic.Pointer[QueryObserver]
+}
+
+func (o observedDBTX) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	defer o.observe(query, time.Now())
+	return o.DBTX.ExecContext(ctx, query, args...)
+}
+
+func (o observedDBTX) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	defer o.observe(query, time.Now())
+	return o.DBTX.QueryContext(ctx, query, args...)
+}
+
+func (o observedDBTX) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	defer o.observe(query, time.Now())
+	return o.DBTX.QueryRowContext(ctx, query, args...)
+}
+
+func (o observedDBTX) observe(query string, start time.Time) {
+	if fn := o.observer.Load(); fn != nil {
+		(*fn)(queryName(query), time.Since(start))
+	}
+}
+
+// queryName returns the name from the "-- name: <Name> :<kind>" header sqlc
+// puts at the start of every query.
+func queryName(query string) string {
+	rest, ok := strings.CutPrefix(query, "-- name: ")
+	if !ok {
+		return "unknown"
+	}
+	name, _, _ := strings.Cut(rest, " ")
+	return name
+}
@@ -0,0 +1,23 @@
+-- Per-topic monotonic sequence numbers for messages.
+ALTER TABLE messages ADD COLUMN seq INTEGER NOT NULL DEFAULT 0;
+
+-- Backfill existing messages in (created_at, rowid) order per topic.
+UPDATE messages SET seq = (
+    SELECT COUNT(*) FROM messages AS m
+    WHERE m.topic = messages.topic
+      AND (m.created_at < messages.created_at
+           OR (m.created_at = messages.created_at AND m.rowid <= messages.rowid))
+);
+
+-- High-water mark per topic. Kept separately from messages so sequences
+-- never restart after retention or pruning deletes the newest rows.
+CREATE TABLE IF NOT EXISTS topic_seqs (
+    topic TEXT PRIMARY KEY,
+    last_seq INTEGER NOT NULL
+);
+
+INSERT INTO topic_seqs (topic, last_seq)
+SELECT topic, MAX(seq) FROM messages GROUP BY topic;
+
+-- Enforces that a sequence number is never reused within a topic.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_topic_seq ON messages(topic, seq);
@@ -0,0 +1,23 @@
+-- Documents attached to a review session. The session's own document_path is
+-- always present; additional rows attach related files (e.g. research docs
+-- referenced by a plan) so their comments are finalized together.
+CREATE TABLE IF NOT EXISTS review_session_documents (
+    session_id TEXT NOT NULL,
+    document_path TEXT NOT NULL,         -- Absolute path to document
+    content_hash TEXT NOT NULL,          -- SHA256 hash when the document was attached
+    added_at INTEGER NOT NULL,           -- Unix timestamp in nanoseconds
+    PRIMARY KEY (session_id, document_path),
+    FOREIGN KEY (session_id) REFERENCES review_sessions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_session_documents_path ON review_session_documents(document_path);
+
+INSERT INTO review_session_documents (session_id, document_path, content_hash, added_at)
+SELECT id, document_path, content_hash, created_at FROM review_sessions;
+
+-- Comments record which attached document they were made on.
+ALTER TABLE review_comments ADD COLUMN document_path TEXT NOT NULL DEFAULT '';
+
+UPDATE review_comments SET document_path = (
+    SELECT rs.document_path FROM review_sessions rs WHERE rs.id = review_comments.session_id
+);
@@ -0,0 +1,5 @@
+-- Column anchors for comments on a span within a line. Columns are 1-indexed
+-- and inclusive: start_col applies to start_line and end_col to end_line.
+-- 0 means the comment anchors to whole lines.
+ALTER TABLE review_comments ADD COLUMN start_col INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE review_comments ADD COLUMN end_col INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Set when a document changed and the text a comment was anchored to could
+-- no longer be found. Outdated comments keep their last known position.
+ALTER TABLE review_comments ADD COLUMN outdated INTEGER NOT NULL DEFAULT 0;
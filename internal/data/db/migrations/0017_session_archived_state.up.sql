@@ -0,0 +1,23 @@
+-- Allow the 'archived' session state. SQLite cannot alter a CHECK
+-- constraint, so the sessions table is rebuilt.
+CREATE TABLE sessions_new (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL,
+    path TEXT NOT NULL,
+    remote TEXT NOT NULL,
+    state TEXT NOT NULL CHECK(state IN ('active', 'recycled', 'corrupted', 'archived')),
+    metadata TEXT, -- JSON blob for map[string]string
+    created_at INTEGER NOT NULL, -- Unix timestamp in nanoseconds
+    updated_at INTEGER NOT NULL, -- Unix timestamp in nanoseconds
+    clone_strategy TEXT NOT NULL DEFAULT 'full',
+    tags TEXT
+);
+
+INSERT INTO sessions_new (id, name, slug, path, remote, state, metadata, created_at, updated_at, clone_strategy, tags)
+SELECT id, name, slug, path, remote, state, metadata, created_at, updated_at, clone_strategy, tags FROM sessions;
+
+DROP TABLE sessions;
+ALTER TABLE sessions_new RENAME TO sessions;
+
+CREATE INDEX IF NOT EXISTS idx_sessions_state_remote ON sessions(state, remote);
@@ -0,0 +1,15 @@
+-- Per-session, per-day agent token usage and cost.
+CREATE TABLE IF NOT EXISTS usage_stats (
+    session_id            TEXT NOT NULL,
+    remote                TEXT NOT NULL,     -- kept so totals survive session deletion
+    day                   TEXT NOT NULL,     -- local date, YYYY-MM-DD
+    input_tokens          INTEGER NOT NULL DEFAULT 0,
+    output_tokens         INTEGER NOT NULL DEFAULT 0,
+    cache_creation_tokens INTEGER NOT NULL DEFAULT 0,
+    cache_read_tokens     INTEGER NOT NULL DEFAULT 0,
+    cost_usd              REAL NOT NULL DEFAULT 0,
+    updated_at            INTEGER NOT NULL,  -- Unix nanos
+    PRIMARY KEY (session_id, day)
+);
+
+CREATE INDEX IF NOT EXISTS idx_usage_stats_day ON usage_stats(day);
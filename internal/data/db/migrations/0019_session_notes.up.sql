@@ -0,0 +1,2 @@
+-- Free-form reminders the user keeps against a session, e.g. "waiting on CI".
+ALTER TABLE sessions ADD COLUMN notes TEXT NOT NULL DEFAULT '';
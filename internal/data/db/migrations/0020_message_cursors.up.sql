@@ -0,0 +1,11 @@
+-- Per-consumer read position in each topic. A named consumer (e.g. one of
+-- several agents reading a broadcast topic) has acknowledged every message
+-- of the topic up to and including seq.
+CREATE TABLE IF NOT EXISTS message_cursors (
+    consumer TEXT NOT NULL,
+    topic TEXT NOT NULL,
+    seq INTEGER NOT NULL,
+    message_id TEXT,            -- ID of the message at seq, when known
+    updated_at INTEGER NOT NULL, -- Unix nanoseconds
+    PRIMARY KEY (consumer, topic)
+);
@@ -0,0 +1,3 @@
+-- How strongly the reviewer wants a comment addressed: nit, suggestion,
+-- issue, or blocker. Comments made before severities existed are suggestions.
+ALTER TABLE review_comments ADD COLUMN severity TEXT NOT NULL DEFAULT 'suggestion';
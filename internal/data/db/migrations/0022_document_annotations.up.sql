@@ -0,0 +1,17 @@
+-- Personal notes on documents, kept apart from review sessions: they survive
+-- finalization and are never part of review feedback.
+CREATE TABLE IF NOT EXISTS document_annotations (
+    id TEXT PRIMARY KEY,
+    document_path TEXT NOT NULL,          -- Absolute path to document
+    content_hash TEXT NOT NULL,           -- SHA256 hash of the content the anchor refers to
+    start_line INTEGER NOT NULL,
+    end_line INTEGER NOT NULL,
+    start_col INTEGER NOT NULL DEFAULT 0, -- 0 anchors to whole lines
+    end_col INTEGER NOT NULL DEFAULT 0,
+    context_text TEXT NOT NULL,           -- Quoted text the note is anchored to
+    note TEXT NOT NULL,
+    outdated INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL           -- Unix timestamp in nanoseconds
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_annotations_path ON document_annotations(document_path);
@@ -0,0 +1,2 @@
+-- Topic a request expects its reply on, set by hive msg request.
+ALTER TABLE messages ADD COLUMN reply_to TEXT;
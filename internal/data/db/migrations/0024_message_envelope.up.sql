@@ -0,0 +1,6 @@
+-- Optional structured envelope fields, set by hive msg pub --type/--json.
+ALTER TABLE messages ADD COLUMN type TEXT;
+ALTER TABLE messages ADD COLUMN correlation_id TEXT;
+ALTER TABLE messages ADD COLUMN content_type TEXT;
+
+CREATE INDEX idx_messages_type ON messages(type);
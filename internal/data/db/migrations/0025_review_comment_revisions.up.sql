@@ -0,0 +1,13 @@
+-- Earlier versions of edited review comments, so the changes to a comment can
+-- be shown after it is edited. A revision is recorded each time a comment's
+-- text changes; deleting the comment deletes its revisions.
+CREATE TABLE IF NOT EXISTS review_comment_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    comment_id TEXT NOT NULL,
+    comment_text TEXT NOT NULL,           -- Text before the edit
+    severity TEXT NOT NULL,               -- Severity before the edit
+    replaced_at INTEGER NOT NULL,         -- Unix timestamp in nanoseconds
+    FOREIGN KEY (comment_id) REFERENCES review_comments(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_comment_revisions_comment_id ON review_comment_revisions(comment_id);
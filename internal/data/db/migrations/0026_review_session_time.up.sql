@@ -0,0 +1,3 @@
+-- Time the reviewer spent with the review session open and in use, in
+-- nanoseconds. Idle stretches are not counted.
+ALTER TABLE review_sessions ADD COLUMN review_time INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,9 @@
+-- Leases that elect one hive process to run a group of background workers.
+-- The holder renews its lease before it expires; another process takes it
+-- over once it has expired or been released.
+CREATE TABLE IF NOT EXISTS worker_leases (
+    name        TEXT PRIMARY KEY,
+    holder      TEXT NOT NULL,       -- host:pid of the holding process
+    acquired_at INTEGER NOT NULL,    -- Unix nanos
+    expires_at  INTEGER NOT NULL     -- Unix nanos
+);
@@ -0,0 +1,5 @@
+-- When finalized feedback was sent to an agent with hive review dispatch,
+-- the hive session it was sent to, and the topic the agent acknowledges on.
+ALTER TABLE review_sessions ADD COLUMN dispatched_at INTEGER;
+ALTER TABLE review_sessions ADD COLUMN dispatched_to TEXT NOT NULL DEFAULT '';
+ALTER TABLE review_sessions ADD COLUMN dispatch_reply_to TEXT NOT NULL DEFAULT '';
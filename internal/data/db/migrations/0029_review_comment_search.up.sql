@@ -0,0 +1,26 @@
+-- Full-text index over review comments for hive review search. It keeps its
+-- own copy of the text keyed by comment id, since review_comments rowids are
+-- not stable across VACUUM; the triggers keep it in sync.
+CREATE VIRTUAL TABLE IF NOT EXISTS review_comments_fts USING fts5(
+    id UNINDEXED,
+    comment_text,
+    context_text
+);
+
+INSERT INTO review_comments_fts(id, comment_text, context_text)
+SELECT id, comment_text, context_text FROM review_comments;
+
+CREATE TRIGGER IF NOT EXISTS review_comments_fts_insert AFTER INSERT ON review_comments BEGIN
+    INSERT INTO review_comments_fts(id, comment_text, context_text)
+    VALUES (new.id, new.comment_text, new.context_text);
+END;
+
+CREATE TRIGGER IF NOT EXISTS review_comments_fts_delete AFTER DELETE ON review_comments BEGIN
+    DELETE FROM review_comments_fts WHERE id = old.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS review_comments_fts_update AFTER UPDATE OF id, comment_text, context_text ON review_comments BEGIN
+    DELETE FROM review_comments_fts WHERE id = old.id;
+    INSERT INTO review_comments_fts(id, comment_text, context_text)
+    VALUES (new.id, new.comment_text, new.context_text);
+END;
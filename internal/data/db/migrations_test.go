@@ -131,3 +131,74 @@ func TestRunMigrations_LegacyBootstrap_EmptySchemaVersion(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, applied, len(migrations))
 }
+
+// migrationsBefore returns the hive migrations that precede the named one.
+func migrationsBefore(t *testing.T, name string) []migrate.Migration {
+	t.Helper()
+	var before []migrate.Migration
+	for _, m := range hiveMigrations(t) {
+		if m.Name == name {
+			break
+		}
+		before = append(before, m)
+	}
+	return before
+}
+
+func TestRunMigrations_BackfillsMessageSeqs(t *testing.T) {
+	conn := openRawConn(t)
+	ctx := context.Background()
+
+	require.NoError(t, migrate.Apply(ctx, conn, migrationsBefore(t, "message_seqs")))
+
+	_, err := conn.ExecContext(ctx, `
+		INSERT INTO messages (id, topic, payload, created_at) VALUES
+			('m1', 'a', 'first', 100),
+			('m2', 'b', 'other', 150),
+			('m3', 'a', 'second', 200),
+			('m4', 'a', 'tie', 200);
+	`)
+	require.NoError(t, err)
+
+	require.NoError(t, runMigrations(ctx, conn))
+
+	q := New(conn)
+	rows, err := q.SubscribeToTopicAfterSeq(ctx, SubscribeToTopicAfterSeqParams{Topic: "a", Seq: 0})
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, []string{"m1", "m3", "m4"}, []string{rows[0].ID, rows[1].ID, rows[2].ID})
+	assert.Equal(t, []int64{1, 2, 3}, []int64{rows[0].Seq, rows[1].Seq, rows[2].Seq})
+
+	next, err := q.NextTopicSeq(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), next, "high-water mark should continue after backfill")
+}
+
+func TestRunMigrations_BackfillsReviewSessionDocuments(t *testing.T) {
+	conn := openRawConn(t)
+	ctx := context.Background()
+
+	require.NoError(t, migrate.Apply(ctx, conn, migrationsBefore(t, "review_session_documents")))
+
+	_, err := conn.ExecContext(ctx, `
+		INSERT INTO review_sessions (id, document_path, content_hash, created_at) VALUES
+			('s1', '/ctx/plan.md', 'h1', 100);
+		INSERT INTO review_comments (id, session_id, start_line, end_line, context_text, comment_text, created_at) VALUES
+			('c1', 's1', 1, 2, 'ctx', 'fix', 110);
+	`)
+	require.NoError(t, err)
+
+	require.NoError(t, runMigrations(ctx, conn))
+
+	q := New(conn)
+	docs, err := q.ListReviewSessionDocuments(ctx, "s1")
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "/ctx/plan.md", docs[0].DocumentPath)
+	assert.Equal(t, "h1", docs[0].ContentHash)
+
+	comments, err := q.ListReviewComments(ctx, "s1")
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "/ctx/plan.md", comments[0].DocumentPath, "existing comments belong to the session document")
+}
@@ -10,6 +10,20 @@ import (
 	"github.com/colonyops/hive/internal/core/hc"
 )
 
+type DocumentAnnotation struct {
+	ID           string `json:"id"`
+	DocumentPath string `json:"document_path"`
+	ContentHash  string `json:"content_hash"`
+	StartLine    int64  `json:"start_line"`
+	EndLine      int64  `json:"end_line"`
+	StartCol     int64  `json:"start_col"`
+	EndCol       int64  `json:"end_col"`
+	ContextText  string `json:"context_text"`
+	Note         string `json:"note"`
+	Outdated     int64  `json:"outdated"`
+	CreatedAt    int64  `json:"created_at"`
+}
+
 type HcComment struct {
 	ID        string `json:"id"`
 	ItemID    string `json:"item_id"`
@@ -46,12 +60,25 @@ type KvStore struct {
 }
 
 type Message struct {
-	ID        string         `json:"id"`
+	ID            string         `json:"id"`
+	Topic         string         `json:"topic"`
+	Payload       string         `json:"payload"`
+	Sender        sql.NullString `json:"sender"`
+	SessionID     sql.NullString `json:"session_id"`
+	CreatedAt     int64          `json:"created_at"`
+	Seq           int64          `json:"seq"`
+	ReplyTo       sql.NullString `json:"reply_to"`
+	Type          sql.NullString `json:"type"`
+	CorrelationID sql.NullString `json:"correlation_id"`
+	ContentType   sql.NullString `json:"content_type"`
+}
+
+type MessageCursor struct {
+	Consumer  string         `json:"consumer"`
 	Topic     string         `json:"topic"`
-	Payload   string         `json:"payload"`
-	Sender    sql.NullString `json:"sender"`
-	SessionID sql.NullString `json:"session_id"`
-	CreatedAt int64          `json:"created_at"`
+	Seq       int64          `json:"seq"`
+	MessageID sql.NullString `json:"message_id"`
+	UpdatedAt int64          `json:"updated_at"`
 }
 
 type MessageRead struct {
@@ -68,21 +95,45 @@ type Notification struct {
 }
 
 type ReviewComment struct {
-	ID          string `json:"id"`
-	SessionID   string `json:"session_id"`
-	StartLine   int64  `json:"start_line"`
-	EndLine     int64  `json:"end_line"`
-	ContextText string `json:"context_text"`
+	ID           string `json:"id"`
+	SessionID    string `json:"session_id"`
+	StartLine    int64  `json:"start_line"`
+	EndLine      int64  `json:"end_line"`
+	ContextText  string `json:"context_text"`
+	CommentText  string `json:"comment_text"`
+	CreatedAt    int64  `json:"created_at"`
+	DocumentPath string `json:"document_path"`
+	StartCol     int64  `json:"start_col"`
+	EndCol       int64  `json:"end_col"`
+	Outdated     int64  `json:"outdated"`
+	Severity     string `json:"severity"`
+}
+
+type ReviewCommentRevision struct {
+	ID          int64  `json:"id"`
+	CommentID   string `json:"comment_id"`
 	CommentText string `json:"comment_text"`
-	CreatedAt   int64  `json:"created_at"`
+	Severity    string `json:"severity"`
+	ReplacedAt  int64  `json:"replaced_at"`
 }
 
 type ReviewSession struct {
-	ID           string        `json:"id"`
-	DocumentPath string        `json:"document_path"`
-	ContentHash  string        `json:"content_hash"`
-	CreatedAt    int64         `json:"created_at"`
-	FinalizedAt  sql.NullInt64 `json:"finalized_at"`
+	ID              string        `json:"id"`
+	DocumentPath    string        `json:"document_path"`
+	ContentHash     string        `json:"content_hash"`
+	CreatedAt       int64         `json:"created_at"`
+	FinalizedAt     sql.NullInt64 `json:"finalized_at"`
+	ReviewTime      int64         `json:"review_time"`
+	DispatchedAt    sql.NullInt64 `json:"dispatched_at"`
+	DispatchedTo    string        `json:"dispatched_to"`
+	DispatchReplyTo string        `json:"dispatch_reply_to"`
+}
+
+type ReviewSessionDocument struct {
+	SessionID    string `json:"session_id"`
+	DocumentPath string `json:"document_path"`
+	ContentHash  string `json:"content_hash"`
+	AddedAt      int64  `json:"added_at"`
 }
 
 type Session struct {
@@ -97,6 +148,7 @@ type Session struct {
 	UpdatedAt     int64          `json:"updated_at"`
 	CloneStrategy string         `json:"clone_strategy"`
 	Tags          sql.NullString `json:"tags"`
+	Notes         string         `json:"notes"`
 }
 
 type TodoItem struct {
@@ -115,3 +167,27 @@ type Topic struct {
 	Name      string      `json:"name"`
 	UpdatedAt interface{} `json:"updated_at"`
 }
+
+type TopicSeq struct {
+	Topic   string `json:"topic"`
+	LastSeq int64  `json:"last_seq"`
+}
+
+type UsageStat struct {
+	SessionID           string  `json:"session_id"`
+	Remote              string  `json:"remote"`
+	Day                 string  `json:"day"`
+	InputTokens         int64   `json:"input_tokens"`
+	OutputTokens        int64   `json:"output_tokens"`
+	CacheCreationTokens int64   `json:"cache_creation_tokens"`
+	CacheReadTokens     int64   `json:"cache_read_tokens"`
+	CostUsd             float64 `json:"cost_usd"`
+	UpdatedAt           int64   `json:"updated_at"`
+}
+
+type WorkerLease struct {
+	Name       string `json:"name"`
+	Holder     string `json:"holder"`
+	AcquiredAt int64  `json:"acquired_at"`
+	ExpiresAt  int64  `json:"expires_at"`
+}
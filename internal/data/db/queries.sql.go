@@ -28,6 +28,181 @@ func (q *Queries) AcknowledgeMessages(ctx context.Context, arg AcknowledgeMessag
 	return err
 }
 
+const acquireWorkerLease = `-- name: AcquireWorkerLease :execrows
+INSERT INTO worker_leases (name, holder, acquired_at, expires_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (name) DO UPDATE SET
+    holder = excluded.holder,
+    acquired_at = CASE WHEN worker_leases.holder = excluded.holder
+        THEN worker_leases.acquired_at ELSE excluded.acquired_at END,
+    expires_at = excluded.expires_at
+WHERE worker_leases.holder = excluded.holder OR worker_leases.expires_at <= excluded.acquired_at
+`
+
+type AcquireWorkerLeaseParams struct {
+	Name       string `json:"name"`
+	Holder     string `json:"holder"`
+	AcquiredAt int64  `json:"acquired_at"`
+	ExpiresAt  int64  `json:"expires_at"`
+}
+
+func (q *Queries) AcquireWorkerLease(ctx context.Context, arg AcquireWorkerLeaseParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, acquireWorkerLease,
+		arg.Name,
+		arg.Holder,
+		arg.AcquiredAt,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const addReviewCommentRevision = `-- name: AddReviewCommentRevision :exec
+INSERT INTO review_comment_revisions (comment_id, comment_text, severity, replaced_at)
+SELECT id, comment_text, severity, CAST(? AS INTEGER) FROM review_comments
+WHERE id = ? AND (comment_text != ? OR severity != ?)
+`
+
+type AddReviewCommentRevisionParams struct {
+	ReplacedAt  int64  `json:"replaced_at"`
+	ID          string `json:"id"`
+	CommentText string `json:"comment_text"`
+	Severity    string `json:"severity"`
+}
+
+func (q *Queries) AddReviewCommentRevision(ctx context.Context, arg AddReviewCommentRevisionParams) error {
+	_, err := q.db.ExecContext(ctx, addReviewCommentRevision,
+		arg.ReplacedAt,
+		arg.ID,
+		arg.CommentText,
+		arg.Severity,
+	)
+	return err
+}
+
+const addReviewSessionDocument = `-- name: AddReviewSessionDocument :exec
+INSERT INTO review_session_documents (
+    session_id, document_path, content_hash, added_at
+) VALUES (?, ?, ?, ?)
+`
+
+type AddReviewSessionDocumentParams struct {
+	SessionID    string `json:"session_id"`
+	DocumentPath string `json:"document_path"`
+	ContentHash  string `json:"content_hash"`
+	AddedAt      int64  `json:"added_at"`
+}
+
+func (q *Queries) AddReviewSessionDocument(ctx context.Context, arg AddReviewSessionDocumentParams) error {
+	_, err := q.db.ExecContext(ctx, addReviewSessionDocument,
+		arg.SessionID,
+		arg.DocumentPath,
+		arg.ContentHash,
+		arg.AddedAt,
+	)
+	return err
+}
+
+const addReviewSessionTime = `-- name: AddReviewSessionTime :exec
+UPDATE review_sessions
+SET review_time = review_time + ?
+WHERE id = ?
+`
+
+type AddReviewSessionTimeParams struct {
+	ReviewTime int64  `json:"review_time"`
+	ID         string `json:"id"`
+}
+
+func (q *Queries) AddReviewSessionTime(ctx context.Context, arg AddReviewSessionTimeParams) error {
+	_, err := q.db.ExecContext(ctx, addReviewSessionTime, arg.ReviewTime, arg.ID)
+	return err
+}
+
+const advanceConsumerCursor = `-- name: AdvanceConsumerCursor :exec
+INSERT INTO message_cursors (consumer, topic, seq, message_id, updated_at)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (consumer, topic) DO UPDATE SET
+    seq = excluded.seq,
+    message_id = excluded.message_id,
+    updated_at = excluded.updated_at
+WHERE excluded.seq > message_cursors.seq
+`
+
+type AdvanceConsumerCursorParams struct {
+	Consumer  string         `json:"consumer"`
+	Topic     string         `json:"topic"`
+	Seq       int64          `json:"seq"`
+	MessageID sql.NullString `json:"message_id"`
+	UpdatedAt int64          `json:"updated_at"`
+}
+
+func (q *Queries) AdvanceConsumerCursor(ctx context.Context, arg AdvanceConsumerCursorParams) error {
+	_, err := q.db.ExecContext(ctx, advanceConsumerCursor,
+		arg.Consumer,
+		arg.Topic,
+		arg.Seq,
+		arg.MessageID,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const countMessagesByDay = `-- name: CountMessagesByDay :many
+SELECT CAST((created_at + ?) / 86400000000000 AS INTEGER) AS day, COUNT(*) AS count
+FROM messages
+WHERE created_at >= ? AND created_at < ?
+GROUP BY day
+ORDER BY day
+`
+
+type CountMessagesByDayParams struct {
+	OffsetNs int64 `json:"offset_ns"`
+	Since    int64 `json:"since"`
+	Until    int64 `json:"until"`
+}
+
+type CountMessagesByDayRow struct {
+	Day   int64 `json:"day"`
+	Count int64 `json:"count"`
+}
+
+func (q *Queries) CountMessagesByDay(ctx context.Context, arg CountMessagesByDayParams) ([]CountMessagesByDayRow, error) {
+	rows, err := q.db.QueryContext(ctx, countMessagesByDay, arg.OffsetNs, arg.Since, arg.Until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountMessagesByDayRow{}
+	for rows.Next() {
+		var i CountMessagesByDayRow
+		if err := rows.Scan(&i.Day, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countMessages = `-- name: CountMessages :one
+SELECT COUNT(*) FROM messages
+`
+
+func (q *Queries) CountMessages(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countMessages)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countMessagesInTopic = `-- name: CountMessagesInTopic :one
 SELECT COUNT(*) FROM messages
 WHERE topic = ?
@@ -101,6 +276,92 @@ func (q *Queries) CountRecentTodoItemsBySession(ctx context.Context, arg CountRe
 	return count, err
 }
 
+const countReviewsFinalizedByDay = `-- name: CountReviewsFinalizedByDay :many
+SELECT CAST((finalized_at + ?) / 86400000000000 AS INTEGER) AS day, COUNT(*) AS count,
+    CAST(SUM(review_time) AS INTEGER) AS review_time
+FROM review_sessions
+WHERE finalized_at >= ? AND finalized_at < ?
+GROUP BY day
+ORDER BY day
+`
+
+type CountReviewsFinalizedByDayParams struct {
+	OffsetNs int64 `json:"offset_ns"`
+	Since    int64 `json:"since"`
+	Until    int64 `json:"until"`
+}
+
+type CountReviewsFinalizedByDayRow struct {
+	Day        int64 `json:"day"`
+	Count      int64 `json:"count"`
+	ReviewTime int64 `json:"review_time"`
+}
+
+func (q *Queries) CountReviewsFinalizedByDay(ctx context.Context, arg CountReviewsFinalizedByDayParams) ([]CountReviewsFinalizedByDayRow, error) {
+	rows, err := q.db.QueryContext(ctx, countReviewsFinalizedByDay, arg.OffsetNs, arg.Since, arg.Until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountReviewsFinalizedByDayRow{}
+	for rows.Next() {
+		var i CountReviewsFinalizedByDayRow
+		if err := rows.Scan(&i.Day, &i.Count, &i.ReviewTime); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countSessionsByDay = `-- name: CountSessionsByDay :many
+SELECT CAST((created_at + ?) / 86400000000000 AS INTEGER) AS day, COUNT(*) AS count
+FROM sessions
+WHERE created_at >= ? AND created_at < ?
+GROUP BY day
+ORDER BY day
+`
+
+type CountSessionsByDayParams struct {
+	OffsetNs int64 `json:"offset_ns"`
+	Since    int64 `json:"since"`
+	Until    int64 `json:"until"`
+}
+
+type CountSessionsByDayRow struct {
+	Day   int64 `json:"day"`
+	Count int64 `json:"count"`
+}
+
+func (q *Queries) CountSessionsByDay(ctx context.Context, arg CountSessionsByDayParams) ([]CountSessionsByDayRow, error) {
+	rows, err := q.db.QueryContext(ctx, countSessionsByDay, arg.OffsetNs, arg.Since, arg.Until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountSessionsByDayRow{}
+	for rows.Next() {
+		var i CountSessionsByDayRow
+		if err := rows.Scan(&i.Day, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createReviewSession = `-- name: CreateReviewSession :exec
 INSERT INTO review_sessions (
     id, document_path, content_hash, created_at, finalized_at
@@ -167,12 +428,35 @@ func (q *Queries) DeleteAllNotifications(ctx context.Context) error {
 	return err
 }
 
+const deleteDocumentAnnotation = `-- name: DeleteDocumentAnnotation :exec
+DELETE FROM document_annotations
+WHERE id = ?
+`
+
+func (q *Queries) DeleteDocumentAnnotation(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteDocumentAnnotation, id)
+	return err
+}
+
+const deleteMessagesInTopic = `-- name: DeleteMessagesInTopic :execrows
+DELETE FROM messages
+WHERE topic = ?
+`
+
+func (q *Queries) DeleteMessagesInTopic(ctx context.Context, topic string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteMessagesInTopic, topic)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteOldestMessagesInTopic = `-- name: DeleteOldestMessagesInTopic :exec
 DELETE FROM messages
 WHERE id IN (
     SELECT id FROM messages AS m
     WHERE m.topic = ?
-    ORDER BY m.created_at ASC
+    ORDER BY m.seq ASC
     LIMIT ?
 )
 `
@@ -209,7 +493,7 @@ func (q *Queries) DeleteReviewSession(ctx context.Context, id string) error {
 
 const deleteReviewSessionsByDocPath = `-- name: DeleteReviewSessionsByDocPath :exec
 DELETE FROM review_sessions
-WHERE document_path = ? AND content_hash != ?
+WHERE document_path = ? AND content_hash != ? AND finalized_at IS NULL
 `
 
 type DeleteReviewSessionsByDocPathParams struct {
@@ -256,6 +540,31 @@ func (q *Queries) FinalizeReviewSession(ctx context.Context, arg FinalizeReviewS
 	return err
 }
 
+const getActiveReviewSessionByDocument = `-- name: GetActiveReviewSessionByDocument :one
+SELECT rs.id, rs.document_path, rs.content_hash, rs.created_at, rs.finalized_at, rs.review_time, rs.dispatched_at, rs.dispatched_to, rs.dispatch_reply_to FROM review_sessions rs
+JOIN review_session_documents rsd ON rsd.session_id = rs.id
+WHERE rsd.document_path = ? AND rs.finalized_at IS NULL
+ORDER BY rs.created_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetActiveReviewSessionByDocument(ctx context.Context, documentPath string) (ReviewSession, error) {
+	row := q.db.QueryRowContext(ctx, getActiveReviewSessionByDocument, documentPath)
+	var i ReviewSession
+	err := row.Scan(
+		&i.ID,
+		&i.DocumentPath,
+		&i.ContentHash,
+		&i.CreatedAt,
+		&i.FinalizedAt,
+		&i.ReviewTime,
+		&i.DispatchedAt,
+		&i.DispatchedTo,
+		&i.DispatchReplyTo,
+	)
+	return i, err
+}
+
 const getAllActiveSessionsWithCounts = `-- name: GetAllActiveSessionsWithCounts :many
 SELECT
     rs.id,
@@ -263,6 +572,7 @@ SELECT
     rs.content_hash,
     rs.created_at,
     rs.finalized_at,
+    rs.review_time,
     COUNT(rc.id) as comment_count
 FROM review_sessions rs
 LEFT JOIN review_comments rc ON rs.id = rc.session_id
@@ -276,6 +586,7 @@ type GetAllActiveSessionsWithCountsRow struct {
 	ContentHash  string        `json:"content_hash"`
 	CreatedAt    int64         `json:"created_at"`
 	FinalizedAt  sql.NullInt64 `json:"finalized_at"`
+	ReviewTime   int64         `json:"review_time"`
 	CommentCount int64         `json:"comment_count"`
 }
 
@@ -294,6 +605,7 @@ func (q *Queries) GetAllActiveSessionsWithCounts(ctx context.Context) ([]GetAllA
 			&i.ContentHash,
 			&i.CreatedAt,
 			&i.FinalizedAt,
+			&i.ReviewTime,
 			&i.CommentCount,
 		); err != nil {
 			return nil, err
@@ -309,8 +621,51 @@ func (q *Queries) GetAllActiveSessionsWithCounts(ctx context.Context) ([]GetAllA
 	return items, nil
 }
 
+const getMessageByID = `-- name: GetMessageByID :one
+SELECT id, topic, payload, sender, session_id, created_at, seq, reply_to, type, correlation_id, content_type FROM messages
+WHERE id = ?
+`
+
+func (q *Queries) GetMessageByID(ctx context.Context, id string) (Message, error) {
+	row := q.db.QueryRowContext(ctx, getMessageByID, id)
+	var i Message
+	err := row.Scan(
+		&i.ID,
+		&i.Topic,
+		&i.Payload,
+		&i.Sender,
+		&i.SessionID,
+		&i.CreatedAt,
+		&i.Seq,
+		&i.ReplyTo,
+		&i.Type,
+		&i.CorrelationID,
+		&i.ContentType,
+	)
+	return i, err
+}
+
+const getMessageSeqAtOffset = `-- name: GetMessageSeqAtOffset :one
+SELECT seq FROM messages
+WHERE topic = ?
+ORDER BY seq ASC
+LIMIT 1 OFFSET ?
+`
+
+type GetMessageSeqAtOffsetParams struct {
+	Topic  string `json:"topic"`
+	Offset int64  `json:"offset"`
+}
+
+func (q *Queries) GetMessageSeqAtOffset(ctx context.Context, arg GetMessageSeqAtOffsetParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getMessageSeqAtOffset, arg.Topic, arg.Offset)
+	var seq int64
+	err := row.Scan(&seq)
+	return seq, err
+}
+
 const getReviewSessionByDocPath = `-- name: GetReviewSessionByDocPath :one
-SELECT id, document_path, content_hash, created_at, finalized_at FROM review_sessions
+SELECT id, document_path, content_hash, created_at, finalized_at, review_time, dispatched_at, dispatched_to, dispatch_reply_to FROM review_sessions
 WHERE document_path = ?
 ORDER BY created_at DESC
 LIMIT 1
@@ -325,12 +680,16 @@ func (q *Queries) GetReviewSessionByDocPath(ctx context.Context, documentPath st
 		&i.ContentHash,
 		&i.CreatedAt,
 		&i.FinalizedAt,
+		&i.ReviewTime,
+		&i.DispatchedAt,
+		&i.DispatchedTo,
+		&i.DispatchReplyTo,
 	)
 	return i, err
 }
 
 const getReviewSessionByDocPathAndHash = `-- name: GetReviewSessionByDocPathAndHash :one
-SELECT id, document_path, content_hash, created_at, finalized_at FROM review_sessions
+SELECT id, document_path, content_hash, created_at, finalized_at, review_time, dispatched_at, dispatched_to, dispatch_reply_to FROM review_sessions
 WHERE document_path = ? AND content_hash = ?
 `
 
@@ -348,12 +707,16 @@ func (q *Queries) GetReviewSessionByDocPathAndHash(ctx context.Context, arg GetR
 		&i.ContentHash,
 		&i.CreatedAt,
 		&i.FinalizedAt,
+		&i.ReviewTime,
+		&i.DispatchedAt,
+		&i.DispatchedTo,
+		&i.DispatchReplyTo,
 	)
 	return i, err
 }
 
 const getSession = `-- name: GetSession :one
-SELECT id, name, slug, path, remote, state, metadata, created_at, updated_at, clone_strategy, tags FROM sessions
+SELECT id, name, slug, path, remote, state, metadata, created_at, updated_at, clone_strategy, tags, notes FROM sessions
 WHERE id = ?
 `
 
@@ -372,6 +735,7 @@ func (q *Queries) GetSession(ctx context.Context, id string) (Session, error) {
 		&i.UpdatedAt,
 		&i.CloneStrategy,
 		&i.Tags,
+		&i.Notes,
 	)
 	return i, err
 }
@@ -398,12 +762,12 @@ func (q *Queries) GetTodoItem(ctx context.Context, id string) (TodoItem, error)
 }
 
 const getUnreadMessages = `-- name: GetUnreadMessages :many
-SELECT m.id, m.topic, m.payload, m.sender, m.session_id, m.created_at
+SELECT m.id, m.topic, m.payload, m.sender, m.session_id, m.created_at, m.seq, m.reply_to, m.type, m.correlation_id, m.content_type
 FROM messages m
 LEFT JOIN message_reads mr ON mr.message_id = m.id AND mr.consumer_id = ?
 WHERE m.topic = ?
   AND mr.message_id IS NULL
-ORDER BY m.created_at ASC
+ORDER BY m.seq ASC
 `
 
 type GetUnreadMessagesParams struct {
@@ -427,6 +791,11 @@ func (q *Queries) GetUnreadMessages(ctx context.Context, arg GetUnreadMessagesPa
 			&i.Sender,
 			&i.SessionID,
 			&i.CreatedAt,
+			&i.Seq,
+			&i.ReplyTo,
+			&i.Type,
+			&i.CorrelationID,
+			&i.ContentType,
 		); err != nil {
 			return nil, err
 		}
@@ -580,25 +949,27 @@ func (q *Queries) KVSweepExpired(ctx context.Context, expiresAt sql.NullInt64) e
 	return err
 }
 
-const listNotifications = `-- name: ListNotifications :many
-SELECT id, level, message, created_at FROM notifications
-ORDER BY created_at DESC
+const listConsumerCursors = `-- name: ListConsumerCursors :many
+SELECT consumer, topic, seq, message_id, updated_at FROM message_cursors
+WHERE consumer = ?
+ORDER BY topic ASC
 `
 
-func (q *Queries) ListNotifications(ctx context.Context) ([]Notification, error) {
-	rows, err := q.db.QueryContext(ctx, listNotifications)
+func (q *Queries) ListConsumerCursors(ctx context.Context, consumer string) ([]MessageCursor, error) {
+	rows, err := q.db.QueryContext(ctx, listConsumerCursors, consumer)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Notification{}
+	items := []MessageCursor{}
 	for rows.Next() {
-		var i Notification
+		var i MessageCursor
 		if err := rows.Scan(
-			&i.ID,
-			&i.Level,
-			&i.Message,
-			&i.CreatedAt,
+			&i.Consumer,
+			&i.Topic,
+			&i.Seq,
+			&i.MessageID,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -613,28 +984,32 @@ func (q *Queries) ListNotifications(ctx context.Context) ([]Notification, error)
 	return items, nil
 }
 
-const listReviewComments = `-- name: ListReviewComments :many
-SELECT id, session_id, start_line, end_line, context_text, comment_text, created_at FROM review_comments
-WHERE session_id = ?
-ORDER BY start_line ASC
+const listDocumentAnnotations = `-- name: ListDocumentAnnotations :many
+SELECT id, document_path, content_hash, start_line, end_line, start_col, end_col, context_text, note, outdated, created_at FROM document_annotations
+WHERE document_path = ?
+ORDER BY start_line ASC, created_at ASC
 `
 
-func (q *Queries) ListReviewComments(ctx context.Context, sessionID string) ([]ReviewComment, error) {
-	rows, err := q.db.QueryContext(ctx, listReviewComments, sessionID)
+func (q *Queries) ListDocumentAnnotations(ctx context.Context, documentPath string) ([]DocumentAnnotation, error) {
+	rows, err := q.db.QueryContext(ctx, listDocumentAnnotations, documentPath)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []ReviewComment{}
+	items := []DocumentAnnotation{}
 	for rows.Next() {
-		var i ReviewComment
+		var i DocumentAnnotation
 		if err := rows.Scan(
 			&i.ID,
-			&i.SessionID,
+			&i.DocumentPath,
+			&i.ContentHash,
 			&i.StartLine,
 			&i.EndLine,
+			&i.StartCol,
+			&i.EndCol,
 			&i.ContextText,
-			&i.CommentText,
+			&i.Note,
+			&i.Outdated,
 			&i.CreatedAt,
 		); err != nil {
 			return nil, err
@@ -650,33 +1025,32 @@ func (q *Queries) ListReviewComments(ctx context.Context, sessionID string) ([]R
 	return items, nil
 }
 
-const listSessions = `-- name: ListSessions :many
-SELECT id, name, slug, path, remote, state, metadata, created_at, updated_at, clone_strategy, tags FROM sessions
-ORDER BY created_at DESC
+const listLastReviewedDocuments = `-- name: ListLastReviewedDocuments :many
+SELECT rsd.document_path, rsd.content_hash
+FROM review_session_documents rsd
+JOIN review_sessions rs ON rs.id = rsd.session_id
+WHERE rs.finalized_at = (
+    SELECT MAX(rs2.finalized_at) FROM review_sessions rs2
+    JOIN review_session_documents rsd2 ON rsd2.session_id = rs2.id
+    WHERE rsd2.document_path = rsd.document_path
+)
 `
 
-func (q *Queries) ListSessions(ctx context.Context) ([]Session, error) {
-	rows, err := q.db.QueryContext(ctx, listSessions)
+type ListLastReviewedDocumentsRow struct {
+	DocumentPath string `json:"document_path"`
+	ContentHash  string `json:"content_hash"`
+}
+
+func (q *Queries) ListLastReviewedDocuments(ctx context.Context) ([]ListLastReviewedDocumentsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLastReviewedDocuments)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Session{}
+	items := []ListLastReviewedDocumentsRow{}
 	for rows.Next() {
-		var i Session
-		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Slug,
-			&i.Path,
-			&i.Remote,
-			&i.State,
-			&i.Metadata,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.CloneStrategy,
-			&i.Tags,
-		); err != nil {
+		var i ListLastReviewedDocumentsRow
+		if err := rows.Scan(&i.DocumentPath, &i.ContentHash); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -690,29 +1064,25 @@ func (q *Queries) ListSessions(ctx context.Context) ([]Session, error) {
 	return items, nil
 }
 
-const listTodoItems = `-- name: ListTodoItems :many
-SELECT id, session_id, source, title, uri, status, created_at, updated_at, completed_at FROM todo_items ORDER BY created_at DESC
+const listNotifications = `-- name: ListNotifications :many
+SELECT id, level, message, created_at FROM notifications
+ORDER BY created_at DESC
 `
 
-func (q *Queries) ListTodoItems(ctx context.Context) ([]TodoItem, error) {
-	rows, err := q.db.QueryContext(ctx, listTodoItems)
+func (q *Queries) ListNotifications(ctx context.Context) ([]Notification, error) {
+	rows, err := q.db.QueryContext(ctx, listNotifications)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []TodoItem{}
+	items := []Notification{}
 	for rows.Next() {
-		var i TodoItem
+		var i Notification
 		if err := rows.Scan(
 			&i.ID,
-			&i.SessionID,
-			&i.Source,
-			&i.Title,
-			&i.Uri,
-			&i.Status,
+			&i.Level,
+			&i.Message,
 			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.CompletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -727,7 +1097,246 @@ func (q *Queries) ListTodoItems(ctx context.Context) ([]TodoItem, error) {
 	return items, nil
 }
 
-const listTodoItemsByStatus = `-- name: ListTodoItemsByStatus :many
+const listReviewComments = `-- name: ListReviewComments :many
+SELECT id, session_id, start_line, end_line, context_text, comment_text, created_at, document_path, start_col, end_col, outdated, severity FROM review_comments
+WHERE session_id = ?
+ORDER BY start_line ASC
+`
+
+func (q *Queries) ListReviewComments(ctx context.Context, sessionID string) ([]ReviewComment, error) {
+	rows, err := q.db.QueryContext(ctx, listReviewComments, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ReviewComment{}
+	for rows.Next() {
+		var i ReviewComment
+		if err := rows.Scan(
+			&i.ID,
+			&i.SessionID,
+			&i.StartLine,
+			&i.EndLine,
+			&i.ContextText,
+			&i.CommentText,
+			&i.CreatedAt,
+			&i.DocumentPath,
+			&i.StartCol,
+			&i.EndCol,
+			&i.Outdated,
+			&i.Severity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReviewCommentRevisions = `-- name: ListReviewCommentRevisions :many
+SELECT id, comment_id, comment_text, severity, replaced_at FROM review_comment_revisions
+WHERE comment_id = ?
+ORDER BY id ASC
+`
+
+func (q *Queries) ListReviewCommentRevisions(ctx context.Context, commentID string) ([]ReviewCommentRevision, error) {
+	rows, err := q.db.QueryContext(ctx, listReviewCommentRevisions, commentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ReviewCommentRevision{}
+	for rows.Next() {
+		var i ReviewCommentRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.CommentID,
+			&i.CommentText,
+			&i.Severity,
+			&i.ReplacedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReviewSessionDocuments = `-- name: ListReviewSessionDocuments :many
+SELECT session_id, document_path, content_hash, added_at FROM review_session_documents
+WHERE session_id = ?
+ORDER BY added_at ASC
+`
+
+func (q *Queries) ListReviewSessionDocuments(ctx context.Context, sessionID string) ([]ReviewSessionDocument, error) {
+	rows, err := q.db.QueryContext(ctx, listReviewSessionDocuments, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ReviewSessionDocument{}
+	for rows.Next() {
+		var i ReviewSessionDocument
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.DocumentPath,
+			&i.ContentHash,
+			&i.AddedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessionUsageTotals = `-- name: ListSessionUsageTotals :many
+SELECT session_id,
+    CAST(SUM(input_tokens) AS INTEGER) AS input_tokens,
+    CAST(SUM(output_tokens) AS INTEGER) AS output_tokens,
+    CAST(SUM(cache_creation_tokens) AS INTEGER) AS cache_creation_tokens,
+    CAST(SUM(cache_read_tokens) AS INTEGER) AS cache_read_tokens,
+    CAST(SUM(cost_usd) AS REAL) AS cost_usd
+FROM usage_stats
+GROUP BY session_id
+`
+
+type ListSessionUsageTotalsRow struct {
+	SessionID           string  `json:"session_id"`
+	InputTokens         int64   `json:"input_tokens"`
+	OutputTokens        int64   `json:"output_tokens"`
+	CacheCreationTokens int64   `json:"cache_creation_tokens"`
+	CacheReadTokens     int64   `json:"cache_read_tokens"`
+	CostUsd             float64 `json:"cost_usd"`
+}
+
+func (q *Queries) ListSessionUsageTotals(ctx context.Context) ([]ListSessionUsageTotalsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSessionUsageTotals)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSessionUsageTotalsRow{}
+	for rows.Next() {
+		var i ListSessionUsageTotalsRow
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.CacheCreationTokens,
+			&i.CacheReadTokens,
+			&i.CostUsd,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessions = `-- name: ListSessions :many
+SELECT id, name, slug, path, remote, state, metadata, created_at, updated_at, clone_strategy, tags, notes FROM sessions
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListSessions(ctx context.Context) ([]Session, error) {
+	rows, err := q.db.QueryContext(ctx, listSessions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Session{}
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Slug,
+			&i.Path,
+			&i.Remote,
+			&i.State,
+			&i.Metadata,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CloneStrategy,
+			&i.Tags,
+			&i.Notes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTodoItems = `-- name: ListTodoItems :many
+SELECT id, session_id, source, title, uri, status, created_at, updated_at, completed_at FROM todo_items ORDER BY created_at DESC
+`
+
+func (q *Queries) ListTodoItems(ctx context.Context) ([]TodoItem, error) {
+	rows, err := q.db.QueryContext(ctx, listTodoItems)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TodoItem{}
+	for rows.Next() {
+		var i TodoItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.SessionID,
+			&i.Source,
+			&i.Title,
+			&i.Uri,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTodoItemsByStatus = `-- name: ListTodoItemsByStatus :many
 SELECT id, session_id, source, title, uri, status, created_at, updated_at, completed_at FROM todo_items WHERE status = ? ORDER BY created_at DESC
 `
 
@@ -764,6 +1373,34 @@ func (q *Queries) ListTodoItemsByStatus(ctx context.Context, status string) ([]T
 	return items, nil
 }
 
+const listTopicSeqs = `-- name: ListTopicSeqs :many
+SELECT topic, last_seq FROM topic_seqs
+ORDER BY topic ASC
+`
+
+func (q *Queries) ListTopicSeqs(ctx context.Context) ([]TopicSeq, error) {
+	rows, err := q.db.QueryContext(ctx, listTopicSeqs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TopicSeq{}
+	for rows.Next() {
+		var i TopicSeq
+		if err := rows.Scan(&i.Topic, &i.LastSeq); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTopics = `-- name: ListTopics :many
 SELECT name FROM topics
 ORDER BY name ASC
@@ -792,6 +1429,96 @@ func (q *Queries) ListTopics(ctx context.Context) ([]string, error) {
 	return items, nil
 }
 
+const listUsageByRepoDay = `-- name: ListUsageByRepoDay :many
+SELECT remote, day,
+    CAST(SUM(input_tokens) AS INTEGER) AS input_tokens,
+    CAST(SUM(output_tokens) AS INTEGER) AS output_tokens,
+    CAST(SUM(cache_creation_tokens) AS INTEGER) AS cache_creation_tokens,
+    CAST(SUM(cache_read_tokens) AS INTEGER) AS cache_read_tokens,
+    CAST(SUM(cost_usd) AS REAL) AS cost_usd
+FROM usage_stats
+WHERE day >= ?
+GROUP BY remote, day
+ORDER BY day, remote
+`
+
+type ListUsageByRepoDayRow struct {
+	Remote              string  `json:"remote"`
+	Day                 string  `json:"day"`
+	InputTokens         int64   `json:"input_tokens"`
+	OutputTokens        int64   `json:"output_tokens"`
+	CacheCreationTokens int64   `json:"cache_creation_tokens"`
+	CacheReadTokens     int64   `json:"cache_read_tokens"`
+	CostUsd             float64 `json:"cost_usd"`
+}
+
+func (q *Queries) ListUsageByRepoDay(ctx context.Context, day string) ([]ListUsageByRepoDayRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUsageByRepoDay, day)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUsageByRepoDayRow{}
+	for rows.Next() {
+		var i ListUsageByRepoDayRow
+		if err := rows.Scan(
+			&i.Remote,
+			&i.Day,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.CacheCreationTokens,
+			&i.CacheReadTokens,
+			&i.CostUsd,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markReviewSessionDispatched = `-- name: MarkReviewSessionDispatched :exec
+UPDATE review_sessions
+SET dispatched_at = ?, dispatched_to = ?, dispatch_reply_to = ?
+WHERE id = ?
+`
+
+type MarkReviewSessionDispatchedParams struct {
+	DispatchedAt    sql.NullInt64 `json:"dispatched_at"`
+	DispatchedTo    string        `json:"dispatched_to"`
+	DispatchReplyTo string        `json:"dispatch_reply_to"`
+	ID              string        `json:"id"`
+}
+
+func (q *Queries) MarkReviewSessionDispatched(ctx context.Context, arg MarkReviewSessionDispatchedParams) error {
+	_, err := q.db.ExecContext(ctx, markReviewSessionDispatched,
+		arg.DispatchedAt,
+		arg.DispatchedTo,
+		arg.DispatchReplyTo,
+		arg.ID,
+	)
+	return err
+}
+
+const nextTopicSeq = `-- name: NextTopicSeq :one
+INSERT INTO topic_seqs (topic, last_seq) VALUES (?, 1)
+ON CONFLICT(topic) DO UPDATE SET last_seq = last_seq + 1
+RETURNING last_seq
+`
+
+func (q *Queries) NextTopicSeq(ctx context.Context, topic string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, nextTopicSeq, topic)
+	var last_seq int64
+	err := row.Scan(&last_seq)
+	return last_seq, err
+}
+
 const pruneMessages = `-- name: PruneMessages :exec
 DELETE FROM messages
 WHERE created_at < ?
@@ -802,47 +1529,161 @@ func (q *Queries) PruneMessages(ctx context.Context, createdAt int64) error {
 	return err
 }
 
+const pruneMessagesInTopic = `-- name: PruneMessagesInTopic :execrows
+DELETE FROM messages
+WHERE topic = ?
+  AND (created_at < ? OR seq <= ?)
+`
+
+type PruneMessagesInTopicParams struct {
+	Topic     string `json:"topic"`
+	CreatedAt int64  `json:"created_at"`
+	Seq       int64  `json:"seq"`
+}
+
+// Deletes the messages of a topic created before a time or up to a sequence
+// number.
+func (q *Queries) PruneMessagesInTopic(ctx context.Context, arg PruneMessagesInTopicParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, pruneMessagesInTopic, arg.Topic, arg.CreatedAt, arg.Seq)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const pruneAckedMessagesInTopic = `-- name: PruneAckedMessagesInTopic :execrows
+DELETE FROM messages
+WHERE topic = ?
+  AND (created_at < ? OR seq <= ?)
+  AND (
+    EXISTS (SELECT 1 FROM message_reads AS r WHERE r.message_id = messages.id)
+    OR EXISTS (SELECT 1 FROM message_cursors AS c WHERE c.topic = messages.topic AND c.seq >= messages.seq)
+  )
+`
+
+type PruneAckedMessagesInTopicParams struct {
+	Topic     string `json:"topic"`
+	CreatedAt int64  `json:"created_at"`
+	Seq       int64  `json:"seq"`
+}
+
+// Deletes the acknowledged messages of a topic created before a time or up to
+// a sequence number. A message is acknowledged once a consumer marked it read
+// or moved its cursor past it.
+func (q *Queries) PruneAckedMessagesInTopic(ctx context.Context, arg PruneAckedMessagesInTopicParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, pruneAckedMessagesInTopic, arg.Topic, arg.CreatedAt, arg.Seq)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const publishMessage = `-- name: PublishMessage :exec
 INSERT INTO messages (
-    id, topic, payload, sender, session_id, created_at
-) VALUES (?, ?, ?, ?, ?, ?)
+    id, topic, seq, payload, sender, session_id, created_at, reply_to, type, correlation_id, content_type
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type PublishMessageParams struct {
-	ID        string         `json:"id"`
-	Topic     string         `json:"topic"`
-	Payload   string         `json:"payload"`
-	Sender    sql.NullString `json:"sender"`
-	SessionID sql.NullString `json:"session_id"`
-	CreatedAt int64          `json:"created_at"`
+	ID            string         `json:"id"`
+	Topic         string         `json:"topic"`
+	Seq           int64          `json:"seq"`
+	Payload       string         `json:"payload"`
+	Sender        sql.NullString `json:"sender"`
+	SessionID     sql.NullString `json:"session_id"`
+	CreatedAt     int64          `json:"created_at"`
+	ReplyTo       sql.NullString `json:"reply_to"`
+	Type          sql.NullString `json:"type"`
+	CorrelationID sql.NullString `json:"correlation_id"`
+	ContentType   sql.NullString `json:"content_type"`
 }
 
 func (q *Queries) PublishMessage(ctx context.Context, arg PublishMessageParams) error {
 	_, err := q.db.ExecContext(ctx, publishMessage,
 		arg.ID,
 		arg.Topic,
+		arg.Seq,
 		arg.Payload,
 		arg.Sender,
 		arg.SessionID,
 		arg.CreatedAt,
+		arg.ReplyTo,
+		arg.Type,
+		arg.CorrelationID,
+		arg.ContentType,
+	)
+	return err
+}
+
+const releaseWorkerLease = `-- name: ReleaseWorkerLease :exec
+DELETE FROM worker_leases WHERE name = ? AND holder = ?
+`
+
+type ReleaseWorkerLeaseParams struct {
+	Name   string `json:"name"`
+	Holder string `json:"holder"`
+}
+
+func (q *Queries) ReleaseWorkerLease(ctx context.Context, arg ReleaseWorkerLeaseParams) error {
+	_, err := q.db.ExecContext(ctx, releaseWorkerLease, arg.Name, arg.Holder)
+	return err
+}
+
+const saveDocumentAnnotation = `-- name: SaveDocumentAnnotation :exec
+INSERT INTO document_annotations (
+    id, document_path, content_hash, start_line, end_line, start_col, end_col,
+    context_text, note, created_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type SaveDocumentAnnotationParams struct {
+	ID           string `json:"id"`
+	DocumentPath string `json:"document_path"`
+	ContentHash  string `json:"content_hash"`
+	StartLine    int64  `json:"start_line"`
+	EndLine      int64  `json:"end_line"`
+	StartCol     int64  `json:"start_col"`
+	EndCol       int64  `json:"end_col"`
+	ContextText  string `json:"context_text"`
+	Note         string `json:"note"`
+	CreatedAt    int64  `json:"created_at"`
+}
+
+func (q *Queries) SaveDocumentAnnotation(ctx context.Context, arg SaveDocumentAnnotationParams) error {
+	_, err := q.db.ExecContext(ctx, saveDocumentAnnotation,
+		arg.ID,
+		arg.DocumentPath,
+		arg.ContentHash,
+		arg.StartLine,
+		arg.EndLine,
+		arg.StartCol,
+		arg.EndCol,
+		arg.ContextText,
+		arg.Note,
+		arg.CreatedAt,
 	)
 	return err
 }
 
 const saveReviewComment = `-- name: SaveReviewComment :exec
 INSERT INTO review_comments (
-    id, session_id, start_line, end_line, context_text, comment_text, created_at
-) VALUES (?, ?, ?, ?, ?, ?, ?)
+    id, session_id, start_line, end_line, context_text, comment_text, created_at, document_path,
+    start_col, end_col, severity
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type SaveReviewCommentParams struct {
-	ID          string `json:"id"`
-	SessionID   string `json:"session_id"`
-	StartLine   int64  `json:"start_line"`
-	EndLine     int64  `json:"end_line"`
-	ContextText string `json:"context_text"`
-	CommentText string `json:"comment_text"`
-	CreatedAt   int64  `json:"created_at"`
+	ID           string `json:"id"`
+	SessionID    string `json:"session_id"`
+	StartLine    int64  `json:"start_line"`
+	EndLine      int64  `json:"end_line"`
+	ContextText  string `json:"context_text"`
+	CommentText  string `json:"comment_text"`
+	CreatedAt    int64  `json:"created_at"`
+	DocumentPath string `json:"document_path"`
+	StartCol     int64  `json:"start_col"`
+	EndCol       int64  `json:"end_col"`
+	Severity     string `json:"severity"`
 }
 
 func (q *Queries) SaveReviewComment(ctx context.Context, arg SaveReviewCommentParams) error {
@@ -854,15 +1695,19 @@ func (q *Queries) SaveReviewComment(ctx context.Context, arg SaveReviewCommentPa
 		arg.ContextText,
 		arg.CommentText,
 		arg.CreatedAt,
+		arg.DocumentPath,
+		arg.StartCol,
+		arg.EndCol,
+		arg.Severity,
 	)
 	return err
 }
 
 const saveSession = `-- name: SaveSession :exec
 INSERT INTO sessions (
-    id, name, slug, path, remote, state, clone_strategy, metadata, tags,
+    id, name, slug, path, remote, state, clone_strategy, metadata, tags, notes,
     created_at, updated_at
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(id) DO UPDATE SET
     name = excluded.name,
     slug = excluded.slug,
@@ -872,6 +1717,7 @@ ON CONFLICT(id) DO UPDATE SET
     clone_strategy = excluded.clone_strategy,
     metadata = excluded.metadata,
     tags = excluded.tags,
+    notes = excluded.notes,
     updated_at = excluded.updated_at
 `
 
@@ -885,6 +1731,7 @@ type SaveSessionParams struct {
 	CloneStrategy string         `json:"clone_strategy"`
 	Metadata      sql.NullString `json:"metadata"`
 	Tags          sql.NullString `json:"tags"`
+	Notes         string         `json:"notes"`
 	CreatedAt     int64          `json:"created_at"`
 	UpdatedAt     int64          `json:"updated_at"`
 }
@@ -900,16 +1747,89 @@ func (q *Queries) SaveSession(ctx context.Context, arg SaveSessionParams) error
 		arg.CloneStrategy,
 		arg.Metadata,
 		arg.Tags,
+		arg.Notes,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
 	return err
 }
 
+const searchFinalizedReviewComments = `-- name: SearchFinalizedReviewComments :many
+SELECT rc.id, rc.session_id, rc.start_line, rc.end_line, rc.context_text, rc.comment_text, rc.created_at, rc.document_path, rc.start_col, rc.end_col, rc.outdated, rc.severity,
+    rs.document_path AS session_document_path,
+    rs.finalized_at
+FROM review_comments_fts
+JOIN review_comments rc ON rc.id = review_comments_fts.id
+JOIN review_sessions rs ON rs.id = rc.session_id
+WHERE review_comments_fts MATCH ? AND rs.finalized_at IS NOT NULL
+ORDER BY rs.finalized_at DESC, rc.start_line ASC
+LIMIT ?
+`
+
+type SearchFinalizedReviewCommentsParams struct {
+	Query      string `json:"query"`
+	MaxResults int64  `json:"max_results"`
+}
+
+type SearchFinalizedReviewCommentsRow struct {
+	ID                  string        `json:"id"`
+	SessionID           string        `json:"session_id"`
+	StartLine           int64         `json:"start_line"`
+	EndLine             int64         `json:"end_line"`
+	ContextText         string        `json:"context_text"`
+	CommentText         string        `json:"comment_text"`
+	CreatedAt           int64         `json:"created_at"`
+	DocumentPath        string        `json:"document_path"`
+	StartCol            int64         `json:"start_col"`
+	EndCol              int64         `json:"end_col"`
+	Outdated            int64         `json:"outdated"`
+	Severity            string        `json:"severity"`
+	SessionDocumentPath string        `json:"session_document_path"`
+	FinalizedAt         sql.NullInt64 `json:"finalized_at"`
+}
+
+func (q *Queries) SearchFinalizedReviewComments(ctx context.Context, arg SearchFinalizedReviewCommentsParams) ([]SearchFinalizedReviewCommentsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchFinalizedReviewComments, arg.Query, arg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchFinalizedReviewCommentsRow{}
+	for rows.Next() {
+		var i SearchFinalizedReviewCommentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SessionID,
+			&i.StartLine,
+			&i.EndLine,
+			&i.ContextText,
+			&i.CommentText,
+			&i.CreatedAt,
+			&i.DocumentPath,
+			&i.StartCol,
+			&i.EndCol,
+			&i.Outdated,
+			&i.Severity,
+			&i.SessionDocumentPath,
+			&i.FinalizedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const subscribeToTopic = `-- name: SubscribeToTopic :many
-SELECT id, topic, payload, sender, session_id, created_at FROM messages
+SELECT id, topic, payload, sender, session_id, created_at, seq, reply_to, type, correlation_id, content_type FROM messages
 WHERE topic = ? AND created_at > ?
-ORDER BY created_at ASC
+ORDER BY seq ASC
 `
 
 type SubscribeToTopicParams struct {
@@ -933,6 +1853,57 @@ func (q *Queries) SubscribeToTopic(ctx context.Context, arg SubscribeToTopicPara
 			&i.Sender,
 			&i.SessionID,
 			&i.CreatedAt,
+			&i.Seq,
+			&i.ReplyTo,
+			&i.Type,
+			&i.CorrelationID,
+			&i.ContentType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const subscribeToTopicAfterSeq = `-- name: SubscribeToTopicAfterSeq :many
+SELECT id, topic, payload, sender, session_id, created_at, seq, reply_to, type, correlation_id, content_type FROM messages
+WHERE topic = ? AND seq > ?
+ORDER BY seq ASC
+`
+
+type SubscribeToTopicAfterSeqParams struct {
+	Topic string `json:"topic"`
+	Seq   int64  `json:"seq"`
+}
+
+func (q *Queries) SubscribeToTopicAfterSeq(ctx context.Context, arg SubscribeToTopicAfterSeqParams) ([]Message, error) {
+	rows, err := q.db.QueryContext(ctx, subscribeToTopicAfterSeq, arg.Topic, arg.Seq)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Message{}
+	for rows.Next() {
+		var i Message
+		if err := rows.Scan(
+			&i.ID,
+			&i.Topic,
+			&i.Payload,
+			&i.Sender,
+			&i.SessionID,
+			&i.CreatedAt,
+			&i.Seq,
+			&i.ReplyTo,
+			&i.Type,
+			&i.CorrelationID,
+			&i.ContentType,
 		); err != nil {
 			return nil, err
 		}
@@ -947,19 +1918,126 @@ func (q *Queries) SubscribeToTopic(ctx context.Context, arg SubscribeToTopicPara
 	return items, nil
 }
 
+const updateDocumentAnnotationAnchor = `-- name: UpdateDocumentAnnotationAnchor :exec
+UPDATE document_annotations
+SET content_hash = ?, start_line = ?, end_line = ?, start_col = ?, end_col = ?, outdated = ?
+WHERE id = ?
+`
+
+type UpdateDocumentAnnotationAnchorParams struct {
+	ContentHash string `json:"content_hash"`
+	StartLine   int64  `json:"start_line"`
+	EndLine     int64  `json:"end_line"`
+	StartCol    int64  `json:"start_col"`
+	EndCol      int64  `json:"end_col"`
+	Outdated    int64  `json:"outdated"`
+	ID          string `json:"id"`
+}
+
+func (q *Queries) UpdateDocumentAnnotationAnchor(ctx context.Context, arg UpdateDocumentAnnotationAnchorParams) error {
+	_, err := q.db.ExecContext(ctx, updateDocumentAnnotationAnchor,
+		arg.ContentHash,
+		arg.StartLine,
+		arg.EndLine,
+		arg.StartCol,
+		arg.EndCol,
+		arg.Outdated,
+		arg.ID,
+	)
+	return err
+}
+
+const updateDocumentAnnotationNote = `-- name: UpdateDocumentAnnotationNote :exec
+UPDATE document_annotations
+SET note = ?
+WHERE id = ?
+`
+
+type UpdateDocumentAnnotationNoteParams struct {
+	Note string `json:"note"`
+	ID   string `json:"id"`
+}
+
+func (q *Queries) UpdateDocumentAnnotationNote(ctx context.Context, arg UpdateDocumentAnnotationNoteParams) error {
+	_, err := q.db.ExecContext(ctx, updateDocumentAnnotationNote, arg.Note, arg.ID)
+	return err
+}
+
 const updateReviewComment = `-- name: UpdateReviewComment :exec
 UPDATE review_comments
-SET comment_text = ?
+SET comment_text = ?, severity = ?
 WHERE id = ?
 `
 
 type UpdateReviewCommentParams struct {
 	CommentText string `json:"comment_text"`
+	Severity    string `json:"severity"`
 	ID          string `json:"id"`
 }
 
 func (q *Queries) UpdateReviewComment(ctx context.Context, arg UpdateReviewCommentParams) error {
-	_, err := q.db.ExecContext(ctx, updateReviewComment, arg.CommentText, arg.ID)
+	_, err := q.db.ExecContext(ctx, updateReviewComment, arg.CommentText, arg.Severity, arg.ID)
+	return err
+}
+
+const updateReviewCommentAnchor = `-- name: UpdateReviewCommentAnchor :exec
+UPDATE review_comments
+SET start_line = ?, end_line = ?, start_col = ?, end_col = ?, outdated = ?
+WHERE id = ?
+`
+
+type UpdateReviewCommentAnchorParams struct {
+	StartLine int64  `json:"start_line"`
+	EndLine   int64  `json:"end_line"`
+	StartCol  int64  `json:"start_col"`
+	EndCol    int64  `json:"end_col"`
+	Outdated  int64  `json:"outdated"`
+	ID        string `json:"id"`
+}
+
+func (q *Queries) UpdateReviewCommentAnchor(ctx context.Context, arg UpdateReviewCommentAnchorParams) error {
+	_, err := q.db.ExecContext(ctx, updateReviewCommentAnchor,
+		arg.StartLine,
+		arg.EndLine,
+		arg.StartCol,
+		arg.EndCol,
+		arg.Outdated,
+		arg.ID,
+	)
+	return err
+}
+
+const updateReviewSessionDocumentHash = `-- name: UpdateReviewSessionDocumentHash :exec
+UPDATE review_session_documents
+SET content_hash = ?
+WHERE session_id = ? AND document_path = ?
+`
+
+type UpdateReviewSessionDocumentHashParams struct {
+	ContentHash  string `json:"content_hash"`
+	SessionID    string `json:"session_id"`
+	DocumentPath string `json:"document_path"`
+}
+
+func (q *Queries) UpdateReviewSessionDocumentHash(ctx context.Context, arg UpdateReviewSessionDocumentHashParams) error {
+	_, err := q.db.ExecContext(ctx, updateReviewSessionDocumentHash, arg.ContentHash, arg.SessionID, arg.DocumentPath)
+	return err
+}
+
+const updateReviewSessionHash = `-- name: UpdateReviewSessionHash :exec
+UPDATE review_sessions
+SET content_hash = ?
+WHERE id = ? AND document_path = ?
+`
+
+type UpdateReviewSessionHashParams struct {
+	ContentHash  string `json:"content_hash"`
+	ID           string `json:"id"`
+	DocumentPath string `json:"document_path"`
+}
+
+func (q *Queries) UpdateReviewSessionHash(ctx context.Context, arg UpdateReviewSessionHashParams) error {
+	_, err := q.db.ExecContext(ctx, updateReviewSessionHash, arg.ContentHash, arg.ID, arg.DocumentPath)
 	return err
 }
 
@@ -983,3 +2061,50 @@ func (q *Queries) UpdateTodoItemStatus(ctx context.Context, arg UpdateTodoItemSt
 	)
 	return err
 }
+
+const upsertUsageStat = `-- name: UpsertUsageStat :exec
+
+INSERT INTO usage_stats (
+    session_id, remote, day, input_tokens, output_tokens,
+    cache_creation_tokens, cache_read_tokens, cost_usd, updated_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(session_id, day) DO UPDATE SET
+    remote = excluded.remote,
+    input_tokens = MAX(input_tokens, excluded.input_tokens),
+    output_tokens = MAX(output_tokens, excluded.output_tokens),
+    cache_creation_tokens = MAX(cache_creation_tokens, excluded.cache_creation_tokens),
+    cache_read_tokens = MAX(cache_read_tokens, excluded.cache_read_tokens),
+    cost_usd = MAX(cost_usd, excluded.cost_usd),
+    updated_at = excluded.updated_at
+`
+
+type UpsertUsageStatParams struct {
+	SessionID           string  `json:"session_id"`
+	Remote              string  `json:"remote"`
+	Day                 string  `json:"day"`
+	InputTokens         int64   `json:"input_tokens"`
+	OutputTokens        int64   `json:"output_tokens"`
+	CacheCreationTokens int64   `json:"cache_creation_tokens"`
+	CacheReadTokens     int64   `json:"cache_read_tokens"`
+	CostUsd             float64 `json:"cost_usd"`
+	UpdatedAt           int64   `json:"updated_at"`
+}
+
+// Usage Stats
+//
+// Counters only grow: agents prune old transcripts, and a re-scan after
+// pruning must not lower totals that were already recorded.
+func (q *Queries) UpsertUsageStat(ctx context.Context, arg UpsertUsageStatParams) error {
+	_, err := q.db.ExecContext(ctx, upsertUsageStat,
+		arg.SessionID,
+		arg.Remote,
+		arg.Day,
+		arg.InputTokens,
+		arg.OutputTokens,
+		arg.CacheCreationTokens,
+		arg.CacheReadTokens,
+		arg.CostUsd,
+		arg.UpdatedAt,
+	)
+	return err
+}
@@ -8,9 +8,9 @@ WHERE id = ?;
 
 -- name: SaveSession :exec
 INSERT INTO sessions (
-    id, name, slug, path, remote, state, clone_strategy, metadata, tags,
+    id, name, slug, path, remote, state, clone_strategy, metadata, tags, notes,
     created_at, updated_at
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(id) DO UPDATE SET
     name = excluded.name,
     slug = excluded.slug,
@@ -20,15 +20,28 @@ ON CONFLICT(id) DO UPDATE SET
     clone_strategy = excluded.clone_strategy,
     metadata = excluded.metadata,
     tags = excluded.tags,
+    notes = excluded.notes,
     updated_at = excluded.updated_at;
 
 -- name: DeleteSession :exec
 DELETE FROM sessions WHERE id = ?;
 
+-- name: NextTopicSeq :one
+INSERT INTO topic_seqs (topic, last_seq) VALUES (?, 1)
+ON CONFLICT(topic) DO UPDATE SET last_seq = last_seq + 1
+RETURNING last_seq;
+
+-- name: ListTopicSeqs :many
+SELECT * FROM topic_seqs
+ORDER BY topic ASC;
+
 -- name: PublishMessage :exec
 INSERT INTO messages (
-    id, topic, payload, sender, session_id, created_at
-) VALUES (?, ?, ?, ?, ?, ?);
+    id, topic, seq, payload, sender, session_id, created_at, reply_to, type, correlation_id, content_type
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: CountMessages :one
+SELECT COUNT(*) FROM messages;
 
 -- name: CountMessagesInTopic :one
 SELECT COUNT(*) FROM messages
@@ -39,14 +52,23 @@ DELETE FROM messages
 WHERE id IN (
     SELECT id FROM messages AS m
     WHERE m.topic = ?
-    ORDER BY m.created_at ASC
+    ORDER BY m.seq ASC
     LIMIT ?
 );
 
+-- name: DeleteMessagesInTopic :execrows
+DELETE FROM messages
+WHERE topic = ?;
+
 -- name: SubscribeToTopic :many
 SELECT * FROM messages
 WHERE topic = ? AND created_at > ?
-ORDER BY created_at ASC;
+ORDER BY seq ASC;
+
+-- name: SubscribeToTopicAfterSeq :many
+SELECT * FROM messages
+WHERE topic = ? AND seq > ?
+ORDER BY seq ASC;
 
 -- name: ListTopics :many
 SELECT name FROM topics
@@ -60,6 +82,31 @@ WHERE created_at < ?;
 SELECT COUNT(*) FROM messages
 WHERE created_at < ?;
 
+-- name: PruneMessagesInTopic :execrows
+-- Deletes the messages of a topic created before a time or up to a sequence
+-- number.
+DELETE FROM messages
+WHERE topic = ?
+  AND (created_at < ? OR seq <= ?);
+
+-- name: PruneAckedMessagesInTopic :execrows
+-- Deletes the acknowledged messages of a topic created before a time or up to
+-- a sequence number. A message is acknowledged once a consumer marked it read
+-- or moved its cursor past it.
+DELETE FROM messages
+WHERE topic = ?
+  AND (created_at < ? OR seq <= ?)
+  AND (
+    EXISTS (SELECT 1 FROM message_reads AS r WHERE r.message_id = messages.id)
+    OR EXISTS (SELECT 1 FROM message_cursors AS c WHERE c.topic = messages.topic AND c.seq >= messages.seq)
+  );
+
+-- name: GetMessageSeqAtOffset :one
+SELECT seq FROM messages
+WHERE topic = ?
+ORDER BY seq ASC
+LIMIT 1 OFFSET ?;
+
 -- name: AcknowledgeMessages :exec
 INSERT INTO message_reads (message_id, consumer_id, read_at)
 VALUES (?, ?, ?)
@@ -67,12 +114,30 @@ ON CONFLICT (message_id, consumer_id) DO UPDATE SET
     read_at = excluded.read_at;
 
 -- name: GetUnreadMessages :many
-SELECT m.id, m.topic, m.payload, m.sender, m.session_id, m.created_at
+SELECT m.id, m.topic, m.payload, m.sender, m.session_id, m.created_at, m.seq, m.reply_to, m.type, m.correlation_id, m.content_type
 FROM messages m
 LEFT JOIN message_reads mr ON mr.message_id = m.id AND mr.consumer_id = ?
 WHERE m.topic = ?
   AND mr.message_id IS NULL
-ORDER BY m.created_at ASC;
+ORDER BY m.seq ASC;
+
+-- name: GetMessageByID :one
+SELECT * FROM messages
+WHERE id = ?;
+
+-- name: AdvanceConsumerCursor :exec
+INSERT INTO message_cursors (consumer, topic, seq, message_id, updated_at)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (consumer, topic) DO UPDATE SET
+    seq = excluded.seq,
+    message_id = excluded.message_id,
+    updated_at = excluded.updated_at
+WHERE excluded.seq > message_cursors.seq;
+
+-- name: ListConsumerCursors :many
+SELECT * FROM message_cursors
+WHERE consumer = ?
+ORDER BY topic ASC;
 
 -- name: CreateReviewSession :exec
 INSERT INTO review_sessions (
@@ -94,33 +159,107 @@ UPDATE review_sessions
 SET finalized_at = ?
 WHERE id = ?;
 
+-- name: MarkReviewSessionDispatched :exec
+UPDATE review_sessions
+SET dispatched_at = ?, dispatched_to = ?, dispatch_reply_to = ?
+WHERE id = ?;
+
+-- name: AddReviewSessionTime :exec
+UPDATE review_sessions
+SET review_time = review_time + ?
+WHERE id = ?;
+
 -- name: DeleteReviewSession :exec
 DELETE FROM review_sessions
 WHERE id = ?;
 
 -- name: DeleteReviewSessionsByDocPath :exec
 DELETE FROM review_sessions
-WHERE document_path = ? AND content_hash != ?;
+WHERE document_path = ? AND content_hash != ? AND finalized_at IS NULL;
 
 -- name: SaveReviewComment :exec
 INSERT INTO review_comments (
-    id, session_id, start_line, end_line, context_text, comment_text, created_at
-) VALUES (?, ?, ?, ?, ?, ?, ?);
+    id, session_id, start_line, end_line, context_text, comment_text, created_at, document_path,
+    start_col, end_col, severity
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: ListReviewComments :many
 SELECT * FROM review_comments
 WHERE session_id = ?
 ORDER BY start_line ASC;
 
+-- name: SearchFinalizedReviewComments :many
+SELECT rc.id, rc.session_id, rc.start_line, rc.end_line, rc.context_text, rc.comment_text, rc.created_at, rc.document_path, rc.start_col, rc.end_col, rc.outdated, rc.severity,
+    rs.document_path AS session_document_path,
+    rs.finalized_at
+FROM review_comments_fts
+JOIN review_comments rc ON rc.id = review_comments_fts.id
+JOIN review_sessions rs ON rs.id = rc.session_id
+WHERE review_comments_fts MATCH sqlc.arg(query) AND rs.finalized_at IS NOT NULL
+ORDER BY rs.finalized_at DESC, rc.start_line ASC
+LIMIT sqlc.arg(max_results);
+
 -- name: UpdateReviewComment :exec
 UPDATE review_comments
-SET comment_text = ?
+SET comment_text = ?, severity = ?
+WHERE id = ?;
+
+-- name: AddReviewCommentRevision :exec
+INSERT INTO review_comment_revisions (comment_id, comment_text, severity, replaced_at)
+SELECT id, comment_text, severity, CAST(sqlc.arg(replaced_at) AS INTEGER) FROM review_comments
+WHERE id = sqlc.arg(id) AND (comment_text != sqlc.arg(comment_text) OR severity != sqlc.arg(severity));
+
+-- name: ListReviewCommentRevisions :many
+SELECT * FROM review_comment_revisions
+WHERE comment_id = ?
+ORDER BY id ASC;
+
+-- name: UpdateReviewCommentAnchor :exec
+UPDATE review_comments
+SET start_line = ?, end_line = ?, start_col = ?, end_col = ?, outdated = ?
 WHERE id = ?;
 
 -- name: DeleteReviewComment :exec
 DELETE FROM review_comments
 WHERE id = ?;
 
+-- name: AddReviewSessionDocument :exec
+INSERT INTO review_session_documents (
+    session_id, document_path, content_hash, added_at
+) VALUES (?, ?, ?, ?);
+
+-- name: UpdateReviewSessionHash :exec
+UPDATE review_sessions
+SET content_hash = ?
+WHERE id = ? AND document_path = ?;
+
+-- name: UpdateReviewSessionDocumentHash :exec
+UPDATE review_session_documents
+SET content_hash = ?
+WHERE session_id = ? AND document_path = ?;
+
+-- name: ListReviewSessionDocuments :many
+SELECT * FROM review_session_documents
+WHERE session_id = ?
+ORDER BY added_at ASC;
+
+-- name: GetActiveReviewSessionByDocument :one
+SELECT rs.* FROM review_sessions rs
+JOIN review_session_documents rsd ON rsd.session_id = rs.id
+WHERE rsd.document_path = ? AND rs.finalized_at IS NULL
+ORDER BY rs.created_at DESC
+LIMIT 1;
+
+-- name: ListLastReviewedDocuments :many
+SELECT rsd.document_path, rsd.content_hash
+FROM review_session_documents rsd
+JOIN review_sessions rs ON rs.id = rsd.session_id
+WHERE rs.finalized_at = (
+    SELECT MAX(rs2.finalized_at) FROM review_sessions rs2
+    JOIN review_session_documents rsd2 ON rsd2.session_id = rs2.id
+    WHERE rsd2.document_path = rsd.document_path
+);
+
 -- name: GetAllActiveSessionsWithCounts :many
 SELECT
     rs.id,
@@ -128,6 +267,7 @@ SELECT
     rs.content_hash,
     rs.created_at,
     rs.finalized_at,
+    rs.review_time,
     COUNT(rc.id) as comment_count
 FROM review_sessions rs
 LEFT JOIN review_comments rc ON rs.id = rc.session_id
@@ -206,3 +346,106 @@ SELECT COUNT(*) FROM todo_items WHERE session_id = ? AND created_at > ?;
 
 -- name: DeleteTodoItem :exec
 DELETE FROM todo_items WHERE id = ?;
+
+-- Activity: per-day counts. Days are bucketed by (timestamp + offset_ns) / 1 day
+-- so callers can shift buckets into their local timezone.
+
+-- name: CountSessionsByDay :many
+SELECT CAST((created_at + sqlc.arg(offset_ns)) / 86400000000000 AS INTEGER) AS day, COUNT(*) AS count
+FROM sessions
+WHERE created_at >= sqlc.arg(since) AND created_at < sqlc.arg(until)
+GROUP BY day
+ORDER BY day;
+
+-- name: CountReviewsFinalizedByDay :many
+SELECT CAST((finalized_at + sqlc.arg(offset_ns)) / 86400000000000 AS INTEGER) AS day, COUNT(*) AS count,
+    CAST(SUM(review_time) AS INTEGER) AS review_time
+FROM review_sessions
+WHERE finalized_at >= sqlc.arg(since) AND finalized_at < sqlc.arg(until)
+GROUP BY day
+ORDER BY day;
+
+-- name: CountMessagesByDay :many
+SELECT CAST((created_at + sqlc.arg(offset_ns)) / 86400000000000 AS INTEGER) AS day, COUNT(*) AS count
+FROM messages
+WHERE created_at >= sqlc.arg(since) AND created_at < sqlc.arg(until)
+GROUP BY day
+ORDER BY day;
+
+-- Usage Stats
+
+-- Counters only grow: agents prune old transcripts, and a re-scan after
+-- pruning must not lower totals that were already recorded.
+-- name: UpsertUsageStat :exec
+INSERT INTO usage_stats (
+    session_id, remote, day, input_tokens, output_tokens,
+    cache_creation_tokens, cache_read_tokens, cost_usd, updated_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(session_id, day) DO UPDATE SET
+    remote = excluded.remote,
+    input_tokens = MAX(input_tokens, excluded.input_tokens),
+    output_tokens = MAX(output_tokens, excluded.output_tokens),
+    cache_creation_tokens = MAX(cache_creation_tokens, excluded.cache_creation_tokens),
+    cache_read_tokens = MAX(cache_read_tokens, excluded.cache_read_tokens),
+    cost_usd = MAX(cost_usd, excluded.cost_usd),
+    updated_at = excluded.updated_at;
+
+-- name: ListSessionUsageTotals :many
+SELECT session_id,
+    CAST(SUM(input_tokens) AS INTEGER) AS input_tokens,
+    CAST(SUM(output_tokens) AS INTEGER) AS output_tokens,
+    CAST(SUM(cache_creation_tokens) AS INTEGER) AS cache_creation_tokens,
+    CAST(SUM(cache_read_tokens) AS INTEGER) AS cache_read_tokens,
+    CAST(SUM(cost_usd) AS REAL) AS cost_usd
+FROM usage_stats
+GROUP BY session_id;
+
+-- name: ListUsageByRepoDay :many
+SELECT remote, day,
+    CAST(SUM(input_tokens) AS INTEGER) AS input_tokens,
+    CAST(SUM(output_tokens) AS INTEGER) AS output_tokens,
+    CAST(SUM(cache_creation_tokens) AS INTEGER) AS cache_creation_tokens,
+    CAST(SUM(cache_read_tokens) AS INTEGER) AS cache_read_tokens,
+    CAST(SUM(cost_usd) AS REAL) AS cost_usd
+FROM usage_stats
+WHERE day >= ?
+GROUP BY remote, day
+ORDER BY day, remote;
+
+-- name: SaveDocumentAnnotation :exec
+INSERT INTO document_annotations (
+    id, document_path, content_hash, start_line, end_line, start_col, end_col,
+    context_text, note, created_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListDocumentAnnotations :many
+SELECT * FROM document_annotations
+WHERE document_path = ?
+ORDER BY start_line ASC, created_at ASC;
+
+-- name: UpdateDocumentAnnotationNote :exec
+UPDATE document_annotations
+SET note = ?
+WHERE id = ?;
+
+-- name: UpdateDocumentAnnotationAnchor :exec
+UPDATE document_annotations
+SET content_hash = ?, start_line = ?, end_line = ?, start_col = ?, end_col = ?, outdated = ?
+WHERE id = ?;
+
+-- name: DeleteDocumentAnnotation :exec
+DELETE FROM document_annotations
+WHERE id = ?;
+
+-- name: AcquireWorkerLease :execrows
+INSERT INTO worker_leases (name, holder, acquired_at, expires_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (name) DO UPDATE SET
+    holder = excluded.holder,
+    acquired_at = CASE WHEN worker_leases.holder = excluded.holder
+        THEN worker_leases.acquired_at ELSE excluded.acquired_at END,
+    expires_at = excluded.expires_at
+WHERE worker_leases.holder = excluded.holder OR worker_leases.expires_at <= excluded.acquired_at;
+
+-- name: ReleaseWorkerLease :exec
+DELETE FROM worker_leases WHERE name = ? AND holder = ?;
@@ -0,0 +1,101 @@
+package stores
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/activity"
+	"github.com/colonyops/hive/internal/data/db"
+)
+
+const nanosPerDay = int64(24 * time.Hour)
+
+// ActivityStore implements activity.Store using SQLite.
+type ActivityStore struct {
+	db *db.DB
+}
+
+var _ activity.Store = (*ActivityStore)(nil)
+
+// NewActivityStore creates a new SQLite-backed activity store.
+func NewActivityStore(db *db.DB) *ActivityStore {
+	return &ActivityStore{db: db}
+}
+
+// Daily returns per-day counts of sessions created, reviews finalized and
+// messages published. Buckets use since's UTC offset, so a DST change inside
+// the range shifts the boundary by an hour for the remaining days.
+func (s *ActivityStore) Daily(ctx context.Context, since, until time.Time) ([]activity.Day, error) {
+	start := startOfDay(since)
+	if !until.After(start) {
+		return nil, nil
+	}
+
+	_, offset := start.Zone()
+	offsetNs := int64(offset) * int64(time.Second)
+	q := s.db.Queries()
+
+	sessions, err := q.CountSessionsByDay(ctx, db.CountSessionsByDayParams{
+		OffsetNs: offsetNs,
+		Since:    start.UnixNano(),
+		Until:    until.UnixNano(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("count sessions by day: %w", err)
+	}
+
+	reviews, err := q.CountReviewsFinalizedByDay(ctx, db.CountReviewsFinalizedByDayParams{
+		OffsetNs: offsetNs,
+		Since:    start.UnixNano(),
+		Until:    until.UnixNano(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("count reviews by day: %w", err)
+	}
+
+	messages, err := q.CountMessagesByDay(ctx, db.CountMessagesByDayParams{
+		OffsetNs: offsetNs,
+		Since:    start.UnixNano(),
+		Until:    until.UnixNano(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("count messages by day: %w", err)
+	}
+
+	// Day buckets are days since the epoch in the shifted timeline.
+	firstBucket := (start.UnixNano() + offsetNs) / nanosPerDay
+	var days []activity.Day
+	for d := start; d.Before(until); d = d.AddDate(0, 0, 1) {
+		days = append(days, activity.Day{Date: d})
+	}
+
+	index := func(bucket int64) (int, bool) {
+		i := int(bucket - firstBucket)
+		return i, i >= 0 && i < len(days)
+	}
+	for _, row := range sessions {
+		if i, ok := index(row.Day); ok {
+			days[i].Sessions = int(row.Count)
+		}
+	}
+	for _, row := range reviews {
+		if i, ok := index(row.Day); ok {
+			days[i].Reviews = int(row.Count)
+			days[i].ReviewTime = time.Duration(row.ReviewTime)
+		}
+	}
+	for _, row := range messages {
+		if i, ok := index(row.Day); ok {
+			days[i].Messages = int(row.Count)
+		}
+	}
+
+	return days, nil
+}
+
+// startOfDay returns midnight of t's day in t's location.
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,54 @@
+package stores
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityStore_Daily(t *testing.T) {
+	ctx := context.Background()
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err)
+	defer func() { _ = database.Close() }()
+
+	// A fixed UTC-5 zone makes day boundaries differ from UTC.
+	loc := time.FixedZone("test", -5*60*60)
+	day := func(d, hour int) int64 {
+		return time.Date(2026, 3, d, hour, 0, 0, 0, loc).UnixNano()
+	}
+
+	conn := database.Conn()
+	for i, ts := range []int64{day(2, 9), day(2, 23), day(4, 1)} {
+		_, err := conn.ExecContext(ctx,
+			`INSERT INTO sessions (id, name, slug, path, remote, state, created_at, updated_at) VALUES (?, 'n', 's', '/p', 'r', 'active', ?, ?)`,
+			string(rune('a'+i)), ts, ts)
+		require.NoError(t, err)
+	}
+	_, err = conn.ExecContext(ctx,
+		`INSERT INTO review_sessions (id, document_path, content_hash, created_at, finalized_at) VALUES ('r1', '/d', 'h', ?, ?), ('r2', '/e', 'h', ?, NULL)`,
+		day(1, 8), day(3, 22), day(3, 8))
+	require.NoError(t, err)
+	_, err = conn.ExecContext(ctx,
+		`INSERT INTO messages (id, topic, payload, seq, created_at) VALUES ('m1', 't', 'x', 1, ?), ('m2', 't', 'y', 2, ?)`,
+		day(3, 0), day(9, 12))
+	require.NoError(t, err)
+
+	store := NewActivityStore(database)
+	days, err := store.Daily(ctx, time.Date(2026, 3, 1, 15, 0, 0, 0, loc), time.Date(2026, 3, 5, 0, 0, 0, 0, loc))
+	require.NoError(t, err)
+	require.Len(t, days, 4, "one entry per day, including empty days")
+
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, loc), days[0].Date)
+	assert.Zero(t, days[0].Total(), "review created but not finalized on day 1")
+
+	assert.Equal(t, 2, days[1].Sessions, "23:00 local stays on the local day")
+	assert.Equal(t, 1, days[2].Reviews)
+	assert.Equal(t, 1, days[2].Messages)
+	assert.Equal(t, 1, days[3].Sessions)
+	assert.Equal(t, 1, days[3].Total(), "activity outside the range is excluded")
+}
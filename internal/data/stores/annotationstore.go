@@ -0,0 +1,123 @@
+package stores
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/internal/data/db"
+)
+
+// AnnotationStore implements review.AnnotationStore using SQLite.
+type AnnotationStore struct {
+	db *db.DB
+}
+
+var _ review.AnnotationStore = (*AnnotationStore)(nil)
+
+// NewAnnotationStore creates a new SQLite-backed annotation store.
+func NewAnnotationStore(db *db.DB) *AnnotationStore {
+	return &AnnotationStore{db: db}
+}
+
+// SaveAnnotation adds an annotation to a document.
+func (s *AnnotationStore) SaveAnnotation(ctx context.Context, annotation review.Annotation) error {
+	err := s.db.Queries().SaveDocumentAnnotation(ctx, db.SaveDocumentAnnotationParams{
+		ID:           annotation.ID,
+		DocumentPath: annotation.DocumentPath,
+		ContentHash:  annotation.ContentHash,
+		StartLine:    int64(annotation.StartLine),
+		EndLine:      int64(annotation.EndLine),
+		StartCol:     int64(annotation.StartCol),
+		EndCol:       int64(annotation.EndCol),
+		ContextText:  annotation.ContextText,
+		Note:         annotation.Note,
+		CreatedAt:    annotation.CreatedAt.UnixNano(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save document annotation: %w", err)
+	}
+	return nil
+}
+
+// ListAnnotations returns all annotations on a document, sorted by start line.
+func (s *AnnotationStore) ListAnnotations(ctx context.Context, documentPath string) ([]review.Annotation, error) {
+	rows, err := s.db.Queries().ListDocumentAnnotations(ctx, documentPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list document annotations: %w", err)
+	}
+
+	annotations := make([]review.Annotation, 0, len(rows))
+	for _, row := range rows {
+		annotations = append(annotations, rowToAnnotation(row))
+	}
+	return annotations, nil
+}
+
+// UpdateAnnotation updates the note of an existing annotation.
+func (s *AnnotationStore) UpdateAnnotation(ctx context.Context, annotation review.Annotation) error {
+	err := s.db.Queries().UpdateDocumentAnnotationNote(ctx, db.UpdateDocumentAnnotationNoteParams{
+		Note: annotation.Note,
+		ID:   annotation.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update document annotation: %w", err)
+	}
+	return nil
+}
+
+// ReanchorAnnotations records a changed document's new content hash and moves
+// the given annotations to their re-anchored positions.
+func (s *AnnotationStore) ReanchorAnnotations(ctx context.Context, contentHash string, annotations []review.Annotation) error {
+	err := s.db.WithTx(ctx, func(q *db.Queries) error {
+		for _, a := range annotations {
+			var outdated int64
+			if a.Outdated {
+				outdated = 1
+			}
+			if err := q.UpdateDocumentAnnotationAnchor(ctx, db.UpdateDocumentAnnotationAnchorParams{
+				ContentHash: contentHash,
+				StartLine:   int64(a.StartLine),
+				EndLine:     int64(a.EndLine),
+				StartCol:    int64(a.StartCol),
+				EndCol:      int64(a.EndCol),
+				Outdated:    outdated,
+				ID:          a.ID,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to re-anchor document annotations: %w", err)
+	}
+	return nil
+}
+
+// DeleteAnnotation removes a specific annotation.
+func (s *AnnotationStore) DeleteAnnotation(ctx context.Context, annotationID string) error {
+	err := s.db.Queries().DeleteDocumentAnnotation(ctx, annotationID)
+	if err != nil {
+		return fmt.Errorf("failed to delete document annotation: %w", err)
+	}
+	return nil
+}
+
+// rowToAnnotation converts a db.DocumentAnnotation to a review.Annotation.
+func rowToAnnotation(row db.DocumentAnnotation) review.Annotation {
+	return review.Annotation{
+		ID:           row.ID,
+		DocumentPath: row.DocumentPath,
+		ContentHash:  row.ContentHash,
+		StartLine:    int(row.StartLine),
+		EndLine:      int(row.EndLine),
+		StartCol:     int(row.StartCol),
+		EndCol:       int(row.EndCol),
+		Outdated:     row.Outdated != 0,
+		ContextText:  row.ContextText,
+		Note:         row.Note,
+		CreatedAt:    time.Unix(0, row.CreatedAt),
+	}
+}
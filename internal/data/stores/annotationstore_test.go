@@ -0,0 +1,108 @@
+package stores
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotationStore(t *testing.T) {
+	ctx := context.Background()
+
+	newStore := func(t *testing.T) (*AnnotationStore, *db.DB) {
+		t.Helper()
+		database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+		require.NoError(t, err, "Open")
+		t.Cleanup(func() { _ = database.Close() })
+		return NewAnnotationStore(database), database
+	}
+
+	annotation := func(docPath string, line int, note string) review.Annotation {
+		return review.Annotation{
+			ID:           uuid.NewString(),
+			DocumentPath: docPath,
+			ContentHash:  "hash1",
+			StartLine:    line,
+			EndLine:      line,
+			ContextText:  "context",
+			Note:         note,
+			CreatedAt:    time.Now(),
+		}
+	}
+
+	t.Run("save and list", func(t *testing.T) {
+		store, _ := newStore(t)
+
+		require.NoError(t, store.SaveAnnotation(ctx, annotation("/tmp/a.md", 5, "later")))
+		require.NoError(t, store.SaveAnnotation(ctx, annotation("/tmp/a.md", 2, "earlier")))
+		require.NoError(t, store.SaveAnnotation(ctx, annotation("/tmp/b.md", 1, "other doc")))
+
+		got, err := store.ListAnnotations(ctx, "/tmp/a.md")
+		require.NoError(t, err, "ListAnnotations")
+		require.Len(t, got, 2)
+		assert.Equal(t, "earlier", got[0].Note)
+		assert.Equal(t, "later", got[1].Note)
+		assert.Equal(t, "hash1", got[0].ContentHash)
+	})
+
+	t.Run("update and delete", func(t *testing.T) {
+		store, _ := newStore(t)
+
+		a := annotation("/tmp/a.md", 1, "first")
+		require.NoError(t, store.SaveAnnotation(ctx, a))
+
+		a.Note = "revised"
+		require.NoError(t, store.UpdateAnnotation(ctx, a))
+		got, err := store.ListAnnotations(ctx, a.DocumentPath)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, "revised", got[0].Note)
+
+		require.NoError(t, store.DeleteAnnotation(ctx, a.ID))
+		got, err = store.ListAnnotations(ctx, a.DocumentPath)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("reanchor", func(t *testing.T) {
+		store, _ := newStore(t)
+
+		a := annotation("/tmp/a.md", 3, "note")
+		require.NoError(t, store.SaveAnnotation(ctx, a))
+
+		a.StartLine, a.EndLine = 7, 8
+		a.Outdated = true
+		require.NoError(t, store.ReanchorAnnotations(ctx, "hash2", []review.Annotation{a}))
+
+		got, err := store.ListAnnotations(ctx, a.DocumentPath)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, 7, got[0].StartLine)
+		assert.Equal(t, 8, got[0].EndLine)
+		assert.True(t, got[0].Outdated)
+		assert.Equal(t, "hash2", got[0].ContentHash)
+	})
+
+	t.Run("survive review finalization", func(t *testing.T) {
+		store, database := newStore(t)
+		reviews := NewReviewStore(database)
+
+		session, err := reviews.CreateSession(ctx, "/tmp/a.md", "hash1")
+		require.NoError(t, err)
+		require.NoError(t, store.SaveAnnotation(ctx, annotation("/tmp/a.md", 1, "keep me")))
+		require.NoError(t, reviews.FinalizeSession(ctx, session.ID))
+		require.NoError(t, reviews.CleanupStaleSessions(ctx, "/tmp/a.md", "hash2"))
+		require.NoError(t, reviews.DeleteSession(ctx, session.ID))
+
+		got, err := store.ListAnnotations(ctx, "/tmp/a.md")
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, "keep me", got[0].Note)
+	})
+}
@@ -0,0 +1,48 @@
+package stores
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/colonyops/hive/internal/data/db"
+)
+
+// LeaseStore stores the leases that elect one hive process to run a group
+// of background workers.
+type LeaseStore struct {
+	db  *db.DB
+	now func() time.Time
+}
+
+// NewLeaseStore creates a new SQLite-backed lease store.
+func NewLeaseStore(db *db.DB) *LeaseStore {
+	return &LeaseStore{db: db, now: time.Now}
+}
+
+// Acquire takes the lease name for holder, or renews it if holder already
+// has it, until ttl from now. It reports false when the lease is held by
+// another holder and has not expired.
+func (s *LeaseStore) Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	now := s.now()
+	n, err := s.db.Queries().AcquireWorkerLease(ctx, db.AcquireWorkerLeaseParams{
+		Name:       name,
+		Holder:     holder,
+		AcquiredAt: now.UnixNano(),
+		ExpiresAt:  now.Add(ttl).UnixNano(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("acquire lease %q: %w", name, err)
+	}
+	return n > 0, nil
+}
+
+// Release gives up holder's lease on name so another process can take it
+// without waiting for it to expire. Releasing a lease held by someone else
+// is a no-op.
+func (s *LeaseStore) Release(ctx context.Context, name, holder string) error {
+	if err := s.db.Queries().ReleaseWorkerLease(ctx, db.ReleaseWorkerLeaseParams{Name: name, Holder: holder}); err != nil {
+		return fmt.Errorf("release lease %q: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package stores
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLeaseStore(t *testing.T) *LeaseStore {
+	t.Helper()
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+	return NewLeaseStore(database)
+}
+
+func TestLeaseStore_Acquire(t *testing.T) {
+	ctx := context.Background()
+	store := newTestLeaseStore(t)
+	now := time.Unix(1_700_000_000, 0)
+	store.now = func() time.Time { return now }
+
+	ok, err := store.Acquire(ctx, "maintenance", "a", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok, "free lease is acquired")
+
+	ok, err = store.Acquire(ctx, "maintenance", "b", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, ok, "held lease is not taken over")
+
+	ok, err = store.Acquire(ctx, "other", "b", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok, "leases are independent by name")
+
+	now = now.Add(50 * time.Second)
+	ok, err = store.Acquire(ctx, "maintenance", "a", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok, "holder renews its lease")
+
+	now = now.Add(50 * time.Second)
+	ok, err = store.Acquire(ctx, "maintenance", "b", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, ok, "renewed lease has not expired")
+
+	now = now.Add(time.Minute)
+	ok, err = store.Acquire(ctx, "maintenance", "b", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok, "expired lease is taken over")
+
+	ok, err = store.Acquire(ctx, "maintenance", "a", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, ok, "previous holder lost the lease")
+}
+
+func TestLeaseStore_Release(t *testing.T) {
+	ctx := context.Background()
+	store := newTestLeaseStore(t)
+
+	ok, err := store.Acquire(ctx, "maintenance", "a", time.Hour)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.NoError(t, store.Release(ctx, "maintenance", "b"))
+	ok, err = store.Acquire(ctx, "maintenance", "b", time.Hour)
+	require.NoError(t, err)
+	assert.False(t, ok, "release by another holder is a no-op")
+
+	require.NoError(t, store.Release(ctx, "maintenance", "a"))
+	ok, err = store.Acquire(ctx, "maintenance", "b", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, ok, "released lease is free")
+}
@@ -3,10 +3,9 @@ package stores
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"regexp"
 	"sort"
-	"strings"
 	"time"
 
 	"github.com/colonyops/hive/internal/core/messaging"
@@ -16,8 +15,8 @@ import (
 
 // MessageStore implements messaging.Store using SQLite.
 type MessageStore struct {
-	db          *db.DB
-	maxMessages int
+	db        *db.DB
+	retention messaging.RetentionPolicy
 }
 
 var _ messaging.Store = (*MessageStore)(nil)
@@ -26,25 +25,31 @@ var _ messaging.Store = (*MessageStore)(nil)
 // maxMessages controls retention per topic (0 = unlimited).
 func NewMessageStore(db *db.DB, maxMessages int) *MessageStore {
 	return &MessageStore{
-		db:          db,
-		maxMessages: maxMessages,
+		db:        db,
+		retention: messaging.RetentionPolicy{Default: messaging.Retention{MaxPerTopic: maxMessages}},
 	}
 }
 
+// SetRetention replaces the store's retention policy. Per-topic limits apply
+// on publish; message ages are enforced by ApplyRetention.
+func (m *MessageStore) SetRetention(policy messaging.RetentionPolicy) {
+	m.retention = policy
+}
+
 // Publish adds a message to multiple topics.
 // Wildcards are expanded before publishing.
 // Enforces retention limit by deleting oldest messages if needed.
-// All topics are published atomically within a single transaction.
+// All topics are published atomically within a single transaction, and each
+// copy is assigned the next sequence number of its topic inside that
+// transaction.
 func (m *MessageStore) Publish(ctx context.Context, msg messaging.Message, topics []string) (messaging.PublishResult, error) {
-	// Set timestamp if not set (shared across all copies)
-	if msg.CreatedAt.IsZero() {
-		msg.CreatedAt = time.Now()
-	}
-
 	// Expand wildcards and deduplicate
 	expandedTopics := make(map[string]bool)
 	for _, pattern := range topics {
-		if strings.Contains(pattern, "*") {
+		if err := messaging.ValidateTopicPattern(pattern); err != nil {
+			return messaging.PublishResult{}, err
+		}
+		if messaging.IsTopicPattern(pattern) {
 			matched, err := m.expandTopicPattern(ctx, pattern)
 			if err != nil {
 				return messaging.PublishResult{}, fmt.Errorf("expand pattern %s: %w", pattern, err)
@@ -65,43 +70,52 @@ func (m *MessageStore) Publish(ctx context.Context, msg messaging.Message, topic
 	sort.Strings(resolvedTopics)
 
 	// Publish all topics atomically in a single transaction
+	seqs := make(map[string]int64, len(resolvedTopics))
 	err := m.db.WithTx(ctx, func(q *db.Queries) error {
 		for _, topic := range resolvedTopics {
+			// Allocating the sequence is the first write in the transaction,
+			// so it takes SQLite's write lock and serializes publishers.
+			seq, err := q.NextTopicSeq(ctx, topic)
+			if err != nil {
+				return fmt.Errorf("allocate sequence for topic %s: %w", topic, err)
+			}
+			seqs[topic] = seq
+
+			// Stamp the timestamp under the write lock (shared across all
+			// copies) so creation time agrees with sequence order.
+			if msg.CreatedAt.IsZero() {
+				msg.CreatedAt = time.Now()
+			}
+
 			msgCopy := msg
 			msgCopy.Topic = topic
+			msgCopy.Seq = seq
 			// Each copy needs a unique ID
 			msgCopy.ID = randid.Generate(8)
 
 			// Insert message
-			err := q.PublishMessage(ctx, db.PublishMessageParams{
-				ID:        msgCopy.ID,
-				Topic:     msgCopy.Topic,
-				Payload:   msgCopy.Payload,
-				Sender:    toNullString(msgCopy.Sender),
-				SessionID: toNullString(msgCopy.SessionID),
-				CreatedAt: msgCopy.CreatedAt.UnixNano(),
+			err = q.PublishMessage(ctx, db.PublishMessageParams{
+				ID:            msgCopy.ID,
+				Topic:         msgCopy.Topic,
+				Seq:           msgCopy.Seq,
+				Payload:       msgCopy.Payload,
+				Sender:        toNullString(msgCopy.Sender),
+				SessionID:     toNullString(msgCopy.SessionID),
+				CreatedAt:     msgCopy.CreatedAt.UnixNano(),
+				ReplyTo:       toNullString(msgCopy.ReplyTo),
+				Type:          toNullString(msgCopy.Type),
+				CorrelationID: toNullString(msgCopy.CorrelationID),
+				ContentType:   toNullString(msgCopy.ContentType),
 			})
 			if err != nil {
 				return fmt.Errorf("publish to topic %s: %w", topic, err)
 			}
 
-			// Enforce retention limit if configured
-			if m.maxMessages > 0 {
-				count, err := q.CountMessagesInTopic(ctx, msgCopy.Topic)
-				if err != nil {
-					return fmt.Errorf("count messages in topic %s: %w", topic, err)
-				}
-
-				// Delete oldest messages if over limit
-				if count > int64(m.maxMessages) {
-					toDelete := count - int64(m.maxMessages)
-					err = q.DeleteOldestMessagesInTopic(ctx, db.DeleteOldestMessagesInTopicParams{
-						Topic: msgCopy.Topic,
-						Limit: toDelete,
-					})
-					if err != nil {
-						return fmt.Errorf("enforce retention for topic %s: %w", topic, err)
-					}
+			// Enforce retention limit if configured; acked-only topics are
+			// trimmed by ApplyRetention once their messages are acknowledged
+			if r := m.retention.For(topic); !r.AckedOnly {
+				if _, err := trimTopic(ctx, q, topic, r.MaxPerTopic); err != nil {
+					return err
 				}
 			}
 		}
@@ -111,55 +125,59 @@ func (m *MessageStore) Publish(ctx context.Context, msg messaging.Message, topic
 		return messaging.PublishResult{}, err
 	}
 
-	return messaging.PublishResult{Topics: resolvedTopics}, nil
+	return messaging.PublishResult{Topics: resolvedTopics, Seqs: seqs}, nil
 }
 
 // Subscribe returns all messages for a topic pattern, optionally filtered by since timestamp.
 // The topic parameter supports wildcards:
 //   - "*" or "" returns messages from all topics
-//   - "prefix.*" matches topics starting with "prefix."
+//   - "agent.*.inbox" matches one token in place of the "*"
+//   - "build.>" matches every topic below "build."
 //
 // Returns ErrTopicNotFound if no matching topics exist.
 func (m *MessageStore) Subscribe(ctx context.Context, topic string, since time.Time) ([]messaging.Message, error) {
-	// Get all topics
-	allTopics, err := m.db.Queries().ListTopics(ctx)
+	matchedTopics, err := m.matchSubscribedTopics(ctx, topic)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list topics: %w", err)
+		return nil, err
 	}
 
-	// Match topics based on pattern
-	var matchedTopics []string
-	switch {
-	case topic == "" || topic == "*":
-		matchedTopics = allTopics
-	case strings.HasSuffix(topic, ".*"):
-		// Wildcard match
-		prefix := strings.TrimSuffix(topic, ".*") + "."
-		for _, t := range allTopics {
-			if strings.HasPrefix(t, prefix) {
-				matchedTopics = append(matchedTopics, t)
-			}
+	// Collect messages from all matched topics
+	var messages []messaging.Message
+	for _, t := range matchedTopics {
+		rows, err := m.db.Queries().SubscribeToTopic(ctx, db.SubscribeToTopicParams{
+			Topic:     t,
+			CreatedAt: since.UnixNano(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to subscribe to topic %s: %w", t, err)
 		}
-	default:
-		// Exact match
-		for _, t := range allTopics {
-			if t == topic {
-				matchedTopics = append(matchedTopics, topic)
-				break
-			}
+
+		for _, row := range rows {
+			messages = append(messages, rowToMessage(row))
 		}
 	}
 
-	if len(matchedTopics) == 0 {
-		return nil, messaging.ErrTopicNotFound
+	sortMessages(messages)
+
+	return messages, nil
+}
+
+// SubscribeAfter returns messages for a topic pattern whose sequence number is
+// greater than the cursor position for their topic. It accepts the same
+// patterns as Subscribe. Topics missing from the cursor are read from the start.
+//
+// Returns ErrTopicNotFound if no matching topics exist.
+func (m *MessageStore) SubscribeAfter(ctx context.Context, topic string, cursor messaging.Cursor) ([]messaging.Message, error) {
+	matchedTopics, err := m.matchSubscribedTopics(ctx, topic)
+	if err != nil {
+		return nil, err
 	}
 
-	// Collect messages from all matched topics
 	var messages []messaging.Message
 	for _, t := range matchedTopics {
-		rows, err := m.db.Queries().SubscribeToTopic(ctx, db.SubscribeToTopicParams{
-			Topic:     t,
-			CreatedAt: since.UnixNano(),
+		rows, err := m.db.Queries().SubscribeToTopicAfterSeq(ctx, db.SubscribeToTopicAfterSeqParams{
+			Topic: t,
+			Seq:   cursor[t],
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to subscribe to topic %s: %w", t, err)
@@ -170,15 +188,74 @@ func (m *MessageStore) Subscribe(ctx context.Context, topic string, since time.T
 		}
 	}
 
-	// Sort messages by timestamp (chronological order)
-	// Messages are already sorted per-topic, but need sorting across topics
-	sort.Slice(messages, func(i, j int) bool {
-		return messages[i].CreatedAt.Before(messages[j].CreatedAt)
-	})
+	sortMessages(messages)
 
 	return messages, nil
 }
 
+// Get returns the message with the given ID.
+func (m *MessageStore) Get(ctx context.Context, id string) (messaging.Message, error) {
+	row, err := m.db.Queries().GetMessageByID(ctx, id)
+	if IsNotFoundError(err) {
+		return messaging.Message{}, fmt.Errorf("%w: %s", messaging.ErrMessageNotFound, id)
+	}
+	if err != nil {
+		return messaging.Message{}, fmt.Errorf("failed to get message %s: %w", id, err)
+	}
+	return rowToMessage(row), nil
+}
+
+// Head returns a cursor positioned at the latest sequence number of every
+// topic matching the pattern. Topics whose messages were all pruned are still
+// included so readers never see a reused sequence number.
+func (m *MessageStore) Head(ctx context.Context, topic string) (messaging.Cursor, error) {
+	if err := messaging.ValidateTopicPattern(topic); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.Queries().ListTopicSeqs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list topic sequences: %w", err)
+	}
+
+	cursor := make(messaging.Cursor)
+	for _, row := range rows {
+		if messaging.MatchTopic(topic, row.Topic) {
+			cursor[row.Topic] = row.LastSeq
+		}
+	}
+	return cursor, nil
+}
+
+// matchSubscribedTopics resolves a subscribe pattern against the existing
+// topics. Returns ErrTopicNotFound if nothing matches.
+func (m *MessageStore) matchSubscribedTopics(ctx context.Context, pattern string) ([]string, error) {
+	matched, err := m.expandTopicPattern(ctx, pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(matched) == 0 {
+		return nil, messaging.ErrTopicNotFound
+	}
+	return matched, nil
+}
+
+// sortMessages orders messages deterministically: by creation time, then
+// topic, then sequence number. Within a single topic this matches sequence
+// order because timestamps are stamped under the publish write lock.
+func sortMessages(messages []messaging.Message) {
+	sort.SliceStable(messages, func(i, j int) bool {
+		a, b := messages[i], messages[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		if a.Topic != b.Topic {
+			return a.Topic < b.Topic
+		}
+		return a.Seq < b.Seq
+	})
+}
+
 // List returns all topic names.
 func (m *MessageStore) List(ctx context.Context) ([]string, error) {
 	topics, err := m.db.Queries().ListTopics(ctx)
@@ -208,15 +285,121 @@ func (m *MessageStore) Prune(ctx context.Context, olderThan time.Duration) (int,
 	return int(count), nil
 }
 
+// DeleteTopic removes every message of a topic. The topic's sequence
+// high-water mark is kept so sequence numbers are never reused. Returns the
+// number of messages removed.
+func (m *MessageStore) DeleteTopic(ctx context.Context, topic string) (int, error) {
+	removed, err := m.db.Queries().DeleteMessagesInTopic(ctx, topic)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete topic %s: %w", topic, err)
+	}
+	return int(removed), nil
+}
+
+// ApplyRetention deletes the messages the retention policy no longer keeps
+// from topics matching the pattern. A positive maxAge also removes messages
+// older than it, whatever the policy allows. Topics whose retention is
+// AckedOnly only lose messages a consumer has acknowledged. Returns the number
+// of messages removed.
+func (m *MessageStore) ApplyRetention(ctx context.Context, topic string, maxAge time.Duration) (int, error) {
+	topics, err := m.expandTopicPattern(ctx, topic)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var removed int64
+	err = m.db.WithTx(ctx, func(q *db.Queries) error {
+		for _, t := range topics {
+			r := m.retention.For(t)
+			age := r.MaxAge
+			if maxAge > 0 && (age == 0 || maxAge < age) {
+				age = maxAge
+			}
+
+			params := db.PruneMessagesInTopicParams{Topic: t}
+			if age > 0 {
+				params.CreatedAt = now.Add(-age).UnixNano()
+			}
+			if r.MaxPerTopic > 0 {
+				// Messages up to the seq of the newest one past the limit
+				count, err := q.CountMessagesInTopic(ctx, t)
+				if err != nil {
+					return fmt.Errorf("count messages in topic %s: %w", t, err)
+				}
+				if excess := count - int64(r.MaxPerTopic); excess > 0 {
+					params.Seq, err = q.GetMessageSeqAtOffset(ctx, db.GetMessageSeqAtOffsetParams{Topic: t, Offset: excess - 1})
+					if err != nil {
+						return fmt.Errorf("find retention limit of topic %s: %w", t, err)
+					}
+				}
+			}
+			if params.CreatedAt == 0 && params.Seq == 0 {
+				continue
+			}
+
+			var (
+				count int64
+				err   error
+			)
+			if r.AckedOnly {
+				count, err = q.PruneAckedMessagesInTopic(ctx, db.PruneAckedMessagesInTopicParams(params))
+			} else {
+				count, err = q.PruneMessagesInTopic(ctx, params)
+			}
+			if err != nil {
+				return fmt.Errorf("prune topic %s: %w", t, err)
+			}
+			removed += count
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(removed), nil
+}
+
+// trimTopic deletes the oldest messages of topic beyond limit (0 = unlimited)
+// and returns how many it deleted.
+func trimTopic(ctx context.Context, q *db.Queries, topic string, limit int) (int64, error) {
+	if limit <= 0 {
+		return 0, nil
+	}
+
+	count, err := q.CountMessagesInTopic(ctx, topic)
+	if err != nil {
+		return 0, fmt.Errorf("count messages in topic %s: %w", topic, err)
+	}
+	if count <= int64(limit) {
+		return 0, nil
+	}
+
+	toDelete := count - int64(limit)
+	err = q.DeleteOldestMessagesInTopic(ctx, db.DeleteOldestMessagesInTopicParams{
+		Topic: topic,
+		Limit: toDelete,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("enforce retention for topic %s: %w", topic, err)
+	}
+	return toDelete, nil
+}
+
 // rowToMessage converts a db.Message to a messaging.Message.
 func rowToMessage(row db.Message) messaging.Message {
 	return messaging.Message{
-		ID:        row.ID,
-		Topic:     row.Topic,
-		Payload:   row.Payload,
-		Sender:    fromNullString(row.Sender),
-		SessionID: fromNullString(row.SessionID),
-		CreatedAt: time.Unix(0, row.CreatedAt),
+		ID:            row.ID,
+		Topic:         row.Topic,
+		Payload:       row.Payload,
+		Sender:        fromNullString(row.Sender),
+		SessionID:     fromNullString(row.SessionID),
+		ReplyTo:       fromNullString(row.ReplyTo),
+		Type:          fromNullString(row.Type),
+		CorrelationID: fromNullString(row.CorrelationID),
+		ContentType:   fromNullString(row.ContentType),
+		Seq:           row.Seq,
+		CreatedAt:     time.Unix(0, row.CreatedAt),
 	}
 }
 
@@ -236,26 +419,20 @@ func fromNullString(ns sql.NullString) string {
 	return ""
 }
 
-// expandTopicPattern expands a wildcard pattern to matching topics.
+// expandTopicPattern returns the existing topics matching pattern.
 func (m *MessageStore) expandTopicPattern(ctx context.Context, pattern string) ([]string, error) {
-	allTopics, err := m.List(ctx)
-	if err != nil {
+	if err := messaging.ValidateTopicPattern(pattern); err != nil {
 		return nil, err
 	}
 
-	// Convert glob to regex: "agent.*.inbox" → "^agent\\..*\\.inbox$"
-	regexPattern := regexp.QuoteMeta(pattern)
-	regexPattern = strings.ReplaceAll(regexPattern, "\\*", ".*")
-	regexPattern = "^" + regexPattern + "$"
-
-	re, err := regexp.Compile(regexPattern)
+	allTopics, err := m.List(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("invalid pattern: %w", err)
+		return nil, err
 	}
 
 	var matched []string
 	for _, topic := range allTopics {
-		if re.MatchString(topic) {
+		if messaging.MatchTopic(pattern, topic) {
 			matched = append(matched, topic)
 		}
 	}
@@ -294,7 +471,7 @@ func (m *MessageStore) GetUnread(ctx context.Context, consumerID string, topic s
 
 	var allRows []db.Message
 
-	if strings.Contains(topic, "*") {
+	if messaging.IsTopicPattern(topic) {
 		// Expand pattern and query each topic
 		topics, err := m.expandTopicPattern(ctx, topic)
 		if err != nil {
@@ -323,15 +500,78 @@ func (m *MessageStore) GetUnread(ctx context.Context, consumerID string, topic s
 		allRows = rows
 	}
 
-	// Convert and sort by timestamp
+	// Convert and sort deterministically
 	messages := make([]messaging.Message, len(allRows))
 	for i, row := range allRows {
 		messages[i] = rowToMessage(row)
 	}
 
-	sort.Slice(messages, func(i, j int) bool {
-		return messages[i].CreatedAt.Before(messages[j].CreatedAt)
-	})
+	sortMessages(messages)
 
 	return messages, nil
 }
+
+// ConsumerCursor returns the read position of a named consumer in every
+// topic matching the pattern that it has acknowledged messages in.
+func (m *MessageStore) ConsumerCursor(ctx context.Context, consumer string, topic string) (messaging.Cursor, error) {
+	if consumer == "" {
+		return nil, fmt.Errorf("consumer required")
+	}
+	if err := messaging.ValidateTopicPattern(topic); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.Queries().ListConsumerCursors(ctx, consumer)
+	if err != nil {
+		return nil, fmt.Errorf("list cursors for consumer %s: %w", consumer, err)
+	}
+
+	cursor := make(messaging.Cursor)
+	for _, row := range rows {
+		if messaging.MatchTopic(topic, row.Topic) {
+			cursor[row.Topic] = row.Seq
+		}
+	}
+	return cursor, nil
+}
+
+// AckThrough moves a named consumer's cursor in each message's topic to that
+// message. Only the latest message of each topic moves the cursor, and a
+// cursor already past it is left alone.
+func (m *MessageStore) AckThrough(ctx context.Context, consumer string, messageIDs []string) error {
+	if consumer == "" {
+		return fmt.Errorf("consumer required")
+	}
+
+	now := time.Now().UnixNano()
+
+	return m.db.WithTx(ctx, func(q *db.Queries) error {
+		latest := make(map[string]db.Message)
+		for _, id := range messageIDs {
+			msg, err := q.GetMessageByID(ctx, id)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return fmt.Errorf("%w: %s", messaging.ErrMessageNotFound, id)
+				}
+				return fmt.Errorf("get message %s: %w", id, err)
+			}
+			if prev, ok := latest[msg.Topic]; !ok || msg.Seq > prev.Seq {
+				latest[msg.Topic] = msg
+			}
+		}
+
+		for topic, msg := range latest {
+			err := q.AdvanceConsumerCursor(ctx, db.AdvanceConsumerCursorParams{
+				Consumer:  consumer,
+				Topic:     topic,
+				Seq:       msg.Seq,
+				MessageID: toNullString(msg.ID),
+				UpdatedAt: now,
+			})
+			if err != nil {
+				return fmt.Errorf("advance cursor for topic %s: %w", topic, err)
+			}
+		}
+		return nil
+	})
+}
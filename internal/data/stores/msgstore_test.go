@@ -102,6 +102,41 @@ func TestMsgStore_SubscribeWildcard(t *testing.T) {
 	assert.True(t, payloads["tests running"], "Missing expected payloads in %v", messages)
 }
 
+func TestMsgStore_SubscribeWildcards(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err, "Open")
+	defer func() { _ = database.Close() }()
+
+	store := NewMessageStore(database, 0)
+	ctx := context.Background()
+
+	for _, topic := range []string{"agent.a.inbox", "agent.b.inbox", "agent.b.outbox", "agent.c.main.inbox", "build.started", "build.api.done", "build"} {
+		_, err := store.Publish(ctx, messaging.Message{Payload: topic}, []string{topic})
+		require.NoError(t, err)
+	}
+
+	payloads := func(pattern string) []string {
+		messages, err := store.Subscribe(ctx, pattern, time.Time{})
+		require.NoError(t, err, pattern)
+		out := make([]string, 0, len(messages))
+		for _, m := range messages {
+			out = append(out, m.Payload)
+		}
+		return out
+	}
+
+	assert.ElementsMatch(t, []string{"agent.a.inbox", "agent.b.inbox", "agent.c.main.inbox"}, payloads("agent.*.inbox"))
+	assert.ElementsMatch(t, []string{"agent.a.inbox", "agent.b.inbox", "agent.b.outbox", "agent.c.main.inbox"}, payloads("agent.*"))
+	assert.ElementsMatch(t, []string{"build.started", "build.api.done"}, payloads("build.>"))
+
+	_, err = store.Subscribe(ctx, "build.>.done", time.Time{})
+	require.Error(t, err)
+
+	_, err = store.Publish(ctx, messaging.Message{Payload: "literal"}, []string{"agent*"})
+	require.NoError(t, err, "topic names without wildcard tokens stay valid")
+	assert.Equal(t, []string{"literal"}, payloads("agent*"))
+}
+
 func TestMsgStore_SubscribeAll(t *testing.T) {
 	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
 	require.NoError(t, err, "Open")
@@ -272,6 +307,141 @@ func TestMsgStore_Prune(t *testing.T) {
 	assert.Equal(t, "new", messages[0].Payload)
 }
 
+func TestMsgStore_DeleteTopic(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err, "Open")
+	defer func() { _ = database.Close() }()
+
+	store := NewMessageStore(database, 0)
+	ctx := context.Background()
+
+	_, _ = store.Publish(ctx, messaging.Message{Payload: "a"}, []string{"agent.x.inbox"})
+	_, _ = store.Publish(ctx, messaging.Message{Payload: "b"}, []string{"agent.x.inbox"})
+	_, _ = store.Publish(ctx, messaging.Message{Payload: "c"}, []string{"agent.y.inbox"})
+
+	removed, err := store.DeleteTopic(ctx, "agent.x.inbox")
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+
+	_, err = store.Subscribe(ctx, "agent.x.inbox", time.Time{})
+	require.ErrorIs(t, err, messaging.ErrTopicNotFound)
+	messages, err := store.Subscribe(ctx, "agent.y.inbox", time.Time{})
+	require.NoError(t, err)
+	assert.Len(t, messages, 1)
+
+	result, err := store.Publish(ctx, messaging.Message{Payload: "d"}, []string{"agent.x.inbox"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result.Seqs["agent.x.inbox"], "sequence must not restart after delete")
+}
+
+func TestMsgStore_ApplyRetention(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err, "Open")
+	defer func() { _ = database.Close() }()
+
+	store := NewMessageStore(database, 0)
+	store.SetRetention(messaging.RetentionPolicy{
+		Default: messaging.Retention{MaxAge: 24 * time.Hour},
+		Topics: []messaging.TopicRetention{
+			{Pattern: "build.>", Retention: messaging.Retention{MaxAge: time.Hour, MaxPerTopic: 2}},
+		},
+	})
+	ctx := context.Background()
+
+	now := time.Now()
+	publish := func(topic, payload string, age time.Duration) {
+		_, err := store.Publish(ctx, messaging.Message{Topic: topic, Payload: payload, CreatedAt: now.Add(-age)}, []string{topic})
+		require.NoError(t, err)
+	}
+	publish("events", "unread", 72*time.Hour)
+	publish("events", "two-days", 48*time.Hour)
+	publish("events", "two-hours", 2*time.Hour)
+	publish("build.api", "two-hours", 2*time.Hour)
+	publish("build.api", "a", time.Minute)
+	publish("build.api", "b", time.Minute)
+	publish("build.api", "c", 0)
+
+	// Per-topic limits are enforced on publish.
+	messages, err := store.Subscribe(ctx, "build.api", time.Time{})
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "b", messages[0].Payload)
+
+	// Retention deletes expired messages whether or not they were read.
+	removed, err := store.ApplyRetention(ctx, "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed, "the unread and two-day-old events exceed their retention")
+
+	messages, err = store.Subscribe(ctx, "events", time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"two-hours"}, payloads(messages))
+
+	t.Run("max age override", func(t *testing.T) {
+		removed, err := store.ApplyRetention(ctx, "build.*", 30*time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, 1, removed)
+
+		messages, err := store.Subscribe(ctx, "build.api", time.Time{})
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, "c", messages[0].Payload)
+
+		// Topics outside the pattern are untouched.
+		messages, err = store.Subscribe(ctx, "events", time.Time{})
+		require.NoError(t, err)
+		assert.Len(t, messages, 1)
+	})
+
+	t.Run("acked only", func(t *testing.T) {
+		store.SetRetention(messaging.RetentionPolicy{
+			Topics: []messaging.TopicRetention{
+				{Pattern: "inbox", Retention: messaging.Retention{MaxAge: time.Hour, AckedOnly: true}},
+			},
+		})
+		publish("inbox", "unread", 2*time.Hour)
+		publish("inbox", "read", 2*time.Hour)
+		messages, err := store.Subscribe(ctx, "inbox", time.Time{})
+		require.NoError(t, err)
+		require.NoError(t, store.Acknowledge(ctx, "reader", []string{messages[1].ID}))
+
+		removed, err := store.ApplyRetention(ctx, "inbox", 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, removed)
+
+		messages, err = store.Subscribe(ctx, "inbox", time.Time{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"unread"}, payloads(messages), "unread messages are kept")
+	})
+
+	t.Run("consumer cursors acknowledge", func(t *testing.T) {
+		store.SetRetention(messaging.RetentionPolicy{Default: messaging.Retention{MaxPerTopic: 1, AckedOnly: true}})
+		publish("queue", "first", 0)
+		publish("queue", "second", 0)
+		publish("queue", "third", 0)
+
+		removed, err := store.ApplyRetention(ctx, "queue", 0)
+		require.NoError(t, err)
+		assert.Zero(t, removed, "nothing is acknowledged yet")
+
+		messages, err := store.Subscribe(ctx, "queue", time.Time{})
+		require.NoError(t, err)
+		require.NoError(t, store.AckThrough(ctx, "worker", []string{messages[0].ID}))
+
+		removed, err = store.ApplyRetention(ctx, "queue", 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, removed, "messages up to the cursor are acknowledged")
+
+		messages, err = store.Subscribe(ctx, "queue", time.Time{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"second", "third"}, payloads(messages))
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := store.ApplyRetention(ctx, "build.>.x", 0)
+		assert.Error(t, err)
+	})
+}
+
 func TestMsgStore_MessageOrdering(t *testing.T) {
 	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
 	require.NoError(t, err, "Open")
@@ -357,6 +527,53 @@ func TestMsgStore_Acknowledge(t *testing.T) {
 	assert.Len(t, unread, 2, "Expected 2 unread messages for consumer-2, got %d", len(unread))
 }
 
+func TestMsgStore_ConsumerCursors(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err, "Open")
+	defer func() { _ = database.Close() }()
+
+	store := NewMessageStore(database, 0)
+	ctx := context.Background()
+
+	for _, payload := range []string{"one", "two", "three"} {
+		_, err := store.Publish(ctx, messaging.Message{Payload: payload}, []string{"announce", "other"})
+		require.NoError(t, err)
+	}
+	announce, err := store.Subscribe(ctx, "announce", time.Time{})
+	require.NoError(t, err)
+	require.Len(t, announce, 3)
+
+	cursor, err := store.ConsumerCursor(ctx, "alice", "announce")
+	require.NoError(t, err)
+	assert.Empty(t, cursor, "a new consumer has acknowledged nothing")
+
+	// Acknowledging a message acknowledges every earlier one in its topic.
+	require.NoError(t, store.AckThrough(ctx, "alice", []string{announce[1].ID}))
+	cursor, err = store.ConsumerCursor(ctx, "alice", "*")
+	require.NoError(t, err)
+	assert.Equal(t, messaging.Cursor{"announce": 2}, cursor)
+
+	unacked, err := store.SubscribeAfter(ctx, "announce", cursor)
+	require.NoError(t, err)
+	require.Len(t, unacked, 1)
+	assert.Equal(t, "three", unacked[0].Payload)
+
+	// Cursors never move backwards.
+	require.NoError(t, store.AckThrough(ctx, "alice", []string{announce[0].ID}))
+	cursor, err = store.ConsumerCursor(ctx, "alice", "announce")
+	require.NoError(t, err)
+	assert.Equal(t, messaging.Cursor{"announce": 2}, cursor)
+
+	// Each consumer tracks its own progress.
+	cursor, err = store.ConsumerCursor(ctx, "bob", "announce")
+	require.NoError(t, err)
+	assert.Empty(t, cursor)
+
+	err = store.AckThrough(ctx, "alice", []string{"missing"})
+	require.ErrorIs(t, err, messaging.ErrMessageNotFound)
+	assert.Error(t, store.AckThrough(ctx, "", []string{announce[0].ID}))
+}
+
 func TestMsgStore_Acknowledge_EmptyConsumer(t *testing.T) {
 	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
 	require.NoError(t, err, "Open")
@@ -510,3 +727,195 @@ func TestMsgStore_AcknowledgeIdempotent(t *testing.T) {
 	unread, _ := store.GetUnread(ctx, "consumer-1", "test.topic")
 	assert.Empty(t, unread, "Expected 0 unread after double-acknowledge, got %d", len(unread))
 }
+
+func TestMsgStore_SequencesArePerTopicAndMonotonic(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err, "Open")
+	defer func() { _ = database.Close() }()
+
+	store := NewMessageStore(database, 0)
+	ctx := context.Background()
+
+	for i := range 3 {
+		result, err := store.Publish(ctx, messaging.Message{Payload: fmt.Sprintf("a%d", i)}, []string{"topic.a"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(i+1), result.Seqs["topic.a"])
+	}
+
+	result, err := store.Publish(ctx, messaging.Message{Payload: "both"}, []string{"topic.a", "topic.b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"topic.a": 4, "topic.b": 1}, result.Seqs)
+
+	messages, err := store.Subscribe(ctx, "topic.a", time.Time{})
+	require.NoError(t, err)
+	require.Len(t, messages, 4)
+	for i, msg := range messages {
+		assert.Equal(t, int64(i+1), msg.Seq)
+	}
+}
+
+func TestMsgStore_SequencesNotReusedAfterRetention(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err, "Open")
+	defer func() { _ = database.Close() }()
+
+	store := NewMessageStore(database, 2)
+	ctx := context.Background()
+
+	for i := range 5 {
+		_, err := store.Publish(ctx, messaging.Message{Payload: fmt.Sprintf("msg%d", i)}, []string{"events"})
+		require.NoError(t, err)
+	}
+
+	messages, err := store.Subscribe(ctx, "events", time.Time{})
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, int64(4), messages[0].Seq)
+	assert.Equal(t, int64(5), messages[1].Seq)
+
+	_, err = store.Prune(ctx, 0)
+	require.NoError(t, err)
+
+	result, err := store.Publish(ctx, messaging.Message{Payload: "after prune"}, []string{"events"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), result.Seqs["events"], "sequence must not restart after prune")
+}
+
+func TestMsgStore_SubscribeAfter(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err, "Open")
+	defer func() { _ = database.Close() }()
+
+	store := NewMessageStore(database, 0)
+	ctx := context.Background()
+
+	for i := range 4 {
+		_, err := store.Publish(ctx, messaging.Message{Payload: fmt.Sprintf("msg%d", i)}, []string{"handoff"})
+		require.NoError(t, err)
+	}
+
+	messages, err := store.SubscribeAfter(ctx, "handoff", messaging.Cursor{"handoff": 2})
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "msg2", messages[0].Payload)
+	assert.Equal(t, "msg3", messages[1].Payload)
+
+	_, err = store.SubscribeAfter(ctx, "missing", messaging.Cursor{})
+	assert.ErrorIs(t, err, messaging.ErrTopicNotFound)
+}
+
+func TestMsgStore_HeadAndCursorReadNewMessagesOnly(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err, "Open")
+	defer func() { _ = database.Close() }()
+
+	store := NewMessageStore(database, 0)
+	ctx := context.Background()
+
+	_, err = store.Publish(ctx, messaging.Message{Payload: "old"}, []string{"agent.a"})
+	require.NoError(t, err)
+	_, err = store.Publish(ctx, messaging.Message{Payload: "unrelated"}, []string{"other"})
+	require.NoError(t, err)
+
+	cursor, err := store.Head(ctx, "agent.*")
+	require.NoError(t, err)
+	assert.Equal(t, messaging.Cursor{"agent.a": 1}, cursor)
+
+	// Publish with an old timestamp: a time-based cursor would miss this,
+	// the sequence cursor must not.
+	_, err = store.Publish(ctx, messaging.Message{Payload: "late", CreatedAt: time.Unix(0, 1)}, []string{"agent.a"})
+	require.NoError(t, err)
+	_, err = store.Publish(ctx, messaging.Message{Payload: "new topic"}, []string{"agent.b"})
+	require.NoError(t, err)
+
+	messages, err := store.SubscribeAfter(ctx, "agent.*", cursor)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "late", messages[0].Payload)
+	assert.Equal(t, "new topic", messages[1].Payload)
+
+	cursor.Advance(messages)
+	assert.Equal(t, messaging.Cursor{"agent.a": 2, "agent.b": 1}, cursor)
+
+	messages, err = store.SubscribeAfter(ctx, "agent.*", cursor)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+func TestMsgStore_ReadYourWrites(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err, "Open")
+	defer func() { _ = database.Close() }()
+
+	store := NewMessageStore(database, 0)
+	ctx := context.Background()
+
+	for i := range 50 {
+		result, err := store.Publish(ctx, messaging.Message{Payload: fmt.Sprintf("msg%d", i)}, []string{"rapid"})
+		require.NoError(t, err)
+
+		messages, err := store.SubscribeAfter(ctx, "rapid", messaging.Cursor{"rapid": result.Seqs["rapid"] - 1})
+		require.NoError(t, err)
+		require.Len(t, messages, 1, "latest message must be visible immediately after publish")
+		assert.Equal(t, fmt.Sprintf("msg%d", i), messages[0].Payload)
+	}
+}
+
+func TestMsgStore_GetWithReplyTo(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err, "Open")
+	defer func() { _ = database.Close() }()
+
+	store := NewMessageStore(database, 0)
+	ctx := context.Background()
+
+	_, err = store.Publish(ctx, messaging.Message{Payload: "ping", ReplyTo: "reply.abc"}, []string{"requests"})
+	require.NoError(t, err, "Publish failed")
+
+	messages, err := store.Subscribe(ctx, "requests", time.Time{})
+	require.NoError(t, err, "Subscribe failed")
+	require.Len(t, messages, 1)
+	assert.Equal(t, "reply.abc", messages[0].ReplyTo)
+
+	got, err := store.Get(ctx, messages[0].ID)
+	require.NoError(t, err, "Get failed")
+	assert.Equal(t, messages[0], got)
+
+	_, err = store.Get(ctx, "missing")
+	assert.ErrorIs(t, err, messaging.ErrMessageNotFound)
+}
+
+func TestMsgStore_EnvelopeFields(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err, "Open")
+	defer func() { _ = database.Close() }()
+
+	store := NewMessageStore(database, 0)
+	ctx := context.Background()
+
+	msg := messaging.Message{
+		Payload:       `{"branch":"main"}`,
+		SessionID:     "sess-1",
+		Type:          "handoff",
+		CorrelationID: "task-42",
+		ContentType:   messaging.ContentTypeJSON,
+	}
+	_, err = store.Publish(ctx, msg, []string{"handoffs"})
+	require.NoError(t, err, "Publish failed")
+
+	messages, err := store.Subscribe(ctx, "handoffs", time.Time{})
+	require.NoError(t, err, "Subscribe failed")
+	require.Len(t, messages, 1)
+	assert.Equal(t, "sess-1", messages[0].SessionID)
+	assert.Equal(t, "handoff", messages[0].Type)
+	assert.Equal(t, "task-42", messages[0].CorrelationID)
+	assert.Equal(t, messaging.ContentTypeJSON, messages[0].ContentType)
+}
+
+func payloads(messages []messaging.Message) []string {
+	out := make([]string, len(messages))
+	for i, msg := range messages {
+		out[i] = msg.Payload
+	}
+	return out
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/colonyops/hive/internal/core/review"
@@ -28,12 +29,22 @@ func (s *ReviewStore) CreateSession(ctx context.Context, documentPath string, co
 	sessionID := uuid.NewString()
 	now := time.Now()
 
-	err := s.db.Queries().CreateReviewSession(ctx, db.CreateReviewSessionParams{
-		ID:           sessionID,
-		DocumentPath: documentPath,
-		ContentHash:  contentHash,
-		CreatedAt:    now.UnixNano(),
-		FinalizedAt:  sql.NullInt64{Valid: false},
+	err := s.db.WithTx(ctx, func(q *db.Queries) error {
+		if err := q.CreateReviewSession(ctx, db.CreateReviewSessionParams{
+			ID:           sessionID,
+			DocumentPath: documentPath,
+			ContentHash:  contentHash,
+			CreatedAt:    now.UnixNano(),
+			FinalizedAt:  sql.NullInt64{Valid: false},
+		}); err != nil {
+			return err
+		}
+		return q.AddReviewSessionDocument(ctx, db.AddReviewSessionDocumentParams{
+			SessionID:    sessionID,
+			DocumentPath: documentPath,
+			ContentHash:  contentHash,
+			AddedAt:      now.UnixNano(),
+		})
 	})
 	if err != nil {
 		return review.Session{}, fmt.Errorf("failed to create review session: %w", err)
@@ -77,7 +88,83 @@ func (s *ReviewStore) GetSessionByHash(ctx context.Context, documentPath string,
 	return rowToReviewSession(row), nil
 }
 
-// CleanupStaleSessions removes review sessions for a document with different content hash.
+// GetActiveSessionForDocument returns the active session the document is attached to.
+func (s *ReviewStore) GetActiveSessionForDocument(ctx context.Context, documentPath string) (review.Session, error) {
+	row, err := s.db.Queries().GetActiveReviewSessionByDocument(ctx, documentPath)
+	if IsNotFoundError(err) {
+		return review.Session{}, review.ErrSessionNotFound
+	}
+	if err != nil {
+		return review.Session{}, fmt.Errorf("failed to get review session for document: %w", err)
+	}
+
+	return rowToReviewSession(row), nil
+}
+
+// AddDocument attaches a related document to an existing session.
+// Attaching a document that is already part of the same session is a no-op.
+func (s *ReviewStore) AddDocument(ctx context.Context, sessionID string, documentPath string, contentHash string) error {
+	return s.db.WithTx(ctx, func(q *db.Queries) error {
+		existing, err := q.GetActiveReviewSessionByDocument(ctx, documentPath)
+		switch {
+		case err == nil && existing.ID == sessionID:
+			return nil
+		case err == nil:
+			return review.ErrDocumentInReview
+		case !IsNotFoundError(err):
+			return fmt.Errorf("failed to check document review session: %w", err)
+		}
+
+		err = q.AddReviewSessionDocument(ctx, db.AddReviewSessionDocumentParams{
+			SessionID:    sessionID,
+			DocumentPath: documentPath,
+			ContentHash:  contentHash,
+			AddedAt:      time.Now().UnixNano(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add document to review session: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListDocuments returns the documents attached to a session in the order they were added.
+func (s *ReviewStore) ListDocuments(ctx context.Context, sessionID string) ([]review.Document, error) {
+	rows, err := s.db.Queries().ListReviewSessionDocuments(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list review session documents: %w", err)
+	}
+
+	docs := make([]review.Document, 0, len(rows))
+	for _, row := range rows {
+		docs = append(docs, review.Document{
+			SessionID:    row.SessionID,
+			DocumentPath: row.DocumentPath,
+			ContentHash:  row.ContentHash,
+			AddedAt:      time.Unix(0, row.AddedAt),
+		})
+	}
+
+	return docs, nil
+}
+
+// LastReviewedHashes returns, for each document that has been part of a
+// finalized review, the content hash it had in the most recent one.
+func (s *ReviewStore) LastReviewedHashes(ctx context.Context) (map[string]string, error) {
+	rows, err := s.db.Queries().ListLastReviewedDocuments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list last reviewed documents: %w", err)
+	}
+
+	hashes := make(map[string]string, len(rows))
+	for _, row := range rows {
+		hashes[row.DocumentPath] = row.ContentHash
+	}
+	return hashes, nil
+}
+
+// CleanupStaleSessions removes unfinalized review sessions for a document with
+// a different content hash. Finalized sessions are kept as review history.
 func (s *ReviewStore) CleanupStaleSessions(ctx context.Context, documentPath string, currentHash string) error {
 	err := s.db.Queries().DeleteReviewSessionsByDocPath(ctx, db.DeleteReviewSessionsByDocPathParams{
 		DocumentPath: documentPath,
@@ -89,6 +176,49 @@ func (s *ReviewStore) CleanupStaleSessions(ctx context.Context, documentPath str
 	return nil
 }
 
+// ReanchorDocument records a changed document's new content hash on a session
+// and moves the given comments to their re-anchored positions. The session's
+// own hash is only updated when documentPath is the session's document.
+func (s *ReviewStore) ReanchorDocument(ctx context.Context, sessionID string, documentPath string, contentHash string, comments []review.Comment) error {
+	err := s.db.WithTx(ctx, func(q *db.Queries) error {
+		if err := q.UpdateReviewSessionHash(ctx, db.UpdateReviewSessionHashParams{
+			ContentHash:  contentHash,
+			ID:           sessionID,
+			DocumentPath: documentPath,
+		}); err != nil {
+			return err
+		}
+		if err := q.UpdateReviewSessionDocumentHash(ctx, db.UpdateReviewSessionDocumentHashParams{
+			ContentHash:  contentHash,
+			SessionID:    sessionID,
+			DocumentPath: documentPath,
+		}); err != nil {
+			return err
+		}
+		for _, c := range comments {
+			var outdated int64
+			if c.Outdated {
+				outdated = 1
+			}
+			if err := q.UpdateReviewCommentAnchor(ctx, db.UpdateReviewCommentAnchorParams{
+				StartLine: int64(c.StartLine),
+				EndLine:   int64(c.EndLine),
+				StartCol:  int64(c.StartCol),
+				EndCol:    int64(c.EndCol),
+				Outdated:  outdated,
+				ID:        c.ID,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to re-anchor review document: %w", err)
+	}
+	return nil
+}
+
 // FinalizeSession marks a review session as finalized.
 func (s *ReviewStore) FinalizeSession(ctx context.Context, sessionID string) error {
 	now := time.Now()
@@ -102,6 +232,32 @@ func (s *ReviewStore) FinalizeSession(ctx context.Context, sessionID string) err
 	return nil
 }
 
+// MarkDispatched records that a finalized session's feedback was sent to an agent.
+func (s *ReviewStore) MarkDispatched(ctx context.Context, sessionID string, target string, replyTo string) error {
+	err := s.db.Queries().MarkReviewSessionDispatched(ctx, db.MarkReviewSessionDispatchedParams{
+		DispatchedAt:    sql.NullInt64{Int64: time.Now().UnixNano(), Valid: true},
+		DispatchedTo:    target,
+		DispatchReplyTo: replyTo,
+		ID:              sessionID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark review session dispatched: %w", err)
+	}
+	return nil
+}
+
+// AddReviewTime adds time spent reviewing to a review session.
+func (s *ReviewStore) AddReviewTime(ctx context.Context, sessionID string, d time.Duration) error {
+	err := s.db.Queries().AddReviewSessionTime(ctx, db.AddReviewSessionTimeParams{
+		ReviewTime: int64(d),
+		ID:         sessionID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add review time: %w", err)
+	}
+	return nil
+}
+
 // DeleteSession removes a review session and all associated comments.
 func (s *ReviewStore) DeleteSession(ctx context.Context, sessionID string) error {
 	err := s.db.Queries().DeleteReviewSession(ctx, sessionID)
@@ -114,13 +270,17 @@ func (s *ReviewStore) DeleteSession(ctx context.Context, sessionID string) error
 // SaveComment adds a comment to a review session.
 func (s *ReviewStore) SaveComment(ctx context.Context, comment review.Comment) error {
 	err := s.db.Queries().SaveReviewComment(ctx, db.SaveReviewCommentParams{
-		ID:          comment.ID,
-		SessionID:   comment.SessionID,
-		StartLine:   int64(comment.StartLine),
-		EndLine:     int64(comment.EndLine),
-		ContextText: comment.ContextText,
-		CommentText: comment.CommentText,
-		CreatedAt:   comment.CreatedAt.UnixNano(),
+		ID:           comment.ID,
+		SessionID:    comment.SessionID,
+		StartLine:    int64(comment.StartLine),
+		EndLine:      int64(comment.EndLine),
+		StartCol:     int64(comment.StartCol),
+		EndCol:       int64(comment.EndCol),
+		ContextText:  comment.ContextText,
+		CommentText:  comment.CommentText,
+		CreatedAt:    comment.CreatedAt.UnixNano(),
+		DocumentPath: comment.DocumentPath,
+		Severity:     string(comment.Severity.OrDefault()),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to save review comment: %w", err)
@@ -143,11 +303,24 @@ func (s *ReviewStore) ListComments(ctx context.Context, sessionID string) ([]rev
 	return comments, nil
 }
 
-// UpdateComment updates the comment text for an existing comment.
+// UpdateComment updates the text and severity of an existing comment. When
+// either changes, the previous version is kept as a revision.
 func (s *ReviewStore) UpdateComment(ctx context.Context, comment review.Comment) error {
-	err := s.db.Queries().UpdateReviewComment(ctx, db.UpdateReviewCommentParams{
-		CommentText: comment.CommentText,
-		ID:          comment.ID,
+	severity := string(comment.Severity.OrDefault())
+	err := s.db.WithTx(ctx, func(q *db.Queries) error {
+		if err := q.AddReviewCommentRevision(ctx, db.AddReviewCommentRevisionParams{
+			ReplacedAt:  time.Now().UnixNano(),
+			ID:          comment.ID,
+			CommentText: comment.CommentText,
+			Severity:    severity,
+		}); err != nil {
+			return err
+		}
+		return q.UpdateReviewComment(ctx, db.UpdateReviewCommentParams{
+			CommentText: comment.CommentText,
+			Severity:    severity,
+			ID:          comment.ID,
+		})
 	})
 	if err != nil {
 		return fmt.Errorf("failed to update review comment: %w", err)
@@ -155,6 +328,25 @@ func (s *ReviewStore) UpdateComment(ctx context.Context, comment review.Comment)
 	return nil
 }
 
+// ListCommentRevisions returns the earlier versions of a comment, oldest first.
+func (s *ReviewStore) ListCommentRevisions(ctx context.Context, commentID string) ([]review.CommentRevision, error) {
+	rows, err := s.db.Queries().ListReviewCommentRevisions(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list review comment revisions: %w", err)
+	}
+
+	revisions := make([]review.CommentRevision, 0, len(rows))
+	for _, row := range rows {
+		revisions = append(revisions, review.CommentRevision{
+			CommentID:   row.CommentID,
+			CommentText: row.CommentText,
+			Severity:    review.Severity(row.Severity),
+			ReplacedAt:  time.Unix(0, row.ReplacedAt),
+		})
+	}
+	return revisions, nil
+}
+
 // DeleteComment removes a specific comment.
 func (s *ReviewStore) DeleteComment(ctx context.Context, commentID string) error {
 	err := s.db.Queries().DeleteReviewComment(ctx, commentID)
@@ -164,6 +356,66 @@ func (s *ReviewStore) DeleteComment(ctx context.Context, commentID string) error
 	return nil
 }
 
+// SearchComments returns up to limit comments from finalized reviews whose
+// text or quoted context contains every word of query (the last one as a
+// prefix), newest review first.
+func (s *ReviewStore) SearchComments(ctx context.Context, query string, limit int) ([]review.SearchResult, error) {
+	match := ftsMatchQuery(query)
+	if match == "" {
+		return nil, nil
+	}
+
+	rows, err := s.db.Queries().SearchFinalizedReviewComments(ctx, db.SearchFinalizedReviewCommentsParams{
+		Query:      match,
+		MaxResults: int64(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search review comments: %w", err)
+	}
+
+	results := make([]review.SearchResult, 0, len(rows))
+	for _, row := range rows {
+		comment := rowToReviewComment(db.ReviewComment{
+			ID:           row.ID,
+			SessionID:    row.SessionID,
+			StartLine:    row.StartLine,
+			EndLine:      row.EndLine,
+			ContextText:  row.ContextText,
+			CommentText:  row.CommentText,
+			CreatedAt:    row.CreatedAt,
+			DocumentPath: row.DocumentPath,
+			StartCol:     row.StartCol,
+			EndCol:       row.EndCol,
+			Outdated:     row.Outdated,
+			Severity:     row.Severity,
+		})
+		if comment.DocumentPath == "" {
+			comment.DocumentPath = row.SessionDocumentPath
+		}
+		results = append(results, review.SearchResult{
+			Comment:     comment,
+			FinalizedAt: time.Unix(0, row.FinalizedAt.Int64),
+		})
+	}
+	return results, nil
+}
+
+// ftsMatchQuery turns free text into an FTS5 query matching every word,
+// quoting each so punctuation is not read as query syntax. The last word
+// matches as a prefix. Returns "" when query has no words.
+func ftsMatchQuery(query string) string {
+	words := strings.Fields(query)
+	if len(words) == 0 {
+		return ""
+	}
+	terms := make([]string, len(words))
+	for i, w := range words {
+		terms[i] = `"` + strings.ReplaceAll(w, `"`, `""`) + `"`
+	}
+	terms[len(terms)-1] += "*"
+	return strings.Join(terms, " ")
+}
+
 // SessionInfo contains session data with comment count for efficient batch queries.
 type SessionInfo struct {
 	Session      review.Session
@@ -193,6 +445,7 @@ func (s *ReviewStore) GetAllActiveSessionsWithCounts(ctx context.Context) (map[s
 			ContentHash:  row.ContentHash,
 			CreatedAt:    time.Unix(0, row.CreatedAt),
 			FinalizedAt:  finalizedAt,
+			ReviewTime:   time.Duration(row.ReviewTime),
 		}
 
 		result[row.DocumentPath] = SessionInfo{
@@ -211,25 +464,39 @@ func rowToReviewSession(row db.ReviewSession) review.Session {
 		t := time.Unix(0, row.FinalizedAt.Int64)
 		finalizedAt = &t
 	}
+	var dispatchedAt *time.Time
+	if row.DispatchedAt.Valid {
+		t := time.Unix(0, row.DispatchedAt.Int64)
+		dispatchedAt = &t
+	}
 
 	return review.Session{
-		ID:           row.ID,
-		DocumentPath: row.DocumentPath,
-		ContentHash:  row.ContentHash,
-		CreatedAt:    time.Unix(0, row.CreatedAt),
-		FinalizedAt:  finalizedAt,
+		ID:              row.ID,
+		DocumentPath:    row.DocumentPath,
+		ContentHash:     row.ContentHash,
+		CreatedAt:       time.Unix(0, row.CreatedAt),
+		FinalizedAt:     finalizedAt,
+		ReviewTime:      time.Duration(row.ReviewTime),
+		DispatchedAt:    dispatchedAt,
+		DispatchedTo:    row.DispatchedTo,
+		DispatchReplyTo: row.DispatchReplyTo,
 	}
 }
 
 // rowToReviewComment converts a db.ReviewComment to a review.Comment.
 func rowToReviewComment(row db.ReviewComment) review.Comment {
 	return review.Comment{
-		ID:          row.ID,
-		SessionID:   row.SessionID,
-		StartLine:   int(row.StartLine),
-		EndLine:     int(row.EndLine),
-		ContextText: row.ContextText,
-		CommentText: row.CommentText,
-		CreatedAt:   time.Unix(0, row.CreatedAt),
+		ID:           row.ID,
+		SessionID:    row.SessionID,
+		DocumentPath: row.DocumentPath,
+		StartLine:    int(row.StartLine),
+		EndLine:      int(row.EndLine),
+		StartCol:     int(row.StartCol),
+		EndCol:       int(row.EndCol),
+		Outdated:     row.Outdated != 0,
+		Severity:     review.Severity(row.Severity),
+		ContextText:  row.ContextText,
+		CommentText:  row.CommentText,
+		CreatedAt:    time.Unix(0, row.CreatedAt),
 	}
 }
@@ -84,6 +84,48 @@ func TestReviewStore(t *testing.T) {
 		assert.NotNil(t, got.FinalizedAt, "expected non-nil FinalizedAt")
 	})
 
+	t.Run("add review time", func(t *testing.T) {
+		database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+		require.NoError(t, err, "Open")
+		defer func() { _ = database.Close() }()
+
+		store := NewReviewStore(database)
+
+		docPath := "/tmp/timed.md"
+		session, err := store.CreateSession(ctx, docPath, "test-hash")
+		require.NoError(t, err, "CreateSession")
+		assert.Zero(t, session.ReviewTime)
+
+		require.NoError(t, store.AddReviewTime(ctx, session.ID, 90*time.Second), "AddReviewTime")
+		require.NoError(t, store.AddReviewTime(ctx, session.ID, 30*time.Second), "AddReviewTime")
+
+		got, err := store.GetSession(ctx, docPath)
+		require.NoError(t, err, "GetSession")
+		assert.Equal(t, 2*time.Minute, got.ReviewTime)
+	})
+
+	t.Run("mark dispatched", func(t *testing.T) {
+		database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+		require.NoError(t, err, "Open")
+		defer func() { _ = database.Close() }()
+
+		store := NewReviewStore(database)
+
+		docPath := "/tmp/dispatched.md"
+		session, err := store.CreateSession(ctx, docPath, "test-hash")
+		require.NoError(t, err, "CreateSession")
+		assert.Nil(t, session.DispatchedAt)
+
+		require.NoError(t, store.FinalizeSession(ctx, session.ID), "FinalizeSession")
+		require.NoError(t, store.MarkDispatched(ctx, session.ID, "sess-1", "reply.abc"), "MarkDispatched")
+
+		got, err := store.GetSession(ctx, docPath)
+		require.NoError(t, err, "GetSession")
+		require.NotNil(t, got.DispatchedAt)
+		assert.Equal(t, "sess-1", got.DispatchedTo)
+		assert.Equal(t, "reply.abc", got.DispatchReplyTo)
+	})
+
 	t.Run("delete session", func(t *testing.T) {
 		database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
 		require.NoError(t, err, "Open")
@@ -137,6 +179,8 @@ func TestReviewStore(t *testing.T) {
 			SessionID:   session.ID,
 			StartLine:   5,
 			EndLine:     7,
+			StartCol:    3,
+			EndCol:      12,
 			ContextText: "Earlier context",
 			CommentText: "Fix this typo",
 			CreatedAt:   time.Now(),
@@ -155,6 +199,99 @@ func TestReviewStore(t *testing.T) {
 
 		// Verify comment data
 		assert.Equal(t, comment2.CommentText, comments[0].CommentText)
+		assert.Equal(t, 3, comments[0].StartCol, "column anchors round-trip")
+		assert.Equal(t, 12, comments[0].EndCol)
+		assert.Zero(t, comments[1].StartCol, "line comments have no columns")
+	})
+
+	t.Run("comment severity", func(t *testing.T) {
+		database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+		require.NoError(t, err, "Open")
+		defer func() { _ = database.Close() }()
+
+		store := NewReviewStore(database)
+
+		session, err := store.CreateSession(ctx, "/tmp/severity-test.md", "test-hash")
+		require.NoError(t, err, "CreateSession")
+
+		comment := review.Comment{
+			ID:          uuid.NewString(),
+			SessionID:   session.ID,
+			StartLine:   1,
+			EndLine:     1,
+			CommentText: "unset severity",
+			CreatedAt:   time.Now(),
+		}
+		require.NoError(t, store.SaveComment(ctx, comment))
+
+		comments, err := store.ListComments(ctx, session.ID)
+		require.NoError(t, err, "ListComments")
+		require.Len(t, comments, 1)
+		assert.Equal(t, review.SeveritySuggestion, comments[0].Severity, "defaults to suggestion")
+
+		comment.CommentText = "must fix"
+		comment.Severity = review.SeverityBlocker
+		require.NoError(t, store.UpdateComment(ctx, comment))
+
+		comments, err = store.ListComments(ctx, session.ID)
+		require.NoError(t, err, "ListComments")
+		assert.Equal(t, "must fix", comments[0].CommentText)
+		assert.Equal(t, review.SeverityBlocker, comments[0].Severity)
+	})
+
+	t.Run("comment revisions", func(t *testing.T) {
+		database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+		require.NoError(t, err, "Open")
+		defer func() { _ = database.Close() }()
+
+		store := NewReviewStore(database)
+
+		session, err := store.CreateSession(ctx, "/tmp/revisions-test.md", "test-hash")
+		require.NoError(t, err, "CreateSession")
+
+		comment := review.Comment{
+			ID:          uuid.NewString(),
+			SessionID:   session.ID,
+			StartLine:   1,
+			EndLine:     1,
+			CommentText: "rename the function",
+			CreatedAt:   time.Now(),
+		}
+		require.NoError(t, store.SaveComment(ctx, comment))
+
+		revisions, err := store.ListCommentRevisions(ctx, comment.ID)
+		require.NoError(t, err, "ListCommentRevisions")
+		assert.Empty(t, revisions, "new comments have no revisions")
+
+		require.NoError(t, store.UpdateComment(ctx, comment))
+		revisions, err = store.ListCommentRevisions(ctx, comment.ID)
+		require.NoError(t, err, "ListCommentRevisions")
+		assert.Empty(t, revisions, "saving an unchanged comment records no revision")
+
+		comment.Severity = review.SeverityBlocker
+		require.NoError(t, store.UpdateComment(ctx, comment))
+		revisions, err = store.ListCommentRevisions(ctx, comment.ID)
+		require.NoError(t, err, "ListCommentRevisions")
+		require.Len(t, revisions, 1, "severity changes record a revision")
+		assert.Equal(t, "rename the function", revisions[0].CommentText)
+		assert.Equal(t, review.SeveritySuggestion, revisions[0].Severity)
+
+		comment.CommentText = "rename the handler"
+		require.NoError(t, store.UpdateComment(ctx, comment))
+		comment.CommentText = "rename the handler and its tests"
+		require.NoError(t, store.UpdateComment(ctx, comment))
+
+		revisions, err = store.ListCommentRevisions(ctx, comment.ID)
+		require.NoError(t, err, "ListCommentRevisions")
+		require.Len(t, revisions, 3)
+		assert.Equal(t, "rename the function", revisions[1].CommentText)
+		assert.Equal(t, review.SeverityBlocker, revisions[1].Severity)
+		assert.Equal(t, "rename the handler", revisions[2].CommentText)
+
+		require.NoError(t, store.DeleteComment(ctx, comment.ID))
+		revisions, err = store.ListCommentRevisions(ctx, comment.ID)
+		require.NoError(t, err, "ListCommentRevisions")
+		assert.Empty(t, revisions, "deleting a comment deletes its revisions")
 	})
 
 	t.Run("delete comment", func(t *testing.T) {
@@ -278,4 +415,237 @@ func TestReviewStore(t *testing.T) {
 		require.Len(t, comments2, 1, "session2: got %d comments, want 1", len(comments2))
 		assert.Equal(t, comment2.CommentText, comments2[0].CommentText)
 	})
+
+	t.Run("attach related documents", func(t *testing.T) {
+		database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+		require.NoError(t, err, "Open")
+		defer func() { _ = database.Close() }()
+
+		store := NewReviewStore(database)
+
+		session, err := store.CreateSession(ctx, "/tmp/plan.md", "plan-hash")
+		require.NoError(t, err, "CreateSession")
+
+		require.NoError(t, store.AddDocument(ctx, session.ID, "/tmp/research.md", "research-hash"), "AddDocument")
+		require.NoError(t, store.AddDocument(ctx, session.ID, "/tmp/research.md", "research-hash"), "AddDocument is idempotent")
+
+		docs, err := store.ListDocuments(ctx, session.ID)
+		require.NoError(t, err, "ListDocuments")
+		require.Len(t, docs, 2)
+		assert.Equal(t, "/tmp/plan.md", docs[0].DocumentPath, "session document is listed first")
+		assert.Equal(t, "/tmp/research.md", docs[1].DocumentPath)
+		assert.Equal(t, "research-hash", docs[1].ContentHash)
+
+		got, err := store.GetActiveSessionForDocument(ctx, "/tmp/research.md")
+		require.NoError(t, err, "GetActiveSessionForDocument")
+		assert.Equal(t, session.ID, got.ID)
+
+		comment := review.Comment{
+			ID:           uuid.NewString(),
+			SessionID:    session.ID,
+			DocumentPath: "/tmp/research.md",
+			StartLine:    4,
+			EndLine:      6,
+			CommentText:  "cite this",
+			CreatedAt:    time.Now(),
+		}
+		require.NoError(t, store.SaveComment(ctx, comment), "SaveComment")
+
+		comments, err := store.ListComments(ctx, session.ID)
+		require.NoError(t, err, "ListComments")
+		require.Len(t, comments, 1)
+		assert.Equal(t, "/tmp/research.md", comments[0].DocumentPath)
+
+		require.NoError(t, store.FinalizeSession(ctx, session.ID), "FinalizeSession")
+		_, err = store.GetActiveSessionForDocument(ctx, "/tmp/research.md")
+		assert.ErrorIs(t, err, review.ErrSessionNotFound, "finalized sessions no longer claim their documents")
+	})
+
+	t.Run("document cannot join two active sessions", func(t *testing.T) {
+		database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+		require.NoError(t, err, "Open")
+		defer func() { _ = database.Close() }()
+
+		store := NewReviewStore(database)
+
+		session1, err := store.CreateSession(ctx, "/tmp/doc1.md", "hash1")
+		require.NoError(t, err, "CreateSession 1")
+		session2, err := store.CreateSession(ctx, "/tmp/doc2.md", "hash2")
+		require.NoError(t, err, "CreateSession 2")
+
+		err = store.AddDocument(ctx, session1.ID, "/tmp/doc2.md", "hash2")
+		require.ErrorIs(t, err, review.ErrDocumentInReview)
+
+		require.NoError(t, store.AddDocument(ctx, session2.ID, "/tmp/shared.md", "shared"), "AddDocument")
+		err = store.AddDocument(ctx, session1.ID, "/tmp/shared.md", "shared")
+		require.ErrorIs(t, err, review.ErrDocumentInReview)
+
+		require.NoError(t, store.DeleteSession(ctx, session2.ID), "DeleteSession")
+		require.NoError(t, store.AddDocument(ctx, session1.ID, "/tmp/shared.md", "shared"), "document is free after its session is deleted")
+	})
+
+	t.Run("reanchor document", func(t *testing.T) {
+		database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+		require.NoError(t, err, "Open")
+		defer func() { _ = database.Close() }()
+
+		store := NewReviewStore(database)
+
+		session, err := store.CreateSession(ctx, "/tmp/plan.md", "old")
+		require.NoError(t, err, "CreateSession")
+		require.NoError(t, store.AddDocument(ctx, session.ID, "/tmp/notes.md", "notes-old"), "AddDocument")
+		comment := review.Comment{
+			ID:           "c1",
+			SessionID:    session.ID,
+			DocumentPath: "/tmp/plan.md",
+			StartLine:    2,
+			EndLine:      3,
+			CommentText:  "keep this",
+			CreatedAt:    time.Now(),
+		}
+		require.NoError(t, store.SaveComment(ctx, comment), "SaveComment")
+
+		comment.StartLine, comment.EndLine, comment.StartCol, comment.EndCol = 7, 7, 3, 9
+		comment.Outdated = true
+		require.NoError(t, store.ReanchorDocument(ctx, session.ID, "/tmp/plan.md", "new", []review.Comment{comment}), "ReanchorDocument")
+
+		got, err := store.GetSessionByHash(ctx, "/tmp/plan.md", "new")
+		require.NoError(t, err, "session is found by the new hash")
+		assert.Equal(t, session.ID, got.ID)
+
+		comments, err := store.ListComments(ctx, session.ID)
+		require.NoError(t, err, "ListComments")
+		require.Len(t, comments, 1)
+		assert.Equal(t, 7, comments[0].StartLine)
+		assert.Equal(t, 7, comments[0].EndLine)
+		assert.Equal(t, 3, comments[0].StartCol)
+		assert.Equal(t, 9, comments[0].EndCol)
+		assert.True(t, comments[0].Outdated)
+		assert.Equal(t, "keep this", comments[0].CommentText)
+
+		// Re-anchoring an attached document leaves the session's own hash alone
+		require.NoError(t, store.ReanchorDocument(ctx, session.ID, "/tmp/notes.md", "notes-new", nil), "ReanchorDocument attached")
+		docs, err := store.ListDocuments(ctx, session.ID)
+		require.NoError(t, err, "ListDocuments")
+		require.Len(t, docs, 2)
+		assert.Equal(t, "new", docs[0].ContentHash)
+		assert.Equal(t, "notes-new", docs[1].ContentHash)
+		_, err = store.GetSessionByHash(ctx, "/tmp/plan.md", "new")
+		assert.NoError(t, err)
+	})
+
+	t.Run("last reviewed hashes", func(t *testing.T) {
+		database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+		require.NoError(t, err, "Open")
+		defer func() { _ = database.Close() }()
+
+		store := NewReviewStore(database)
+
+		hashes, err := store.LastReviewedHashes(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, hashes)
+
+		first, err := store.CreateSession(ctx, "/tmp/plan.md", "v1")
+		require.NoError(t, err)
+		require.NoError(t, store.AddDocument(ctx, first.ID, "/tmp/notes.md", "n1"))
+		require.NoError(t, store.FinalizeSession(ctx, first.ID))
+
+		second, err := store.CreateSession(ctx, "/tmp/plan.md", "v2")
+		require.NoError(t, err)
+		require.NoError(t, store.FinalizeSession(ctx, second.ID))
+
+		_, err = store.CreateSession(ctx, "/tmp/draft.md", "d1")
+		require.NoError(t, err)
+
+		hashes, err = store.LastReviewedHashes(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"/tmp/plan.md":  "v2",
+			"/tmp/notes.md": "n1",
+		}, hashes, "only finalized reviews count, latest wins")
+
+		// Cleaning up after a content change keeps finalized history.
+		require.NoError(t, store.CleanupStaleSessions(ctx, "/tmp/plan.md", "v3"))
+		hashes, err = store.LastReviewedHashes(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "v2", hashes["/tmp/plan.md"])
+	})
+
+	t.Run("search finalized comments", func(t *testing.T) {
+		database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+		require.NoError(t, err, "Open")
+		defer func() { _ = database.Close() }()
+
+		store := NewReviewStore(database)
+
+		addComment := func(sessionID, docPath, text, context string) review.Comment {
+			c := review.Comment{
+				ID:           uuid.NewString(),
+				SessionID:    sessionID,
+				DocumentPath: docPath,
+				StartLine:    1,
+				EndLine:      1,
+				Severity:     review.SeveritySuggestion,
+				ContextText:  context,
+				CommentText:  text,
+				CreatedAt:    time.Now(),
+			}
+			require.NoError(t, store.SaveComment(ctx, c))
+			return c
+		}
+
+		older, err := store.CreateSession(ctx, "/tmp/auth.md", "a1")
+		require.NoError(t, err)
+		wrapped := addComment(older.ID, "", "Wrap the error with context before returning", "return err")
+		addComment(older.ID, "/tmp/notes.md", "Handle the timeout case", "ctx.Done()")
+		require.NoError(t, store.FinalizeSession(ctx, older.ID))
+
+		newer, err := store.CreateSession(ctx, "/tmp/api.md", "b1")
+		require.NoError(t, err)
+		edited := addComment(newer.ID, "", "Log errors once", "log.Println")
+		require.NoError(t, store.FinalizeSession(ctx, newer.ID))
+
+		active, err := store.CreateSession(ctx, "/tmp/draft.md", "c1")
+		require.NoError(t, err)
+		addComment(active.ID, "", "Errors are ignored here", "_ = f()")
+
+		results, err := store.SearchComments(ctx, "error", 10)
+		require.NoError(t, err)
+		require.Len(t, results, 2, "prefix match, finalized reviews only")
+		assert.Equal(t, "Log errors once", results[0].CommentText, "newest review first")
+		assert.Equal(t, "/tmp/api.md", results[0].DocumentPath, "falls back to the session document")
+		assert.False(t, results[0].FinalizedAt.IsZero())
+		assert.Equal(t, "Wrap the error with context before returning", results[1].CommentText)
+
+		results, err = store.SearchComments(ctx, "ctx.Done", 10)
+		require.NoError(t, err)
+		require.Len(t, results, 1, "matches quoted context; punctuation is not query syntax")
+		assert.Equal(t, "/tmp/notes.md", results[0].DocumentPath)
+
+		results, err = store.SearchComments(ctx, "error", 1)
+		require.NoError(t, err)
+		assert.Len(t, results, 1)
+
+		edited.CommentText = "Log failures once"
+		require.NoError(t, store.UpdateComment(ctx, edited))
+		results, err = store.SearchComments(ctx, "errors once", 10)
+		require.NoError(t, err)
+		assert.Empty(t, results, "edits update the index")
+
+		// VACUUM may renumber rowids once rows are deleted; results follow ids.
+		require.NoError(t, store.DeleteComment(ctx, wrapped.ID))
+		_, err = database.Conn().ExecContext(ctx, "VACUUM")
+		require.NoError(t, err)
+		results, err = store.SearchComments(ctx, "timeout", 10)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "Handle the timeout case", results[0].CommentText)
+		results, err = store.SearchComments(ctx, "wrap", 10)
+		require.NoError(t, err)
+		assert.Empty(t, results, "deletes update the index")
+
+		results, err = store.SearchComments(ctx, "  ", 10)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
 }
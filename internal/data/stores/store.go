@@ -97,6 +97,7 @@ func (s *SessionStore) Save(ctx context.Context, sess session.Session) error {
 		CloneStrategy: strategy,
 		Metadata:      metadataJSON,
 		Tags:          tagsJSON,
+		Notes:         sess.Notes,
 		CreatedAt:     sess.CreatedAt.UnixNano(),
 		UpdatedAt:     sess.UpdatedAt.UnixNano(),
 	})
@@ -153,6 +154,7 @@ func rowToSession(row db.Session) (session.Session, error) {
 		State:         session.State(row.State),
 		CloneStrategy: row.CloneStrategy,
 		Tags:          tags,
+		Notes:         row.Notes,
 		Metadata:      metadata,
 		CreatedAt:     time.Unix(0, row.CreatedAt),
 		UpdatedAt:     time.Unix(0, row.UpdatedAt),
@@ -39,6 +39,27 @@ func TestStore(t *testing.T) {
 		assert.Equal(t, sess.Name, got.Name)
 	})
 
+	t.Run("notes round trip", func(t *testing.T) {
+		database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+		require.NoError(t, err, "Open")
+		defer func() { _ = database.Close() }()
+
+		store := NewSessionStore(database)
+
+		sess := session.Session{ID: "test-id", Name: "test", State: session.StateActive, Notes: "waiting on CI\nneeds review"}
+		require.NoError(t, store.Save(ctx, sess), "Save")
+
+		got, err := store.Get(ctx, "test-id")
+		require.NoError(t, err, "Get")
+		assert.Equal(t, sess.Notes, got.Notes)
+
+		sess.Notes = ""
+		require.NoError(t, store.Save(ctx, sess), "Save cleared")
+		got, err = store.Get(ctx, "test-id")
+		require.NoError(t, err, "Get")
+		assert.Empty(t, got.Notes)
+	})
+
 	t.Run("get not found", func(t *testing.T) {
 		database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
 		require.NoError(t, err, "Open")
@@ -0,0 +1,94 @@
+package stores
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/usage"
+	"github.com/colonyops/hive/internal/data/db"
+)
+
+// UsageStore implements usage.Store using SQLite.
+type UsageStore struct {
+	db *db.DB
+}
+
+var _ usage.Store = (*UsageStore)(nil)
+
+// NewUsageStore creates a new SQLite-backed usage store.
+func NewUsageStore(db *db.DB) *UsageStore {
+	return &UsageStore{db: db}
+}
+
+// Record upserts per-day usage in a single transaction.
+func (s *UsageStore) Record(ctx context.Context, days []usage.Day) error {
+	if len(days) == 0 {
+		return nil
+	}
+
+	now := time.Now().UnixNano()
+	return s.db.WithTx(ctx, func(q *db.Queries) error {
+		for _, d := range days {
+			err := q.UpsertUsageStat(ctx, db.UpsertUsageStatParams{
+				SessionID:           d.SessionID,
+				Remote:              d.Remote,
+				Day:                 d.Date,
+				InputTokens:         d.InputTokens,
+				OutputTokens:        d.OutputTokens,
+				CacheCreationTokens: d.CacheCreationTokens,
+				CacheReadTokens:     d.CacheReadTokens,
+				CostUsd:             d.CostUSD,
+				UpdatedAt:           now,
+			})
+			if err != nil {
+				return fmt.Errorf("record usage for session %s on %s: %w", d.SessionID, d.Date, err)
+			}
+		}
+		return nil
+	})
+}
+
+// SessionTotals returns cumulative usage keyed by session ID.
+func (s *UsageStore) SessionTotals(ctx context.Context) (map[string]usage.Usage, error) {
+	rows, err := s.db.Queries().ListSessionUsageTotals(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list session usage: %w", err)
+	}
+
+	totals := make(map[string]usage.Usage, len(rows))
+	for _, row := range rows {
+		totals[row.SessionID] = usage.Usage{
+			InputTokens:         row.InputTokens,
+			OutputTokens:        row.OutputTokens,
+			CacheCreationTokens: row.CacheCreationTokens,
+			CacheReadTokens:     row.CacheReadTokens,
+			CostUSD:             row.CostUsd,
+		}
+	}
+	return totals, nil
+}
+
+// ByRepoDay returns usage per repository and day on or after since.
+func (s *UsageStore) ByRepoDay(ctx context.Context, since string) ([]usage.RepoDay, error) {
+	rows, err := s.db.Queries().ListUsageByRepoDay(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("list usage by day: %w", err)
+	}
+
+	days := make([]usage.RepoDay, 0, len(rows))
+	for _, row := range rows {
+		days = append(days, usage.RepoDay{
+			Remote: row.Remote,
+			Date:   row.Day,
+			Usage: usage.Usage{
+				InputTokens:         row.InputTokens,
+				OutputTokens:        row.OutputTokens,
+				CacheCreationTokens: row.CacheCreationTokens,
+				CacheReadTokens:     row.CacheReadTokens,
+				CostUSD:             row.CostUsd,
+			},
+		})
+	}
+	return days, nil
+}
@@ -0,0 +1,42 @@
+package stores
+
+import (
+	"context"
+	"testing"
+
+	"github.com/colonyops/hive/internal/core/usage"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageStore(t *testing.T) {
+	ctx := context.Background()
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err)
+	defer func() { _ = database.Close() }()
+
+	store := NewUsageStore(database)
+	require.NoError(t, store.Record(ctx, []usage.Day{
+		{SessionID: "a", Remote: "r1", Date: "2026-03-01", Usage: usage.Usage{InputTokens: 10, OutputTokens: 5, CostUSD: 0.5}},
+		{SessionID: "a", Remote: "r1", Date: "2026-03-02", Usage: usage.Usage{InputTokens: 20, CostUSD: 1}},
+		{SessionID: "b", Remote: "r1", Date: "2026-03-02", Usage: usage.Usage{OutputTokens: 7, CostUSD: 0.25}},
+		{SessionID: "c", Remote: "r2", Date: "2026-03-02", Usage: usage.Usage{CacheReadTokens: 100, CostUSD: 2}},
+	}))
+
+	// A re-scan after transcripts were pruned reports less; totals must not drop.
+	require.NoError(t, store.Record(ctx, []usage.Day{
+		{SessionID: "a", Remote: "r1", Date: "2026-03-01", Usage: usage.Usage{InputTokens: 1}},
+	}))
+
+	totals, err := store.SessionTotals(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, usage.Usage{InputTokens: 30, OutputTokens: 5, CostUSD: 1.5}, totals["a"])
+	assert.Equal(t, usage.Usage{OutputTokens: 7, CostUSD: 0.25}, totals["b"])
+
+	days, err := store.ByRepoDay(ctx, "2026-03-02")
+	require.NoError(t, err)
+	require.Len(t, days, 2)
+	assert.Equal(t, usage.RepoDay{Remote: "r1", Date: "2026-03-02", Usage: usage.Usage{InputTokens: 20, OutputTokens: 7, CostUSD: 1.25}}, days[0])
+	assert.Equal(t, "r2", days[1].Remote)
+}
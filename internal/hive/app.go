@@ -11,7 +11,9 @@ import (
 	"github.com/colonyops/hive/internal/core/todo"
 	"github.com/colonyops/hive/internal/data/db"
 	"github.com/colonyops/hive/internal/hive/plugins"
+	"github.com/colonyops/hive/internal/hive/supervisor"
 	"github.com/colonyops/hive/internal/sources"
+	"github.com/colonyops/hive/pkg/executil"
 	"github.com/colonyops/hive/pkg/tmpl"
 	"github.com/rs/zerolog"
 )
@@ -43,6 +45,9 @@ type App struct {
 	Renderer   *tmpl.Renderer
 	Build      BuildInfo
 	Sources    *sources.Registry
+	Exec       executil.Executor      // runs commands on this machine
+	Remote     executil.Executor      // runs commands on the --host host; nil when local
+	Workers    *supervisor.Supervisor // supervises background workers; nil disables supervision
 }
 
 // NewApp constructs an App from explicit dependencies.
@@ -62,11 +67,24 @@ func NewApp(
 	pluginInfos []doctor.PluginInfo,
 	logger zerolog.Logger,
 ) *App {
+	sessions.SetCreationLogs(kvStore)
+	sessions.SetSessionKV(kvStore)
+	sessions.SetMessages(msgStore)
+	messages := NewMessageService(msgStore, cfg, bus)
+	messages.recordCapabilities = sessions.RecordCapabilities
+	doctorSvc := NewDoctorService(sessions.sessions, cfg, pluginInfos)
+	if database != nil {
+		doctorSvc.SetDatabase(database)
+	}
+	if kvStore != nil {
+		doctorSvc.SetCleaner(sessions)
+	}
+
 	return &App{
 		Sessions:   sessions,
-		Messages:   NewMessageService(msgStore, cfg, bus),
+		Messages:   messages,
 		Context:    NewContextService(cfg, sessions.git),
-		Doctor:     NewDoctorService(sessions.sessions, cfg, pluginInfos),
+		Doctor:     doctorSvc,
 		Todos:      NewTodoService(todoStore, bus, cfg, logger.With().Str("component", "todos").Logger()),
 		Honeycomb:  NewHoneycombService(hcStore, logger.With().Str("component", "honeycomb").Logger()),
 		Bus:        bus,
@@ -79,3 +97,12 @@ func NewApp(
 		Renderer:   renderer,
 	}
 }
+
+// SessionExec returns the executor for commands run in session directories:
+// Remote under --host, otherwise Exec.
+func (a *App) SessionExec() executil.Executor {
+	if a.Remote != nil {
+		return a.Remote
+	}
+	return a.Exec
+}
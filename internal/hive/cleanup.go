@@ -0,0 +1,384 @@
+package hive
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/doctor"
+	"github.com/colonyops/hive/internal/core/kv"
+	"github.com/colonyops/hive/internal/core/messaging"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+)
+
+// cleanupNamespace is the KV namespace recording deleted sessions that left
+// resources behind, keyed by session ID.
+const cleanupNamespace = "session.cleanup"
+
+// cleanupRecord describes a deleted session's resources so they can be
+// verified again and removed later.
+type cleanupRecord struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Path      string    `json:"path"`
+	Tmux      []string  `json:"tmux"`
+	Inbox     string    `json:"inbox"`
+	DeletedAt time.Time `json:"deleted_at"`
+	Leftovers []string  `json:"leftovers"`
+
+	// TmuxCreated holds the creation time (unix seconds) of each tmux
+	// session found left behind, so a later one with the same name is not
+	// mistaken for it.
+	TmuxCreated map[string]int64 `json:"tmux_created,omitempty"`
+}
+
+func newCleanupRecord(sess *session.Session) cleanupRecord {
+	return cleanupRecord{
+		ID:        sess.ID,
+		Name:      sess.Name,
+		Path:      sess.Path,
+		Tmux:      tmuxSessionNames(sess),
+		Inbox:     sess.InboxTopic(),
+		DeletedAt: time.Now(),
+	}
+}
+
+// SetMessages removes a session's inbox when it is deleted. A nil store
+// leaves inboxes in place.
+func (s *SessionService) SetMessages(store messaging.Store) {
+	s.messages = store
+}
+
+// WaitForCleanups blocks until the verifications started by DeleteSession
+// finish.
+func (s *SessionService) WaitForCleanups() {
+	s.cleanups.Wait()
+}
+
+// tmuxSessionNames returns the names of the tmux sessions a session may own:
+// its slug and the name recorded when its tmux session was created.
+func tmuxSessionNames(sess *session.Session) []string {
+	names := []string{sess.Slug}
+	if name := sess.GetMeta(session.MetaTmuxSession); name != "" && name != sess.Slug {
+		names = append(names, name)
+	}
+	return names
+}
+
+// killTmuxSessions kills the named tmux sessions (best-effort). Names are
+// matched exactly so a session never takes down another whose name it
+// prefixes.
+func (s *SessionService) killTmuxSessions(ctx context.Context, names []string) {
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if _, err := s.executor.Run(ctx, "tmux", "kill-session", "-t", "="+name); err != nil {
+			s.log.Debug().Err(err).Str("session", name).Msg("no tmux session to kill")
+		}
+	}
+}
+
+// liveTmuxSession is a running tmux session: when it was created and the
+// @hive-session tags of its panes.
+type liveTmuxSession struct {
+	created int64
+	tags    []string
+}
+
+// liveTmuxSessions returns the running tmux sessions by name, or nil when
+// tmux is not running.
+func (s *SessionService) liveTmuxSessions(ctx context.Context) map[string]*liveTmuxSession {
+	out, err := s.executor.Run(ctx, "tmux", "list-sessions", "-F", "#{session_name}\t#{session_created}")
+	if err != nil {
+		return nil
+	}
+	live := make(map[string]*liveTmuxSession)
+	for line := range strings.Lines(string(out)) {
+		name, created, ok := strings.Cut(strings.TrimRight(line, "\n"), "\t")
+		if !ok {
+			continue
+		}
+		unix, _ := strconv.ParseInt(created, 10, 64)
+		live[name] = &liveTmuxSession{created: unix}
+	}
+
+	out, err = s.executor.Run(ctx, "tmux", "list-panes", "-a", "-F", "#{session_name}\t#{@hive-session}")
+	if err != nil {
+		s.log.Debug().Err(err).Msg("failed to list tmux panes")
+	}
+	for line := range strings.Lines(string(out)) {
+		name, tag, _ := strings.Cut(strings.TrimRight(line, "\n"), "\t")
+		if t := live[name]; t != nil && tag != "" {
+			t.tags = append(t.tags, tag)
+		}
+	}
+	return live
+}
+
+// activeTmuxNames returns the tmux session names that active sessions own:
+// their slugs and the names recorded when their tmux sessions were created.
+func (s *SessionService) activeTmuxNames(ctx context.Context) (map[string]bool, error) {
+	sessions, err := s.sessions.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[string]bool)
+	for _, sess := range sessions {
+		if sess.State != session.StateActive {
+			continue
+		}
+		for _, name := range tmuxSessionNames(&sess) {
+			owned[name] = true
+		}
+	}
+	return owned, nil
+}
+
+// leftoverTmux returns the tmux sessions of a deleted session that are still
+// running. A tmux session is not the deleted session's when an active session
+// owns it, by name or by the @hive-session tag of its panes, or when it was
+// created after the deletion; the creation time of each leftover is recorded
+// in rec.
+func (s *SessionService) leftoverTmux(ctx context.Context, rec *cleanupRecord) []string {
+	live := s.liveTmuxSessions(ctx)
+	if len(live) == 0 {
+		return nil
+	}
+	owned, err := s.activeTmuxNames(ctx)
+	if err != nil {
+		// Without the active sessions no tmux session is safe to claim
+		s.log.Warn().Err(err).Str("session_id", rec.ID).Msg("failed to list sessions for tmux leftovers")
+		return nil
+	}
+
+	var names []string
+	for _, name := range rec.Tmux {
+		t := live[name]
+		if t == nil || owned[name] || slices.ContainsFunc(t.tags, func(tag string) bool { return owned[tag] }) {
+			continue
+		}
+		if created, ok := rec.TmuxCreated[name]; ok && created != t.created {
+			continue // replaced by a newer tmux session with the same name
+		}
+		if t.created > rec.DeletedAt.Unix() {
+			continue
+		}
+		if rec.TmuxCreated == nil {
+			rec.TmuxCreated = make(map[string]int64)
+		}
+		rec.TmuxCreated[name] = t.created
+		names = append(names, name)
+	}
+	return names
+}
+
+// removeSessionResources removes what a deleted session keeps outside its
+// directory and record: its inbox, its stored values and plugin caches, and
+// its last terminal status.
+func (s *SessionService) removeSessionResources(ctx context.Context, rec cleanupRecord) error {
+	var errs []error
+	if s.messages != nil {
+		if _, err := s.messages.DeleteTopic(ctx, rec.Inbox); err != nil {
+			errs = append(errs, fmt.Errorf("delete inbox: %w", err))
+		}
+	}
+	if s.sessionKV != nil {
+		keys, err := s.sessionStoredKeys(ctx, rec.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("list stored values: %w", err))
+		}
+		for _, key := range keys {
+			if err := s.sessionKV.Delete(ctx, key); err != nil {
+				errs = append(errs, fmt.Errorf("delete %s: %w", key, err))
+			}
+		}
+		if err := s.dropTerminalStatus(ctx, rec.ID); err != nil {
+			errs = append(errs, fmt.Errorf("drop terminal status: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sessionStoredKeys returns the KV keys holding a session's data: its
+// session values and the plugin cache entries keyed by its ID.
+func (s *SessionService) sessionStoredKeys(ctx context.Context, id string) ([]string, error) {
+	all, err := s.sessionKV.ListKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	values := sessionKVNamespace + "." + id + ":"
+	var keys []string
+	for _, key := range all {
+		ns, name, ok := strings.Cut(key, ":")
+		switch {
+		case strings.HasPrefix(key, values):
+			keys = append(keys, key)
+		case ok && name == id && ns != cleanupNamespace:
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// dropTerminalStatus removes a session from the stored terminal status
+// snapshot, keeping the snapshot's expiry.
+func (s *SessionService) dropTerminalStatus(ctx context.Context, id string) error {
+	entry, err := s.sessionKV.GetRaw(ctx, terminal.StatusSnapshotKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	snapshot, ok := s.statusSnapshotWith(ctx, id)
+	if !ok || entry.ExpiresAt == nil {
+		return nil
+	}
+	ttl := time.Until(*entry.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	delete(snapshot.Statuses, id)
+	return s.sessionKV.SetTTL(ctx, terminal.StatusSnapshotKey, snapshot, ttl)
+}
+
+// statusSnapshotWith returns the stored terminal status snapshot when it
+// still lists the session.
+func (s *SessionService) statusSnapshotWith(ctx context.Context, id string) (terminal.StatusSnapshot, bool) {
+	var snapshot terminal.StatusSnapshot
+	if err := s.sessionKV.Get(ctx, terminal.StatusSnapshotKey, &snapshot); err != nil {
+		return snapshot, false
+	}
+	_, ok := snapshot.Statuses[id]
+	return snapshot, ok
+}
+
+// findLeftovers checks whether a deleted session's resources are gone and
+// describes those that are not.
+func (s *SessionService) findLeftovers(ctx context.Context, rec *cleanupRecord) []string {
+	var leftovers []string
+	if rec.Path != "" {
+		if exists, err := s.files.Exists(ctx, rec.Path); err != nil || exists {
+			leftovers = append(leftovers, "directory "+rec.Path)
+		}
+	}
+	for _, name := range s.leftoverTmux(ctx, rec) {
+		leftovers = append(leftovers, "tmux session "+name)
+	}
+	if s.messages != nil {
+		msgs, err := s.messages.Subscribe(ctx, rec.Inbox, time.Time{})
+		if err == nil && len(msgs) > 0 {
+			leftovers = append(leftovers, fmt.Sprintf("%d inbox message(s)", len(msgs)))
+		}
+	}
+	if s.sessionKV != nil {
+		if keys, err := s.sessionStoredKeys(ctx, rec.ID); err == nil && len(keys) > 0 {
+			leftovers = append(leftovers, fmt.Sprintf("%d stored value(s)", len(keys)))
+		}
+		if _, ok := s.statusSnapshotWith(ctx, rec.ID); ok {
+			leftovers = append(leftovers, "terminal status")
+		}
+	}
+	return leftovers
+}
+
+// verifyCleanup checks that a deleted session left nothing behind,
+// recording the leftovers for doctor when it did.
+func (s *SessionService) verifyCleanup(ctx context.Context, rec cleanupRecord) {
+	rec.Leftovers = s.findLeftovers(ctx, &rec)
+	if len(rec.Leftovers) == 0 {
+		return
+	}
+	s.log.Warn().Str("session_id", rec.ID).Strs("leftovers", rec.Leftovers).Msg("deleted session left resources behind")
+	if err := s.cleanupRecords().Set(ctx, rec.ID, rec); err != nil {
+		s.log.Warn().Err(err).Str("session_id", rec.ID).Msg("failed to record session leftovers")
+	}
+}
+
+func (s *SessionService) cleanupRecords() *kv.TypedKV[cleanupRecord] {
+	return kv.Scoped[cleanupRecord](s.sessionKV, cleanupNamespace)
+}
+
+// Leftovers verifies the deletions that left resources behind again and
+// returns those still incomplete. Deletions that have since completed are
+// forgotten.
+func (s *SessionService) Leftovers(ctx context.Context) ([]doctor.SessionLeftovers, error) {
+	if s.sessionKV == nil {
+		return nil, nil
+	}
+	all, err := s.sessionKV.ListKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records := s.cleanupRecords()
+	var out []doctor.SessionLeftovers
+	for _, key := range all {
+		id, ok := strings.CutPrefix(key, cleanupNamespace+":")
+		if !ok {
+			continue
+		}
+		rec, err := records.Get(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue // cleaned up since listing
+		}
+		if err != nil {
+			return nil, err
+		}
+		recorded := len(rec.TmuxCreated)
+		if rec.Leftovers = s.findLeftovers(ctx, &rec); len(rec.Leftovers) == 0 {
+			if err := records.Delete(ctx, id); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if len(rec.TmuxCreated) != recorded {
+			if err := records.Set(ctx, id, rec); err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, doctor.SessionLeftovers{SessionID: rec.ID, Name: rec.Name, Resources: rec.Leftovers})
+	}
+	return out, nil
+}
+
+// CleanLeftovers removes what a deleted session left behind and forgets the
+// deletion once nothing remains. Tmux sessions another session now owns are
+// left running.
+func (s *SessionService) CleanLeftovers(ctx context.Context, id string) error {
+	if s.sessionKV == nil {
+		return fmt.Errorf("session values are not available")
+	}
+	records := s.cleanupRecords()
+	rec, err := records.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get cleanup record: %w", err)
+	}
+
+	s.killTmuxSessions(ctx, s.leftoverTmux(ctx, &rec))
+	var errs []error
+	if rec.Path != "" {
+		if err := s.files.RemoveAll(ctx, rec.Path); err != nil {
+			errs = append(errs, fmt.Errorf("remove directory: %w", err))
+		}
+	}
+	if err := s.removeSessionResources(ctx, rec); err != nil {
+		errs = append(errs, err)
+	}
+
+	if rec.Leftovers = s.findLeftovers(ctx, &rec); len(rec.Leftovers) > 0 {
+		if err := records.Set(ctx, id, rec); err != nil {
+			errs = append(errs, err)
+		}
+		errs = append(errs, fmt.Errorf("still left %s", strings.Join(rec.Leftovers, ", ")))
+		return errors.Join(errs...)
+	}
+	return records.Delete(ctx, id)
+}
@@ -0,0 +1,207 @@
+package hive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/kv"
+	"github.com/colonyops/hive/internal/core/messaging"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/internal/data/stores"
+	"github.com/colonyops/hive/pkg/executil/executiltest"
+)
+
+// fakeTmux answers list-sessions, list-panes and kill-session for a set of
+// live tmux sessions. With stuck set, kill-session fails and sessions stay
+// alive.
+type fakeTmux struct {
+	executiltest.Exec
+	mu      sync.Mutex
+	live    map[string]bool
+	created map[string]int64  // creation time of a live session; unset is 1, before any deletion
+	tags    map[string]string // @hive-session tag of a live session's panes
+	stuck   bool
+}
+
+func (f *fakeTmux) Run(ctx context.Context, cmd string, args ...string) ([]byte, error) {
+	_, _ = f.Exec.Run(ctx, cmd, args...)
+	if cmd != "tmux" || len(args) == 0 {
+		return nil, nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out strings.Builder
+	switch args[0] {
+	case "list-sessions":
+		if len(f.live) == 0 {
+			return nil, errors.New("no server running")
+		}
+		for name := range f.live {
+			fmt.Fprintf(&out, "%s\t%d\n", name, max(f.created[name], 1))
+		}
+	case "list-panes":
+		for name := range f.live {
+			fmt.Fprintf(&out, "%s\t%s\n", name, f.tags[name])
+		}
+	case "kill-session":
+		name := args[2][1:] // strip the exact-match "="
+		if f.stuck || !f.live[name] {
+			return nil, errors.New("can't kill session")
+		}
+		delete(f.live, name)
+	}
+	return []byte(out.String()), nil
+}
+
+func newCleanupTestService(t *testing.T, tmux *fakeTmux) (*SessionService, *mockStore, kv.KV, messaging.Store) {
+	t.Helper()
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+
+	store := newMockStore()
+	svc := newTestService(t, store, nil)
+	svc.executor = tmux
+	kvStore := stores.NewKVStore(database)
+	msgStore := stores.NewMessageStore(database, 0)
+	svc.SetSessionKV(kvStore)
+	svc.SetMessages(msgStore)
+	t.Cleanup(svc.WaitForCleanups)
+	return svc, store, kvStore, msgStore
+}
+
+func TestDeleteSession_RemovesSessionResources(t *testing.T) {
+	ctx := context.Background()
+	tmux := &fakeTmux{live: map[string]bool{"auth": true, "auth-work": true, "auth-other": true}}
+	svc, store, kvStore, msgStore := newCleanupTestService(t, tmux)
+
+	sess := session.Session{
+		ID:       "s1",
+		Name:     "auth",
+		Slug:     "auth",
+		State:    session.StateActive,
+		Path:     t.TempDir(),
+		Metadata: map[string]string{session.MetaTmuxSession: "auth-work"},
+	}
+	require.NoError(t, store.Save(ctx, sess))
+	require.NoError(t, svc.SetSessionValue(ctx, "s1", "last_sha", "abc123"))
+	require.NoError(t, kvStore.Set(ctx, "github.pr:s1", "cached"))
+	require.NoError(t, kvStore.Set(ctx, "github.pr:s2", "cached"))
+	require.NoError(t, kvStore.SetTTL(ctx, terminal.StatusSnapshotKey, terminal.StatusSnapshot{
+		Statuses: map[string]terminal.Status{"s1": terminal.StatusActive, "s2": terminal.StatusReady},
+	}, time.Minute))
+	_, err := msgStore.Publish(ctx, messaging.Message{Payload: "hi"}, []string{sess.InboxTopic()})
+	require.NoError(t, err)
+
+	require.NoError(t, svc.DeleteSession(ctx, "s1"))
+	svc.WaitForCleanups()
+
+	assert.Equal(t, map[string]bool{"auth-other": true}, tmux.live, "only the session's own tmux sessions are killed")
+	_, err = msgStore.Subscribe(ctx, sess.InboxTopic(), time.Time{})
+	require.ErrorIs(t, err, messaging.ErrTopicNotFound)
+
+	keys, err := kvStore.ListKeys(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"github.pr:s2", terminal.StatusSnapshotKey}, keys)
+
+	var snapshot terminal.StatusSnapshot
+	require.NoError(t, kvStore.Get(ctx, terminal.StatusSnapshotKey, &snapshot))
+	assert.Equal(t, map[string]terminal.Status{"s2": terminal.StatusReady}, snapshot.Statuses)
+
+	leftovers, err := svc.Leftovers(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, leftovers)
+}
+
+func TestDeleteSession_RecordsLeftovers(t *testing.T) {
+	ctx := context.Background()
+	tmux := &fakeTmux{live: map[string]bool{"auth": true}, stuck: true}
+	svc, store, _, _ := newCleanupTestService(t, tmux)
+
+	require.NoError(t, store.Save(ctx, session.Session{ID: "s1", Name: "auth", Slug: "auth", State: session.StateActive, Path: t.TempDir()}))
+	require.NoError(t, svc.DeleteSession(ctx, "s1"))
+	svc.WaitForCleanups()
+
+	leftovers, err := svc.Leftovers(ctx)
+	require.NoError(t, err)
+	require.Len(t, leftovers, 1)
+	assert.Equal(t, "s1", leftovers[0].SessionID)
+	assert.Equal(t, "auth", leftovers[0].Name)
+	assert.Equal(t, []string{"tmux session auth"}, leftovers[0].Resources)
+
+	err = svc.CleanLeftovers(ctx, "s1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "still left tmux session auth")
+
+	tmux.stuck = false
+	require.NoError(t, svc.CleanLeftovers(ctx, "s1"))
+	assert.Empty(t, tmux.live)
+
+	leftovers, err = svc.Leftovers(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, leftovers, "a completed cleanup is forgotten")
+}
+
+func TestLeftovers_SkipsTmuxSessionsNowOwned(t *testing.T) {
+	ctx := context.Background()
+	tmux := &fakeTmux{live: map[string]bool{"auth": true, "api": true, "web": true}, stuck: true}
+	svc, store, _, _ := newCleanupTestService(t, tmux)
+
+	deleted := session.Session{
+		ID:       "s1",
+		Name:     "auth",
+		Slug:     "auth",
+		State:    session.StateActive,
+		Metadata: map[string]string{session.MetaTmuxSession: "api"},
+	}
+	require.NoError(t, store.Save(ctx, deleted))
+	require.NoError(t, svc.DeleteSession(ctx, "s1"))
+	svc.WaitForCleanups()
+
+	leftovers, err := svc.Leftovers(ctx)
+	require.NoError(t, err)
+	require.Len(t, leftovers, 1)
+	assert.ElementsMatch(t, []string{"tmux session auth", "tmux session api"}, leftovers[0].Resources)
+
+	// A new session takes the name auth, and api's panes now belong to web
+	require.NoError(t, store.Save(ctx, session.Session{ID: "s2", Name: "auth", Slug: "auth", State: session.StateActive}))
+	require.NoError(t, store.Save(ctx, session.Session{ID: "s3", Name: "web", Slug: "web", State: session.StateActive}))
+	tmux.tags = map[string]string{"api": "web"}
+
+	leftovers, err = svc.Leftovers(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, leftovers)
+}
+
+func TestLeftovers_SkipsReplacedTmuxSession(t *testing.T) {
+	ctx := context.Background()
+	tmux := &fakeTmux{live: map[string]bool{"auth": true}, stuck: true}
+	svc, store, _, _ := newCleanupTestService(t, tmux)
+
+	require.NoError(t, store.Save(ctx, session.Session{ID: "s1", Name: "auth", Slug: "auth", State: session.StateActive}))
+	require.NoError(t, svc.DeleteSession(ctx, "s1"))
+	svc.WaitForCleanups()
+
+	leftovers, err := svc.Leftovers(ctx)
+	require.NoError(t, err)
+	require.Len(t, leftovers, 1)
+
+	// The leftover goes away and an unrelated tmux session reuses its name
+	tmux.created = map[string]int64{"auth": time.Now().Add(time.Hour).Unix()}
+	require.NoError(t, svc.CleanLeftovers(ctx, "s1"))
+	assert.True(t, tmux.live["auth"], "a tmux session created after the deletion is not killed")
+
+	leftovers, err = svc.Leftovers(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, leftovers)
+}
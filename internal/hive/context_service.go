@@ -10,17 +10,18 @@ import (
 
 	"github.com/colonyops/hive/internal/core/config"
 	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/session"
 	"github.com/rs/zerolog/log"
 )
 
 // ContextService manages per-repository context directories.
 type ContextService struct {
 	config *config.Config
-	git    git.Git
+	git    git.VCS
 }
 
 // NewContextService creates a new ContextService.
-func NewContextService(cfg *config.Config, gitClient git.Git) *ContextService {
+func NewContextService(cfg *config.Config, gitClient git.VCS) *ContextService {
 	return &ContextService{
 		config: cfg,
 		git:    gitClient,
@@ -134,3 +135,79 @@ func (c *ContextService) Prune(ctxDir string, olderThan time.Duration) (int, err
 
 	return count, nil
 }
+
+// IssueContext is an issue written to a context directory.
+type IssueContext struct {
+	Number string
+	Title  string
+	URL    string
+	Body   string
+}
+
+// WriteIssueContext writes issue to references/issue-<number>.md in ctxDir,
+// so agents find it under .hive/ like other context documents. An existing
+// file for the issue is replaced. It returns the path written.
+func WriteIssueContext(ctxDir string, issue IssueContext) (string, error) {
+	if issue.Number == "" || strings.ContainsAny(issue.Number, `/\.`) {
+		return "", fmt.Errorf("invalid issue number %q", issue.Number)
+	}
+
+	dir := filepath.Join(ctxDir, "references")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create references directory: %w", err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# #%s %s\n", issue.Number, strings.TrimSpace(issue.Title))
+	if issue.URL != "" {
+		fmt.Fprintf(&b, "\n%s\n", issue.URL)
+	}
+	if body := strings.TrimSpace(issue.Body); body != "" {
+		fmt.Fprintf(&b, "\n%s\n", body)
+	}
+
+	path := filepath.Join(dir, "issue-"+issue.Number+".md")
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return "", fmt.Errorf("write issue: %w", err)
+	}
+	return path, nil
+}
+
+// SessionReviewCounts counts, for each session, the review sessions on
+// documents that belong to it: documents inside its checkout or in the
+// context directory of its repository. commentCounts maps the document path
+// of each open review session to its number of comments; reviews without
+// comments are not counted. Sessions without reviews are omitted.
+func SessionReviewCounts(cfg *config.Config, sessions []session.Session, commentCounts map[string]int) map[string]int {
+	counts := make(map[string]int)
+	if len(commentCounts) == 0 {
+		return counts
+	}
+	for i := range sessions {
+		s := &sessions[i]
+		dirs := []string{s.Path}
+		if owner, repo := git.ExtractOwnerRepo(s.Remote); owner != "" && repo != "" {
+			dirs = append(dirs, cfg.RepoContextDir(owner, repo))
+		}
+		for docPath, n := range commentCounts {
+			if n > 0 && underAnyDir(docPath, dirs) {
+				counts[s.ID]++
+			}
+		}
+	}
+	return counts
+}
+
+// underAnyDir reports whether path is inside one of dirs.
+func underAnyDir(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package hive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/session"
+)
+
+func TestWriteIssueContext(t *testing.T) {
+	ctxDir := t.TempDir()
+
+	path, err := WriteIssueContext(ctxDir, IssueContext{
+		Number: "42",
+		Title:  "Fix login redirect",
+		URL:    "https://github.com/colonyops/hive/issues/42",
+		Body:   "Users land on /home.\n",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(ctxDir, "references", "issue-42.md"), path)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# #42 Fix login redirect\n\nhttps://github.com/colonyops/hive/issues/42\n\nUsers land on /home.\n", string(data))
+
+	_, err = WriteIssueContext(ctxDir, IssueContext{Number: "42", Title: "Fix login redirect"})
+	require.NoError(t, err)
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# #42 Fix login redirect\n", string(data), "rewriting replaces the file")
+
+	for _, number := range []string{"", "../42", "4.2"} {
+		_, err := WriteIssueContext(ctxDir, IssueContext{Number: number})
+		assert.Error(t, err, number)
+	}
+}
+
+func TestSessionReviewCounts(t *testing.T) {
+	cfg := &config.Config{DataDir: "/data"}
+	ctxDir := cfg.RepoContextDir("org", "api")
+	sessions := []session.Session{
+		{ID: "a", Path: "/src/api-a", Remote: "git@github.com:org/api.git"},
+		{ID: "b", Path: "/src/api-b", Remote: "git@github.com:org/api.git"},
+		{ID: "c", Path: "/src/web", Remote: "git@github.com:org/web.git"},
+	}
+
+	counts := SessionReviewCounts(cfg, sessions, map[string]int{
+		filepath.Join(ctxDir, "plans", "cache.md"): 3,
+		"/src/api-a/docs/design.md":                1,
+		"/src/api-abc/notes.md":                    2, // sibling directory, not inside /src/api-a
+		"/src/web/README.md":                       0, // no comments yet
+	})
+	assert.Equal(t, map[string]int{"a": 2, "b": 1}, counts)
+}
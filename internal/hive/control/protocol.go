@@ -0,0 +1,111 @@
+// Package control implements the local control API served by hive serve: a
+// JSON-RPC 2.0 service over a Unix socket, one JSON object per line, for
+// editors and scripts that drive hive without shelling out to the CLI.
+package control
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/colonyops/hive/internal/core/messaging"
+)
+
+// Methods served by the control API.
+const (
+	MethodSessionsList      = "sessions.list"
+	MethodSessionsCreate    = "sessions.create"
+	MethodSessionsRecycle   = "sessions.recycle"
+	MethodSessionsDelete    = "sessions.delete"
+	MethodMessagesPublish   = "messages.publish"
+	MethodMessagesSubscribe = "messages.subscribe"
+	MethodStatusesGet       = "statuses.get"
+)
+
+// JSON-RPC 2.0 error codes.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeServerError    = -32000
+)
+
+// Request is a JSON-RPC 2.0 request. Requests without an ID are
+// notifications and get no response.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is a JSON-RPC 2.0 response carrying either Result or Error.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC 2.0 error object.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("control: %s (code %d)", e.Message, e.Code)
+}
+
+// SessionsListParams filters sessions.list.
+type SessionsListParams struct {
+	State string `json:"state,omitempty"` // only sessions in this state
+}
+
+// SessionsCreateParams are the parameters of sessions.create. Sessions are
+// always created in the background; the daemon never attaches to tmux.
+type SessionsCreateParams struct {
+	Name   string   `json:"name"`
+	Remote string   `json:"remote"`
+	Source string   `json:"source,omitempty"` // directory rules copy files from (default: the daemon's working directory)
+	Prompt string   `json:"prompt,omitempty"` // passed to batch_spawn; selects batch_spawn when set
+	Agent  string   `json:"agent,omitempty"`  // agent profile key
+	Tags   []string `json:"tags,omitempty"`
+}
+
+// SessionIDParams identifies the session for sessions.recycle and
+// sessions.delete. Sessions with uncommitted or unpushed work are refused
+// unless Force is set.
+type SessionIDParams struct {
+	ID    string `json:"id"`
+	Force bool   `json:"force,omitempty"`
+}
+
+// MessagesPublishParams are the parameters of messages.publish.
+type MessagesPublishParams struct {
+	Topics  []string `json:"topics"`
+	Payload string   `json:"payload"`
+	Sender  string   `json:"sender,omitempty"`
+}
+
+// MessagesPublishResult is the result of messages.publish.
+type MessagesPublishResult struct {
+	Topics []string         `json:"topics"`
+	Seqs   map[string]int64 `json:"seqs"`
+}
+
+// MessagesSubscribeParams are the parameters of messages.subscribe. Messages
+// after Cursor are returned; pass the returned cursor to the next call to
+// follow a topic. With WaitMs set, the call blocks up to that long for a
+// message to arrive.
+type MessagesSubscribeParams struct {
+	Topic  string           `json:"topic"`
+	Cursor messaging.Cursor `json:"cursor,omitempty"`
+	WaitMs int              `json:"wait_ms,omitempty"`
+}
+
+// MessagesSubscribeResult is the result of messages.subscribe.
+type MessagesSubscribeResult struct {
+	Messages []messaging.Message `json:"messages"`
+	Cursor   messaging.Cursor    `json:"cursor"`
+}
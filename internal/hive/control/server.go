@@ -0,0 +1,336 @@
+package control
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/colonyops/hive/internal/core/messaging"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+)
+
+// maxRequestSize bounds a single request line.
+const maxRequestSize = 1 << 20
+
+// subscribePollInterval is how often a waiting messages.subscribe checks for
+// new messages.
+const subscribePollInterval = 250 * time.Millisecond
+
+// maxSubscribeWait caps how long messages.subscribe may block.
+const maxSubscribeWait = 5 * time.Minute
+
+// Backend is the set of operations the control API exposes.
+type Backend struct {
+	ListSessions   func(ctx context.Context) ([]session.Session, error)
+	CreateSession  func(ctx context.Context, p SessionsCreateParams) (*session.Session, error)
+	RecycleSession func(ctx context.Context, id string, force bool) error
+	DeleteSession  func(ctx context.Context, id string, force bool) error
+	Publish        func(ctx context.Context, msg messaging.Message, topics []string) (messaging.PublishResult, error)
+	SubscribeAfter func(ctx context.Context, topic string, cursor messaging.Cursor) ([]messaging.Message, error)
+	// Statuses returns the agent statuses from the last TUI poll, or nil
+	// when no TUI is polling.
+	Statuses func(ctx context.Context) (*terminal.StatusSnapshot, error)
+}
+
+// Server serves the control API on a Unix socket. Each connection handles
+// its requests in order.
+type Server struct {
+	backend Backend
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+	wg    sync.WaitGroup
+}
+
+// NewServer creates a server that dispatches requests to backend.
+func NewServer(backend Backend) *Server {
+	return &Server{backend: backend, conns: make(map[net.Conn]struct{})}
+}
+
+// Listen opens a Unix socket at path, readable only by the current user. The
+// socket is created in a private directory and linked into place once its
+// mode is restricted, so it is never reachable with looser permissions. A
+// stale socket left by a daemon that exited uncleanly is replaced; a socket
+// another daemon is still serving is an error.
+func Listen(path string) (net.Listener, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("control socket %s is in use by another hive serve", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("remove stale control socket: %w", err)
+		}
+	}
+
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".hive-sock-")
+	if err != nil {
+		return nil, fmt.Errorf("create control socket: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	tmp := filepath.Join(dir, "s")
+	ln, err := net.ListenUnix("unix", &net.UnixAddr{Name: tmp, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	// The listener is served from path; the private name goes with dir.
+	ln.SetUnlinkOnClose(false)
+	if err := os.Chmod(tmp, 0o600); err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("restrict control socket: %w", err)
+	}
+	if err := os.Link(tmp, path); err != nil {
+		_ = ln.Close()
+		if errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("control socket %s is in use by another hive serve", path)
+		}
+		return nil, fmt.Errorf("create control socket: %w", err)
+	}
+	return ln, nil
+}
+
+// Serve accepts connections on ln until ctx is done, then closes ln and all
+// open connections and waits for in-flight requests to finish.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		<-ctx.Done()
+		_ = ln.Close()
+		s.mu.Lock()
+		for conn := range s.conns {
+			_ = conn.Close()
+		}
+		s.mu.Unlock()
+	}()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			s.wg.Wait()
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("accept: %w", err)
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.serveConn(ctx, conn)
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+func (s *Server) serveConn(ctx context.Context, conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRequestSize)
+	enc := json.NewEncoder(conn)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		resp, ok := s.handle(ctx, line)
+		if !ok {
+			continue
+		}
+		if err := enc.Encode(resp); err != nil {
+			log.Debug().Err(err).Msg("control: write response")
+			return
+		}
+	}
+}
+
+// handle decodes and dispatches one request line. It returns false for
+// notifications, which get no response.
+func (s *Server) handle(ctx context.Context, line []byte) (Response, bool) {
+	var req Request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return errorResponse(nil, CodeParseError, "parse error: "+err.Error()), true
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, CodeInvalidRequest, "invalid request"), len(req.ID) > 0
+	}
+
+	result, err := s.dispatch(ctx, req)
+	if len(req.ID) == 0 {
+		return Response{}, false
+	}
+	if err != nil {
+		var rpcErr *Error
+		if errors.As(err, &rpcErr) {
+			return errorResponse(req.ID, rpcErr.Code, rpcErr.Message), true
+		}
+		return errorResponse(req.ID, CodeServerError, err.Error()), true
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return errorResponse(req.ID, CodeServerError, "encode result: "+err.Error()), true
+	}
+	return Response{JSONRPC: "2.0", ID: req.ID, Result: data}, true
+}
+
+func (s *Server) dispatch(ctx context.Context, req Request) (any, error) {
+	switch req.Method {
+	case MethodSessionsList:
+		var p SessionsListParams
+		if err := decodeParams(req.Params, &p); err != nil {
+			return nil, err
+		}
+		return s.listSessions(ctx, p)
+
+	case MethodSessionsCreate:
+		var p SessionsCreateParams
+		if err := decodeParams(req.Params, &p); err != nil {
+			return nil, err
+		}
+		if p.Name == "" || p.Remote == "" {
+			return nil, invalidParams("name and remote are required")
+		}
+		return s.backend.CreateSession(ctx, p)
+
+	case MethodSessionsRecycle, MethodSessionsDelete:
+		var p SessionIDParams
+		if err := decodeParams(req.Params, &p); err != nil {
+			return nil, err
+		}
+		if p.ID == "" {
+			return nil, invalidParams("id is required")
+		}
+		op := s.backend.RecycleSession
+		if req.Method == MethodSessionsDelete {
+			op = s.backend.DeleteSession
+		}
+		if err := op(ctx, p.ID, p.Force); err != nil {
+			return nil, err
+		}
+		return struct{}{}, nil
+
+	case MethodMessagesPublish:
+		var p MessagesPublishParams
+		if err := decodeParams(req.Params, &p); err != nil {
+			return nil, err
+		}
+		if len(p.Topics) == 0 {
+			return nil, invalidParams("at least one topic is required")
+		}
+		result, err := s.backend.Publish(ctx, messaging.Message{Payload: p.Payload, Sender: p.Sender}, p.Topics)
+		if err != nil {
+			return nil, err
+		}
+		return MessagesPublishResult{Topics: result.Topics, Seqs: result.Seqs}, nil
+
+	case MethodMessagesSubscribe:
+		var p MessagesSubscribeParams
+		if err := decodeParams(req.Params, &p); err != nil {
+			return nil, err
+		}
+		if p.Topic == "" {
+			return nil, invalidParams("topic is required")
+		}
+		return s.subscribe(ctx, p)
+
+	case MethodStatusesGet:
+		return s.backend.Statuses(ctx)
+
+	default:
+		return nil, &Error{Code: CodeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+}
+
+func (s *Server) listSessions(ctx context.Context, p SessionsListParams) ([]session.Session, error) {
+	sessions, err := s.backend.ListSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p.State == "" {
+		return sessions, nil
+	}
+
+	filtered := make([]session.Session, 0, len(sessions))
+	for _, sess := range sessions {
+		if string(sess.State) == p.State {
+			filtered = append(filtered, sess)
+		}
+	}
+	return filtered, nil
+}
+
+// subscribe reads messages after the cursor, polling until one arrives or
+// the requested wait elapses. A topic that does not exist yet reads as empty
+// so callers can wait on an inbox before anything is published to it.
+func (s *Server) subscribe(ctx context.Context, p MessagesSubscribeParams) (MessagesSubscribeResult, error) {
+	cursor := p.Cursor
+	if cursor == nil {
+		cursor = messaging.Cursor{}
+	}
+
+	wait := min(time.Duration(p.WaitMs)*time.Millisecond, maxSubscribeWait)
+	deadline := time.Now().Add(wait)
+
+	for {
+		msgs, err := s.backend.SubscribeAfter(ctx, p.Topic, cursor)
+		if err != nil && !errors.Is(err, messaging.ErrTopicNotFound) {
+			return MessagesSubscribeResult{}, err
+		}
+		if len(msgs) > 0 || !time.Now().Before(deadline) {
+			cursor.Advance(msgs)
+			if msgs == nil {
+				msgs = []messaging.Message{}
+			}
+			return MessagesSubscribeResult{Messages: msgs, Cursor: cursor}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return MessagesSubscribeResult{}, ctx.Err()
+		case <-time.After(subscribePollInterval):
+		}
+	}
+}
+
+func decodeParams(raw json.RawMessage, v any) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return invalidParams(err.Error())
+	}
+	return nil
+}
+
+func invalidParams(msg string) *Error {
+	return &Error{Code: CodeInvalidParams, Message: "invalid params: " + msg}
+}
+
+func errorResponse(id json.RawMessage, code int, msg string) Response {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return Response{JSONRPC: "2.0", ID: id, Error: &Error{Code: code, Message: msg}}
+}
@@ -0,0 +1,250 @@
+package control
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/messaging"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+)
+
+// fakeBackend is an in-memory Backend.
+type fakeBackend struct {
+	mu       sync.Mutex
+	sessions []session.Session
+	messages []messaging.Message
+	deleted  []string
+}
+
+func (f *fakeBackend) backend() Backend {
+	return Backend{
+		ListSessions: func(context.Context) ([]session.Session, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			return append([]session.Session(nil), f.sessions...), nil
+		},
+		CreateSession: func(_ context.Context, p SessionsCreateParams) (*session.Session, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			sess := session.Session{ID: "new1", Name: p.Name, Remote: p.Remote, State: session.StateActive, Tags: p.Tags}
+			f.sessions = append(f.sessions, sess)
+			return &sess, nil
+		},
+		RecycleSession: func(context.Context, string, bool) error {
+			return errors.New("recycle failed")
+		},
+		DeleteSession: func(_ context.Context, id string, force bool) error {
+			if !force {
+				return errors.New("session has unpushed commits")
+			}
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			f.deleted = append(f.deleted, id)
+			return nil
+		},
+		Publish: func(_ context.Context, msg messaging.Message, topics []string) (messaging.PublishResult, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			result := messaging.PublishResult{Seqs: map[string]int64{}}
+			for _, topic := range topics {
+				msg.Topic = topic
+				msg.Seq = int64(len(f.messages) + 1)
+				f.messages = append(f.messages, msg)
+				result.Topics = append(result.Topics, topic)
+				result.Seqs[topic] = msg.Seq
+			}
+			return result, nil
+		},
+		SubscribeAfter: func(_ context.Context, topic string, cursor messaging.Cursor) ([]messaging.Message, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			var msgs []messaging.Message
+			for _, msg := range f.messages {
+				if msg.Topic == topic && msg.Seq > cursor[topic] {
+					msgs = append(msgs, msg)
+				}
+			}
+			if len(msgs) == 0 && len(f.messages) == 0 {
+				return nil, messaging.ErrTopicNotFound
+			}
+			return msgs, nil
+		},
+		Statuses: func(context.Context) (*terminal.StatusSnapshot, error) {
+			return &terminal.StatusSnapshot{Statuses: map[string]terminal.Status{"s1": terminal.StatusReady}}, nil
+		},
+	}
+}
+
+// rpcConn sends requests to the control socket one line at a time, as an
+// editor or script would.
+type rpcConn struct {
+	conn    net.Conn
+	scanner *bufio.Scanner
+	nextID  int
+}
+
+// call invokes method and decodes the result into result, which may be nil.
+// Errors returned by the server are *Error.
+func (c *rpcConn) call(method string, params, result any) error {
+	c.nextID++
+	req := map[string]any{"jsonrpc": "2.0", "id": c.nextID, "method": method}
+	if params != nil {
+		req["params"] = params
+	}
+	if err := json.NewEncoder(c.conn).Encode(req); err != nil {
+		return err
+	}
+	if !c.scanner.Scan() {
+		return errors.New("connection closed")
+	}
+
+	var resp Response
+	if err := json.Unmarshal(c.scanner.Bytes(), &resp); err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Result, result)
+}
+
+// startServer serves f on a temporary socket and returns a connection to it.
+func startServer(t *testing.T, f *fakeBackend) (*rpcConn, string) {
+	t.Helper()
+
+	// Unix socket paths are length-limited, so avoid the long t.TempDir path.
+	dir, err := os.MkdirTemp("", "hive-control")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	path := filepath.Join(dir, "hive.sock")
+
+	ln, err := Listen(path)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- NewServer(f.backend()).Serve(ctx, ln) }()
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-done)
+	})
+
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return &rpcConn{conn: conn, scanner: bufio.NewScanner(conn)}, path
+}
+
+func TestServer_Sessions(t *testing.T) {
+	f := &fakeBackend{sessions: []session.Session{
+		{ID: "a", Name: "api", State: session.StateActive},
+		{ID: "b", Name: "web", State: session.StateRecycled},
+	}}
+	client, _ := startServer(t, f)
+
+	var sessions []session.Session
+	require.NoError(t, client.call(MethodSessionsList, SessionsListParams{State: "recycled"}, &sessions))
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "b", sessions[0].ID)
+
+	var created session.Session
+	require.NoError(t, client.call(MethodSessionsCreate, SessionsCreateParams{Name: "docs", Remote: "git@github.com:o/r.git", Tags: []string{"x"}}, &created))
+	assert.Equal(t, "new1", created.ID)
+	assert.Equal(t, []string{"x"}, created.Tags)
+
+	err := client.call(MethodSessionsDelete, SessionIDParams{ID: "a"}, nil)
+	require.Error(t, err)
+	var rpcErr *Error
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, CodeServerError, rpcErr.Code)
+	assert.Contains(t, rpcErr.Message, "unpushed")
+
+	require.NoError(t, client.call(MethodSessionsDelete, SessionIDParams{ID: "a", Force: true}, nil))
+	assert.Equal(t, []string{"a"}, f.deleted)
+
+	err = client.call(MethodSessionsRecycle, SessionIDParams{}, nil)
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, CodeInvalidParams, rpcErr.Code)
+}
+
+func TestServer_Messages(t *testing.T) {
+	client, _ := startServer(t, &fakeBackend{})
+
+	// An unknown topic reads as empty.
+	var sub MessagesSubscribeResult
+	require.NoError(t, client.call(MethodMessagesSubscribe, MessagesSubscribeParams{Topic: "agent.a.inbox"}, &sub))
+	assert.Empty(t, sub.Messages)
+
+	var pub MessagesPublishResult
+	require.NoError(t, client.call(MethodMessagesPublish, MessagesPublishParams{Topics: []string{"agent.a.inbox"}, Payload: "hi", Sender: "me"}, &pub))
+	assert.Equal(t, []string{"agent.a.inbox"}, pub.Topics)
+
+	require.NoError(t, client.call(MethodMessagesSubscribe, MessagesSubscribeParams{Topic: "agent.a.inbox", Cursor: sub.Cursor}, &sub))
+	require.Len(t, sub.Messages, 1)
+	assert.Equal(t, "hi", sub.Messages[0].Payload)
+	assert.Equal(t, int64(1), sub.Cursor["agent.a.inbox"])
+
+	// Waiting with nothing new blocks for the requested time.
+	start := time.Now()
+	require.NoError(t, client.call(MethodMessagesSubscribe, MessagesSubscribeParams{Topic: "agent.a.inbox", Cursor: sub.Cursor, WaitMs: 300}, &sub))
+	assert.Empty(t, sub.Messages)
+	assert.GreaterOrEqual(t, time.Since(start), 300*time.Millisecond)
+}
+
+func TestServer_Statuses(t *testing.T) {
+	client, _ := startServer(t, &fakeBackend{})
+
+	var snap terminal.StatusSnapshot
+	require.NoError(t, client.call(MethodStatusesGet, nil, &snap))
+	assert.Equal(t, terminal.StatusReady, snap.Statuses["s1"])
+}
+
+func TestServer_ProtocolErrors(t *testing.T) {
+	client, _ := startServer(t, &fakeBackend{})
+
+	var rpcErr *Error
+	err := client.call("sessions.nope", nil, nil)
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, CodeMethodNotFound, rpcErr.Code)
+
+	_, err = client.conn.Write([]byte("{not json\n"))
+	require.NoError(t, err)
+	require.True(t, client.scanner.Scan())
+	assert.Contains(t, client.scanner.Text(), `"code":-32700`)
+}
+
+func TestListen_Permissions(t *testing.T) {
+	_, path := startServer(t, &fakeBackend{})
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "the private socket directory is removed")
+	assert.Equal(t, "hive.sock", entries[0].Name())
+}
+
+func TestListen_InUse(t *testing.T) {
+	_, path := startServer(t, &fakeBackend{})
+
+	_, err := Listen(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "in use")
+}
@@ -29,6 +29,13 @@ func NewFileCopier(log zerolog.Logger, stdout io.Writer) *FileCopier {
 	}
 }
 
+// withOutput returns a copy of c that writes its output to stdout.
+func (c *FileCopier) withOutput(stdout io.Writer) *FileCopier {
+	cp := *c
+	cp.stdout = stdout
+	return &cp
+}
+
 // CopyFiles copies files matching the rule's copy patterns from sourceDir to destDir.
 func (c *FileCopier) CopyFiles(ctx context.Context, rule config.Rule, sourceDir, destDir string) error {
 	// Validate source directory exists
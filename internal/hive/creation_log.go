@@ -0,0 +1,139 @@
+package hive
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/kv"
+	"github.com/colonyops/hive/internal/core/session"
+)
+
+// ErrNoCreationLog is returned by CreationLog when no log is recorded for a
+// session ID.
+var ErrNoCreationLog = errors.New("no creation log recorded")
+
+// creationLogNamespace is the KV namespace creation logs are stored under,
+// keyed by session ID.
+const creationLogNamespace = "session.creation-log"
+
+// creationLogTTL is how long a creation log is kept.
+const creationLogTTL = 30 * 24 * time.Hour
+
+// creationLogMaxBytes caps the output kept per log; earlier output is
+// dropped first.
+const creationLogMaxBytes = 256 * 1024
+
+// CreationLog is the captured output of the last attempt to create a
+// session: progress lines plus rule command, hook, and spawn output.
+type CreationLog struct {
+	SessionID  string    `json:"session_id"`
+	Name       string    `json:"name"`
+	Remote     string    `json:"remote,omitempty"`
+	Output     string    `json:"output"`
+	Error      string    `json:"error,omitempty"` // empty when creation succeeded
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// NewSessionID returns a random ID for a new session. Callers that need to
+// refer to a creation attempt before it finishes, such as to look up its
+// creation log after a failure, pass it as CreateOptions.SessionID.
+func NewSessionID() string {
+	return generateID()
+}
+
+// SetCreationLogs enables recording each CreateSession call's output in
+// store, retrievable with CreationLog. A nil store disables recording.
+func (s *SessionService) SetCreationLogs(store kv.KV) {
+	if store == nil {
+		s.creationLogs = nil
+		return
+	}
+	s.creationLogs = kv.Scoped[CreationLog](store, creationLogNamespace)
+}
+
+// CreationLog returns the log of the last creation attempt for a session ID.
+// Failed attempts are stored under CreateOptions.SessionID, since the session
+// itself was never saved.
+func (s *SessionService) CreationLog(ctx context.Context, id string) (CreationLog, error) {
+	if s.creationLogs == nil {
+		return CreationLog{}, ErrNoCreationLog
+	}
+	entry, err := s.creationLogs.Get(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return CreationLog{}, ErrNoCreationLog
+	}
+	return entry, err
+}
+
+// recordCreation runs create with its output captured, then stores the
+// captured output as the session's creation log. Output still reaches
+// opts.Progress, or the service writers when no progress writer is set.
+// Only this call's output is captured, so concurrent creates each get their
+// own log.
+func (s *SessionService) recordCreation(ctx context.Context, opts CreateOptions, create func(CreateOptions) (*session.Session, error)) (*session.Session, error) {
+	if opts.SessionID == "" {
+		opts.SessionID = generateID()
+	}
+
+	buf := &tailBuffer{max: creationLogMaxBytes}
+	if opts.Progress != nil {
+		opts.Progress = io.MultiWriter(buf, opts.Progress)
+	} else {
+		opts.stdout = io.MultiWriter(buf, s.out)
+		opts.stderr = io.MultiWriter(buf, s.err)
+	}
+
+	entry := CreationLog{
+		SessionID: opts.SessionID,
+		Name:      opts.Name,
+		Remote:    opts.Remote,
+		StartedAt: time.Now(),
+	}
+
+	sess, err := create(opts)
+
+	entry.FinishedAt = time.Now()
+	entry.Output = buf.String()
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.SessionID = sess.ID
+		entry.Remote = sess.Remote
+	}
+
+	// Use a fresh context so a cancelled creation still records why it stopped.
+	if setErr := s.creationLogs.SetTTL(context.WithoutCancel(ctx), entry.SessionID, entry, creationLogTTL); setErr != nil {
+		s.log.Warn().Err(setErr).Str("session_id", entry.SessionID).Msg("failed to save creation log")
+	}
+
+	return sess, err
+}
+
+// tailBuffer keeps the last max bytes written to it. It is safe for
+// concurrent use, since hook stdout and stderr may be written in parallel.
+type tailBuffer struct {
+	mu   sync.Mutex
+	max  int
+	data []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.data = append(b.data, p...)
+	if over := len(b.data) - b.max; over > 0 {
+		b.data = append(b.data[:0], b.data[over:]...)
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.data)
+}
@@ -0,0 +1,115 @@
+package hive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/config"
+)
+
+func TestCreationLog_RecordsSuccess(t *testing.T) {
+	store := newMockStore()
+	svc := newTestService(t, store, nil)
+	svc.SetCreationLogs(newSourcesTestKV(t))
+
+	var progress bytes.Buffer
+	sess, err := svc.CreateSession(context.Background(), CreateOptions{
+		Name:       "logged",
+		Remote:     testRemote,
+		Background: true,
+		Progress:   &progress,
+	})
+	require.NoError(t, err)
+
+	entry, err := svc.CreationLog(context.Background(), sess.ID)
+	require.NoError(t, err)
+	assert.Equal(t, sess.ID, entry.SessionID)
+	assert.Equal(t, "logged", entry.Name)
+	assert.Equal(t, progress.String(), entry.Output)
+	assert.Empty(t, entry.Error)
+	assert.False(t, entry.FinishedAt.Before(entry.StartedAt))
+}
+
+func TestCreationLog_CapturesOnlyItsOwnOutput(t *testing.T) {
+	store := newMockStore()
+	cfg := &config.Config{
+		DataDir: t.TempDir(),
+		GitPath: "git",
+		Rules:   []config.Rule{{Pattern: "", Commands: []string{"echo {{ .Name }}"}}},
+	}
+	svc := newTestService(t, store, cfg)
+	svc.SetCreationLogs(newSourcesTestKV(t))
+
+	var logs []string
+	for _, name := range []string{"first", "second"} {
+		sess, err := svc.CreateSession(context.Background(), CreateOptions{
+			Name:      name,
+			Remote:    testRemote,
+			SkipSpawn: true,
+		})
+		require.NoError(t, err)
+
+		entry, err := svc.CreationLog(context.Background(), sess.ID)
+		require.NoError(t, err)
+		assert.Contains(t, entry.Output, "echo "+name)
+		logs = append(logs, entry.Output)
+	}
+
+	assert.NotContains(t, logs[1], "echo first")
+	assert.Equal(t, io.Discard, svc.out.w, "the service writers are left alone")
+}
+
+func TestCreationLog_RecordsFailureUnderPresetID(t *testing.T) {
+	store := newMockStore()
+	cfg := &config.Config{
+		DataDir: t.TempDir(),
+		GitPath: "git",
+		Agents: config.AgentsConfig{
+			Default:  "claude",
+			Profiles: map[string]config.AgentProfile{"claude": {Command: "claude"}},
+		},
+	}
+	svc := newTestService(t, store, cfg)
+	svc.SetCreationLogs(newSourcesTestKV(t))
+
+	id := NewSessionID()
+	_, err := svc.CreateSession(context.Background(), CreateOptions{
+		SessionID: id,
+		Name:      "broken",
+		Remote:    testRemote,
+		AgentKey:  "missing",
+	})
+	require.Error(t, err)
+
+	entry, err := svc.CreationLog(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, id, entry.SessionID)
+	assert.Contains(t, entry.Error, `unknown agent "missing"`)
+}
+
+func TestCreationLog_NotFound(t *testing.T) {
+	svc := newTestService(t, newMockStore(), nil)
+
+	_, err := svc.CreationLog(context.Background(), "nope")
+	require.ErrorIs(t, err, ErrNoCreationLog)
+
+	svc.SetCreationLogs(newSourcesTestKV(t))
+	_, err = svc.CreationLog(context.Background(), "nope")
+	require.ErrorIs(t, err, ErrNoCreationLog)
+}
+
+func TestTailBuffer_KeepsLastBytes(t *testing.T) {
+	buf := &tailBuffer{max: 8}
+	_, _ = buf.Write([]byte("hello "))
+	_, _ = buf.Write([]byte("world"))
+	assert.Equal(t, "lo world", buf.String())
+
+	_, _ = buf.Write([]byte(strings.Repeat("x", 20)))
+	assert.Equal(t, strings.Repeat("x", 8), buf.String())
+}
@@ -0,0 +1,232 @@
+// Package dashboard implements the read-only HTTP dashboard served by hive
+// serve: a small JSON API plus a single HTML page that refreshes itself when
+// the server pushes events over SSE.
+package dashboard
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/messaging"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+)
+
+//go:embed index.html
+var indexHTML []byte
+
+// recentMessagesWindow is how far back /api/messages looks.
+const recentMessagesWindow = 24 * time.Hour
+
+// maxRecentMessages caps the messages /api/messages returns.
+const maxRecentMessages = 50
+
+// gitCacheTTL is how long a session's git summary is reused, so clients
+// refreshing on every event do not run git for every session each time.
+const gitCacheTTL = 15 * time.Second
+
+// Sources are the reads the dashboard makes on each request.
+type Sources struct {
+	Sessions func(ctx context.Context) ([]session.Session, error)
+	// Statuses returns the agent statuses from the last TUI poll, or nil
+	// when no TUI is polling.
+	Statuses func(ctx context.Context) (*terminal.StatusSnapshot, error)
+	// Git returns the version control backend for a session. Nil disables
+	// git summaries.
+	Git func(sess *session.Session) git.VCS
+	// Messages returns messages on all topics published after since.
+	Messages func(ctx context.Context, since time.Time) ([]messaging.Message, error)
+}
+
+// SessionView is a session as listed by /api/sessions.
+type SessionView struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Repo      string      `json:"repo"`
+	State     string      `json:"state"`
+	Group     string      `json:"group,omitempty"`
+	Tags      []string    `json:"tags,omitempty"`
+	Status    string      `json:"status,omitempty"` // agent status from the last TUI poll
+	Git       *GitSummary `json:"git,omitempty"`    // active sessions only
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// GitSummary is the working tree state of a session.
+type GitSummary struct {
+	Branch    string `json:"branch"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Dirty     bool   `json:"dirty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Server serves the dashboard. Create it with New; Notify pushes an event to
+// every connected page.
+type Server struct {
+	src    Sources
+	events *broker
+	now    func() time.Time
+
+	gitMu    sync.Mutex
+	gitCache map[string]gitEntry
+}
+
+type gitEntry struct {
+	summary GitSummary
+	at      time.Time
+}
+
+// New creates a dashboard reading from src.
+func New(src Sources) *Server {
+	return &Server{
+		src:      src,
+		events:   newBroker(),
+		now:      time.Now,
+		gitCache: make(map[string]gitEntry),
+	}
+}
+
+// Notify tells connected pages that event happened so they reload their
+// data. It never blocks; pages that fall behind miss events.
+func (s *Server) Notify(event string) {
+	s.events.publish(event)
+}
+
+// Handler returns the dashboard's routes.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleIndex)
+	mux.HandleFunc("GET /api/sessions", s.handleSessions)
+	mux.HandleFunc("GET /api/messages", s.handleMessages)
+	mux.HandleFunc("GET /api/events", s.handleEvents)
+	return mux
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(indexHTML)
+}
+
+func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
+	views, err := s.sessions(r.Context(), r.URL.Query().Get("state"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, views)
+}
+
+func (s *Server) handleMessages(w http.ResponseWriter, r *http.Request) {
+	msgs, err := s.src.Messages(r.Context(), s.now().Add(-recentMessagesWindow))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	slices.SortFunc(msgs, func(a, b messaging.Message) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	if len(msgs) > maxRecentMessages {
+		msgs = msgs[:maxRecentMessages]
+	}
+	if msgs == nil {
+		msgs = []messaging.Message{}
+	}
+	writeJSON(w, msgs)
+}
+
+// sessions lists sessions, optionally only those in state, with their agent
+// status and, for active sessions, a git summary.
+func (s *Server) sessions(ctx context.Context, state string) ([]SessionView, error) {
+	sessions, err := s.src.Sessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses map[string]terminal.Status
+	if snap, err := s.src.Statuses(ctx); err == nil && snap != nil {
+		statuses = snap.Statuses
+	}
+
+	views := make([]SessionView, 0, len(sessions))
+	for i := range sessions {
+		sess := &sessions[i]
+		if state != "" && string(sess.State) != state {
+			continue
+		}
+
+		view := SessionView{
+			ID:        sess.ID,
+			Name:      sess.Name,
+			Repo:      git.ExtractRepoName(sess.Remote),
+			State:     string(sess.State),
+			Group:     sess.GetMeta(session.MetaGroup),
+			Tags:      sess.Tags,
+			Status:    string(statuses[sess.ID]),
+			UpdatedAt: sess.UpdatedAt,
+		}
+		if sess.State == session.StateActive && s.src.Git != nil {
+			summary := s.gitSummary(ctx, sess)
+			view.Git = &summary
+		}
+		views = append(views, view)
+	}
+
+	slices.SortFunc(views, func(a, b SessionView) int {
+		return b.UpdatedAt.Compare(a.UpdatedAt)
+	})
+	return views, nil
+}
+
+// gitSummary returns the session's cached git summary, refreshing it when
+// older than gitCacheTTL.
+func (s *Server) gitSummary(ctx context.Context, sess *session.Session) GitSummary {
+	s.gitMu.Lock()
+	entry, ok := s.gitCache[sess.ID]
+	s.gitMu.Unlock()
+	if ok && s.now().Sub(entry.at) < gitCacheTTL {
+		return entry.summary
+	}
+
+	vcs := s.src.Git(sess)
+	var summary GitSummary
+	branch, err := vcs.Branch(ctx, sess.Path)
+	if err == nil {
+		summary.Branch = branch
+		summary.Additions, summary.Deletions, err = vcs.DiffStats(ctx, sess.Path)
+	}
+	if err == nil {
+		var clean bool
+		clean, err = vcs.IsClean(ctx, sess.Path)
+		summary.Dirty = !clean
+	}
+	if err != nil {
+		log.Debug().Err(err).Str("session", sess.ID).Msg("dashboard: git summary")
+		summary.Error = err.Error()
+	}
+
+	s.gitMu.Lock()
+	s.gitCache[sess.ID] = gitEntry{summary: summary, at: s.now()}
+	s.gitMu.Unlock()
+	return summary
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Debug().Err(err).Msg("dashboard: write response")
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	log.Warn().Err(err).Msg("dashboard: request failed")
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
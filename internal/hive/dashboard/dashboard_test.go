@@ -0,0 +1,150 @@
+package dashboard
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/messaging"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+)
+
+// fakeVCS answers the git summary reads; other methods panic.
+type fakeVCS struct {
+	git.VCS
+	calls int
+}
+
+func (f *fakeVCS) Branch(context.Context, string) (string, error) {
+	f.calls++
+	return "feat/x", nil
+}
+
+func (f *fakeVCS) DiffStats(context.Context, string) (int, int, error) { return 12, 3, nil }
+func (f *fakeVCS) IsClean(context.Context, string) (bool, error)       { return false, nil }
+
+func testSources(vcs *fakeVCS, msgs []messaging.Message) Sources {
+	now := time.Now()
+	return Sources{
+		Sessions: func(context.Context) ([]session.Session, error) {
+			return []session.Session{
+				{ID: "a", Name: "api", Remote: "git@github.com:org/api.git", State: session.StateActive, UpdatedAt: now.Add(-time.Hour)},
+				{ID: "b", Name: "web", Remote: "git@github.com:org/web.git", State: session.StateActive, UpdatedAt: now},
+				{ID: "c", Name: "old", State: session.StateRecycled, UpdatedAt: now},
+			}, nil
+		},
+		Statuses: func(context.Context) (*terminal.StatusSnapshot, error) {
+			return &terminal.StatusSnapshot{Statuses: map[string]terminal.Status{"a": terminal.StatusApproval}}, nil
+		},
+		Git: func(*session.Session) git.VCS { return vcs },
+		Messages: func(context.Context, time.Time) ([]messaging.Message, error) {
+			return msgs, nil
+		},
+	}
+}
+
+func getJSON(t *testing.T, h http.Handler, path string, v any) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), v))
+}
+
+func TestSessions(t *testing.T) {
+	vcs := &fakeVCS{}
+	h := New(testSources(vcs, nil)).Handler()
+
+	var views []SessionView
+	getJSON(t, h, "/api/sessions?state=active", &views)
+	require.Len(t, views, 2)
+
+	// Most recently updated first.
+	assert.Equal(t, "b", views[0].ID)
+	assert.Equal(t, "a", views[1].ID)
+	assert.Equal(t, "api", views[1].Repo)
+	assert.Equal(t, "approval", views[1].Status)
+	assert.Empty(t, views[0].Status)
+	require.NotNil(t, views[1].Git)
+	assert.Equal(t, GitSummary{Branch: "feat/x", Additions: 12, Deletions: 3, Dirty: true}, *views[1].Git)
+
+	var all []SessionView
+	getJSON(t, h, "/api/sessions", &all)
+	require.Len(t, all, 3)
+	for _, v := range all {
+		if v.ID == "c" {
+			assert.Nil(t, v.Git, "only active sessions get git summaries")
+		}
+	}
+
+	// Summaries are cached between requests.
+	assert.Equal(t, 2, vcs.calls)
+}
+
+func TestMessages(t *testing.T) {
+	base := time.Now().Add(-time.Hour)
+	var msgs []messaging.Message
+	for i := range maxRecentMessages + 5 {
+		msgs = append(msgs, messaging.Message{ID: fmt.Sprint(i), Topic: "t", CreatedAt: base.Add(time.Duration(i) * time.Second)})
+	}
+	h := New(testSources(&fakeVCS{}, msgs)).Handler()
+
+	var got []messaging.Message
+	getJSON(t, h, "/api/messages", &got)
+	require.Len(t, got, maxRecentMessages)
+	assert.Equal(t, fmt.Sprint(maxRecentMessages+4), got[0].ID, "newest first")
+
+	h = New(testSources(&fakeVCS{}, nil)).Handler()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/messages", nil))
+	assert.Equal(t, "[]\n", rec.Body.String())
+}
+
+func TestIndex(t *testing.T) {
+	h := New(testSources(&fakeVCS{}, nil)).Handler()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, rec.Body.String(), "api/events")
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/nope", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestEvents(t *testing.T) {
+	dash := New(testSources(&fakeVCS{}, nil))
+	srv := httptest.NewServer(dash.Handler())
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/events", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// The subscription is registered before the headers are flushed.
+	dash.Notify("session.created")
+
+	scanner := bufio.NewScanner(resp.Body)
+	require.True(t, scanner.Scan())
+	assert.Equal(t, "event: session.created", scanner.Text())
+	require.True(t, scanner.Scan())
+	assert.True(t, strings.HasPrefix(scanner.Text(), "data: "))
+}
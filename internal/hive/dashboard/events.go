@@ -0,0 +1,96 @@
+package dashboard
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// refreshInterval is how often connected pages are told to reload even
+// without events. The event bus is per process, so changes made by the TUI
+// or other hive commands only show up on these refreshes.
+const refreshInterval = 15 * time.Second
+
+// subscriberBuffer is the number of events queued per connected page.
+const subscriberBuffer = 16
+
+// broker fans events out to connected pages.
+type broker struct {
+	mu   sync.Mutex
+	subs map[chan string]struct{}
+}
+
+func newBroker() *broker {
+	return &broker{subs: make(map[chan string]struct{})}
+}
+
+func (b *broker) subscribe() chan string {
+	ch := make(chan string, subscriberBuffer)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+func (b *broker) unsubscribe(ch chan string) {
+	b.mu.Lock()
+	delete(b.subs, ch)
+	b.mu.Unlock()
+}
+
+func (b *broker) publish(event string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// handleEvents streams server-sent events until the client disconnects:
+// each bus event by name, and a "refresh" event every refreshInterval.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := s.events.subscribe()
+	defer s.events.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	send := func(event string) bool {
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: {}\n\n", event); err != nil {
+			return false
+		}
+		flusher.Flush()
+		return true
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event := <-ch:
+			if !send(event) {
+				return
+			}
+		case <-ticker.C:
+			if !send("refresh") {
+				return
+			}
+		}
+	}
+}
@@ -2,10 +2,19 @@ package hive
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
 
 	"github.com/colonyops/hive/internal/core/config"
 	"github.com/colonyops/hive/internal/core/doctor"
+	"github.com/colonyops/hive/internal/core/kv"
 	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/hive/scripts"
+	"github.com/colonyops/hive/internal/hive/supervisor"
+	"github.com/colonyops/hive/pkg/executil"
 )
 
 // DoctorService runs health checks on the hive setup.
@@ -13,6 +22,21 @@ type DoctorService struct {
 	store       session.Store
 	config      *config.Config
 	pluginInfos []doctor.PluginInfo
+
+	workers     *supervisor.Supervisor
+	workerStore kv.KV
+
+	database doctor.IntegrityChecker
+	cleaner  doctor.LeftoverCleaner
+
+	scriptsDir string // data directory the bundled scripts are extracted to
+	version    string // hive version the scripts are extracted for
+
+	exec   executil.Executor // runs tmux, on the --host host when remote
+	remote bool              // session directories live on another host
+	tools  executil.Executor // runs local tool version commands
+
+	configErr error // from the last ReloadConfig
 }
 
 // NewDoctorService creates a new DoctorService.
@@ -24,14 +48,138 @@ func NewDoctorService(store session.Store, cfg *config.Config, pluginInfos []doc
 	}
 }
 
-// RunChecks executes all doctor checks and returns results.
+// SetWorkers reports the health of the background workers supervised by
+// sup, and of those published to store by other hive processes.
+func (d *DoctorService) SetWorkers(sup *supervisor.Supervisor, store kv.KV) {
+	d.workers = sup
+	d.workerStore = store
+}
+
+// SetDatabase checks the integrity of database.
+func (d *DoctorService) SetDatabase(database doctor.IntegrityChecker) {
+	d.database = database
+}
+
+// SetCleaner checks for resources that deleted sessions left behind,
+// removing them when fixing.
+func (d *DoctorService) SetCleaner(cleaner doctor.LeftoverCleaner) {
+	d.cleaner = cleaner
+}
+
+// SetScripts checks the bundled scripts extracted to dataDir for version,
+// re-extracting them when fixing.
+func (d *DoctorService) SetScripts(dataDir, version string) {
+	d.scriptsDir = dataDir
+	d.version = version
+}
+
+// SetHost checks the tmux sessions exec lists. When remote is set, session
+// directories live on another host, so stale session records are not
+// checked.
+func (d *DoctorService) SetHost(exec executil.Executor, remote bool) {
+	d.exec = exec
+	d.remote = remote
+}
+
+// SetTools checks the versions of the local tools hive runs with exec.
+func (d *DoctorService) SetTools(exec executil.Executor) {
+	d.tools = exec
+}
+
+// ReloadConfig re-reads the configuration file so later checks see its
+// current contents. If it cannot be loaded, checks keep using the previous
+// configuration and report the error.
+func (d *DoctorService) ReloadConfig(configPath string) error {
+	cfg, err := config.Load(configPath, d.config.DataDir)
+	if err != nil {
+		d.configErr = err
+		return err
+	}
+	d.config = cfg
+	d.configErr = nil
+	return nil
+}
+
+// RunChecks executes all doctor checks and returns results. With autofix,
+// checks repair the problems they can.
 func (d *DoctorService) RunChecks(ctx context.Context, configPath string, autofix bool) []doctor.Result {
 	checks := []doctor.Check{
-		doctor.NewToolsCheck(),
+		doctor.NewToolsCheck(d.tools).WithClipboard(d.config.CopyCommand),
 		doctor.NewPluginCheck(d.pluginInfos),
-		doctor.NewConfigCheck(d.config, configPath),
-		doctor.NewRepoDirsCheck(d.config.Workspaces),
-		doctor.NewOrphanCheck(d.store, d.config.ReposDir(), autofix),
+		doctor.NewConfigCheck(d.config, configPath).WithLoadError(d.configErr),
+		doctor.NewDataDirsCheck([]string{d.config.DataDir, d.config.ReposDir()}, autofix),
+	}
+	if d.scriptsDir != "" {
+		checks = append(checks, doctor.NewScriptsCheck(scripts.ScriptPaths(d.scriptsDir), func() error {
+			return scripts.Extract(d.scriptsDir, d.version)
+		}, autofix))
 	}
+	if d.database != nil {
+		checks = append(checks, doctor.NewDatabaseCheck(d.database))
+	}
+	checks = append(checks, doctor.NewRepoDirsCheck(d.config.Workspaces))
+	checks = append(checks, d.reconcileChecks(autofix)...)
+	checks = append(checks,
+		doctor.NewOverdueCheck(d.store, d.config.Due.ArchiveAfter),
+		doctor.NewWorkerCheck(d.workerInfos(ctx)),
+	)
 	return doctor.RunAll(ctx, checks)
 }
+
+// Reconcile cross-references session records, session directories, and
+// live tmux sessions, reporting directories without records, records
+// without directories, tmux sessions without an active session, and
+// resources deleted sessions left behind. With fix, each is cleaned up.
+func (d *DoctorService) Reconcile(ctx context.Context, fix bool) []doctor.Result {
+	return doctor.RunAll(ctx, d.reconcileChecks(fix))
+}
+
+// reconcileChecks returns the checks that reconcile session records with
+// the directories and tmux sessions on disk.
+func (d *DoctorService) reconcileChecks(fix bool) []doctor.Check {
+	checks := []doctor.Check{
+		doctor.NewOrphanCheck(d.store, d.config.ReposDir(), fix),
+	}
+	if !d.remote {
+		checks = append(checks, doctor.NewStaleSessionCheck(d.store, fix))
+	}
+	if d.exec != nil {
+		checks = append(checks, doctor.NewTmuxSessionsCheck(d.exec, d.store, d.config.ReposDir(), fix))
+	}
+	if d.cleaner != nil {
+		checks = append(checks, doctor.NewCleanupCheck(d.cleaner, fix))
+	}
+	return checks
+}
+
+// workerInfos collects worker health from this process and from the
+// snapshots other hive processes published.
+func (d *DoctorService) workerInfos(ctx context.Context) []doctor.WorkerInfo {
+	now := time.Now()
+	var infos []doctor.WorkerInfo
+	add := func(pid int, workers []supervisor.Health) {
+		for _, h := range workers {
+			infos = append(infos, doctor.WorkerInfo{
+				Process:   fmt.Sprintf("pid %d", pid),
+				Name:      h.Name,
+				State:     string(h.State),
+				Stale:     h.Stale(now),
+				Restarts:  h.Restarts,
+				LastBeat:  h.LastBeat,
+				LastError: h.LastError,
+			})
+		}
+	}
+
+	add(os.Getpid(), d.workers.Health())
+	if d.workerStore != nil {
+		snapshots, err := supervisor.LoadSnapshots(ctx, d.workerStore)
+		if err != nil {
+			log.Debug().Err(err).Msg("failed to load worker health snapshots")
+		}
+		for _, snapshot := range snapshots {
+			add(snapshot.PID, snapshot.Workers)
+		}
+	}
+	return infos
+}
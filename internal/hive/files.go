@@ -0,0 +1,119 @@
+package hive
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/pkg/executil"
+)
+
+// sessionFiles performs the filesystem operations SessionService makes on
+// session and clone directories. They run locally, or through the executor
+// when the directories live on another host.
+type sessionFiles interface {
+	Exists(ctx context.Context, path string) (bool, error)
+	MkdirAll(ctx context.Context, path string) error
+	RemoveAll(ctx context.Context, path string) error
+	Remove(ctx context.Context, path string) error
+	WriteFile(ctx context.Context, path string, data []byte) error
+	// AddLocalExclude lists pattern in the info/exclude file of the
+	// repository checked out at dir.
+	AddLocalExclude(ctx context.Context, dir, pattern string) error
+}
+
+// localFiles operates on the local filesystem.
+type localFiles struct{}
+
+func (localFiles) Exists(_ context.Context, path string) (bool, error) {
+	_, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (localFiles) MkdirAll(_ context.Context, path string) error {
+	return os.MkdirAll(path, 0o755)
+}
+
+func (localFiles) RemoveAll(_ context.Context, path string) error {
+	return os.RemoveAll(path)
+}
+
+func (localFiles) Remove(_ context.Context, path string) error {
+	err := os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (localFiles) WriteFile(_ context.Context, path string, data []byte) error {
+	return os.WriteFile(path, data, 0o644)
+}
+
+func (localFiles) AddLocalExclude(_ context.Context, dir, pattern string) error {
+	return git.AddLocalExclude(dir, pattern)
+}
+
+// execFiles runs shell commands through an executor, such as an
+// executil.SSHExecutor for sessions on another host. Paths and data are
+// passed as positional parameters, never interpolated into the script.
+type execFiles struct {
+	exec executil.Executor
+}
+
+func (f execFiles) sh(ctx context.Context, script string, args ...string) (string, error) {
+	out, err := f.exec.Run(ctx, "sh", append([]string{"-c", script, "sh"}, args...)...)
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return "", fmt.Errorf("%s: %w", msg, err)
+		}
+		return "", err
+	}
+	return string(out), nil
+}
+
+func (f execFiles) Exists(ctx context.Context, path string) (bool, error) {
+	out, err := f.sh(ctx, `if [ -e "$1" ]; then echo yes; fi`, path)
+	return strings.TrimSpace(out) == "yes", err
+}
+
+func (f execFiles) MkdirAll(ctx context.Context, path string) error {
+	_, err := f.sh(ctx, `mkdir -p "$1"`, path)
+	return err
+}
+
+func (f execFiles) RemoveAll(ctx context.Context, path string) error {
+	_, err := f.sh(ctx, `rm -rf "$1"`, path)
+	return err
+}
+
+func (f execFiles) Remove(ctx context.Context, path string) error {
+	_, err := f.sh(ctx, `rm -f "$1"`, path)
+	return err
+}
+
+func (f execFiles) WriteFile(ctx context.Context, path string, data []byte) error {
+	_, err := f.sh(ctx, `printf '%s' "$2" > "$1"`, path, string(data))
+	return err
+}
+
+func (f execFiles) AddLocalExclude(ctx context.Context, dir, pattern string) error {
+	// git rev-parse resolves the exclude file shared by all worktrees.
+	_, err := f.sh(ctx, `cd "$1" && e=$(git rev-parse --path-format=absolute --git-common-dir)/info/exclude &&
+mkdir -p "$(dirname "$e")" && { grep -qxF "$2" "$e" 2>/dev/null || printf '%s\n' "$2" >> "$e"; }`, dir, pattern)
+	return err
+}
+
+// SetRemoteHost makes the service manage sessions whose directories live on
+// the named host: file operations run through the service's executor, which
+// must run commands on that host, and copy rules are skipped since their
+// source files are local.
+func (s *SessionService) SetRemoteHost(name string) {
+	s.host = name
+	s.files = execFiles{exec: s.executor}
+}
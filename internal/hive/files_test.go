@@ -0,0 +1,71 @@
+package hive
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/pkg/executil"
+)
+
+// TestExecFiles runs the shell scripts locally, as the host would run them.
+func TestExecFiles(t *testing.T) {
+	ctx := context.Background()
+	files := execFiles{exec: &executil.RealExecutor{}}
+	dir := filepath.Join(t.TempDir(), "it's a dir")
+
+	ok, err := files.Exists(ctx, dir)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, files.MkdirAll(ctx, filepath.Join(dir, "a", "b")))
+	ok, err = files.Exists(ctx, dir)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	path := filepath.Join(dir, "a", "file")
+	data := "line one\n%s $HOME `x`\n"
+	require.NoError(t, files.WriteFile(ctx, path, []byte(data)))
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, data, string(got))
+
+	require.NoError(t, files.Remove(ctx, path))
+	require.NoError(t, files.Remove(ctx, path), "removing a missing file is not an error")
+	require.NoError(t, files.RemoveAll(ctx, dir))
+	_, err = os.Stat(dir)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestExecFiles_AddLocalExclude(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+	require.NoError(t, exec.Command("git", "init", "-q", dir).Run())
+
+	files := execFiles{exec: &executil.RealExecutor{}}
+	require.NoError(t, files.AddLocalExclude(ctx, dir, ".hive-session"))
+	require.NoError(t, files.AddLocalExclude(ctx, dir, ".hive-session"))
+
+	got, err := os.ReadFile(filepath.Join(dir, ".git", "info", "exclude"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, countLines(string(got), ".hive-session"))
+}
+
+func countLines(s, line string) int {
+	n := 0
+	for _, l := range strings.Split(s, "\n") {
+		if l == line {
+			n++
+		}
+	}
+	return n
+}
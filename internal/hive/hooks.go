@@ -33,6 +33,14 @@ func NewHookRunner(log zerolog.Logger, executor executil.Executor, renderer *tmp
 	}
 }
 
+// withOutput returns a copy of h that writes command output to stdout and
+// stderr.
+func (h *HookRunner) withOutput(stdout, stderr io.Writer) *HookRunner {
+	cp := *h
+	cp.stdout, cp.stderr = stdout, stderr
+	return &cp
+}
+
 // RunHooks executes the commands from a matched rule, rendering each as a Go template.
 func (h *HookRunner) RunHooks(ctx context.Context, rule config.Rule, path string, data config.SpawnTemplateData) error {
 	h.log.Debug().
@@ -40,6 +48,11 @@ func (h *HookRunner) RunHooks(ctx context.Context, rule config.Rule, path string
 		Strs("commands", rule.Commands).
 		Msg("running rule commands")
 
+	// Rendered commands are shell scripts, which the executor does not
+	// translate, so render them with the paths it sees.
+	data.Path = executil.TranslatePath(h.executor, data.Path)
+	data.ContextDir = executil.TranslatePath(h.executor, data.ContextDir)
+
 	for i, cmdTmpl := range rule.Commands {
 		select {
 		case <-ctx.Done():
@@ -54,7 +67,8 @@ func (h *HookRunner) RunHooks(ctx context.Context, rule config.Rule, path string
 
 		h.printCommandHeader(i+1, len(rule.Commands), cmd)
 
-		if err := h.executor.RunDirStream(ctx, path, h.stdout, h.stderr, "sh", "-c", cmd); err != nil {
+		shell, args := executil.Shell(cmd)
+		if err := h.executor.RunDirStream(ctx, path, h.stdout, h.stderr, shell, args...); err != nil {
 			return fmt.Errorf("run command %q: %w", cmd, err)
 		}
 
@@ -0,0 +1,112 @@
+// Package leader elects one hive process to run background maintenance.
+// The TUI, hive serve, and CLI commands all open the same database, and
+// without an election each would run the same sweeps against it. The elected
+// process holds a lease that it renews on a heartbeat. It releases the lease
+// when it exits, or the lease expires if it dies, and another process takes
+// over.
+package leader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// releaseTimeout bounds the release of a lease when its holder exits.
+const releaseTimeout = 2 * time.Second
+
+// Leases stores the leases processes compete for.
+type Leases interface {
+	// Acquire takes or renews the lease name for holder until ttl from now,
+	// and reports false when another holder has it.
+	Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+	// Release gives up holder's lease on name.
+	Release(ctx context.Context, name, holder string) error
+}
+
+// Holder returns the name this process holds leases under, host:pid.
+func Holder() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "localhost"
+	}
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}
+
+// Run calls fn while this process holds the lease name and blocks until ctx
+// is cancelled. fn should run until its context is cancelled.
+//
+// The lease lasts ttl and is renewed every ttl/3. The first attempt to take
+// it is made after one renewal interval, so short lived commands never lead.
+// When the lease is taken over, or cannot be renewed before it expires, fn's
+// context is cancelled and Run waits for fn to return before competing for
+// the lease again. When ctx is cancelled, fn is stopped and the lease is
+// released so another process can take over at its next attempt.
+func Run(ctx context.Context, leases Leases, name, holder string, ttl time.Duration, fn func(ctx context.Context)) {
+	if ttl <= 0 {
+		return
+	}
+	interval := ttl / 3
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var (
+		cancel  context.CancelFunc
+		done    chan struct{}
+		renewed time.Time
+	)
+	stop := func() {
+		cancel()
+		<-done
+		cancel, done = nil, nil
+	}
+	defer func() {
+		if cancel == nil {
+			return
+		}
+		stop()
+		releaseCtx, cancelRelease := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+		defer cancelRelease()
+		if err := leases.Release(releaseCtx, name, holder); err != nil {
+			log.Debug().Err(err).Str("lease", name).Msg("failed to release lease")
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			ok, err := leases.Acquire(ctx, name, holder, ttl)
+			switch {
+			case err != nil:
+				if ctx.Err() != nil {
+					return
+				}
+				log.Debug().Err(err).Str("lease", name).Msg("failed to acquire lease")
+				if cancel != nil && now.Sub(renewed) >= ttl-interval {
+					log.Warn().Str("lease", name).Msg("could not renew lease, stopping workers")
+					stop()
+				}
+			case ok:
+				renewed = now
+				if cancel == nil {
+					log.Debug().Str("lease", name).Str("holder", holder).Msg("acquired lease, starting workers")
+					leadCtx, leadCancel := context.WithCancel(ctx)
+					leadDone := make(chan struct{})
+					go func() {
+						defer close(leadDone)
+						fn(leadCtx)
+					}()
+					cancel, done = leadCancel, leadDone
+				}
+			case cancel != nil:
+				log.Warn().Str("lease", name).Msg("lease taken over by another process, stopping workers")
+				stop()
+			}
+		}
+	}
+}
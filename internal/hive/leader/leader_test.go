@@ -0,0 +1,115 @@
+package leader
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memLeases is an in-memory Leases whose leases never expire.
+type memLeases struct {
+	mu      sync.Mutex
+	holders map[string]string
+}
+
+func newMemLeases() *memLeases {
+	return &memLeases{holders: make(map[string]string)}
+}
+
+func (m *memLeases) Acquire(_ context.Context, name, holder string, _ time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if h, ok := m.holders[name]; ok && h != holder {
+		return false, nil
+	}
+	m.holders[name] = holder
+	return true, nil
+}
+
+func (m *memLeases) Release(_ context.Context, name, holder string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.holders[name] == holder {
+		delete(m.holders, name)
+	}
+	return nil
+}
+
+func (m *memLeases) holder(name string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.holders[name]
+}
+
+func (m *memLeases) steal(name, holder string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.holders[name] = holder
+}
+
+const testTTL = 30 * time.Millisecond
+
+// runLeader starts Run for holder and returns a counter of running workers
+// and a function that stops it and waits for Run to return.
+func runLeader(t *testing.T, leases Leases, holder string) (*atomic.Int32, func()) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	var running atomic.Int32
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Run(ctx, leases, "maintenance", holder, testTTL, func(ctx context.Context) {
+			running.Add(1)
+			<-ctx.Done()
+			running.Add(-1)
+		})
+	}()
+	stop := func() {
+		cancel()
+		<-done
+	}
+	t.Cleanup(stop)
+	return &running, stop
+}
+
+func TestRun_OneLeaderAndFailover(t *testing.T) {
+	leases := newMemLeases()
+	a, stopA := runLeader(t, leases, "a")
+
+	require.Eventually(t, func() bool { return a.Load() == 1 }, time.Second, 5*time.Millisecond)
+	b, _ := runLeader(t, leases, "b")
+
+	time.Sleep(3 * testTTL)
+	assert.Equal(t, int32(0), b.Load(), "only the lease holder runs workers")
+
+	stopA()
+	assert.Equal(t, int32(0), a.Load(), "workers stop before Run returns")
+	require.Eventually(t, func() bool { return b.Load() == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "b", leases.holder("maintenance"))
+}
+
+func TestRun_StopsWhenLeaseTakenOver(t *testing.T) {
+	leases := newMemLeases()
+	a, _ := runLeader(t, leases, "a")
+	require.Eventually(t, func() bool { return a.Load() == 1 }, time.Second, 5*time.Millisecond)
+
+	leases.steal("maintenance", "b")
+	require.Eventually(t, func() bool { return a.Load() == 0 }, time.Second, 5*time.Millisecond)
+
+	leases.steal("maintenance", "a")
+	require.Eventually(t, func() bool { return a.Load() == 1 }, time.Second, 5*time.Millisecond)
+}
+
+func TestRun_ShortLivedNeverLeads(t *testing.T) {
+	leases := newMemLeases()
+	a, stop := runLeader(t, leases, "a")
+	stop()
+
+	assert.Equal(t, int32(0), a.Load())
+	assert.Empty(t, leases.holder("maintenance"))
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/colonyops/hive/internal/core/config"
 	"github.com/colonyops/hive/internal/core/eventbus"
 	"github.com/colonyops/hive/internal/core/messaging"
+	"github.com/colonyops/hive/internal/core/session"
 	"github.com/colonyops/hive/pkg/randid"
+	"github.com/rs/zerolog/log"
 )
 
 // MessageService wraps messaging.Store with domain logic.
@@ -16,6 +18,10 @@ type MessageService struct {
 	store  messaging.Store
 	config *config.Config
 	bus    *eventbus.EventBus
+
+	// recordCapabilities stores announcements published to agent meta
+	// topics; nil ignores them.
+	recordCapabilities func(ctx context.Context, sessionID string, caps session.Capabilities) error
 }
 
 // NewMessageService creates a new MessageService.
@@ -28,14 +34,27 @@ func NewMessageService(store messaging.Store, cfg *config.Config, bus *eventbus.
 }
 
 // Publish adds a message to multiple topics.
-// Returns the resolved topics after wildcard expansion.
+// Returns the resolved topics after wildcard expansion, or
+// messaging.ErrInvalidEnvelope if the message's envelope is malformed.
 func (m *MessageService) Publish(ctx context.Context, msg messaging.Message, topics []string) (messaging.PublishResult, error) {
+	if err := msg.Validate(); err != nil {
+		return messaging.PublishResult{}, err
+	}
+	for _, topic := range topics {
+		if _, ok := session.SessionIDFromMetaTopic(topic); ok {
+			if _, err := session.ParseCapabilities(msg.Payload); err != nil {
+				return messaging.PublishResult{}, fmt.Errorf("%s: %w", topic, err)
+			}
+		}
+	}
+
 	result, err := m.store.Publish(ctx, msg, topics)
 	if err != nil {
 		return messaging.PublishResult{}, err
 	}
 
 	for _, topic := range result.Topics {
+		m.recordAnnouncement(ctx, topic, msg)
 		m.bus.PublishMessageReceived(eventbus.MessageReceivedPayload{
 			Topic:   topic,
 			Message: &msg,
@@ -45,11 +64,70 @@ func (m *MessageService) Publish(ctx context.Context, msg messaging.Message, top
 	return result, nil
 }
 
+// recordAnnouncement stores the capabilities in msg if topic is an agent
+// meta topic. Failures are logged: the message itself was published.
+func (m *MessageService) recordAnnouncement(ctx context.Context, topic string, msg messaging.Message) {
+	sessionID, ok := session.SessionIDFromMetaTopic(topic)
+	if !ok || m.recordCapabilities == nil {
+		return
+	}
+	caps, err := session.ParseCapabilities(msg.Payload)
+	if err != nil {
+		log.Warn().Err(err).Str("topic", topic).Msg("ignoring invalid capability announcement")
+		return
+	}
+	caps.AnnouncedAt = time.Now()
+	if err := m.recordCapabilities(ctx, sessionID, caps); err != nil {
+		log.Warn().Err(err).Str("topic", topic).Msg("failed to record agent capabilities")
+	}
+}
+
+// replyTopicPrefix namespaces the topics requests await their replies on.
+const replyTopicPrefix = "reply"
+
+// Request publishes msg to topics with a new, empty reply topic as its
+// ReplyTo, and returns that topic. Responders answer with Reply.
+func (m *MessageService) Request(ctx context.Context, msg messaging.Message, topics []string) (messaging.PublishResult, string, error) {
+	msg.ReplyTo = replyTopicPrefix + "." + randid.Generate(12)
+	result, err := m.Publish(ctx, msg, topics)
+	if err != nil {
+		return messaging.PublishResult{}, "", err
+	}
+	return result, msg.ReplyTo, nil
+}
+
+// Reply publishes msg to the reply topic of the request with the given ID,
+// correlated with the request unless msg carries its own correlation ID.
+// Returns messaging.ErrNoReplyTopic if the request did not ask for a reply.
+func (m *MessageService) Reply(ctx context.Context, requestID string, msg messaging.Message) (messaging.PublishResult, error) {
+	req, err := m.store.Get(ctx, requestID)
+	if err != nil {
+		return messaging.PublishResult{}, err
+	}
+	if req.ReplyTo == "" {
+		return messaging.PublishResult{}, fmt.Errorf("%w: %s", messaging.ErrNoReplyTopic, requestID)
+	}
+	if msg.CorrelationID == "" {
+		msg.CorrelationID = requestID
+	}
+	return m.Publish(ctx, msg, []string{req.ReplyTo})
+}
+
 // Subscribe returns all messages for a topic, optionally filtered by since timestamp.
 func (m *MessageService) Subscribe(ctx context.Context, topic string, since time.Time) ([]messaging.Message, error) {
 	return m.store.Subscribe(ctx, topic, since)
 }
 
+// SubscribeAfter returns messages whose per-topic sequence number is past the cursor.
+func (m *MessageService) SubscribeAfter(ctx context.Context, topic string, cursor messaging.Cursor) ([]messaging.Message, error) {
+	return m.store.SubscribeAfter(ctx, topic, cursor)
+}
+
+// Head returns a cursor positioned at the latest message of each matching topic.
+func (m *MessageService) Head(ctx context.Context, topic string) (messaging.Cursor, error) {
+	return m.store.Head(ctx, topic)
+}
+
 // GetUnread returns messages not yet acknowledged by consumer.
 func (m *MessageService) GetUnread(ctx context.Context, consumerID string, topic string) ([]messaging.Message, error) {
 	return m.store.GetUnread(ctx, consumerID, topic)
@@ -60,6 +138,28 @@ func (m *MessageService) Acknowledge(ctx context.Context, consumerID string, mes
 	return m.store.Acknowledge(ctx, consumerID, messageIDs)
 }
 
+// ConsumerCursor returns a named consumer's read position in the topics
+// matching the pattern.
+func (m *MessageService) ConsumerCursor(ctx context.Context, consumer string, topic string) (messaging.Cursor, error) {
+	return m.store.ConsumerCursor(ctx, consumer, topic)
+}
+
+// GetUnacked returns the messages of topics matching the pattern past a named
+// consumer's cursor.
+func (m *MessageService) GetUnacked(ctx context.Context, consumer string, topic string) ([]messaging.Message, error) {
+	cursor, err := m.store.ConsumerCursor(ctx, consumer, topic)
+	if err != nil {
+		return nil, err
+	}
+	return m.store.SubscribeAfter(ctx, topic, cursor)
+}
+
+// AckThrough acknowledges messages, and every earlier message of their
+// topics, for a named consumer.
+func (m *MessageService) AckThrough(ctx context.Context, consumer string, messageIDs []string) error {
+	return m.store.AckThrough(ctx, consumer, messageIDs)
+}
+
 // ListTopics returns all topic names.
 func (m *MessageService) ListTopics(ctx context.Context) ([]string, error) {
 	return m.store.List(ctx)
@@ -70,6 +170,17 @@ func (m *MessageService) Prune(ctx context.Context, olderThan time.Duration) (in
 	return m.store.Prune(ctx, olderThan)
 }
 
+// ApplyRetention deletes messages past the configured retention from topics
+// matching the pattern, plus any older than maxAge when it is positive.
+func (m *MessageService) ApplyRetention(ctx context.Context, topic string, maxAge time.Duration) (int, error) {
+	return m.store.ApplyRetention(ctx, topic, maxAge)
+}
+
+// DeleteTopic removes every message of a topic.
+func (m *MessageService) DeleteTopic(ctx context.Context, topic string) (int, error) {
+	return m.store.DeleteTopic(ctx, topic)
+}
+
 // GenerateTopic creates a new topic name using the configured prefix and a random suffix.
 func (m *MessageService) GenerateTopic(prefix string) string {
 	if prefix == "" {
@@ -9,6 +9,7 @@ import (
 	"github.com/colonyops/hive/internal/core/eventbus"
 	"github.com/colonyops/hive/internal/core/eventbus/testbus"
 	"github.com/colonyops/hive/internal/core/messaging"
+	"github.com/colonyops/hive/internal/core/session"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -32,16 +33,40 @@ func (m *mockMsgStore) Subscribe(context.Context, string, time.Time) ([]messagin
 	return nil, nil
 }
 
+func (m *mockMsgStore) SubscribeAfter(context.Context, string, messaging.Cursor) ([]messaging.Message, error) {
+	return nil, nil
+}
+
+func (m *mockMsgStore) Get(context.Context, string) (messaging.Message, error) {
+	return messaging.Message{}, messaging.ErrMessageNotFound
+}
+
+func (m *mockMsgStore) Head(context.Context, string) (messaging.Cursor, error) {
+	return messaging.Cursor{}, nil
+}
+
 func (m *mockMsgStore) Acknowledge(context.Context, string, []string) error { return nil }
 
 func (m *mockMsgStore) GetUnread(context.Context, string, string) ([]messaging.Message, error) {
 	return nil, nil
 }
 
+func (m *mockMsgStore) ConsumerCursor(context.Context, string, string) (messaging.Cursor, error) {
+	return messaging.Cursor{}, nil
+}
+
+func (m *mockMsgStore) AckThrough(context.Context, string, []string) error { return nil }
+
 func (m *mockMsgStore) List(context.Context) ([]string, error) { return nil, nil }
 
 func (m *mockMsgStore) Prune(context.Context, time.Duration) (int, error) { return 0, nil }
 
+func (m *mockMsgStore) ApplyRetention(context.Context, string, time.Duration) (int, error) {
+	return 0, nil
+}
+
+func (m *mockMsgStore) DeleteTopic(context.Context, string) (int, error) { return 0, nil }
+
 func TestMessageService_PublishEmitsEvent(t *testing.T) {
 	store := &mockMsgStore{}
 	tb := testbus.New(t)
@@ -71,4 +96,41 @@ func TestMessageService_PublishEmitsEvent(t *testing.T) {
 	assert.Equal(t, 2, count, "should emit one event per topic")
 }
 
+func TestMessageService_PublishValidatesEnvelope(t *testing.T) {
+	store := &mockMsgStore{}
+	svc := NewMessageService(store, &config.Config{}, testbus.New(t).EventBus)
+
+	msg := messaging.Message{Type: "handoff", ContentType: messaging.ContentTypeJSON, Payload: `{"done":`}
+	_, err := svc.Publish(context.Background(), msg, []string{"topic.a"})
+	require.ErrorIs(t, err, messaging.ErrInvalidEnvelope)
+	assert.Empty(t, store.published, "invalid messages are not stored")
+
+	msg.Payload = `{"done":true}`
+	_, err = svc.Publish(context.Background(), msg, []string{"topic.a"})
+	require.NoError(t, err)
+	require.Len(t, store.published, 1)
+	assert.Equal(t, "handoff", store.published[0].msg.Type)
+}
+
+func TestMessageService_PublishRecordsCapabilities(t *testing.T) {
+	store := &mockMsgStore{}
+	svc := NewMessageService(store, &config.Config{}, testbus.New(t).EventBus)
+	recorded := map[string]session.Capabilities{}
+	svc.recordCapabilities = func(_ context.Context, id string, caps session.Capabilities) error {
+		recorded[id] = caps
+		return nil
+	}
+
+	_, err := svc.Publish(context.Background(), messaging.Message{Payload: "not json"}, []string{"agent.s1.meta"})
+	require.Error(t, err)
+	assert.Empty(t, store.published, "invalid announcements are not stored")
+
+	_, err = svc.Publish(context.Background(), messaging.Message{Payload: `{"model":"opus","tools":["bash"]}`}, []string{"agent.s1.meta", "agent.s1.inbox"})
+	require.NoError(t, err)
+	require.Len(t, recorded, 1)
+	assert.Equal(t, "opus", recorded["s1"].Model)
+	assert.Equal(t, []string{"bash"}, recorded["s1"].Tools)
+	assert.False(t, recorded["s1"].AnnouncedAt.IsZero())
+}
+
 var _ messaging.Store = (*mockMsgStore)(nil)
@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+)
+
+// Sources are the reads a Collector makes on each scrape.
+type Sources struct {
+	Sessions func(ctx context.Context) ([]session.Session, error)
+	// Statuses returns the agent statuses from the last TUI poll, or nil
+	// when no TUI is polling.
+	Statuses func(ctx context.Context) (*terminal.StatusSnapshot, error)
+	Messages func(ctx context.Context) (int64, error)
+	Topics   func(ctx context.Context) ([]string, error)
+}
+
+// Collector gathers metrics on each scrape. DB and Plugins accumulate query
+// and plugin poll timings between scrapes; wire them to
+// db.DB.ObserveQueries and plugins.Manager.ObservePolls.
+type Collector struct {
+	src     Sources
+	DB      *Timings
+	Plugins *Timings
+	now     func() time.Time
+}
+
+// NewCollector creates a collector reading from src.
+func NewCollector(src Sources) *Collector {
+	return &Collector{
+		src:     src,
+		DB:      NewTimings(),
+		Plugins: NewTimings(),
+		now:     time.Now,
+	}
+}
+
+// sessionStates lists the states reported by hive_sessions, so each has a
+// sample even when no session is in it.
+var sessionStates = []session.State{
+	session.StateActive,
+	session.StateRecycled,
+	session.StateCorrupted,
+	session.StateArchived,
+}
+
+// agentStatuses lists the statuses reported by hive_agents. "unknown" counts
+// active sessions the last poll had no status for.
+var agentStatuses = []terminal.Status{
+	terminal.StatusActive,
+	terminal.StatusApproval,
+	terminal.StatusReady,
+	terminal.StatusMissing,
+	"unknown",
+}
+
+// Collect reads the current metrics.
+func (c *Collector) Collect(ctx context.Context) ([]Metric, error) {
+	sessions, err := c.src.Sessions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+	snapshot, err := c.src.Statuses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read statuses: %w", err)
+	}
+	messages, err := c.src.Messages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("count messages: %w", err)
+	}
+	topics, err := c.src.Topics(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list topics: %w", err)
+	}
+
+	byState := make(map[session.State]int)
+	pool := make(map[string]int) // remote → recycled sessions
+	byStatus := make(map[terminal.Status]int)
+	for _, s := range sessions {
+		byState[s.State]++
+		switch s.State {
+		case session.StateRecycled:
+			pool[s.Remote]++
+		case session.StateActive:
+			if snapshot != nil {
+				status, ok := snapshot.Statuses[s.ID]
+				if !ok || !slices.Contains(agentStatuses, status) {
+					status = "unknown"
+				}
+				byStatus[status]++
+			}
+		}
+	}
+
+	sessionsMetric := Metric{Name: "hive_sessions", Help: "Sessions by state.", Type: TypeGauge}
+	for _, state := range sessionStates {
+		sessionsMetric.Samples = append(sessionsMetric.Samples, Sample{
+			Labels: []Label{{Name: "state", Value: string(state)}},
+			Value:  float64(byState[state]),
+		})
+	}
+
+	poolMetric := Metric{Name: "hive_recycled_pool_size", Help: "Recycled sessions available for reuse, by remote.", Type: TypeGauge}
+	for _, remote := range slices.Sorted(maps.Keys(pool)) {
+		poolMetric.Samples = append(poolMetric.Samples, Sample{
+			Labels: []Label{{Name: "remote", Value: remote}},
+			Value:  float64(pool[remote]),
+		})
+	}
+
+	metrics := []Metric{sessionsMetric, poolMetric}
+
+	// Agent statuses are only known while a TUI is polling; leaving the
+	// metrics out lets dashboards tell "no data" from "no agents".
+	if snapshot != nil {
+		agentsMetric := Metric{Name: "hive_agents", Help: "Active sessions by agent status, from the last TUI poll.", Type: TypeGauge}
+		for _, status := range agentStatuses {
+			agentsMetric.Samples = append(agentsMetric.Samples, Sample{
+				Labels: []Label{{Name: "status", Value: string(status)}},
+				Value:  float64(byStatus[status]),
+			})
+		}
+		metrics = append(metrics, agentsMetric, Metric{
+			Name:    "hive_agent_status_age_seconds",
+			Help:    "Seconds since the TUI last polled agent statuses.",
+			Type:    TypeGauge,
+			Samples: []Sample{{Value: c.now().Sub(snapshot.UpdatedAt).Seconds()}},
+		})
+	}
+
+	metrics = append(metrics,
+		Metric{Name: "hive_messages", Help: "Messages in the message store.", Type: TypeGauge, Samples: []Sample{{Value: float64(messages)}}},
+		Metric{Name: "hive_message_topics", Help: "Topics in the message store.", Type: TypeGauge, Samples: []Sample{{Value: float64(len(topics))}}},
+		c.Plugins.Metric("hive_plugin_poll_duration_seconds", "Time spent refreshing plugin statuses.", "plugin"),
+		c.DB.Metric("hive_db_query_duration_seconds", "Time spent executing database queries.", "query"),
+	)
+	return metrics, nil
+}
+
+// ServeHTTP writes the current metrics.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	metrics, err := c.Collect(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = Write(w, metrics)
+}
@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+)
+
+func TestWrite(t *testing.T) {
+	var b strings.Builder
+	err := Write(&b, []Metric{
+		{
+			Name: "hive_things",
+			Help: "Things.\nSecond line.",
+			Type: TypeGauge,
+			Samples: []Sample{
+				{Value: 3},
+				{Labels: []Label{{Name: "path", Value: `C:\a "b"`}, {Name: "n", Value: "1"}}, Value: 0.25},
+			},
+		},
+		{Name: "hive_empty", Help: "No samples.", Type: TypeSummary},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, `# HELP hive_things Things.\nSecond line.
+# TYPE hive_things gauge
+hive_things 3
+hive_things{path="C:\\a \"b\"",n="1"} 0.25
+# HELP hive_empty No samples.
+# TYPE hive_empty summary
+`, b.String())
+}
+
+func TestTimings(t *testing.T) {
+	timings := NewTimings()
+	timings.Observe("b", 2*time.Second)
+	timings.Observe("a", 500*time.Millisecond)
+	timings.Observe("a", 250*time.Millisecond)
+
+	m := timings.Metric("hive_op_seconds", "Ops.", "op")
+	assert.Equal(t, TypeSummary, m.Type)
+	assert.Equal(t, []Sample{
+		{Suffix: "_sum", Labels: []Label{{Name: "op", Value: "a"}}, Value: 0.75},
+		{Suffix: "_count", Labels: []Label{{Name: "op", Value: "a"}}, Value: 2},
+		{Suffix: "_sum", Labels: []Label{{Name: "op", Value: "b"}}, Value: 2},
+		{Suffix: "_count", Labels: []Label{{Name: "op", Value: "b"}}, Value: 1},
+	}, m.Samples)
+}
+
+func testSources(snapshot *terminal.StatusSnapshot) Sources {
+	return Sources{
+		Sessions: func(context.Context) ([]session.Session, error) {
+			return []session.Session{
+				{ID: "a", State: session.StateActive},
+				{ID: "b", State: session.StateActive},
+				{ID: "c", State: session.StateActive},
+				{ID: "d", State: session.StateRecycled, Remote: "git@github.com:org/api.git"},
+				{ID: "e", State: session.StateRecycled, Remote: "git@github.com:org/api.git"},
+				{ID: "f", State: session.StateRecycled, Remote: "git@github.com:org/web.git"},
+				{ID: "g", State: session.StateArchived},
+			}, nil
+		},
+		Statuses: func(context.Context) (*terminal.StatusSnapshot, error) { return snapshot, nil },
+		Messages: func(context.Context) (int64, error) { return 12, nil },
+		Topics:   func(context.Context) ([]string, error) { return []string{"agent.a.inbox", "builds"}, nil },
+	}
+}
+
+func TestCollector(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := NewCollector(testSources(&terminal.StatusSnapshot{
+		UpdatedAt: now.Add(-5 * time.Second),
+		Statuses:  map[string]terminal.Status{"a": terminal.StatusApproval, "b": terminal.StatusActive},
+	}))
+	c.now = func() time.Time { return now }
+	c.DB.Observe("GetSession", 2*time.Millisecond)
+	c.Plugins.Observe("github", time.Second)
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+
+	body := rec.Body.String()
+	for _, line := range []string{
+		`hive_sessions{state="active"} 3`,
+		`hive_sessions{state="recycled"} 3`,
+		`hive_sessions{state="corrupted"} 0`,
+		`hive_sessions{state="archived"} 1`,
+		`hive_recycled_pool_size{remote="git@github.com:org/api.git"} 2`,
+		`hive_recycled_pool_size{remote="git@github.com:org/web.git"} 1`,
+		`hive_agents{status="active"} 1`,
+		`hive_agents{status="approval"} 1`,
+		`hive_agents{status="ready"} 0`,
+		`hive_agents{status="unknown"} 1`,
+		`hive_agent_status_age_seconds 5`,
+		`hive_messages 12`,
+		`hive_message_topics 2`,
+		`hive_plugin_poll_duration_seconds_sum{plugin="github"} 1`,
+		`hive_plugin_poll_duration_seconds_count{plugin="github"} 1`,
+		`hive_db_query_duration_seconds_sum{query="GetSession"} 0.002`,
+		`hive_db_query_duration_seconds_count{query="GetSession"} 1`,
+	} {
+		assert.Contains(t, body, line+"\n")
+	}
+}
+
+func TestCollector_NoStatusSnapshot(t *testing.T) {
+	metrics, err := NewCollector(testSources(nil)).Collect(context.Background())
+	require.NoError(t, err)
+
+	for _, m := range metrics {
+		assert.NotEqual(t, "hive_agents", m.Name, "agent statuses are omitted without a TUI polling")
+	}
+}
+
+func TestCollector_SourceError(t *testing.T) {
+	src := testSources(nil)
+	src.Messages = func(context.Context) (int64, error) { return 0, errors.New("database is locked") }
+
+	rec := httptest.NewRecorder()
+	NewCollector(src).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "database is locked")
+}
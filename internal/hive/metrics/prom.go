@@ -0,0 +1,70 @@
+// Package metrics exposes hive fleet health in the Prometheus text
+// exposition format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Metric types written in TYPE comments.
+const (
+	TypeGauge   = "gauge"
+	TypeSummary = "summary"
+)
+
+// Metric is a metric family: a name with one or more labeled samples.
+type Metric struct {
+	Name    string
+	Help    string
+	Type    string
+	Samples []Sample
+}
+
+// Sample is one value of a metric. Suffix is appended to the metric name,
+// e.g. "_sum" and "_count" for summaries.
+type Sample struct {
+	Suffix string
+	Labels []Label
+	Value  float64
+}
+
+// Label is a sample label. Labels are written in the order given.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Write writes metrics in the Prometheus text exposition format.
+func Write(w io.Writer, metrics []Metric) error {
+	bw := bufio.NewWriter(w)
+	for _, m := range metrics {
+		fmt.Fprintf(bw, "# HELP %s %s\n", m.Name, helpEscaper.Replace(m.Help))
+		fmt.Fprintf(bw, "# TYPE %s %s\n", m.Name, m.Type)
+		for _, s := range m.Samples {
+			bw.WriteString(m.Name + s.Suffix)
+			if len(s.Labels) > 0 {
+				bw.WriteByte('{')
+				for i, l := range s.Labels {
+					if i > 0 {
+						bw.WriteByte(',')
+					}
+					fmt.Fprintf(bw, `%s="%s"`, l.Name, labelEscaper.Replace(l.Value))
+				}
+				bw.WriteByte('}')
+			}
+			bw.WriteByte(' ')
+			bw.WriteString(strconv.FormatFloat(s.Value, 'g', -1, 64))
+			bw.WriteByte('\n')
+		}
+	}
+	return bw.Flush()
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
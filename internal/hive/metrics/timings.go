@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Timings accumulates durations by key, e.g. per query or per plugin, for
+// reporting as a summary. It is safe for concurrent use.
+type Timings struct {
+	mu    sync.Mutex
+	byKey map[string]*timing
+}
+
+type timing struct {
+	count int64
+	sum   time.Duration
+}
+
+// NewTimings creates an empty Timings.
+func NewTimings() *Timings {
+	return &Timings{byKey: make(map[string]*timing)}
+}
+
+// Observe records one duration for key.
+func (t *Timings) Observe(key string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tm, ok := t.byKey[key]
+	if !ok {
+		tm = &timing{}
+		t.byKey[key] = tm
+	}
+	tm.count++
+	tm.sum += d
+}
+
+// Metric returns the timings as a summary named name, with each key as the
+// value of label and the durations in seconds.
+func (t *Timings) Metric(name, help, label string) Metric {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	m := Metric{Name: name, Help: help, Type: TypeSummary}
+	for _, key := range slices.Sorted(maps.Keys(t.byKey)) {
+		tm := t.byKey[key]
+		labels := []Label{{Name: label, Value: key}}
+		m.Samples = append(m.Samples,
+			Sample{Suffix: "_sum", Labels: labels, Value: tm.sum.Seconds()},
+			Sample{Suffix: "_count", Labels: labels, Value: float64(tm.count)},
+		)
+	}
+	return m
+}
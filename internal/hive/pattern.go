@@ -1,12 +1,24 @@
 package hive
 
-import "regexp"
+import (
+	"regexp"
+
+	"github.com/colonyops/hive/internal/core/git"
+)
 
 // matchRemotePattern checks if remote matches the regex pattern.
-// Empty pattern matches all remotes.
+// Empty pattern matches all remotes. Like config.Rule.Matches, Azure DevOps
+// and Bitbucket remotes also match against their HTTPS URL.
 func matchRemotePattern(pattern, remote string) (bool, error) {
 	if pattern == "" {
 		return true, nil
 	}
-	return regexp.MatchString(pattern, remote)
+	matched, err := regexp.MatchString(pattern, remote)
+	if err != nil || matched {
+		return matched, err
+	}
+	if parsed, ok := git.ParseRemote(remote); ok {
+		return regexp.MatchString(pattern, parsed.WebURL())
+	}
+	return false, nil
 }
@@ -0,0 +1,125 @@
+package hive
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/session"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrPlanNotFound is returned when a session has no plan document.
+var ErrPlanNotFound = errors.New("plan document not found")
+
+// planFrontmatterLimit caps how much of a document is read while looking for
+// its frontmatter.
+const planFrontmatterLimit = 4096
+
+// PlanDocument returns the absolute path of a session's plan in its
+// repository's context directory. The rendered plan_document path (default
+// plans/<slug>.md) is used when it exists; otherwise the most recently
+// modified markdown document whose frontmatter "session" field names the
+// session's ID, name, or slug is returned.
+func (s *SessionService) PlanDocument(sess session.Session) (string, error) {
+	owner, repo := git.ExtractOwnerRepo(sess.Remote)
+	if owner == "" || repo == "" {
+		return "", fmt.Errorf("session %s has no repository context", sess.Name)
+	}
+	contextDir := s.config.RepoContextDir(owner, repo)
+
+	tmplStr := s.config.GetPlanDocument(sess.Remote)
+	rendered, err := s.renderer.Render(tmplStr, config.PlanDocumentTemplateData{
+		Name:  sess.Name,
+		Slug:  sess.Slug,
+		Owner: owner,
+		Repo:  repo,
+		ID:    sess.ID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("plan_document render failed: %w", err)
+	}
+	if rendered = strings.TrimSpace(rendered); rendered != "" {
+		path := filepath.Join(contextDir, rendered)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+	}
+
+	refs := map[string]bool{sess.ID: true, sess.Name: true, sess.Slug: true}
+	delete(refs, "")
+
+	var (
+		best    string
+		bestMod time.Time
+	)
+	err = filepath.WalkDir(contextDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == contextDir && errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+		if !refs[frontmatterSession(path)] {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil // the file vanished while walking
+		}
+		if best == "" || info.ModTime().After(bestMod) {
+			best, bestMod = path, info.ModTime()
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("scan context directory: %w", err)
+	}
+	if best == "" {
+		return "", fmt.Errorf("%w for session %s", ErrPlanNotFound, sess.Name)
+	}
+	return best, nil
+}
+
+// frontmatterSession returns the "session" field of a markdown document's
+// YAML frontmatter, or "" when it has none.
+func frontmatterSession(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(io.LimitReader(f, planFrontmatterLimit))
+	if !scanner.Scan() || strings.TrimSpace(scanner.Text()) != "---" {
+		return ""
+	}
+	var buf bytes.Buffer
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "---" {
+			var fm struct {
+				Session string `yaml:"session"`
+			}
+			if err := yaml.Unmarshal(buf.Bytes(), &fm); err != nil {
+				return ""
+			}
+			return strings.TrimSpace(fm.Session)
+		}
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+package hive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePlanFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestPlanDocument(t *testing.T) {
+	sess := session.Session{ID: "26kj0c", Name: "Auth Refactor", Slug: "auth-refactor", Remote: "git@github.com:org/repo.git"}
+
+	t.Run("default path", func(t *testing.T) {
+		svc, _ := newSnapshotService(t, nil, nil)
+		ctxDir := svc.config.RepoContextDir("org", "repo")
+		want := filepath.Join(ctxDir, "plans", "auth-refactor.md")
+		writePlanFile(t, want, "# Plan\n")
+
+		got, err := svc.PlanDocument(sess)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("rule template", func(t *testing.T) {
+		svc, _ := newSnapshotService(t, nil, func(cfg *config.Config) {
+			cfg.Rules = []config.Rule{{PlanDocument: "{{ .ID }}/plan.md"}}
+		})
+		want := filepath.Join(svc.config.RepoContextDir("org", "repo"), "26kj0c", "plan.md")
+		writePlanFile(t, want, "# Plan\n")
+
+		got, err := svc.PlanDocument(sess)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("frontmatter fallback picks newest", func(t *testing.T) {
+		svc, _ := newSnapshotService(t, nil, nil)
+		ctxDir := svc.config.RepoContextDir("org", "repo")
+		older := filepath.Join(ctxDir, "research", "old.md")
+		newer := filepath.Join(ctxDir, "plans", "auth.md")
+		writePlanFile(t, older, "---\nsession: 26kj0c\n---\n# Old\n")
+		writePlanFile(t, newer, "---\ntitle: Auth\nsession: Auth Refactor\n---\n# New\n")
+		writePlanFile(t, filepath.Join(ctxDir, "plans", "other.md"), "---\nsession: other\n---\n")
+		past := time.Now().Add(-time.Hour)
+		require.NoError(t, os.Chtimes(older, past, past))
+
+		got, err := svc.PlanDocument(sess)
+		require.NoError(t, err)
+		assert.Equal(t, newer, got)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc, _ := newSnapshotService(t, nil, nil)
+		writePlanFile(t, filepath.Join(svc.config.RepoContextDir("org", "repo"), "notes.md"), "# Notes\n---\nsession: 26kj0c\n---\n")
+
+		_, err := svc.PlanDocument(sess)
+		require.ErrorIs(t, err, ErrPlanNotFound)
+
+		_, err = svc.PlanDocument(session.Session{ID: "x", Name: "x", Remote: "git@github.com:org/missing.git"})
+		require.ErrorIs(t, err, ErrPlanNotFound, "a missing context directory has no plan")
+	})
+}
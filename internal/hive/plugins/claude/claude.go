@@ -3,20 +3,33 @@ package claude
 
 import (
 	"context"
+	"fmt"
 	"os/exec"
+	"time"
+
+	"charm.land/lipgloss/v2"
+	"github.com/rs/zerolog/log"
 
 	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/styles"
+	"github.com/colonyops/hive/internal/core/usage"
 	"github.com/colonyops/hive/internal/hive/plugins"
 )
 
 // Plugin implements Claude Code command integration.
 type Plugin struct {
-	cfg config.ClaudePluginConfig
+	cfg     config.ClaudePluginConfig
+	store   usage.Store
+	scanner *Scanner
 }
 
 // New creates a new Claude plugin.
-func New(cfg config.ClaudePluginConfig) *Plugin {
-	return &Plugin{cfg: cfg}
+// If store is non-nil, the background status refresh persists session usage
+// read from Claude's transcripts there and, with show_cost enabled, shows it
+// as a session status.
+func New(cfg config.ClaudePluginConfig, store usage.Store) *Plugin {
+	return &Plugin{cfg: cfg, store: store, scanner: NewScanner(ProjectsDir())}
 }
 
 func (p *Plugin) Name() string {
@@ -58,5 +71,54 @@ tmux select-window -t "$window_name"
 }
 
 func (p *Plugin) StatusProvider() plugins.StatusProvider {
-	return nil
+	if p.store == nil {
+		return nil
+	}
+	return p
+}
+
+// RefreshStatus records the usage the sessions' transcripts gained since the
+// last refresh and, with show_cost enabled, reports each session's cumulative
+// cost. It is the only place usage is written; commands read what it
+// recorded.
+func (p *Plugin) RefreshStatus(ctx context.Context, sessions []*session.Session, _ *plugins.WorkerPool) (map[string]plugins.Status, error) {
+	batch := make([]session.Session, 0, len(sessions))
+	for _, sess := range sessions {
+		batch = append(batch, *sess)
+	}
+	if err := SyncUsage(ctx, p.store, p.scanner, batch); err != nil {
+		// Report what was recorded before rather than dropping every status.
+		log.Debug().Err(err).Msg("claude usage sync failed")
+	}
+	if !p.cfg.ShowCost {
+		return nil, nil
+	}
+
+	totals, err := p.store.SessionTotals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	style := lipgloss.NewStyle().Foreground(styles.ColorMuted)
+	results := make(map[string]plugins.Status, len(sessions))
+	for _, sess := range sessions {
+		total, ok := totals[sess.ID]
+		if !ok || total.IsZero() {
+			continue
+		}
+		results[sess.ID] = plugins.Status{Label: FormatCost(total.CostUSD), Style: style}
+	}
+	return results, nil
+}
+
+func (p *Plugin) StatusCacheDuration() time.Duration {
+	return time.Minute
+}
+
+// FormatCost renders a USD amount, e.g. "$1.23" or "<$0.01".
+func FormatCost(usd float64) string {
+	if usd > 0 && usd < 0.01 {
+		return "<$0.01"
+	}
+	return fmt.Sprintf("$%.2f", usd)
 }
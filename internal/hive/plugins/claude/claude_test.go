@@ -1,11 +1,17 @@
 package claude
 
 import (
+	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/internal/data/stores"
 )
 
 func TestPlugin_Available(t *testing.T) {
@@ -13,7 +19,7 @@ func TestPlugin_Available(t *testing.T) {
 		disabled := false
 		plugin := New(config.ClaudePluginConfig{
 			Enabled: &disabled,
-		})
+		}, nil)
 
 		assert.False(t, plugin.Available())
 	})
@@ -22,20 +28,64 @@ func TestPlugin_Available(t *testing.T) {
 		enabled := true
 		plugin := New(config.ClaudePluginConfig{
 			Enabled: &enabled,
-		})
+		}, nil)
 
 		_ = plugin.Available()
 	})
 }
 
 func TestPlugin_StatusProvider(t *testing.T) {
-	plugin := New(config.ClaudePluginConfig{})
+	t.Run("nil without a usage store", func(t *testing.T) {
+		assert.Nil(t, New(config.ClaudePluginConfig{ShowCost: true}, nil).StatusProvider())
+	})
+
+	t.Run("records usage without show_cost", func(t *testing.T) {
+		configDir := t.TempDir()
+		t.Setenv("CLAUDE_CONFIG_DIR", configDir)
+		writeTranscript(t, filepath.Join(configDir, "projects"), "/work/a", `{"timestamp":"2026-03-01T10:00:00Z","requestId":"r1","costUSD":1.5,"message":{"id":"m1","usage":{"input_tokens":10}}}`)
+
+		database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = database.Close() })
 
-	assert.Nil(t, plugin.StatusProvider())
+		store := stores.NewUsageStore(database)
+		provider := New(config.ClaudePluginConfig{}, store).StatusProvider()
+		require.NotNil(t, provider)
+
+		statuses, err := provider.RefreshStatus(context.Background(), []*session.Session{{ID: "a", Path: "/work/a", Remote: "r"}}, nil)
+		require.NoError(t, err)
+		assert.Empty(t, statuses, "costs are only shown with show_cost")
+
+		totals, err := store.SessionTotals(context.Background())
+		require.NoError(t, err)
+		assert.InDelta(t, 1.5, totals["a"].CostUSD, 1e-9)
+	})
+
+	t.Run("reports cumulative cost", func(t *testing.T) {
+		configDir := t.TempDir()
+		t.Setenv("CLAUDE_CONFIG_DIR", configDir)
+		writeTranscript(t, filepath.Join(configDir, "projects"), "/work/a", `{"timestamp":"2026-03-01T10:00:00Z","requestId":"r1","costUSD":1.5,"message":{"id":"m1","usage":{"input_tokens":10}}}`)
+
+		database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = database.Close() })
+
+		plugin := New(config.ClaudePluginConfig{ShowCost: true}, stores.NewUsageStore(database))
+		provider := plugin.StatusProvider()
+		require.NotNil(t, provider)
+
+		statuses, err := provider.RefreshStatus(context.Background(), []*session.Session{
+			{ID: "a", Path: "/work/a", Remote: "r"},
+			{ID: "b", Path: "/work/b", Remote: "r"},
+		}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "$1.50", statuses["a"].Label)
+		assert.NotContains(t, statuses, "b", "sessions without usage have no status")
+	})
 }
 
 func TestPlugin_Commands(t *testing.T) {
-	plugin := New(config.ClaudePluginConfig{})
+	plugin := New(config.ClaudePluginConfig{}, nil)
 
 	commands := plugin.Commands()
 	assert.Contains(t, commands, "ClaudeFork")
@@ -0,0 +1,310 @@
+package claude
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/usage"
+)
+
+// ProjectsDir returns the directory Claude Code writes session transcripts
+// to, honoring CLAUDE_CONFIG_DIR.
+func ProjectsDir() string {
+	if dir := os.Getenv("CLAUDE_CONFIG_DIR"); dir != "" {
+		return filepath.Join(dir, "projects")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".claude", "projects")
+}
+
+// projectDir returns the transcript directory for sessions run in path.
+// Claude Code names it after the path with every character other than an
+// ASCII letter or digit replaced by '-'.
+func projectDir(projectsDir, path string) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '-'
+	}, path)
+	return filepath.Join(projectsDir, name)
+}
+
+// transcriptLine is the subset of a transcript entry that carries usage.
+type transcriptLine struct {
+	Timestamp time.Time `json:"timestamp"`
+	RequestID string    `json:"requestId"`
+	CostUSD   *float64  `json:"costUSD"`
+	Message   struct {
+		ID    string `json:"id"`
+		Model string `json:"model"`
+		Usage *struct {
+			InputTokens              int64 `json:"input_tokens"`
+			OutputTokens             int64 `json:"output_tokens"`
+			CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+			CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+}
+
+// ReadUsage sums the usage recorded in every transcript of sessions run in
+// path, keyed by local date (YYYY-MM-DD). A path Claude was never run in
+// yields no usage.
+func ReadUsage(projectsDir, path string) (map[string]usage.Usage, error) {
+	days, _, err := NewScanner(projectsDir).Usage(path)
+	return days, err
+}
+
+// Scanner reads session usage from transcripts incrementally: each call only
+// reads what was appended to a transcript since the previous call, and skips
+// transcripts whose size and modification time are unchanged. It is safe for
+// concurrent use.
+type Scanner struct {
+	projectsDir string
+
+	mu       sync.Mutex
+	projects map[string]*projectScan
+}
+
+// projectScan is the scan state of one session directory's transcripts.
+type projectScan struct {
+	files map[string]transcriptFile
+	// seen holds the responses already counted. It spans every transcript of
+	// the directory because resumed and forked sessions copy earlier
+	// responses into a new transcript.
+	seen map[string]bool
+	days map[string]usage.Usage
+}
+
+// transcriptFile records how far a transcript has been read.
+type transcriptFile struct {
+	size    int64
+	modTime time.Time
+	offset  int64 // end of the last complete line read
+}
+
+// NewScanner creates a scanner for transcripts under projectsDir.
+func NewScanner(projectsDir string) *Scanner {
+	return &Scanner{projectsDir: projectsDir, projects: make(map[string]*projectScan)}
+}
+
+// Usage returns the cumulative usage of sessions run in path, keyed by local
+// date (YYYY-MM-DD), and whether it changed since the previous call.
+func (s *Scanner) Usage(path string) (map[string]usage.Usage, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	scan := s.projects[path]
+	if scan == nil {
+		scan = newProjectScan()
+		s.projects[path] = scan
+	}
+
+	changed, err := scan.update(projectDir(s.projectsDir, path))
+	if errors.Is(err, errTranscriptTruncated) {
+		// A transcript was rewritten; what was counted from it is unknown, so
+		// start over.
+		scan = newProjectScan()
+		s.projects[path] = scan
+		changed, err = scan.update(projectDir(s.projectsDir, path))
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("read claude transcripts for %s: %w", path, err)
+	}
+	return maps.Clone(scan.days), changed, nil
+}
+
+var errTranscriptTruncated = errors.New("transcript truncated")
+
+func newProjectScan() *projectScan {
+	return &projectScan{
+		files: make(map[string]transcriptFile),
+		seen:  make(map[string]bool),
+		days:  make(map[string]usage.Usage),
+	}
+}
+
+// update reads what was appended to the transcripts in dir and reports
+// whether anything was read.
+func (scan *projectScan) update(dir string) (bool, error) {
+	changed := false
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(p) != ".jsonl" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		file := scan.files[p]
+		if info.Size() == file.size && info.ModTime().Equal(file.modTime) {
+			return nil
+		}
+		if info.Size() < file.offset {
+			return errTranscriptTruncated
+		}
+
+		offset, err := readTranscript(p, file.offset, scan.days, scan.seen)
+		if err != nil {
+			return err
+		}
+		scan.files[p] = transcriptFile{size: info.Size(), modTime: info.ModTime(), offset: offset}
+		changed = changed || offset != file.offset
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return changed, nil
+	}
+	return changed, err
+}
+
+// readTranscript adds the usage in the complete lines of path after offset
+// and returns the offset just past the last complete line. A trailing line
+// still being written is left for the next read.
+func readTranscript(path string, offset int64, days map[string]usage.Usage, seen map[string]bool) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return offset, err
+	}
+	defer func() { _ = f.Close() }()
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return offset, err
+	}
+
+	// Transcript lines embed tool output and can be arbitrarily long, so read
+	// whole lines rather than using a size-limited bufio.Scanner.
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			return offset, nil
+		}
+		if err != nil {
+			return offset, err
+		}
+		offset += int64(len(line))
+		if bytes.Contains(line, []byte(`"usage"`)) {
+			addTranscriptLine(line, days, seen)
+		}
+	}
+}
+
+func addTranscriptLine(line []byte, days map[string]usage.Usage, seen map[string]bool) {
+	var entry transcriptLine
+	if err := json.Unmarshal(line, &entry); err != nil || entry.Message.Usage == nil || entry.Timestamp.IsZero() {
+		return
+	}
+
+	// Streamed responses repeat the same message and request once per
+	// content block; count each response once.
+	if entry.Message.ID != "" || entry.RequestID != "" {
+		key := entry.Message.ID + ":" + entry.RequestID
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+	}
+
+	u := entry.Message.Usage
+	tally := usage.Usage{
+		InputTokens:         u.InputTokens,
+		OutputTokens:        u.OutputTokens,
+		CacheCreationTokens: u.CacheCreationInputTokens,
+		CacheReadTokens:     u.CacheReadInputTokens,
+	}
+	if entry.CostUSD != nil {
+		tally.CostUSD = *entry.CostUSD
+	} else {
+		tally.CostUSD = estimateCost(entry.Message.Model, tally)
+	}
+
+	date := entry.Timestamp.Local().Format(time.DateOnly)
+	day := days[date]
+	day.Add(tally)
+	days[date] = day
+}
+
+// modelPrice is a model's list price in USD per million tokens.
+type modelPrice struct {
+	match         string
+	input, output float64
+}
+
+// modelPrices is matched in order against the model ID, so more specific
+// entries come first. Cache writes cost 1.25x input and cache reads 0.1x.
+var modelPrices = []modelPrice{
+	{match: "opus-4-5", input: 5, output: 25},
+	{match: "opus", input: 15, output: 75},
+	{match: "sonnet", input: 3, output: 15},
+	{match: "haiku-4-5", input: 1, output: 5},
+	{match: "haiku", input: 0.8, output: 4},
+}
+
+// estimateCost prices u at the list price of model. Unknown models cost 0.
+func estimateCost(model string, u usage.Usage) float64 {
+	for _, price := range modelPrices {
+		if !strings.Contains(model, price.match) {
+			continue
+		}
+		const perToken = 1.0 / 1_000_000
+		return perToken * (float64(u.InputTokens)*price.input +
+			float64(u.OutputTokens)*price.output +
+			float64(u.CacheCreationTokens)*price.input*1.25 +
+			float64(u.CacheReadTokens)*price.input*0.1)
+	}
+	return 0
+}
+
+// SyncUsage reads the Claude usage of each session with scanner and records
+// the usage that changed in store. Sessions whose transcripts cannot be read
+// are skipped; the first such error is returned after the rest are recorded.
+func SyncUsage(ctx context.Context, store usage.Store, scanner *Scanner, sessions []session.Session) error {
+	var (
+		records  []usage.Day
+		firstErr error
+	)
+	for _, sess := range sessions {
+		if sess.Path == "" {
+			continue
+		}
+		days, changed, err := scanner.Usage(sess.Path)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if !changed {
+			continue
+		}
+		for date, u := range days {
+			records = append(records, usage.Day{SessionID: sess.ID, Remote: sess.Remote, Date: date, Usage: u})
+		}
+	}
+
+	if err := store.Record(ctx, records); err != nil {
+		return err
+	}
+	return firstErr
+}
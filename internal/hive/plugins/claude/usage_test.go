@@ -0,0 +1,111 @@
+package claude
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/usage"
+)
+
+func writeTranscript(t *testing.T, projectsDir, path string, lines ...string) {
+	t.Helper()
+	dir := projectDir(projectsDir, path)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	name := filepath.Join(dir, "session.jsonl")
+	require.NoError(t, os.WriteFile(name, []byte(strings.Join(lines, "\n")+"\n"), 0o644))
+}
+
+func TestProjectDir(t *testing.T) {
+	assert.Equal(t, filepath.Join("/p", "-Users-me--hive-repos-app-v1-2"), projectDir("/p", "/Users/me/.hive/repos/app_v1.2"))
+}
+
+func TestReadUsage(t *testing.T) {
+	projects := t.TempDir()
+	writeTranscript(t, projects, "/work/app",
+		`{"type":"user","timestamp":"2026-03-01T10:00:00Z","message":{"content":"hi"}}`,
+		`{"type":"assistant","timestamp":"2026-03-01T10:00:01Z","requestId":"r1","message":{"id":"m1","model":"claude-sonnet-4","usage":{"input_tokens":1000000,"output_tokens":100000,"cache_read_input_tokens":1000000}}}`,
+		`{"type":"assistant","timestamp":"2026-03-01T10:00:02Z","requestId":"r1","message":{"id":"m1","model":"claude-sonnet-4","usage":{"input_tokens":1000000,"output_tokens":100000,"cache_read_input_tokens":1000000}}}`,
+		`{"type":"assistant","timestamp":"2026-03-05T10:00:00Z","requestId":"r2","costUSD":0.25,"message":{"id":"m2","model":"claude-opus-4","usage":{"output_tokens":5}}}`,
+		`not json with "usage"`,
+	)
+
+	days, err := ReadUsage(projects, "/work/app")
+	require.NoError(t, err)
+
+	first := time.Date(2026, 3, 1, 10, 0, 1, 0, time.UTC).Local().Format(time.DateOnly)
+	second := time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC).Local().Format(time.DateOnly)
+	require.Len(t, days, 2)
+
+	got := days[first]
+	assert.Equal(t, int64(1000000), got.InputTokens, "repeated streaming entries are counted once")
+	assert.Equal(t, int64(100000), got.OutputTokens)
+	assert.InDelta(t, 3+1.5+0.3, got.CostUSD, 1e-9, "priced at sonnet list price")
+
+	assert.Equal(t, usage.Usage{OutputTokens: 5, CostUSD: 0.25}, days[second], "recorded cost wins over the estimate")
+}
+
+func TestReadUsage_NoTranscripts(t *testing.T) {
+	days, err := ReadUsage(t.TempDir(), "/never/used")
+	require.NoError(t, err)
+	assert.Empty(t, days)
+}
+
+func TestScanner_ReadsIncrementally(t *testing.T) {
+	projects := t.TempDir()
+	line := func(id string, tokens int) string {
+		return fmt.Sprintf(`{"timestamp":"2026-03-01T10:00:00Z","requestId":"r-%s","costUSD":1,"message":{"id":"%s","usage":{"output_tokens":%d}}}`, id, id, tokens)
+	}
+	writeTranscript(t, projects, "/work/app", line("m1", 10))
+	name := filepath.Join(projectDir(projects, "/work/app"), "session.jsonl")
+
+	scanner := NewScanner(projects)
+	days, changed, err := scanner.Usage("/work/app")
+	require.NoError(t, err)
+	assert.True(t, changed)
+	date := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC).Local().Format(time.DateOnly)
+	assert.Equal(t, int64(10), days[date].OutputTokens)
+
+	_, changed, err = scanner.Usage("/work/app")
+	require.NoError(t, err)
+	assert.False(t, changed, "an unchanged transcript is not read again")
+
+	// Append a complete line and the start of one still being written
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	partial := line("m3", 1000)
+	_, err = f.WriteString(line("m2", 5) + "\n" + partial[:20])
+	require.NoError(t, err)
+
+	days, changed, err = scanner.Usage("/work/app")
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, int64(15), days[date].OutputTokens, "only the appended complete line is added")
+
+	_, err = f.WriteString(partial[20:] + "\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	days, _, err = scanner.Usage("/work/app")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1015), days[date].OutputTokens, "the finished line is read once")
+
+	// A rewritten, shorter transcript is read from the start
+	require.NoError(t, os.WriteFile(name, []byte(line("m9", 7)+"\n"), 0o644))
+	days, changed, err = scanner.Usage("/work/app")
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, int64(7), days[date].OutputTokens)
+}
+
+func TestFormatCost(t *testing.T) {
+	assert.Equal(t, "$0.00", FormatCost(0))
+	assert.Equal(t, "<$0.01", FormatCost(0.004))
+	assert.Equal(t, "$12.35", FormatCost(12.345))
+}
@@ -22,8 +22,8 @@ type CommandSet struct {
 }
 
 // NewCommandSet constructs a CommandSet seeded with system and user commands.
-// Either map may be nil (treated as empty). System and user are immutable
-// after construction; if a config-reload story arrives later, add setters then.
+// Either map may be nil (treated as empty). System is immutable after
+// construction; user is replaced by SetUser when the config is reloaded.
 func NewCommandSet(system, user map[string]config.UserCommand) *CommandSet {
 	return &CommandSet{
 		system:  cloneCommands(system),
@@ -32,6 +32,14 @@ func NewCommandSet(system, user map[string]config.UserCommand) *CommandSet {
 	}
 }
 
+// SetUser replaces the user-config slot. Pass nil to clear it.
+func (s *CommandSet) SetUser(cmds map[string]config.UserCommand) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.user = cloneCommands(cmds)
+}
+
 // SetPlugin replaces the named plugin's slot. Pass nil cmds to clear
 // (removes the entry from plugins map).
 func (s *CommandSet) SetPlugin(name string, cmds map[string]config.UserCommand) {
@@ -80,6 +88,27 @@ func (s *CommandSet) Lookup(name string) (config.UserCommand, bool) {
 	return config.UserCommand{}, false
 }
 
+// Source reports which source the command named name resolves from under
+// Lookup's precedence: "user", "system", or the name of the plugin. It
+// returns "" if no source defines name.
+func (s *CommandSet) Source(name string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.user[name]; ok {
+		return "user"
+	}
+	for plugin, slot := range s.plugins {
+		if _, ok := slot[name]; ok {
+			return plugin
+		}
+	}
+	if _, ok := s.system[name]; ok {
+		return "system"
+	}
+	return ""
+}
+
 // All returns a defensive copy of the fully merged command map.
 func (s *CommandSet) All() map[string]config.UserCommand {
 	s.mu.RLock()
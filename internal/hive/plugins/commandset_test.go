@@ -39,6 +39,19 @@ func TestCommandSet_PrecedenceOrdering(t *testing.T) {
 	assert.Equal(t, "S", s.All()["Foo"].Sh)
 }
 
+func TestCommandSet_Source(t *testing.T) {
+	s := NewCommandSet(
+		map[string]config.UserCommand{"Foo": {}, "Bar": {}, "Baz": {}},
+		map[string]config.UserCommand{"Foo": {}},
+	)
+	s.SetPlugin("github", map[string]config.UserCommand{"Foo": {}, "Bar": {}})
+
+	assert.Equal(t, "user", s.Source("Foo"))
+	assert.Equal(t, "github", s.Source("Bar"))
+	assert.Equal(t, "system", s.Source("Baz"))
+	assert.Empty(t, s.Source("Missing"))
+}
+
 func TestCommandSet_SetPlugin_ReplacesSlot(t *testing.T) {
 	s := NewCommandSet(nil, nil)
 
@@ -72,6 +85,22 @@ func TestCommandSet_SetPluginNil_ClearsSlot(t *testing.T) {
 	assert.False(t, hasA)
 }
 
+func TestCommandSet_SetUser_ReplacesSlot(t *testing.T) {
+	s := NewCommandSet(
+		map[string]config.UserCommand{"Sys": {Sh: "system"}},
+		map[string]config.UserCommand{"Sys": {Sh: "user"}, "Old": {Sh: "old"}},
+	)
+
+	s.SetUser(map[string]config.UserCommand{"New": {Sh: "new"}})
+
+	got, ok := s.Lookup("Sys")
+	assert.True(t, ok)
+	assert.Equal(t, "system", got.Sh, "dropped user override falls back to system")
+	_, ok = s.Lookup("Old")
+	assert.False(t, ok)
+	assert.Equal(t, "user", s.Source("New"))
+}
+
 func TestCommandSet_DefensiveCopy_All(t *testing.T) {
 	s := NewCommandSet(
 		map[string]config.UserCommand{"Sys": {Sh: "s"}},
@@ -3,24 +3,25 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"os/exec"
 	"sync"
 	"time"
 
 	"charm.land/lipgloss/v2"
 	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/git"
 	"github.com/colonyops/hive/internal/core/kv"
 	"github.com/colonyops/hive/internal/core/session"
 	"github.com/colonyops/hive/internal/core/styles"
 	"github.com/colonyops/hive/internal/hive/plugins"
 	"github.com/colonyops/hive/internal/hive/plugins/pluglib"
+	"github.com/colonyops/hive/pkg/executil"
 )
 
 // Plugin implements the GitHub plugin for Hive.
 type Plugin struct {
 	cfg   config.GitHubPluginConfig
-	cache *kv.Cache[prInfo]
+	cache *kv.Cache[PullRequestStatus]
 }
 
 // New creates a new GitHub plugin.
@@ -28,7 +29,7 @@ type Plugin struct {
 func New(cfg config.GitHubPluginConfig, kvStore kv.KV) *Plugin {
 	p := &Plugin{cfg: cfg}
 	if kvStore != nil {
-		p.cache = kv.NewCache[prInfo](kvStore, "github.pr", p.StatusCacheDuration())
+		p.cache = kv.NewCache[PullRequestStatus](kvStore, "github.pr", p.StatusCacheDuration())
 	}
 	return p
 }
@@ -54,6 +55,12 @@ func (p *Plugin) Commands() map[string]config.UserCommand {
 		"GithubOpenPR":   {Sh: "cd {{ .Path }} && gh pr view --web", Help: "view current PR in browser", Scope: []string{"sessions"}},
 		"GithubPRStatus": pluglib.TmuxPopup(`cd "{{ .Path }}" && gh pr status {{ join .Args " " }}`, "show PR status [flags]"),
 		"GithubPRCreate": {Sh: "cd {{ .Path }} && gh pr create --web", Help: "create PR in browser", Scope: []string{"sessions"}},
+		"GithubNewFromIssue": {
+			Sh:    "cd {{ .Path | shq }} && hive new --background --remote {{ .Remote | shq }} --from-issue {{ .Form.issue | shq }}",
+			Form:  []config.FormField{{Variable: "issue", Label: "Issue number or URL", Placeholder: "123"}},
+			Help:  "new session from a GitHub issue in the selected session's repo",
+			Scope: []string{"sessions"},
+		},
 	}
 }
 
@@ -61,25 +68,23 @@ func (p *Plugin) StatusProvider() plugins.StatusProvider {
 	return p
 }
 
-// prInfo represents GitHub PR information from gh CLI.
-type prInfo struct {
-	Number  int    `json:"number"`
-	State   string `json:"state"`
-	IsDraft bool   `json:"isDraft"`
-}
-
 func (p *Plugin) RefreshStatus(ctx context.Context, sessions []*session.Session, pool *plugins.WorkerPool) (map[string]plugins.Status, error) {
 	results := make(map[string]plugins.Status)
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 
 	for _, sess := range sessions {
+		// ParseRemote only recognizes Azure DevOps and Bitbucket remotes,
+		// which gh cannot query.
+		if _, ok := git.ParseRemote(sess.Remote); ok {
+			continue
+		}
 		wg.Add(1)
 		go func(s *session.Session) {
 			defer wg.Done()
 			pool.Run(func() {
-				info := p.fetchPRInfo(ctx, s.ID, s.Path)
-				status := infoToStatus(info)
+				pr := p.fetchPR(ctx, s.ID, s.Path)
+				status := prToStatus(pr)
 				if status.Label != "" {
 					mu.Lock()
 					results[s.ID] = status
@@ -93,71 +98,73 @@ func (p *Plugin) RefreshStatus(ctx context.Context, sessions []*session.Session,
 	return results, nil
 }
 
-// fetchPRInfo returns PR info, checking the cache first. Empty results
-// are cached too, to avoid repeated gh calls for sessions without a PR.
-func (p *Plugin) fetchPRInfo(ctx context.Context, sessionID, path string) prInfo {
+// fetchPR returns the session's pull request, checking the cache first.
+// Empty results are cached too, to avoid repeated gh calls for sessions
+// without a PR.
+func (p *Plugin) fetchPR(ctx context.Context, sessionID, path string) PullRequestStatus {
 	if p.cache != nil {
 		if cached, ok := p.cache.Get(ctx, sessionID); ok {
 			return cached
 		}
 	}
 
-	info := p.fetchFromGH(ctx, path)
+	pr, err := ViewPullRequest(ctx, &executil.RealExecutor{}, path)
+	if err != nil {
+		pr = PullRequestStatus{}
+	}
 
 	if p.cache != nil {
-		p.cache.Set(ctx, sessionID, info)
+		p.cache.Set(ctx, sessionID, pr)
 	}
-	return info
+	return pr
 }
 
-func (p *Plugin) fetchFromGH(ctx context.Context, path string) prInfo {
-	cmd := exec.CommandContext(ctx, "gh", "pr", "view", "--json", "number,state,isDraft")
-	cmd.Dir = path
-	output, err := cmd.Output()
-	if err != nil {
-		return prInfo{}
+// CachedPullRequest returns the pull request cached for a session by the
+// status provider, without calling gh. ok is false on a cache miss or when
+// the session has no pull request.
+func (p *Plugin) CachedPullRequest(ctx context.Context, sessionID string) (PullRequestStatus, bool) {
+	if p.cache == nil {
+		return PullRequestStatus{}, false
 	}
-
-	var info prInfo
-	if err := json.Unmarshal(output, &info); err != nil {
-		return prInfo{}
-	}
-
-	return info
+	pr, ok := p.cache.Get(ctx, sessionID)
+	return pr, ok && pr.Number != 0
 }
 
-func infoToStatus(info prInfo) plugins.Status {
-	if info.Number == 0 {
+// prToStatus renders the pull request as "approved ✓ 5 / ✗ 1", colored by
+// its review state and checks. The raw pull request is the status's Data.
+func prToStatus(pr PullRequestStatus) plugins.Status {
+	if pr.Number == 0 {
 		return plugins.Status{}
 	}
 
-	var label string
+	label := pr.ReviewState()
 	var style lipgloss.Style
-
-	if info.IsDraft {
-		label = "draft"
+	switch label {
+	case "open":
+		style = lipgloss.NewStyle().Foreground(styles.ColorSuccess)
+	case "approved":
+		style = lipgloss.NewStyle().Foreground(styles.ColorSuccess).Bold(true)
+	case "changes requested":
+		style = lipgloss.NewStyle().Foreground(styles.ColorWarning)
+	case "merged":
+		style = lipgloss.NewStyle().Foreground(styles.ColorPrimary)
+	case "draft", "closed":
 		style = lipgloss.NewStyle().Foreground(styles.ColorMuted)
-	} else {
-		switch info.State {
-		case "OPEN":
-			label = "open"
-			style = lipgloss.NewStyle().Foreground(styles.ColorSuccess)
-		case "MERGED":
-			label = "merged"
-			style = lipgloss.NewStyle().Foreground(styles.ColorPrimary)
-		case "CLOSED":
-			label = "closed"
-			style = lipgloss.NewStyle().Foreground(styles.ColorMuted)
-		default:
-			label = info.State
-			style = lipgloss.NewStyle()
-		}
+	default:
+		style = lipgloss.NewStyle()
+	}
+	if pr.ChecksFailed > 0 && pr.State == "OPEN" {
+		style = lipgloss.NewStyle().Foreground(styles.ColorError)
+	}
+	if checks := pr.ChecksSummary(); checks != "" && pr.State == "OPEN" {
+		label += " " + checks
 	}
 
 	return plugins.Status{
 		Label: label,
 		Icon:  "PR",
 		Style: style,
+		Data:  pr,
 	}
 }
 
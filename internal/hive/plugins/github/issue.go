@@ -0,0 +1,79 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/sources"
+	"github.com/colonyops/hive/pkg/executil"
+)
+
+// Issue is a GitHub issue a session can be created from.
+type Issue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	URL    string `json:"url"`
+	State  string `json:"state"`
+}
+
+// FetchIssue reads an issue with gh. ref is an issue number, resolved against
+// repo ("owner/repo") or, when repo is empty, the repository in dir; or a
+// full issue URL.
+func FetchIssue(ctx context.Context, executor executil.Executor, dir, repo, ref string) (Issue, error) {
+	ref = strings.TrimPrefix(strings.TrimSpace(ref), "#")
+	if ref == "" {
+		return Issue{}, fmt.Errorf("issue number or URL required")
+	}
+
+	args := []string{"issue", "view", ref, "--json", "number,title,body,url,state"}
+	if _, err := strconv.Atoi(ref); err == nil && repo != "" {
+		args = append(args, "--repo", repo)
+	}
+
+	out, err := executor.RunDir(ctx, dir, "gh", args...)
+	if err != nil {
+		return Issue{}, fmt.Errorf("gh issue view %s: %w: %s", ref, err, strings.TrimSpace(string(out)))
+	}
+
+	var issue Issue
+	if err := json.Unmarshal(out, &issue); err != nil {
+		return Issue{}, fmt.Errorf("parse gh output: %w", err)
+	}
+	if issue.Number == 0 {
+		return Issue{}, fmt.Errorf("issue %s not found", ref)
+	}
+	return issue, nil
+}
+
+// Repo returns the owner and name of the issue's repository, parsed from its
+// URL, or empty strings when the URL is not an issue URL.
+func (i Issue) Repo() (owner, repo string) {
+	base, _, ok := strings.Cut(i.URL, "/issues/")
+	if !ok {
+		return "", ""
+	}
+	return git.ExtractOwnerRepo(base)
+}
+
+// SourceItem returns the issue as an item of the issues source and its
+// detail, so it renders with the same session templates as the picker.
+func (i Issue) SourceItem() (sources.Item, sources.Detail) {
+	item := sources.Item{
+		ID:       strconv.Itoa(i.Number),
+		Title:    i.Title,
+		Subtitle: fmt.Sprintf("#%d · %s", i.Number, i.State),
+		URI:      i.URL,
+		Fields: map[string]any{
+			"number": i.Number,
+			"title":  i.Title,
+			"state":  i.State,
+			"url":    i.URL,
+		},
+	}
+	return item, sources.Detail{Markdown: &sources.MarkdownDetail{Content: i.Body}}
+}
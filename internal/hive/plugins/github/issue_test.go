@@ -0,0 +1,83 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/sources"
+	"github.com/colonyops/hive/pkg/executil"
+)
+
+func TestFetchIssue(t *testing.T) {
+	out := []byte(`{"number":42,"title":"Fix login redirect","body":"Users land on /home.","url":"https://github.com/colonyops/hive/issues/42"}`)
+
+	t.Run("number resolved against repo", func(t *testing.T) {
+		exec := &executil.RecordingExecutor{Outputs: map[string][]byte{"gh": out}}
+		issue, err := FetchIssue(context.Background(), exec, "/src", "colonyops/hive", "#42")
+		require.NoError(t, err)
+
+		assert.Equal(t, Issue{
+			Number: 42,
+			Title:  "Fix login redirect",
+			Body:   "Users land on /home.",
+			URL:    "https://github.com/colonyops/hive/issues/42",
+		}, issue)
+		require.Len(t, exec.Commands, 1)
+		assert.Equal(t, "/src", exec.Commands[0].Dir)
+		assert.Equal(t, []string{"issue", "view", "42", "--json", "number,title,body,url,state", "--repo", "colonyops/hive"}, exec.Commands[0].Args)
+	})
+
+	t.Run("url ignores repo", func(t *testing.T) {
+		exec := &executil.RecordingExecutor{Outputs: map[string][]byte{"gh": out}}
+		_, err := FetchIssue(context.Background(), exec, "/src", "colonyops/hive", "https://github.com/colonyops/hive/issues/42")
+		require.NoError(t, err)
+		assert.NotContains(t, exec.Commands[0].Args, "--repo")
+	})
+
+	t.Run("gh failure", func(t *testing.T) {
+		exec := &executil.RecordingExecutor{
+			Outputs: map[string][]byte{"gh": []byte("could not resolve to an issue")},
+			Errors:  map[string]error{"gh": errors.New("exit status 1")},
+		}
+		_, err := FetchIssue(context.Background(), exec, "/src", "", "7")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "could not resolve to an issue")
+	})
+
+	t.Run("empty ref", func(t *testing.T) {
+		_, err := FetchIssue(context.Background(), &executil.RecordingExecutor{}, "/src", "", " ")
+		require.Error(t, err)
+	})
+}
+
+func TestIssueRepoAndSourceItem(t *testing.T) {
+	issue := Issue{Number: 42, Title: "Fix login redirect", Body: "Users land on /home.", URL: "https://github.com/colonyops/hive/issues/42", State: "OPEN"}
+
+	owner, repo := issue.Repo()
+	assert.Equal(t, "colonyops", owner)
+	assert.Equal(t, "hive", repo)
+	owner, _ = Issue{URL: "https://github.com/colonyops/hive/pull/42"}.Repo()
+	assert.Empty(t, owner)
+
+	item, detail := issue.SourceItem()
+	assert.Equal(t, "42", item.ID)
+	assert.Equal(t, "#42 · OPEN", item.Subtitle)
+	assert.Equal(t, 42, item.Fields["number"])
+	assert.Equal(t, issue.URL, item.Fields["url"])
+	require.NotNil(t, detail.Markdown)
+	assert.Equal(t, "Users land on /home.", detail.Markdown.Content)
+
+	rendered, err := sources.RenderSessionTemplates(sources.TemplateConfig{
+		Name:   "gh-{{ .Fields.number }}-{{ .Title }}",
+		Prompt: "Work on {{ .Title }}\n\n{{ .Fields.url }}\n\n{{ .Detail }}",
+		Tags:   []string{"issue-{{ .Fields.number }}"},
+	}, item, detail)
+	require.NoError(t, err)
+	assert.Equal(t, "gh-42-fix-login-redirect", rendered.Name)
+	assert.Equal(t, "Work on Fix login redirect\n\nhttps://github.com/colonyops/hive/issues/42\n\nUsers land on /home.", rendered.Prompt)
+	assert.Equal(t, []string{"issue-42"}, rendered.Tags)
+}
@@ -0,0 +1,224 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/pkg/executil"
+	"github.com/colonyops/hive/pkg/tmpl"
+)
+
+// PullRequest is a pull request to open for a branch.
+type PullRequest struct {
+	Branch string // head branch, pushed to origin
+	Base   string // base branch; empty uses the repository's default branch
+	Title  string
+	Body   string
+	Draft  bool
+}
+
+// PRTemplateData is the data available to the pull request title and body
+// templates.
+type PRTemplateData struct {
+	Name     string   // session name
+	Branch   string   // branch the pull request is opened from
+	Base     string   // branch the pull request targets
+	Prompt   string   // prompt the session was created with
+	Commits  []string // subjects of the branch's commits, oldest first
+	Issue    string   // number of the issue the session was created from
+	IssueURL string
+}
+
+// RenderPullRequest renders the configured title and body templates.
+func RenderPullRequest(r *tmpl.Renderer, cfg config.GitHubPRConfig, data PRTemplateData) (title, body string, err error) {
+	title, err = r.Render(cfg.Title, data)
+	if err != nil {
+		return "", "", fmt.Errorf("title template: %w", err)
+	}
+	body, err = r.Render(cfg.Body, data)
+	if err != nil {
+		return "", "", fmt.Errorf("body template: %w", err)
+	}
+	return strings.TrimSpace(title), strings.TrimSpace(body), nil
+}
+
+// BranchCommits returns the subjects of the commits on HEAD in dir that are
+// not on base, oldest first. base is compared as origin/<base>, falling back
+// to the local branch for bare-clone worktrees, which have no remote refs.
+func BranchCommits(ctx context.Context, executor executil.Executor, gitPath, dir, base string) ([]string, error) {
+	var out []byte
+	var err error
+	for _, ref := range []string{"origin/" + base, base} {
+		out, err = executor.RunDir(ctx, dir, gitPath, "--no-optional-locks", "log", "--reverse", "--format=%s", ref+"..HEAD")
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("git log %s..HEAD: %w", base, err)
+	}
+
+	var subjects []string
+	for line := range strings.SplitSeq(strings.TrimSpace(string(out)), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			subjects = append(subjects, line)
+		}
+	}
+	return subjects, nil
+}
+
+// PushBranch pushes branch in dir to origin and sets it as the upstream.
+func PushBranch(ctx context.Context, executor executil.Executor, gitPath, dir, branch string) error {
+	out, err := executor.RunDir(ctx, dir, gitPath, "push", "--set-upstream", "origin", branch)
+	if err != nil {
+		return fmt.Errorf("git push %s: %w: %s", branch, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// CreatePullRequest opens pr with gh from dir and returns its URL.
+func CreatePullRequest(ctx context.Context, executor executil.Executor, dir string, pr PullRequest) (string, error) {
+	if strings.TrimSpace(pr.Title) == "" {
+		return "", fmt.Errorf("pull request title is empty")
+	}
+
+	args := []string{"pr", "create", "--head", pr.Branch, "--title", pr.Title, "--body", pr.Body}
+	if pr.Base != "" {
+		args = append(args, "--base", pr.Base)
+	}
+	if pr.Draft {
+		args = append(args, "--draft")
+	}
+
+	out, err := executor.RunDir(ctx, dir, "gh", args...)
+	if err != nil {
+		return "", fmt.Errorf("gh pr create: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	// gh prints progress before the URL; the URL is the last line.
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1]), nil
+}
+
+// Summaries of a pull request's status checks.
+const (
+	ChecksPassing = "passing"
+	ChecksFailing = "failing"
+	ChecksPending = "pending"
+)
+
+// PullRequestStatus is the pull request of the branch checked out in a
+// directory, as gh pr view reports it. The github status provider caches it
+// and exposes it to preview templates and hive ls --json.
+type PullRequestStatus struct {
+	Number  int    `json:"number"`
+	URL     string `json:"url,omitempty"`
+	State   string `json:"state"` // OPEN, MERGED, or CLOSED
+	IsDraft bool   `json:"isDraft"`
+	// ReviewDecision is APPROVED, CHANGES_REQUESTED, or REVIEW_REQUIRED;
+	// empty when the repository does not require reviews.
+	ReviewDecision string `json:"reviewDecision,omitempty"`
+	Checks         string `json:"checks,omitempty"` // ChecksPassing, ChecksFailing, ChecksPending, or empty without checks
+	ChecksPassed   int    `json:"checksPassed,omitempty"`
+	ChecksFailed   int    `json:"checksFailed,omitempty"`
+	ChecksPending  int    `json:"checksPending,omitempty"`
+}
+
+// prViewJSON is the output of gh pr view --json.
+type prViewJSON struct {
+	Number            int    `json:"number"`
+	URL               string `json:"url"`
+	State             string `json:"state"`
+	IsDraft           bool   `json:"isDraft"`
+	ReviewDecision    string `json:"reviewDecision"`
+	StatusCheckRollup []struct {
+		Status     string `json:"status"`     // check runs: QUEUED, IN_PROGRESS, COMPLETED
+		Conclusion string `json:"conclusion"` // check runs, once completed
+		State      string `json:"state"`      // commit statuses: PENDING, SUCCESS, FAILURE, ERROR
+	} `json:"statusCheckRollup"`
+}
+
+// ViewPullRequest returns the pull request of the branch checked out in dir.
+// It fails when the branch has no pull request.
+func ViewPullRequest(ctx context.Context, executor executil.Executor, dir string) (PullRequestStatus, error) {
+	out, err := executor.RunDir(ctx, dir, "gh", "pr", "view", "--json", "number,url,state,isDraft,reviewDecision,statusCheckRollup")
+	if err != nil {
+		return PullRequestStatus{}, fmt.Errorf("gh pr view: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	var view prViewJSON
+	if err := json.Unmarshal(out, &view); err != nil {
+		return PullRequestStatus{}, fmt.Errorf("parse gh pr view output: %w", err)
+	}
+
+	status := PullRequestStatus{
+		Number:         view.Number,
+		URL:            view.URL,
+		State:          view.State,
+		IsDraft:        view.IsDraft,
+		ReviewDecision: view.ReviewDecision,
+	}
+	for _, check := range view.StatusCheckRollup {
+		result := check.State
+		if result == "" {
+			result = check.Conclusion
+			if check.Status != "COMPLETED" {
+				result = "PENDING"
+			}
+		}
+		switch result {
+		case "SUCCESS", "NEUTRAL", "SKIPPED":
+			status.ChecksPassed++
+		case "PENDING", "EXPECTED":
+			status.ChecksPending++
+		default:
+			status.ChecksFailed++
+		}
+	}
+	switch {
+	case status.ChecksFailed > 0:
+		status.Checks = ChecksFailing
+	case status.ChecksPending > 0:
+		status.Checks = ChecksPending
+	case status.ChecksPassed > 0:
+		status.Checks = ChecksPassing
+	}
+	return status, nil
+}
+
+// ReviewState summarizes the pull request for display: draft, open,
+// approved, changes requested, merged, or closed.
+func (pr PullRequestStatus) ReviewState() string {
+	switch {
+	case pr.State == "MERGED":
+		return "merged"
+	case pr.State == "CLOSED":
+		return "closed"
+	case pr.IsDraft:
+		return "draft"
+	case pr.ReviewDecision == "APPROVED":
+		return "approved"
+	case pr.ReviewDecision == "CHANGES_REQUESTED":
+		return "changes requested"
+	case pr.State == "OPEN":
+		return "open"
+	default:
+		return strings.ToLower(pr.State)
+	}
+}
+
+// ChecksSummary counts the pull request's checks as "✓ 5 / ✗ 1", adding
+// "● 2" for pending checks. It is empty without checks.
+func (pr PullRequestStatus) ChecksSummary() string {
+	if pr.ChecksPassed+pr.ChecksFailed+pr.ChecksPending == 0 {
+		return ""
+	}
+	summary := fmt.Sprintf("✓ %d / ✗ %d", pr.ChecksPassed, pr.ChecksFailed)
+	if pr.ChecksPending > 0 {
+		summary += fmt.Sprintf(" / ● %d", pr.ChecksPending)
+	}
+	return summary
+}
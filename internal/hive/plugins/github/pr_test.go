@@ -0,0 +1,139 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/pkg/executil"
+	"github.com/colonyops/hive/pkg/tmpl"
+)
+
+func TestRenderPullRequest_Defaults(t *testing.T) {
+	cfg := config.DefaultConfig().Plugins.GitHub.PR
+	r := tmpl.New(tmpl.Config{})
+
+	title, body, err := RenderPullRequest(r, cfg, PRTemplateData{
+		Name:    "fix-login",
+		Prompt:  "Fix the login redirect.",
+		Commits: []string{"Redirect to the saved page after login"},
+		Issue:   "42",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Redirect to the saved page after login", title)
+	assert.Equal(t, "## Task\n\nFix the login redirect.\n\n## Commits\n\n- Redirect to the saved page after login\n\nCloses #42", body)
+
+	title, body, err = RenderPullRequest(r, cfg, PRTemplateData{
+		Name:    "fix-login",
+		Commits: []string{"Add test", "Fix redirect"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "fix-login", title, "several commits use the session name")
+	assert.Equal(t, "## Commits\n\n- Add test\n- Fix redirect", body)
+
+	_, _, err = RenderPullRequest(r, config.GitHubPRConfig{Title: "{{ .Missing }}"}, PRTemplateData{})
+	require.ErrorContains(t, err, "title template")
+}
+
+func TestBranchCommits(t *testing.T) {
+	exec := &executil.RecordingExecutor{Outputs: map[string][]byte{"git": []byte("Add test\nFix redirect\n")}}
+
+	commits, err := BranchCommits(context.Background(), exec, "git", "/src", "main")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Add test", "Fix redirect"}, commits)
+	require.Len(t, exec.Commands, 1)
+	assert.Equal(t, []string{"--no-optional-locks", "log", "--reverse", "--format=%s", "origin/main..HEAD"}, exec.Commands[0].Args)
+
+	failing := &executil.RecordingExecutor{Errors: map[string]error{"git": errors.New("exit status 128")}}
+	_, err = BranchCommits(context.Background(), failing, "git", "/src", "main")
+	require.Error(t, err)
+	require.Len(t, failing.Commands, 2, "falls back to the local base branch")
+	assert.Equal(t, "main..HEAD", failing.Commands[1].Args[4])
+}
+
+func TestCreatePullRequest(t *testing.T) {
+	exec := &executil.RecordingExecutor{Outputs: map[string][]byte{
+		"gh": []byte("Creating pull request for fix-login into main\n\nhttps://github.com/colonyops/hive/pull/7\n"),
+	}}
+
+	url, err := CreatePullRequest(context.Background(), exec, "/src", PullRequest{
+		Branch: "fix-login",
+		Base:   "main",
+		Title:  "Fix login",
+		Body:   "Body",
+		Draft:  true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/colonyops/hive/pull/7", url)
+	assert.Equal(t, "/src", exec.Commands[0].Dir)
+	assert.Equal(t, []string{"pr", "create", "--head", "fix-login", "--title", "Fix login", "--body", "Body", "--base", "main", "--draft"}, exec.Commands[0].Args)
+
+	_, err = CreatePullRequest(context.Background(), exec, "/src", PullRequest{Branch: "fix-login", Title: " "})
+	require.Error(t, err)
+}
+
+func TestViewPullRequest(t *testing.T) {
+	exec := &executil.RecordingExecutor{Outputs: map[string][]byte{"gh": []byte(`{
+		"number": 7, "url": "https://github.com/colonyops/hive/pull/7", "state": "OPEN", "isDraft": false,
+		"reviewDecision": "APPROVED",
+		"statusCheckRollup": [
+			{"__typename": "CheckRun", "status": "COMPLETED", "conclusion": "SUCCESS"},
+			{"__typename": "CheckRun", "status": "IN_PROGRESS", "conclusion": ""},
+			{"__typename": "StatusContext", "state": "SUCCESS"}
+		]}`)}}
+
+	pr, err := ViewPullRequest(context.Background(), exec, "/src")
+	require.NoError(t, err)
+	assert.Equal(t, PullRequestStatus{
+		Number:         7,
+		URL:            "https://github.com/colonyops/hive/pull/7",
+		State:          "OPEN",
+		ReviewDecision: "APPROVED",
+		Checks:         ChecksPending,
+		ChecksPassed:   2,
+		ChecksPending:  1,
+	}, pr)
+	assert.Equal(t, "/src", exec.Commands[0].Dir)
+
+	exec.Outputs["gh"] = []byte(`{"number": 7, "state": "OPEN", "statusCheckRollup": [
+		{"status": "IN_PROGRESS"}, {"status": "COMPLETED", "conclusion": "FAILURE"}, {"status": "COMPLETED", "conclusion": "SKIPPED"}]}`)
+	pr, err = ViewPullRequest(context.Background(), exec, "/src")
+	require.NoError(t, err)
+	assert.Equal(t, ChecksFailing, pr.Checks)
+	assert.Equal(t, "✓ 1 / ✗ 1 / ● 1", pr.ChecksSummary())
+
+	exec.Outputs["gh"] = []byte(`{"number": 7, "state": "MERGED", "statusCheckRollup": []}`)
+	pr, err = ViewPullRequest(context.Background(), exec, "/src")
+	require.NoError(t, err)
+	assert.Empty(t, pr.Checks)
+	assert.Empty(t, pr.ChecksSummary())
+
+	failing := &executil.RecordingExecutor{Errors: map[string]error{"gh": errors.New("exit status 1")}}
+	_, err = ViewPullRequest(context.Background(), failing, "/src")
+	require.Error(t, err)
+}
+
+func TestPRToStatus(t *testing.T) {
+	assert.Empty(t, prToStatus(PullRequestStatus{}).Label, "no pull request")
+
+	tests := []struct {
+		pr    PullRequestStatus
+		label string
+	}{
+		{PullRequestStatus{Number: 1, State: "OPEN", IsDraft: true, ReviewDecision: "APPROVED"}, "draft"},
+		{PullRequestStatus{Number: 1, State: "OPEN", ReviewDecision: "REVIEW_REQUIRED"}, "open"},
+		{PullRequestStatus{Number: 1, State: "OPEN", ReviewDecision: "APPROVED", ChecksPassed: 5, ChecksFailed: 1}, "approved ✓ 5 / ✗ 1"},
+		{PullRequestStatus{Number: 1, State: "OPEN", ReviewDecision: "CHANGES_REQUESTED"}, "changes requested"},
+		{PullRequestStatus{Number: 1, State: "MERGED", ReviewDecision: "APPROVED", ChecksPassed: 5}, "merged"},
+		{PullRequestStatus{Number: 1, State: "CLOSED"}, "closed"},
+	}
+	for _, tt := range tests {
+		status := prToStatus(tt.pr)
+		assert.Equal(t, tt.label, status.Label)
+		assert.Equal(t, tt.pr, status.Data)
+	}
+}
@@ -2,10 +2,12 @@ package plugins
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/hive/supervisor"
 	"github.com/rs/zerolog/log"
 )
 
@@ -25,6 +27,12 @@ type Manager struct {
 	commandSet *CommandSet
 	mu         sync.RWMutex
 
+	pollObserver func(plugin string, d time.Duration) // guarded by mu
+
+	// supervisor restarts background workers that panic or wedge; nil runs
+	// them unsupervised.
+	supervisor *supervisor.Supervisor
+
 	// Background worker state
 	collector     *StatusCollector
 	jobs          chan Job
@@ -128,6 +136,12 @@ func (m *Manager) Collector() *StatusCollector {
 	return m.collector
 }
 
+// SetSupervisor supervises the background workers started by
+// StartBackgroundWorker with s. Call it before starting them.
+func (m *Manager) SetSupervisor(s *supervisor.Supervisor) {
+	m.supervisor = s
+}
+
 // StartBackgroundWorker starts background workers that fetch plugin statuses.
 // Returns a channel that receives results as they complete.
 // Call Stop() to shut down the workers.
@@ -149,13 +163,21 @@ func (m *Manager) StartBackgroundWorker(ctx context.Context, pollInterval time.D
 
 	// Start workers
 	for i := 0; i < m.workerCount; i++ {
-		m.wg.Add(1)
-		go m.worker(ctx, i)
+		m.wg.Go(func() {
+			m.supervisor.Run(ctx, fmt.Sprintf("plugins.worker-%d", i), supervisor.Options{}, func(ctx context.Context) error {
+				m.worker(ctx, i)
+				return nil
+			})
+		})
 	}
 
 	// Start scheduler
-	m.wg.Add(1)
-	go m.scheduler(ctx, pollInterval)
+	m.wg.Go(func() {
+		m.supervisor.Run(ctx, "plugins.scheduler", supervisor.Options{StaleAfter: -1}, func(ctx context.Context) error {
+			m.scheduler(ctx, pollInterval)
+			return nil
+		})
+	})
 
 	// Create output channel and start forwarder
 	output := make(chan Result, defaultResultBufferSize)
@@ -235,8 +257,6 @@ func (m *Manager) Stop() {
 
 // scheduler runs the polling loop that enqueues jobs.
 func (m *Manager) scheduler(ctx context.Context, pollInterval time.Duration) {
-	defer m.wg.Done()
-
 	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
 
@@ -248,8 +268,10 @@ func (m *Manager) scheduler(ctx context.Context, pollInterval time.Duration) {
 		case <-ctx.Done():
 			return
 		case <-m.refreshTrigger:
+			supervisor.Beat(ctx)
 			m.enqueueAllJobs(ctx)
 		case <-ticker.C:
+			supervisor.Beat(ctx)
 			m.enqueueAllJobs(ctx)
 		}
 	}
@@ -303,8 +325,6 @@ func (m *Manager) enqueueAllJobs(ctx context.Context) {
 
 // worker processes jobs from the jobs channel.
 func (m *Manager) worker(ctx context.Context, id int) {
-	defer m.wg.Done()
-
 	log.Debug().Int("workerID", id).Msg("worker started")
 
 	for {
@@ -317,7 +337,9 @@ func (m *Manager) worker(ctx context.Context, id int) {
 				log.Debug().Int("workerID", id).Msg("worker stopping (channel closed)")
 				return
 			}
+			done := supervisor.Busy(ctx)
 			m.processJob(ctx, job)
+			done()
 		}
 	}
 }
@@ -327,6 +349,7 @@ func (m *Manager) processJob(ctx context.Context, job Job) {
 	// Get the plugin
 	m.mu.RLock()
 	plugin, ok := m.plugins[job.PluginName]
+	observe := m.pollObserver
 	m.mu.RUnlock()
 
 	if !ok {
@@ -342,7 +365,11 @@ func (m *Manager) processJob(ctx context.Context, job Job) {
 	err := m.pool.RunContext(ctx, func() {
 		// Call RefreshStatus with a single session
 		sessions := []*session.Session{job.Session}
+		start := time.Now()
 		statuses, err := provider.RefreshStatus(ctx, sessions, m.pool)
+		if observe != nil {
+			observe(job.PluginName, time.Since(start))
+		}
 
 		result := Result{
 			PluginName: job.PluginName,
@@ -452,6 +479,14 @@ func (m *Manager) RefreshAllStatus(ctx context.Context, sessions []*session.Sess
 	return results
 }
 
+// ObservePolls reports how long each plugin status refresh made by the
+// background worker takes to fn. Passing nil stops reporting.
+func (m *Manager) ObservePolls(fn func(plugin string, d time.Duration)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pollObserver = fn
+}
+
 // Get returns a plugin by name, or nil if not found.
 func (m *Manager) Get(name string) Plugin {
 	m.mu.RLock()
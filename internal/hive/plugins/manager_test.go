@@ -3,9 +3,12 @@ package plugins
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/colonyops/hive/internal/core/config"
 	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/hive/supervisor"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -161,3 +164,78 @@ func TestSessionsChanged(t *testing.T) {
 		})
 	}
 }
+
+// statusPlugin is a mockPlugin that reports a fixed status for every session.
+type statusPlugin struct {
+	mockPlugin
+}
+
+func (p *statusPlugin) StatusProvider() StatusProvider     { return p }
+func (p *statusPlugin) StatusCacheDuration() time.Duration { return time.Minute }
+
+func (p *statusPlugin) RefreshStatus(_ context.Context, sessions []*session.Session, _ *WorkerPool) (map[string]Status, error) {
+	statuses := make(map[string]Status, len(sessions))
+	for _, s := range sessions {
+		statuses[s.ID] = Status{Label: "ok"}
+	}
+	return statuses, nil
+}
+
+func TestManager_ObservePolls(t *testing.T) {
+	mgr := NewManager(NewWorkerPool(0), NewCommandSet(nil, nil))
+	mgr.Register(&statusPlugin{mockPlugin{name: "probe"}})
+
+	var polled []string
+	mgr.ObservePolls(func(plugin string, d time.Duration) {
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		polled = append(polled, plugin)
+	})
+
+	sess := &session.Session{ID: "a"}
+	mgr.processJob(context.Background(), Job{PluginName: "probe", SessionID: sess.ID, Session: sess})
+	assert.Equal(t, []string{"probe"}, polled)
+
+	result := <-mgr.results
+	assert.Equal(t, "ok", result.Status.Label)
+}
+
+// panickyPlugin is a statusPlugin that panics when refreshing session "bad".
+type panickyPlugin struct {
+	statusPlugin
+}
+
+func (p *panickyPlugin) StatusProvider() StatusProvider { return p }
+
+func (p *panickyPlugin) RefreshStatus(ctx context.Context, sessions []*session.Session, pool *WorkerPool) (map[string]Status, error) {
+	if sessions[0].ID == "bad" {
+		panic("nil map")
+	}
+	return p.statusPlugin.RefreshStatus(ctx, sessions, pool)
+}
+
+func TestManager_BackgroundWorkerRecoversFromPanic(t *testing.T) {
+	sup := supervisor.New(zerolog.Nop())
+	mgr := NewManager(NewWorkerPool(0), NewCommandSet(nil, nil))
+	mgr.SetSupervisor(sup)
+	mgr.Register(&panickyPlugin{statusPlugin{mockPlugin{name: "probe"}}})
+	mgr.UpdateSessions([]*session.Session{{ID: "bad"}, {ID: "good"}})
+
+	results := mgr.StartBackgroundWorker(context.Background(), time.Hour)
+	defer mgr.Stop()
+
+	select {
+	case result := <-results:
+		assert.Equal(t, "good", result.SessionID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no result from the surviving workers")
+	}
+
+	require.Eventually(t, func() bool {
+		for _, h := range sup.Health() {
+			if h.Restarts == 1 {
+				return h.LastError == "panic: nil map"
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+}
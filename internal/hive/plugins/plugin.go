@@ -53,4 +53,5 @@ type Status struct {
 	Label string         // e.g., "0/3", "PR#42", "main +2/-1"
 	Icon  string         // e.g., "●", "◆", "!"
 	Style lipgloss.Style // color/formatting
+	Data  any            // raw data behind the label, for preview templates; optional
 }
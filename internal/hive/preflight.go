@@ -0,0 +1,79 @@
+package hive
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/config"
+)
+
+// dockerCheckTimeout bounds the docker preflight check; docker info can hang
+// when the daemon socket exists but the daemon does not respond.
+const dockerCheckTimeout = 10 * time.Second
+
+// checkPreflight verifies the preflight checks for a new session of remote
+// and returns an error listing every failed check. Checks inspect the local
+// machine, so they are skipped for sessions on a remote host.
+func (s *SessionService) checkPreflight(ctx context.Context, remote, agentKey string, batch bool) error {
+	checks := s.config.GetPreflight(remote, agentKey, batch)
+	if len(checks) == 0 {
+		return nil
+	}
+	if s.host != "" {
+		s.log.Warn().Str("host", s.host).Msg("skipping preflight checks for remote session")
+		return nil
+	}
+
+	var failures []string
+	for _, check := range checks {
+		if err := s.runPreflightCheck(ctx, check); err != nil {
+			msg := err.Error()
+			if check.Hint != "" {
+				msg += " (" + check.Hint + ")"
+			}
+			failures = append(failures, msg)
+		}
+	}
+
+	switch len(failures) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("preflight check failed: %s", failures[0])
+	default:
+		return fmt.Errorf("%d preflight checks failed:\n  - %s", len(failures), strings.Join(failures, "\n  - "))
+	}
+}
+
+// runPreflightCheck verifies a single preflight check.
+func (s *SessionService) runPreflightCheck(ctx context.Context, check config.PreflightCheck) error {
+	switch {
+	case check.Command != "":
+		if _, err := exec.LookPath(check.Command); err != nil {
+			return fmt.Errorf("command %q not found on PATH", check.Command)
+		}
+	case check.Env != "":
+		if os.Getenv(check.Env) == "" {
+			return fmt.Errorf("environment variable %s is not set", check.Env)
+		}
+	case check.Port != 0:
+		ln, err := net.Listen("tcp", net.JoinHostPort("localhost", strconv.Itoa(check.Port)))
+		if err != nil {
+			return fmt.Errorf("port %d is already in use", check.Port)
+		}
+		_ = ln.Close()
+	case check.Docker:
+		ctx, cancel := context.WithTimeout(ctx, dockerCheckTimeout)
+		defer cancel()
+		if _, err := s.executor.Run(ctx, "docker", "info"); err != nil {
+			return fmt.Errorf("docker is not running: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package hive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/eventbus/testbus"
+	"github.com/colonyops/hive/pkg/executil/executiltest"
+	"github.com/colonyops/hive/pkg/tmpl"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPreflightService(t *testing.T, checks []config.PreflightCheck, exec *executiltest.Exec) (*SessionService, *mockStore) {
+	t.Helper()
+	store := newMockStore()
+	cfg := &config.Config{
+		DataDir: t.TempDir(),
+		GitPath: "git",
+		Rules:   []config.Rule{{Preflight: checks}},
+	}
+	svc := NewSessionService(store, &mockGit{}, cfg, testbus.New(t).EventBus, exec, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard)
+	return svc, store
+}
+
+func TestCheckPreflight(t *testing.T) {
+	t.Setenv("HIVE_PREFLIGHT_SET", "1")
+
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	busyPort := ln.Addr().(*net.TCPAddr).Port
+
+	t.Run("passing checks", func(t *testing.T) {
+		exec := &executiltest.Exec{}
+		svc, _ := newPreflightService(t, []config.PreflightCheck{
+			{Command: "go"},
+			{Env: "HIVE_PREFLIGHT_SET"},
+			{Docker: true},
+		}, exec)
+
+		require.NoError(t, svc.checkPreflight(context.Background(), "git@github.com:org/repo.git", "", false))
+		require.Len(t, exec.Calls(), 1)
+		assert.Equal(t, []string{"info"}, exec.Calls()[0].Args)
+	})
+
+	t.Run("failing checks are all reported", func(t *testing.T) {
+		exec := &executiltest.Exec{Responses: []executiltest.Response{{Err: errors.New("cannot connect to the docker daemon")}}}
+		svc, _ := newPreflightService(t, []config.PreflightCheck{
+			{Command: "hive-preflight-missing", Hint: "install it with make tools"},
+			{Env: "HIVE_PREFLIGHT_UNSET"},
+			{Port: busyPort},
+			{Docker: true},
+		}, exec)
+
+		err := svc.checkPreflight(context.Background(), "git@github.com:org/repo.git", "", false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "4 preflight checks failed")
+		assert.Contains(t, err.Error(), `command "hive-preflight-missing" not found on PATH (install it with make tools)`)
+		assert.Contains(t, err.Error(), "environment variable HIVE_PREFLIGHT_UNSET is not set")
+		assert.Contains(t, err.Error(), "port "+strconv.Itoa(busyPort)+" is already in use")
+		assert.Contains(t, err.Error(), "docker is not running")
+	})
+}
+
+func TestCreateSession_PreflightFailsBeforeClone(t *testing.T) {
+	svc, store := newPreflightService(t, []config.PreflightCheck{{Env: "HIVE_PREFLIGHT_UNSET"}}, &executiltest.Exec{})
+
+	_, err := svc.CreateSession(context.Background(), CreateOptions{Name: "fix-auth", Remote: "git@github.com:org/repo.git"})
+	require.ErrorContains(t, err, "preflight check failed: environment variable HIVE_PREFLIGHT_UNSET is not set")
+
+	sessions, err := store.List(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+
+	_, err = svc.CreateSession(context.Background(), CreateOptions{Name: "fix-auth", Remote: "git@github.com:org/repo.git", SkipSpawn: true})
+	assert.NoError(t, err, "preflight checks only guard spawning")
+}
@@ -49,7 +49,8 @@ func (r *Recycler) Recycle(ctx context.Context, path string, commands []string,
 
 		r.log.Debug().Str("command", rendered).Msg("executing recycle command")
 
-		if err := r.executor.RunDirStream(ctx, path, w, w, "sh", "-c", rendered); err != nil {
+		shell, args := executil.Shell(rendered)
+		if err := r.executor.RunDirStream(ctx, path, w, w, shell, args...); err != nil {
 			return fmt.Errorf("execute recycle command %q: %w", rendered, err)
 		}
 	}
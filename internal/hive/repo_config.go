@@ -0,0 +1,57 @@
+package hive
+
+import (
+	"fmt"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/session"
+)
+
+// repoConfig returns the validated repository config in dir for sessions of
+// remote, or nil when no rule trusts it (repo_config) or dir has none. It is
+// read from the local filesystem, so sessions on a remote host have none.
+func (s *SessionService) repoConfig(remote, dir string) (*config.RepoConfig, error) {
+	if s.host != "" || dir == "" || !s.config.GetRepoConfig(remote) {
+		return nil, nil
+	}
+	rc, err := config.LoadRepoConfig(dir)
+	if err != nil || rc == nil {
+		return nil, err
+	}
+	if err := s.config.ValidateRepoConfig(rc); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", config.RepoConfigFile, err)
+	}
+	return rc, nil
+}
+
+// configFor returns the config for a session of remote cloned in dir: the
+// global config with the repository config applied. When the repository
+// config cannot be read, the error is returned with the global config.
+func (s *SessionService) configFor(remote, dir string) (*config.Config, error) {
+	rc, err := s.repoConfig(remote, dir)
+	if err != nil || rc == nil {
+		return s.config, err
+	}
+	return s.config.WithRepoConfig(rc), nil
+}
+
+// configForSession is configFor for an existing session, falling back to the
+// global config when its repository config is invalid.
+func (s *SessionService) configForSession(sess *session.Session) *config.Config {
+	cfg, err := s.configFor(sess.Remote, sess.Path)
+	if err != nil {
+		s.log.Warn().Err(err).Str("session_id", sess.ID).Msg("ignoring repository config")
+	}
+	return cfg
+}
+
+// RepoUserCommands returns the user commands a session's repository config
+// adds. They replace global commands of the same name in the session's
+// command palette.
+func (s *SessionService) RepoUserCommands(sess *session.Session) (map[string]config.UserCommand, error) {
+	rc, err := s.repoConfig(sess.Remote, sess.Path)
+	if err != nil || rc == nil {
+		return nil, err
+	}
+	return rc.UserCommands, nil
+}
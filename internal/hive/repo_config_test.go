@@ -0,0 +1,89 @@
+package hive
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/eventbus/testbus"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/pkg/executil/executiltest"
+	"github.com/colonyops/hive/pkg/tmpl"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoConfig(t *testing.T) {
+	const repoConfig = `
+rules:
+  - sync: ["make sync"]
+usercommands:
+  test:
+    sh: make test
+`
+	defaultSync := []string{"git fetch origin", "git rebase origin/main"}
+
+	setup := func(t *testing.T, trusted bool, content string) (*SessionService, *executiltest.Exec, session.Session) {
+		t.Helper()
+		store := newMockStore()
+		cfg := &config.Config{DataDir: t.TempDir(), GitPath: "git"}
+		if trusted {
+			cfg.Rules = []config.Rule{{Pattern: ".*/example/.*", RepoConfig: new(true)}}
+		}
+		exec := &executiltest.Exec{}
+		svc := NewSessionService(store, &mockGit{}, cfg, testbus.New(t).EventBus, exec, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard)
+
+		sess := session.Session{
+			ID:     "abc123",
+			Name:   "my-session",
+			Slug:   "my-session",
+			State:  session.StateActive,
+			Path:   t.TempDir(),
+			Remote: "https://github.com/example/repo.git",
+		}
+		require.NoError(t, os.WriteFile(filepath.Join(sess.Path, config.RepoConfigFile), []byte(content), 0o644))
+		require.NoError(t, store.Save(context.Background(), sess))
+		return svc, exec, sess
+	}
+
+	syncCommands := func(t *testing.T, svc *SessionService, exec *executiltest.Exec) []string {
+		t.Helper()
+		require.NoError(t, svc.SyncSession(context.Background(), "abc123", io.Discard))
+		var cmds []string
+		for _, call := range exec.Calls() {
+			cmds = append(cmds, call.Args[len(call.Args)-1])
+		}
+		return cmds
+	}
+
+	t.Run("trusted", func(t *testing.T) {
+		svc, exec, sess := setup(t, true, repoConfig)
+
+		assert.Equal(t, []string{"make sync"}, syncCommands(t, svc, exec))
+		cmds, err := svc.RepoUserCommands(&sess)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]config.UserCommand{"test": {Sh: "make test"}}, cmds)
+	})
+
+	t.Run("untrusted", func(t *testing.T) {
+		svc, exec, sess := setup(t, false, repoConfig)
+
+		assert.Equal(t, defaultSync, syncCommands(t, svc, exec))
+		cmds, err := svc.RepoUserCommands(&sess)
+		require.NoError(t, err)
+		assert.Empty(t, cmds)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		svc, exec, sess := setup(t, true, "rules:\n  - sync: [\"make sync\"]\n    base_branch: develop\n")
+
+		assert.Equal(t, defaultSync, syncCommands(t, svc, exec), "an invalid repository config is ignored")
+		_, err := svc.RepoUserCommands(&sess)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "rules[0].base_branch")
+	})
+}
@@ -0,0 +1,121 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+// timeLayout formats the report's timestamps.
+const timeLayout = "2006-01-02 15:04 MST"
+
+// checkBadges are the Markdown badges of pull request checks.
+var checkBadges = map[string]string{
+	"passing": "✅ passing",
+	"failing": "❌ failing",
+	"pending": "⏳ pending",
+}
+
+// Markdown writes the report as a Markdown document with one table per
+// repository.
+func (r Report) Markdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Hive status: %s\n\n", r.GeneratedAt.Format(timeLayout))
+	fmt.Fprintf(&b, "%s.", r.Summary())
+	if r.StatusesAt == nil {
+		b.WriteString(" Agent statuses are unavailable (no hive TUI is polling).")
+	}
+	b.WriteString("\n")
+
+	for _, repo := range r.Repos {
+		fmt.Fprintf(&b, "\n## %s\n\n", repo.Name)
+		b.WriteString("| Session | Status | Branch | Pull request | Checks | Reviews |\n")
+		b.WriteString("| ------- | ------ | ------ | ------------ | ------ | ------- |\n")
+		for _, s := range repo.Sessions {
+			pr, checks := "", ""
+			if s.PR != nil {
+				pr = fmt.Sprintf("[#%d](%s) %s", s.PR.Number, s.PR.URL, s.PR.State)
+				checks = checkBadges[s.PR.Checks]
+			}
+			reviews := ""
+			if s.Reviews > 0 {
+				reviews = fmt.Sprint(s.Reviews)
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n",
+				mdCell(s.Name), mdCell(s.Status), mdCell(s.Branch), pr, checks, reviews)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mdCell escapes s for a Markdown table cell.
+func mdCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// HTML writes the report as a standalone HTML page.
+func (r Report) HTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, r)
+}
+
+// Summary is the report's one-line overview: "7 active sessions in 3 repositories".
+func (r Report) Summary() string {
+	sessions, repos := "sessions", "repositories"
+	if r.Sessions == 1 {
+		sessions = "session"
+	}
+	if len(r.Repos) == 1 {
+		repos = "repository"
+	}
+	return fmt.Sprintf("%d active %s in %d %s", r.Sessions, sessions, len(r.Repos), repos)
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"timestamp": func(t time.Time) string { return t.Format(timeLayout) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Hive status: {{ timestamp .GeneratedAt }}</title>
+<style>
+  body { font-family: system-ui, sans-serif; margin: 2rem; color: #222; }
+  table { border-collapse: collapse; margin-bottom: 1.5rem; }
+  th, td { text-align: left; padding: 0.3rem 0.8rem; border-bottom: 1px solid #ddd; }
+  th { font-weight: 600; }
+  .badge { border-radius: 0.6rem; padding: 0.05rem 0.5rem; font-size: 0.85em; }
+  .status-active { background: #dcfce7; }
+  .status-approval { background: #fef9c3; }
+  .status-ready { background: #cffafe; }
+  .status-missing { background: #f3f4f6; }
+  .checks-passing { background: #dcfce7; }
+  .checks-failing { background: #fee2e2; }
+  .checks-pending { background: #fef9c3; }
+  .muted { color: #777; }
+</style>
+</head>
+<body>
+<h1>Hive status: {{ timestamp .GeneratedAt }}</h1>
+<p>{{ .Summary }}.{{ if not .StatusesAt }} <span class="muted">Agent statuses are unavailable (no hive TUI is polling).</span>{{ end }}</p>
+{{- range .Repos }}
+<h2>{{ .Name }}</h2>
+<table>
+<tr><th>Session</th><th>Status</th><th>Branch</th><th>Pull request</th><th>Checks</th><th>Reviews</th></tr>
+{{- range .Sessions }}
+<tr>
+  <td>{{ .Name }}</td>
+  <td>{{ with .Status }}<span class="badge status-{{ . }}">{{ . }}</span>{{ end }}</td>
+  <td>{{ .Branch }}</td>
+  <td>{{ with .PR }}<a href="{{ .URL }}">#{{ .Number }}</a> {{ .State }}{{ end }}</td>
+  <td>{{ with .PR }}{{ with .Checks }}<span class="badge checks-{{ . }}">{{ . }}</span>{{ end }}{{ end }}</td>
+  <td>{{ if .Reviews }}{{ .Reviews }}{{ end }}</td>
+</tr>
+{{- end }}
+</table>
+{{- end }}
+</body>
+</html>
+`))
@@ -0,0 +1,180 @@
+// Package report builds the status report printed by hive report: active
+// sessions grouped by repository with their agent status, branch, pull
+// request, and open reviews, rendered as Markdown or HTML for pasting into a
+// standup note or sending on.
+package report
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/hive/plugins/github"
+)
+
+// lookupWorkers bounds the concurrent git and gh calls made for sessions.
+const lookupWorkers = 4
+
+// Sources are the reads a report is built from.
+type Sources struct {
+	Sessions func(ctx context.Context) ([]session.Session, error)
+	// Statuses returns the agent statuses from the last TUI poll, or nil
+	// when no TUI is polling.
+	Statuses func(ctx context.Context) (*terminal.StatusSnapshot, error)
+	// Git returns the version control backend for a session. Nil omits
+	// branches.
+	Git func(sess *session.Session) git.VCS
+	// PullRequest returns the pull request of a session's branch. An error
+	// means the session has none. Nil omits pull requests.
+	PullRequest func(ctx context.Context, sess *session.Session) (github.PullRequestStatus, error)
+	// Reviews returns the number of open reviews per session ID. Nil omits
+	// review counts.
+	Reviews func(ctx context.Context, sessions []session.Session) (map[string]int, error)
+}
+
+// Report is a snapshot of the active sessions.
+type Report struct {
+	GeneratedAt time.Time
+	// StatusesAt is when the agent statuses were polled; nil when no TUI
+	// is polling and statuses are unknown.
+	StatusesAt *time.Time
+	Sessions   int
+	Repos      []Repo
+}
+
+// Repo is the sessions of one repository.
+type Repo struct {
+	Name     string
+	Remote   string
+	Sessions []Session
+}
+
+// Session is one line of the report.
+type Session struct {
+	ID      string
+	Name    string
+	Status  string // agent status from the last TUI poll
+	Branch  string
+	Tags    []string
+	PR      *PullRequest
+	Reviews int // open review sessions with comments
+}
+
+// PullRequest is the pull request of a session's branch.
+type PullRequest struct {
+	Number int
+	URL    string
+	State  string // as github.PullRequestStatus.ReviewState reports it
+	Checks string // passing, failing, or pending
+}
+
+// Build reads the active sessions from src and assembles a report. Sessions
+// are grouped by repository; repositories and the sessions in them are
+// sorted by name.
+func Build(ctx context.Context, src Sources, now time.Time) (Report, error) {
+	all, err := src.Sessions(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+	active := make([]session.Session, 0, len(all))
+	for _, s := range all {
+		if s.State == session.StateActive {
+			active = append(active, s)
+		}
+	}
+
+	r := Report{GeneratedAt: now, Sessions: len(active)}
+
+	var statuses map[string]terminal.Status
+	if src.Statuses != nil {
+		snap, err := src.Statuses(ctx)
+		if err != nil {
+			return Report{}, fmt.Errorf("read statuses: %w", err)
+		}
+		if snap != nil {
+			statuses = snap.Statuses
+			r.StatusesAt = &snap.UpdatedAt
+		}
+	}
+
+	var reviews map[string]int
+	if src.Reviews != nil {
+		if reviews, err = src.Reviews(ctx, active); err != nil {
+			log.Debug().Err(err).Msg("report: review counts")
+		}
+	}
+
+	rows := make([]Session, len(active))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, lookupWorkers)
+	for i := range active {
+		sess := &active[i]
+		rows[i] = Session{
+			ID:      sess.ID,
+			Name:    sess.Name,
+			Status:  string(statuses[sess.ID]),
+			Tags:    sess.Tags,
+			Reviews: reviews[sess.ID],
+		}
+		if src.Git == nil && src.PullRequest == nil {
+			continue
+		}
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			rows[i].Branch, rows[i].PR = lookup(ctx, src, sess)
+		})
+	}
+	wg.Wait()
+
+	byRemote := make(map[string]*Repo)
+	for i := range active {
+		remote := active[i].Remote
+		repo, ok := byRemote[remote]
+		if !ok {
+			name := "(no remote)"
+			if remote != "" {
+				name = git.ExtractRepoName(remote)
+			}
+			repo = &Repo{Name: name, Remote: remote}
+			byRemote[remote] = repo
+		}
+		repo.Sessions = append(repo.Sessions, rows[i])
+	}
+	for _, repo := range byRemote {
+		slices.SortFunc(repo.Sessions, func(a, b Session) int { return cmp.Compare(a.Name, b.Name) })
+		r.Repos = append(r.Repos, *repo)
+	}
+	slices.SortFunc(r.Repos, func(a, b Repo) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Remote, b.Remote))
+	})
+	return r, nil
+}
+
+// lookup reads a session's branch and pull request. Failures leave them
+// empty; a session without a pull request is the common case.
+func lookup(ctx context.Context, src Sources, sess *session.Session) (string, *PullRequest) {
+	var branch string
+	if src.Git != nil {
+		var err error
+		if branch, err = src.Git(sess).Branch(ctx, sess.Path); err != nil {
+			log.Debug().Err(err).Str("session", sess.ID).Msg("report: branch")
+		}
+	}
+	if src.PullRequest == nil {
+		return branch, nil
+	}
+	pr, err := src.PullRequest(ctx, sess)
+	if err != nil || pr.Number == 0 {
+		return branch, nil
+	}
+	return branch, &PullRequest{Number: pr.Number, URL: pr.URL, State: pr.ReviewState(), Checks: pr.Checks}
+}
@@ -0,0 +1,123 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/hive/plugins/github"
+)
+
+// fakeVCS answers Branch with the session path's base; other methods panic.
+type fakeVCS struct{ git.VCS }
+
+func (fakeVCS) Branch(_ context.Context, dir string) (string, error) {
+	return "feat/" + dir[len("/src/"):], nil
+}
+
+func testSources() Sources {
+	return Sources{
+		Sessions: func(context.Context) ([]session.Session, error) {
+			return []session.Session{
+				{ID: "a", Name: "login", Path: "/src/login", Remote: "git@github.com:org/web.git", State: session.StateActive},
+				{ID: "b", Name: "api-docs", Path: "/src/docs", Remote: "git@github.com:org/api.git", State: session.StateActive},
+				{ID: "c", Name: "cache|fix", Path: "/src/cache", Remote: "git@github.com:org/api.git", State: session.StateActive},
+				{ID: "d", Name: "spare", Path: "/src/spare", Remote: "git@github.com:org/api.git", State: session.StateRecycled},
+			}, nil
+		},
+		Statuses: func(context.Context) (*terminal.StatusSnapshot, error) {
+			return &terminal.StatusSnapshot{
+				UpdatedAt: time.Now(),
+				Statuses:  map[string]terminal.Status{"a": terminal.StatusApproval, "c": terminal.StatusActive},
+			}, nil
+		},
+		Git: func(*session.Session) git.VCS { return fakeVCS{} },
+		PullRequest: func(_ context.Context, sess *session.Session) (github.PullRequestStatus, error) {
+			if sess.ID != "a" {
+				return github.PullRequestStatus{}, errors.New("no pull requests found")
+			}
+			return github.PullRequestStatus{Number: 42, URL: "https://github.com/org/web/pull/42", State: "OPEN", IsDraft: true, Checks: github.ChecksFailing}, nil
+		},
+		Reviews: func(context.Context, []session.Session) (map[string]int, error) {
+			return map[string]int{"b": 2}, nil
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	r, err := Build(context.Background(), testSources(), now)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, r.Sessions, "recycled sessions are left out")
+	require.Len(t, r.Repos, 2)
+	assert.Equal(t, "api", r.Repos[0].Name)
+	assert.Equal(t, []string{"api-docs", "cache|fix"}, []string{r.Repos[0].Sessions[0].Name, r.Repos[0].Sessions[1].Name})
+	assert.Equal(t, 2, r.Repos[0].Sessions[0].Reviews)
+
+	login := r.Repos[1].Sessions[0]
+	assert.Equal(t, "approval", login.Status)
+	assert.Equal(t, "feat/login", login.Branch)
+	require.NotNil(t, login.PR)
+	assert.Equal(t, PullRequest{Number: 42, URL: "https://github.com/org/web/pull/42", State: "draft", Checks: "failing"}, *login.PR)
+	assert.Nil(t, r.Repos[0].Sessions[0].PR)
+}
+
+func TestMarkdown(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	r, err := Build(context.Background(), testSources(), now)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, r.Markdown(&buf))
+	assert.Equal(t, `# Hive status: 2026-03-02 09:30 UTC
+
+3 active sessions in 2 repositories.
+
+## api
+
+| Session | Status | Branch | Pull request | Checks | Reviews |
+| ------- | ------ | ------ | ------------ | ------ | ------- |
+| api-docs |  | feat/docs |  |  | 2 |
+| cache\|fix | active | feat/cache |  |  |  |
+
+## web
+
+| Session | Status | Branch | Pull request | Checks | Reviews |
+| ------- | ------ | ------ | ------------ | ------ | ------- |
+| login | approval | feat/login | [#42](https://github.com/org/web/pull/42) draft | ❌ failing |  |
+`, buf.String())
+
+	src := testSources()
+	src.Statuses = func(context.Context) (*terminal.StatusSnapshot, error) { return nil, nil }
+	r, err = Build(context.Background(), src, now)
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, r.Markdown(&buf))
+	assert.Contains(t, buf.String(), "Agent statuses are unavailable")
+
+	src.Statuses = func(context.Context) (*terminal.StatusSnapshot, error) { return nil, errors.New("database is locked") }
+	_, err = Build(context.Background(), src, now)
+	require.ErrorContains(t, err, "database is locked")
+}
+
+func TestHTML(t *testing.T) {
+	r, err := Build(context.Background(), testSources(), time.Now())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, r.HTML(&buf))
+	html := buf.String()
+	assert.Contains(t, html, "<h2>api</h2>")
+	assert.Contains(t, html, `<a href="https://github.com/org/web/pull/42">#42</a> draft`)
+	assert.Contains(t, html, `<span class="badge checks-failing">failing</span>`)
+	assert.Contains(t, html, `<span class="badge status-approval">approval</span>`)
+}
@@ -41,6 +41,15 @@ func EnsureExtracted(dataDir, version string) error {
 		return nil
 	}
 
+	return Extract(dataDir, version)
+}
+
+// Extract writes bundled scripts to $dataDir/bin/ regardless of the version
+// marker, replacing missing or modified copies, and updates the marker.
+func Extract(dataDir, version string) error {
+	dir := BinDir(dataDir)
+	marker := filepath.Join(dir, ".version")
+
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return fmt.Errorf("create bin dir: %w", err)
 	}
@@ -89,6 +89,28 @@ func TestEnsureExtracted_ReExtractsOnVersionChange(t *testing.T) {
 	}
 }
 
+func TestExtract_IgnoresVersionMarker(t *testing.T) {
+	dataDir := t.TempDir()
+
+	if err := EnsureExtracted(dataDir, "v1.0.0"); err != nil {
+		t.Fatalf("first extraction: %v", err)
+	}
+
+	// Remove a script; the marker still matches the version
+	missing := filepath.Join(BinDir(dataDir), "agent-send")
+	if err := os.Remove(missing); err != nil {
+		t.Fatalf("remove script: %v", err)
+	}
+
+	if err := Extract(dataDir, "v1.0.0"); err != nil {
+		t.Fatalf("extract: %v", err)
+	}
+
+	if _, err := os.Stat(missing); err != nil {
+		t.Errorf("extract should restore missing script: %v", err)
+	}
+}
+
 func TestScriptPaths(t *testing.T) {
 	paths := ScriptPaths("/data")
 	if got := paths["hive-tmux"]; got != "/data/bin/hive-tmux" {
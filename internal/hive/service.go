@@ -2,10 +2,12 @@ package hive
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -15,6 +17,7 @@ import (
 	"github.com/colonyops/hive/internal/core/config"
 	"github.com/colonyops/hive/internal/core/eventbus"
 	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/kv"
 	"github.com/colonyops/hive/internal/core/messaging"
 	"github.com/colonyops/hive/internal/core/session"
 	coretmux "github.com/colonyops/hive/internal/core/tmux"
@@ -66,9 +69,33 @@ type CreateOptions struct {
 	AgentKey string
 	// Tags are user-defined labels attached to the session for external provider tracking.
 	Tags []string
+	// Metadata is stored on the session before it is saved, e.g. the issue
+	// it was created from.
+	Metadata map[string]string
+	// ForkFrom is the path of another checkout of the same repository. When
+	// set, the new session checks out a branch at that checkout's HEAD.
+	ForkFrom string
 	// Progress receives human-readable progress lines during session creation.
 	// When non-nil, service output (hooks, file copies) is also redirected here.
 	Progress io.Writer
+
+	// stdout and stderr receive this call's service output when Progress is
+	// nil, in place of the service writers. Set by recordCreation.
+	stdout, stderr io.Writer
+}
+
+// output returns the writers for the service output of a create call.
+// Each call gets its own, so concurrent creates don't capture each other's
+// output.
+func (s *SessionService) output(opts CreateOptions) (stdout, stderr io.Writer) {
+	switch {
+	case opts.Progress != nil:
+		return opts.Progress, opts.Progress
+	case opts.stdout != nil:
+		return opts.stdout, opts.stderr
+	default:
+		return s.out, s.err
+	}
 }
 
 // switchWriter is an io.Writer whose target can be swapped at runtime.
@@ -99,7 +126,7 @@ func (s *switchWriter) set(w io.Writer) io.Writer {
 // SessionService orchestrates hive session operations.
 type SessionService struct {
 	sessions   session.Store
-	git        git.Git
+	git        git.VCS
 	config     *config.Config
 	executor   executil.Executor
 	log        zerolog.Logger
@@ -112,12 +139,20 @@ type SessionService struct {
 	out        *switchWriter
 	err        *switchWriter
 	bareMu     sync.Map // map[remote → *sync.Mutex]
+
+	creationLogs *kv.TypedKV[CreationLog] // nil disables creation logs
+	sessionKV    kv.KV                    // nil disables session values
+	messages     messaging.Store          // nil keeps inboxes of deleted sessions
+	cleanups     sync.WaitGroup           // running DeleteSession verifications
+
+	files sessionFiles // session and clone directory operations
+	host  string       // remote host the sessions live on; empty when local
 }
 
 // NewSessionService creates a new SessionService.
 func NewSessionService(
 	sessions session.Store,
-	gitClient git.Git,
+	gitClient git.VCS,
 	cfg *config.Config,
 	bus *eventbus.EventBus,
 	exec executil.Executor,
@@ -136,11 +171,12 @@ func NewSessionService(
 		log:        log,
 		out:        out,
 		err:        err,
-		spawner:    NewSpawner(log.With().Str("component", "spawner").Logger(), exec, renderer, coretmux.New(exec, log.With().Str("component", "tmux").Logger()), out, err),
+		spawner:    NewSpawner(log.With().Str("component", "spawner").Logger(), exec, renderer, newSessionClient(exec, log), out, err),
 		recycler:   NewRecycler(log.With().Str("component", "recycler").Logger(), exec, renderer),
 		hookRunner: NewHookRunner(log.With().Str("component", "hooks").Logger(), exec, renderer, out, err),
 		fileCopier: NewFileCopier(log.With().Str("component", "copier").Logger(), out),
 		renderer:   renderer,
+		files:      localFiles{},
 	}
 }
 
@@ -156,21 +192,22 @@ func (s *SessionService) SilenceOutput() (restore func()) {
 	}
 }
 
-// CreateSession creates a new session or recycles an existing one.
+// CreateSession creates a new session or recycles an existing one. When
+// creation logs are enabled, its output is recorded (see SetCreationLogs).
 func (s *SessionService) CreateSession(ctx context.Context, opts CreateOptions) (*session.Session, error) {
+	if s.creationLogs != nil {
+		return s.recordCreation(ctx, opts, func(opts CreateOptions) (*session.Session, error) {
+			return s.createSession(ctx, opts)
+		})
+	}
+	return s.createSession(ctx, opts)
+}
+
+func (s *SessionService) createSession(ctx context.Context, opts CreateOptions) (*session.Session, error) {
 	s.log.Info().Str("name", opts.Name).Str("remote", opts.Remote).Msg("creating session")
 
 	progress := opts.Progress
-
-	// Redirect service output to progress writer when provided.
-	if progress != nil {
-		prevOut := s.out.set(progress)
-		prevErr := s.err.set(progress)
-		defer func() {
-			s.out.set(prevOut)
-			s.err.set(prevErr)
-		}()
-	}
+	stdout, stderr := s.output(opts)
 
 	remote := opts.Remote
 	if remote == "" {
@@ -193,10 +230,20 @@ func (s *SessionService) CreateSession(ctx context.Context, opts CreateOptions)
 	}
 	writeProgressf(progress, "Clone strategy: %s", cloneStrategy)
 
+	vcsName := s.config.GetVCS(remote)
+	vcs := s.vcsFor(vcsName)
+
 	if err := session.ValidateName(opts.Name); err != nil {
 		return nil, err
 	}
 
+	// Fail before cloning when a spawn prerequisite is missing.
+	if !opts.SkipSpawn {
+		if err := s.checkPreflight(ctx, remote, opts.AgentKey, opts.UseBatchSpawn); err != nil {
+			return nil, err
+		}
+	}
+
 	var sess session.Session
 	var dirID string
 	slug := session.Slugify(opts.Name)
@@ -218,7 +265,7 @@ func (s *SessionService) CreateSession(ctx context.Context, opts CreateOptions)
 	var recyclable *session.Session
 	if cloneStrategy == config.CloneStrategyFull {
 		writeProgressf(progress, "Looking for recyclable session...")
-		recyclable = s.findValidRecyclable(ctx, remote, cloneStrategy)
+		recyclable = s.findValidRecyclable(ctx, remote, cloneStrategy, vcsName)
 	}
 
 	if recyclable != nil {
@@ -228,7 +275,7 @@ func (s *SessionService) CreateSession(ctx context.Context, opts CreateOptions)
 		// Pull latest changes before running hooks.
 		s.log.Debug().Str("path", recyclable.Path).Msg("pulling latest changes")
 		writeProgressf(progress, "Pulling latest changes...")
-		if err := s.git.Pull(ctx, recyclable.Path); err != nil {
+		if err := vcs.Pull(ctx, recyclable.Path); err != nil {
 			// Pull failed - mark as corrupted and fall through to clone.
 			s.log.Warn().Err(err).Str("session_id", recyclable.ID).Msg("pull failed, marking corrupted")
 			s.markCorrupted(ctx, recyclable)
@@ -242,6 +289,7 @@ func (s *SessionService) CreateSession(ctx context.Context, opts CreateOptions)
 		sess.Slug = slug
 		sess.State = session.StateActive
 		sess.Tags = opts.Tags
+		sess.Notes = ""
 		sess.UpdatedAt = time.Now()
 	} else {
 		// Create new session (either no recyclable found or it was corrupted)
@@ -277,30 +325,59 @@ func (s *SessionService) CreateSession(ctx context.Context, opts CreateOptions)
 		}
 
 		if cloneStrategy == config.CloneStrategyWorktree {
-			bareDir, err := s.ensureBareClone(ctx, remote, progress)
+			bareDir, err := s.ensureBareClone(ctx, remote, vcsName, progress)
 			if err != nil {
 				return nil, fmt.Errorf("ensure bare clone: %w", err)
 			}
 			writeProgressf(progress, "Adding worktree...")
-			branch, err := s.worktreeBranchName(remote, opts.Name, slug, dirID)
+			branch, err := s.branchName(remote, opts.Name, slug, dirID)
 			if err != nil {
 				return nil, err
 			}
-			if err := s.git.WorktreeAdd(ctx, bareDir, path, branch); err != nil {
+			if err := vcs.WorktreeAdd(ctx, bareDir, path, branch); err != nil {
 				return nil, fmt.Errorf("worktree add: %w", err)
 			}
 			sess.SetMeta(session.MetaWorktreeBranch, branch)
 		} else {
 			writeProgressf(progress, "Cloning repository...")
-			if err := s.git.Clone(ctx, remote, path); err != nil {
+			if err := vcs.Clone(ctx, remote, path); err != nil {
 				return nil, fmt.Errorf("clone repository: %w", err)
 			}
 		}
+		if vcsName != config.VCSGit {
+			sess.SetMeta(session.MetaVCS, vcsName)
+		}
 
 		writeProgressf(progress, "Clone complete")
 		s.log.Debug().Msg("clone complete")
 	}
 
+	// Worktrees always have a branch of their own. Full clones, new or
+	// recycled, get one when forking or when the rules name a branch template
+	// or a base branch; otherwise they stay on the default branch.
+	base := s.config.GetBaseBranch(remote)
+	branch := sess.GetMeta(session.MetaWorktreeBranch)
+	if branch == "" && (opts.ForkFrom != "" || base != "" || s.config.GetBranchTemplate(remote) != "") {
+		branch, err = s.branchName(remote, opts.Name, slug, firstNonEmpty(dirID, generateID()))
+		if err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case opts.ForkFrom != "":
+		writeProgressf(progress, "Checking out %s...", branch)
+		if err := vcs.ForkBranch(ctx, sess.Path, opts.ForkFrom, branch); err != nil {
+			return nil, fmt.Errorf("fork branch: %w", err)
+		}
+		base = "" // started from the source checkout, not a remote branch
+	case branch != "" && (base != "" || cloneStrategy != config.CloneStrategyWorktree):
+		writeProgressf(progress, "Checking out %s from %s...", branch, firstNonEmpty(base, "the default branch"))
+		if err := vcs.StartBranch(ctx, sess.Path, branch, base); err != nil {
+			return nil, fmt.Errorf("start branch: %w", err)
+		}
+	}
+	sess.SetBranch(branch, base)
+
 	// Execute matching rules
 	writeProgressf(progress, "Executing rules...")
 	owner, repoName := git.ExtractOwnerRepo(remote)
@@ -313,15 +390,40 @@ func (s *SessionService) CreateSession(ctx context.Context, opts CreateOptions)
 		Repo:       repoName,
 		ID:         dirID,
 	}
-	if err := s.executeRules(ctx, remote, opts.Source, sess.Path, hookData); err != nil {
+	cfg, err := s.configFor(remote, sess.Path)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.executeRules(ctx, cfg.Rules, remote, opts.Source, sess.Path, hookData, stdout, stderr); err != nil {
 		return nil, fmt.Errorf("execute rules: %w", err)
 	}
 
+	// Recycled sessions carry metadata from their previous use.
+	if opts.Prompt != "" {
+		sess.SetMeta(session.MetaPrompt, opts.Prompt)
+	} else {
+		delete(sess.Metadata, session.MetaPrompt)
+	}
+	delete(sess.Metadata, session.MetaIssueNumber)
+	delete(sess.Metadata, session.MetaIssueURL)
+	for k, v := range opts.Metadata {
+		sess.SetMeta(k, v)
+	}
+
+	// Tmux targets and the agent profile from a previous use are stale; a
+	// spawn records new ones.
+	delete(sess.Metadata, session.MetaAgentProfile)
+	delete(sess.Metadata, session.MetaTmuxSession)
+	delete(sess.Metadata, session.MetaTmuxWindow)
+	delete(sess.Metadata, session.MetaTmuxPane)
+	delete(sess.Metadata, session.MetaTmuxTargets)
+
 	// Save session
 	writeProgressf(progress, "Saving session...")
 	if err := s.sessions.Save(ctx, sess); err != nil {
 		return nil, fmt.Errorf("save session: %w", err)
 	}
+	s.writeSessionFile(ctx, &sess)
 
 	// Spawn terminal
 	writeProgressf(progress, "Spawning terminal...")
@@ -336,23 +438,9 @@ func (s *SessionService) CreateSession(ctx context.Context, opts CreateOptions)
 	}
 
 	if !opts.SkipSpawn {
-		strategy := config.ResolveSpawn(s.config.Rules, remote, opts.UseBatchSpawn)
-		renderer, err := s.rendererForAgent(firstNonEmpty(opts.AgentKey, strategy.Agent))
-		if err != nil {
+		if err := s.spawnSession(ctx, &sess, opts, data); err != nil {
 			return nil, err
 		}
-		switch {
-		case strategy.IsWindows():
-			if err := s.spawner.SpawnWindowsWith(ctx, strategy.Windows, data, opts.UseBatchSpawn || opts.Background, renderer); err != nil {
-				return nil, fmt.Errorf("spawn terminal: %w", err)
-			}
-		case len(strategy.Commands) > 0:
-			if err := s.spawner.SpawnWith(ctx, strategy.Commands, data, renderer); err != nil {
-				return nil, fmt.Errorf("spawn terminal: %w", err)
-			}
-		default:
-			return nil, fmt.Errorf("spawn terminal: no spawn strategy resolved for remote %q", remote)
-		}
 	}
 
 	writeProgressf(progress, "Session created: %s", sess.Name)
@@ -363,9 +451,214 @@ func (s *SessionService) CreateSession(ctx context.Context, opts CreateOptions)
 	return &sess, nil
 }
 
-// worktreeBranchName returns the branch name for a worktree session, applying
-// the configured branch template for the remote when one is set.
-func (s *SessionService) worktreeBranchName(remote, name, slug, dirID string) (string, error) {
+// spawnSession runs the spawn strategy the rules resolve for sess's remote,
+// including its repository config: its windows, attaching unless opts asks
+// for a background or batch session, or its spawn commands. The agent profile
+// they are rendered with is recorded in sess's metadata.
+func (s *SessionService) spawnSession(ctx context.Context, sess *session.Session, opts CreateOptions, data SpawnData) error {
+	strategy := config.ResolveSpawn(s.configForSession(sess).Rules, sess.Remote, opts.UseBatchSpawn)
+	agentKey := firstNonEmpty(opts.AgentKey, strategy.Agent)
+	spawner := s.spawner.withOutput(s.output(opts))
+	renderer, err := s.rendererForAgent(agentKey)
+	if err != nil {
+		return err
+	}
+	sess.SetMeta(session.MetaAgentProfile, firstNonEmpty(agentKey, s.config.Agents.Default))
+	switch {
+	case strategy.IsWindows():
+		result, err := spawner.SpawnWindowsWith(ctx, strategy.Windows, data, renderer)
+		if err != nil {
+			return fmt.Errorf("spawn terminal: %w", err)
+		}
+		s.recordSpawnResult(ctx, sess, result)
+		if !opts.UseBatchSpawn && !opts.Background {
+			if err := s.spawner.Attach(ctx, result.TmuxSession); err != nil {
+				return fmt.Errorf("spawn terminal: %w", err)
+			}
+		}
+	case len(strategy.Commands) > 0:
+		if err := spawner.SpawnWith(ctx, strategy.Commands, data, renderer); err != nil {
+			return fmt.Errorf("spawn terminal: %w", err)
+		}
+		if err := s.sessions.Save(ctx, *sess); err != nil {
+			s.log.Warn().Err(err).Str("session_id", sess.ID).Msg("failed to save agent profile")
+		}
+	default:
+		return fmt.Errorf("spawn terminal: no spawn strategy resolved for remote %q", sess.Remote)
+	}
+	return nil
+}
+
+// recordSpawnResult stores the tmux session, agent window, agent pane, and
+// window layout created by a windows spawn in the session's metadata, so
+// terminal discovery doesn't have to guess. Failures are logged: the session
+// still works through discovery.
+func (s *SessionService) recordSpawnResult(ctx context.Context, sess *session.Session, result *SpawnResult) {
+	sess.SetMeta(session.MetaTmuxSession, result.TmuxSession)
+	if result.AgentWindow != "" {
+		sess.SetMeta(session.MetaTmuxWindow, result.AgentWindow)
+	}
+	if result.AgentPane != "" {
+		sess.SetMeta(session.MetaTmuxPane, result.AgentPane)
+	}
+	if len(result.Windows) > 0 {
+		targets, err := json.Marshal(result.Windows)
+		if err != nil {
+			s.log.Warn().Err(err).Str("session_id", sess.ID).Msg("failed to encode tmux targets")
+		} else {
+			sess.SetMeta(session.MetaTmuxTargets, string(targets))
+		}
+	}
+	if err := s.sessions.Save(ctx, *sess); err != nil {
+		s.log.Warn().Err(err).Str("session_id", sess.ID).Msg("failed to save tmux targets")
+	}
+}
+
+// CloneSession creates a new session from the same remote as an active
+// session, on a new branch at the source checkout's HEAD. Files matched by
+// the rules' copy patterns are copied from the source checkout. Uncommitted
+// changes in the source are not carried over.
+func (s *SessionService) CloneSession(ctx context.Context, id string, opts CreateOptions) (*session.Session, error) {
+	src, err := s.sessions.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get session: %w", err)
+	}
+	if src.State != session.StateActive {
+		return nil, fmt.Errorf("session %s cannot be cloned (state: %s)", id, src.State)
+	}
+
+	// jj cannot fork another checkout; fail before cloning anything.
+	if sessionVCSName(&src) == config.VCSJJ || s.config.GetVCS(src.Remote) == config.VCSJJ {
+		return nil, fmt.Errorf("session %s cannot be cloned: jj repositories do not support forking a checkout", id)
+	}
+
+	if opts.Name == "" {
+		opts.Name, err = s.cloneName(ctx, src.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	opts.Remote = src.Remote
+	opts.Source = src.Path
+	opts.ForkFrom = src.Path
+	if opts.CloneStrategy == "" {
+		opts.CloneStrategy = src.CloneStrategy
+	}
+	if opts.Tags == nil {
+		opts.Tags = slices.Clone(src.Tags)
+	}
+
+	return s.CreateSession(ctx, opts)
+}
+
+// cloneName returns the first of "<name>-clone", "<name>-clone-2", … not
+// used by an active session.
+func (s *SessionService) cloneName(ctx context.Context, name string) (string, error) {
+	return s.availableName(ctx, name+"-clone")
+}
+
+// availableName returns the first of "<name>", "<name>-2", … not used by an
+// active session.
+func (s *SessionService) availableName(ctx context.Context, name string) (string, error) {
+	sessions, err := s.ListSessions(ctx)
+	if err != nil {
+		return "", fmt.Errorf("list sessions: %w", err)
+	}
+	taken := make(map[string]bool)
+	for _, sess := range sessions {
+		if sess.State == session.StateActive {
+			taken[sess.Name] = true
+		}
+	}
+	candidate := name
+	for i := 2; taken[candidate]; i++ {
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+	return candidate, nil
+}
+
+// CompareSide is one session's state in a SessionComparison.
+type CompareSide struct {
+	Session     session.Session `json:"session"`
+	Branch      string          `json:"branch"`
+	Additions   int             `json:"additions"` // against the default branch
+	Deletions   int             `json:"deletions"`
+	Uncommitted bool            `json:"uncommitted"`
+	// Errors lists the lookups that failed, e.g. "branch: ...". The fields
+	// they fill are left at their zero values.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// SessionComparison compares two sessions of the same repository.
+type SessionComparison struct {
+	A CompareSide `json:"a"`
+	B CompareSide `json:"b"`
+	// Divergence is B's HEAD relative to A's: Ahead lists commits only in A
+	// and Behind commits only in B.
+	Divergence git.Divergence `json:"divergence"`
+}
+
+// CompareSessions compares the checkouts of two active sessions of the same
+// repository. Per-session lookups that fail are listed in CompareSide.Errors;
+// comparing the HEADs must succeed.
+func (s *SessionService) CompareSessions(ctx context.Context, idA, idB string) (SessionComparison, error) {
+	if idA == idB {
+		return SessionComparison{}, fmt.Errorf("cannot compare session %s with itself", idA)
+	}
+
+	a, err := s.sessions.Get(ctx, idA)
+	if err != nil {
+		return SessionComparison{}, fmt.Errorf("get session %s: %w", idA, err)
+	}
+	b, err := s.sessions.Get(ctx, idB)
+	if err != nil {
+		return SessionComparison{}, fmt.Errorf("get session %s: %w", idB, err)
+	}
+	for _, sess := range []session.Session{a, b} {
+		if sess.State != session.StateActive {
+			return SessionComparison{}, fmt.Errorf("session %s cannot be compared (state: %s)", sess.ID, sess.State)
+		}
+	}
+	if git.RemoteIdentity(a.Remote) != git.RemoteIdentity(b.Remote) {
+		return SessionComparison{}, fmt.Errorf("sessions %s and %s are from different repositories", a.ID, b.ID)
+	}
+
+	divergence, err := s.VCS(&a).Compare(ctx, a.Path, b.Path)
+	if err != nil {
+		return SessionComparison{}, fmt.Errorf("compare sessions: %w", err)
+	}
+
+	return SessionComparison{
+		A:          s.compareSide(ctx, a),
+		B:          s.compareSide(ctx, b),
+		Divergence: divergence,
+	}, nil
+}
+
+func (s *SessionService) compareSide(ctx context.Context, sess session.Session) CompareSide {
+	vcs := s.VCS(&sess)
+	side := CompareSide{Session: sess}
+	if branch, err := vcs.Branch(ctx, sess.Path); err != nil {
+		side.Errors = append(side.Errors, fmt.Sprintf("branch: %v", err))
+	} else {
+		side.Branch = branch
+	}
+	if additions, deletions, err := vcs.DiffStats(ctx, sess.Path); err != nil {
+		side.Errors = append(side.Errors, fmt.Sprintf("diff stats: %v", err))
+	} else {
+		side.Additions, side.Deletions = additions, deletions
+	}
+	if clean, err := vcs.IsClean(ctx, sess.Path); err != nil {
+		side.Errors = append(side.Errors, fmt.Sprintf("status: %v", err))
+	} else {
+		side.Uncommitted = !clean
+	}
+	return side
+}
+
+// branchName returns the branch name for a new session, applying the
+// configured branch template for the remote when one is set.
+func (s *SessionService) branchName(remote, name, slug, dirID string) (string, error) {
 	branch := "hive/" + slug + "-" + dirID
 	tmplStr := s.config.GetBranchTemplate(remote)
 	if tmplStr == "" {
@@ -397,9 +690,31 @@ func writeProgressf(w io.Writer, format string, args ...any) {
 	_, _ = fmt.Fprintf(w, format+"\n", args...)
 }
 
-// ListSessions returns all sessions.
+// ListSessions returns all sessions except archived ones; see
+// ListArchivedSessions.
 func (s *SessionService) ListSessions(ctx context.Context) ([]session.Session, error) {
-	return s.sessions.List(ctx)
+	sessions, err := s.sessions.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(sessions, func(sess session.Session) bool {
+		return sess.State == session.StateArchived
+	}), nil
+}
+
+// ListArchivedSessions returns archived sessions, most recently archived first.
+func (s *SessionService) ListArchivedSessions(ctx context.Context) ([]session.Session, error) {
+	sessions, err := s.sessions.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	archived := slices.DeleteFunc(sessions, func(sess session.Session) bool {
+		return sess.State != session.StateArchived
+	})
+	slices.SortStableFunc(archived, func(a, b session.Session) int {
+		return b.UpdatedAt.Compare(a.UpdatedAt)
+	})
+	return archived, nil
 }
 
 // GetSession returns a session by ID.
@@ -407,6 +722,10 @@ func (s *SessionService) GetSession(ctx context.Context, id string) (session.Ses
 	return s.sessions.Get(ctx, id)
 }
 
+// ErrRecycleFailed is returned, wrapped, when a session's recycle commands
+// fail. The session is left marked as needing attention.
+var ErrRecycleFailed = errors.New("recycle commands failed")
+
 // RecycleSession marks a session for recycling and runs recycle commands.
 // The session directory is not moved; only the DB record state changes.
 // Output is written to w. If w is nil, output is discarded.
@@ -420,6 +739,12 @@ func (s *SessionService) RecycleSession(ctx context.Context, id string, w io.Wri
 		return fmt.Errorf("session %s cannot be recycled (state: %s)", id, sess.State)
 	}
 
+	if s.config.GetSnapshotOnRecycle(sess.Remote) {
+		if err := s.snapshotBeforeRecycle(ctx, &sess, w); err != nil {
+			return err
+		}
+	}
+
 	if sess.CloneStrategy == config.CloneStrategyWorktree {
 		s.log.Info().Str("session_id", id).Msg("deleting worktree session instead of retaining recycled state")
 		return s.DeleteSession(ctx, id)
@@ -427,14 +752,15 @@ func (s *SessionService) RecycleSession(ctx context.Context, id string, w io.Wri
 
 	// Full-clone recycle: validate, reset, and mark recycled.
 	// Validate repository before recycling
-	if err := s.git.IsValidRepo(ctx, sess.Path); err != nil {
+	vcs := s.VCS(&sess)
+	if err := vcs.IsValidRepo(ctx, sess.Path); err != nil {
 		s.log.Warn().Err(err).Str("session_id", id).Msg("session has corrupted repository")
 		s.markCorrupted(ctx, &sess)
 		return fmt.Errorf("session %s has corrupted repository: %w", id, err)
 	}
 
 	// Get default branch for template
-	defaultBranch, err := s.git.DefaultBranch(ctx, sess.Path)
+	defaultBranch, err := vcs.DefaultBranch(ctx, sess.Path)
 	if err != nil {
 		s.log.Warn().Err(err).Msg("failed to get default branch, using 'main'")
 		defaultBranch = "main"
@@ -444,15 +770,16 @@ func (s *SessionService) RecycleSession(ctx context.Context, id string, w io.Wri
 		DefaultBranch: defaultBranch,
 	}
 
-	if err := s.recycler.Recycle(ctx, sess.Path, s.config.GetRecycleCommands(sess.Remote), data, w); err != nil {
-		return fmt.Errorf("recycle session %s: %w", id, err)
-	}
+	s.removeSessionFile(ctx, &sess)
 
-	// Kill associated tmux session (best-effort)
-	if _, err := s.executor.Run(ctx, "tmux", "kill-session", "-t", sess.Slug); err != nil {
-		s.log.Debug().Err(err).Str("session", sess.Slug).Msg("no tmux session to kill")
+	if err := s.recycler.Recycle(ctx, sess.Path, s.configForSession(&sess).GetRecycleCommandsForVCS(sess.Remote, sessionVCSName(&sess)), data, w); err != nil {
+		s.markRecycleFailed(ctx, &sess, err)
+		return fmt.Errorf("recycle session %s: %w: %w", id, ErrRecycleFailed, err)
 	}
 
+	// Kill associated tmux sessions (best-effort)
+	s.killTmuxSessions(ctx, tmuxSessionNames(&sess))
+
 	sess.MarkRecycled(time.Now())
 
 	if err := s.sessions.Save(ctx, sess); err != nil {
@@ -471,7 +798,47 @@ func (s *SessionService) RecycleSession(ctx context.Context, id string, w io.Wri
 	return nil
 }
 
-// RenameSession changes the name (and slug) of an existing session.
+// ForceRecycleSession discards the session's local changes before recycling
+// it. It recovers sessions whose recycle commands failed on a dirty working
+// tree or an unfinished merge.
+func (s *SessionService) ForceRecycleSession(ctx context.Context, id string, w io.Writer) error {
+	sess, err := s.sessions.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get session: %w", err)
+	}
+
+	if !sess.CanRecycle() {
+		return fmt.Errorf("session %s cannot be recycled (state: %s)", id, sess.State)
+	}
+
+	if w != nil {
+		_, _ = fmt.Fprintln(w, "Discarding local changes...")
+	}
+	if err := s.VCS(&sess).ResetHard(ctx, sess.Path); err != nil {
+		return fmt.Errorf("reset session %s: %w", id, err)
+	}
+
+	return s.RecycleSession(ctx, id, w)
+}
+
+// markRecycleFailed records a recycle failure on the session so the TUI can
+// flag it. The session stays active with its working tree left as is.
+func (s *SessionService) markRecycleFailed(ctx context.Context, sess *session.Session, recycleErr error) {
+	s.log.Warn().Err(recycleErr).Str("session_id", sess.ID).Msg("recycle failed, session needs attention")
+
+	sess.MarkRecycleFailed(recycleErr, time.Now())
+	if err := s.sessions.Save(ctx, *sess); err != nil {
+		s.log.Error().Err(err).Str("session_id", sess.ID).Msg("failed to save recycle failure")
+		return
+	}
+
+	// The session file was removed before the recycle commands ran.
+	s.writeSessionFile(ctx, sess)
+}
+
+// RenameSession changes the name (and slug) of an existing session. A tmux
+// session named after the old slug is renamed with it; if saving the session
+// fails, the tmux rename is undone so the two never disagree.
 func (s *SessionService) RenameSession(ctx context.Context, id, newName string) error {
 	newName = strings.TrimSpace(newName)
 	if err := session.ValidateName(newName); err != nil {
@@ -485,15 +852,36 @@ func (s *SessionService) RenameSession(ctx context.Context, id, newName string)
 		return fmt.Errorf("get session: %w", err)
 	}
 
-	oldName := sess.Name
+	oldName, oldSlug := sess.Name, sess.Slug
+
+	// Sessions pinned to a differently named tmux session keep it.
+	tmuxName := sess.GetMeta(session.MetaTmuxSession)
+	renamedTmux := false
+	if slug != oldSlug && (tmuxName == "" || tmuxName == oldSlug) {
+		renamedTmux, err = s.spawner.tmux.RenameSession(ctx, oldSlug, slug)
+		if err != nil {
+			return fmt.Errorf("rename tmux session: %w", err)
+		}
+		if renamedTmux && tmuxName != "" {
+			sess.SetMeta(session.MetaTmuxSession, slug)
+		}
+	}
+
 	sess.Name = newName
 	sess.Slug = slug
 	sess.UpdatedAt = time.Now()
 
 	if err := s.sessions.Save(ctx, sess); err != nil {
+		if renamedTmux {
+			if _, undoErr := s.spawner.tmux.RenameSession(ctx, slug, oldSlug); undoErr != nil {
+				s.log.Error().Err(undoErr).Str("session_id", id).Msg("failed to restore tmux session name")
+			}
+		}
 		return fmt.Errorf("save session: %w", err)
 	}
 
+	s.writeSessionFile(ctx, &sess)
+
 	s.bus.PublishSessionRenamed(eventbus.SessionRenamedPayload{Session: &sess, OldName: oldName})
 
 	s.log.Info().Str("session_id", id).Str("new_name", newName).Msg("session renamed")
@@ -521,11 +909,145 @@ func (s *SessionService) SetSessionGroup(ctx context.Context, id, group string)
 	return nil
 }
 
+// SetSessionTags replaces the tags on a session. Tags are validated, and
+// duplicates differing only in case are dropped.
+func (s *SessionService) SetSessionTags(ctx context.Context, id string, tags []string) error {
+	for _, tag := range tags {
+		if err := session.ValidateTag(tag); err != nil {
+			return err
+		}
+	}
+
+	sess, err := s.sessions.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get session: %w", err)
+	}
+
+	sess.Tags = nil
+	sess.AddTags(tags...)
+	sess.UpdatedAt = time.Now()
+
+	if err := s.sessions.Save(ctx, sess); err != nil {
+		return fmt.Errorf("save session: %w", err)
+	}
+
+	if sess.State == session.StateActive {
+		s.writeSessionFile(ctx, &sess)
+	}
+
+	s.log.Info().Str("session_id", id).Strs("tags", sess.Tags).Msg("session tags updated")
+	return nil
+}
+
+// SetSessionNotes replaces the notes on a session. Trailing whitespace is
+// dropped; empty notes clear them.
+func (s *SessionService) SetSessionNotes(ctx context.Context, id, notes string) error {
+	notes = strings.TrimRight(notes, " \t\r\n")
+
+	sess, err := s.sessions.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get session: %w", err)
+	}
+
+	sess.Notes = notes
+	sess.UpdatedAt = time.Now()
+
+	if err := s.sessions.Save(ctx, sess); err != nil {
+		return fmt.Errorf("save session: %w", err)
+	}
+
+	s.log.Info().Str("session_id", id).Int("length", len(notes)).Msg("session notes updated")
+	return nil
+}
+
+// RecordCapabilities stores the capabilities a session's agent announced,
+// replacing any it announced before.
+func (s *SessionService) RecordCapabilities(ctx context.Context, id string, caps session.Capabilities) error {
+	sess, err := s.sessions.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get session: %w", err)
+	}
+
+	if err := sess.SetCapabilities(caps); err != nil {
+		return err
+	}
+	sess.UpdatedAt = time.Now()
+
+	if err := s.sessions.Save(ctx, sess); err != nil {
+		return fmt.Errorf("save session: %w", err)
+	}
+
+	s.log.Info().Str("session_id", id).Str("agent", caps.Agent).Str("model", caps.Model).Msg("agent capabilities recorded")
+	return nil
+}
+
+// SetSessionDue sets when a session is expected to be done. A zero time
+// clears the due date.
+func (s *SessionService) SetSessionDue(ctx context.Context, id string, due time.Time) error {
+	sess, err := s.sessions.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get session: %w", err)
+	}
+
+	sess.SetDueAt(due)
+	sess.UpdatedAt = time.Now()
+
+	if err := s.sessions.Save(ctx, sess); err != nil {
+		return fmt.Errorf("save session: %w", err)
+	}
+
+	s.log.Info().Str("session_id", id).Time("due", due).Msg("session due date updated")
+	return nil
+}
+
+// ArchiveOverdue archives active sessions whose due date passed more than
+// due.archive_after before now, and returns them. Sessions with uncommitted
+// or unpushed work are left for the user. Nothing is archived when
+// due.archive_after is 0.
+func (s *SessionService) ArchiveOverdue(ctx context.Context, now time.Time) ([]session.Session, error) {
+	grace := s.config.Due.ArchiveAfter
+	if grace <= 0 {
+		return nil, nil
+	}
+
+	sessions, err := s.ListSessions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+
+	var archived []session.Session
+	for _, sess := range sessions {
+		if !sess.IsOverdue(now.Add(-grace)) {
+			continue
+		}
+
+		risk, err := s.CheckSessionRisk(ctx, sess.ID)
+		if err != nil {
+			s.log.Warn().Err(err).Str("session_id", sess.ID).Msg("failed to check overdue session")
+			continue
+		}
+		if risk.HasRisk() {
+			s.log.Info().Str("session_id", sess.ID).Msg("not archiving overdue session with uncommitted or unpushed work")
+			continue
+		}
+
+		if err := s.ArchiveSession(ctx, sess.ID); err != nil {
+			s.log.Warn().Err(err).Str("session_id", sess.ID).Msg("failed to archive overdue session")
+			continue
+		}
+		archived = append(archived, sess)
+	}
+
+	return archived, nil
+}
+
 // SessionRisk describes uncommitted or unpushed work that would be lost if a session
 // is deleted or recycled. Only meaningful for active sessions.
 type SessionRisk struct {
 	UncommittedChanges bool
 	UnpushedCommits    bool
+	ChangedFiles       []string // files with uncommitted changes, when they could be listed
+	UnpushedCount      int      // commits not yet pushed; 0 when unknown
 }
 
 // HasRisk returns true if any data would be lost.
@@ -533,10 +1055,11 @@ func (r SessionRisk) HasRisk() bool {
 	return r.UncommittedChanges || r.UnpushedCommits
 }
 
-// CheckSessionRisk checks whether an active session has uncommitted or unpushed changes.
+// CheckSessionRisk checks whether an active session has uncommitted or unpushed changes,
+// listing the changed files and counting the unpushed commits.
 // Non-active sessions (recycled, corrupted) always return an empty risk.
-// Git errors are treated conservatively: IsClean failures assume dirty; HasUnpushedCommits
-// failures assume no unpushed commits.
+// Git errors are treated conservatively: IsClean and UnpushedCommits failures
+// assume there is work to lose.
 func (s *SessionService) CheckSessionRisk(ctx context.Context, id string) (SessionRisk, error) {
 	sess, err := s.sessions.Get(ctx, id)
 	if err != nil {
@@ -546,22 +1069,28 @@ func (s *SessionService) CheckSessionRisk(ctx context.Context, id string) (Sessi
 		return SessionRisk{}, nil
 	}
 
-	clean, err := s.git.IsClean(ctx, sess.Path)
+	vcs := s.VCS(&sess)
+	clean, err := vcs.IsClean(ctx, sess.Path)
 	if err != nil {
 		s.log.Debug().Err(err).Str("session_id", id).Msg("failed to check git clean status")
 		clean = false // assume dirty on error to be safe
 	}
 
-	unpushed, err := s.git.HasUnpushedCommits(ctx, sess.Path)
+	risk := SessionRisk{UncommittedChanges: !clean}
+	if !clean {
+		if risk.ChangedFiles, err = vcs.ChangedFiles(ctx, sess.Path); err != nil {
+			s.log.Debug().Err(err).Str("session_id", id).Msg("failed to list changed files")
+		}
+	}
+
+	risk.UnpushedCount, err = vcs.UnpushedCommits(ctx, sess.Path)
 	if err != nil {
 		s.log.Debug().Err(err).Str("session_id", id).Msg("failed to check unpushed commits")
-		unpushed = true // assume risky on error, consistent with IsClean failure handling
+		risk.UnpushedCommits = true // assume risky on error, consistent with IsClean failure handling
 	}
+	risk.UnpushedCommits = risk.UnpushedCommits || risk.UnpushedCount > 0
 
-	return SessionRisk{
-		UncommittedChanges: !clean,
-		UnpushedCommits:    unpushed,
-	}, nil
+	return risk, nil
 }
 
 // DeleteSession removes a session and its directory.
@@ -573,23 +1102,18 @@ func (s *SessionService) DeleteSession(ctx context.Context, id string) error {
 
 	s.log.Info().Str("session_id", id).Str("path", sess.Path).Msg("deleting session")
 
-	// For worktree sessions, remove the worktree via git before os.RemoveAll so
+	// For worktree sessions, remove the worktree via git before RemoveAll so
 	// the bare repo's internal worktree tracking stays consistent.
 	if sess.CloneStrategy == config.CloneStrategyWorktree {
-		branch := sess.GetMeta(session.MetaWorktreeBranch)
-		bareDir := s.bareDirForRemote(sess.Remote)
-		if err := s.git.WorktreeRemove(ctx, bareDir, sess.Path, branch); err != nil {
-			s.log.Warn().Err(err).Str("session_id", id).Msg("worktree remove failed during delete, proceeding with RemoveAll")
-		}
+		s.removeWorktree(ctx, &sess, sess.GetMeta(session.MetaWorktreeBranch))
 	}
 
-	// Kill associated tmux session (best-effort)
-	if _, err := s.executor.Run(ctx, "tmux", "kill-session", "-t", sess.Slug); err != nil {
-		s.log.Debug().Err(err).Str("session", sess.Slug).Msg("no tmux session to kill")
-	}
+	// Kill associated tmux sessions (best-effort)
+	rec := newCleanupRecord(&sess)
+	s.killTmuxSessions(ctx, rec.Tmux)
 
 	// Remove directory
-	if err := os.RemoveAll(sess.Path); err != nil {
+	if err := s.files.RemoveAll(ctx, sess.Path); err != nil {
 		return fmt.Errorf("remove directory: %w", err)
 	}
 
@@ -598,11 +1122,79 @@ func (s *SessionService) DeleteSession(ctx context.Context, id string) error {
 		return fmt.Errorf("delete session: %w", err)
 	}
 
+	if err := s.removeSessionResources(ctx, rec); err != nil {
+		s.log.Warn().Err(err).Str("session_id", id).Msg("failed to remove session resources")
+	}
+
 	s.bus.PublishSessionDeleted(eventbus.SessionDeletedPayload{SessionID: id})
 
+	// Verify in the background that nothing was left behind; doctor reports
+	// and cleans up what was.
+	if s.sessionKV != nil {
+		s.cleanups.Go(func() { s.verifyCleanup(context.WithoutCancel(ctx), rec) })
+	}
+
+	return nil
+}
+
+// ArchiveSession removes an active session's checkout and tmux session but
+// keeps its record, with a summary of the checkout's final git state, for
+// later auditing. Worktree sessions keep their branch in the shared bare
+// clone.
+func (s *SessionService) ArchiveSession(ctx context.Context, id string) error {
+	sess, err := s.sessions.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get session: %w", err)
+	}
+
+	if sess.State != session.StateActive {
+		return fmt.Errorf("session %s cannot be archived (state: %s)", id, sess.State)
+	}
+
+	s.log.Info().Str("session_id", id).Str("path", sess.Path).Msg("archiving session")
+
+	summary := s.archiveSummary(ctx, &sess)
+
+	if sess.CloneStrategy == config.CloneStrategyWorktree {
+		s.removeWorktree(ctx, &sess, "")
+	}
+
+	// Kill associated tmux sessions (best-effort)
+	s.killTmuxSessions(ctx, tmuxSessionNames(&sess))
+
+	if err := s.files.RemoveAll(ctx, sess.Path); err != nil {
+		return fmt.Errorf("remove directory: %w", err)
+	}
+
+	sess.MarkArchived(summary)
+	if err := s.sessions.Save(ctx, sess); err != nil {
+		return fmt.Errorf("save session: %w", err)
+	}
+
 	return nil
 }
 
+// archiveSummary records the final git state of a session's checkout.
+// Lookups are best-effort: a failed lookup leaves its field at the zero value.
+func (s *SessionService) archiveSummary(ctx context.Context, sess *session.Session) session.ArchiveSummary {
+	vcs := s.VCS(sess)
+	summary := session.ArchiveSummary{ArchivedAt: time.Now()}
+
+	if branch, err := vcs.Branch(ctx, sess.Path); err == nil {
+		summary.Branch = branch
+	}
+	if additions, deletions, err := vcs.DiffStats(ctx, sess.Path); err == nil {
+		summary.Additions, summary.Deletions = additions, deletions
+	}
+	if clean, err := vcs.IsClean(ctx, sess.Path); err == nil {
+		summary.Uncommitted = !clean
+	}
+	if unpushed, err := vcs.UnpushedCommits(ctx, sess.Path); err == nil {
+		summary.Unpushed = unpushed > 0
+	}
+	return summary
+}
+
 // Prune removes recycled and corrupted sessions and their directories.
 // If all is true, deletes ALL recycled sessions.
 // If all is false, respects max_recycled limit per repository (keeps newest N).
@@ -747,7 +1339,7 @@ func (s *SessionService) SessionLaunchOptions(ctx context.Context) (SessionLaunc
 		add(SessionLaunchRepository{Name: repo.Name, Remote: repo.Remote, Source: repo.Path})
 	}
 	for _, sess := range sessions {
-		if info, err := os.Stat(sess.Path); err != nil || !info.IsDir() {
+		if ok, err := s.files.Exists(ctx, sess.Path); err != nil || !ok {
 			continue
 		}
 		add(SessionLaunchRepository{Name: sess.Name, Remote: sess.Remote, Source: sess.Path})
@@ -782,9 +1374,15 @@ func (s *SessionService) DetectSession(ctx context.Context) (string, error) {
 }
 
 // OpenTmuxSession opens (or creates) a tmux session for the given session parameters.
-// It resolves the spawn strategy, renders window templates, and delegates to the spawner.
-func (s *SessionService) OpenTmuxSession(ctx context.Context, name, path, remote, targetWindow string, background bool) error {
-	strategy := config.ResolveSpawn(s.config.Rules, remote, false)
+// It resolves the spawn strategy, renders window templates with the agent
+// profile the session was spawned with (or the rules' agent when empty), and
+// delegates to the spawner.
+func (s *SessionService) OpenTmuxSession(ctx context.Context, name, path, remote, agent, targetWindow string, background bool) error {
+	cfg, err := s.configFor(remote, path)
+	if err != nil {
+		s.log.Warn().Err(err).Str("path", path).Msg("ignoring repository config")
+	}
+	strategy := config.ResolveSpawn(cfg.Rules, remote, false)
 	if !strategy.IsWindows() {
 		return fmt.Errorf("tmux action requires windows config (legacy spawn commands should use shell executor)")
 	}
@@ -799,7 +1397,11 @@ func (s *SessionService) OpenTmuxSession(ctx context.Context, name, path, remote
 		Repo:       repo,
 	}
 
-	renderer, err := s.rendererForAgent(strategy.Agent)
+	if _, ok := s.config.Agents.Profiles[agent]; agent != "" && !ok {
+		s.log.Warn().Str("agent", agent).Str("path", path).Msg("agent profile no longer configured, using the rules' agent")
+		agent = ""
+	}
+	renderer, err := s.rendererForAgent(firstNonEmpty(agent, strategy.Agent))
 	if err != nil {
 		return err
 	}
@@ -832,7 +1434,7 @@ func firstNonEmpty(values ...string) string {
 }
 
 // Git returns the git client for use in background operations.
-func (s *SessionService) Git() git.Git {
+func (s *SessionService) Git() git.VCS {
 	return s.git
 }
 
@@ -841,9 +1443,9 @@ func generateID() string {
 	return randid.Generate(6)
 }
 
-// findValidRecyclable finds a recyclable session matching remote and cloneStrategy.
+// findValidRecyclable finds a recyclable session matching remote, cloneStrategy and vcs.
 // Returns nil if none found or all candidates are corrupted.
-func (s *SessionService) findValidRecyclable(ctx context.Context, remote, cloneStrategy string) *session.Session {
+func (s *SessionService) findValidRecyclable(ctx context.Context, remote, cloneStrategy, vcs string) *session.Session {
 	sessions, err := s.sessions.List(ctx)
 	if err != nil {
 		s.log.Warn().Err(err).Msg("failed to list sessions")
@@ -860,12 +1462,12 @@ func (s *SessionService) findValidRecyclable(ctx context.Context, remote, cloneS
 		}
 
 		// Skip non-recyclable sessions or strategy mismatch
-		if sess.State != session.StateRecycled || sess.Remote != remote || sessStrategy != cloneStrategy {
+		if sess.State != session.StateRecycled || sess.Remote != remote || sessStrategy != cloneStrategy || sessionVCSName(sess) != vcs {
 			continue
 		}
 
 		// Validate the repository
-		if err := s.git.IsValidRepo(ctx, sess.Path); err != nil {
+		if err := s.VCS(sess).IsValidRepo(ctx, sess.Path); err != nil {
 			s.log.Warn().Err(err).Str("session_id", sess.ID).Str("path", sess.Path).Msg("corrupted session found")
 			s.markCorrupted(ctx, sess)
 			continue
@@ -885,40 +1487,101 @@ func (s *SessionService) getBareCloneLock(remote string) *sync.Mutex {
 
 // ensureBareClone returns the path to the bare clone of remote, creating or fetching it as needed.
 // It serializes concurrent calls for the same remote to prevent duplicate clones.
-func (s *SessionService) ensureBareClone(ctx context.Context, remote string, progress io.Writer) (string, error) {
+func (s *SessionService) ensureBareClone(ctx context.Context, remote, vcsName string, progress io.Writer) (string, error) {
 	mu := s.getBareCloneLock(remote)
 	mu.Lock()
 	defer mu.Unlock()
 
-	bareDir := s.bareDirForRemote(remote)
-	_, statErr := os.Stat(bareDir)
-	if statErr != nil && !os.IsNotExist(statErr) {
-		return "", fmt.Errorf("stat bare dir: %w", statErr)
+	vcs := s.vcsFor(vcsName)
+	bareDir := s.bareDirForRemote(remote, vcsName)
+	exists, err := s.files.Exists(ctx, bareDir)
+	if err != nil {
+		return "", fmt.Errorf("stat bare dir: %w", err)
 	}
-	if os.IsNotExist(statErr) {
+	if !exists {
 		writeProgressf(progress, "Creating bare clone (one-time setup for this repo, may take a while)...")
-		if err := os.MkdirAll(filepath.Dir(bareDir), 0o755); err != nil {
+		if err := s.files.MkdirAll(ctx, filepath.Dir(bareDir)); err != nil {
 			return "", fmt.Errorf("create bare parent: %w", err)
 		}
-		if err := s.git.CloneBare(ctx, remote, bareDir); err != nil {
-			_ = os.RemoveAll(bareDir) // clean up partial clone
+		if err := vcs.CloneBare(ctx, remote, bareDir); err != nil {
+			_ = s.files.RemoveAll(ctx, bareDir) // clean up partial clone
 			return "", fmt.Errorf("bare clone: %w", err)
 		}
 	} else {
 		writeProgressf(progress, "Fetching latest changes...")
-		if err := s.git.Fetch(ctx, bareDir); err != nil {
+		if err := vcs.Fetch(ctx, bareDir); err != nil {
 			return "", fmt.Errorf("fetch bare: %w", err)
 		}
 	}
 	return bareDir, nil
 }
 
-// bareDirForRemote returns the path where the bare clone for remote is stored.
-func (s *SessionService) bareDirForRemote(remote string) string {
+// removeWorktree removes a worktree session's checkout from the shared
+// clone and deletes branch when provided. If the worktree cannot be removed,
+// its directory is deleted and the shared clone pruned of it, so a damaged
+// checkout does not stay registered. A branch checked out by another
+// worktree is kept. Failures are logged; callers go on to remove the
+// directory regardless.
+func (s *SessionService) removeWorktree(ctx context.Context, sess *session.Session, branch string) {
+	vcs := s.VCS(sess)
+	bareDir := s.bareDirForRemote(sess.Remote, sessionVCSName(sess))
+
+	err := vcs.WorktreeRemove(ctx, bareDir, sess.Path, branch)
+	if err != nil && !errors.Is(err, git.ErrBranchInUse) {
+		s.log.Warn().Err(err).Str("session_id", sess.ID).Msg("worktree remove failed, removing directory and pruning")
+		if err := s.files.RemoveAll(ctx, sess.Path); err != nil {
+			s.log.Warn().Err(err).Str("session_id", sess.ID).Msg("failed to remove worktree directory")
+			return
+		}
+		if err := vcs.WorktreePrune(ctx, bareDir); err != nil {
+			s.log.Warn().Err(err).Str("session_id", sess.ID).Msg("worktree prune failed")
+			return
+		}
+		// With its worktree pruned, the branch is no longer checked out.
+		err = nil
+		if branch != "" {
+			err = vcs.WorktreeRemove(ctx, bareDir, sess.Path, branch)
+		}
+	}
+	if errors.Is(err, git.ErrBranchInUse) {
+		s.log.Info().Str("session_id", sess.ID).Str("branch", branch).Msg("keeping worktree branch checked out in another worktree")
+	} else if err != nil {
+		s.log.Warn().Err(err).Str("session_id", sess.ID).Str("branch", branch).Msg("failed to delete worktree branch")
+	}
+}
+
+// bareDirForRemote returns the path where the shared clone for remote is
+// stored. jj keeps its own clone since it cannot share a bare git repo.
+func (s *SessionService) bareDirForRemote(remote, vcs string) string {
 	owner, repo := git.ExtractOwnerRepo(remote)
+	if vcs == config.VCSJJ {
+		return filepath.Join(s.config.ReposDir(), ".jj", owner, repo)
+	}
 	return filepath.Join(s.config.ReposDir(), ".bare", owner, repo)
 }
 
+// VCS returns the version control backend a session was created with.
+func (s *SessionService) VCS(sess *session.Session) git.VCS {
+	return s.vcsFor(sessionVCSName(sess))
+}
+
+// vcsFor returns the backend for a vcs config value, defaulting to git.
+func (s *SessionService) vcsFor(name string) git.VCS {
+	if name == config.VCSJJ {
+		return git.NewJJExecutor(s.config.JJPath, s.executor)
+	}
+	return s.git
+}
+
+// sessionVCSName returns the vcs a session was created with; sessions
+// without the metadata use git.
+func sessionVCSName(sess *session.Session) string {
+	if name := sess.GetMeta(session.MetaVCS); name != "" {
+		return name
+	}
+	return config.VCSGit
+}
+
 // markCorrupted marks a session as corrupted and optionally deletes it.
 func (s *SessionService) markCorrupted(ctx context.Context, sess *session.Session) {
 	sess.MarkCorrupted(time.Now())
@@ -940,9 +1603,12 @@ func (s *SessionService) markCorrupted(ctx context.Context, sess *session.Sessio
 	}
 }
 
-// executeRules executes all rules matching the remote URL.
-func (s *SessionService) executeRules(ctx context.Context, remote, source, dest string, data config.SpawnTemplateData) error {
-	for _, rule := range s.config.Rules {
+// executeRules executes the rules matching the remote URL, writing their
+// output to stdout and stderr.
+func (s *SessionService) executeRules(ctx context.Context, rules []config.Rule, remote, source, dest string, data config.SpawnTemplateData, stdout, stderr io.Writer) error {
+	copier := s.fileCopier.withOutput(stdout)
+	hooks := s.hookRunner.withOutput(stdout, stderr)
+	for _, rule := range rules {
 		matched, err := matchRemotePattern(rule.Pattern, remote)
 		if err != nil {
 			return fmt.Errorf("match pattern %q: %w", rule.Pattern, err)
@@ -957,16 +1623,19 @@ func (s *SessionService) executeRules(ctx context.Context, remote, source, dest
 			Strs("copy", rule.Copy).
 			Msg("rule matched")
 
-		// Copy files first (so hooks can operate on them)
-		if len(rule.Copy) > 0 && source != "" {
-			if err := s.fileCopier.CopyFiles(ctx, rule, source, dest); err != nil {
+		// Copy files first (so hooks can operate on them). Sessions on
+		// another host cannot copy from the local source directory.
+		if len(rule.Copy) > 0 && source != "" && s.host != "" {
+			s.log.Warn().Str("pattern", rule.Pattern).Str("host", s.host).Msg("skipping copy rule for remote session")
+		} else if len(rule.Copy) > 0 && source != "" {
+			if err := copier.CopyFiles(ctx, rule, source, dest); err != nil {
 				return fmt.Errorf("copy files: %w", err)
 			}
 		}
 
 		// Run commands
 		if len(rule.Commands) > 0 {
-			if err := s.hookRunner.RunHooks(ctx, rule, dest, data); err != nil {
+			if err := hooks.RunHooks(ctx, rule, dest, data); err != nil {
 				return fmt.Errorf("run hooks: %w", err)
 			}
 		}
@@ -2,10 +2,12 @@ package hive
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"testing"
 	"time"
 
@@ -27,6 +29,7 @@ const testRemote = "https://github.com/test/repo"
 // mockStore implements session.Store for testing.
 type mockStore struct {
 	sessions map[string]session.Session
+	listErr  error
 }
 
 func newMockStore() *mockStore {
@@ -34,6 +37,9 @@ func newMockStore() *mockStore {
 }
 
 func (m *mockStore) List(_ context.Context) ([]session.Session, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
 	var result []session.Session
 	for _, s := range m.sessions {
 		result = append(result, s)
@@ -59,25 +65,53 @@ func (m *mockStore) Delete(_ context.Context, id string) error {
 	return nil
 }
 
-// mockGit implements git.Git for testing.
-type mockGit struct{}
-
-func (m *mockGit) Clone(_ context.Context, _, _ string) error                   { return nil }
-func (m *mockGit) Checkout(_ context.Context, _, _ string) error                { return nil }
-func (m *mockGit) Pull(_ context.Context, _ string) error                       { return nil }
-func (m *mockGit) ResetHard(_ context.Context, _ string) error                  { return nil }
-func (m *mockGit) RemoteURL(_ context.Context, _ string) (string, error)        { return "", nil }
-func (m *mockGit) IsClean(_ context.Context, _ string) (bool, error)            { return true, nil }
-func (m *mockGit) Branch(_ context.Context, _ string) (string, error)           { return "main", nil }
-func (m *mockGit) CloneBare(_ context.Context, _, _ string) error               { return nil }
-func (m *mockGit) WorktreeAdd(_ context.Context, _, _, _ string) error          { return nil }
-func (m *mockGit) WorktreeRemove(_ context.Context, _, _, _ string) error       { return nil }
-func (m *mockGit) Fetch(_ context.Context, _ string) error                      { return nil }
-func (m *mockGit) HasUnpushedCommits(_ context.Context, _ string) (bool, error) { return false, nil }
+// mockGit implements git.VCS for testing.
+type mockGit struct {
+	forks      []string // "dir<-srcDir@branch" for each ForkBranch call
+	starts     []string // "dir:branch@base" for each StartBranch call
+	divergence git.Divergence
+	dirty      map[string]bool // paths IsClean reports as dirty
+	unpushed   int             // commits UnpushedCommits reports
+	statsErr   error           // returned by DiffStats
+}
+
+func (m *mockGit) Clone(_ context.Context, _, _ string) error             { return nil }
+func (m *mockGit) Checkout(_ context.Context, _, _ string) error          { return nil }
+func (m *mockGit) Pull(_ context.Context, _ string) error                 { return nil }
+func (m *mockGit) ResetHard(_ context.Context, _ string) error            { return nil }
+func (m *mockGit) RemoteURL(_ context.Context, _ string) (string, error)  { return "", nil }
+func (m *mockGit) IsClean(_ context.Context, dir string) (bool, error)    { return !m.dirty[dir], nil }
+func (m *mockGit) Branch(_ context.Context, _ string) (string, error)     { return "main", nil }
+func (m *mockGit) CloneBare(_ context.Context, _, _ string) error         { return nil }
+func (m *mockGit) WorktreeAdd(_ context.Context, _, _, _ string) error    { return nil }
+func (m *mockGit) WorktreeRemove(_ context.Context, _, _, _ string) error { return nil }
+func (m *mockGit) WorktreePrune(_ context.Context, _ string) error        { return nil }
+func (m *mockGit) Fetch(_ context.Context, _ string) error                { return nil }
+func (m *mockGit) Compare(_ context.Context, _, _ string) (git.Divergence, error) {
+	return m.divergence, nil
+}
+func (m *mockGit) StartBranch(_ context.Context, dir, branch, base string) error {
+	m.starts = append(m.starts, dir+":"+branch+"@"+base)
+	return nil
+}
+func (m *mockGit) ForkBranch(_ context.Context, dir, srcDir, branch string) error {
+	m.forks = append(m.forks, dir+"<-"+srcDir+"@"+branch)
+	return nil
+}
+func (m *mockGit) UnpushedCommits(_ context.Context, _ string) (int, error) { return m.unpushed, nil }
+func (m *mockGit) ChangedFiles(_ context.Context, dir string) ([]string, error) {
+	if m.dirty[dir] {
+		return []string{"main.go"}, nil
+	}
+	return nil, nil
+}
+func (m *mockGit) HeadStatus(_ context.Context, _ string) (git.HeadStatus, error) {
+	return git.HeadStatus{}, nil
+}
 func (m *mockGit) DefaultBranch(_ context.Context, _ string) (string, error) {
 	return "main", nil
 }
-func (m *mockGit) DiffStats(_ context.Context, _ string) (int, int, error) { return 0, 0, nil }
+func (m *mockGit) DiffStats(_ context.Context, _ string) (int, int, error) { return 0, 0, m.statsErr }
 func (m *mockGit) IsValidRepo(_ context.Context, _ string) error           { return nil }
 
 func newTestService(t *testing.T, store session.Store, cfg *config.Config) *SessionService {
@@ -170,6 +204,91 @@ func TestRenameSession_InvalidChars(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid session name")
 }
 
+// saveErrStore is a mockStore whose Save always fails.
+type saveErrStore struct{ *mockStore }
+
+func (s saveErrStore) Save(_ context.Context, _ session.Session) error {
+	return errors.New("disk full")
+}
+
+func tmuxCalls(exec *executiltest.Exec, subcommand string) [][]string {
+	var calls [][]string
+	for _, c := range exec.Calls() {
+		if c.Cmd == "tmux" && len(c.Args) > 0 && c.Args[0] == subcommand {
+			calls = append(calls, c.Args)
+		}
+	}
+	return calls
+}
+
+func TestRenameSession_RenamesTmuxSession(t *testing.T) {
+	store := newMockStore()
+	exec := &executiltest.Exec{}
+	svc := NewSessionService(store, &mockGit{}, &config.Config{DataDir: t.TempDir(), GitPath: "git"}, testbus.New(t).EventBus, exec, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard)
+
+	sess := session.Session{ID: "test1", Name: "old-name", Slug: "old-name", State: session.StateActive}
+	sess.SetMeta(session.MetaTmuxSession, "old-name")
+	require.NoError(t, store.Save(context.Background(), sess))
+
+	require.NoError(t, svc.RenameSession(context.Background(), "test1", "New Name"))
+
+	assert.Equal(t, [][]string{{"rename-session", "-t", "old-name", "new-name"}}, tmuxCalls(exec, "rename-session"))
+	updated, err := store.Get(context.Background(), "test1")
+	require.NoError(t, err)
+	assert.Equal(t, "new-name", updated.Slug)
+	assert.Equal(t, "new-name", updated.GetMeta(session.MetaTmuxSession))
+}
+
+func TestRenameSession_PinnedTmuxSessionUntouched(t *testing.T) {
+	store := newMockStore()
+	exec := &executiltest.Exec{}
+	svc := NewSessionService(store, &mockGit{}, &config.Config{DataDir: t.TempDir(), GitPath: "git"}, testbus.New(t).EventBus, exec, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard)
+
+	sess := session.Session{ID: "test1", Name: "old-name", Slug: "old-name", State: session.StateActive}
+	sess.SetMeta(session.MetaTmuxSession, "shared")
+	require.NoError(t, store.Save(context.Background(), sess))
+
+	require.NoError(t, svc.RenameSession(context.Background(), "test1", "new-name"))
+
+	assert.Empty(t, tmuxCalls(exec, "rename-session"))
+	updated, err := store.Get(context.Background(), "test1")
+	require.NoError(t, err)
+	assert.Equal(t, "shared", updated.GetMeta(session.MetaTmuxSession))
+}
+
+func TestRenameSession_TmuxFailureKeepsName(t *testing.T) {
+	store := newMockStore()
+	exec := &executiltest.Exec{Responses: []executiltest.Response{
+		{}, // has-session
+		{Stderr: []byte("duplicate session: new-name"), Err: errors.New("exit status 1")},
+	}}
+	svc := NewSessionService(store, &mockGit{}, &config.Config{DataDir: t.TempDir(), GitPath: "git"}, testbus.New(t).EventBus, exec, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard)
+	require.NoError(t, store.Save(context.Background(), session.Session{ID: "test1", Name: "old-name", Slug: "old-name", State: session.StateActive}))
+
+	err := svc.RenameSession(context.Background(), "test1", "new-name")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate session")
+
+	unchanged, err := store.Get(context.Background(), "test1")
+	require.NoError(t, err)
+	assert.Equal(t, "old-name", unchanged.Name)
+}
+
+func TestRenameSession_SaveFailureRestoresTmuxName(t *testing.T) {
+	store := newMockStore()
+	require.NoError(t, store.Save(context.Background(), session.Session{ID: "test1", Name: "old-name", Slug: "old-name", State: session.StateActive}))
+	exec := &executiltest.Exec{}
+	svc := NewSessionService(saveErrStore{store}, &mockGit{}, &config.Config{DataDir: t.TempDir(), GitPath: "git"}, testbus.New(t).EventBus, exec, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard)
+
+	err := svc.RenameSession(context.Background(), "test1", "new-name")
+	require.Error(t, err)
+
+	assert.Equal(t, [][]string{
+		{"rename-session", "-t", "old-name", "new-name"},
+		{"rename-session", "-t", "new-name", "old-name"},
+	}, tmuxCalls(exec, "rename-session"))
+}
+
 // TestCreateSession_SlugUsedAsTmuxName verifies that CreateSession uses the slug (not the
 // display name) as the tmux session name when using the windows spawn strategy. This ensures
 // that tmux session detection always works via slug-based lookup, even when the display name
@@ -236,6 +355,52 @@ func (c *capturingExec) RunDirStream(_ context.Context, _ string, _ io.Writer, _
 	return nil
 }
 
+// listPanesExec answers tmux list-panes with a fixed layout.
+type listPanesExec struct {
+	capturingExec
+	layout string
+}
+
+func (e *listPanesExec) Run(_ context.Context, cmd string, args ...string) ([]byte, error) {
+	if cmd == "tmux" && len(args) > 0 && args[0] == "list-panes" {
+		return []byte(e.layout), nil
+	}
+	return nil, nil
+}
+
+func TestCreateSession_RecordsTmuxTargets(t *testing.T) {
+	store := newMockStore()
+	exec := &listPanesExec{layout: "0\tshell\t%7\n1\tagent\t%8\n1\tagent\t%9\n"}
+	cfg := &config.Config{
+		DataDir: t.TempDir(),
+		GitPath: "git",
+		Rules: []config.Rule{
+			{
+				Pattern: "",
+				Windows: []config.WindowConfig{{Name: "shell"}, {Name: "agent", Focus: true}},
+			},
+		},
+	}
+	svc := NewSessionService(store, &mockGit{}, cfg, testbus.New(t).EventBus, exec, tmpl.New(tmpl.Config{}), zerolog.New(io.Discard), io.Discard, io.Discard)
+
+	sess, err := svc.CreateSession(context.Background(), CreateOptions{
+		Name:       "targets",
+		Remote:     testRemote,
+		Background: true,
+	})
+	require.NoError(t, err)
+
+	stored, err := store.Get(context.Background(), sess.ID)
+	require.NoError(t, err)
+	for _, s := range []session.Session{*sess, stored} {
+		assert.Equal(t, "targets", s.GetMeta(session.MetaTmuxSession))
+		assert.Equal(t, "1", s.GetMeta(session.MetaTmuxWindow), "focused window is the agent window")
+		assert.Equal(t, "%8", s.GetMeta(session.MetaTmuxPane))
+		assert.JSONEq(t, `[{"index":"0","name":"shell","panes":["%7"]},{"index":"1","name":"agent","panes":["%8","%9"]}]`,
+			s.GetMeta(session.MetaTmuxTargets))
+	}
+}
+
 func TestCreateSession_AgentKeyOverridesSpawnRenderer(t *testing.T) {
 	store := newMockStore()
 	exec := &capturingStreamExec{}
@@ -257,7 +422,7 @@ func TestCreateSession_AgentKeyOverridesSpawnRenderer(t *testing.T) {
 	renderer := tmpl.New(tmpl.Config{AgentCommand: "claude", AgentWindow: "claude"})
 	svc := NewSessionService(store, &mockGit{}, cfg, testbus.New(t).EventBus, exec, renderer, log, io.Discard, io.Discard)
 
-	_, err := svc.CreateSession(context.Background(), CreateOptions{
+	sess, err := svc.CreateSession(context.Background(), CreateOptions{
 		Name:     "agent override",
 		Remote:   testRemote,
 		AgentKey: "aider",
@@ -265,6 +430,10 @@ func TestCreateSession_AgentKeyOverridesSpawnRenderer(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, exec.streamCommands, 1)
 	assert.Equal(t, "aider-bin|aider|--yes --model sonnet", exec.streamCommands[0])
+
+	saved, err := store.Get(context.Background(), sess.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "aider", saved.GetMeta(session.MetaAgentProfile), "the profile is recorded for display")
 }
 
 func TestCreateSession_RuleAgentOverridesSpawnRenderer(t *testing.T) {
@@ -288,13 +457,17 @@ func TestCreateSession_RuleAgentOverridesSpawnRenderer(t *testing.T) {
 	renderer := tmpl.New(tmpl.Config{AgentCommand: "claude", AgentWindow: "claude"})
 	svc := NewSessionService(store, &mockGit{}, cfg, testbus.New(t).EventBus, exec, renderer, log, io.Discard, io.Discard)
 
-	_, err := svc.CreateSession(context.Background(), CreateOptions{
+	sess, err := svc.CreateSession(context.Background(), CreateOptions{
 		Name:   "rule agent override",
 		Remote: testRemote,
 	})
 	require.NoError(t, err)
 	require.Len(t, exec.streamCommands, 1)
 	assert.Equal(t, "aider-bin|aider|--model sonnet", exec.streamCommands[0])
+
+	saved, err := store.Get(context.Background(), sess.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "aider", saved.GetMeta(session.MetaAgentProfile))
 }
 
 func TestCreateSession_AgentKeyOverridesRuleAgent(t *testing.T) {
@@ -319,7 +492,7 @@ func TestCreateSession_AgentKeyOverridesRuleAgent(t *testing.T) {
 	renderer := tmpl.New(tmpl.Config{AgentCommand: "claude", AgentWindow: "claude"})
 	svc := NewSessionService(store, &mockGit{}, cfg, testbus.New(t).EventBus, exec, renderer, log, io.Discard, io.Discard)
 
-	_, err := svc.CreateSession(context.Background(), CreateOptions{
+	sess, err := svc.CreateSession(context.Background(), CreateOptions{
 		Name:     "cli agent override",
 		Remote:   testRemote,
 		AgentKey: "codex",
@@ -327,6 +500,10 @@ func TestCreateSession_AgentKeyOverridesRuleAgent(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, exec.streamCommands, 1)
 	assert.Equal(t, "codex-bin|codex|--fast", exec.streamCommands[0])
+
+	saved, err := store.Get(context.Background(), sess.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "codex", saved.GetMeta(session.MetaAgentProfile))
 }
 
 func TestCreateSession_UnknownAgentKey(t *testing.T) {
@@ -832,6 +1009,214 @@ func TestCreateSession_PathFormat(t *testing.T) {
 		"directory name must not use Session.ID")
 }
 
+func TestCreateSession_StartsBranch(t *testing.T) {
+	newService := func(t *testing.T, rules []config.Rule) (*SessionService, *mockGit) {
+		t.Helper()
+		cfg := &config.Config{DataDir: t.TempDir(), GitPath: "git", Rules: rules}
+		vcs := &mockGit{}
+		return NewSessionService(newMockStore(), vcs, cfg, testbus.New(t).EventBus, &executiltest.Exec{}, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard), vcs
+	}
+	opts := CreateOptions{Name: "auth", Remote: "https://github.com/example/myrepo.git", SkipSpawn: true}
+
+	t.Run("full clone stays on the default branch without rules", func(t *testing.T) {
+		svc, vcs := newService(t, nil)
+		sess, err := svc.CreateSession(context.Background(), opts)
+		require.NoError(t, err)
+		assert.Empty(t, vcs.starts)
+		assert.Empty(t, sess.Branch())
+	})
+
+	t.Run("full clone starts the templated branch from the base", func(t *testing.T) {
+		svc, vcs := newService(t, []config.Rule{{Pattern: "", BranchTemplate: "agent/{{ .Name }}-{{ .ID }}", BaseBranch: "develop"}})
+		sess, err := svc.CreateSession(context.Background(), opts)
+		require.NoError(t, err)
+		require.Len(t, vcs.starts, 1)
+		assert.Regexp(t, `^`+regexp.QuoteMeta(sess.Path)+`:agent/auth-[a-z0-9]{6}@develop$`, vcs.starts[0])
+		assert.Regexp(t, `^agent/auth-[a-z0-9]{6}$`, sess.Branch())
+		assert.Equal(t, "develop", sess.GetMeta(session.MetaBaseBranch))
+	})
+
+	t.Run("base branch alone uses the default branch name", func(t *testing.T) {
+		svc, vcs := newService(t, []config.Rule{{Pattern: "", BaseBranch: "release"}})
+		sess, err := svc.CreateSession(context.Background(), opts)
+		require.NoError(t, err)
+		require.Len(t, vcs.starts, 1)
+		assert.Regexp(t, `^hive/auth-[a-z0-9]{6}$`, sess.Branch())
+	})
+
+	t.Run("worktree only restarts its branch for a base", func(t *testing.T) {
+		svc, vcs := newService(t, []config.Rule{{Pattern: "", CloneStrategy: config.CloneStrategyWorktree}})
+		sess, err := svc.CreateSession(context.Background(), opts)
+		require.NoError(t, err)
+		assert.Empty(t, vcs.starts, "worktree add already branches from the default branch")
+		assert.Equal(t, sess.GetMeta(session.MetaWorktreeBranch), sess.Branch())
+
+		svc, vcs = newService(t, []config.Rule{{Pattern: "", CloneStrategy: config.CloneStrategyWorktree, BaseBranch: "develop"}})
+		sess, err = svc.CreateSession(context.Background(), opts)
+		require.NoError(t, err)
+		require.Len(t, vcs.starts, 1)
+		assert.Equal(t, sess.Path+":"+sess.GetMeta(session.MetaWorktreeBranch)+"@develop", vcs.starts[0])
+	})
+}
+
+func TestCloneSession(t *testing.T) {
+	store := newMockStore()
+	cfg := &config.Config{DataDir: t.TempDir(), GitPath: "git"}
+	vcs := &mockGit{}
+	svc := NewSessionService(store, vcs, cfg, testbus.New(t).EventBus, &executiltest.Exec{}, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard)
+	ctx := context.Background()
+
+	require.NoError(t, store.Save(ctx, session.Session{
+		ID:     "src123",
+		Name:   "auth",
+		Slug:   "auth",
+		State:  session.StateActive,
+		Path:   "/repos/myrepo-aaaaaa",
+		Remote: "https://github.com/example/myrepo.git",
+		Tags:   []string{"backend"},
+	}))
+
+	clone, err := svc.CloneSession(ctx, "src123", CreateOptions{SkipSpawn: true})
+	require.NoError(t, err)
+	assert.Equal(t, "auth-clone", clone.Name)
+	assert.Equal(t, "https://github.com/example/myrepo.git", clone.Remote)
+	assert.Equal(t, []string{"backend"}, clone.Tags)
+	require.Len(t, vcs.forks, 1)
+	assert.Regexp(t, `^`+regexp.QuoteMeta(clone.Path)+`<-/repos/myrepo-aaaaaa@hive/auth-clone-[a-z0-9]{6}$`, vcs.forks[0])
+
+	second, err := svc.CloneSession(ctx, "src123", CreateOptions{SkipSpawn: true})
+	require.NoError(t, err)
+	assert.Equal(t, "auth-clone-2", second.Name)
+
+	named, err := svc.CloneSession(ctx, "src123", CreateOptions{Name: "auth-variant", SkipSpawn: true})
+	require.NoError(t, err)
+	assert.Equal(t, "auth-variant", named.Name)
+
+	_, err = svc.CloneSession(ctx, "missing", CreateOptions{})
+	require.ErrorIs(t, err, session.ErrNotFound)
+
+	store.listErr = errors.New("database is locked")
+	_, err = svc.CloneSession(ctx, "src123", CreateOptions{SkipSpawn: true})
+	require.ErrorContains(t, err, "database is locked", "a clone name is not guessed without the session list")
+	store.listErr = nil
+
+	jj := session.Session{ID: "jj1", Name: "jj", State: session.StateActive, Path: "/repos/jj", Remote: "https://github.com/example/jjrepo.git"}
+	jj.SetMeta(session.MetaVCS, config.VCSJJ)
+	require.NoError(t, store.Save(ctx, jj))
+	forks := len(vcs.forks)
+	_, err = svc.CloneSession(ctx, "jj1", CreateOptions{SkipSpawn: true})
+	require.ErrorContains(t, err, "jj")
+	assert.Len(t, vcs.forks, forks, "nothing is cloned for a jj session")
+}
+
+func TestCompareSessions(t *testing.T) {
+	store := newMockStore()
+	cfg := &config.Config{DataDir: t.TempDir(), GitPath: "git"}
+	vcs := &mockGit{divergence: git.Divergence{
+		Ahead:     []git.Commit{{Hash: "abc1234", Subject: "Use JWT"}},
+		Additions: 12,
+		Deletions: 3,
+	}}
+	svc := NewSessionService(store, vcs, cfg, testbus.New(t).EventBus, &executiltest.Exec{}, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard)
+	ctx := context.Background()
+
+	for _, sess := range []session.Session{
+		{ID: "a", Name: "auth", State: session.StateActive, Path: "/a", Remote: "git@github.com:example/repo.git"},
+		{ID: "b", Name: "auth-clone", State: session.StateActive, Path: "/b", Remote: "https://github.com/example/repo"},
+		{ID: "c", Name: "other", State: session.StateActive, Path: "/c", Remote: "https://github.com/example/other"},
+		{ID: "d", Name: "old", State: session.StateRecycled, Path: "/d", Remote: "https://github.com/example/repo"},
+	} {
+		require.NoError(t, store.Save(ctx, sess))
+	}
+
+	cmp, err := svc.CompareSessions(ctx, "a", "b")
+	require.NoError(t, err)
+	assert.Equal(t, "auth", cmp.A.Session.Name)
+	assert.Equal(t, "main", cmp.B.Branch)
+	assert.Equal(t, vcs.divergence, cmp.Divergence)
+	assert.Empty(t, cmp.A.Errors)
+
+	vcs.statsErr = errors.New("bad object")
+	cmp, err = svc.CompareSessions(ctx, "a", "b")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"diff stats: bad object"}, cmp.A.Errors)
+	assert.Equal(t, "main", cmp.A.Branch, "other lookups still fill their fields")
+	vcs.statsErr = nil
+
+	_, err = svc.CompareSessions(ctx, "a", "c")
+	require.ErrorContains(t, err, "different repositories")
+	_, err = svc.CompareSessions(ctx, "a", "d")
+	require.ErrorContains(t, err, "cannot be compared")
+	_, err = svc.CompareSessions(ctx, "a", "a")
+	require.Error(t, err)
+}
+
+func TestCheckSessionRisk(t *testing.T) {
+	store := newMockStore()
+	cfg := &config.Config{DataDir: t.TempDir(), GitPath: "git"}
+	vcs := &mockGit{dirty: map[string]bool{"/dirty": true}}
+	svc := NewSessionService(store, vcs, cfg, testbus.New(t).EventBus, &executiltest.Exec{}, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard)
+	ctx := context.Background()
+
+	for _, sess := range []session.Session{
+		{ID: "clean", Name: "clean", State: session.StateActive, Path: "/clean"},
+		{ID: "dirty", Name: "dirty", State: session.StateActive, Path: "/dirty"},
+		{ID: "recycled", Name: "recycled", State: session.StateRecycled, Path: "/dirty"},
+	} {
+		require.NoError(t, store.Save(ctx, sess))
+	}
+
+	risk, err := svc.CheckSessionRisk(ctx, "clean")
+	require.NoError(t, err)
+	assert.False(t, risk.HasRisk())
+
+	risk, err = svc.CheckSessionRisk(ctx, "dirty")
+	require.NoError(t, err)
+	assert.Equal(t, SessionRisk{UncommittedChanges: true, ChangedFiles: []string{"main.go"}}, risk)
+
+	vcs.unpushed = 2
+	risk, err = svc.CheckSessionRisk(ctx, "clean")
+	require.NoError(t, err)
+	assert.Equal(t, SessionRisk{UnpushedCommits: true, UnpushedCount: 2}, risk)
+
+	risk, err = svc.CheckSessionRisk(ctx, "recycled")
+	require.NoError(t, err)
+	assert.False(t, risk.HasRisk(), "recycled sessions have nothing to lose")
+}
+
+func TestCreateSession_JJRule(t *testing.T) {
+	store := newMockStore()
+	cfg := &config.Config{
+		DataDir: t.TempDir(),
+		GitPath: "git",
+		JJPath:  "jj",
+		Rules:   []config.Rule{{Pattern: "example/jjrepo", VCS: config.VCSJJ}},
+	}
+	exec := &executiltest.Exec{}
+	svc := NewSessionService(store, &mockGit{}, cfg, testbus.New(t).EventBus, exec, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard)
+
+	sess, err := svc.CreateSession(context.Background(), CreateOptions{
+		Name:   "jj-feature",
+		Remote: "https://github.com/example/jjrepo.git",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, config.VCSJJ, sess.GetMeta(session.MetaVCS))
+	assert.IsType(t, &git.JJExecutor{}, svc.VCS(sess))
+	require.NotEmpty(t, exec.Calls())
+	first := exec.Calls()[0]
+	assert.Equal(t, "jj", first.Cmd)
+	assert.Equal(t, []string{"git", "clone", "--colocate", "https://github.com/example/jjrepo.git", sess.Path}, first.Args)
+
+	other, err := svc.CreateSession(context.Background(), CreateOptions{
+		Name:   "git-feature",
+		Remote: "https://github.com/example/gitrepo.git",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, other.GetMeta(session.MetaVCS), "git sessions carry no vcs metadata")
+	assert.IsType(t, &mockGit{}, svc.VCS(other))
+}
+
 func TestRecycleSession_PathUnchanged(t *testing.T) {
 	store := newMockStore()
 	cfg := &config.Config{
@@ -862,6 +1247,184 @@ func TestRecycleSession_PathUnchanged(t *testing.T) {
 	assert.Equal(t, session.StateRecycled, recycled.State)
 }
 
+func TestRecycleSession_FailureNeedsAttention(t *testing.T) {
+	store := newMockStore()
+	cfg := &config.Config{DataDir: t.TempDir(), GitPath: "git"}
+	exec := &executiltest.Exec{Responses: []executiltest.Response{
+		{},
+		{Stderr: []byte("error: you need to resolve your current index first\n"), Err: errors.New("exit status 1")},
+	}}
+	svc := NewSessionService(store, &mockGit{}, cfg, testbus.New(t).EventBus, exec, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard)
+
+	sessDir := t.TempDir()
+	require.NoError(t, store.Save(context.Background(), session.Session{
+		ID:     "abc123",
+		Name:   "my-session",
+		Slug:   "my-session",
+		State:  session.StateActive,
+		Path:   sessDir,
+		Remote: "https://github.com/example/repo.git",
+	}))
+
+	err := svc.RecycleSession(context.Background(), "abc123", io.Discard)
+	require.Error(t, err)
+
+	failed, err := store.Get(context.Background(), "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, session.StateActive, failed.State)
+	assert.True(t, failed.NeedsAttention())
+	assert.Contains(t, failed.GetMeta(session.MetaRecycleError), "git checkout -f main")
+
+	require.NoError(t, svc.ForceRecycleSession(context.Background(), "abc123", io.Discard))
+
+	recycled, err := store.Get(context.Background(), "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, session.StateRecycled, recycled.State)
+	assert.False(t, recycled.NeedsAttention())
+}
+
+func TestSyncSession(t *testing.T) {
+	store := newMockStore()
+	cfg := &config.Config{DataDir: t.TempDir(), GitPath: "git"}
+	exec := &executiltest.Exec{Responses: []executiltest.Response{
+		{},
+		{Stderr: []byte("CONFLICT (content): Merge conflict in main.go\n"), Err: errors.New("exit status 1")},
+	}}
+	svc := NewSessionService(store, &mockGit{}, cfg, testbus.New(t).EventBus, exec, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard)
+	ctx := context.Background()
+
+	sess := session.Session{
+		ID:     "abc123",
+		Name:   "my-session",
+		Slug:   "my-session",
+		State:  session.StateActive,
+		Path:   t.TempDir(),
+		Remote: "https://github.com/example/repo.git",
+	}
+	sess.SetBranch("hive/my-session", "release")
+	require.NoError(t, store.Save(ctx, sess))
+
+	require.Error(t, svc.SyncSession(ctx, "abc123", io.Discard))
+
+	calls := exec.Calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, sess.Path, calls[1].Dir)
+	assert.Contains(t, calls[1].Args, "git rebase origin/release", "rebases onto the branch the session started from")
+
+	failed, err := store.Get(ctx, "abc123")
+	require.NoError(t, err)
+	assert.Contains(t, failed.SyncConflict(), "git rebase origin/release")
+
+	require.NoError(t, svc.SyncSession(ctx, "abc123", io.Discard))
+
+	synced, err := store.Get(ctx, "abc123")
+	require.NoError(t, err)
+	assert.Empty(t, synced.SyncConflict())
+	_, ok := synced.SyncedAt()
+	assert.True(t, ok)
+}
+
+func TestArchiveSession(t *testing.T) {
+	store := newMockStore()
+	cfg := &config.Config{DataDir: t.TempDir(), GitPath: "git"}
+	svc := newTestService(t, store, cfg)
+	ctx := context.Background()
+
+	sessDir := filepath.Join(t.TempDir(), "repo-abc123")
+	require.NoError(t, os.MkdirAll(sessDir, 0o755))
+	require.NoError(t, store.Save(ctx, session.Session{
+		ID:     "abc123",
+		Name:   "my-session",
+		Slug:   "my-session",
+		State:  session.StateActive,
+		Path:   sessDir,
+		Remote: "https://github.com/example/repo.git",
+	}))
+
+	require.NoError(t, svc.ArchiveSession(ctx, "abc123"))
+	assert.NoDirExists(t, sessDir)
+
+	archived, err := store.Get(ctx, "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, session.StateArchived, archived.State)
+	assert.Equal(t, "main", archived.ArchiveSummary().Branch)
+
+	live, err := svc.ListSessions(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, live, "archived sessions are hidden from ListSessions")
+
+	history, err := svc.ListArchivedSessions(ctx)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "abc123", history[0].ID)
+
+	require.Error(t, svc.ArchiveSession(ctx, "abc123"), "only active sessions can be archived")
+}
+
+func TestArchiveOverdue(t *testing.T) {
+	store := newMockStore()
+	cfg := &config.Config{DataDir: t.TempDir(), GitPath: "git"}
+	svc := newTestService(t, store, cfg)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	save := func(id string, due time.Time) string {
+		dir := filepath.Join(t.TempDir(), id)
+		require.NoError(t, os.MkdirAll(dir, 0o755))
+		sess := session.Session{ID: id, Name: id, Slug: id, State: session.StateActive, Path: dir}
+		sess.SetDueAt(due)
+		require.NoError(t, store.Save(ctx, sess))
+		return dir
+	}
+	save("long-overdue", now.Add(-5*24*time.Hour))
+	save("just-overdue", now.Add(-time.Hour))
+	save("not-due", now.Add(time.Hour))
+	dirtyDir := save("dirty", now.Add(-5*24*time.Hour))
+	svc.git.(*mockGit).dirty = map[string]bool{dirtyDir: true}
+
+	archived, err := svc.ArchiveOverdue(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, archived, "nothing is archived without due.archive_after")
+
+	cfg.Due.ArchiveAfter = 72 * time.Hour
+	archived, err = svc.ArchiveOverdue(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, archived, 1)
+	assert.Equal(t, "long-overdue", archived[0].ID)
+
+	for id, want := range map[string]session.State{
+		"long-overdue": session.StateArchived,
+		"just-overdue": session.StateActive,
+		"not-due":      session.StateActive,
+		"dirty":        session.StateActive,
+	} {
+		sess, err := store.Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, want, sess.State, id)
+	}
+}
+
+func TestSetSessionDue(t *testing.T) {
+	store := newMockStore()
+	svc := newTestService(t, store, nil)
+	ctx := context.Background()
+	require.NoError(t, store.Save(ctx, session.Session{ID: "abc123", State: session.StateActive}))
+
+	due := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, svc.SetSessionDue(ctx, "abc123", due))
+	sess, err := store.Get(ctx, "abc123")
+	require.NoError(t, err)
+	got, ok := sess.DueAt()
+	require.True(t, ok)
+	assert.Equal(t, due, got)
+
+	require.NoError(t, svc.SetSessionDue(ctx, "abc123", time.Time{}))
+	sess, err = store.Get(ctx, "abc123")
+	require.NoError(t, err)
+	_, ok = sess.DueAt()
+	assert.False(t, ok)
+}
+
 func TestCreateSession_RecycledSessionKeepsPath(t *testing.T) {
 	store := newMockStore()
 	cfg := &config.Config{
@@ -879,12 +1442,17 @@ func TestCreateSession_RecycledSessionKeepsPath(t *testing.T) {
 		State:  session.StateRecycled,
 		Path:   recycledPath,
 		Remote: "https://github.com/example/repo.git",
+		Notes:  "waiting on CI",
+		Metadata: map[string]string{
+			session.MetaTmuxSession: "old-name",
+		},
 	}
 	require.NoError(t, store.Save(context.Background(), recycled))
 
 	sess, err := svc.CreateSession(context.Background(), CreateOptions{
-		Name:   "new-name",
-		Remote: "https://github.com/example/repo.git",
+		Name:      "new-name",
+		Remote:    "https://github.com/example/repo.git",
+		SkipSpawn: true,
 	})
 	require.NoError(t, err)
 
@@ -892,6 +1460,38 @@ func TestCreateSession_RecycledSessionKeepsPath(t *testing.T) {
 	assert.Equal(t, recycledPath, sess.Path, "path must not change on reactivation")
 	assert.Equal(t, "new-name", sess.Name)
 	assert.Equal(t, session.StateActive, sess.State)
+	assert.Empty(t, sess.GetMeta(session.MetaTmuxSession), "the previous use's tmux session is not kept")
+	assert.Empty(t, sess.Notes, "the previous use's notes are not kept")
+}
+
+func TestCreateSession_IssueMetadata(t *testing.T) {
+	store := newMockStore()
+	cfg := &config.Config{
+		DataDir: t.TempDir(),
+		GitPath: "git",
+	}
+	svc := newTestService(t, store, cfg)
+
+	recycled := session.Session{
+		ID:       "abc123",
+		Name:     "old-name",
+		Slug:     "old-name",
+		State:    session.StateRecycled,
+		Path:     filepath.Join(cfg.ReposDir(), "repo-abc123"),
+		Remote:   "https://github.com/example/repo.git",
+		Metadata: map[string]string{session.MetaIssueNumber: "7", session.MetaIssueURL: "https://github.com/example/repo/issues/7"},
+	}
+	require.NoError(t, store.Save(context.Background(), recycled))
+
+	sess, err := svc.CreateSession(context.Background(), CreateOptions{
+		Name:     "42 Fix login",
+		Remote:   "https://github.com/example/repo.git",
+		Metadata: map[string]string{session.MetaIssueNumber: "42"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "42", sess.GetMeta(session.MetaIssueNumber))
+	assert.Empty(t, sess.GetMeta(session.MetaIssueURL), "issue metadata from the previous use is cleared")
 }
 
 func TestCreateSession_DuplicateNameRejected(t *testing.T) {
@@ -981,6 +1581,34 @@ func TestSetSessionGroup(t *testing.T) {
 	assert.True(t, updated.UpdatedAt.After(past))
 }
 
+func TestSetSessionNotes(t *testing.T) {
+	store := newMockStore()
+	svc := newTestService(t, store, nil)
+
+	past := time.Now().Add(-1 * time.Hour)
+	require.NoError(t, store.Save(context.Background(), session.Session{
+		ID:        "test1",
+		Name:      "my-session",
+		State:     session.StateActive,
+		CreatedAt: past,
+		UpdatedAt: past,
+	}))
+
+	require.NoError(t, svc.SetSessionNotes(context.Background(), "test1", "waiting on CI\n  check logs  \n\n"))
+
+	updated, err := store.Get(context.Background(), "test1")
+	require.NoError(t, err)
+	assert.Equal(t, "waiting on CI\n  check logs", updated.Notes, "trailing whitespace is dropped")
+	assert.True(t, updated.UpdatedAt.After(past))
+
+	require.NoError(t, svc.SetSessionNotes(context.Background(), "test1", "  \n"))
+	updated, err = store.Get(context.Background(), "test1")
+	require.NoError(t, err)
+	assert.Empty(t, updated.Notes)
+
+	assert.Error(t, svc.SetSessionNotes(context.Background(), "nonexistent", "x"))
+}
+
 func TestSetSessionGroup_NotFound(t *testing.T) {
 	store := newMockStore()
 	svc := newTestService(t, store, nil)
@@ -1109,6 +1737,49 @@ func TestDeleteWorktreeSession(t *testing.T) {
 	assert.ErrorIs(t, err, session.ErrNotFound)
 }
 
+func TestDeleteWorktreeSession_PrunesBrokenWorktree(t *testing.T) {
+	spy := &worktreeRemoveSpy{errs: []error{errors.New("worktree is corrupt")}}
+	cfg := &config.Config{DataDir: t.TempDir(), GitPath: "git"}
+	store := newMockStore()
+	svc := NewSessionService(store, spy, cfg, testbus.New(t).EventBus, &executiltest.Exec{}, tmpl.New(tmpl.Config{}), zerolog.New(io.Discard), io.Discard, io.Discard)
+
+	sessDir := filepath.Join(t.TempDir(), "wt")
+	require.NoError(t, os.MkdirAll(sessDir, 0o755))
+	require.NoError(t, store.Save(context.Background(), session.Session{
+		ID:            "wt-broken",
+		State:         session.StateActive,
+		Path:          sessDir,
+		Remote:        "https://github.com/example/repo.git",
+		CloneStrategy: config.CloneStrategyWorktree,
+		Metadata:      map[string]string{session.MetaWorktreeBranch: "hive-broken"},
+	}))
+
+	require.NoError(t, svc.DeleteSession(context.Background(), "wt-broken"))
+
+	assert.NoDirExists(t, sessDir)
+	assert.Equal(t, []string{"remove hive-broken", "prune", "remove hive-broken"}, spy.calls,
+		"a worktree that cannot be removed is pruned after its directory is deleted, then its branch deleted")
+}
+
+func TestDeleteWorktreeSession_KeepsBranchInUse(t *testing.T) {
+	spy := &worktreeRemoveSpy{errs: []error{fmt.Errorf("%w: hive-shared", git.ErrBranchInUse)}}
+	cfg := &config.Config{DataDir: t.TempDir(), GitPath: "git"}
+	store := newMockStore()
+	svc := NewSessionService(store, spy, cfg, testbus.New(t).EventBus, &executiltest.Exec{}, tmpl.New(tmpl.Config{}), zerolog.New(io.Discard), io.Discard, io.Discard)
+
+	require.NoError(t, store.Save(context.Background(), session.Session{
+		ID:            "wt-shared",
+		State:         session.StateActive,
+		Path:          t.TempDir(),
+		Remote:        "https://github.com/example/repo.git",
+		CloneStrategy: config.CloneStrategyWorktree,
+		Metadata:      map[string]string{session.MetaWorktreeBranch: "hive-shared"},
+	}))
+
+	require.NoError(t, svc.DeleteSession(context.Background(), "wt-shared"))
+	assert.Equal(t, []string{"remove hive-shared"}, spy.calls, "a branch in use is not retried")
+}
+
 func TestEnforceMaxRecycled_SeparateByStrategy(t *testing.T) {
 	intPtr := func(n int) *int { return &n }
 
@@ -1172,7 +1843,7 @@ func TestEnforceMaxRecycled_SeparateByStrategy(t *testing.T) {
 }
 
 func TestCreateSession_BranchTemplate(t *testing.T) {
-	makeService := func(t *testing.T, gitImpl git.Git, rules []config.Rule) *SessionService {
+	makeService := func(t *testing.T, gitImpl git.VCS, rules []config.Rule) *SessionService {
 		t.Helper()
 		store := newMockStore()
 		cfg := &config.Config{
@@ -1304,9 +1975,33 @@ func (m *capturingMockGit) WorktreeAdd(ctx context.Context, repoDir, path, branc
 	return nil
 }
 
+// worktreeRemoveSpy records worktree removals and prunes, failing removals
+// with errs in order.
+type worktreeRemoveSpy struct {
+	mockGit
+	errs  []error
+	calls []string
+}
+
+func (m *worktreeRemoveSpy) WorktreeRemove(_ context.Context, _, _, branch string) error {
+	m.calls = append(m.calls, "remove "+branch)
+	if len(m.errs) == 0 {
+		return nil
+	}
+	err := m.errs[0]
+	m.errs = m.errs[1:]
+	return err
+}
+
+func (m *worktreeRemoveSpy) WorktreePrune(_ context.Context, _ string) error {
+	m.calls = append(m.calls, "prune")
+	return nil
+}
+
 // Ensure the mock implements the interface at compile time.
 var (
-	_ git.Git       = (*mockGit)(nil)
-	_ git.Git       = (*capturingMockGit)(nil)
+	_ git.VCS       = (*mockGit)(nil)
+	_ git.VCS       = (*capturingMockGit)(nil)
+	_ git.VCS       = (*worktreeRemoveSpy)(nil)
 	_ session.Store = (*mockStore)(nil)
 )
@@ -0,0 +1,93 @@
+package hive
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/session"
+)
+
+// SessionFileName is the file written into each session directory when
+// session_file is enabled.
+const SessionFileName = ".hive-session.md"
+
+// writeSessionFile writes (or rewrites) the session's .hive-session.md so
+// agents landing in the directory can find their hive context without
+// environment variables. It is a no-op unless session_file is enabled.
+// Failures are logged rather than returned; the file is informational.
+func (s *SessionService) writeSessionFile(ctx context.Context, sess *session.Session) {
+	if !s.config.SessionFile {
+		return
+	}
+
+	// Colocated jj clones read the same exclude file; jj workspaces have no
+	// .git and need a global ignore instead.
+	if err := s.files.AddLocalExclude(ctx, sess.Path, "/"+SessionFileName); err != nil {
+		event := s.log.Debug()
+		if sessionVCSName(sess) == config.VCSGit {
+			event = s.log.Warn()
+		}
+		event.Err(err).Str("session_id", sess.ID).Msg("failed to exclude session file from version control")
+	}
+
+	owner, repo := git.ExtractOwnerRepo(sess.Remote)
+	content := renderSessionFile(sess, s.config.RepoContextDir(owner, repo))
+	if err := s.files.WriteFile(ctx, filepath.Join(sess.Path, SessionFileName), []byte(content)); err != nil {
+		s.log.Warn().Err(err).Str("session_id", sess.ID).Msg("failed to write session file")
+	}
+}
+
+// removeSessionFile deletes the session file so a recycled directory does not
+// advertise the previous session.
+func (s *SessionService) removeSessionFile(ctx context.Context, sess *session.Session) {
+	if err := s.files.Remove(ctx, filepath.Join(sess.Path, SessionFileName)); err != nil {
+		s.log.Warn().Err(err).Str("session_id", sess.ID).Msg("failed to remove session file")
+	}
+}
+
+// renderSessionFile renders the markdown content of .hive-session.md.
+func renderSessionFile(sess *session.Session, contextDir string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Hive Session: %s\n\n", sess.Name)
+	b.WriteString("This directory is a hive session. hive rewrites this file when the session changes; edits are not kept.\n\n")
+
+	b.WriteString("| Field | Value |\n")
+	b.WriteString("| ----- | ----- |\n")
+	fmt.Fprintf(&b, "| ID | `%s` |\n", sess.ID)
+	fmt.Fprintf(&b, "| Name | %s |\n", sess.Name)
+	fmt.Fprintf(&b, "| Inbox topic | `%s` |\n", sess.InboxTopic())
+	fmt.Fprintf(&b, "| Remote | `%s` |\n", sess.Remote)
+	if branch := sess.Branch(); branch != "" {
+		fmt.Fprintf(&b, "| Branch | `%s` |\n", branch)
+	}
+	if agent := sess.GetMeta(session.MetaAgentProfile); agent != "" {
+		fmt.Fprintf(&b, "| Agent profile | %s |\n", agent)
+	}
+	if contextDir != "" {
+		fmt.Fprintf(&b, "| Context directory | `%s` |\n", contextDir)
+	}
+	if len(sess.Tags) > 0 {
+		fmt.Fprintf(&b, "| Tags | %s |\n", strings.Join(sess.Tags, ", "))
+	}
+
+	if prompt := strings.TrimSpace(sess.GetMeta(session.MetaPrompt)); prompt != "" {
+		b.WriteString("\n## Task\n\n")
+		b.WriteString(prompt)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n## Hive Commands\n\n")
+	b.WriteString("```bash\n")
+	b.WriteString("hive session info                    # this session's details\n")
+	b.WriteString("hive msg inbox                       # unread messages sent to this session\n")
+	b.WriteString("hive msg inbox --wait                # wait for the next message\n")
+	b.WriteString("hive msg pub -t <topic> -m \"...\"     # message another session\n")
+	b.WriteString("hive ctx ls                          # shared context documents for this repository\n")
+	b.WriteString("hive review                          # review context documents\n")
+	b.WriteString("```\n")
+	return b.String()
+}
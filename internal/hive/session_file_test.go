@@ -0,0 +1,90 @@
+package hive
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/session"
+)
+
+// seedRecyclable saves a recycled full-clone session whose directory exists,
+// so CreateSession reuses it instead of cloning.
+func seedRecyclable(t *testing.T, store session.Store, cfg *config.Config, remote string) string {
+	t.Helper()
+	dir := filepath.Join(cfg.ReposDir(), "repo-seed01")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0o755))
+	require.NoError(t, store.Save(context.Background(), session.Session{
+		ID:            "seed01",
+		Name:          "old",
+		Slug:          "old",
+		Path:          dir,
+		Remote:        remote,
+		State:         session.StateRecycled,
+		CloneStrategy: config.CloneStrategyFull,
+		Metadata:      map[string]string{session.MetaPrompt: "previous task"},
+	}))
+	return dir
+}
+
+func TestSessionFile(t *testing.T) {
+	const remote = "https://github.com/example/repo.git"
+	store := newMockStore()
+	cfg := &config.Config{DataDir: t.TempDir(), GitPath: "git", SessionFile: true}
+	svc := newTestService(t, store, cfg)
+	dir := seedRecyclable(t, store, cfg, remote)
+	ctx := context.Background()
+
+	sess, err := svc.CreateSession(ctx, CreateOptions{
+		Name:      "fix-auth",
+		Remote:    remote,
+		Prompt:    "Fix the token refresh bug",
+		SkipSpawn: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, dir, sess.Path, "recycled directory is reused")
+
+	content, err := os.ReadFile(filepath.Join(dir, SessionFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# Hive Session: fix-auth\n")
+	assert.Contains(t, string(content), "| ID | `seed01` |")
+	assert.Contains(t, string(content), "| Inbox topic | `agent.seed01.inbox` |")
+	assert.Contains(t, string(content), "## Task\n\nFix the token refresh bug\n")
+	assert.NotContains(t, string(content), "previous task")
+
+	exclude, err := os.ReadFile(filepath.Join(dir, ".git", "info", "exclude"))
+	require.NoError(t, err)
+	assert.Equal(t, "/"+SessionFileName+"\n", string(exclude))
+
+	require.NoError(t, svc.RenameSession(ctx, sess.ID, "fix-auth-v2"))
+	content, err = os.ReadFile(filepath.Join(dir, SessionFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# Hive Session: fix-auth-v2\n", "rename rewrites the file")
+	assert.Contains(t, string(content), "Fix the token refresh bug", "the prompt survives a rename")
+
+	require.NoError(t, svc.RecycleSession(ctx, sess.ID, io.Discard))
+	assert.NoFileExists(t, filepath.Join(dir, SessionFileName), "recycling removes the file")
+}
+
+func TestSessionFile_Disabled(t *testing.T) {
+	const remote = "https://github.com/example/repo.git"
+	store := newMockStore()
+	cfg := &config.Config{DataDir: t.TempDir(), GitPath: "git"}
+	svc := newTestService(t, store, cfg)
+	dir := seedRecyclable(t, store, cfg, remote)
+
+	sess, err := svc.CreateSession(context.Background(), CreateOptions{
+		Name:      "fix-auth",
+		Remote:    remote,
+		SkipSpawn: true,
+	})
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, SessionFileName))
+	assert.Empty(t, sess.GetMeta(session.MetaPrompt), "a recycled session drops the previous prompt")
+}
@@ -0,0 +1,109 @@
+package hive
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/colonyops/hive/internal/core/kv"
+)
+
+// ErrNoSessionValue is returned by SessionValue when a key is not set for a
+// session.
+var ErrNoSessionValue = errors.New("no value set")
+
+// sessionKVNamespace is the KV namespace session values are stored under.
+// Each session gets its own namespace, "session.kv.<id>", so its values can
+// be listed and removed with the session.
+const sessionKVNamespace = "session.kv"
+
+// SetSessionKV enables session values, stored in store and removed when
+// their session is deleted. A nil store disables them.
+func (s *SessionService) SetSessionKV(store kv.KV) {
+	s.sessionKV = store
+}
+
+func (s *SessionService) sessionValues(id string) (*kv.TypedKV[string], error) {
+	if s.sessionKV == nil {
+		return nil, fmt.Errorf("session values are not available")
+	}
+	return kv.Scoped[string](s.sessionKV, sessionKVNamespace+"."+id), nil
+}
+
+// SessionValue returns the value of key for a session, or
+// ErrNoSessionValue when it is not set.
+func (s *SessionService) SessionValue(ctx context.Context, id, key string) (string, error) {
+	values, err := s.sessionValues(id)
+	if err != nil {
+		return "", err
+	}
+	value, err := values.Get(ctx, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrNoSessionValue
+	}
+	return value, err
+}
+
+// SetSessionValue sets key to value for a session.
+func (s *SessionService) SetSessionValue(ctx context.Context, id, key, value string) error {
+	if strings.TrimSpace(key) == "" {
+		return fmt.Errorf("key is required")
+	}
+	values, err := s.sessionValues(id)
+	if err != nil {
+		return err
+	}
+	return values.Set(ctx, key, value)
+}
+
+// DeleteSessionValue removes key from a session's values.
+func (s *SessionService) DeleteSessionValue(ctx context.Context, id, key string) error {
+	values, err := s.sessionValues(id)
+	if err != nil {
+		return err
+	}
+	return values.Delete(ctx, key)
+}
+
+// SessionValues returns all values set for a session.
+func (s *SessionService) SessionValues(ctx context.Context, id string) (map[string]string, error) {
+	values, err := s.sessionValues(id)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := s.sessionKeys(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]string, len(keys))
+	for _, key := range keys {
+		value, err := values.Get(ctx, key)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue // removed since listing
+		}
+		if err != nil {
+			return nil, err
+		}
+		out[key] = value
+	}
+	return out, nil
+}
+
+// sessionKeys returns the keys set for a session, without the namespace.
+func (s *SessionService) sessionKeys(ctx context.Context, id string) ([]string, error) {
+	all, err := s.sessionKV.ListKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prefix := sessionKVNamespace + "." + id + ":"
+	var keys []string
+	for _, key := range all {
+		if rest, ok := strings.CutPrefix(key, prefix); ok {
+			keys = append(keys, rest)
+		}
+	}
+	return keys, nil
+}
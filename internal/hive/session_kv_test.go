@@ -0,0 +1,60 @@
+package hive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/session"
+)
+
+func TestSessionValues(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	svc := newTestService(t, store, nil)
+	svc.SetSessionKV(newSourcesTestKV(t))
+
+	_, err := svc.SessionValue(ctx, "s1", "last_sha")
+	require.ErrorIs(t, err, ErrNoSessionValue)
+
+	require.NoError(t, svc.SetSessionValue(ctx, "s1", "last_sha", "abc123"))
+	require.NoError(t, svc.SetSessionValue(ctx, "s1", "round", "2"))
+	require.NoError(t, svc.SetSessionValue(ctx, "s12", "round", "5"))
+	require.Error(t, svc.SetSessionValue(ctx, "s1", " ", "x"))
+
+	value, err := svc.SessionValue(ctx, "s1", "last_sha")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", value)
+
+	values, err := svc.SessionValues(ctx, "s1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"last_sha": "abc123", "round": "2"}, values)
+
+	require.NoError(t, svc.DeleteSessionValue(ctx, "s1", "round"))
+	values, err = svc.SessionValues(ctx, "s1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"last_sha": "abc123"}, values)
+}
+
+func TestSessionValues_RemovedWithSession(t *testing.T) {
+	ctx := context.Background()
+	store := newMockStore()
+	svc := newTestService(t, store, nil)
+	svc.SetSessionKV(newSourcesTestKV(t))
+
+	require.NoError(t, store.Save(ctx, session.Session{ID: "s1", Name: "one", State: session.StateActive, Path: t.TempDir()}))
+	require.NoError(t, svc.SetSessionValue(ctx, "s1", "last_sha", "abc123"))
+	require.NoError(t, svc.SetSessionValue(ctx, "s2", "last_sha", "def456"))
+
+	require.NoError(t, svc.DeleteSession(ctx, "s1"))
+	svc.WaitForCleanups()
+
+	values, err := svc.SessionValues(ctx, "s1")
+	require.NoError(t, err)
+	assert.Empty(t, values)
+	value, err := svc.SessionValue(ctx, "s2", "last_sha")
+	require.NoError(t, err)
+	assert.Equal(t, "def456", value, "other sessions keep their values")
+}
@@ -0,0 +1,351 @@
+package hive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/eventbus"
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/session"
+)
+
+// ErrSnapshotNotFound is returned when no snapshot has the requested ID.
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// SnapshotReasonRecycle marks snapshots taken automatically before a recycle
+// (rules snapshot_on_recycle).
+const SnapshotReasonRecycle = "recycle"
+
+// Snapshot describes a saved copy of a session directory. The directory is
+// stored as <id>.tar.gz under the snapshots directory, with the snapshot's
+// metadata in <id>.json.
+type Snapshot struct {
+	ID        string    `json:"id"`
+	SessionID string    `json:"session_id"`
+	Name      string    `json:"name"`
+	Remote    string    `json:"remote"`
+	Branch    string    `json:"branch,omitempty"`
+	Reason    string    `json:"reason,omitempty"` // SnapshotReasonRecycle for automatic snapshots
+	Size      int64     `json:"size"`             // bytes on disk, including the bundle
+	CreatedAt time.Time `json:"created_at"`
+	// Bundle is set for worktree sessions, whose history lives in the shared
+	// bare clone: the archive leaves out the .git file and the branch is
+	// saved in <id>.bundle instead.
+	Bundle bool `json:"bundle,omitempty"`
+}
+
+// SnapshotSession saves a copy of an active session's directory, including
+// uncommitted changes, so it can later be restored with RestoreSnapshot.
+// Snapshots beyond snapshots.max_per_session for the session are deleted,
+// oldest first.
+func (s *SessionService) SnapshotSession(ctx context.Context, id string) (Snapshot, error) {
+	sess, err := s.sessions.Get(ctx, id)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("get session: %w", err)
+	}
+	if sess.State != session.StateActive {
+		return Snapshot{}, fmt.Errorf("session %s cannot be snapshotted (state: %s)", id, sess.State)
+	}
+	return s.snapshot(ctx, &sess, "")
+}
+
+func (s *SessionService) snapshot(ctx context.Context, sess *session.Session, reason string) (Snapshot, error) {
+	if s.host != "" {
+		return Snapshot{}, fmt.Errorf("snapshots are not supported for sessions on remote hosts")
+	}
+
+	dir := s.config.SnapshotsDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return Snapshot{}, fmt.Errorf("create snapshots directory: %w", err)
+	}
+
+	snap := Snapshot{
+		ID:        generateID(),
+		SessionID: sess.ID,
+		Name:      sess.Name,
+		Remote:    sess.Remote,
+		Branch:    sess.Branch(),
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}
+
+	// A worktree's .git is a file pointing into the shared bare clone, which
+	// the archive cannot carry; save the checked-out branch as a bundle.
+	info, err := os.Lstat(filepath.Join(sess.Path, ".git"))
+	snap.Bundle = err == nil && !info.IsDir()
+	if snap.Bundle {
+		if sessionVCSName(sess) != config.VCSGit {
+			return Snapshot{}, fmt.Errorf("snapshots of %s worktree sessions are not supported", sessionVCSName(sess))
+		}
+		if err := s.writeBundle(ctx, sess.Path, &snap); err != nil {
+			return Snapshot{}, err
+		}
+	}
+
+	size, err := writeArchive(s.snapshotFile(snap.ID, ".tar.gz"), sess.Path, func(rel string) bool {
+		return snap.Bundle && rel == ".git"
+	})
+	if err != nil {
+		s.removeSnapshotFiles(snap.ID)
+		return Snapshot{}, fmt.Errorf("archive session directory: %w", err)
+	}
+	snap.Size += size
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err == nil {
+		err = os.WriteFile(s.snapshotFile(snap.ID, ".json"), data, 0o644)
+	}
+	if err != nil {
+		s.removeSnapshotFiles(snap.ID)
+		return Snapshot{}, fmt.Errorf("write snapshot metadata: %w", err)
+	}
+
+	s.log.Info().Str("session_id", sess.ID).Str("snapshot_id", snap.ID).Int64("size", snap.Size).Msg("session snapshotted")
+	s.pruneSnapshots(sess.ID)
+	return snap, nil
+}
+
+// snapshotBeforeRecycle takes the automatic snapshot of a session about to
+// be recycled (rules snapshot_on_recycle). Remote sessions are recycled
+// without one.
+func (s *SessionService) snapshotBeforeRecycle(ctx context.Context, sess *session.Session, w io.Writer) error {
+	if s.host != "" {
+		s.log.Warn().Str("host", s.host).Str("session_id", sess.ID).Msg("skipping snapshot before recycle for remote session")
+		return nil
+	}
+	snap, err := s.snapshot(ctx, sess, SnapshotReasonRecycle)
+	if err != nil {
+		return fmt.Errorf("snapshot before recycle: %w", err)
+	}
+	if w != nil {
+		_, _ = fmt.Fprintf(w, "Saved snapshot %s\n", snap.ID)
+	}
+	return nil
+}
+
+// writeBundle saves the branch checked out at dir, or the detached HEAD, as
+// the snapshot's git bundle.
+func (s *SessionService) writeBundle(ctx context.Context, dir string, snap *Snapshot) error {
+	out, err := s.executor.RunDir(ctx, dir, s.config.GitPath, "symbolic-ref", "--short", "-q", "HEAD")
+	if branch := strings.TrimSpace(string(out)); err == nil && branch != "" {
+		snap.Branch = branch
+	} else {
+		snap.Branch = ""
+	}
+
+	refs := []string{"HEAD"}
+	if snap.Branch != "" {
+		refs = append(refs, snap.Branch)
+	}
+	bundle := s.snapshotFile(snap.ID, ".bundle")
+	args := append([]string{"bundle", "create", bundle}, refs...)
+	if out, err := s.executor.RunDir(ctx, dir, s.config.GitPath, args...); err != nil {
+		return fmt.Errorf("git bundle: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	if info, err := os.Stat(bundle); err == nil {
+		snap.Size += info.Size()
+	}
+	return nil
+}
+
+// ListSnapshots returns the snapshots of a session, or of every session when
+// sessionID is empty, newest first.
+func (s *SessionService) ListSnapshots(sessionID string) ([]Snapshot, error) {
+	paths, err := filepath.Glob(filepath.Join(s.config.SnapshotsDir(), "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]Snapshot, 0, len(paths))
+	for _, path := range paths {
+		snap, err := readSnapshot(path)
+		if err != nil {
+			s.log.Warn().Err(err).Str("path", path).Msg("skipping unreadable snapshot")
+			continue
+		}
+		if sessionID == "" || snap.SessionID == sessionID {
+			snapshots = append(snapshots, snap)
+		}
+	}
+	slices.SortFunc(snapshots, func(a, b Snapshot) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return snapshots, nil
+}
+
+// GetSnapshot returns the snapshot with the given ID.
+func (s *SessionService) GetSnapshot(id string) (Snapshot, error) {
+	if id == "" || filepath.Base(id) != id {
+		return Snapshot{}, fmt.Errorf("%w: %q", ErrSnapshotNotFound, id)
+	}
+	snap, err := readSnapshot(s.snapshotFile(id, ".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return Snapshot{}, fmt.Errorf("%w: %q", ErrSnapshotNotFound, id)
+	}
+	return snap, err
+}
+
+// RestoreSnapshot creates a new session from a snapshot, with the files the
+// snapshotted directory had, and spawns it like a new session. The session
+// is named opts.Name, or after the snapshotted session. Restored sessions are
+// always full clones; a worktree snapshot's branch is cloned from its bundle
+// and its origin pointed back at the remote.
+func (s *SessionService) RestoreSnapshot(ctx context.Context, id string, opts CreateOptions) (*session.Session, error) {
+	if s.host != "" {
+		return nil, fmt.Errorf("snapshots are not supported for sessions on remote hosts")
+	}
+	snap, err := s.GetSnapshot(id)
+	if err != nil {
+		return nil, err
+	}
+
+	name := opts.Name
+	if name == "" {
+		if name, err = s.availableName(ctx, snap.Name); err != nil {
+			return nil, err
+		}
+	}
+	if err := session.ValidateName(name); err != nil {
+		return nil, err
+	}
+	existing, err := s.sessions.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+	for _, e := range existing {
+		if e.State == session.StateActive && e.Name == name {
+			return nil, fmt.Errorf("%w: %q", session.ErrDuplicateName, name)
+		}
+	}
+
+	dirID := generateID()
+	path := filepath.Join(s.config.ReposDir(), fmt.Sprintf("%s-%s", git.ExtractRepoName(snap.Remote), dirID))
+	writeProgressf(opts.Progress, "Restoring snapshot %s...", snap.ID)
+	if err := s.materializeSnapshot(ctx, snap, path); err != nil {
+		_ = os.RemoveAll(path)
+		return nil, fmt.Errorf("restore snapshot %s: %w", snap.ID, err)
+	}
+
+	now := time.Now()
+	sess := session.Session{
+		ID:            generateID(),
+		Name:          name,
+		Slug:          session.Slugify(name),
+		Path:          path,
+		Remote:        snap.Remote,
+		State:         session.StateActive,
+		CloneStrategy: config.CloneStrategyFull,
+		Tags:          opts.Tags,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	sess.SetBranch(snap.Branch, "")
+	sess.SetMeta(session.MetaRestoredFrom, snap.ID)
+
+	if err := s.sessions.Save(ctx, sess); err != nil {
+		_ = os.RemoveAll(path)
+		return nil, fmt.Errorf("save session: %w", err)
+	}
+	s.writeSessionFile(ctx, &sess)
+
+	if !opts.SkipSpawn {
+		writeProgressf(opts.Progress, "Spawning terminal...")
+		owner, repo := git.ExtractOwnerRepo(sess.Remote)
+		data := SpawnData{
+			Path:       sess.Path,
+			Name:       sess.Name,
+			Slug:       sess.Slug,
+			ContextDir: s.config.RepoContextDir(owner, repo),
+			Owner:      owner,
+			Repo:       repo,
+		}
+		if err := s.spawnSession(ctx, &sess, opts, data); err != nil {
+			return nil, err
+		}
+	}
+
+	s.log.Info().Str("session_id", sess.ID).Str("snapshot_id", snap.ID).Str("path", sess.Path).Msg("snapshot restored")
+	s.bus.PublishSessionCreated(eventbus.SessionCreatedPayload{Session: &sess})
+	return &sess, nil
+}
+
+// materializeSnapshot recreates a snapshot's directory at path.
+func (s *SessionService) materializeSnapshot(ctx context.Context, snap Snapshot, path string) error {
+	archive := s.snapshotFile(snap.ID, ".tar.gz")
+	if !snap.Bundle {
+		return extractArchive(archive, path)
+	}
+
+	args := []string{"clone", "--no-checkout"}
+	if snap.Branch != "" {
+		args = append(args, "--branch", snap.Branch)
+	}
+	args = append(args, s.snapshotFile(snap.ID, ".bundle"), path)
+	if out, err := s.executor.Run(ctx, s.config.GitPath, args...); err != nil {
+		return fmt.Errorf("clone bundle: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	if out, err := s.executor.RunDir(ctx, path, s.config.GitPath, "remote", "set-url", "origin", snap.Remote); err != nil {
+		return fmt.Errorf("set origin: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	if err := extractArchive(archive, path); err != nil {
+		return err
+	}
+	// The clone has no index; read it from HEAD so uncommitted changes in the
+	// archive show up as changes again.
+	if out, err := s.executor.RunDir(ctx, path, s.config.GitPath, "reset", "-q"); err != nil {
+		return fmt.Errorf("git reset: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// pruneSnapshots deletes a session's snapshots beyond
+// snapshots.max_per_session, oldest first.
+func (s *SessionService) pruneSnapshots(sessionID string) {
+	limit := s.config.Snapshots.MaxPerSession
+	if limit <= 0 {
+		return
+	}
+	snapshots, err := s.ListSnapshots(sessionID)
+	if err != nil {
+		s.log.Warn().Err(err).Str("session_id", sessionID).Msg("failed to list snapshots")
+		return
+	}
+	for _, snap := range snapshots[min(limit, len(snapshots)):] {
+		s.log.Debug().Str("session_id", sessionID).Str("snapshot_id", snap.ID).Msg("deleting old snapshot")
+		s.removeSnapshotFiles(snap.ID)
+	}
+}
+
+// removeSnapshotFiles deletes every file of a snapshot.
+func (s *SessionService) removeSnapshotFiles(id string) {
+	for _, ext := range []string{".json", ".tar.gz", ".bundle"} {
+		if err := os.Remove(s.snapshotFile(id, ext)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			s.log.Warn().Err(err).Str("snapshot_id", id).Msg("failed to remove snapshot file")
+		}
+	}
+}
+
+func (s *SessionService) snapshotFile(id, ext string) string {
+	return filepath.Join(s.config.SnapshotsDir(), id+ext)
+}
+
+func readSnapshot(path string) (Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return Snapshot{}, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	return snap, nil
+}
@@ -0,0 +1,164 @@
+package hive
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/eventbus/testbus"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/pkg/executil"
+	"github.com/colonyops/hive/pkg/executil/executiltest"
+	"github.com/colonyops/hive/pkg/tmpl"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gitIn runs git in dir and returns its trimmed output.
+func gitIn(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=hive", "-c", "user.email=hive@example.com"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+func newSnapshotService(t *testing.T, exec executil.Executor, mutate func(*config.Config)) (*SessionService, *mockStore) {
+	t.Helper()
+	store := newMockStore()
+	cfg := &config.Config{DataDir: t.TempDir(), GitPath: "git"}
+	cfg.Snapshots.MaxPerSession = config.DefaultSnapshotsPerSession
+	if mutate != nil {
+		mutate(cfg)
+	}
+	svc := NewSessionService(store, &mockGit{}, cfg, testbus.New(t).EventBus, exec, tmpl.New(tmpl.Config{}), zerolog.Nop(), io.Discard, io.Discard)
+	return svc, store
+}
+
+func TestSnapshotRestore_FullClone(t *testing.T) {
+	svc, store := newSnapshotService(t, &executil.RealExecutor{}, nil)
+	ctx := context.Background()
+
+	dir := filepath.Join(t.TempDir(), "repo")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	gitIn(t, dir, "init", "-q", "-b", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o644))
+	gitIn(t, dir, "add", ".")
+	gitIn(t, dir, "commit", "-q", "-m", "init")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main // edited\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.md"), []byte("wip\n"), 0o644))
+
+	require.NoError(t, store.Save(ctx, session.Session{ID: "s1", Name: "auth", State: session.StateActive, Path: dir, Remote: "git@github.com:org/repo.git"}))
+
+	snap, err := svc.SnapshotSession(ctx, "s1")
+	require.NoError(t, err)
+	assert.False(t, snap.Bundle)
+	assert.Positive(t, snap.Size)
+
+	listed, err := svc.ListSnapshots("s1")
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, snap.ID, listed[0].ID)
+
+	restored, err := svc.RestoreSnapshot(ctx, snap.ID, CreateOptions{SkipSpawn: true})
+	require.NoError(t, err)
+	assert.Equal(t, "auth-2", restored.Name, "the snapshotted session still holds the name")
+	assert.Equal(t, snap.ID, restored.GetMeta(session.MetaRestoredFrom))
+	assert.Equal(t, config.CloneStrategyFull, restored.CloneStrategy)
+
+	content, err := os.ReadFile(filepath.Join(restored.Path, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main // edited\n", string(content))
+	assert.Equal(t, "M main.go\n?? notes.md", gitIn(t, restored.Path, "status", "--porcelain"))
+
+	_, err = svc.RestoreSnapshot(ctx, "missing", CreateOptions{SkipSpawn: true})
+	require.ErrorIs(t, err, ErrSnapshotNotFound)
+}
+
+func TestSnapshotRestore_Worktree(t *testing.T) {
+	svc, store := newSnapshotService(t, &executil.RealExecutor{}, nil)
+	ctx := context.Background()
+	root := t.TempDir()
+
+	origin := filepath.Join(root, "origin")
+	require.NoError(t, os.MkdirAll(origin, 0o755))
+	gitIn(t, origin, "init", "-q", "-b", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(origin, "README.md"), []byte("# repo\n"), 0o644))
+	gitIn(t, origin, "add", ".")
+	gitIn(t, origin, "commit", "-q", "-m", "init")
+
+	bare := filepath.Join(root, "bare.git")
+	gitIn(t, root, "clone", "-q", "--bare", origin, bare)
+	wt := filepath.Join(root, "wt")
+	gitIn(t, bare, "worktree", "add", "-q", "-b", "hive/auth", wt)
+	require.NoError(t, os.WriteFile(filepath.Join(wt, "auth.go"), []byte("package auth\n"), 0o644))
+	gitIn(t, wt, "add", ".")
+	gitIn(t, wt, "commit", "-q", "-m", "Add auth")
+	require.NoError(t, os.WriteFile(filepath.Join(wt, "README.md"), []byte("# repo\nwip\n"), 0o644))
+
+	sess := session.Session{ID: "s1", Name: "auth", State: session.StateActive, Path: wt, Remote: "git@github.com:org/repo.git", CloneStrategy: config.CloneStrategyWorktree}
+	require.NoError(t, store.Save(ctx, sess))
+
+	snap, err := svc.SnapshotSession(ctx, "s1")
+	require.NoError(t, err)
+	assert.True(t, snap.Bundle)
+	assert.Equal(t, "hive/auth", snap.Branch)
+
+	restored, err := svc.RestoreSnapshot(ctx, snap.ID, CreateOptions{Name: "auth-restored", SkipSpawn: true})
+	require.NoError(t, err)
+	assert.Equal(t, "hive/auth", restored.Branch())
+	assert.Equal(t, "hive/auth", gitIn(t, restored.Path, "branch", "--show-current"))
+	assert.Equal(t, "Add auth", gitIn(t, restored.Path, "log", "-1", "--format=%s"))
+	assert.Equal(t, "M README.md", gitIn(t, restored.Path, "status", "--porcelain"))
+	assert.Equal(t, "git@github.com:org/repo.git", gitIn(t, restored.Path, "remote", "get-url", "origin"))
+}
+
+func TestSnapshot_Retention(t *testing.T) {
+	svc, store := newSnapshotService(t, &executiltest.Exec{}, func(cfg *config.Config) { cfg.Snapshots.MaxPerSession = 2 })
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644))
+	require.NoError(t, store.Save(ctx, session.Session{ID: "s1", Name: "auth", State: session.StateActive, Path: dir}))
+
+	var ids []string
+	for range 3 {
+		snap, err := svc.SnapshotSession(ctx, "s1")
+		require.NoError(t, err)
+		ids = append(ids, snap.ID)
+		time.Sleep(time.Millisecond)
+	}
+
+	listed, err := svc.ListSnapshots("")
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, []string{ids[2], ids[1]}, []string{listed[0].ID, listed[1].ID})
+	_, err = os.Stat(filepath.Join(svc.config.SnapshotsDir(), ids[0]+".tar.gz"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRecycleSession_SnapshotOnRecycle(t *testing.T) {
+	svc, store := newSnapshotService(t, &executiltest.Exec{}, func(cfg *config.Config) {
+		cfg.Rules = []config.Rule{{SnapshotOnRecycle: new(true)}}
+	})
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644))
+	require.NoError(t, store.Save(ctx, session.Session{ID: "s1", Name: "auth", State: session.StateActive, Path: dir, CloneStrategy: config.CloneStrategyFull}))
+
+	require.NoError(t, svc.RecycleSession(ctx, "s1", io.Discard))
+
+	snapshots, err := svc.ListSnapshots("s1")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, SnapshotReasonRecycle, snapshots[0].Reason)
+}
@@ -5,10 +5,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"runtime"
 	"strings"
 
 	"github.com/colonyops/hive/internal/core/config"
 	coretmux "github.com/colonyops/hive/internal/core/tmux"
+	"github.com/colonyops/hive/internal/core/wt"
 	"github.com/colonyops/hive/pkg/executil"
 	"github.com/colonyops/hive/pkg/tmpl"
 	"github.com/rs/zerolog"
@@ -20,6 +22,25 @@ type SessionClient interface {
 	OpenSession(ctx context.Context, name, workDir string, windows []coretmux.RenderedWindow, background bool, targetWindow string) error
 	AddWindows(ctx context.Context, name, workDir string, windows []coretmux.RenderedWindow) error
 	AttachOrSwitch(ctx context.Context, name string) error
+	RenameSession(ctx context.Context, oldName, newName string) (bool, error)
+	ListTargets(ctx context.Context, name string) ([]coretmux.WindowTarget, error)
+}
+
+// newSessionClient returns the terminal backend sessions are spawned into:
+// tmux, or Windows Terminal on Windows where tmux is unavailable.
+func newSessionClient(exec executil.Executor, log zerolog.Logger) SessionClient {
+	if runtime.GOOS == "windows" {
+		return wt.New(exec, log.With().Str("component", "wt").Logger())
+	}
+	return coretmux.New(exec, log.With().Str("component", "tmux").Logger())
+}
+
+// SpawnResult describes the tmux session created by a windows spawn.
+type SpawnResult struct {
+	TmuxSession string
+	Windows     []coretmux.WindowTarget // empty when the targets could not be listed
+	AgentWindow string                  // index of the focused window
+	AgentPane   string                  // first pane ID of the focused window
 }
 
 // SpawnData is the template context for spawn commands.
@@ -55,6 +76,22 @@ func NewSpawner(log zerolog.Logger, executor executil.Executor, renderer *tmpl.R
 	}
 }
 
+// withOutput returns a copy of s that writes spawn command output to stdout
+// and stderr.
+func (s *Spawner) withOutput(stdout, stderr io.Writer) *Spawner {
+	cp := *s
+	cp.stdout, cp.stderr = stdout, stderr
+	return &cp
+}
+
+// executorData rewrites the paths in data to where the executor sees them,
+// since rendered commands are shell scripts the executor does not translate.
+func (s *Spawner) executorData(data SpawnData) SpawnData {
+	data.Path = executil.TranslatePath(s.executor, data.Path)
+	data.ContextDir = executil.TranslatePath(s.executor, data.ContextDir)
+	return data
+}
+
 // Spawn executes spawn commands sequentially with template rendering.
 func (s *Spawner) Spawn(ctx context.Context, commands []string, data SpawnData) error {
 	return s.SpawnWith(ctx, commands, data, s.renderer)
@@ -62,6 +99,7 @@ func (s *Spawner) Spawn(ctx context.Context, commands []string, data SpawnData)
 
 // SpawnWith executes spawn commands using the given renderer instead of the default.
 func (s *Spawner) SpawnWith(ctx context.Context, commands []string, data SpawnData, renderer *tmpl.Renderer) error {
+	data = s.executorData(data)
 	for _, cmdTmpl := range commands {
 		s.log.Debug().Str("command", cmdTmpl).Msg("executing spawn command")
 
@@ -70,7 +108,8 @@ func (s *Spawner) SpawnWith(ctx context.Context, commands []string, data SpawnDa
 			return fmt.Errorf("render spawn command %q: %w", cmdTmpl, err)
 		}
 
-		if err := s.executor.RunStream(ctx, s.stdout, s.stderr, "sh", "-c", rendered); err != nil {
+		shell, args := executil.Shell(rendered)
+		if err := s.executor.RunStream(ctx, s.stdout, s.stderr, shell, args...); err != nil {
 			return fmt.Errorf("execute spawn command %q: %w", rendered, err)
 		}
 	}
@@ -79,28 +118,82 @@ func (s *Spawner) SpawnWith(ctx context.Context, commands []string, data SpawnDa
 	return nil
 }
 
-// SpawnWindows renders window templates and creates a tmux session.
-func (s *Spawner) SpawnWindows(ctx context.Context, windows []config.WindowConfig, data SpawnData, background bool) error {
-	return s.SpawnWindowsWith(ctx, windows, data, background, s.renderer)
+// SpawnWindows renders window templates and creates a detached tmux session.
+func (s *Spawner) SpawnWindows(ctx context.Context, windows []config.WindowConfig, data SpawnData) (*SpawnResult, error) {
+	return s.SpawnWindowsWith(ctx, windows, data, s.renderer)
 }
 
-// SpawnWindowsWith renders window templates using the given renderer and creates a tmux session.
-func (s *Spawner) SpawnWindowsWith(ctx context.Context, windows []config.WindowConfig, data SpawnData, background bool, renderer *tmpl.Renderer) error {
+// SpawnWindowsWith renders window templates using the given renderer and
+// creates a detached tmux session, returning the windows and panes it created.
+// Callers attach with Attach once the result is recorded, since attaching
+// outside tmux blocks until the user detaches.
+func (s *Spawner) SpawnWindowsWith(ctx context.Context, windows []config.WindowConfig, data SpawnData, renderer *tmpl.Renderer) (*SpawnResult, error) {
+	data = s.executorData(data)
 	rendered, err := RenderWindows(renderer, windows, data)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	s.log.Debug().Int("windows", len(rendered)).Bool("background", background).Msg("spawning tmux session")
+	s.log.Debug().Int("windows", len(rendered)).Msg("spawning tmux session")
 
-	if err := s.tmux.CreateSession(ctx, data.Slug, data.Path, rendered, background); err != nil {
-		return fmt.Errorf("create tmux session: %w", err)
+	if err := s.tmux.CreateSession(ctx, data.Slug, data.Path, rendered, true); err != nil {
+		return nil, fmt.Errorf("create tmux session: %w", err)
+	}
+
+	result := &SpawnResult{TmuxSession: data.Slug}
+	targets, err := s.tmux.ListTargets(ctx, data.Slug)
+	if err != nil {
+		s.log.Warn().Err(err).Str("session", data.Slug).Msg("failed to list tmux targets after spawn")
+	} else {
+		result.setTargets(targets, focusedWindowName(rendered))
 	}
 
 	s.log.Debug().Msg("spawn windows complete")
+	return result, nil
+}
+
+// Attach attaches to (or switches the client to) the tmux session name.
+func (s *Spawner) Attach(ctx context.Context, name string) error {
+	if err := s.tmux.AttachOrSwitch(ctx, name); err != nil {
+		return fmt.Errorf("attach tmux session: %w", err)
+	}
 	return nil
 }
 
+// setTargets records the listed windows and picks the agent window by name,
+// falling back to the first window.
+func (r *SpawnResult) setTargets(targets []coretmux.WindowTarget, agentWindow string) {
+	r.Windows = targets
+	if len(targets) == 0 {
+		return
+	}
+	agent := targets[0]
+	for _, t := range targets {
+		if t.Name == agentWindow {
+			agent = t
+			break
+		}
+	}
+	r.AgentWindow = agent.Index
+	if len(agent.Panes) > 0 {
+		r.AgentPane = agent.Panes[0]
+	}
+}
+
+// focusedWindowName returns the name of the window CreateSession selects: the
+// first with Focus set, else the first window.
+func focusedWindowName(windows []coretmux.RenderedWindow) string {
+	for _, w := range windows {
+		if w.Focus {
+			return w.Name
+		}
+	}
+	if len(windows) > 0 {
+		return windows[0].Name
+	}
+	return ""
+}
+
 // OpenWindows renders window templates and opens (or creates) a tmux session.
 // If the session already exists, it attaches to it (optionally selecting targetWindow).
 func (s *Spawner) OpenWindows(ctx context.Context, windows []config.WindowConfig, data SpawnData, background bool, targetWindow string) error {
@@ -109,6 +202,7 @@ func (s *Spawner) OpenWindows(ctx context.Context, windows []config.WindowConfig
 
 // OpenWindowsWith renders window templates using the given renderer and opens (or creates) a tmux session.
 func (s *Spawner) OpenWindowsWith(ctx context.Context, windows []config.WindowConfig, data SpawnData, background bool, targetWindow string, renderer *tmpl.Renderer) error {
+	data = s.executorData(data)
 	rendered, err := RenderWindows(renderer, windows, data)
 	if err != nil {
 		return err
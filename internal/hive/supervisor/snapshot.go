@@ -0,0 +1,87 @@
+package supervisor
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corekv "github.com/colonyops/hive/internal/core/kv"
+)
+
+// snapshotKeyPrefix namespaces the health snapshots processes publish to
+// the KV store, keyed by process ID.
+const snapshotKeyPrefix = "supervisor.health."
+
+// Snapshot is the worker health of one hive process, published to the KV
+// store so hive doctor can report on workers running in other processes.
+type Snapshot struct {
+	PID       int       `json:"pid"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Workers   []Health  `json:"workers"`
+}
+
+// Publish writes the supervisor's health to store every interval until ctx
+// is cancelled. The first snapshot is written after one interval, so short
+// lived commands never publish. Snapshots expire after three intervals once
+// the process stops publishing. Unhealthy workers are logged on each
+// publish.
+func (s *Supervisor) Publish(ctx context.Context, store corekv.KV, interval time.Duration) {
+	if s == nil || store == nil || interval <= 0 {
+		return
+	}
+
+	key := snapshotKeyPrefix + strconv.Itoa(os.Getpid())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			snapshot := Snapshot{PID: os.Getpid(), UpdatedAt: s.now(), Workers: s.Health()}
+			s.logUnhealthy(snapshot)
+			if err := store.SetTTL(ctx, key, snapshot, 3*interval); err != nil {
+				s.log.Debug().Err(err).Msg("failed to publish worker health")
+			}
+		}
+	}
+}
+
+func (s *Supervisor) logUnhealthy(snapshot Snapshot) {
+	for _, h := range snapshot.Workers {
+		switch {
+		case h.Stale(snapshot.UpdatedAt):
+			s.log.Warn().Str("worker", h.Name).Time("busy_since", h.BusySince).Msg("background worker is wedged")
+		case h.State == StateRestarting:
+			s.log.Warn().Str("worker", h.Name).Int("restarts", h.Restarts).Str("last_error", h.LastError).Msg("background worker is restarting")
+		}
+	}
+}
+
+// LoadSnapshots returns the snapshots published by other hive processes,
+// ordered by PID.
+func LoadSnapshots(ctx context.Context, store corekv.KV) ([]Snapshot, error) {
+	keys, err := store.ListKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	self := snapshotKeyPrefix + strconv.Itoa(os.Getpid())
+	var snapshots []Snapshot
+	for _, key := range keys {
+		if !strings.HasPrefix(key, snapshotKeyPrefix) || key == self {
+			continue
+		}
+		var snapshot Snapshot
+		if err := store.Get(ctx, key, &snapshot); err != nil {
+			continue // expired between ListKeys and Get
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	slices.SortFunc(snapshots, func(a, b Snapshot) int { return a.PID - b.PID })
+	return snapshots, nil
+}
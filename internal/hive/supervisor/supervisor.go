@@ -0,0 +1,365 @@
+// Package supervisor keeps hive's background workers alive. A supervised
+// worker that panics or stays busy past its stall deadline is restarted with
+// exponential backoff, and its heartbeats, restarts, and last error are
+// reported by Health for hive doctor.
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultStaleAfter is how long a worker may stay busy before it is
+	// considered wedged.
+	DefaultStaleAfter = 2 * time.Minute
+
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = time.Minute
+
+	// stopGrace is how long a wedged worker gets to exit after its context
+	// is cancelled before it is abandoned and replaced.
+	stopGrace = 5 * time.Second
+)
+
+// State is the lifecycle state of a worker.
+type State string
+
+const (
+	StateRunning    State = "running"
+	StateRestarting State = "restarting"
+	StateStopped    State = "stopped"
+)
+
+// Options tunes how a worker is supervised. Zero values use the defaults.
+type Options struct {
+	// StaleAfter is how long the worker may stay busy (see Busy) before it
+	// is restarted. Negative disables stall detection.
+	StaleAfter time.Duration
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+func (o Options) withDefaults() Options {
+	if o.StaleAfter == 0 {
+		o.StaleAfter = DefaultStaleAfter
+	}
+	if o.MinBackoff <= 0 {
+		o.MinBackoff = defaultMinBackoff
+	}
+	if o.MaxBackoff < o.MinBackoff {
+		o.MaxBackoff = max(defaultMaxBackoff, o.MinBackoff)
+	}
+	return o
+}
+
+// Health is a point-in-time report on one worker.
+type Health struct {
+	Name       string        `json:"name"`
+	State      State         `json:"state"`
+	Restarts   int           `json:"restarts"`
+	LastBeat   time.Time     `json:"last_beat"`
+	BusySince  time.Time     `json:"busy_since,omitzero"`
+	StaleAfter time.Duration `json:"stale_after"`
+	LastError  string        `json:"last_error,omitempty"`
+}
+
+// Stale reports whether the worker has been busy longer than its stall
+// deadline at now.
+func (h Health) Stale(now time.Time) bool {
+	return h.State == StateRunning && h.StaleAfter > 0 && !h.BusySince.IsZero() && now.Sub(h.BusySince) > h.StaleAfter
+}
+
+// Supervisor runs and monitors background workers. A nil Supervisor is
+// valid: Run calls the worker once without supervision and Track returns
+// the context unchanged.
+type Supervisor struct {
+	log zerolog.Logger
+	now func() time.Time
+
+	mu      sync.Mutex
+	workers map[string]*worker
+}
+
+// New returns a Supervisor logging worker failures to log.
+func New(log zerolog.Logger) *Supervisor {
+	return &Supervisor{
+		log:     log,
+		now:     time.Now,
+		workers: make(map[string]*worker),
+	}
+}
+
+// Run runs fn under supervision until ctx is cancelled or fn returns nil.
+// When fn panics, returns an error, or stays busy past opts.StaleAfter, it is
+// restarted after a backoff that doubles on each consecutive failure and
+// resets once the worker has run for MaxBackoff. Run blocks; start it in its
+// own goroutine.
+func (s *Supervisor) Run(ctx context.Context, name string, opts Options, fn func(ctx context.Context) error) {
+	if s == nil {
+		_ = fn(ctx)
+		return
+	}
+
+	opts = opts.withDefaults()
+	w := s.register(name, opts)
+	backoff := opts.MinBackoff
+
+	for {
+		started := s.now()
+		err := s.runOnce(ctx, w, opts, fn)
+		if err == nil || ctx.Err() != nil {
+			w.stop()
+			return
+		}
+
+		if s.now().Sub(started) >= opts.MaxBackoff {
+			backoff = opts.MinBackoff
+		}
+		w.fail(err, StateRestarting)
+		s.log.Warn().Err(err).Str("worker", name).Dur("backoff", backoff).Msg("background worker failed, restarting")
+
+		select {
+		case <-ctx.Done():
+			w.stop()
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, opts.MaxBackoff)
+	}
+}
+
+// runOnce starts one attempt of fn and waits for it to return, panic, or
+// stall.
+func (s *Supervisor) runOnce(ctx context.Context, w *worker, opts Options, fn func(ctx context.Context) error) error {
+	r := w.start(s.now())
+	runCtx, cancel := context.WithCancel(context.WithValue(ctx, runKey{}, r))
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- s.panicked(w.name, p)
+			}
+		}()
+		done <- fn(runCtx)
+	}()
+
+	if opts.StaleAfter < 0 {
+		return <-done
+	}
+
+	ticker := time.NewTicker(max(opts.StaleAfter/4, 10*time.Millisecond))
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-done:
+			return err
+		case <-ticker.C:
+			busy, ok := w.busyFor(r, s.now())
+			if !ok || busy <= opts.StaleAfter {
+				continue
+			}
+			cancel()
+			select {
+			case <-done:
+			case <-time.After(stopGrace):
+				s.log.Warn().Str("worker", w.name).Msg("wedged background worker ignored cancellation, abandoning it")
+			}
+			return fmt.Errorf("stalled: busy for %s", busy.Round(time.Second))
+		}
+	}
+}
+
+// Track registers a worker that schedules its own work, like a poller driven
+// by a ticker, and returns a context for Beat, Busy, and Panicked. A tracked
+// worker is not restarted by the supervisor; its health is only reported.
+func (s *Supervisor) Track(ctx context.Context, name string, opts Options) context.Context {
+	if s == nil {
+		return ctx
+	}
+	w := s.register(name, opts.withDefaults())
+	return context.WithValue(ctx, runKey{}, w.start(s.now()))
+}
+
+// Health returns the health of every worker, sorted by name.
+func (s *Supervisor) Health() []Health {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	workers := make([]*worker, 0, len(s.workers))
+	for _, w := range s.workers {
+		workers = append(workers, w)
+	}
+	s.mu.Unlock()
+
+	health := make([]Health, 0, len(workers))
+	for _, w := range workers {
+		health = append(health, w.health())
+	}
+	slices.SortFunc(health, func(a, b Health) int { return strings.Compare(a.Name, b.Name) })
+	return health
+}
+
+func (s *Supervisor) register(name string, opts Options) *worker {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if w, ok := s.workers[name]; ok {
+		return w
+	}
+	w := &worker{sup: s, name: name, staleAfter: opts.StaleAfter}
+	s.workers[name] = w
+	return w
+}
+
+func (s *Supervisor) panicked(name string, p any) error {
+	s.log.Error().Str("worker", name).Str("stack", string(debug.Stack())).Msgf("background worker panicked: %v", p)
+	return fmt.Errorf("panic: %v", p)
+}
+
+// Beat records that the worker running with ctx is alive. It is a no-op
+// outside a supervised worker.
+func Beat(ctx context.Context) {
+	if r, ok := current(ctx); ok {
+		r.w.beat(r, nil)
+	}
+}
+
+// Busy marks the worker running with ctx as busy until the returned function
+// is called. A worker busy for longer than its StaleAfter is considered
+// wedged. Workers that block while idle, waiting on a channel or ticker,
+// should only be busy while doing work.
+func Busy(ctx context.Context) (done func()) {
+	r, ok := current(ctx)
+	if !ok {
+		return func() {}
+	}
+	r.w.beat(r, func(w *worker) { w.busySince = w.sup.now() })
+	return func() { r.w.beat(r, func(w *worker) { w.busySince = time.Time{} }) }
+}
+
+// Panicked records a panic recovered by a tracked worker and returns it as
+// an error. Use it in a deferred recover where the worker handles the
+// failure itself.
+func Panicked(ctx context.Context, p any) error {
+	r, ok := current(ctx)
+	if !ok {
+		log.Error().Str("stack", string(debug.Stack())).Msgf("recovered panic: %v", p)
+		return fmt.Errorf("panic: %v", p)
+	}
+	err := r.w.sup.panicked(r.w.name, p)
+	r.w.fail(err, StateRunning)
+	return err
+}
+
+type runKey struct{}
+
+// current returns the worker attempt carried by ctx.
+func current(ctx context.Context) (*run, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	r, ok := ctx.Value(runKey{}).(*run)
+	return r, ok
+}
+
+// run is one attempt of a worker. Beats from an attempt that was replaced
+// are ignored.
+type run struct {
+	w   *worker
+	gen int
+}
+
+type worker struct {
+	sup        *Supervisor
+	name       string
+	staleAfter time.Duration
+
+	mu        sync.Mutex
+	gen       int
+	state     State
+	restarts  int
+	lastBeat  time.Time
+	busySince time.Time
+	lastError string
+}
+
+func (w *worker) start(now time.Time) *run {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.gen++
+	w.state = StateRunning
+	w.lastBeat = now
+	w.busySince = time.Time{}
+	return &run{w: w, gen: w.gen}
+}
+
+func (w *worker) beat(r *run, update func(w *worker)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if r.gen != w.gen {
+		return
+	}
+	w.lastBeat = w.sup.now()
+	if update != nil {
+		update(w)
+	}
+}
+
+func (w *worker) busyFor(r *run, now time.Time) (time.Duration, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if r.gen != w.gen || w.busySince.IsZero() {
+		return 0, false
+	}
+	return now.Sub(w.busySince), true
+}
+
+func (w *worker) fail(err error, state State) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.restarts++
+	w.state = state
+	w.busySince = time.Time{}
+	w.lastError = err.Error()
+}
+
+func (w *worker) stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.gen++
+	w.state = StateStopped
+	w.busySince = time.Time{}
+}
+
+func (w *worker) health() Health {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return Health{
+		Name:       w.name,
+		State:      w.state,
+		Restarts:   w.restarts,
+		LastBeat:   w.lastBeat,
+		BusySince:  w.busySince,
+		StaleAfter: w.staleAfter,
+		LastError:  w.lastError,
+	}
+}
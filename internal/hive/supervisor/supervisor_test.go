@@ -0,0 +1,155 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/internal/data/stores"
+)
+
+var fastRestart = Options{MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+func TestSupervisor_RestartsAfterPanic(t *testing.T) {
+	s := New(zerolog.Nop())
+	var attempts atomic.Int32
+
+	s.Run(context.Background(), "panicky", fastRestart, func(ctx context.Context) error {
+		if attempts.Add(1) < 3 {
+			panic("boom")
+		}
+		return nil
+	})
+
+	assert.Equal(t, int32(3), attempts.Load())
+	health := s.Health()
+	require.Len(t, health, 1)
+	assert.Equal(t, "panicky", health[0].Name)
+	assert.Equal(t, StateStopped, health[0].State)
+	assert.Equal(t, 2, health[0].Restarts)
+	assert.Equal(t, "panic: boom", health[0].LastError)
+}
+
+func TestSupervisor_RestartsAfterError(t *testing.T) {
+	s := New(zerolog.Nop())
+	var attempts atomic.Int32
+
+	s.Run(context.Background(), "flaky", fastRestart, func(ctx context.Context) error {
+		if attempts.Add(1) == 1 {
+			return errors.New("lost connection")
+		}
+		return nil
+	})
+
+	assert.Equal(t, int32(2), attempts.Load())
+	assert.Equal(t, "lost connection", s.Health()[0].LastError)
+}
+
+func TestSupervisor_RestartsWedgedWorker(t *testing.T) {
+	s := New(zerolog.Nop())
+	opts := fastRestart
+	opts.StaleAfter = 20 * time.Millisecond
+	var attempts atomic.Int32
+
+	s.Run(context.Background(), "wedged", opts, func(ctx context.Context) error {
+		if attempts.Add(1) > 1 {
+			return nil
+		}
+		done := Busy(ctx)
+		defer done()
+		<-ctx.Done() // wedged until the supervisor cancels it
+		return ctx.Err()
+	})
+
+	assert.Equal(t, int32(2), attempts.Load())
+	health := s.Health()[0]
+	assert.Equal(t, 1, health.Restarts)
+	assert.Contains(t, health.LastError, "stalled")
+}
+
+func TestSupervisor_IdleWorkerIsNotStale(t *testing.T) {
+	s := New(zerolog.Nop())
+	opts := fastRestart
+	opts.StaleAfter = 10 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	s.Run(ctx, "idle", opts, func(ctx context.Context) error {
+		Beat(ctx)
+		<-ctx.Done()
+		return nil
+	})
+
+	assert.Equal(t, 0, s.Health()[0].Restarts)
+}
+
+func TestSupervisor_Track(t *testing.T) {
+	s := New(zerolog.Nop())
+	ctx := s.Track(context.Background(), "poller", Options{StaleAfter: time.Minute})
+
+	done := Busy(ctx)
+	health := s.Health()[0]
+	assert.Equal(t, StateRunning, health.State)
+	assert.False(t, health.BusySince.IsZero())
+	assert.False(t, health.Stale(time.Now()))
+	assert.True(t, health.Stale(time.Now().Add(2*time.Minute)))
+	done()
+
+	err := Panicked(ctx, "nil map")
+	require.EqualError(t, err, "panic: nil map")
+	health = s.Health()[0]
+	assert.Equal(t, 1, health.Restarts)
+	assert.Equal(t, StateRunning, health.State)
+	assert.True(t, health.BusySince.IsZero())
+}
+
+func TestSupervisor_Nil(t *testing.T) {
+	var s *Supervisor
+	ran := false
+	s.Run(context.Background(), "plain", Options{}, func(ctx context.Context) error {
+		ran = true
+		Beat(ctx)
+		Busy(ctx)()
+		return nil
+	})
+	assert.True(t, ran)
+
+	ctx := context.Background()
+	assert.Equal(t, ctx, s.Track(ctx, "poller", Options{}))
+	assert.Nil(t, s.Health())
+	assert.EqualError(t, Panicked(ctx, "boom"), "panic: boom")
+}
+
+func TestLoadSnapshots(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+	store := stores.NewKVStore(database)
+	ctx := context.Background()
+
+	other := Snapshot{PID: 1, UpdatedAt: time.Now().UTC(), Workers: []Health{{Name: "sweep.kv", State: StateRunning}}}
+	require.NoError(t, store.Set(ctx, snapshotKeyPrefix+"1", other))
+	require.NoError(t, store.Set(ctx, "tui.history.palette", []string{"x"}))
+
+	s := New(zerolog.Nop())
+	publishCtx, cancel := context.WithCancel(ctx)
+	go s.Publish(publishCtx, store, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		keys, _ := store.ListKeys(ctx)
+		return len(keys) == 3
+	}, time.Second, 5*time.Millisecond)
+	cancel()
+
+	snapshots, err := LoadSnapshots(ctx, store)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1, "own snapshot is excluded")
+	assert.Equal(t, 1, snapshots[0].PID)
+	assert.Equal(t, "sweep.kv", snapshots[0].Workers[0].Name)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/colonyops/hive/internal/data/stores"
+	"github.com/colonyops/hive/internal/hive/supervisor"
 )
 
 // Start launches a background goroutine that periodically sweeps expired KV entries.
@@ -20,9 +21,36 @@ func Start(ctx context.Context, kvStore *stores.KVStore, interval time.Duration)
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
+			done := supervisor.Busy(ctx)
 			if err := kvStore.SweepExpired(ctx); err != nil {
 				log.Debug().Err(err).Msg("kv sweep failed")
 			}
+			done()
+		}
+	}
+}
+
+// StartMessages periodically deletes messages past the message store's
+// retention policy. It blocks until the context is cancelled.
+func StartMessages(ctx context.Context, msgStore *stores.MessageStore, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			done := supervisor.Busy(ctx)
+			removed, err := msgStore.ApplyRetention(ctx, "", 0)
+			done()
+			if err != nil {
+				log.Debug().Err(err).Msg("message retention sweep failed")
+				continue
+			}
+			if removed > 0 {
+				log.Debug().Int("removed", removed).Msg("message retention sweep")
+			}
 		}
 	}
 }
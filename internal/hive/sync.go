@@ -0,0 +1,100 @@
+package hive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/pkg/executil"
+)
+
+// SyncData contains template data for sync commands.
+type SyncData struct {
+	DefaultBranch string
+	BaseBranch    string
+	Branch        string
+}
+
+// SyncSession brings an active session's branch up to date with its base
+// branch by running the sync commands for its repository (by default a fetch
+// and a rebase onto origin/<base>) in the session directory. A failing
+// command, usually a conflict, is recorded on the session for the TUI to
+// flag, and the working tree is left as the command left it so the agent or
+// user can resolve it. Output is written to w. If w is nil, output is
+// discarded.
+func (s *SessionService) SyncSession(ctx context.Context, id string, w io.Writer) error {
+	sess, err := s.sessions.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get session: %w", err)
+	}
+	if sess.State != session.StateActive {
+		return fmt.Errorf("session %s cannot be synced (state: %s)", id, sess.State)
+	}
+	if w == nil {
+		w = io.Discard
+	}
+
+	data := s.syncData(ctx, &sess)
+	for _, cmd := range s.configForSession(&sess).GetSyncCommands(sess.Remote) {
+		rendered, err := s.renderer.Render(cmd, data)
+		if err != nil {
+			return fmt.Errorf("render sync command %q: %w", cmd, err)
+		}
+
+		_, _ = fmt.Fprintf(w, "$ %s\n", rendered)
+		shell, args := executil.Shell(rendered)
+		if err := s.executor.RunDirStream(ctx, sess.Path, w, w, shell, args...); err != nil {
+			syncErr := fmt.Errorf("sync command %q: %w", rendered, err)
+			s.markSyncFailed(ctx, &sess, syncErr)
+			return fmt.Errorf("sync session %s: %w", id, syncErr)
+		}
+	}
+
+	sess.MarkSynced(time.Now())
+	if err := s.sessions.Save(ctx, sess); err != nil {
+		return fmt.Errorf("save session: %w", err)
+	}
+
+	s.log.Info().Str("session_id", id).Str("base", data.BaseBranch).Msg("session synced")
+	return nil
+}
+
+// syncData resolves the branches a session's sync commands are rendered
+// with. The base is the one the session branch started from, falling back
+// to the rule's base_branch and then the default branch.
+func (s *SessionService) syncData(ctx context.Context, sess *session.Session) SyncData {
+	vcs := s.VCS(sess)
+	defaultBranch, err := vcs.DefaultBranch(ctx, sess.Path)
+	if err != nil {
+		s.log.Warn().Err(err).Msg("failed to get default branch, using 'main'")
+		defaultBranch = "main"
+	}
+
+	data := SyncData{DefaultBranch: defaultBranch, Branch: sess.Branch()}
+	if data.Branch == "" {
+		if data.Branch, err = vcs.Branch(ctx, sess.Path); err != nil {
+			s.log.Debug().Err(err).Str("session_id", sess.ID).Msg("failed to get session branch")
+		}
+	}
+	data.BaseBranch = sess.GetMeta(session.MetaBaseBranch)
+	if data.BaseBranch == "" {
+		data.BaseBranch = s.config.GetBaseBranch(sess.Remote)
+	}
+	if data.BaseBranch == "" {
+		data.BaseBranch = defaultBranch
+	}
+	return data
+}
+
+// markSyncFailed records a sync failure on the session so the TUI can flag
+// it.
+func (s *SessionService) markSyncFailed(ctx context.Context, sess *session.Session, syncErr error) {
+	s.log.Warn().Err(syncErr).Str("session_id", sess.ID).Msg("sync failed, session needs attention")
+
+	sess.MarkSyncFailed(syncErr, time.Now())
+	if err := s.sessions.Save(ctx, *sess); err != nil {
+		s.log.Error().Err(err).Str("session_id", sess.ID).Msg("failed to save sync failure")
+	}
+}
@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/data/stores"
+	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/internal/tui/views/sessions"
+)
+
+// newAttentionFunc returns the sessions view's source for the FilterAttention
+// filter. Unread messages are those in a session's inbox not yet read by the
+// session; reviews are linked to sessions by hive.SessionReviewCounts.
+// msgs and reviews may be nil.
+func newAttentionFunc(cfg *config.Config, msgs *hive.MessageService, reviews *stores.ReviewStore) sessions.AttentionFunc {
+	return func(ctx context.Context, all []session.Session) map[string]sessions.Attention {
+		var reviewCounts map[string]int
+		if reviews != nil {
+			pending, err := reviews.GetAllActiveSessionsWithCounts(ctx)
+			if err != nil {
+				log.Debug().Err(err).Msg("attention: failed to load review sessions")
+			}
+			commentCounts := make(map[string]int, len(pending))
+			for path, info := range pending {
+				commentCounts[path] = info.CommentCount
+			}
+			reviewCounts = hive.SessionReviewCounts(cfg, all, commentCounts)
+		}
+
+		result := make(map[string]sessions.Attention)
+		for i := range all {
+			s := &all[i]
+			a := sessions.Attention{PendingReviews: reviewCounts[s.ID]}
+			if msgs != nil {
+				if unread, err := msgs.GetUnread(ctx, s.ID, s.InboxTopic()); err == nil {
+					a.UnreadMessages = len(unread)
+				}
+			}
+			if a.Any() {
+				result[s.ID] = a
+			}
+		}
+		return result
+	}
+}
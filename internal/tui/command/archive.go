@@ -0,0 +1,25 @@
+package command
+
+import "context"
+
+// ArchiveExecutor executes a session archive operation.
+type ArchiveExecutor struct {
+	archiver  SessionArchiver
+	sessionID string
+}
+
+// Execute archives the session asynchronously.
+// Returns nil output channel (non-streaming).
+func (e *ArchiveExecutor) Execute(ctx context.Context) (output <-chan string, done <-chan error, cancel context.CancelFunc) {
+	doneCh := make(chan error, 1)
+	ctx, cancel = context.WithCancel(ctx)
+
+	go func() {
+		defer close(doneCh)
+		doneCh <- e.archiver.ArchiveSession(ctx, e.sessionID)
+	}()
+
+	return nil, doneCh, cancel
+}
+
+var _ Executor = (*ArchiveExecutor)(nil)
@@ -22,6 +22,11 @@ type CreateExecutor struct {
 	// after receiving from done.
 	ResultSessionID   string
 	ResultSessionName string
+
+	// LogID is the session ID a failed creation's log is saved under, set
+	// when Execute is called. Successful creations save their log under
+	// ResultSessionID, which differs when a recycled session is reused.
+	LogID string
 }
 
 // Execute starts the create operation and returns channels for output and completion.
@@ -31,11 +36,16 @@ func (e *CreateExecutor) Execute(ctx context.Context) (output <-chan string, don
 
 	ctx, cancel = context.WithCancel(ctx)
 
+	opts := e.opts
+	if opts.SessionID == "" {
+		opts.SessionID = hive.NewSessionID()
+	}
+	e.LogID = opts.SessionID
+
 	go func() {
 		defer close(outCh)
 		defer close(doneCh)
 
-		opts := e.opts
 		opts.Progress = &channelWriter{ch: outCh, ctx: ctx}
 		sess, err := e.creator.CreateSession(ctx, opts)
 		if err == nil && sess != nil {
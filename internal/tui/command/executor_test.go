@@ -23,9 +23,19 @@ func (m *mockDeleter) DeleteSession(_ context.Context, id string) error {
 	return m.deleteErr
 }
 
+type mockArchiver struct {
+	archived []string
+}
+
+func (m *mockArchiver) ArchiveSession(_ context.Context, id string) error {
+	m.archived = append(m.archived, id)
+	return nil
+}
+
 type mockRecycler struct {
 	recycleErr error
 	recycled   []string
+	forced     []string
 	output     string // What to write to the writer
 }
 
@@ -37,6 +47,22 @@ func (m *mockRecycler) RecycleSession(_ context.Context, id string, w io.Writer)
 	return m.recycleErr
 }
 
+func (m *mockRecycler) ForceRecycleSession(ctx context.Context, id string, w io.Writer) error {
+	m.forced = append(m.forced, id)
+	return m.RecycleSession(ctx, id, w)
+}
+
+type mockSyncer struct {
+	syncErr error
+	synced  []string
+}
+
+func (m *mockSyncer) SyncSession(_ context.Context, id string, w io.Writer) error {
+	m.synced = append(m.synced, id)
+	_, _ = io.WriteString(w, "$ git fetch origin\n")
+	return m.syncErr
+}
+
 // DeleteExecutor tests
 
 func TestDeleteExecutor_Execute(t *testing.T) {
@@ -170,6 +196,31 @@ func TestRecycleExecutor_Cancel(t *testing.T) {
 	// Should not panic or block indefinitely
 }
 
+func TestRecycleExecutor_Force(t *testing.T) {
+	mock := &mockRecycler{}
+	exec := &RecycleExecutor{
+		recycler:  mock,
+		sessionID: "test-123",
+		force:     true,
+	}
+
+	require.NoError(t, ExecuteSync(context.Background(), exec))
+	assert.Equal(t, []string{"test-123"}, mock.forced)
+}
+
+// SyncExecutor tests
+
+func TestSyncExecutor_Execute(t *testing.T) {
+	mock := &mockSyncer{}
+	exec := &SyncExecutor{syncer: mock, sessionID: "test-123"}
+
+	require.NoError(t, ExecuteSync(context.Background(), exec))
+	assert.Equal(t, []string{"test-123"}, mock.synced)
+
+	mock.syncErr = errors.New("rebase conflict")
+	require.ErrorContains(t, ExecuteSync(context.Background(), exec), "rebase conflict")
+}
+
 // ShellExecutor tests
 
 func TestShellExecutor_Execute(t *testing.T) {
@@ -312,12 +363,12 @@ type mockTmuxOpener struct {
 }
 
 type tmuxOpenCall struct {
-	Name, Path, Remote, TargetWindow string
-	Background                       bool
+	Name, Path, Remote, Agent, TargetWindow string
+	Background                              bool
 }
 
-func (m *mockTmuxOpener) OpenTmuxSession(_ context.Context, name, path, remote, targetWindow string, background bool) error {
-	m.calls = append(m.calls, tmuxOpenCall{name, path, remote, targetWindow, background})
+func (m *mockTmuxOpener) OpenTmuxSession(_ context.Context, name, path, remote, agent, targetWindow string, background bool) error {
+	m.calls = append(m.calls, tmuxOpenCall{name, path, remote, agent, targetWindow, background})
 	return m.openErr
 }
 
@@ -334,7 +385,7 @@ func (m *mockWindowSpawner) CreateSessionWithWindows(_ context.Context, _ action
 // Service tests
 
 func TestService_CreateExecutor(t *testing.T) {
-	svc := NewService(&mockDeleter{}, &mockRecycler{}, &mockTmuxOpener{}, &mockWindowSpawner{}, nil)
+	svc := NewService(&mockDeleter{}, &mockRecycler{}, &mockArchiver{}, &mockSyncer{}, &mockTmuxOpener{}, &mockWindowSpawner{}, nil)
 
 	tests := []struct {
 		name    string
@@ -351,6 +402,21 @@ func TestService_CreateExecutor(t *testing.T) {
 			action:  Action{Type: action.TypeRecycle, SessionID: "test-123"},
 			wantErr: false,
 		},
+		{
+			name:    "recycle reset action",
+			action:  Action{Type: action.TypeRecycleReset, SessionID: "test-123"},
+			wantErr: false,
+		},
+		{
+			name:    "archive action",
+			action:  Action{Type: action.TypeArchive, SessionID: "test-123"},
+			wantErr: false,
+		},
+		{
+			name:    "sync action",
+			action:  Action{Type: action.TypeSync, SessionID: "test-123"},
+			wantErr: false,
+		},
 		{
 			name:    "shell action",
 			action:  Action{Type: action.TypeShell, ShellCmd: "echo test"},
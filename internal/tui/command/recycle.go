@@ -8,6 +8,7 @@ import (
 type RecycleExecutor struct {
 	recycler  SessionRecycler
 	sessionID string
+	force     bool // discard local changes first
 }
 
 // Execute starts the recycle operation and returns channels for output and completion.
@@ -23,8 +24,11 @@ func (e *RecycleExecutor) Execute(ctx context.Context) (output <-chan string, do
 		defer close(doneCh)
 
 		writer := &channelWriter{ch: outCh, ctx: ctx}
-		err := e.recycler.RecycleSession(ctx, e.sessionID, writer)
-		doneCh <- err
+		recycle := e.recycler.RecycleSession
+		if e.force {
+			recycle = e.recycler.ForceRecycleSession
+		}
+		doneCh <- recycle(ctx, e.sessionID, writer)
 	}()
 
 	return outCh, doneCh, cancel
@@ -17,14 +17,26 @@ type SessionDeleter interface {
 	DeleteSession(ctx context.Context, id string) error
 }
 
+// SessionArchiver is the interface for archiving sessions.
+type SessionArchiver interface {
+	ArchiveSession(ctx context.Context, id string) error
+}
+
 // SessionRecycler is the interface for recycling sessions.
 type SessionRecycler interface {
 	RecycleSession(ctx context.Context, id string, w io.Writer) error
+	// ForceRecycleSession discards local changes before recycling.
+	ForceRecycleSession(ctx context.Context, id string, w io.Writer) error
+}
+
+// SessionSyncer is the interface for syncing sessions with their base branch.
+type SessionSyncer interface {
+	SyncSession(ctx context.Context, id string, w io.Writer) error
 }
 
 // TmuxOpener opens or creates tmux sessions for hive sessions.
 type TmuxOpener interface {
-	OpenTmuxSession(ctx context.Context, name, path, remote, targetWindow string, background bool) error
+	OpenTmuxSession(ctx context.Context, name, path, remote, agent, targetWindow string, background bool) error
 }
 
 // WindowSpawner handles window operations for SpawnWindows actions.
@@ -40,16 +52,20 @@ type WindowSpawner interface {
 type Service struct {
 	deleter       SessionDeleter
 	recycler      SessionRecycler
+	archiver      SessionArchiver
+	syncer        SessionSyncer
 	tmuxOpener    TmuxOpener
 	windowSpawner WindowSpawner
 	creator       SessionCreator
 }
 
 // NewService creates a new command service with the given dependencies.
-func NewService(deleter SessionDeleter, recycler SessionRecycler, tmuxOpener TmuxOpener, windowSpawner WindowSpawner, creator SessionCreator) *Service {
+func NewService(deleter SessionDeleter, recycler SessionRecycler, archiver SessionArchiver, syncer SessionSyncer, tmuxOpener TmuxOpener, windowSpawner WindowSpawner, creator SessionCreator) *Service {
 	return &Service{
 		deleter:       deleter,
 		recycler:      recycler,
+		archiver:      archiver,
+		syncer:        syncer,
 		tmuxOpener:    tmuxOpener,
 		windowSpawner: windowSpawner,
 		creator:       creator,
@@ -78,6 +94,22 @@ func (s *Service) CreateExecutor(a Action) (Executor, error) {
 			recycler:  s.recycler,
 			sessionID: a.SessionID,
 		}, nil
+	case action.TypeRecycleReset:
+		return &RecycleExecutor{
+			recycler:  s.recycler,
+			sessionID: a.SessionID,
+			force:     true,
+		}, nil
+	case action.TypeArchive:
+		return &ArchiveExecutor{
+			archiver:  s.archiver,
+			sessionID: a.SessionID,
+		}, nil
+	case action.TypeSync:
+		return &SyncExecutor{
+			syncer:    s.syncer,
+			sessionID: a.SessionID,
+		}, nil
 	case action.TypeShell:
 		return &ShellExecutor{
 			cmd: a.ShellCmd,
@@ -97,6 +129,7 @@ func (s *Service) CreateExecutor(a Action) (Executor, error) {
 			name:         a.SessionName,
 			path:         a.SessionPath,
 			remote:       a.SessionRemote,
+			agent:        a.SessionAgent,
 			targetWindow: a.TmuxWindow,
 			background:   false,
 		}, nil
@@ -106,6 +139,7 @@ func (s *Service) CreateExecutor(a Action) (Executor, error) {
 			name:         a.SessionName,
 			path:         a.SessionPath,
 			remote:       a.SessionRemote,
+			agent:        a.SessionAgent,
 			targetWindow: a.TmuxWindow,
 			background:   true,
 		}, nil
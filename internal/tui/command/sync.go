@@ -0,0 +1,31 @@
+package command
+
+import (
+	"context"
+)
+
+// SyncExecutor executes a session sync with streaming output.
+type SyncExecutor struct {
+	syncer    SessionSyncer
+	sessionID string
+}
+
+// Execute starts the sync and returns channels for output and completion.
+func (e *SyncExecutor) Execute(ctx context.Context) (output <-chan string, done <-chan error, cancel context.CancelFunc) {
+	outCh := make(chan string, 100)
+	doneCh := make(chan error, 1)
+
+	ctx, cancel = context.WithCancel(ctx)
+
+	go func() {
+		defer close(outCh)
+		defer close(doneCh)
+
+		writer := &channelWriter{ch: outCh, ctx: ctx}
+		doneCh <- e.syncer.SyncSession(ctx, e.sessionID, writer)
+	}()
+
+	return outCh, doneCh, cancel
+}
+
+var _ Executor = (*SyncExecutor)(nil)
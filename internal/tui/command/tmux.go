@@ -8,6 +8,7 @@ type TmuxExecutor struct {
 	name         string
 	path         string
 	remote       string
+	agent        string
 	targetWindow string
 	background   bool
 }
@@ -20,7 +21,7 @@ func (e *TmuxExecutor) Execute(ctx context.Context) (output <-chan string, done
 
 	go func() {
 		defer close(doneCh)
-		doneCh <- e.opener.OpenTmuxSession(ctx, e.name, e.path, e.remote, e.targetWindow, e.background)
+		doneCh <- e.opener.OpenTmuxSession(ctx, e.name, e.path, e.remote, e.agent, e.targetWindow, e.background)
 	}()
 
 	return nil, doneCh, cancel
@@ -12,6 +12,7 @@ import (
 	"github.com/colonyops/hive/internal/core/session"
 	"github.com/colonyops/hive/internal/core/styles"
 	"github.com/colonyops/hive/internal/tui/components"
+	"github.com/colonyops/hive/internal/tui/components/input"
 	"github.com/sahilm/fuzzy"
 )
 
@@ -20,7 +21,7 @@ func isCommandInScopeForView(cmd config.UserCommand, view ViewType) bool {
 	if len(cmd.Scope) == 0 {
 		return true // global by default
 	}
-	currentScope := view.String()
+	currentScope := view.scope()
 	for _, scope := range cmd.Scope {
 		if scope == "global" || scope == currentScope {
 			return true
@@ -29,6 +30,9 @@ func isCommandInScopeForView(cmd config.UserCommand, view ViewType) bool {
 	return false
 }
 
+// kvPaletteHistoryKey is the kv store key for the command palette history.
+const kvPaletteHistoryKey = "tui.history.palette"
+
 const (
 	// MaxVisibleCommands is the maximum number of commands shown in the palette.
 	MaxVisibleCommands = 12
@@ -51,7 +55,7 @@ func (c commandEntries) Len() int            { return len(c) }
 // CommandPalette is a vim-style command palette for user commands.
 type CommandPalette struct {
 	commands     []CommandEntry
-	input        textinput.Model
+	input        input.Line
 	filteredList []CommandEntry
 	selectedIdx  int
 	scrollOffset int
@@ -82,20 +86,19 @@ func NewCommandPalette(cmds map[string]config.UserCommand, sess *session.Session
 	}
 
 	// Create text input
-	input := textinput.New()
-	input.Placeholder = "command [args...]"
-	input.Prompt = ":"
-	input.Focus()
-	input.KeyMap.Paste.SetEnabled(true)
+	ti := input.NewLine(nil)
+	ti.Placeholder = "command [args...]"
+	ti.Prompt = ":"
+	ti.Focus()
 	inputStyles := textinput.DefaultStyles(true)
 	inputStyles.Focused.Prompt = styles.TextPrimaryStyle
 	inputStyles.Cursor.Color = styles.ColorPrimary
-	input.SetWidth(40)
-	input.SetStyles(inputStyles)
+	ti.SetWidth(40)
+	ti.SetStyles(inputStyles)
 
 	p := &CommandPalette{
 		commands:     entries,
-		input:        input,
+		input:        ti,
 		filteredList: entries, // Start with all commands visible
 		selectedIdx:  0,
 		width:        width,
@@ -113,6 +116,7 @@ func (p *CommandPalette) Update(msg tea.Msg) (*CommandPalette, tea.Cmd) {
 		case "enter":
 			if len(p.filteredList) > 0 && p.selectedIdx < len(p.filteredList) {
 				p.selected = true
+				p.recordHistory()
 			}
 			return p, nil
 		case "esc":
@@ -172,6 +176,23 @@ func (p *CommandPalette) Update(msg tea.Msg) (*CommandPalette, tea.Cmd) {
 	return p, cmd
 }
 
+// SetHistory lets earlier command lines be recalled with alt+p/alt+n, and
+// records the command line of each selected command.
+func (p *CommandPalette) SetHistory(history *input.History) {
+	p.input.History = history
+}
+
+// recordHistory records the selected command with its arguments, so a
+// fuzzy-matched name is recalled as the full command name.
+func (p *CommandPalette) recordHistory() {
+	parsed := ParseCommandInput(p.input.Value())
+	line := p.filteredList[p.selectedIdx].Name
+	if len(parsed.Args) > 0 {
+		line += " " + strings.Join(parsed.Args, " ")
+	}
+	p.input.History.Record(line)
+}
+
 // updateFilter filters the command list based on the current input.
 func (p *CommandPalette) updateFilter() {
 	inputVal := p.input.Value()
@@ -6,6 +6,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	act "github.com/colonyops/hive/internal/core/action"
 	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/tui/components/input"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -45,6 +46,32 @@ func TestCommandPalette_Selection(t *testing.T) {
 	assert.Empty(t, args)
 }
 
+func TestCommandPalette_History(t *testing.T) {
+	cmds := map[string]config.UserCommand{
+		"open":   {Sh: "open {{ .Path }}"},
+		"review": {Sh: "echo review"},
+	}
+	history := input.NewHistory(0)
+
+	p := NewCommandPalette(cmds, nil, 80, 24, ViewSessions)
+	p.SetHistory(history)
+	for _, r := range "rev now" {
+		p, _ = p.Update(tea.KeyPressMsg(tea.Key{Code: r, Text: string(r)}))
+	}
+	p, _ = p.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	assert.Equal(t, []string{"review now"}, history.Entries(), "the full command name is recorded")
+
+	// A new palette recalls the entry with alt+p and filters to it.
+	p = NewCommandPalette(cmds, nil, 80, 24, ViewSessions)
+	p.SetHistory(history)
+	p, _ = p.Update(tea.KeyPressMsg(tea.Key{Code: 'p', Mod: tea.ModAlt}))
+	p, _ = p.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyEnter}))
+	entry, args, ok := p.SelectedCommand()
+	require.True(t, ok)
+	assert.Equal(t, "review", entry.Name)
+	assert.Equal(t, []string{"now"}, args)
+}
+
 func TestCommandPalette_Cancel(t *testing.T) {
 	cmds := map[string]config.UserCommand{
 		"test": {Sh: "echo test"},
@@ -5,6 +5,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	lipgloss "charm.land/lipgloss/v2"
 	"github.com/colonyops/hive/internal/core/styles"
+	"github.com/colonyops/hive/internal/tui/components/input"
 )
 
 // TextField is a single-line text input form field.
@@ -16,7 +17,7 @@ type TextField struct {
 
 // NewTextField creates a new single-line text input field.
 func NewTextField(label, placeholder, defaultVal string) *TextField {
-	ti := textinput.New()
+	ti := input.NewTextInput()
 	ti.Placeholder = placeholder
 	ti.Prompt = ""
 	ti.SetWidth(40)
@@ -30,7 +31,6 @@ func NewTextField(label, placeholder, defaultVal string) *TextField {
 	inputStyles.Focused.Placeholder = lipgloss.NewStyle().Foreground(styles.ColorMuted)
 	inputStyles.Blurred.Placeholder = lipgloss.NewStyle().Foreground(styles.ColorMuted)
 	ti.SetStyles(inputStyles)
-	ti.KeyMap.Paste.SetEnabled(true)
 
 	return &TextField{
 		input: ti,
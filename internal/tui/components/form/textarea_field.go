@@ -5,6 +5,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	lipgloss "charm.land/lipgloss/v2"
 	"github.com/colonyops/hive/internal/core/styles"
+	"github.com/colonyops/hive/internal/tui/components/input"
 )
 
 // TextAreaField is a multi-line text input form field.
@@ -16,7 +17,7 @@ type TextAreaField struct {
 
 // NewTextAreaField creates a new multi-line text input field.
 func NewTextAreaField(label, placeholder, defaultVal string) *TextAreaField {
-	ta := textarea.New()
+	ta := input.NewTextArea()
 	ta.Placeholder = placeholder
 	ta.SetHeight(4)
 	ta.SetWidth(40)
@@ -24,7 +25,6 @@ func NewTextAreaField(label, placeholder, defaultVal string) *TextAreaField {
 	// InsertNewline is disabled so Enter advances the form rather than inserting a newline.
 	// Multi-line content can still be pasted.
 	ta.KeyMap.InsertNewline.SetEnabled(false)
-	ta.KeyMap.Paste.SetEnabled(true)
 
 	if defaultVal != "" {
 		ta.SetValue(defaultVal)
@@ -0,0 +1,116 @@
+package input
+
+import (
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textarea"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+)
+
+// HistoryKeyMap holds the keys that browse an input's history.
+type HistoryKeyMap struct {
+	Prev key.Binding
+	Next key.Binding
+}
+
+// DefaultHistoryKeyMap browses history with up/down and alt+p/alt+n. Inputs
+// that use up/down for something else, like the command palette's list,
+// intercept them first, so alt+p/alt+n work everywhere.
+var DefaultHistoryKeyMap = HistoryKeyMap{
+	Prev: key.NewBinding(key.WithKeys("up", "alt+p"), key.WithHelp("alt+p", "previous entry")),
+	Next: key.NewBinding(key.WithKeys("down", "alt+n"), key.WithHelp("alt+n", "next entry")),
+}
+
+// Line is a single-line text input with readline keys and history.
+type Line struct {
+	textinput.Model
+	History *History
+}
+
+// NewLine returns a Line browsing history, which may be nil.
+func NewLine(history *History) Line {
+	return Line{Model: NewTextInput(), History: history}
+}
+
+// Update recalls history entries on the history keys and passes every other
+// message to the text input.
+func (l Line) Update(msg tea.Msg) (Line, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok && l.History != nil {
+		switch {
+		case key.Matches(keyMsg, DefaultHistoryKeyMap.Prev):
+			if value, ok := l.History.Prev(l.Value()); ok {
+				l.SetValue(value)
+				l.CursorEnd()
+			}
+			return l, nil
+		case key.Matches(keyMsg, DefaultHistoryKeyMap.Next):
+			if value, ok := l.History.Next(); ok {
+				l.SetValue(value)
+				l.CursorEnd()
+			}
+			return l, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	l.Model, cmd = l.Model.Update(msg)
+	return l, cmd
+}
+
+// Commit records the current value in the history.
+func (l *Line) Commit() {
+	l.History.Record(l.Value())
+}
+
+// Reset clears the input and stops browsing history.
+func (l *Line) Reset() {
+	l.Model.Reset()
+	l.History.Reset()
+}
+
+// Area is a multi-line text input with readline keys and history. up and
+// down recall entries only from the first and last line, so they still move
+// the cursor between lines.
+type Area struct {
+	textarea.Model
+	History *History
+}
+
+// NewArea returns an Area browsing history, which may be nil.
+func NewArea(history *History) Area {
+	return Area{Model: NewTextArea(), History: history}
+}
+
+// Update recalls history entries on the history keys and passes every other
+// message to the textarea.
+func (a Area) Update(msg tea.Msg) (Area, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok && a.History != nil {
+		switch {
+		case key.Matches(keyMsg, DefaultHistoryKeyMap.Prev) && (keyMsg.String() != "up" || a.Line() == 0):
+			if value, ok := a.History.Prev(a.Value()); ok {
+				a.SetValue(value)
+			}
+			return a, nil
+		case key.Matches(keyMsg, DefaultHistoryKeyMap.Next) && (keyMsg.String() != "down" || a.Line() == a.LineCount()-1):
+			if value, ok := a.History.Next(); ok {
+				a.SetValue(value)
+			}
+			return a, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	a.Model, cmd = a.Model.Update(msg)
+	return a, cmd
+}
+
+// Commit records the current value in the history.
+func (a *Area) Commit() {
+	a.History.Record(a.Value())
+}
+
+// Reset clears the textarea and stops browsing history.
+func (a *Area) Reset() {
+	a.Model.Reset()
+	a.History.Reset()
+}
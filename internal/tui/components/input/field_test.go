@@ -0,0 +1,82 @@
+package input
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func typeText(t *testing.T, l Line, s string) Line {
+	t.Helper()
+	for _, r := range s {
+		l, _ = l.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	return l
+}
+
+func TestLine_ReadlineKeys(t *testing.T) {
+	l := NewLine(nil)
+	l.Focus()
+	l = typeText(t, l, "hello big world")
+
+	l, _ = l.Update(tea.KeyPressMsg{Code: 'w', Mod: tea.ModCtrl})
+	assert.Equal(t, "hello big ", l.Value(), "ctrl+w deletes the previous word")
+
+	l, _ = l.Update(tea.KeyPressMsg{Code: 'b', Mod: tea.ModAlt})
+	assert.Equal(t, len("hello "), l.Position(), "alt+b moves back a word")
+
+	l, _ = l.Update(tea.KeyPressMsg{Code: 'a', Mod: tea.ModCtrl})
+	assert.Equal(t, 0, l.Position())
+
+	l, _ = l.Update(tea.KeyPressMsg{Code: 'e', Mod: tea.ModCtrl})
+	assert.Equal(t, len("hello big "), l.Position())
+
+	l, _ = l.Update(tea.KeyPressMsg{Code: 'u', Mod: tea.ModCtrl})
+	assert.Empty(t, l.Value(), "ctrl+u deletes before the cursor")
+}
+
+func TestLine_History(t *testing.T) {
+	h := NewHistory(0)
+	h.Record("older")
+	h.Record("newer")
+
+	l := NewLine(h)
+	l.Focus()
+	l = typeText(t, l, "dra")
+
+	l, _ = l.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	assert.Equal(t, "newer", l.Value())
+	assert.Equal(t, len("newer"), l.Position(), "cursor moves to the end")
+
+	l, _ = l.Update(tea.KeyPressMsg{Code: 'p', Mod: tea.ModAlt})
+	assert.Equal(t, "older", l.Value())
+
+	l, _ = l.Update(tea.KeyPressMsg{Code: 'n', Mod: tea.ModAlt})
+	l, _ = l.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	assert.Equal(t, "dra", l.Value(), "the draft is restored")
+
+	l = typeText(t, l, "ft")
+	l.Commit()
+	assert.Equal(t, []string{"older", "newer", "draft"}, h.Entries())
+}
+
+func TestArea_HistoryFromEdgeLines(t *testing.T) {
+	h := NewHistory(0)
+	h.Record("earlier comment")
+
+	a := NewArea(h)
+	a.Focus()
+	a.SetValue("line one\nline two")
+
+	// up on the last line moves the cursor instead of recalling history
+	a, _ = a.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	assert.Equal(t, "line one\nline two", a.Value())
+	assert.Equal(t, 0, a.Line())
+
+	a, _ = a.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	assert.Equal(t, "earlier comment", a.Value())
+
+	a, _ = a.Update(tea.KeyPressMsg{Code: 'n', Mod: tea.ModAlt})
+	assert.Equal(t, "line one\nline two", a.Value())
+}
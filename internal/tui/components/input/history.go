@@ -0,0 +1,128 @@
+package input
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	corekv "github.com/colonyops/hive/internal/core/kv"
+)
+
+// DefaultHistoryLimit is the number of entries a History keeps by default.
+const DefaultHistoryLimit = 100
+
+// History is the list of values previously entered in an input, browsed
+// like shell history. A nil History is valid and records nothing.
+type History struct {
+	store   corekv.KV
+	key     string
+	limit   int
+	entries []string // oldest first
+
+	pos   int    // index of the recalled entry; len(entries) when not browsing
+	draft string // value being edited before browsing started
+}
+
+// NewHistory returns an in-memory history keeping at most limit entries.
+func NewHistory(limit int) *History {
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	return &History{limit: limit}
+}
+
+// LoadHistory returns a history persisted in store under key, loaded with
+// the entries saved by earlier runs. A nil store keeps the history in
+// memory only.
+func LoadHistory(store corekv.KV, key string, limit int) *History {
+	h := NewHistory(limit)
+	if store == nil {
+		return h
+	}
+	h.store, h.key = store, key
+
+	var entries []string
+	if err := store.Get(context.Background(), key, &entries); err == nil {
+		if len(entries) > h.limit {
+			entries = entries[len(entries)-h.limit:]
+		}
+		h.entries = entries
+	}
+	h.pos = len(h.entries)
+	return h
+}
+
+// Record adds value as the newest entry and stops browsing. Blank values are
+// ignored, and an earlier copy of value is removed so each entry appears once.
+func (h *History) Record(value string) {
+	if h == nil {
+		return
+	}
+	defer h.Reset()
+	if strings.TrimSpace(value) == "" {
+		return
+	}
+
+	h.entries = slices.DeleteFunc(h.entries, func(e string) bool { return e == value })
+	h.entries = append(h.entries, value)
+	if len(h.entries) > h.limit {
+		h.entries = h.entries[len(h.entries)-h.limit:]
+	}
+	h.save()
+}
+
+// Prev returns the entry before the one being browsed. current is the
+// input's value, restored by Next once browsing moves past the newest entry.
+// Returns false when there is no older entry.
+func (h *History) Prev(current string) (string, bool) {
+	if h == nil || h.pos == 0 {
+		return "", false
+	}
+	if h.pos == len(h.entries) {
+		h.draft = current
+	}
+	h.pos--
+	return h.entries[h.pos], true
+}
+
+// Next returns the entry after the one being browsed, or the value being
+// edited before browsing started once past the newest entry. Returns false
+// when not browsing.
+func (h *History) Next() (string, bool) {
+	if h == nil || h.pos >= len(h.entries) {
+		return "", false
+	}
+	h.pos++
+	if h.pos == len(h.entries) {
+		return h.draft, true
+	}
+	return h.entries[h.pos], true
+}
+
+// Reset stops browsing so the next Prev starts from the newest entry.
+func (h *History) Reset() {
+	if h == nil {
+		return
+	}
+	h.pos = len(h.entries)
+	h.draft = ""
+}
+
+// Entries returns the recorded entries, oldest first.
+func (h *History) Entries() []string {
+	if h == nil {
+		return nil
+	}
+	return slices.Clone(h.entries)
+}
+
+func (h *History) save() {
+	if h.store == nil {
+		return
+	}
+	if err := h.store.Set(context.Background(), h.key, h.entries); err != nil {
+		log.Debug().Err(err).Str("key", h.key).Msg("failed to persist input history")
+	}
+}
@@ -0,0 +1,86 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/internal/data/stores"
+)
+
+func TestHistory_Browse(t *testing.T) {
+	h := NewHistory(0)
+	h.Record("first")
+	h.Record("second")
+
+	got, ok := h.Prev("draft")
+	require.True(t, ok)
+	assert.Equal(t, "second", got)
+
+	got, ok = h.Prev(got)
+	require.True(t, ok)
+	assert.Equal(t, "first", got)
+
+	_, ok = h.Prev(got)
+	assert.False(t, ok, "no entry before the oldest")
+
+	got, ok = h.Next()
+	require.True(t, ok)
+	assert.Equal(t, "second", got)
+
+	got, ok = h.Next()
+	require.True(t, ok)
+	assert.Equal(t, "draft", got, "moving past the newest entry restores the draft")
+
+	_, ok = h.Next()
+	assert.False(t, ok)
+}
+
+func TestHistory_Record(t *testing.T) {
+	h := NewHistory(3)
+	for _, v := range []string{"a", "b", "  ", "a", "c", "d"} {
+		h.Record(v)
+	}
+	assert.Equal(t, []string{"a", "c", "d"}, h.Entries(), "blank values are skipped, duplicates move to the end, and the limit applies")
+
+	_, _ = h.Prev("")
+	h.Record("e")
+	got, ok := h.Prev("")
+	require.True(t, ok)
+	assert.Equal(t, "e", got, "recording stops browsing")
+}
+
+func TestHistory_Nil(t *testing.T) {
+	var h *History
+	h.Record("x")
+	h.Reset()
+	_, ok := h.Prev("")
+	assert.False(t, ok)
+	_, ok = h.Next()
+	assert.False(t, ok)
+	assert.Nil(t, h.Entries())
+}
+
+func TestLoadHistory(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+	store := stores.NewKVStore(database)
+
+	h := LoadHistory(store, "tui.history.test", 2)
+	assert.Empty(t, h.Entries())
+	h.Record("one")
+	h.Record("two")
+	h.Record("three")
+
+	reloaded := LoadHistory(store, "tui.history.test", 2)
+	assert.Equal(t, []string{"two", "three"}, reloaded.Entries())
+
+	got, ok := reloaded.Prev("")
+	require.True(t, ok)
+	assert.Equal(t, "three", got)
+
+	assert.Empty(t, LoadHistory(nil, "tui.history.test", 0).Entries())
+}
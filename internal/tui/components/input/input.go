@@ -0,0 +1,59 @@
+// Package input provides the text inputs used across the TUI. Every input
+// gets the same emacs-style editing keys, and Line and Area add per-input
+// history that can be persisted in the KV store.
+package input
+
+import (
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textarea"
+	"charm.land/bubbles/v2/textinput"
+)
+
+// NewTextInput returns a textinput with readline keys and paste enabled.
+func NewTextInput() textinput.Model {
+	ti := textinput.New()
+	ti.KeyMap = TextInputKeyMap()
+	return ti
+}
+
+// NewTextArea returns a textarea with readline keys and paste enabled.
+func NewTextArea() textarea.Model {
+	ta := textarea.New()
+	ta.KeyMap = TextAreaKeyMap()
+	return ta
+}
+
+// TextInputKeyMap returns the textinput key map with the readline bindings:
+// ctrl+a/e to jump to the line start and end, ctrl+w and alt+backspace to
+// delete the previous word, ctrl+u and ctrl+k to delete before and after the
+// cursor, and alt+b/f to move by word.
+func TextInputKeyMap() textinput.KeyMap {
+	km := textinput.DefaultKeyMap()
+	km.LineStart = key.NewBinding(key.WithKeys("home", "ctrl+a"))
+	km.LineEnd = key.NewBinding(key.WithKeys("end", "ctrl+e"))
+	km.WordBackward = key.NewBinding(key.WithKeys("alt+left", "ctrl+left", "alt+b"))
+	km.WordForward = key.NewBinding(key.WithKeys("alt+right", "ctrl+right", "alt+f"))
+	km.DeleteWordBackward = key.NewBinding(key.WithKeys("alt+backspace", "ctrl+w"))
+	km.DeleteWordForward = key.NewBinding(key.WithKeys("alt+delete", "alt+d"))
+	km.DeleteBeforeCursor = key.NewBinding(key.WithKeys("ctrl+u"))
+	km.DeleteAfterCursor = key.NewBinding(key.WithKeys("ctrl+k"))
+	km.Paste.SetEnabled(true)
+	return km
+}
+
+// TextAreaKeyMap returns the textarea key map with the same readline
+// bindings as TextInputKeyMap. ctrl+a/e, ctrl+u and ctrl+k act on the
+// current line.
+func TextAreaKeyMap() textarea.KeyMap {
+	km := textarea.DefaultKeyMap()
+	km.LineStart = key.NewBinding(key.WithKeys("home", "ctrl+a"), key.WithHelp("ctrl+a", "line start"))
+	km.LineEnd = key.NewBinding(key.WithKeys("end", "ctrl+e"), key.WithHelp("ctrl+e", "line end"))
+	km.WordBackward = key.NewBinding(key.WithKeys("alt+left", "ctrl+left", "alt+b"), key.WithHelp("alt+b", "word backward"))
+	km.WordForward = key.NewBinding(key.WithKeys("alt+right", "ctrl+right", "alt+f"), key.WithHelp("alt+f", "word forward"))
+	km.DeleteWordBackward = key.NewBinding(key.WithKeys("alt+backspace", "ctrl+w"), key.WithHelp("ctrl+w", "delete word backward"))
+	km.DeleteWordForward = key.NewBinding(key.WithKeys("alt+delete", "alt+d"), key.WithHelp("alt+d", "delete word forward"))
+	km.DeleteBeforeCursor = key.NewBinding(key.WithKeys("ctrl+u"), key.WithHelp("ctrl+u", "delete before cursor"))
+	km.DeleteAfterCursor = key.NewBinding(key.WithKeys("ctrl+k"), key.WithHelp("ctrl+k", "delete after cursor"))
+	km.Paste.SetEnabled(true)
+	return km
+}
@@ -0,0 +1,213 @@
+package tui
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/fsnotify/fsnotify"
+	"github.com/hay-kot/criterio"
+	"github.com/rs/zerolog/log"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/notify"
+	"github.com/colonyops/hive/internal/core/styles"
+	"github.com/colonyops/hive/internal/tui/components"
+)
+
+// configIssue is a problem found in the config file on disk.
+type configIssue struct {
+	Field   string // offending field path, empty when the file does not parse
+	Message string
+}
+
+// configReloadMsg reports the result of reloading the config file after it
+// changed on disk: the reloaded config when the file is valid, or its
+// problems when it is not.
+type configReloadMsg struct {
+	cfg    *config.Config
+	issues []configIssue
+}
+
+// configWatcher reloads the config file whenever it is saved, so the TUI can
+// apply the settings that are safe to change in place and warn when the
+// file on disk no longer loads.
+type configWatcher struct {
+	watcher     *fsnotify.Watcher
+	path        string
+	dataDir     string
+	debounceDur time.Duration
+}
+
+// newConfigWatcher watches the config file at path.
+func newConfigWatcher(path, dataDir string) (*configWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	// Watch the directory: editors often replace the file rather than write it
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+
+	return &configWatcher{
+		watcher:     watcher,
+		path:        filepath.Clean(path),
+		dataDir:     dataDir,
+		debounceDur: 100 * time.Millisecond,
+	}, nil
+}
+
+// Next returns a command that waits for the config file to change and
+// reloads it.
+func (w *configWatcher) Next() tea.Cmd {
+	return func() tea.Msg {
+		for {
+			select {
+			case event, ok := <-w.watcher.Events:
+				if !ok {
+					return nil
+				}
+				if filepath.Clean(event.Name) != w.path || event.Has(fsnotify.Chmod) {
+					continue
+				}
+
+				// Debounce: editors write the file in several steps
+				time.Sleep(w.debounceDur)
+				for drained := false; !drained; {
+					select {
+					case <-w.watcher.Events:
+					default:
+						drained = true
+					}
+				}
+
+				cfg, issues := reloadConfig(w.path, w.dataDir)
+				return configReloadMsg{cfg: cfg, issues: issues}
+
+			case err, ok := <-w.watcher.Errors:
+				if !ok {
+					return nil
+				}
+				log.Error().Err(err).Msg("config: file watcher error")
+			}
+		}
+	}
+}
+
+// Close stops the watcher.
+func (w *configWatcher) Close() error {
+	return w.watcher.Close()
+}
+
+// reloadConfig loads the config file the way startup does. It returns the
+// config, or every problem found when the file does not load.
+func reloadConfig(path, dataDir string) (*config.Config, []configIssue) {
+	cfg, err := config.Load(path, dataDir)
+	if err == nil {
+		return cfg, nil
+	}
+
+	var fieldErrs criterio.FieldErrors
+	if errors.As(err, &fieldErrs) && len(fieldErrs) > 0 {
+		issues := make([]configIssue, 0, len(fieldErrs))
+		for _, fe := range fieldErrs {
+			issues = append(issues, configIssue{Field: fe.Field, Message: fe.Err.Error()})
+		}
+		return nil, issues
+	}
+	return nil, []configIssue{{Message: err.Error()}}
+}
+
+// handleConfigReload applies a reloaded config, or shows its problems in the
+// warning banner and, unless another dialog is open, in a modal. Either way
+// it keeps watching the file.
+func (m Model) handleConfigReload(msg configReloadMsg) (tea.Model, tea.Cmd) {
+	shown := m.configIssue != nil
+	cmds := []tea.Cmd{m.configWatcher.Next()}
+
+	if len(msg.issues) > 0 {
+		issue := msg.issues[0]
+		log.Warn().Str("field", issue.Field).Str("error", issue.Message).Msg("config file on disk is invalid")
+		m.configIssue = &issue
+		if m.state == stateNormal {
+			m.showConfigIssues(msg.issues)
+		}
+	} else {
+		m.configIssue = nil
+		cmds = append(cmds, m.applyReloadedConfig(msg.cfg))
+		log.Info().Msg("config file reloaded")
+		m.publishNotificationf(notify.LevelInfo, "Config reloaded")
+	}
+
+	if shown != (m.configIssue != nil) {
+		m.resizeViews()
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// applyReloadedConfig copies the settings that are safe to change while the
+// TUI runs from next into the running config: user commands, keybindings,
+// the theme, the session preview templates, and refresh intervals. Every
+// other setting keeps the value hive started with.
+func (m *Model) applyReloadedConfig(next *config.Config) tea.Cmd {
+	cfg := m.cfg
+	prevRefresh := cfg.Views.Sessions.RefreshInterval
+	prevTheme := cfg.TUI.Theme
+
+	cfg.UserCommands = next.UserCommands
+	cfg.Keybindings = next.Keybindings
+	cfg.Views.Global.Keybindings = next.Views.Global.Keybindings
+	cfg.Views.Sessions.Keybindings = next.Views.Sessions.Keybindings
+	cfg.Views.Tasks.Keybindings = next.Views.Tasks.Keybindings
+	cfg.Views.Review.Keybindings = next.Views.Review.Keybindings
+	cfg.Views.Sessions.PreviewTitle = next.Views.Sessions.PreviewTitle
+	cfg.Views.Sessions.PreviewStatus = next.Views.Sessions.PreviewStatus
+	cfg.Views.Sessions.RefreshInterval = next.Views.Sessions.RefreshInterval
+	cfg.Tmux.PollInterval = next.Tmux.PollInterval
+	cfg.TUI.Theme = next.TUI.Theme
+
+	m.commandSet.SetUser(cfg.UserCommands)
+	m.handler.SetKeybindings(viewKeybindings(cfg))
+	// Only a changed theme is applied, so a theme picked with SetTheme
+	// survives unrelated edits.
+	if cfg.TUI.Theme != prevTheme {
+		m.applyTheme(cfg.TUI.Theme)
+	}
+	return m.sessionsView.ReloadConfig(prevRefresh)
+}
+
+// showConfigIssues opens a dialog listing the problems in the config file.
+func (m *Model) showConfigIssues(issues []configIssue) {
+	items := make([]components.InfoItem, 0, len(issues))
+	for _, issue := range issues {
+		label := issue.Field
+		if label == "" {
+			label = "config"
+		}
+		items = append(items, components.InfoItem{Label: label, Value: issue.Message, Status: components.InfoStatusFail})
+	}
+	m.modals.ShowInfo("Config Reload Failed",
+		[]components.InfoSection{{Title: m.configWatcher.path, Items: items}},
+		styles.TextMutedStyle.Render("Still using the last valid config; fix the file and save to reload."),
+		components.KeyHints(
+			components.HelpEntry{Key: "j/k", Desc: "scroll"},
+			components.HelpEntry{Key: "esc", Desc: "close"},
+		))
+	m.state = stateShowingInfo
+}
+
+// renderConfigBanner renders the persistent warning shown while the config
+// file on disk is invalid.
+func (m Model) renderConfigBanner(width int) string {
+	text := "Config file invalid: "
+	if m.configIssue.Field != "" {
+		text += m.configIssue.Field + ": "
+	}
+	text += strings.Join(strings.Fields(m.configIssue.Message), " ") + " (still using the last valid config)"
+	return styles.TextWarningStyle.Render(ansi.Truncate(" ⚠ "+text, width, "…"))
+}
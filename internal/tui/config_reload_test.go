@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte("git:\n  status_workers: 2\n"), 0o644))
+	cfg, issues := reloadConfig(path, dir)
+	assert.Empty(t, issues)
+	require.NotNil(t, cfg)
+	assert.Equal(t, 2, cfg.Git.StatusWorkers)
+
+	require.NoError(t, os.WriteFile(path, []byte("git:\n  status_workers: -1\n"), 0o644))
+	cfg, issues = reloadConfig(path, dir)
+	assert.Nil(t, cfg)
+	require.NotEmpty(t, issues)
+	assert.Equal(t, "git.status_workers", issues[0].Field)
+	assert.NotEmpty(t, issues[0].Message)
+
+	require.NoError(t, os.WriteFile(path, []byte("git: [unclosed\n"), 0o644))
+	_, issues = reloadConfig(path, dir)
+	require.Len(t, issues, 1)
+	assert.Empty(t, issues[0].Field)
+	assert.Contains(t, issues[0].Message, "parse")
+}
+
+func TestConfigWatcher_ReloadsInPlace(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("git:\n  status_workers: 2\n"), 0o644))
+
+	watcher, err := newConfigWatcher(path, dir)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = watcher.Close() })
+
+	m := newKeybindingPrecedenceModel(t, nil)
+	m.configWatcher = watcher
+	m.width, m.height = 80, 24
+	workers := m.cfg.Git.StatusWorkers
+
+	// An invalid file shows the banner and a dialog, and changes nothing.
+	require.NoError(t, os.WriteFile(path, []byte("git:\n  status_workers: -1\n"), 0o644))
+	msg := watcher.Next()()
+	require.IsType(t, configReloadMsg{}, msg)
+	result, cmd := m.Update(msg)
+	m = result.(Model)
+	require.NotNil(t, cmd, "keeps watching the file")
+	require.NotNil(t, m.configIssue)
+	assert.Equal(t, stateShowingInfo, m.state)
+	assert.Equal(t, 4, m.chromeHeight())
+	assert.Contains(t, m.renderConfigBanner(200), "Config file invalid: git.status_workers:")
+	m.state = stateNormal
+
+	// A valid file applies the safe subset in place.
+	require.NoError(t, os.WriteFile(path, []byte(`git:
+  status_workers: 9
+usercommands:
+  Hello:
+    sh: echo hello
+views:
+  sessions:
+    refresh_interval: 30s
+    preview_title: "{{ .Name }}!"
+    keybindings:
+      "z":
+        cmd: Hello
+`), 0o644))
+	result, _ = m.Update(watcher.Next()())
+	m = result.(Model)
+	assert.Nil(t, m.configIssue)
+	assert.Equal(t, 3, m.chromeHeight())
+	assert.Equal(t, stateNormal, m.state)
+
+	_, ok := m.commandSet.Lookup("Hello")
+	assert.True(t, ok, "user commands are reloaded")
+	kb, _, ok := m.handler.Binding("sessions", "z")
+	require.True(t, ok, "keybindings are reloaded")
+	assert.Equal(t, "Hello", kb.Cmd)
+	assert.Equal(t, 30*time.Second, m.cfg.Views.Sessions.RefreshInterval)
+	assert.Equal(t, "{{ .Name }}!", m.cfg.Views.Sessions.PreviewTitle)
+	assert.Equal(t, workers, m.cfg.Git.StatusWorkers, "other settings keep their startup values")
+
+	assert.Eventually(t, func() bool {
+		for _, n := range m.notifyBuffer.Drain() {
+			if n.Message == "Config reloaded" {
+				return true
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond, "reload is announced")
+}
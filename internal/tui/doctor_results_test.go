@@ -44,3 +44,23 @@ func TestBuildDoctorDialogContent_StatusMappingAndSummary(t *testing.T) {
 	assert.Contains(t, footer, "1 warnings", "footer should include warning count")
 	assert.Contains(t, footer, "1 failed", "footer should include fail count")
 }
+
+func TestHandleReconcileResults(t *testing.T) {
+	m := Model{notifyBuffer: NewNotificationBuffer()}
+
+	result, _ := m.handleReconcileResults(reconcileResultsMsg{results: []doctor.Result{
+		{Name: "Orphan Worktrees", Items: []doctor.CheckItem{{Label: "No orphans", Status: doctor.StatusPass}}},
+	}})
+	assert.Empty(t, result.(Model).notifyBuffer.Drain(), "nothing to report")
+
+	result, _ = m.handleReconcileResults(reconcileResultsMsg{results: []doctor.Result{
+		{Name: "Tmux Sessions", Items: []doctor.CheckItem{
+			{Label: "old", Status: doctor.StatusWarn, Fixable: true},
+			{Label: "older", Status: doctor.StatusWarn, Fixable: true},
+		}},
+	}})
+	notifications := result.(Model).notifyBuffer.Drain()
+	if assert.Len(t, notifications, 1) {
+		assert.Contains(t, notifications[0].Message, "Reconcile found 2 issue(s)")
+	}
+}
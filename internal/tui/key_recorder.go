@@ -0,0 +1,265 @@
+package tui
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/styles"
+	"github.com/colonyops/hive/internal/tui/components"
+)
+
+// reservedKeys are handled by the TUI before keybindings and cannot be bound.
+var reservedKeys = []string{keyCtrlC, "esc", "tab", "shift+tab"}
+
+// keyBoundMsg reports the result of writing a recorded binding to config.
+type keyBoundMsg struct {
+	view   string
+	key    string
+	cmd    string
+	backup string
+	err    error
+}
+
+// KeyRecorder is a modal that captures a keypress, shows what it is bound to,
+// and picks the command to bind it to. While recording, the next key is
+// captured (esc cancels). Then:
+//   - Type: Filter commands
+//   - Up/Down: Select a command
+//   - Tab: Switch between the view's and the global keybindings
+//   - Enter: Bind the key to the selected command
+//   - Esc: Record a different key
+type KeyRecorder struct {
+	views    []string // views the key can be bound in; the first is the active view
+	view     int
+	commands map[string][]string // view -> names of the commands in scope, sorted
+
+	// describe returns the current binding of a key in a view.
+	describe func(view, key string) string
+
+	key          string // recorded key, empty while recording
+	notice       string
+	filtered     []string
+	cursor       int
+	scrollOffset int
+	query        string
+	width        int
+	height       int
+	cancelled    bool
+	selected     string
+}
+
+// NewKeyRecorder creates a key recorder binding keys in view, or in the
+// global keybindings, to one of cmds.
+func NewKeyRecorder(view string, cmds map[string]config.UserCommand, describe func(view, key string) string, width, height int) *KeyRecorder {
+	views := []string{"global"}
+	if view != "global" && slices.Contains(config.KeybindingViews, view) {
+		views = []string{view, "global"}
+	}
+
+	commands := make(map[string][]string, len(views))
+	for _, v := range views {
+		for name, cmd := range cmds {
+			if commandInScope(cmd, v) {
+				commands[v] = append(commands[v], name)
+			}
+		}
+		sort.Strings(commands[v])
+	}
+
+	return &KeyRecorder{
+		views:    views,
+		commands: commands,
+		describe: describe,
+		width:    width,
+		height:   height,
+	}
+}
+
+// commandInScope reports whether cmd runs when bound in view. Commands bound
+// globally must be global themselves.
+func commandInScope(cmd config.UserCommand, view string) bool {
+	if len(cmd.Scope) == 0 {
+		return true
+	}
+	return slices.Contains(cmd.Scope, "global") || (view != "global" && slices.Contains(cmd.Scope, view))
+}
+
+// Update handles key events for the recorder.
+func (r *KeyRecorder) Update(msg tea.Msg) (*KeyRecorder, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return r, nil
+	}
+	keyStr := keyMsg.String()
+
+	if r.key == "" {
+		switch {
+		case keyStr == "esc":
+			r.cancelled = true
+		case slices.Contains(reservedKeys, keyStr):
+			r.notice = keyStr + " is reserved by hive, press another key"
+		default:
+			r.key = keyStr
+			r.notice = ""
+			r.applyFilter()
+		}
+		return r, nil
+	}
+
+	switch keyStr {
+	case "esc":
+		r.key = ""
+		r.query = ""
+	case "enter":
+		if len(r.filtered) > 0 && r.cursor < len(r.filtered) {
+			r.selected = r.filtered[r.cursor]
+		}
+	case "tab":
+		r.view = (r.view + 1) % len(r.views)
+		r.applyFilter()
+	case "up":
+		if r.cursor > 0 {
+			r.cursor--
+			r.clampScroll()
+		}
+	case "down":
+		if r.cursor < len(r.filtered)-1 {
+			r.cursor++
+			r.clampScroll()
+		}
+	case "backspace":
+		if len(r.query) > 0 {
+			r.query = r.query[:len(r.query)-1]
+			r.applyFilter()
+		}
+	default:
+		// Single printable character → append to query.
+		if len(keyStr) == 1 {
+			r.query += keyStr
+			r.applyFilter()
+		}
+	}
+	return r, nil
+}
+
+func (r *KeyRecorder) applyFilter() {
+	commands := r.commands[r.TargetView()]
+	q := strings.ToLower(r.query)
+	r.filtered = make([]string, 0, len(commands))
+	for _, name := range commands {
+		if strings.Contains(strings.ToLower(name), q) {
+			r.filtered = append(r.filtered, name)
+		}
+	}
+	r.cursor = 0
+	r.scrollOffset = 0
+}
+
+func (r *KeyRecorder) visibleCount() int {
+	return min(len(r.filtered), max(r.height/3, 5))
+}
+
+func (r *KeyRecorder) clampScroll() {
+	mv := r.visibleCount()
+	if r.cursor < r.scrollOffset {
+		r.scrollOffset = r.cursor
+	} else if r.cursor >= r.scrollOffset+mv {
+		r.scrollOffset = r.cursor - mv + 1
+	}
+	maxOffset := max(len(r.filtered)-mv, 0)
+	r.scrollOffset = min(max(r.scrollOffset, 0), maxOffset)
+}
+
+// View renders the recorder content.
+func (r *KeyRecorder) View() string {
+	modalWidth := max(int(float64(r.width)*0.5), 50)
+	title := styles.ModalTitleStyle.Render("Bind Key")
+
+	if r.key == "" {
+		parts := []string{title, "", "Press the key to bind…"}
+		if r.notice != "" {
+			parts = append(parts, "", styles.TextWarningStyle.Render(r.notice))
+		}
+		parts = append(parts, "", styles.ModalHelpStyle.Render(components.KeyHints(
+			components.HelpEntry{Key: "esc", Desc: "cancel"},
+		)))
+		return styles.ModalStyle.Width(modalWidth).Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+	}
+
+	label := func(s string) string { return styles.TextMutedStyle.Render(s) }
+	details := []string{
+		label("Key      ") + styles.TextPrimaryBoldStyle.Render(r.key),
+		label("View     ") + r.TargetView(),
+		label("Current  ") + r.describe(r.TargetView(), r.key),
+	}
+
+	searchLine := styles.TextMutedStyle.Render("> ") + r.query + styles.TextMutedStyle.Render("█")
+
+	var lines []string
+	for i := range r.visibleCount() {
+		idx := i + r.scrollOffset
+		if idx >= len(r.filtered) {
+			break
+		}
+		name := r.filtered[idx]
+		if idx == r.cursor {
+			lines = append(lines, styles.TextPrimaryBoldStyle.Render("▸ "+name))
+		} else {
+			lines = append(lines, "  "+name)
+		}
+	}
+	if len(r.filtered) == 0 {
+		lines = append(lines, styles.TextMutedStyle.Render("  no matching commands"))
+	}
+
+	hints := []components.HelpEntry{{Key: "enter", Desc: "bind"}}
+	if len(r.views) > 1 {
+		hints = append(hints, components.HelpEntry{Key: "tab", Desc: "switch view"})
+	}
+	hints = append(hints, components.HelpEntry{Key: "esc", Desc: "other key"})
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Left,
+		title,
+		"",
+		strings.Join(details, "\n"),
+		"",
+		searchLine,
+		"",
+		strings.Join(lines, "\n"),
+		"",
+		styles.ModalHelpStyle.Render(components.KeyHints(hints...)),
+	)
+	return styles.ModalStyle.Width(modalWidth).Render(content)
+}
+
+// Overlay renders the recorder centered over the background.
+func (r *KeyRecorder) Overlay(bg string, w, h int) string {
+	return centeredOverlay(bg, r.View(), w, h)
+}
+
+// TargetView returns the view the key is bound in.
+func (r *KeyRecorder) TargetView() string {
+	return r.views[r.view]
+}
+
+// Key returns the recorded key, or empty string while recording.
+func (r *KeyRecorder) Key() string {
+	return r.key
+}
+
+// Cancelled returns true if the user dismissed the recorder.
+func (r *KeyRecorder) Cancelled() bool {
+	return r.cancelled
+}
+
+// Selected returns the command to bind the key to, or empty string if none
+// was chosen.
+func (r *KeyRecorder) Selected() string {
+	return r.selected
+}
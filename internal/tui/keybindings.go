@@ -42,10 +42,11 @@ type KeybindingResolver struct {
 	effectiveKeybindings   map[string]config.Keybinding            // merged global + active view
 	commandSet             *plugins.CommandSet
 	renderer               *tmpl.Renderer
-	activeView             ViewType                      // current active view for scope checking
-	tmuxWindowLookup       func(sessionID string) string // optional: returns tmux target for a session
-	toolLookup             func(sessionID string) string // optional: returns detected tool name for a session
-	selectedWindowOverride string                        // if set, overrides tmuxWindowLookup for the next resolve
+	activeView             ViewType                                    // current active view for scope checking
+	tmuxWindowLookup       func(sessionID string) string               // optional: returns tmux target for a session
+	toolLookup             func(sessionID string) string               // optional: returns detected tool name for a session
+	kvLookup               func(sessionID, key string) (string, error) // optional: returns a session value for kvGet
+	selectedWindowOverride string                                      // if set, overrides tmuxWindowLookup for the next resolve
 }
 
 // NewKeybindingResolver creates a resolver. commandSet is the canonical
@@ -74,7 +75,7 @@ func (h *KeybindingResolver) rebuildEffective() {
 	if global, ok := h.viewKeybindings["global"]; ok {
 		maps.Copy(effective, global)
 	}
-	viewName := h.activeView.String()
+	viewName := h.activeView.scope()
 	if viewKBs, ok := h.viewKeybindings[viewName]; ok {
 		maps.Copy(effective, viewKBs)
 	}
@@ -88,6 +89,34 @@ func (h *KeybindingResolver) SetActiveView(view ViewType) {
 	h.rebuildEffective()
 }
 
+// Binding returns the binding of key in view and the view it is inherited
+// from: view itself, or "global" when view does not override it.
+func (h *KeybindingResolver) Binding(view, key string) (config.Keybinding, string, bool) {
+	if kb, ok := h.viewKeybindings[view][key]; ok {
+		return kb, view, true
+	}
+	if kb, ok := h.viewKeybindings["global"][key]; ok {
+		return kb, "global", true
+	}
+	return config.Keybinding{}, "", false
+}
+
+// SetBinding binds key in view, taking effect immediately.
+func (h *KeybindingResolver) SetBinding(view, key string, kb config.Keybinding) {
+	if h.viewKeybindings[view] == nil {
+		h.viewKeybindings[view] = make(map[string]config.Keybinding)
+	}
+	h.viewKeybindings[view][key] = kb
+	h.rebuildEffective()
+}
+
+// SetKeybindings replaces every view's keybindings, taking effect
+// immediately.
+func (h *KeybindingResolver) SetKeybindings(viewKeybindings map[string]map[string]config.Keybinding) {
+	h.viewKeybindings = viewKeybindings
+	h.rebuildEffective()
+}
+
 // SetTmuxWindowLookup sets a function that resolves tmux window or pane targets for sessions.
 // This enables the legacy TmuxWindow field in shell command templates.
 func (h *KeybindingResolver) SetTmuxWindowLookup(fn func(sessionID string) string) {
@@ -99,6 +128,12 @@ func (h *KeybindingResolver) SetToolLookup(fn func(sessionID string) string) {
 	h.toolLookup = fn
 }
 
+// SetSessionKVLookup sets a function that resolves session values for the
+// kvGet template function.
+func (h *KeybindingResolver) SetSessionKVLookup(fn func(sessionID, key string) (string, error)) {
+	h.kvLookup = fn
+}
+
 // SetSelectedTarget overrides the legacy TmuxWindow template value for the next resolve call.
 // The target may be a tmux window name/index or a pane ID such as %7.
 // The override is consumed (cleared) after each Resolve or ResolveUserCommand call.
@@ -136,12 +171,23 @@ func (h *KeybindingResolver) toolForSession(sessionID string) string {
 	return h.toolLookup(sessionID)
 }
 
+// rendererFor returns the renderer for commands run against a session, with
+// kvGet reading that session's values.
+func (h *KeybindingResolver) rendererFor(sessionID string) *tmpl.Renderer {
+	if h.kvLookup == nil || sessionID == "" {
+		return h.renderer
+	}
+	return h.renderer.WithKV(func(key string) (string, error) {
+		return h.kvLookup(sessionID, key)
+	})
+}
+
 // isCommandInScope checks if a command is active in the current view.
 func (h *KeybindingResolver) isCommandInScope(cmd config.UserCommand) bool {
 	if len(cmd.Scope) == 0 {
 		return true // global by default
 	}
-	currentScope := h.activeView.String()
+	currentScope := h.activeView.scope()
 	for _, scope := range cmd.Scope {
 		if scope == "global" || scope == currentScope {
 			return true
@@ -207,8 +253,9 @@ func renderUserCommandWindows(renderer *tmpl.Renderer, windows []config.WindowCo
 // It routes sh: to the appropriate location depending on whether options.session_name is set.
 func (h *KeybindingResolver) resolveWindowsAction(a Action, cmd config.UserCommand, sess session.Session, data map[string]any) Action {
 	a.Type = action.TypeSpawnWindows
+	renderer := h.rendererFor(sess.ID)
 
-	windows, err := renderUserCommandWindows(h.renderer, cmd.Windows, data)
+	windows, err := renderUserCommandWindows(renderer, cmd.Windows, data)
 	if err != nil {
 		a.Err = fmt.Errorf("template error in windows: %w", err)
 		return a
@@ -217,14 +264,14 @@ func (h *KeybindingResolver) resolveWindowsAction(a Action, cmd config.UserComma
 	// Build new-session request if options.session_name is set.
 	var newSess *action.NewSessionRequest
 	if cmd.Options.SessionName != "" {
-		sessionName, err := h.renderer.Render(cmd.Options.SessionName, data)
+		sessionName, err := renderer.Render(cmd.Options.SessionName, data)
 		if err != nil {
 			a.Err = fmt.Errorf("template error in options.session_name: %w", err)
 			return a
 		}
 		remote := sess.Remote
 		if cmd.Options.Remote != "" {
-			rendered, err := h.renderer.Render(cmd.Options.Remote, data)
+			rendered, err := renderer.Render(cmd.Options.Remote, data)
 			if err != nil {
 				a.Err = fmt.Errorf("template error in options.remote: %w", err)
 				return a
@@ -237,7 +284,7 @@ func (h *KeybindingResolver) resolveWindowsAction(a Action, cmd config.UserComma
 	// Render sh: and route it to the right location.
 	var shCmd, shDir string
 	if cmd.Sh != "" {
-		rendered, err := h.renderer.Render(cmd.Sh, data)
+		rendered, err := renderer.Render(cmd.Sh, data)
 		if err != nil {
 			a.Err = fmt.Errorf("template error in sh: %w", err)
 			return a
@@ -286,8 +333,8 @@ func (h *KeybindingResolver) Resolve(key string, sess session.Session) (Action,
 		return Action{}, false
 	}
 
-	// Recycled sessions only allow delete - prevent accidental operations
-	if sess.State == session.StateRecycled && cmd.Action != action.TypeDelete {
+	// Recycled and archived sessions only allow delete - prevent accidental operations
+	if (sess.State == session.StateRecycled || sess.State == session.StateArchived) && cmd.Action != action.TypeDelete {
 		return Action{}, false
 	}
 
@@ -300,6 +347,7 @@ func (h *KeybindingResolver) Resolve(key string, sess session.Session) (Action,
 		SessionName:   sess.Name,
 		SessionPath:   sess.Path,
 		SessionRemote: sess.Remote,
+		SessionAgent:  sess.GetMeta(session.MetaAgentProfile),
 		Silent:        cmd.Silent,
 		Exit:          cmd.ShouldExit(),
 	}
@@ -337,6 +385,8 @@ func (h *KeybindingResolver) Resolve(key string, sess session.Session) (Action,
 			"Remote":     sess.Remote,
 			"ID":         sess.ID,
 			"Name":       sess.Name,
+			"Tags":       sess.Tags,
+			"Notes":      sess.Notes,
 			"Tool":       h.toolForSession(sess.ID),
 			"TmuxWindow": h.consumeWindowOverride(sess.ID),
 		}
@@ -345,7 +395,7 @@ func (h *KeybindingResolver) Resolve(key string, sess session.Session) (Action,
 			return h.resolveWindowsAction(a, cmd, sess, data), true
 		}
 
-		rendered, err := h.renderer.Render(cmd.Sh, data)
+		rendered, err := h.rendererFor(sess.ID).Render(cmd.Sh, data)
 		if err != nil {
 			// Surface template error instead of masking it
 			a.Type = action.TypeShell
@@ -494,6 +544,7 @@ func (h *KeybindingResolver) ResolveUserCommand(name string, cmd config.UserComm
 		SessionName:   sess.Name,
 		SessionPath:   sess.Path,
 		SessionRemote: sess.Remote,
+		SessionAgent:  sess.GetMeta(session.MetaAgentProfile),
 		Silent:        cmd.Silent,
 		Exit:          cmd.ShouldExit(),
 	}
@@ -520,6 +571,8 @@ func (h *KeybindingResolver) ResolveUserCommand(name string, cmd config.UserComm
 		"Remote":     sess.Remote,
 		"ID":         sess.ID,
 		"Name":       sess.Name,
+		"Tags":       sess.Tags,
+		"Notes":      sess.Notes,
 		"Tool":       h.toolForSession(sess.ID),
 		"TmuxWindow": h.consumeWindowOverride(sess.ID),
 		"Args":       args,
@@ -530,7 +583,7 @@ func (h *KeybindingResolver) ResolveUserCommand(name string, cmd config.UserComm
 		return h.resolveWindowsAction(a, cmd, sess, data)
 	}
 
-	rendered, err := h.renderer.Render(cmd.Sh, data)
+	rendered, err := h.rendererFor(sess.ID).Render(cmd.Sh, data)
 	if err != nil {
 		a.Type = action.TypeShell
 		a.Err = fmt.Errorf("template error in command %q: %w", name, err)
@@ -564,6 +617,7 @@ func (h *KeybindingResolver) RenderWithFormData(
 		SessionName:   sess.Name,
 		SessionPath:   sess.Path,
 		SessionRemote: sess.Remote,
+		SessionAgent:  sess.GetMeta(session.MetaAgentProfile),
 		Silent:        cmd.Silent,
 		Exit:          cmd.ShouldExit(),
 	}
@@ -573,6 +627,8 @@ func (h *KeybindingResolver) RenderWithFormData(
 		"Remote":     sess.Remote,
 		"ID":         sess.ID,
 		"Name":       sess.Name,
+		"Tags":       sess.Tags,
+		"Notes":      sess.Notes,
 		"Tool":       h.toolForSession(sess.ID),
 		"TmuxWindow": h.consumeWindowOverride(sess.ID),
 		"Args":       args,
@@ -584,7 +640,7 @@ func (h *KeybindingResolver) RenderWithFormData(
 		return h.resolveWindowsAction(a, cmd, sess, data)
 	}
 
-	rendered, err := h.renderer.Render(cmd.Sh, data)
+	rendered, err := h.rendererFor(sess.ID).Render(cmd.Sh, data)
 	if err != nil {
 		a.Type = action.TypeShell
 		a.Err = fmt.Errorf("template error in command %q: %w", name, err)
@@ -614,7 +670,7 @@ func (h *KeybindingResolver) ResolveFormCommand(key string, sess session.Session
 		return "", config.UserCommand{}, false
 	}
 
-	if sess.State == session.StateRecycled {
+	if sess.State == session.StateRecycled || sess.State == session.StateArchived {
 		return "", config.UserCommand{}, false
 	}
 
@@ -52,6 +52,12 @@ func TestKeybindingHandler_Resolve_RecycledSession(t *testing.T) {
 		State: session.StateRecycled,
 	}
 
+	archivedSession := session.Session{
+		ID:    "test-id",
+		Path:  "/test/path",
+		State: session.StateArchived,
+	}
+
 	tests := []struct {
 		name    string
 		key     string
@@ -99,6 +105,19 @@ func TestKeybindingHandler_Resolve_RecycledSession(t *testing.T) {
 			sess:   recycledSession,
 			wantOK: false,
 		},
+		{
+			name:    "archived session allows delete",
+			key:     "d",
+			sess:    archivedSession,
+			wantOK:  true,
+			wantTyp: act.TypeDelete,
+		},
+		{
+			name:   "archived session blocks shell command",
+			key:    "o",
+			sess:   archivedSession,
+			wantOK: false,
+		},
 		{
 			name:   "unknown key returns false",
 			key:    "x",
@@ -433,6 +452,31 @@ func TestKeybindingResolver_TmuxWindowAndTool(t *testing.T) {
 	})
 }
 
+func TestKeybindingResolver_SessionKV(t *testing.T) {
+	commands := map[string]config.UserCommand{
+		"deploy": {Sh: `deploy --since {{ kvGet "last_sha" }}`, Help: "deploy"},
+	}
+	keybindings := map[string]config.Keybinding{
+		"D": {Cmd: "deploy"},
+	}
+	sess := session.Session{ID: "test-id", Path: "/test/path", State: session.StateActive}
+
+	handler := NewKeybindingResolver(sessionsKBs(keybindings), commandSetFromMap(commands), testRenderer)
+	action, ok := handler.Resolve("D", sess)
+	require.True(t, ok)
+	assert.Equal(t, "deploy --since ", action.ShellCmd, "kvGet is empty without a lookup")
+
+	handler.SetSessionKVLookup(func(id, key string) (string, error) {
+		return id + "/" + key, nil
+	})
+	action, ok = handler.Resolve("D", sess)
+	require.True(t, ok)
+	assert.Equal(t, "deploy --since test-id/last_sha", action.ShellCmd)
+
+	action = handler.ResolveUserCommand("deploy", commands["deploy"], sess, nil, nil)
+	assert.Equal(t, "deploy --since test-id/last_sha", action.ShellCmd)
+}
+
 func TestKeybindingResolver_TmuxActionConsumesWindowOverride(t *testing.T) {
 	commands := map[string]config.UserCommand{
 		"TmuxOpen":  {Action: act.TypeTmuxOpen, Help: "open"},
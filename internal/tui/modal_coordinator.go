@@ -4,7 +4,9 @@ import (
 	"context"
 
 	"charm.land/bubbles/v2/spinner"
+	"charm.land/bubbles/v2/textarea"
 	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/colonyops/hive/internal/core/config"
@@ -33,10 +35,13 @@ type ModalCoordinator struct {
 	SourcePicker    *sourcepicker.Picker
 	DocsRepoEntries []docsRepoEntry
 	TodoPanel       *TodoPanel
+	KeyRecorder     *KeyRecorder
 	RenameInput     textinput.Model
 	RenameSessionID string
 	GroupInput      textinput.Model
 	GroupSessionID  string
+	NotesInput      textarea.Model
+	NotesSessionID  string
 
 	// Pending action state
 	Pending                 Action
@@ -51,6 +56,7 @@ type ModalCoordinator struct {
 	StreamDone   <-chan error
 	StreamCancel context.CancelFunc
 	StreamResult streamResult // session metadata from create operations
+	StreamRetry  tea.Cmd      // restarts the last failed operation, if retryable
 
 	// Background streaming (dismissed but still running)
 	BgStreamOutput <-chan string
@@ -165,6 +171,21 @@ func (mc *ModalCoordinator) Overlay(state UIState, bg string, s spinner.Model, l
 		)
 		return centeredOverlay(bg, styles.ModalStyle.Width(50).Render(groupContent), w, h)
 
+	case state == stateEditingNotes:
+		notesContent := lipgloss.JoinVertical(
+			lipgloss.Left,
+			styles.ModalTitleStyle.Render("Session Notes"),
+			"",
+			mc.NotesInput.View(),
+			"",
+			styles.ModalHelpStyle.Render(components.KeyHints(
+				components.HelpEntry{Key: "ctrl+s", Desc: "save"},
+				components.HelpEntry{Key: "esc", Desc: "cancel"},
+				components.HelpEntry{Key: "empty", Desc: "clear notes"},
+			)),
+		)
+		return centeredOverlay(bg, styles.ModalStyle.Width(62).Render(notesContent), w, h)
+
 	case state == stateShowingTodos && mc.TodoPanel != nil:
 		return mc.TodoPanel.Overlay(bg, w, h)
 
@@ -174,6 +195,9 @@ func (mc *ModalCoordinator) Overlay(state UIState, bg string, s spinner.Model, l
 	case state == stateSourcePicker && mc.SourcePicker != nil:
 		return centeredOverlay(bg, mc.SourcePicker.View(), w, h)
 
+	case state == stateRecordingKey && mc.KeyRecorder != nil:
+		return mc.KeyRecorder.Overlay(bg, w, h)
+
 	default:
 		return bg
 	}
@@ -246,7 +270,7 @@ func (mc *ModalCoordinator) ClearFormState() {
 // HasEditorFocus returns true if a modal with text input is active.
 func (mc *ModalCoordinator) HasEditorFocus(state UIState) bool {
 	switch state { //nolint:exhaustive // only editor-bearing states return true
-	case stateCommandPalette, stateCreatingSession, stateRenaming, stateSettingGroup, stateFormInput, stateSelectingRepo, stateSourcePicker:
+	case stateCommandPalette, stateCreatingSession, stateRenaming, stateSettingGroup, stateEditingNotes, stateFormInput, stateSelectingRepo, stateSourcePicker, stateRecordingKey:
 		return true
 	}
 	return false
@@ -35,14 +35,17 @@ import (
 	"github.com/colonyops/hive/internal/data/stores"
 	"github.com/colonyops/hive/internal/hive"
 	"github.com/colonyops/hive/internal/hive/plugins"
+	"github.com/colonyops/hive/internal/hive/supervisor"
 	"github.com/colonyops/hive/internal/hive/updatecheck"
 	"github.com/colonyops/hive/internal/tui/command"
 	"github.com/colonyops/hive/internal/tui/components"
+	"github.com/colonyops/hive/internal/tui/components/input"
 	"github.com/colonyops/hive/internal/tui/views/messages"
 	"github.com/colonyops/hive/internal/tui/views/review"
 	"github.com/colonyops/hive/internal/tui/views/sessions"
 	"github.com/colonyops/hive/internal/tui/views/tasks"
 
+	"github.com/colonyops/hive/pkg/executil"
 	"github.com/colonyops/hive/pkg/tmpl"
 )
 
@@ -61,10 +64,12 @@ const (
 	stateShowingInfo
 	stateRenaming
 	stateSettingGroup
+	stateEditingNotes
 	stateFormInput
 	stateShowingTodos
 	stateSelectingRepo
 	stateSourcePicker
+	stateRecordingKey
 )
 
 // Key constants for event handling.
@@ -93,6 +98,8 @@ type Deps struct {
 	DoctorService *hive.DoctorService
 	Honeycomb     *hive.HoneycombService
 	Sources       *sources.Registry
+	Workers       *supervisor.Supervisor
+	Exec          executil.Executor // runs local commands such as git for the review view
 }
 
 // Opts holds runtime options that are not service dependencies.
@@ -140,6 +147,11 @@ type Model struct {
 	kvStore corekv.KV
 	kvView  *KVView
 
+	paletteHistory *input.History // command lines run from the command palette
+
+	// Switches to the view saved by the previous invocation; run from Init.
+	restoreCmd tea.Cmd
+
 	tasksView *tasks.View
 
 	notifyStore     notify.Store
@@ -159,10 +171,15 @@ type Model struct {
 	updateInfo    *updatecheck.Result
 	doctorService *hive.DoctorService
 	configPath    string
+	configWatcher *configWatcher // nil when the config file is not watched
+	configIssue   *configIssue   // first problem in the config file on disk, nil when valid
 
 	sourceRegistry     *sources.Registry
 	pendingSourceScope sourcePickerScope
 
+	// Session ID marked by Compare, awaiting a second session to compare with
+	compareMark string
+
 	// Startup warnings to show as toasts after init
 	startupWarnings []string
 
@@ -182,6 +199,10 @@ type actionCompleteMsg struct {
 type streamResult struct {
 	sessionID   *string
 	sessionName *string
+	logID       *string // creation log to point at when the operation fails
+	failureHint string  // shown below the output when the operation fails
+	hintOn      error   // when set, failureHint is shown only for errors wrapping it
+	retry       tea.Cmd // restarts the operation from the output modal after a failure
 }
 
 // streamStartedMsg is sent when a streaming operation (create, recycle) begins.
@@ -229,6 +250,17 @@ type doctorResultsMsg struct {
 	results []doctor.Result
 }
 
+// reconcileResultsMsg carries the results of the startup reconcile pass.
+type reconcileResultsMsg struct {
+	results []doctor.Result
+}
+
+type planResolvedMsg struct {
+	sessionID string
+	path      string
+	err       error
+}
+
 type todoCountUpdatedMsg struct {
 	pendingCount int
 	openCount    int
@@ -254,14 +286,8 @@ func New(deps Deps, opts Opts) Model {
 	cfg := deps.Config
 	service := deps.Service
 
-	viewKBs := map[string]map[string]config.Keybinding{
-		"global":   cfg.Views.Global.Keybindings,
-		"sessions": cfg.Views.Sessions.Keybindings,
-		"tasks":    cfg.Views.Tasks.Keybindings,
-		"review":   cfg.Views.Review.Keybindings,
-	}
-	handler := NewKeybindingResolver(viewKBs, deps.CommandSet, deps.Renderer)
-	cmdService := command.NewService(service, service, service, service, service)
+	handler := NewKeybindingResolver(viewKeybindings(cfg), deps.CommandSet, deps.Renderer)
+	cmdService := command.NewService(service, service, service, service, service, service, service)
 
 	sessionsView := sessions.New(sessions.ViewOpts{
 		Cfg:             cfg,
@@ -273,6 +299,10 @@ func New(deps Deps, opts Opts) Model {
 		Workspaces:      cfg.Workspaces,
 		Renderer:        deps.Renderer,
 		Bus:             deps.Bus,
+		StatusCache:     deps.KVStore,
+		Preferences:     deps.KVStore,
+		Workers:         deps.Workers,
+		Attention:       newAttentionFunc(cfg, deps.MsgStore, stores.NewReviewStore(deps.DB)),
 	})
 
 	// Wire handler lookups through sessions view stores
@@ -288,12 +318,25 @@ func New(deps Deps, opts Opts) Model {
 		}
 		return ""
 	})
+	handler.SetSessionKVLookup(func(sessionID, key string) (string, error) {
+		value, err := service.SessionValue(context.Background(), sessionID, key)
+		if errors.Is(err, hive.ErrNoSessionValue) {
+			return "", nil
+		}
+		return value, err
+	})
 
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = styles.TextPrimaryStyle
 
 	msgView := messages.New(deps.MsgStore, "*", cfg.CopyCommand, cfg.Views.Messages.SplitRatio)
+	msgView.SetInboxTopic(func() string {
+		if sess := sessionsView.SelectedSession(); sess != nil {
+			return sess.InboxTopic()
+		}
+		return ""
+	})
 
 	kvView := NewKVView()
 
@@ -316,12 +359,27 @@ func New(deps Deps, opts Opts) Model {
 	}
 
 	var reviewStore *stores.ReviewStore
+	var annotationStore *stores.AnnotationStore
 	if deps.DB != nil {
 		reviewStore = stores.NewReviewStore(deps.DB)
+		annotationStore = stores.NewAnnotationStore(deps.DB)
 	}
 
+	review.SetRenderer(cfg.Review.Renderer)
 	reviewView := review.New(docs, contextDir, reviewStore, handler, cfg.Views.Review.SplitRatioOrDefault(30))
 	reviewView.SetRepoKey(repoKey)
+	reviewView.SetFeedbackTemplate(cfg.GetFeedbackTemplate(opts.LocalRemote), cfg.Review.ReviewerName())
+	reviewView.SetSaveFeedback(cfg.Review.SaveFeedback)
+	reviewView.SetFinalizeHooks(cfg.Review.FinalizeHooks)
+	reviewView.SetIncludeTiming(cfg.Review.IncludeTiming)
+	if deps.Exec != nil {
+		reviewView.SetGit(cfg.GitPath, deps.Exec)
+	}
+	reviewView.SetHistoryStore(deps.KVStore)
+	reviewView.SetAnnotationStore(annotationStore)
+	if deps.MsgStore != nil {
+		reviewView.SetReviewEvents(deps.MsgStore, deps.KVStore)
+	}
 
 	notifyStore := stores.NewNotifyStore(deps.DB)
 	toastCtrl := NewToastController()
@@ -355,7 +413,7 @@ func New(deps Deps, opts Opts) Model {
 
 	updateChecker := updatecheck.New(deps.KVStore, nil)
 
-	return Model{
+	m := Model{
 		cfg:             cfg,
 		service:         service,
 		cmdService:      cmdService,
@@ -372,6 +430,7 @@ func New(deps Deps, opts Opts) Model {
 		reviewView:      &reviewView,
 		kvStore:         deps.KVStore,
 		kvView:          kvView,
+		paletteHistory:  input.LoadHistory(deps.KVStore, kvPaletteHistoryKey, 0),
 		tasksView:       tasksView,
 		notifyStore:     notifyStore,
 		notifyBuffer:    notifyBuffer,
@@ -388,14 +447,54 @@ func New(deps Deps, opts Opts) Model {
 		startupWarnings: opts.Warnings,
 		sourceRegistry:  deps.Sources,
 	}
+
+	if cfg.TUI.RestoreState {
+		state, ok, err := loadUIState(deps.KVStore)
+		switch {
+		case err != nil:
+			m.startupWarnings = append(m.startupWarnings, err.Error())
+		case ok:
+			m.restoreCmd = m.applyUIState(state)
+		}
+	}
+
+	if opts.ConfigPath != "" {
+		if watcher, err := newConfigWatcher(opts.ConfigPath, cfg.DataDir); err != nil {
+			log.Debug().Err(err).Str("path", opts.ConfigPath).Msg("cannot watch config file")
+		} else {
+			m.configWatcher = watcher
+		}
+	}
+
+	return m
+}
+
+// viewKeybindings returns the configured keybindings of each view the
+// keybinding resolver handles, keyed by scope.
+func viewKeybindings(cfg *config.Config) map[string]map[string]config.Keybinding {
+	return map[string]map[string]config.Keybinding{
+		"global":   cfg.Views.Global.Keybindings,
+		"sessions": cfg.Views.Sessions.Keybindings,
+		"tasks":    cfg.Views.Tasks.Keybindings,
+		"review":   cfg.Views.Review.Keybindings,
+	}
 }
 
 // quit sets the quitting flag and emits tui.stopped.
 func (m Model) quit() (Model, tea.Cmd) {
 	m.quitting = true
+	if m.reviewView != nil {
+		m.reviewView.FlushReviewTime()
+	}
+	if m.cfg.TUI.RestoreState {
+		saveUIState(m.kvStore, m.captureUIState())
+	}
 	if m.modals.BgStreamCancel != nil {
 		m.modals.BgStreamCancel()
 	}
+	if m.configWatcher != nil {
+		_ = m.configWatcher.Close()
+	}
 	if m.bus != nil {
 		m.bus.PublishTuiStopped(eventbus.TUIStoppedPayload{})
 	}
@@ -440,20 +539,32 @@ func (m Model) Init() tea.Cmd {
 			cmds = append(cmds, cmd)
 		}
 	}
-	// Start review view file watcher
+	// Start review view file watcher and vault discovery
 	if m.reviewView != nil {
 		if cmd := m.reviewView.Init(); cmd != nil {
 			cmds = append(cmds, cmd)
 		}
+		if cmd := review.DiscoverVaultDocumentsCmd(m.cfg.Context.Vaults); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 	}
 	if m.cfg.TUI.UpdateChecker && m.updateChecker != nil && m.buildInfo.Version != "" {
 		cmds = append(cmds, checkForUpdate(m.updateChecker, m.buildInfo.Version))
 	}
+	if m.restoreCmd != nil {
+		cmds = append(cmds, m.restoreCmd)
+	}
 	// Load initial todo counts and start polling + event listening
 	cmds = append(cmds, m.loadTodoCounts(), scheduleTodoPollTick())
 	if m.todoCh != nil {
 		cmds = append(cmds, m.listenForTodoCreated())
 	}
+	if m.configWatcher != nil {
+		cmds = append(cmds, m.configWatcher.Next())
+	}
+	if m.cfg.TUI.ReconcileOnStart && m.doctorService != nil {
+		cmds = append(cmds, m.reconcile())
+	}
 	return tea.Batch(cmds...)
 }
 
@@ -575,15 +686,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case docsRepoKeysLoadedMsg:
 		model, cmd = m.handleDocsRepoKeysLoaded(msg)
 
+	case repoCommandsLoadedMsg:
+		model, cmd = m.handleRepoCommandsLoaded(msg)
+
 	// Action results
 	case renameCompleteMsg:
 		model, cmd = m.handleRenameComplete(msg)
 	case setGroupCompleteMsg:
 		model, cmd = m.handleSetGroupComplete(msg)
+	case setNotesCompleteMsg:
+		model, cmd = m.handleSetNotesComplete(msg)
+	case keyBoundMsg:
+		model, cmd = m.handleKeyBound(msg)
 	case actionCompleteMsg:
 		model, cmd = m.handleActionComplete(msg)
 	case doctorResultsMsg:
 		model, cmd = m.handleDoctorResults(msg)
+	case reconcileResultsMsg:
+		model, cmd = m.handleReconcileResults(msg)
+	case planResolvedMsg:
+		model, cmd = m.handlePlanResolved(msg)
+	case compareResultMsg:
+		model, cmd = m.handleCompareResult(msg)
 	case streamStartedMsg:
 		model, cmd = m.handleStreamStarted(msg)
 	case streamOutputMsg:
@@ -602,16 +726,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Review delegation
 	case review.DocumentChangeMsg:
 		model, cmd = m.handleReviewDocChange(msg)
+	case review.CollabTickMsg, review.CollabEventsMsg, review.VaultDocumentsMsg:
+		model, cmd = m.forwardToReview(msg)
 	case review.ReviewFinalizedMsg:
 		model, cmd = m.handleReviewFinalized(msg)
 	case review.OpenDocumentMsg:
 		model, cmd = m.handleReviewOpenDoc(msg)
+	case review.ErrorMsg:
+		m.notifyErrorf("%v", msg.Err)
+		model, cmd = m, nil
 
 	// Notifications
 	case drainNotificationsMsg:
 		model, cmd = m.handleDrainNotifications(msg)
 	case updateAvailableMsg:
 		model, cmd = m.handleUpdateAvailable(msg)
+	case configReloadMsg:
+		model, cmd = m.handleConfigReload(msg)
+	case restoreViewMsg:
+		model, cmd = m.switchToView(msg.view)
 
 	// Todos
 	case todoCountUpdatedMsg:
@@ -713,6 +846,9 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	if m.state == stateSettingGroup {
 		return m.handleGroupKey(msg, keyStr)
 	}
+	if m.state == stateEditingNotes {
+		return m.handleNotesKey(msg, keyStr)
+	}
 	if m.state == stateStreaming {
 		return m.handleStreamingModalKey(keyStr)
 	}
@@ -731,6 +867,9 @@ func (m Model) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	if m.state == stateSourcePicker {
 		return m.handleSourcePickerKey(msg)
 	}
+	if m.state == stateRecordingKey {
+		return m.handleKeyRecorderKey(msg, keyStr)
+	}
 
 	// When filtering in either list, pass most keys except quit. The KV
 	// filter only captures keys while the Store view is active so it cannot
@@ -931,11 +1070,11 @@ func (m Model) dispatchAction(action Action) (Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// For delete and recycle on named sessions, run an async git risk check first.
+	// For delete, recycle and archive on named sessions, run an async git risk check first.
 	// The result handler (handleSessionRiskChecked) continues the dispatch.
 	// A separate 250ms delay command reveals the loading spinner only if the
 	// check hasn't completed yet, avoiding a flash on fast repos.
-	if (action.Type == act.TypeDelete || action.Type == act.TypeRecycle) && action.SessionID != "" {
+	if (action.Type == act.TypeDelete || action.Type == act.TypeArchive || isRecycleAction(action.Type)) && action.SessionID != "" {
 		m.modals.Pending = action
 		sessionID := action.SessionID
 		return m, tea.Batch(
@@ -957,9 +1096,14 @@ func (m Model) dispatchAction(action Action) (Model, tea.Cmd) {
 		return m, nil
 	}
 
-	if action.Type == act.TypeRecycle {
+	if isRecycleAction(action.Type) {
+		m.state = stateNormal
+		return m, m.startRecycle(action)
+	}
+
+	if action.Type == act.TypeSync {
 		m.state = stateNormal
-		return m, m.startRecycle(action.SessionID)
+		return m, m.startSync(action)
 	}
 
 	if action.Exit {
@@ -1125,6 +1269,69 @@ func (m Model) showHiveDoctor() (tea.Model, tea.Cmd) {
 	})
 }
 
+// reconcile returns a command that reports orphaned sessions, directories,
+// and tmux sessions without cleaning them up.
+func (m Model) reconcile() tea.Cmd {
+	return func() tea.Msg {
+		return reconcileResultsMsg{results: m.doctorService.Reconcile(context.Background(), false)}
+	}
+}
+
+// showHiveRules shows which rules match remote and what they resolve to.
+// An empty remote falls back to the repository hive was started in.
+func (m Model) showHiveRules(remote string) (tea.Model, tea.Cmd) {
+	if remote == "" {
+		remote = m.sessionsView.LocalRemote()
+	}
+	if remote == "" {
+		m.notifyErrorf("no remote to test rules against")
+		return m, nil
+	}
+
+	m.modals.ShowInfo("Hive Rules", buildRulesDialogContent(m.cfg.ResolveRules(remote)), remote, components.KeyHints(
+		components.HelpEntry{Key: "j/k", Desc: "scroll"},
+		components.HelpEntry{Key: "esc", Desc: "close"},
+	))
+	m.state = stateShowingInfo
+	return m, nil
+}
+
+func buildRulesDialogContent(r config.ResolvedRules) []components.InfoSection {
+	matches := make([]components.InfoItem, 0, len(r.Matches))
+	for _, match := range r.Matches {
+		matches = append(matches, components.InfoItem{
+			Label: fmt.Sprintf("rules[%d]", match.Index),
+			Value: config.RulePatternLabel(match.Rule.Pattern),
+		})
+	}
+	if len(matches) == 0 {
+		matches = append(matches, components.InfoItem{Label: "none", Value: "defaults apply"})
+	}
+
+	resolved := make([]components.InfoItem, 0, len(config.ResolvedRuleFields))
+	for _, field := range config.ResolvedRuleFields {
+		resolved = append(resolved, components.InfoItem{
+			Label: field,
+			Value: strings.Join(r.Display(field), ", ") + " (" + r.Source(field) + ")",
+		})
+	}
+
+	accumulated := make([]components.InfoItem, 0, 2)
+	for _, field := range []string{"commands", "copy"} {
+		value := strings.Join(r.Display(field), ", ")
+		if value == "" {
+			value = "(none)"
+		}
+		accumulated = append(accumulated, components.InfoItem{Label: field, Value: value})
+	}
+
+	return []components.InfoSection{
+		{Title: "Matching Rules (last match wins)", Items: matches},
+		{Title: "Resolved", Items: resolved},
+		{Title: "From All Matching Rules", Items: accumulated},
+	}
+}
+
 func (m Model) handleDoctorResults(msg doctorResultsMsg) (tea.Model, tea.Cmd) {
 	sections, footer := buildDoctorDialogContent(msg.results)
 	m.modals.ShowInfo("Hive Doctor", sections, footer, components.KeyHints(
@@ -1135,6 +1342,16 @@ func (m Model) handleDoctorResults(msg doctorResultsMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleReconcileResults warns when the startup reconcile pass found
+// anything to clean up.
+func (m Model) handleReconcileResults(msg reconcileResultsMsg) (tea.Model, tea.Cmd) {
+	_, warned, failed := doctor.Summary(msg.results)
+	if warned+failed > 0 {
+		m.publishNotificationf(notify.LevelWarning, "Reconcile found %d issue(s); run 'hive doctor reconcile' to review and --fix to clean up", warned+failed)
+	}
+	return m, nil
+}
+
 func buildDoctorDialogContent(results []doctor.Result) ([]components.InfoSection, string) {
 	sections := make([]components.InfoSection, 0, len(results))
 	for _, result := range results {
@@ -1261,6 +1478,33 @@ func (m Model) handleCommandPaletteKey(msg tea.KeyPressMsg, keyStr string) (tea.
 			return m, cmd
 		}
 
+		// SortCycle doesn't require a session
+		if entry.Command.Action == act.TypeSortCycle {
+			m.state = stateNormal
+			return m, m.sessionsView.CycleSort()
+		}
+
+		// ArchivedToggle doesn't require a session
+		if entry.Command.Action == act.TypeArchivedToggle {
+			m.state = stateNormal
+			return m, m.sessionsView.ToggleArchived()
+		}
+
+		// Compare marks or compares the selected session
+		if entry.Command.Action == act.TypeCompare {
+			m.state = stateNormal
+			return m.startCompare(selected, args)
+		}
+
+		// EditNotes requires a selected session
+		if entry.Command.Action == act.TypeEditNotes {
+			m.state = stateNormal
+			if selected == nil {
+				return m, nil
+			}
+			return m.openNotesInput(selected)
+		}
+
 		// GroupSet requires a selected session
 		if entry.Command.Action == act.TypeGroupSet {
 			m.state = stateNormal
@@ -1284,11 +1528,28 @@ func (m Model) handleCommandPaletteKey(msg tea.KeyPressMsg, keyStr string) (tea.
 			return m.showHiveInfo()
 		}
 
+		if entry.Command.Action == act.TypeBindKey {
+			return m.openKeyRecorder()
+		}
+
 		if entry.Command.Action == act.TypeHiveDoctor {
 			m.state = stateNormal
 			return m.showHiveDoctor()
 		}
 
+		// HiveRules takes an optional remote, else the selected session's
+		if entry.Command.Action == act.TypeHiveRules {
+			m.state = stateNormal
+			remote := ""
+			switch {
+			case len(args) > 0:
+				remote = args[0]
+			case selected != nil:
+				remote = selected.Remote
+			}
+			return m.showHiveRules(remote)
+		}
+
 		// Task actions don't require a session
 		if isTaskAction(entry.Command.Action) {
 			m.state = stateNormal
@@ -1312,6 +1573,11 @@ func (m Model) handleCommandPaletteKey(msg tea.KeyPressMsg, keyStr string) (tea.
 			return m, nil
 		}
 
+		if entry.Command.Action == act.TypeFilterTag {
+			m.state = stateNormal
+			return m, m.applyTagFilter(args)
+		}
+
 		// Form commands don't require a selected session (they collect their own input)
 		if len(entry.Command.Form) > 0 {
 			m.state = stateNormal
@@ -1365,7 +1631,8 @@ func isTaskAction(t act.Type) bool {
 
 func isDocsAction(t act.Type) bool {
 	switch t { //nolint:exhaustive // only matching docs-specific actions
-	case act.TypeDocsCopyPath, act.TypeDocsCopyRelPath, act.TypeDocsCopyContents, act.TypeDocsOpen, act.TypeDocsTogglePreview, act.TypeDocsSelectRepo:
+	case act.TypeDocsCopyPath, act.TypeDocsCopyRelPath, act.TypeDocsCopyContents, act.TypeDocsOpen, act.TypeDocsTogglePreview, act.TypeDocsSelectRepo, act.TypeDocsAddToReview, act.TypeDocsToggleInstant, act.TypeDocsToggleComments, act.TypeDocsOpenRevision, act.TypeDocsCommentEdits, act.TypeDocsToggleAnnotations,
+		act.TypeDocsNextComment, act.TypeDocsPrevComment:
 		return true
 	}
 	return false
@@ -1467,6 +1734,13 @@ func (m Model) handleNormalKey(msg tea.KeyPressMsg, keyStr string) (tea.Model, t
 		}
 	}
 
+	// The review comments panel uses tab to switch focus with the document
+	if keyStr == "tab" && m.isReviewFocused() && m.reviewView != nil && m.reviewView.CommentsPanelOpen() {
+		var cmd tea.Cmd
+		*m.reviewView, cmd = m.reviewView.Update(msg)
+		return m, cmd
+	}
+
 	// Messages preview pane intercepts keys before global handlers
 	if m.isMessagesFocused() && m.msgView != nil && m.msgView.HasPreviewFocus() {
 		if keyStr == keyCtrlC {
@@ -1573,9 +1847,9 @@ func (m Model) handleNormalKey(msg tea.KeyPressMsg, keyStr string) (tea.Model, t
 
 // handleTabKey handles tab/shift+tab for switching views.
 // direction: +1 for next tab, -1 for previous tab.
-// Cycle: Sessions -> [Tasks if visible] -> Messages -> [Store if visible] -> Docs -> Sessions
+// Cycle: Sessions -> Board -> [Tasks if visible] -> Docs -> Messages -> [Store if visible] -> Sessions
 func (m Model) handleTabKey(direction int) (tea.Model, tea.Cmd) {
-	tabs := []ViewType{ViewSessions}
+	tabs := []ViewType{ViewSessions, ViewBoard}
 	if m.tasksView != nil {
 		tabs = append(tabs, ViewTasks)
 	}
@@ -1611,18 +1885,17 @@ type setGroupCompleteMsg struct {
 
 // openRenameInput initializes the rename text input with the current session name.
 func (m Model) openRenameInput(sess *session.Session) (tea.Model, tea.Cmd) {
-	input := textinput.New()
-	input.SetValue(sess.Name)
-	input.Focus()
-	input.CharLimit = 64
-	input.Prompt = ""
-	input.SetWidth(40)
-	input.KeyMap.Paste.SetEnabled(true)
+	ti := input.NewTextInput()
+	ti.SetValue(sess.Name)
+	ti.Focus()
+	ti.CharLimit = 64
+	ti.Prompt = ""
+	ti.SetWidth(40)
 	inputStyles := textinput.DefaultStyles(true)
 	inputStyles.Cursor.Color = styles.ColorPrimary
-	input.SetStyles(inputStyles)
+	ti.SetStyles(inputStyles)
 
-	m.modals.RenameInput = input
+	m.modals.RenameInput = ti
 	m.modals.RenameSessionID = sess.ID
 	m.state = stateRenaming
 	return m, nil
@@ -1659,55 +1932,23 @@ func (m Model) handleRenameKey(msg tea.KeyPressMsg, keyStr string) (tea.Model, t
 // executeRename returns a command that renames a session and its tmux session.
 func (m Model) executeRename(sessionID, newName string) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
-
-		// Look up old session to find current tmux session name
-		oldSess, err := m.service.GetSession(ctx, sessionID)
-		if err != nil {
-			return renameCompleteMsg{err: err}
-		}
-
-		oldTmuxName := oldSess.GetMeta(session.MetaTmuxSession)
-		if oldTmuxName == "" {
-			oldTmuxName = oldSess.Slug
-		}
-
-		// Rename in hive store
-		if err := m.service.RenameSession(ctx, sessionID, newName); err != nil {
-			return renameCompleteMsg{err: err}
-		}
-
-		// Rename tmux session (best-effort; session may not have a tmux session)
-		newSlug := session.Slugify(newName)
-		if oldTmuxName != "" && newSlug != "" && oldTmuxName != newSlug {
-			//nolint:gosec // arguments are slugified, not user-controlled shell input
-			tmuxCmd := exec.Command("tmux", "rename-session", "-t", oldTmuxName, newSlug)
-			if tmuxErr := tmuxCmd.Run(); tmuxErr != nil {
-				log.Debug().Err(tmuxErr).
-					Str("old", oldTmuxName).
-					Str("new", newSlug).
-					Msg("tmux rename-session failed (session may not exist)")
-			}
-		}
-
-		return renameCompleteMsg{err: nil}
+		return renameCompleteMsg{err: m.service.RenameSession(context.Background(), sessionID, newName)}
 	}
 }
 
 // openGroupInput initializes the group text input with the current session group.
 func (m Model) openGroupInput(sess *session.Session) (tea.Model, tea.Cmd) {
-	input := textinput.New()
-	input.SetValue(sess.Group())
-	input.Focus()
-	input.CharLimit = 64
-	input.Prompt = ""
-	input.SetWidth(40)
-	input.KeyMap.Paste.SetEnabled(true)
+	ti := input.NewTextInput()
+	ti.SetValue(sess.Group())
+	ti.Focus()
+	ti.CharLimit = 64
+	ti.Prompt = ""
+	ti.SetWidth(40)
 	inputStyles := textinput.DefaultStyles(true)
 	inputStyles.Cursor.Color = styles.ColorPrimary
-	input.SetStyles(inputStyles)
+	ti.SetStyles(inputStyles)
 
-	m.modals.GroupInput = input
+	m.modals.GroupInput = ti
 	m.modals.GroupSessionID = sess.ID
 	m.state = stateSettingGroup
 	return m, nil
@@ -1788,6 +2029,13 @@ func (m Model) openNewSessionForm() (tea.Model, tea.Cmd) {
 
 	newSessionForm := NewNewSessionForm(m.sessionsView.DiscoveredRepos(), preselectedRemote, existingNames, agentKeys)
 	newSessionForm.selectAgent(defaultAgent)
+	newSessionForm.agentFor = func(remote string) string {
+		if agent := config.ResolveSpawn(m.cfg.Rules, remote, false).Agent; agent != "" {
+			return agent
+		}
+		return defaultAgent
+	}
+	newSessionForm.followRepoAgent()
 	m.modals.NewSession = newSessionForm
 	m.state = stateCreatingSession
 	return m, m.modals.NewSession.Init()
@@ -1823,14 +2071,18 @@ func (m Model) syncTasksRepoFromSessions() tea.Cmd {
 	}
 
 	var remote string
+	var target review.InstantTarget
 	switch {
 	case ti.IsHeader:
 		remote = ti.RepoRemote
 	case ti.IsWindowItem:
 		remote = ti.ParentSession.Remote
+		target = review.InstantTarget{ID: ti.ParentSession.ID, Name: ti.ParentSession.Name, Topic: ti.ParentSession.InboxTopic()}
 	case !ti.IsRecycledPlaceholder:
 		remote = ti.Session.Remote
+		target = review.InstantTarget{ID: ti.Session.ID, Name: ti.Session.Name, Topic: ti.Session.InboxTopic()}
 	}
+	m.reviewView.SetInstantTarget(target)
 
 	if remote == "" {
 		return nil
@@ -1862,14 +2114,18 @@ func (m Model) syncDocsRepoFromSessions() tea.Cmd {
 	}
 
 	var remote string
+	var target review.InstantTarget
 	switch {
 	case ti.IsHeader:
 		remote = ti.RepoRemote
 	case ti.IsWindowItem:
 		remote = ti.ParentSession.Remote
+		target = review.InstantTarget{ID: ti.ParentSession.ID, Name: ti.ParentSession.Name, Topic: ti.ParentSession.InboxTopic()}
 	case !ti.IsRecycledPlaceholder:
 		remote = ti.Session.Remote
+		target = review.InstantTarget{ID: ti.Session.ID, Name: ti.Session.Name, Topic: ti.Session.InboxTopic()}
 	}
+	m.reviewView.SetInstantTarget(target)
 
 	if remote == "" {
 		return nil
@@ -1886,6 +2142,7 @@ func (m Model) syncDocsRepoFromSessions() tea.Cmd {
 	}
 
 	m.reviewView.SetRepoKey(owner + "/" + repo)
+	m.reviewView.SetFeedbackTemplate(m.cfg.GetFeedbackTemplate(remote), m.cfg.Review.ReviewerName())
 	return m.reviewView.SetContextDir(contextDir)
 }
 
@@ -1934,6 +2191,9 @@ func (m Model) delegateToComponent(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	case stateSettingGroup:
 		m.modals.GroupInput, cmd = m.modals.GroupInput.Update(msg)
 		return m, cmd
+	case stateEditingNotes:
+		m.modals.NotesInput, cmd = m.modals.NotesInput.Update(msg)
+		return m, cmd
 	case stateFormInput:
 		if m.modals.FormDialog != nil {
 			m.modals.FormDialog, cmd = m.modals.FormDialog.Update(msg)
@@ -1946,7 +2206,7 @@ func (m Model) delegateToComponent(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 
 	// Route to active view
 	switch m.activeView {
-	case ViewSessions:
+	case ViewSessions, ViewBoard:
 		cmd = m.sessionsView.Update(msg)
 		return m, cmd
 	case ViewTasks:
@@ -1969,9 +2229,10 @@ func (m Model) delegateToComponent(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-// isSessionsFocused returns true if the sessions view is active.
+// isSessionsFocused returns true if the sessions view is active, either as
+// the tree or as the status board.
 func (m Model) isSessionsFocused() bool {
-	return m.activeView == ViewSessions
+	return m.activeView == ViewSessions || m.activeView == ViewBoard
 }
 
 // isMessagesFocused returns true if the messages view is active.
@@ -1998,11 +2259,12 @@ func (m Model) isModalActive() bool {
 }
 
 // startRecycle returns a command that starts the recycle operation with streaming output.
-func (m Model) startRecycle(sessionID string) tea.Cmd {
+// RecycleReset actions discard local changes before the recycle commands run.
+func (m Model) startRecycle(a Action) tea.Cmd {
 	return func() tea.Msg {
 		exec, err := m.cmdService.CreateExecutor(Action{
-			Type:      act.TypeRecycle,
-			SessionID: sessionID,
+			Type:      a.Type,
+			SessionID: a.SessionID,
 		})
 		if err != nil {
 			return streamCompleteMsg{err: err}
@@ -2014,10 +2276,59 @@ func (m Model) startRecycle(sessionID string) tea.Cmd {
 			output: output,
 			done:   done,
 			cancel: cancel,
+			result: streamResult{failureHint: recycleFailureHint, hintOn: hive.ErrRecycleFailed},
+		}
+	}
+}
+
+// recycleFailureHint lists the recovery commands for a session whose recycle
+// commands failed.
+const recycleFailureHint = `The session is marked as needing attention. From the command palette:
+  Recycle       retry after fixing the working tree
+  RecycleShell  open a shell window in the session
+  RecycleReset  discard local changes and recycle`
+
+// startSync returns a command that syncs a session with streaming output.
+func (m Model) startSync(a Action) tea.Cmd {
+	return func() tea.Msg {
+		exec, err := m.cmdService.CreateExecutor(Action{
+			Type:      a.Type,
+			SessionID: a.SessionID,
+		})
+		if err != nil {
+			return streamCompleteMsg{err: err}
+		}
+
+		output, done, cancel := exec.Execute(context.Background())
+		return streamStartedMsg{
+			title:  "Syncing session...",
+			output: output,
+			done:   done,
+			cancel: cancel,
+			result: streamResult{failureHint: syncFailureHint},
 		}
 	}
 }
 
+// syncFailureHint explains the state a failed sync leaves a session in.
+const syncFailureHint = `The session is marked with a sync conflict and its working tree is left
+mid-rebase. Ask the agent to resolve it, or resolve it yourself in the
+session, then run Sync again.`
+
+// applyTagFilter narrows the sessions view to the tag in args, or opens the
+// focus filter pre-filled with "tag:" when no tag is given.
+func (m Model) applyTagFilter(args []string) tea.Cmd {
+	if len(args) == 0 {
+		return m.sessionsView.StartTagFilter()
+	}
+	return m.sessionsView.SetTagFilter(args[0])
+}
+
+// isRecycleAction reports whether t recycles the selected session.
+func isRecycleAction(t act.Type) bool {
+	return t == act.TypeRecycle || t == act.TypeRecycleReset
+}
+
 // startCreate returns a command that starts session creation with streaming output.
 func (m Model) startCreate(name, remote, agentKey string) tea.Cmd {
 	return func() tea.Msg {
@@ -2038,6 +2349,8 @@ func (m Model) startCreate(name, remote, agentKey string) tea.Cmd {
 			result: streamResult{
 				sessionID:   &exec.ResultSessionID,
 				sessionName: &exec.ResultSessionName,
+				logID:       &exec.LogID,
+				retry:       m.startCreate(name, remote, agentKey),
 			},
 		}
 	}
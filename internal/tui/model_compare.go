@@ -0,0 +1,171 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/notify"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/internal/tui/components"
+)
+
+// compareResultMsg carries the result of comparing two sessions.
+type compareResultMsg struct {
+	cmp hive.SessionComparison
+	err error
+}
+
+// startCompare compares selected with the session named in args, or with the
+// session marked by a previous Compare. Without either, it marks selected.
+func (m Model) startCompare(selected *session.Session, args []string) (tea.Model, tea.Cmd) {
+	if selected == nil {
+		return m, nil
+	}
+
+	if len(args) > 0 {
+		other, ok := m.findSession(args[0])
+		if !ok {
+			return m, m.notifyError("session %q not found", args[0])
+		}
+		return m.compareSessions(selected.ID, other.ID)
+	}
+
+	if m.compareMark == "" || m.compareMark == selected.ID {
+		m.compareMark = selected.ID
+		m.publishNotificationf(notify.LevelInfo, "Marked %s, select another session and run Compare again", selected.Name)
+		return m, nil
+	}
+
+	marked := m.compareMark
+	m.compareMark = ""
+	return m.compareSessions(marked, selected.ID)
+}
+
+// findSession looks up a loaded session by ID or name.
+func (m Model) findSession(ref string) (session.Session, bool) {
+	all := m.sessionsView.AllSessions()
+	if i := slices.IndexFunc(all, func(s session.Session) bool { return s.ID == ref }); i >= 0 {
+		return all[i], true
+	}
+	if i := slices.IndexFunc(all, func(s session.Session) bool { return s.Name == ref }); i >= 0 {
+		return all[i], true
+	}
+	return session.Session{}, false
+}
+
+func (m Model) compareSessions(idA, idB string) (tea.Model, tea.Cmd) {
+	m.state = stateLoading
+	m.loadingMessage = "Comparing sessions..."
+
+	return m, tea.Batch(m.spinner.Tick, func() tea.Msg {
+		cmp, err := m.service.CompareSessions(context.Background(), idA, idB)
+		return compareResultMsg{cmp: cmp, err: err}
+	})
+}
+
+func (m Model) handleCompareResult(msg compareResultMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.state = stateNormal
+		return m, m.notifyError("compare failed: %v", msg.err)
+	}
+
+	m.modals.ShowInfo("Compare Sessions", m.buildCompareSections(msg.cmp), "", components.KeyHints(
+		components.HelpEntry{Key: "j/k", Desc: "scroll"},
+		components.HelpEntry{Key: "esc", Desc: "close"},
+	))
+	m.state = stateShowingInfo
+	return m, nil
+}
+
+// buildCompareSections lays out a comparison as info dialog sections, with
+// per-session values side by side as "A │ B".
+func (m Model) buildCompareSections(cmp hive.SessionComparison) []components.InfoSection {
+	type row struct{ label, a, b string }
+	rows := []row{
+		{"Session", cmp.A.Session.Name, cmp.B.Session.Name},
+		{"Branch", cmp.A.Branch, cmp.B.Branch},
+		{"Changes", compareChanges(cmp.A), compareChanges(cmp.B)},
+		{"Commits", fmt.Sprintf("%d only here", len(cmp.Divergence.Ahead)), fmt.Sprintf("%d only here", len(cmp.Divergence.Behind))},
+	}
+
+	pluginStatuses := m.sessionsView.PluginStatuses()
+	names := make([]string, 0, len(pluginStatuses))
+	for name := range pluginStatuses {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		store := pluginStatuses[name]
+		a, okA := store.Get(cmp.A.Session.ID)
+		b, okB := store.Get(cmp.B.Session.ID)
+		if !okA && !okB {
+			continue
+		}
+		rows = append(rows, row{name, strings.TrimSpace(a.Icon + " " + a.Label), strings.TrimSpace(b.Icon + " " + b.Label)})
+	}
+
+	width := 0
+	for _, r := range rows {
+		width = max(width, lipgloss.Width(r.a))
+	}
+	items := make([]components.InfoItem, 0, len(rows))
+	for _, r := range rows {
+		pad := strings.Repeat(" ", width-lipgloss.Width(r.a))
+		items = append(items, components.InfoItem{Label: r.label, Value: r.a + pad + " │ " + r.b})
+	}
+
+	sections := []components.InfoSection{{Title: "A │ B", Items: items}}
+	for _, side := range []struct {
+		title   string
+		commits []git.Commit
+	}{{"Only in A", cmp.Divergence.Ahead}, {"Only in B", cmp.Divergence.Behind}} {
+		if len(side.commits) == 0 {
+			continue
+		}
+		commitItems := make([]components.InfoItem, 0, len(side.commits))
+		for _, c := range side.commits {
+			commitItems = append(commitItems, components.InfoItem{Label: c.Hash, Value: c.Subject})
+		}
+		sections = append(sections, components.InfoSection{
+			Title: fmt.Sprintf("%s (%d)", side.title, len(side.commits)),
+			Items: commitItems,
+		})
+	}
+
+	var errItems []components.InfoItem
+	for _, side := range []struct {
+		label string
+		errs  []string
+	}{{"A", cmp.A.Errors}, {"B", cmp.B.Errors}} {
+		for _, e := range side.errs {
+			errItems = append(errItems, components.InfoItem{Label: side.label, Value: e})
+		}
+	}
+	if len(errItems) > 0 {
+		sections = append(sections, components.InfoSection{Title: "Errors", Items: errItems})
+	}
+
+	sections = append(sections, components.InfoSection{
+		Title: "Between HEADs",
+		Items: []components.InfoItem{{
+			Label: "Diff",
+			Value: fmt.Sprintf("+%d -%d", cmp.Divergence.Additions, cmp.Divergence.Deletions),
+		}},
+	})
+	return sections
+}
+
+func compareChanges(side hive.CompareSide) string {
+	changes := fmt.Sprintf("+%d -%d", side.Additions, side.Deletions)
+	if side.Uncommitted {
+		changes += " *"
+	}
+	return changes
+}
@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/session"
+)
+
+func TestStartCompareMarksThenCompares(t *testing.T) {
+	m := newKeybindingPrecedenceModel(t, nil)
+	a := &session.Session{ID: "a", Name: "alpha"}
+	b := &session.Session{ID: "b", Name: "beta"}
+
+	model, cmd := m.startCompare(a, nil)
+	m = model.(Model)
+	assert.Nil(t, cmd)
+	assert.Equal(t, "a", m.compareMark)
+
+	model, cmd = m.startCompare(a, nil)
+	m = model.(Model)
+	assert.Nil(t, cmd, "re-marking the same session should not compare it with itself")
+	assert.Equal(t, "a", m.compareMark)
+
+	model, cmd = m.startCompare(b, nil)
+	m = model.(Model)
+	require.NotNil(t, cmd)
+	assert.Empty(t, m.compareMark)
+	assert.Equal(t, stateLoading, m.state)
+}
+
+func TestStartCompareUnknownTarget(t *testing.T) {
+	m := newKeybindingPrecedenceModel(t, nil)
+
+	model, cmd := m.startCompare(&session.Session{ID: "a"}, []string{"missing"})
+	m = model.(Model)
+	assert.Nil(t, cmd)
+	assert.Equal(t, stateNormal, m.state)
+}
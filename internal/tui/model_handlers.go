@@ -2,7 +2,9 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -16,6 +18,7 @@ import (
 
 	act "github.com/colonyops/hive/internal/core/action"
 	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/eventbus"
 	"github.com/colonyops/hive/internal/core/git"
 	"github.com/colonyops/hive/internal/core/hc"
 	"github.com/colonyops/hive/internal/core/notify"
@@ -28,6 +31,7 @@ import (
 	"github.com/colonyops/hive/internal/tui/views/review"
 	"github.com/colonyops/hive/internal/tui/views/sessions"
 	"github.com/colonyops/hive/internal/tui/views/tasks"
+	"github.com/colonyops/hive/pkg/executil"
 	"github.com/colonyops/hive/pkg/tmpl"
 )
 
@@ -37,40 +41,51 @@ func (m Model) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	m.width = msg.Width
 	m.height = msg.Height
 
-	contentHeight := msg.Height - 3
-	if contentHeight < 1 {
-		contentHeight = 1
+	m.modals.SetSize(msg.Width, msg.Height)
+	m.resizeViews()
+
+	// Publish startup warnings on the first WindowSizeMsg
+	if len(m.startupWarnings) > 0 {
+		for _, w := range m.startupWarnings {
+			m.publishNotificationf(notify.LevelWarning, "%s", w)
+		}
+		m.startupWarnings = nil
+		return m, nil
 	}
+	return m, nil
+}
 
-	m.modals.SetSize(msg.Width, msg.Height)
+// chromeHeight returns the rows drawn around the active view: the top
+// divider, the header and its divider, and the config banner while shown.
+func (m Model) chromeHeight() int {
+	if m.configIssue != nil {
+		return 4
+	}
+	return 3
+}
+
+// resizeViews sizes the views to the space left by the chrome.
+func (m Model) resizeViews() {
+	contentHeight := max(m.height-m.chromeHeight(), 1)
 
 	if m.sessionsView != nil {
-		m.sessionsView.SetSize(msg.Width, msg.Height)
+		// The sessions view subtracts the header rows itself
+		m.sessionsView.SetSize(m.width, m.height-(m.chromeHeight()-3))
 	}
 
 	if m.msgView != nil {
-		m.msgView.SetSize(msg.Width, contentHeight)
+		m.msgView.SetSize(m.width, contentHeight)
 	}
 
 	if m.reviewView != nil {
-		m.reviewView.SetSize(msg.Width, contentHeight)
+		m.reviewView.SetSize(m.width, contentHeight)
 	}
 
-	m.kvView.SetSize(msg.Width, contentHeight)
+	m.kvView.SetSize(m.width, contentHeight)
 
 	if m.tasksView != nil {
-		m.tasksView.SetSize(msg.Width, contentHeight)
+		m.tasksView.SetSize(m.width, contentHeight)
 	}
-
-	// Publish startup warnings on the first WindowSizeMsg
-	if len(m.startupWarnings) > 0 {
-		for _, w := range m.startupWarnings {
-			m.publishNotificationf(notify.LevelWarning, "%s", w)
-		}
-		m.startupWarnings = nil
-		return m, nil
-	}
-	return m, nil
 }
 
 // --- KV data loaded ---
@@ -156,13 +171,35 @@ func (m Model) handleSessionAction(msg sessions.ActionRequestMsg) (tea.Model, te
 		}
 		return m.openGroupInput(sess)
 	}
+	if action.Type == act.TypeEditNotes {
+		sess := m.sessionsView.SelectedSession()
+		if sess == nil {
+			return m, nil
+		}
+		return m.openNotesInput(sess)
+	}
 	if action.Type == act.TypeGroupToggle {
 		cmd := m.sessionsView.ToggleGroupBy()
 		return m, cmd
 	}
+	if action.Type == act.TypeSortCycle {
+		return m, m.sessionsView.CycleSort()
+	}
+	if action.Type == act.TypeArchivedToggle {
+		return m, m.sessionsView.ToggleArchived()
+	}
+	if action.Type == act.TypeCompare {
+		return m.startCompare(m.sessionsView.SelectedSession(), action.Args)
+	}
 	if action.Type == act.TypeViewTasks {
 		return m.viewTasksForSelectedSession()
 	}
+	if action.Type == act.TypeOpenPlan {
+		return m, m.resolvePlanForSelectedSession()
+	}
+	if action.Type == act.TypeBindKey {
+		return m.openKeyRecorder()
+	}
 	if action.Type == act.TypeOpenSourcePicker {
 		sourceID, err := m.resolveSourceID(action.Args)
 		if err != nil {
@@ -176,6 +213,9 @@ func (m Model) handleSessionAction(msg sessions.ActionRequestMsg) (tea.Model, te
 		m.sessionsView.ApplyStatusFilter(action.Type)
 		return m, nil
 	}
+	if action.Type == act.TypeFilterTag {
+		return m, m.applyTagFilter(action.Args)
+	}
 	return m.dispatchAction(action)
 }
 
@@ -183,8 +223,51 @@ func (m Model) handleSessionFormCommand(msg sessions.FormCommandRequestMsg) (tea
 	return m.showFormOrExecute(msg.Name, msg.Cmd, msg.Session, nil)
 }
 
+// newCommandPalette opens the command palette for the active view with the
+// shared command line history. repoCmds are the commands of the session's
+// repository config, added to the palette of a session.
+func (m Model) newCommandPalette(sess *session.Session, repoCmds map[string]config.UserCommand) *CommandPalette {
+	cmds := m.commandSet.All()
+	maps.Copy(cmds, repoCmds)
+	p := NewCommandPalette(cmds, sess, m.width, m.height, m.activeView)
+	p.SetHistory(m.paletteHistory)
+	return p
+}
+
+// handleSessionCommandPalette opens the session palette once the commands of
+// the session's repository config are loaded.
 func (m Model) handleSessionCommandPalette(msg sessions.CommandPaletteRequestMsg) (tea.Model, tea.Cmd) {
-	m.modals.CommandPalette = NewCommandPalette(m.commandSet.All(), msg.Session, m.width, m.height, m.activeView)
+	if msg.Session == nil || m.service == nil {
+		m.modals.CommandPalette = m.newCommandPalette(msg.Session, nil)
+		m.state = stateCommandPalette
+		return m, nil
+	}
+	return m, m.loadRepoCommands(msg.Session)
+}
+
+type repoCommandsLoadedMsg struct {
+	session *session.Session
+	cmds    map[string]config.UserCommand
+	err     error
+}
+
+func (m Model) loadRepoCommands(sess *session.Session) tea.Cmd {
+	svc := m.service
+	return func() tea.Msg {
+		cmds, err := svc.RepoUserCommands(sess)
+		return repoCommandsLoadedMsg{session: sess, cmds: cmds, err: err}
+	}
+}
+
+func (m Model) handleRepoCommandsLoaded(msg repoCommandsLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.state != stateNormal {
+		// Another modal opened while the config loaded
+		return m, nil
+	}
+	if msg.err != nil {
+		m.notifyErrorf("%v", msg.err)
+	}
+	m.modals.CommandPalette = m.newCommandPalette(msg.session, msg.cmds)
 	m.state = stateCommandPalette
 	return m, nil
 }
@@ -240,6 +323,42 @@ func (m Model) viewTasksForSelectedSession() (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// resolvePlanForSelectedSession looks up the selected session's plan
+// document in the background.
+func (m Model) resolvePlanForSelectedSession() tea.Cmd {
+	sess := m.sessionsView.SelectedSession()
+	if sess == nil {
+		return nil
+	}
+	svc, s := m.service, *sess
+	return func() tea.Msg {
+		path, err := svc.PlanDocument(s)
+		return planResolvedMsg{sessionID: s.ID, path: path, err: err}
+	}
+}
+
+// handlePlanResolved opens the resolved plan in the Docs tab, provided the
+// session it belongs to is still selected.
+func (m Model) handlePlanResolved(msg planResolvedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.publishNotificationf(notify.LevelWarning, "%v", msg.err)
+		return m, nil
+	}
+	if m.reviewView == nil {
+		m.publishNotificationf(notify.LevelWarning, "review view is not available")
+		return m, nil
+	}
+	if sess := m.sessionsView.SelectedSession(); sess == nil || sess.ID != msg.sessionID {
+		return m, nil
+	}
+
+	// Switching views points the Docs tab at the session's repository before
+	// the document is opened.
+	model, syncCmd := m.switchToView(ViewReview)
+	m = model.(Model)
+	return m, tea.Sequence(syncCmd, m.reviewView.OpenDocumentByPath(msg.path))
+}
+
 func (m Model) handleSessionOpenRepo(msg sessions.OpenRepoRequestMsg) (tea.Model, tea.Cmd) {
 	return m.openRepoHeaderByRemote(msg.Name, msg.Remote)
 }
@@ -353,6 +472,10 @@ func (m Model) handleGlobalAction(a Action) (tea.Model, tea.Cmd) {
 		return m.showHiveInfo()
 	case act.TypeHiveDoctor:
 		return m.showHiveDoctor()
+	case act.TypeHiveRules:
+		return m.showHiveRules(a.SessionRemote)
+	case act.TypeBindKey:
+		return m.openKeyRecorder()
 	case act.TypeNotifications:
 		m.state = stateShowingNotifications
 		m.modals.ShowNotifications(m.notifyStore)
@@ -466,13 +589,13 @@ func (m Model) handleDocsRepoKeysLoaded(msg docsRepoKeysLoadedMsg) (tea.Model, t
 }
 
 func (m Model) handleTaskCommandPalette(_ tasks.CommandPaletteRequestMsg) (tea.Model, tea.Cmd) {
-	m.modals.CommandPalette = NewCommandPalette(m.commandSet.All(), nil, m.width, m.height, m.activeView)
+	m.modals.CommandPalette = m.newCommandPalette(nil, nil)
 	m.state = stateCommandPalette
 	return m, nil
 }
 
 func (m Model) handleReviewCommandPalette() (tea.Model, tea.Cmd) {
-	m.modals.CommandPalette = NewCommandPalette(m.commandSet.All(), nil, m.width, m.height, m.activeView)
+	m.modals.CommandPalette = m.newCommandPalette(nil, nil)
 	m.state = stateCommandPalette
 	return m, nil
 }
@@ -514,10 +637,12 @@ func (m Model) handleReviewAction(msg review.ActionRequestMsg) (tea.Model, tea.C
 	}
 
 	var docPath, docRelPath string
+	var docReadOnly bool
 	if m.reviewView != nil {
 		if doc := m.reviewView.SelectedDoc(); doc != nil {
 			docPath = doc.Path
 			docRelPath = doc.RelPath
+			docReadOnly = doc.ReadOnly
 		}
 	}
 
@@ -558,6 +683,10 @@ func (m Model) handleReviewAction(msg review.ActionRequestMsg) (tea.Model, tea.C
 		if docPath == "" {
 			return m, nil
 		}
+		if docReadOnly {
+			m.publishNotificationf(notify.LevelWarning, "%s is in a read-only vault", docRelPath)
+			return m, nil
+		}
 		editor := os.Getenv("EDITOR")
 		if editor == "" {
 			editor = "vi"
@@ -577,8 +706,58 @@ func (m Model) handleReviewAction(msg review.ActionRequestMsg) (tea.Model, tea.C
 		if m.reviewView != nil {
 			return m, m.reviewView.ToggleTree()
 		}
+	case act.TypeDocsToggleComments:
+		if m.reviewView != nil {
+			m.reviewView.ToggleComments()
+		}
+	case act.TypeDocsToggleAnnotations:
+		if m.reviewView != nil {
+			m.reviewView.ToggleAnnotations()
+		}
+	case act.TypeDocsNextComment, act.TypeDocsPrevComment:
+		if m.reviewView != nil {
+			m.reviewView.JumpToComment(a.Type == act.TypeDocsNextComment)
+		}
 	case act.TypeDocsSelectRepo:
 		return m, m.loadDocsRepoKeys()
+	case act.TypeDocsOpenRevision:
+		if m.reviewView == nil {
+			return m, nil
+		}
+		cmd, err := m.reviewView.OpenRevisionPicker()
+		if err != nil {
+			m.notifyErrorf("open at commit: %v", err)
+		}
+		return m, cmd
+	case act.TypeDocsCommentEdits:
+		if m.reviewView == nil {
+			return m, nil
+		}
+		if err := m.reviewView.OpenCommentEdits(); err != nil {
+			m.notifyErrorf("comment edits: %v", err)
+		}
+	case act.TypeDocsAddToReview:
+		if m.reviewView == nil {
+			return m, nil
+		}
+		if rel, err := m.reviewView.AddSelectedToReview(); err != nil {
+			m.notifyErrorf("add to review failed: %v", err)
+		} else {
+			m.publishNotificationf(notify.LevelInfo, "Added %s to review", rel)
+		}
+	case act.TypeDocsToggleInstant:
+		if m.reviewView == nil {
+			return m, nil
+		}
+		target, err := m.reviewView.ToggleInstant()
+		switch {
+		case err != nil:
+			m.notifyErrorf("instant mode: %v", err)
+		case target != nil:
+			m.publishNotificationf(notify.LevelInfo, "Instant mode on: comments go to %s (%s)", target.Name, target.Topic)
+		default:
+			m.publishNotificationf(notify.LevelInfo, "Instant mode off")
+		}
 	default:
 		return m.handleGlobalAction(a)
 	}
@@ -600,6 +779,7 @@ func (m Model) handleActionComplete(msg actionCompleteMsg) (tea.Model, tea.Cmd)
 
 func (m Model) handleStreamStarted(msg streamStartedMsg) (tea.Model, tea.Cmd) {
 	m.state = stateStreaming
+	m.modals.StreamRetry = nil
 	m.modals.ShowOutputModal(msg.title)
 	m.modals.StreamOutput = msg.output
 	m.modals.StreamDone = msg.done
@@ -656,8 +836,18 @@ func (m Model) handleStreamComplete(msg streamCompleteMsg) (tea.Model, tea.Cmd)
 		return m, tea.Batch(cmds...)
 	}
 
+	if result.failureHint != "" && (result.hintOn == nil || errors.Is(msg.err, result.hintOn)) {
+		m.modals.Output.AddLine("")
+		m.modals.Output.AddLine(result.failureHint)
+	}
+	if result.logID != nil && *result.logID != "" {
+		m.modals.Output.AddLine("")
+		m.modals.Output.AddLine("Log saved: hive session creation-log " + *result.logID)
+	}
+	m.modals.StreamRetry = result.retry
+	m.modals.Output.SetRetryable(result.retry != nil)
 	m.modals.Output.SetComplete(msg.err)
-	return m, nil
+	return m, m.refreshSessions()
 }
 
 // handleStreamingModalKey handles keys when a streaming output modal is shown.
@@ -705,6 +895,20 @@ func (m Model) handleStreamingModalKey(keyStr string) (tea.Model, tea.Cmd) {
 			m.modals.Pending = Action{}
 			return m, m.refreshSessions()
 		}
+	case "r":
+		if m.modals.Output.CanRetry() && m.modals.StreamRetry != nil {
+			retry := m.modals.StreamRetry
+			m.modals.StreamRetry = nil
+			return m, retry
+		}
+	case "up", "k":
+		m.modals.Output.ScrollUp(1)
+	case "down", "j":
+		m.modals.Output.ScrollDown(1)
+	case "pgup":
+		m.modals.Output.ScrollUp(outputModalMaxHeight)
+	case "pgdown":
+		m.modals.Output.ScrollDown(outputModalMaxHeight)
 	}
 	return m, nil
 }
@@ -742,6 +946,11 @@ func (m Model) handleBgStreamComplete(msg bgStreamCompleteMsg) (tea.Model, tea.C
 // --- Review delegation ---
 
 func (m Model) handleReviewDocChange(msg review.DocumentChangeMsg) (tea.Model, tea.Cmd) {
+	return m.forwardToReview(msg)
+}
+
+// forwardToReview delivers msg to the review view whether or not it is focused.
+func (m Model) forwardToReview(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.reviewView != nil {
 		var cmd tea.Cmd
 		*m.reviewView, cmd = m.reviewView.Update(msg)
@@ -751,12 +960,31 @@ func (m Model) handleReviewDocChange(msg review.DocumentChangeMsg) (tea.Model, t
 }
 
 func (m Model) handleReviewFinalized(msg review.ReviewFinalizedMsg) (tea.Model, tea.Cmd) {
+	if msg.SaveErr != nil {
+		m.notifyErrorf("failed to save feedback: %v", msg.SaveErr)
+	}
+	if msg.HookErr != nil {
+		m.notifyErrorf("review finalize hooks failed: %v", msg.HookErr)
+	}
+
+	if m.bus != nil {
+		m.bus.PublishReviewFinalized(eventbus.ReviewFinalizedPayload{
+			DocumentPath: msg.DocumentPath,
+			DocumentRel:  msg.DocumentRel,
+			Feedback:     msg.Feedback,
+		})
+	}
+
 	if err := m.copyToClipboard(msg.Feedback); err != nil {
 		m.notifyErrorf("failed to copy feedback: %v", err)
 		return m, nil
 	}
 
-	m.publishNotificationf(notify.LevelInfo, "Review copied to clipboard")
+	if msg.SavedPath != "" {
+		m.publishNotificationf(notify.LevelInfo, "Review copied to clipboard and saved to reviews/%s", filepath.Base(msg.SavedPath))
+	} else {
+		m.publishNotificationf(notify.LevelInfo, "Review copied to clipboard")
+	}
 
 	// Auto-complete todos whose ref matches the finalized document
 	if msg.DocumentPath != "" || msg.DocumentRel != "" {
@@ -1095,6 +1323,9 @@ func (m Model) createSourceSession(ctx context.Context, result sourcepicker.Resu
 	if err != nil {
 		return "", "", err
 	}
+	if result.SourceID == "issues" {
+		m.writeIssueContext(result.Item, detail, scope.Search)
+	}
 
 	exec := m.cmdService.NewCreateExecutor(hive.CreateOptions{
 		Name:          rendered.Name,
@@ -1117,6 +1348,30 @@ func (m Model) createSourceSession(ctx context.Context, result sourcepicker.Resu
 	return exec.ResultSessionID, exec.ResultSessionName, nil
 }
 
+// writeIssueContext writes a picked issue to the context directory of its
+// repository, scope ("owner/name"). Failures are logged; the session is
+// created either way.
+func (m Model) writeIssueContext(item sources.Item, detail sources.Detail, scope string) {
+	owner, name, ok := strings.Cut(scope, "/")
+	if !ok || owner == "" || name == "" {
+		return
+	}
+	url, _ := item.Fields["url"].(string)
+	var body string
+	if detail.Markdown != nil {
+		body = detail.Markdown.Content
+	}
+	_, err := hive.WriteIssueContext(m.cfg.RepoContextDir(owner, name), hive.IssueContext{
+		Number: item.ID,
+		Title:  item.Title,
+		URL:    url,
+		Body:   body,
+	})
+	if err != nil {
+		log.Warn().Err(err).Str("item", item.ID).Msg("source picker: failed to write issue to context directory")
+	}
+}
+
 // streamLine sends a line to a stream output channel without blocking past
 // cancellation.
 func streamLine(ctx context.Context, out chan<- string, line string) {
@@ -1464,7 +1719,7 @@ func (m Model) handleMouseClick(msg tea.MouseClickMsg) (tea.Model, tea.Cmd) {
 
 	var cmd tea.Cmd
 	switch m.activeView {
-	case ViewSessions:
+	case ViewSessions, ViewBoard:
 		if m.sessionsView != nil {
 			cmd = m.sessionsView.SelectAtRow(msg.X, contentY)
 		}
@@ -1499,7 +1754,8 @@ func (m Model) switchToView(view ViewType) (tea.Model, tea.Cmd) {
 	}
 	m.activeView = view
 	m.handler.SetActiveView(view)
-	m.sessionsView.SetActive(view == ViewSessions)
+	m.sessionsView.SetActive(view == ViewSessions || view == ViewBoard)
+	m.sessionsView.SetBoardMode(view == ViewBoard)
 	if m.msgView != nil {
 		m.msgView.SetActive(view == ViewMessages)
 	}
@@ -1519,7 +1775,7 @@ func (m Model) switchToView(view ViewType) (tea.Model, tea.Cmd) {
 		if cmd := m.syncDocsRepoFromSessions(); cmd != nil {
 			return m, cmd
 		}
-	case ViewSessions, ViewMessages:
+	case ViewSessions, ViewBoard, ViewMessages:
 		// No data load needed on switch.
 	}
 	return m, nil
@@ -1534,7 +1790,7 @@ func (m Model) handleTabClick(x int) (tea.Model, tea.Cmd) {
 		view  ViewType
 		label string
 	}
-	tabs := []tabEntry{{ViewSessions, "Sessions"}}
+	tabs := []tabEntry{{ViewSessions, "Sessions"}, {ViewBoard, "Board"}}
 	if m.tasksView != nil {
 		tabs = append(tabs, tabEntry{ViewTasks, "Tasks"})
 	}
@@ -1646,26 +1902,26 @@ func (m Model) handleSessionRiskChecked(msg sessionRiskCheckedMsg) (tea.Model, t
 	action := msg.action
 
 	if msg.risk.HasRisk() {
-		var lines []string
-		lines = append(lines, "This session has work that will be permanently lost:")
-		lines = append(lines, "")
-		if msg.risk.UncommittedChanges {
-			lines = append(lines, "  • Uncommitted changes")
-		}
-		if msg.risk.UnpushedCommits {
-			lines = append(lines, "  • Unpushed commits")
-		}
+		lines := append([]string{"This session has work that will be permanently lost:", ""}, sessionRiskLines(msg.risk)...)
 
 		title := "Delete Session?"
 		requireText := "delete"
-		if action.Type == act.TypeRecycle {
+		switch {
+		case isRecycleAction(action.Type):
 			title = "Recycle Session?"
 			requireText = "recycle"
+		case action.Type == act.TypeArchive:
+			title = "Archive Session?"
+			requireText = "archive"
 		}
 
 		m.state = stateConfirming
 		m.modals.Pending = action
-		m.modals.Confirm = NewDangerousModal(title, strings.Join(lines, "\n"), requireText)
+		if m.cfg.WarnOnUnsavedWork() {
+			m.modals.Confirm = NewModal(title, strings.Join(lines, "\n"))
+		} else {
+			m.modals.Confirm = NewDangerousModal(title, strings.Join(lines, "\n"), requireText)
+		}
 		return m, nil
 	}
 
@@ -1677,10 +1933,10 @@ func (m Model) handleSessionRiskChecked(msg sessionRiskCheckedMsg) (tea.Model, t
 		return m, nil
 	}
 
-	if action.Type == act.TypeRecycle {
+	if isRecycleAction(action.Type) {
 		m.state = stateNormal
 		m.modals.Pending = Action{}
-		return m, m.startRecycle(action.SessionID)
+		return m, m.startRecycle(action)
 	}
 
 	m.state = stateNormal
@@ -1693,6 +1949,36 @@ func (m Model) handleSessionRiskChecked(msg sessionRiskCheckedMsg) (tea.Model, t
 	return m, m.executeAction(action)
 }
 
+// maxRiskFiles caps how many changed files the unsaved work modal lists.
+const maxRiskFiles = 8
+
+// sessionRiskLines describes the work a session would lose, listing the
+// first changed files and the number of unpushed commits when known.
+func sessionRiskLines(risk hive.SessionRisk) []string {
+	var lines []string
+	if risk.UncommittedChanges {
+		if len(risk.ChangedFiles) == 0 {
+			lines = append(lines, "  • Uncommitted changes")
+		} else {
+			lines = append(lines, fmt.Sprintf("  • Uncommitted changes (%d):", len(risk.ChangedFiles)))
+			for _, file := range risk.ChangedFiles[:min(len(risk.ChangedFiles), maxRiskFiles)] {
+				lines = append(lines, "      "+file)
+			}
+			if extra := len(risk.ChangedFiles) - maxRiskFiles; extra > 0 {
+				lines = append(lines, fmt.Sprintf("      … and %d more", extra))
+			}
+		}
+	}
+	if risk.UnpushedCommits {
+		if risk.UnpushedCount > 0 {
+			lines = append(lines, fmt.Sprintf("  • Unpushed commits (%d)", risk.UnpushedCount))
+		} else {
+			lines = append(lines, "  • Unpushed commits")
+		}
+	}
+	return lines
+}
+
 // handleConfirmModalKey handles keys when confirmation modal is shown.
 func (m Model) handleConfirmModalKey(keyStr string) (tea.Model, tea.Cmd) {
 	if m.modals.Confirm.IsTextInput() {
@@ -1705,9 +1991,13 @@ func (m Model) handleConfirmModalKey(keyStr string) (tea.Model, tea.Cmd) {
 		m.modals.DismissConfirm()
 		if confirmed {
 			action := m.modals.Pending
-			if action.Type == act.TypeRecycle {
+			if isRecycleAction(action.Type) {
+				m.state = stateNormal
+				return m, m.startRecycle(action)
+			}
+			if action.Type == act.TypeSync {
 				m.state = stateNormal
-				return m, m.startRecycle(action.SessionID)
+				return m, m.startSync(action)
 			}
 			if action.Type == act.TypeDeleteRecycledBatch {
 				m.state = stateNormal
@@ -1792,9 +2082,9 @@ func (m Model) handleDangerousConfirmKey(keyStr string) (tea.Model, tea.Cmd) {
 		action := m.modals.Pending
 		m.modals.Pending = Action{}
 		m.modals.PendingRecycledSessions = nil
-		if action.Type == act.TypeRecycle {
+		if isRecycleAction(action.Type) {
 			m.state = stateNormal
-			return m, m.startRecycle(action.SessionID)
+			return m, m.startRecycle(action)
 		}
 		m.state = stateNormal
 		if !action.Silent {
@@ -1847,6 +2137,8 @@ func osOpenCmd(uri string) *exec.Cmd {
 	switch runtime.GOOS {
 	case "darwin":
 		return exec.Command("open", uri)
+	case "windows":
+		return exec.Command("cmd", "/c", "start", "", uri)
 	default:
 		return exec.Command("xdg-open", uri)
 	}
@@ -1862,5 +2154,6 @@ func renderCustomAction(tmplStr string, ref todo.Ref) (*exec.Cmd, error) {
 	if err != nil {
 		return nil, fmt.Errorf("render action template: %w", err)
 	}
-	return exec.Command("sh", "-c", rendered), nil
+	shell, args := executil.Shell(rendered)
+	return exec.Command(shell, args...), nil
 }
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/notify"
+)
+
+// openKeyRecorder opens the key recorder for the active view.
+func (m Model) openKeyRecorder() (tea.Model, tea.Cmd) {
+	if m.configPath == "" {
+		m.state = stateNormal
+		return m, m.notifyError("bind: no config file")
+	}
+	m.modals.KeyRecorder = NewKeyRecorder(m.activeView.scope(), m.commandSet.All(), m.describeKeybinding, m.width, m.height)
+	m.state = stateRecordingKey
+	return m, nil
+}
+
+// describeKeybinding describes what key is bound to in view: the command and
+// where it comes from, or why it cannot be bound.
+func (m Model) describeKeybinding(view, key string) string {
+	if slices.Contains(reservedKeys, key) {
+		return "reserved by hive"
+	}
+	kb, from, ok := m.handler.Binding(view, key)
+	if !ok {
+		return "unbound"
+	}
+
+	var source string
+	switch src := m.commandSet.Source(kb.Cmd); src {
+	case "":
+		source = "unknown command"
+	case "system":
+		source = "built-in"
+	case "user":
+		source = "user command"
+	default:
+		source = "plugin " + src
+	}
+	desc := fmt.Sprintf("%s (%s)", kb.Cmd, source)
+	if from != view {
+		desc += ", from " + from
+	}
+	return desc
+}
+
+// handleKeyRecorderKey handles keys when the key recorder is shown.
+func (m Model) handleKeyRecorderKey(msg tea.KeyPressMsg, keyStr string) (tea.Model, tea.Cmd) {
+	if m.modals.KeyRecorder == nil {
+		m.state = stateNormal
+		return m, nil
+	}
+	if keyStr == keyCtrlC {
+		return m.quit()
+	}
+
+	m.modals.KeyRecorder, _ = m.modals.KeyRecorder.Update(msg)
+	recorder := m.modals.KeyRecorder
+
+	if recorder.Cancelled() {
+		m.modals.KeyRecorder = nil
+		m.state = stateNormal
+		return m, nil
+	}
+
+	if cmd := recorder.Selected(); cmd != "" {
+		m.modals.KeyRecorder = nil
+		m.state = stateNormal
+		return m, m.writeKeybinding(recorder.TargetView(), recorder.Key(), cmd)
+	}
+
+	return m, nil
+}
+
+// writeKeybinding returns a command that binds key to cmd in the config file.
+func (m Model) writeKeybinding(view, key, cmd string) tea.Cmd {
+	configPath, dataDir := m.configPath, m.cfg.DataDir
+	return func() tea.Msg {
+		backup, err := config.WriteKeybinding(configPath, dataDir, view, key, cmd)
+		return keyBoundMsg{view: view, key: key, cmd: cmd, backup: backup, err: err}
+	}
+}
+
+// handleKeyBound applies a binding written to config to the running TUI.
+func (m Model) handleKeyBound(msg keyBoundMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m, m.notifyError("bind %s: %v", msg.key, msg.err)
+	}
+	m.handler.SetBinding(msg.view, msg.key, config.Keybinding{Cmd: msg.cmd})
+
+	text := fmt.Sprintf("Bound %s to %s in %s", msg.key, msg.cmd, msg.view)
+	if msg.backup != "" {
+		text += " (backup: " + msg.backup + ")"
+	}
+	m.publishNotificationf(notify.LevelInfo, "%s", text)
+	return m, nil
+}
@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/terminal"
+)
+
+// pressKey sends a key to the key recorder.
+func pressKey(t *testing.T, m Model, msg tea.KeyPressMsg) (Model, tea.Cmd) {
+	t.Helper()
+	model, cmd := m.handleKeyRecorderKey(msg, msg.String())
+	return model.(Model), cmd
+}
+
+func TestKeyRecorder_BindsUserCommand(t *testing.T) {
+	m := newKeybindingPrecedenceModel(t, func(cfg *config.Config) {
+		cfg.UserCommands["Deploy"] = config.UserCommand{Sh: "make deploy"}
+	})
+	m.configPath = filepath.Join(t.TempDir(), "config.yaml")
+	original := "usercommands:\n  Deploy:\n    sh: make deploy\n"
+	require.NoError(t, os.WriteFile(m.configPath, []byte(original), 0o644))
+
+	model, _ := m.openKeyRecorder()
+	m = model.(Model)
+	require.Equal(t, stateRecordingKey, m.state)
+	assert.True(t, m.modals.HasEditorFocus(m.state))
+
+	m, _ = pressKey(t, m, tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Empty(t, m.modals.KeyRecorder.Key(), "reserved keys are not recorded")
+	assert.Contains(t, terminal.StripANSI(m.modals.KeyRecorder.View()), "tab is reserved")
+
+	m, _ = pressKey(t, m, tea.KeyPressMsg{Code: 'o', Mod: tea.ModCtrl})
+	require.Equal(t, "ctrl+o", m.modals.KeyRecorder.Key())
+	view := terminal.StripANSI(m.modals.KeyRecorder.View())
+	assert.Contains(t, view, "sessions")
+	assert.Contains(t, view, "unbound")
+
+	for _, r := range "deploy" {
+		m, _ = pressKey(t, m, tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	m, cmd := pressKey(t, m, tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Equal(t, stateNormal, m.state)
+	assert.Nil(t, m.modals.KeyRecorder)
+	require.NotNil(t, cmd, "binding writes the config")
+
+	msg, ok := cmd().(keyBoundMsg)
+	require.True(t, ok)
+	require.NoError(t, msg.err)
+	model, _ = m.handleKeyBound(msg)
+	m = model.(Model)
+
+	kb, from, ok := m.handler.Binding("sessions", "ctrl+o")
+	require.True(t, ok)
+	assert.Equal(t, "Deploy", kb.Cmd)
+	assert.Equal(t, "sessions", from)
+	assert.Equal(t, "Deploy (user command)", m.describeKeybinding("sessions", "ctrl+o"))
+
+	backup, err := os.ReadFile(m.configPath + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, original, string(backup))
+	cfg, err := config.Load(m.configPath, t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, "Deploy", cfg.Views.Sessions.Keybindings["ctrl+o"].Cmd)
+}
+
+func TestKeyRecorder_Cancel(t *testing.T) {
+	m := newKeybindingPrecedenceModel(t, nil)
+	m.configPath = filepath.Join(t.TempDir(), "config.yaml")
+
+	model, _ := m.openKeyRecorder()
+	m = model.(Model)
+	m, _ = pressKey(t, m, tea.KeyPressMsg{Code: 'x', Text: "x"})
+	require.Equal(t, "x", m.modals.KeyRecorder.Key())
+
+	m, _ = pressKey(t, m, tea.KeyPressMsg{Code: tea.KeyEscape})
+	assert.Empty(t, m.modals.KeyRecorder.Key(), "esc records another key")
+	m, cmd := pressKey(t, m, tea.KeyPressMsg{Code: tea.KeyEscape})
+	assert.Equal(t, stateNormal, m.state)
+	assert.Nil(t, cmd)
+	assert.NoFileExists(t, m.configPath)
+}
+
+func TestKeyRecorder_SwitchesToGlobal(t *testing.T) {
+	r := NewKeyRecorder("sessions", map[string]config.UserCommand{
+		"Recycle":  {Scope: []string{"sessions"}},
+		"HiveInfo": {},
+	}, func(string, string) string { return "" }, 80, 24)
+
+	r.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	assert.Equal(t, []string{"HiveInfo", "Recycle"}, r.filtered)
+
+	r.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, "global", r.TargetView())
+	assert.Equal(t, []string{"HiveInfo"}, r.filtered, "only global commands can be bound globally")
+}
+
+func TestDescribeKeybinding(t *testing.T) {
+	m := newKeybindingPrecedenceModel(t, nil)
+
+	assert.Equal(t, "Recycle (built-in)", m.describeKeybinding("sessions", "r"))
+	assert.Equal(t, "Quit (built-in), from global", m.describeKeybinding("sessions", "q"))
+	assert.Equal(t, "unbound", m.describeKeybinding("sessions", "ctrl+o"))
+	assert.Equal(t, "reserved by hive", m.describeKeybinding("sessions", "tab"))
+}
@@ -49,14 +49,26 @@ func (g *mouseTestGit) IsValidRepo(_ context.Context, _ string) error
 func (g *mouseTestGit) CloneBare(_ context.Context, _, _ string) error            { return nil }
 func (g *mouseTestGit) WorktreeAdd(_ context.Context, _, _, _ string) error       { return nil }
 func (g *mouseTestGit) WorktreeRemove(_ context.Context, _, _, _ string) error    { return nil }
+func (g *mouseTestGit) WorktreePrune(_ context.Context, _ string) error           { return nil }
 func (g *mouseTestGit) Fetch(_ context.Context, _ string) error                   { return nil }
-func (g *mouseTestGit) HasUnpushedCommits(_ context.Context, _ string) (bool, error) {
-	return false, nil
+func (g *mouseTestGit) ForkBranch(_ context.Context, _, _, _ string) error        { return nil }
+func (g *mouseTestGit) StartBranch(_ context.Context, _, _, _ string) error       { return nil }
+func (g *mouseTestGit) Compare(_ context.Context, _, _ string) (git.Divergence, error) {
+	return git.Divergence{}, nil
+}
+func (g *mouseTestGit) UnpushedCommits(_ context.Context, _ string) (int, error) {
+	return 0, nil
+}
+func (g *mouseTestGit) ChangedFiles(_ context.Context, _ string) ([]string, error) {
+	return nil, nil
+}
+func (g *mouseTestGit) HeadStatus(_ context.Context, _ string) (git.HeadStatus, error) {
+	return git.HeadStatus{}, nil
 }
 
 var (
 	_ session.Store = (*mouseTestStore)(nil)
-	_ git.Git       = (*mouseTestGit)(nil)
+	_ git.VCS       = (*mouseTestGit)(nil)
 )
 
 // newMouseTestSessionService creates a minimal SessionService for mouse tests.
@@ -149,10 +161,10 @@ func TestHandleMouseClick_TopDivider_NoOp(t *testing.T) {
 }
 
 func TestHandleMouseClick_TabBar_DelegatesTabClick(t *testing.T) {
-	// Y=1 is the tab bar; x=12 falls in "Messages" label → ViewMessages
+	// Y=1 is the tab bar; x=20 falls in "Messages" label → ViewMessages
 	m := newBaseMouseModel(t)
 
-	result, _ := m.handleMouseClick(tea.MouseClickMsg{Button: tea.MouseLeft, X: 12, Y: 1})
+	result, _ := m.handleMouseClick(tea.MouseClickMsg{Button: tea.MouseLeft, X: 20, Y: 1})
 	rm := result.(Model)
 	assert.Equal(t, ViewMessages, rm.activeView, "Y=1 with x in Messages tab should switch to ViewMessages")
 }
@@ -212,7 +224,9 @@ func TestHandleMouseClick_DoubleClick_ResetsTracking(t *testing.T) {
 //   left margin = 1
 //   "Sessions" (8 chars): x=1..8
 //   separator " | ": x=9..11
-//   "Messages" (8 chars): x=12..19
+//   "Board" (5 chars): x=12..16
+//   separator " | ": x=17..19
+//   "Messages" (8 chars): x=20..27
 //
 // (Store tab hidden when kvStore == nil and activeView != ViewStore)
 
@@ -241,9 +255,9 @@ func TestHandleTabClick_Messages(t *testing.T) {
 		name string
 		x    int
 	}{
-		{"first char", 12},
-		{"middle", 15},
-		{"last char", 19},
+		{"first char", 20},
+		{"middle", 23},
+		{"last char", 27},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -256,6 +270,19 @@ func TestHandleTabClick_Messages(t *testing.T) {
 	}
 }
 
+func TestHandleTabClick_Board(t *testing.T) {
+	m := newBaseMouseModel(t)
+	result, _ := m.handleTabClick(14)
+	rm := result.(Model)
+	assert.Equal(t, ViewBoard, rm.activeView)
+	assert.True(t, rm.sessionsView.BoardMode(), "board tab should switch the sessions view to board mode")
+
+	result, _ = rm.handleTabClick(1)
+	rm = result.(Model)
+	assert.Equal(t, ViewSessions, rm.activeView)
+	assert.False(t, rm.sessionsView.BoardMode())
+}
+
 func TestHandleTabClick_Separator_NoOp(t *testing.T) {
 	// Separator " | " occupies x=9..11
 	for _, x := range []int{9, 10, 11} {
@@ -269,10 +296,10 @@ func TestHandleTabClick_Separator_NoOp(t *testing.T) {
 }
 
 func TestHandleTabClick_PastAllLabels_NoOp(t *testing.T) {
-	// x=20 is past all labels (Sessions=8, sep=3, Messages=8 → total 19)
+	// x=28 is past all labels (Sessions=8, sep=3, Board=5, sep=3, Messages=8 → total 27)
 	m := newBaseMouseModel(t)
 	initial := m.activeView
-	result, cmd := m.handleTabClick(20)
+	result, cmd := m.handleTabClick(28)
 	rm := result.(Model)
 	assert.Equal(t, initial, rm.activeView, "x past all labels should not change view")
 	assert.Nil(t, cmd, "x past all labels should return nil cmd")
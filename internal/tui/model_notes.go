@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"context"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/tui/components/input"
+	"github.com/colonyops/hive/internal/tui/views/sessions"
+)
+
+// setNotesCompleteMsg is sent when a set-notes operation completes.
+type setNotesCompleteMsg struct {
+	err error
+}
+
+// openNotesInput initializes the notes editor with the session's current notes.
+func (m Model) openNotesInput(sess *session.Session) (tea.Model, tea.Cmd) {
+	ta := input.NewTextArea()
+	ta.Placeholder = "e.g. waiting on CI, needs human review..."
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 2000
+	ta.SetWidth(56)
+	ta.SetHeight(6)
+	ta.SetValue(sess.Notes)
+	ta.CursorEnd()
+
+	m.modals.NotesInput = ta
+	m.modals.NotesSessionID = sess.ID
+	m.state = stateEditingNotes
+	return m, m.modals.NotesInput.Focus()
+}
+
+// handleNotesKey handles keys when the notes editor is active. Enter inserts
+// a newline; ctrl+s saves.
+func (m Model) handleNotesKey(msg tea.KeyPressMsg, keyStr string) (tea.Model, tea.Cmd) {
+	switch keyStr {
+	case keyCtrlC:
+		return m.quit()
+	case "esc":
+		m.state = stateNormal
+		m.modals.NotesSessionID = ""
+		return m, nil
+	case "ctrl+s":
+		notes := m.modals.NotesInput.Value()
+		sessionID := m.modals.NotesSessionID
+		m.state = stateNormal
+		m.modals.NotesSessionID = ""
+		return m, m.executeSetNotes(sessionID, notes)
+	}
+
+	var cmd tea.Cmd
+	m.modals.NotesInput, cmd = m.modals.NotesInput.Update(msg)
+	return m, cmd
+}
+
+// executeSetNotes returns a command that saves a session's notes.
+func (m Model) executeSetNotes(sessionID, notes string) tea.Cmd {
+	return func() tea.Msg {
+		return setNotesCompleteMsg{err: m.service.SetSessionNotes(context.Background(), sessionID, notes)}
+	}
+}
+
+func (m Model) handleSetNotesComplete(msg setNotesCompleteMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m, m.notifyError("save notes failed: %v", msg.err)
+	}
+	return m, func() tea.Msg { return sessions.RefreshSessionsMsg{} }
+}
@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/session"
+)
+
+func TestNotesInputSaveAndCancel(t *testing.T) {
+	m := newKeybindingPrecedenceModel(t, nil)
+	sess := &session.Session{ID: "a", Name: "alpha", Notes: "waiting on CI"}
+
+	model, _ := m.openNotesInput(sess)
+	m = model.(Model)
+	require.Equal(t, stateEditingNotes, m.state)
+	assert.Equal(t, "waiting on CI", m.modals.NotesInput.Value())
+	assert.True(t, m.modals.HasEditorFocus(m.state))
+
+	// Enter inserts a newline rather than saving
+	model, _ = m.handleNotesKey(tea.KeyPressMsg{Code: tea.KeyEnter}, keyEnter)
+	m = model.(Model)
+	assert.Equal(t, stateEditingNotes, m.state)
+	assert.Equal(t, "waiting on CI\n", m.modals.NotesInput.Value())
+
+	model, cmd := m.handleNotesKey(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl}, "ctrl+s")
+	m = model.(Model)
+	assert.Equal(t, stateNormal, m.state)
+	assert.Empty(t, m.modals.NotesSessionID)
+	require.NotNil(t, cmd, "saving runs the update")
+
+	model, _ = m.openNotesInput(sess)
+	m = model.(Model)
+	model, cmd = m.handleNotesKey(tea.KeyPressMsg{Code: tea.KeyEscape}, "esc")
+	m = model.(Model)
+	assert.Equal(t, stateNormal, m.state)
+	assert.Nil(t, cmd)
+}
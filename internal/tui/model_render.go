@@ -7,6 +7,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	lipgloss "charm.land/lipgloss/v2"
 
+	"github.com/colonyops/hive/internal/core/config"
 	"github.com/colonyops/hive/internal/core/styles"
 	"github.com/colonyops/hive/internal/tui/components"
 )
@@ -53,6 +54,7 @@ func (m Model) renderTabView() string {
 	// Build tab list
 	tabs := []string{
 		renderTab("Sessions", ViewSessions),
+		renderTab("Board", ViewBoard),
 	}
 	if m.tasksView != nil {
 		tabs = append(tabs, renderTab("Tasks", ViewTasks))
@@ -72,6 +74,15 @@ func (m Model) renderTabView() string {
 		filterLabel := string(statusFilter)
 		tabsLeft = lipgloss.JoinHorizontal(lipgloss.Left, tabsLeft, "  ", styles.TextPrimaryBoldStyle.Render("["+filterLabel+"]"))
 	}
+	if m.sessionsView.ShowingArchived() {
+		tabsLeft = lipgloss.JoinHorizontal(lipgloss.Left, tabsLeft, "  ", styles.TextPrimaryBoldStyle.Render("[archived]"))
+	}
+	if tagFilter := m.sessionsView.TagFilter(); tagFilter != "" {
+		tabsLeft = lipgloss.JoinHorizontal(lipgloss.Left, tabsLeft, "  ", styles.TextPrimaryBoldStyle.Render("[tag:"+tagFilter+"]"))
+	}
+	if sortMode := m.sessionsView.SortMode(); sortMode != config.SessionSortName {
+		tabsLeft = lipgloss.JoinHorizontal(lipgloss.Left, tabsLeft, "  ", styles.TextPrimaryBoldStyle.Render("[sort:"+sortMode+"]"))
+	}
 
 	// Background operation indicator
 	bgIndicator := ""
@@ -125,13 +136,12 @@ func (m Model) renderTabView() string {
 	topDivider := styles.TextSurfaceStyle.Render(strings.Repeat("─", dividerWidth))
 	headerDivider := styles.TextSurfaceStyle.Render(strings.Repeat("─", dividerWidth))
 
-	// Calculate content height: total - top divider (1) - header (1) - bottom divider (1)
-	contentHeight := max(m.height-3, 1)
+	contentHeight := max(m.height-m.chromeHeight(), 1)
 
 	// Build content with fixed height to prevent layout shift
 	var content string
 	switch m.activeView {
-	case ViewSessions:
+	case ViewSessions, ViewBoard:
 		content = m.sessionsView.View()
 		content = lipgloss.NewStyle().Height(contentHeight).Render(content)
 	case ViewTasks:
@@ -152,5 +162,8 @@ func (m Model) renderTabView() string {
 		}
 	}
 
+	if m.configIssue != nil {
+		return lipgloss.JoinVertical(lipgloss.Left, topDivider, header, headerDivider, content, m.renderConfigBanner(dividerWidth))
+	}
 	return lipgloss.JoinVertical(lipgloss.Left, topDivider, header, headerDivider, content)
 }
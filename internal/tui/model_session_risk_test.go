@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	act "github.com/colonyops/hive/internal/core/action"
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/hive"
+)
+
+func TestSessionRiskLines(t *testing.T) {
+	files := make([]string, maxRiskFiles+2)
+	for i := range files {
+		files[i] = fmt.Sprintf("file%d.go", i)
+	}
+
+	lines := sessionRiskLines(hive.SessionRisk{
+		UncommittedChanges: true,
+		ChangedFiles:       files,
+		UnpushedCommits:    true,
+		UnpushedCount:      3,
+	})
+	assert.Equal(t, "  • Uncommitted changes (10):", lines[0])
+	assert.Equal(t, "      file0.go", lines[1])
+	assert.Equal(t, "      … and 2 more", lines[maxRiskFiles+1])
+	assert.Equal(t, "  • Unpushed commits (3)", lines[len(lines)-1])
+
+	assert.Equal(t, []string{"  • Uncommitted changes", "  • Unpushed commits"},
+		sessionRiskLines(hive.SessionRisk{UncommittedChanges: true, UnpushedCommits: true}))
+}
+
+func TestHandleSessionRiskChecked_UnsavedWorkMode(t *testing.T) {
+	msg := sessionRiskCheckedMsg{
+		action: Action{Type: act.TypeDelete, SessionID: "s1"},
+		risk:   hive.SessionRisk{UncommittedChanges: true, ChangedFiles: []string{"main.go"}},
+	}
+
+	m := newKeybindingPrecedenceModel(t, nil)
+	model, _ := m.handleSessionRiskChecked(msg)
+	m = model.(Model)
+	assert.Equal(t, stateConfirming, m.state)
+	assert.True(t, m.modals.Confirm.IsTextInput(), "unsaved work requires typed confirmation by default")
+	assert.Contains(t, m.modals.Confirm.message, "main.go")
+
+	m = newKeybindingPrecedenceModel(t, func(cfg *config.Config) { cfg.UnsavedWork = config.UnsavedWorkWarn })
+	model, _ = m.handleSessionRiskChecked(msg)
+	m = model.(Model)
+	assert.Equal(t, stateConfirming, m.state)
+	assert.False(t, m.modals.Confirm.IsTextInput(), "warn mode downgrades to a normal confirmation")
+	assert.Contains(t, m.modals.Confirm.message, "main.go")
+}
@@ -2,10 +2,12 @@ package tui
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 	"unsafe"
@@ -147,6 +149,35 @@ func TestOpenNewSessionFormUsesEnvironmentDefaultAgent(t *testing.T) {
 	assert.Equal(t, "pi", opened.modals.NewSession.agent.keys[opened.modals.NewSession.agent.selected])
 }
 
+func TestHandleRepoCommandsLoadedOpensPalette(t *testing.T) {
+	m := Model{
+		modals:     NewModalCoordinator(),
+		commandSet: commandSetFromMap(map[string]config.UserCommand{"Global": {Sh: "true"}}),
+	}
+	sess := &session.Session{ID: "s1"}
+
+	model, _ := m.handleRepoCommandsLoaded(repoCommandsLoadedMsg{
+		session: sess,
+		cmds:    map[string]config.UserCommand{"RepoOnly": {Sh: "true"}},
+	})
+	opened := model.(Model)
+	require.Equal(t, stateCommandPalette, opened.state)
+	require.NotNil(t, opened.modals.CommandPalette)
+	assert.Equal(t, sess, opened.modals.CommandPalette.session)
+	var names []string
+	for _, entry := range opened.modals.CommandPalette.commands {
+		names = append(names, entry.Name)
+	}
+	assert.Contains(t, names, "Global")
+	assert.Contains(t, names, "RepoOnly")
+
+	// A modal opened while the config loaded is left alone.
+	m = Model{modals: NewModalCoordinator(), state: stateConfirming}
+	model, _ = m.handleRepoCommandsLoaded(repoCommandsLoadedMsg{session: sess})
+	assert.Equal(t, stateConfirming, model.(Model).state)
+	assert.Nil(t, model.(Model).modals.CommandPalette)
+}
+
 func TestHandleBgStreamCompleteIgnoresStaleCompletion(t *testing.T) {
 	firstDone := make(chan error)
 	var secondDone <-chan error = make(chan error)
@@ -219,6 +250,55 @@ func TestHandleStreamingModalKeyDoesNotOverwriteActiveBackgroundStream(t *testin
 	assert.Equal(t, streamDone, next.modals.StreamDone)
 }
 
+func TestHandleStreamCompleteFailureOffersRetry(t *testing.T) {
+	type retryMsg struct{}
+	retry := func() tea.Msg { return retryMsg{} }
+
+	m := Model{modals: NewModalCoordinator()}
+	m.state = stateStreaming
+	m.modals.ShowOutputModal("Creating session...")
+	logID := "abc123"
+	m.modals.StreamResult = streamResult{logID: &logID, retry: retry}
+
+	model, _ := m.handleStreamComplete(streamCompleteMsg{err: errors.New("clone failed")})
+	next := model.(Model)
+
+	assert.True(t, next.modals.Output.CanRetry())
+	assert.Contains(t, next.modals.Output.lines, "Log saved: hive session creation-log abc123")
+
+	model, cmd := next.handleStreamingModalKey("r")
+	require.NotNil(t, cmd)
+	assert.IsType(t, retryMsg{}, cmd())
+	assert.Nil(t, model.(Model).modals.StreamRetry)
+}
+
+func TestHandleStreamCompleteRecycleHint(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"recycle commands failed", fmt.Errorf("recycle session s1: %w: exit status 1", hive.ErrRecycleFailed), true},
+		{"other failure", errors.New("session s1 cannot be recycled (state: recycled)"), false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{modals: NewModalCoordinator()}
+			m.state = stateStreaming
+			m.modals.ShowOutputModal("Recycling session...")
+			m.modals.StreamResult = streamResult{failureHint: recycleFailureHint, hintOn: hive.ErrRecycleFailed}
+
+			model, _ := m.handleStreamComplete(streamCompleteMsg{err: tt.err})
+			lines := model.(Model).modals.Output.lines
+			first, _, _ := strings.Cut(recycleFailureHint, "\n")
+			if tt.want {
+				assert.Contains(t, lines, first)
+			} else {
+				assert.NotContains(t, lines, first)
+			}
+		})
+	}
+}
+
 func keyPressMsg(key string) tea.KeyPressMsg {
 	switch key {
 	case "up":
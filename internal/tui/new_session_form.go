@@ -10,6 +10,7 @@ import (
 	"github.com/colonyops/hive/internal/core/styles"
 	"github.com/colonyops/hive/internal/core/workspace"
 	"github.com/colonyops/hive/internal/tui/components"
+	"github.com/colonyops/hive/internal/tui/components/input"
 )
 
 // agentPicker is a compact inline selector for a small set of agent names.
@@ -76,6 +77,10 @@ type NewSessionForm struct {
 
 	hasAgentSelector bool
 	agent            agentPicker
+	// agentFor returns the agent the rules pick for a remote. Until the user
+	// picks one, the selector follows the selected repository.
+	agentFor    func(remote string) string
+	agentPicked bool
 
 	// focusedField tracks which field has focus.
 	// Without agent selector: 0=repo, 1=name
@@ -90,7 +95,7 @@ type NewSessionForm struct {
 type NewSessionFormResult struct {
 	Repo        workspace.DiscoveredRepo
 	SessionName string
-	AgentKey    string // empty when agent selector is disabled
+	AgentKey    string // profile to spawn the session with; empty when agent selector is disabled
 }
 
 // NewNewSessionForm creates a new session form with the given repos.
@@ -116,12 +121,11 @@ func NewNewSessionForm(repos []workspace.DiscoveredRepo, preselectedRemote strin
 	repoSelect := NewSelectField("Repository", items, selectedIdx)
 	repoSelect.Focus()
 
-	nameInput := textinput.New()
+	nameInput := input.NewTextInput()
 	nameInput.Placeholder = "my-feature-branch"
 	nameInput.CharLimit = 64
 	nameInput.Prompt = ""
 	nameInput.SetWidth(40)
-	nameInput.KeyMap.Paste.SetEnabled(true)
 
 	inputStyles := textinput.DefaultStyles(true)
 	inputStyles.Cursor.Color = styles.ColorPrimary
@@ -146,7 +150,7 @@ func NewNewSessionForm(repos []workspace.DiscoveredRepo, preselectedRemote strin
 	return f
 }
 
-// repoIdx returns the focusedField value for the repo field.
+// selectAgent pre-selects the agent key, if it is one of the choices.
 func (f *NewSessionForm) selectAgent(key string) {
 	if !f.hasAgentSelector || key == "" {
 		return
@@ -159,6 +163,20 @@ func (f *NewSessionForm) selectAgent(key string) {
 	}
 }
 
+// followRepoAgent pre-selects the agent the rules pick for the selected
+// repository, unless the user has already picked one.
+func (f *NewSessionForm) followRepoAgent() {
+	if f.agentFor == nil || f.agentPicked || len(f.repos) == 0 {
+		return
+	}
+	idx := f.repoSelect.SelectedIndex()
+	if idx < 0 || idx >= len(f.repos) {
+		return
+	}
+	f.selectAgent(f.agentFor(f.repos[idx].Remote))
+}
+
+// repoIdx returns the focusedField value for the repo field.
 func (f *NewSessionForm) repoIdx() int {
 	if f.hasAgentSelector {
 		return repoFieldWithAgent
@@ -258,11 +276,16 @@ func (f *NewSessionForm) moveFocus(idx int) (NewSessionForm, tea.Cmd) {
 func (f *NewSessionForm) updateFocusedField(msg tea.Msg) (NewSessionForm, tea.Cmd) {
 	var cmd tea.Cmd
 	if f.hasAgentSelector && f.focusedField == agentSelectorField {
+		prev := f.agent.selected
 		f.agent.update(msg)
+		if f.agent.selected != prev {
+			f.agentPicked = true
+		}
 		return *f, nil
 	}
 	if f.focusedField == f.repoIdx() {
 		f.repoSelect, cmd = f.repoSelect.Update(msg)
+		f.followRepoAgent()
 		return *f, cmd
 	}
 	// name field
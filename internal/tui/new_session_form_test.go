@@ -135,4 +135,28 @@ func TestNewNewSessionForm(t *testing.T) {
 		result := form.Result()
 		assert.Equal(t, "pi", result.AgentKey)
 	})
+
+	t.Run("agent selector follows the repository until an agent is picked", func(t *testing.T) {
+		form := NewNewSessionForm(repos, "", nil, []string{"claude", "codex", "pi"})
+		form.agentFor = func(remote string) string {
+			if remote == "git@github.com:user/beta.git" {
+				return "pi"
+			}
+			return "claude"
+		}
+		form.followRepoAgent()
+		assert.Equal(t, "claude", form.Result().AgentKey)
+
+		updated, _ := form.Update(keyPress(tea.KeyDown))
+		assert.Equal(t, "pi", updated.Result().AgentKey, "the rule's agent for beta")
+
+		updated, _ = updated.Update(tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift})
+		require.Equal(t, agentSelectorField, updated.focusedField)
+		updated, _ = updated.Update(keyPress(tea.KeyLeft))
+		assert.Equal(t, "codex", updated.Result().AgentKey)
+
+		updated, _ = updated.Update(keyPress(tea.KeyTab))
+		updated, _ = updated.Update(keyPress(tea.KeyDown))
+		assert.Equal(t, "codex", updated.Result().AgentKey, "a picked agent is kept")
+	})
 }
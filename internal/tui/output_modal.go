@@ -11,13 +11,13 @@ import (
 
 // Output modal layout constants.
 const (
-	outputModalMaxWidth   = 100 // maximum modal width in columns
-	outputModalMaxHeight  = 20  // maximum modal height in rows
-	outputModalMargin     = 4   // margin from screen edges
-	outputModalChrome     = 6   // rows for title, status, help, and spacing
-	outputModalPadding    = 4   // padding inside content area
-	outputModalTruncation = 7   // space for "..." when truncating lines
-	outputModalMaxLines   = 100 // max lines to buffer
+	outputModalMaxWidth   = 100  // maximum modal width in columns
+	outputModalMaxHeight  = 20   // maximum modal height in rows
+	outputModalMargin     = 4    // margin from screen edges
+	outputModalChrome     = 6    // rows for title, status, help, and spacing
+	outputModalPadding    = 4    // padding inside content area
+	outputModalTruncation = 7    // space for "..." when truncating lines
+	outputModalMaxLines   = 1000 // max lines to buffer
 )
 
 // Pulse animation constants.
@@ -26,15 +26,18 @@ const (
 	outputPulseMinBright = 0.6 // minimum brightness at midpoint
 )
 
-// OutputModal displays streaming command output in a modal dialog.
+// OutputModal displays streaming command output in a modal dialog. Once the
+// command finishes, the buffered output can be scrolled.
 type OutputModal struct {
-	title    string
-	lines    []string
-	running  bool
-	err      error
-	spinner  spinner.Model
-	maxLines int // max lines to keep in buffer
-	frame    int // animation frame counter
+	title     string
+	lines     []string
+	running   bool
+	err       error
+	spinner   spinner.Model
+	maxLines  int  // max lines to keep in buffer
+	frame     int  // animation frame counter
+	scroll    int  // lines scrolled up from the bottom
+	retryable bool // a failed command can be retried
 }
 
 // NewOutputModal creates a new output modal with the given title.
@@ -69,6 +72,26 @@ func (m *OutputModal) SetComplete(err error) {
 	m.err = err
 }
 
+// SetRetryable marks whether a failed command can be retried from the modal.
+func (m *OutputModal) SetRetryable(retryable bool) {
+	m.retryable = retryable
+}
+
+// CanRetry returns true if the command failed and can be retried.
+func (m *OutputModal) CanRetry() bool {
+	return !m.running && m.err != nil && m.retryable
+}
+
+// ScrollUp scrolls the output up by n lines, towards earlier output.
+func (m *OutputModal) ScrollUp(n int) {
+	m.scroll = min(m.scroll+n, max(len(m.lines)-1, 0))
+}
+
+// ScrollDown scrolls the output down by n lines, towards the latest output.
+func (m *OutputModal) ScrollDown(n int) {
+	m.scroll = max(m.scroll-n, 0)
+}
+
 // IsRunning returns true if the command is still running.
 func (m *OutputModal) IsRunning() bool {
 	return m.running
@@ -99,16 +122,17 @@ func (m OutputModal) Overlay(background string, width, height int) string {
 	// Build content lines with per-line status indicators
 	var contentBuilder strings.Builder
 
-	// Show last N lines that fit
-	startIdx := 0
+	// Show the N lines that fit, ending scroll lines above the last one
+	endIdx := len(m.lines)
 	if len(m.lines) > contentHeight {
-		startIdx = len(m.lines) - contentHeight
+		endIdx -= min(m.scroll, len(m.lines)-contentHeight)
 	}
+	startIdx := max(endIdx-contentHeight, 0)
 
 	indicatorWidth := 4 // "● " or spinner + space, with safety margin
 	maxLineWidth := modalWidth - outputModalPadding - indicatorWidth
 
-	for i := startIdx; i < len(m.lines); i++ {
+	for i := startIdx; i < endIdx; i++ {
 		line := m.lines[i]
 		// Truncate long lines
 		if len(line) > maxLineWidth {
@@ -124,13 +148,13 @@ func (m OutputModal) Overlay(background string, width, height int) string {
 		}
 		contentBuilder.WriteString(indicator + " " + styles.TextMutedStyle.Render(line))
 
-		if i < len(m.lines)-1 {
+		if i < endIdx-1 {
 			contentBuilder.WriteString("\n")
 		}
 	}
 
 	// Pad with empty lines if needed
-	lineCount := len(m.lines) - startIdx
+	lineCount := endIdx - startIdx
 	for i := lineCount; i < contentHeight; i++ {
 		contentBuilder.WriteString("\n")
 	}
@@ -159,7 +183,12 @@ func (m OutputModal) Overlay(background string, width, height int) string {
 			components.HelpEntry{Key: "esc", Desc: "cancel"},
 		)
 	} else {
-		help = components.KeyHints(components.HelpEntry{Key: "enter/esc", Desc: "close"})
+		entries := []components.HelpEntry{{Key: "↑/↓", Desc: "scroll"}}
+		if m.CanRetry() {
+			entries = append(entries, components.HelpEntry{Key: "r", Desc: "retry"})
+		}
+		entries = append(entries, components.HelpEntry{Key: "enter/esc", Desc: "close"})
+		help = components.KeyHints(entries...)
 	}
 
 	// Assemble modal content
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -75,6 +76,43 @@ func TestOutputModal_SetComplete(t *testing.T) {
 	})
 }
 
+func TestOutputModal_Scroll(t *testing.T) {
+	m := NewOutputModal("Test")
+	for i := range 30 {
+		m.AddLine(fmt.Sprintf("line %02d", i))
+	}
+	m.SetComplete(errors.New("failed"))
+
+	// Starts at the bottom.
+	result := terminal.StripANSI(m.Overlay("background", 80, 24))
+	assert.Contains(t, result, "line 29")
+	assert.NotContains(t, result, "line 05")
+
+	m.ScrollUp(100)
+	result = terminal.StripANSI(m.Overlay("background", 80, 24))
+	assert.Contains(t, result, "line 00")
+	assert.NotContains(t, result, "line 29")
+
+	m.ScrollDown(100)
+	assert.Equal(t, 0, m.scroll)
+}
+
+func TestOutputModal_Retry(t *testing.T) {
+	m := NewOutputModal("Test")
+	m.SetRetryable(true)
+	assert.False(t, m.CanRetry(), "running commands cannot be retried")
+
+	m.SetComplete(nil)
+	assert.False(t, m.CanRetry(), "successful commands cannot be retried")
+
+	m.SetComplete(errors.New("failed"))
+	assert.True(t, m.CanRetry())
+	assert.Contains(t, terminal.StripANSI(m.Overlay("background", 80, 24)), "r retry")
+
+	m.SetRetryable(false)
+	assert.NotContains(t, terminal.StripANSI(m.Overlay("background", 80, 24)), "r retry")
+}
+
 func TestOutputModal_Overlay(t *testing.T) {
 	t.Run("renders with running state", func(t *testing.T) {
 		m := NewOutputModal("Running Task")
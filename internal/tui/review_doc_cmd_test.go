@@ -1,12 +1,14 @@
 package tui
 
 import (
+	"fmt"
 	"sync/atomic"
 	"testing"
 
 	"github.com/colonyops/hive/internal/core/config"
 	"github.com/colonyops/hive/internal/core/eventbus"
 	"github.com/colonyops/hive/internal/core/eventbus/testbus"
+	"github.com/colonyops/hive/internal/hive"
 	"github.com/colonyops/hive/internal/hive/plugins"
 	"github.com/colonyops/hive/internal/tui/views/review"
 	"github.com/stretchr/testify/assert"
@@ -254,3 +256,15 @@ func TestResolveReviewURI(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlePlanResolved_NotFound(t *testing.T) {
+	m := Model{activeView: ViewSessions, notifyBuffer: NewNotificationBuffer()}
+
+	result, cmd := m.handlePlanResolved(planResolvedMsg{sessionID: "s1", err: fmt.Errorf("%w for session auth", hive.ErrPlanNotFound)})
+	assert.Nil(t, cmd)
+	assert.Equal(t, ViewSessions, result.(Model).activeView, "stays on the sessions view")
+	notifications := result.(Model).notifyBuffer.Drain()
+	if assert.Len(t, notifications, 1) {
+		assert.Equal(t, "plan document not found for session auth", notifications[0].Message)
+	}
+}
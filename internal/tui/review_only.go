@@ -10,18 +10,29 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	act "github.com/colonyops/hive/internal/core/action"
+	corekv "github.com/colonyops/hive/internal/core/kv"
 	"github.com/colonyops/hive/internal/data/db"
 	"github.com/colonyops/hive/internal/data/stores"
 	review "github.com/colonyops/hive/internal/tui/views/review"
+	"github.com/colonyops/hive/pkg/executil"
 )
 
 // ReviewOnlyOptions configures the review-only TUI.
 type ReviewOnlyOptions struct {
-	Documents   []review.Document
-	InitialDoc  *review.Document
-	ContextDir  string // Directory for saving feedback files (e.g., context directory)
-	DB          *db.DB
-	CopyCommand string // Shell command for copying to clipboard (e.g., "pbcopy" on macOS)
+	Documents    []review.Document
+	VaultDocs    []review.Document // Documents from external vaults (context.vaults)
+	InitialDoc   *review.Document
+	ContextDir   string // Directory for saving feedback files (e.g., context directory)
+	DB           *db.DB
+	CopyCommand  string               // Shell command for copying to clipboard (e.g., "pbcopy" on macOS)
+	SaveFeedback bool                 // Write finalized feedback to <ContextDir>/reviews/
+	Hooks        []string             // review.finalize_hooks commands run after finalization
+	Timing       bool                 // Add review time and comment timestamps to feedback
+	Renderer     string               // review.renderer name; empty uses glamour
+	Events       review.ReviewEvents  // Review events and instant comments; nil disables the other-reviewers indicator
+	Instant      review.InstantTarget // Session instant mode sends comments to; empty when not run from a session
+	GitPath      string               // git binary for earlier document versions
+	Exec         executil.Executor    // runs git and finalize hooks; nil keeps the view's default
 }
 
 // ReviewOnlyModel is a minimal TUI for reviewing context documents.
@@ -34,6 +45,7 @@ type ReviewOnlyModel struct {
 	height      int
 	quitting    bool
 	initialized bool
+	errs        []error // non-fatal errors, reported when the TUI exits
 }
 
 // NewReviewOnly creates a new review-only TUI model.
@@ -42,7 +54,25 @@ func NewReviewOnly(opts ReviewOnlyOptions) ReviewOnlyModel {
 	store := stores.NewReviewStore(opts.DB)
 
 	// Create review view
+	review.SetRenderer(opts.Renderer)
 	reviewView := review.New(opts.Documents, opts.ContextDir, store, nil, 0)
+	reviewView.SetVaultDocuments(opts.VaultDocs)
+	reviewView.SetInstantTarget(opts.Instant)
+	reviewView.SetSaveFeedback(opts.SaveFeedback)
+	reviewView.SetFinalizeHooks(opts.Hooks)
+	reviewView.SetIncludeTiming(opts.Timing)
+	if opts.Exec != nil {
+		reviewView.SetGit(opts.GitPath, opts.Exec)
+	}
+	var kvStore corekv.KV
+	if opts.DB != nil {
+		kvStore = stores.NewKVStore(opts.DB)
+	}
+	reviewView.SetReviewEvents(opts.Events, kvStore)
+	if opts.DB != nil {
+		reviewView.SetHistoryStore(kvStore)
+		reviewView.SetAnnotationStore(stores.NewAnnotationStore(opts.DB))
+	}
 
 	// When opening with a specific document, hide the tree so the document
 	// gets full-width focus. The user can toggle the tree with V to navigate.
@@ -90,12 +120,26 @@ func (m ReviewOnlyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if !m.reviewView.HasActiveEditor() {
 			switch msg.String() {
 			case keyCtrlC, "q":
+				m.reviewView.FlushReviewTime()
 				m.quitting = true
 				return m, tea.Quit
+			case "C", "shift+c":
+				m.reviewView.ToggleComments()
+				return m, nil
+			case "H", "shift+h":
+				m.reviewView.ToggleAnnotations()
+				return m, nil
+			case "I", "shift+i":
+				// The footer shows when instant mode is on.
+				if _, err := m.reviewView.ToggleInstant(); err != nil {
+					return m, review.ErrorCmd(fmt.Errorf("instant mode: %w", err))
+				}
+				return m, nil
 			case "esc":
 				// In tree view (not fullScreen), esc exits the app.
 				// In reader mode, esc is handled by the view to return to tree first.
 				if !m.reviewView.IsFullScreen() {
+					m.reviewView.FlushReviewTime()
 					m.quitting = true
 					return m, tea.Quit
 				}
@@ -108,12 +152,22 @@ func (m ReviewOnlyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, m.reviewView.ToggleTree()
 		case act.TypeDocsTogglePreview:
 			return m, m.reviewView.TogglePreview()
+		case act.TypeDocsToggleComments:
+			m.reviewView.ToggleComments()
+			return m, nil
+		case act.TypeDocsToggleAnnotations:
+			m.reviewView.ToggleAnnotations()
+			return m, nil
 		case act.TypeDocsSelectRepo:
 			// Not applicable in review-only mode; ignore.
 		default:
 			// Other action types are not applicable in review-only mode.
 		}
 
+	case review.ErrorMsg:
+		m.errs = append(m.errs, msg.Err)
+		return m, nil
+
 	case review.ReviewFinalizedMsg:
 		// Print feedback to stderr first (so user can retrieve it even if clipboard fails)
 		if msg.Feedback != "" {
@@ -123,17 +177,27 @@ func (m ReviewOnlyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			fmt.Fprintln(os.Stderr, "======================")
 			fmt.Fprintln(os.Stderr, "")
 		}
+		if msg.SavedPath != "" {
+			fmt.Fprintf(os.Stderr, "Feedback saved to: %s\n", msg.SavedPath)
+		} else if msg.SaveErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to save feedback: %v\n", msg.SaveErr)
+		}
+		if msg.HookErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Review finalize hooks failed: %v\n", msg.HookErr)
+		}
 
 		// Try to copy to clipboard (best effort)
 		if err := m.copyToClipboard(msg.Feedback); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: Failed to copy to clipboard: %v\n", err)
 
-			// Save to file as fallback
-			if filePath, saveErr := m.saveFeedbackToFile(msg.Feedback); saveErr == nil {
-				fmt.Fprintf(os.Stderr, "Feedback saved to: %s\n", filePath)
-			} else {
-				fmt.Fprintf(os.Stderr, "Failed to save feedback to file: %v\n", saveErr)
-				fmt.Fprintln(os.Stderr, "Feedback is printed above and can be retrieved from terminal history.")
+			// Save to file as fallback, unless it was already saved
+			if msg.SavedPath == "" {
+				if filePath, saveErr := m.saveFeedbackToFile(msg.Feedback); saveErr == nil {
+					fmt.Fprintf(os.Stderr, "Feedback saved to: %s\n", filePath)
+				} else {
+					fmt.Fprintf(os.Stderr, "Failed to save feedback to file: %v\n", saveErr)
+					fmt.Fprintln(os.Stderr, "Feedback is printed above and can be retrieved from terminal history.")
+				}
 			}
 		}
 
@@ -148,6 +212,11 @@ func (m ReviewOnlyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// Errors returns the non-fatal errors raised while the TUI ran.
+func (m ReviewOnlyModel) Errors() []error {
+	return m.errs
+}
+
 // View implements tea.Model.
 func (m ReviewOnlyModel) View() tea.View {
 	if m.quitting {
@@ -103,3 +103,28 @@ func TestReviewView_HasActiveEditor(t *testing.T) {
 
 	assert.False(t, v.HasActiveEditor(), "Should have no active editor initially")
 }
+
+// TestReviewOnly_InstantErrorReported verifies that failing to turn on
+// instant mode is recorded rather than dropped.
+func TestReviewOnly_InstantErrorReported(t *testing.T) {
+	dbConn, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, dbConn.Close())
+	}()
+
+	m := NewReviewOnly(ReviewOnlyOptions{DB: dbConn})
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = model.(ReviewOnlyModel)
+
+	model, cmd := m.Update(tea.KeyPressMsg{Text: "I", Code: 'I'})
+	m = model.(ReviewOnlyModel)
+	require.NotNil(t, cmd)
+	errMsg, ok := cmd().(review.ErrorMsg)
+	require.True(t, ok)
+	assert.ErrorContains(t, errMsg.Err, "instant mode")
+
+	model, _ = m.Update(errMsg)
+	m = model.(ReviewOnlyModel)
+	require.Len(t, m.Errors(), 1)
+}
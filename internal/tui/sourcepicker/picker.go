@@ -19,6 +19,7 @@ import (
 	"github.com/colonyops/hive/internal/core/styles"
 	"github.com/colonyops/hive/internal/sources"
 	"github.com/colonyops/hive/internal/tui/components"
+	"github.com/colonyops/hive/internal/tui/components/input"
 )
 
 // Fixed dialog sizing: the modal's overall width/height are a deterministic
@@ -145,20 +146,20 @@ type Result struct {
 // tab by source ID; if not found the first tab is used. dir is the local repo
 // working directory sources run their CLI in (empty when no local checkout).
 func New(tabSources []TabSource, initialTab, scope, dir string, width, height int) Picker {
-	input := textinput.New()
-	input.Placeholder = "search..."
-	input.Prompt = "/ "
+	ti := input.NewTextInput()
+	ti.Placeholder = "search..."
+	ti.Prompt = "/ "
 	inputStyles := textinput.DefaultStyles(true)
 	inputStyles.Focused.Prompt = styles.TextPrimaryStyle
 	inputStyles.Cursor.Color = styles.ColorPrimary
-	input.SetStyles(inputStyles)
+	ti.SetStyles(inputStyles)
 
 	s := spinner.New()
 	s.Spinner = spinner.Meter
 	s.Style = lipgloss.NewStyle().Foreground(styles.ColorPrimary)
 
 	modalWidth, modalHeight, contentWidth, innerW, contentHeight := computePickerDims(width, height)
-	input.SetWidth(max(innerW-4, 10))
+	ti.SetWidth(max(innerW-4, 10))
 
 	tabs := make([]tabState, len(tabSources))
 	activeIdx := 0
@@ -175,7 +176,7 @@ func New(tabSources []TabSource, initialTab, scope, dir string, width, height in
 		activeTab:     activeIdx,
 		scope:         scope,
 		dir:           dir,
-		input:         input,
+		input:         ti,
 		spinner:       s,
 		width:         width,
 		height:        height,
@@ -537,6 +538,8 @@ func browserOpenCmd(uri string) *exec.Cmd {
 	switch runtime.GOOS {
 	case "darwin":
 		return exec.Command("open", uri)
+	case "windows":
+		return exec.Command("cmd", "/c", "start", "", uri)
 	default:
 		return exec.Command("xdg-open", uri)
 	}
@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/rs/zerolog/log"
+
+	corekv "github.com/colonyops/hive/internal/core/kv"
+	"github.com/colonyops/hive/internal/core/terminal"
+)
+
+// kvUIStateKey is the kv store key for the UI state saved on quit.
+const kvUIStateKey = "tui.state"
+
+// uiState is the UI state persisted across TUI invocations when
+// tui.restore_state is enabled.
+type uiState struct {
+	View              string          `json:"view"`
+	SelectedSessionID string          `json:"selected_session_id,omitempty"`
+	StatusFilter      terminal.Status `json:"status_filter,omitempty"`
+	TagFilter         string          `json:"tag_filter,omitempty"`
+	PreviewEnabled    bool            `json:"preview_enabled"`
+	SelectedMessageID string          `json:"selected_message_id,omitempty"`
+	MessageScroll     int             `json:"message_scroll,omitempty"`
+}
+
+// restoreViewMsg switches to the view saved in the previous invocation.
+type restoreViewMsg struct {
+	view ViewType
+}
+
+// restorableViews lists the views that can be restored, in tab order.
+var restorableViews = []ViewType{ViewSessions, ViewBoard, ViewTasks, ViewMessages, ViewReview, ViewStore}
+
+// parseViewType returns the view named name, or false if there is none.
+func parseViewType(name string) (ViewType, bool) {
+	idx := slices.IndexFunc(restorableViews, func(v ViewType) bool { return v.String() == name })
+	if idx < 0 {
+		return ViewSessions, false
+	}
+	return restorableViews[idx], true
+}
+
+// loadUIState reads the saved UI state from store. It reports false without
+// an error when nothing has been saved yet.
+func loadUIState(store corekv.KV) (uiState, bool, error) {
+	if store == nil {
+		return uiState{}, false, nil
+	}
+	var state uiState
+	if err := store.Get(context.Background(), kvUIStateKey, &state); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uiState{}, false, nil
+		}
+		return uiState{}, false, fmt.Errorf("load tui state: %w", err)
+	}
+	return state, true, nil
+}
+
+// saveUIState writes the UI state to store.
+func saveUIState(store corekv.KV, state uiState) {
+	if store == nil {
+		return
+	}
+	if err := store.Set(context.Background(), kvUIStateKey, state); err != nil {
+		log.Debug().Err(err).Msg("failed to persist tui state")
+	}
+}
+
+// captureUIState snapshots the state worth restoring on the next launch.
+func (m Model) captureUIState() uiState {
+	state := uiState{View: m.activeView.String()}
+	if m.sessionsView != nil {
+		if sess := m.sessionsView.SelectedSession(); sess != nil {
+			state.SelectedSessionID = sess.ID
+		}
+		state.StatusFilter = m.sessionsView.StatusFilter()
+		state.TagFilter = m.sessionsView.TagFilter()
+		state.PreviewEnabled = m.sessionsView.PreviewEnabled()
+	}
+	if m.msgView != nil {
+		state.SelectedMessageID = m.msgView.SelectedMessageID()
+		state.MessageScroll = m.msgView.ScrollOffset()
+	}
+	return state
+}
+
+// applyUIState restores the saved state into the views. The active view is
+// switched by the returned command once the program starts, so the usual
+// data loads for that view run.
+func (m *Model) applyUIState(state uiState) tea.Cmd {
+	var cmds []tea.Cmd
+	if m.sessionsView != nil {
+		if state.SelectedSessionID != "" {
+			m.sessionsView.SelectOnNextRefresh(state.SelectedSessionID)
+		}
+		m.sessionsView.SetPreviewEnabled(state.PreviewEnabled)
+		if state.StatusFilter != "" {
+			cmds = append(cmds, m.sessionsView.SetStatusFilter(state.StatusFilter))
+		}
+		if state.TagFilter != "" {
+			cmds = append(cmds, m.sessionsView.SetTagFilter(state.TagFilter))
+		}
+	}
+	if m.msgView != nil {
+		if state.SelectedMessageID != "" {
+			m.msgView.SelectOnLoad(state.SelectedMessageID)
+		}
+		if state.MessageScroll > 0 {
+			m.msgView.ScrollOnLoad(state.MessageScroll)
+		}
+	}
+
+	view, ok := parseViewType(state.View)
+	if ok && view != ViewSessions && (view != ViewStore || m.cfg.TUI.Store) {
+		cmds = append(cmds, func() tea.Msg { return restoreViewMsg{view: view} })
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/internal/data/stores"
+)
+
+func TestUIStatePersistence(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+	store := stores.NewKVStore(database)
+
+	_, ok, err := loadUIState(store)
+	require.NoError(t, err)
+	assert.False(t, ok, "nothing saved yet")
+	_, ok, err = loadUIState(nil)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	want := uiState{
+		View:              "messages",
+		SelectedSessionID: "abc",
+		StatusFilter:      terminal.StatusApproval,
+		TagFilter:         "backend",
+		SelectedMessageID: "m1",
+		MessageScroll:     4,
+	}
+	saveUIState(store, want)
+
+	got, ok, err := loadUIState(store)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, want, got)
+
+	require.NoError(t, database.Close())
+	_, ok, err = loadUIState(store)
+	require.Error(t, err, "read failures are reported, not treated as no saved state")
+	assert.False(t, ok)
+}
+
+func TestApplyUIState(t *testing.T) {
+	m := newKeybindingPrecedenceModel(t, nil)
+
+	cmd := m.applyUIState(uiState{
+		View:           "messages",
+		StatusFilter:   terminal.StatusReady,
+		TagFilter:      "backend",
+		PreviewEnabled: false,
+	})
+	assert.Equal(t, terminal.StatusReady, m.sessionsView.StatusFilter())
+	assert.Equal(t, "backend", m.sessionsView.TagFilter())
+	assert.False(t, m.sessionsView.PreviewEnabled())
+
+	require.NotNil(t, cmd)
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		for _, c := range batch {
+			if c == nil {
+				continue
+			}
+			if restore, ok := c().(restoreViewMsg); ok {
+				msg = restore
+			}
+		}
+	}
+	assert.Equal(t, restoreViewMsg{view: ViewMessages}, msg)
+
+	updated, _ := m.Update(msg)
+	assert.Equal(t, ViewMessages, updated.(Model).activeView)
+
+	captured := updated.(Model).captureUIState()
+	assert.Equal(t, "messages", captured.View)
+	assert.Equal(t, terminal.StatusReady, captured.StatusFilter)
+	assert.Equal(t, "backend", captured.TagFilter)
+}
+
+func TestApplyUIState_SkipsUnavailableViews(t *testing.T) {
+	m := newKeybindingPrecedenceModel(t, nil)
+
+	assert.Nil(t, m.applyUIState(uiState{View: "sessions", PreviewEnabled: true}))
+	assert.Nil(t, m.applyUIState(uiState{View: "bogus", PreviewEnabled: true}))
+	assert.Nil(t, m.applyUIState(uiState{View: "store", PreviewEnabled: true}), "store tab is disabled by default")
+}
@@ -11,6 +11,7 @@ const (
 	ViewMessages
 	ViewReview
 	ViewStore
+	ViewBoard
 )
 
 // String returns the lowercase name of the view type for scope matching.
@@ -26,7 +27,18 @@ func (v ViewType) String() string {
 		return "review"
 	case ViewStore:
 		return "store"
+	case ViewBoard:
+		return "board"
 	default:
 		return unknownViewType
 	}
 }
+
+// scope returns the keybinding and command scope of the view. The board is
+// another layout of the sessions list, so it shares the sessions scope.
+func (v ViewType) scope() string {
+	if v == ViewBoard {
+		return ViewSessions.String()
+	}
+	return v.String()
+}
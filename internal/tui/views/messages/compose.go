@@ -0,0 +1,292 @@
+package messages
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/textarea"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/colonyops/hive/internal/core/messaging"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/styles"
+	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/internal/tui/components"
+	"github.com/colonyops/hive/internal/tui/components/input"
+)
+
+// composeSender identifies messages sent from the TUI.
+const composeSender = "hive-tui"
+
+// composeWidth is the width of the compose modal's inputs.
+const composeWidth = 56
+
+// composeField is the compose modal input that has focus.
+type composeField int
+
+const (
+	fieldTopic composeField = iota
+	fieldBody
+)
+
+// messageSentMsg reports the result of publishing a composed message.
+type messageSentMsg struct {
+	topics []string
+	err    error
+}
+
+// composeModal writes a new message, or a reply to an existing one. Keys:
+//   - Tab: Accept the suggested topic, or switch between topic and body
+//   - Enter: Move from the topic to the body; insert a newline in the body
+//   - Ctrl+S: Send
+//   - Esc: Cancel
+type composeModal struct {
+	topic textinput.Model
+	body  textarea.Model
+	focus composeField
+
+	// replyTo is the message being replied to, nil for a new message.
+	replyTo *messaging.Message
+	err     string
+}
+
+// newComposeModal returns a compose modal with the topic prefilled and known
+// topics offered as suggestions.
+func newComposeModal(topic string, topics []string) *composeModal {
+	ti := input.NewTextInput()
+	ti.Placeholder = "agent.<session-id>.inbox"
+	ti.CharLimit = 200
+	ti.SetWidth(composeWidth)
+	ti.ShowSuggestions = true
+	ti.SetSuggestions(topics)
+	ti.SetValue(topic)
+	ti.CursorEnd()
+
+	ta := input.NewTextArea()
+	ta.Placeholder = "Message..."
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 10000
+	ta.SetWidth(composeWidth)
+	ta.SetHeight(8)
+
+	c := &composeModal{topic: ti, body: ta}
+	if topic == "" {
+		c.focusField(fieldTopic)
+	} else {
+		c.focusField(fieldBody)
+	}
+	return c
+}
+
+// newReplyModal returns a compose modal answering msg on its reply topic.
+func newReplyModal(msg *messaging.Message, topics []string) *composeModal {
+	c := newComposeModal(replyTopic(msg), topics)
+	c.replyTo = msg
+	return c
+}
+
+// replyTopic returns the topic a reply to msg is sent to: the request's reply
+// topic, else the inbox of the session that sent it, else msg's own topic.
+func replyTopic(msg *messaging.Message) string {
+	switch {
+	case msg.ReplyTo != "":
+		return msg.ReplyTo
+	case msg.SessionID != "":
+		return (&session.Session{ID: msg.SessionID}).InboxTopic()
+	default:
+		return msg.Topic
+	}
+}
+
+// focusField moves focus to field.
+func (c *composeModal) focusField(field composeField) tea.Cmd {
+	c.focus = field
+	if field == fieldTopic {
+		c.body.Blur()
+		return c.topic.Focus()
+	}
+	c.topic.Blur()
+	return c.body.Focus()
+}
+
+// message returns the message to send. Replies carry the ID of the message
+// they answer as their correlation ID.
+func (c *composeModal) message() messaging.Message {
+	msg := messaging.Message{
+		Payload: c.body.Value(),
+		Sender:  composeSender,
+	}
+	if c.replyTo != nil {
+		msg.CorrelationID = c.replyTo.ID
+	}
+	return msg
+}
+
+// update handles a key, returning sent when the message should be sent and
+// closed when the modal should close without sending.
+func (c *composeModal) update(msg tea.KeyPressMsg) (cmd tea.Cmd, sent, closed bool) {
+	switch msg.String() {
+	case "esc":
+		return nil, false, true
+	case "ctrl+s":
+		switch {
+		case strings.TrimSpace(c.topic.Value()) == "":
+			c.err = "topic is required"
+			return c.focusField(fieldTopic), false, false
+		case strings.TrimSpace(c.body.Value()) == "":
+			c.err = "message is empty"
+			return c.focusField(fieldBody), false, false
+		}
+		return nil, true, false
+	case "tab":
+		if c.focus == fieldTopic && c.topic.CurrentSuggestion() != c.topic.Value() && len(c.topic.MatchedSuggestions()) > 0 {
+			break // let the input accept the suggestion
+		}
+		return c.focusField(1 - c.focus), false, false
+	case "shift+tab":
+		return c.focusField(1 - c.focus), false, false
+	case "enter":
+		if c.focus == fieldTopic {
+			return c.focusField(fieldBody), false, false
+		}
+	}
+
+	c.err = ""
+	if c.focus == fieldTopic {
+		c.topic, cmd = c.topic.Update(msg)
+	} else {
+		c.body, cmd = c.body.Update(msg)
+	}
+	return cmd, false, false
+}
+
+// view renders the modal.
+func (c *composeModal) view() string {
+	title := "New Message"
+	if c.replyTo != nil {
+		title = "Reply"
+	}
+
+	parts := []string{styles.ModalTitleStyle.Render(title), ""}
+	if c.replyTo != nil {
+		sender := c.replyTo.Sender
+		if sender == "" {
+			sender = unknownSender
+		}
+		parts = append(parts, styles.TextMutedStyle.Render("to "+sender+" "+iconDot+" correlation "+c.replyTo.ID), "")
+	}
+	parts = append(parts,
+		composeLabel("Topic", c.focus == fieldTopic),
+		c.topic.View(),
+		"",
+		composeLabel("Message", c.focus == fieldBody),
+		c.body.View(),
+	)
+	if c.err != "" {
+		parts = append(parts, styles.TextErrorStyle.Render(c.err))
+	}
+	parts = append(parts, "", styles.ModalHelpStyle.Render(components.KeyHints(
+		components.HelpEntry{Key: "ctrl+s", Desc: "send"},
+		components.HelpEntry{Key: "tab", Desc: "next field"},
+		components.HelpEntry{Key: "esc", Desc: "cancel"},
+	)))
+
+	return styles.ModalStyle.Width(composeWidth + 6).Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
+
+// composeLabel renders a field label, highlighted when the field has focus.
+func composeLabel(label string, focused bool) string {
+	if focused {
+		return styles.TextPrimaryBoldStyle.Render(label)
+	}
+	return styles.TextMutedStyle.Render(label)
+}
+
+// sendMessage publishes msg to topic.
+func sendMessage(svc *hive.MessageService, msg messaging.Message, topic string) tea.Cmd {
+	return func() tea.Msg {
+		if svc == nil {
+			return messageSentMsg{}
+		}
+		result, err := svc.Publish(context.Background(), msg, []string{topic})
+		return messageSentMsg{topics: result.Topics, err: err}
+	}
+}
+
+// knownTopics returns the topics of the loaded messages, plus extra, sorted
+// and without duplicates.
+func (v *View) knownTopics(extra ...string) []string {
+	topics := slices.Clone(extra)
+	for _, msg := range v.ctrl.Displayed() {
+		topics = append(topics, msg.Topic)
+	}
+	topics = slices.DeleteFunc(topics, func(t string) bool { return t == "" })
+	slices.Sort(topics)
+	return slices.Compact(topics)
+}
+
+// SetInboxTopic sets the function that returns the topic new messages are
+// addressed to by default, typically the selected session's inbox.
+func (v *View) SetInboxTopic(fn func() string) {
+	v.inboxTopic = fn
+}
+
+// openCompose opens the compose modal for a new message.
+func (v *View) openCompose() tea.Cmd {
+	topic := ""
+	if v.inboxTopic != nil {
+		topic = v.inboxTopic()
+	}
+	v.compose = newComposeModal(topic, v.knownTopics(topic))
+	v.copyStatus = ""
+	return v.compose.focusField(v.compose.focus)
+}
+
+// openReply opens the compose modal to reply to the selected message.
+func (v *View) openReply() tea.Cmd {
+	sel := v.ctrl.Selected()
+	if sel == nil {
+		return nil
+	}
+	reply := *sel
+	v.compose = newReplyModal(&reply, v.knownTopics(replyTopic(sel)))
+	v.copyStatus = ""
+	return v.compose.focusField(v.compose.focus)
+}
+
+// handleComposeKey routes a key to the compose modal, sending the message on
+// ctrl+s.
+func (v *View) handleComposeKey(msg tea.KeyPressMsg) tea.Cmd {
+	cmd, sent, closed := v.compose.update(msg)
+	switch {
+	case closed:
+		v.compose = nil
+	case sent:
+		c := v.compose
+		v.compose = nil
+		return sendMessage(v.msgStore, c.message(), strings.TrimSpace(c.topic.Value()))
+	}
+	return cmd
+}
+
+// handleMessageSent reports the result of sending a composed message.
+func (v *View) handleMessageSent(msg messageSentMsg) tea.Cmd {
+	if msg.err != nil {
+		v.copyStatus = "Send failed: " + msg.err.Error()
+		return nil
+	}
+	v.copyStatus = "Sent to " + strings.Join(msg.topics, ", ")
+	return nil
+}
+
+// composeOverlay renders the compose modal centered over bg.
+func (v *View) composeOverlay(bg string) string {
+	modal := v.compose.view()
+	bgLayer := lipgloss.NewLayer(bg)
+	modalLayer := lipgloss.NewLayer(modal)
+	modalLayer.X(max((v.width-lipgloss.Width(modal))/2, 0)).Y(max((v.height-lipgloss.Height(modal))/2, 0)).Z(1)
+	return lipgloss.NewCompositor(bgLayer, modalLayer).Render()
+}
@@ -0,0 +1,163 @@
+package messages
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/eventbus/testbus"
+	"github.com/colonyops/hive/internal/core/messaging"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/internal/data/stores"
+	"github.com/colonyops/hive/internal/hive"
+)
+
+// newComposeView returns a view backed by a message store and the service
+// publishing to it.
+func newComposeView(t *testing.T) (*View, *hive.MessageService) {
+	t.Helper()
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+
+	svc := hive.NewMessageService(stores.NewMessageStore(database, 0), &config.Config{}, testbus.New(t).EventBus)
+	v := New(svc, "*", "", 0)
+	v.SetSize(130, 30)
+	return v, svc
+}
+
+// typeText sends text to the view one key at a time.
+func typeText(v *View, text string) {
+	for _, r := range text {
+		v.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+}
+
+// send presses ctrl+s and delivers the resulting send to the view.
+func send(t *testing.T, v *View) {
+	t.Helper()
+	cmd := v.Update(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	require.NotNil(t, cmd, "ctrl+s sends the message")
+	v.Update(cmd())
+}
+
+func TestCompose_PrefillsInboxAndSends(t *testing.T) {
+	v, svc := newComposeView(t)
+	v.SetInboxTopic(func() string { return "agent.abc123.inbox" })
+
+	typeText(v, "m")
+	require.NotNil(t, v.compose)
+	assert.True(t, v.HasEditorFocus(), "compose captures keys")
+	assert.Equal(t, "agent.abc123.inbox", v.compose.topic.Value())
+	assert.Equal(t, fieldBody, v.compose.focus, "prefilled topic focuses the body")
+	assert.Contains(t, terminal.StripANSI(v.View()), "New Message")
+
+	typeText(v, "first")
+	v.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	typeText(v, "second")
+	send(t, v)
+	assert.Nil(t, v.compose)
+	assert.Equal(t, "Sent to agent.abc123.inbox", v.copyStatus)
+
+	got, err := svc.Subscribe(context.Background(), "agent.abc123.inbox", time.Time{})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "first\nsecond", got[0].Payload)
+	assert.Equal(t, composeSender, got[0].Sender)
+	assert.Empty(t, got[0].CorrelationID)
+}
+
+func TestCompose_RequiresTopicAndBody(t *testing.T) {
+	v, _ := newComposeView(t)
+
+	typeText(v, "m")
+	require.NotNil(t, v.compose)
+	assert.Equal(t, fieldTopic, v.compose.focus, "no inbox focuses the topic")
+
+	v.Update(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	require.NotNil(t, v.compose, "empty compose is not sent")
+	assert.Equal(t, "topic is required", v.compose.err)
+
+	typeText(v, "ops")
+	v.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Equal(t, fieldBody, v.compose.focus, "enter moves to the body")
+	v.Update(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	assert.Equal(t, "message is empty", v.compose.err)
+
+	v.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	assert.Nil(t, v.compose, "esc cancels")
+	assert.False(t, v.HasEditorFocus())
+}
+
+func TestCompose_TabAcceptsTopicSuggestion(t *testing.T) {
+	v, _ := newComposeView(t)
+	v.ctrl.Append([]messaging.Message{{ID: "m1", Topic: "deploy.status", Payload: "ok", CreatedAt: time.Now()}})
+
+	typeText(v, "m")
+	typeText(v, "dep")
+	v.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, "deploy.status", v.compose.topic.Value())
+	assert.Equal(t, fieldTopic, v.compose.focus)
+
+	v.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, fieldBody, v.compose.focus, "tab without a suggestion switches fields")
+}
+
+func TestReply(t *testing.T) {
+	tests := []struct {
+		name  string
+		msg   messaging.Message
+		topic string
+	}{
+		{
+			name:  "request",
+			msg:   messaging.Message{ID: "req1", Topic: "work", SessionID: "abc", ReplyTo: "reply.xyz"},
+			topic: "reply.xyz",
+		},
+		{
+			name:  "session message",
+			msg:   messaging.Message{ID: "msg1", Topic: "work", SessionID: "abc"},
+			topic: "agent.abc.inbox",
+		},
+		{
+			name:  "anonymous message",
+			msg:   messaging.Message{ID: "msg2", Topic: "work"},
+			topic: "work",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, svc := newComposeView(t)
+			tt.msg.Payload = "question"
+			tt.msg.CreatedAt = time.Now()
+			v.ctrl.Append([]messaging.Message{tt.msg})
+
+			typeText(v, "r")
+			require.NotNil(t, v.compose)
+			assert.Equal(t, tt.topic, v.compose.topic.Value())
+			assert.Contains(t, terminal.StripANSI(v.View()), "correlation "+tt.msg.ID)
+
+			typeText(v, "answer")
+			send(t, v)
+
+			got, err := svc.Subscribe(context.Background(), tt.topic, time.Time{})
+			require.NoError(t, err)
+			require.Len(t, got, 1)
+			assert.Equal(t, "answer", got[0].Payload)
+			assert.Equal(t, tt.msg.ID, got[0].CorrelationID, "replies are correlated with the message")
+		})
+	}
+}
+
+func TestReply_NoSelection(t *testing.T) {
+	v, _ := newComposeView(t)
+	typeText(v, "r")
+	assert.Nil(t, v.compose)
+}
@@ -142,6 +142,12 @@ func (c *Controller) Offset() int {
 	return c.offset
 }
 
+// ScrollTo sets the scroll offset, clamped so the cursor stays visible.
+func (c *Controller) ScrollTo(offset int, visibleLines int) {
+	c.offset = offset
+	c.clampOffset(visibleLines)
+}
+
 // SelectAt sets the cursor to idx and adjusts the offset to keep it visible.
 // idx is an index into filteredAt (the visible/filtered item list).
 func (c *Controller) SelectAt(idx int, visibleLines int) {
@@ -152,6 +158,18 @@ func (c *Controller) SelectAt(idx int, visibleLines int) {
 	c.clampOffset(visibleLines)
 }
 
+// SelectID moves the cursor to the visible message with the given ID and
+// reports whether it was found.
+func (c *Controller) SelectID(id string, visibleLines int) bool {
+	for i, idx := range c.filteredAt {
+		if idx < len(c.displayed) && c.displayed[idx].ID == id {
+			c.SelectAt(i, visibleLines)
+			return true
+		}
+	}
+	return false
+}
+
 // Len returns the total number of accumulated messages.
 func (c *Controller) Len() int {
 	return len(c.messages)
@@ -162,12 +180,28 @@ func (c *Controller) SetSize(visibleLines int) {
 	c.clampOffset(visibleLines)
 }
 
+// Leading qualifiers narrow the list before the free-text filter applies:
+// "topic:agent.*.inbox type:handoff hello" shows handoff messages on any
+// inbox containing "hello".
+const (
+	topicFilterPrefix = "topic:"
+	typeFilterPrefix  = "type:"
+)
+
 func (c *Controller) applyFilter() {
 	c.filteredAt = c.filteredAt[:0]
-	filter := strings.ToLower(c.filter)
+	pattern, msgType, text := splitFilter(c.filter)
+	text = strings.ToLower(text)
 
 	for i := range c.displayed {
-		if filter == "" || matchesFilter(&c.displayed[i], filter) {
+		msg := &c.displayed[i]
+		if pattern != "" && !messaging.MatchTopic(pattern, msg.Topic) {
+			continue
+		}
+		if msgType != "" && msg.Type != msgType {
+			continue
+		}
+		if text == "" || matchesFilter(msg, text) {
 			c.filteredAt = append(c.filteredAt, i)
 		}
 	}
@@ -177,9 +211,27 @@ func (c *Controller) applyFilter() {
 	}
 }
 
+// splitFilter separates leading "topic:<pattern>" and "type:<type>" tokens,
+// in either order, from the free-text part of filter.
+func splitFilter(filter string) (pattern, msgType, text string) {
+	text = filter
+	for {
+		token, rest, _ := strings.Cut(text, " ")
+		if p, ok := strings.CutPrefix(token, topicFilterPrefix); ok {
+			pattern = p
+		} else if t, ok := strings.CutPrefix(token, typeFilterPrefix); ok {
+			msgType = t
+		} else {
+			return pattern, msgType, strings.TrimSpace(text)
+		}
+		text = rest
+	}
+}
+
 func matchesFilter(msg *messaging.Message, filter string) bool {
 	return strings.Contains(strings.ToLower(msg.Topic), filter) ||
 		strings.Contains(strings.ToLower(msg.Sender), filter) ||
+		strings.Contains(strings.ToLower(msg.Type), filter) ||
 		strings.Contains(strings.ToLower(msg.Payload), filter)
 }
 
@@ -138,6 +138,66 @@ func TestController_Filter(t *testing.T) {
 		assert.False(t, c.IsFiltering())
 	})
 
+	t.Run("topic pattern", func(t *testing.T) {
+		c := NewController()
+		c.Append([]messaging.Message{
+			newMsg("agent.a.inbox", "alice", "hello"),
+			newMsg("agent.b.inbox", "bob", "help"),
+			newMsg("build.api.done", "ci", "hello"),
+		})
+
+		// Displayed newest first: build, agent.b, agent.a.
+		c.StartFilter()
+		for _, r := range "topic:agent.*.inbox" {
+			c.AddFilterRune(r)
+		}
+		assert.Equal(t, []int{1, 2}, c.FilteredAt())
+
+		for _, r := range " hello" {
+			c.AddFilterRune(r)
+		}
+		assert.Equal(t, []int{2}, c.FilteredAt())
+
+		c.CancelFilter()
+		c.StartFilter()
+		for _, r := range "topic:build.>" {
+			c.AddFilterRune(r)
+		}
+		assert.Equal(t, []int{0}, c.FilteredAt())
+	})
+
+	t.Run("type qualifier", func(t *testing.T) {
+		c := NewController()
+		handoff := newMsg("agent.a.inbox", "alice", "hello")
+		handoff.Type = "handoff"
+		c.Append([]messaging.Message{
+			handoff,
+			newMsg("agent.b.inbox", "bob", "hello"),
+			newMsg("build.api.done", "ci", "hello"),
+		})
+
+		// Displayed newest first: build, agent.b, agent.a.
+		c.StartFilter()
+		for _, r := range "type:handoff" {
+			c.AddFilterRune(r)
+		}
+		assert.Equal(t, []int{2}, c.FilteredAt())
+
+		c.CancelFilter()
+		c.StartFilter()
+		for _, r := range "topic:agent.*.inbox type:handoff hello" {
+			c.AddFilterRune(r)
+		}
+		assert.Equal(t, []int{2}, c.FilteredAt())
+
+		c.CancelFilter()
+		c.StartFilter()
+		for _, r := range "type:status" {
+			c.AddFilterRune(r)
+		}
+		assert.Empty(t, c.FilteredAt())
+	})
+
 	t.Run("delete rune narrows then widens", func(t *testing.T) {
 		c := NewController()
 		c.Append([]messaging.Message{
@@ -295,3 +355,36 @@ func TestController_SelectAt(t *testing.T) {
 		assert.NotNil(t, c.Selected())
 	})
 }
+
+func TestController_SelectID(t *testing.T) {
+	c := NewController()
+	first, second := newMsg("t1", "s1", "first"), newMsg("t2", "s2", "second")
+	first.ID, second.ID = "m1", "m2"
+	c.Append([]messaging.Message{first, second})
+
+	assert.True(t, c.SelectID("m1", 10))
+	assert.Equal(t, 1, c.Cursor())
+
+	assert.False(t, c.SelectID("missing", 10))
+	assert.Equal(t, 1, c.Cursor(), "cursor unchanged when ID is not found")
+}
+
+func TestController_ScrollTo(t *testing.T) {
+	c := NewController()
+	msgs := make([]messaging.Message, 10)
+	for i := range msgs {
+		msgs[i] = messaging.Message{ID: string(rune('a' + i))}
+	}
+	c.Append(msgs)
+
+	c.ScrollTo(3, 4)
+	assert.Equal(t, 0, c.Cursor())
+	assert.Equal(t, 0, c.Offset(), "offset is clamped so the cursor stays visible")
+
+	c.SelectAt(5, 4)
+	c.ScrollTo(3, 4)
+	assert.Equal(t, 3, c.Offset())
+
+	c.ScrollTo(20, 4)
+	assert.Equal(t, 5, c.Offset())
+}
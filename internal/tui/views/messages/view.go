@@ -1,9 +1,12 @@
 package messages
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os/exec"
 	"regexp"
 	"strings"
@@ -55,14 +58,14 @@ var builderPool = sync.Pool{
 
 // View is the Bubble Tea sub-model for the messages tab.
 type View struct {
-	ctrl         *Controller
-	msgStore     *hive.MessageService
-	lastPollTime time.Time
-	topicFilter  string
-	copyCommand  string
-	width        int
-	height       int
-	active       bool // true when this view is the active tab
+	ctrl        *Controller
+	msgStore    *hive.MessageService
+	cursor      messaging.Cursor // last seq loaded per topic
+	topicFilter string
+	copyCommand string
+	width       int
+	height      int
+	active      bool // true when this view is the active tab
 
 	// Split-pane state
 	focus      focusPane
@@ -72,6 +75,15 @@ type View struct {
 
 	// Cached selected message index for detecting changes
 	lastSelectedIdx int
+
+	// pendingSelectID is selected once a load includes that message.
+	pendingSelectID string
+	// pendingScroll is the list scroll offset restored on the next load.
+	pendingScroll int
+
+	// Compose state
+	compose    *composeModal // open compose modal, nil when closed
+	inboxTopic func() string // default topic for new messages
 }
 
 // New creates a new messages View.
@@ -79,6 +91,7 @@ func New(msgStore *hive.MessageService, topicFilter, copyCommand string, splitRa
 	return &View{
 		ctrl:            NewController(),
 		msgStore:        msgStore,
+		cursor:          make(messaging.Cursor),
 		topicFilter:     topicFilter,
 		copyCommand:     copyCommand,
 		splitRatio:      splitRatio,
@@ -92,7 +105,7 @@ func (v *View) Init() tea.Cmd {
 		return nil
 	}
 	return tea.Batch(
-		loadMessages(v.msgStore, v.topicFilter, time.Time{}),
+		loadMessages(v.msgStore, v.topicFilter, v.cursor),
 		schedulePollTick(),
 	)
 }
@@ -104,6 +117,8 @@ func (v *View) Update(msg tea.Msg) tea.Cmd {
 		return v.handleMessagesLoaded(msg)
 	case pollTickMsg:
 		return v.handlePollTick()
+	case messageSentMsg:
+		return v.handleMessageSent(msg)
 	case tea.KeyPressMsg:
 		return v.handleKey(msg)
 	}
@@ -112,15 +127,21 @@ func (v *View) Update(msg tea.Msg) tea.Cmd {
 
 // View renders the messages view.
 func (v *View) View() string {
+	var content string
 	if v.width >= minDualPaneWidth {
-		return v.renderDualColumnLayout()
+		content = v.renderDualColumnLayout()
+	} else {
+		content = v.renderCompactList()
 	}
-	return v.renderCompactList()
+	if v.compose != nil {
+		content = v.composeOverlay(content)
+	}
+	return content
 }
 
-// HasEditorFocus returns true if the filter input is active.
+// HasEditorFocus returns true if the filter input or compose modal is active.
 func (v *View) HasEditorFocus() bool {
-	return v.ctrl.IsFiltering()
+	return v.ctrl.IsFiltering() || v.compose != nil
 }
 
 // HasPreviewFocus returns true when the preview pane has focus.
@@ -171,6 +192,31 @@ func (v *View) SetActive(active bool) {
 	v.active = active
 }
 
+// SelectedMessageID returns the ID of the selected message, or "" if none.
+func (v *View) SelectedMessageID() string {
+	if msg := v.ctrl.Selected(); msg != nil {
+		return msg.ID
+	}
+	return ""
+}
+
+// ScrollOffset returns the scroll offset of the message list.
+func (v *View) ScrollOffset() int {
+	return v.ctrl.Offset()
+}
+
+// ScrollOnLoad arranges for the message list to be scrolled to offset once
+// the next load completes.
+func (v *View) ScrollOnLoad(offset int) {
+	v.pendingScroll = offset
+}
+
+// SelectOnLoad arranges for the message with the given ID to be selected
+// once it has been loaded.
+func (v *View) SelectOnLoad(id string) {
+	v.pendingSelectID = id
+}
+
 // HelpSections returns view-specific help sections for the help dialog.
 func (v *View) HelpSections() []components.HelpDialogSection {
 	return []components.HelpDialogSection{
@@ -185,6 +231,14 @@ func (v *View) HelpSections() []components.HelpDialogSection {
 				{Key: "esc", Desc: "back to list"},
 			},
 		},
+		{
+			Title: "Compose",
+			Entries: []components.HelpEntry{
+				{Key: "m", Desc: "new message"},
+				{Key: "r", Desc: "reply to message"},
+				{Key: "ctrl+s", Desc: "send"},
+			},
+		},
 	}
 }
 
@@ -199,15 +253,23 @@ func (v *View) handleMessagesLoaded(msg messagesLoadedMsg) tea.Cmd {
 	}
 	if len(msg.messages) > 0 {
 		v.ctrl.Append(msg.messages)
+		v.cursor.Advance(msg.messages)
+	}
+	if v.pendingSelectID != "" {
+		v.ctrl.SelectID(v.pendingSelectID, v.visibleLines())
+		v.pendingSelectID = ""
+	}
+	if v.pendingScroll > 0 {
+		v.ctrl.ScrollTo(v.pendingScroll, v.visibleLines())
+		v.pendingScroll = 0
 	}
-	v.lastPollTime = time.Now()
 	return nil
 }
 
 func (v *View) handlePollTick() tea.Cmd {
 	if v.active && v.msgStore != nil {
 		return tea.Batch(
-			loadMessages(v.msgStore, v.topicFilter, v.lastPollTime),
+			loadMessages(v.msgStore, v.topicFilter, v.cursor),
 			schedulePollTick(),
 		)
 	}
@@ -215,6 +277,11 @@ func (v *View) handlePollTick() tea.Cmd {
 }
 
 func (v *View) handleKey(msg tea.KeyPressMsg) tea.Cmd {
+	// The compose modal captures every key while open
+	if v.compose != nil {
+		return v.handleComposeKey(msg)
+	}
+
 	// Preview pane keys take priority when focused
 	if v.focus == panePreview {
 		return v.handlePreviewPaneKey(msg)
@@ -242,6 +309,10 @@ func (v *View) handlePreviewPaneKey(msg tea.KeyPressMsg) tea.Cmd {
 		v.viewport.ScrollUp(v.viewport.VisibleLineCount())
 	case "pgdown":
 		v.viewport.ScrollDown(v.viewport.VisibleLineCount())
+	case "m":
+		return v.openCompose()
+	case "r":
+		return v.openReply()
 	case "c", "y":
 		sel := v.ctrl.Selected()
 		if sel != nil {
@@ -289,6 +360,10 @@ func (v *View) handleNormalKey(msg tea.KeyPressMsg) tea.Cmd {
 		v.ctrl.MoveDown(v.visibleLines())
 	case "/":
 		v.ctrl.StartFilter()
+	case "m":
+		return v.openCompose()
+	case "r":
+		return v.openReply()
 	case "c", "y":
 		sel := v.ctrl.Selected()
 		if sel != nil {
@@ -438,6 +513,7 @@ func (v *View) dualPaneHelp() string {
 		}
 		return components.KeyHints(
 			components.HelpEntry{Key: "j/k", Desc: "scroll" + scrollInfo},
+			components.HelpEntry{Key: "r", Desc: "reply"},
 			components.HelpEntry{Key: "c", Desc: "copy"},
 			components.HelpEntry{Key: "esc", Desc: "back"},
 		)
@@ -446,6 +522,8 @@ func (v *View) dualPaneHelp() string {
 			components.HintNav,
 			components.HintFilter,
 			components.HelpEntry{Key: "enter", Desc: "preview"},
+			components.HelpEntry{Key: "m", Desc: "compose"},
+			components.HelpEntry{Key: "r", Desc: "reply"},
 		)
 	}
 }
@@ -594,12 +672,15 @@ func (v *View) renderPreviewPane(width, maxHeight int) string {
 	topicStr := styles.PreviewTopicStyle.Render(fmt.Sprintf("[%s]", sel.Topic))
 	senderStr := styles.TextSuccessStyle.Render(sender)
 	timeStr := styles.TextMutedStyle.Render(sel.CreatedAt.Format("2006-01-02 15:04:05"))
+	if sel.Type != "" {
+		topicStr += " " + styles.TextPrimaryBoldStyle.Render(sel.Type)
+	}
 	fmt.Fprintf(&b, "  %s %s %s %s", topicStr, senderStr, iconDot, timeStr)
 	b.WriteString("\n")
 
-	if sel.SessionID != "" {
-		sessionStr := styles.PreviewSessionStyle.Render(fmt.Sprintf("  session: %s", sel.SessionID))
-		b.WriteString(sessionStr)
+	meta := previewMeta(sel)
+	if meta != "" {
+		b.WriteString(styles.PreviewSessionStyle.Render("  " + meta))
 		b.WriteString("\n")
 	}
 
@@ -621,8 +702,8 @@ func (v *View) renderPreviewPane(width, maxHeight int) string {
 	b.WriteString(strings.Join(vpLines, "\n"))
 
 	// Calculate remaining lines for padding
-	headerLines := 3 // topic/sender line + session line + divider
-	if sel.SessionID == "" {
+	headerLines := 3 // topic/sender line + metadata line + divider
+	if meta == "" {
 		headerLines = 2
 	}
 	vpHeight := v.viewport.VisibleLineCount()
@@ -634,6 +715,19 @@ func (v *View) renderPreviewPane(width, maxHeight int) string {
 	return b.String()
 }
 
+// previewMeta returns the session and correlation ID of msg for the line
+// below the preview header, or "" if it has neither.
+func previewMeta(msg *messaging.Message) string {
+	var parts []string
+	if msg.SessionID != "" {
+		parts = append(parts, "session: "+msg.SessionID)
+	}
+	if msg.CorrelationID != "" {
+		parts = append(parts, "correlation: "+msg.CorrelationID)
+	}
+	return strings.Join(parts, "  ")
+}
+
 // updatePreviewContent re-renders the glamour markdown for the selected message.
 func (v *View) updatePreviewContent() {
 	sel := v.ctrl.Selected()
@@ -647,11 +741,24 @@ func (v *View) updatePreviewContent() {
 		contentWidth = 60
 	}
 
-	rendered := renderMarkdown(sel.Payload, contentWidth)
+	rendered := renderMarkdown(previewPayload(sel), contentWidth)
 	v.viewport.SetContent(rendered)
 	v.viewport.SetYOffset(0)
 }
 
+// previewPayload returns the markdown to preview for msg. JSON payloads are
+// indented and shown as a code block.
+func previewPayload(msg *messaging.Message) string {
+	if !msg.IsJSON() {
+		return msg.Payload
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, []byte(msg.Payload), "", "  "); err != nil {
+		return msg.Payload
+	}
+	return "```json\n" + indented.String() + "\n```"
+}
+
 // renderCompactList is the fallback single-column layout for narrow terminals.
 func (v *View) renderCompactList() string {
 	var b strings.Builder
@@ -726,7 +833,15 @@ func (v *View) renderCompactList() string {
 	}
 
 	bar := components.StatusBar{Width: v.width}
-	help := components.KeyHints(components.HintNav, components.HintFilter)
+	help := components.KeyHints(
+		components.HintNav,
+		components.HintFilter,
+		components.HelpEntry{Key: "m", Desc: "compose"},
+		components.HelpEntry{Key: "r", Desc: "reply"},
+	)
+	if v.copyStatus != "" {
+		help = styles.TextSuccessStyle.Render(v.copyStatus)
+	}
 	b.WriteString(bar.Rule())
 	b.WriteString("\n")
 	b.WriteString(bar.Render(help, ""))
@@ -940,7 +1055,10 @@ func formatAge(t time.Time) string {
 // Commands
 // --------------------------------------------------------------------
 
-func loadMessages(svc *hive.MessageService, topic string, since time.Time) tea.Cmd {
+// loadMessages reads messages past cursor. The cursor is copied because the
+// command runs off the update goroutine, which owns and advances it.
+func loadMessages(svc *hive.MessageService, topic string, cursor messaging.Cursor) tea.Cmd {
+	cursor = maps.Clone(cursor)
 	return func() tea.Msg {
 		if svc == nil {
 			return messagesLoadedMsg{err: nil}
@@ -948,7 +1066,7 @@ func loadMessages(svc *hive.MessageService, topic string, since time.Time) tea.C
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		messages, err := svc.Subscribe(ctx, topic, since)
+		messages, err := svc.SubscribeAfter(ctx, topic, cursor)
 		if err != nil {
 			if errors.Is(err, messaging.ErrTopicNotFound) {
 				return messagesLoadedMsg{messages: nil, err: nil}
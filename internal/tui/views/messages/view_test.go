@@ -6,12 +6,13 @@ import (
 	"time"
 
 	"github.com/colonyops/hive/internal/core/messaging"
+	"github.com/colonyops/hive/internal/core/terminal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestLoadMessages_NilService(t *testing.T) {
-	cmd := loadMessages(nil, "*", time.Time{})
+	cmd := loadMessages(nil, "*", messaging.Cursor{})
 	require.NotNil(t, cmd, "loadMessages with nil service returns a cmd")
 	msg := cmd()
 	loaded, ok := msg.(messagesLoadedMsg)
@@ -33,13 +34,13 @@ func TestHandleMessagesLoaded_WithError(t *testing.T) {
 func TestHandleMessagesLoaded_WithMessages(t *testing.T) {
 	v := New(nil, "*", "", 0)
 	msgs := []messaging.Message{
-		{Topic: "t", Sender: "s", Payload: "hello", CreatedAt: time.Now()},
+		{Topic: "t", Seq: 3, Sender: "s", Payload: "hello", CreatedAt: time.Now()},
 	}
 	msg := messagesLoadedMsg{messages: msgs}
 	cmd := v.handleMessagesLoaded(msg)
 	assert.Nil(t, cmd)
 	assert.Equal(t, 1, v.ctrl.Len())
-	assert.False(t, v.lastPollTime.IsZero(), "lastPollTime updated on success")
+	assert.Equal(t, int64(3), v.cursor["t"], "cursor advanced past loaded messages")
 }
 
 func TestHandleMessagesLoaded_EmptyMessages(t *testing.T) {
@@ -135,3 +136,22 @@ func TestView_SelectAtRow(t *testing.T) {
 		assert.Equal(t, 1, v.ctrl.Cursor())
 	})
 }
+
+func TestView_PreviewShowsEnvelope(t *testing.T) {
+	v := New(nil, "*", "", 0)
+	v.SetSize(160, 30)
+	v.ctrl.Append([]messaging.Message{{
+		Topic:         "agent.a.inbox",
+		Sender:        "s",
+		Type:          "handoff",
+		CorrelationID: "task-42",
+		ContentType:   messaging.ContentTypeJSON,
+		Payload:       `{"branch":"main"}`,
+		CreatedAt:     time.Now(),
+	}})
+
+	out := terminal.StripANSI(v.View())
+	assert.Contains(t, out, "handoff")
+	assert.Contains(t, out, "correlation: task-42")
+	assert.Contains(t, out, `"branch": "main"`, "JSON payloads are indented")
+}
@@ -0,0 +1,252 @@
+package review
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	corereview "github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/internal/core/styles"
+	"github.com/colonyops/hive/internal/data/stores"
+)
+
+// Annotations are personal notes on a document. Unlike comments they do not
+// belong to a review session: they are kept when a review is finalized or
+// discarded and never appear in feedback.
+
+// SetAnnotationStore persists annotations in store. Without a store,
+// annotations only last until the document is reloaded.
+func (v *View) SetAnnotationStore(store *stores.AnnotationStore) {
+	v.annotationStore = store
+}
+
+// ToggleAnnotations shows or hides annotations in the reader.
+func (v *View) ToggleAnnotations() {
+	v.hideAnnotations = !v.hideAnnotations
+	v.renderSelection()
+}
+
+// visibleAnnotations returns the selected document's annotations, or nil
+// while they are hidden.
+func (v *View) visibleAnnotations() []corereview.Annotation {
+	if v.hideAnnotations {
+		return nil
+	}
+	return v.annotations
+}
+
+// hasInlineNotes reports whether any comments or annotations are rendered
+// inline in the document.
+func (v *View) hasInlineNotes() bool {
+	return len(v.docComments()) > 0 || len(v.visibleAnnotations()) > 0
+}
+
+// loadAnnotations loads the document's annotations. Annotations saved against
+// different content are re-anchored to the document as it is now.
+func (v *View) loadAnnotations(doc *Document) {
+	v.annotations = nil
+	if v.annotationStore == nil {
+		return
+	}
+
+	ctx := context.Background()
+	annotations, err := v.annotationStore.ListAnnotations(ctx, doc.Path)
+	if err != nil {
+		log.Error().Err(err).Str("document", doc.RelPath).Msg("review: failed to load annotations")
+		return
+	}
+	v.annotations = annotations
+
+	contentHash, err := doc.contentHash()
+	if err != nil {
+		return
+	}
+	if err := v.reanchorAnnotations(ctx, doc, contentHash); err != nil {
+		log.Error().Err(err).Str("document", doc.RelPath).Msg("review: failed to re-anchor annotations")
+	}
+}
+
+// reanchorAnnotations moves annotations saved against other content to their
+// positions in the document's current content.
+func (v *View) reanchorAnnotations(ctx context.Context, doc *Document, contentHash string) error {
+	var stale []int
+	for i, a := range v.annotations {
+		if a.ContentHash != contentHash {
+			stale = append(stale, i)
+		}
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	// Annotations are anchored to rendered lines, so compare against the
+	// rendering of the content on disk
+	if err := doc.LoadContent(); err != nil {
+		return err
+	}
+	if _, err := doc.Render(v.docWidth()); err != nil {
+		return err
+	}
+
+	comments := make([]Comment, 0, len(stale))
+	for _, i := range stale {
+		comments = append(comments, annotationAsComment(v.annotations[i]))
+	}
+	moved := make([]corereview.Annotation, 0, len(stale))
+	for j, c := range ReanchorComments(comments, doc.RenderedLines) {
+		a := &v.annotations[stale[j]]
+		a.StartLine, a.EndLine = c.StartLine, c.EndLine
+		a.StartCol, a.EndCol = c.StartCol, c.EndCol
+		a.Outdated = c.Outdated
+		a.ContentHash = contentHash
+		moved = append(moved, *a)
+	}
+
+	log.Debug().
+		Str("document", doc.RelPath).
+		Int("annotations", len(moved)).
+		Msg("review: re-anchored annotations to changed document")
+
+	return v.annotationStore.ReanchorAnnotations(ctx, contentHash, moved)
+}
+
+// annotationAsComment returns the anchor of an annotation as a comment, so
+// annotations share the comment re-anchoring logic.
+func annotationAsComment(a corereview.Annotation) Comment {
+	return Comment{
+		ID:          a.ID,
+		StartLine:   a.StartLine,
+		EndLine:     a.EndLine,
+		StartCol:    a.StartCol,
+		EndCol:      a.EndCol,
+		Outdated:    a.Outdated,
+		ContextText: a.ContextText,
+	}
+}
+
+// annotationAtCursor returns the first visible annotation covering the
+// cursor line.
+func (v *View) annotationAtCursor() (corereview.Annotation, bool) {
+	for _, a := range v.visibleAnnotations() {
+		if v.cursorLine >= a.StartLine && v.cursorLine <= a.EndLine {
+			return a, true
+		}
+	}
+	return corereview.Annotation{}, false
+}
+
+// openAnnotationModal opens the annotation modal for the visual selection.
+func (v *View) openAnnotationModal() {
+	start, _, end, _ := v.selectionBounds()
+	modal := NewAnnotationModal(start, end, v.getSelectedText(), v.width, v.height)
+	v.commentModal = &modal
+	v.annotating = true
+}
+
+// editAnnotation opens the annotation modal pre-filled with a's note.
+func (v *View) editAnnotation(a corereview.Annotation) {
+	modal := NewAnnotationModal(a.StartLine, a.EndLine, a.ContextText, v.width, v.height)
+	modal.SetExistingComment(a.Note)
+	v.commentModal = &modal
+	v.annotating = true
+	v.editingAnnotationID = a.ID
+}
+
+// submitAnnotation saves the annotation modal's note, updating the annotation
+// being edited or adding one on the visual selection.
+func (v *View) submitAnnotation(note string) {
+	if v.editingAnnotationID != "" {
+		v.updateAnnotation(v.editingAnnotationID, note)
+	} else {
+		v.addAnnotation(note)
+	}
+	v.annotating = false
+	v.editingAnnotationID = ""
+}
+
+// addAnnotation adds an annotation on the visual selection. Persistence is
+// best-effort: the annotation is kept in memory if it cannot be saved.
+func (v *View) addAnnotation(note string) {
+	if v.selectedDoc == nil {
+		return
+	}
+
+	start, startCol, end, endCol := v.selectionBounds()
+	contentHash, _ := v.selectedDoc.contentHash()
+	a := corereview.Annotation{
+		ID:           uuid.NewString(),
+		DocumentPath: v.selectedDoc.Path,
+		ContentHash:  contentHash,
+		StartLine:    start,
+		EndLine:      end,
+		StartCol:     startCol,
+		EndCol:       endCol,
+		ContextText:  v.rangeText(start, startCol, end, endCol),
+		Note:         note,
+		CreatedAt:    time.Now(),
+	}
+
+	if v.annotationStore != nil {
+		if err := v.annotationStore.SaveAnnotation(context.Background(), a); err != nil {
+			log.Error().
+				Err(err).
+				Str("annotation_id", a.ID).
+				Msg("review: failed to save annotation to database - annotation will only exist in memory")
+		}
+	}
+	v.annotations = append(v.annotations, a)
+}
+
+// updateAnnotation replaces the note of an annotation.
+func (v *View) updateAnnotation(id, note string) {
+	for i := range v.annotations {
+		if v.annotations[i].ID != id {
+			continue
+		}
+		v.annotations[i].Note = note
+		if v.annotationStore != nil {
+			if err := v.annotationStore.UpdateAnnotation(context.Background(), v.annotations[i]); err != nil {
+				log.Error().
+					Err(err).
+					Str("annotation_id", id).
+					Msg("review: failed to update annotation in database")
+			}
+		}
+		return
+	}
+}
+
+// deleteAnnotation removes an annotation.
+func (v *View) deleteAnnotation(id string) {
+	for i, a := range v.annotations {
+		if a.ID != id {
+			continue
+		}
+		if v.annotationStore != nil {
+			if err := v.annotationStore.DeleteAnnotation(context.Background(), id); err != nil {
+				log.Error().
+					Err(err).
+					Str("annotation_id", id).
+					Msg("review: failed to delete annotation from database - may reappear on restart")
+			}
+		}
+		v.annotations = append(v.annotations[:i], v.annotations[i+1:]...)
+		return
+	}
+}
+
+// formatAnnotationLines renders an annotation as styled inline lines.
+func (v *View) formatAnnotationLines(a corereview.Annotation, contentWidth int) []string {
+	text := a.Note
+	if a.Outdated {
+		text = "(outdated) " + text
+	}
+	formatted := v.formatCommentLines(styles.IconNote, text, 7, contentWidth)
+	lines := make([]string, 0, len(formatted))
+	for _, line := range formatted {
+		lines = append(lines, styles.ReviewInlineAnnotationStyle.Render(line))
+	}
+	return lines
+}
@@ -0,0 +1,148 @@
+package review
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corereview "github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/internal/data/stores"
+)
+
+// newAnnotatedView opens a document in a view backed by a database.
+func newAnnotatedView(t *testing.T, content string) (View, *Document, *stores.AnnotationStore, *stores.ReviewStore) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	database, err := db.Open(tmpDir, db.DefaultOpenOptions())
+	require.NoError(t, err, "failed to open database")
+	t.Cleanup(func() { _ = database.Close() })
+
+	docPath := filepath.Join(tmpDir, "research.md")
+	require.NoError(t, os.WriteFile(docPath, []byte(content), 0o644))
+
+	reviews := stores.NewReviewStore(database)
+	annotations := stores.NewAnnotationStore(database)
+	doc := &Document{Path: docPath, RelPath: "research.md", Type: DocTypeResearch, ModTime: time.Now()}
+	v := New([]Document{*doc}, tmpDir, reviews, nil, 0)
+	v.SetAnnotationStore(annotations)
+	v.SetSize(100, 24)
+	v.loadDocument(doc)
+	return v, doc, annotations, reviews
+}
+
+// annotate adds an annotation on line through the visual mode "A" key.
+func annotate(t *testing.T, v View, line int, note string) View {
+	t.Helper()
+	v.cursorLine = line
+	v = pressKey(v, "V")
+	v = pressKey(v, "A")
+	require.NotNil(t, v.commentModal, "A opens the annotation modal in visual mode")
+	v.commentModal.SetExistingComment(note)
+	v, _ = v.Update(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	require.Nil(t, v.commentModal)
+	return v
+}
+
+func TestAnnotations(t *testing.T) {
+	v, doc, store, _ := newAnnotatedView(t, "one\n\ntwo\n\nthree\n")
+	v = annotate(t, v, 3, "remember this")
+	assert.False(t, v.selectionMode)
+
+	saved, err := store.ListAnnotations(context.Background(), doc.Path)
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, "remember this", saved[0].Note)
+	assert.Equal(t, 3, saved[0].StartLine)
+	assert.Nil(t, v.activeSession, "annotations do not start a review")
+
+	out := terminal.StripANSI(v.viewport.View())
+	assert.Contains(t, out, "remember this")
+
+	v.ToggleAnnotations()
+	out = terminal.StripANSI(v.viewport.View())
+	assert.NotContains(t, out, "remember this", "hidden annotations are not rendered")
+	v.cursorLine = 3
+	v = pressKey(v, "e")
+	assert.Nil(t, v.commentModal, "hidden annotations cannot be edited")
+	v.ToggleAnnotations()
+
+	v.cursorLine = 3
+	v = pressKey(v, "e")
+	require.NotNil(t, v.commentModal)
+	assert.Equal(t, "remember this", v.commentModal.Value())
+	v.commentModal.SetExistingComment("revised")
+	v, _ = v.Update(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	saved, err = store.ListAnnotations(context.Background(), doc.Path)
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, "revised", saved[0].Note)
+
+	v = pressKey(v, "d")
+	require.NotNil(t, v.confirmModal)
+	v, _ = v.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Empty(t, v.annotations)
+	saved, err = store.ListAnnotations(context.Background(), doc.Path)
+	require.NoError(t, err)
+	assert.Empty(t, saved)
+}
+
+func TestAnnotationsOutliveReviews(t *testing.T) {
+	v, doc, _, reviews := newAnnotatedView(t, "one\n\ntwo\n\nthree\n")
+	v = annotate(t, v, 1, "private note")
+
+	v.selectionMode = true
+	v.selectionStart, v.cursorLine = 5, 5
+	v.addComment("review comment", corereview.SeveritySuggestion)
+	require.NotNil(t, v.activeSession)
+
+	feedback := GenerateReviewFeedback(v.activeSession, doc.RelPath)
+	assert.Contains(t, feedback, "review comment")
+	assert.NotContains(t, feedback, "private note", "annotations are never feedback")
+
+	require.NoError(t, reviews.FinalizeSession(context.Background(), v.activeSession.ID))
+	v.activeSession = nil
+	v.loadDocument(doc)
+
+	assert.Nil(t, v.activeSession)
+	require.Len(t, v.annotations, 1, "annotations survive finalization")
+	assert.Contains(t, terminal.StripANSI(v.viewport.View()), "private note")
+}
+
+func TestAnnotationsReanchorOnDocumentChange(t *testing.T) {
+	v, doc, store, _ := newAnnotatedView(t, "Keep this paragraph.\n\nDrop this paragraph.\n")
+	lineOf := func(text string) int {
+		for i := range doc.RenderedLines {
+			if strings.Contains(string(doc.plainLine(i+1)), text) {
+				return i + 1
+			}
+		}
+		t.Fatalf("%q not rendered", text)
+		return 0
+	}
+	v = annotate(t, v, lineOf("Keep this"), "keep")
+
+	require.NoError(t, os.WriteFile(doc.Path, []byte("# Title\n\nIntro.\n\nKeep this paragraph.\n"), 0o644))
+	doc.Content = ""
+	v.loadDocument(doc)
+
+	require.Len(t, v.annotations, 1)
+	assert.Equal(t, lineOf("Keep this"), v.annotations[0].StartLine, "annotation follows its text")
+	assert.False(t, v.annotations[0].Outdated)
+
+	saved, err := store.ListAnnotations(context.Background(), doc.Path)
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, v.annotations[0].StartLine, saved[0].StartLine)
+	hash, err := doc.contentHash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, saved[0].ContentHash)
+}
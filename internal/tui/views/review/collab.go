@@ -0,0 +1,394 @@
+package review
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	corekv "github.com/colonyops/hive/internal/core/kv"
+	"github.com/colonyops/hive/internal/core/messaging"
+	corereview "github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/pkg/randid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	collabPollInterval = 2 * time.Second
+	collabHeartbeat    = time.Minute     // how often an open review refreshes its presence
+	collabPresenceTTL  = 3 * time.Minute // presence not refreshed for this long expires
+)
+
+// Review events published on a document's review topic when its comments
+// change.
+const (
+	ReviewEventComment   = "comment"
+	ReviewEventFinalized = "finalized"
+	ReviewEventDiscarded = "discarded"
+)
+
+// ReviewEvents carries review events between hive instances and agents.
+// *hive.MessageService implements it.
+type ReviewEvents interface {
+	Publish(ctx context.Context, msg messaging.Message, topics []string) (messaging.PublishResult, error)
+	SubscribeAfter(ctx context.Context, topic string, cursor messaging.Cursor) ([]messaging.Message, error)
+}
+
+// ReviewEvent is the JSON payload of a review topic message.
+type ReviewEvent struct {
+	Event     string `json:"event"`
+	Instance  string `json:"instance"` // identifies the publishing reviewer
+	Document  string `json:"document"`
+	SessionID string `json:"session_id,omitempty"`
+	Comments  int    `json:"comments"` // comments on the document after the event
+}
+
+// ReviewPresence is the KV value a reviewer keeps under PresenceKeyPrefix
+// while the document is open.
+type ReviewPresence struct {
+	Instance string `json:"instance"`
+	Document string `json:"document"`
+}
+
+// docHash returns the short hash identifying a document in topics and keys.
+func docHash(docPath string) string {
+	sum := sha256.Sum256([]byte(docPath))
+	return hex.EncodeToString(sum[:6])
+}
+
+// ReviewTopic returns the messaging topic for review events on a document:
+// review.<hash of the document path>.events.
+func ReviewTopic(docPath string) string {
+	return "review." + docHash(docPath) + ".events"
+}
+
+// PresenceKeyPrefix returns the prefix of the KV keys reviewers of a document
+// hold while it is open: review.presence.<hash of the document path>.
+func PresenceKeyPrefix(docPath string) string {
+	return "review.presence." + docHash(docPath) + "."
+}
+
+// CollabTickMsg triggers a poll of the open document's reviewers and events.
+type CollabTickMsg struct{}
+
+// CollabEventsMsg delivers the reviewers and review events read for a
+// document.
+type CollabEventsMsg struct {
+	topic     string
+	reviewers int
+	messages  []messaging.Message
+	err       error
+}
+
+// commentsRefreshedMsg delivers the active review of a document reloaded
+// after another reviewer changed it.
+type commentsRefreshedMsg struct {
+	path     string
+	session  corereview.Session
+	found    bool
+	comments []corereview.Comment
+	docs     []corereview.Document
+	err      error
+}
+
+// collab tracks other reviewers of the document open in this view.
+type collab struct {
+	events    ReviewEvents
+	presence  corekv.KV
+	instance  string
+	doc       string // document announced as open, "" when none
+	cursor    messaging.Cursor
+	reviewers int // other reviewers with doc open, as of the last poll
+	lastSent  time.Time
+}
+
+func newCollab(events ReviewEvents, presence corekv.KV) *collab {
+	return &collab{
+		events:   events,
+		presence: presence,
+		instance: randid.Generate(8),
+		cursor:   messaging.Cursor{},
+	}
+}
+
+// announce returns a command that records this reviewer as having doc open.
+func (c *collab) announce(doc string) tea.Cmd {
+	c.lastSent = time.Now()
+	presence, key := c.presence, PresenceKeyPrefix(doc)+c.instance
+	value := ReviewPresence{Instance: c.instance, Document: doc}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := presence.SetTTL(ctx, key, value, collabPresenceTTL); err != nil {
+			log.Debug().Err(err).Str("document", doc).Msg("review: failed to record presence")
+		}
+		return nil
+	}
+}
+
+// leave returns a command that removes this reviewer's presence on doc.
+func (c *collab) leave(doc string) tea.Cmd {
+	presence, key := c.presence, PresenceKeyPrefix(doc)+c.instance
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := presence.Delete(ctx, key); err != nil {
+			log.Debug().Err(err).Str("document", doc).Msg("review: failed to clear presence")
+		}
+		return nil
+	}
+}
+
+// publish returns a command that sends a review event for the announced
+// document.
+func (c *collab) publish(event, sessionID string, comments int) tea.Cmd {
+	if c.doc == "" {
+		return nil
+	}
+	payload, err := json.Marshal(ReviewEvent{
+		Event:     event,
+		Instance:  c.instance,
+		Document:  c.doc,
+		SessionID: sessionID,
+		Comments:  comments,
+	})
+	if err != nil {
+		log.Warn().Err(err).Str("event", event).Msg("review: failed to encode review event")
+		return nil
+	}
+
+	events, doc := c.events, c.doc
+	msg := messaging.Message{Payload: string(payload), Sender: "review:" + c.instance}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if _, err := events.Publish(ctx, msg, []string{ReviewTopic(doc)}); err != nil {
+			log.Debug().Err(err).Str("event", event).Str("document", doc).Msg("review: failed to publish review event")
+		}
+		return nil
+	}
+}
+
+// apply records the reviewers and events read by fetch. It reports whether
+// another reviewer changed the document's comments.
+func (c *collab) apply(msg CollabEventsMsg) bool {
+	c.reviewers = msg.reviewers
+	c.cursor.Advance(msg.messages)
+
+	changed := false
+	for _, m := range msg.messages {
+		var ev ReviewEvent
+		if err := json.Unmarshal([]byte(m.Payload), &ev); err != nil || ev.Instance == c.instance {
+			continue
+		}
+		switch ev.Event {
+		case ReviewEventComment, ReviewEventFinalized, ReviewEventDiscarded:
+			changed = true
+		}
+	}
+	return changed
+}
+
+// fetch reads the other reviewers' presence and the events past the cursor.
+// The cursor is copied because the command runs off the update goroutine,
+// which owns and advances it.
+func (c *collab) fetch() tea.Cmd {
+	if c.doc == "" {
+		return nil
+	}
+	events, presence, instance := c.events, c.presence, c.instance
+	topic, prefix, cursor := ReviewTopic(c.doc), PresenceKeyPrefix(c.doc), maps.Clone(c.cursor)
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		reviewers, err := countReviewers(ctx, presence, prefix, instance)
+		if err != nil {
+			return CollabEventsMsg{topic: topic, err: err}
+		}
+		msgs, err := events.SubscribeAfter(ctx, topic, cursor)
+		if errors.Is(err, messaging.ErrTopicNotFound) {
+			err = nil
+		}
+		return CollabEventsMsg{topic: topic, reviewers: reviewers, messages: msgs, err: err}
+	}
+}
+
+// countReviewers counts the unexpired presence keys under prefix other than
+// instance's own.
+func countReviewers(ctx context.Context, presence corekv.KV, prefix, instance string) (int, error) {
+	keys, err := presence.ListKeys(ctx)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, key := range keys {
+		if strings.HasPrefix(key, prefix) && key != prefix+instance {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func scheduleCollabTick() tea.Cmd {
+	return tea.Tick(collabPollInterval, func(time.Time) tea.Msg {
+		return CollabTickMsg{}
+	})
+}
+
+// SetReviewEvents enables live review presence: the view records the
+// document it has open in presence and shows how many other reviewers have
+// it open, and comment changes are announced on events. The same bus carries
+// instant-mode comments. Presence is disabled when either is nil.
+func (v *View) SetReviewEvents(events ReviewEvents, presence corekv.KV) {
+	v.instant.events = events
+	if events == nil || presence == nil {
+		v.collab = nil
+		return
+	}
+	v.collab = newCollab(events, presence)
+}
+
+// syncCollab records the document open in the reader, clearing the presence
+// on the previously open one, and refreshes the presence when it is due.
+func (v *View) syncCollab() tea.Cmd {
+	c := v.collab
+	if c == nil {
+		return nil
+	}
+
+	doc := ""
+	if v.fullScreen && v.selectedDoc != nil {
+		doc = v.selectedDoc.Path
+	}
+	if doc != c.doc {
+		var cmds []tea.Cmd
+		if c.doc != "" {
+			cmds = append(cmds, c.leave(c.doc))
+		}
+		c.doc = doc
+		c.cursor = messaging.Cursor{}
+		c.reviewers = 0
+		if doc != "" {
+			cmds = append(cmds, c.announce(doc))
+		}
+		return tea.Batch(cmds...)
+	}
+	if doc != "" && time.Since(c.lastSent) >= collabHeartbeat {
+		return c.announce(doc)
+	}
+	return nil
+}
+
+// publishReviewEvent announces a change to the open document's review.
+func (v *View) publishReviewEvent(event string) tea.Cmd {
+	if v.collab == nil {
+		return nil
+	}
+	sync := v.syncCollab()
+	sessionID := ""
+	if v.activeSession != nil {
+		sessionID = v.activeSession.ID
+	}
+	return tea.Batch(sync, v.collab.publish(event, sessionID, len(v.docComments())))
+}
+
+// handleCollabEvents applies the reviewers and events read for the open
+// document, reloading its comments when another reviewer changed them.
+func (v *View) handleCollabEvents(msg CollabEventsMsg) tea.Cmd {
+	c := v.collab
+	if c == nil || c.doc == "" || msg.topic != ReviewTopic(c.doc) {
+		return nil
+	}
+	if msg.err != nil {
+		log.Debug().Err(msg.err).Str("topic", msg.topic).Msg("review: failed to read review events")
+		return nil
+	}
+	if c.apply(msg) {
+		return v.refreshComments()
+	}
+	return nil
+}
+
+// refreshComments returns a command that reloads the active review of the
+// selected document from the store, picking up comments made by other
+// reviewers.
+func (v *View) refreshComments() tea.Cmd {
+	if v.store == nil || v.selectedDoc == nil {
+		return nil
+	}
+
+	store, path := v.store, v.selectedDoc.Path
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		msg := commentsRefreshedMsg{path: path}
+		dbSession, err := store.GetActiveSessionForDocument(ctx, path)
+		switch {
+		case errors.Is(err, corereview.ErrSessionNotFound):
+			return msg
+		case err != nil:
+			msg.err = err
+			return msg
+		case dbSession.IsFinalized():
+			return msg
+		}
+		msg.session, msg.found = dbSession, true
+		if msg.comments, err = store.ListComments(ctx, dbSession.ID); err != nil {
+			msg.err = err
+			return msg
+		}
+		if msg.docs, err = store.ListDocuments(ctx, dbSession.ID); err != nil {
+			msg.err = err
+		}
+		return msg
+	}
+}
+
+// handleCommentsRefreshed applies a review reloaded by refreshComments.
+func (v *View) handleCommentsRefreshed(msg commentsRefreshedMsg) {
+	if v.selectedDoc == nil || v.selectedDoc.Path != msg.path {
+		return
+	}
+	if msg.err != nil {
+		log.Debug().Err(msg.err).Str("document", msg.path).Msg("review: failed to reload comments")
+		return
+	}
+
+	if msg.found {
+		session := v.sessionFromStore(msg.session, msg.comments, msg.docs)
+		v.activeSession = session
+		v.currentReview = session
+	} else {
+		if v.activeSession != nil && v.currentReview == v.activeSession {
+			v.currentReview = nil
+		}
+		v.activeSession = nil
+	}
+
+	v.updateTreeItemCommentCount()
+	v.renderSelection()
+}
+
+// collabIndicator returns the "N other reviewers" label, or "" when the
+// document is not open elsewhere.
+func (v *View) collabIndicator() string {
+	if v.collab == nil || v.collab.doc == "" {
+		return ""
+	}
+	switch n := v.collab.reviewers; n {
+	case 0:
+		return ""
+	case 1:
+		return "1 other reviewer"
+	default:
+		return fmt.Sprintf("%d other reviewers", n)
+	}
+}
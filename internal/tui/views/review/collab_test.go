@@ -0,0 +1,158 @@
+package review
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/messaging"
+	corereview "github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/internal/data/stores"
+)
+
+// memEvents is an in-memory ReviewEvents shared by several views.
+type memEvents struct {
+	mu   sync.Mutex
+	msgs []messaging.Message
+}
+
+func (e *memEvents) Publish(_ context.Context, msg messaging.Message, topics []string) (messaging.PublishResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, topic := range topics {
+		msg.Topic = topic
+		msg.Seq = int64(len(e.msgs) + 1)
+		msg.CreatedAt = time.Now()
+		e.msgs = append(e.msgs, msg)
+	}
+	return messaging.PublishResult{Topics: topics}, nil
+}
+
+func (e *memEvents) SubscribeAfter(_ context.Context, topic string, cursor messaging.Cursor) ([]messaging.Message, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var out []messaging.Message
+	for _, msg := range e.msgs {
+		if msg.Topic == topic && msg.Seq > cursor[topic] {
+			out = append(out, msg)
+		}
+	}
+	if len(out) == 0 && cursor[topic] == 0 {
+		return nil, messaging.ErrTopicNotFound
+	}
+	return out, nil
+}
+
+func (e *memEvents) events(t *testing.T) []string {
+	t.Helper()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var out []string
+	for _, msg := range e.msgs {
+		var ev ReviewEvent
+		require.NoError(t, json.Unmarshal([]byte(msg.Payload), &ev))
+		out = append(out, ev.Event)
+	}
+	return out
+}
+
+// pollCollab runs one presence tick synchronously, without scheduling the
+// next tick.
+func pollCollab(t *testing.T, v View) View {
+	t.Helper()
+	runCmds(v.syncCollab())
+	return deliver(v, v.collab.fetch())
+}
+
+// deliver runs cmd and feeds the messages it produces back into v.
+func deliver(v View, cmd tea.Cmd) View {
+	for _, msg := range runCmds(cmd) {
+		if msg == nil {
+			continue
+		}
+		var next tea.Cmd
+		v, next = v.Update(msg)
+		v = deliver(v, next)
+	}
+	return v
+}
+
+func TestCollabPresence(t *testing.T) {
+	tmpDir := t.TempDir()
+	database, err := db.Open(tmpDir, db.DefaultOpenOptions())
+	require.NoError(t, err)
+	defer func() { _ = database.Close() }()
+	store := stores.NewReviewStore(database)
+	presence := stores.NewKVStore(database)
+
+	docPath := filepath.Join(tmpDir, "plan.md")
+	require.NoError(t, os.WriteFile(docPath, []byte("one\n\ntwo\n"), 0o644))
+
+	events := &memEvents{}
+	open := func() View {
+		doc := Document{Path: docPath, RelPath: "plan.md", Type: DocTypePlan, ModTime: time.Now()}
+		v := New([]Document{doc}, tmpDir, store, nil, 0)
+		v.SetReviewEvents(events, presence)
+		v.SetSize(80, 24)
+		v.loadDocument(&doc)
+		return v
+	}
+
+	alice := pollCollab(t, open())
+	assert.Empty(t, alice.collabIndicator(), "alone on the document")
+
+	bob := pollCollab(t, open())
+	assert.Equal(t, "1 other reviewer", bob.collabIndicator())
+	alice = pollCollab(t, alice)
+	assert.Equal(t, "1 other reviewer", alice.collabIndicator())
+	assert.Contains(t, alice.View(), "1 other reviewer")
+
+	bob.selectionMode = true
+	bob.selectionStart, bob.cursorLine = 1, 1
+	runCmds(bob.addComment("from bob", corereview.SeveritySuggestion))
+
+	alice = pollCollab(t, alice)
+	require.Len(t, alice.docComments(), 1, "comments from other reviewers appear live")
+	assert.Equal(t, "from bob", alice.docComments()[0].CommentText)
+
+	bob.exitFullScreen()
+	bob = pollCollab(t, bob)
+	alice = pollCollab(t, alice)
+	assert.Empty(t, alice.collabIndicator(), "closing the document ends presence")
+
+	assert.Equal(t, []string{"comment"}, events.events(t), "presence stays out of the message store")
+}
+
+func TestCollabPresenceExpires(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err)
+	defer func() { _ = database.Close() }()
+	presence := stores.NewKVStore(database)
+
+	prefix := PresenceKeyPrefix("/ctx/plans/plan.md")
+	require.NoError(t, presence.SetTTL(t.Context(), prefix+"gone", ReviewPresence{}, time.Millisecond))
+	require.NoError(t, presence.SetTTL(t.Context(), prefix+"here", ReviewPresence{}, time.Minute))
+	require.NoError(t, presence.SetTTL(t.Context(), prefix+"me", ReviewPresence{}, time.Minute))
+	require.NoError(t, presence.SetTTL(t.Context(), PresenceKeyPrefix("/other.md")+"elsewhere", ReviewPresence{}, time.Minute))
+	time.Sleep(5 * time.Millisecond)
+
+	n, err := countReviewers(t.Context(), presence, prefix, "me")
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestReviewTopic(t *testing.T) {
+	topic := ReviewTopic("/ctx/plans/plan.md")
+	assert.Regexp(t, `^review\.[0-9a-f]{12}\.events$`, topic)
+	assert.Equal(t, topic, ReviewTopic("/ctx/plans/plan.md"))
+	assert.NotEqual(t, topic, ReviewTopic("/ctx/plans/other.md"))
+}
@@ -4,7 +4,12 @@ import (
 	"strings"
 	"testing"
 
+	tea "charm.land/bubbletea/v2"
+
+	corereview "github.com/colonyops/hive/internal/core/review"
 	"github.com/colonyops/hive/internal/tui/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCommentModal_View(t *testing.T) {
@@ -70,3 +75,89 @@ func TestCommentModal_ViewLongMultiline(t *testing.T) {
 
 	testutil.RequireGolden(t, output)
 }
+
+func TestCommentModal_InsertReference(t *testing.T) {
+	modal := NewCommentModal(1, 1, "context", 80, 24)
+	modal.SetReferenceDocuments([]Document{
+		{Path: "/ctx/plans/auth.md", RelPath: "plans/auth.md"},
+		{Path: "/ctx/research/notes.md", RelPath: "research/notes.md"},
+	})
+	modal.SetExistingComment("See ")
+
+	press := func(msgs ...tea.Msg) {
+		for _, msg := range msgs {
+			modal, _ = modal.Update(msg)
+		}
+	}
+	typeText := func(s string) {
+		for _, r := range s {
+			press(tea.KeyPressMsg{Code: r, Text: string(r)})
+		}
+	}
+
+	assert.Contains(t, testutil.StripANSI(modal.View()), "ctrl+r reference")
+
+	press(tea.KeyPressMsg{Code: 'r', Mod: tea.ModCtrl})
+	require.NotNil(t, modal.refPicker, "ctrl+r opens the picker")
+
+	typeText("notes")
+	require.Len(t, modal.refPicker.matches, 1)
+	press(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, modal.refPicker.doc)
+
+	typeText("9-3")
+	press(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, modal.refPicker, "an invalid range keeps the picker open")
+	assert.Contains(t, testutil.StripANSI(modal.View()), "invalid range")
+
+	modal.refInput.SetValue("3-5")
+	press(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Nil(t, modal.refPicker)
+	assert.Equal(t, "See [[research/notes.md#L3-L5]]", modal.Value())
+	assert.False(t, modal.Cancelled())
+}
+
+func TestCommentModal_ReferencePickerEscape(t *testing.T) {
+	modal := NewCommentModal(1, 1, "context", 80, 24)
+	modal.SetReferenceDocuments([]Document{{Path: "/ctx/plans/auth.md", RelPath: "plans/auth.md"}})
+
+	modal, _ = modal.Update(tea.KeyPressMsg{Code: 'r', Mod: tea.ModCtrl})
+	modal, _ = modal.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, modal.refPicker.doc)
+
+	modal, _ = modal.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	require.NotNil(t, modal.refPicker, "esc steps back to the document list")
+	assert.Nil(t, modal.refPicker.doc)
+
+	modal, _ = modal.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	assert.Nil(t, modal.refPicker)
+	assert.False(t, modal.Cancelled(), "closing the picker keeps the comment open")
+	assert.Empty(t, modal.Value())
+}
+
+func TestCommentModal_NoReferenceDocuments(t *testing.T) {
+	modal := NewCommentModal(1, 1, "context", 80, 24)
+	modal, _ = modal.Update(tea.KeyPressMsg{Code: 'r', Mod: tea.ModCtrl})
+	assert.Nil(t, modal.refPicker)
+	assert.NotContains(t, testutil.StripANSI(modal.View()), "reference")
+}
+
+func TestCommentModal_CycleSeverity(t *testing.T) {
+	modal := NewCommentModal(1, 1, "context", 80, 24)
+	assert.Equal(t, corereview.SeveritySuggestion, modal.Severity())
+
+	modal, _ = modal.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, corereview.SeverityIssue, modal.Severity())
+	modal, _ = modal.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	modal, _ = modal.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, corereview.SeverityNit, modal.Severity(), "tab wraps around")
+	modal, _ = modal.Update(tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift})
+	assert.Equal(t, corereview.SeverityBlocker, modal.Severity())
+	assert.Empty(t, modal.Value(), "tab does not reach the text input")
+
+	modal.SetSeverity(corereview.SeverityNit)
+	assert.Equal(t, corereview.SeverityNit, modal.Severity())
+
+	imported := NewImportModal(80, 24)
+	assert.NotContains(t, testutil.StripANSI(imported.View()), "Severity")
+}
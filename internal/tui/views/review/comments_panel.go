@@ -0,0 +1,182 @@
+package review
+
+import (
+	"fmt"
+	"strings"
+
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/colonyops/hive/internal/core/styles"
+	"github.com/colonyops/hive/internal/tui/views/shared"
+)
+
+const (
+	// commentsPanelMinDocWidth is the narrowest the document may get before
+	// the comments panel is hidden.
+	commentsPanelMinDocWidth = 50
+	// commentsPanelRowsPerItem is the height of one comment: anchor, then text.
+	commentsPanelRowsPerItem = 2
+)
+
+// commentsPanel is the reader-mode side panel that lists the document's
+// comments. Its cursor follows the document cursor until the panel is
+// focused; moving it then moves the document to the selected comment.
+type commentsPanel struct {
+	visible bool
+	focused bool
+	cursor  int // index into sortedDocComments
+	scroll  int // first visible comment
+}
+
+// ToggleComments shows or hides the comments panel in reader mode.
+func (v *View) ToggleComments() {
+	v.comments.visible = !v.comments.visible
+	v.comments.focused = false
+	v.resizeReader()
+}
+
+// CommentsPanelOpen reports whether the comments panel is on screen, in which
+// case tab switches focus between it and the document.
+func (v *View) CommentsPanelOpen() bool {
+	return v.comments.visible && v.fullScreen && !v.showTree && v.selectedDoc != nil &&
+		v.width-commentsPanelWidth(v.width)-1 >= commentsPanelMinDocWidth
+}
+
+// commentsPanelWidth returns the panel width for a view width.
+func commentsPanelWidth(width int) int {
+	return min(max(width*30/100, 30), 50)
+}
+
+// docWidth returns the width the document renders at in reader mode.
+func (v *View) docWidth() int {
+	if v.CommentsPanelOpen() {
+		return v.width - commentsPanelWidth(v.width) - 1
+	}
+	return v.width
+}
+
+// resizeReader re-renders the open document after the reader width changes.
+func (v *View) resizeReader() {
+	if !v.fullScreen || v.selectedDoc == nil {
+		return
+	}
+	v.viewport.SetWidth(v.docWidth())
+	v.renderSelection()
+	v.ensureCursorVisible()
+}
+
+// commentsPanelRows returns how many comments fit in the panel below its
+// header line.
+func (v *View) commentsPanelRows() int {
+	contentHeight := max(v.height-2, 1)
+	return max((contentHeight-1)/commentsPanelRowsPerItem, 1)
+}
+
+// syncCommentsPanel points the panel cursor at the comment under the document
+// cursor, or the last comment above it, while the document has focus.
+func (v *View) syncCommentsPanel() {
+	if !v.comments.visible || v.comments.focused {
+		return
+	}
+	comments := v.sortedDocComments()
+	v.comments.cursor = 0
+	for i, c := range comments {
+		if c.StartLine > v.cursorLine {
+			break
+		}
+		v.comments.cursor = i
+	}
+	v.clampCommentsScroll(len(comments))
+}
+
+// moveCommentsCursor moves the panel selection by delta and scrolls the
+// document to the selected comment.
+func (v *View) moveCommentsCursor(delta int) {
+	comments := v.sortedDocComments()
+	if len(comments) == 0 {
+		return
+	}
+	v.comments.cursor = min(max(v.comments.cursor+delta, 0), len(comments)-1)
+	v.clampCommentsScroll(len(comments))
+	v.cursorLine = comments[v.comments.cursor].StartLine
+	v.centerCursorInViewport()
+	v.renderSelection()
+}
+
+// clampCommentsScroll keeps the panel cursor within the visible rows.
+func (v *View) clampCommentsScroll(total int) {
+	rows := v.commentsPanelRows()
+	v.comments.cursor = min(max(v.comments.cursor, 0), max(total-1, 0))
+	if v.comments.cursor < v.comments.scroll {
+		v.comments.scroll = v.comments.cursor
+	}
+	if v.comments.cursor >= v.comments.scroll+rows {
+		v.comments.scroll = v.comments.cursor - rows + 1
+	}
+	v.comments.scroll = min(max(v.comments.scroll, 0), max(total-rows, 0))
+}
+
+// handleCommentsPanelKey handles keys while the comments panel has focus.
+// Keys it does not use fall through to the reader, where e and d act on the
+// selected comment because the document cursor sits on it.
+func (v *View) handleCommentsPanelKey(key string) bool {
+	switch key {
+	case "tab", "esc":
+		v.comments.focused = false
+		v.renderSelection()
+	case "j", "down":
+		v.moveCommentsCursor(1)
+	case "k", "up":
+		v.moveCommentsCursor(-1)
+	case "g":
+		v.moveCommentsCursor(-v.comments.cursor)
+	case "G":
+		v.moveCommentsCursor(len(v.docComments()))
+	case keyEnter:
+		// Jump and hand focus back to the document
+		v.moveCommentsCursor(0)
+		v.comments.focused = false
+	default:
+		return false
+	}
+	return true
+}
+
+// renderCommentsPanel renders the comment list at the given size.
+func (v View) renderCommentsPanel(width, height int) string {
+	comments := v.sortedDocComments()
+
+	titleStyle := styles.TextMutedStyle
+	if v.comments.focused {
+		titleStyle = styles.TextPrimaryStyle
+	}
+	lines := []string{" " + titleStyle.Render(fmt.Sprintf("Comments (%d)", len(comments)))}
+
+	if len(comments) == 0 {
+		lines = append(lines, "", " "+styles.TextMutedStyle.Render("Select lines with V, then c"))
+	}
+
+	textWidth := max(width-4, 1)
+	end := min(v.comments.scroll+v.commentsPanelRows(), len(comments))
+	for i := v.comments.scroll; i < end; i++ {
+		c := comments[i]
+		prefix := "  "
+		anchorStyle := styles.TextMutedStyle
+		if i == v.comments.cursor {
+			prefix = styles.TextPrimaryStyle.Render("┃") + " "
+			anchorStyle = styles.TextPrimaryStyle
+		}
+
+		severity := lipgloss.NewStyle().Foreground(severityColor(c.Severity)).Render(c.Severity.Label())
+		anchor := anchorStyle.Render(commentAnchor(c)) + " " + severity
+		text, _, _ := strings.Cut(strings.TrimSpace(renderDocRefMarkers(c.CommentText)), "\n")
+		lines = append(lines,
+			prefix+anchor,
+			prefix+"  "+ansi.Truncate(text, textWidth, "…"),
+		)
+	}
+
+	content := shared.EnsureExactHeight(strings.Join(lines, "\n"), height)
+	return shared.EnsureExactWidth(content, width)
+}
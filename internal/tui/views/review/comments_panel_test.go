@@ -0,0 +1,90 @@
+package review
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corereview "github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/internal/core/terminal"
+)
+
+// newCommentedView opens a document with comments on lines 1 and 5.
+func newCommentedView(t *testing.T, width int) View {
+	t.Helper()
+	tmpDir := t.TempDir()
+	docPath := filepath.Join(tmpDir, "plan.md")
+	require.NoError(t, os.WriteFile(docPath, []byte("one\n\ntwo\n\nthree\n\nfour\n"), 0o644))
+
+	doc := Document{Path: docPath, RelPath: "plan.md", Type: DocTypePlan, ModTime: time.Now()}
+	v := New([]Document{doc}, tmpDir, nil, nil, 0)
+	v.SetSize(width, 24)
+	v.loadDocument(&doc)
+
+	for _, line := range []int{5, 1} {
+		v.selectionMode = true
+		v.selectionStart, v.cursorLine = line, line
+		v.addComment("note on "+strings.Repeat("x", line), corereview.SeveritySuggestion)
+	}
+	v.selectionMode = false
+	v.cursorLine = 1
+	v.renderSelection()
+	return v
+}
+
+func pressKey(v View, key string) View {
+	msg := tea.KeyPressMsg{Code: []rune(key)[0], Text: key}
+	if key == "tab" {
+		msg = tea.KeyPressMsg{Code: tea.KeyTab}
+	} else if key == keyEnter {
+		msg = tea.KeyPressMsg{Code: tea.KeyEnter}
+	}
+	v, _ = v.Update(msg)
+	return v
+}
+
+func TestCommentsPanel(t *testing.T) {
+	v := newCommentedView(t, 140)
+	require.False(t, v.CommentsPanelOpen())
+
+	v.ToggleComments()
+	require.True(t, v.CommentsPanelOpen())
+	assert.Equal(t, 140-commentsPanelWidth(140)-1, v.docWidth())
+
+	out := terminal.StripANSI(v.View())
+	assert.Contains(t, out, "Comments (2)")
+	assert.Contains(t, out, "note on x")
+
+	v = pressKey(v, "tab")
+	require.True(t, v.comments.focused)
+	assert.Equal(t, 0, v.comments.cursor)
+
+	v = pressKey(v, "j")
+	assert.Equal(t, 1, v.comments.cursor)
+	assert.Equal(t, 5, v.cursorLine, "selecting a comment moves the document to it")
+
+	v = pressKey(v, keyEnter)
+	assert.False(t, v.comments.focused, "enter returns focus to the document")
+	assert.Equal(t, 5, v.cursorLine)
+
+	v = pressKey(v, "k")
+	assert.Equal(t, 4, v.cursorLine, "j/k move the document cursor once it has focus")
+	assert.Equal(t, 0, v.comments.cursor, "the panel follows the document cursor")
+
+	v.ToggleComments()
+	assert.False(t, v.CommentsPanelOpen())
+	assert.Equal(t, 140, v.docWidth())
+}
+
+func TestCommentsPanel_TooNarrow(t *testing.T) {
+	v := newCommentedView(t, 70)
+	v.ToggleComments()
+	assert.False(t, v.CommentsPanelOpen(), "the document keeps the full width on narrow terminals")
+	assert.Equal(t, 70, v.docWidth())
+}
@@ -0,0 +1,76 @@
+package review
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// contentHashes caches document content hashes so rebuilding the tree and
+// reloading documents does not re-read every file. A hash is reused while
+// the file's modification time and size are unchanged.
+var contentHashes = hashCache{entries: make(map[string]hashEntry)}
+
+type hashEntry struct {
+	modTime time.Time
+	size    int64
+	hash    string
+}
+
+type hashCache struct {
+	mu      sync.Mutex
+	entries map[string]hashEntry
+}
+
+func (c *hashCache) get(path string, info os.FileInfo) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[path]
+	if !ok || e.size != info.Size() || !e.modTime.Equal(info.ModTime()) {
+		return "", false
+	}
+	return e.hash, true
+}
+
+func (c *hashCache) put(path string, info os.FileInfo, hash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[path] = hashEntry{modTime: info.ModTime(), size: info.Size(), hash: hash}
+}
+
+func (c *hashCache) forget(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, path)
+}
+
+// calculateContentHash computes the SHA256 hash of file content, streaming
+// the file rather than reading it into memory. The result is cached by
+// path, modification time, and size.
+func calculateContentHash(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		contentHashes.forget(path)
+		return "", err
+	}
+	if hash, ok := contentHashes.get(path, info); ok {
+		return hash, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	hash := hex.EncodeToString(h.Sum(nil))
+	contentHashes.put(path, info, hash)
+	return hash, nil
+}
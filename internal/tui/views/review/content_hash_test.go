@@ -0,0 +1,53 @@
+package review
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateContentHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.md")
+	modTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	write := func(content string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	sum := func(content string) string {
+		h := sha256.Sum256([]byte(content))
+		return hex.EncodeToString(h[:])
+	}
+
+	write("# Plan\n")
+	hash, err := calculateContentHash(path)
+	require.NoError(t, err)
+	assert.Equal(t, sum("# Plan\n"), hash)
+
+	// Same size and modification time: the cached hash is reused.
+	write("# Plam\n")
+	hash, err = calculateContentHash(path)
+	require.NoError(t, err)
+	assert.Equal(t, sum("# Plan\n"), hash)
+
+	write("# Plan v2\n")
+	hash, err = calculateContentHash(path)
+	require.NoError(t, err)
+	assert.Equal(t, sum("# Plan v2\n"), hash, "size change invalidates the cache")
+
+	modTime = modTime.Add(time.Second)
+	write("# Plan v3\n")
+	hash, err = calculateContentHash(path)
+	require.NoError(t, err)
+	assert.Equal(t, sum("# Plan v3\n"), hash, "modification time change invalidates the cache")
+
+	require.NoError(t, os.Remove(path))
+	_, err = calculateContentHash(path)
+	assert.Error(t, err)
+}
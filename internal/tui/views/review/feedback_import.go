@@ -0,0 +1,154 @@
+package review
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	corereview "github.com/colonyops/hive/internal/core/review"
+)
+
+// importedComment is a comment parsed from pasted feedback.
+type importedComment struct {
+	Document  string // Document the feedback names; empty for the simple format
+	StartLine int
+	EndLine   int
+	StartCol  int // 0 for comments on whole lines
+	EndCol    int
+	Severity  corereview.Severity // Empty unless the anchor was tagged with one
+	Text      string
+}
+
+var (
+	// shortAnchorPattern matches the simple format: "L12-L20: text" or "L5: text".
+	shortAnchorPattern = regexp.MustCompile(`^L(\d+)(?:-L?(\d+))?:\s*(.*)$`)
+	// lineAnchorPattern matches "Line 5:" and "Line 5 (cols 3-9):", with the
+	// " at 2006-01-02 15:04" timestamp of timed feedback.
+	lineAnchorPattern = regexp.MustCompile(`^Line (\d+)(?: \(cols (\d+)-(\d+)\))?(?: \(outdated\))?(?: at \d{4}-\d{2}-\d{2} \d{2}:\d{2})?:$`)
+	// linesAnchorPattern matches "Lines 10-15:" and "Lines 10:3-12:8:".
+	linesAnchorPattern = regexp.MustCompile(`^Lines (\d+)(?::(\d+))?-(\d+)(?::(\d+))?(?: \(outdated\))?(?: at \d{4}-\d{2}-\d{2} \d{2}:\d{2})?:$`)
+	// severityTagPattern matches the "[blocker] " tag finalized feedback puts
+	// before an anchor.
+	severityTagPattern = regexp.MustCompile(`^\[(\w+)\] `)
+	// documentHeaderPattern matches the "Document: <path>" section header.
+	documentHeaderPattern = regexp.MustCompile(`^Document: (.+)$`)
+)
+
+// parseFeedback parses feedback written outside hive back into comments. It
+// accepts the simple format, one comment per anchor with continuation lines
+// until the next anchor:
+//
+//	L12-L20: comment text
+//	L5: comment text
+//
+// and the format produced by GenerateReviewFeedback, where quoted context
+// lines ("> ...") are dropped, the severity tag before an anchor is kept, and
+// the "Document:" headers of multi-document feedback are recorded on each
+// comment. Text before the first anchor, such as
+// general notes, is ignored.
+func parseFeedback(text string) []importedComment {
+	var (
+		comments  []importedComment
+		current   *importedComment
+		body      []string
+		inContext bool // Still reading the quoted context after an anchor
+		document  string
+	)
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.Text = strings.TrimSpace(strings.Join(body, "\n"))
+		if current.Text != "" {
+			comments = append(comments, *current)
+		}
+		current, body = nil, nil
+	}
+
+	for line := range strings.SplitSeq(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if m := documentHeaderPattern.FindStringSubmatch(trimmed); m != nil {
+			flush()
+			document = strings.TrimSpace(m[1])
+			continue
+		}
+		if trimmed == "---" {
+			flush()
+			continue
+		}
+
+		if c, first, ok := parseAnchor(trimmed); ok {
+			flush()
+			c.Document = document
+			current = &c
+			if first != "" {
+				body = append(body, first)
+			}
+			inContext = first == ""
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+		if inContext && (line == ">" || strings.HasPrefix(line, "> ")) {
+			continue
+		}
+		inContext = false
+		body = append(body, line)
+	}
+	flush()
+
+	return comments
+}
+
+// parseAnchor parses a comment anchor line, optionally tagged with a
+// severity. It returns the text following the anchor on the same line, which
+// only the simple format has.
+func parseAnchor(line string) (importedComment, string, bool) {
+	if m := severityTagPattern.FindStringSubmatch(line); m != nil {
+		if sev, ok := corereview.ParseSeverity(m[1]); ok {
+			c, first, ok := parseAnchor(line[len(m[0]):])
+			c.Severity = sev
+			return c, first, ok
+		}
+	}
+
+	if m := shortAnchorPattern.FindStringSubmatch(line); m != nil {
+		start := atoi(m[1])
+		end := start
+		if m[2] != "" {
+			end = atoi(m[2])
+		}
+		if start < 1 || end < start {
+			return importedComment{}, "", false
+		}
+		return importedComment{StartLine: start, EndLine: end}, m[3], true
+	}
+
+	if m := lineAnchorPattern.FindStringSubmatch(line); m != nil {
+		c := importedComment{StartLine: atoi(m[1]), EndLine: atoi(m[1])}
+		if m[2] != "" {
+			c.StartCol, c.EndCol = atoi(m[2]), atoi(m[3])
+		}
+		return c, "", c.StartLine > 0
+	}
+
+	if m := linesAnchorPattern.FindStringSubmatch(line); m != nil {
+		c := importedComment{StartLine: atoi(m[1]), EndLine: atoi(m[3])}
+		// Columns are only meaningful when both ends have one
+		if m[2] != "" && m[4] != "" {
+			c.StartCol, c.EndCol = atoi(m[2]), atoi(m[4])
+		}
+		return c, "", c.StartLine > 0 && c.EndLine >= c.StartLine
+	}
+
+	return importedComment{}, "", false
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,84 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corereview "github.com/colonyops/hive/internal/core/review"
+)
+
+func TestParseFeedback(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []importedComment
+	}{
+		{
+			name: "empty",
+			text: "",
+			want: nil,
+		},
+		{
+			name: "simple format",
+			text: "L12-L20: Split this section\ninto two steps.\nL5: Typo\n\nL7-9: Range without second L",
+			want: []importedComment{
+				{StartLine: 12, EndLine: 20, Text: "Split this section\ninto two steps."},
+				{StartLine: 5, EndLine: 5, Text: "Typo"},
+				{StartLine: 7, EndLine: 9, Text: "Range without second L"},
+			},
+		},
+		{
+			name: "simple format ignores preamble and empty comments",
+			text: "Hi, some notes on the plan:\n\nL3:\nL4: Keep",
+			want: []importedComment{
+				{StartLine: 4, EndLine: 4, Text: "Keep"},
+			},
+		},
+		{
+			name: "generated format",
+			text: "General Notes:\nLooks good overall\n\n---\n\n" +
+				"Document: plans/test.md\nComments: 3\n\n" +
+				"Line 5:\n> context\nFix this\n\n" +
+				"Lines 10-12:\n> a\n> \n> b\nFirst paragraph\n\nSecond paragraph\n\n" +
+				"Line 14 (cols 3-9) (outdated):\n> phrase\nReword\n",
+			want: []importedComment{
+				{Document: "plans/test.md", StartLine: 5, EndLine: 5, Text: "Fix this"},
+				{Document: "plans/test.md", StartLine: 10, EndLine: 12, Text: "First paragraph\n\nSecond paragraph"},
+				{Document: "plans/test.md", StartLine: 14, EndLine: 14, StartCol: 3, EndCol: 9, Text: "Reword"},
+			},
+		},
+		{
+			name: "generated multi-document format",
+			text: "Documents: 2\nComments: 2\n\n---\n\n" +
+				"Document: plans/a.md\nComments: 1\n\nLines 2:4-3:6:\n> span\nOne\n\n---\n\n" +
+				"Document: plans/b.md\nComments: 1\n\nLine 1:\nTwo\n",
+			want: []importedComment{
+				{Document: "plans/a.md", StartLine: 2, EndLine: 3, StartCol: 4, EndCol: 6, Text: "One"},
+				{Document: "plans/b.md", StartLine: 1, EndLine: 1, Text: "Two"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseFeedback(tt.text))
+		})
+	}
+}
+
+func TestParseFeedback_RoundTrip(t *testing.T) {
+	session := &Session{
+		ID:      "session-1",
+		DocPath: "/path/to/doc.md",
+		Comments: []Comment{
+			{StartLine: 3, EndLine: 3, StartCol: 2, EndCol: 6, ContextText: "word", CommentText: "Rename"},
+			{StartLine: 8, EndLine: 11, ContextText: "one\ntwo", CommentText: "Explain why\n\nwith an example", Severity: corereview.SeverityBlocker},
+		},
+	}
+
+	got := parseFeedback(GenerateReviewFeedback(session, "plans/doc.md"))
+	assert.Equal(t, []importedComment{
+		{Document: "plans/doc.md", StartLine: 8, EndLine: 11, Severity: corereview.SeverityBlocker, Text: "Explain why\n\nwith an example"},
+		{Document: "plans/doc.md", StartLine: 3, EndLine: 3, StartCol: 2, EndCol: 6, Severity: corereview.SeveritySuggestion, Text: "Rename"},
+	}, got)
+}
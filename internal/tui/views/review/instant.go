@@ -0,0 +1,120 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/messaging"
+	corereview "github.com/colonyops/hive/internal/core/review"
+	"github.com/rs/zerolog/log"
+)
+
+// instantSender identifies instant-mode comments in the recipient's inbox.
+const instantSender = "hive-review"
+
+// InstantTarget is the hive session whose agent receives comments in
+// instant mode.
+type InstantTarget struct {
+	ID    string // session ID
+	Name  string // session name, for display
+	Topic string // the session's inbox topic
+}
+
+// instantMode tracks whether comments are forwarded as they are saved.
+type instantMode struct {
+	events    ReviewEvents
+	candidate InstantTarget // session instant mode would send to if enabled
+	target    *InstantTarget
+}
+
+// SetInstantTarget sets the session that instant mode sends comments to
+// when it is next enabled. An enabled instant mode keeps its recipient, so
+// browsing other sessions mid-review does not redirect comments.
+func (v *View) SetInstantTarget(target InstantTarget) {
+	v.instant.candidate = target
+}
+
+// InstantTarget returns the recipient of instant-mode comments, or nil when
+// instant mode is off.
+func (v *View) InstantTarget() *InstantTarget {
+	return v.instant.target
+}
+
+// ToggleInstant turns instant mode on or off and returns the recipient when
+// it was turned on.
+func (v *View) ToggleInstant() (*InstantTarget, error) {
+	if v.instant.target != nil {
+		v.instant.target = nil
+		return nil, nil
+	}
+	if v.instant.events == nil {
+		return nil, errors.New("messaging is not available")
+	}
+	if v.instant.candidate.Topic == "" {
+		return nil, errors.New("no session selected to send comments to")
+	}
+	target := v.instant.candidate
+	v.instant.target = &target
+	return v.instant.target, nil
+}
+
+// sendInstantComment publishes a saved comment to the instant-mode
+// recipient's inbox. previous is the comment before an edit, nil for a new
+// comment. It is a no-op when instant mode is off.
+func (v *View) sendInstantComment(comment Comment, previous *Comment) {
+	target := v.instant.target
+	if target == nil {
+		return
+	}
+
+	payload := formatInstantComment(v.relPathFor(comment.DocPath), v.reviewer, comment, previous)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	msg := messaging.Message{Payload: payload, Sender: instantSender}
+	if _, err := v.instant.events.Publish(ctx, msg, []string{target.Topic}); err != nil {
+		log.Warn().Err(err).Str("topic", target.Topic).Str("comment_id", comment.ID).Msg("review: failed to send comment to agent inbox")
+	}
+}
+
+// formatInstantComment renders one comment in the layout used by finalized
+// feedback, so agents can handle both the same way:
+//
+//	Document: <path>
+//	Reviewer: <name>
+//
+//	[<severity>] Lines <start>-<end>:
+//	> <context>
+//	<feedback>
+//
+// An edited comment is marked (edited) and, when its text changed, followed
+// by a word diff against previous: "Changes: [-removed-]{+added+}".
+func formatInstantComment(relPath, reviewer string, comment Comment, previous *Comment) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Document: %s\n", relPath)
+	if reviewer != "" {
+		fmt.Fprintf(&b, "Reviewer: %s\n", reviewer)
+	}
+	b.WriteString("\n")
+
+	anchor := severityAnchor(comment)
+	if previous != nil {
+		anchor += " (edited)"
+	}
+	fmt.Fprintf(&b, "%s:\n", anchor)
+	if comment.ContextText != "" {
+		cleanContext := ansiStripPattern.ReplaceAllString(comment.ContextText, "")
+		for line := range strings.SplitSeq(cleanContext, "\n") {
+			fmt.Fprintf(&b, "> %s\n", line)
+		}
+	}
+	b.WriteString(linkDocRefs(comment.CommentText, relPath))
+	b.WriteString("\n")
+	if previous != nil && previous.CommentText != comment.CommentText {
+		diff := corereview.DiffWords(previous.CommentText, comment.CommentText)
+		fmt.Fprintf(&b, "Changes: %s\n", corereview.FormatWordDiff(diff))
+	}
+	return b.String()
+}
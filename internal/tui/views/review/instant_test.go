@@ -0,0 +1,116 @@
+package review
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corereview "github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/internal/data/stores"
+)
+
+func TestToggleInstant(t *testing.T) {
+	v := New(nil, t.TempDir(), nil, nil, 0)
+
+	_, err := v.ToggleInstant()
+	require.Error(t, err, "instant mode needs messaging")
+
+	v.SetReviewEvents(&memEvents{}, nil)
+	_, err = v.ToggleInstant()
+	require.Error(t, err, "instant mode needs a recipient")
+
+	v.SetInstantTarget(InstantTarget{Name: "api", Topic: "agent.abc.inbox"})
+	target, err := v.ToggleInstant()
+	require.NoError(t, err)
+	require.NotNil(t, target)
+	assert.Equal(t, "agent.abc.inbox", target.Topic)
+
+	v.SetInstantTarget(InstantTarget{Name: "web", Topic: "agent.def.inbox"})
+	assert.Equal(t, "agent.abc.inbox", v.InstantTarget().Topic, "enabled instant mode keeps its recipient")
+
+	target, err = v.ToggleInstant()
+	require.NoError(t, err)
+	assert.Nil(t, target)
+	assert.Nil(t, v.InstantTarget())
+}
+
+func TestInstantModeSendsComments(t *testing.T) {
+	tmpDir := t.TempDir()
+	database, err := db.Open(tmpDir, db.DefaultOpenOptions())
+	require.NoError(t, err)
+	defer func() { _ = database.Close() }()
+
+	docPath := filepath.Join(tmpDir, "plan.md")
+	require.NoError(t, os.WriteFile(docPath, []byte("one\n\ntwo\n"), 0o644))
+
+	doc := Document{Path: docPath, RelPath: "plan.md", Type: DocTypePlan, ModTime: time.Now()}
+	v := New([]Document{doc}, tmpDir, stores.NewReviewStore(database), nil, 0)
+	events := &memEvents{}
+	v.SetReviewEvents(events, nil)
+	v.SetInstantTarget(InstantTarget{Name: "api", Topic: "agent.abc.inbox"})
+	v.SetSize(80, 24)
+	v.loadDocument(&doc)
+
+	v.selectionMode = true
+	v.selectionStart, v.cursorLine = 1, 1
+	v.addComment("before instant mode", corereview.SeveritySuggestion)
+
+	_, err = v.ToggleInstant()
+	require.NoError(t, err)
+
+	v.selectionMode = true
+	v.selectionStart, v.cursorLine = 3, 3
+	v.addComment("rename this", corereview.SeveritySuggestion)
+
+	comments := v.docComments()
+	require.Len(t, comments, 2)
+	v.updateComment(comments[1].ID, "rename this step", corereview.SeverityIssue)
+
+	inbox := inboxPayloads(events, "agent.abc.inbox")
+	require.Len(t, inbox, 2, "only comments saved while instant mode is on are sent")
+	assert.Contains(t, inbox[0], "Document: plan.md\n")
+	assert.Contains(t, inbox[0], commentAnchor(comments[1])+":\n")
+	assert.True(t, strings.HasSuffix(inbox[0], "\nrename this\n"))
+	assert.Contains(t, inbox[1], "[issue] "+commentAnchor(comments[1])+" (edited):\n")
+	assert.True(t, strings.HasSuffix(inbox[1], "\nrename this step\nChanges: rename this{+ step+}\n"))
+}
+
+func TestFormatInstantComment(t *testing.T) {
+	comment := Comment{
+		StartLine:   2,
+		EndLine:     3,
+		Severity:    corereview.SeverityIssue,
+		ContextText: "first\nsecond",
+		CommentText: "merge these",
+	}
+
+	got := formatInstantComment("plans/plan.md", "alice", comment, nil)
+	assert.Equal(t, "Document: plans/plan.md\nReviewer: alice\n\n[issue] Lines 2-3:\n> first\n> second\nmerge these\n", got)
+
+	previous := comment
+	got = formatInstantComment("plans/plan.md", "", comment, &previous)
+	assert.Equal(t, "Document: plans/plan.md\n\n[issue] Lines 2-3 (edited):\n> first\n> second\nmerge these\n", got)
+
+	previous.CommentText = "join these"
+	got = formatInstantComment("plans/plan.md", "", comment, &previous)
+	assert.True(t, strings.HasSuffix(got, "\nmerge these\nChanges: [-join-]{+merge+} these\n"))
+}
+
+// inboxPayloads returns the payloads published to topic, oldest first.
+func inboxPayloads(e *memEvents, topic string) []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var out []string
+	for _, msg := range e.msgs {
+		if msg.Topic == topic {
+			out = append(out, msg.Payload)
+		}
+	}
+	return out
+}
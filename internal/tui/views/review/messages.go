@@ -1,12 +1,30 @@
 package review
 
-import "github.com/colonyops/hive/internal/core/action"
+import (
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/colonyops/hive/internal/core/action"
+)
 
 // ActionRequestMsg requests the parent to execute a resolved action.
 type ActionRequestMsg struct {
 	Action action.Action
 }
 
+// ErrorMsg signals a non-fatal error to the parent model.
+type ErrorMsg struct{ Err error }
+
+// ErrorCmd returns a tea.Cmd that emits ErrorMsg.
+func ErrorCmd(err error) tea.Cmd {
+	return func() tea.Msg { return ErrorMsg{Err: err} }
+}
+
+// VaultDocumentsMsg carries the documents found by
+// DiscoverVaultDocumentsCmd.
+type VaultDocumentsMsg struct {
+	Documents []Document
+}
+
 // CommandPaletteRequestMsg requests the parent to open the command palette.
 type CommandPaletteRequestMsg struct{}
 
@@ -17,3 +35,10 @@ type docPreviewRenderedMsg struct {
 	path    string
 	content string
 }
+
+// changedDocsLoadedMsg carries the documents changed since their last
+// finalized review, hashed off the update goroutine by refreshChanged.
+type changedDocsLoadedMsg struct {
+	paths []string
+	err   error
+}
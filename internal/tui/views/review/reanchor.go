@@ -0,0 +1,177 @@
+package review
+
+import (
+	"strings"
+	"unicode"
+)
+
+// reanchorThreshold is the minimum word similarity for a block of the changed
+// document to be accepted as the new position of a comment's quoted lines.
+const reanchorThreshold = 0.75
+
+// ReanchorComments moves comments to the position of their quoted context in
+// the rendered lines of a changed document. An exact match closest to the
+// comment's previous position wins; comments on whole lines fall back to the
+// most similar block of the same height. Comments whose text can no longer be
+// found keep their position, clamped to the document, and are marked outdated.
+func ReanchorComments(comments []Comment, lines []string) []Comment {
+	plain := make([]string, len(lines))
+	for i, line := range lines {
+		plain[i] = ansiStripPattern.ReplaceAllString(line, "")
+	}
+
+	out := make([]Comment, len(comments))
+	for i, c := range comments {
+		out[i] = reanchorComment(c, plain)
+	}
+	return out
+}
+
+// reanchorComment re-anchors a single comment against ANSI-stripped lines.
+func reanchorComment(c Comment, lines []string) Comment {
+	quoted := strings.Split(ansiStripPattern.ReplaceAllString(c.ContextText, ""), "\n")
+	height := len(quoted)
+
+	found := false
+	if c.StartCol > 0 {
+		found = reanchorSpan(&c, quoted, lines)
+	} else if start, ok := findBlock(quoted, lines, c.StartLine); ok {
+		c.StartLine, c.EndLine = start, start+height-1
+		found = true
+	}
+
+	c.Outdated = !found
+	if c.Outdated {
+		height = c.EndLine - c.StartLine + 1
+		c.StartLine = min(max(c.StartLine, 1), max(len(lines)-height+1, 1))
+		c.EndLine = min(c.StartLine+height-1, max(len(lines), 1))
+	}
+	return c
+}
+
+// findBlock returns the 1-indexed start of the block of lines matching quoted:
+// an exact match (ignoring whitespace) nearest near, otherwise the most
+// similar block above reanchorThreshold.
+func findBlock(quoted, lines []string, near int) (int, bool) {
+	height := len(quoted)
+	if height == 0 || height > len(lines) {
+		return 0, false
+	}
+
+	want := make([]string, height)
+	for i, q := range quoted {
+		want[i] = normalizeLine(q)
+	}
+
+	best, bestScore := 0, 0.0
+	for start := 1; start+height-1 <= len(lines); start++ {
+		score := 0.0
+		for i := range height {
+			score += wordSimilarity(want[i], normalizeLine(lines[start-1+i]))
+		}
+		score /= float64(height)
+		if score > bestScore || (score == bestScore && score > 0 && closer(start, best, near)) {
+			best, bestScore = start, score
+		}
+	}
+
+	if bestScore < reanchorThreshold {
+		return 0, false
+	}
+	return best, true
+}
+
+// reanchorSpan locates a column-anchored comment's quoted phrase, which
+// starts part way through its first line and ends part way through its last.
+// Only exact matches are accepted: an edited phrase makes the comment outdated.
+func reanchorSpan(c *Comment, quoted, lines []string) bool {
+	height := len(quoted)
+	first := strings.TrimRightFunc(quoted[0], unicode.IsSpace)
+	last := quoted[height-1]
+
+	best := 0
+	var bestStartCol, bestEndCol int
+	for start := 1; start+height-1 <= len(lines); start++ {
+		var startCol, endCol int
+		if height == 1 {
+			idx := strings.Index(lines[start-1], quoted[0])
+			if idx < 0 || quoted[0] == "" {
+				continue
+			}
+			startCol = len([]rune(lines[start-1][:idx])) + 1
+			endCol = startCol + len([]rune(quoted[0])) - 1
+		} else {
+			head := strings.TrimRightFunc(lines[start-1], unicode.IsSpace)
+			tail := lines[start+height-2]
+			if first == "" || !strings.HasSuffix(head, first) || !strings.HasPrefix(tail, last) {
+				continue
+			}
+			if !equalNormalized(quoted[1:height-1], lines[start:start+height-2]) {
+				continue
+			}
+			startCol = len([]rune(head)) - len([]rune(first)) + 1
+			endCol = max(len([]rune(last)), 1)
+		}
+		if best == 0 || closer(start, best, c.StartLine) {
+			best, bestStartCol, bestEndCol = start, startCol, endCol
+		}
+	}
+
+	if best == 0 {
+		return false
+	}
+	c.StartLine, c.EndLine = best, best+height-1
+	c.StartCol, c.EndCol = bestStartCol, bestEndCol
+	return true
+}
+
+// closer reports whether line a is strictly closer to near than line b.
+func closer(a, b, near int) bool {
+	da, db := a-near, b-near
+	return da*da < db*db
+}
+
+// equalNormalized reports whether two line slices are equal ignoring whitespace.
+func equalNormalized(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if normalizeLine(a[i]) != normalizeLine(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeLine collapses whitespace so indentation and rendering padding do
+// not prevent a match.
+func normalizeLine(line string) string {
+	return strings.Join(strings.Fields(line), " ")
+}
+
+// wordSimilarity returns the Dice coefficient of the words in two normalized
+// lines: 1 for identical lines (including two blank lines), 0 when they share
+// no words.
+func wordSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	wa, wb := strings.Fields(a), strings.Fields(b)
+	if len(wa) == 0 || len(wb) == 0 {
+		return 0
+	}
+
+	counts := make(map[string]int, len(wa))
+	for _, w := range wa {
+		counts[w]++
+	}
+	common := 0
+	for _, w := range wb {
+		if counts[w] > 0 {
+			counts[w]--
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(wa)+len(wb))
+}
@@ -0,0 +1,79 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReanchorComments(t *testing.T) {
+	lines := []string{
+		"  # Plan",
+		"",
+		"  A new introduction paragraph.",
+		"",
+		"  Step one: add the retry budget",
+		"  Step two: wire the client",
+		"",
+		"  Step three: ship it to staging",
+	}
+
+	tests := []struct {
+		name    string
+		comment Comment
+		want    Comment
+	}{
+		{
+			name:    "block moved down",
+			comment: Comment{StartLine: 3, EndLine: 4, ContextText: "  Step one: add the retry budget\n  Step two: wire the client"},
+			want:    Comment{StartLine: 5, EndLine: 6},
+		},
+		{
+			name:    "ansi and whitespace ignored",
+			comment: Comment{StartLine: 1, EndLine: 1, ContextText: "\x1b[1m# Plan\x1b[0m   "},
+			want:    Comment{StartLine: 1, EndLine: 1},
+		},
+		{
+			name:    "edited line matched fuzzily",
+			comment: Comment{StartLine: 6, EndLine: 6, ContextText: "  Step three: ship it to production"},
+			want:    Comment{StartLine: 8, EndLine: 8},
+		},
+		{
+			name:    "nearest exact match wins",
+			comment: Comment{StartLine: 6, EndLine: 6, ContextText: ""},
+			want:    Comment{StartLine: 7, EndLine: 7},
+		},
+		{
+			name:    "column span moved",
+			comment: Comment{StartLine: 3, EndLine: 3, StartCol: 20, EndCol: 31, ContextText: "retry budget"},
+			want:    Comment{StartLine: 5, EndLine: 5, StartCol: 21, EndCol: 32},
+		},
+		{
+			name:    "multi-line column span",
+			comment: Comment{StartLine: 2, EndLine: 3, StartCol: 17, EndCol: 11, ContextText: "the retry budget\n  Step two"},
+			want:    Comment{StartLine: 5, EndLine: 6, StartCol: 17, EndCol: 10},
+		},
+		{
+			name:    "edited phrase is outdated",
+			comment: Comment{StartLine: 5, EndLine: 5, StartCol: 21, EndCol: 31, ContextText: "retry limit"},
+			want:    Comment{StartLine: 5, EndLine: 5, StartCol: 21, EndCol: 31, Outdated: true},
+		},
+		{
+			name:    "deleted text is outdated and clamped",
+			comment: Comment{StartLine: 12, EndLine: 13, ContextText: "Rollback plan\nRevert the deploy"},
+			want:    Comment{StartLine: 7, EndLine: 8, Outdated: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ReanchorComments([]Comment{tt.comment}, lines)[0]
+			assert.Equal(t, tt.want.StartLine, got.StartLine, "StartLine")
+			assert.Equal(t, tt.want.EndLine, got.EndLine, "EndLine")
+			assert.Equal(t, tt.want.StartCol, got.StartCol, "StartCol")
+			assert.Equal(t, tt.want.EndCol, got.EndCol, "EndCol")
+			assert.Equal(t, tt.want.Outdated, got.Outdated, "Outdated")
+			assert.Equal(t, tt.comment.ContextText, got.ContextText, "context is preserved")
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package review
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/colonyops/hive/internal/core/styles"
+)
+
+// docRefPattern matches a reference to another document embedded in comment
+// text, e.g. "[[plans/auth.md#L10-L15]]" or "[[plans/auth.md#L10]]".
+var docRefPattern = regexp.MustCompile(`\[\[([^\[\]#]+)#L(\d+)(?:-L(\d+))?\]\]`)
+
+// DocRef is a reference from a comment to a line range of another document.
+type DocRef struct {
+	Path      string // Document path relative to the context directory
+	StartLine int    // 1-indexed
+	EndLine   int    // Inclusive
+}
+
+// String returns the token that embeds the reference in comment text.
+func (r DocRef) String() string {
+	return "[[" + r.Path + "#" + r.fragment() + "]]"
+}
+
+// fragment returns the line range as a markdown link fragment, e.g. "L10-L15".
+func (r DocRef) fragment() string {
+	if r.EndLine <= r.StartLine {
+		return fmt.Sprintf("L%d", r.StartLine)
+	}
+	return fmt.Sprintf("L%d-L%d", r.StartLine, r.EndLine)
+}
+
+// label describes the reference for display, e.g. "plans/auth.md:10-15".
+func (r DocRef) label() string {
+	if r.EndLine <= r.StartLine {
+		return fmt.Sprintf("%s:%d", r.Path, r.StartLine)
+	}
+	return fmt.Sprintf("%s:%d-%d", r.Path, r.StartLine, r.EndLine)
+}
+
+// parseDocRef converts a docRefPattern submatch into a DocRef.
+func parseDocRef(match []string) DocRef {
+	ref := DocRef{Path: match[1]}
+	ref.StartLine, _ = strconv.Atoi(match[2])
+	ref.EndLine = ref.StartLine
+	if match[3] != "" {
+		ref.EndLine, _ = strconv.Atoi(match[3])
+	}
+	return ref
+}
+
+// parseDocRefs returns the document references in text, in order.
+func parseDocRefs(text string) []DocRef {
+	matches := docRefPattern.FindAllStringSubmatch(text, -1)
+	refs := make([]DocRef, 0, len(matches))
+	for _, m := range matches {
+		refs = append(refs, parseDocRef(m))
+	}
+	return refs
+}
+
+// renderDocRefMarkers replaces reference tokens with a link marker and the
+// referenced location for display in the document and comment list.
+func renderDocRefMarkers(text string) string {
+	return docRefPattern.ReplaceAllStringFunc(text, func(token string) string {
+		return styles.IconLink + parseDocRef(docRefPattern.FindStringSubmatch(token)).label()
+	})
+}
+
+// linkDocRefs replaces reference tokens with markdown links relative to the
+// directory of the document the comment is on (fromRelPath).
+func linkDocRefs(text, fromRelPath string) string {
+	return docRefPattern.ReplaceAllStringFunc(text, func(token string) string {
+		ref := parseDocRef(docRefPattern.FindStringSubmatch(token))
+		return fmt.Sprintf("[%s](%s#%s)", ref.label(), relativeLink(fromRelPath, ref.Path), ref.fragment())
+	})
+}
+
+// relativeLink returns the slash-separated path of target relative to the
+// directory containing from. Both are relative to the same root; absolute
+// targets are returned unchanged.
+func relativeLink(from, target string) string {
+	if path.IsAbs(target) {
+		return target
+	}
+	fromParts := splitPath(path.Dir(from))
+	targetParts := splitPath(target)
+
+	common := 0
+	for common < len(fromParts) && common < len(targetParts)-1 && fromParts[common] == targetParts[common] {
+		common++
+	}
+
+	parts := make([]string, 0, len(fromParts)-common+len(targetParts)-common)
+	for range fromParts[common:] {
+		parts = append(parts, "..")
+	}
+	parts = append(parts, targetParts[common:]...)
+	return strings.Join(parts, "/")
+}
+
+// splitPath splits a cleaned slash-separated path, treating "." as empty.
+func splitPath(p string) []string {
+	p = path.Clean(strings.ReplaceAll(p, "\\", "/"))
+	if p == "." || p == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimPrefix(p, "/"), "/")
+}
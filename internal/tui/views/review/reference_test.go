@@ -0,0 +1,48 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDocRefs(t *testing.T) {
+	refs := parseDocRefs("See [[plans/auth.md#L10-L15]] and [[research/notes.md#L3]], not [[broken]].")
+	assert.Equal(t, []DocRef{
+		{Path: "plans/auth.md", StartLine: 10, EndLine: 15},
+		{Path: "research/notes.md", StartLine: 3, EndLine: 3},
+	}, refs)
+
+	assert.Empty(t, parseDocRefs("no references here"))
+}
+
+func TestDocRefString(t *testing.T) {
+	assert.Equal(t, "[[plans/auth.md#L10-L15]]", DocRef{Path: "plans/auth.md", StartLine: 10, EndLine: 15}.String())
+	assert.Equal(t, "[[plans/auth.md#L4]]", DocRef{Path: "plans/auth.md", StartLine: 4, EndLine: 4}.String())
+}
+
+func TestRelativeLink(t *testing.T) {
+	tests := []struct {
+		from, target, want string
+	}{
+		{"plans/a.md", "plans/b.md", "b.md"},
+		{"plans/a.md", "research/notes.md", "../research/notes.md"},
+		{"a.md", "plans/b.md", "plans/b.md"},
+		{"plans/sub/a.md", "plans/b.md", "../b.md"},
+		{"plans/a.md", "/vault/notes.md", "/vault/notes.md"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, relativeLink(tt.from, tt.target), "%s -> %s", tt.from, tt.target)
+	}
+}
+
+func TestLinkDocRefs(t *testing.T) {
+	got := linkDocRefs("Conflicts with [[research/notes.md#L3-L5]].", "plans/a.md")
+	assert.Equal(t, "Conflicts with [research/notes.md:3-5](../research/notes.md#L3-L5).", got)
+}
+
+func TestRenderDocRefMarkers(t *testing.T) {
+	got := renderDocRefMarkers("See [[plans/b.md#L7]]")
+	assert.Contains(t, got, "plans/b.md:7")
+	assert.NotContains(t, got, "[[")
+}
@@ -0,0 +1,146 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+
+	corereview "github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/internal/core/styles"
+	"github.com/colonyops/hive/internal/tui/components"
+)
+
+// commentVersion is one version of a comment's text.
+type commentVersion struct {
+	text     string
+	severity corereview.Severity
+	at       time.Time // when the version was written
+}
+
+// CommentEdits shows word-level diffs between the versions of an edited
+// comment, one edit at a time, starting with the latest.
+type CommentEdits struct {
+	comment  Comment
+	versions []commentVersion // oldest first, ending with the current text
+	edit     int              // index into versions of the version shown, compared to the one before
+	width    int
+	closed   bool
+}
+
+// NewCommentEdits creates the edit viewer for comment, given its earlier
+// versions oldest first.
+func NewCommentEdits(comment Comment, revisions []corereview.CommentRevision, width int) CommentEdits {
+	versions := make([]commentVersion, 0, len(revisions)+1)
+	at := comment.CreatedAt
+	for _, rev := range revisions {
+		versions = append(versions, commentVersion{text: rev.CommentText, severity: rev.Severity.OrDefault(), at: at})
+		at = rev.ReplacedAt
+	}
+	versions = append(versions, commentVersion{text: comment.CommentText, severity: comment.Severity.OrDefault(), at: at})
+
+	return CommentEdits{
+		comment:  comment,
+		versions: versions,
+		edit:     len(versions) - 1,
+		width:    min(width-10, maxContentWidth+10),
+	}
+}
+
+// Update handles messages.
+func (m CommentEdits) Update(msg tea.Msg) (CommentEdits, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "h", "left", "k", "up":
+		m.edit = max(m.edit-1, 1)
+	case "l", "right", "j", "down":
+		m.edit = min(m.edit+1, len(m.versions)-1)
+	case "esc", "q", keyEnter:
+		m.closed = true
+	}
+	return m, nil
+}
+
+// View renders the edit shown.
+func (m CommentEdits) View() string {
+	prev, cur := m.versions[m.edit-1], m.versions[m.edit]
+
+	lines := fmt.Sprintf("Line %d", m.comment.StartLine)
+	if m.comment.EndLine != m.comment.StartLine {
+		lines = fmt.Sprintf("Lines %d-%d", m.comment.StartLine, m.comment.EndLine)
+	}
+	parts := []string{
+		styles.ReviewCommentTitleStyle.Render("Comment Edits"),
+		styles.ReviewCommentLabelStyle.Render(fmt.Sprintf("%s · edit %d of %d · %s",
+			lines, m.edit, len(m.versions)-1, cur.at.Format(time.DateTime))),
+	}
+	if prev.severity != cur.severity {
+		parts = append(parts, styles.TextMutedStyle.Render(fmt.Sprintf("severity: %s → %s", prev.severity.Label(), cur.severity.Label())))
+	}
+
+	deleted := styles.TextErrorStyle.Strikethrough(true)
+	var diff strings.Builder
+	for _, span := range corereview.DiffWords(prev.text, cur.text) {
+		switch span.Op {
+		case corereview.DiffDelete:
+			diff.WriteString(deleted.Render(span.Text))
+		case corereview.DiffInsert:
+			diff.WriteString(styles.TextSuccessStyle.Render(span.Text))
+		default:
+			diff.WriteString(span.Text)
+		}
+	}
+	parts = append(parts, "", lipgloss.NewStyle().Width(max(m.width-10, 20)).Render(diff.String()), "")
+
+	parts = append(parts, styles.ReviewCommentHelpStyle.Render(components.KeyHints(
+		components.HelpEntry{Key: "h/l", Desc: "previous/next edit"},
+		components.HelpEntry{Key: "esc", Desc: "close"},
+	)))
+	return strings.Join(parts, "\n")
+}
+
+// Closed returns true once the viewer was dismissed.
+func (m CommentEdits) Closed() bool {
+	return m.closed
+}
+
+// OpenCommentEdits shows how the comment at the cursor changed with each edit.
+func (v *View) OpenCommentEdits() error {
+	if !v.fullScreen || v.selectedDoc == nil {
+		return errors.New("no document open")
+	}
+
+	var comment *Comment
+	for _, c := range v.docComments() {
+		if v.cursorLine >= c.StartLine && v.cursorLine <= c.EndLine {
+			comment = &c
+			break
+		}
+	}
+	if comment == nil {
+		return errors.New("no comment at cursor")
+	}
+	if v.store == nil {
+		return errors.New("comment edits are not recorded")
+	}
+
+	revisions, err := v.store.ListCommentRevisions(context.Background(), comment.ID)
+	if err != nil {
+		return err
+	}
+	if len(revisions) == 0 {
+		return errors.New("comment has not been edited")
+	}
+
+	modal := NewCommentEdits(*comment, revisions, v.width)
+	v.commentEdits = &modal
+	return nil
+}
@@ -0,0 +1,64 @@
+package review
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corereview "github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/internal/data/stores"
+)
+
+func TestOpenCommentEdits(t *testing.T) {
+	tmpDir := t.TempDir()
+	database, err := db.Open(tmpDir, db.DefaultOpenOptions())
+	require.NoError(t, err)
+	defer func() { _ = database.Close() }()
+
+	docPath := filepath.Join(tmpDir, "plan.md")
+	require.NoError(t, os.WriteFile(docPath, []byte("one\n\ntwo\n"), 0o644))
+
+	doc := Document{Path: docPath, RelPath: "plan.md", Type: DocTypePlan, ModTime: time.Now()}
+	v := New([]Document{doc}, tmpDir, stores.NewReviewStore(database), nil, 0)
+	v.SetSize(100, 30)
+	v.loadDocument(&doc)
+	v.fullScreen = true
+
+	v.selectionMode = true
+	v.selectionStart, v.cursorLine = 3, 3
+	v.addComment("rename this", corereview.SeveritySuggestion)
+
+	v.cursorLine = 1
+	require.EqualError(t, v.OpenCommentEdits(), "no comment at cursor")
+	v.cursorLine = 3
+	require.EqualError(t, v.OpenCommentEdits(), "comment has not been edited")
+
+	id := v.docComments()[0].ID
+	v.updateComment(id, "rename this step", corereview.SeveritySuggestion)
+	v.updateComment(id, "rename the last step", corereview.SeverityIssue)
+
+	require.NoError(t, v.OpenCommentEdits())
+	require.NotNil(t, v.commentEdits)
+	assert.True(t, v.HasActiveEditor())
+
+	view := terminal.StripANSI(v.commentEdits.View())
+	assert.Contains(t, view, "edit 2 of 2")
+	assert.Contains(t, view, "severity: suggestion → issue")
+	assert.Contains(t, view, "rename thisthe last step")
+
+	v, _ = v.Update(keyMsg("h"))
+	view = terminal.StripANSI(v.commentEdits.View())
+	assert.Contains(t, view, "edit 1 of 2")
+	assert.NotContains(t, view, "severity:")
+	assert.Contains(t, view, "rename this step")
+
+	v, _ = v.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	assert.Nil(t, v.commentEdits)
+}
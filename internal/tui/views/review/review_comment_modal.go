@@ -2,36 +2,63 @@ package review
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
-	"charm.land/bubbles/v2/textarea"
+	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
 
+	corereview "github.com/colonyops/hive/internal/core/review"
 	"github.com/colonyops/hive/internal/core/styles"
 	"github.com/colonyops/hive/internal/tui/components"
+	"github.com/colonyops/hive/internal/tui/components/input"
 )
 
 // CommentModal handles multiline comment entry for selected text.
 // Uses textarea for multiline input with the following keybindings:
 //   - Enter: Insert newline
+//   - Tab/Shift+Tab: Cycle the comment's severity
 //   - Ctrl+Enter or Ctrl+S: Submit comment
+//   - Ctrl+R: Insert a reference to another document (see SetReferenceDocuments)
+//   - Alt+P/Alt+N: Recall earlier comments (see SetHistory)
 //   - Esc: Cancel modal
 type CommentModal struct {
-	textArea       textarea.Model
+	textArea       input.Area
+	title          string
 	lineRange      string // e.g., "Lines 10-15"
 	contextPreview string // First 100 chars of selected text
 	width          int
 	height         int
 	submitted      bool
 	cancelled      bool
+	err            string // shown below the input, cleared on the next edit
+	severity       int    // index into corereview.Severities
+	pickSeverity   bool   // false for modals whose input is not a single comment
+
+	refDocs   []Document      // documents a reference can point to
+	refPicker *refPicker      // active reference picker, nil when closed
+	refInput  textinput.Model // filter, then line range input of the picker
+}
+
+// refPicker is the state of the reference picker: first a document is chosen
+// from refDocs, then a line range is entered for it.
+type refPicker struct {
+	matches []Document // refDocs matching the filter
+	cursor  int
+	doc     *Document // chosen document; the input holds the line range once set
+	err     string
 }
 
+// refPickerRows is the number of documents the reference picker shows.
+const refPickerRows = 8
+
 // NewCommentModal creates a new comment modal.
 func NewCommentModal(startLine, endLine int, contextText string, width, height int) CommentModal {
 	// Constrain modal width to content width (with padding for borders)
 	modalWidth := min(width-10, maxContentWidth+10) // +10 for padding/borders
 
-	ta := textarea.New()
+	ta := input.NewArea(nil)
 	ta.Placeholder = "Enter your review comment..."
 	ta.Focus()
 	ta.SetWidth(modalWidth - 10) // Account for padding and borders
@@ -39,9 +66,8 @@ func NewCommentModal(startLine, endLine int, contextText string, width, height i
 	ta.ShowLineNumbers = false
 	ta.CharLimit = 1000
 
-	// Enable multiline input and paste
+	// Enable multiline input
 	ta.KeyMap.InsertNewline.SetEnabled(true)
-	ta.KeyMap.Paste.SetEnabled(true)
 
 	// Format line range
 	lineRange := fmt.Sprintf("Lines %d-%d", startLine, endLine)
@@ -52,15 +78,59 @@ func NewCommentModal(startLine, endLine int, contextText string, width, height i
 	// Format context preview - show first 20 lines + ... + last 3 lines
 	contextPreview := formatContextPreview(contextText)
 
+	ti := input.NewTextInput()
+	ti.CharLimit = 200
+	ti.SetWidth(modalWidth - 10)
+
 	return CommentModal{
 		textArea:       ta,
+		title:          "Add Review Comment",
 		lineRange:      lineRange,
 		contextPreview: contextPreview,
 		width:          width,
 		height:         height,
+		severity:       corereview.DefaultSeverity.Rank(),
+		pickSeverity:   true,
+		refInput:       ti,
 	}
 }
 
+// NewImportModal creates a modal for pasting feedback written outside hive,
+// which is parsed back into comments on the open document (see parseFeedback).
+func NewImportModal(width, height int) CommentModal {
+	m := NewCommentModal(0, 0, "", width, height)
+	m.title = "Import Feedback"
+	m.pickSeverity = false
+	m.lineRange = "Paste \"L12-L20: comment\" lines or finalized review feedback"
+	m.textArea.Placeholder = "L12-L20: This section needs an example..."
+	m.textArea.SetHeight(12)
+	m.textArea.CharLimit = 0
+	return m
+}
+
+// NewAnnotationModal creates a modal for a personal annotation on the given
+// lines. Annotations are private notes, so there is no severity to pick.
+func NewAnnotationModal(startLine, endLine int, contextText string, width, height int) CommentModal {
+	m := NewCommentModal(startLine, endLine, contextText, width, height)
+	m.title = "Add Annotation"
+	m.pickSeverity = false
+	m.textArea.Placeholder = "Enter a private note..."
+	return m
+}
+
+// SetReferenceDocuments sets the documents the comment can reference. The
+// reference picker is only available when docs is non-empty.
+func (m *CommentModal) SetReferenceDocuments(docs []Document) {
+	m.refDocs = docs
+}
+
+// SetHistory lets the comment be recalled from, and recorded in, history.
+// Submitted comments are recorded, and so are cancelled ones, so a comment
+// dismissed by accident can be brought back.
+func (m *CommentModal) SetHistory(history *input.History) {
+	m.textArea.History = history
+}
+
 // formatContextPreview formats multi-line context: first 20 lines + ... + last 3 lines.
 func formatContextPreview(text string) string {
 	lines := strings.Split(text, "\n")
@@ -80,16 +150,37 @@ func formatContextPreview(text string) string {
 
 // Update handles messages.
 func (m CommentModal) Update(msg tea.Msg) (CommentModal, tea.Cmd) {
+	if m.refPicker != nil {
+		return m.updateRefPicker(msg)
+	}
+
 	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
 		switch keyMsg.String() {
+		case "ctrl+r":
+			if len(m.refDocs) > 0 {
+				return m, m.openRefPicker()
+			}
+			return m, nil
+		case "tab":
+			if m.pickSeverity {
+				m.severity = (m.severity + 1) % len(corereview.Severities)
+				return m, nil
+			}
+		case "shift+tab":
+			if m.pickSeverity {
+				m.severity = (m.severity + len(corereview.Severities) - 1) % len(corereview.Severities)
+				return m, nil
+			}
 		case "ctrl+s":
 			// Submit with Ctrl+S
 			if m.textArea.Value() != "" {
+				m.textArea.Commit()
 				m.submitted = true
 				return m, nil
 			}
 		case "esc":
-			// Cancel modal
+			// Cancel modal, keeping the draft in history
+			m.textArea.Commit()
 			m.cancelled = true
 			return m, nil
 			// Note: Regular "enter" is handled by textarea to insert newline
@@ -97,25 +188,198 @@ func (m CommentModal) Update(msg tea.Msg) (CommentModal, tea.Cmd) {
 	}
 
 	// Forward all other keys to textarea (including Enter for newline)
+	m.err = ""
 	var cmd tea.Cmd
 	m.textArea, cmd = m.textArea.Update(msg)
 	return m, cmd
 }
 
+// openRefPicker opens the reference picker with every document listed.
+func (m *CommentModal) openRefPicker() tea.Cmd {
+	m.textArea.Blur()
+	m.refPicker = &refPicker{matches: m.refDocs}
+	m.refInput.Placeholder = "Filter documents..."
+	m.refInput.SetValue("")
+	return m.refInput.Focus()
+}
+
+// closeRefPicker returns focus to the comment text.
+func (m *CommentModal) closeRefPicker() tea.Cmd {
+	m.refPicker = nil
+	m.refInput.Blur()
+	return m.textArea.Focus()
+}
+
+// updateRefPicker handles input while the reference picker is open. Enter
+// picks the highlighted document, then inserts a reference to the entered
+// line range at the cursor. Esc steps back without inserting anything.
+func (m CommentModal) updateRefPicker(msg tea.Msg) (CommentModal, tea.Cmd) {
+	p := m.refPicker
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			if p.doc != nil {
+				return m, m.openRefPicker()
+			}
+			return m, m.closeRefPicker()
+		case "up", "ctrl+p":
+			if p.doc == nil {
+				p.cursor = max(p.cursor-1, 0)
+			}
+			return m, nil
+		case "down", "ctrl+n":
+			if p.doc == nil {
+				p.cursor = max(min(p.cursor+1, len(p.matches)-1), 0)
+			}
+			return m, nil
+		case "enter":
+			if p.doc == nil {
+				if len(p.matches) == 0 {
+					return m, nil
+				}
+				doc := p.matches[p.cursor]
+				p.doc = &doc
+				m.refInput.Placeholder = "Line or range, e.g. 10 or 10-15"
+				m.refInput.SetValue("")
+				return m, nil
+			}
+			start, end, err := parseLineRange(m.refInput.Value())
+			if err != nil {
+				p.err = err.Error()
+				return m, nil
+			}
+			m.textArea.InsertString(DocRef{Path: p.doc.RelPath, StartLine: start, EndLine: end}.String())
+			return m, m.closeRefPicker()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.refInput, cmd = m.refInput.Update(msg)
+	p.err = ""
+	if p.doc == nil {
+		p.matches = filterRefDocs(m.refDocs, m.refInput.Value())
+		p.cursor = min(p.cursor, max(len(p.matches)-1, 0))
+	}
+	return m, cmd
+}
+
+// filterRefDocs returns the documents whose relative path contains query,
+// ignoring case.
+func filterRefDocs(docs []Document, query string) []Document {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return docs
+	}
+	var matches []Document
+	for _, d := range docs {
+		if strings.Contains(strings.ToLower(d.RelPath), query) {
+			matches = append(matches, d)
+		}
+	}
+	return matches
+}
+
+// parseLineRange parses "10" or "10-15" into a 1-indexed inclusive range.
+func parseLineRange(s string) (int, int, error) {
+	startStr, endStr, isRange := strings.Cut(strings.TrimSpace(s), "-")
+	start, err := strconv.Atoi(strings.TrimSpace(startStr))
+	if err != nil || start < 1 {
+		return 0, 0, fmt.Errorf("invalid line %q", startStr)
+	}
+	if !isRange {
+		return start, start, nil
+	}
+	end, err := strconv.Atoi(strings.TrimSpace(endStr))
+	if err != nil || end < start {
+		return 0, 0, fmt.Errorf("invalid range %q", s)
+	}
+	return start, end, nil
+}
+
 // View renders the modal.
 func (m CommentModal) View() string {
-	content := strings.Join([]string{
-		styles.ReviewCommentTitleStyle.Render("Add Review Comment"),
+	if m.refPicker != nil {
+		return m.refPickerView()
+	}
+
+	hints := []components.HelpEntry{{Key: "ctrl+s", Desc: "submit"}}
+	if m.pickSeverity {
+		hints = append(hints, components.HelpEntry{Key: "tab", Desc: "severity"})
+	}
+	if len(m.refDocs) > 0 {
+		hints = append(hints, components.HelpEntry{Key: "ctrl+r", Desc: "reference"})
+	}
+	hints = append(hints, components.HelpEntry{Key: "esc", Desc: "cancel"})
+
+	parts := []string{
+		styles.ReviewCommentTitleStyle.Render(m.title),
 		styles.ReviewCommentLabelStyle.Render(m.lineRange),
-		styles.ReviewCommentContextStyle.Render(m.contextPreview),
-		m.textArea.View(),
-		styles.ReviewCommentHelpStyle.Render(components.KeyHints(
-			components.HelpEntry{Key: "ctrl+s", Desc: "submit"},
-			components.HelpEntry{Key: "esc", Desc: "cancel"},
-		)),
-	}, "\n")
+	}
+	if m.contextPreview != "" {
+		parts = append(parts, styles.ReviewCommentContextStyle.Render(m.contextPreview))
+	}
+	if m.pickSeverity {
+		parts = append(parts, m.severityView())
+	}
+	parts = append(parts, m.textArea.View())
+	if m.err != "" {
+		parts = append(parts, styles.TextErrorStyle.Render(m.err))
+	}
+	parts = append(parts, styles.ReviewCommentHelpStyle.Render(components.KeyHints(hints...)))
+
+	return strings.Join(parts, "\n")
+}
+
+// severityView renders the severities with the selected one highlighted in
+// its color.
+func (m CommentModal) severityView() string {
+	var b strings.Builder
+	b.WriteString(styles.TextMutedStyle.Render("Severity "))
+	for i, sev := range corereview.Severities {
+		if i == m.severity {
+			b.WriteString(lipgloss.NewStyle().Foreground(severityColor(sev)).Bold(true).Render("[" + sev.Label() + "]"))
+		} else {
+			b.WriteString(styles.TextMutedStyle.Render(" " + sev.Label() + " "))
+		}
+	}
+	return lipgloss.NewStyle().MarginBottom(1).Render(b.String())
+}
+
+// refPickerView renders the reference picker in place of the comment input.
+func (m CommentModal) refPickerView() string {
+	p := m.refPicker
+	parts := []string{styles.ReviewCommentTitleStyle.Render("Insert Reference")}
+
+	if p.doc == nil {
+		parts = append(parts, m.refInput.View())
+		if len(p.matches) == 0 {
+			parts = append(parts, styles.TextMutedStyle.Render("  No matching documents"))
+		}
+		offset := max(min(p.cursor-refPickerRows/2, len(p.matches)-refPickerRows), 0)
+		for i := offset; i < min(offset+refPickerRows, len(p.matches)); i++ {
+			if i == p.cursor {
+				parts = append(parts, styles.TextPrimaryStyle.Render("> "+p.matches[i].RelPath))
+			} else {
+				parts = append(parts, "  "+p.matches[i].RelPath)
+			}
+		}
+		parts = append(parts, styles.ReviewCommentHelpStyle.Render(components.KeyHints(
+			components.HelpEntry{Key: "↑/↓", Desc: "select"},
+			components.HelpEntry{Key: "enter", Desc: "choose"},
+			components.HelpEntry{Key: "esc", Desc: "back"},
+		)))
+		return strings.Join(parts, "\n")
+	}
 
-	return content
+	parts = append(parts, styles.ReviewCommentLabelStyle.Render(p.doc.RelPath), m.refInput.View())
+	if p.err != "" {
+		parts = append(parts, styles.TextErrorStyle.Render(p.err))
+	}
+	parts = append(parts, styles.ReviewCommentHelpStyle.Render(components.KeyHints(
+		components.HelpEntry{Key: "enter", Desc: "insert"},
+		components.HelpEntry{Key: "esc", Desc: "back"},
+	)))
+	return strings.Join(parts, "\n")
 }
 
 // Submitted returns true if the comment was submitted.
@@ -133,6 +397,22 @@ func (m CommentModal) Value() string {
 	return m.textArea.Value()
 }
 
+// Severity returns the selected severity.
+func (m CommentModal) Severity() corereview.Severity {
+	return corereview.Severities[m.severity]
+}
+
+// SetSeverity selects the severity of the comment being edited.
+func (m *CommentModal) SetSeverity(sev corereview.Severity) {
+	m.severity = sev.Rank()
+}
+
+// SetError shows msg in the modal and keeps it open for another submit.
+func (m *CommentModal) SetError(msg string) {
+	m.err = msg
+	m.submitted = false
+}
+
 // SetExistingComment pre-fills the modal with existing comment text for editing.
 func (m *CommentModal) SetExistingComment(text string) {
 	m.textArea.SetValue(text)
@@ -7,7 +7,7 @@ import (
 	"strings"
 	"time"
 
-	"charm.land/glamour/v2"
+	corereview "github.com/colonyops/hive/internal/core/review"
 	"github.com/colonyops/hive/internal/core/styles"
 )
 
@@ -56,8 +56,11 @@ type Document struct {
 	RelPath       string       // Relative to repo (e.g., ".hive/plans/...")
 	Type          DocumentType // Plan, Research, Context, Other
 	ModTime       time.Time
+	Vault         string   // Vault name for documents discovered outside the context directory
+	ReadOnly      bool     // True when hive must not modify the document (read-only vault, pinned version)
+	Revision      string   // Short commit hash of a pinned version; empty for the working copy
 	Content       string   // Raw content
-	RenderedLines []string // Glamour-rendered lines with ANSI (cached)
+	RenderedLines []string // Rendered lines with ANSI (cached)
 	cachedWidth   int      // Width used for cached rendering
 }
 
@@ -65,20 +68,57 @@ type Document struct {
 type Comment struct {
 	ID          string // UUID
 	SessionID   string // Associated session ID
+	DocPath     string // Document the comment was made on (empty means the session's document)
 	StartLine   int    // 1-indexed line number
 	EndLine     int    // Inclusive
+	StartCol    int    // 1-indexed column on StartLine (0 = whole lines)
+	EndCol      int    // Inclusive column on EndLine (0 = whole lines)
+	Outdated    bool   // Anchored text was removed when the document changed
+	Severity    corereview.Severity
 	ContextText string // Quoted text from document
 	CommentText string // User's feedback
 	CreatedAt   time.Time
 }
 
+// SessionDocument is a document attached to a review session.
+type SessionDocument struct {
+	Path    string // Absolute path
+	RelPath string // Path shown in generated feedback
+}
+
 // Session holds state for active review.
 type Session struct {
 	ID         string
 	DocPath    string
+	Documents  []SessionDocument // Attached documents, session document first
 	Comments   []Comment
 	CreatedAt  time.Time
 	ModifiedAt time.Time
+	ReviewTime time.Duration // focused time spent on the review
+}
+
+// HasDocument reports whether path is attached to the session.
+func (s *Session) HasDocument(path string) bool {
+	if s.DocPath == path {
+		return true
+	}
+	for _, d := range s.Documents {
+		if d.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// CommentsFor returns the comments made on the document at path.
+func (s *Session) CommentsFor(path string) []Comment {
+	var comments []Comment
+	for _, c := range s.Comments {
+		if c.DocPath == path || (c.DocPath == "" && s.DocPath == path) {
+			comments = append(comments, c)
+		}
+	}
+	return comments
 }
 
 // DiscoverDocuments walks the actual context directory and returns categorized documents.
@@ -185,6 +225,10 @@ func sortDocuments(docs []Document) {
 
 // LoadContent reads the document content from disk.
 func (d *Document) LoadContent() error {
+	// A pinned version's content was read from git and never changes
+	if d.Revision != "" {
+		return nil
+	}
 	content, err := os.ReadFile(d.Path)
 	if err != nil {
 		return err
@@ -194,8 +238,8 @@ func (d *Document) LoadContent() error {
 	return nil
 }
 
-// Render renders the document content using Glamour with line numbers.
-// Returns a string with ANSI-styled markdown and line numbers.
+// Render renders the document content with the configured Renderer and
+// adds line numbers. Returns a string with ANSI-styled lines.
 func (d *Document) Render(width int) (string, error) {
 	// Use cached rendered lines if available and width matches
 	if d.RenderedLines != nil && d.cachedWidth == width {
@@ -209,24 +253,12 @@ func (d *Document) Render(width int) (string, error) {
 		}
 	}
 
-	// Create glamour renderer with Tokyo Night theme
-	wrapWidth := contentWrapWidth(width)
-	r, err := glamour.NewTermRenderer(
-		glamour.WithStyles(styles.GlamourStyle()),
-		glamour.WithWordWrap(wrapWidth),
-	)
+	// Render with the configured renderer and cache with width
+	lines, err := documentRenderer.Render(d.Content, contentWrapWidth(width))
 	if err != nil {
 		return "", err
 	}
-
-	// Render markdown
-	rendered, err := r.Render(d.Content)
-	if err != nil {
-		return "", err
-	}
-
-	// Split into lines and cache with width
-	d.RenderedLines = strings.Split(strings.TrimRight(rendered, "\n"), "\n")
+	d.RenderedLines = lines
 	d.cachedWidth = width
 
 	return d.formatWithLineNumbers(d.RenderedLines), nil
@@ -8,6 +8,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/config"
 )
 
 func TestInferDocumentType(t *testing.T) {
@@ -199,3 +201,53 @@ func TestDiscoverDocuments_EmptyContextDir(t *testing.T) {
 	require.NoError(t, err, "DiscoverDocuments() error")
 	assert.Empty(t, docs, "expected 0 documents, got %d", len(docs))
 }
+
+func TestDiscoverVaultDocuments(t *testing.T) {
+	vaultDir := t.TempDir()
+	files := []string{
+		"Projects/hive/plans/rollout.md",
+		"Projects/hive/notes.txt",
+		"Projects/other/ignored.md",
+		"Research/caching.md",
+		"Projects/hive/.obsidian/workspace.md",
+		"Projects/hive/image.png",
+	}
+	for _, f := range files {
+		path := filepath.Join(vaultDir, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte("# "+f), 0o644))
+	}
+
+	docs := DiscoverVaultDocuments([]config.VaultConfig{
+		{Name: "notes", Path: vaultDir, Folders: []string{"Projects/hive", "Research", "Missing"}},
+	})
+
+	byRel := make(map[string]Document, len(docs))
+	for _, d := range docs {
+		byRel[d.RelPath] = d
+	}
+	require.Len(t, byRel, 3, "got %v", docs)
+
+	plan := byRel["@notes/Projects/hive/plans/rollout.md"]
+	assert.Equal(t, DocTypePlan, plan.Type, "type is inferred relative to the configured folder")
+	assert.Equal(t, "notes", plan.Vault)
+	assert.True(t, plan.ReadOnly, "vaults default to read-only")
+
+	assert.Contains(t, byRel, "@notes/Projects/hive/notes.txt")
+	assert.Contains(t, byRel, "@notes/Research/caching.md")
+
+	rw := DiscoverVaultDocuments([]config.VaultConfig{
+		{Path: vaultDir, Folders: []string{"Research"}, Mode: config.VaultModeReadWrite},
+	})
+	require.Len(t, rw, 1)
+	assert.False(t, rw[0].ReadOnly)
+	assert.Equal(t, "@"+filepath.Base(vaultDir)+"/Research/caching.md", rw[0].RelPath)
+
+	assert.Nil(t, DiscoverVaultDocumentsCmd(nil), "no vaults, no command")
+	cmd := DiscoverVaultDocumentsCmd([]config.VaultConfig{{Name: "notes", Path: vaultDir, Folders: []string{"Research"}}})
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(VaultDocumentsMsg)
+	require.True(t, ok)
+	require.Len(t, msg.Documents, 1)
+	assert.Equal(t, "@notes/Research/caching.md", msg.Documents[0].RelPath)
+}
@@ -1,72 +1,354 @@
 package review
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/config"
+	corereview "github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/pkg/tmpl"
 )
 
 // ansiStripPattern matches ANSI escape sequences for stripping.
 var ansiStripPattern = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
 
 // GenerateReviewFeedback creates a formatted review feedback string from a session.
+// docRelPath labels the session's own document.
+// Comments are grouped by severity, blockers first, then ordered by line.
 // Format:
 //
 //	Document: <path>
-//	Comments: <count>
+//	Comments: <count> (<n> blocker, <n> suggestions)
 //
-//	Lines <start>-<end>:
+//	[blocker] Lines <start>-<end>:
 //	> <context line 1>
 //	> <context line 2>
 //	<feedback text>
 //
-//	Lines <start>-<end>:
-//	> <context>
+//	[suggestion] Line <n> (cols <from>-<to>):
+//	> <exact phrase>
 //	<feedback>
+//
+// Sessions with related documents attached produce one such section per
+// commented document, separated by "---" under a summary header:
+//
+//	Documents: <count>
+//	Comments: <count> (<n> issues)
+//
+//	---
+//
+//	Document: <path>
+//	...
 func GenerateReviewFeedback(session *Session, docRelPath string) string {
+	return generateFeedback(session, docRelPath, false)
+}
+
+// GenerateTimedReviewFeedback is GenerateReviewFeedback with the session's
+// review time in the header and the time each comment was made after its
+// anchor:
+//
+//	Document: <path>
+//	Comments: <count> (<n> blocker)
+//	Review time: <duration>
+//
+//	[blocker] Lines <start>-<end> at <yyyy-mm-dd hh:mm>:
+func GenerateTimedReviewFeedback(session *Session, docRelPath string) string {
+	return generateFeedback(session, docRelPath, true)
+}
+
+func generateFeedback(session *Session, docRelPath string, timing bool) string {
 	if session == nil || len(session.Comments) == 0 {
 		return ""
 	}
 
 	var b strings.Builder
+	reviewTime := ""
+	if timing {
+		reviewTime = fmt.Sprintf("Review time: %s\n", session.ReviewTime.Round(time.Second))
+	}
+
+	if len(session.Documents) <= 1 {
+		writeDocumentFeedback(&b, docRelPath, session.Comments, reviewTime, timing)
+		return b.String()
+	}
+
+	sections := feedbackSections(session, docRelPath)
+	fmt.Fprintf(&b, "Documents: %d\n", len(sections))
+	fmt.Fprintf(&b, "Comments: %s\n", commentsSummary(session.Comments))
+	b.WriteString(reviewTime)
+	for _, sec := range sections {
+		b.WriteString("\n---\n\n")
+		writeDocumentFeedback(&b, sec.relPath, sec.comments, "", timing)
+	}
+
+	return b.String()
+}
+
+// RenderReviewFeedback renders feedback for a session with a configured
+// feedback template (see config.FeedbackTemplateData). An empty template
+// produces the built-in format from GenerateReviewFeedback, or from
+// GenerateTimedReviewFeedback when timing is set.
+func RenderReviewFeedback(session *Session, docRelPath, template, reviewer string, timing bool) (string, error) {
+	feedback := generateFeedback(session, docRelPath, timing)
+	if template == "" || feedback == "" {
+		return feedback, nil
+	}
+
+	data := config.FeedbackTemplateData{
+		Reviewer:   reviewer,
+		DocPath:    docRelPath,
+		ReviewTime: session.ReviewTime.Round(time.Second).String(),
+		Default:    feedback,
+	}
+	sections := []feedbackSection{{relPath: docRelPath, comments: session.Comments}}
+	if len(session.Documents) > 1 {
+		sections = feedbackSections(session, docRelPath)
+	}
+	for _, sec := range sections {
+		doc := config.FeedbackDocumentData{Path: sec.relPath}
+		for _, c := range sortedBySeverity(sec.comments) {
+			doc.Comments = append(doc.Comments, config.FeedbackCommentData{
+				Document:  sec.relPath,
+				Anchor:    commentAnchor(c),
+				StartLine: c.StartLine,
+				EndLine:   c.EndLine,
+				StartCol:  c.StartCol,
+				EndCol:    c.EndCol,
+				Context:   ansiStripPattern.ReplaceAllString(c.ContextText, ""),
+				Text:      linkDocRefs(c.CommentText, sec.relPath),
+				Outdated:  c.Outdated,
+				Severity:  c.Severity.Label(),
+				CreatedAt: c.CreatedAt,
+			})
+			if c.Outdated {
+				data.Counts.Outdated++
+			}
+			switch c.Severity.OrDefault() {
+			case corereview.SeverityBlocker:
+				data.Counts.Blockers++
+			case corereview.SeverityIssue:
+				data.Counts.Issues++
+			case corereview.SeveritySuggestion:
+				data.Counts.Suggestions++
+			case corereview.SeverityNit:
+				data.Counts.Nits++
+			}
+		}
+		data.Documents = append(data.Documents, doc)
+		data.Comments = append(data.Comments, doc.Comments...)
+	}
+	data.Counts.Comments = len(data.Comments)
+	data.Counts.Documents = len(data.Documents)
+
+	out, err := tmpl.New(tmpl.Config{}).Render(template, data)
+	if err != nil {
+		return "", fmt.Errorf("render feedback template: %w", err)
+	}
+	return out, nil
+}
+
+// feedbackSection is the set of comments on one document.
+type feedbackSection struct {
+	relPath  string
+	comments []Comment
+}
+
+// feedbackSections groups a multi-document session's comments by document,
+// skipping documents without comments.
+func feedbackSections(session *Session, docRelPath string) []feedbackSection {
+	var sections []feedbackSection
+	for _, doc := range session.Documents {
+		comments := session.CommentsFor(doc.Path)
+		if len(comments) == 0 {
+			continue
+		}
+		relPath := doc.RelPath
+		if doc.Path == session.DocPath && docRelPath != "" {
+			relPath = docRelPath
+		}
+		if relPath == "" {
+			relPath = doc.Path
+		}
+		sections = append(sections, feedbackSection{relPath: relPath, comments: comments})
+	}
+	return sections
+}
 
+// commentTimeLayout formats comment timestamps in timed feedback.
+const commentTimeLayout = "2006-01-02 15:04"
+
+// writeDocumentFeedback writes the feedback section for a single document.
+// header is written after the comment count; timing adds each comment's
+// timestamp to its anchor.
+func writeDocumentFeedback(b *strings.Builder, docRelPath string, comments []Comment, header string, timing bool) {
 	// Header
-	fmt.Fprintf(&b, "Document: %s\n", docRelPath)
-	fmt.Fprintf(&b, "Comments: %d\n\n", len(session.Comments))
-
-	// Sort comments by line number
-	sortedComments := make([]Comment, len(session.Comments))
-	copy(sortedComments, session.Comments)
-	sort.Slice(sortedComments, func(i, j int) bool {
-		return sortedComments[i].StartLine < sortedComments[j].StartLine
-	})
+	fmt.Fprintf(b, "Document: %s\n", docRelPath)
+	fmt.Fprintf(b, "Comments: %s\n", commentsSummary(comments))
+	b.WriteString(header)
+	b.WriteString("\n")
 
-	// Format each comment
-	for i, comment := range sortedComments {
+	// Format each comment, most severe first
+	for i, comment := range sortedBySeverity(comments) {
 		if i > 0 {
 			b.WriteString("\n")
 		}
 
-		// Line range
-		if comment.StartLine == comment.EndLine {
-			fmt.Fprintf(&b, "Line %d:\n", comment.StartLine)
-		} else {
-			fmt.Fprintf(&b, "Lines %d-%d:\n", comment.StartLine, comment.EndLine)
+		anchor := severityAnchor(comment)
+		if timing && !comment.CreatedAt.IsZero() {
+			anchor += " at " + comment.CreatedAt.Local().Format(commentTimeLayout)
 		}
+		fmt.Fprintf(b, "%s:\n", anchor)
 
 		// Context (quoted) - strip ANSI codes for plain text
 		if comment.ContextText != "" {
 			cleanContext := ansiStripPattern.ReplaceAllString(comment.ContextText, "")
 			for line := range strings.SplitSeq(cleanContext, "\n") {
-				fmt.Fprintf(&b, "> %s\n", line)
+				fmt.Fprintf(b, "> %s\n", line)
 			}
 		}
 
-		// Feedback
-		b.WriteString(comment.CommentText)
+		// Feedback, with references to other documents as relative links
+		b.WriteString(linkDocRefs(comment.CommentText, docRelPath))
 		b.WriteString("\n")
 	}
+}
 
-	return b.String()
+// sortedBySeverity returns a copy of comments grouped by severity, most
+// severe first, and sorted by start line within each severity.
+func sortedBySeverity(comments []Comment) []Comment {
+	sorted := make([]Comment, len(comments))
+	copy(sorted, comments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if ri, rj := sorted[i].Severity.Rank(), sorted[j].Severity.Rank(); ri != rj {
+			return ri > rj
+		}
+		return sorted[i].StartLine < sorted[j].StartLine
+	})
+	return sorted
+}
+
+// severityCount is the number of comments with one severity.
+type severityCount struct {
+	severity corereview.Severity
+	count    int
+}
+
+// String returns e.g. "1 blocker" or "3 nits".
+func (c severityCount) String() string {
+	label := c.severity.Label()
+	if c.count != 1 {
+		label += "s"
+	}
+	return fmt.Sprintf("%d %s", c.count, label)
+}
+
+// countSeverities counts comments by severity, most severe first. Severities
+// without comments are left out.
+func countSeverities(comments []Comment) []severityCount {
+	counts := make([]int, len(corereview.Severities))
+	for _, c := range comments {
+		counts[c.Severity.Rank()]++
+	}
+	var out []severityCount
+	for i := len(corereview.Severities) - 1; i >= 0; i-- {
+		if counts[i] > 0 {
+			out = append(out, severityCount{severity: corereview.Severities[i], count: counts[i]})
+		}
+	}
+	return out
+}
+
+// commentsSummary returns the comment count followed by the count of each
+// severity, e.g. "3 (1 blocker, 2 nits)".
+func commentsSummary(comments []Comment) string {
+	counts := countSeverities(comments)
+	parts := make([]string, 0, len(counts))
+	for _, c := range counts {
+		parts = append(parts, c.String())
+	}
+	return fmt.Sprintf("%d (%s)", len(comments), strings.Join(parts, ", "))
+}
+
+// severityAnchor prefixes a comment's anchor with its severity tag, e.g.
+// "[blocker] Lines 4-6".
+func severityAnchor(comment Comment) string {
+	return "[" + comment.Severity.Label() + "] " + commentAnchor(comment)
+}
+
+// commentAnchor describes a comment's position: the line range, with columns
+// for comments anchored to a span.
+func commentAnchor(comment Comment) string {
+	var anchor string
+	switch {
+	case comment.StartCol > 0 && comment.StartLine == comment.EndLine:
+		anchor = fmt.Sprintf("Line %d (cols %d-%d)", comment.StartLine, comment.StartCol, comment.EndCol)
+	case comment.StartCol > 0:
+		anchor = fmt.Sprintf("Lines %d:%d-%d:%d", comment.StartLine, comment.StartCol, comment.EndLine, comment.EndCol)
+	case comment.StartLine == comment.EndLine:
+		anchor = fmt.Sprintf("Line %d", comment.StartLine)
+	default:
+		anchor = fmt.Sprintf("Lines %d-%d", comment.StartLine, comment.EndLine)
+	}
+	// The quoted text no longer exists in the document
+	if comment.Outdated {
+		anchor += " (outdated)"
+	}
+	return anchor
+}
+
+// feedbackDir is the context directory folder finalized feedback is saved to.
+const feedbackDir = "reviews"
+
+// SaveFeedback writes finalized feedback to
+// <contextDir>/reviews/<doc-slug>-<date>.md so it is discovered alongside the
+// reviewed documents. Later reviews of the same document on the same day get a
+// numeric suffix rather than overwriting earlier feedback. Returns the path
+// written.
+func SaveFeedback(contextDir, docRelPath, feedback string, now time.Time) (string, error) {
+	if feedback == "" {
+		return "", errors.New("no feedback to save")
+	}
+
+	dir := filepath.Join(contextDir, feedbackDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create reviews directory: %w", err)
+	}
+
+	slug := session.Slugify(strings.TrimSuffix(filepath.Base(docRelPath), filepath.Ext(docRelPath)))
+	if slug == "" {
+		slug = "review"
+	}
+	base := slug + "-" + now.Format(time.DateOnly)
+
+	for n := 1; ; n++ {
+		name := base + ".md"
+		if n > 1 {
+			name = fmt.Sprintf("%s-%d.md", base, n)
+		}
+		path := filepath.Join(dir, name)
+
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("create feedback file: %w", err)
+		}
+		if _, err := f.WriteString(feedback); err != nil {
+			_ = f.Close()
+			return "", fmt.Errorf("write feedback file: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return "", fmt.Errorf("write feedback file: %w", err)
+		}
+		return path, nil
+	}
 }
@@ -1,11 +1,16 @@
 package review
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corereview "github.com/colonyops/hive/internal/core/review"
 )
 
 func TestGenerateReviewFeedback(t *testing.T) {
@@ -50,7 +55,7 @@ func TestGenerateReviewFeedback(t *testing.T) {
 				},
 			},
 			docRelPath: "plans/test.md",
-			want:       "Document: plans/test.md\nComments: 1\n\nLine 5:\n> This is the context\nThis needs improvement\n",
+			want:       "Document: plans/test.md\nComments: 1 (1 suggestion)\n\n[suggestion] Line 5:\n> This is the context\nThis needs improvement\n",
 		},
 		{
 			name: "multiple comments sorted by line",
@@ -79,7 +84,7 @@ func TestGenerateReviewFeedback(t *testing.T) {
 				},
 			},
 			docRelPath: "plans/test.md",
-			want:       "Document: plans/test.md\nComments: 2\n\nLine 5:\n> First context\nFirst feedback\n\nLines 15-17:\n> Second context\nSecond feedback\n",
+			want:       "Document: plans/test.md\nComments: 2 (2 suggestions)\n\n[suggestion] Line 5:\n> First context\nFirst feedback\n\n[suggestion] Lines 15-17:\n> Second context\nSecond feedback\n",
 		},
 		{
 			name: "multiline context",
@@ -99,7 +104,91 @@ func TestGenerateReviewFeedback(t *testing.T) {
 				},
 			},
 			docRelPath: "research/doc.md",
-			want:       "Document: research/doc.md\nComments: 1\n\nLines 10-12:\n> Line 1\n> Line 2\n> Line 3\nCheck these lines\n",
+			want:       "Document: research/doc.md\nComments: 1 (1 suggestion)\n\n[suggestion] Lines 10-12:\n> Line 1\n> Line 2\n> Line 3\nCheck these lines\n",
+		},
+		{
+			name: "column anchored comments",
+			session: &Session{
+				ID:      "session-1",
+				DocPath: "/path/to/doc.md",
+				Comments: []Comment{
+					{
+						ID:          "comment-1",
+						SessionID:   "session-1",
+						StartLine:   4,
+						EndLine:     4,
+						StartCol:    7,
+						EndCol:      18,
+						ContextText: "retry budget",
+						CommentText: "How big?",
+						CreatedAt:   time.Now(),
+					},
+					{
+						ID:          "comment-2",
+						SessionID:   "session-1",
+						StartLine:   8,
+						EndLine:     9,
+						StartCol:    3,
+						EndCol:      5,
+						ContextText: "end of line\nnew",
+						CommentText: "Reword",
+						CreatedAt:   time.Now(),
+					},
+				},
+			},
+			docRelPath: "plans/test.md",
+			want:       "Document: plans/test.md\nComments: 2 (2 suggestions)\n\n[suggestion] Line 4 (cols 7-18):\n> retry budget\nHow big?\n\n[suggestion] Lines 8:3-9:5:\n> end of line\n> new\nReword\n",
+		},
+		{
+			name: "outdated comment",
+			session: &Session{
+				ID:      "session-1",
+				DocPath: "/path/to/doc.md",
+				Comments: []Comment{
+					{ID: "c1", StartLine: 5, EndLine: 5, ContextText: "Removed step", CommentText: "Why?", Outdated: true},
+				},
+			},
+			docRelPath: "plans/test.md",
+			want:       "Document: plans/test.md\nComments: 1 (1 suggestion)\n\n[suggestion] Line 5 (outdated):\n> Removed step\nWhy?\n",
+		},
+		{
+			name: "multiple documents grouped into sections",
+			session: &Session{
+				ID:      "session-1",
+				DocPath: "/ctx/plans/plan.md",
+				Documents: []SessionDocument{
+					{Path: "/ctx/plans/plan.md", RelPath: "plans/plan.md"},
+					{Path: "/ctx/research/empty.md", RelPath: "research/empty.md"},
+					{Path: "/ctx/research/notes.md", RelPath: "research/notes.md"},
+				},
+				Comments: []Comment{
+					{ID: "c1", DocPath: "/ctx/research/notes.md", StartLine: 2, EndLine: 2, CommentText: "Cite the source", Severity: corereview.SeverityIssue},
+					{ID: "c2", StartLine: 1, EndLine: 3, ContextText: "Step one", CommentText: "Split this step"},
+				},
+			},
+			docRelPath: "plans/plan.md",
+			want: "Documents: 2\nComments: 2 (1 issue, 1 suggestion)\n" +
+				"\n---\n\nDocument: plans/plan.md\nComments: 1 (1 suggestion)\n\n[suggestion] Lines 1-3:\n> Step one\nSplit this step\n" +
+				"\n---\n\nDocument: research/notes.md\nComments: 1 (1 issue)\n\n[issue] Line 2:\nCite the source\n",
+		},
+		{
+			name: "grouped by severity, blockers first",
+			session: &Session{
+				ID:      "session-1",
+				DocPath: "/path/to/doc.md",
+				Comments: []Comment{
+					{ID: "c1", StartLine: 2, EndLine: 2, CommentText: "Typo", Severity: corereview.SeverityNit},
+					{ID: "c2", StartLine: 9, EndLine: 9, CommentText: "Wrong API", Severity: corereview.SeverityBlocker},
+					{ID: "c3", StartLine: 4, EndLine: 4, CommentText: "Unclear", Severity: corereview.SeverityIssue},
+					{ID: "c4", StartLine: 1, EndLine: 1, CommentText: "Missing auth", Severity: corereview.SeverityBlocker},
+				},
+			},
+			docRelPath: "plans/test.md",
+			want: "Document: plans/test.md\nComments: 4 (2 blockers, 1 issue, 1 nit)\n\n" +
+				"[blocker] Line 1:\nMissing auth\n\n" +
+				"[blocker] Line 9:\nWrong API\n\n" +
+				"[issue] Line 4:\nUnclear\n\n" +
+				"[nit] Line 2:\nTypo\n",
 		},
 	}
 
@@ -133,3 +222,129 @@ func TestGenerateReviewFeedback(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderReviewFeedback(t *testing.T) {
+	session := &Session{
+		ID:      "session-1",
+		DocPath: "/ctx/plans/plan.md",
+		Documents: []SessionDocument{
+			{Path: "/ctx/plans/plan.md", RelPath: "plans/plan.md"},
+			{Path: "/ctx/research/notes.md", RelPath: "research/notes.md"},
+		},
+		Comments: []Comment{
+			{ID: "c2", DocPath: "/ctx/plans/plan.md", StartLine: 9, EndLine: 9, CommentText: "later", Outdated: true},
+			{ID: "c1", DocPath: "/ctx/plans/plan.md", StartLine: 2, EndLine: 3, ContextText: "a\nb", CommentText: "first"},
+			{ID: "c3", DocPath: "/ctx/research/notes.md", StartLine: 1, EndLine: 1, StartCol: 2, EndCol: 4, CommentText: "cite"},
+		},
+	}
+
+	t.Run("empty template uses built-in format", func(t *testing.T) {
+		got, err := RenderReviewFeedback(session, "plans/plan.md", "", "sam", false)
+		assert.NoError(t, err)
+		assert.Equal(t, GenerateReviewFeedback(session, "plans/plan.md"), got)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		tmpl := `{{ .Reviewer }}: {{ .Counts.Comments }} comments, {{ .Counts.Outdated }} outdated, {{ .Counts.Documents }} docs
+{{ range .Documents }}## {{ .Path }}
+{{ range .Comments }}- {{ .Anchor }}: {{ .Text }}
+{{ end }}{{ end }}`
+		got, err := RenderReviewFeedback(session, "plans/plan.md", tmpl, "sam", false)
+		assert.NoError(t, err)
+		assert.Equal(t, `sam: 3 comments, 1 outdated, 2 docs
+## plans/plan.md
+- Lines 2-3: first
+- Line 9 (outdated): later
+## research/notes.md
+- Line 1 (cols 2-4): cite
+`, got)
+	})
+
+	t.Run("execution error", func(t *testing.T) {
+		_, err := RenderReviewFeedback(session, "plans/plan.md", "{{ .Missing }}", "sam", false)
+		assert.Error(t, err)
+	})
+
+	t.Run("no comments renders nothing", func(t *testing.T) {
+		got, err := RenderReviewFeedback(&Session{}, "plans/plan.md", "header", "sam", false)
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func TestGenerateTimedReviewFeedback(t *testing.T) {
+	commented := time.Date(2026, 3, 14, 9, 30, 0, 0, time.Local)
+	session := &Session{
+		ID:         "session-1",
+		DocPath:    "/ctx/plans/plan.md",
+		ReviewTime: 12*time.Minute + 30*time.Second + 400*time.Millisecond,
+		Comments: []Comment{
+			{ID: "c1", StartLine: 2, EndLine: 3, CommentText: "first", CreatedAt: commented},
+			{ID: "c2", StartLine: 9, EndLine: 9, CommentText: "undated"},
+		},
+	}
+
+	got := GenerateTimedReviewFeedback(session, "plans/plan.md")
+	assert.Equal(t, `Document: plans/plan.md
+Comments: 2 (2 suggestions)
+Review time: 12m30s
+
+[suggestion] Lines 2-3 at 2026-03-14 09:30:
+first
+
+[suggestion] Line 9:
+undated
+`, got)
+
+	imported := parseFeedback(got)
+	require.Len(t, imported, 2)
+	assert.Equal(t, 2, imported[0].StartLine)
+	assert.Equal(t, "first", imported[0].Text)
+
+	rendered, err := RenderReviewFeedback(session, "plans/plan.md", `{{ .ReviewTime }}{{ range .Comments }} {{ .CreatedAt.Format "15:04" }}{{ end }}`, "", false)
+	require.NoError(t, err)
+	assert.Equal(t, "12m30s 09:30 00:00", rendered)
+}
+
+func TestGenerateReviewFeedback_DocumentReferences(t *testing.T) {
+	session := &Session{
+		ID:      "session-1",
+		DocPath: "/ctx/plans/plan.md",
+		Comments: []Comment{{
+			ID:          "comment-1",
+			StartLine:   2,
+			EndLine:     2,
+			CommentText: "Conflicts with [[research/notes.md#L3-L5]]",
+		}},
+	}
+
+	feedback := GenerateReviewFeedback(session, "plans/plan.md")
+	assert.Contains(t, feedback, "Conflicts with [research/notes.md:3-5](../research/notes.md#L3-L5)\n")
+
+	rendered, err := RenderReviewFeedback(session, "plans/plan.md", "{{ range .Comments }}{{ .Text }}{{ end }}", "", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "Conflicts with [research/notes.md:3-5](../research/notes.md#L3-L5)", rendered)
+}
+
+func TestSaveFeedback(t *testing.T) {
+	contextDir := t.TempDir()
+	now := time.Date(2026, 3, 14, 9, 30, 0, 0, time.Local)
+
+	path, err := SaveFeedback(contextDir, "plans/Auth Refactor.md", "first", now)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(contextDir, "reviews", "auth-refactor-2026-03-14.md"), path)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(data))
+
+	path, err = SaveFeedback(contextDir, "plans/Auth Refactor.md", "second", now)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(contextDir, "reviews", "auth-refactor-2026-03-14-2.md"), path, "same-day reviews don't overwrite")
+
+	docs, err := DiscoverDocuments(contextDir)
+	require.NoError(t, err)
+	assert.Len(t, docs, 2, "saved feedback is discovered as documents")
+
+	_, err = SaveFeedback(contextDir, "plans/auth.md", "", now)
+	assert.Error(t, err)
+}
@@ -7,14 +7,17 @@ import (
 	tea "charm.land/bubbletea/v2"
 	lipgloss "charm.land/lipgloss/v2"
 
+	corereview "github.com/colonyops/hive/internal/core/review"
 	"github.com/colonyops/hive/internal/core/styles"
 	"github.com/colonyops/hive/internal/tui/components"
+	"github.com/colonyops/hive/internal/tui/components/input"
 )
 
-// FinalizationModal collects an optional general note before saving the review
-// and copying it to the clipboard.
+// FinalizationModal collects a verdict and an optional general note before
+// saving the review and copying it to the clipboard.
 type FinalizationModal struct {
 	feedback    string
+	verdict     int // index into corereview.Verdicts
 	width       int
 	height      int
 	confirmed   bool
@@ -25,14 +28,13 @@ type FinalizationModal struct {
 // NewFinalizationModal creates a new finalization modal.
 func NewFinalizationModal(feedback string, width, height int) FinalizationModal {
 	contentWidth := max(min(width-14, 100), 40) // 14 = border(2) + padding(4*2) + margin
-	ta := textarea.New()
+	ta := input.NewTextArea()
 	ta.Placeholder = "Optional general notes about this document..."
 	ta.SetWidth(contentWidth)
 	ta.SetHeight(4)
 	ta.ShowLineNumbers = false
 	ta.CharLimit = 2000
 	ta.KeyMap.InsertNewline.SetEnabled(true)
-	ta.KeyMap.Paste.SetEnabled(true)
 	ta.Focus()
 
 	return FinalizationModal{
@@ -53,6 +55,12 @@ func (m FinalizationModal) Update(msg tea.Msg) (FinalizationModal, tea.Cmd) {
 		case "ctrl+s":
 			m.confirmed = true
 			return m, nil
+		case "tab":
+			m.verdict = (m.verdict + 1) % len(corereview.Verdicts)
+			return m, nil
+		case "shift+tab":
+			m.verdict = (m.verdict + len(corereview.Verdicts) - 1) % len(corereview.Verdicts)
+			return m, nil
 		}
 	}
 
@@ -69,10 +77,23 @@ func (m FinalizationModal) View() string {
 	var content strings.Builder
 
 	content.WriteString("Finalize Review\n\n")
+	content.WriteString(styles.TextMutedStyle.Render("Verdict") + "\n")
+	for i, verdict := range corereview.Verdicts {
+		if i > 0 {
+			content.WriteString("  ")
+		}
+		if i == m.verdict {
+			content.WriteString(styles.TextPrimaryBoldStyle.Render("[" + verdict.Label() + "]"))
+		} else {
+			content.WriteString(styles.TextMutedStyle.Render(" " + verdict.Label() + " "))
+		}
+	}
+	content.WriteString("\n\n")
 	content.WriteString(styles.TextMutedStyle.Render("General Notes") + "\n")
 	content.WriteString(m.generalNote.View())
 	content.WriteString("\n\n")
 	content.WriteString(components.KeyHints(
+		components.HelpEntry{Key: "tab", Desc: "verdict"},
 		components.HelpEntry{Key: "ctrl+s", Desc: "save & copy to clipboard"},
 		components.HelpEntry{Key: "esc", Desc: "cancel"},
 	))
@@ -107,3 +128,8 @@ func (m FinalizationModal) Cancelled() bool { return m.cancelled }
 func (m FinalizationModal) GeneralComment() string {
 	return strings.TrimSpace(m.generalNote.Value())
 }
+
+// Verdict returns the selected verdict.
+func (m FinalizationModal) Verdict() corereview.Verdict {
+	return corereview.Verdicts[m.verdict]
+}
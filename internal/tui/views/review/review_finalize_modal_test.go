@@ -5,6 +5,8 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/stretchr/testify/assert"
+
+	corereview "github.com/colonyops/hive/internal/core/review"
 )
 
 const testFeedback = "Test feedback"
@@ -65,3 +67,15 @@ func TestFinalizationModal_GeneralComment(t *testing.T) {
 	modal := NewFinalizationModal(testFeedback, 100, 40)
 	assert.Empty(t, modal.GeneralComment())
 }
+
+func TestFinalizationModal_Verdict(t *testing.T) {
+	modal := NewFinalizationModal(testFeedback, 100, 40)
+	assert.Equal(t, corereview.VerdictComment, modal.Verdict())
+
+	modal, _ = modal.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyTab}))
+	assert.Equal(t, corereview.VerdictApprove, modal.Verdict())
+
+	modal, _ = modal.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyTab, Mod: tea.ModShift}))
+	modal, _ = modal.Update(tea.KeyPressMsg(tea.Key{Code: tea.KeyTab, Mod: tea.ModShift}))
+	assert.Equal(t, corereview.VerdictRequestChanges, modal.Verdict(), "shift+tab wraps around")
+}
@@ -0,0 +1,62 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/pkg/executil"
+	"github.com/colonyops/hive/pkg/tmpl"
+)
+
+// finalizeHooksTimeout bounds how long all finalize hooks of one review may
+// run together.
+const finalizeHooksTimeout = 30 * time.Second
+
+// RunFinalizeHooks runs review.finalize_hooks commands in order through exec,
+// which must implement executil.InputRunner. Each command is rendered as a Go
+// template with data and run in the platform shell in the context directory,
+// with the finalized feedback on stdin. A failing hook does not stop later
+// hooks; all failures are returned joined. Hooks still running when the
+// shared time budget runs out are stopped.
+func RunFinalizeHooks(ctx context.Context, exec executil.Executor, hooks []string, data config.FinalizeHookTemplateData, feedback string) error {
+	runner, ok := exec.(executil.InputRunner)
+	if !ok {
+		return fmt.Errorf("finalize hooks: executor %T cannot write to stdin", exec)
+	}
+	renderer := tmpl.New(tmpl.Config{})
+
+	ctx, cancel := context.WithTimeout(ctx, finalizeHooksTimeout)
+	defer cancel()
+
+	var errs []error
+	for i, hook := range hooks {
+		if err := runFinalizeHook(ctx, runner, renderer, hook, data, feedback); err != nil {
+			errs = append(errs, fmt.Errorf("finalize hook %d: %w", i+1, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func runFinalizeHook(ctx context.Context, runner executil.InputRunner, renderer *tmpl.Renderer, hook string, data config.FinalizeHookTemplateData, feedback string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("not run: %w", err)
+	}
+	rendered, err := renderer.Render(hook, data)
+	if err != nil {
+		return fmt.Errorf("render command: %w", err)
+	}
+
+	shell, args := executil.Shell(rendered)
+	out, err := runner.RunInputDir(ctx, data.ContextDir, strings.NewReader(feedback), shell, args...)
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package review
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/pkg/executil"
+	"github.com/colonyops/hive/pkg/executil/executiltest"
+)
+
+func TestRunFinalizeHooks(t *testing.T) {
+	dir := t.TempDir()
+	data := config.FinalizeHookTemplateData{
+		DocRel:     "plans/auth.md",
+		Verdict:    "approve",
+		ReviewID:   "rev1",
+		ContextDir: dir,
+	}
+
+	err := RunFinalizeHooks(context.Background(), &executil.RealExecutor{}, []string{
+		"cat > feedback.txt",
+		"echo '{{ .DocRel }} {{ .Verdict }} {{ .ReviewID }}' > vars.txt",
+	}, data, "Line 1:\nfix this\n")
+	require.NoError(t, err)
+
+	feedback, err := os.ReadFile(filepath.Join(dir, "feedback.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "Line 1:\nfix this\n", string(feedback))
+
+	vars, err := os.ReadFile(filepath.Join(dir, "vars.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "plans/auth.md approve rev1\n", string(vars))
+}
+
+func TestRunFinalizeHooks_FailureDoesNotStopLaterHooks(t *testing.T) {
+	dir := t.TempDir()
+	data := config.FinalizeHookTemplateData{ContextDir: dir}
+
+	err := RunFinalizeHooks(context.Background(), &executil.RealExecutor{}, []string{
+		"echo boom >&2; exit 1",
+		"touch ran",
+	}, data, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "finalize hook 1")
+	assert.Contains(t, err.Error(), "boom")
+	assert.FileExists(t, filepath.Join(dir, "ran"))
+}
+
+func TestRunFinalizeHooks_UsesExecutor(t *testing.T) {
+	exec := &executiltest.Exec{}
+	data := config.FinalizeHookTemplateData{DocRel: "plans/it's.md", ContextDir: "/ctx"}
+
+	err := RunFinalizeHooks(context.Background(), exec, []string{"notify {{ .DocRel | shq }}"}, data, "feedback")
+	require.NoError(t, err)
+
+	calls := exec.Calls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, "/ctx", calls[0].Dir)
+	assert.Equal(t, "feedback", calls[0].Stdin)
+	assert.Equal(t, `notify 'plans/it'\''s.md'`, calls[0].Args[len(calls[0].Args)-1])
+}
+
+func TestRunFinalizeHooks_SharesOneTimeBudget(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	exec := &executiltest.Exec{}
+
+	err := RunFinalizeHooks(ctx, exec, []string{"true", "true"}, config.FinalizeHookTemplateData{}, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not run")
+	assert.Empty(t, exec.Calls(), "hooks are not started once the budget is spent")
+}
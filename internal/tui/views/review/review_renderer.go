@@ -0,0 +1,110 @@
+package review
+
+import (
+	"strings"
+
+	"charm.land/glamour/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/styles"
+)
+
+// Renderer turns a document's markdown into the display lines shown in the
+// review view. Comments anchor to these lines, so a renderer must produce the
+// same lines for the same content and width.
+type Renderer interface {
+	Render(content string, wrapWidth int) ([]string, error)
+}
+
+// documentRenderer renders every document in the process. It is chosen once
+// at startup from review.renderer.
+var documentRenderer Renderer = GlamourRenderer{}
+
+// SetRenderer selects the renderer documents are displayed with by its
+// review.renderer name. Unknown names select the default glamour renderer.
+// Call it before any document is rendered.
+func SetRenderer(name string) {
+	documentRenderer = RendererByName(name)
+}
+
+// RendererByName returns the renderer for a review.renderer name, falling
+// back to GlamourRenderer.
+func RendererByName(name string) Renderer {
+	switch name {
+	case config.ReviewRendererMinimal:
+		return MinimalRenderer{}
+	case config.ReviewRendererRaw:
+		return RawRenderer{}
+	default:
+		return GlamourRenderer{}
+	}
+}
+
+// GlamourRenderer renders styled markdown with glamour and the active theme.
+type GlamourRenderer struct{}
+
+func (GlamourRenderer) Render(content string, wrapWidth int) ([]string, error) {
+	r, err := glamour.NewTermRenderer(
+		glamour.WithStyles(styles.GlamourStyle()),
+		glamour.WithWordWrap(wrapWidth),
+	)
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := r.Render(content)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.TrimRight(rendered, "\n"), "\n"), nil
+}
+
+// MinimalRenderer wraps the source line by line and highlights headings,
+// quotes, and fenced code without parsing the markdown. It is much faster
+// than glamour on large documents and uses no box-drawing characters.
+type MinimalRenderer struct{}
+
+func (MinimalRenderer) Render(content string, wrapWidth int) ([]string, error) {
+	var (
+		lines   []string
+		inFence bool
+	)
+	for _, line := range sourceLines(content) {
+		trimmed := strings.TrimSpace(line)
+		fence := strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+
+		style := styles.TextForegroundStyle
+		switch {
+		case fence || inFence:
+			style = styles.TextSecondaryStyle
+		case strings.HasPrefix(trimmed, "#"):
+			style = styles.TextPrimaryBoldStyle
+		case strings.HasPrefix(trimmed, ">"):
+			style = styles.TextMutedStyle
+		}
+		if fence {
+			inFence = !inFence
+		}
+
+		for wrapped := range strings.SplitSeq(ansi.Wrap(line, wrapWidth, ""), "\n") {
+			lines = append(lines, style.Render(wrapped))
+		}
+	}
+	return lines, nil
+}
+
+// RawRenderer shows the source text as-is: no styling and no wrapping, so
+// display lines match the file's lines.
+type RawRenderer struct{}
+
+func (RawRenderer) Render(content string, _ int) ([]string, error) {
+	return sourceLines(content), nil
+}
+
+// sourceLines splits content into lines with tabs expanded, dropping
+// trailing blank lines.
+func sourceLines(content string) []string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.ReplaceAll(content, "\t", "    ")
+	return strings.Split(strings.TrimRight(content, "\n"), "\n")
+}
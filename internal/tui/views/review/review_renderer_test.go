@@ -0,0 +1,55 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/terminal"
+)
+
+func TestRendererByName(t *testing.T) {
+	assert.IsType(t, GlamourRenderer{}, RendererByName(""))
+	assert.IsType(t, GlamourRenderer{}, RendererByName(config.ReviewRendererGlamour))
+	assert.IsType(t, MinimalRenderer{}, RendererByName(config.ReviewRendererMinimal))
+	assert.IsType(t, RawRenderer{}, RendererByName(config.ReviewRendererRaw))
+}
+
+func TestRawRenderer(t *testing.T) {
+	lines, err := RawRenderer{}.Render("# Plan\r\n\n\tstep one\n\n", 20)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"# Plan", "", "    step one"}, lines)
+}
+
+func TestMinimalRenderer(t *testing.T) {
+	content := "# Plan\n\nThis sentence is long enough to wrap.\n```\ncode\n```\n"
+	lines, err := MinimalRenderer{}.Render(content, 20)
+	require.NoError(t, err)
+
+	plain := make([]string, len(lines))
+	for i, line := range lines {
+		plain[i] = terminal.StripANSI(line)
+	}
+	assert.Equal(t, []string{
+		"# Plan",
+		"",
+		"This sentence is",
+		"long enough to wrap.",
+		"```",
+		"code",
+		"```",
+	}, plain)
+}
+
+func TestDocument_RenderUsesSelectedRenderer(t *testing.T) {
+	t.Cleanup(func() { SetRenderer("") })
+	SetRenderer(config.ReviewRendererRaw)
+
+	doc := Document{Content: "**bold**\nline two"}
+	rendered, err := doc.Render(80)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"**bold**", "line two"}, doc.RenderedLines)
+	assert.Contains(t, terminal.StripANSI(rendered), "**bold**")
+}
@@ -0,0 +1,120 @@
+package review
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/colonyops/hive/internal/core/styles"
+	"github.com/colonyops/hive/internal/tui/components"
+)
+
+// revisionPickerRows is the number of commits the revision picker shows.
+const revisionPickerRows = 10
+
+// RevisionPicker lists the commits that changed a document so one can be
+// opened read-only for review.
+type RevisionPicker struct {
+	doc       Document
+	revisions []Revision
+	cursor    int
+	err       string // shown below the list, e.g. when the version cannot be read
+	chosen    bool
+	loading   bool // the chosen revision is being read
+	cancelled bool
+}
+
+// NewRevisionPicker creates a picker over revisions of doc, newest first.
+func NewRevisionPicker(doc Document, revisions []Revision) RevisionPicker {
+	return RevisionPicker{doc: doc, revisions: revisions}
+}
+
+// Update handles messages.
+func (m RevisionPicker) Update(msg tea.Msg) (RevisionPicker, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "j", "down", "ctrl+n":
+		m.cursor = min(m.cursor+1, len(m.revisions)-1)
+	case "k", "up", "ctrl+p":
+		m.cursor = max(m.cursor-1, 0)
+	case keyEnter:
+		if len(m.revisions) > 0 && !m.loading {
+			m.chosen = true
+		}
+	case "esc", "q":
+		m.cancelled = true
+	}
+	m.err = ""
+	return m, nil
+}
+
+// View renders the picker.
+func (m RevisionPicker) View() string {
+	parts := []string{
+		styles.ReviewCommentTitleStyle.Render("Open at Commit"),
+		styles.ReviewCommentLabelStyle.Render(m.doc.RelPath),
+	}
+
+	offset := max(min(m.cursor-revisionPickerRows/2, len(m.revisions)-revisionPickerRows), 0)
+	for i := offset; i < min(offset+revisionPickerRows, len(m.revisions)); i++ {
+		rev := m.revisions[i]
+		row := fmt.Sprintf("%s  %s  %s", rev.ShortHash, rev.Date.Format(time.DateOnly), rev.Subject)
+		if i == m.cursor {
+			parts = append(parts, styles.TextPrimaryStyle.Render("> "+row))
+		} else {
+			parts = append(parts, "  "+row)
+		}
+	}
+
+	if m.loading {
+		parts = append(parts, styles.TextMutedStyle.Render("Loading..."))
+	}
+	if m.err != "" {
+		parts = append(parts, styles.TextErrorStyle.Render(m.err))
+	}
+	parts = append(parts, styles.ReviewCommentHelpStyle.Render(components.KeyHints(
+		components.HelpEntry{Key: "j/k", Desc: "select"},
+		components.HelpEntry{Key: "enter", Desc: "open read-only"},
+		components.HelpEntry{Key: "esc", Desc: "cancel"},
+	)))
+
+	return strings.Join(parts, "\n")
+}
+
+// Chosen returns the selected revision once enter was pressed.
+func (m RevisionPicker) Chosen() (Revision, bool) {
+	if !m.chosen || len(m.revisions) == 0 {
+		return Revision{}, false
+	}
+	return m.revisions[m.cursor], true
+}
+
+// Cancelled returns true if the picker was dismissed.
+func (m RevisionPicker) Cancelled() bool {
+	return m.cancelled
+}
+
+// Document returns the working-copy document the revisions belong to.
+func (m RevisionPicker) Document() Document {
+	return m.doc
+}
+
+// SetLoading marks the chosen revision as being read, so enter is ignored
+// until SetError or the picker closes.
+func (m *RevisionPicker) SetLoading() {
+	m.loading = true
+	m.chosen = false
+}
+
+// SetError shows msg in the picker and keeps it open.
+func (m *RevisionPicker) SetError(msg string) {
+	m.err = msg
+	m.chosen = false
+	m.loading = false
+}
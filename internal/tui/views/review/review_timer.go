@@ -0,0 +1,80 @@
+package review
+
+import (
+	"context"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// reviewIdleLimit caps the time credited for one gap between inputs, so
+	// a review left open while away is not counted as time spent reviewing.
+	reviewIdleLimit = 2 * time.Minute
+	// reviewTimeFlush is how much uncounted time accumulates before it is
+	// written to the store.
+	reviewTimeFlush = 30 * time.Second
+)
+
+// reviewTimer measures the focused time spent on a review session: the gaps
+// between key presses and mouse events while the session is active, each
+// capped at reviewIdleLimit.
+type reviewTimer struct {
+	sessionID string        // session the pending time belongs to
+	last      time.Time     // time of the previous input, zero before the first
+	pending   time.Duration // time not yet written to the store
+}
+
+// trackReviewTime credits the time since the previous input to the active
+// review session when msg is user input.
+func (v *View) trackReviewTime(msg tea.Msg, now time.Time) {
+	switch msg.(type) {
+	case tea.KeyPressMsg, tea.MouseMsg:
+	default:
+		return
+	}
+
+	id := v.activeReviewID()
+	if v.timer.sessionID != id {
+		v.FlushReviewTime()
+		v.timer.sessionID = id
+	}
+
+	last := v.timer.last
+	v.timer.last = now
+	if id == "" || last.IsZero() {
+		return
+	}
+	gap := min(now.Sub(last), reviewIdleLimit)
+	if gap <= 0 {
+		return
+	}
+	v.timer.pending += gap
+	v.activeSession.ReviewTime += gap
+	if v.timer.pending >= reviewTimeFlush {
+		v.FlushReviewTime()
+	}
+}
+
+// FlushReviewTime writes the review time not yet stored to the review
+// session it was spent on. Call it before the view is discarded.
+func (v *View) FlushReviewTime() {
+	pending := v.timer.pending
+	v.timer.pending = 0
+	if pending == 0 || v.timer.sessionID == "" || v.store == nil {
+		return
+	}
+	if err := v.store.AddReviewTime(context.Background(), v.timer.sessionID, pending); err != nil {
+		log.Debug().Err(err).Str("session", v.timer.sessionID).Msg("review: record review time")
+	}
+}
+
+// unflushedReviewTime returns the time spent on the session with id that is
+// not yet stored.
+func (v *View) unflushedReviewTime(id string) time.Duration {
+	if v.timer.sessionID != id {
+		return 0
+	}
+	return v.timer.pending
+}
@@ -0,0 +1,57 @@
+package review
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corereview "github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/internal/data/stores"
+)
+
+func TestTrackReviewTime(t *testing.T) {
+	tmpDir := t.TempDir()
+	database, err := db.Open(tmpDir, db.DefaultOpenOptions())
+	require.NoError(t, err)
+	defer func() { _ = database.Close() }()
+	store := stores.NewReviewStore(database)
+
+	path := filepath.Join(tmpDir, "plan.md")
+	require.NoError(t, os.WriteFile(path, []byte("Step 1\nStep 2"), 0o644))
+	doc := Document{Path: path, RelPath: "plan.md", Type: DocTypePlan, Content: "Step 1\nStep 2"}
+
+	v := New([]Document{doc}, tmpDir, store, nil, 0)
+	v.SetSize(80, 24)
+	v.loadDocument(&doc)
+	v.selectionStart = 1
+	v.cursorLine = 1
+	v.addComment("Split this step", corereview.SeveritySuggestion)
+	require.NotNil(t, v.activeSession)
+
+	key := tea.KeyPressMsg{Code: 'j', Text: "j"}
+	start := time.Now()
+	v.trackReviewTime(key, start)
+	v.trackReviewTime(tea.WindowSizeMsg{}, start.Add(time.Hour)) // not input
+	v.trackReviewTime(key, start.Add(10*time.Second))
+	v.trackReviewTime(key, start.Add(time.Hour)) // idle gap is capped
+
+	want := 10*time.Second + reviewIdleLimit
+	assert.Equal(t, want, v.activeSession.ReviewTime)
+
+	got, err := store.GetSession(context.Background(), path)
+	require.NoError(t, err)
+	assert.Equal(t, want, got.ReviewTime, "time past reviewTimeFlush is stored")
+
+	v.trackReviewTime(key, start.Add(time.Hour+5*time.Second))
+	v.FlushReviewTime()
+	got, err = store.GetSession(context.Background(), path)
+	require.NoError(t, err)
+	assert.Equal(t, want+5*time.Second, got.ReviewTime)
+}
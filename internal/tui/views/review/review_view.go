@@ -2,18 +2,17 @@ package review
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"charm.land/bubbles/v2/list"
-	"charm.land/bubbles/v2/textinput"
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	lipgloss "charm.land/lipgloss/v2"
@@ -21,11 +20,15 @@ import (
 	"github.com/rs/zerolog/log"
 
 	act "github.com/colonyops/hive/internal/core/action"
+	"github.com/colonyops/hive/internal/core/config"
+	corekv "github.com/colonyops/hive/internal/core/kv"
 	corereview "github.com/colonyops/hive/internal/core/review"
 	"github.com/colonyops/hive/internal/core/styles"
 	"github.com/colonyops/hive/internal/data/stores"
 	"github.com/colonyops/hive/internal/tui/components"
+	"github.com/colonyops/hive/internal/tui/components/input"
 	"github.com/colonyops/hive/internal/tui/views/shared"
+	"github.com/colonyops/hive/pkg/executil"
 )
 
 // ReviewFinalizedMsg is sent when review is finalized and copied to clipboard.
@@ -33,8 +36,19 @@ type ReviewFinalizedMsg struct {
 	Feedback     string
 	DocumentPath string
 	DocumentRel  string
+	Verdict      corereview.Verdict
+	SavedPath    string // Feedback file in the context directory, when saving is enabled
+	SaveErr      error  // Error writing the feedback file
+	HookErr      error  // Failures from review.finalize_hooks
 }
 
+const (
+	// kvSearchHistoryKey is the kv store key for the document search history.
+	kvSearchHistoryKey = "tui.history.review_search"
+	// commentHistoryLimit is the number of comment drafts kept for recall.
+	commentHistoryLimit = 20
+)
+
 // reviewDiscardedMsg is sent when review is discarded (internal only).
 type reviewDiscardedMsg struct{}
 
@@ -52,20 +66,27 @@ type View struct {
 	selectedDoc       *Document                // Currently selected document for preview
 	selectionMode     bool                     // True when in visual selection mode
 	selectionStart    int                      // Line number where selection starts (1-indexed)
+	selectionStartCol int                      // Column where a charwise selection starts (0 = linewise)
+	cursorCol         int                      // Column cursor in charwise visual mode (0 = linewise)
 	cursorLine        int                      // Line number where cursor is positioned (1-indexed)
 	activeSession     *Session                 // Current review session with comments
+	currentReview     *Session                 // Review that "add to current review" attaches documents to; survives navigation
 	commentModal      *CommentModal            // Active comment entry modal
 	confirmModal      *components.ConfirmModal // Active confirmation modal
 	finalizationModal *FinalizationModal       // Active finalization options modal
+	revisionPicker    *RevisionPicker          // Active commit picker for opening an earlier version
+	commentEdits      *CommentEdits            // Active viewer for the edits of a comment
 	feedbackGenerated string                   // Generated feedback (for clipboard)
 	searchMode        bool                     // True when in search/filter mode
-	searchInput       textinput.Model          // Search input field
+	searchInput       input.Line               // Search input field
 	searchQuery       string                   // Current search query
 	searchMatches     []int                    // Line numbers of search matches (1-indexed)
 	searchMatchIndex  int                      // Current match index in searchMatches
 	pendingDeleteLine int                      // Line number for pending comment deletion (0 if none)
 	pendingDiscard    bool                     // True when waiting for discard confirmation
+	pendingMotion     act.Type                 // DocsNextComment or DocsPrevComment awaiting the "c" of a ]c / [c motion
 	editingCommentID  string                   // ID of comment being edited (empty if creating new)
+	importingFeedback bool                     // Comment modal holds pasted feedback to import
 	lineMapping       map[int]int              // Maps document line numbers to display line numbers (nil when no comments)
 
 	// Phase 1 refactor: extracted components
@@ -74,19 +95,44 @@ type View struct {
 	modalState          ModalState   // Modal coordination
 
 	// Phase 2: folder tree state (parallel to list.Model, not yet rendered)
-	roots           []*DocTreeNode  // document folder tree
-	flatNodes       []DocFlatNode   // flattened for rendering
-	treeCursor      int             // current cursor position in flatNodes
-	treeScroll      int             // scroll offset in flatNodes
-	splitRatio      int             // panel split percentage (0 = default 30%)
-	showPreview     bool            // whether the right detail pane is visible
-	showTree        bool            // whether the left tree pane is visible
-	treeSearchMode  bool            // whether the tree search input is active
-	treeSearchInput textinput.Model // search input for tree navigation
-	treeSearchQuery string          // current tree search query
+	roots           []*DocTreeNode // document folder tree
+	flatNodes       []DocFlatNode  // flattened for rendering
+	treeCursor      int            // current cursor position in flatNodes
+	treeScroll      int            // scroll offset in flatNodes
+	splitRatio      int            // panel split percentage (0 = default 30%)
+	showPreview     bool           // whether the right detail pane is visible
+	showTree        bool           // whether the left tree pane is visible
+	treeSearchMode  bool           // whether the tree search input is active
+	treeSearchInput input.Line     // search input for tree navigation
+	treeSearchQuery string         // current tree search query
+
+	commentHistory *input.History // comment drafts, recalled in the comment modal
 
 	handler    KeyResolver            // resolves configurable keybindings to actions
+	exec       executil.Executor      // runs git for earlier document versions and finalize hooks
+	gitPath    string                 // git binary used with exec
 	helpDialog *components.HelpDialog // active help overlay, nil when not shown
+
+	vaultDocs []Document // documents from external vaults, appended to every discovery
+	changed   []string   // paths changed since their last finalized review, as of the last refreshChanged
+
+	feedbackTemplate string   // configured feedback template for the current repo ("" = built-in)
+	reviewer         string   // .Reviewer in feedback templates
+	saveFeedback     bool     // write finalized feedback to the context directory
+	finalizeHooks    []string // review.finalize_hooks commands
+	includeTiming    bool     // add review time and comment timestamps to feedback
+	timer            reviewTimer
+
+	collab   *collab       // live presence of other reviewers, nil when disabled
+	instant  instantMode   // sends each saved comment to an agent inbox when enabled
+	comments commentsPanel // reader-mode comment list beside the document
+
+	annotationStore         *stores.AnnotationStore // persistence for personal annotations, nil keeps them in memory
+	annotations             []corereview.Annotation // personal notes on the selected document
+	hideAnnotations         bool                    // annotations are not rendered or editable while hidden
+	annotating              bool                    // comment modal holds an annotation
+	editingAnnotationID     string                  // ID of annotation being edited (empty if creating new)
+	pendingDeleteAnnotation string                  // ID of annotation awaiting delete confirmation
 }
 
 // New creates a new review view.
@@ -126,17 +172,18 @@ func New(documents []Document, contextDir string, store *stores.ReviewStore, han
 	// Create viewport for document preview
 	vp := viewport.New()
 
+	// Document and tree searches share one history
+	searchHistory := input.NewHistory(0)
+
 	// Initialize search input (for document content search in full-screen mode)
-	ti := textinput.New()
+	ti := input.NewLine(searchHistory)
 	ti.Placeholder = "Search..."
 	ti.CharLimit = 100
-	ti.KeyMap.Paste.SetEnabled(true)
 
 	// Initialize tree search input (for navigating the file tree)
-	ti2 := textinput.New()
+	ti2 := input.NewLine(searchHistory)
 	ti2.Placeholder = "/"
 	ti2.CharLimit = 100
-	ti2.KeyMap.Paste.SetEnabled(true)
 
 	// Initialize Phase 1 components
 	documentView := NewDocumentView(nil)
@@ -153,10 +200,13 @@ func New(documents []Document, contextDir string, store *stores.ReviewStore, han
 		cursorLine:      1,
 		searchInput:     ti,
 		treeSearchInput: ti2,
+		commentHistory:  input.NewHistory(commentHistoryLimit),
 		showPreview:     true,
 		showTree:        true,
 		splitRatio:      splitRatio,
 		handler:         handler,
+		exec:            &executil.RealExecutor{},
+		gitPath:         "git",
 		// Phase 1 components
 		documentView:        documentView,
 		searchModeComponent: searchModeComponent,
@@ -181,10 +231,10 @@ func (v *View) SetSize(width, height int) {
 	}
 
 	if v.fullScreen {
-		v.viewport = viewport.New(viewport.WithWidth(v.width), viewport.WithHeight(contentHeight))
-		rendered, err := v.selectedDoc.Render(v.width)
+		v.viewport = viewport.New(viewport.WithWidth(v.docWidth()), viewport.WithHeight(contentHeight))
+		rendered, err := v.selectedDoc.Render(v.docWidth())
 		if err == nil {
-			if v.activeSession != nil && len(v.activeSession.Comments) > 0 {
+			if v.hasInlineNotes() {
 				v.renderSelection()
 			} else {
 				v.viewport.SetContent(rendered)
@@ -224,7 +274,7 @@ func (v *View) IsFullScreen() bool {
 
 // HasActiveEditor returns true if an input field or overlay has focus.
 func (v *View) HasActiveEditor() bool {
-	return v.searchMode || v.treeSearchMode || v.commentModal != nil || v.helpDialog != nil || v.finalizationModal != nil || v.confirmModal != nil
+	return v.searchMode || v.treeSearchMode || v.commentModal != nil || v.helpDialog != nil || v.finalizationModal != nil || v.confirmModal != nil || v.revisionPicker != nil || v.commentEdits != nil
 }
 
 // ContextDir returns the current context directory.
@@ -237,6 +287,94 @@ func (v *View) SetRepoKey(repoKey string) {
 	v.repoKey = repoKey
 }
 
+// SetFeedbackTemplate sets the template used to format finalized feedback and
+// the reviewer name passed to it. An empty template uses the built-in format.
+func (v *View) SetFeedbackTemplate(template, reviewer string) {
+	v.feedbackTemplate = template
+	v.reviewer = reviewer
+}
+
+// SetSaveFeedback enables writing finalized feedback to the context
+// directory's reviews folder (see SaveFeedback).
+func (v *View) SetSaveFeedback(enabled bool) {
+	v.saveFeedback = enabled
+}
+
+// SetIncludeTiming adds the review time and each comment's timestamp to
+// finalized feedback.
+func (v *View) SetIncludeTiming(enabled bool) {
+	v.includeTiming = enabled
+}
+
+// SetGit runs gitPath through exec to read earlier document versions. Finalize
+// hooks run through exec too.
+func (v *View) SetGit(gitPath string, exec executil.Executor) {
+	v.gitPath = gitPath
+	v.exec = exec
+}
+
+// SetHistoryStore persists the search history in store, loading the
+// searches saved by earlier runs. Comment drafts are kept for this run only.
+func (v *View) SetHistoryStore(store corekv.KV) {
+	history := input.LoadHistory(store, kvSearchHistoryKey, 0)
+	v.searchInput.History = history
+	v.treeSearchInput.History = history
+}
+
+// SetFinalizeHooks sets the shell commands run after a review is finalized
+// (see RunFinalizeHooks).
+func (v *View) SetFinalizeHooks(hooks []string) {
+	v.finalizeHooks = hooks
+}
+
+// finalizedCmd returns a command that saves feedback to the context directory
+// when enabled, runs finalize hooks, and reports the finalized review.
+func (v *View) finalizedCmd(feedback, docPath, docRel, reviewID string, verdict corereview.Verdict) tea.Cmd {
+	save := v.saveFeedback && v.contextDir != ""
+	hooks, exec := v.finalizeHooks, v.exec
+	data := config.FinalizeHookTemplateData{
+		DocPath:    docPath,
+		DocRel:     docRel,
+		Verdict:    string(verdict),
+		ReviewID:   reviewID,
+		SessionID:  v.instant.candidate.ID,
+		Reviewer:   v.reviewer,
+		ContextDir: v.contextDir,
+	}
+	return func() tea.Msg {
+		msg := ReviewFinalizedMsg{Feedback: feedback, DocumentPath: docPath, DocumentRel: docRel, Verdict: verdict}
+		if save {
+			msg.SavedPath, msg.SaveErr = SaveFeedback(data.ContextDir, docRel, feedback, time.Now())
+			data.SavedPath = msg.SavedPath
+		}
+		if len(hooks) > 0 {
+			msg.HookErr = RunFinalizeHooks(context.Background(), exec, hooks, data, feedback)
+		}
+		return msg
+	}
+}
+
+// activeReviewID returns the active review session's ID, or "" when no review
+// is in progress.
+func (v *View) activeReviewID() string {
+	if v.activeSession == nil {
+		return ""
+	}
+	return v.activeSession.ID
+}
+
+// generateFeedback formats the active session's comments with the configured
+// feedback template. A template that fails to render falls back to the
+// built-in format so finalizing never loses comments.
+func (v *View) generateFeedback(docRel string) string {
+	feedback, err := RenderReviewFeedback(v.activeSession, docRel, v.feedbackTemplate, v.reviewer, v.includeTiming)
+	if err != nil {
+		log.Error().Err(err).Msg("review feedback template failed, using built-in format")
+		return generateFeedback(v.activeSession, docRel, v.includeTiming)
+	}
+	return feedback
+}
+
 // RepoKey returns the current owner/repo display label.
 func (v View) RepoKey() string {
 	return v.repoKey
@@ -297,6 +435,8 @@ func (v *View) HelpSections() []components.HelpDialogSection {
 					{Key: "ctrl+d/u", Desc: "half page down/up"},
 					{Key: "g/G", Desc: "top/bottom"},
 					{Key: "n/N", Desc: "next/prev comment or match"},
+					{Key: "]c/[c", Desc: "next/prev comment"},
+					{Key: "enter", Desc: "jump to comment's reference"},
 					{Key: "h/esc", Desc: "back to tree"},
 				},
 			},
@@ -304,12 +444,21 @@ func (v *View) HelpSections() []components.HelpDialogSection {
 				Title: "Actions",
 				Entries: []components.HelpEntry{
 					{Key: "V", Desc: "visual select mode"},
+					{Key: "h/l, w/b", Desc: "select within line (in visual mode)"},
 					{Key: "c", Desc: "add comment (in visual mode)"},
-					{Key: "e", Desc: "edit comment at cursor"},
-					{Key: "d", Desc: "delete comment at cursor"},
+					{Key: "A", Desc: "add annotation (in visual mode)"},
+					{Key: "e", Desc: "edit comment or annotation at cursor"},
+					{Key: "p", Desc: "import pasted feedback"},
+					{Key: "P", Desc: "open at an earlier commit"},
+					{Key: "R", Desc: "show edits of comment at cursor"},
+					{Key: "d", Desc: "delete comment or annotation at cursor"},
 					{Key: "D", Desc: "discard entire review"},
 					{Key: "/", Desc: "search document"},
 					{Key: "f", Desc: "finalize & copy to clipboard"},
+					{Key: "I", Desc: "toggle instant mode"},
+					{Key: "C", Desc: "toggle comments panel"},
+					{Key: "H", Desc: "show/hide annotations"},
+					{Key: "tab", Desc: "switch focus to/from comments panel"},
 				},
 			},
 		}
@@ -363,6 +512,20 @@ func (v *View) ToggleTree() tea.Cmd {
 	return v.previewDocument(doc)
 }
 
+// SetVaultDocuments adds documents discovered in external vaults (see
+// DiscoverVaultDocuments) to the tree. They are kept across file watcher
+// rescans, which only cover contextDir.
+func (v *View) SetVaultDocuments(docs []Document) {
+	v.vaultDocs = docs
+	if len(v.vaultDocs) == 0 {
+		return
+	}
+
+	all := slices.Concat(extractDocumentsFromListItems(v.list.Items()), v.vaultDocs)
+	v.list.SetItems(BuildTreeItems(all))
+	v.rebuildTree()
+}
+
 // SetContextDir updates the context directory, restarts the file watcher,
 // and returns a cmd that scans and delivers a DocumentChangeMsg.
 func (v *View) SetContextDir(contextDir string) tea.Cmd {
@@ -401,16 +564,30 @@ func (v *View) SetContextDir(contextDir string) tea.Cmd {
 
 // Init initializes the review view and starts the file watcher.
 func (v View) Init() tea.Cmd {
+	var cmds []tea.Cmd
 	if v.watcher != nil {
-		return v.watcher.Start()
+		cmds = append(cmds, v.watcher.Start())
 	}
-	return nil
+	if v.collab != nil {
+		cmds = append(cmds, scheduleCollabTick())
+	}
+	cmds = append(cmds, v.refreshChanged())
+	return tea.Batch(cmds...)
 }
 
 // Update handles messages.
 // The underlying list handles j/k navigation, Enter selection, and / filtering.
 func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
+	v.trackReviewTime(msg, time.Now())
+
 	switch msg := msg.(type) {
+	case revisionsLoadedMsg:
+		return v, v.handleRevisionsLoaded(msg)
+
+	case revisionLoadedMsg:
+		v.handleRevisionLoaded(msg)
+		return v, nil
+
 	case docPreviewRenderedMsg:
 		// Apply rendered content only if the user hasn't navigated away.
 		if v.selectedDoc != nil && v.selectedDoc.Path == msg.path {
@@ -418,19 +595,39 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 		}
 		return v, nil
 
+	case CollabTickMsg:
+		if v.collab == nil {
+			return v, nil
+		}
+		return v, tea.Batch(v.syncCollab(), v.collab.fetch(), scheduleCollabTick())
+
+	case CollabEventsMsg:
+		return v, v.handleCollabEvents(msg)
+
+	case commentsRefreshedMsg:
+		v.handleCommentsRefreshed(msg)
+		return v, nil
+
+	case VaultDocumentsMsg:
+		v.SetVaultDocuments(msg.Documents)
+		return v, nil
+
 	case DocumentChangeMsg:
 		// Rebuild tree with new documents
 		log.Debug().
 			Int("document_count", len(msg.Documents)).
+			Int("vault_document_count", len(v.vaultDocs)).
 			Msg("review: rebuilding document tree from file watcher")
-		items := BuildTreeItems(msg.Documents)
+		docs := slices.Concat(msg.Documents, v.vaultDocs)
+		items := BuildTreeItems(docs)
 		v.list.SetItems(items)
 		v.rebuildTree()
+		changed := v.refreshChanged()
 
 		// Refresh currently open document if one is selected
 		if v.selectedDoc != nil {
 			// Find updated version of current document
-			for _, doc := range msg.Documents {
+			for _, doc := range docs {
 				if doc.Path == v.selectedDoc.Path {
 					// Reload the document to refresh the view
 					v.loadDocument(&doc)
@@ -441,18 +638,29 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 
 		// Continue watching for more changes
 		if v.watcher != nil {
-			return v, v.watcher.Start()
+			return v, tea.Batch(changed, v.watcher.Start())
 		}
+		return v, changed
+
+	case changedDocsLoadedMsg:
+		if msg.err != nil {
+			log.Debug().Err(msg.err).Msg("review: failed to load last reviewed documents")
+			return v, nil
+		}
+		v.changed = msg.paths
+		v.rebuildTree()
 		return v, nil
 
 	case reviewDiscardedMsg:
 		// Clear active session and reload document
 		v.activeSession = nil
+		v.timer = reviewTimer{}
+		v.currentReview = nil
 		v.updateTreeItemCommentCount()
 		if v.selectedDoc != nil {
 			v.loadDocument(v.selectedDoc)
 		}
-		return v, nil
+		return v, v.publishReviewEvent(ReviewEventDiscarded)
 
 	case tea.KeyPressMsg:
 		// Handle help dialog if active.
@@ -468,6 +676,29 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 			return v, nil
 		}
 
+		// Handle the commit picker for opening an earlier version
+		if v.revisionPicker != nil {
+			picker, cmd := v.revisionPicker.Update(msg)
+			v.revisionPicker = &picker
+			if rev, ok := picker.Chosen(); ok {
+				v.revisionPicker.SetLoading()
+				return v, tea.Batch(cmd, v.openRevision(picker.Document(), rev))
+			} else if picker.Cancelled() {
+				v.revisionPicker = nil
+			}
+			return v, cmd
+		}
+
+		// Handle the viewer for the edits of a comment
+		if v.commentEdits != nil {
+			edits, cmd := v.commentEdits.Update(msg)
+			v.commentEdits = &edits
+			if edits.Closed() {
+				v.commentEdits = nil
+			}
+			return v, cmd
+		}
+
 		// Toggle help dialog.
 		if msg.String() == "?" && !v.treeSearchMode && !v.searchMode && v.commentModal == nil {
 			d := components.NewHelpDialog("Keyboard Shortcuts", v.HelpSections(), v.width, v.height)
@@ -482,33 +713,35 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 
 			if v.finalizationModal.Confirmed() {
 				generalComment := v.finalizationModal.GeneralComment()
+				verdict := v.finalizationModal.Verdict()
 				v.finalizationModal = nil
 
 				feedback := v.feedbackGenerated
 				if generalComment != "" {
 					feedback = "General Notes:\n" + generalComment + "\n\n---\n\n" + feedback
 				}
+				if verdict != corereview.VerdictComment {
+					feedback = "Verdict: " + verdict.Label() + "\n\n" + feedback
+				}
 
 				// Finalize session in database if store is available
 				if v.store != nil && v.activeSession != nil {
 					ctx := context.Background()
+					v.FlushReviewTime()
 					_ = v.store.FinalizeSession(ctx, v.activeSession.ID)
 					// Ignore errors - finalization is best effort
 				}
 
+				docPath, docRel := v.sessionDocument()
+				reviewID := v.activeReviewID()
+
 				// Clear active session
 				v.activeSession = nil
+				v.currentReview = nil
 				// Reload document without comments
 				v.loadDocument(v.selectedDoc)
 
-				var docPath, docRel string
-				if v.selectedDoc != nil {
-					docPath = v.selectedDoc.Path
-					docRel = v.selectedDoc.RelPath
-				}
-				return v, func() tea.Msg {
-					return ReviewFinalizedMsg{Feedback: feedback, DocumentPath: docPath, DocumentRel: docRel}
-				}
+				return v, tea.Batch(v.refreshChanged(), v.finalizedCmd(feedback, docPath, docRel, reviewID, verdict))
 			}
 
 			if v.finalizationModal.Cancelled() {
@@ -532,48 +765,57 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 					return v, v.discardReview()
 				}
 
+				// Check if this is an annotation deletion confirmation
+				if v.pendingDeleteAnnotation != "" {
+					v.deleteAnnotation(v.pendingDeleteAnnotation)
+					v.pendingDeleteAnnotation = ""
+					v.confirmModal = nil
+					v.renderSelection()
+					return v, cmd
+				}
+
 				// Check if this is a comment deletion confirmation
 				if v.pendingDeleteLine > 0 {
 					// Execute deletion
-					v.deleteCommentsAtLine(v.pendingDeleteLine)
+					deleted := v.deleteCommentsAtLine(v.pendingDeleteLine)
 					v.pendingDeleteLine = 0
 					v.confirmModal = nil
 					v.renderSelection()
-					return v, cmd
+					return v, tea.Batch(cmd, deleted)
 				}
 
 				// Otherwise, it's a finalization confirmation
 				// Generate feedback and finalize
-				feedback := GenerateReviewFeedback(v.activeSession, v.selectedDoc.RelPath)
+				docPath, docRel := v.sessionDocument()
+				feedback := v.generateFeedback(docRel)
 				v.feedbackGenerated = feedback
 				v.confirmModal = nil
+				published := v.publishReviewEvent(ReviewEventFinalized)
 
 				// Finalize session in database if store is available
 				if v.store != nil && v.activeSession != nil {
 					ctx := context.Background()
+					v.FlushReviewTime()
 					_ = v.store.FinalizeSession(ctx, v.activeSession.ID)
 					// Ignore errors - finalization is best effort
 				}
 
+				reviewID := v.activeReviewID()
+
 				// Clear active session
 				v.activeSession = nil
+				v.currentReview = nil
 				// Reload document without comments
 				v.loadDocument(v.selectedDoc)
 				// Return message to trigger clipboard copy
-				var docPath, docRel string
-				if v.selectedDoc != nil {
-					docPath = v.selectedDoc.Path
-					docRel = v.selectedDoc.RelPath
-				}
-				return v, func() tea.Msg {
-					return ReviewFinalizedMsg{Feedback: feedback, DocumentPath: docPath, DocumentRel: docRel}
-				}
+				return v, tea.Batch(published, v.refreshChanged(), v.finalizedCmd(feedback, docPath, docRel, reviewID, corereview.VerdictComment))
 			}
 
 			if v.confirmModal.Cancelled() {
 				v.confirmModal = nil
-				v.pendingDeleteLine = 0  // Clear pending delete
-				v.pendingDiscard = false // Clear pending discard
+				v.pendingDeleteLine = 0        // Clear pending delete
+				v.pendingDeleteAnnotation = "" // Clear pending annotation delete
+				v.pendingDiscard = false       // Clear pending discard
 				return v, cmd
 			}
 
@@ -586,14 +828,24 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 			v.commentModal = &modal
 
 			if v.commentModal.Submitted() {
-				// Check if we're editing an existing comment or creating a new one
-				if v.editingCommentID != "" {
+				// Check if we're importing feedback, editing an existing comment or creating a new one
+				if v.importingFeedback {
+					if v.importFeedback(v.commentModal.Value()) == 0 {
+						v.commentModal.SetError("No comments found for this document")
+						return v, cmd
+					}
+					v.importingFeedback = false
+					cmd = tea.Batch(cmd, v.publishReviewEvent(ReviewEventComment))
+				} else if v.annotating {
+					v.submitAnnotation(v.commentModal.Value())
+					v.selectionMode = false
+				} else if v.editingCommentID != "" {
 					// Update existing comment
-					v.updateComment(v.editingCommentID, v.commentModal.Value())
+					cmd = tea.Batch(cmd, v.updateComment(v.editingCommentID, v.commentModal.Value(), v.commentModal.Severity()))
 					v.editingCommentID = ""
 				} else {
 					// Create new comment
-					v.addComment(v.commentModal.Value())
+					cmd = tea.Batch(cmd, v.addComment(v.commentModal.Value(), v.commentModal.Severity()))
 					v.selectionMode = false
 				}
 				v.commentModal = nil
@@ -604,6 +856,9 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 			if v.commentModal.Cancelled() {
 				v.commentModal = nil
 				v.editingCommentID = "" // Clear editing state
+				v.importingFeedback = false
+				v.annotating = false
+				v.editingAnnotationID = ""
 				v.renderSelection()
 				return v, cmd
 			}
@@ -617,6 +872,7 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 			case keyEnter:
 				// Find matches and jump to first
 				v.searchQuery = v.searchInput.Value()
+				v.searchInput.Commit()
 				v.searchMode = false
 				v.findSearchMatches()
 				if len(v.searchMatches) > 0 {
@@ -633,6 +889,20 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 			}
 		}
 
+		// The comments panel takes navigation keys while focused; tab moves
+		// focus between it and the document.
+		if v.CommentsPanelOpen() && !v.selectionMode && !v.searchMode {
+			if v.comments.focused && v.handleCommentsPanelKey(msg.String()) {
+				return v, nil
+			}
+			if msg.String() == "tab" {
+				v.syncCommentsPanel()
+				v.comments.focused = true
+				v.moveCommentsCursor(0)
+				return v, nil
+			}
+		}
+
 		// Handle esc key
 		if msg.String() == "esc" {
 			// Priority order: tree search > doc search > visual mode > exit fullscreen > close preview
@@ -684,7 +954,8 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 				// Finalize review - show finalization options if there are comments
 				if v.activeSession != nil && len(v.activeSession.Comments) > 0 {
 					// Generate feedback now so we can pass it to the modal
-					feedback := GenerateReviewFeedback(v.activeSession, v.selectedDoc.RelPath)
+					_, docRel := v.sessionDocument()
+					feedback := v.generateFeedback(docRel)
 					modal := NewFinalizationModal(feedback, v.width, v.height)
 					v.finalizationModal = &modal
 					v.feedbackGenerated = feedback
@@ -694,18 +965,24 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 				// Open comment modal if in selection mode
 				if v.selectionMode {
 					contextText := v.getSelectedText()
-					// Calculate selection range from anchor to cursor
-					start := min(v.selectionStart, v.cursorLine)
-					end := max(v.selectionStart, v.cursorLine)
+					start, _, end, _ := v.selectionBounds()
 					modal := NewCommentModal(start, end, contextText, v.width, v.height)
+					modal.SetReferenceDocuments(v.referenceDocuments())
+					modal.SetHistory(v.commentHistory)
 					v.commentModal = &modal
 					return v, nil
 				}
+			case "A", "shift+a":
+				// Open annotation modal if in selection mode
+				if v.selectionMode {
+					v.openAnnotationModal()
+					return v, nil
+				}
 			case "e":
-				// Edit comment on current cursor line
-				if !v.selectionMode && v.activeSession != nil {
+				// Edit comment on current cursor line, or the annotation there
+				if !v.selectionMode {
 					// Find comment at cursor line
-					for _, comment := range v.activeSession.Comments {
+					for _, comment := range v.docComments() {
 						if v.cursorLine >= comment.StartLine && v.cursorLine <= comment.EndLine {
 							// Open comment modal pre-filled with existing comment
 							modal := NewCommentModal(
@@ -716,18 +993,40 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 								v.height,
 							)
 							modal.SetExistingComment(comment.CommentText)
+							modal.SetSeverity(comment.Severity)
+							modal.SetReferenceDocuments(v.referenceDocuments())
+							modal.SetHistory(v.commentHistory)
 							v.commentModal = &modal
 							v.editingCommentID = comment.ID // Track which comment is being edited
 							return v, nil
 						}
 					}
+					if a, ok := v.annotationAtCursor(); ok {
+						v.editAnnotation(a)
+						return v, nil
+					}
+				}
+			case "p":
+				// Import feedback pasted from outside hive as comments
+				if !v.selectionMode {
+					modal := NewImportModal(v.width, v.height)
+					v.commentModal = &modal
+					v.importingFeedback = true
+					return v, nil
+				}
+			case keyEnter:
+				// Jump to the document referenced by the comment on the cursor line
+				if !v.selectionMode {
+					if ref, ok := v.referenceAtCursor(); ok {
+						return v, v.jumpToReference(ref)
+					}
 				}
 			case "d":
-				// Delete comment(s) on current cursor line
-				if !v.selectionMode && v.activeSession != nil {
+				// Delete comment(s) on current cursor line, or the annotation there
+				if !v.selectionMode {
 					// Check if there are comments at the cursor line
 					hasComment := false
-					for _, comment := range v.activeSession.Comments {
+					for _, comment := range v.docComments() {
 						if v.cursorLine >= comment.StartLine && v.cursorLine <= comment.EndLine {
 							hasComment = true
 							break
@@ -741,6 +1040,12 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 						v.confirmModal = &modal
 						return v, nil
 					}
+					if a, ok := v.annotationAtCursor(); ok {
+						v.pendingDeleteAnnotation = a.ID
+						modal := components.NewConfirmModal("Delete annotation at this line?")
+						v.confirmModal = &modal
+						return v, nil
+					}
 				}
 			case "D", "shift+d":
 				// Discard entire review
@@ -757,8 +1062,10 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 				// Enter or exit visual selection mode
 				if !v.selectionMode {
 					v.selectionMode = true
-					// Set selection anchor to cursor position
+					// Set selection anchor to cursor position; selections start linewise
 					v.selectionStart = v.cursorLine
+					v.selectionStartCol = 0
+					v.cursorCol = 0
 					v.renderSelection()
 					return v, nil
 				} else {
@@ -774,13 +1081,29 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 					v.renderSelection()
 					return v, nil
 				}
+			case "h", "left", "l", "right", "w", "b":
+				// Narrow a visual selection to a span within the line
+				if v.selectionMode {
+					switch msg.String() {
+					case "h", "left":
+						v.moveCursorCol(-1)
+					case "l", "right":
+						v.moveCursorCol(1)
+					case "w":
+						v.moveCursorWord(true)
+					case "b":
+						v.moveCursorWord(false)
+					}
+					v.renderSelection()
+					return v, nil
+				}
 			}
 		}
 
 		if v.fullScreen && !v.selectionMode && msg.String() == "/" {
 			v.searchMode = true
 			v.searchInput.Focus()
-			v.searchInput.SetValue("")
+			v.searchInput.Reset()
 			v.searchQuery = ""
 			// Clear previous search results
 			v.searchMatches = nil
@@ -788,6 +1111,22 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 			return v, nil
 		}
 
+		// Handle ]c / [c comment motions. The key bound to DocsNextComment or
+		// DocsPrevComment is held until the next key arrives; any key other
+		// than "c" cancels the pending motion.
+		if v.fullScreen && !v.selectionMode && !v.searchMode {
+			if pending := v.pendingMotion; pending != "" {
+				v.pendingMotion = ""
+				if msg.String() == "c" {
+					v.JumpToComment(pending == act.TypeDocsNextComment)
+					return v, nil
+				}
+			} else if motion := v.commentMotion(msg.String()); motion != "" {
+				v.pendingMotion = motion
+				return v, nil
+			}
+		}
+
 		// Handle n/N navigation (search matches or comments)
 		if v.fullScreen && !v.selectionMode {
 			switch msg.String() {
@@ -799,7 +1138,7 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 					v.jumpToMatch(v.searchMatches[v.searchMatchIndex])
 					v.renderSelection()
 					return v, nil
-				} else if v.activeSession != nil && len(v.activeSession.Comments) > 0 {
+				} else if len(v.docComments()) > 0 {
 					// Jump to next comment
 					v.jumpToNextComment()
 					v.renderSelection()
@@ -813,7 +1152,7 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 					v.jumpToMatch(v.searchMatches[v.searchMatchIndex])
 					v.renderSelection()
 					return v, nil
-				} else if v.activeSession != nil && len(v.activeSession.Comments) > 0 {
+				} else if len(v.docComments()) > 0 {
 					// Jump to previous comment
 					v.jumpToPrevComment()
 					v.renderSelection()
@@ -829,6 +1168,9 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 				switch msg.String() {
 				case "enter", "esc":
 					v.treeSearchMode = false
+					if msg.String() == "enter" {
+						v.treeSearchInput.Commit()
+					}
 					if msg.String() == "esc" {
 						v.treeSearchQuery = ""
 						v.treeSearchInput.SetValue("")
@@ -856,7 +1198,7 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 			case "/":
 				v.treeSearchMode = true
 				v.treeSearchQuery = ""
-				v.treeSearchInput.SetValue("")
+				v.treeSearchInput.Reset()
 				return v, v.treeSearchInput.Focus()
 			case keyEnter:
 				if v.treeCursor >= 0 && v.treeCursor < len(v.flatNodes) {
@@ -866,12 +1208,12 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 							// Doc already previewed — shift focus to reader mode, hide tree.
 							v.fullScreen = true
 							v.showTree = false
-							v.viewport = viewport.New(viewport.WithWidth(v.width), viewport.WithHeight(max(v.height-2, 1)))
+							v.viewport = viewport.New(viewport.WithWidth(v.docWidth()), viewport.WithHeight(max(v.height-2, 1)))
 							v.viewport.SetContent(styles.TextMutedStyle.Render(filepath.Base(node.Doc.RelPath)))
 							v.cursorLine = 1
 							docPath := node.Doc.Path
 							docRef := node.Doc
-							width := v.width
+							width := v.docWidth()
 							return v, func() tea.Msg {
 								rendered, err := docRef.Render(width)
 								if err != nil {
@@ -932,11 +1274,13 @@ func (v View) Update(msg tea.Msg) (View, tea.Cmd) {
 			case "j", "down":
 				// Move cursor down (selection extends automatically if in visual mode)
 				v.moveCursorDown(1)
+				v.clampCursorCol()
 				v.renderSelection()
 				return v, nil
 			case "k", "up":
 				// Move cursor up (selection extends automatically if in visual mode)
 				v.moveCursorUp(1)
+				v.clampCursorCol()
 				v.renderSelection()
 				return v, nil
 			case "ctrl+d":
@@ -1046,6 +1390,15 @@ func (v View) View() string {
 
 	var body string
 	switch {
+	case !v.showTree && v.CommentsPanelOpen():
+		// Focused reader with the comments panel on the right.
+		dividerStyle := styles.TextMutedStyle
+		if v.comments.focused {
+			dividerStyle = styles.TextPrimaryStyle
+		}
+		divider := shared.BuildDividerStyled(contentHeight, dividerStyle)
+		body = lipgloss.JoinHorizontal(lipgloss.Top, buildDetailContent(v.docWidth()), divider,
+			v.renderCommentsPanel(commentsPanelWidth(v.width), contentHeight))
 	case !v.showTree:
 		// Focused reader: full-width detail, no tree pane.
 		body = buildDetailContent(v.width)
@@ -1086,8 +1439,16 @@ func (v View) View() string {
 		case v.selectionMode:
 			helpLeft = badge + "  " + components.KeyHints(
 				components.HelpEntry{Key: "c", Desc: "comment"},
+				components.HelpEntry{Key: "h/l", Desc: "span"},
 				components.HelpEntry{Key: "v/esc", Desc: "exit visual"},
 			)
+		case v.comments.focused && v.CommentsPanelOpen():
+			helpLeft = badge + "  " + components.KeyHints(
+				components.HelpEntry{Key: "j/k", Desc: "select"},
+				components.HelpEntry{Key: "enter", Desc: "jump"},
+				components.HelpEntry{Key: "e/d", Desc: "edit/delete"},
+				components.HelpEntry{Key: "tab", Desc: "document"},
+			)
 		default:
 			helpLeft = badge + "  " + components.KeyHints(
 				components.HelpEntry{Key: "j/k", Desc: "scroll"},
@@ -1100,10 +1461,39 @@ func (v View) View() string {
 		if v.selectedDoc != nil {
 			if v.searchQuery != "" && len(v.searchMatches) > 0 {
 				helpRight = styles.ReviewPosStyle.Render(fmt.Sprintf("%d/%d matches", v.searchMatchIndex+1, len(v.searchMatches)))
+			} else if idx, total := v.commentPosition(); idx > 0 {
+				helpRight = styles.ReviewPosStyle.Render(fmt.Sprintf("Comment %d/%d", idx, total))
 			} else {
 				totalLines := len(v.selectedDoc.RenderedLines)
 				helpRight = styles.ReviewPosStyle.Render(fmt.Sprintf("Line %d/%d", v.cursorLine, totalLines))
 			}
+			var indicators []string
+			if rev := v.selectedDoc.Revision; rev != "" {
+				indicators = append(indicators, "pinned @"+rev)
+			}
+			if t := v.instant.target; t != nil {
+				indicators = append(indicators, "instant → "+t.Name)
+			}
+			if others := v.collabIndicator(); others != "" {
+				indicators = append(indicators, others)
+			}
+			if len(indicators) > 0 {
+				// Drop the position, then the key hints, rather than wrap the
+				// footer on narrow terminals
+				indicator := styles.TextWarningStyle.Render(strings.Join(indicators, " · "))
+				switch {
+				case lipgloss.Width(helpLeft)+lipgloss.Width(indicator)+lipgloss.Width(helpRight)+5 <= v.width:
+					indicator += "  " + helpRight
+				case lipgloss.Width(helpLeft)+lipgloss.Width(indicator)+3 > v.width:
+					helpLeft = badge
+				}
+				helpRight = indicator
+			}
+			// Severity counts are the first thing dropped when space runs out
+			if counts := renderSeverityCounts(v.docComments()); counts != "" &&
+				lipgloss.Width(helpLeft)+lipgloss.Width(counts)+lipgloss.Width(helpRight)+5 <= v.width {
+				helpRight = counts + "  " + helpRight
+			}
 		}
 	default:
 		helpLeft = components.KeyHints(
@@ -1113,6 +1503,9 @@ func (v View) View() string {
 			components.HelpEntry{Key: "/", Desc: "search"},
 			components.HintHelp,
 		)
+		if v.currentReview != nil && len(v.currentReview.Documents) > 1 {
+			helpRight = styles.ReviewPosStyle.Render(fmt.Sprintf("Review: %d docs", len(v.currentReview.Documents)))
+		}
 	}
 
 	baseView := body + "\n" + bar.Rule() + "\n" + bar.Render(helpLeft, helpRight)
@@ -1142,9 +1535,17 @@ func (v View) View() string {
 		return compositor.Render()
 	}
 
-	// Overlay comment modal if active
-	if v.commentModal != nil {
-		modalContent := v.commentModal.View()
+	// Overlay comment modal, commit picker, or comment edits if active
+	if v.commentModal != nil || v.revisionPicker != nil || v.commentEdits != nil {
+		var modalContent string
+		switch {
+		case v.commentModal != nil:
+			modalContent = v.commentModal.View()
+		case v.revisionPicker != nil:
+			modalContent = v.revisionPicker.View()
+		default:
+			modalContent = v.commentEdits.View()
+		}
 		modal := styles.ReviewOverlayModalStyle.Render(modalContent)
 
 		// Center the modal
@@ -1182,16 +1583,6 @@ func (v View) SelectedDocument() *Document {
 	return nil
 }
 
-// calculateContentHash computes SHA256 hash of file content.
-func calculateContentHash(path string) (string, error) {
-	content, err := os.ReadFile(path)
-	if err != nil {
-		return "", err
-	}
-	hash := sha256.Sum256(content)
-	return hex.EncodeToString(hash[:]), nil
-}
-
 // loadDocument loads and renders a document for preview.
 // Also loads any existing review session from the database.
 func (v *View) loadDocument(doc *Document) {
@@ -1199,6 +1590,7 @@ func (v *View) loadDocument(doc *Document) {
 	if doc == nil {
 		v.viewport.SetContent("")
 		v.activeSession = nil
+		v.annotations = nil
 		v.fullScreen = false
 		return
 	}
@@ -1213,7 +1605,7 @@ func (v *View) loadDocument(doc *Document) {
 	v.showTree = false
 
 	// Adjust viewport size for full-screen
-	v.viewport = viewport.New(viewport.WithWidth(v.width), viewport.WithHeight(max(v.height-2, 1)))
+	v.viewport = viewport.New(viewport.WithWidth(v.docWidth()), viewport.WithHeight(max(v.height-2, 1)))
 
 	// Reset cursor to top when loading new document
 	v.cursorLine = 1
@@ -1222,14 +1614,38 @@ func (v *View) loadDocument(doc *Document) {
 	v.lineMapping = nil
 
 	// Load existing session from database if store is available
+	v.activeSession = nil
 	if v.store != nil {
 		ctx := context.Background()
 
 		// Calculate current content hash
-		currentHash, err := calculateContentHash(doc.Path)
+		currentHash, err := doc.contentHash()
 		if err == nil {
 			// Try to get session with matching hash
 			dbSession, err := v.store.GetSessionByHash(ctx, doc.Path, currentHash)
+			if err != nil {
+				// The document changed since it was last reviewed, or is attached
+				// to another document's review. Carry the active session's
+				// comments over to the new content before cleaning up stale
+				// sessions.
+				dbSession, err = v.store.GetActiveSessionForDocument(ctx, doc.Path)
+				cleanup := err != nil
+				if err == nil {
+					if rerr := v.reanchorDocument(ctx, dbSession, doc, currentHash); rerr != nil {
+						// Keep the stale session rather than lose its comments
+						log.Error().
+							Err(rerr).
+							Str("session_id", dbSession.ID).
+							Str("document", doc.RelPath).
+							Msg("review: failed to re-anchor comments")
+					} else {
+						cleanup = true
+					}
+				}
+				if cleanup {
+					_ = v.store.CleanupStaleSessions(ctx, doc.Path, currentHash)
+				}
+			}
 			if err == nil {
 				// Skip finalized sessions - they should not be edited
 				if dbSession.IsFinalized() {
@@ -1237,48 +1653,34 @@ func (v *View) loadDocument(doc *Document) {
 						Str("session_id", dbSession.ID).
 						Str("document", doc.RelPath).
 						Msg("review: skipping finalized session")
+					if v.currentReview != nil && v.currentReview.ID == dbSession.ID {
+						v.currentReview = nil
+					}
 				} else {
 					log.Debug().
 						Str("session_id", dbSession.ID).
 						Str("document", doc.RelPath).
 						Msg("review: loaded existing session")
 
-					// Load comments from database
-					dbComments, err := v.store.ListComments(ctx, dbSession.ID)
-					if err == nil {
-						// Convert to TUI types
-						comments := make([]Comment, 0, len(dbComments))
-						for _, dbComment := range dbComments {
-							comments = append(comments, Comment{
-								ID:          dbComment.ID,
-								SessionID:   dbComment.SessionID,
-								StartLine:   dbComment.StartLine,
-								EndLine:     dbComment.EndLine,
-								ContextText: dbComment.ContextText,
-								CommentText: dbComment.CommentText,
-								CreatedAt:   dbComment.CreatedAt,
-							})
-						}
-
-						v.activeSession = &Session{
-							ID:         dbSession.ID,
-							DocPath:    dbSession.DocumentPath,
-							Comments:   comments,
-							CreatedAt:  dbSession.CreatedAt,
-							ModifiedAt: time.Now(),
-						}
-					}
+					v.activeSession = v.loadSession(ctx, dbSession)
 				}
-			} else {
-				// No matching session found, cleanup stale sessions
-				_ = v.store.CleanupStaleSessions(ctx, doc.Path, currentHash)
 			}
 		}
 		// If hash calculation or session load fails, activeSession remains nil
 	}
 
-	// Render document using full width
-	rendered, err := doc.Render(v.width)
+	// Without a store, the in-memory review is the only record of attached documents
+	if v.store == nil && v.currentReview != nil && v.currentReview.HasDocument(doc.Path) {
+		v.activeSession = v.currentReview
+	}
+	if v.activeSession != nil {
+		v.currentReview = v.activeSession
+	}
+
+	v.loadAnnotations(doc)
+
+	// Render document using the reader width
+	rendered, err := doc.Render(v.docWidth())
 	if err != nil {
 		v.viewport.SetContent("Error rendering document: " + err.Error())
 		return
@@ -1287,12 +1689,219 @@ func (v *View) loadDocument(doc *Document) {
 	v.viewport.SetContent(rendered)
 	v.viewport.GotoTop()
 
-	// Render selection to show comments immediately if session was loaded
-	if v.activeSession != nil && len(v.activeSession.Comments) > 0 {
+	// Render selection to show comments and annotations immediately
+	if v.hasInlineNotes() {
 		v.renderSelection()
 	}
 }
 
+// reanchorDocument moves the session's comments on doc to their positions in
+// the document's current content and records its new content hash. Nothing is
+// written when the hash recorded for the document is already current.
+func (v *View) reanchorDocument(ctx context.Context, dbSession corereview.Session, doc *Document, contentHash string) error {
+	dbDocs, err := v.store.ListDocuments(ctx, dbSession.ID)
+	if err != nil {
+		return err
+	}
+	for _, d := range dbDocs {
+		if d.DocumentPath == doc.Path && d.ContentHash == contentHash {
+			return nil
+		}
+	}
+
+	dbComments, err := v.store.ListComments(ctx, dbSession.ID)
+	if err != nil {
+		return err
+	}
+	var docComments []corereview.Comment
+	for _, c := range dbComments {
+		if c.DocumentPath == doc.Path || (c.DocumentPath == "" && dbSession.DocumentPath == doc.Path) {
+			docComments = append(docComments, c)
+		}
+	}
+
+	// Comments are anchored to rendered lines, so compare against the
+	// rendering of the content on disk
+	if err := doc.LoadContent(); err != nil {
+		return err
+	}
+	if _, err := doc.Render(v.docWidth()); err != nil {
+		return err
+	}
+
+	comments := make([]Comment, 0, len(docComments))
+	for _, c := range docComments {
+		comments = append(comments, commentFromStore(c))
+	}
+	outdated := 0
+	for i, c := range ReanchorComments(comments, doc.RenderedLines) {
+		docComments[i].StartLine, docComments[i].EndLine = c.StartLine, c.EndLine
+		docComments[i].StartCol, docComments[i].EndCol = c.StartCol, c.EndCol
+		docComments[i].Outdated = c.Outdated
+		if c.Outdated {
+			outdated++
+		}
+	}
+
+	log.Debug().
+		Str("session_id", dbSession.ID).
+		Str("document", doc.RelPath).
+		Int("comments", len(docComments)).
+		Int("outdated", outdated).
+		Msg("review: re-anchored comments to changed document")
+
+	return v.store.ReanchorDocument(ctx, dbSession.ID, doc.Path, contentHash, docComments)
+}
+
+// commentFromStore converts a stored comment into view state.
+func commentFromStore(c corereview.Comment) Comment {
+	return Comment{
+		ID:          c.ID,
+		SessionID:   c.SessionID,
+		DocPath:     c.DocumentPath,
+		StartLine:   c.StartLine,
+		EndLine:     c.EndLine,
+		StartCol:    c.StartCol,
+		EndCol:      c.EndCol,
+		Outdated:    c.Outdated,
+		Severity:    c.Severity,
+		ContextText: c.ContextText,
+		CommentText: c.CommentText,
+		CreatedAt:   c.CreatedAt,
+	}
+}
+
+// loadSession converts a stored review session, its attached documents and
+// comments into view state. Returns nil if the comments cannot be loaded.
+func (v *View) loadSession(ctx context.Context, dbSession corereview.Session) *Session {
+	dbComments, err := v.store.ListComments(ctx, dbSession.ID)
+	if err != nil {
+		return nil
+	}
+	dbDocs, _ := v.store.ListDocuments(ctx, dbSession.ID)
+	return v.sessionFromStore(dbSession, dbComments, dbDocs)
+}
+
+// sessionFromStore converts a stored review session with its comments and
+// related documents to the view's Session.
+func (v *View) sessionFromStore(dbSession corereview.Session, dbComments []corereview.Comment, dbDocs []corereview.Document) *Session {
+	comments := make([]Comment, 0, len(dbComments))
+	for _, dbComment := range dbComments {
+		comments = append(comments, commentFromStore(dbComment))
+	}
+
+	documents := []SessionDocument{{Path: dbSession.DocumentPath, RelPath: v.relPathFor(dbSession.DocumentPath)}}
+	for _, d := range dbDocs {
+		if d.DocumentPath != dbSession.DocumentPath {
+			documents = append(documents, SessionDocument{Path: d.DocumentPath, RelPath: v.relPathFor(d.DocumentPath)})
+		}
+	}
+
+	return &Session{
+		ID:         dbSession.ID,
+		DocPath:    dbSession.DocumentPath,
+		Documents:  documents,
+		Comments:   comments,
+		CreatedAt:  dbSession.CreatedAt,
+		ModifiedAt: time.Now(),
+		ReviewTime: dbSession.ReviewTime + v.unflushedReviewTime(dbSession.ID),
+	}
+}
+
+// relPathFor returns the display path for a document: its RelPath when it is
+// in the tree, otherwise its path relative to the context directory.
+func (v *View) relPathFor(path string) string {
+	for _, ti := range TreeItemsDocuments(v.list.Items()) {
+		if ti.Document.Path == path {
+			return ti.Document.RelPath
+		}
+	}
+	if v.contextDir != "" {
+		if rel, err := filepath.Rel(v.contextDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return path
+}
+
+// renderSeverityCounts renders how many comments there are of each
+// severity, most severe first, each in its severity's color.
+func renderSeverityCounts(comments []Comment) string {
+	counts := countSeverities(comments)
+	parts := make([]string, 0, len(counts))
+	for _, c := range counts {
+		parts = append(parts, lipgloss.NewStyle().Foreground(severityColor(c.severity)).Render(c.String()))
+	}
+	return strings.Join(parts, styles.TextMutedStyle.Render(" · "))
+}
+
+// docComments returns the active session's comments on the selected document.
+func (v *View) docComments() []Comment {
+	if v.activeSession == nil || v.selectedDoc == nil {
+		return nil
+	}
+	return v.activeSession.CommentsFor(v.selectedDoc.Path)
+}
+
+// sessionDocument returns the absolute and display path of the active
+// session's own document, falling back to the selected document.
+func (v *View) sessionDocument() (string, string) {
+	if v.activeSession == nil {
+		if v.selectedDoc == nil {
+			return "", ""
+		}
+		return v.selectedDoc.Path, v.selectedDoc.RelPath
+	}
+	if v.selectedDoc != nil && v.selectedDoc.Path == v.activeSession.DocPath {
+		return v.selectedDoc.Path, v.selectedDoc.RelPath
+	}
+	for _, d := range v.activeSession.Documents {
+		if d.Path == v.activeSession.DocPath {
+			return d.Path, d.RelPath
+		}
+	}
+	return v.activeSession.DocPath, v.relPathFor(v.activeSession.DocPath)
+}
+
+// AddSelectedToReview attaches the document under the tree cursor to the
+// current review so its comments are finalized together. Returns the display
+// path of the attached document.
+func (v *View) AddSelectedToReview() (string, error) {
+	doc := v.SelectedDoc()
+	if doc == nil {
+		return "", errors.New("no document selected")
+	}
+	if v.currentReview == nil {
+		return "", errors.New("no review in progress: comment on a document first")
+	}
+	if v.currentReview.HasDocument(doc.Path) {
+		return doc.RelPath, nil
+	}
+
+	if v.store != nil {
+		contentHash, err := calculateContentHash(doc.Path)
+		if err != nil {
+			return "", fmt.Errorf("hash document: %w", err)
+		}
+		if err := v.store.AddDocument(context.Background(), v.currentReview.ID, doc.Path, contentHash); err != nil {
+			return "", err
+		}
+	}
+
+	if len(v.currentReview.Documents) == 0 {
+		v.currentReview.Documents = []SessionDocument{{Path: v.currentReview.DocPath, RelPath: v.relPathFor(v.currentReview.DocPath)}}
+	}
+	v.currentReview.Documents = append(v.currentReview.Documents, SessionDocument{Path: doc.Path, RelPath: doc.RelPath})
+
+	log.Debug().
+		Str("session_id", v.currentReview.ID).
+		Str("document", doc.RelPath).
+		Int("documents", len(v.currentReview.Documents)).
+		Msg("review: attached document to review")
+
+	return doc.RelPath, nil
+}
+
 // moveCursorDown moves cursor down by n lines, scrolling if needed.
 func (v *View) moveCursorDown(n int) {
 	if v.selectedDoc == nil {
@@ -1346,19 +1955,20 @@ func (v *View) renderSelection() {
 	}
 
 	// Render document
-	rendered, err := v.selectedDoc.Render(v.width)
+	width := v.docWidth()
+	rendered, err := v.selectedDoc.Render(width)
 	if err != nil {
 		v.viewport.SetContent("Error rendering document: " + err.Error())
 		return
 	}
 
-	// Insert comments inline if session exists and build line mapping
-	if v.activeSession != nil && len(v.activeSession.Comments) > 0 {
+	// Insert comments and annotations inline and build line mapping
+	if v.hasInlineNotes() {
 		var mappedContent string
-		mappedContent, v.lineMapping = v.insertCommentsInline(rendered, v.width)
+		mappedContent, v.lineMapping = v.insertCommentsInline(rendered, width)
 		rendered = mappedContent
 	} else {
-		// Clear line mapping when no comments
+		// Clear line mapping when nothing is inserted
 		v.lineMapping = nil
 	}
 
@@ -1366,6 +1976,7 @@ func (v *View) renderSelection() {
 	rendered = v.highlightSelection(rendered, v.lineMapping)
 
 	v.viewport.SetContent(rendered)
+	v.syncCommentsPanel()
 }
 
 // findSearchMatches finds all lines matching the search query and stores their line numbers.
@@ -1397,27 +2008,45 @@ func (v *View) jumpToMatch(lineNum int) {
 	v.centerCursorInViewport()
 }
 
+// commentMotion returns the comment motion key starts, or "" when it starts
+// none. Without a resolver (review-only mode) the default ] and [ keys apply.
+func (v *View) commentMotion(key string) act.Type {
+	if v.handler == nil {
+		switch key {
+		case "]":
+			return act.TypeDocsNextComment
+		case "[":
+			return act.TypeDocsPrevComment
+		}
+		return ""
+	}
+	for _, t := range []act.Type{act.TypeDocsNextComment, act.TypeDocsPrevComment} {
+		if v.handler.IsAction(key, t) {
+			return t
+		}
+	}
+	return ""
+}
+
+// JumpToComment moves the cursor to the next comment, or the previous one
+// when next is false, wrapping around the document.
+func (v *View) JumpToComment(next bool) {
+	if next {
+		v.jumpToNextComment()
+	} else {
+		v.jumpToPrevComment()
+	}
+	v.renderSelection()
+}
+
 // jumpToNextComment moves the cursor to the next comment after the current cursor position.
 // If at or past the last comment, wraps to the first comment.
 func (v *View) jumpToNextComment() {
-	if v.activeSession == nil || len(v.activeSession.Comments) == 0 {
+	sortedComments := v.sortedDocComments()
+	if len(sortedComments) == 0 {
 		return
 	}
 
-	// Sort comments by start line to ensure consistent navigation order
-	comments := v.activeSession.Comments
-	sortedComments := make([]Comment, len(comments))
-	copy(sortedComments, comments)
-
-	// Simple bubble sort by start line
-	for i := range sortedComments {
-		for j := i + 1; j < len(sortedComments); j++ {
-			if sortedComments[i].StartLine > sortedComments[j].StartLine {
-				sortedComments[i], sortedComments[j] = sortedComments[j], sortedComments[i]
-			}
-		}
-	}
-
 	// Find first comment after cursor position
 	for _, comment := range sortedComments {
 		if comment.StartLine > v.cursorLine {
@@ -1435,24 +2064,11 @@ func (v *View) jumpToNextComment() {
 // jumpToPrevComment moves the cursor to the previous comment before the current cursor position.
 // If at or before the first comment, wraps to the last comment.
 func (v *View) jumpToPrevComment() {
-	if v.activeSession == nil || len(v.activeSession.Comments) == 0 {
+	sortedComments := v.sortedDocComments()
+	if len(sortedComments) == 0 {
 		return
 	}
 
-	// Sort comments by start line to ensure consistent navigation order
-	comments := v.activeSession.Comments
-	sortedComments := make([]Comment, len(comments))
-	copy(sortedComments, comments)
-
-	// Simple bubble sort by start line
-	for i := range sortedComments {
-		for j := i + 1; j < len(sortedComments); j++ {
-			if sortedComments[i].StartLine > sortedComments[j].StartLine {
-				sortedComments[i], sortedComments[j] = sortedComments[j], sortedComments[i]
-			}
-		}
-	}
-
 	// Find last comment before cursor position (iterate in reverse)
 	for i := len(sortedComments) - 1; i >= 0; i-- {
 		if sortedComments[i].StartLine < v.cursorLine {
@@ -1467,6 +2083,80 @@ func (v *View) jumpToPrevComment() {
 	v.centerCursorInViewport()
 }
 
+// referenceAtCursor returns the first document reference in the comments on
+// the cursor line.
+func (v *View) referenceAtCursor() (DocRef, bool) {
+	for _, comment := range v.sortedDocComments() {
+		if v.cursorLine < comment.StartLine || v.cursorLine > comment.EndLine {
+			continue
+		}
+		if refs := parseDocRefs(comment.CommentText); len(refs) > 0 {
+			return refs[0], true
+		}
+	}
+	return DocRef{}, false
+}
+
+// jumpToReference opens the referenced document with the cursor on the start
+// of the referenced range. A document that is no longer discovered is
+// reported through an OpenDocumentMsg error.
+func (v *View) jumpToReference(ref DocRef) tea.Cmd {
+	var target *Document
+	for _, doc := range v.referenceDocuments() {
+		if doc.RelPath == ref.Path || doc.Path == ref.Path {
+			target = &doc
+			break
+		}
+	}
+	if target == nil {
+		return func() tea.Msg {
+			return OpenDocumentMsg{Path: ref.Path, Err: fmt.Errorf("referenced document not found: %s", ref.Path)}
+		}
+	}
+
+	if v.selectedDoc == nil || v.selectedDoc.Path != target.Path {
+		v.loadDocument(target)
+	}
+	v.selectionMode = false
+	v.cursorLine = max(min(ref.StartLine, len(v.selectedDoc.RenderedLines)), 1)
+	v.centerCursorInViewport()
+	v.renderSelection()
+	return nil
+}
+
+// referenceDocuments returns the discovered documents a comment can
+// reference.
+func (v *View) referenceDocuments() []Document {
+	var docs []Document
+	for _, ti := range TreeItemsDocuments(v.list.Items()) {
+		docs = append(docs, ti.Document)
+	}
+	return docs
+}
+
+// sortedDocComments returns the current document's comments ordered by start
+// line so navigation and position indicators agree on comment order.
+func (v *View) sortedDocComments() []Comment {
+	comments := slices.Clone(v.docComments())
+	slices.SortStableFunc(comments, func(a, b Comment) int {
+		return a.StartLine - b.StartLine
+	})
+	return comments
+}
+
+// commentPosition returns the 1-indexed position of the comment under the
+// cursor and the number of comments on the document. The position is 0 when
+// the cursor is not within a comment's line range.
+func (v *View) commentPosition() (int, int) {
+	comments := v.sortedDocComments()
+	for i, c := range comments {
+		if v.cursorLine >= c.StartLine && v.cursorLine <= c.EndLine {
+			return i + 1, len(comments)
+		}
+	}
+	return 0, len(comments)
+}
+
 // highlightSelection applies background color to cursor and selected lines.
 // Also highlights line numbers of commented lines.
 // lineMapping maps document line numbers to display line numbers (nil if no comments inserted).
@@ -1514,6 +2204,12 @@ func (v *View) highlightSelection(content string, lineMapping map[int]int) strin
 	// Map cursor line to display coordinates
 	displayCursorLine := v.mapDocToDisplay(v.cursorLine, lineMapping)
 
+	// Charwise selections and column-anchored comments highlight a span
+	// (document coordinates) instead of the whole line.
+	charwise := v.charwiseSelection()
+	selStartLine, selStartCol, selEndLine, selEndCol := v.selectionBounds()
+	commentSpans := v.commentColumnSpans()
+
 	// Apply highlighting (priority: current search > cursor > visual selection > other search > comments > normal)
 	for i := range lines {
 		displayLineNum := i + 1
@@ -1550,6 +2246,14 @@ func (v *View) highlightSelection(content string, lineMapping map[int]int) strin
 			// Current search match (highest priority)
 			cleanLine := ansiStripPattern.ReplaceAllString(line, "")
 			lines[i] = currentSearchMatchStyle.Render(cleanLine)
+		case charwise && docLineNum >= selStartLine && docLineNum <= selEndLine:
+			// Charwise visual selection: highlight only the selected span
+			from, to, ok := columnSpan(docLineNum, len(v.selectedDoc.plainLine(docLineNum)), selStartLine, selStartCol, selEndLine, selEndCol)
+			if ok {
+				lines[i] = highlightColumns(line, from, to, selectionStyle)
+			} else {
+				lines[i] = ansiStripPattern.ReplaceAllString(line, "")
+			}
 		case displayLineNum == displayCursorLine:
 			// Cursor highlight
 			cleanLine := ansiStripPattern.ReplaceAllString(line, "")
@@ -1562,6 +2266,10 @@ func (v *View) highlightSelection(content string, lineMapping map[int]int) strin
 			// Other search matches (subtle)
 			cleanLine := ansiStripPattern.ReplaceAllString(line, "")
 			lines[i] = searchMatchStyle.Render(cleanLine)
+		case docLineNum > 0 && commentSpans[docLineNum] != [2]int{}:
+			// Column-anchored comment: underline the commented phrase
+			span := commentSpans[docLineNum]
+			lines[i] = highlightColumns(line, span[0], span[1], styles.ReviewCommentedSpanStyle)
 		default:
 			lines[i] = line
 		}
@@ -1618,14 +2326,35 @@ func (v *View) highlightLineNumber(line string, style lipgloss.Style) string {
 	return styledGutter + restOfLine
 }
 
+// commentColumnSpans returns the commented column range per document line for
+// column-anchored comments. Overlapping spans on a line are merged.
+func (v *View) commentColumnSpans() map[int][2]int {
+	spans := make(map[int][2]int)
+	if v.selectedDoc == nil {
+		return spans
+	}
+	for _, c := range v.docComments() {
+		if c.StartCol == 0 {
+			continue
+		}
+		for line := c.StartLine; line <= c.EndLine; line++ {
+			from, to, ok := columnSpan(line, len(v.selectedDoc.plainLine(line)), c.StartLine, c.StartCol, c.EndLine, c.EndCol)
+			if !ok {
+				continue
+			}
+			if prev, seen := spans[line]; seen {
+				from, to = min(from, prev[0]), max(to, prev[1])
+			}
+			spans[line] = [2]int{from, to}
+		}
+	}
+	return spans
+}
+
 // getCommentedLines returns a map of line numbers that have comments.
 func (v *View) getCommentedLines() map[int]bool {
 	commented := make(map[int]bool)
-	if v.activeSession == nil {
-		return commented
-	}
-
-	for _, comment := range v.activeSession.Comments {
+	for _, comment := range v.docComments() {
 		for line := comment.StartLine; line <= comment.EndLine; line++ {
 			commented[line] = true
 		}
@@ -1679,13 +2408,19 @@ func (v *View) mapDisplayToDoc(displayLine int, lineMapping map[int]int) int {
 
 // getSelectedText extracts the text from the selected line range.
 func (v *View) getSelectedText() string {
+	return v.rangeText(v.selectionBounds())
+}
+
+// rangeText returns the document text a comment range covers: whole lines, or
+// the exact span when columns are set.
+func (v *View) rangeText(start, startCol, end, endCol int) string {
 	if v.selectedDoc == nil || len(v.selectedDoc.RenderedLines) == 0 {
 		return ""
 	}
 
-	// Calculate selection range from anchor to cursor
-	start := min(v.selectionStart, v.cursorLine)
-	end := max(v.selectionStart, v.cursorLine)
+	if startCol > 0 {
+		return v.selectedSpanText(start, startCol, end, endCol)
+	}
 
 	// Extract selected lines (adjust for 1-indexed)
 	var selectedLines []string
@@ -1700,13 +2435,25 @@ func (v *View) getSelectedText() string {
 // Database operations are best-effort: comments are kept in-memory even if persistence fails.
 // Errors are logged but do not prevent the comment from being added to the session.
 // If no session exists, one is created (either in the database or in-memory only).
-func (v *View) addComment(commentText string) {
+func (v *View) addComment(commentText string, severity corereview.Severity) tea.Cmd {
+	start, startCol, end, endCol := v.selectionBounds()
+	return v.addCommentAt(start, startCol, end, endCol, commentText, severity)
+}
+
+// addCommentAt adds a comment on the given range of the open document. See
+// addComment.
+func (v *View) addCommentAt(start, startCol, end, endCol int, commentText string, severity corereview.Severity) tea.Cmd {
 	if v.selectedDoc == nil {
-		return
+		return nil
 	}
 
 	ctx := context.Background()
 
+	// Continue the current review if this document is already attached to it
+	if v.activeSession == nil && v.currentReview != nil && v.currentReview.HasDocument(v.selectedDoc.Path) {
+		v.activeSession = v.currentReview
+	}
+
 	// Initialize session if needed
 	if v.activeSession == nil {
 		sessionID := uuid.NewString()
@@ -1714,7 +2461,7 @@ func (v *View) addComment(commentText string) {
 		// Create session in database if store is available
 		if v.store != nil {
 			// Calculate content hash
-			contentHash, err := calculateContentHash(v.selectedDoc.Path)
+			contentHash, err := v.selectedDoc.contentHash()
 			if err != nil {
 				contentHash = "" // Fallback to empty hash
 			}
@@ -1739,23 +2486,25 @@ func (v *View) addComment(commentText string) {
 		v.activeSession = &Session{
 			ID:         sessionID,
 			DocPath:    v.selectedDoc.Path,
+			Documents:  []SessionDocument{{Path: v.selectedDoc.Path, RelPath: v.selectedDoc.RelPath}},
 			Comments:   []Comment{},
 			CreatedAt:  time.Now(),
 			ModifiedAt: time.Now(),
 		}
+		v.currentReview = v.activeSession
 	}
 
-	// Calculate selection range from anchor to cursor
-	start := min(v.selectionStart, v.cursorLine)
-	end := max(v.selectionStart, v.cursorLine)
-
 	// Create comment
 	comment := Comment{
 		ID:          uuid.NewString(),
 		SessionID:   v.activeSession.ID,
+		DocPath:     v.selectedDoc.Path,
 		StartLine:   start,
 		EndLine:     end,
-		ContextText: v.getSelectedText(),
+		StartCol:    startCol,
+		EndCol:      endCol,
+		Severity:    severity.OrDefault(),
+		ContextText: v.rangeText(start, startCol, end, endCol),
 		CommentText: commentText,
 		CreatedAt:   time.Now(),
 	}
@@ -1763,13 +2512,17 @@ func (v *View) addComment(commentText string) {
 	// Save to database if store is available
 	if v.store != nil {
 		dbComment := corereview.Comment{
-			ID:          comment.ID,
-			SessionID:   comment.SessionID,
-			StartLine:   comment.StartLine,
-			EndLine:     comment.EndLine,
-			ContextText: comment.ContextText,
-			CommentText: comment.CommentText,
-			CreatedAt:   comment.CreatedAt,
+			ID:           comment.ID,
+			SessionID:    comment.SessionID,
+			DocumentPath: comment.DocPath,
+			StartLine:    comment.StartLine,
+			EndLine:      comment.EndLine,
+			StartCol:     comment.StartCol,
+			EndCol:       comment.EndCol,
+			Severity:     comment.Severity,
+			ContextText:  comment.ContextText,
+			CommentText:  comment.CommentText,
+			CreatedAt:    comment.CreatedAt,
 		}
 		if err := v.store.SaveComment(ctx, dbComment); err != nil {
 			log.Error().
@@ -1792,12 +2545,50 @@ func (v *View) addComment(commentText string) {
 
 	// Update comment count in tree
 	v.updateTreeItemCommentCount()
+	v.sendInstantComment(comment, nil)
+	return v.publishReviewEvent(ReviewEventComment)
+}
+
+// importFeedback parses pasted feedback and adds its comments to the open
+// document. Comments on other documents of multi-document feedback, or on
+// lines past the end of the document, are skipped. Returns the number of
+// comments added.
+func (v *View) importFeedback(text string) int {
+	if v.selectedDoc == nil {
+		return 0
+	}
+
+	lineCount := len(v.selectedDoc.RenderedLines)
+	added := 0
+	for _, c := range parseFeedback(text) {
+		if c.Document != "" && c.Document != v.selectedDoc.RelPath && c.Document != v.relPathFor(v.selectedDoc.Path) {
+			continue
+		}
+		if c.StartLine > lineCount {
+			continue
+		}
+		end := min(c.EndLine, lineCount)
+		startCol, endCol := c.StartCol, c.EndCol
+		if end != c.EndLine {
+			startCol, endCol = 0, 0
+		}
+		// The caller announces the import once
+		_ = v.addCommentAt(c.StartLine, startCol, end, endCol, c.Text, c.Severity)
+		added++
+	}
+
+	log.Debug().
+		Str("doc_path", v.selectedDoc.Path).
+		Int("imported", added).
+		Msg("review: imported pasted feedback")
+
+	return added
 }
 
-// updateComment updates the text of an existing comment.
-func (v *View) updateComment(commentID, newText string) {
+// updateComment updates the text and severity of an existing comment.
+func (v *View) updateComment(commentID, newText string, severity corereview.Severity) tea.Cmd {
 	if v.activeSession == nil {
-		return
+		return nil
 	}
 
 	ctx := context.Background()
@@ -1806,18 +2597,21 @@ func (v *View) updateComment(commentID, newText string) {
 	for i, comment := range v.activeSession.Comments {
 		if comment.ID == commentID {
 			v.activeSession.Comments[i].CommentText = newText
+			v.activeSession.Comments[i].Severity = severity.OrDefault()
 			v.activeSession.ModifiedAt = time.Now()
 
 			// Update in database if store is available
 			if v.store != nil {
 				dbComment := corereview.Comment{
-					ID:          comment.ID,
-					SessionID:   comment.SessionID,
-					StartLine:   comment.StartLine,
-					EndLine:     comment.EndLine,
-					ContextText: comment.ContextText,
-					CommentText: newText,
-					CreatedAt:   comment.CreatedAt,
+					ID:           comment.ID,
+					SessionID:    comment.SessionID,
+					DocumentPath: comment.DocPath,
+					StartLine:    comment.StartLine,
+					EndLine:      comment.EndLine,
+					Severity:     severity,
+					ContextText:  comment.ContextText,
+					CommentText:  newText,
+					CreatedAt:    comment.CreatedAt,
 				}
 				if err := v.store.UpdateComment(ctx, dbComment); err != nil {
 					log.Error().
@@ -1832,28 +2626,34 @@ func (v *View) updateComment(commentID, newText string) {
 				Int("start_line", comment.StartLine).
 				Int("end_line", comment.EndLine).
 				Msg("review: updated comment")
-			break
+			v.sendInstantComment(v.activeSession.Comments[i], &comment)
+			return v.publishReviewEvent(ReviewEventComment)
 		}
 	}
+	return nil
 }
 
-// deleteCommentsAtLine removes all comments that include the specified line number.
+// deleteCommentsAtLine removes all comments on the selected document that include the specified line number.
 // Multiple comments may be deleted if they overlap the target line.
 // Database deletion errors are logged but do not prevent in-memory deletion.
 // If all comments are deleted, activeSession is set to nil (ending the review session).
 // Callers should call updateTreeItemCommentCount() after deletion.
-func (v *View) deleteCommentsAtLine(lineNum int) {
-	if v.activeSession == nil || len(v.activeSession.Comments) == 0 {
-		return
+func (v *View) deleteCommentsAtLine(lineNum int) tea.Cmd {
+	if v.activeSession == nil || len(v.activeSession.Comments) == 0 || v.selectedDoc == nil {
+		return nil
 	}
 
 	ctx := context.Background()
+	onDoc := make(map[string]bool)
+	for _, comment := range v.docComments() {
+		onDoc[comment.ID] = true
+	}
 
 	// Filter out comments that include this line
 	var remainingComments []Comment
 	for _, comment := range v.activeSession.Comments {
-		// Keep comment if it doesn't include the cursor line
-		if lineNum < comment.StartLine || lineNum > comment.EndLine {
+		// Keep comment if it belongs to another document or doesn't include the cursor line
+		if !onDoc[comment.ID] || lineNum < comment.StartLine || lineNum > comment.EndLine {
 			remainingComments = append(remainingComments, comment)
 		} else if v.store != nil {
 			// Delete from database if store is available
@@ -1881,6 +2681,7 @@ func (v *View) deleteCommentsAtLine(lineNum int) {
 	if len(v.activeSession.Comments) == 0 {
 		v.activeSession = nil
 	}
+	return v.publishReviewEvent(ReviewEventComment)
 }
 
 // discardReview discards the entire review session, deleting it from the database.
@@ -1910,12 +2711,41 @@ func (v *View) discardReview() tea.Cmd {
 func (v *View) rebuildTree() {
 	docs := extractDocumentsFromListItems(v.list.Items())
 	v.roots = buildDocTree(docs)
+	changed := slices.DeleteFunc(slices.Clone(docs), func(d Document) bool {
+		return !slices.Contains(v.changed, d.Path)
+	})
+	if section := buildChangedSection(changed); section != nil {
+		v.roots = append([]*DocTreeNode{section}, v.roots...)
+	}
 	v.flatNodes = flattenDocTree(v.roots)
 	if v.treeCursor >= len(v.flatNodes) {
 		v.treeCursor = max(0, len(v.flatNodes)-1)
 	}
 }
 
+// refreshChanged returns a command that finds the documents changed since
+// their last finalized review. Hashing reads every reviewed document, so it
+// runs off the update goroutine; the tree picks up the result in
+// changedDocsLoadedMsg. It returns nil without a store.
+func (v *View) refreshChanged() tea.Cmd {
+	if v.store == nil {
+		return nil
+	}
+	store, docs := v.store, extractDocumentsFromListItems(v.list.Items())
+	return func() tea.Msg {
+		lastReviewed, err := store.LastReviewedHashes(context.Background())
+		if err != nil {
+			return changedDocsLoadedMsg{err: err}
+		}
+		changed := changedDocuments(docs, lastReviewed)
+		paths := make([]string, len(changed))
+		for i, doc := range changed {
+			paths[i] = doc.Path
+		}
+		return changedDocsLoadedMsg{paths: paths}
+	}
+}
+
 // moveTreeCursorDown moves the tree cursor down by n positions and clamps scroll.
 func (v *View) moveTreeCursorDown(n int) {
 	v.treeCursor = min(v.treeCursor+n, max(0, len(v.flatNodes)-1))
@@ -1986,6 +2816,7 @@ func (v *View) previewDocument(doc *Document) tea.Cmd {
 	v.cursorLine = 1
 	v.lineMapping = nil
 	v.activeSession = nil
+	v.annotations = nil
 
 	renderWidth := v.splitDetailWidth()
 	v.viewport = viewport.New(viewport.WithWidth(renderWidth), viewport.WithHeight(v.height-1))
@@ -2010,6 +2841,7 @@ func (v *View) previewDocument(doc *Document) tea.Cmd {
 // at split width. The preview remains visible.
 func (v *View) exitFullScreen() {
 	v.fullScreen = false
+	v.comments.focused = false
 	if v.selectedDoc == nil {
 		return
 	}
@@ -2017,7 +2849,7 @@ func (v *View) exitFullScreen() {
 	v.viewport = viewport.New(viewport.WithWidth(renderWidth), viewport.WithHeight(v.height-1))
 	rendered, err := v.selectedDoc.Render(renderWidth)
 	if err == nil {
-		if v.activeSession != nil && len(v.activeSession.Comments) > 0 {
+		if v.hasInlineNotes() {
 			v.renderSelection()
 		} else {
 			v.viewport.SetContent(rendered)
@@ -2072,10 +2904,7 @@ func (v *View) updateTreeItemCommentCount() {
 	}
 
 	items := v.list.Items()
-	commentCount := 0
-	if v.activeSession != nil {
-		commentCount = len(v.activeSession.Comments)
-	}
+	commentCount := len(v.docComments())
 
 	// Find and update the tree item for the current document
 	for i, ti := range TreeItemsDocuments(items) {
@@ -2161,17 +2990,26 @@ func (v *View) insertCommentsInline(content string, contentWidth int) (string, m
 	lines := strings.Split(content, "\n")
 	originalLineCount := len(lines)
 
-	// Group comments by end line
+	// Group comments and annotations by end line
 	commentsByLine := make(map[int][]Comment)
-	for _, comment := range v.activeSession.Comments {
+	for _, comment := range v.docComments() {
 		commentsByLine[comment.EndLine] = append(commentsByLine[comment.EndLine], comment)
 	}
+	annotationsByLine := make(map[int][]corereview.Annotation)
+	for _, a := range v.visibleAnnotations() {
+		annotationsByLine[a.EndLine] = append(annotationsByLine[a.EndLine], a)
+	}
 
 	// Get sorted line numbers to insert in reverse order (prevents offset issues)
-	lineNumbers := make([]int, 0, len(commentsByLine))
+	lineNumbers := make([]int, 0, len(commentsByLine)+len(annotationsByLine))
 	for lineNum := range commentsByLine {
 		lineNumbers = append(lineNumbers, lineNum)
 	}
+	for lineNum := range annotationsByLine {
+		if _, ok := commentsByLine[lineNum]; !ok {
+			lineNumbers = append(lineNumbers, lineNum)
+		}
+	}
 	// Sort in descending order to insert from bottom to top
 	for i := 0; i < len(lineNumbers); i++ {
 		for j := i + 1; j < len(lineNumbers); j++ {
@@ -2181,9 +3019,6 @@ func (v *View) insertCommentsInline(content string, contentWidth int) (string, m
 		}
 	}
 
-	// Insert comments after their lines with enhanced visual styling
-	commentStyle := styles.ReviewInlineCommentStyle
-
 	// Track how many comment lines were inserted before each document line
 	// This allows us to map document line numbers to display line numbers
 	insertedBeforeLine := make(map[int]int) // document line -> number of comment lines inserted before it
@@ -2199,14 +3034,24 @@ func (v *View) insertCommentsInline(content string, contentWidth int) (string, m
 		commentLines := make([]string, 0, len(comments))
 		for _, comment := range comments {
 			icon := styles.IconComment
+			text := renderDocRefMarkers(comment.CommentText)
+			if comment.Outdated {
+				text = "(outdated) " + text
+			}
+			text = "[" + comment.Severity.Label() + "] " + text
 			// Format with proper indentation, preserving explicit newlines
-			formattedLines := v.formatCommentLines(icon, comment.CommentText, 7, contentWidth)
-			// Apply styling to each formatted line
+			formattedLines := v.formatCommentLines(icon, text, 7, contentWidth)
+			// Apply styling to each formatted line, colored by severity
+			commentStyle := styles.ReviewInlineCommentStyle.Foreground(severityColor(comment.Severity))
 			for _, formattedLine := range formattedLines {
 				styledLine := commentStyle.Render(formattedLine)
 				commentLines = append(commentLines, styledLine)
 			}
 		}
+		// Annotations follow the line's comments
+		for _, a := range annotationsByLine[lineNum] {
+			commentLines = append(commentLines, v.formatAnnotationLines(a, contentWidth)...)
+		}
 
 		// Insert comment lines after the target line
 		insertPos := lineNum
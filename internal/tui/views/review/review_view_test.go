@@ -11,10 +11,13 @@ import (
 	"time"
 
 	tea "charm.land/bubbletea/v2"
+	act "github.com/colonyops/hive/internal/core/action"
 
+	corereview "github.com/colonyops/hive/internal/core/review"
 	"github.com/colonyops/hive/internal/core/styles"
 	"github.com/colonyops/hive/internal/data/db"
 	"github.com/colonyops/hive/internal/data/stores"
+	"github.com/colonyops/hive/internal/tui/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -854,7 +857,7 @@ func TestFinalizedSessionsNotReloaded(t *testing.T) {
 	view.selectionStart = 1
 	view.cursorLine = 2
 	view.selectionMode = true
-	view.addComment("Test comment before finalization")
+	view.addComment("Test comment before finalization", corereview.SeveritySuggestion)
 
 	require.NotNil(t, view.activeSession, "expected active session after adding comment")
 
@@ -874,6 +877,141 @@ func TestFinalizedSessionsNotReloaded(t *testing.T) {
 	assert.Nil(t, view.activeSession, "expected activeSession to be nil after reloading with finalized session")
 }
 
+// TestChangedSinceLastReviewSection verifies that documents edited after their
+// last finalized review are listed in a section at the top of the tree.
+func TestChangedSinceLastReviewSection(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	database, err := db.Open(tmpDir, db.DefaultOpenOptions())
+	require.NoError(t, err, "failed to open database")
+	defer func() {
+		assert.NoError(t, database.Close(), "failed to close database")
+	}()
+	store := stores.NewReviewStore(database)
+	ctx := context.Background()
+
+	newDoc := func(rel, content string) Document {
+		path := filepath.Join(tmpDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return Document{Path: path, RelPath: rel, Type: DocTypePlan, ModTime: time.Now(), Content: content}
+	}
+	plan := newDoc("plans/plan.md", "Step 1")
+	notes := newDoc("research/notes.md", "Finding A")
+	draft := newDoc("plans/draft.md", "Draft")
+
+	for _, doc := range []Document{plan, notes} {
+		hash, err := calculateContentHash(doc.Path)
+		require.NoError(t, err)
+		sess, err := store.CreateSession(ctx, doc.Path, hash)
+		require.NoError(t, err)
+		require.NoError(t, store.FinalizeSession(ctx, sess.ID))
+	}
+	require.NoError(t, os.WriteFile(plan.Path, []byte("Step 1\nStep 2"), 0o644))
+
+	view := New([]Document{plan, notes, draft}, tmpDir, store, nil, 0)
+	for _, root := range view.roots {
+		assert.False(t, root.Section, "documents are hashed off the update goroutine")
+	}
+	cmd := view.refreshChanged()
+	require.NotNil(t, cmd)
+	view, _ = view.Update(cmd())
+
+	require.NotEmpty(t, view.roots)
+	section := view.roots[0]
+	assert.True(t, section.Section)
+	assert.Equal(t, changedSectionName, section.Name)
+	require.Len(t, section.Children, 1, "unchanged and never-reviewed documents are not listed")
+	assert.Equal(t, "plans/plan.md", section.Children[0].Name)
+	assert.Equal(t, plan.Path, section.Children[0].Doc.Path)
+
+	view.treeCursor = 1
+	require.NotNil(t, view.SelectedDoc())
+	assert.Equal(t, plan.Path, view.SelectedDoc().Path)
+
+	// Without a store there is no review history to compare against.
+	noStore := New([]Document{plan, notes, draft}, tmpDir, nil, nil, 0)
+	assert.Nil(t, noStore.refreshChanged())
+	for _, root := range noStore.roots {
+		assert.False(t, root.Section)
+	}
+}
+
+// TestMultiDocumentReview verifies that related documents attached to the
+// current review share one session and finalize into per-file sections.
+func TestMultiDocumentReview(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	database, err := db.Open(tmpDir, db.DefaultOpenOptions())
+	require.NoError(t, err, "failed to open database")
+	defer func() {
+		assert.NoError(t, database.Close(), "failed to close database")
+	}()
+	store := stores.NewReviewStore(database)
+
+	newDoc := func(rel, content string, typ DocumentType) Document {
+		path := filepath.Join(tmpDir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return Document{Path: path, RelPath: rel, Type: typ, ModTime: time.Now(), Content: content}
+	}
+	plan := newDoc("plans/plan.md", "Step 1\nStep 2", DocTypePlan)
+	research := newDoc("research/notes.md", "Finding A\nFinding B", DocTypeResearch)
+
+	view := New([]Document{plan, research}, tmpDir, store, nil, 0)
+	view.SetSize(80, 24)
+
+	selectTreeDoc := func(path string) {
+		for i, fn := range view.flatNodes {
+			if fn.Node.Doc != nil && fn.Node.Doc.Path == path {
+				view.treeCursor = i
+				return
+			}
+		}
+		t.Fatalf("document %s not in tree", path)
+	}
+
+	selectTreeDoc(research.Path)
+	_, err = view.AddSelectedToReview()
+	require.Error(t, err, "adding without a review in progress should fail")
+
+	view.loadDocument(&plan)
+	view.selectionStart = 1
+	view.cursorLine = 1
+	view.addComment("Split this step", corereview.SeveritySuggestion)
+	require.NotNil(t, view.activeSession)
+	sessionID := view.activeSession.ID
+
+	// Back in the tree, attach the research notes to the plan's review.
+	view.exitFullScreen()
+	selectTreeDoc(research.Path)
+	rel, err := view.AddSelectedToReview()
+	require.NoError(t, err)
+	assert.Equal(t, "research/notes.md", rel)
+
+	view.loadDocument(&research)
+	require.NotNil(t, view.activeSession, "attached document should load the shared session")
+	assert.Equal(t, sessionID, view.activeSession.ID)
+	assert.Empty(t, view.docComments(), "plan comments are not shown on the research doc")
+
+	view.selectionStart = 2
+	view.cursorLine = 2
+	view.addComment("Cite the source", corereview.SeveritySuggestion)
+	assert.Equal(t, sessionID, view.activeSession.ID, "comments join the existing session")
+	assert.Len(t, view.activeSession.Comments, 2)
+
+	docPath, docRel := view.sessionDocument()
+	assert.Equal(t, plan.Path, docPath)
+	feedback := GenerateReviewFeedback(view.activeSession, docRel)
+	assert.Contains(t, feedback, "Documents: 2\nComments: 2 (2 suggestions)\n")
+	assert.Contains(t, feedback, "Document: plans/plan.md\nComments: 1 (1 suggestion)\n")
+	assert.Contains(t, feedback, "Document: research/notes.md\nComments: 1 (1 suggestion)\n")
+
+	comments, err := store.ListComments(context.Background(), sessionID)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+}
+
 // TestCtrlDUWithComments verifies that ctrl+d and ctrl+u correctly handle
 // display-to-document coordinate mapping when comments are inserted inline.
 func TestCtrlDUWithComments(t *testing.T) {
@@ -1277,3 +1415,400 @@ func TestSelectAtRow_ShowPreview_ClickInTree_Selects(t *testing.T) {
 	v.SelectAtRow(5, 2)
 	assert.Equal(t, 1, v.treeCursor, "click in tree pane should select item")
 }
+
+func TestCommentSeverityDisplay(t *testing.T) {
+	doc := Document{
+		Path:    "/path/to/test.md",
+		RelPath: "plans/test.md",
+		Type:    DocTypePlan,
+		ModTime: time.Now(),
+		Content: strings.Repeat("Line\n", 20),
+	}
+
+	view := New([]Document{doc}, "", nil, nil, 0)
+	view.SetSize(140, 24)
+	view.fullScreen = true
+	view.selectedDoc = &doc
+	view.activeSession = &Session{
+		ID:      "test-session",
+		DocPath: doc.Path,
+		Comments: []Comment{
+			{ID: "c1", StartLine: 2, EndLine: 2, CommentText: "broken", Severity: corereview.SeverityBlocker},
+			{ID: "c2", StartLine: 2, EndLine: 2, CommentText: "typo", Severity: corereview.SeverityNit},
+			{ID: "c3", StartLine: 2, EndLine: 2, CommentText: "also broken", Severity: corereview.SeverityBlocker},
+		},
+	}
+	view.renderSelection()
+
+	out := testutil.StripANSI(view.View())
+	assert.Contains(t, out, "[blocker] broken")
+	assert.Contains(t, out, "[nit] typo")
+	assert.Contains(t, out, "2 blockers · 1 nit")
+}
+
+func TestBracketCommentNavigation(t *testing.T) {
+	doc := Document{
+		Path:    "/path/to/test.md",
+		RelPath: "plans/test.md",
+		Type:    DocTypePlan,
+		ModTime: time.Now(),
+		Content: strings.Repeat("Line\n", 20),
+	}
+
+	view := New([]Document{doc}, "", nil, nil, 0)
+	view.SetSize(80, 24)
+	view.fullScreen = true
+	view.selectedDoc = &doc
+	view.activeSession = &Session{
+		ID:      "test-session",
+		DocPath: doc.Path,
+		Comments: []Comment{
+			{ID: "c3", StartLine: 15, EndLine: 15, CommentText: "third"},
+			{ID: "c1", StartLine: 3, EndLine: 4, CommentText: "first"},
+			{ID: "c2", StartLine: 8, EndLine: 8, CommentText: "second"},
+		},
+	}
+	view.cursorLine = 1
+
+	press := func(keys ...string) {
+		for _, k := range keys {
+			view, _ = view.Update(keyMsg(k))
+		}
+	}
+
+	press("]", "c")
+	assert.Equal(t, 3, view.cursorLine)
+	idx, total := view.commentPosition()
+	assert.Equal(t, 1, idx)
+	assert.Equal(t, 3, total)
+
+	press("]", "c")
+	assert.Equal(t, 8, view.cursorLine)
+
+	press("]", "c")
+	assert.Equal(t, 15, view.cursorLine)
+
+	press("]", "c")
+	assert.Equal(t, 3, view.cursorLine, "next wraps to the first comment")
+
+	press("[", "c")
+	assert.Equal(t, 15, view.cursorLine, "previous wraps to the last comment")
+
+	press("[", "c")
+	assert.Equal(t, 8, view.cursorLine)
+	assert.Contains(t, testutil.StripANSI(view.View()), "Comment 2/3")
+
+	press("]", "x")
+	assert.Equal(t, 8, view.cursorLine, "a non-c key cancels the pending motion")
+	assert.Empty(t, view.pendingMotion)
+
+	view.cursorLine = 10
+	idx, _ = view.commentPosition()
+	assert.Equal(t, 0, idx, "cursor outside any comment has no position")
+
+	// With a resolver the motion follows the keys bound to the actions
+	view.handler = motionResolver{"}": act.TypeDocsNextComment}
+	view.cursorLine = 1
+	press("]", "c")
+	assert.Equal(t, 1, view.cursorLine, "unbound bracket does not start a motion")
+	press("}", "c")
+	assert.Equal(t, 3, view.cursorLine)
+}
+
+// motionResolver resolves keys to the built-in action types in the map.
+type motionResolver map[string]act.Type
+
+func (r motionResolver) IsAction(key string, t act.Type) bool { return r[key] == t }
+
+func (r motionResolver) ResolveAction(string) (act.Action, bool) { return act.Action{}, false }
+
+func (r motionResolver) HelpEntries() []string { return nil }
+
+func TestCharwiseSelectionComment(t *testing.T) {
+	doc := Document{
+		Path:    "/path/to/test.md",
+		RelPath: "plans/test.md",
+		Type:    DocTypePlan,
+		ModTime: time.Now(),
+		Content: "Use a retry budget here.\n\nSecond paragraph.",
+	}
+
+	view := New([]Document{doc}, "", nil, nil, 0)
+	view.SetSize(80, 24)
+	view.fullScreen = true
+	view.selectedDoc = &doc
+	view.renderSelection()
+
+	// Locate "retry" in the rendered output.
+	line, col := 0, 0
+	for i := range doc.RenderedLines {
+		if idx := strings.Index(string(doc.plainLine(i+1)), "retry"); idx >= 0 {
+			line, col = i+1, len([]rune(string(doc.plainLine(i + 1))[:idx]))+1
+			break
+		}
+	}
+	require.NotZero(t, line, "rendered document should contain the phrase")
+	view.cursorLine = line
+
+	view, _ = view.Update(keyMsg("V"))
+	assert.False(t, view.charwiseSelection(), "visual mode starts linewise")
+
+	view, _ = view.Update(keyMsg("l"))
+	require.True(t, view.charwiseSelection(), "h/l switches to a span selection")
+
+	view.selectionStartCol = col
+	view.cursorCol = col
+	view, _ = view.Update(keyMsg("w"))
+	view, _ = view.Update(keyMsg("w"))
+	assert.Equal(t, "retry budget", view.getSelectedText(), "w extends to the end of the next word")
+
+	view, _ = view.Update(keyMsg("b"))
+	assert.Equal(t, "retry b", view.getSelectedText(), "b moves back to the start of the word")
+	view, _ = view.Update(keyMsg("w"))
+
+	view.addComment("How big?", corereview.SeveritySuggestion)
+	require.NotNil(t, view.activeSession)
+	require.Len(t, view.activeSession.Comments, 1)
+	c := view.activeSession.Comments[0]
+	assert.Equal(t, line, c.StartLine)
+	assert.Equal(t, col, c.StartCol)
+	assert.Equal(t, col+len("retry budget")-1, c.EndCol)
+	assert.Equal(t, "retry budget", c.ContextText)
+
+	view.selectionMode = false
+	assert.Equal(t, [2]int{c.StartCol, c.EndCol}, view.commentColumnSpans()[line])
+}
+
+func TestColumnSpan(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     int
+		from, to int
+		ok       bool
+	}{
+		{name: "single line span", line: 3, from: 5, to: 9, ok: true},
+		{name: "outside range", line: 2, ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, ok := columnSpan(tt.line, 20, 3, 5, 3, 9)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.from, from)
+			assert.Equal(t, tt.to, to)
+		})
+	}
+
+	// Multi-line spans run from the start column to end of line, then whole
+	// middle lines, then up to the end column.
+	from, to, ok := columnSpan(1, 10, 1, 4, 3, 2)
+	assert.True(t, ok)
+	assert.Equal(t, []int{4, 10}, []int{from, to})
+	from, to, _ = columnSpan(2, 12, 1, 4, 3, 2)
+	assert.Equal(t, []int{1, 12}, []int{from, to})
+	from, to, _ = columnSpan(3, 12, 1, 4, 3, 2)
+	assert.Equal(t, []int{1, 2}, []int{from, to})
+
+	_, _, ok = columnSpan(1, 10, 1, 0, 3, 0)
+	assert.False(t, ok, "linewise ranges have no column span")
+}
+
+// TestReanchorOnDocumentChange verifies that editing a document under review
+// keeps the session and moves comments with the text they quote.
+func TestReanchorOnDocumentChange(t *testing.T) {
+	tmpDir := t.TempDir()
+	database, err := db.Open(tmpDir, db.DefaultOpenOptions())
+	require.NoError(t, err, "failed to open database")
+	defer func() { _ = database.Close() }()
+	store := stores.NewReviewStore(database)
+
+	docPath := filepath.Join(tmpDir, "plan.md")
+	require.NoError(t, os.WriteFile(docPath, []byte("Keep this paragraph.\n\nDrop this paragraph.\n"), 0o644))
+
+	doc := Document{Path: docPath, RelPath: "plan.md", Type: DocTypePlan, ModTime: time.Now()}
+	view := New([]Document{doc}, tmpDir, store, nil, 0)
+	view.SetSize(80, 24)
+	view.loadDocument(&doc)
+
+	lineOf := func(text string) int {
+		for i := range doc.RenderedLines {
+			if strings.Contains(string(doc.plainLine(i+1)), text) {
+				return i + 1
+			}
+		}
+		t.Fatalf("%q not rendered", text)
+		return 0
+	}
+
+	keep, drop := lineOf("Keep this"), lineOf("Drop this")
+	view.selectionMode = true
+	view.selectionStart, view.cursorLine = keep, keep
+	view.addComment("keep", corereview.SeveritySuggestion)
+	view.selectionMode = true
+	view.selectionStart, view.cursorLine = drop, drop
+	view.addComment("drop", corereview.SeveritySuggestion)
+	require.NotNil(t, view.activeSession)
+	sessionID := view.activeSession.ID
+
+	require.NoError(t, os.WriteFile(docPath, []byte("# Title\n\nIntro.\n\nKeep this paragraph.\n"), 0o644))
+	doc.Content = ""
+	view.activeSession = nil
+	view.loadDocument(&doc)
+
+	require.NotNil(t, view.activeSession, "session survives the edit")
+	assert.Equal(t, sessionID, view.activeSession.ID)
+
+	comments := make(map[string]Comment)
+	for _, c := range view.docComments() {
+		comments[c.CommentText] = c
+	}
+	require.Len(t, comments, 2)
+	assert.Equal(t, lineOf("Keep this"), comments["keep"].StartLine, "comment follows its text")
+	assert.False(t, comments["keep"].Outdated)
+	assert.True(t, comments["drop"].Outdated, "comment on deleted text is outdated")
+	assert.Contains(t, GenerateReviewFeedback(view.activeSession, "plan.md"), "(outdated)")
+}
+
+func TestJumpToReference(t *testing.T) {
+	plan := Document{
+		Path:    "/ctx/plans/plan.md",
+		RelPath: "plans/plan.md",
+		Type:    DocTypePlan,
+		ModTime: time.Now(),
+		Content: strings.Repeat("Step\n", 5),
+	}
+	notes := Document{
+		Path:    "/ctx/research/notes.md",
+		RelPath: "research/notes.md",
+		Type:    DocTypeResearch,
+		ModTime: time.Now(),
+		Content: strings.Repeat("- Finding\n", 30),
+	}
+
+	view := New([]Document{plan, notes}, "", nil, nil, 0)
+	view.SetSize(80, 24)
+	view.loadDocument(&plan)
+	view.activeSession = &Session{
+		ID:      "test-session",
+		DocPath: plan.Path,
+		Comments: []Comment{
+			{ID: "c1", DocPath: plan.Path, StartLine: 2, EndLine: 2, CommentText: "Contradicts [[research/notes.md#L20-L22]]"},
+			{ID: "c2", DocPath: plan.Path, StartLine: 4, EndLine: 4, CommentText: "See [[research/missing.md#L1]]"},
+		},
+	}
+	view.renderSelection()
+
+	assert.Contains(t, testutil.StripANSI(view.View()), "research/notes.md:20-22", "inline comment shows the reference")
+
+	view.cursorLine = 1
+	view, cmd := view.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.Equal(t, plan.Path, view.selectedDoc.Path, "enter without a reference stays put")
+
+	view.cursorLine = 2
+	view, cmd = view.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	require.NotNil(t, view.selectedDoc)
+	assert.Equal(t, notes.Path, view.selectedDoc.Path)
+	assert.Equal(t, 20, view.cursorLine)
+	assert.True(t, view.fullScreen)
+
+	view.loadDocument(&plan)
+	view.activeSession = &Session{
+		ID:       "test-session",
+		DocPath:  plan.Path,
+		Comments: []Comment{{ID: "c2", DocPath: plan.Path, StartLine: 4, EndLine: 4, CommentText: "See [[research/missing.md#L1]]"}},
+	}
+	view.cursorLine = 4
+	_, cmd = view.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(OpenDocumentMsg)
+	require.True(t, ok)
+	assert.Error(t, msg.Err, "a missing referenced document is reported")
+}
+
+func TestFinalizeSavesFeedback(t *testing.T) {
+	contextDir := t.TempDir()
+	docs := []Document{{Path: filepath.Join(contextDir, "plans", "auth.md"), RelPath: "plans/auth.md", Type: DocTypePlan}}
+
+	for _, enabled := range []bool{false, true} {
+		v := New(docs, contextDir, nil, nil, 0)
+		v.SetSaveFeedback(enabled)
+
+		msg, ok := v.finalizedCmd("feedback", docs[0].Path, docs[0].RelPath, "", corereview.VerdictComment)().(ReviewFinalizedMsg)
+		require.True(t, ok)
+		require.NoError(t, msg.SaveErr)
+		assert.Equal(t, "feedback", msg.Feedback)
+		if !enabled {
+			assert.Empty(t, msg.SavedPath)
+			continue
+		}
+		require.NotEmpty(t, msg.SavedPath)
+		assert.Equal(t, filepath.Join(contextDir, "reviews"), filepath.Dir(msg.SavedPath))
+		assert.True(t, strings.HasPrefix(filepath.Base(msg.SavedPath), "auth-"))
+	}
+}
+
+func TestFinalizeRunsHooks(t *testing.T) {
+	contextDir := t.TempDir()
+	docs := []Document{{Path: filepath.Join(contextDir, "plans", "auth.md"), RelPath: "plans/auth.md", Type: DocTypePlan}}
+
+	v := New(docs, contextDir, nil, nil, 0)
+	v.SetSaveFeedback(true)
+	v.SetInstantTarget(InstantTarget{ID: "sess1", Name: "api", Topic: "agent.sess1.inbox"})
+	v.SetFinalizeHooks([]string{
+		"cat > hook.txt; echo '{{ .SessionID }} {{ .ReviewID }} {{ .Verdict }}' >> hook.txt; test -f '{{ .SavedPath }}'",
+		"exit 3",
+	})
+
+	msg, ok := v.finalizedCmd("feedback\n", docs[0].Path, docs[0].RelPath, "rev1", corereview.VerdictRequestChanges)().(ReviewFinalizedMsg)
+	require.True(t, ok)
+	require.NoError(t, msg.SaveErr)
+	require.Error(t, msg.HookErr, "failing hook is reported")
+	assert.Contains(t, msg.HookErr.Error(), "finalize hook 2")
+	assert.Equal(t, corereview.VerdictRequestChanges, msg.Verdict)
+
+	data, err := os.ReadFile(filepath.Join(contextDir, "hook.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "feedback\nsess1 rev1 request_changes\n", string(data))
+}
+
+func TestImportFeedback(t *testing.T) {
+	doc := Document{
+		Path:    "/path/to/test.md",
+		RelPath: "plans/test.md",
+		Type:    DocTypePlan,
+		ModTime: time.Now(),
+		Content: strings.Repeat("Paragraph\n\n", 10),
+	}
+
+	view := New([]Document{doc}, "", nil, nil, 0)
+	view.SetSize(80, 24)
+	view.fullScreen = true
+	view.selectedDoc = &doc
+	view.renderSelection()
+	lineCount := len(doc.RenderedLines)
+	require.Greater(t, lineCount, 3)
+
+	view, _ = view.Update(keyMsg("p"))
+	require.NotNil(t, view.commentModal)
+	assert.Contains(t, testutil.StripANSI(view.commentModal.View()), "Import Feedback")
+
+	view.commentModal.SetExistingComment("no anchors here")
+	view, _ = view.Update(keyMsg("ctrl+s"))
+	require.NotNil(t, view.commentModal, "modal stays open when nothing parses")
+	assert.Contains(t, view.commentModal.View(), "No comments found")
+
+	view.commentModal.SetExistingComment("L1: Tighten this\nL2-L999: Merge these\nL999: Past the end")
+	view, _ = view.Update(keyMsg("ctrl+s"))
+	assert.Nil(t, view.commentModal)
+	assert.False(t, view.importingFeedback)
+
+	require.NotNil(t, view.activeSession)
+	comments := view.docComments()
+	require.Len(t, comments, 2, "comments past the end of the document are skipped")
+	assert.Equal(t, 1, comments[0].StartLine)
+	assert.Equal(t, "Tighten this", comments[0].CommentText)
+	assert.Equal(t, 2, comments[1].StartLine)
+	assert.Equal(t, lineCount, comments[1].EndLine, "ranges are clamped to the document")
+	assert.Equal(t, view.rangeText(2, 0, lineCount, 0), comments[1].ContextText)
+}
@@ -0,0 +1,199 @@
+package review
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/colonyops/hive/pkg/executil"
+)
+
+// gitTimeout bounds the git commands used to list and read document revisions.
+const gitTimeout = 10 * time.Second
+
+// maxRevisions is the number of commits offered when pinning a document.
+const maxRevisions = 50
+
+// Revision is a commit that changed a document.
+type Revision struct {
+	Hash      string
+	ShortHash string
+	Date      time.Time
+	Subject   string
+}
+
+// documentRevisions lists the commits that changed the document at path,
+// newest first, from the git repository containing it.
+func documentRevisions(ctx context.Context, executor executil.Executor, gitPath, path string) ([]Revision, error) {
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+
+	out, err := executor.RunDir(ctx, filepath.Dir(path), gitPath, "log",
+		"-n", strconv.Itoa(maxRevisions), "--format=%H%x1f%h%x1f%ct%x1f%s", "--", filepath.Base(path))
+	if err != nil {
+		return nil, fmt.Errorf("git log: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	revisions := parseRevisions(string(out))
+	if len(revisions) == 0 {
+		return nil, fmt.Errorf("no commits found for %s", filepath.Base(path))
+	}
+	return revisions, nil
+}
+
+// parseRevisions parses git log output in the format used by
+// documentRevisions, skipping malformed lines.
+func parseRevisions(out string) []Revision {
+	var revisions []Revision
+	for line := range strings.SplitSeq(strings.TrimSpace(out), "\n") {
+		fields := strings.SplitN(line, "\x1f", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		unix, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		revisions = append(revisions, Revision{
+			Hash:      fields[0],
+			ShortHash: fields[1],
+			Date:      time.Unix(unix, 0),
+			Subject:   fields[3],
+		})
+	}
+	return revisions
+}
+
+// revisionContent returns the content of the document at path as of rev.
+func revisionContent(ctx context.Context, executor executil.Executor, gitPath, path, rev string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+
+	// "./" resolves the path relative to the document's directory rather
+	// than the repository root
+	out, err := executor.RunDir(ctx, filepath.Dir(path), gitPath, "show", rev+":./"+filepath.Base(path))
+	if err != nil {
+		return "", fmt.Errorf("git show: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+// pinnedDocument returns a read-only copy of doc with the content it had at
+// rev. The pinned copy is keyed by "<path>@<short hash>", so it gets its own
+// review session and is left alone when the working copy changes.
+func pinnedDocument(doc Document, rev Revision, content string) Document {
+	return Document{
+		Path:     doc.Path + "@" + rev.ShortHash,
+		RelPath:  doc.RelPath + "@" + rev.ShortHash,
+		Type:     doc.Type,
+		ModTime:  rev.Date,
+		Vault:    doc.Vault,
+		ReadOnly: true,
+		Revision: rev.ShortHash,
+		Content:  content,
+	}
+}
+
+// sourcePath returns the path of the working copy a pinned document was read
+// from, or the document's own path.
+func (d *Document) sourcePath() string {
+	if d.Revision == "" {
+		return d.Path
+	}
+	return strings.TrimSuffix(d.Path, "@"+d.Revision)
+}
+
+// contentHash computes the SHA256 hash of the document's content. Pinned
+// documents hash their revision's content; others read the file.
+func (d *Document) contentHash() (string, error) {
+	if d.Revision == "" {
+		return calculateContentHash(d.Path)
+	}
+	hash := sha256.Sum256([]byte(d.Content))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// revisionsLoadedMsg carries the commits that changed a document, listed in
+// the background for the revision picker.
+type revisionsLoadedMsg struct {
+	doc       Document
+	revisions []Revision
+	err       error
+}
+
+// revisionLoadedMsg carries the content of a document as of a revision.
+type revisionLoadedMsg struct {
+	doc     Document
+	rev     Revision
+	content string
+	err     error
+}
+
+// OpenRevisionPicker lists the commits that changed the selected document so
+// an earlier version can be opened for review. The picker opens once git
+// answers.
+func (v *View) OpenRevisionPicker() (tea.Cmd, error) {
+	doc := v.SelectedDoc()
+	if v.fullScreen && v.selectedDoc != nil {
+		doc = v.selectedDoc
+	}
+	if doc == nil {
+		return nil, errors.New("no document selected")
+	}
+
+	source := *doc
+	source.Path = doc.sourcePath()
+	source.RelPath = strings.TrimSuffix(doc.RelPath, "@"+doc.Revision)
+	source.Revision = ""
+
+	executor, gitPath := v.exec, v.gitPath
+	return func() tea.Msg {
+		revisions, err := documentRevisions(context.Background(), executor, gitPath, source.Path)
+		return revisionsLoadedMsg{doc: source, revisions: revisions, err: err}
+	}, nil
+}
+
+// handleRevisionsLoaded opens the revision picker, or reports why the
+// document's history could not be listed.
+func (v *View) handleRevisionsLoaded(msg revisionsLoadedMsg) tea.Cmd {
+	if msg.err != nil {
+		return ErrorCmd(fmt.Errorf("open at commit: %w", msg.err))
+	}
+	picker := NewRevisionPicker(msg.doc, msg.revisions)
+	v.revisionPicker = &picker
+	return nil
+}
+
+// openRevision reads doc as of rev in the background.
+func (v *View) openRevision(doc Document, rev Revision) tea.Cmd {
+	executor, gitPath := v.exec, v.gitPath
+	return func() tea.Msg {
+		content, err := revisionContent(context.Background(), executor, gitPath, doc.Path, rev.Hash)
+		return revisionLoadedMsg{doc: doc, rev: rev, content: content, err: err}
+	}
+}
+
+// handleRevisionLoaded loads the pinned version for review, or shows why it
+// could not be read in the picker. Results for a picker that was closed in
+// the meantime are dropped.
+func (v *View) handleRevisionLoaded(msg revisionLoadedMsg) {
+	if v.revisionPicker == nil || v.revisionPicker.Document().Path != msg.doc.Path {
+		return
+	}
+	if msg.err != nil {
+		v.revisionPicker.SetError(msg.err.Error())
+		return
+	}
+
+	v.revisionPicker = nil
+	pinned := pinnedDocument(msg.doc, msg.rev, msg.content)
+	v.loadDocument(&pinned)
+}
@@ -0,0 +1,186 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corereview "github.com/colonyops/hive/internal/core/review"
+)
+
+// fakeGit answers git log and git show for revision tests.
+type fakeGit struct {
+	log      string
+	contents map[string]string // "<rev>:<file>" → content
+	calls    [][]string
+	bin      string // the command last run
+}
+
+func (g *fakeGit) Run(ctx context.Context, cmd string, args ...string) ([]byte, error) {
+	return g.RunDir(ctx, "", cmd, args...)
+}
+
+func (g *fakeGit) RunDir(_ context.Context, dir, cmd string, args ...string) ([]byte, error) {
+	g.calls = append(g.calls, append([]string{dir}, args...))
+	g.bin = cmd
+	switch args[0] {
+	case "log":
+		return []byte(g.log), nil
+	case "show":
+		if content, ok := g.contents[args[1]]; ok {
+			return []byte(content), nil
+		}
+		return []byte("fatal: path does not exist"), errors.New("exit status 128")
+	}
+	return nil, errors.New("unexpected git command")
+}
+
+func (g *fakeGit) RunStream(context.Context, io.Writer, io.Writer, string, ...string) error {
+	return nil
+}
+
+func (g *fakeGit) RunDirStream(context.Context, string, io.Writer, io.Writer, string, ...string) error {
+	return nil
+}
+
+// runCmds runs cmd, and the commands of a batch, returning their messages.
+func runCmds(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return []tea.Msg{msg}
+	}
+	var msgs []tea.Msg
+	for _, c := range batch {
+		msgs = append(msgs, runCmds(c)...)
+	}
+	return msgs
+}
+
+func TestParseRevisions(t *testing.T) {
+	out := "aaaa1111\x1faaaa111\x1f1767225600\x1fRevise plan\n" +
+		"malformed line\n" +
+		"bbbb2222\x1fbbbb222\x1fnot-a-time\x1fBad date\n" +
+		"cccc3333\x1fcccc333\x1f1767139200\x1fInitial: draft\x1fwith separator\n"
+
+	revs := parseRevisions(out)
+	require.Len(t, revs, 2)
+	assert.Equal(t, Revision{Hash: "aaaa1111", ShortHash: "aaaa111", Date: time.Unix(1767225600, 0), Subject: "Revise plan"}, revs[0])
+	assert.Equal(t, "Initial: draft\x1fwith separator", revs[1].Subject)
+	assert.Empty(t, parseRevisions(""))
+}
+
+func TestOpenRevision(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plan.md")
+	require.NoError(t, os.WriteFile(path, []byte("# Plan\n\nCurrent text.\n"), 0o644))
+
+	doc := Document{Path: path, RelPath: "plans/plan.md", Type: DocTypePlan, ModTime: time.Now()}
+	git := &fakeGit{
+		log: "aaaa1111\x1faaaa111\x1f1767225600\x1fRevise plan\n" +
+			"bbbb2222\x1fbbbb222\x1f1767139200\x1fFirst draft\n",
+		contents: map[string]string{"bbbb2222:./plan.md": "# Plan\n\nFirst draft text.\n"},
+	}
+
+	view := New([]Document{doc}, "", nil, nil, 0)
+	view.SetGit("/opt/git", git)
+	view.SetSize(100, 30)
+	view.treeCursor = 1 // repo header, then the document
+
+	openPicker := func() {
+		t.Helper()
+		cmd, err := view.OpenRevisionPicker()
+		require.NoError(t, err)
+		assert.Nil(t, view.revisionPicker, "git runs in the returned command")
+		view, _ = view.Update(cmd())
+	}
+	// press sends key and runs the commands it returns, as the program would.
+	press := func(key string) {
+		t.Helper()
+		var cmd tea.Cmd
+		view, cmd = view.Update(keyMsg(key))
+		for _, msg := range runCmds(cmd) {
+			view, _ = view.Update(msg)
+		}
+	}
+
+	openPicker()
+	require.NotNil(t, view.revisionPicker)
+	assert.Equal(t, []string{dir, "log", "-n", "50", "--format=%H%x1f%h%x1f%ct%x1f%s", "--", "plan.md"}, git.calls[0])
+	assert.Equal(t, "/opt/git", git.bin)
+	assert.Contains(t, view.View(), "First draft")
+
+	press("j")
+	press("enter")
+	require.Nil(t, view.revisionPicker)
+
+	pinned := view.selectedDoc
+	require.NotNil(t, pinned)
+	assert.Equal(t, path+"@bbbb222", pinned.Path)
+	assert.Equal(t, "plans/plan.md@bbbb222", pinned.RelPath)
+	assert.True(t, pinned.ReadOnly)
+	assert.Equal(t, path, pinned.sourcePath())
+	assert.Contains(t, pinned.Content, "First draft text.")
+	assert.True(t, view.fullScreen)
+	assert.Contains(t, view.View(), "pinned @bbbb222")
+
+	// Comments start a session of their own on the pinned version
+	view.selectionStart = 1
+	view.addComment("Keep this wording", corereview.SeveritySuggestion)
+	require.NotNil(t, view.activeSession)
+	assert.Equal(t, pinned.Path, view.activeSession.DocPath)
+
+	// Reopening from the pinned version lists the working copy's history
+	openPicker()
+	assert.Equal(t, "plans/plan.md", view.revisionPicker.Document().RelPath)
+
+	press("enter")
+	require.NotNil(t, view.revisionPicker, "picker stays open when the version cannot be read")
+	assert.Contains(t, view.View(), "git show")
+
+	press("esc")
+	assert.Nil(t, view.revisionPicker)
+}
+
+func TestOpenRevisionPicker_ReportsGitErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.md")
+	require.NoError(t, os.WriteFile(path, []byte("# Plan\n"), 0o644))
+
+	view := New([]Document{{Path: path, RelPath: "plans/plan.md", Type: DocTypePlan, ModTime: time.Now()}}, "", nil, nil, 0)
+	view.SetGit("git", &fakeGit{})
+	view.SetSize(100, 30)
+	view.treeCursor = 1
+
+	cmd, err := view.OpenRevisionPicker()
+	require.NoError(t, err)
+	view, cmd = view.Update(cmd())
+	assert.Nil(t, view.revisionPicker)
+	require.NotNil(t, cmd)
+	errMsg, ok := cmd().(ErrorMsg)
+	require.True(t, ok)
+	assert.ErrorContains(t, errMsg.Err, "no commits found for plan.md")
+}
+
+func TestPinnedContentHash(t *testing.T) {
+	a := Document{Path: "/x/plan.md@aaaa111", Revision: "aaaa111", Content: "one"}
+	b := Document{Path: "/x/plan.md@bbbb222", Revision: "bbbb222", Content: "two"}
+
+	ha, err := a.contentHash()
+	require.NoError(t, err)
+	hb, err := b.contentHash()
+	require.NoError(t, err)
+	assert.NotEqual(t, ha, hb)
+	assert.NoError(t, a.LoadContent(), "pinned content is not reloaded from disk")
+	assert.Equal(t, "one", a.Content)
+}
@@ -5,6 +5,8 @@ import (
 
 	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
+
+	"github.com/colonyops/hive/internal/tui/components/input"
 )
 
 // SearchMode handles document search functionality.
@@ -18,10 +20,9 @@ type SearchMode struct {
 
 // NewSearchMode creates a new SearchMode instance.
 func NewSearchMode() SearchMode {
-	ti := textinput.New()
+	ti := input.NewTextInput()
 	ti.Placeholder = "Search..."
 	ti.CharLimit = 100
-	ti.KeyMap.Paste.SetEnabled(true)
 
 	return SearchMode{
 		active:       false,
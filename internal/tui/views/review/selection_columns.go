@@ -0,0 +1,160 @@
+package review
+
+import (
+	"strings"
+	"unicode"
+
+	lipgloss "charm.land/lipgloss/v2"
+)
+
+// Column anchors address a span within rendered document lines. Columns are
+// 1-indexed rune offsets into the ANSI-stripped rendered line (without the
+// line number gutter); a column of 0 means the selection or comment covers
+// whole lines.
+
+// plainLine returns the ANSI-stripped runes of a 1-indexed rendered line.
+func (d *Document) plainLine(line int) []rune {
+	if d == nil || line < 1 || line > len(d.RenderedLines) {
+		return nil
+	}
+	return []rune(ansiStripPattern.ReplaceAllString(d.RenderedLines[line-1], ""))
+}
+
+// charwiseSelection reports whether visual mode is selecting a column span
+// rather than whole lines.
+func (v *View) charwiseSelection() bool {
+	return v.selectionMode && v.cursorCol > 0
+}
+
+// selectionBounds returns the ordered selection range. Columns are 0 for a
+// linewise selection.
+func (v *View) selectionBounds() (startLine, startCol, endLine, endCol int) {
+	startLine, startCol = v.selectionStart, v.selectionStartCol
+	endLine, endCol = v.cursorLine, v.cursorCol
+	if endLine < startLine || (endLine == startLine && endCol < startCol) {
+		startLine, startCol, endLine, endCol = endLine, endCol, startLine, startCol
+	}
+	if !v.charwiseSelection() {
+		startCol, endCol = 0, 0
+	}
+	return startLine, startCol, endLine, endCol
+}
+
+// moveCursorCol moves the visual mode column cursor by delta. The first
+// horizontal move switches a linewise selection to charwise, anchoring both
+// ends at the first non-blank column of their lines.
+func (v *View) moveCursorCol(delta int) {
+	if v.selectedDoc == nil || !v.selectionMode {
+		return
+	}
+	if v.cursorCol == 0 {
+		v.selectionStartCol = firstNonBlankCol(v.selectedDoc.plainLine(v.selectionStart))
+		v.cursorCol = firstNonBlankCol(v.selectedDoc.plainLine(v.cursorLine))
+	}
+	v.cursorCol += delta
+	v.clampCursorCol()
+}
+
+// moveCursorWord moves the column cursor by one word on the current line:
+// forward to the end of the next word so the selection covers it whole, or
+// back to the start of the previous word.
+func (v *View) moveCursorWord(forward bool) {
+	if v.selectedDoc == nil || !v.selectionMode {
+		return
+	}
+	if v.cursorCol == 0 {
+		v.moveCursorCol(0)
+	}
+	line := v.selectedDoc.plainLine(v.cursorLine)
+	if len(line) == 0 {
+		return
+	}
+	i := v.cursorCol - 1
+	if forward {
+		i++
+		for i < len(line)-1 && unicode.IsSpace(line[i]) {
+			i++
+		}
+		for i < len(line)-1 && !unicode.IsSpace(line[i+1]) {
+			i++
+		}
+	} else {
+		i--
+		for i > 0 && unicode.IsSpace(line[i]) {
+			i--
+		}
+		for i > 0 && !unicode.IsSpace(line[i-1]) {
+			i--
+		}
+	}
+	v.cursorCol = i + 1
+	v.clampCursorCol()
+}
+
+// clampCursorCol keeps the column cursor within the current line.
+func (v *View) clampCursorCol() {
+	if v.cursorCol == 0 || v.selectedDoc == nil {
+		return
+	}
+	v.cursorCol = min(max(v.cursorCol, 1), max(len(v.selectedDoc.plainLine(v.cursorLine)), 1))
+}
+
+// firstNonBlankCol returns the 1-indexed column of the first non-space rune,
+// or 1 for blank lines.
+func firstNonBlankCol(line []rune) int {
+	for i, r := range line {
+		if !unicode.IsSpace(r) {
+			return i + 1
+		}
+	}
+	return 1
+}
+
+// columnSpan returns the inclusive column range of line covered by a range
+// anchored at (startLine, startCol) through (endLine, endCol). ok is false when
+// the range is linewise or does not include line.
+func columnSpan(line, lineLen, startLine, startCol, endLine, endCol int) (from, to int, ok bool) {
+	if startCol == 0 || endCol == 0 || line < startLine || line > endLine {
+		return 0, 0, false
+	}
+	from, to = 1, lineLen
+	if line == startLine {
+		from = startCol
+	}
+	if line == endLine {
+		to = min(endCol, lineLen)
+	}
+	if from > to {
+		return 0, 0, false
+	}
+	return from, to, true
+}
+
+// selectedSpanText returns the exact text of a charwise selection, joining
+// lines with newlines.
+func (v *View) selectedSpanText(startLine, startCol, endLine, endCol int) string {
+	var parts []string
+	for line := startLine; line <= endLine; line++ {
+		runes := v.selectedDoc.plainLine(line)
+		from, to, ok := columnSpan(line, len(runes), startLine, startCol, endLine, endCol)
+		if !ok {
+			parts = append(parts, "")
+			continue
+		}
+		parts = append(parts, string(runes[from-1:to]))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// highlightColumns styles columns [from, to] of a displayed document line,
+// leaving the line number gutter and the rest of the line unstyled.
+func highlightColumns(line string, from, to int, style lipgloss.Style) string {
+	clean := ansiStripPattern.ReplaceAllString(line, "")
+	gutter := lineNumGutterPattern.FindString(clean)
+	body := []rune(clean[len(gutter):])
+	if from < 1 || from > len(body) {
+		return clean
+	}
+	to = min(to, len(body))
+	return gutter + string(body[:from-1]) + style.Render(string(body[from-1:to])) + string(body[to:])
+}
@@ -1,5 +1,12 @@
 package review
 
+import (
+	"image/color"
+
+	corereview "github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/internal/core/styles"
+)
+
 // Tree characters for rendering document tree.
 const (
 	treeBranch = "├─"
@@ -10,3 +17,18 @@ const (
 const (
 	keyEnter = "enter"
 )
+
+// severityColor returns the color comments of a severity are highlighted in,
+// escalating from muted nits to blockers in the error color.
+func severityColor(sev corereview.Severity) color.Color {
+	switch sev.OrDefault() {
+	case corereview.SeverityNit:
+		return styles.ColorMuted
+	case corereview.SeverityIssue:
+		return styles.ColorWarning
+	case corereview.SeverityBlocker:
+		return styles.ColorError
+	default:
+		return styles.ColorSecondary
+	}
+}
@@ -16,8 +16,13 @@ type DocTreeNode struct {
 	Doc      *Document      // Non-nil for leaf nodes (files)
 	Children []*DocTreeNode // Non-nil for directory nodes
 	Expanded bool           // Whether directory is expanded
+	Section  bool           // Virtual group of documents listed again from elsewhere in the tree
 }
 
+// changedSectionName is the label of the section listing documents changed
+// since their last finalized review.
+const changedSectionName = "Changed since last review"
+
 // DocFlatNode is a flattened tree node for rendering.
 type DocFlatNode struct {
 	Node   *DocTreeNode
@@ -93,6 +98,51 @@ func buildDocTree(docs []Document) []*DocTreeNode {
 	return roots
 }
 
+// changedDocuments returns the documents whose content no longer matches the
+// hash recorded in their most recent finalized review. Documents that were
+// never reviewed, or that can no longer be read, are not included.
+func changedDocuments(docs []Document, lastReviewed map[string]string) []Document {
+	var changed []Document
+	for _, doc := range docs {
+		last, ok := lastReviewed[doc.Path]
+		if !ok {
+			continue
+		}
+		hash, err := calculateContentHash(doc.Path)
+		if err != nil || hash == last {
+			continue
+		}
+		changed = append(changed, doc)
+	}
+	return changed
+}
+
+// buildChangedSection returns the section node listing changed documents by
+// their relative path, or nil when there are none.
+func buildChangedSection(changed []Document) *DocTreeNode {
+	if len(changed) == 0 {
+		return nil
+	}
+
+	section := &DocTreeNode{
+		Name:     changedSectionName,
+		Children: make([]*DocTreeNode, 0, len(changed)),
+		Expanded: true,
+		Section:  true,
+	}
+	for i := range changed {
+		doc := &changed[i]
+		section.Children = append(section.Children, &DocTreeNode{
+			Name:    doc.RelPath,
+			Path:    doc.Path,
+			RelPath: doc.RelPath,
+			Doc:     doc,
+		})
+	}
+	sortDocTreeNodes(section.Children)
+	return section
+}
+
 // sortDocTreeNodes sorts nodes in-place: directories before files, then
 // alphabetically within each group. Recurses into directory children.
 func sortDocTreeNodes(nodes []*DocTreeNode) {
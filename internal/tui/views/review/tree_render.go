@@ -62,6 +62,14 @@ func renderDocDirNode(fn DocFlatNode, isSelected bool) string {
 
 	indent := strings.Repeat("  ", fn.Depth)
 
+	if node.Section {
+		label := fmt.Sprintf("%s%s (%d)", folderIcon, node.Name, len(node.Children))
+		if isSelected {
+			return indent + styles.TextPrimaryStyle.Render(label)
+		}
+		return indent + styles.TextWarningStyle.Render(label)
+	}
+
 	var name string
 	if isSelected {
 		name = styles.TextPrimaryStyle.Render(fmt.Sprintf("%s%s", folderIcon, node.Name))
@@ -98,6 +106,9 @@ func renderDocFileNode(fn DocFlatNode, isSelected bool) string {
 	} else {
 		name = styles.TextForegroundStyle.Render(label)
 	}
+	if node.Doc != nil && node.Doc.ReadOnly {
+		name += styles.TextMutedStyle.Render(" (read-only)")
+	}
 
 	if fn.Depth == 0 {
 		return name
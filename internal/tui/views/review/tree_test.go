@@ -255,3 +255,28 @@ func TestFlattenDocTree_NestedDirs(t *testing.T) {
 		}
 	}
 }
+
+// TestBuildChangedSection verifies the changed section lists documents by
+// relative path and is omitted when nothing changed.
+func TestBuildChangedSection(t *testing.T) {
+	if got := buildChangedSection(nil); got != nil {
+		t.Fatalf("expected nil section for no changes, got %+v", got)
+	}
+
+	section := buildChangedSection([]Document{makeDoc("research/b.md"), makeDoc("plans/a.md")})
+	if section == nil {
+		t.Fatal("expected section node")
+	}
+	if !section.Section || !section.Expanded || section.Doc != nil {
+		t.Errorf("expected expanded section without a document, got %+v", section)
+	}
+	if len(section.Children) != 2 {
+		t.Fatalf("expected 2 children, got %d", len(section.Children))
+	}
+	if want := filepath.FromSlash("plans/a.md"); section.Children[0].Name != want {
+		t.Errorf("expected first child %q, got %q", want, section.Children[0].Name)
+	}
+	if section.Children[0].Doc == nil || section.Children[0].Doc.Path != makeDoc("plans/a.md").Path {
+		t.Errorf("expected child to reference its document, got %+v", section.Children[0].Doc)
+	}
+}
@@ -0,0 +1,117 @@
+package review
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/colonyops/hive/internal/core/config"
+)
+
+// vaultPrefix marks the top-level tree folder for documents from an external vault.
+const vaultPrefix = "@"
+
+// DiscoverVaultDocumentsCmd discovers the documents of vaults off the update
+// goroutine and delivers them as a VaultDocumentsMsg. It returns nil when no
+// vaults are configured.
+func DiscoverVaultDocumentsCmd(vaults []config.VaultConfig) tea.Cmd {
+	if len(vaults) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		return VaultDocumentsMsg{Documents: DiscoverVaultDocuments(vaults)}
+	}
+}
+
+// DiscoverVaultDocuments walks the configured folders of each vault and returns
+// their documents. RelPath is "@<vault>/<path within vault>" so vault documents
+// group under their own folder in the tree. Vaults that cannot be read are
+// skipped with a warning rather than failing discovery.
+func DiscoverVaultDocuments(vaults []config.VaultConfig) []Document {
+	var docs []Document
+	for _, vault := range vaults {
+		root := vault.Root()
+		folders := vault.Folders
+		if len(folders) == 0 {
+			folders = []string{"."}
+		}
+
+		seen := make(map[string]bool)
+		for _, folder := range folders {
+			dir := filepath.Join(root, folder)
+			found, err := discoverVaultFolder(vault, root, dir)
+			if err != nil {
+				log.Warn().
+					Err(err).
+					Str("vault", vault.DisplayName()).
+					Str("folder", folder).
+					Msg("review: skipping unreadable vault folder")
+				continue
+			}
+			for _, doc := range found {
+				if !seen[doc.Path] {
+					seen[doc.Path] = true
+					docs = append(docs, doc)
+				}
+			}
+		}
+	}
+
+	sortDocuments(docs)
+	return docs
+}
+
+// discoverVaultFolder returns the markdown and text documents below dir.
+// Hidden directories such as .obsidian and .trash are skipped.
+func discoverVaultFolder(vault config.VaultConfig, root, dir string) ([]Document, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+
+	var docs []Document
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		ext := filepath.Ext(path)
+		if ext != ".md" && ext != ".txt" {
+			return nil
+		}
+
+		vaultRel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		folderRel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		docs = append(docs, Document{
+			Path:     path,
+			RelPath:  filepath.Join(vaultPrefix+vault.DisplayName(), vaultRel),
+			Type:     inferDocumentType(folderRel),
+			ModTime:  info.ModTime(),
+			Vault:    vault.DisplayName(),
+			ReadOnly: vault.ReadOnly(),
+		})
+		return nil
+	})
+	return docs, err
+}
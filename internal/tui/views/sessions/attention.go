@@ -0,0 +1,40 @@
+package sessions
+
+import (
+	"context"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+)
+
+// AttentionFilter is the status filter set by FilterAttention. It is not an
+// agent status: it selects sessions waiting on approval or with unread
+// inbox messages or pending review comments.
+const AttentionFilter terminal.Status = "attention"
+
+// Attention counts the feedback waiting on a session besides its agent status.
+type Attention struct {
+	UnreadMessages int // unread messages in the session's inbox
+	PendingReviews int // review sessions with comments on the session's documents
+}
+
+// Any reports whether there is anything to look at.
+func (a Attention) Any() bool {
+	return a.UnreadMessages > 0 || a.PendingReviews > 0
+}
+
+// AttentionFunc reports Attention for sessions, keyed by session ID. Sessions
+// with nothing pending may be omitted.
+type AttentionFunc func(ctx context.Context, sessions []session.Session) map[string]Attention
+
+// needsAttention reports whether s is kept by the attention filter.
+func (v *View) needsAttention(s session.Session) bool {
+	if v.attention[s.ID].Any() {
+		return true
+	}
+	if v.terminalStatuses == nil {
+		return false
+	}
+	status, ok := v.terminalStatuses.Get(s.ID)
+	return ok && status.Status == terminal.StatusApproval
+}
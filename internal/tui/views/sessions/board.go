@@ -0,0 +1,316 @@
+package sessions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/list"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/styles"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/tui/components"
+	"github.com/colonyops/hive/pkg/kv"
+)
+
+// Board layout: a column title line and a blank line above the cards, and
+// cards of three content lines inside a rounded border.
+const (
+	boardHeaderLines = 2
+	boardCardHeight  = 5
+)
+
+// boardColumn is one status column of the board.
+type boardColumn struct {
+	Status   terminal.Status
+	Title    string
+	Sessions []session.Session
+}
+
+// boardColumnOrder lists the board columns left to right.
+var boardColumnOrder = []boardColumn{
+	{Status: terminal.StatusActive, Title: "Active"},
+	{Status: terminal.StatusApproval, Title: "Waiting Approval"},
+	{Status: terminal.StatusReady, Title: "Ready"},
+	{Status: terminal.StatusMissing, Title: "Missing"},
+}
+
+// buildBoardColumns groups the active sessions among items by terminal
+// status, keeping list order within each column. Sessions without a known
+// status land in Missing.
+func buildBoardColumns(items []list.Item, statuses *kv.Store[string, TerminalStatus]) []boardColumn {
+	cols := make([]boardColumn, len(boardColumnOrder))
+	copy(cols, boardColumnOrder)
+
+	for _, item := range items {
+		ti, ok := item.(TreeItem)
+		if !ok || !ti.IsSession() || ti.Session.State != session.StateActive {
+			continue
+		}
+		status := terminal.StatusMissing
+		if statuses != nil {
+			if ts, ok := statuses.Get(ti.Session.ID); ok && ts.Status != "" {
+				status = ts.Status
+			}
+		}
+		for i := range cols {
+			if cols[i].Status == status {
+				cols[i].Sessions = append(cols[i].Sessions, ti.Session)
+				break
+			}
+		}
+	}
+	return cols
+}
+
+// boardPosition returns the column and row of the session with id, or
+// (-1, -1) when it is not on the board.
+func boardPosition(cols []boardColumn, id string) (int, int) {
+	if id == "" {
+		return -1, -1
+	}
+	for c, col := range cols {
+		for r, sess := range col.Sessions {
+			if sess.ID == id {
+				return c, r
+			}
+		}
+	}
+	return -1, -1
+}
+
+// boardOffset returns the index of the first card drawn in a column of n
+// cards with row selected, so the selected card stays in view.
+func boardOffset(n, row, visible int) int {
+	if visible <= 0 || row < visible {
+		return 0
+	}
+	return min(row-visible+1, max(n-visible, 0))
+}
+
+// SetBoardMode switches between the tree and the status board layout. Both
+// share the session list, so keybindings act on the selected card exactly as
+// they would on the selected tree row.
+func (v *View) SetBoardMode(enabled bool) {
+	v.boardMode = enabled
+	if enabled {
+		v.syncBoardSelection()
+	}
+}
+
+// BoardMode reports whether the status board layout is active.
+func (v *View) BoardMode() bool {
+	return v.boardMode
+}
+
+// boardColumns returns the board for the sessions currently listed.
+func (v *View) boardColumns() []boardColumn {
+	return buildBoardColumns(v.list.VisibleItems(), v.terminalStatuses)
+}
+
+// syncBoardSelection moves the list cursor onto a card: the selected session
+// row when the cursor is on one of its windows or panes, otherwise the first
+// card on the board.
+func (v *View) syncBoardSelection() {
+	cols := v.boardColumns()
+	id := ""
+	if sel := v.SelectedSession(); sel != nil {
+		id = sel.ID
+	}
+	if c, _ := boardPosition(cols, id); c >= 0 {
+		v.selectSessionItem(id)
+		return
+	}
+	for _, col := range cols {
+		if len(col.Sessions) > 0 {
+			v.selectSessionItem(col.Sessions[0].ID)
+			return
+		}
+	}
+}
+
+// boardMove moves the selected card by dCol columns (skipping empty ones) or
+// dRow cards within the current column.
+func (v *View) boardMove(dCol, dRow int) {
+	cols := v.boardColumns()
+	id := ""
+	if sel := v.SelectedSession(); sel != nil {
+		id = sel.ID
+	}
+	c, r := boardPosition(cols, id)
+	if c < 0 {
+		v.syncBoardSelection()
+		return
+	}
+
+	if dRow != 0 {
+		r = max(0, min(r+dRow, len(cols[c].Sessions)-1))
+		v.selectSessionItem(cols[c].Sessions[r].ID)
+		return
+	}
+
+	for next := c + dCol; next >= 0 && next < len(cols); next += dCol {
+		if n := len(cols[next].Sessions); n > 0 {
+			v.selectSessionItem(cols[next].Sessions[min(r, n-1)].ID)
+			return
+		}
+	}
+}
+
+// selectSessionItem selects the session row for id in the list.
+func (v *View) selectSessionItem(id string) {
+	for i, item := range v.list.VisibleItems() {
+		if ti, ok := item.(TreeItem); ok && ti.IsSession() && ti.Session.ID == id {
+			v.list.Select(i)
+			return
+		}
+	}
+}
+
+// boardColumnWidth returns the width of each board column.
+func (v *View) boardColumnWidth() int {
+	return max(v.width/len(boardColumnOrder), 12)
+}
+
+// selectBoardAt selects the card drawn at column x and row contentY.
+func (v *View) selectBoardAt(x, row int) {
+	cols := v.boardColumns()
+	c := x / v.boardColumnWidth()
+	if c < 0 || c >= len(cols) || row < boardHeaderLines {
+		return
+	}
+
+	visible := max((max(v.height-5, 1)-boardHeaderLines)/boardCardHeight, 1)
+	offset := 0
+	id := ""
+	if sel := v.SelectedSession(); sel != nil {
+		id = sel.ID
+	}
+	if selCol, selRow := boardPosition(cols, id); selCol == c {
+		offset = boardOffset(len(cols[c].Sessions), selRow, visible)
+	}
+
+	idx := offset + (row-boardHeaderLines)/boardCardHeight
+	if idx < len(cols[c].Sessions) {
+		v.selectSessionItem(cols[c].Sessions[idx].ID)
+	}
+}
+
+// renderBoard renders the sessions as cards in status columns.
+func (v *View) renderBoard(contentHeight int) string {
+	cols := v.boardColumns()
+	colWidth := v.boardColumnWidth()
+	visible := max((contentHeight-boardHeaderLines)/boardCardHeight, 1)
+
+	selectedID := ""
+	if sel := v.SelectedSession(); sel != nil {
+		selectedID = sel.ID
+	}
+	selCol, selRow := boardPosition(cols, selectedID)
+
+	rendered := make([]string, len(cols))
+	for c, col := range cols {
+		title := v.boardStatusStyle(col.Status).Bold(true).Render(fmt.Sprintf("%s (%d)", col.Title, len(col.Sessions)))
+		lines := []string{" " + title, ""}
+
+		offset := 0
+		if c == selCol {
+			offset = boardOffset(len(col.Sessions), selRow, visible)
+		}
+		end := min(offset+visible, len(col.Sessions))
+		for i := offset; i < end; i++ {
+			lines = append(lines, v.renderBoardCard(col.Sessions[i], col.Status, colWidth-1, col.Sessions[i].ID == selectedID))
+		}
+		if hidden := len(col.Sessions) - (end - offset); hidden > 0 {
+			lines = append(lines, styles.TextMutedStyle.Render(fmt.Sprintf("  +%d more", hidden)))
+		}
+
+		content := ensureExactHeight(strings.Join(lines, "\n"), contentHeight)
+		rendered[c] = ensureExactWidth(content, colWidth)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+}
+
+// renderBoardCard renders one session card: name, repo and branch, and the
+// last non-empty line of the agent pane.
+func (v *View) renderBoardCard(sess session.Session, status terminal.Status, width int, selected bool) string {
+	inner := max(width-4, 4) // border and one column of padding on each side
+
+	name := styles.TextForegroundStyle.Bold(selected).Render(sess.Name)
+	if due, ok := sess.DueAt(); ok && sess.IsOverdue(time.Now()) {
+		name += v.treeDelegate.Styles.Overdue.Render(" " + overdueLabel(due, time.Now()))
+	}
+
+	location := git.ExtractRepoName(sess.Remote)
+	if gs, ok := v.gitStatuses.Get(sess.Path); ok && gs.Branch != "" {
+		location += " · " + gs.Branch
+	}
+
+	lastLine := ""
+	if ts, ok := v.terminalStatuses.Get(sess.ID); ok {
+		lastLine = lastPaneLine(ts.PaneContent)
+		if ts.Stalled != nil {
+			name += v.treeDelegate.Styles.Stalled.Render(" " + stalledLabel(*ts.Stalled, time.Now()))
+		}
+	}
+
+	body := truncateLines(strings.Join([]string{
+		name,
+		styles.TextMutedStyle.Render(location),
+		styles.TextMutedStyle.Render(lastLine),
+	}, "\n"), inner)
+
+	border := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorSurface).
+		Padding(0, 1).
+		Width(width)
+	if selected {
+		border = border.BorderForeground(styles.ColorPrimary)
+	} else if status == terminal.StatusApproval {
+		border = border.BorderForeground(styles.ColorWarning)
+	}
+	return border.Render(body)
+}
+
+// boardStatusStyle returns the column title style for status.
+func (v *View) boardStatusStyle(status terminal.Status) lipgloss.Style {
+	s := v.treeDelegate.Styles
+	switch status {
+	case terminal.StatusActive:
+		return s.StatusActive
+	case terminal.StatusApproval:
+		return s.StatusApproval
+	case terminal.StatusReady:
+		return s.StatusReady
+	default:
+		return s.StatusUnknown
+	}
+}
+
+// boardHelp returns the footer hints for the board layout.
+func boardHelp() string {
+	return components.KeyHints(
+		components.HelpEntry{Key: "h/l", Desc: "column"},
+		components.HelpEntry{Key: "j/k", Desc: "card"},
+		components.HintFilter,
+		components.HelpEntry{Key: "enter", Desc: "select"},
+		components.HintHelp,
+	)
+}
+
+// lastPaneLine returns the last non-blank line of pane content without ANSI
+// escapes.
+func lastPaneLine(content string) string {
+	lines := strings.Split(ansi.Strip(content), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			return line
+		}
+	}
+	return ""
+}
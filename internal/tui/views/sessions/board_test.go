@@ -0,0 +1,80 @@
+package sessions
+
+import (
+	"testing"
+
+	"charm.land/bubbles/v2/list"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/pkg/kv"
+)
+
+func TestBuildBoardColumns(t *testing.T) {
+	active := func(id, name string) TreeItem {
+		return TreeItem{Session: session.Session{ID: id, Name: name, State: session.StateActive}}
+	}
+	items := []list.Item{
+		TreeItem{IsHeader: true, RepoName: "hive"},
+		active("a", "alpha"),
+		TreeItem{IsWindowItem: true, ParentSession: session.Session{ID: "a"}},
+		active("b", "bravo"),
+		active("c", "charlie"),
+		active("d", "delta"),
+		TreeItem{IsRecycledPlaceholder: true},
+		TreeItem{Session: session.Session{ID: "e", Name: "echo", State: session.StateRecycled}},
+	}
+
+	statuses := kv.New[string, TerminalStatus]()
+	statuses.Set("a", TerminalStatus{Status: terminal.StatusReady})
+	statuses.Set("b", TerminalStatus{Status: terminal.StatusApproval})
+	statuses.Set("c", TerminalStatus{Status: terminal.StatusReady})
+
+	cols := buildBoardColumns(items, statuses)
+	require.Len(t, cols, 4)
+
+	got := make(map[terminal.Status][]string)
+	for _, col := range cols {
+		got[col.Status] = sessionNames(col.Sessions)
+	}
+	assert.Empty(t, got[terminal.StatusActive])
+	assert.Equal(t, []string{"bravo"}, got[terminal.StatusApproval])
+	assert.Equal(t, []string{"alpha", "charlie"}, got[terminal.StatusReady])
+	assert.Equal(t, []string{"delta"}, got[terminal.StatusMissing], "sessions without a status are missing")
+
+	assert.Empty(t, boardColumnOrder[2].Sessions, "column order template must not be mutated")
+
+	c, r := boardPosition(cols, "c")
+	assert.Equal(t, 2, c)
+	assert.Equal(t, 1, r)
+	c, r = boardPosition(cols, "e")
+	assert.Equal(t, -1, c)
+	assert.Equal(t, -1, r)
+}
+
+func TestBoardOffset(t *testing.T) {
+	tests := []struct {
+		name            string
+		n, row, visible int
+		want            int
+	}{
+		{name: "fits", n: 3, row: 2, visible: 5, want: 0},
+		{name: "row in first page", n: 10, row: 2, visible: 3, want: 0},
+		{name: "row past first page", n: 10, row: 4, visible: 3, want: 2},
+		{name: "last row", n: 10, row: 9, visible: 3, want: 7},
+		{name: "no room", n: 10, row: 4, visible: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, boardOffset(tt.n, tt.row, tt.visible))
+		})
+	}
+}
+
+func TestLastPaneLine(t *testing.T) {
+	assert.Equal(t, "", lastPaneLine(""))
+	assert.Equal(t, "running tests", lastPaneLine("build ok\n\x1b[32mrunning tests\x1b[0m\n\n   \n"))
+	assert.Equal(t, "done", lastPaneLine("  done  "))
+}
@@ -0,0 +1,241 @@
+package sessions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/styles"
+	"github.com/colonyops/hive/internal/tui/components"
+	"github.com/colonyops/hive/pkg/timeutil"
+)
+
+// ColumnWidths holds the widest cell of each aligned column, keyed by
+// column name.
+type ColumnWidths map[string]int
+
+// CalculateColumnWidths calculates the widest cell of each column across
+// sessions, so rows line up as git and plugin statuses arrive.
+func (d TreeDelegate) CalculateColumnWidths(sessions []session.Session) ColumnWidths {
+	widths := make(ColumnWidths, len(d.Columns))
+	for _, s := range sessions {
+		name := d.Styles.SessionName.Render(s.Name)
+		for _, col := range d.Columns {
+			widths[col.Name] = max(widths[col.Name], lipgloss.Width(d.renderCell(col, s, name)))
+		}
+	}
+	return widths
+}
+
+// renderColumns joins the cells of columns for sess, padding each to its
+// column width. name is the session name, already styled for selection and
+// filter matches. Trailing empty cells are dropped.
+func (d TreeDelegate) renderColumns(columns []config.SessionColumn, sess session.Session, name string) string {
+	cells := make([]string, len(columns))
+	last := -1
+	for i, col := range columns {
+		cells[i] = d.renderCell(col, sess, name)
+		if cells[i] != "" {
+			last = i
+		}
+	}
+
+	var b strings.Builder
+	for i, cell := range cells[:last+1] {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(cell)
+		if i < last && d.ColumnWidths != nil {
+			if pad := (*d.ColumnWidths)[columns[i].Name] - lipgloss.Width(cell); pad > 0 {
+				b.WriteString(components.Pad(pad))
+			}
+		}
+	}
+	return b.String()
+}
+
+// renderCell renders a single column for sess.
+func (d TreeDelegate) renderCell(col config.SessionColumn, sess session.Session, name string) string {
+	if col.IsCustom() {
+		return d.renderCustomCell(col, sess)
+	}
+	if plugin, ok := col.Plugin(); ok {
+		return d.renderPluginCell(plugin, sess.ID)
+	}
+
+	switch col.Name {
+	case config.SessionColumnAgentStatus:
+		var termStatus *TerminalStatus
+		if d.TerminalStatuses != nil {
+			if ts, ok := d.TerminalStatuses.Get(sess.ID); ok {
+				termStatus = &ts
+			}
+		}
+		return renderStatusIndicator(sess.State, termStatus, d.Styles, d.AnimationFrame)
+	case config.SessionColumnName:
+		return name
+	case config.SessionColumnID:
+		id := d.Styles.SessionID.Render("#" + shortSessionID(sess.ID))
+		if sess.NeedsAttention() {
+			id += d.Styles.NeedsAttention.Render(" " + needsAttentionLabel)
+		}
+		if sess.SyncConflict() != "" {
+			id += d.Styles.NeedsAttention.Render(" " + syncConflictLabel)
+		}
+		if due, ok := sess.DueAt(); ok && sess.IsOverdue(time.Now()) {
+			id += d.Styles.Overdue.Render(" " + overdueLabel(due, time.Now()))
+		}
+		if d.TerminalStatuses != nil {
+			if ts, ok := d.TerminalStatuses.Get(sess.ID); ok && ts.Stalled != nil {
+				id += d.Styles.Stalled.Render(" " + stalledLabel(*ts.Stalled, time.Now()))
+			}
+		}
+		return id
+	case config.SessionColumnBranch:
+		return d.renderBranchCell(sess)
+	case config.SessionColumnGit:
+		return d.renderGitCell(sess.Path)
+	case config.SessionColumnAge:
+		return styles.TextMutedStyle.Render(timeutil.Ago(sess.CreatedAt))
+	case config.SessionColumnTag:
+		if len(sess.Tags) == 0 {
+			return ""
+		}
+		return styles.TextMutedStyle.Render("#" + strings.Join(sess.Tags, " #"))
+	case config.SessionColumnAgent:
+		agent := sess.GetMeta(session.MetaAgentProfile)
+		if agent == "" {
+			return ""
+		}
+		return styles.TextMutedStyle.Render(agent)
+	case config.SessionColumnCost:
+		return d.renderPluginCell(PluginClaude, sess.ID)
+	default:
+		return ""
+	}
+}
+
+// renderBranchCell returns the session's branch, e.g. "(main)". Until its
+// git status arrives, the branch hive checked out for the session is shown.
+func (d TreeDelegate) renderBranchCell(sess session.Session) string {
+	branch := sess.Branch()
+	if status, ok := d.gitStatus(sess.Path); ok {
+		branch = status.Branch
+	}
+	if branch == "" {
+		return ""
+	}
+	if d.IconsEnabled {
+		return d.Styles.SessionBranch.Render("(" + styles.IconGitBranch + " " + branch + ")")
+	}
+	return d.Styles.SessionBranch.Render("(" + branch + ")")
+}
+
+// renderGitCell returns the session's diff stats and dirty state, or a
+// loading placeholder until its git status arrives.
+func (d TreeDelegate) renderGitCell(path string) string {
+	if d.GitStatuses == nil {
+		return styles.TextMutedStyle.Render("...")
+	}
+	status, ok := d.GitStatuses.Get(path)
+	if !ok || status.IsLoading {
+		return styles.TextMutedStyle.Render("...")
+	}
+	if status.Error != nil {
+		return ""
+	}
+
+	cell := styles.TextSuccessStyle.Render(fmt.Sprintf("+%d", status.Additions)) +
+		" " + styles.TextErrorStyle.Render(fmt.Sprintf("-%d", status.Deletions))
+	if label := aheadBehindLabel(status); label != "" {
+		cell += " " + styles.TextPrimaryStyle.Render(label)
+	}
+
+	if d.IconsEnabled {
+		// With icons: show yellow git icon for uncommitted, nothing for clean
+		if status.HasChanges {
+			cell += styles.TextWarningStyle.Render(" " + styles.IconGit)
+		}
+		return cell
+	}
+
+	// Without icons: show text indicator
+	if status.HasChanges {
+		return cell + styles.TextWarningStyle.Render(" • uncommitted")
+	}
+	return cell + styles.TextMutedStyle.Render(" • clean")
+}
+
+// gitStatus returns the loaded git status for path.
+func (d TreeDelegate) gitStatus(path string) (GitStatus, bool) {
+	if d.GitStatuses == nil {
+		return GitStatus{}, false
+	}
+	status, ok := d.GitStatuses.Get(path)
+	if !ok || status.IsLoading || status.Error != nil {
+		return GitStatus{}, false
+	}
+	return status, true
+}
+
+// renderPluginCell returns a plugin's status for a session.
+func (d TreeDelegate) renderPluginCell(plugin, sessionID string) string {
+	store, ok := d.PluginStatuses[plugin]
+	if !ok || store == nil {
+		return ""
+	}
+	status, ok := store.Get(sessionID)
+	if !ok || status.Label == "" {
+		return ""
+	}
+
+	icon := status.Icon
+	if d.IconsEnabled && plugin == PluginGitHub {
+		icon = styles.IconGithub
+	}
+	return icon + lipgloss.NewStyle().Foreground(styles.ColorMuted).Render(status.Label)
+}
+
+// renderCustomCell renders a custom column's template on a single line.
+// Render errors are logged and leave the cell empty.
+func (d TreeDelegate) renderCustomCell(col config.SessionColumn, sess session.Session) string {
+	if d.Renderer == nil {
+		return ""
+	}
+
+	var branch string
+	if status, ok := d.gitStatus(sess.Path); ok {
+		branch = status.Branch
+	}
+	out, err := d.Renderer.Render(col.Template, map[string]any{
+		"ID":        sess.ID,
+		"Name":      sess.Name,
+		"Path":      sess.Path,
+		"Remote":    sess.Remote,
+		"State":     string(sess.State),
+		"Tags":      sess.Tags,
+		"Group":     sess.GetMeta(session.MetaGroup),
+		"Agent":     sess.GetMeta(session.MetaAgentProfile),
+		"Branch":    branch,
+		"CreatedAt": sess.CreatedAt,
+	})
+	if err != nil {
+		log.Debug().Err(err).Str("column", col.Name).Msg("render custom column")
+		return ""
+	}
+	return styles.TextMutedStyle.Render(strings.Join(strings.Fields(out), " "))
+}
+
+// shortSessionID returns the last four characters of a session ID.
+func shortSessionID(id string) string {
+	if len(id) > 4 {
+		return id[len(id)-4:]
+	}
+	return id
+}
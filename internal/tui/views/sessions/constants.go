@@ -1,4 +1,7 @@
 package sessions
 
 // Plugin name constants used for status display ordering and icon mapping.
-const PluginGitHub = "github"
+const (
+	PluginGitHub = "github"
+	PluginClaude = "claude"
+)
@@ -2,6 +2,8 @@ package sessions
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,12 +16,17 @@ const gitStatusTimeout = 5 * time.Second
 
 // GitStatus holds the git status information for a session.
 type GitStatus struct {
-	Branch     string
-	Additions  int
-	Deletions  int
-	HasChanges bool
-	IsLoading  bool
-	Error      error
+	Branch       string
+	Additions    int
+	Deletions    int
+	HasChanges   bool
+	HasUpstream  bool      // the branch tracks an upstream branch
+	Ahead        int       // commits not yet pushed to the upstream
+	Behind       int       // upstream commits not yet pulled
+	LastCommit   string    // subject of the most recent commit
+	LastCommitAt time.Time // committer date of the most recent commit
+	IsLoading    bool
+	Error        error
 }
 
 // GitStatusBatchCompleteMsg is sent when all git status fetches complete.
@@ -28,7 +35,7 @@ type GitStatusBatchCompleteMsg struct {
 }
 
 // fetchGitStatusForPath fetches git status for a single path.
-func fetchGitStatusForPath(ctx context.Context, g git.Git, path string) GitStatus {
+func fetchGitStatusForPath(ctx context.Context, g git.VCS, path string) GitStatus {
 	status := GitStatus{}
 
 	// Get branch name
@@ -59,13 +66,42 @@ func fetchGitStatusForPath(ctx context.Context, g git.Git, path string) GitStatu
 	}
 	status.HasChanges = !isClean
 
+	// The upstream and last commit only add detail; failing to read them
+	// leaves the rest of the status intact.
+	head, err := g.HeadStatus(ctx, path)
+	if err != nil {
+		log.Debug().Err(err).Str("path", path).Msg("git head status lookup failed")
+		return status
+	}
+	status.HasUpstream = head.HasUpstream
+	status.Ahead = head.Ahead
+	status.Behind = head.Behind
+	status.LastCommit = head.Subject
+	status.LastCommitAt = head.CommittedAt
+
 	return status
 }
 
-// FetchGitStatusBatch returns a command that fetches git status for multiple paths
-// using a bounded worker pool.
-func FetchGitStatusBatch(g git.Git, paths []string, workers int) tea.Cmd {
-	if len(paths) == 0 {
+// aheadBehindLabel returns the commits the branch is ahead of and behind its
+// upstream, e.g. "↑2 ↓1", or "" when it has no upstream or is in sync.
+func aheadBehindLabel(status GitStatus) string {
+	if !status.HasUpstream {
+		return ""
+	}
+	var parts []string
+	if status.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d", status.Ahead))
+	}
+	if status.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d", status.Behind))
+	}
+	return strings.Join(parts, " ")
+}
+
+// FetchGitStatusBatch returns a command that fetches status for multiple paths,
+// each with its session's version control backend, using a bounded worker pool.
+func FetchGitStatusBatch(repos map[string]git.VCS, workers int) tea.Cmd {
+	if len(repos) == 0 {
 		return nil
 	}
 
@@ -77,9 +113,9 @@ func FetchGitStatusBatch(g git.Git, paths []string, workers int) tea.Cmd {
 		sem := make(chan struct{}, workers)
 		var wg sync.WaitGroup
 
-		for _, path := range paths {
+		for path, g := range repos {
 			wg.Add(1)
-			go func(p string) {
+			go func(p string, g git.VCS) {
 				defer wg.Done()
 
 				// Acquire semaphore
@@ -94,7 +130,7 @@ func FetchGitStatusBatch(g git.Git, paths []string, workers int) tea.Cmd {
 				mu.Lock()
 				results[p] = status
 				mu.Unlock()
-			}(path)
+			}(path, g)
 		}
 
 		wg.Wait()
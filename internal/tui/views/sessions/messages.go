@@ -69,8 +69,9 @@ func ErrorCmd(err error) tea.Cmd {
 
 // sessionsLoadedMsg is sent when sessions are loaded from the store.
 type sessionsLoadedMsg struct {
-	sessions []session.Session
-	err      error
+	sessions  []session.Session
+	attention map[string]Attention
+	err       error
 }
 
 // reposDiscoveredMsg is sent when repository scanning completes.
@@ -0,0 +1,94 @@
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/colonyops/hive/internal/core/eventbus"
+	"github.com/colonyops/hive/internal/core/notify"
+	"github.com/colonyops/hive/internal/core/session"
+)
+
+// overdueArchivedMsg is sent when overdue sessions have been auto-archived.
+type overdueArchivedMsg struct {
+	sessions []session.Session
+	err      error
+}
+
+// observeOverdue publishes a session.overdue event for each session that has
+// become overdue since the last load. When due.archive_after is set, it
+// returns a command archiving sessions that are overdue by more than that;
+// each session is only tried once, so sessions left for having unsaved work
+// are not checked on every refresh.
+func (v *View) observeOverdue(now time.Time) tea.Cmd {
+	if v.showArchived {
+		return nil
+	}
+
+	var grace time.Duration
+	if v.cfg != nil {
+		grace = v.cfg.Due.ArchiveAfter
+	}
+
+	overdue := make(map[string]bool)
+	archive := false
+	for _, sess := range v.allSessions {
+		if !sess.IsOverdue(now) {
+			continue
+		}
+		overdue[sess.ID] = true
+		if !v.overdueSeen[sess.ID] && v.bus != nil {
+			due, _ := sess.DueAt()
+			v.bus.PublishSessionOverdue(eventbus.SessionOverduePayload{Session: &sess, DueAt: due})
+		}
+		if grace > 0 && sess.IsOverdue(now.Add(-grace)) && !v.archiveTried[sess.ID] {
+			if v.archiveTried == nil {
+				v.archiveTried = make(map[string]bool)
+			}
+			v.archiveTried[sess.ID] = true
+			archive = true
+		}
+	}
+	v.overdueSeen = overdue
+
+	if !archive || v.service == nil {
+		return nil
+	}
+	svc := v.service
+	return func() tea.Msg {
+		archived, err := svc.ArchiveOverdue(context.Background(), now)
+		return overdueArchivedMsg{sessions: archived, err: err}
+	}
+}
+
+func (v *View) handleOverdueArchived(msg overdueArchivedMsg) tea.Cmd {
+	if msg.err != nil {
+		return ErrorCmd(fmt.Errorf("archive overdue sessions: %w", msg.err))
+	}
+	if len(msg.sessions) == 0 {
+		return nil
+	}
+	if v.bus != nil {
+		v.bus.PublishNotificationPublished(eventbus.NotificationPublishedPayload{
+			Level:   notify.LevelInfo,
+			Message: fmt.Sprintf("archived %d overdue session(s)", len(msg.sessions)),
+		})
+	}
+	return v.loadSessions()
+}
+
+// overdueLabel returns the badge for an overdue session, e.g. "overdue 2d".
+func overdueLabel(due, now time.Time) string {
+	d := now.Sub(due)
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("overdue %dd", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("overdue %dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("overdue %dm", int(d.Minutes()))
+	}
+}
@@ -0,0 +1,77 @@
+package sessions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/eventbus"
+	"github.com/colonyops/hive/internal/core/eventbus/testbus"
+	"github.com/colonyops/hive/internal/core/session"
+)
+
+func TestObserveOverdue(t *testing.T) {
+	tb := testbus.New(t)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	overdue := newSess("a", "alpha")
+	overdue.State = session.StateActive
+	overdue.SetDueAt(now.Add(-2 * time.Hour))
+	later := newSess("b", "bravo")
+	later.State = session.StateActive
+	later.SetDueAt(now.Add(time.Hour))
+
+	v := &View{
+		allSessions: []session.Session{overdue, later},
+		bus:         tb.EventBus,
+		cfg:         &config.Config{},
+	}
+
+	countOverdue := func() int {
+		n := 0
+		for _, e := range tb.Events() {
+			if e.Event == eventbus.EventSessionOverdue {
+				n++
+			}
+		}
+		return n
+	}
+
+	assert.Nil(t, v.observeOverdue(now), "nothing is archived without due.archive_after")
+	p := testbus.FindPayload[eventbus.SessionOverduePayload](tb, t, eventbus.EventSessionOverdue)
+	require.NotNil(t, p.Session)
+	assert.Equal(t, "alpha", p.Session.Name)
+
+	v.observeOverdue(now.Add(time.Minute))
+	assert.Equal(t, 1, countOverdue(), "a session is reported once while it stays overdue")
+
+	v.observeOverdue(now.Add(2 * time.Hour))
+	assert.Equal(t, 2, countOverdue(), "bravo becomes overdue")
+}
+
+func TestObserveOverdue_ArchivesOncePerSession(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	sess := newSess("a", "alpha")
+	sess.State = session.StateActive
+	sess.SetDueAt(now.Add(-48 * time.Hour))
+
+	v := &View{
+		allSessions: []session.Session{sess},
+		cfg:         &config.Config{Due: config.DueConfig{ArchiveAfter: 72 * time.Hour}},
+	}
+	v.observeOverdue(now)
+	assert.Empty(t, v.archiveTried, "within the grace period")
+
+	v.observeOverdue(now.Add(25 * time.Hour))
+	assert.True(t, v.archiveTried["a"])
+}
+
+func TestOverdueLabel(t *testing.T) {
+	due := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, "overdue 5m", overdueLabel(due, due.Add(5*time.Minute)))
+	assert.Equal(t, "overdue 3h", overdueLabel(due, due.Add(3*time.Hour)))
+	assert.Equal(t, "overdue 2d", overdueLabel(due, due.Add(50*time.Hour)))
+}
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"strings"
 	"text/template"
+	"time"
+
+	"github.com/colonyops/hive/internal/hive/plugins/github"
 )
 
 // PreviewIcons holds nerd font icons for templates.
@@ -18,15 +21,25 @@ type PreviewIcons struct {
 
 // PreviewGitData holds git status data for templates.
 type PreviewGitData struct {
-	Branch     string
-	Additions  int
-	Deletions  int
-	HasChanges bool
+	Branch        string
+	Additions     int
+	Deletions     int
+	HasChanges    bool
+	HasUpstream   bool // the branch tracks an upstream branch
+	Ahead         int  // commits not yet pushed to the upstream
+	Behind        int  // upstream commits not yet pulled
+	LastCommit    string
+	LastCommitAt  time.Time
+	LastCommitAge string // e.g. "3h ago"
 }
 
 // PreviewPluginData holds plugin status data for templates.
 type PreviewPluginData struct {
-	Github string // e.g., "open", "draft", "merged"
+	Github string // e.g., "open", "approved ✓ 5 / ✗ 1", "merged"
+	// GithubPR is the pull request behind Github, nil without one. Its
+	// fields include Number, URL, State, ReviewDecision, ChecksPassed,
+	// ChecksFailed, and ChecksPending.
+	GithubPR *github.PullRequestStatus
 }
 
 // PreviewTemplateData holds all data available to preview templates.
@@ -0,0 +1,116 @@
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/colonyops/hive/internal/core/config"
+	corekv "github.com/colonyops/hive/internal/core/kv"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/pkg/kv"
+)
+
+// kvSortKey is the kv store key for persisting the sessions sort mode.
+const kvSortKey = "tui.sessions.sort"
+
+// nextSortMode returns the sort mode after mode in config.ValidSessionSorts.
+func nextSortMode(mode string) string {
+	idx := slices.Index(config.ValidSessionSorts, mode)
+	return config.ValidSessionSorts[(idx+1)%len(config.ValidSessionSorts)]
+}
+
+// restoreSortMode returns the sort mode persisted in store, or fallback when
+// none is saved, it cannot be read or the saved value is no longer valid.
+func restoreSortMode(store corekv.KV, fallback string) string {
+	if fallback == "" {
+		fallback = config.SessionSortName
+	}
+	if store == nil {
+		return fallback
+	}
+	var saved string
+	if err := store.Get(context.Background(), kvSortKey, &saved); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Warn().Err(err).Msg("failed to restore sessions sort mode")
+		}
+		return fallback
+	}
+	if !slices.Contains(config.ValidSessionSorts, saved) {
+		return fallback
+	}
+	return saved
+}
+
+// saveSortMode persists the sort mode to store.
+func saveSortMode(store corekv.KV, mode string) {
+	if store == nil {
+		return
+	}
+	if err := store.Set(context.Background(), kvSortKey, mode); err != nil {
+		log.Debug().Err(err).Msg("failed to persist sessions sort mode")
+	}
+}
+
+// SortGroupSessions orders the active sessions of each group by mode. Ties,
+// and sessions with no terminal status, fall back to alphabetical order.
+func SortGroupSessions(groups []RepoGroup, mode string, statuses *kv.Store[string, TerminalStatus]) {
+	if mode == "" || mode == config.SessionSortName {
+		return
+	}
+	for i := range groups {
+		sortSessionsBy(groups[i].Sessions, mode, statuses)
+	}
+}
+
+// sortSessionsBy sorts sessions by mode, breaking ties by name.
+func sortSessionsBy(sessions []session.Session, mode string, statuses *kv.Store[string, TerminalStatus]) {
+	status := func(id string) TerminalStatus {
+		if statuses == nil {
+			return TerminalStatus{}
+		}
+		ts, _ := statuses.Get(id)
+		return ts
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		a, b := sessions[i], sessions[j]
+		switch mode {
+		case config.SessionSortActivity:
+			aAct, bAct := status(a.ID).Activity, status(b.ID).Activity
+			if !aAct.Equal(bAct) {
+				return aAct.After(bAct)
+			}
+		case config.SessionSortAge:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+		case config.SessionSortStatus:
+			aRank, bRank := sortStatusRank(status(a.ID).Status), sortStatusRank(status(b.ID).Status)
+			if aRank != bRank {
+				return aRank > bRank
+			}
+		}
+		return a.Name < b.Name
+	})
+}
+
+// sortStatusRank ranks agent statuses for the status sort mode: sessions
+// waiting on approval first, then working agents, then idle ones.
+func sortStatusRank(status terminal.Status) int {
+	switch status {
+	case terminal.StatusApproval:
+		return 3
+	case terminal.StatusActive:
+		return 2
+	case terminal.StatusReady:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,91 @@
+package sessions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/internal/data/stores"
+	"github.com/colonyops/hive/pkg/kv"
+)
+
+func sessionNames(sessions []session.Session) []string {
+	names := make([]string, len(sessions))
+	for i, s := range sessions {
+		names[i] = s.Name
+	}
+	return names
+}
+
+func TestSortGroupSessions(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	sessions := []session.Session{
+		{ID: "a", Name: "alpha", CreatedAt: base},
+		{ID: "b", Name: "bravo", CreatedAt: base.Add(2 * time.Hour)},
+		{ID: "c", Name: "charlie", CreatedAt: base.Add(time.Hour)},
+		{ID: "d", Name: "delta", CreatedAt: base.Add(time.Hour)},
+	}
+
+	statuses := kv.New[string, TerminalStatus]()
+	statuses.Set("a", TerminalStatus{Status: terminal.StatusReady, Activity: base.Add(3 * time.Hour)})
+	statuses.Set("b", TerminalStatus{Status: terminal.StatusActive, Activity: base.Add(time.Hour)})
+	statuses.Set("c", TerminalStatus{Status: terminal.StatusApproval, Activity: base.Add(2 * time.Hour)})
+
+	tests := []struct {
+		mode string
+		want []string
+	}{
+		{mode: config.SessionSortName, want: []string{"alpha", "bravo", "charlie", "delta"}},
+		{mode: config.SessionSortActivity, want: []string{"alpha", "charlie", "bravo", "delta"}},
+		{mode: config.SessionSortAge, want: []string{"bravo", "charlie", "delta", "alpha"}},
+		{mode: config.SessionSortStatus, want: []string{"charlie", "bravo", "alpha", "delta"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			groups := []RepoGroup{{Name: "hive", Sessions: append([]session.Session(nil), sessions...)}}
+			SortGroupSessions(groups, tt.mode, statuses)
+			assert.Equal(t, tt.want, sessionNames(groups[0].Sessions))
+		})
+	}
+}
+
+func TestSortGroupSessions_NilStatuses(t *testing.T) {
+	groups := []RepoGroup{{Sessions: []session.Session{{ID: "2", Name: "b"}, {ID: "1", Name: "a"}}}}
+	SortGroupSessions(groups, config.SessionSortStatus, nil)
+	assert.Equal(t, []string{"a", "b"}, sessionNames(groups[0].Sessions))
+}
+
+func TestNextSortMode(t *testing.T) {
+	mode := config.SessionSortName
+	seen := make([]string, 0, len(config.ValidSessionSorts))
+	for range config.ValidSessionSorts {
+		mode = nextSortMode(mode)
+		seen = append(seen, mode)
+	}
+	assert.Equal(t, []string{config.SessionSortActivity, config.SessionSortAge, config.SessionSortStatus, config.SessionSortName}, seen)
+	assert.Equal(t, config.ValidSessionSorts[0], nextSortMode("bogus"))
+}
+
+func TestSortModePersistence(t *testing.T) {
+	database, err := db.Open(t.TempDir(), db.DefaultOpenOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+	store := stores.NewKVStore(database)
+
+	assert.Equal(t, config.SessionSortAge, restoreSortMode(store, config.SessionSortAge), "config value used when nothing is saved")
+	assert.Equal(t, config.SessionSortName, restoreSortMode(nil, ""))
+
+	saveSortMode(store, config.SessionSortStatus)
+	assert.Equal(t, config.SessionSortStatus, restoreSortMode(store, config.SessionSortAge), "saved value wins over config")
+
+	require.NoError(t, store.Set(context.Background(), kvSortKey, "bogus"))
+	assert.Equal(t, config.SessionSortAge, restoreSortMode(store, config.SessionSortAge))
+}
@@ -9,12 +9,21 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"github.com/rs/zerolog/log"
 
+	corekv "github.com/colonyops/hive/internal/core/kv"
 	"github.com/colonyops/hive/internal/core/session"
 	"github.com/colonyops/hive/internal/core/terminal"
 	terminaltmux "github.com/colonyops/hive/internal/core/terminal/tmux"
+	"github.com/colonyops/hive/internal/core/watchdog"
+	"github.com/colonyops/hive/internal/hive/supervisor"
 )
 
-const terminalStatusTimeout = 2 * time.Second
+const (
+	terminalStatusTimeout = 2 * time.Second
+
+	// terminalPollStaleAfter is how long a poll may run before the poller
+	// is reported as wedged.
+	terminalPollStaleAfter = time.Minute
+)
 
 // PaneStatus holds per-pane terminal status for agent panes.
 type PaneStatus struct {
@@ -43,7 +52,9 @@ type TerminalStatus struct {
 	PaneContent string
 	IsLoading   bool
 	Error       error
-	Windows     []WindowStatus // per-window statuses (populated only for multi-window sessions)
+	Windows     []WindowStatus  // per-window statuses (populated only for multi-window sessions)
+	Activity    time.Time       // most recent activity across the session's agent panes
+	Stalled     *watchdog.Alert // set while the watchdog considers the session stalled
 }
 
 // TerminalStatusBatchCompleteMsg is sent when all terminal status fetches complete.
@@ -54,13 +65,25 @@ type TerminalStatusBatchCompleteMsg struct {
 // TerminalPollTickMsg triggers a terminal status poll cycle.
 type TerminalPollTickMsg struct{}
 
-// FetchTerminalStatusBatch returns a command that fetches terminal status for multiple sessions.
-func FetchTerminalStatusBatch(mgr *terminal.Manager, sessions []*session.Session, workers int) tea.Cmd {
+// FetchTerminalStatusBatch returns a command that fetches terminal status for
+// multiple sessions. ctx carries the poller's supervisor heartbeat (see
+// supervisor.Track); a panic while fetching is recovered and reported there
+// instead of crashing the TUI.
+func FetchTerminalStatusBatch(ctx context.Context, mgr *terminal.Manager, sessions []*session.Session, workers int) tea.Cmd {
 	if len(sessions) == 0 || !mgr.HasEnabledIntegrations() {
 		return nil
 	}
 
-	return func() tea.Msg {
+	return func() (msg tea.Msg) {
+		done := supervisor.Busy(ctx)
+		defer done()
+		defer func() {
+			if p := recover(); p != nil {
+				_ = supervisor.Panicked(ctx, p)
+				msg = nil
+			}
+		}()
+
 		// Refresh integration caches once before fetching statuses
 		mgr.RefreshAll()
 
@@ -83,10 +106,7 @@ func FetchTerminalStatusBatch(mgr *terminal.Manager, sessions []*session.Session
 				sem <- struct{}{}
 				defer func() { <-sem }()
 
-				ctx, cancel := context.WithTimeout(context.Background(), terminalStatusTimeout)
-				defer cancel()
-
-				status := fetchTerminalStatusForSession(ctx, mgr, s)
+				status := safeFetchTerminalStatus(ctx, mgr, s)
 
 				mu.Lock()
 				results[s.ID] = status
@@ -99,6 +119,45 @@ func FetchTerminalStatusBatch(mgr *terminal.Manager, sessions []*session.Session
 	}
 }
 
+// saveStatusSnapshot returns a command that stores the poll results as a
+// terminal.StatusSnapshot for `hive status`. It returns nil without a store.
+func saveStatusSnapshot(store corekv.KV, results map[string]TerminalStatus, ttl time.Duration) tea.Cmd {
+	if store == nil {
+		return nil
+	}
+
+	snapshot := terminal.StatusSnapshot{
+		UpdatedAt: time.Now(),
+		Statuses:  make(map[string]terminal.Status, len(results)),
+	}
+	for id, status := range results {
+		snapshot.Statuses[id] = status.Status
+	}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), terminalStatusTimeout)
+		defer cancel()
+		if err := store.SetTTL(ctx, terminal.StatusSnapshotKey, snapshot, ttl); err != nil {
+			log.Debug().Err(err).Msg("failed to save terminal status snapshot")
+		}
+		return nil
+	}
+}
+
+// safeFetchTerminalStatus fetches terminal status for a single session,
+// reporting a panic as the status error.
+func safeFetchTerminalStatus(ctx context.Context, mgr *terminal.Manager, sess *session.Session) (status TerminalStatus) {
+	defer func() {
+		if p := recover(); p != nil {
+			status = TerminalStatus{Status: terminal.StatusMissing, Error: supervisor.Panicked(ctx, p)}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, terminalStatusTimeout)
+	defer cancel()
+	return fetchTerminalStatusForSession(ctx, mgr, sess)
+}
+
 // fetchTerminalStatusForSession fetches terminal status for a single session.
 func fetchTerminalStatusForSession(ctx context.Context, mgr *terminal.Manager, sess *session.Session) TerminalStatus {
 	status := TerminalStatus{
@@ -137,6 +196,7 @@ func fetchTerminalStatusForSession(ctx context.Context, mgr *terminal.Manager, s
 	status.Tool = info.DetectedTool
 	status.WindowName = info.WindowName
 	status.PaneContent = info.PaneContent
+	status.Activity = info.Activity
 
 	// Discover all panes/windows if the integration supports it.
 	var allInfos []*terminal.SessionInfo
@@ -148,6 +208,11 @@ func fetchTerminalStatusForSession(ctx context.Context, mgr *terminal.Manager, s
 		if discErr != nil {
 			log.Debug().Err(discErr).Str("session", sess.Slug).Msg("multi-window discovery failed, using single-window mode")
 		} else if len(allInfos) > 0 {
+			for _, wi := range allInfos {
+				if wi.Activity.After(status.Activity) {
+					status.Activity = wi.Activity
+				}
+			}
 			windows := groupPaneStatuses(ctx, integration, sess.Slug, allInfos)
 			if shouldExposeWindows(windows) {
 				status.Windows = windows
@@ -4,7 +4,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/colonyops/hive/internal/core/session"
 	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/hive/supervisor"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -71,3 +74,39 @@ func (f *fakeTerminalIntegration) DiscoverSession(context.Context, string, map[s
 func (f *fakeTerminalIntegration) GetStatus(_ context.Context, info *terminal.SessionInfo) (terminal.Status, error) {
 	return f.statuses[info.PaneID], nil
 }
+
+// panickingTerminalIntegration panics while discovering session "bad".
+type panickingTerminalIntegration struct {
+	fakeTerminalIntegration
+}
+
+func (f *panickingTerminalIntegration) DiscoverSession(_ context.Context, slug string, _ map[string]string) (*terminal.SessionInfo, error) {
+	if slug == "bad" {
+		panic("nil pane")
+	}
+	return nil, nil
+}
+
+func TestFetchTerminalStatusBatch_RecoversPanic(t *testing.T) {
+	mgr := terminal.NewManager([]string{"fake"})
+	mgr.Register(&panickingTerminalIntegration{})
+	workers := supervisor.New(zerolog.Nop())
+	ctx := workers.Track(context.Background(), "terminal.poller", supervisor.Options{})
+
+	cmd := FetchTerminalStatusBatch(ctx, mgr, []*session.Session{
+		{ID: "1", Slug: "bad", State: session.StateActive},
+		{ID: "2", Slug: "good", State: session.StateActive},
+	}, 2)
+	require.NotNil(t, cmd)
+
+	msg, ok := cmd().(TerminalStatusBatchCompleteMsg)
+	require.True(t, ok)
+	require.Error(t, msg.Results["1"].Error)
+	assert.Equal(t, terminal.StatusMissing, msg.Results["1"].Status)
+	require.NoError(t, msg.Results["2"].Error)
+
+	health := workers.Health()
+	require.Len(t, health, 1)
+	assert.Equal(t, 1, health[0].Restarts)
+	assert.Equal(t, "panic: nil pane", health[0].LastError)
+}
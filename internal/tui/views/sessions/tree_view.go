@@ -9,12 +9,13 @@ import (
 	"charm.land/bubbles/v2/list"
 	tea "charm.land/bubbletea/v2"
 	lipgloss "charm.land/lipgloss/v2"
+	"github.com/colonyops/hive/internal/core/config"
 	"github.com/colonyops/hive/internal/core/session"
 	"github.com/colonyops/hive/internal/core/styles"
 	"github.com/colonyops/hive/internal/core/terminal"
 	"github.com/colonyops/hive/internal/hive/plugins"
-	"github.com/colonyops/hive/internal/tui/components"
 	"github.com/colonyops/hive/pkg/kv"
+	"github.com/colonyops/hive/pkg/tmpl"
 )
 
 // Tree characters for rendering the session tree.
@@ -23,6 +24,14 @@ const (
 	treeLast   = "└─"
 )
 
+// previewColumns are shown instead of the configured columns while the
+// preview pane is open.
+var previewColumns = []config.SessionColumn{
+	{Name: config.SessionColumnAgentStatus},
+	{Name: config.SessionColumnName},
+	{Name: config.SessionColumnID},
+}
+
 // Animation constants.
 const (
 	// AnimationFrameCount is the total number of frames in the fade animation.
@@ -51,6 +60,12 @@ func generatePulseColors(base color.Color, frames int, minBrightness float64) []
 	return colors
 }
 
+// needsAttentionLabel marks sessions whose last recycle failed.
+const needsAttentionLabel = "! recycle failed"
+
+// syncConflictLabel marks sessions whose last sync failed.
+const syncConflictLabel = "! sync conflict"
+
 // Star indicator for current repository.
 const currentRepoIndicator = "◆"
 
@@ -221,6 +236,9 @@ type TreeDelegateStyles struct {
 	StatusReady    lipgloss.Style
 	StatusUnknown  lipgloss.Style
 	StatusRecycled lipgloss.Style
+	NeedsAttention lipgloss.Style
+	Stalled        lipgloss.Style
+	Overdue        lipgloss.Style
 
 	// Selection styles
 	Selected       lipgloss.Style
@@ -245,6 +263,9 @@ func DefaultTreeDelegateStyles() TreeDelegateStyles {
 		StatusReady:    lipgloss.NewStyle().Foreground(styles.ColorSecondary),
 		StatusUnknown:  lipgloss.NewStyle().Foreground(styles.ColorMuted).Faint(true),
 		StatusRecycled: lipgloss.NewStyle().Foreground(styles.ColorMuted),
+		NeedsAttention: lipgloss.NewStyle().Foreground(styles.ColorError),
+		Stalled:        lipgloss.NewStyle().Foreground(styles.ColorWarning),
+		Overdue:        lipgloss.NewStyle().Foreground(styles.ColorWarning),
 
 		Selected:       lipgloss.NewStyle().Foreground(styles.ColorPrimary).Bold(true),
 		SelectedBorder: lipgloss.NewStyle().Foreground(styles.ColorPrimary),
@@ -253,39 +274,6 @@ func DefaultTreeDelegateStyles() TreeDelegateStyles {
 	}
 }
 
-// ColumnWidths holds the calculated widths for aligned columns.
-type ColumnWidths struct {
-	Name   int
-	Branch int
-	ID     int
-}
-
-// CalculateColumnWidths calculates the maximum widths for each column within a repo group.
-func CalculateColumnWidths(sessions []session.Session, gitBranches map[string]string) ColumnWidths {
-	var widths ColumnWidths
-
-	for _, s := range sessions {
-		if len(s.Name) > widths.Name {
-			widths.Name = len(s.Name)
-		}
-
-		branch := gitBranches[s.Path]
-		if len(branch) > widths.Branch {
-			widths.Branch = len(branch)
-		}
-
-		shortID := s.ID
-		if len(shortID) > 4 {
-			shortID = shortID[len(shortID)-4:]
-		}
-		if len(shortID) > widths.ID {
-			widths.ID = len(shortID)
-		}
-	}
-
-	return widths
-}
-
 // TreeDelegate handles rendering of tree items in the list.
 type TreeDelegate struct {
 	Styles           TreeDelegateStyles
@@ -293,9 +281,11 @@ type TreeDelegate struct {
 	TerminalStatuses *kv.Store[string, TerminalStatus]
 	PluginStatuses   map[string]*kv.Store[string, plugins.Status] // plugin name -> session ID -> status
 	ColumnWidths     *ColumnWidths
-	AnimationFrame   int  // Current frame for status animations
-	PreviewMode      bool // When true, show minimal info (session names only)
-	IconsEnabled     bool // When true, show nerd font icons
+	Columns          []config.SessionColumn // ordered row columns
+	Renderer         *tmpl.Renderer         // renders custom columns
+	AnimationFrame   int                    // Current frame for status animations
+	PreviewMode      bool                   // When true, show minimal info (session names only)
+	IconsEnabled     bool                   // When true, show nerd font icons
 }
 
 // NewTreeDelegate creates a new tree delegate with default styles.
@@ -405,17 +395,6 @@ func (d TreeDelegate) renderSession(item TreeItem, isSelected bool, m list.Model
 	}
 	prefixStyled := d.Styles.TreeLine.Render(prefix)
 
-	// Get terminal status if available
-	var termStatus *TerminalStatus
-	if d.TerminalStatuses != nil {
-		if ts, ok := d.TerminalStatuses.Get(item.Session.ID); ok {
-			termStatus = &ts
-		}
-	}
-
-	// Status indicator - use terminal status for active sessions
-	statusStr := renderStatusIndicator(item.Session.State, termStatus, d.Styles, d.AnimationFrame)
-
 	// Session name with filter matching
 	nameStyle := d.Styles.SessionName
 	matchStyle := d.Styles.FilterMatch
@@ -435,32 +414,13 @@ func (d TreeDelegate) renderSession(item TreeItem, isSelected bool, m list.Model
 	nameOffset := len([]rune(item.RepoPrefix)) + 1
 	name := d.renderWithMatches(item.Session.Name, nameOffset, matchSet, nameStyle, matchStyle)
 
-	// Pad name to align columns (add spaces after styled name)
-	namePadding := ""
-	if d.ColumnWidths != nil && d.ColumnWidths.Name > 0 {
-		padLen := d.ColumnWidths.Name - len(item.Session.Name)
-		if padLen > 0 {
-			namePadding = components.Pad(padLen)
-		}
-	}
-
-	// Short ID (always show)
-	shortID := item.Session.ID
-	if len(shortID) > 4 {
-		shortID = shortID[len(shortID)-4:]
-	}
-	id := d.Styles.SessionID.Render(" #" + shortID)
-
 	// In preview mode, show minimal info (status + name + ID only)
+	columns := d.Columns
 	if d.PreviewMode {
-		return fmt.Sprintf("%s %s %s%s", prefixStyled, statusStr, name, id)
+		columns = previewColumns
 	}
 
-	// Full mode: show ID, git status, and plugin statuses
-	gitInfo := d.renderGitStatus(item.Session.Path)
-	pluginInfo := d.renderPluginStatuses(item.Session.ID)
-
-	return fmt.Sprintf("%s %s %s%s%s%s%s", prefixStyled, statusStr, name, namePadding, id, gitInfo, pluginInfo)
+	return prefixStyled + " " + d.renderColumns(columns, item.Session, name)
 }
 
 // renderPane renders a pane sub-item nested under a window.
@@ -573,95 +533,6 @@ func (d TreeDelegate) renderWindow(item TreeItem, isSelected bool) string {
 	return fmt.Sprintf("%s %s %s%s", prefixStyled, statusStr, name, indexStr)
 }
 
-// renderGitStatus returns the formatted git status for a session path.
-func (d TreeDelegate) renderGitStatus(path string) string {
-	if d.GitStatuses == nil {
-		return styles.TextMutedStyle.Render(" ...")
-	}
-
-	status, ok := d.GitStatuses.Get(path)
-	if !ok || status.IsLoading {
-		return styles.TextMutedStyle.Render(" ...")
-	}
-
-	if status.Error != nil {
-		return ""
-	}
-
-	// Format with icons: ( branch) +N -N [dirty icon]
-	// Format without icons: (branch) +N -N • clean/dirty
-	var branch string
-	if d.IconsEnabled {
-		branch = d.Styles.SessionBranch.Render(" (" + styles.IconGitBranch + " " + status.Branch + ")")
-	} else {
-		branch = d.Styles.SessionBranch.Render(" (" + status.Branch + ")")
-	}
-	additions := styles.TextSuccessStyle.Render(fmt.Sprintf(" +%d", status.Additions))
-	deletions := styles.TextErrorStyle.Render(fmt.Sprintf(" -%d", status.Deletions))
-
-	var indicator string
-	if d.IconsEnabled {
-		// With icons: show yellow git icon for uncommitted, nothing for clean
-		if status.HasChanges {
-			indicator = styles.TextWarningStyle.Render(" " + styles.IconGit)
-		}
-	} else {
-		// Without icons: show text indicator
-		if status.HasChanges {
-			indicator = styles.TextWarningStyle.Render(" • uncommitted")
-		} else {
-			indicator = styles.TextMutedStyle.Render(" • clean")
-		}
-	}
-
-	return branch + additions + deletions + indicator
-}
-
-// renderPluginStatuses returns formatted plugin status indicators for a session.
-func (d TreeDelegate) renderPluginStatuses(sessionID string) string {
-	if len(d.PluginStatuses) == 0 {
-		return ""
-	}
-
-	// Neutral gray style for all plugin text
-	neutralStyle := lipgloss.NewStyle().Foreground(styles.ColorMuted)
-
-	var parts []string
-	pluginOrder := []string{PluginGitHub}
-	for _, name := range pluginOrder {
-		store, ok := d.PluginStatuses[name]
-		if !ok || store == nil {
-			continue
-		}
-		status, ok := store.Get(sessionID)
-		if !ok || status.Label == "" {
-			continue
-		}
-
-		icon := status.Icon
-		if d.IconsEnabled && name == PluginGitHub {
-			icon = styles.IconGithub
-		}
-
-		parts = append(parts, icon+neutralStyle.Render(status.Label))
-	}
-
-	if len(parts) == 0 {
-		return ""
-	}
-
-	// Join all parts with bullet separators
-	result := ""
-	for i, part := range parts {
-		if i == 0 {
-			result = " " + neutralStyle.Render("•") + " " + part
-		} else {
-			result += " " + neutralStyle.Render("•") + " " + part
-		}
-	}
-	return result
-}
-
 // renderWithMatches renders text with underlined characters at matched positions.
 func (d TreeDelegate) renderWithMatches(text string, offset int, matchSet map[int]bool, baseStyle, matchStyle lipgloss.Style) string {
 	if len(matchSet) == 0 {
@@ -3,7 +3,13 @@ package sessions
 import (
 	"testing"
 
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/colonyops/hive/internal/core/config"
 	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/hive/plugins"
+	"github.com/colonyops/hive/pkg/kv"
+	"github.com/colonyops/hive/pkg/tmpl"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -172,6 +178,15 @@ func TestTreeItem_FilterValue(t *testing.T) {
 	}
 }
 
+func newColumnsDelegate(columns ...config.SessionColumn) TreeDelegate {
+	d := NewTreeDelegate()
+	d.GitStatuses = kv.New[string, GitStatus]()
+	d.PluginStatuses = map[string]*kv.Store[string, plugins.Status]{PluginGitHub: kv.New[string, plugins.Status]()}
+	d.Columns = columns
+	d.Renderer = tmpl.New(tmpl.Config{})
+	return d
+}
+
 func TestCalculateColumnWidths(t *testing.T) {
 	sessions := []session.Session{
 		{ID: "abcd1234", Name: "short", Path: "/path1"},
@@ -179,15 +194,51 @@ func TestCalculateColumnWidths(t *testing.T) {
 		{ID: "ijkl9012", Name: "medium", Path: "/path3"},
 	}
 
-	gitBranches := map[string]string{
-		"/path1": "main",
-		"/path2": "feature/very-long-branch-name",
-		"/path3": "develop",
+	d := newColumnsDelegate(
+		config.SessionColumn{Name: config.SessionColumnName},
+		config.SessionColumn{Name: config.SessionColumnID},
+		config.SessionColumn{Name: config.SessionColumnBranch},
+		config.SessionColumn{Name: "plugin:github"},
+		config.SessionColumn{Name: "slug", Template: "{{ join .Tags \",\" }}{{ .Name }}!"},
+	)
+	d.GitStatuses.SetBatch(map[string]GitStatus{
+		"/path1": {Branch: "main"},
+		"/path2": {Branch: "feature/very-long-branch-name"},
+		"/path3": {IsLoading: true},
+	})
+
+	widths := d.CalculateColumnWidths(sessions)
+
+	assert.Equal(t, len("much-longer-name"), widths[config.SessionColumnName])
+	assert.Equal(t, len("(feature/very-long-branch-name)"), widths[config.SessionColumnBranch])
+	assert.Equal(t, 5, widths[config.SessionColumnID]) // "#" + last 4 ID chars
+	assert.Zero(t, widths["plugin:github"], "no plugin statuses loaded yet")
+	assert.Equal(t, len("much-longer-name!"), widths["slug"])
+
+	d.PluginStatuses[PluginGitHub].Set("abcd1234", plugins.Status{Icon: "PR", Label: "open"})
+	assert.Equal(t, len("PRopen"), d.CalculateColumnWidths(sessions)["plugin:github"], "widths follow status updates")
+}
+
+func TestRenderColumns(t *testing.T) {
+	d := newColumnsDelegate(
+		config.SessionColumn{Name: config.SessionColumnName},
+		config.SessionColumn{Name: config.SessionColumnTag},
+		config.SessionColumn{Name: config.SessionColumnBranch},
+	)
+	sessions := []session.Session{
+		{ID: "a", Name: "alpha", Path: "/a", Tags: []string{"x"}},
+		{ID: "b", Name: "be", Path: "/b"},
 	}
+	d.GitStatuses.Set("/a", GitStatus{Branch: "main"})
+	widths := d.CalculateColumnWidths(sessions)
+	d.ColumnWidths = &widths
 
-	widths := CalculateColumnWidths(sessions, gitBranches)
+	render := func(s session.Session) string {
+		return ansi.Strip(d.renderColumns(d.Columns, s, s.Name))
+	}
+	assert.Equal(t, "alpha #x (main)", render(sessions[0]))
+	assert.Equal(t, "be", render(sessions[1]), "trailing empty cells are dropped")
 
-	assert.Equal(t, len("much-longer-name"), widths.Name)
-	assert.Equal(t, len("feature/very-long-branch-name"), widths.Branch)
-	assert.Equal(t, 4, widths.ID) // All IDs are truncated to 4 chars
+	d.GitStatuses.Set("/b", GitStatus{Branch: "dev"})
+	assert.Equal(t, "be       (dev)", render(sessions[1]), "empty cells are padded to keep columns aligned")
 }
@@ -17,15 +17,22 @@ import (
 	act "github.com/colonyops/hive/internal/core/action"
 	"github.com/colonyops/hive/internal/core/config"
 	"github.com/colonyops/hive/internal/core/eventbus"
+	"github.com/colonyops/hive/internal/core/git"
+	corekv "github.com/colonyops/hive/internal/core/kv"
 	"github.com/colonyops/hive/internal/core/session"
 	"github.com/colonyops/hive/internal/core/styles"
 	"github.com/colonyops/hive/internal/core/terminal"
 	"github.com/colonyops/hive/internal/core/tmux"
+	"github.com/colonyops/hive/internal/core/watchdog"
 	"github.com/colonyops/hive/internal/core/workspace"
 	"github.com/colonyops/hive/internal/hive"
 	"github.com/colonyops/hive/internal/hive/plugins"
+	"github.com/colonyops/hive/internal/hive/plugins/github"
+	"github.com/colonyops/hive/internal/hive/supervisor"
 	"github.com/colonyops/hive/internal/tui/components"
+	"github.com/colonyops/hive/internal/tui/components/input"
 	"github.com/colonyops/hive/pkg/kv"
+	"github.com/colonyops/hive/pkg/timeutil"
 	"github.com/colonyops/hive/pkg/tmpl"
 )
 
@@ -50,13 +57,22 @@ type ViewOpts struct {
 	Workspaces  []string
 	Renderer    *tmpl.Renderer
 	Bus         *eventbus.EventBus
+	StatusCache corekv.KV              // receives a terminal.StatusSnapshot after each poll
+	Preferences corekv.KV              // persists the sort mode across restarts
+	Workers     *supervisor.Supervisor // reports terminal poller health
+	Attention   AttentionFunc          // feeds the FilterAttention filter
 }
 
 // View is the Bubble Tea sub-model for the sessions tab.
 type View struct {
 	allSessions  []session.Session
 	statusFilter terminal.Status
+	attention    map[string]Attention // by session ID, refreshed with the sessions
+	attentionFn  AttentionFunc
+	tagFilter    string // show only sessions with this tag; empty shows all
+	showArchived bool   // list archived sessions instead of live ones
 	groupBy      string // "repo" or "group", runtime-togglable
+	sortMode     string // one of config.ValidSessionSorts, runtime-cyclable
 	localRemote  string
 
 	cfg     *config.Config
@@ -68,6 +84,7 @@ type View struct {
 	treeDelegate TreeDelegate
 	handler      KeyResolver
 	columnWidths *ColumnWidths
+	treeSessions []session.Session // sessions currently listed, for column widths
 
 	// Git integration
 	gitStatuses *kv.Store[string, GitStatus]
@@ -76,6 +93,9 @@ type View struct {
 	// Terminal integration
 	terminalManager    *terminal.Manager
 	terminalStatuses   *kv.Store[string, TerminalStatus]
+	pollCtx            context.Context // carries the poller's supervisor heartbeat
+	statusCache        corekv.KV
+	prefs              corekv.KV
 	previewEnabled     bool
 	previewTemplates   *PreviewTemplates
 	currentTmuxSession string
@@ -92,7 +112,7 @@ type View struct {
 	// Focus mode filtering
 	focusMode        bool
 	focusFilter      string
-	focusFilterInput textinput.Model
+	focusFilterInput input.Line
 
 	// Repository discovery
 	workspaces      []string
@@ -108,6 +128,16 @@ type View struct {
 	// Pending selection — overrides saveSelection on next applyFilter.
 	pendingSelectID string
 
+	// Status board layout (board tab) instead of the tree.
+	boardMode bool
+
+	// Stalled session detection, nil when watchdog.enabled is off.
+	watchdog *watchdog.Tracker
+
+	// Overdue sessions already reported, and those auto-archive was tried on.
+	overdueSeen  map[string]bool
+	archiveTried map[string]bool
+
 	// Template rendering
 	renderer *tmpl.Renderer
 }
@@ -138,6 +168,8 @@ func New(opts ViewOpts) *View {
 	delegate.ColumnWidths = columnWidths
 	delegate.PluginStatuses = pluginStatuses
 	delegate.IconsEnabled = cfg.TUI.IconsEnabled()
+	delegate.Columns = cfg.TUI.Sessions.ColumnsOrDefault()
+	delegate.Renderer = opts.Renderer
 
 	l := list.New([]list.Item{}, delegate, 0, 0)
 	l.SetShowStatusBar(false)
@@ -153,7 +185,7 @@ func New(opts ViewOpts) *View {
 
 	l.SetShowHelp(false)
 
-	focusInput := textinput.New()
+	focusInput := input.NewLine(input.LoadHistory(opts.Preferences, kvFilterHistoryKey, 0))
 	focusInput.Prompt = "/"
 	focusInputStyles := textinput.DefaultStyles(true)
 	focusInputStyles.Focused.Prompt = styles.ListFilterPromptStyle
@@ -173,9 +205,16 @@ func New(opts ViewOpts) *View {
 		pluginPollInterval = cfg.Plugins.GitHub.ResultsCache
 	}
 
+	var tracker *watchdog.Tracker
+	if cfg.Watchdog.Enabled {
+		tracker = watchdog.NewTracker(cfg.Watchdog.Approval, cfg.Watchdog.Idle)
+	}
+
 	return &View{
 		localRemote: opts.LocalRemote,
 		groupBy:     cfg.Views.Sessions.GroupBy,
+		sortMode:    restoreSortMode(opts.Preferences, cfg.TUI.Sessions.Sort),
+		prefs:       opts.Preferences,
 		cfg:         cfg,
 		service:     opts.Service,
 		bus:         opts.Bus,
@@ -190,6 +229,8 @@ func New(opts ViewOpts) *View {
 
 		terminalManager:    opts.TerminalManager,
 		terminalStatuses:   terminalStatuses,
+		pollCtx:            opts.Workers.Track(context.Background(), "terminal.poller", supervisor.Options{StaleAfter: terminalPollStaleAfter}),
+		statusCache:        opts.StatusCache,
 		previewEnabled:     cfg.Views.Sessions.PreviewEnabled,
 		previewTemplates:   previewTemplates,
 		currentTmuxSession: currentTmux,
@@ -201,7 +242,9 @@ func New(opts ViewOpts) *View {
 		workspaces: opts.Workspaces,
 
 		focusFilterInput: focusInput,
+		watchdog:         tracker,
 		renderer:         opts.Renderer,
+		attentionFn:      opts.Attention,
 	}
 }
 
@@ -238,6 +281,8 @@ func (v *View) Update(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
 	case sessionsLoadedMsg:
 		return v.handleSessionsLoaded(msg)
+	case overdueArchivedMsg:
+		return v.handleOverdueArchived(msg)
 	case GitStatusBatchCompleteMsg:
 		return v.handleGitStatusComplete(msg)
 	case TerminalStatusBatchCompleteMsg:
@@ -275,7 +320,8 @@ func (v *View) handleSessionsLoaded(msg sessionsLoadedMsg) tea.Cmd {
 		return ErrorCmd(fmt.Errorf("failed to load sessions: %w", msg.err))
 	}
 	v.allSessions = msg.sessions
-	cmds := []tea.Cmd{v.applyFilter()}
+	v.attention = msg.attention
+	cmds := []tea.Cmd{v.applyFilter(), v.observeOverdue(time.Now())}
 	if len(v.pluginStatuses) > 0 {
 		sessions := make([]*session.Session, len(v.allSessions))
 		for i := range v.allSessions {
@@ -291,13 +337,14 @@ func (v *View) handleSessionsLoaded(msg sessionsLoadedMsg) tea.Cmd {
 		for i := range v.allSessions {
 			sessPtrs[i] = &v.allSessions[i]
 		}
-		cmds = append(cmds, FetchTerminalStatusBatch(v.terminalManager, sessPtrs, v.gitWorkers))
+		cmds = append(cmds, FetchTerminalStatusBatch(v.pollCtx, v.terminalManager, sessPtrs, v.gitWorkers))
 	}
 	return tea.Batch(cmds...)
 }
 
 func (v *View) handleGitStatusComplete(msg GitStatusBatchCompleteMsg) tea.Cmd {
 	v.gitStatuses.SetBatch(msg.Results)
+	v.updateColumnWidths()
 	v.refreshing = false
 	return nil
 }
@@ -331,10 +378,11 @@ func (v *View) handleTerminalStatusComplete(msg TerminalStatusBatchCompleteMsg)
 			}
 		}
 
+		v.observeWatchdog(msg.Results, time.Now())
 		v.terminalStatuses.SetBatch(msg.Results)
 		v.rebuildWindowItems()
 	}
-	return nil
+	return saveStatusSnapshot(v.statusCache, msg.Results, terminal.StatusSnapshotTTL(v.cfg.Tmux.PollInterval))
 }
 
 func (v *View) handleTerminalPollTick() tea.Cmd {
@@ -344,7 +392,7 @@ func (v *View) handleTerminalPollTick() tea.Cmd {
 	for i := range allSess {
 		sessPtrs[i] = &v.allSessions[i]
 	}
-	cmds = append(cmds, FetchTerminalStatusBatch(v.terminalManager, sessPtrs, v.gitWorkers))
+	cmds = append(cmds, FetchTerminalStatusBatch(v.pollCtx, v.terminalManager, sessPtrs, v.gitWorkers))
 	if v.terminalManager.HasEnabledIntegrations() {
 		cmds = append(cmds, StartTerminalPollTicker(v.cfg.Tmux.PollInterval))
 	}
@@ -373,10 +421,17 @@ func (v *View) handlePluginStatusUpdate(msg pluginStatusUpdateMsg) tea.Cmd {
 			Msg("plugin status updated")
 	}
 	v.treeDelegate.PluginStatuses = v.pluginStatuses
+	v.updateColumnWidths()
 	v.list.SetDelegate(v.treeDelegate)
 	return listenForPluginResult(v.pluginResultsChan)
 }
 
+// updateColumnWidths realigns tree columns to the cells of the listed
+// sessions, which change as git and plugin statuses arrive.
+func (v *View) updateColumnWidths() {
+	*v.columnWidths = v.treeDelegate.CalculateColumnWidths(v.treeSessions)
+}
+
 func (v *View) handleReposDiscovered(msg reposDiscoveredMsg) tea.Cmd {
 	v.discoveredRepos = msg.repos
 	if msg.err != nil {
@@ -433,6 +488,29 @@ func (v *View) handleKey(msg tea.KeyPressMsg) (*View, tea.Cmd) {
 		v.navigateToNextActive(-1)
 		return v, nil
 	}
+	// Board column moves do nothing in the tree
+	if v.handler.IsAction(keyStr, act.TypeBoardColumnPrev) {
+		if v.boardMode {
+			v.boardMove(-1, 0)
+		}
+		return v, nil
+	}
+	if v.handler.IsAction(keyStr, act.TypeBoardColumnNext) {
+		if v.boardMode {
+			v.boardMove(1, 0)
+		}
+		return v, nil
+	}
+	if v.boardMode {
+		switch {
+		case v.handler.IsAction(keyStr, act.TypeSessionsNavigateUp):
+			v.boardMove(0, -1)
+			return v, nil
+		case v.handler.IsAction(keyStr, act.TypeSessionsNavigateDown):
+			v.boardMove(0, 1)
+			return v, nil
+		}
+	}
 	if v.handler.IsAction(keyStr, act.TypeSessionsNavigateUp) {
 		v.navigateSkippingPlaceholders(-1)
 		return v, nil
@@ -449,27 +527,7 @@ func (v *View) handleKey(msg tea.KeyPressMsg) (*View, tea.Cmd) {
 		return v, nil
 	}
 	if v.handler.IsAction(keyStr, act.TypeSessionsFilterStart) {
-		v.focusMode = true
-		v.focusFilter = ""
-		v.focusFilterInput.Reset()
-		v.focusFilterInput.SetValue("")
-
-		// Tab chrome (3) + rule + help bar (2)
-		contentHeight := v.height - 5
-		if contentHeight < 2 {
-			contentHeight = 2
-		}
-
-		var listWidth int
-		if v.previewEnabled && v.width >= 80 {
-			listWidth = int(float64(v.width) * 0.25)
-		} else {
-			listWidth = v.width
-		}
-
-		v.list.SetSize(listWidth, contentHeight-1) // Make room for search input
-
-		return v, v.focusFilterInput.Focus()
+		return v, v.startFocusMode("")
 	}
 	if v.handler.IsAction(keyStr, act.TypeSessionsCommandPaletteOpen) {
 		sess := v.SelectedSession()
@@ -542,7 +600,12 @@ func (v *View) handleFilterKey(msg tea.KeyPressMsg, keyStr string) (*View, tea.C
 		v.stopFocusMode()
 		return v, nil
 	case "enter":
+		value := v.focusFilterInput.Value()
+		v.focusFilterInput.Commit()
 		v.stopFocusMode()
+		if tag, ok := strings.CutPrefix(value, tagFilterPrefix); ok {
+			return v, v.SetTagFilter(strings.TrimSpace(tag))
+		}
 		return v, nil
 	default:
 		var cmd tea.Cmd
@@ -683,10 +746,28 @@ func (v *View) applyFilter() tea.Cmd {
 
 	allSess := v.allSessions
 	filteredSess := allSess
-	statusFilter := v.statusFilter
-	if statusFilter != "" && v.terminalStatuses != nil {
+	if v.tagFilter != "" {
 		filtered := make([]session.Session, 0, len(allSess))
 		for _, s := range allSess {
+			if s.HasTag(v.tagFilter) {
+				filtered = append(filtered, s)
+			}
+		}
+		filteredSess = filtered
+	}
+
+	statusFilter := v.statusFilter
+	if statusFilter == AttentionFilter {
+		filtered := make([]session.Session, 0, len(allSess))
+		for _, s := range filteredSess {
+			if v.needsAttention(s) {
+				filtered = append(filtered, s)
+			}
+		}
+		filteredSess = filtered
+	} else if statusFilter != "" && v.terminalStatuses != nil {
+		filtered := make([]session.Session, 0, len(allSess))
+		for _, s := range filteredSess {
 			if status, ok := v.terminalStatuses.Get(s.ID); ok {
 				if status.Status == statusFilter {
 					filtered = append(filtered, s)
@@ -704,15 +785,21 @@ func (v *View) applyFilter() tea.Cmd {
 	} else {
 		groups = GroupSessionsByRepo(filteredSess, localRemote)
 	}
+	SortGroupSessions(groups, v.sortMode, v.terminalStatuses)
 	items := BuildTreeItems(groups, localRemote)
 	items = v.expandWindowItems(items)
-	*v.columnWidths = CalculateColumnWidths(filteredSess, nil)
+	v.treeSessions = filteredSess
+	v.updateColumnWidths()
 
 	// Collect paths for git status fetching (use filtered sessions)
-	// During background refresh, keep existing statuses to avoid flashing
-	paths := make([]string, 0, len(filteredSess))
+	// During background refresh, keep existing statuses to avoid flashing.
+	// Archived sessions have no checkout to inspect.
+	repos := make(map[string]git.VCS, len(filteredSess))
 	for _, s := range filteredSess {
-		paths = append(paths, s.Path)
+		if s.State == session.StateArchived {
+			continue
+		}
+		repos[s.Path] = v.service.VCS(&s)
 		if !v.refreshing {
 			v.gitStatuses.Set(s.Path, GitStatus{IsLoading: true})
 		}
@@ -721,12 +808,12 @@ func (v *View) applyFilter() tea.Cmd {
 	v.list.SetItems(items)
 	v.restoreSelection(sel)
 
-	if len(paths) == 0 {
+	if len(repos) == 0 {
 		v.refreshing = false
 		return nil
 	}
 	// refreshing is cleared when GitStatusBatchCompleteMsg is received
-	return FetchGitStatusBatch(v.service.Git(), paths, v.gitWorkers)
+	return FetchGitStatusBatch(repos, v.gitWorkers)
 }
 
 // rebuildWindowItems strips existing window sub-items from the list and re-expands
@@ -846,6 +933,39 @@ func (v *View) expandWindowItems(items []list.Item) []list.Item {
 
 // --- Focus mode ---
 
+// kvFilterHistoryKey is the kv store key for the focus filter history.
+const kvFilterHistoryKey = "tui.history.filter"
+
+// tagFilterPrefix turns a focus filter into a tag filter: entering
+// "tag:backend" narrows the tree to sessions tagged backend.
+const tagFilterPrefix = "tag:"
+
+// startFocusMode activates focus mode filtering with value pre-filled.
+func (v *View) startFocusMode(value string) tea.Cmd {
+	v.focusMode = true
+	v.focusFilter = ""
+	v.focusFilterInput.Reset()
+	v.focusFilterInput.SetValue(value)
+	v.focusFilterInput.CursorEnd()
+
+	// Tab chrome (3) + rule + help bar (2)
+	contentHeight := v.height - 5
+	if contentHeight < 2 {
+		contentHeight = 2
+	}
+
+	var listWidth int
+	if v.previewEnabled && v.width >= 80 {
+		listWidth = int(float64(v.width) * 0.25)
+	} else {
+		listWidth = v.width
+	}
+
+	v.list.SetSize(listWidth, contentHeight-1) // Make room for search input
+
+	return v.focusFilterInput.Focus()
+}
+
 // stopFocusMode deactivates focus mode filtering.
 func (v *View) stopFocusMode() {
 	v.focusMode = false
@@ -870,8 +990,8 @@ func (v *View) stopFocusMode() {
 // updateFocusFilter updates the filter and navigates to first match.
 func (v *View) updateFocusFilter(filter string) {
 	v.focusFilter = filter
-	if filter == "" {
-		return // no navigation with empty filter
+	if filter == "" || strings.HasPrefix(filter, tagFilterPrefix) {
+		return // no navigation with empty filter; tag filters apply on enter
 	}
 
 	filterLower := strings.ToLower(filter)
@@ -898,6 +1018,15 @@ func (v *View) View() string {
 	// Calculate content height: total - tab chrome (3) - rule + help bar (2)
 	contentHeight := max(v.height-5, 1)
 
+	if v.boardMode {
+		body := v.renderBoard(contentHeight)
+		if v.focusMode {
+			body = ensureExactHeight(body, contentHeight-1) + "\n" + v.focusFilterInput.View()
+		}
+		bar := components.StatusBar{Width: v.width}
+		return body + "\n" + bar.Rule() + "\n" + bar.Render(boardHelp(), "")
+	}
+
 	var body string
 	if v.previewEnabled && v.width >= 80 {
 		body = v.renderDualColumnLayout(contentHeight)
@@ -1057,9 +1186,16 @@ func (v *View) renderPreviewHeader(sess *session.Session, maxWidth int) string {
 			if iconsEnabled {
 				gitPart += branchStyle.Render(styles.IconGitBranch + " ")
 			}
-			gitPart += branchStyle.Render(status.Branch + ")")
+			branch := status.Branch
+			if base := sess.GetMeta(session.MetaBaseBranch); base != "" && branch == sess.Branch() {
+				branch += " from " + base
+			}
+			gitPart += branchStyle.Render(branch + ")")
 			gitPart += " " + addStyle.Render("+"+fmt.Sprintf("%d", status.Additions))
 			gitPart += " " + delStyle.Render("-"+fmt.Sprintf("%d", status.Deletions))
+			if label := aheadBehindLabel(status); label != "" {
+				gitPart += " " + branchStyle.Render(label)
+			}
 			if status.HasChanges && iconsEnabled {
 				gitPart += " " + dirtyStyle.Render(styles.IconGit)
 			}
@@ -1069,7 +1205,7 @@ func (v *View) renderPreviewHeader(sess *session.Session, maxWidth int) string {
 
 	// Plugin statuses (neutral color)
 	if v.pluginStatuses != nil {
-		pluginOrder := []string{PluginGitHub}
+		pluginOrder := []string{PluginGitHub, PluginClaude}
 		for _, name := range pluginOrder {
 			store, ok := v.pluginStatuses[name]
 			if !ok || store == nil {
@@ -1092,6 +1228,17 @@ func (v *View) renderPreviewHeader(sess *session.Session, maxWidth int) string {
 
 	status := strings.Join(statusParts, separatorStyle.Render(" • "))
 
+	// Configured preview templates replace the built-in title and status.
+	if v.cfg.Views.Sessions.PreviewTitle != "" || v.cfg.Views.Sessions.PreviewStatus != "" {
+		data := v.previewTemplateData(sess)
+		if v.cfg.Views.Sessions.PreviewTitle != "" {
+			title = nameStyle.Render(v.previewTemplates.RenderTitle(data))
+		}
+		if v.cfg.Views.Sessions.PreviewStatus != "" {
+			status = separatorStyle.Render(v.previewTemplates.RenderStatus(data))
+		}
+	}
+
 	// Build header
 	var parts []string
 	parts = append(parts, title)
@@ -1099,6 +1246,28 @@ func (v *View) renderPreviewHeader(sess *session.Session, maxWidth int) string {
 	if status != "" {
 		parts = append(parts, status)
 	}
+	if line := v.lastCommitLine(sess); line != "" {
+		parts = append(parts, styles.TextMutedStyle.Render(ansi.Truncate(line, maxWidth, "…")))
+	}
+	if sess.NeedsAttention() {
+		recycleErr, _, _ := strings.Cut(sess.GetMeta(session.MetaRecycleError), "\n")
+		parts = append(parts, styles.TextErrorStyle.Render(ansi.Truncate("Recycle failed: "+recycleErr, maxWidth, "…")))
+		parts = append(parts, styles.TextMutedStyle.Render(ansi.Truncate("Recycle to retry • RecycleShell to inspect • RecycleReset to discard changes", maxWidth, "…")))
+	}
+	if syncErr := sess.SyncConflict(); syncErr != "" {
+		syncErr, _, _ = strings.Cut(syncErr, "\n")
+		parts = append(parts, styles.TextErrorStyle.Render(ansi.Truncate("Sync failed: "+syncErr, maxWidth, "…")))
+		parts = append(parts, styles.TextMutedStyle.Render(ansi.Truncate("Resolve the conflict in the session, then Sync again", maxWidth, "…")))
+	}
+	if caps, ok := sess.Capabilities(); ok {
+		parts = append(parts, separatorStyle.Render(ansi.Truncate(capabilitiesLine(caps), maxWidth, "…")))
+	}
+	if sess.State == session.StateArchived {
+		parts = append(parts, styles.TextMutedStyle.Render(ansi.Truncate(archiveSummaryLine(sess.ArchiveSummary()), maxWidth, "…")))
+	}
+	for _, line := range notesPreviewLines(sess.Notes) {
+		parts = append(parts, styles.TextWarningStyle.Render(ansi.Truncate(line, maxWidth, "…")))
+	}
 	parts = append(parts, "")
 	parts = append(parts, styles.TextMutedStyle.Render("Output"))
 	parts = append(parts, dividerStyle.Render(divider))
@@ -1106,6 +1275,140 @@ func (v *View) renderPreviewHeader(sess *session.Session, maxWidth int) string {
 	return strings.Join(parts, "\n")
 }
 
+// lastCommitLine describes the most recent commit in sess, or returns ""
+// until its git status arrives.
+func (v *View) lastCommitLine(sess *session.Session) string {
+	if v.gitStatuses == nil {
+		return ""
+	}
+	status, ok := v.gitStatuses.Get(sess.Path)
+	if !ok || status.IsLoading || status.Error != nil || status.LastCommit == "" {
+		return ""
+	}
+	line := "Last commit: " + status.LastCommit
+	if !status.LastCommitAt.IsZero() {
+		line += " • " + timeutil.Ago(status.LastCommitAt)
+	}
+	return line
+}
+
+// previewTemplateData collects the data available to the preview templates
+// for sess.
+func (v *View) previewTemplateData(sess *session.Session) PreviewTemplateData {
+	shortID := sess.ID
+	if len(shortID) > 4 {
+		shortID = shortID[len(shortID)-4:]
+	}
+	data := PreviewTemplateData{
+		Name:    sess.Name,
+		ID:      sess.ID,
+		ShortID: shortID,
+		Path:    sess.Path,
+	}
+	if v.cfg.TUI.IconsEnabled() {
+		data.Icon = PreviewIcons{
+			Git:       styles.IconGit,
+			GitBranch: styles.IconGitBranch,
+			Github:    styles.IconGithub,
+			CheckList: styles.IconCheckList,
+			Bee:       styles.IconBee,
+			Hive:      styles.IconHive,
+		}
+	}
+	if v.gitStatuses != nil {
+		if gs, ok := v.gitStatuses.Get(sess.Path); ok && !gs.IsLoading && gs.Error == nil {
+			data.Branch = gs.Branch
+			data.GitStatus = PreviewGitData{
+				Branch:       gs.Branch,
+				Additions:    gs.Additions,
+				Deletions:    gs.Deletions,
+				HasChanges:   gs.HasChanges,
+				HasUpstream:  gs.HasUpstream,
+				Ahead:        gs.Ahead,
+				Behind:       gs.Behind,
+				LastCommit:   gs.LastCommit,
+				LastCommitAt: gs.LastCommitAt,
+			}
+			if !gs.LastCommitAt.IsZero() {
+				data.GitStatus.LastCommitAge = timeutil.Ago(gs.LastCommitAt)
+			}
+		}
+	}
+	if store, ok := v.pluginStatuses[PluginGitHub]; ok && store != nil {
+		if status, ok := store.Get(sess.ID); ok {
+			data.Plugin.Github = status.Label
+			if pr, ok := status.Data.(github.PullRequestStatus); ok {
+				data.Plugin.GithubPR = &pr
+			}
+		}
+	}
+	if v.terminalStatuses != nil {
+		if ts, ok := v.terminalStatuses.Get(sess.ID); ok {
+			data.TerminalStatus = string(ts.Status)
+		}
+	}
+	return data
+}
+
+// maxPreviewNotesLines is the number of note lines shown in the preview header.
+const maxPreviewNotesLines = 3
+
+// notesPreviewLines returns the session notes as preview header lines, the
+// first prefixed with "Notes:" and the last marked when lines were cut.
+func notesPreviewLines(notes string) []string {
+	notes = strings.TrimSpace(notes)
+	if notes == "" {
+		return nil
+	}
+	lines := strings.Split(notes, "\n")
+	if len(lines) > maxPreviewNotesLines {
+		lines = lines[:maxPreviewNotesLines]
+		lines[maxPreviewNotesLines-1] += " …"
+	}
+	lines[0] = "Notes: " + lines[0]
+	for i := 1; i < len(lines); i++ {
+		lines[i] = "       " + lines[i]
+	}
+	return lines
+}
+
+// archiveSummaryLine describes the git state recorded when a session was
+// archived, e.g. "Archived 2026-01-15 10:04 • feat/auth • +12 -3 • unpushed".
+func archiveSummaryLine(summary session.ArchiveSummary) string {
+	parts := []string{"Archived " + summary.ArchivedAt.Local().Format("2006-01-02 15:04")}
+	if summary.Branch != "" {
+		parts = append(parts, summary.Branch)
+	}
+	parts = append(parts, fmt.Sprintf("+%d -%d", summary.Additions, summary.Deletions))
+	if summary.Uncommitted {
+		parts = append(parts, "uncommitted")
+	}
+	if summary.Unpushed {
+		parts = append(parts, "unpushed")
+	}
+	return strings.Join(parts, " • ")
+}
+
+// capabilitiesLine describes what a session's agent announced, e.g.
+// "Agent claude • opus v1.2 • 3 tools: bash, edit, web".
+func capabilitiesLine(caps session.Capabilities) string {
+	parts := []string{"Agent"}
+	if caps.Agent != "" {
+		parts[0] += " " + caps.Agent
+	}
+	model := caps.Model
+	if caps.Version != "" {
+		model = strings.TrimSpace(model + " v" + strings.TrimPrefix(caps.Version, "v"))
+	}
+	if model != "" {
+		parts = append(parts, model)
+	}
+	if len(caps.Tools) > 0 {
+		parts = append(parts, fmt.Sprintf("%d tools: %s", len(caps.Tools), strings.Join(caps.Tools, ", ")))
+	}
+	return strings.Join(parts, " • ")
+}
+
 // isCurrentTmuxSession returns true if the given session matches the current tmux session.
 // This prevents recursive preview when hive is previewing its own pane.
 func (v *View) isCurrentTmuxSession(sess *session.Session) bool {
@@ -1134,6 +1437,7 @@ func (v *View) handleFilterAction(actionType act.Type) bool {
 	switch actionType {
 	case act.TypeFilterAll:
 		v.statusFilter = ""
+		v.tagFilter = ""
 		return true
 	case act.TypeFilterActive:
 		v.statusFilter = terminal.StatusActive
@@ -1144,6 +1448,9 @@ func (v *View) handleFilterAction(actionType act.Type) bool {
 	case act.TypeFilterReady:
 		v.statusFilter = terminal.StatusReady
 		return true
+	case act.TypeFilterAttention:
+		v.statusFilter = AttentionFilter
+		return true
 	default:
 		return false
 	}
@@ -1210,9 +1517,19 @@ func (v *View) selectedWindowStatus() *WindowStatus {
 
 // loadSessions returns a command that loads sessions from the service.
 func (v *View) loadSessions() tea.Cmd {
+	showArchived := v.showArchived
+	attentionFn := v.attentionFn
 	return func() tea.Msg {
+		if showArchived {
+			sessions, err := v.service.ListArchivedSessions(context.Background())
+			return sessionsLoadedMsg{sessions: sessions, err: err}
+		}
 		sessions, err := v.service.ListSessions(context.Background())
-		return sessionsLoadedMsg{sessions: sessions, err: err}
+		msg := sessionsLoadedMsg{sessions: sessions, err: err}
+		if err == nil && attentionFn != nil {
+			msg.attention = attentionFn(context.Background(), sessions)
+		}
+		return msg
 	}
 }
 
@@ -1238,18 +1555,18 @@ func (v *View) startPluginWorker() tea.Cmd {
 // RefreshGitStatuses returns a command that refreshes git status for all sessions.
 func (v *View) RefreshGitStatuses() tea.Cmd {
 	items := v.list.Items()
-	paths := make([]string, 0, len(items))
+	repos := make(map[string]git.VCS, len(items))
 
 	for _, ti := range TreeItemsSessions(items) {
-		paths = append(paths, ti.Session.Path)
+		repos[ti.Session.Path] = v.service.VCS(&ti.Session)
 		v.gitStatuses.Set(ti.Session.Path, GitStatus{IsLoading: true})
 	}
 
-	if len(paths) == 0 {
+	if len(repos) == 0 {
 		return nil
 	}
 
-	return FetchGitStatusBatch(v.service.Git(), paths, v.gitWorkers)
+	return FetchGitStatusBatch(repos, v.gitWorkers)
 }
 
 // scheduleSessionRefresh returns a command that schedules the next session refresh.
@@ -1281,6 +1598,10 @@ func scheduleAnimationTick() tea.Cmd {
 // visible list area (row 0 = first visible item on the current page).
 // x is the terminal column of the click; clicks in the preview pane are ignored.
 func (v *View) SelectAtRow(x, row int) tea.Cmd {
+	if v.boardMode {
+		v.selectBoardAt(x, row)
+		return nil
+	}
 	if v.previewEnabled && v.width >= 80 {
 		splitPct := v.cfg.Views.Sessions.SplitRatioOrDefault(25)
 		listWidth := v.width * splitPct / 100
@@ -1474,6 +1795,13 @@ func (v *View) StatusFilter() terminal.Status {
 	return v.statusFilter
 }
 
+// SetStatusFilter narrows the tree to sessions whose agent has status. An
+// empty status clears the filter.
+func (v *View) SetStatusFilter(status terminal.Status) tea.Cmd {
+	v.statusFilter = status
+	return v.applyFilter()
+}
+
 // findByID returns the session with the given ID, or nil if not found.
 func (v *View) findByID(id string) *session.Session {
 	for i := range v.allSessions {
@@ -1484,6 +1812,23 @@ func (v *View) findByID(id string) *session.Session {
 	return nil
 }
 
+// TagFilter returns the current tag filter.
+func (v *View) TagFilter() string {
+	return v.tagFilter
+}
+
+// SetTagFilter narrows the tree to sessions carrying tag. An empty tag
+// clears the filter.
+func (v *View) SetTagFilter(tag string) tea.Cmd {
+	v.tagFilter = tag
+	return v.applyFilter()
+}
+
+// StartTagFilter opens the focus filter pre-filled with the tag: prefix.
+func (v *View) StartTagFilter() tea.Cmd {
+	return v.startFocusMode(tagFilterPrefix)
+}
+
 // ApplyStatusFilter sets the filter based on the action type and rebuilds the view.
 func (v *View) ApplyStatusFilter(actionType act.Type) {
 	v.handleFilterAction(actionType)
@@ -1500,6 +1845,18 @@ func (v *View) TogglePreview() {
 	v.previewEnabled = !v.previewEnabled
 }
 
+// ToggleArchived switches between live and archived sessions and reloads
+// the tree.
+func (v *View) ToggleArchived() tea.Cmd {
+	v.showArchived = !v.showArchived
+	return v.loadSessions()
+}
+
+// ShowingArchived reports whether the tree lists archived sessions.
+func (v *View) ShowingArchived() bool {
+	return v.showArchived
+}
+
 // ToggleGroupBy switches between repo and group tree view modes and rebuilds the tree.
 func (v *View) ToggleGroupBy() tea.Cmd {
 	if v.groupBy == config.GroupByGroup {
@@ -1515,6 +1872,18 @@ func (v *View) GroupBy() string {
 	return v.groupBy
 }
 
+// CycleSort advances to the next sort mode, persists it, and rebuilds the tree.
+func (v *View) CycleSort() tea.Cmd {
+	v.sortMode = nextSortMode(v.sortMode)
+	saveSortMode(v.prefs, v.sortMode)
+	return v.applyFilter()
+}
+
+// SortMode returns the current sort mode within tree groups.
+func (v *View) SortMode() string {
+	return v.sortMode
+}
+
 // ApplyTheme resets delegate styles and clears cached animation colors for a theme change.
 func (v *View) ApplyTheme() {
 	v.treeDelegate.Styles = DefaultTreeDelegateStyles()
@@ -1522,6 +1891,21 @@ func (v *View) ApplyTheme() {
 	ClearAnimationColors()
 }
 
+// ReloadConfig re-applies the settings that can change while the TUI runs:
+// the preview templates and the refresh interval. prevRefresh is the refresh
+// interval before the reload; a refresh timer stopped by a zero interval is
+// restarted.
+func (v *View) ReloadConfig(prevRefresh time.Duration) tea.Cmd {
+	v.previewTemplates = ParsePreviewTemplates(
+		v.cfg.Views.Sessions.PreviewTitle,
+		v.cfg.Views.Sessions.PreviewStatus,
+	)
+	if prevRefresh == 0 {
+		return v.scheduleSessionRefresh()
+	}
+	return nil
+}
+
 // LocalRemote returns the local remote URL.
 func (v *View) LocalRemote() string {
 	return v.localRemote
@@ -1538,7 +1922,7 @@ func isActiveStatus(s terminal.Status) bool {
 func IsFilterAction(t act.Type) bool {
 	switch t {
 	case act.TypeFilterAll, act.TypeFilterActive,
-		act.TypeFilterApproval, act.TypeFilterReady:
+		act.TypeFilterApproval, act.TypeFilterReady, act.TypeFilterAttention:
 		return true
 	default:
 		return false
@@ -3,12 +3,16 @@ package sessions
 import (
 	"context"
 	"testing"
+	"time"
 
 	"charm.land/bubbles/v2/list"
+	act "github.com/colonyops/hive/internal/core/action"
 	"github.com/colonyops/hive/internal/core/config"
 	"github.com/colonyops/hive/internal/core/session"
 	"github.com/colonyops/hive/internal/core/terminal"
 	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/internal/hive/plugins"
+	"github.com/colonyops/hive/internal/hive/plugins/github"
 	"github.com/colonyops/hive/pkg/kv"
 	"github.com/stretchr/testify/assert"
 )
@@ -165,6 +169,81 @@ func TestRenderPreviewHeader_SelectedPaneUsesDisplayID(t *testing.T) {
 	assert.NotContains(t, got, "[%12]")
 }
 
+func TestRenderPreviewHeader_Notes(t *testing.T) {
+	v := newTestView(nil, 0)
+	cfg := config.DefaultConfig()
+	v.cfg = &cfg
+
+	sess := session.Session{ID: "abcd1234", Name: "my-session", Notes: "waiting on CI\nthen rebase"}
+	got := terminal.StripANSI(v.renderPreviewHeader(&sess, 80))
+	assert.Contains(t, got, "Notes: waiting on CI\n       then rebase")
+
+	sess.Notes = ""
+	got = terminal.StripANSI(v.renderPreviewHeader(&sess, 80))
+	assert.NotContains(t, got, "Notes:")
+}
+
+func TestRenderPreviewHeader_Templates(t *testing.T) {
+	v := newTestView(nil, 0)
+	cfg := config.DefaultConfig()
+	cfg.Views.Sessions.PreviewTitle = "{{ .Name }} ({{ .ShortID }})"
+	cfg.Views.Sessions.PreviewStatus = `{{ with .Plugin.GithubPR }}#{{ .Number }} {{ .ReviewDecision }} {{ .ChecksPassed }}/{{ .ChecksFailed }}{{ end }}`
+	v.cfg = &cfg
+	v.previewTemplates = ParsePreviewTemplates(cfg.Views.Sessions.PreviewTitle, cfg.Views.Sessions.PreviewStatus)
+
+	pr := github.PullRequestStatus{Number: 42, State: "OPEN", ReviewDecision: "APPROVED", ChecksPassed: 5, ChecksFailed: 1}
+	store := kv.New[string, plugins.Status]()
+	store.Set("abcd1234", plugins.Status{Label: "approved", Data: pr})
+	v.pluginStatuses = map[string]*kv.Store[string, plugins.Status]{PluginGitHub: store}
+
+	sess := session.Session{ID: "abcd1234", Name: "my-session"}
+	got := terminal.StripANSI(v.renderPreviewHeader(&sess, 80))
+	assert.Contains(t, got, "my-session (1234)")
+	assert.Contains(t, got, "#42 APPROVED 5/1")
+}
+
+func TestRenderPreviewHeader_UpstreamAndLastCommit(t *testing.T) {
+	v := newTestView(nil, 0)
+	cfg := config.DefaultConfig()
+	cfg.TUI.Icons = new(false)
+	v.cfg = &cfg
+	v.gitStatuses = kv.New[string, GitStatus]()
+	v.gitStatuses.Set("/repo", GitStatus{
+		Branch:       "feature",
+		HasUpstream:  true,
+		Ahead:        2,
+		Behind:       1,
+		LastCommit:   "Add login",
+		LastCommitAt: time.Now().Add(-3 * time.Hour),
+	})
+
+	sess := session.Session{ID: "abcd1234", Name: "my-session", Path: "/repo"}
+	got := terminal.StripANSI(v.renderPreviewHeader(&sess, 80))
+	assert.Contains(t, got, "(feature) +0 -0 ↑2 ↓1")
+	assert.Contains(t, got, "Last commit: Add login • 3h ago")
+
+	cfg.Views.Sessions.PreviewStatus = "{{ .GitStatus.Ahead }}/{{ .GitStatus.Behind }} {{ .GitStatus.LastCommit }} {{ .GitStatus.LastCommitAge }}"
+	v.previewTemplates = ParsePreviewTemplates("", cfg.Views.Sessions.PreviewStatus)
+	got = terminal.StripANSI(v.renderPreviewHeader(&sess, 80))
+	assert.Contains(t, got, "2/1 Add login 3h ago")
+}
+
+func TestAheadBehindLabel(t *testing.T) {
+	assert.Empty(t, aheadBehindLabel(GitStatus{Ahead: 2}), "no upstream")
+	assert.Empty(t, aheadBehindLabel(GitStatus{HasUpstream: true}), "in sync")
+	assert.Equal(t, "↑2", aheadBehindLabel(GitStatus{HasUpstream: true, Ahead: 2}))
+	assert.Equal(t, "↑2 ↓1", aheadBehindLabel(GitStatus{HasUpstream: true, Ahead: 2, Behind: 1}))
+}
+
+func TestNotesPreviewLines(t *testing.T) {
+	assert.Nil(t, notesPreviewLines("  \n "))
+	assert.Equal(t, []string{"Notes: one"}, notesPreviewLines("one\n"))
+	assert.Equal(t,
+		[]string{"Notes: a", "       b", "       c …"},
+		notesPreviewLines("a\nb\nc\nd"),
+	)
+}
+
 func TestExpandWindowItems_MultipleWindows(t *testing.T) {
 	ts := kv.New[string, TerminalStatus]()
 	ts.Set("s1", TerminalStatus{
@@ -315,6 +394,26 @@ func TestApplyFilter_FilterThenClear(t *testing.T) {
 	assert.Equal(t, 2, sessionCount, "all sessions restored after filter cleared")
 }
 
+func TestApplyFilter_TagFilter(t *testing.T) {
+	tagged := newSess("s1", "api")
+	tagged.Tags = []string{"Backend"}
+	sessions := []session.Session{tagged, newSess("s2", "web")}
+	v := newFilterTestView(sessions, "", nil)
+
+	v.SetTagFilter("backend")
+
+	var sessionIDs []string
+	for _, item := range v.list.Items() {
+		if ti, ok := item.(TreeItem); ok && ti.IsSession() {
+			sessionIDs = append(sessionIDs, ti.Session.ID)
+		}
+	}
+	assert.Equal(t, []string{"s1"}, sessionIDs, "tags match ignoring case")
+
+	v.ApplyStatusFilter(act.TypeFilterAll)
+	assert.Empty(t, v.TagFilter(), "FilterAll clears the tag filter")
+}
+
 func TestApplyFilter_NoTerminalStatusExcluded(t *testing.T) {
 	ts := kv.New[string, TerminalStatus]()
 	// neither session has a terminal status entry
@@ -335,6 +434,37 @@ func TestApplyFilter_NoTerminalStatusExcluded(t *testing.T) {
 	assert.Empty(t, sessionIDs, "sessions without terminal status are excluded from status filter")
 }
 
+func TestApplyFilter_Attention(t *testing.T) {
+	ts := kv.New[string, TerminalStatus]()
+	ts.Set("s1", TerminalStatus{Status: terminal.StatusApproval})
+	ts.Set("s2", TerminalStatus{Status: terminal.StatusReady})
+	ts.Set("s3", TerminalStatus{Status: terminal.StatusReady})
+	ts.Set("s4", TerminalStatus{Status: terminal.StatusActive})
+
+	sessions := []session.Session{
+		newSess("s1", "approval"),
+		newSess("s2", "unread"),
+		newSess("s3", "reviewed"),
+		newSess("s4", "busy"),
+	}
+	v := newFilterTestView(sessions, "", ts)
+	v.attention = map[string]Attention{
+		"s2": {UnreadMessages: 2},
+		"s3": {PendingReviews: 1},
+		"s4": {},
+	}
+	v.ApplyStatusFilter(act.TypeFilterAttention)
+	assert.Equal(t, AttentionFilter, v.StatusFilter())
+
+	var sessionIDs []string
+	for _, item := range v.list.Items() {
+		if ti, ok := item.(TreeItem); ok && ti.IsSession() {
+			sessionIDs = append(sessionIDs, ti.Session.ID)
+		}
+	}
+	assert.ElementsMatch(t, []string{"s1", "s2", "s3"}, sessionIDs)
+}
+
 // --- handleSessionsLoaded ---
 
 // mockIntegration implements terminal.Integration for testing.
@@ -0,0 +1,48 @@
+package sessions
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/eventbus"
+	"github.com/colonyops/hive/internal/core/watchdog"
+)
+
+// observeWatchdog feeds a poll's results to the watchdog. Each newly stalled
+// session is published as a session.stalled event, and every result for a
+// session that is still stalled is marked so the tree can show a badge.
+func (v *View) observeWatchdog(results map[string]TerminalStatus, now time.Time) {
+	if v.watchdog == nil {
+		return
+	}
+
+	for sessionID, ts := range results {
+		alert, raised := v.watchdog.Observe(now, sessionID, ts.Status, ts.PaneContent)
+		if raised && v.bus != nil {
+			if sess := v.findByID(sessionID); sess != nil {
+				v.bus.PublishSessionStalled(eventbus.SessionStalledPayload{
+					Session: sess,
+					Alert:   alert,
+				})
+			}
+		}
+		if stalled, ok := v.watchdog.Stalled(sessionID); ok {
+			ts.Stalled = &stalled
+			results[sessionID] = ts
+		}
+	}
+
+	v.watchdog.Retain(func(sessionID string) bool {
+		_, ok := results[sessionID]
+		return ok
+	})
+}
+
+// stalledLabel returns the badge for a stalled session, e.g. "stalled 12m".
+func stalledLabel(alert watchdog.Alert, now time.Time) string {
+	d := alert.Duration(now)
+	if d >= time.Hour {
+		return fmt.Sprintf("stalled %dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("stalled %dm", int(d.Minutes()))
+}
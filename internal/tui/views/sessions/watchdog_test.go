@@ -0,0 +1,60 @@
+package sessions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/eventbus"
+	"github.com/colonyops/hive/internal/core/eventbus/testbus"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/core/terminal"
+	"github.com/colonyops/hive/internal/core/watchdog"
+)
+
+func TestObserveWatchdog(t *testing.T) {
+	tb := testbus.New(t)
+	v := &View{
+		allSessions: []session.Session{newSess("a", "alpha"), newSess("b", "bravo")},
+		bus:         tb.EventBus,
+		watchdog:    watchdog.NewTracker(5*time.Minute, 0),
+	}
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	poll := func(now time.Time) map[string]TerminalStatus {
+		results := map[string]TerminalStatus{
+			"a": {Status: terminal.StatusApproval, PaneContent: "Allow?"},
+			"b": {Status: terminal.StatusReady},
+		}
+		v.observeWatchdog(results, now)
+		return results
+	}
+
+	results := poll(base)
+	assert.Nil(t, results["a"].Stalled)
+
+	results = poll(base.Add(6 * time.Minute))
+	require.NotNil(t, results["a"].Stalled)
+	assert.Equal(t, watchdog.ReasonApproval, results["a"].Stalled.Reason)
+	assert.Nil(t, results["b"].Stalled)
+
+	p := testbus.FindPayload[eventbus.SessionStalledPayload](tb, t, eventbus.EventSessionStalled)
+	require.NotNil(t, p.Session)
+	assert.Equal(t, "alpha", p.Session.Name)
+	assert.Equal(t, base, p.Alert.Since)
+
+	results = poll(base.Add(7 * time.Minute))
+	require.NotNil(t, results["a"].Stalled, "badge stays while the session is stalled")
+
+	assert.Equal(t, "stalled 7m", stalledLabel(*results["a"].Stalled, base.Add(7*time.Minute)))
+	assert.Equal(t, "stalled 2h", stalledLabel(*results["a"].Stalled, base.Add(150*time.Minute)))
+}
+
+func TestObserveWatchdog_Disabled(t *testing.T) {
+	v := &View{}
+	results := map[string]TerminalStatus{"a": {Status: terminal.StatusApproval}}
+	v.observeWatchdog(results, time.Now())
+	assert.Nil(t, results["a"].Stalled)
+}
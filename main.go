@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"runtime/debug"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -18,9 +19,11 @@ import (
 	"github.com/colonyops/hive/internal/core/eventbus"
 	"github.com/colonyops/hive/internal/core/git"
 	"github.com/colonyops/hive/internal/core/styles"
+	"github.com/colonyops/hive/internal/core/watchdog"
 	"github.com/colonyops/hive/internal/data/db"
 	"github.com/colonyops/hive/internal/data/stores"
 	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/internal/hive/leader"
 	"github.com/colonyops/hive/internal/hive/plugins"
 	"github.com/colonyops/hive/internal/hive/plugins/claude"
 	"github.com/colonyops/hive/internal/hive/plugins/contextdir"
@@ -29,9 +32,11 @@ import (
 	"github.com/colonyops/hive/internal/hive/plugins/neovim"
 	plugintmux "github.com/colonyops/hive/internal/hive/plugins/tmux"
 	"github.com/colonyops/hive/internal/hive/scripts"
+	"github.com/colonyops/hive/internal/hive/supervisor"
 	"github.com/colonyops/hive/internal/hive/sweep"
 	"github.com/colonyops/hive/pkg/executil"
 	"github.com/colonyops/hive/pkg/logutils"
+	"github.com/colonyops/hive/pkg/osnotify"
 	"github.com/colonyops/hive/pkg/tmpl"
 )
 
@@ -133,6 +138,33 @@ func isInitCommand(args []string) bool {
 	return false
 }
 
+// globalValueFlags are the global flags that take a value. Given as
+// "--flag value", the value is a separate argument that isConfigFileCommand
+// must skip.
+var globalValueFlags = []string{"log-level", "log-file", "config", "c", "data-dir", "host"}
+
+// isConfigFileCommand reports whether the subcommand is "config init",
+// "config validate", or "config edit", scanning past global flags and their
+// values. They work on the config file itself, which may be missing or
+// invalid, so Before() skips full app initialisation for them too.
+func isConfigFileCommand(args []string) bool {
+	var words []string
+	for i := min(len(args), 1); i < len(args); i++ {
+		arg := args[i]
+		if strings.HasPrefix(arg, "-") {
+			if name := strings.TrimLeft(arg, "-"); slices.Contains(globalValueFlags, name) {
+				i++ // skip the value
+			}
+			continue
+		}
+		if words = append(words, arg); len(words) == 2 {
+			break
+		}
+	}
+	return len(words) == 2 && words[0] == "config" &&
+		slices.Contains([]string{"init", "validate", "edit"}, words[1])
+}
+
 func main() {
 	ctx := context.Background()
 
@@ -143,7 +175,10 @@ func main() {
 		pluginMgr   *plugins.Manager
 		sweepCancel context.CancelFunc
 		busCancel   context.CancelFunc
-		bgWg        sync.WaitGroup // tracks background goroutines for clean shutdown
+		busDone     chan struct{} // closed when the event bus stops
+		bus         *eventbus.EventBus
+		hookCancel  context.CancelFunc // stops webhook delivery after the bus drains
+		bgWg        sync.WaitGroup     // tracks background goroutines for clean shutdown
 	)
 
 	flags := &commands.Flags{}
@@ -192,6 +227,12 @@ Run 'hive new' to create a new session from the current repository.`,
 				Value:       config.DefaultDataDir(),
 				Destination: &flags.DataDir,
 			},
+			&cli.StringFlag{
+				Name:        "host",
+				Usage:       "manage sessions on a remote host from the hosts config",
+				Sources:     cli.EnvVars("HIVE_HOST"),
+				Destination: &flags.Host,
+			},
 		},
 		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
 			// Skip heavy initialization during shell completion. The
@@ -200,7 +241,7 @@ Run 'hive new' to create a new session from the current repository.`,
 			if isShellCompletion(os.Args) {
 				return ctx, nil
 			}
-			if isInitCommand(os.Args) {
+			if isInitCommand(os.Args) || isConfigFileCommand(os.Args) {
 				v, c, d := resolvedBuildInfo()
 				hiveApp.Build = hive.BuildInfo{Version: v, Commit: c, Date: d}
 				return ctx, nil
@@ -221,7 +262,26 @@ Run 'hive new' to create a new session from the current repository.`,
 
 			cfg, err := config.Load(flags.ConfigPath, flags.DataDir)
 			if err != nil {
-				return ctx, fmt.Errorf("load config: %w", err)
+				return ctx, commands.WithKind(commands.ErrorKindConfig, fmt.Errorf("load config: %w", err))
+			}
+
+			// With --host, session records are kept in a per-host data
+			// directory and commands run on the host over ssh.
+			var remote *executil.SSHExecutor
+			if flags.Host != "" {
+				host, err := cfg.Host(flags.Host)
+				if err != nil {
+					return ctx, commands.WithKind(commands.ErrorKindConfig, err)
+				}
+				hostDataDir := cfg.HostDataDir(flags.Host)
+				if err := os.MkdirAll(hostDataDir, 0o755); err != nil {
+					return ctx, fmt.Errorf("create host data dir: %w", err)
+				}
+				remote = executil.NewSSHExecutor(host.SSH, host.SSHArgs,
+					executil.PathMapping{Local: hostDataDir, Remote: host.DataDir},
+					executil.PathMapping{Local: cfg.DataDir, Remote: host.DataDir},
+				)
+				cfg.DataDir = hostDataDir
 			}
 
 			// Create template renderer
@@ -255,22 +315,47 @@ Run 'hive new' to create a new session from the current repository.`,
 
 			// Create stores
 			sessionStore := stores.NewSessionStore(database)
-			msgStore := stores.NewMessageStore(database, 0) // 0 = unlimited retention
+			msgStore := stores.NewMessageStore(database, 0)
+			msgStore.SetRetention(cfg.Messaging.RetentionPolicy())
 			kvStore := stores.NewKVStore(database)
 			todoStore := stores.NewTodoStore(database)
 			hcStore := stores.NewHCStore(database)
 
-			// Start background KV sweep goroutine
+			// Start background KV and message retention sweep goroutines,
+			// restarted by the supervisor if they panic or wedge. Only the
+			// hive process holding the maintenance lease runs them.
+			workers := supervisor.New(log.With().Str("component", "supervisor").Logger())
 			sweepCtx, cancel := context.WithCancel(context.Background())
 			sweepCancel = cancel
+			leaseStore := stores.NewLeaseStore(database)
+			bgWg.Go(func() {
+				leader.Run(sweepCtx, leaseStore, "maintenance", leader.Holder(), 30*time.Second, func(ctx context.Context) {
+					var wg sync.WaitGroup
+					wg.Go(func() {
+						workers.Run(ctx, "sweep.kv", supervisor.Options{}, func(ctx context.Context) error {
+							sweep.Start(ctx, kvStore, 5*time.Minute)
+							return nil
+						})
+					})
+					wg.Go(func() {
+						workers.Run(ctx, "sweep.messages", supervisor.Options{}, func(ctx context.Context) error {
+							sweep.StartMessages(ctx, msgStore, 5*time.Minute)
+							return nil
+						})
+					})
+					wg.Wait()
+				})
+			})
 			bgWg.Go(func() {
-				sweep.Start(sweepCtx, kvStore, 5*time.Minute)
+				workers.Publish(sweepCtx, kvStore, 30*time.Second)
 			})
 
-			bus := eventbus.New(64)
+			bus = eventbus.New(64)
 			busCtx, cancel := context.WithCancel(context.Background())
 			busCancel = cancel
+			busDone = make(chan struct{})
 			bgWg.Go(func() {
+				defer close(busDone)
 				bus.Start(busCtx)
 				log.Debug().Msg("event bus stopped")
 			})
@@ -281,11 +366,54 @@ Run 'hive new' to create a new session from the current repository.`,
 			// Create service
 			var (
 				exec      = &executil.RealExecutor{}
-				gitExec   = git.NewExecutor(cfg.GitPath, exec)
 				svcLogger = log.With().Str("component", "hive").Logger()
 			)
 
-			sessionSvc := hive.NewSessionService(sessionStore, gitExec, cfg, bus, exec, renderer, svcLogger, os.Stdout, os.Stderr)
+			// Sessions, git and session hooks run on the host under --host;
+			// notifications, webhooks and sources always run locally.
+			var svcExec executil.Executor = exec
+			if remote != nil {
+				svcExec = remote
+			}
+			gitExec := git.NewExecutor(cfg.GitPath, svcExec)
+
+			sessionSvc := hive.NewSessionService(sessionStore, gitExec, cfg, bus, svcExec, renderer, svcLogger, os.Stdout, os.Stderr)
+			if remote != nil {
+				sessionSvc.SetRemoteHost(flags.Host)
+			}
+
+			eventbus.NewDesktopNotifier(bus, cfg.GetNotify, func(title, message string) {
+				go func() {
+					ctx, cancel := context.WithTimeout(busCtx, 10*time.Second)
+					defer cancel()
+					if err := osnotify.Send(ctx, exec, title, message); err != nil {
+						log.Warn().Err(err).Msg("desktop notification failed")
+					}
+				}()
+			}, cfg.DesktopNotify.RateLimit).Register()
+
+			if hooks := cfg.Integrations.Webhooks; len(hooks) > 0 {
+				webhooks := eventbus.NewWebhookDispatcher(bus, hooks, nil, log.With().Str("component", "webhooks").Logger())
+				webhooks.Register()
+				hookCtx, cancel := context.WithCancel(context.Background())
+				hookCancel = cancel
+				bgWg.Go(func() { webhooks.Run(hookCtx) })
+			}
+
+			if cfg.Watchdog.Enabled && cfg.Watchdog.Command != "" {
+				hook := watchdog.NewHook(cfg.Watchdog.Command, exec, renderer)
+				bus.SubscribeSessionStalled(func(p eventbus.SessionStalledPayload) {
+					if p.Session == nil {
+						return
+					}
+					data := watchdog.NewHookData(*p.Session, p.Alert, time.Now())
+					go func() {
+						if err := hook.Run(busCtx, data); err != nil {
+							log.Warn().Err(err).Str("session", data.Name).Msg("watchdog command failed")
+						}
+					}()
+				})
+			}
 
 			// Create all plugin instances, collect availability info for doctor,
 			// then register with the manager.
@@ -306,7 +434,7 @@ Run 'hive new' to create a new session from the current repository.`,
 				{plugin: lazygit.New(cfg.Plugins.LazyGit), disabled: isDisabled(cfg.Plugins.LazyGit.Enabled)},
 				{plugin: neovim.New(cfg.Plugins.Neovim), disabled: isDisabled(cfg.Plugins.Neovim.Enabled)},
 				{plugin: contextdir.New(cfg.Plugins.ContextDir, cfg.DataDir), disabled: isDisabled(cfg.Plugins.ContextDir.Enabled)},
-				{plugin: claude.New(cfg.Plugins.Claude), disabled: isDisabled(cfg.Plugins.Claude.Enabled)},
+				{plugin: claude.New(cfg.Plugins.Claude, stores.NewUsageStore(database)), disabled: isDisabled(cfg.Plugins.Claude.Enabled)},
 				{plugin: plugintmux.New(cfg.Plugins.Tmux), disabled: isDisabled(cfg.Plugins.Tmux.Enabled)},
 			}
 
@@ -321,6 +449,7 @@ Run 'hive new' to create a new session from the current repository.`,
 			}
 
 			pluginMgr = plugins.NewManager(shellPool, commandSet)
+			pluginMgr.SetSupervisor(workers)
 			for _, candidate := range allPlugins {
 				pluginMgr.Register(candidate.plugin)
 			}
@@ -354,12 +483,28 @@ Run 'hive new' to create a new session from the current repository.`,
 				Date:    resolvedDate,
 			}
 			hiveApp.Sources = hive.BuildSourceRegistry(cfg, exec, kvStore, svcLogger)
+			hiveApp.Workers = workers
+			hiveApp.Exec = exec
+			hiveApp.Doctor.SetWorkers(workers, kvStore)
+			hiveApp.Doctor.SetScripts(flags.DataDir, version)
+			hiveApp.Doctor.SetHost(svcExec, remote != nil)
+			hiveApp.Doctor.SetTools(exec)
+			if remote != nil {
+				hiveApp.Remote = remote
+			}
 
 			return ctx, nil
 		},
 		After: func(ctx context.Context, c *cli.Command) error {
 			if busCancel != nil {
 				busCancel()
+				<-busDone
+				// Hand events published just before exiting to their
+				// subscribers, then let webhooks deliver what is queued.
+				bus.Drain()
+			}
+			if hookCancel != nil {
+				hookCancel()
 			}
 
 			// Stop background sweep
@@ -372,6 +517,17 @@ Run 'hive new' to create a new session from the current repository.`,
 				pluginMgr.CloseAll()
 			}
 
+			// Let delete verifications record their findings before the
+			// database closes.
+			if hiveApp.Sessions != nil {
+				hiveApp.Sessions.WaitForCleanups()
+			}
+
+			// Wait for background goroutines to finish before closing the
+			// database, so the maintenance lease is released, and the log
+			// file, so they don't write to a closed file descriptor.
+			bgWg.Wait()
+
 			// Close database connection
 			if database != nil {
 				if err := database.Close(); err != nil {
@@ -380,10 +536,6 @@ Run 'hive new' to create a new session from the current repository.`,
 				}
 			}
 
-			// Wait for background goroutines to finish before closing the
-			// log file so they don't write to a closed file descriptor.
-			bgWg.Wait()
-
 			// Close log file
 			if logCloser != nil {
 				logCloser()
@@ -397,14 +549,22 @@ Run 'hive new' to create a new session from the current repository.`,
 	app = commands.NewNewCmd(flags, hiveApp).Register(app)
 	app = commands.NewPruneCmd(flags, hiveApp).Register(app)
 	app = commands.NewDoctorCmd(flags, hiveApp).Register(app)
+	app = commands.NewActivityCmd(flags, hiveApp).Register(app)
+	app = commands.NewStatsCmd(flags, hiveApp).Register(app)
+	app = commands.NewStatusCmd(flags, hiveApp).Register(app)
+	app = commands.NewReportCmd(flags, hiveApp).Register(app)
+	app = commands.NewServeCmd(flags, hiveApp).Register(app)
+	app = commands.NewWaitCmd(flags, hiveApp).Register(app)
 	app = commands.NewBatchCmd(flags, hiveApp).Register(app)
 	app = commands.NewCtxCmd(flags, hiveApp).Register(app)
+	app = commands.NewKVCmd(flags, hiveApp).Register(app)
 	app = commands.NewMsgCmd(flags, hiveApp).Register(app)
 	app = commands.NewDocCmd(flags, hiveApp).Register(app)
 	app = commands.NewSessionCmd(flags, hiveApp).Register(app)
 	app = commands.NewReviewCmd(flags, hiveApp).Register(app)
 	app = commands.NewTodoCmd(flags, hiveApp).Register(app)
 	app = commands.NewConfigCmd(flags, hiveApp).Register(app)
+	app = commands.NewRulesCmd(flags, hiveApp).Register(app)
 	app = commands.NewDetectCmd(flags, hiveApp).Register(app)
 	app = commands.NewHoneycombCmd(flags, hiveApp).Register(app)
 	app = commands.NewWorkspaceCmd(flags, hiveApp).Register(app)
@@ -422,13 +582,15 @@ Run 'hive new' to create a new session from the current repository.`,
 		return tuiCmd.Run(ctx, c)
 	}
 
-	exitCode := 0
 	runErr := app.Run(ctx, os.Args)
 	if runErr != nil {
-		fmt.Println()
-		fmt.Println(runErr.Error())
-		exitCode = 1
+		if commands.WantsJSON(os.Args[1:]) {
+			_ = commands.WriteErrorJSON(os.Stdout, runErr)
+		} else {
+			fmt.Println()
+			fmt.Println(runErr.Error())
+		}
 	}
 
-	os.Exit(exitCode)
+	os.Exit(commands.ExitCode(runErr))
 }
@@ -31,3 +31,27 @@ func TestIsInitCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestIsConfigFileCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want bool
+	}{
+		{name: "config init", args: []string{"hive", "config", "init"}, want: true},
+		{name: "config validate after flag=value", args: []string{"hive", "--config=foo.yaml", "config", "validate", "--json"}, want: true},
+		{name: "config edit", args: []string{"hive", "config", "edit"}, want: true},
+		{name: "config validate after flag value", args: []string{"hive", "--config", "./x.yaml", "config", "validate"}, want: true},
+		{name: "short config flag value", args: []string{"hive", "-c", "config", "config", "edit"}, want: true},
+		{name: "several flag values", args: []string{"hive", "--log-level", "debug", "--data-dir", "/tmp/hive", "config", "init"}, want: true},
+		{name: "config dump", args: []string{"hive", "config"}, want: false},
+		{name: "other subcommand", args: []string{"hive", "session", "validate"}, want: false},
+		{name: "empty args", args: []string{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isConfigFileCommand(tt.args))
+		})
+	}
+}
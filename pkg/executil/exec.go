@@ -37,13 +37,15 @@ func (w *limitedWriter) Write(p []byte) (int, error) {
 	return origLen, nil
 }
 
-// RunSh executes a shell command in the given directory (empty means inherit cwd).
+// RunSh executes a command in the platform shell (see Shell) in the given
+// directory (empty means inherit cwd).
 // On failure, stderr is returned as the error message, capped at 500 bytes to
 // prevent large or ANSI-polluted output from corrupting logs or TUI display.
 // The original *exec.ExitError is preserved via wrapping so callers can inspect
 // exit codes with errors.As.
 func RunSh(ctx context.Context, dir, cmd string) error {
-	c := exec.CommandContext(ctx, "sh", "-c", cmd)
+	name, args := Shell(cmd)
+	c := exec.CommandContext(ctx, name, args...)
 	if dir != "" {
 		c.Dir = dir
 	}
@@ -72,6 +74,14 @@ type Executor interface {
 	RunDirStream(ctx context.Context, dir string, stdout, stderr io.Writer, cmd string, args ...string) error
 }
 
+// InputRunner is implemented by executors that can write to a command's
+// stdin. *RealExecutor satisfies it.
+type InputRunner interface {
+	// RunInputDir executes a command in dir with stdin as its input and
+	// returns its combined output.
+	RunInputDir(ctx context.Context, dir string, stdin io.Reader, cmd string, args ...string) ([]byte, error)
+}
+
 // RealExecutor calls actual shell commands.
 type RealExecutor struct{}
 
@@ -113,6 +123,18 @@ func (e *RealExecutor) RunDir(ctx context.Context, dir, cmd string, args ...stri
 	return out, nil
 }
 
+// RunInputDir executes a command in dir with stdin as its input.
+func (e *RealExecutor) RunInputDir(ctx context.Context, dir string, stdin io.Reader, cmd string, args ...string) ([]byte, error) {
+	c := exec.CommandContext(ctx, cmd, args...)
+	c.Dir = dir
+	c.Stdin = stdin
+	out, err := c.CombinedOutput()
+	if err != nil {
+		return out, fmt.Errorf("exec %s in %s: %w", cmd, dir, err)
+	}
+	return out, nil
+}
+
 // RunStream executes a command and streams stdout/stderr to the provided writers.
 func (e *RealExecutor) RunStream(ctx context.Context, stdout, stderr io.Writer, cmd string, args ...string) error {
 	c := exec.CommandContext(ctx, cmd, args...)
@@ -11,9 +11,10 @@ import (
 
 // Call records one executor invocation.
 type Call struct {
-	Cmd  string
-	Dir  string
-	Args []string
+	Cmd   string
+	Dir   string
+	Args  []string
+	Stdin string // input given to RunInputDir
 }
 
 // Response scripts the result of one invocation, consumed in call order.
@@ -26,15 +27,18 @@ type Response struct {
 // Exec is a scripted executil.Executor: every invocation records a Call
 // and consumes the next Response (zero-value results once the script runs
 // out, so an unscripted Exec is a silent no-op stub). It also implements
-// the RunOutputDir seam for separated stdout/stderr. Safe for
-// concurrent use.
+// the RunOutputDir seam for separated stdout/stderr and
+// executil.InputRunner. Safe for concurrent use.
 type Exec struct {
 	mu        sync.Mutex
 	calls     []Call
 	Responses []Response
 }
 
-var _ executil.Executor = (*Exec)(nil)
+var (
+	_ executil.Executor    = (*Exec)(nil)
+	_ executil.InputRunner = (*Exec)(nil)
+)
 
 // Calls returns the recorded invocations in call order.
 func (e *Exec) Calls() []Call {
@@ -44,9 +48,13 @@ func (e *Exec) Calls() []Call {
 }
 
 func (e *Exec) record(cmd, dir string, args []string) Response {
+	return e.recordCall(Call{Cmd: cmd, Dir: dir, Args: args})
+}
+
+func (e *Exec) recordCall(call Call) Response {
 	e.mu.Lock()
 	defer e.mu.Unlock()
-	e.calls = append(e.calls, Call{Cmd: cmd, Dir: dir, Args: args})
+	e.calls = append(e.calls, call)
 	if idx := len(e.calls) - 1; idx < len(e.Responses) {
 		return e.Responses[idx]
 	}
@@ -72,6 +80,16 @@ func (e *Exec) RunDir(_ context.Context, dir, cmd string, args ...string) ([]byt
 	return append(append([]byte{}, resp.Out...), resp.Stderr...), resp.Err
 }
 
+// RunInputDir behaves like RunDir and records what was read from stdin.
+func (e *Exec) RunInputDir(_ context.Context, dir string, stdin io.Reader, cmd string, args ...string) ([]byte, error) {
+	input, err := io.ReadAll(stdin)
+	if err != nil {
+		return nil, err
+	}
+	resp := e.recordCall(Call{Cmd: cmd, Dir: dir, Args: args, Stdin: string(input)})
+	return append(append([]byte{}, resp.Out...), resp.Stderr...), resp.Err
+}
+
 // RunStream writes the scripted response to the provided writers.
 func (e *Exec) RunStream(_ context.Context, stdout, stderr io.Writer, cmd string, args ...string) error {
 	return e.stream(stdout, stderr, cmd, "", args)
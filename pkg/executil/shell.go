@@ -0,0 +1,32 @@
+package executil
+
+import (
+	"os/exec"
+	"runtime"
+	"sync"
+)
+
+// Shell returns the command and arguments that run script in the platform
+// shell: sh -c on Unix, and PowerShell on Windows, where POSIX sh is not
+// generally available. PowerShell 7 (pwsh) is preferred because it supports
+// the && and || operators hive's default templates rely on.
+func Shell(script string) (string, []string) {
+	return shellCmd(runtime.GOOS, script)
+}
+
+// shellCmd returns the shell invocation for the given GOOS. Split out as a
+// pure function so the platform matrix is unit-testable.
+func shellCmd(goos, script string) (string, []string) {
+	if goos == "windows" {
+		return windowsShell(), []string{"-NoLogo", "-NoProfile", "-NonInteractive", "-Command", script}
+	}
+	return "sh", []string{"-c", script}
+}
+
+// windowsShell resolves the PowerShell binary once per process.
+var windowsShell = sync.OnceValue(func() string {
+	if _, err := exec.LookPath("pwsh"); err == nil {
+		return "pwsh"
+	}
+	return "powershell"
+})
@@ -0,0 +1,21 @@
+package executil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShellCmd(t *testing.T) {
+	name, args := shellCmd("linux", "echo hi && true")
+	assert.Equal(t, "sh", name)
+	assert.Equal(t, []string{"-c", "echo hi && true"}, args)
+
+	name, args = shellCmd("darwin", "true")
+	assert.Equal(t, "sh", name)
+	assert.Equal(t, []string{"-c", "true"}, args)
+
+	name, args = shellCmd("windows", "echo hi")
+	assert.Contains(t, []string{"pwsh", "powershell"}, name)
+	assert.Equal(t, []string{"-NoLogo", "-NoProfile", "-NonInteractive", "-Command", "echo hi"}, args)
+}
@@ -0,0 +1,151 @@
+package executil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// PathMapping translates paths under Local to the same relative path under
+// Remote.
+type PathMapping struct {
+	Local  string
+	Remote string
+}
+
+// PathTranslator is implemented by executors that run commands where local
+// paths live under a different directory.
+type PathTranslator interface {
+	TranslatePath(s string) string
+}
+
+// TranslatePath returns path as exec's commands see it. Executors that do
+// not implement PathTranslator see the local path.
+func TranslatePath(exec Executor, path string) string {
+	if t, ok := exec.(PathTranslator); ok {
+		return t.TranslatePath(path)
+	}
+	return path
+}
+
+// SSHExecutor runs commands on another host over ssh. Local paths in the
+// working directory and arguments are translated with Paths, so callers can
+// keep using the local layout of directories that are mirrored on the host.
+type SSHExecutor struct {
+	Destination string   // ssh destination, e.g. "me@devbox"
+	Args        []string // extra ssh options, e.g. ["-p", "2222"]
+	Paths       []PathMapping
+	SSHPath     string   // ssh binary (default "ssh")
+	Local       Executor // runs ssh (default RealExecutor)
+}
+
+// NewSSHExecutor creates an executor for destination. Mappings are applied
+// longest local prefix first.
+func NewSSHExecutor(destination string, args []string, paths ...PathMapping) *SSHExecutor {
+	paths = slices.Clone(paths)
+	slices.SortStableFunc(paths, func(a, b PathMapping) int {
+		return len(b.Local) - len(a.Local)
+	})
+	return &SSHExecutor{Destination: destination, Args: args, Paths: paths}
+}
+
+// TranslatePath rewrites s when it starts with a mapped local path, either
+// on its own or as the value of a "--flag=" argument. Paths elsewhere in s,
+// such as inside shell scripts, are left as they are.
+func (e *SSHExecutor) TranslatePath(s string) string {
+	prefix, path := "", s
+	if strings.HasPrefix(s, "-") {
+		if i := strings.IndexByte(s, '='); i >= 0 {
+			prefix, path = s[:i+1], s[i+1:]
+		}
+	}
+	for _, m := range e.Paths {
+		if m.Local == "" {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(path, m.Local); ok && (rest == "" || rest[0] == '/') {
+			return prefix + m.Remote + rest
+		}
+	}
+	return s
+}
+
+// Command returns the local ssh command line that runs cmd with args in dir
+// on the host. An empty dir runs in the remote login directory.
+func (e *SSHExecutor) Command(dir, cmd string, args ...string) (string, []string) {
+	words := make([]string, 0, len(args)+1)
+	words = append(words, quote(e.TranslatePath(cmd)))
+	for _, arg := range args {
+		words = append(words, quote(e.TranslatePath(arg)))
+	}
+	remote := strings.Join(words, " ")
+	if dir != "" {
+		remote = "cd " + quote(e.TranslatePath(dir)) + " && " + remote
+	}
+
+	sshArgs := make([]string, 0, len(e.Args)+3)
+	sshArgs = append(sshArgs, e.Args...)
+	sshArgs = append(sshArgs, "--", e.Destination, remote)
+
+	ssh := e.SSHPath
+	if ssh == "" {
+		ssh = "ssh"
+	}
+	return ssh, sshArgs
+}
+
+func (e *SSHExecutor) local() Executor {
+	if e.Local != nil {
+		return e.Local
+	}
+	return &RealExecutor{}
+}
+
+// Run executes a command on the host and returns its combined output.
+func (e *SSHExecutor) Run(ctx context.Context, cmd string, args ...string) ([]byte, error) {
+	ssh, sshArgs := e.Command("", cmd, args...)
+	out, err := e.local().Run(ctx, ssh, sshArgs...)
+	if err != nil {
+		return out, fmt.Errorf("%s on %s: %w", cmd, e.Destination, err)
+	}
+	return out, nil
+}
+
+// RunDir executes a command in a directory on the host.
+func (e *SSHExecutor) RunDir(ctx context.Context, dir, cmd string, args ...string) ([]byte, error) {
+	ssh, sshArgs := e.Command(dir, cmd, args...)
+	out, err := e.local().Run(ctx, ssh, sshArgs...)
+	if err != nil {
+		return out, fmt.Errorf("%s in %s on %s: %w", cmd, dir, e.Destination, err)
+	}
+	return out, nil
+}
+
+// RunStream executes a command on the host and streams its output.
+func (e *SSHExecutor) RunStream(ctx context.Context, stdout, stderr io.Writer, cmd string, args ...string) error {
+	ssh, sshArgs := e.Command("", cmd, args...)
+	if err := e.local().RunStream(ctx, stdout, stderr, ssh, sshArgs...); err != nil {
+		return fmt.Errorf("%s on %s: %w", cmd, e.Destination, err)
+	}
+	return nil
+}
+
+// RunDirStream executes a command in a directory on the host and streams its
+// output.
+func (e *SSHExecutor) RunDirStream(ctx context.Context, dir string, stdout, stderr io.Writer, cmd string, args ...string) error {
+	ssh, sshArgs := e.Command(dir, cmd, args...)
+	if err := e.local().RunStream(ctx, stdout, stderr, ssh, sshArgs...); err != nil {
+		return fmt.Errorf("%s in %s on %s: %w", cmd, dir, e.Destination, err)
+	}
+	return nil
+}
+
+// quote single-quotes s for the remote shell.
+func quote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
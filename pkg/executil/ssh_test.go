@@ -0,0 +1,73 @@
+package executil
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSHExecutor_Command(t *testing.T) {
+	e := NewSSHExecutor("me@devbox", []string{"-p", "2222"},
+		PathMapping{Local: "/home/local/.hive", Remote: "/srv/hive"},
+		PathMapping{Local: "/home/local/.hive/hosts/devbox", Remote: "/srv/hive"},
+	)
+
+	ssh, args := e.Command("/home/local/.hive/hosts/devbox/repos/api", "git", "commit", "-m", "it's done", "--file=/home/local/.hive/bin/x")
+	assert.Equal(t, "ssh", ssh)
+	assert.Equal(t, []string{
+		"-p", "2222", "--", "me@devbox",
+		`cd '/srv/hive/repos/api' && 'git' 'commit' '-m' 'it'\''s done' '--file=/srv/hive/bin/x'`,
+	}, args)
+
+	_, args = e.Command("", "sh", "-c", "cat /home/local/.hive/x", "/home/local/.hivex")
+	assert.Equal(t, `'sh' '-c' 'cat /home/local/.hive/x' '/home/local/.hivex'`, args[len(args)-1])
+
+	_, args = e.Command("", "tmux", "ls")
+	assert.Equal(t, `'tmux' 'ls'`, args[len(args)-1])
+}
+
+func TestTranslatePath(t *testing.T) {
+	e := NewSSHExecutor("devbox", nil, PathMapping{Local: "/home/local/.hive", Remote: "/srv/hive"})
+	assert.Equal(t, "/srv/hive/repos/api", TranslatePath(e, "/home/local/.hive/repos/api"))
+	assert.Equal(t, "/home/local/.hive/repos/api", TranslatePath(&RealExecutor{}, "/home/local/.hive/repos/api"))
+}
+
+func TestSSHExecutor_Run(t *testing.T) {
+	rec := &RecordingExecutor{Outputs: map[string][]byte{"ssh": []byte("ok")}}
+	e := NewSSHExecutor("devbox", nil)
+	e.Local = rec
+
+	out, err := e.RunDir(context.Background(), "/tmp", "ls")
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(out))
+	require.Len(t, rec.Commands, 1)
+	assert.Equal(t, "ssh", rec.Commands[0].Cmd)
+	assert.Equal(t, []string{"--", "devbox", "cd '/tmp' && 'ls'"}, rec.Commands[0].Args)
+}
+
+// TestSSHExecutor_Quoting runs the remote command line through a local shell
+// standing in for ssh, so arguments must survive quoting unchanged.
+func TestSSHExecutor_Quoting(t *testing.T) {
+	dir := t.TempDir()
+	fakeSSH := filepath.Join(dir, "ssh")
+	require.NoError(t, os.WriteFile(fakeSSH, []byte("#!/bin/sh\nfor last; do :; done\nexec sh -c \"$last\"\n"), 0o755))
+
+	e := NewSSHExecutor("devbox", nil)
+	e.SSHPath = fakeSSH
+
+	args := []string{"a b", "it's", `"quoted"`, "$HOME", "", "semi;colon"}
+	out, err := e.RunDir(context.Background(), dir, "printf", append([]string{"[%s]"}, args...)...)
+	require.NoError(t, err)
+	assert.Equal(t, "[a b][it's][\"quoted\"][$HOME][][semi;colon]", string(out))
+
+	var stdout strings.Builder
+	require.NoError(t, e.RunDirStream(context.Background(), dir, &stdout, nil, "pwd"))
+	resolved, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	assert.Equal(t, resolved, strings.TrimSpace(stdout.String()))
+}
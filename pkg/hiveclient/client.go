@@ -0,0 +1,192 @@
+// Package hiveclient embeds hive in other Go programs. A Client works on a
+// hive config file and data directory the same way the hive CLI does, so
+// bots and dashboards can create and list sessions, exchange messages with
+// agents, and read review comments without shelling out to hive:
+//
+//	c, err := hiveclient.Open(ctx, hiveclient.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	defer c.Close()
+//
+//	sessions, err := c.Sessions(ctx)
+//
+// This package is hive's stable Go API: its exported identifiers only change
+// incompatibly in a new major version, and new fields and methods are added
+// without breaking existing callers. It defines its own types instead of
+// exposing hive's internal ones, which may change in any release.
+//
+// Several processes, including the hive CLI and TUI, can use the same data
+// directory at once.
+package hiveclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/colonyops/hive/internal/core/config"
+	"github.com/colonyops/hive/internal/core/eventbus"
+	"github.com/colonyops/hive/internal/core/git"
+	"github.com/colonyops/hive/internal/data/db"
+	"github.com/colonyops/hive/internal/data/stores"
+	"github.com/colonyops/hive/internal/hive"
+	"github.com/colonyops/hive/internal/hive/scripts"
+	"github.com/colonyops/hive/pkg/executil"
+	"github.com/colonyops/hive/pkg/tmpl"
+)
+
+// ErrNotFound is returned when a session, topic or review does not exist.
+var ErrNotFound = errors.New("not found")
+
+// Options configures Open. The zero value uses the same config file and data
+// directory as the hive CLI.
+type Options struct {
+	// ConfigPath is the hive config file. Empty uses $HIVE_CONFIG, then the
+	// default location ($XDG_CONFIG_HOME/hive/config.yaml). Without a config
+	// file the defaults apply.
+	ConfigPath string
+	// DataDir is the hive data directory. Empty uses $HIVE_DATA_DIR, then
+	// the default location ($XDG_DATA_HOME/hive).
+	DataDir string
+	// Logger receives hive's log output. The zero value discards it.
+	Logger zerolog.Logger
+	// Output receives the output of the commands run while creating and
+	// deleting sessions. Nil discards it. Writes to it are serialized, so it
+	// need not be safe for concurrent use.
+	Output io.Writer
+}
+
+func (o Options) withDefaults() Options {
+	if o.ConfigPath == "" {
+		o.ConfigPath = os.Getenv("HIVE_CONFIG")
+	}
+	if o.ConfigPath == "" {
+		o.ConfigPath = config.DefaultConfigPath()
+	}
+	if o.DataDir == "" {
+		o.DataDir = os.Getenv("HIVE_DATA_DIR")
+	}
+	if o.DataDir == "" {
+		o.DataDir = config.DefaultDataDir()
+	}
+	if o.Output == nil {
+		o.Output = io.Discard
+	}
+	o.Output = &lockedWriter{w: o.Output}
+	return o
+}
+
+// lockedWriter serializes writes from concurrent session operations.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// Client gives access to the sessions, messages and reviews of a hive data
+// directory. It is safe for concurrent use. Close it when done.
+type Client struct {
+	app     *hive.App
+	reviews *stores.ReviewStore
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// Open loads the hive config and opens the data directory, creating it if
+// needed. An invalid config fails with the same errors hive reports.
+func Open(ctx context.Context, opts Options) (*Client, error) {
+	opts = opts.withDefaults()
+
+	cfg, err := config.Load(opts.ConfigPath, opts.DataDir)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	if err := os.MkdirAll(cfg.DataDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create data dir: %w", err)
+	}
+
+	database, err := db.Open(cfg.DataDir, db.OpenOptions{
+		MaxOpenConns: cfg.Database.MaxOpenConns,
+		MaxIdleConns: cfg.Database.MaxIdleConns,
+		BusyTimeout:  cfg.Database.BusyTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	if err := stores.MigrateFromJSON(ctx, database, cfg.DataDir); err != nil {
+		_ = database.Close()
+		return nil, fmt.Errorf("migrate from JSON: %w", err)
+	}
+
+	profile := cfg.Agents.DefaultProfile()
+	renderer := tmpl.New(tmpl.Config{
+		ScriptPaths:  scripts.ScriptPaths(cfg.DataDir),
+		AgentCommand: profile.CommandOrDefault(cfg.Agents.Default),
+		AgentWindow:  cfg.Agents.Default,
+		AgentFlags:   profile.ShellFlags(),
+	})
+
+	msgStore := stores.NewMessageStore(database, 0)
+	msgStore.SetRetention(cfg.Messaging.RetentionPolicy())
+	kvStore := stores.NewKVStore(database)
+
+	exec := &executil.RealExecutor{}
+	bus := eventbus.New(64)
+	logger := opts.Logger.With().Str("component", "hiveclient").Logger()
+	sessions := hive.NewSessionService(stores.NewSessionStore(database), git.NewExecutor(cfg.GitPath, exec), cfg, bus, exec, renderer, logger, opts.Output, opts.Output)
+
+	busCtx, cancel := context.WithCancel(context.Background())
+	c := &Client{
+		app: hive.NewApp(
+			sessions,
+			msgStore,
+			stores.NewTodoStore(database),
+			stores.NewHCStore(database),
+			cfg,
+			bus,
+			nil, // no terminal monitoring
+			nil, // no plugins
+			nil,
+			database,
+			kvStore,
+			renderer,
+			nil,
+			logger,
+		),
+		reviews: stores.NewReviewStore(database),
+		cancel:  cancel,
+	}
+	c.wg.Go(func() { bus.Start(busCtx) })
+	return c, nil
+}
+
+// Close waits for pending session cleanups and closes the data directory.
+func (c *Client) Close() error {
+	c.app.Sessions.WaitForCleanups()
+	c.cancel()
+	c.wg.Wait()
+	return c.app.DB.Close()
+}
+
+// notFound wraps err with ErrNotFound when it reports a missing record, so
+// callers can match it with errors.Is.
+func notFound(err error, missing ...error) error {
+	for _, target := range missing {
+		if errors.Is(err, target) {
+			return fmt.Errorf("%w: %w", ErrNotFound, err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,191 @@
+package hiveclient
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/colonyops/hive/internal/core/review"
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/data/stores"
+)
+
+func openTestClient(t *testing.T) *Client {
+	t.Helper()
+	c, err := Open(context.Background(), Options{
+		ConfigPath: filepath.Join(t.TempDir(), "missing.yaml"),
+		DataDir:    filepath.Join(t.TempDir(), "hive"),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, c.Close()) })
+	return c
+}
+
+func TestClientSessions(t *testing.T) {
+	ctx := context.Background()
+	c := openTestClient(t)
+
+	sessions, err := c.Sessions(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+
+	now := time.Now()
+	require.NoError(t, stores.NewSessionStore(c.app.DB).Save(ctx, session.Session{
+		ID:        "abc123",
+		Name:      "hiveclient-test",
+		Slug:      "hiveclient-test",
+		Path:      t.TempDir(),
+		Remote:    "https://github.com/example/repo",
+		State:     session.StateActive,
+		Tags:      []string{"backend"},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}))
+
+	sessions, err = c.Sessions(ctx)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	got := sessions[0]
+	assert.Equal(t, "abc123", got.ID)
+	assert.Equal(t, SessionActive, got.State)
+	assert.Equal(t, []string{"backend"}, got.Tags)
+	assert.Equal(t, "agent.abc123.inbox", got.Inbox)
+
+	_, err = c.Session(ctx, "missing")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = c.CreateSession(ctx, CreateOptions{Name: "no-remote"})
+	require.Error(t, err)
+
+	_, err = c.CreateSession(ctx, CreateOptions{Name: "bad-agent", Remote: "https://github.com/example/repo", Agent: "missing"})
+	require.ErrorContains(t, err, `unknown agent "missing"`)
+
+	require.NoError(t, c.DeleteSession(ctx, "abc123"))
+	_, err = c.Session(ctx, "abc123")
+	require.ErrorIs(t, err, ErrNotFound)
+	require.ErrorIs(t, c.DeleteSession(ctx, "abc123"), ErrNotFound)
+}
+
+func TestClientMessages(t *testing.T) {
+	ctx := context.Background()
+	c := openTestClient(t)
+
+	_, err := c.Messages(ctx, "agent.abc123.inbox", time.Time{})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	topics, err := c.Publish(ctx, Message{Payload: "run the tests", Sender: "bot"}, "agent.abc123.inbox")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"agent.abc123.inbox"}, topics)
+
+	msgs, err := c.Messages(ctx, "agent.*.inbox", time.Time{})
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, "run the tests", msgs[0].Payload)
+	assert.Equal(t, "bot", msgs[0].Sender)
+	assert.Equal(t, "agent.abc123.inbox", msgs[0].Topic)
+	assert.NotEmpty(t, msgs[0].ID)
+
+	unread, err := c.Unread(ctx, "dashboard", "agent.abc123.inbox")
+	require.NoError(t, err)
+	require.Len(t, unread, 1)
+	require.NoError(t, c.Acknowledge(ctx, "dashboard", unread[0].ID))
+	unread, err = c.Unread(ctx, "dashboard", "agent.abc123.inbox")
+	require.NoError(t, err)
+	assert.Empty(t, unread)
+
+	all, err := c.Topics(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, all, "agent.abc123.inbox")
+}
+
+func TestClientReviews(t *testing.T) {
+	ctx := context.Background()
+	c := openTestClient(t)
+
+	_, err := c.Review(ctx, "/docs/plan.md")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	store := stores.NewReviewStore(c.app.DB)
+	rs, err := store.CreateSession(ctx, "/docs/plan.md", "hash")
+	require.NoError(t, err)
+	require.NoError(t, store.SaveComment(ctx, review.Comment{
+		ID:          "c1",
+		SessionID:   rs.ID,
+		StartLine:   3,
+		EndLine:     4,
+		Severity:    review.SeverityIssue,
+		ContextText: "retry forever",
+		CommentText: "cap the retries",
+		CreatedAt:   time.Now(),
+	}))
+	require.NoError(t, store.FinalizeSession(ctx, rs.ID))
+
+	got, err := c.Review(ctx, "/docs/plan.md")
+	require.NoError(t, err)
+	assert.Equal(t, rs.ID, got.ID)
+	assert.NotNil(t, got.FinalizedAt)
+
+	comments, err := c.ReviewComments(ctx, rs.ID)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "issue", comments[0].Severity)
+	assert.Equal(t, "cap the retries", comments[0].Text)
+	assert.Equal(t, rs.ID, comments[0].ReviewID)
+
+	results, err := c.SearchReviewComments(ctx, "retr", 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "c1", results[0].ID)
+	assert.False(t, results[0].FinalizedAt.IsZero())
+}
+
+func TestClientCreateSessionConcurrent(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+
+	remote := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", remote},
+		{"-C", remote, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		require.NoError(t, exec.Command("git", args...).Run())
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("rules:\n  - commands: [\"echo setup {{ .Name }}\"]\n"), 0o644))
+
+	var output bytes.Buffer
+	c, err := Open(ctx, Options{
+		ConfigPath: configPath,
+		DataDir:    filepath.Join(t.TempDir(), "hive"),
+		Output:     &output,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, c.Close()) })
+
+	var wg sync.WaitGroup
+	for _, name := range []string{"one", "two", "three"} {
+		wg.Go(func() {
+			_, err := c.CreateSession(ctx, CreateOptions{Name: name, Remote: remote, SkipSpawn: true})
+			assert.NoError(t, err)
+		})
+	}
+	wg.Wait()
+
+	sessions, err := c.Sessions(ctx)
+	require.NoError(t, err)
+	assert.Len(t, sessions, 3)
+	for _, name := range []string{"one", "two", "three"} {
+		assert.Contains(t, output.String(), "setup "+name)
+	}
+}
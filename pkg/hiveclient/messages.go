@@ -0,0 +1,89 @@
+package hiveclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/messaging"
+)
+
+// Message is a message published to a topic. Agents read their session's
+// Inbox topic; see hive msg for the topic conventions.
+type Message struct {
+	ID        string // set by Publish
+	Topic     string // set by Publish
+	Seq       int64  // position in the topic, set by Publish
+	Payload   string
+	Sender    string // free-form name of the sender
+	SessionID string // hive session that sent the message, if any
+	ReplyTo   string // topic the sender awaits a reply on
+	CreatedAt time.Time
+}
+
+func newMessage(m messaging.Message) Message {
+	return Message{
+		ID:        m.ID,
+		Topic:     m.Topic,
+		Seq:       m.Seq,
+		Payload:   m.Payload,
+		Sender:    m.Sender,
+		SessionID: m.SessionID,
+		ReplyTo:   m.ReplyTo,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func newMessages(msgs []messaging.Message) []Message {
+	out := make([]Message, len(msgs))
+	for i, m := range msgs {
+		out[i] = newMessage(m)
+	}
+	return out
+}
+
+// Publish publishes msg to each topic. Topics may use wildcards, such as
+// agent.*.inbox, which expand to the existing matching topics. It returns
+// the topics msg was published to.
+func (c *Client) Publish(ctx context.Context, msg Message, topics ...string) ([]string, error) {
+	result, err := c.app.Messages.Publish(ctx, messaging.Message{
+		Payload:   msg.Payload,
+		Sender:    msg.Sender,
+		SessionID: msg.SessionID,
+		ReplyTo:   msg.ReplyTo,
+	}, topics)
+	if err != nil {
+		return nil, err
+	}
+	return result.Topics, nil
+}
+
+// Messages returns the messages of the topics matching topic, oldest first.
+// A non-zero since skips older messages. It returns ErrNotFound if no topic
+// matches.
+func (c *Client) Messages(ctx context.Context, topic string, since time.Time) ([]Message, error) {
+	msgs, err := c.app.Messages.Subscribe(ctx, topic, since)
+	if err != nil {
+		return nil, notFound(err, messaging.ErrTopicNotFound)
+	}
+	return newMessages(msgs), nil
+}
+
+// Unread returns the messages of the topics matching topic that consumer has
+// not acknowledged.
+func (c *Client) Unread(ctx context.Context, consumer, topic string) ([]Message, error) {
+	msgs, err := c.app.Messages.GetUnread(ctx, consumer, topic)
+	if err != nil {
+		return nil, notFound(err, messaging.ErrTopicNotFound)
+	}
+	return newMessages(msgs), nil
+}
+
+// Acknowledge marks messages as read by consumer.
+func (c *Client) Acknowledge(ctx context.Context, consumer string, messageIDs ...string) error {
+	return c.app.Messages.Acknowledge(ctx, consumer, messageIDs)
+}
+
+// Topics returns the names of all topics.
+func (c *Client) Topics(ctx context.Context) ([]string, error) {
+	return c.app.Messages.ListTopics(ctx)
+}
@@ -0,0 +1,104 @@
+package hiveclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/review"
+)
+
+// Review is a review of a document in hive's review view. A review is
+// finalized when its feedback is sent.
+type Review struct {
+	ID           string
+	DocumentPath string
+	CreatedAt    time.Time
+	FinalizedAt  *time.Time    // nil until finalized
+	ReviewTime   time.Duration // time spent in the review view
+	DispatchedTo string        // hive session the feedback was sent to, if any
+}
+
+func newReview(s review.Session) Review {
+	return Review{
+		ID:           s.ID,
+		DocumentPath: s.DocumentPath,
+		CreatedAt:    s.CreatedAt,
+		FinalizedAt:  s.FinalizedAt,
+		ReviewTime:   s.ReviewTime,
+		DispatchedTo: s.DispatchedTo,
+	}
+}
+
+// ReviewComment is a comment on a range of lines of a reviewed document.
+type ReviewComment struct {
+	ID           string
+	ReviewID     string
+	DocumentPath string
+	StartLine    int    // 1-indexed
+	EndLine      int    // inclusive
+	Severity     string // nit, suggestion, issue or blocker
+	Context      string // the commented text
+	Text         string
+	Outdated     bool // the commented text was removed from the document
+	CreatedAt    time.Time
+}
+
+func newReviewComment(c review.Comment) ReviewComment {
+	return ReviewComment{
+		ID:           c.ID,
+		ReviewID:     c.SessionID,
+		DocumentPath: c.DocumentPath,
+		StartLine:    c.StartLine,
+		EndLine:      c.EndLine,
+		Severity:     string(c.Severity),
+		Context:      c.ContextText,
+		Text:         c.CommentText,
+		Outdated:     c.Outdated,
+		CreatedAt:    c.CreatedAt,
+	}
+}
+
+// Review returns the most recent review of the document at path, or
+// ErrNotFound.
+func (c *Client) Review(ctx context.Context, documentPath string) (Review, error) {
+	s, err := c.reviews.GetSession(ctx, documentPath)
+	if err != nil {
+		return Review{}, notFound(err, review.ErrSessionNotFound)
+	}
+	return newReview(s), nil
+}
+
+// ReviewComments returns the comments of a review, in document order.
+func (c *Client) ReviewComments(ctx context.Context, reviewID string) ([]ReviewComment, error) {
+	comments, err := c.reviews.ListComments(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ReviewComment, len(comments))
+	for i, comment := range comments {
+		out[i] = newReviewComment(comment)
+	}
+	return out, nil
+}
+
+// ReviewSearchResult is a comment from a finalized review.
+type ReviewSearchResult struct {
+	ReviewComment
+	FinalizedAt time.Time
+}
+
+// SearchReviewComments returns up to limit comments from finalized reviews
+// whose text or commented text contains every word of query, the last one
+// as a prefix, newest review first. This is the search of hive review
+// search.
+func (c *Client) SearchReviewComments(ctx context.Context, query string, limit int) ([]ReviewSearchResult, error) {
+	results, err := c.reviews.SearchComments(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ReviewSearchResult, len(results))
+	for i, r := range results {
+		out[i] = ReviewSearchResult{ReviewComment: newReviewComment(r.Comment), FinalizedAt: r.FinalizedAt}
+	}
+	return out, nil
+}
@@ -0,0 +1,134 @@
+package hiveclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/colonyops/hive/internal/core/session"
+	"github.com/colonyops/hive/internal/hive"
+)
+
+// SessionState is the lifecycle state of a session.
+type SessionState string
+
+const (
+	SessionActive    SessionState = "active"    // in use by an agent
+	SessionRecycled  SessionState = "recycled"  // reset and waiting to be reused
+	SessionCorrupted SessionState = "corrupted" // its clone is broken
+	SessionArchived  SessionState = "archived"  // kept out of the way until restored
+)
+
+// Session is an isolated clone of a repository where an agent works.
+type Session struct {
+	ID       string
+	Name     string
+	Slug     string // name used for the tmux session and branch
+	Path     string // directory of the clone
+	Remote   string // git remote the clone was made from
+	State    SessionState
+	Branch   string // branch checked out when the session was created
+	Agent    string // agent profile the session was spawned with
+	Tags     []string
+	Notes    string
+	Metadata map[string]string // integration data, e.g. the tmux session name
+	// Inbox is the topic other agents publish to when messaging the
+	// session's agent.
+	Inbox     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func newSession(s session.Session) Session {
+	return Session{
+		ID:        s.ID,
+		Name:      s.Name,
+		Slug:      s.Slug,
+		Path:      s.Path,
+		Remote:    s.Remote,
+		State:     SessionState(s.State),
+		Branch:    s.Branch(),
+		Agent:     s.GetMeta(session.MetaAgentProfile),
+		Tags:      slices.Clone(s.Tags),
+		Notes:     s.Notes,
+		Metadata:  maps.Clone(s.Metadata),
+		Inbox:     s.InboxTopic(),
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+// Sessions returns every session that is not archived.
+func (c *Client) Sessions(ctx context.Context) ([]Session, error) {
+	sessions, err := c.app.Sessions.ListSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Session, len(sessions))
+	for i, s := range sessions {
+		out[i] = newSession(s)
+	}
+	return out, nil
+}
+
+// Session returns the session with the given ID, or ErrNotFound.
+func (c *Client) Session(ctx context.Context, id string) (Session, error) {
+	s, err := c.app.Sessions.GetSession(ctx, id)
+	if err != nil {
+		return Session{}, notFound(err, session.ErrNotFound)
+	}
+	return newSession(s), nil
+}
+
+// CreateOptions describes a session to create.
+type CreateOptions struct {
+	Name   string // required
+	Remote string // git remote to clone (required)
+	// Prompt is passed to the agent when the session's windows are spawned.
+	Prompt string
+	// Agent selects an agent profile from the config. Empty uses the one
+	// the config's rules resolve; a name the config does not define fails.
+	Agent string
+	Tags  []string
+	// Background spawns the session's tmux session without attaching to it.
+	// Programs without a terminal should set it.
+	Background bool
+	// SkipSpawn creates the clone without spawning a tmux session.
+	SkipSpawn bool
+}
+
+// CreateSession creates a session as hive new does: it clones the remote,
+// reusing a recycled clone when one is available, runs the matching rules'
+// setup commands, and spawns the session's windows.
+func (c *Client) CreateSession(ctx context.Context, opts CreateOptions) (Session, error) {
+	if opts.Name == "" || opts.Remote == "" {
+		return Session{}, errors.New("create session: name and remote are required")
+	}
+	if opts.Agent != "" {
+		if _, ok := c.app.Config.Agents.Profiles[opts.Agent]; !ok {
+			return Session{}, fmt.Errorf("create session: unknown agent %q", opts.Agent)
+		}
+	}
+	s, err := c.app.Sessions.CreateSession(ctx, hive.CreateOptions{
+		Name:       opts.Name,
+		Remote:     opts.Remote,
+		Prompt:     opts.Prompt,
+		AgentKey:   opts.Agent,
+		Tags:       opts.Tags,
+		Background: opts.Background,
+		SkipSpawn:  opts.SkipSpawn,
+	})
+	if err != nil {
+		return Session{}, err
+	}
+	return newSession(*s), nil
+}
+
+// DeleteSession deletes a session with its clone, tmux sessions and inbox.
+// It returns ErrNotFound if the session does not exist.
+func (c *Client) DeleteSession(ctx context.Context, id string) error {
+	return notFound(c.app.Sessions.DeleteSession(ctx, id), session.ErrNotFound)
+}
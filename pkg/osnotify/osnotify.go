@@ -0,0 +1,46 @@
+// Package osnotify sends native desktop notifications: Notification Center
+// via osascript on macOS and notify-send on Linux and the BSDs.
+package osnotify
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/colonyops/hive/pkg/executil"
+)
+
+// Send shows a desktop notification with the given title and message.
+func Send(ctx context.Context, executor executil.Executor, title, message string) error {
+	name, args := notifyCmd(runtime.GOOS, title, message)
+	if name == "" {
+		return fmt.Errorf("osnotify: unsupported platform %q", runtime.GOOS)
+	}
+	if out, err := executor.Run(ctx, name, args...); err != nil {
+		return fmt.Errorf("osnotify: %s: %w: %s", name, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// notifyCmd returns the command and args that show a notification for the
+// given GOOS, or an empty name for unsupported platforms. Pure so the
+// platform matrix is unit-testable without spawning processes.
+func notifyCmd(goos, title, message string) (string, []string) {
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		return "osascript", []string{"-e", script}
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return "notify-send", []string{"--app-name=hive", title, message}
+	default:
+		return "", nil
+	}
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,27 @@
+package osnotify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifyCmd(t *testing.T) {
+	cases := []struct {
+		goos     string
+		wantName string
+		wantArgs []string
+	}{
+		{"darwin", "osascript", []string{"-e", `display notification "needs \"approval\" in C:\\x" with title "hive"`}},
+		{"linux", "notify-send", []string{"--app-name=hive", "hive", `needs "approval" in C:\x`}},
+		{"openbsd", "notify-send", []string{"--app-name=hive", "hive", `needs "approval" in C:\x`}},
+		{"plan9", "", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.goos, func(t *testing.T) {
+			name, args := notifyCmd(c.goos, "hive", `needs "approval" in C:\x`)
+			require.Equal(t, c.wantName, name)
+			require.Equal(t, c.wantArgs, args)
+		})
+	}
+}
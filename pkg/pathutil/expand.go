@@ -6,7 +6,8 @@ import (
 )
 
 // ExpandHome expands a leading ~ to the user's home directory.
-// Only expands "~" or "~/..." — not "~username/..." forms.
+// Only expands "~" or "~/..." (also "~\..." on Windows) — not
+// "~username/..." forms.
 func ExpandHome(path string) string {
 	if path == "~" {
 		if home, err := os.UserHomeDir(); err == nil {
@@ -14,7 +15,7 @@ func ExpandHome(path string) string {
 		}
 		return path
 	}
-	if len(path) > 1 && path[0] == '~' && path[1] == '/' {
+	if len(path) > 1 && path[0] == '~' && (path[1] == '/' || os.IsPathSeparator(path[1])) {
 		if home, err := os.UserHomeDir(); err == nil {
 			return filepath.Join(home, path[2:])
 		}
@@ -4,13 +4,27 @@ package tmpl
 import (
 	"bytes"
 	"fmt"
+	"runtime"
 	"strings"
 	"text/template"
 )
 
-// shellQuote returns a shell-safe quoted string. It wraps the string in single
-// quotes and escapes any existing single quotes using the '\" technique.
+// shellQuote returns s quoted for the platform shell that runs rendered
+// commands: POSIX sh, or PowerShell on Windows.
 func shellQuote(s string) string {
+	return quoteFor(runtime.GOOS, s)
+}
+
+func quoteFor(goos, s string) string {
+	if goos == "windows" {
+		return powershellQuote(s)
+	}
+	return posixQuote(s)
+}
+
+// posixQuote wraps s in single quotes, closing and reopening the quotes
+// around an escaped quote for any single quote inside it.
+func posixQuote(s string) string {
 	if s == "" {
 		return "''"
 	}
@@ -19,6 +33,25 @@ func shellQuote(s string) string {
 	return "'" + escaped + "'"
 }
 
+// powershellQuoteChars are the characters PowerShell accepts as single
+// quotes, including the typographic variants.
+const powershellQuoteChars = "'\u2018\u2019\u201a\u201b"
+
+// powershellQuote wraps s in a verbatim single-quoted PowerShell string,
+// doubling any quote characters inside it.
+func powershellQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('\'')
+	for _, r := range s {
+		if strings.ContainsRune(powershellQuoteChars, r) {
+			b.WriteRune(r)
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
 func stringOrDefault(s, def string) string {
 	if s != "" {
 		return s
@@ -32,6 +65,10 @@ type Config struct {
 	AgentCommand string            // default profile command (e.g., "claude")
 	AgentWindow  string            // default profile key / tmux window name
 	AgentFlags   string            // shell-quoted flags string
+
+	// KVGet returns the value of a key for kvGet; nil renders every key as
+	// empty.
+	KVGet func(key string) (string, error)
 }
 
 func (c Config) scriptPath(name string) string {
@@ -62,6 +99,12 @@ func New(cfg Config) *Renderer {
 			"agentCommand": func() string { return stringOrDefault(cfg.AgentCommand, "claude") },
 			"agentWindow":  func() string { return stringOrDefault(cfg.AgentWindow, "claude") },
 			"agentFlags":   func() string { return cfg.AgentFlags },
+			"kvGet": func(key string) (string, error) {
+				if cfg.KVGet == nil {
+					return "", nil
+				}
+				return cfg.KVGet(key)
+			},
 		},
 	}
 }
@@ -86,6 +129,14 @@ func (r *Renderer) WithAgent(command, window, flags string) *Renderer {
 	return New(cfg)
 }
 
+// WithKV returns a new Renderer whose kvGet function looks keys up with get.
+// All other config is inherited from the receiver.
+func (r *Renderer) WithKV(get func(key string) (string, error)) *Renderer {
+	cfg := r.cfg
+	cfg.KVGet = get
+	return New(cfg)
+}
+
 // Render executes a Go template string with the given data.
 func (r *Renderer) Render(tmpl string, data any) (string, error) {
 	t, err := template.New("").Funcs(r.funcs).Option("missingkey=error").Parse(tmpl)
@@ -1,6 +1,7 @@
 package tmpl
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -226,3 +227,42 @@ func TestRenderers_Isolated(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "aider", got2)
 }
+
+func TestRenderer_WithKV(t *testing.T) {
+	r := New(Config{AgentCommand: "aider"})
+
+	got, err := r.Render(`{{ kvGet "sha" }}`, nil)
+	require.NoError(t, err)
+	assert.Empty(t, got, "kvGet is empty without a lookup")
+
+	values := map[string]string{"sha": "abc123"}
+	kvr := r.WithKV(func(key string) (string, error) { return values[key], nil })
+	got, err = kvr.Render(`{{ agentCommand }} {{ kvGet "sha" }}`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "aider abc123", got)
+
+	_, err = r.WithKV(func(string) (string, error) { return "", errors.New("boom") }).Render(`{{ kvGet "sha" }}`, nil)
+	require.ErrorContains(t, err, "boom")
+}
+
+func TestQuoteFor(t *testing.T) {
+	tests := []struct {
+		goos string
+		in   string
+		want string
+	}{
+		{"linux", "it's", `'it'\''s'`},
+		{"darwin", "", "''"},
+		{"windows", "hello world", "'hello world'"},
+		{"windows", "it's", "'it''s'"},
+		{"windows", "", "''"},
+		{"windows", "$(whoami); rm -r C:\\", "'$(whoami); rm -r C:\\'"},
+		{"windows", "smart \u2019quote", "'smart \u2019\u2019quote'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos+"/"+tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.want, quoteFor(tt.goos, tt.in))
+		})
+	}
+}